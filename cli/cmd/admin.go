@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Platform administration commands",
+	Long:  "Commands for administering the BMC platform (requires an admin account)",
+}
+
+var adminUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Customer usage commands",
+	Long:  "Commands for the console minutes, power operations and console data used by customers, for billing",
+}
+
+var adminUsageExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the usage of customers over a month",
+	Long: `Export the usage of each customer over a calendar month in UTC, as CSV or
+JSON for billing: console sessions, console minutes, successful power
+operations, and the console data sent by and to the customer's clients.
+
+Usage is attributed to the customer owning the server when it was used. The
+document is written to standard output, or to --file.`,
+	Example: `  bmc-cli admin usage export --month 2024-06
+  bmc-cli admin usage export --month 2024-06 --format json --file usage-2024-06.json`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		month, _ := cmd.Flags().GetString("month")
+		format, _ := cmd.Flags().GetString("format")
+		customerID, _ := cmd.Flags().GetString("customer")
+		file, _ := cmd.Flags().GetString("file")
+
+		export, err := client.ExportUsage(ctx, month, format, customerID)
+		if err != nil {
+			return err
+		}
+
+		if file == "" {
+			_, err := os.Stdout.Write(export.Data)
+			return err
+		}
+		if err := os.WriteFile(file, export.Data, 0o644); err != nil {
+			return fmt.Errorf("failed to write usage export: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported the usage of %d customer(s) for %s to %s\n", len(export.Customers), export.Month, file)
+		return nil
+	},
+}
+
+func init() {
+	adminUsageExportCmd.Flags().String("month", "", "Month to export, e.g. 2024-06")
+	adminUsageExportCmd.Flags().String("format", "csv", "Export format (csv|json)")
+	adminUsageExportCmd.Flags().String("customer", "", "Only export the usage of this customer ID")
+	adminUsageExportCmd.Flags().String("file", "", "Write the export to this file rather than standard output")
+	adminUsageExportCmd.MarkFlagRequired("month")
+	adminUsageExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"csv", "json"}, cobra.ShellCompDirectiveNoFileComp))
+
+	adminUsageCmd.AddCommand(adminUsageExportCmd)
+	adminCmd.AddCommand(adminUsageCmd)
+	rootCmd.AddCommand(adminCmd)
+}
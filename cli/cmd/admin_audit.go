@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the admin audit log",
+	Long: `Show the changes made by admins through the AdminService, newest first:
+gateway approvals, customer accounts and API keys, server assignments,
+maintenance and session terminations.
+
+--since takes a duration, e.g. 24h, or an RFC 3339 time.`,
+	Example: `  bmc-cli admin audit --since 24h
+  bmc-cli admin audit --action server.assign --target bmc-dc1-node01`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		actor, _ := cmd.Flags().GetString("actor")
+		action, _ := cmd.Flags().GetString("action")
+		target, _ := cmd.Flags().GetString("target")
+		since, _ := cmd.Flags().GetString("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		filter, err := auditEventsFilter(actor, action, target, since, limit)
+		if err != nil {
+			return err
+		}
+
+		events, err := client.ListAuditEvents(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			data := make([]map[string]interface{}, 0, len(events))
+			for _, event := range events {
+				data = append(data, map[string]interface{}{
+					"id":          event.Id,
+					"occurred_at": event.OccurredAt.AsTime(),
+					"actor":       event.Actor,
+					"action":      event.Action,
+					"target_type": event.TargetType,
+					"target_id":   event.TargetId,
+					"details":     event.Details,
+				})
+			}
+			return formatter.Output(map[string]interface{}{"events": data})
+		}
+
+		if formatter.IsTable() {
+			return formatter.OutputTable(auditEventsTable(events))
+		}
+
+		if len(events) == 0 {
+			fmt.Println("No audit events found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tACTOR\tACTION\tTARGET\tDETAILS")
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				event.OccurredAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+				event.Actor,
+				event.Action,
+				event.TargetType+"/"+event.TargetId,
+				auditDetails(event.Details))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+// auditEventsFilter builds the ListAuditEvents request of the audit flags
+func auditEventsFilter(actor, action, target, since string, limit int) (*managerv1.ListAuditEventsRequest, error) {
+	filter := &managerv1.ListAuditEventsRequest{
+		Actor:    actor,
+		Action:   action,
+		TargetId: target,
+		Limit:    int32(limit),
+	}
+	if since == "" {
+		return filter, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil {
+		filter.Since = timestamppb.New(time.Now().Add(-d))
+	} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+		filter.Since = timestamppb.New(t)
+	} else {
+		return nil, fmt.Errorf("invalid --since %q: expected a duration such as 24h or an RFC 3339 time", since)
+	}
+	return filter, nil
+}
+
+// auditDetails formats the details of an audit event as sorted key=value
+// pairs
+func auditDetails(details map[string]string) string {
+	pairs := make([]string, 0, len(details))
+	for key, value := range details {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// auditEventsTable lists one audit event per row
+func auditEventsTable(events []*managerv1.AuditEvent) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "time", Header: "TIME"},
+		output.Column{Key: "actor", Header: "ACTOR"},
+		output.Column{Key: "action", Header: "ACTION"},
+		output.Column{Key: "target", Header: "TARGET"},
+		output.Column{Key: "details", Header: "DETAILS"},
+		output.Column{Key: "id", Header: "ID", Wide: true},
+	)
+
+	for _, event := range events {
+		table.AddRow(
+			event.OccurredAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+			event.Actor,
+			event.Action,
+			event.TargetType+"/"+event.TargetId,
+			auditDetails(event.Details),
+			fmt.Sprintf("%d", event.Id),
+		)
+	}
+	return table
+}
+
+func init() {
+	output.AddFormatFlag(adminAuditCmd)
+	adminAuditCmd.Flags().String("actor", "", "Only show changes made by this admin email")
+	adminAuditCmd.Flags().String("action", "", "Only show this action, e.g. customer.create")
+	adminAuditCmd.Flags().String("target", "", "Only show changes to this server, customer or gateway ID")
+	adminAuditCmd.Flags().String("since", "", "Only show changes since this duration ago or time")
+	adminAuditCmd.Flags().Int("limit", 100, "Maximum number of events (at most 1000)")
+
+	adminCmd.AddCommand(adminAuditCmd)
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminConnectivityCmd = &cobra.Command{
+	Use:   "connectivity [server-id]",
+	Short: "Show the connectivity audits of the servers' BMCs",
+	Long: `Show the last connectivity audit of each server: the manager periodically
+asks the agents, through the gateways, to verify the credentials and the
+console endpoints of every registered BMC.
+
+A server's LAST VERIFIED time is the last audit that passed; it is kept when
+later audits fail. FAILED HOP is where the last audit failed:
+
+  gateway      The manager could not run the check through the gateway
+  agent        The gateway could not reach the agent
+  bmc_control  The agent could not read the power state with the credentials
+  sol          The agent could not connect to the serial console endpoint
+  vnc          The agent could not connect to the VNC endpoint
+
+With a server ID, the server is audited now.`,
+	Example: `  bmc-cli admin connectivity
+  bmc-cli admin connectivity --failures
+  bmc-cli admin connectivity --customer customer-1 --output json
+  bmc-cli admin connectivity bmc-dc1-node01`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			audit, err := client.RunConnectivityAudit(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if err := outputConnectivityAudits(formatter, []*managerv1.ConnectivityAudit{audit}, nil); err != nil {
+				return err
+			}
+			if !audit.Healthy {
+				return fmt.Errorf("connectivity audit of %s failed at the %s hop", audit.ServerId, audit.FailedHop)
+			}
+			return nil
+		}
+
+		failuresOnly, _ := cmd.Flags().GetBool("failures")
+		customer, _ := cmd.Flags().GetString("customer")
+
+		resp, err := client.ListConnectivityAudits(ctx, &managerv1.ListConnectivityAuditsRequest{
+			FailuresOnly:   failuresOnly,
+			CustomerFilter: customer,
+		})
+		if err != nil {
+			return err
+		}
+
+		return outputConnectivityAudits(formatter, resp.Audits, resp.LastRunAt)
+	},
+}
+
+// outputConnectivityAudits prints connectivity audits in the format of the
+// command. lastRunAt is the time of the last periodic run, nil if unknown.
+func outputConnectivityAudits(formatter *output.Formatter, audits []*managerv1.ConnectivityAudit, lastRunAt *timestamppb.Timestamp) error {
+	if formatter.IsStructured() {
+		data := make([]map[string]interface{}, 0, len(audits))
+		for _, audit := range audits {
+			hops := make([]map[string]interface{}, 0, len(audit.Hops))
+			for _, hop := range audit.Hops {
+				hops = append(hops, map[string]interface{}{
+					"name":       hop.Name,
+					"status":     hop.Status,
+					"target":     hop.Target,
+					"latency_ms": hop.LatencyMs,
+					"error":      hop.Error,
+				})
+			}
+			data = append(data, map[string]interface{}{
+				"server_id":     audit.ServerId,
+				"customer_id":   audit.CustomerId,
+				"datacenter_id": audit.DatacenterId,
+				"checked_at":    audit.CheckedAt.AsTime(),
+				"verified_at":   optionalTime(audit.VerifiedAt),
+				"healthy":       audit.Healthy,
+				"failed_hop":    audit.FailedHop,
+				"error":         audit.Error,
+				"hops":          hops,
+			})
+		}
+		result := map[string]interface{}{"audits": data}
+		if lastRunAt != nil {
+			result["last_run_at"] = lastRunAt.AsTime()
+		}
+		return formatter.Output(result)
+	}
+
+	if formatter.IsTable() {
+		return formatter.OutputTable(connectivityAuditsTable(audits))
+	}
+
+	if len(audits) == 0 {
+		fmt.Println("No connectivity audits found")
+		return nil
+	}
+
+	if lastRunAt != nil {
+		fmt.Printf("Last run: %s\n\n", formatAuditTime(lastRunAt))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SERVER\tCUSTOMER\tSTATUS\tLAST VERIFIED\tFAILED HOP\tERROR")
+	for _, audit := range audits {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			audit.ServerId,
+			valueOrDash(audit.CustomerId),
+			connectivityAuditStatus(audit),
+			formatAuditTime(audit.VerifiedAt),
+			valueOrDash(audit.FailedHop),
+			valueOrDash(audit.Error))
+	}
+	return w.Flush()
+}
+
+// connectivityAuditsTable lists one connectivity audit per row
+func connectivityAuditsTable(audits []*managerv1.ConnectivityAudit) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "server", Header: "SERVER"},
+		output.Column{Key: "customer", Header: "CUSTOMER"},
+		output.Column{Key: "status", Header: "STATUS"},
+		output.Column{Key: "verified", Header: "LAST VERIFIED"},
+		output.Column{Key: "failed_hop", Header: "FAILED HOP"},
+		output.Column{Key: "error", Header: "ERROR"},
+		output.Column{Key: "datacenter", Header: "DATACENTER", Wide: true},
+		output.Column{Key: "checked", Header: "CHECKED", Wide: true},
+	)
+
+	for _, audit := range audits {
+		table.AddRow(
+			audit.ServerId,
+			valueOrDash(audit.CustomerId),
+			connectivityAuditStatus(audit),
+			formatAuditTime(audit.VerifiedAt),
+			valueOrDash(audit.FailedHop),
+			valueOrDash(audit.Error),
+			valueOrDash(audit.DatacenterId),
+			formatAuditTime(audit.CheckedAt),
+		)
+	}
+	return table
+}
+
+// connectivityAuditStatus returns OK or FAILED for the last audit of a server
+func connectivityAuditStatus(audit *managerv1.ConnectivityAudit) string {
+	if audit.Healthy {
+		return "OK"
+	}
+	return "FAILED"
+}
+
+// formatAuditTime formats the time of an audit, "never" when unset
+func formatAuditTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "never"
+	}
+	return t.AsTime().Local().Format("2006-01-02 15:04:05")
+}
+
+// optionalTime returns the time of a timestamp, nil when unset
+func optionalTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func init() {
+	output.AddFormatFlag(adminConnectivityCmd)
+	adminConnectivityCmd.Flags().Bool("failures", false, "Only show the servers whose last audit failed")
+	adminConnectivityCmd.Flags().String("customer", "", "Only show the servers of this customer ID")
+
+	adminCmd.AddCommand(adminConnectivityCmd)
+}
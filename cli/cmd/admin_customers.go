@@ -0,0 +1,568 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminCustomersCmd = &cobra.Command{
+	Use:   "customers",
+	Short: "Customer administration commands",
+	Long:  "Commands for creating, disabling and deleting customer accounts and issuing their API keys",
+}
+
+var adminCustomersListCmd = &cobra.Command{
+	Use:               "list",
+	Short:             "List customers",
+	Long:              "List the customer accounts of the BMC Manager, with the number of servers they own.",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		customers, err := client.ListCustomers(ctx)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			data := make([]map[string]interface{}, 0, len(customers))
+			for _, customer := range customers {
+				data = append(data, customerData(customer))
+			}
+			return formatter.Output(map[string]interface{}{"customers": data})
+		}
+
+		if formatter.IsTable() {
+			return formatter.OutputTable(customersTable(customers))
+		}
+
+		if len(customers) == 0 {
+			fmt.Println("No customers found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CUSTOMER ID\tEMAIL\tADMIN\tSTATUS\tSERVERS\tONLINE\tCREATED")
+		for _, customer := range customers {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%d\t%d\t%s\n",
+				customer.CustomerId,
+				customer.Email,
+				customer.IsAdmin,
+				customerStatus(customer),
+				customer.ServerCount,
+				customer.OnlineServerCount,
+				customer.CreatedAt.AsTime().Local().Format("2006-01-02"))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var adminCustomersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a customer account",
+	Long: `Create a customer account and issue its API key. The key is only shown
+once: store it before closing the terminal.
+
+Customers are identified by their email unless given an --id.`,
+	Example: `  bmc-cli admin customers create --email ops@example.com
+  bmc-cli admin customers create --email root@example.com --id platform-admins --admin`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		email, _ := cmd.Flags().GetString("email")
+		customerID, _ := cmd.Flags().GetString("id")
+		isAdmin, _ := cmd.Flags().GetBool("admin")
+
+		created, err := client.CreateCustomer(ctx, email, customerID, isAdmin)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			data := customerData(created.Customer)
+			data["api_key"] = created.ApiKey
+			return formatter.Output(data)
+		}
+
+		fmt.Printf("Created customer %s (%s)\n", created.Customer.CustomerId, created.Customer.Email)
+		fmt.Printf("API key: %s\n", created.ApiKey)
+		return nil
+	},
+}
+
+var adminCustomersAPIKeyCmd = &cobra.Command{
+	Use:   "api-key <customer-id>",
+	Short: "Issue a new API key to a customer",
+	Long: `Issue a new API key to a customer. The customer's previous key stops
+authenticating at once. The key is only shown once.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		apiKey, err := client.IssueAPIKey(ctx, customerID)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"customer_id": customerID,
+				"api_key":     apiKey,
+			})
+		}
+
+		fmt.Printf("Issued a new API key to customer %s\n", customerID)
+		fmt.Printf("API key: %s\n", apiKey)
+		return nil
+	},
+}
+
+var adminCustomersResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password <customer-id>",
+	Short: "Issue a password reset token to a customer",
+	Long: `Issue a one-time token letting a customer set a new password with
+'bmc-cli auth reset-password', and unlock its account. The token is only
+shown once, and the customer's password keeps working until it is reset.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		issued, err := client.ResetCustomerPassword(ctx, customerID)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"customer_id": issued.CustomerId,
+				"reset_token": issued.ResetToken,
+				"expires_at":  issued.ExpiresAt.AsTime(),
+			})
+		}
+
+		fmt.Printf("Issued a password reset token to customer %s, valid until %s\n",
+			issued.CustomerId, issued.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("Reset token: %s\n", issued.ResetToken)
+		return nil
+	},
+}
+
+var adminCustomersMFAPolicyCmd = &cobra.Command{
+	Use:   "mfa-policy <customer-id>",
+	Short: "Require two-factor authentication for the consoles of a customer",
+	Long: `Set whether the console sessions of a customer require two-factor
+authentication. Gateways then refuse consoles to tokens of logins without a
+code, so the customer enrolls with 'bmc-cli auth 2fa enroll' and logs in again.
+
+--reset-totp removes the customer's authenticator, e.g. when lost, so that it
+logs in with its password and enrolls again.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		requireMFA, _ := cmd.Flags().GetBool("require")
+		resetTOTP, _ := cmd.Flags().GetBool("reset-totp")
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		policy, err := client.SetCustomerMFAPolicy(ctx, customerID, requireMFA, resetTOTP)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"customer_id":  policy.CustomerId,
+				"require_mfa":  policy.RequireMfa,
+				"totp_enabled": policy.TotpEnabled,
+			})
+		}
+
+		if policy.RequireMfa {
+			fmt.Printf("Console sessions of customer %s require two-factor authentication\n", policy.CustomerId)
+		} else {
+			fmt.Printf("Console sessions of customer %s don't require two-factor authentication\n", policy.CustomerId)
+		}
+		if resetTOTP {
+			fmt.Println("Two-factor authentication reset, the customer can enroll again")
+		} else if policy.RequireMfa && !policy.TotpEnabled {
+			fmt.Println("The customer has not enrolled yet: consoles stay refused until it does and logs in again")
+		}
+		return nil
+	},
+}
+
+var adminCustomersIPAllowlistCmd = &cobra.Command{
+	Use:   "ip-allowlist <customer-id>",
+	Short: "Restrict the source addresses of a customer's BMC access",
+	Long: `Set the source IP ranges, e.g. of a VPN, gateways accept for the BMCs of
+a customer. Gateways refuse server tokens and consoles of the customer from
+other addresses. The allowlist replaces the previous one, and applies to
+server tokens issued afterwards.
+
+Examples:
+  bmc-cli admin customers ip-allowlist acme --cidr 10.8.0.0/16 --cidr 203.0.113.7
+  bmc-cli admin customers ip-allowlist acme --clear`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		cidrs, _ := cmd.Flags().GetStringSlice("cidr")
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		allowlist, err := client.SetCustomerIPAllowlist(ctx, customerID, cidrs)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"customer_id": allowlist.CustomerId,
+				"cidrs":       allowlist.Cidrs,
+			})
+		}
+
+		if len(allowlist.Cidrs) == 0 {
+			fmt.Printf("BMCs of customer %s are accessible from any address\n", allowlist.CustomerId)
+			return nil
+		}
+		fmt.Printf("BMCs of customer %s are accessible from:\n", allowlist.CustomerId)
+		for _, cidr := range allowlist.Cidrs {
+			fmt.Printf("  %s\n", cidr)
+		}
+		return nil
+	},
+}
+
+var adminCustomersDisableCmd = &cobra.Command{
+	Use:   "disable <customer-id>",
+	Short: "Revoke the access of a customer",
+	Long: `Disable a customer account. Its API key and tokens stop authenticating, and
+its console sessions are terminated on every gateway, showing the optional
+--reason to their clients. The customer keeps its servers.
+
+Sessions on gateways that cannot be reached are rejected when a client next
+attaches to them.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		reason, _ := cmd.Flags().GetString("reason")
+
+		disabled, err := client.DisableCustomer(ctx, customerID, reason, false)
+		if err != nil {
+			return err
+		}
+		warnUnreachableGateways(disabled.UnreachableGateways)
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			data := customerData(disabled.Customer)
+			data["terminated_sessions"] = disabled.TerminatedSessions
+			return formatter.Output(data)
+		}
+
+		fmt.Printf("Disabled customer %s (%d console session(s) terminated)\n", customerID, disabled.TerminatedSessions)
+		return nil
+	},
+}
+
+var adminCustomersEnableCmd = &cobra.Command{
+	Use:   "enable <customer-id>",
+	Short: "Restore the access of a disabled customer",
+	Long: `Enable a disabled customer account. Its API key authenticates again, while
+the tokens issued before it was disabled stay revoked: the customer has to
+log in again.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		enabled, err := client.DisableCustomer(ctx, customerID, "", true)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(customerData(enabled.Customer))
+		}
+
+		fmt.Printf("Enabled customer %s\n", customerID)
+		return nil
+	},
+}
+
+var adminCustomersDeleteCmd = &cobra.Command{
+	Use:   "delete <customer-id>",
+	Short: "Delete a customer account",
+	Long: `Delete a customer account, revoking its API key and tokens and terminating
+its console sessions on every gateway.
+
+Customers owning servers can only be deleted with --reassign-to, naming the
+customer to assign their servers to. The audit log and usage records of the
+customer are kept.`,
+	Example:           `  bmc-cli admin customers delete ops@example.com --reassign-to platform-admins`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		reassignTo, _ := cmd.Flags().GetString("reassign-to")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		deleted, err := client.DeleteCustomer(ctx, customerID, reassignTo, reason)
+		if err != nil {
+			return err
+		}
+		warnUnreachableGateways(deleted.UnreachableGateways)
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"customer_id":         customerID,
+				"reassigned_servers":  deleted.ReassignedServers,
+				"reassign_to":         reassignTo,
+				"terminated_sessions": deleted.TerminatedSessions,
+			})
+		}
+
+		fmt.Printf("Deleted customer %s (%d server(s) reassigned, %d console session(s) terminated)\n",
+			customerID, deleted.ReassignedServers, deleted.TerminatedSessions)
+		return nil
+	},
+}
+
+var adminServersCmd = &cobra.Command{
+	Use:   "servers",
+	Short: "Server administration commands",
+	Long:  "Commands for administering the servers of every customer",
+}
+
+var adminServersAssignCmd = &cobra.Command{
+	Use:   "assign <server-id>",
+	Short: "Assign a server to a customer",
+	Long: `Assign a server to a customer. Only the owning customer is issued tokens
+for the server, so its previous owner loses access once their tokens expire.`,
+	Example:           `  bmc-cli admin servers assign bmc-dc1-node01 --customer ops@example.com`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		serverID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		customerID, _ := cmd.Flags().GetString("customer")
+
+		assigned, err := client.AssignServer(ctx, serverID, customerID)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"server_id":            assigned.Server.Id,
+				"customer_id":          assigned.Server.CustomerId,
+				"previous_customer_id": assigned.PreviousCustomerId,
+			})
+		}
+
+		if assigned.PreviousCustomerId == "" {
+			fmt.Printf("Assigned server %s to customer %s\n", assigned.Server.Id, assigned.Server.CustomerId)
+			return nil
+		}
+		fmt.Printf("Assigned server %s to customer %s (previously %s)\n",
+			assigned.Server.Id, assigned.Server.CustomerId, assigned.PreviousCustomerId)
+		return nil
+	},
+}
+
+// warnUnreachableGateways warns of the gateways whose console sessions could
+// not be terminated
+func warnUnreachableGateways(gateways []*managerv1.UnreachableGateway) {
+	for _, gateway := range gateways {
+		fmt.Fprintf(os.Stderr, "Warning: gateway %s could not be reached: %s\n", gateway.GatewayId, gateway.Error)
+	}
+}
+
+// customersTable lists one customer per row
+func customersTable(customers []*managerv1.CustomerSummary) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "id", Header: "CUSTOMER ID"},
+		output.Column{Key: "email", Header: "EMAIL"},
+		output.Column{Key: "admin", Header: "ADMIN"},
+		output.Column{Key: "status", Header: "STATUS"},
+		output.Column{Key: "servers", Header: "SERVERS"},
+		output.Column{Key: "online", Header: "ONLINE"},
+		output.Column{Key: "created", Header: "CREATED"},
+		output.Column{Key: "max_sessions", Header: "MAX SESSIONS", Wide: true},
+		output.Column{Key: "max_rate", Header: "SESSIONS/MIN", Wide: true},
+	)
+
+	for _, customer := range customers {
+		table.AddRow(
+			customer.CustomerId,
+			customer.Email,
+			fmt.Sprintf("%t", customer.IsAdmin),
+			customerStatus(customer),
+			fmt.Sprintf("%d", customer.ServerCount),
+			fmt.Sprintf("%d", customer.OnlineServerCount),
+			customer.CreatedAt.AsTime().Local().Format("2006-01-02"),
+			fmt.Sprintf("%d", customer.MaxConcurrentSessions),
+			fmt.Sprintf("%d", customer.MaxSessionsPerMinute),
+		)
+	}
+	return table
+}
+
+func customerStatus(customer *managerv1.CustomerSummary) string {
+	if customer.Disabled {
+		return "DISABLED"
+	}
+	return "ACTIVE"
+}
+
+func customerData(customer *managerv1.CustomerSummary) map[string]interface{} {
+	return map[string]interface{}{
+		"customer_id":             customer.CustomerId,
+		"email":                   customer.Email,
+		"is_admin":                customer.IsAdmin,
+		"disabled":                customer.Disabled,
+		"totp_enabled":            customer.TotpEnabled,
+		"require_mfa":             customer.RequireMfa,
+		"ip_allowlist":            customer.IpAllowlist,
+		"server_count":            customer.ServerCount,
+		"online_server_count":     customer.OnlineServerCount,
+		"created_at":              customer.CreatedAt.AsTime(),
+		"max_concurrent_sessions": customer.MaxConcurrentSessions,
+		"max_sessions_per_minute": customer.MaxSessionsPerMinute,
+	}
+}
+
+func init() {
+	output.AddFormatFlag(adminCustomersListCmd)
+
+	output.AddFormatFlag(adminCustomersCreateCmd)
+	adminCustomersCreateCmd.Flags().String("email", "", "Email of the customer")
+	adminCustomersCreateCmd.Flags().String("id", "", "Customer ID (defaults to the email)")
+	adminCustomersCreateCmd.Flags().Bool("admin", false, "Grant the customer admin access")
+	adminCustomersCreateCmd.MarkFlagRequired("email")
+
+	output.AddFormatFlag(adminCustomersAPIKeyCmd)
+
+	output.AddFormatFlag(adminCustomersResetPasswordCmd)
+
+	output.AddFormatFlag(adminCustomersMFAPolicyCmd)
+	adminCustomersMFAPolicyCmd.Flags().Bool("require", false, "Require two-factor authentication, --require=false to lift the requirement")
+	adminCustomersMFAPolicyCmd.Flags().Bool("reset-totp", false, "Remove the customer's authenticator so it can enroll again")
+	adminCustomersMFAPolicyCmd.MarkFlagRequired("require")
+
+	output.AddFormatFlag(adminCustomersIPAllowlistCmd)
+	adminCustomersIPAllowlistCmd.Flags().StringSlice("cidr", nil, "Source range to allow, a CIDR or an IP address (repeatable)")
+	adminCustomersIPAllowlistCmd.Flags().Bool("clear", false, "Allow access from any address")
+	adminCustomersIPAllowlistCmd.MarkFlagsOneRequired("cidr", "clear")
+	adminCustomersIPAllowlistCmd.MarkFlagsMutuallyExclusive("cidr", "clear")
+
+	output.AddFormatFlag(adminCustomersDisableCmd)
+	adminCustomersDisableCmd.Flags().String("reason", "", "Reason shown to the clients of the terminated console sessions")
+
+	output.AddFormatFlag(adminCustomersEnableCmd)
+
+	output.AddFormatFlag(adminCustomersDeleteCmd)
+	adminCustomersDeleteCmd.Flags().String("reassign-to", "", "Customer to assign the deleted customer's servers to")
+	adminCustomersDeleteCmd.Flags().String("reason", "", "Reason shown to the clients of the terminated console sessions")
+
+	output.AddFormatFlag(adminServersAssignCmd)
+	adminServersAssignCmd.Flags().String("customer", "", "ID of the customer to assign the server to")
+	adminServersAssignCmd.MarkFlagRequired("customer")
+
+	adminCustomersCmd.AddCommand(adminCustomersListCmd)
+	adminCustomersCmd.AddCommand(adminCustomersCreateCmd)
+	adminCustomersCmd.AddCommand(adminCustomersAPIKeyCmd)
+	adminCustomersCmd.AddCommand(adminCustomersResetPasswordCmd)
+	adminCustomersCmd.AddCommand(adminCustomersMFAPolicyCmd)
+	adminCustomersCmd.AddCommand(adminCustomersIPAllowlistCmd)
+	adminCustomersCmd.AddCommand(adminCustomersDisableCmd)
+	adminCustomersCmd.AddCommand(adminCustomersEnableCmd)
+	adminCustomersCmd.AddCommand(adminCustomersDeleteCmd)
+	adminServersCmd.AddCommand(adminServersAssignCmd)
+	adminCmd.AddCommand(adminCustomersCmd)
+	adminCmd.AddCommand(adminServersCmd)
+}
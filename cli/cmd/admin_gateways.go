@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminGatewaysCmd = &cobra.Command{
+	Use:   "gateways",
+	Short: "Gateway administration commands",
+	Long:  "Commands for listing the regional gateways registered with the BMC Manager and approving new ones",
+}
+
+var adminGatewaysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered gateways",
+	Long: `List the regional gateways registered with the BMC Manager, with their
+health and the number of servers they route.
+
+Gateways awaiting approval are listed with the "pending" status. Use
+--pending to only list those.`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		pendingOnly, _ := cmd.Flags().GetBool("pending")
+
+		gateways, err := client.ListGatewayHealth(ctx)
+		if err != nil {
+			return err
+		}
+		if pendingOnly {
+			gateways = pendingGateways(gateways)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return outputGatewaysData(formatter, gateways)
+		}
+
+		if formatter.IsTable() {
+			return formatter.OutputTable(gatewaysTable(gateways))
+		}
+
+		if len(gateways) == 0 {
+			fmt.Println("No gateways found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "GATEWAY ID\tREGION\tENDPOINT\tSTATUS\tSERVERS\tLAST SEEN")
+		for _, gw := range gateways {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				gw.GatewayId,
+				gw.Region,
+				gw.Endpoint,
+				strings.ToUpper(gw.Status),
+				gw.ServerCount,
+				gw.LastSeen.AsTime().Local().Format("2006-01-02 15:04:05"))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var adminGatewaysApproveCmd = &cobra.Command{
+	Use:   "approve <gateway-id>",
+	Short: "Approve a pending gateway",
+	Long: `Approve a gateway awaiting approval. Once approved, the gateway's endpoint
+reports are accepted and its servers can be reached.
+
+Gateways await approval when the BMC Manager runs with
+gateway_discovery.require_approval enabled.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePendingGatewayIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gatewayID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		gateway, err := client.ApproveGateway(ctx, gatewayID)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(gatewayData(gateway))
+		}
+
+		fmt.Printf("Approved gateway %s (%s, %s)\n", gateway.GatewayId, gateway.Region, gateway.Endpoint)
+		return nil
+	},
+}
+
+var adminGatewaysStatusCmd = &cobra.Command{
+	Use:   "status <gateway-id>",
+	Short: "Show the live status of a gateway",
+	Long: `Show the Local Agents registered with a gateway, with their link probes,
+its console session counts and the BMC endpoints it routes through each agent.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeGatewayIDArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		status, err := client.GetGatewayStatus(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return outputGatewayStatusData(formatter, status)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Gateway ID:\t%s\n", status.GatewayId)
+		fmt.Fprintf(w, "Region:\t%s\n", status.Region)
+		fmt.Fprintf(w, "Manager:\t%s\n", status.ManagerEndpoint)
+		fmt.Fprintf(w, "Console Sessions:\t%d (%d VNC, %d SOL, %d streams attached)\n",
+			status.Sessions.GetTotal(), status.Sessions.GetVnc(), status.Sessions.GetSol(), status.Sessions.GetAttachedStreams())
+		fmt.Fprintln(w)
+
+		fmt.Fprintf(w, "Agents (%d):\n", len(status.Agents))
+		if len(status.Agents) == 0 {
+			fmt.Fprintln(w, "  none registered")
+		} else {
+			fmt.Fprintln(w, "  AGENT ID\tDATACENTER\tSTATUS\tENDPOINTS\tSESSIONS\tRTT")
+			for _, agent := range status.Agents {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%d\t%s\n",
+					agent.AgentId,
+					agent.DatacenterId,
+					agent.Status,
+					len(agent.BmcEndpoints),
+					agent.ActiveSessionCount,
+					formatLinkRTT(agent.Link))
+			}
+		}
+		fmt.Fprintln(w)
+
+		fmt.Fprintf(w, "BMC Endpoints (%d):\n", len(status.EndpointMappings))
+		if len(status.EndpointMappings) == 0 {
+			fmt.Fprintln(w, "  none mapped")
+		} else {
+			fmt.Fprintln(w, "  ENDPOINT\tSERVER ID\tAGENT ID\tTYPE\tSTATUS")
+			for _, mapping := range status.EndpointMappings {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+					mapping.BmcEndpoint,
+					mapping.ServerId,
+					mapping.AgentId,
+					formatAgentBMCType(mapping.BmcType),
+					valueOrUnknown(mapping.Status))
+			}
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+// formatLinkRTT formats the round-trip time of the last link probe of an
+// agent
+func formatLinkRTT(link *gatewayv1.AgentLinkStatus) string {
+	switch {
+	case link == nil:
+		return "-"
+	case link.Error != "":
+		return "failed"
+	default:
+		return fmt.Sprintf("%.1fms", link.RttMs)
+	}
+}
+
+func outputGatewayStatusData(formatter *output.Formatter, status *gatewayv1.GetGatewayStatusResponse) error {
+	agents := make([]map[string]interface{}, 0, len(status.Agents))
+	for _, agent := range status.Agents {
+		data := map[string]interface{}{
+			"agent_id":             agent.AgentId,
+			"datacenter_id":        agent.DatacenterId,
+			"endpoint":             agent.Endpoint,
+			"status":               agent.Status,
+			"version":              agent.Version,
+			"last_seen":            agent.LastSeen.AsTime(),
+			"active_session_count": agent.ActiveSessionCount,
+			"bmc_endpoint_count":   len(agent.BmcEndpoints),
+		}
+		if link := agent.Link; link != nil {
+			data["link"] = map[string]interface{}{
+				"probed_at":    link.ProbedAt.AsTime(),
+				"rtt_ms":       link.RttMs,
+				"upload_bps":   link.UploadBps,
+				"download_bps": link.DownloadBps,
+				"error":        link.Error,
+			}
+		}
+		agents = append(agents, data)
+	}
+
+	mappings := make([]map[string]interface{}, 0, len(status.EndpointMappings))
+	for _, mapping := range status.EndpointMappings {
+		mappings = append(mappings, map[string]interface{}{
+			"bmc_endpoint":  mapping.BmcEndpoint,
+			"server_id":     mapping.ServerId,
+			"agent_id":      mapping.AgentId,
+			"datacenter_id": mapping.DatacenterId,
+			"bmc_type":      formatAgentBMCType(mapping.BmcType),
+			"status":        mapping.Status,
+			"last_seen":     mapping.LastSeen.AsTime(),
+		})
+	}
+
+	return formatter.Output(map[string]interface{}{
+		"gateway_id":       status.GatewayId,
+		"region":           status.Region,
+		"manager_endpoint": status.ManagerEndpoint,
+		"sessions": map[string]interface{}{
+			"total":            status.Sessions.GetTotal(),
+			"vnc":              status.Sessions.GetVnc(),
+			"sol":              status.Sessions.GetSol(),
+			"attached_streams": status.Sessions.GetAttachedStreams(),
+		},
+		"agents":            agents,
+		"endpoint_mappings": mappings,
+	})
+}
+
+// pendingGateways returns the gateways awaiting approval
+func pendingGateways(gateways []*managerv1.GatewayHealth) []*managerv1.GatewayHealth {
+	var pending []*managerv1.GatewayHealth
+	for _, gw := range gateways {
+		if gw.Status == "pending" {
+			pending = append(pending, gw)
+		}
+	}
+	return pending
+}
+
+// gatewaysTable lists one gateway per row
+func gatewaysTable(gateways []*managerv1.GatewayHealth) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "id", Header: "GATEWAY ID"},
+		output.Column{Key: "region", Header: "REGION"},
+		output.Column{Key: "endpoint", Header: "ENDPOINT"},
+		output.Column{Key: "status", Header: "STATUS"},
+		output.Column{Key: "servers", Header: "SERVERS"},
+		output.Column{Key: "last_seen", Header: "LAST SEEN"},
+		output.Column{Key: "datacenters", Header: "DATACENTERS", Wide: true},
+	)
+
+	for _, gw := range gateways {
+		table.AddRow(
+			gw.GatewayId,
+			gw.Region,
+			gw.Endpoint,
+			strings.ToUpper(gw.Status),
+			fmt.Sprintf("%d", gw.ServerCount),
+			gw.LastSeen.AsTime().Local().Format("2006-01-02 15:04:05"),
+			strings.Join(gw.DatacenterIds, ","),
+		)
+	}
+	return table
+}
+
+func gatewayData(gw *managerv1.GatewayHealth) map[string]interface{} {
+	return map[string]interface{}{
+		"gateway_id":     gw.GatewayId,
+		"region":         gw.Region,
+		"endpoint":       gw.Endpoint,
+		"status":         gw.Status,
+		"last_seen":      gw.LastSeen.AsTime(),
+		"server_count":   gw.ServerCount,
+		"datacenter_ids": gw.DatacenterIds,
+	}
+}
+
+func outputGatewaysData(formatter *output.Formatter, gateways []*managerv1.GatewayHealth) error {
+	data := make([]map[string]interface{}, 0, len(gateways))
+	for _, gw := range gateways {
+		data = append(data, gatewayData(gw))
+	}
+	return formatter.Output(map[string]interface{}{"gateways": data})
+}
+
+func init() {
+	output.AddFormatFlag(adminGatewaysListCmd)
+	adminGatewaysListCmd.Flags().Bool("pending", false, "Only list gateways awaiting approval")
+
+	output.AddFormatFlag(adminGatewaysApproveCmd)
+
+	output.AddFormatFlag(adminGatewaysStatusCmd)
+
+	adminGatewaysCmd.AddCommand(adminGatewaysListCmd)
+	adminGatewaysCmd.AddCommand(adminGatewaysApproveCmd)
+	adminGatewaysCmd.AddCommand(adminGatewaysStatusCmd)
+	adminCmd.AddCommand(adminGatewaysCmd)
+}
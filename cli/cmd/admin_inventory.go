@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminServersImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Register servers in bulk from a CSV or YAML inventory file",
+	Long: `Register the servers of an inventory file, with their BMC endpoint, gateway
+and customer. Columns of CSV files, with a header row, or keys of the items
+of a YAML "servers" list:
+
+  server_id      ID of the server (required)
+  customer_id    Customer owning the server (required)
+  datacenter_id  Datacenter of the server (required)
+  gateway_id     Gateway serving the datacenter (required)
+  bmc_type       ipmi or redfish (required)
+  bmc_endpoint   BMC control endpoint (required)
+  username       BMC username
+  password       BMC password
+  features       e.g. power;console;vnc (a list in YAML)
+  external_id    ID of the server in your inventory
+
+Servers are matched like registrations, by their datacenter and BMC endpoint,
+else by ID, and updated; existing servers keep their credentials when the
+file leaves them empty, so that exports can be imported back. Each row is
+imported on its own and reported with its error; --dry-run validates the file
+and reports the changes without saving them.
+
+The format is taken from the file extension unless --format is given. Use -
+to read standard input.`,
+	Example: `  bmc-cli admin servers import servers.csv --dry-run
+  bmc-cli admin servers import servers.yaml
+  bmc-cli admin servers export | bmc-cli admin servers import - --format csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		format, _ := cmd.Flags().GetString("format")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		file := args[0]
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read inventory: %w", err)
+		}
+		if format == "" {
+			format = inventoryFormat(file)
+		}
+
+		imported, err := client.ImportServers(ctx, data, format, dryRun)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case formatter.IsStructured():
+			results := make([]map[string]interface{}, 0, len(imported.Results))
+			for _, result := range imported.Results {
+				results = append(results, map[string]interface{}{
+					"line":      result.Line,
+					"server_id": result.ServerId,
+					"action":    result.Action,
+					"error":     result.Error,
+				})
+			}
+			if err := formatter.Output(map[string]interface{}{
+				"dry_run": imported.DryRun,
+				"created": imported.Created,
+				"updated": imported.Updated,
+				"failed":  imported.Failed,
+				"results": results,
+			}); err != nil {
+				return err
+			}
+
+		case formatter.IsTable():
+			if err := formatter.OutputTable(serverImportTable(imported.Results)); err != nil {
+				return err
+			}
+
+		default:
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "LINE\tSERVER\tACTION\tERROR")
+			for _, result := range imported.Results {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", result.Line, valueOrDash(result.ServerId), result.Action, valueOrDash(result.Error))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			summary := "Imported"
+			if imported.DryRun {
+				summary = "Dry run, nothing saved:"
+			}
+			fmt.Printf("\n%s %d created, %d updated, %d failed\n", summary, imported.Created, imported.Updated, imported.Failed)
+		}
+
+		if imported.Failed > 0 {
+			return fmt.Errorf("%d row(s) of %s were rejected", imported.Failed, file)
+		}
+		return nil
+	},
+}
+
+var adminServersExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the servers as a CSV or YAML inventory file",
+	Long: `Export the servers of every customer, or of --customer, in the inventory
+format of "bmc-cli admin servers import": one row per server with its primary
+BMC endpoint, gateway and customer. Passwords are never exported.
+
+The document is written to standard output, or to --file.`,
+	Example: `  bmc-cli admin servers export > servers.csv
+  bmc-cli admin servers export --format yaml --customer customer-1 --file servers.yaml`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		format, _ := cmd.Flags().GetString("format")
+		customerID, _ := cmd.Flags().GetString("customer")
+		file, _ := cmd.Flags().GetString("file")
+
+		export, err := client.ExportServers(ctx, format, customerID)
+		if err != nil {
+			return err
+		}
+
+		if file == "" {
+			_, err := os.Stdout.Write(export.Data)
+			return err
+		}
+		if err := os.WriteFile(file, export.Data, 0o644); err != nil {
+			return fmt.Errorf("failed to write server export: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d server(s) to %s\n", export.Count, file)
+		return nil
+	},
+}
+
+// inventoryFormat returns the format of an inventory file by its extension
+func inventoryFormat(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "csv"
+	}
+}
+
+// serverImportTable lists the result of one imported row per row
+func serverImportTable(results []*managerv1.ServerImportResult) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "line", Header: "LINE"},
+		output.Column{Key: "server", Header: "SERVER"},
+		output.Column{Key: "action", Header: "ACTION"},
+		output.Column{Key: "error", Header: "ERROR"},
+	)
+
+	for _, result := range results {
+		table.AddRow(
+			fmt.Sprintf("%d", result.Line),
+			valueOrDash(result.ServerId),
+			result.Action,
+			valueOrDash(result.Error),
+		)
+	}
+	return table
+}
+
+func init() {
+	output.AddFormatFlag(adminServersImportCmd)
+	adminServersImportCmd.Flags().String("format", "", "Inventory format (csv|yaml), by default from the file extension")
+	adminServersImportCmd.Flags().Bool("dry-run", false, "Validate the file and report the changes without saving them")
+	adminServersImportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"csv", "yaml"}, cobra.ShellCompDirectiveNoFileComp))
+
+	adminServersExportCmd.Flags().String("format", "csv", "Export format (csv|yaml)")
+	adminServersExportCmd.Flags().String("customer", "", "Only export the servers of this customer ID")
+	adminServersExportCmd.Flags().String("file", "", "Write the export to this file rather than standard output")
+	adminServersExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"csv", "yaml"}, cobra.ShellCompDirectiveNoFileComp))
+
+	adminServersCmd.AddCommand(adminServersImportCmd)
+	adminServersCmd.AddCommand(adminServersExportCmd)
+}
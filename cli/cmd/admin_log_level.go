@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var adminLogLevelCmd = &cobra.Command{
+	Use:   "log-level [level]",
+	Short: "Show or change the log levels of the manager or a gateway",
+	Long: `Show or change the log levels of the manager, or of a gateway with --gateway,
+without restarting it, e.g. to enable debug logging during an incident.
+
+The level is one of trace, debug, info, warn or error. --module sets the level
+of a Go package, named by its import path or its last elements, e.g.
+streaming or internal/gateway; an empty level clears it. Without arguments,
+the current levels are shown.
+
+Agents serve their levels on /debug/log-level, authenticated with their debug
+token.`,
+	Example: `  bmc-cli admin log-level
+  bmc-cli admin log-level debug
+  bmc-cli admin log-level --gateway gateway-us-east-1 --module streaming=debug
+  bmc-cli admin log-level info --reset-modules`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: cobra.FixedCompletions([]string{"trace", "debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		gatewayID, _ := cmd.Flags().GetString("gateway")
+		moduleFlags, _ := cmd.Flags().GetStringArray("module")
+		resetModules, _ := cmd.Flags().GetBool("reset-modules")
+
+		var level string
+		if len(args) == 1 {
+			level = args[0]
+		}
+		modules, err := parseModuleLevels(moduleFlags)
+		if err != nil {
+			return err
+		}
+
+		var current string
+		var currentModules map[string]string
+		service := "manager"
+		if gatewayID != "" {
+			service = "gateway " + gatewayID
+			resp, err := client.SetGatewayLogLevel(ctx, gatewayID, &gatewayv1.SetLogLevelRequest{
+				Level:        level,
+				ModuleLevels: modules,
+				ResetModules: resetModules,
+			})
+			if err != nil {
+				return err
+			}
+			current, currentModules = resp.Level, resp.ModuleLevels
+		} else {
+			resp, err := client.SetManagerLogLevel(ctx, &managerv1.SetLogLevelRequest{
+				Level:        level,
+				ModuleLevels: modules,
+				ResetModules: resetModules,
+			})
+			if err != nil {
+				return err
+			}
+			current, currentModules = resp.Level, resp.ModuleLevels
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"service": service,
+				"level":   current,
+				"modules": currentModules,
+			})
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Service:\t%s\n", service)
+		fmt.Fprintf(w, "Level:\t%s\n", current)
+		if len(currentModules) > 0 {
+			names := make([]string, 0, len(currentModules))
+			for module := range currentModules {
+				names = append(names, module)
+			}
+			slices.Sort(names)
+			fmt.Fprintln(w, "Modules:")
+			for _, module := range names {
+				fmt.Fprintf(w, "  %s\t%s\n", module, currentModules[module])
+			}
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+// parseModuleLevels parses the module=level pairs of --module
+func parseModuleLevels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	modules := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		module, level, ok := strings.Cut(pair, "=")
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid --module %q: expected module=level, e.g. streaming=debug", pair)
+		}
+		modules[module] = level
+	}
+	return modules, nil
+}
+
+func init() {
+	output.AddFormatFlag(adminLogLevelCmd)
+	adminLogLevelCmd.Flags().String("gateway", "", "Change the levels of this gateway instead of the manager")
+	adminLogLevelCmd.Flags().StringArray("module", nil, "Level of a module, as module=level (repeatable)")
+	adminLogLevelCmd.Flags().Bool("reset-modules", false, "Clear the levels of all modules first")
+	adminLogLevelCmd.RegisterFlagCompletionFunc("gateway", completeGatewayIDs)
+
+	adminCmd.AddCommand(adminLogLevelCmd)
+}
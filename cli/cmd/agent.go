@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Local Agent diagnostics commands",
+	Long:  "Commands for inspecting Local Agents as seen by the regional gateways (requires an admin account)",
+}
+
+var agentStatusCmd = &cobra.Command{
+	Use:   "status <agent-id>",
+	Short: "Show the status of a Local Agent",
+	Long: `Show registration details, BMC endpoints, heartbeat recency and session
+activity for a Local Agent.
+
+All gateways registered with the BMC Manager are queried until one reports the
+agent. Use --gateway to query a single gateway by ID, or --gateway-url to query
+a gateway directly.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agentID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		// Only honour an explicit --gateway-url, the configured default is a
+		// legacy single-gateway setting
+		gatewayURL := ""
+		if cmd.Flags().Changed("gateway-url") {
+			gatewayURL = GetConfig().Gateway.URL
+		}
+
+		if gatewayID, _ := cmd.Flags().GetString("gateway"); gatewayID != "" {
+			if gatewayURL != "" {
+				return fmt.Errorf("--gateway and --gateway-url are mutually exclusive")
+			}
+			endpoint, err := gatewayEndpoint(ctx, client, gatewayID)
+			if err != nil {
+				return err
+			}
+			gatewayURL = endpoint
+		}
+
+		status, err := client.GetAgentStatus(ctx, agentID, gatewayURL)
+		if err != nil {
+			return fmt.Errorf("failed to get agent status: %w", err)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Handle JSON/YAML output formats
+		if formatter.IsStructured() {
+			return outputAgentStatusData(formatter, status)
+		}
+
+		// Table formats show a one-row summary
+		if formatter.IsTable() {
+			return formatter.OutputTable(agentStatusTable(status))
+		}
+
+		// Default text output
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintf(w, "Agent ID:\t%s\n", status.AgentId)
+		fmt.Fprintf(w, "Datacenter:\t%s\n", status.DatacenterId)
+		fmt.Fprintf(w, "Gateway:\t%s\n", status.GatewayId)
+		fmt.Fprintf(w, "Endpoint:\t%s\n", status.Endpoint)
+		fmt.Fprintf(w, "Status:\t%s\n", status.Status)
+		fmt.Fprintf(w, "Version:\t%s\n", valueOrUnknown(status.Version))
+		fmt.Fprintf(w, "Registered:\t%s\n", status.RegisteredAt.AsTime().Local().Format("2006-01-02 15:04:05"))
+		fmt.Fprintf(w, "Last Heartbeat:\t%s (%s ago)\n",
+			status.LastSeen.AsTime().Local().Format("2006-01-02 15:04:05"),
+			time.Since(status.LastSeen.AsTime()).Round(time.Second))
+		fmt.Fprintf(w, "Active Sessions:\t%d\n", status.ActiveSessionCount)
+		fmt.Fprintln(w)
+
+		fmt.Fprintf(w, "BMC Endpoints (%d):\n", len(status.BmcEndpoints))
+		if len(status.BmcEndpoints) == 0 {
+			fmt.Fprintln(w, "  none reported")
+		} else {
+			fmt.Fprintln(w, "  SERVER ID\tENDPOINT\tTYPE\tSTATUS\tLAST SEEN")
+			for _, endpoint := range status.BmcEndpoints {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s ago\n",
+					endpoint.ServerId,
+					endpoint.BmcEndpoint,
+					formatAgentBMCType(endpoint.BmcType),
+					valueOrUnknown(endpoint.Status),
+					time.Since(endpoint.LastSeen.AsTime()).Round(time.Second))
+			}
+		}
+
+		w.Flush()
+
+		return nil
+	},
+}
+
+// gatewayEndpoint looks up the endpoint of a registered gateway by ID
+func gatewayEndpoint(ctx context.Context, bmcClient *client.Client, gatewayID string) (string, error) {
+	gateways, err := bmcClient.ListGateways(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list gateways: %w", err)
+	}
+	for _, gateway := range gateways {
+		if gateway.ID == gatewayID {
+			return gateway.Endpoint, nil
+		}
+	}
+	return "", fmt.Errorf("gateway %s is not registered with the manager", gatewayID)
+}
+
+// agentStatusTable summarizes an agent as a single table row
+func agentStatusTable(status *gatewayv1.AgentStatus) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "id", Header: "AGENT ID"},
+		output.Column{Key: "datacenter", Header: "DATACENTER"},
+		output.Column{Key: "status", Header: "STATUS"},
+		output.Column{Key: "version", Header: "VERSION"},
+		output.Column{Key: "last_heartbeat", Header: "LAST HEARTBEAT"},
+		output.Column{Key: "endpoints", Header: "BMC ENDPOINTS"},
+		output.Column{Key: "sessions", Header: "SESSIONS"},
+		output.Column{Key: "gateway", Header: "GATEWAY", Wide: true},
+		output.Column{Key: "endpoint", Header: "ENDPOINT", Wide: true},
+		output.Column{Key: "registered", Header: "REGISTERED", Wide: true},
+	)
+
+	table.AddRow(
+		status.AgentId,
+		status.DatacenterId,
+		status.Status,
+		valueOrUnknown(status.Version),
+		fmt.Sprintf("%s ago", time.Since(status.LastSeen.AsTime()).Round(time.Second)),
+		fmt.Sprintf("%d", len(status.BmcEndpoints)),
+		fmt.Sprintf("%d", status.ActiveSessionCount),
+		status.GatewayId,
+		status.Endpoint,
+		status.RegisteredAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+	)
+	return table
+}
+
+func outputAgentStatusData(formatter *output.Formatter, status *gatewayv1.AgentStatus) error {
+	endpoints := make([]map[string]interface{}, 0, len(status.BmcEndpoints))
+	for _, endpoint := range status.BmcEndpoints {
+		endpoints = append(endpoints, map[string]interface{}{
+			"server_id":    endpoint.ServerId,
+			"bmc_endpoint": endpoint.BmcEndpoint,
+			"bmc_type":     formatAgentBMCType(endpoint.BmcType),
+			"status":       endpoint.Status,
+			"last_seen":    endpoint.LastSeen.AsTime(),
+			"features":     endpoint.Features,
+		})
+	}
+
+	data := map[string]interface{}{
+		"agent_id":                status.AgentId,
+		"datacenter_id":           status.DatacenterId,
+		"gateway_id":              status.GatewayId,
+		"endpoint":                status.Endpoint,
+		"status":                  status.Status,
+		"version":                 status.Version,
+		"registered_at":           status.RegisteredAt.AsTime(),
+		"last_seen":               status.LastSeen.AsTime(),
+		"seconds_since_heartbeat": int64(time.Since(status.LastSeen.AsTime()).Seconds()),
+		"active_session_count":    status.ActiveSessionCount,
+		"bmc_endpoints":           endpoints,
+	}
+
+	return formatter.Output(data)
+}
+
+// formatAgentBMCType converts a proto BMC type enum to its short name (e.g. "ipmi")
+func formatAgentBMCType(bmcType fmt.Stringer) string {
+	return strings.ToLower(strings.TrimPrefix(bmcType.String(), "BMC_"))
+}
+
+// valueOrUnknown returns "unknown" for empty strings
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func init() {
+	output.AddFormatFlag(agentStatusCmd)
+	agentStatusCmd.Flags().String("gateway", "", "Only query the gateway with this ID")
+	agentStatusCmd.RegisterFlagCompletionFunc("gateway", completeGatewayIDs)
+
+	agentCmd.AddCommand(agentStatusCmd)
+	rootCmd.AddCommand(agentCmd)
+}
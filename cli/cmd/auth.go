@@ -10,6 +10,7 @@ import (
 	"golang.org/x/term"
 
 	"cli/pkg/client"
+	"cli/pkg/messages"
 )
 
 var authCmd = &cobra.Command{
@@ -18,6 +19,7 @@ var authCmd = &cobra.Command{
 }
 
 var loginPassword string
+var loginTOTPCode string
 
 var loginCmd = &cobra.Command{
 	Use:   "login [email]",
@@ -36,27 +38,26 @@ This will obtain delegated tokens for accessing Regional Gateways.`,
 		if len(args) > 0 {
 			email = args[0]
 		} else {
-			fmt.Print("Email: ")
+			fmt.Print(messages.Sprintf("auth.email_prompt"))
 			fmt.Scanln(&email)
 		}
 
 		// Get password
-		var password string
-		if loginPassword != "" {
-			password = loginPassword
-		} else {
-			fmt.Print("Password: ")
-			passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
-			if err != nil {
-				return fmt.Errorf("failed to read password: %w", err)
+		password := loginPassword
+		if password == "" {
+			var err error
+			if password, err = readPassword(messages.Sprintf("auth.password_prompt")); err != nil {
+				return err
 			}
-			password = string(passwordBytes)
-			fmt.Println() // New line after password input
 		}
 
-		// Create client and authenticate
+		// Create client and authenticate, prompting for a two-factor code
+		// when the account requires one
 		bmcClient := client.New(cfg)
-		err := bmcClient.Authenticate(ctx, email, password)
+		err := bmcClient.AuthenticateWithTOTP(ctx, email, password, loginTOTPCode)
+		if client.IsTOTPCodeRequired(err) && loginTOTPCode == "" {
+			err = bmcClient.AuthenticateWithTOTP(ctx, email, password, readTOTPCode())
+		}
 		if err != nil {
 			return fmt.Errorf("authentication failed: %w", err)
 		}
@@ -67,7 +68,7 @@ This will obtain delegated tokens for accessing Regional Gateways.`,
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Println("Authentication successful! Tokens saved to config.")
+		fmt.Println(messages.Sprintf("auth.login_succeeded"))
 		return nil
 	},
 }
@@ -80,21 +81,21 @@ var statusCmd = &cobra.Command{
 		cfg := GetConfig()
 
 		if cfg.Auth.AccessToken == "" {
-			fmt.Println("Not authenticated. Run 'bmc-cli auth login' to authenticate.")
+			fmt.Println(messages.Sprintf("auth.not_authenticated"))
 			return nil
 		}
 
-		fmt.Printf("Authenticated as: %s\n", cfg.Auth.Email)
-		fmt.Printf("Access token expires: %s\n", cfg.Auth.TokenExpiresAt.Format("2006-01-02 15:04:05"))
+		fmt.Println(messages.Sprintf("auth.authenticated_as", cfg.Auth.Email))
+		fmt.Println(messages.Sprintf("auth.token_expires", cfg.Auth.TokenExpiresAt.Format("2006-01-02 15:04:05")))
 
 		// Check if token is expired or expires soon
 		now := time.Now()
 		if now.After(cfg.Auth.TokenExpiresAt) {
-			fmt.Println("Status: ❌ Access token is expired")
+			fmt.Println(messages.Sprintf("auth.token_expired"))
 		} else if time.Until(cfg.Auth.TokenExpiresAt) < 5*time.Minute {
-			fmt.Printf("Status: ⚠️  Access token expires in %v\n", time.Until(cfg.Auth.TokenExpiresAt).Round(time.Second))
+			fmt.Println(messages.Sprintf("auth.token_expires_soon", time.Until(cfg.Auth.TokenExpiresAt).Round(time.Second)))
 		} else {
-			fmt.Printf("Status: ✅ Access token valid for %v\n", time.Until(cfg.Auth.TokenExpiresAt).Round(time.Second))
+			fmt.Println(messages.Sprintf("auth.token_valid", time.Until(cfg.Auth.TokenExpiresAt).Round(time.Second)))
 		}
 
 		return nil
@@ -115,19 +116,201 @@ var refreshCmd = &cobra.Command{
 		// Access the manager client to refresh token
 		// This is a simplified approach - in a real implementation,
 		// you might want to expose this method on the main client
-		fmt.Println("Refreshing access token...")
+		fmt.Println(messages.Sprintf("auth.refreshing"))
 
 		// For now, suggest re-login
-		fmt.Println("Token refresh not yet implemented. Please use 'bmc-cli auth login' to re-authenticate.")
+		fmt.Println(messages.Sprintf("auth.refresh_unimplemented"))
+
+		return nil
+	},
+}
+
+var passwdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change the password of your account",
+	Long: `Change the password of the logged-in account. Accounts without a password,
+such as the demo accounts of development stacks, leave the current password
+empty.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		currentPassword, err := readPassword(messages.Sprintf("auth.current_password_prompt"))
+		if err != nil {
+			return err
+		}
+		newPassword, err := readNewPassword()
+		if err != nil {
+			return err
+		}
+
+		bmcClient := client.New(GetConfig())
+		if err := bmcClient.ChangePassword(context.Background(), currentPassword, newPassword); err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("auth.password_changed"))
+		return nil
+	},
+}
+
+var resetPasswordToken string
+
+var resetPasswordCmd = &cobra.Command{
+	Use:   "reset-password [email]",
+	Short: "Set a new password with a reset token",
+	Long: `Set a new password with the one-time reset token an admin issued with
+'bmc-cli admin customers reset-password'. This also unlocks the account.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var email string
+		if len(args) > 0 {
+			email = args[0]
+		} else {
+			fmt.Print(messages.Sprintf("auth.email_prompt"))
+			fmt.Scanln(&email)
+		}
+
+		token := resetPasswordToken
+		if token == "" {
+			var err error
+			if token, err = readPassword(messages.Sprintf("auth.reset_token_prompt")); err != nil {
+				return err
+			}
+		}
+		newPassword, err := readNewPassword()
+		if err != nil {
+			return err
+		}
+
+		bmcClient := client.New(GetConfig())
+		if err := bmcClient.ResetPassword(context.Background(), email, token, newPassword); err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("auth.password_reset"))
+		return nil
+	},
+}
+
+var twoFactorCmd = &cobra.Command{
+	Use:   "2fa",
+	Short: "Manage two-factor authentication",
+	Long: `Manage the TOTP two-factor authentication of your account. Once enabled,
+logging in requires a code of your authenticator app, and consoles of accounts
+whose policy requires two-factor authentication only open after such logins.`,
+}
+
+var twoFactorEnrollCmd = &cobra.Command{
+	Use:   "enroll",
+	Short: "Add your account to an authenticator app",
+	Long: `Generate a TOTP secret for your account, to add to an authenticator app.
+Two-factor authentication is enabled once confirmed with 'bmc-cli auth 2fa
+confirm'. Enrolling again replaces a pending secret.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bmcClient := client.New(GetConfig())
+		enrolled, err := bmcClient.EnrollTOTP(context.Background())
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("auth.totp_enrolled", enrolled.Secret, enrolled.OtpauthUrl))
+		return nil
+	},
+}
+
+var twoFactorConfirmCmd = &cobra.Command{
+	Use:   "confirm [code]",
+	Short: "Enable two-factor authentication",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var code string
+		if len(args) > 0 {
+			code = args[0]
+		} else {
+			code = readTOTPCode()
+		}
+
+		bmcClient := client.New(GetConfig())
+		if err := bmcClient.ConfirmTOTP(context.Background(), code); err != nil {
+			return err
+		}
 
+		fmt.Println(messages.Sprintf("auth.totp_enabled"))
 		return nil
 	},
 }
 
+var twoFactorDisableCmd = &cobra.Command{
+	Use:   "disable [code]",
+	Short: "Disable two-factor authentication",
+	Long: `Disable two-factor authentication with a current code. Accounts whose
+policy requires it ask an admin to reset their authenticator instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var code string
+		if len(args) > 0 {
+			code = args[0]
+		} else {
+			code = readTOTPCode()
+		}
+
+		bmcClient := client.New(GetConfig())
+		if err := bmcClient.DisableTOTP(context.Background(), code); err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("auth.totp_disabled"))
+		return nil
+	},
+}
+
+// readTOTPCode prompts for a code of the authenticator app
+func readTOTPCode() string {
+	var code string
+	fmt.Print(messages.Sprintf("auth.totp_prompt"))
+	fmt.Scanln(&code)
+	return code
+}
+
+// readPassword prompts for a secret without echoing it
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println() // New line after password input
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
+
+// readNewPassword prompts for a new password twice
+func readNewPassword() (string, error) {
+	password, err := readPassword(messages.Sprintf("auth.new_password_prompt"))
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := readPassword(messages.Sprintf("auth.confirm_password_prompt"))
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	return password, nil
+}
+
 func init() {
 	loginCmd.Flags().StringVar(&loginPassword, "password", "", "Password for authentication (for non-interactive use)")
+	loginCmd.Flags().StringVar(&loginTOTPCode, "totp", "", "Two-factor code of your authenticator app (prompted for when required)")
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(statusCmd)
 	authCmd.AddCommand(refreshCmd)
+	resetPasswordCmd.Flags().StringVar(&resetPasswordToken, "token", "", "Reset token issued by an admin (prompted for when omitted)")
+	authCmd.AddCommand(passwdCmd)
+	authCmd.AddCommand(resetPasswordCmd)
+	twoFactorCmd.AddCommand(twoFactorEnrollCmd)
+	twoFactorCmd.AddCommand(twoFactorConfirmCmd)
+	twoFactorCmd.AddCommand(twoFactorDisableCmd)
+	authCmd.AddCommand(twoFactorCmd)
 	rootCmd.AddCommand(authCmd)
 }
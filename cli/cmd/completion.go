@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/config"
+)
+
+// completionTimeout bounds manager lookups so that a slow or unreachable
+// manager never blocks the shell
+const completionTimeout = 3 * time.Second
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a shell completion script for bmc-cli.
+
+Besides commands and flags, server IDs, gateway IDs and datacenters are
+completed dynamically by querying the BMC Manager with your current login.
+
+Bash (requires the bash-completion package):
+
+  $ source <(bmc-cli completion bash)
+
+  # Load for every session
+  $ bmc-cli completion bash > /etc/bash_completion.d/bmc-cli
+
+Zsh:
+
+  # Enable completion if it is not already enabled
+  $ echo "autoload -U compinit; compinit" >> ~/.zshrc
+
+  $ bmc-cli completion zsh > "${fpath[1]}/_bmc-cli"
+
+Fish:
+
+  $ bmc-cli completion fish > ~/.config/fish/completions/bmc-cli.fish
+
+PowerShell:
+
+  PS> bmc-cli completion powershell | Out-String | Invoke-Expression
+
+Start a new shell for the completion to take effect.`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	// Generating a script needs no configuration
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+// completionClient builds a client for completion callbacks. Cobra does not
+// run PersistentPreRunE when completing, so the configuration is loaded here.
+func completionClient() (*client.Client, bool) {
+	initConfig()
+	completionCfg, err := config.Load()
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to load configuration: %v", err), false)
+		return nil, false
+	}
+	return client.New(completionCfg), true
+}
+
+// completeServerIDs completes the optional [server-id] argument with the
+// servers visible to the current user. Server names are offered as well,
+// since they are accepted wherever a server ID is.
+func completeServerIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	bmcClient, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	servers, err := bmcClient.ListServers(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list servers: %v", err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return serverCompletions(servers, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeGatewayIDs completes gateway ID flags with the regional gateways
+// registered with the manager
+func completeGatewayIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	bmcClient, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	gateways, err := bmcClient.ListGateways(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list gateways: %v", err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, gateway := range gateways {
+		if strings.HasPrefix(gateway.ID, toComplete) {
+			completions = append(completions, completionEntry(gateway.ID, gateway.Region, gateway.Endpoint, gateway.Status))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeGatewayIDArg completes the <gateway-id> argument with the regional
+// gateways registered with the manager
+func completeGatewayIDArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeGatewayIDs(cmd, args, toComplete)
+}
+
+// completePendingGatewayIDs completes the <gateway-id> argument with the
+// gateways awaiting approval, which ListGateways does not return
+func completePendingGatewayIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	bmcClient, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	gateways, err := bmcClient.ListGatewayHealth(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list gateways: %v", err), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, gateway := range pendingGateways(gateways) {
+		if strings.HasPrefix(gateway.GatewayId, toComplete) {
+			completions = append(completions, completionEntry(gateway.GatewayId, gateway.Region, gateway.Endpoint))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDatacenters completes datacenter flags with the datacenters served
+// by the registered gateways and those of the current user's servers
+func completeDatacenters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	bmcClient, ok := completionClient()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	// Each lookup is best effort, customers may not see every gateway
+	regions := make(map[string]string)
+	if gateways, err := bmcClient.ListGateways(ctx); err == nil {
+		for _, gateway := range gateways {
+			for _, datacenterID := range gateway.DatacenterIDs {
+				regions[datacenterID] = gateway.Region
+			}
+		}
+	} else {
+		cobra.CompDebugln(fmt.Sprintf("failed to list gateways: %v", err), false)
+	}
+	if servers, err := bmcClient.ListServers(ctx); err == nil {
+		for _, server := range servers {
+			if _, exists := regions[server.DatacenterID]; !exists && server.DatacenterID != "" {
+				regions[server.DatacenterID] = ""
+			}
+		}
+	} else {
+		cobra.CompDebugln(fmt.Sprintf("failed to list servers: %v", err), false)
+	}
+
+	datacenters := make([]string, 0, len(regions))
+	for datacenterID := range regions {
+		if strings.HasPrefix(datacenterID, toComplete) {
+			datacenters = append(datacenters, datacenterID)
+		}
+	}
+	sort.Strings(datacenters)
+
+	completions := make([]string, 0, len(datacenters))
+	for _, datacenterID := range datacenters {
+		completions = append(completions, completionEntry(datacenterID, regions[datacenterID]))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// serverCompletions returns the server IDs and names starting with
+// toComplete, each described by the other attributes of the server
+func serverCompletions(servers []client.ServerInfo, toComplete string) []string {
+	var completions []string
+	for _, server := range servers {
+		var names []string
+		for _, key := range serverNameMetadataKeys {
+			if name := server.Metadata[key]; name != "" {
+				names = append(names, name)
+			}
+		}
+
+		if strings.HasPrefix(server.ID, toComplete) {
+			completions = append(completions, completionEntry(server.ID, strings.Join(names, ","), server.DatacenterID, server.Status))
+		}
+		for _, name := range names {
+			if name != server.ID && strings.HasPrefix(name, toComplete) {
+				completions = append(completions, completionEntry(name, server.ID, server.DatacenterID, server.Status))
+			}
+		}
+	}
+	return completions
+}
+
+// completionEntry formats a completion value with a description built from
+// the non-empty details
+func completionEntry(value string, details ...string) string {
+	var parts []string
+	for _, detail := range details {
+		if detail != "" {
+			parts = append(parts, detail)
+		}
+	}
+	if len(parts) == 0 {
+		return value
+	}
+	return value + "\t" + strings.Join(parts, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
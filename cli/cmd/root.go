@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 
 	"cli/pkg/config"
+	"cli/pkg/messages"
 )
 
 var (
@@ -27,13 +29,29 @@ without exposing BMC ports directly.`,
 		if err != nil {
 			return fmt.Errorf("failed to load configuration: %w", err)
 		}
+		messages.SetLanguage(cfg.Language)
 		return nil
 	},
 }
 
+// exitError is returned by commands that exit with a specific status code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -45,6 +63,8 @@ func init() {
 	rootCmd.PersistentFlags().String("gateway-url", "", "gateway server URL")
 	rootCmd.PersistentFlags().String("api-key", "", "API key for authentication")
 	rootCmd.PersistentFlags().String("token", "", "JWT token for authentication")
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "disable interactive prompts such as the server picker (for scripts)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "run power and console commands on a server matched by a partial or fuzzy reference without confirmation")
 
 	viper.BindPFlag("gateway.url", rootCmd.PersistentFlags().Lookup("gateway-url"))
 	viper.BindPFlag("auth.api_key", rootCmd.PersistentFlags().Lookup("api-key"))
@@ -7,7 +7,12 @@ import (
 var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Server management commands",
-	Long:  "Commands for managing servers through their BMC interfaces",
+	Long: `Commands for managing servers through their BMC interfaces.
+
+Server arguments accept a full server ID, a unique ID prefix, a metadata name
+(the "name" or "hostname" metadata keys), or a fuzzy pattern such as "d1-100".
+When the server is omitted or the reference is ambiguous, an interactive picker
+is shown. Use --no-interactive in scripts to fail instead of prompting.`,
 }
 
 func init() {
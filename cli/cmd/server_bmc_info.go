@@ -15,32 +15,38 @@ import (
 )
 
 var infoCmd = &cobra.Command{
-	Use:   "info <server-id>",
-	Short: "Show BMC hardware information for a server",
-	Long:  "Display detailed BMC hardware information including firmware version, manufacturer, and capabilities",
-	Args:  cobra.ExactArgs(1),
+	Use:               "info [server-id]",
+	Short:             "Show BMC hardware information for a server",
+	Long:              "Display detailed BMC hardware information including firmware version, manufacturer, and capabilities",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
 		bmcInfo, err := client.GetBMCInfo(ctx, serverID)
 		if err != nil {
 			return fmt.Errorf("failed to get BMC info: %w", err)
 		}
 
-		// Get output format
-		format, err := output.GetFormatFromCmd(cmd)
+		formatter, err := output.NewFromCmd(cmd)
 		if err != nil {
 			return err
 		}
 
-		formatter := output.New(format)
+		// Handle JSON/YAML output formats
+		if formatter.IsStructured() {
+			return outputInfoData(formatter, serverID, bmcInfo)
+		}
 
-		// Handle JSON output format
-		if formatter.IsJSON() {
-			return outputInfoJSON(formatter, serverID, bmcInfo)
+		// Table formats show a one-row summary
+		if formatter.IsTable() {
+			return formatter.OutputTable(infoTable(serverID, bmcInfo))
 		}
 
 		// Default text output
@@ -163,7 +169,51 @@ var infoCmd = &cobra.Command{
 	},
 }
 
-func outputInfoJSON(formatter *output.Formatter, serverID string, bmcInfo *gatewayv1.BMCInfo) error {
+// infoTable summarizes BMC information as a single table row
+func infoTable(serverID string, bmcInfo *gatewayv1.BMCInfo) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "id", Header: "SERVER ID"},
+		output.Column{Key: "bmc_type", Header: "BMC TYPE"},
+		output.Column{Key: "manufacturer", Header: "MANUFACTURER"},
+		output.Column{Key: "firmware", Header: "FIRMWARE"},
+		output.Column{Key: "power_state", Header: "POWER STATE"},
+		output.Column{Key: "model", Header: "MODEL", Wide: true},
+		output.Column{Key: "status", Header: "STATUS", Wide: true},
+		output.Column{Key: "boot_progress", Header: "BOOT PROGRESS", Wide: true},
+	)
+
+	manufacturer, firmware, powerState, model, status, bootProgress := "-", "-", "-", "-", "-", "-"
+	switch details := bmcInfo.Details.(type) {
+	case *gatewayv1.BMCInfo_IpmiInfo:
+		ipmi := details.IpmiInfo
+		manufacturer = valueOrDash(ipmi.ManufacturerName)
+		firmware = valueOrDash(ipmi.FirmwareRevision)
+		model = valueOrDash(ipmi.ProductId)
+	case *gatewayv1.BMCInfo_RedfishInfo:
+		redfish := details.RedfishInfo
+		manufacturer = valueOrDash(redfish.Manufacturer)
+		firmware = valueOrDash(redfish.FirmwareVersion)
+		powerState = valueOrDash(redfish.PowerState)
+		model = valueOrDash(redfish.Model)
+		status = valueOrDash(redfish.Status)
+		if sys := redfish.SystemStatus; sys != nil {
+			bootProgress = valueOrDash(sys.BootProgress)
+		}
+	}
+
+	table.AddRow(serverID, bmcInfo.BmcType, manufacturer, firmware, powerState, model, status, bootProgress)
+	return table
+}
+
+// valueOrDash returns "-" for empty strings
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func outputInfoData(formatter *output.Formatter, serverID string, bmcInfo *gatewayv1.BMCInfo) error {
 	// Create a JSON-friendly structure
 	data := map[string]interface{}{
 		"server_id": serverID,
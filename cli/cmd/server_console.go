@@ -6,35 +6,81 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
 	"cli/pkg/client"
+	"cli/pkg/config"
+	"cli/pkg/messages"
 	"cli/pkg/terminal"
 )
 
 var consoleCmd = &cobra.Command{
-	Use:   "console <server-id>",
+	Use:   "console [server-id]",
 	Short: "Open server console (SOL)",
 	Long: `Open a Serial Over LAN (SOL) console connection to the specified server.
 
 By default, this opens a web-based console viewer in your browser.
-Use --terminal flag for direct terminal streaming (advanced).`,
-	Args: cobra.ExactArgs(1),
+Use --terminal flag for direct terminal streaming (advanced).
+
+In terminal mode, press the escape key (Ctrl+] by default) followed by:
+  q  close the console session
+  d  detach, leaving the session open on the gateway
+Press the escape key twice to send it to the server. Use --escape or the
+console.escape_key config setting to change it, e.g. --escape '^A', or
+--escape none to disable escape sequences.
+
+A server's SOL console accepts one session at a time. If another session is
+attached, the console fails with a busy error naming it; --force-takeover
+disconnects that session and takes over the console instead.
+
+A detached session is reattached with "bmc-cli server console attach".
+
+--baud-rate and --flow-control change the BMC's serial settings for the
+session, e.g. for a server console running at 9600 baud. IPMI BMCs support
+the baud rate only, as a volatile setting kept until the BMC resets.
+
+--read-only opens a session whose input the gateway drops, to observe the
+console without controlling it. Tokens with the console:read permission only
+may create read-only sessions.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
 		terminalMode, _ := cmd.Flags().GetBool("terminal")
-		rawMode, _ := cmd.Flags().GetBool("raw")
+		if !terminalMode {
+			for _, flag := range []string{"log-file", "escape", "force-takeover"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s requires --terminal", flag)
+				}
+			}
+		}
+		readOnly, _ := cmd.Flags().GetBool("read-only")
+		forceTakeover, _ := cmd.Flags().GetBool("force-takeover")
+		if readOnly && forceTakeover {
+			return fmt.Errorf("--read-only and --force-takeover are mutually exclusive")
+		}
+
+		opts, err := solConsoleOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
 		if terminalMode {
 			// Terminal streaming mode - direct to CLI terminal
-			return openSOLConsole(ctx, client, serverID, rawMode)
+			return openSOLConsole(ctx, client, serverID, opts)
 		} else {
 			// Web console mode (default) - redirect to gateway
-			return openWebConsole(ctx, client, serverID)
+			return openWebConsole(ctx, client, serverID, opts.serial, opts.readOnly)
 		}
 	},
 }
@@ -55,100 +101,259 @@ func openBrowser(url string) error {
 }
 
 var vncCmd = &cobra.Command{
-	Use:   "vnc <server-id>",
+	Use:   "vnc [server-id]",
 	Short: "Open VNC console viewer",
 	Long: `Open a web-based VNC console viewer for the specified server.
 
 This creates a VNC session with the gateway and opens the VNC viewer
-directly in your web browser for remote graphical console access.`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
+directly in your web browser for remote graphical console access.
+
+--read-only opens a view-only session: the gateway drops keyboard, pointer
+and clipboard input, to observe the console without controlling it.
 
+--keyboard-layout names the layout of your keyboard (de, es, fr, gb, it).
+The gateway translates key events to the US keys BMC KVMs expect, so that
+a host configured with the same layout reads the characters you type.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
-		fmt.Printf("Creating VNC session for server %s...\n", serverID)
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("console.creating_vnc", serverID))
 
 		// Create VNC session
-		session, err := client.CreateVNCSession(ctx, serverID)
+		session, err := client.CreateVNCSession(ctx, serverID, vncSessionOptionsFromFlags(cmd))
 		if err != nil {
 			return fmt.Errorf("failed to create VNC session: %w", err)
 		}
 
-		fmt.Printf("VNC session created: %s\n", session.ID)
-		fmt.Printf("Session expires: %s\n", session.ExpiresAt)
-		fmt.Printf("Opening VNC viewer: %s\n", session.ViewerURL)
+		fmt.Println(messages.Sprintf("console.vnc_created", session.ID))
+		fmt.Println(messages.Sprintf("console.session_expires", session.ExpiresAt))
+		fmt.Println(messages.Sprintf("console.opening_vnc", session.ViewerURL))
 
 		// Open VNC viewer in browser
 		if err := openBrowser(session.ViewerURL); err != nil {
-			fmt.Printf("Failed to open browser automatically. Please navigate to: %s\n", session.ViewerURL)
+			fmt.Println(messages.Sprintf("console.browser_failed", session.ViewerURL))
 		}
 
-		fmt.Println("VNC session is ready!")
+		fmt.Println(messages.Sprintf("console.vnc_ready"))
 		return nil
 	},
 }
 
-func openWebConsole(ctx context.Context, client *client.Client, serverID string) error {
-	fmt.Printf("Creating web console session for server %s...\n", serverID)
+func openWebConsole(ctx context.Context, client *client.Client, serverID string, serial client.SOLSerialSettings, readOnly bool) error {
+	fmt.Println(messages.Sprintf("console.creating_web", serverID))
 
 	// Create SOL session for web console
-	session, err := client.CreateSOLSession(ctx, serverID)
+	session, err := client.CreateSOLSessionWithSettings(ctx, serverID, serial, readOnly)
 	if err != nil {
 		return fmt.Errorf("failed to create web console session: %w", err)
 	}
 
-	fmt.Printf("Web console session created: %s\n", session.ID)
-	fmt.Printf("Session expires: %s\n", session.ExpiresAt)
-	fmt.Printf("Opening web console: %s\n", session.ConsoleURL)
+	fmt.Println(messages.Sprintf("console.web_created", session.ID))
+	fmt.Println(messages.Sprintf("console.session_expires", session.ExpiresAt))
+	fmt.Println(messages.Sprintf("console.opening_web", session.ConsoleURL))
 
 	// Open web console in browser
 	if err := openBrowser(session.ConsoleURL); err != nil {
-		fmt.Printf("Failed to open browser automatically. Please navigate to: %s\n", session.ConsoleURL)
+		fmt.Println(messages.Sprintf("console.browser_failed", session.ConsoleURL))
 	}
 
-	fmt.Println("Web console is ready!")
+	fmt.Println(messages.Sprintf("console.web_ready"))
 	return nil
 }
 
-func openSOLConsole(ctx context.Context, client *client.Client, serverID string, rawMode bool) error {
-	fmt.Fprintf(os.Stderr, "Opening SOL console for server %s...\n", serverID)
+// vncSessionOptionsFromFlags reads the VNC session flags
+func vncSessionOptionsFromFlags(cmd *cobra.Command) client.VNCSessionOptions {
+	var opts client.VNCSessionOptions
+	opts.ReadOnly, _ = cmd.Flags().GetBool("read-only")
+	opts.KeyboardLayout, _ = cmd.Flags().GetString("keyboard-layout")
+	return opts
+}
+
+// solConsoleOptions configures an interactive terminal SOL console
+type solConsoleOptions struct {
+	rawMode       bool
+	logFile       string
+	escapeKey     byte
+	forceTakeover bool
+	serial        client.SOLSerialSettings // Serial settings requested for the session
+	readOnly      bool                     // The gateway drops the console input
+}
+
+// streamOptions returns the console stream handshake options
+func (o solConsoleOptions) streamOptions() client.ConsoleStreamOptions {
+	return client.ConsoleStreamOptions{ForceTakeover: o.forceTakeover}
+}
+
+// solConsoleOptionsFromFlags reads the terminal console flags. The escape
+// key falls back to the console.escape_key config setting.
+func solConsoleOptionsFromFlags(cmd *cobra.Command) (solConsoleOptions, error) {
+	var opts solConsoleOptions
+	opts.rawMode, _ = cmd.Flags().GetBool("raw")
+	opts.logFile, _ = cmd.Flags().GetString("log-file")
+	opts.forceTakeover, _ = cmd.Flags().GetBool("force-takeover")
+	opts.serial.BaudRate, _ = cmd.Flags().GetInt("baud-rate")
+	opts.serial.FlowControl, _ = cmd.Flags().GetString("flow-control")
+	opts.readOnly, _ = cmd.Flags().GetBool("read-only")
+
+	escape, _ := cmd.Flags().GetString("escape")
+	if !cmd.Flags().Changed("escape") {
+		escape = GetConfig().Console.EscapeKey
+	}
+	if escape == "" {
+		escape = terminal.DefaultEscapeKey
+	}
+
+	escapeKey, err := terminal.ParseEscapeKey(escape)
+	if err != nil {
+		return opts, err
+	}
+	opts.escapeKey = escapeKey
+	return opts, nil
+}
+
+// addSOLConsoleFlags adds the flags read by solConsoleOptionsFromFlags
+func addSOLConsoleFlags(cmd *cobra.Command, logFileUsage string) {
+	// Add --raw flag for preserving terminal control sequences
+	cmd.Flags().Bool("raw", false, "Preserve terminal control sequences (allows overwriting lines). Default is append-only mode.")
+	// Add --log-file flag to capture console output (e.g. boot logs)
+	cmd.Flags().String("log-file", "", logFileUsage)
+	// Add --escape flag to change the escape key
+	cmd.Flags().String("escape", "", "Escape key in caret notation, e.g. '^A', or 'none' (default from config, '^]')")
+	addForceTakeoverFlag(cmd)
+}
+
+// addForceTakeoverFlag adds the --force-takeover flag of console commands
+func addForceTakeoverFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("force-takeover", false, "Disconnect the session attached to the server's console and take it over")
+}
+
+// consoleStreamError explains how to take over a console held by another
+// session
+func consoleStreamError(err error) error {
+	if connect.CodeOf(err) == connect.CodeFailedPrecondition {
+		return fmt.Errorf("failed to open console stream: %w\nUse --force-takeover to disconnect that session and take over the console", err)
+	}
+	return fmt.Errorf("failed to open console stream: %w", err)
+}
+
+func openSOLConsole(ctx context.Context, client *client.Client, serverID string, opts solConsoleOptions) error {
+	fmt.Fprintln(os.Stderr, messages.Sprintf("console.opening_sol", serverID))
 
 	// Create SOL session
-	session, err := client.CreateSOLSession(ctx, serverID)
+	session, err := client.CreateSOLSessionWithSettings(ctx, serverID, opts.serial, opts.readOnly)
 	if err != nil {
 		return fmt.Errorf("failed to create SOL session: %w", err)
 	}
 
-	fmt.Fprintf(os.Stderr, "SOL session created: %s\n", session.ID)
-	fmt.Fprintf(os.Stderr, "Connecting to console...\n\n")
+	fmt.Fprintln(os.Stderr, messages.Sprintf("console.sol_created", session.ID))
+	if opts.readOnly {
+		fmt.Fprintln(os.Stderr, messages.Sprintf("console.read_only"))
+	}
+	fmt.Fprintf(os.Stderr, "%s\n\n", messages.Sprintf("console.connecting"))
 
+	return runSOLConsole(ctx, client, serverID, session, opts)
+}
+
+// runSOLConsole streams an existing SOL session to the terminal. The session is
+// closed on the gateway when the console ends, unless the user detached.
+func runSOLConsole(ctx context.Context, client *client.Client, serverID string, session *client.SOLSession, opts solConsoleOptions) error {
 	// Open Connect bidirectional stream
-	// Note: StreamConsoleData signature is (ctx, serverID, sessionID)
-	stream, err := client.StreamConsoleData(ctx, serverID, session.ID)
+	// Note: StreamConsoleDataWithOptions signature is (ctx, serverID, sessionID, opts)
+	stream, err := client.StreamConsoleDataWithOptions(ctx, serverID, session.ID, opts.streamOptions())
 	if err != nil {
-		return fmt.Errorf("failed to open console stream: %w", err)
+		return consoleStreamError(err)
 	}
 
 	// Create terminal handler with Connect stream
 	solTerminal := terminal.NewSOLTerminal(stream, session.ID)
-	solTerminal.SetRawMode(rawMode)
+	solTerminal.SetRawMode(opts.rawMode)
+	solTerminal.SetEscapeKey(opts.escapeKey)
 	defer solTerminal.Close()
 
+	if opts.logFile != "" {
+		if err := solTerminal.SetLogFile(opts.logFile); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, messages.Sprintf("console.logging", opts.logFile))
+	}
+
 	// Start streaming
-	if err := solTerminal.Start(ctx); err != nil {
-		return fmt.Errorf("console session error: %w", err)
+	startErr := solTerminal.Start(ctx)
+	solTerminal.Close()
+
+	if solTerminal.Detached() {
+		recordDetachedSession(serverID, session)
+	} else {
+		closeSOLConsoleSession(client, serverID, session.ID)
 	}
 
+	if startErr != nil {
+		return fmt.Errorf("console session error: %w", startErr)
+	}
 	return nil
 }
 
+// recordDetachedSession remembers a detached session for console attach
+func recordDetachedSession(serverID string, session *client.SOLSession) {
+	record := config.DetachedSession{
+		ID:         session.ID,
+		ServerID:   serverID,
+		ExpiresAt:  parseSessionExpiry(session.ExpiresAt),
+		DetachedAt: time.Now(),
+	}
+
+	store, err := config.DefaultSessionStore()
+	if err == nil {
+		err = store.Put(record)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, messages.Sprintf("console.record_failed", err))
+	}
+
+	if !record.ExpiresAt.IsZero() {
+		fmt.Fprintln(os.Stderr, messages.Sprintf("console.session_remains", session.ID, record.ExpiresAt.Local().Format(time.RFC3339)))
+	}
+	fmt.Fprintln(os.Stderr, messages.Sprintf("console.reattach_with", session.ID))
+}
+
+// closeSOLConsoleSession closes a session on the gateway and forgets it
+func closeSOLConsoleSession(client *client.Client, serverID, sessionID string) {
+	if err := client.CloseServerSOLSession(context.Background(), serverID, sessionID); err != nil {
+		fmt.Fprintln(os.Stderr, messages.Sprintf("console.close_failed", sessionID, err))
+	}
+
+	if store, err := config.DefaultSessionStore(); err == nil {
+		_ = store.Remove(sessionID)
+	}
+}
+
+// parseSessionExpiry parses a session expiry as formatted by the client,
+// returning the zero time if it is unknown
+func parseSessionExpiry(expiresAt string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", expiresAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func init() {
 	// Add --terminal flag to console command
 	consoleCmd.Flags().Bool("terminal", false, "Use direct terminal streaming instead of web console (advanced)")
-	// Add --raw flag for preserving terminal control sequences
-	consoleCmd.Flags().Bool("raw", false, "Preserve terminal control sequences (allows overwriting lines). Default is append-only mode.")
+	addSOLConsoleFlags(consoleCmd, "Append timestamped console output to this file (requires --terminal)")
+	consoleCmd.Flags().Int("baud-rate", 0, "Serial baud rate for this session: 9600, 19200, 38400, 57600 or 115200 (default: BMC setting)")
+	consoleCmd.Flags().String("flow-control", "", "Serial flow control for this session: none, hardware or software (default: BMC setting)")
+	consoleCmd.Flags().Bool("read-only", false, "Observe the console without sending input")
+	vncCmd.Flags().Bool("read-only", false, "Observe the console without keyboard or pointer input")
+	vncCmd.Flags().String("keyboard-layout", "", "Layout of your keyboard, translated for the BMC: de, es, fr, gb, it or us (default: us)")
 
 	serverCmd.AddCommand(consoleCmd)
 	serverCmd.AddCommand(vncCmd)
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/config"
+	"cli/pkg/messages"
+)
+
+var consoleAttachCmd = &cobra.Command{
+	Use:   "attach <session-id>",
+	Short: "Reattach to a detached SOL console session",
+	Long: `Reattach the terminal to a SOL session that was detached with the escape
+key followed by 'd'.
+
+The gateway keeps a detached session until it expires, and the server's
+console connection is re-established on attach. Output produced while
+detached is not replayed.
+
+Sessions detached from this machine are remembered, so only the session ID is
+needed. Use --server for a session detached elsewhere.
+
+Example:
+  bmc-cli server console attach sol-1736000000000000000`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeDetachedSessions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessionID := args[0]
+		serverID, _ := cmd.Flags().GetString("server")
+
+		store, err := config.DefaultSessionStore()
+		if err != nil {
+			return err
+		}
+
+		if serverID == "" {
+			record, err := store.Get(sessionID)
+			if err != nil {
+				return err
+			}
+			if record == nil {
+				return fmt.Errorf("no detached session %s found on this machine, use --server to specify its server", sessionID)
+			}
+			serverID = record.ServerID
+		}
+
+		opts, err := solConsoleOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		session, err := client.GetServerSOLSession(ctx, serverID, sessionID)
+		if err != nil {
+			if connect.CodeOf(err) == connect.CodeNotFound {
+				_ = store.Remove(sessionID)
+				return fmt.Errorf("SOL session %s no longer exists on the gateway", sessionID)
+			}
+			return err
+		}
+		if session.ServerID != "" && session.ServerID != serverID {
+			return fmt.Errorf("SOL session %s belongs to server %s, not %s", sessionID, session.ServerID, serverID)
+		}
+
+		fmt.Fprintf(os.Stderr, "%s\n\n", messages.Sprintf("console.reattaching", sessionID, serverID))
+
+		return runSOLConsole(ctx, client, serverID, session, opts)
+	},
+}
+
+// completeDetachedSessions completes the sessions detached from this machine
+func completeDetachedSessions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	store, err := config.DefaultSessionStore()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	sessions, err := store.List()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		completions = append(completions, completionEntry(session.ID,
+			session.ServerID, "detached "+session.DetachedAt.Local().Format(time.DateTime)))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	consoleAttachCmd.Flags().String("server", "", "Server the session belongs to (default: as recorded when detaching)")
+	consoleAttachCmd.RegisterFlagCompletionFunc("server", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeServerIDs(cmd, nil, toComplete)
+	})
+	addSOLConsoleFlags(consoleAttachCmd, "Append timestamped console output to this file")
+
+	consoleCmd.AddCommand(consoleAttachCmd)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+	"cli/pkg/terminal"
+)
+
+// Exit codes of server console exec
+const (
+	execExitTimeout = 2 // expected output did not appear in time
+)
+
+var consoleExecCmd = &cobra.Command{
+	Use:   "exec <server-id>",
+	Short: "Run a command on the server console non-interactively",
+	Long: `Open a SOL session, send a command, wait for the expected output and exit.
+
+The command is typed on the serial console followed by Enter. Output is
+captured until the --expect regular expression matches, then printed to stdout
+without the echoed command. Without --expect, output is captured until the
+console has been idle for --idle.
+
+Exit status:
+  0  expected output matched (or console went idle without --expect)
+  1  session or stream error
+  2  timed out waiting for the expected output
+
+Example:
+  bmc-cli server console exec server-001 --command "ls /" --expect '\$ $' --timeout 30s`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command, _ := cmd.Flags().GetString("command")
+		expectPattern, _ := cmd.Flags().GetString("expect")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout <= 0 {
+			return fmt.Errorf("--timeout must be positive")
+		}
+		idleTimeout, _ := cmd.Flags().GetDuration("idle")
+		forceTakeover, _ := cmd.Flags().GetBool("force-takeover")
+
+		opts := terminal.ExecOptions{
+			Command:     command,
+			Timeout:     timeout,
+			IdleTimeout: idleTimeout,
+		}
+		if expectPattern != "" {
+			expect, err := regexp.Compile(expectPattern)
+			if err != nil {
+				return fmt.Errorf("invalid --expect pattern: %w", err)
+			}
+			opts.Expect = expect
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		return runConsoleExec(ctx, client, formatter, serverID, opts, forceTakeover)
+	},
+}
+
+// runConsoleExec runs a single command on the server's SOL console
+func runConsoleExec(
+	ctx context.Context,
+	bmcClient *client.Client,
+	formatter *output.Formatter,
+	serverID string,
+	opts terminal.ExecOptions,
+	forceTakeover bool,
+) error {
+	// The timeout covers session setup as well as the command itself
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout+10*time.Second)
+	defer cancel()
+
+	session, err := bmcClient.CreateSOLSession(ctx, serverID)
+	if err != nil {
+		return fmt.Errorf("failed to create SOL session: %w", err)
+	}
+	defer func() {
+		if err := bmcClient.CloseServerSOLSession(context.Background(), serverID, session.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close SOL session %s: %v\n", session.ID, err)
+		}
+	}()
+
+	stream, err := bmcClient.StreamConsoleDataWithOptions(ctx, serverID, session.ID,
+		client.ConsoleStreamOptions{ForceTakeover: forceTakeover})
+	if err != nil {
+		return consoleStreamError(err)
+	}
+
+	result, execErr := terminal.Exec(ctx, stream, session.ID, opts)
+	if result == nil {
+		return fmt.Errorf("console exec failed: %w", execErr)
+	}
+
+	if formatter.IsStructured() {
+		data := map[string]interface{}{
+			"server_id":   serverID,
+			"session_id":  session.ID,
+			"command":     opts.Command,
+			"matched":     result.Matched,
+			"timed_out":   errors.Is(execErr, terminal.ErrExpectTimeout),
+			"output":      result.Output,
+			"duration_ms": result.Duration.Milliseconds(),
+		}
+		if opts.Expect != nil {
+			data["expect"] = opts.Expect.String()
+		}
+		if err := formatter.Output(data); err != nil {
+			return err
+		}
+	} else if result.Output != "" {
+		fmt.Fprint(os.Stdout, result.Output)
+		if !strings.HasSuffix(result.Output, "\n") {
+			fmt.Fprintln(os.Stdout)
+		}
+	}
+
+	switch {
+	case errors.Is(execErr, terminal.ErrExpectTimeout):
+		return &exitError{
+			code: execExitTimeout,
+			err:  fmt.Errorf("pattern %q not seen within %s", opts.Expect.String(), opts.Timeout),
+		}
+	case execErr != nil:
+		return fmt.Errorf("console exec failed: %w", execErr)
+	}
+	return nil
+}
+
+func init() {
+	consoleExecCmd.Flags().StringP("command", "c", "", "Command to send to the console (required)")
+	consoleExecCmd.Flags().StringP("expect", "e", "", "Regular expression to wait for in the output, such as the shell prompt")
+	consoleExecCmd.Flags().Duration("timeout", terminal.DefaultExecTimeout, "Maximum time to wait for the expected output")
+	consoleExecCmd.Flags().Duration("idle", terminal.DefaultExecIdleTimeout, "Idle time after which output is complete when --expect is not set")
+	addForceTakeoverFlag(consoleExecCmd)
+	consoleExecCmd.MarkFlagRequired("command")
+	output.AddFormatFlag(consoleExecCmd)
+
+	consoleCmd.AddCommand(consoleExecCmd)
+}
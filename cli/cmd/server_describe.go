@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [server-id]",
+	Short: "Show how a server's BMC was discovered",
+	Long: `Display the discovery metadata the agent reported for a server's BMC: the
+discovery method and source, the vendor, model and firmware versions, the
+protocols and their fallbacks, security settings, network details, and the
+warnings and errors of the discovery.
+
+This is the metadata shown by 'server show --metadata'.`,
+	Example: `  bmc-cli server describe server-1
+  bmc-cli server describe server-1 --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		server, err := client.GetServer(ctx, serverID)
+		if err != nil {
+			return fmt.Errorf("failed to get server info: %w", err)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"server_id":          server.ID,
+				"discovery_metadata": server.DiscoveryMetadata,
+			})
+		}
+
+		if server.DiscoveryMetadata == nil {
+			fmt.Printf("No discovery metadata reported for server %s\n", server.ID)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Server ID:\t%s\n", server.ID)
+		writeDiscoveryMetadata(w, server.DiscoveryMetadata)
+		return w.Flush()
+	},
+}
+
+func init() {
+	serverCmd.AddCommand(describeCmd)
+	output.AddFormatFlag(describeCmd)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var healthCheckConsoles bool
+
+var healthCmd = &cobra.Command{
+	Use:   "health [server-id]",
+	Short: "Check each hop from the gateway to a server's BMC",
+	Long: `Run a deep health check of the path to a server's BMC and report the status
+of each hop, to find where it is broken:
+
+  agent        The gateway reaches the agent of the server's datacenter
+  bmc_control  The agent reads the power state from the BMC control endpoint
+  sol          The agent connects to the serial console endpoint (--consoles)
+  vnc          The agent connects to the VNC endpoint (--consoles)
+
+The hops after a failed one are skipped. The command exits with an error when
+a hop failed.`,
+	Example: `  bmc-cli server health server-1
+  bmc-cli server health server-1 --consoles
+  bmc-cli server health server-1 --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.DeepHealthCheck(ctx, serverID, healthCheckConsoles)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case formatter.IsStructured():
+			hops := make([]map[string]interface{}, 0, len(result.Hops))
+			for _, hop := range result.Hops {
+				hops = append(hops, map[string]interface{}{
+					"name":       hop.Name,
+					"status":     hop.Status,
+					"target":     hop.Target,
+					"latency_ms": hop.LatencyMs,
+					"error":      hop.Error,
+				})
+			}
+			if err := formatter.Output(map[string]interface{}{
+				"server_id": serverID,
+				"healthy":   result.Healthy,
+				"hops":      hops,
+			}); err != nil {
+				return err
+			}
+
+		case formatter.IsTable():
+			table := output.NewTable(
+				output.Column{Key: "hop", Header: "HOP"},
+				output.Column{Key: "status", Header: "STATUS"},
+				output.Column{Key: "target", Header: "TARGET"},
+				output.Column{Key: "latency", Header: "LATENCY"},
+				output.Column{Key: "error", Header: "ERROR"},
+			)
+			for _, hop := range result.Hops {
+				table.AddRow(
+					hop.Name,
+					strings.ToUpper(hop.Status),
+					valueOrDash(hop.Target),
+					hopLatency(hop.Status, hop.LatencyMs),
+					valueOrDash(hop.Error),
+				)
+			}
+			if err := formatter.OutputTable(table); err != nil {
+				return err
+			}
+
+		default:
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "Server ID:\t%s\n", serverID)
+			fmt.Fprintf(w, "Healthy:\t%t\n", result.Healthy)
+			fmt.Fprintln(w)
+			for _, hop := range result.Hops {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", hop.Name, strings.ToUpper(hop.Status),
+					valueOrDash(hop.Target), hopLatency(hop.Status, hop.LatencyMs))
+				if hop.Error != "" {
+					fmt.Fprintf(w, "    %s\n", hop.Error)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		for _, hop := range result.Hops {
+			if hop.Status == "failed" {
+				return fmt.Errorf("health check of %s failed at the %s hop", serverID, hop.Name)
+			}
+		}
+		return nil
+	},
+}
+
+// hopLatency returns the time taken by the check of a hop, or "-" when it
+// was skipped
+func hopLatency(status string, latencyMs int64) string {
+	if status == "skipped" {
+		return "-"
+	}
+	return fmt.Sprintf("%dms", latencyMs)
+}
+
+func init() {
+	serverCmd.AddCommand(healthCmd)
+	healthCmd.Flags().BoolVar(&healthCheckConsoles, "consoles", false, "Also check the SOL and VNC endpoints")
+	output.AddFormatFlag(healthCmd)
+}
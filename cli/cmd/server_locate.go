@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/messages"
+)
+
+var (
+	locateDuration time.Duration
+	locateOn       bool
+	locateOff      bool
+)
+
+var locateCmd = &cobra.Command{
+	Use:   "locate [server-id]",
+	Short: "Blink the identify LED of a server",
+	Long: `Blink the chassis identify (locate) LED of a server so it can be found in the rack.
+
+The LED blinks for --duration, 5 minutes by default, and is then turned off.
+Use --on to keep it lit until turned off with --off.`,
+	Example: `  bmc-cli server locate server-1 --duration 5m
+  bmc-cli server locate server-1 --on
+  bmc-cli server locate server-1 --off`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		state := gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK
+		switch {
+		case locateOn:
+			state = gatewayv1.IdentifyState_IDENTIFY_STATE_ON
+		case locateOff:
+			state = gatewayv1.IdentifyState_IDENTIFY_STATE_OFF
+		case locateDuration < time.Second:
+			return fmt.Errorf("--duration must be at least 1s")
+		}
+
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.SetChassisIdentify(ctx, serverID, state, locateDuration)
+		if err != nil {
+			return fmt.Errorf("failed to set identify LED: %w", err)
+		}
+
+		switch state {
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_ON:
+			fmt.Println(messages.Sprintf("locate.on", serverID))
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_OFF:
+			fmt.Println(messages.Sprintf("locate.off", serverID))
+		default:
+			offAt := time.Now().Add(locateDuration)
+			if resp.OffAt != nil {
+				offAt = resp.OffAt.AsTime()
+			}
+			fmt.Println(messages.Sprintf("locate.blinking", serverID, offAt.Local().Format("15:04:05")))
+		}
+		return nil
+	},
+}
+
+func init() {
+	serverCmd.AddCommand(locateCmd)
+
+	locateCmd.Flags().DurationVar(&locateDuration, "duration", 5*time.Minute, "How long the LED blinks")
+	locateCmd.Flags().BoolVar(&locateOn, "on", false, "Turn the LED on until turned off")
+	locateCmd.Flags().BoolVar(&locateOff, "off", false, "Turn the LED off")
+	locateCmd.MarkFlagsMutuallyExclusive("on", "off")
+	locateCmd.MarkFlagsMutuallyExclusive("duration", "on")
+	locateCmd.MarkFlagsMutuallyExclusive("duration", "off")
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network [server-id]",
+	Short: "Show the network configuration of a server's BMC",
+	Long: `Display the network configuration of the BMC's own management interfaces:
+MAC address, IPv4 address, subnet mask, gateway, VLAN and IPv6 addresses, and
+whether they are static or assigned by DHCP.
+
+IPMI BMCs report their first LAN channel, Redfish BMCs the EthernetInterfaces
+of their Manager.`,
+	Example: `  bmc-cli server network server-1
+  bmc-cli server network server-1 --output table`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		config, err := client.GetBMCNetworkConfig(ctx, serverID)
+		if err != nil {
+			return fmt.Errorf("failed to get BMC network configuration: %w", err)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			interfaces := make([]map[string]interface{}, 0, len(config.Interfaces))
+			for _, iface := range config.Interfaces {
+				interfaces = append(interfaces, map[string]interface{}{
+					"id":             iface.Id,
+					"enabled":        iface.Enabled,
+					"mac_address":    iface.MacAddress,
+					"address_origin": iface.AddressOrigin,
+					"ipv4_address":   iface.Ipv4Address,
+					"subnet_mask":    iface.SubnetMask,
+					"gateway":        iface.Gateway,
+					"vlan_id":        iface.VlanId,
+					"ipv6_addresses": iface.Ipv6Addresses,
+				})
+			}
+			return formatter.Output(map[string]interface{}{
+				"server_id":  serverID,
+				"bmc_type":   config.BmcType,
+				"interfaces": interfaces,
+			})
+		}
+
+		if formatter.IsTable() {
+			table := output.NewTable(
+				output.Column{Key: "interface", Header: "INTERFACE"},
+				output.Column{Key: "origin", Header: "ORIGIN"},
+				output.Column{Key: "ipv4", Header: "IPV4 ADDRESS"},
+				output.Column{Key: "mask", Header: "SUBNET MASK"},
+				output.Column{Key: "gateway", Header: "GATEWAY"},
+				output.Column{Key: "vlan", Header: "VLAN"},
+				output.Column{Key: "mac", Header: "MAC ADDRESS", Wide: true},
+				output.Column{Key: "ipv6", Header: "IPV6 ADDRESSES", Wide: true},
+			)
+			for _, iface := range config.Interfaces {
+				table.AddRow(
+					valueOrDash(iface.Id),
+					valueOrDash(iface.AddressOrigin),
+					valueOrDash(iface.Ipv4Address),
+					valueOrDash(iface.SubnetMask),
+					valueOrDash(iface.Gateway),
+					vlanName(iface.VlanId),
+					valueOrDash(iface.MacAddress),
+					valueOrDash(strings.Join(iface.Ipv6Addresses, ", ")),
+				)
+			}
+			return formatter.OutputTable(table)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Server ID:\t%s\n", serverID)
+		fmt.Fprintf(w, "BMC Type:\t%s\n", config.BmcType)
+
+		for i, iface := range config.Interfaces {
+			name := iface.Id
+			if name == "" {
+				name = strconv.Itoa(i + 1)
+			}
+			state := "enabled"
+			if !iface.Enabled {
+				state = "disabled"
+			}
+
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "Interface %s (%s):\n", name, state)
+			fmt.Fprintf(w, "  MAC Address:\t%s\n", valueOrDash(iface.MacAddress))
+			fmt.Fprintf(w, "  Address Origin:\t%s\n", valueOrDash(iface.AddressOrigin))
+			fmt.Fprintf(w, "  IPv4 Address:\t%s\n", valueOrDash(iface.Ipv4Address))
+			fmt.Fprintf(w, "  Subnet Mask:\t%s\n", valueOrDash(iface.SubnetMask))
+			fmt.Fprintf(w, "  Gateway:\t%s\n", valueOrDash(iface.Gateway))
+			fmt.Fprintf(w, "  VLAN:\t%s\n", vlanName(iface.VlanId))
+			for _, address := range iface.Ipv6Addresses {
+				fmt.Fprintf(w, "  IPv6 Address:\t%s\n", address)
+			}
+		}
+
+		return w.Flush()
+	},
+}
+
+// vlanName returns the VLAN ID of an interface, or "none" when untagged
+func vlanName(vlanID int32) string {
+	if vlanID == 0 {
+		return "none"
+	}
+	return strconv.Itoa(int(vlanID))
+}
+
+func init() {
+	serverCmd.AddCommand(networkCmd)
+	output.AddFormatFlag(networkCmd)
+}
@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"cli/pkg/client"
+	"cli/pkg/messages"
+	"cli/pkg/output"
+	"cli/pkg/watch"
+	gatewayv1 "gateway/gen/gateway/v1"
 )
 
 var powerCmd = &cobra.Command{
@@ -15,111 +24,420 @@ var powerCmd = &cobra.Command{
 	Long:  "Commands for controlling server power state through BMC",
 }
 
+var (
+	powerWait        bool
+	powerWaitTimeout time.Duration
+	powerAsync       bool
+
+	powerOverrideMaintenance bool
+
+	powerIdempotencyKey string
+)
+
+// addPowerWaitFlags adds --wait, --wait-timeout and --async to a power
+// command
+func addPowerWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&powerWait, "wait", false, "Wait until the BMC completes the operation, showing its progress")
+	cmd.Flags().DurationVar(&powerWaitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait with --wait")
+	cmd.Flags().BoolVar(&powerAsync, "async", false, "Return once the gateway started the operation, printing its ID for 'server power operations'")
+}
+
+// addMaintenanceOverrideFlag adds --override-maintenance to a destructive
+// power command
+func addMaintenanceOverrideFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&powerOverrideMaintenance, "override-maintenance", false,
+		"Run during a maintenance window of the server; overrides are audited")
+}
+
+// addIdempotencyKeyFlag adds --idempotency-key to a power command
+func addIdempotencyKeyFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&powerIdempotencyKey, "idempotency-key", "",
+		"Key of the operation; retries with the same key within the gateway's window return its result instead of running it again")
+}
+
+// runPowerOperation performs a power operation, and prints the done message
+// once completed. With --wait, it follows the operation until the BMC
+// completes it, showing the progress of BMCs that run it as a task; with
+// --async, it returns once the gateway started it, printing its ID;
+// otherwise it returns when the BMC accepts it.
+func runPowerOperation(ctx context.Context, bmcClient *client.Client, serverID string, operation gatewayv1.PowerOperation, perform func(context.Context, string) error, done string) error {
+	if err := validatePowerModeFlags(); err != nil {
+		return err
+	}
+
+	switch {
+	case powerAsync:
+		op, err := bmcClient.StartPowerOperation(ctx, serverID, operation)
+		if err != nil {
+			return err
+		}
+		fmt.Println(messages.Sprintf("power.operation_started", op.Id, serverID))
+		return nil
+	case powerWait:
+		if err := waitPowerOperation(ctx, bmcClient, serverID, operation); err != nil {
+			return err
+		}
+	default:
+		bmcClient.SetIdempotencyKey(powerIdempotencyKey)
+		if err := perform(ctx, serverID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(messages.Sprintf(done, serverID))
+	return nil
+}
+
+// validatePowerModeFlags rejects the combinations of --wait, --async and
+// --idempotency-key
+func validatePowerModeFlags() error {
+	if powerAsync && powerWait {
+		return fmt.Errorf("--async cannot be used with --wait, follow the operation with 'server power operations --wait'")
+	}
+	if powerIdempotencyKey != "" && (powerWait || powerAsync) {
+		return fmt.Errorf("--idempotency-key cannot be used with --wait or --async")
+	}
+	return nil
+}
+
+// waitPowerOperation runs a power operation, showing its progress until the
+// BMC completes it or --wait-timeout
+func waitPowerOperation(ctx context.Context, bmcClient *client.Client, serverID string, operation gatewayv1.PowerOperation) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, powerWaitTimeout)
+	defer cancel()
+
+	progress := output.NewProgress(os.Stdout, watchRedraw())
+	err := bmcClient.RunPowerOperation(ctx, serverID, operation, func(p *gatewayv1.PowerOperationProgress) {
+		updatePowerProgress(progress, p)
+	})
+	progress.Finish()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s, the BMC may still complete the operation", powerWaitTimeout)
+	}
+	return err
+}
+
+// updatePowerProgress shows a progress report of a power operation
+func updatePowerProgress(progress *output.Progress, p *gatewayv1.PowerOperationProgress) {
+	// Operations completed without a task have no progress to show
+	if p.Done && p.TaskId == "" {
+		return
+	}
+	status := p.State
+	if p.Message != "" && !p.Done {
+		status += ": " + p.Message
+	}
+	progress.Update(int(p.PercentComplete), status)
+}
+
 var powerOnCmd = &cobra.Command{
-	Use:   "on <server-id>",
-	Short: "Power on a server",
-	Long:  "Power on the specified server through its BMC interface",
-	Args:  cobra.ExactArgs(1),
+	Use:               "on [server-id]",
+	Short:             "Power on a server",
+	Long:              "Power on the specified server through its BMC interface",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
-		fmt.Printf("Powering on server %s...\n", serverID)
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("power.powering_on", serverID))
 
-		if err := client.PowerOn(ctx, serverID); err != nil {
+		if err := runPowerOperation(ctx, client, serverID, gatewayv1.PowerOperation_POWER_OPERATION_ON, client.PowerOn, "power.powered_on"); err != nil {
 			return fmt.Errorf("failed to power on server: %w", err)
 		}
-
-		fmt.Printf("Server %s powered on successfully\n", serverID)
 		return nil
 	},
 }
 
+var (
+	powerOffGraceful bool
+	powerOffForce    bool
+	powerOffTimeout  time.Duration
+)
+
 var powerOffCmd = &cobra.Command{
-	Use:   "off <server-id>",
+	Use:   "off [server-id]",
 	Short: "Power off a server",
-	Long:  "Power off the specified server through its BMC interface",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
+	Long: `Power off the specified server through its BMC interface.
 
+By default the power is cut immediately. Use --graceful to ask the OS to shut
+down instead, and --graceful --force to force the server off if it is still
+on after --timeout.`,
+	Example: `  bmc-cli server power off server-1 --force
+  bmc-cli server power off server-1 --graceful
+  bmc-cli server power off server-1 --graceful --force --timeout 2m
+  bmc-cli server power off server-1 --override-maintenance`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		client := client.New(GetConfig())
+		client.SetMaintenanceOverride(powerOverrideMaintenance)
 		ctx := context.Background()
 
-		fmt.Printf("Powering off server %s...\n", serverID)
+		if cmd.Flags().Changed("timeout") && !(powerOffGraceful && powerOffForce) {
+			return fmt.Errorf("--timeout requires --graceful and --force")
+		}
+		if powerOffTimeout < time.Second {
+			return fmt.Errorf("--timeout must be at least 1s")
+		}
+		if powerWait && powerOffGraceful {
+			return fmt.Errorf("--wait cannot be used with --graceful, the OS shuts down on its own")
+		}
+		if powerIdempotencyKey != "" && powerOffGraceful {
+			return fmt.Errorf("--idempotency-key cannot be used with --graceful")
+		}
+		if powerAsync && powerOffGraceful {
+			return fmt.Errorf("--async cannot be used with --graceful, the OS shuts down on its own")
+		}
 
-		if err := client.PowerOff(ctx, serverID); err != nil {
-			return fmt.Errorf("failed to power off server: %w", err)
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("Server %s powered off successfully\n", serverID)
+		if powerOffGraceful {
+			return gracefulShutdown(ctx, client, serverID)
+		}
+
+		if powerOffForce {
+			fmt.Println(messages.Sprintf("power.forcing_off", serverID))
+
+			if err := runPowerOperation(ctx, client, serverID, gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF, client.ForceOff, "power.forced_off"); err != nil {
+				return fmt.Errorf("failed to force off server: %w", err)
+			}
+			return nil
+		}
+
+		fmt.Println(messages.Sprintf("power.powering_off", serverID))
+
+		if err := runPowerOperation(ctx, client, serverID, gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF, client.PowerOff, "power.powered_off"); err != nil {
+			return fmt.Errorf("failed to power off server: %w", err)
+		}
 		return nil
 	},
 }
 
+// gracefulShutdown asks the OS of a server to shut down, forced after
+// --timeout with --force
+func gracefulShutdown(ctx context.Context, client *client.Client, serverID string) error {
+	var forceAfter time.Duration
+	if powerOffForce {
+		forceAfter = powerOffTimeout
+	}
+
+	fmt.Println(messages.Sprintf("power.shutting_down", serverID))
+
+	resp, err := client.GracefulShutdown(ctx, serverID, forceAfter)
+	if err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+
+	if resp.ForceAt != nil {
+		fmt.Println(messages.Sprintf("power.shutdown_forced_at", serverID, resp.ForceAt.AsTime().Local().Format("15:04:05")))
+	} else {
+		fmt.Println(messages.Sprintf("power.shutdown_requested", serverID))
+	}
+	return nil
+}
+
 var powerCycleCmd = &cobra.Command{
-	Use:   "cycle <server-id>",
-	Short: "Power cycle a server",
-	Long:  "Power cycle the specified server (power off then on) through its BMC interface",
-	Args:  cobra.ExactArgs(1),
+	Use:               "cycle [server-id]",
+	Short:             "Power cycle a server",
+	Long:              "Power cycle the specified server (power off then on) through its BMC interface",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
+		client.SetMaintenanceOverride(powerOverrideMaintenance)
 		ctx := context.Background()
 
-		fmt.Printf("Power cycling server %s...\n", serverID)
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
 
-		if err := client.PowerCycle(ctx, serverID); err != nil {
+		fmt.Println(messages.Sprintf("power.cycling", serverID))
+
+		if err := runPowerOperation(ctx, client, serverID, gatewayv1.PowerOperation_POWER_OPERATION_CYCLE, client.PowerCycle, "power.cycled"); err != nil {
 			return fmt.Errorf("failed to power cycle server: %w", err)
 		}
+		return nil
+	},
+}
+
+var powerDiagCmd = &cobra.Command{
+	Use:   "diag [server-id]",
+	Short: "Send a diagnostic interrupt (NMI) to a server",
+	Long: `Send a non-maskable interrupt to the specified server through its BMC interface.
+
+Most operating systems panic on an NMI and write a crash dump, which helps
+debugging a hung machine. Requires the power:diag permission, granted to the
+server's owner.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		client.SetMaintenanceOverride(powerOverrideMaintenance)
+		ctx := context.Background()
+
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(messages.Sprintf("power.sending_diagnostic_interrupt", serverID))
 
-		fmt.Printf("Server %s power cycled successfully\n", serverID)
+		client.SetIdempotencyKey(powerIdempotencyKey)
+		if err := client.DiagnosticInterrupt(ctx, serverID); err != nil {
+			return fmt.Errorf("failed to send diagnostic interrupt: %w", err)
+		}
+
+		fmt.Println(messages.Sprintf("power.diagnostic_interrupt_sent", serverID))
 		return nil
 	},
 }
 
 var powerStatusCmd = &cobra.Command{
-	Use:   "status <server-id>",
-	Short: "Get server power status",
-	Long:  "Get the current power status of the specified server",
-	Args:  cobra.ExactArgs(1),
+	Use:               "status [server-id]",
+	Short:             "Get server power status",
+	Long:              "Get the current power status of the specified server",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if watchRequested(cmd) {
+			return watchPowerStatus(cmd, client, formatter, serverID)
+		}
+
 		status, err := client.GetPowerStatus(ctx, serverID)
 		if err != nil {
 			return fmt.Errorf("failed to get power status: %w", err)
 		}
 
-		fmt.Printf("Server %s power status: %s\n", serverID, status)
-		return nil
+		return outputPowerStatus(formatter, serverID, status, false)
 	},
 }
 
+// outputPowerStatus prints a power state in the selected format, optionally
+// highlighting it as changed
+func outputPowerStatus(formatter *output.Formatter, serverID, status string, changed bool) error {
+	if formatter.IsStructured() {
+		return formatter.Output(map[string]interface{}{
+			"server_id":   serverID,
+			"power_state": status,
+		})
+	}
+
+	if formatter.IsTable() {
+		table := output.NewTable(
+			output.Column{Key: "id", Header: "SERVER ID"},
+			output.Column{Key: "power_state", Header: "POWER STATE"},
+		)
+		table.AddRow(serverID, status)
+		if changed {
+			table.Highlight(0)
+		}
+		return formatter.OutputTable(table)
+	}
+
+	if changed {
+		fmt.Println(messages.Sprintf("power.status", serverID, "\033[7m"+status+"\033[0m"))
+		return nil
+	}
+	fmt.Println(messages.Sprintf("power.status", serverID, status))
+	return nil
+}
+
+// watchPowerStatus polls the power state until interrupted.
+//
+// On a terminal the status is redrawn in place and highlighted when it
+// changes. Otherwise the initial state is printed once and a timestamped
+// line is appended for every transition.
+func watchPowerStatus(cmd *cobra.Command, bmcClient *client.Client, formatter *output.Formatter, serverID string) error {
+	tracker := watch.NewTracker()
+	redraw := watchRedraw() && !formatter.IsStructured()
+	previous := ""
+
+	return runWatch(cmd, func(ctx context.Context) error {
+		status, err := bmcClient.GetPowerStatus(ctx, serverID)
+		if err != nil {
+			return fmt.Errorf("failed to get power status: %w", err)
+		}
+
+		primed := tracker.Primed()
+		changes := tracker.Update(map[string]string{serverID: status})
+		defer func() { previous = status }()
+
+		if redraw {
+			watchHeader(cmd)
+			return outputPowerStatus(formatter, serverID, status, changes.Changed[serverID])
+		}
+
+		if !primed {
+			return outputPowerStatus(formatter, serverID, status, false)
+		}
+		if !changes.Any() {
+			return nil
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"server_id":      serverID,
+				"power_state":    status,
+				"previous_state": previous,
+				"changed_at":     time.Now().UTC(),
+			})
+		}
+		if formatter.IsTable() {
+			formatter.SetNoHeaders(true)
+			return outputPowerStatus(formatter, serverID, status, false)
+		}
+		fmt.Println(time.Now().Format("15:04:05"), messages.Sprintf("power.status_changed", serverID, previous, status))
+		return nil
+	})
+}
+
 var resetCmd = &cobra.Command{
-	Use:   "reset <server-id>",
-	Short: "Reset a server",
-	Long:  "Perform a hard reset on the specified server through its BMC interface",
-	Args:  cobra.ExactArgs(1),
+	Use:               "reset [server-id]",
+	Short:             "Reset a server",
+	Long:              "Perform a hard reset on the specified server through its BMC interface",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
+		client.SetMaintenanceOverride(powerOverrideMaintenance)
 		ctx := context.Background()
 
-		fmt.Printf("Resetting server %s...\n", serverID)
+		serverID, err := resolveServerIDForChange(ctx, client, args)
+		if err != nil {
+			return err
+		}
 
-		if err := client.Reset(ctx, serverID); err != nil {
+		fmt.Println(messages.Sprintf("power.resetting", serverID))
+
+		if err := runPowerOperation(ctx, client, serverID, gatewayv1.PowerOperation_POWER_OPERATION_RESET, client.Reset, "power.reset"); err != nil {
 			return fmt.Errorf("failed to reset server: %w", err)
 		}
-
-		fmt.Printf("Server %s reset successfully\n", serverID)
 		return nil
 	},
 }
@@ -131,5 +449,24 @@ func init() {
 	powerCmd.AddCommand(powerOnCmd)
 	powerCmd.AddCommand(powerOffCmd)
 	powerCmd.AddCommand(powerCycleCmd)
+	powerCmd.AddCommand(powerDiagCmd)
 	powerCmd.AddCommand(powerStatusCmd)
+	powerCmd.AddCommand(powerOperationsCmd)
+
+	powerOffCmd.Flags().BoolVar(&powerOffGraceful, "graceful", false, "Ask the OS to shut down instead of cutting the power")
+	powerOffCmd.Flags().BoolVar(&powerOffForce, "force", false, "Cut the power; with --graceful, only after --timeout")
+	powerOffCmd.Flags().DurationVar(&powerOffTimeout, "timeout", 5*time.Minute, "Time the OS has to shut down with --graceful --force")
+
+	for _, cmd := range []*cobra.Command{powerOnCmd, powerOffCmd, powerCycleCmd, resetCmd} {
+		addPowerWaitFlags(cmd)
+	}
+	for _, cmd := range []*cobra.Command{powerOffCmd, powerCycleCmd, powerDiagCmd, resetCmd} {
+		addMaintenanceOverrideFlag(cmd)
+	}
+	for _, cmd := range []*cobra.Command{powerOnCmd, powerOffCmd, powerCycleCmd, powerDiagCmd, resetCmd} {
+		addIdempotencyKeyFlag(cmd)
+	}
+
+	output.AddFormatFlag(powerStatusCmd)
+	addWatchFlags(powerStatusCmd)
 }
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/messages"
+	"cli/pkg/output"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+var powerOperationsCmd = &cobra.Command{
+	Use:   "operations [server-id] [operation-id]",
+	Short: "List or follow the power operations started with --async",
+	Long: `List the power operations of a server started with --async, most recent
+first, or show one of them. With --wait, follow the operation until the BMC
+completes it, showing its progress.
+
+Gateways keep the completed operations for an hour by default.`,
+	Example: `  bmc-cli server power cycle server-1 --async
+  bmc-cli server power operations server-1
+  bmc-cli server power operations server-1 op-3f2c7a9e5b1d4c8e --wait`,
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeServerIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if len(args) < 2 {
+			if powerWait {
+				return fmt.Errorf("--wait requires an operation ID")
+			}
+			operations, err := client.ListOperations(ctx, serverID)
+			if err != nil {
+				return fmt.Errorf("failed to list operations: %w", err)
+			}
+			return outputOperations(formatter, operations)
+		}
+
+		operationID := args[1]
+		if powerWait {
+			return waitOperation(ctx, client, serverID, operationID)
+		}
+
+		op, err := client.GetOperation(ctx, serverID, operationID)
+		if err != nil {
+			return fmt.Errorf("failed to get operation: %w", err)
+		}
+		return outputOperations(formatter, []*gatewayv1.Operation{op})
+	},
+}
+
+// waitOperation follows an operation until it completes or --wait-timeout,
+// showing its progress, and fails when the operation failed
+func waitOperation(ctx context.Context, bmcClient *client.Client, serverID, operationID string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, powerWaitTimeout)
+	defer cancel()
+
+	progress := output.NewProgress(os.Stdout, watchRedraw())
+	op, err := bmcClient.WatchOperation(ctx, serverID, operationID, func(op *gatewayv1.Operation) {
+		if op.Progress != nil {
+			updatePowerProgress(progress, op.Progress)
+		}
+	})
+	progress.Finish()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s, the operation is still running", powerWaitTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	if op.State == gatewayv1.OperationState_OPERATION_STATE_FAILED {
+		return fmt.Errorf("operation %s failed: %s", op.Id, op.Error)
+	}
+
+	fmt.Println(messages.Sprintf("power.operation_succeeded", op.Id, serverID))
+	return nil
+}
+
+// operationName returns the lower case name of an enum value without its
+// prefix, e.g. "cycle" for POWER_OPERATION_CYCLE
+func operationName(value fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value.String(), prefix))
+}
+
+// operationProgress describes the last progress of an operation
+func operationProgress(op *gatewayv1.Operation) string {
+	if op.Error != "" {
+		return op.Error
+	}
+	if op.Progress == nil {
+		return ""
+	}
+	progress := op.Progress.State
+	if op.Progress.PercentComplete >= 0 && op.Progress.TaskId != "" {
+		progress = fmt.Sprintf("%s %d%%", progress, op.Progress.PercentComplete)
+	}
+	if op.Progress.Message != "" {
+		progress += ": " + op.Progress.Message
+	}
+	return strings.TrimPrefix(progress, ": ")
+}
+
+// outputOperations prints operations in the selected format
+func outputOperations(formatter *output.Formatter, operations []*gatewayv1.Operation) error {
+	if formatter.IsStructured() {
+		data := make([]map[string]interface{}, 0, len(operations))
+		for _, op := range operations {
+			entry := map[string]interface{}{
+				"id":         op.Id,
+				"server_id":  op.ServerId,
+				"operation":  operationName(op.Operation, "POWER_OPERATION_"),
+				"state":      operationName(op.State, "OPERATION_STATE_"),
+				"progress":   operationProgress(op),
+				"started_at": op.StartedAt.AsTime(),
+			}
+			if op.CompletedAt != nil {
+				entry["completed_at"] = op.CompletedAt.AsTime()
+			}
+			data = append(data, entry)
+		}
+		return formatter.Output(map[string]interface{}{"operations": data})
+	}
+
+	if formatter.IsTable() {
+		table := output.NewTable(
+			output.Column{Key: "id", Header: "ID"},
+			output.Column{Key: "operation", Header: "OPERATION"},
+			output.Column{Key: "state", Header: "STATE"},
+			output.Column{Key: "started", Header: "STARTED"},
+			output.Column{Key: "progress", Header: "PROGRESS"},
+		)
+		for _, op := range operations {
+			table.AddRow(
+				op.Id,
+				operationName(op.Operation, "POWER_OPERATION_"),
+				operationName(op.State, "OPERATION_STATE_"),
+				op.StartedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+				operationProgress(op),
+			)
+		}
+		return formatter.OutputTable(table)
+	}
+
+	if len(operations) == 0 {
+		fmt.Println("No operations found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tOPERATION\tSTATE\tSTARTED\tPROGRESS")
+	for _, op := range operations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			op.Id,
+			operationName(op.Operation, "POWER_OPERATION_"),
+			operationName(op.State, "OPERATION_STATE_"),
+			op.StartedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+			operationProgress(op))
+	}
+	w.Flush()
+
+	return nil
+}
+
+func init() {
+	output.AddFormatFlag(powerOperationsCmd)
+	powerOperationsCmd.Flags().BoolVar(&powerWait, "wait", false, "Follow the operation until the BMC completes it, showing its progress")
+	powerOperationsCmd.Flags().DurationVar(&powerWaitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait with --wait")
+}
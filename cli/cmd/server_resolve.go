@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"cli/pkg/client"
+	"cli/pkg/picker"
+)
+
+// serverNameMetadataKeys are the metadata keys treated as server name aliases
+var serverNameMetadataKeys = []string{"name", "hostname"}
+
+// noInteractive disables the interactive server picker (set via --no-interactive)
+var noInteractive bool
+
+// assumeYes accepts the confirmation prompts of commands (set via --yes)
+var assumeYes bool
+
+// interactive reports whether prompts may be shown to the user
+func interactive() bool {
+	return !noInteractive && term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// resolveServerID maps the optional server reference in args to a concrete
+// server ID. The reference may be a full ID, a unique prefix, a metadata name,
+// or a fuzzy pattern. When no reference is given or it is ambiguous, an
+// interactive picker is shown unless --no-interactive is set or stdin is not
+// a terminal.
+func resolveServerID(ctx context.Context, bmcClient *client.Client, args []string) (string, error) {
+	return resolveServer(ctx, bmcClient, args, false)
+}
+
+// resolveServerIDForChange resolves the server reference of commands that
+// act on the server, such as power and console commands. A reference that is
+// not the server's exact ID must be confirmed, or accepted with --yes.
+func resolveServerIDForChange(ctx context.Context, bmcClient *client.Client, args []string) (string, error) {
+	return resolveServer(ctx, bmcClient, args, true)
+}
+
+func resolveServer(ctx context.Context, bmcClient *client.Client, args []string, confirmInexact bool) (string, error) {
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+
+	if query == "" && !interactive() {
+		return "", fmt.Errorf("server ID is required (interactive selection disabled)")
+	}
+
+	servers, err := bmcClient.ListServers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list servers to resolve %q: %w", query, err)
+	}
+
+	candidates := serverCandidates(servers)
+
+	if query != "" {
+		match, err := picker.Resolve(query, candidates)
+		if err == nil {
+			if match.ID == query {
+				return match.ID, nil
+			}
+			if confirmInexact {
+				if err := confirmResolution(query, match.ID); err != nil {
+					return "", err
+				}
+				return match.ID, nil
+			}
+			fmt.Fprintf(os.Stderr, "Resolved %q to server %s\n", query, match.ID)
+			return match.ID, nil
+		}
+		if errors.Is(err, picker.ErrNoMatch) {
+			// Unknown to the listing; the manager may still know it
+			return query, nil
+		}
+		if !interactive() {
+			return "", err
+		}
+	}
+
+	choice, err := picker.New(os.Stdin, os.Stderr).Pick("Select a server:", query, candidates)
+	if err != nil {
+		return "", err
+	}
+	return choice.ID, nil
+}
+
+// confirmResolution asks the user to confirm that query, which is not a
+// server ID, refers to the server serverID. It succeeds without asking with
+// --yes, and fails when prompts are disabled.
+func confirmResolution(query, serverID string) error {
+	if assumeYes {
+		fmt.Fprintf(os.Stderr, "Resolved %q to server %s\n", query, serverID)
+		return nil
+	}
+	if !interactive() {
+		return fmt.Errorf("%q is not a server ID, it matches server %s: use the full server ID or --yes", query, serverID)
+	}
+
+	fmt.Fprintf(os.Stderr, "%q matches server %s. Continue? [y/N] ", query, serverID)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("cancelled: %q was not confirmed as server %s", query, serverID)
+	}
+}
+
+// serverCandidates converts servers into picker candidates
+func serverCandidates(servers []client.ServerInfo) []picker.Candidate {
+	candidates := make([]picker.Candidate, 0, len(servers))
+	for _, server := range servers {
+		var aliases []string
+		for _, key := range serverNameMetadataKeys {
+			if name := server.Metadata[key]; name != "" {
+				aliases = append(aliases, name)
+			}
+		}
+
+		name := "-"
+		if len(aliases) > 0 {
+			name = strings.Join(aliases, ",")
+		}
+		description := []string{name, server.DatacenterID, server.Status}
+
+		candidates = append(candidates, picker.Candidate{
+			ID:          server.ID,
+			Aliases:     aliases,
+			Description: strings.Join(description, "\t"),
+		})
+	}
+	return candidates
+}
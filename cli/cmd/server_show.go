@@ -2,14 +2,19 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	"cli/pkg/client"
 	"cli/pkg/output"
+	"cli/pkg/watch"
 	"core/types"
 )
 
@@ -54,34 +59,40 @@ func formatDiscoveryMethod(method types.DiscoveryMethod) string {
 }
 
 var showCmd = &cobra.Command{
-	Use:   "show <server-id>",
-	Short: "Show server BMC information",
-	Long:  "Display detailed information about a server's BMC interface and capabilities",
-	Args:  cobra.ExactArgs(1),
+	Use:               "show [server-id]",
+	Short:             "Show server BMC information",
+	Long:              "Display detailed information about a server's BMC interface and capabilities",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServerIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		serverID := args[0]
-
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
+		serverID, err := resolveServerID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+
 		server, err := client.GetServer(ctx, serverID)
 		if err != nil {
 			return fmt.Errorf("failed to get server info: %w", err)
 		}
 
-		// Get output format
-		format, err := output.GetFormatFromCmd(cmd)
+		formatter, err := output.NewFromCmd(cmd)
 		if err != nil {
 			return err
 		}
 
-		formatter := output.New(format)
-
-		// If JSON format, output raw data and return
-		if formatter.IsJSON() {
+		// If JSON/YAML format, output raw data and return
+		if formatter.IsStructured() {
 			return formatter.Output(server)
 		}
 
+		// Table formats show the same summary row as server list
+		if formatter.IsTable() {
+			return formatter.OutputTable(serverTable(*server))
+		}
+
 		// Check if verbose metadata display is requested
 		showFullMetadata, _ := cmd.Flags().GetBool("metadata")
 
@@ -92,6 +103,12 @@ var showCmd = &cobra.Command{
 		fmt.Fprintf(w, "Server ID:\t%s\n", server.ID)
 		fmt.Fprintf(w, "Status:\t%s\n", server.Status)
 		fmt.Fprintf(w, "Datacenter:\t%s\n", server.DatacenterID)
+		if server.MaintenanceMode {
+			fmt.Fprintf(w, "Maintenance:\tyes (power operations are blocked)\n")
+		}
+		if server.Notes != "" {
+			fmt.Fprintf(w, "Notes:\t%s\n", server.Notes)
+		}
 
 		// Show discovery summary inline
 		if server.DiscoveryMetadata != nil {
@@ -230,149 +247,162 @@ var showCmd = &cobra.Command{
 		if showFullMetadata && server.DiscoveryMetadata != nil {
 			fmt.Fprintf(w, "\nBMC Discovery Metadata:\n")
 			fmt.Fprintf(w, "======================\n")
+			writeDiscoveryMetadata(w, server.DiscoveryMetadata)
+		}
 
-			// Discovery information
-			fmt.Fprintf(w, "\nDiscovery Information:\n")
-			if server.DiscoveryMetadata.DiscoveryMethod != "" {
-				fmt.Fprintf(w, "  Method:\t%s\n", formatDiscoveryMethod(server.DiscoveryMetadata.DiscoveryMethod))
-			}
-			if server.DiscoveryMetadata.DiscoverySource != "" {
-				fmt.Fprintf(w, "  Source:\t%s\n", server.DiscoveryMetadata.DiscoverySource)
-			}
-			if !server.DiscoveryMetadata.DiscoveredAt.IsZero() {
-				fmt.Fprintf(w, "  Discovered:\t%s\n", server.DiscoveryMetadata.DiscoveredAt.Format("2006-01-02 15:04:05"))
-			}
-			if server.DiscoveryMetadata.ConfigSource != "" {
-				fmt.Fprintf(w, "  Config File:\t%s\n", server.DiscoveryMetadata.ConfigSource)
-			}
+		w.Flush()
 
-			// Vendor information
-			if server.DiscoveryMetadata.Vendor != nil {
-				fmt.Fprintf(w, "\nVendor Information:\n")
-				if server.DiscoveryMetadata.Vendor.Manufacturer != "" {
-					fmt.Fprintf(w, "  Manufacturer:\t%s\n", server.DiscoveryMetadata.Vendor.Manufacturer)
-				}
-				if server.DiscoveryMetadata.Vendor.Model != "" {
-					fmt.Fprintf(w, "  Model:\t%s\n", server.DiscoveryMetadata.Vendor.Model)
-				}
-				if server.DiscoveryMetadata.Vendor.FirmwareVersion != "" {
-					fmt.Fprintf(w, "  Firmware:\t%s\n", server.DiscoveryMetadata.Vendor.FirmwareVersion)
-				}
-				if server.DiscoveryMetadata.Vendor.BMCVersion != "" {
-					fmt.Fprintf(w, "  BMC Version:\t%s\n", server.DiscoveryMetadata.Vendor.BMCVersion)
-				}
-			}
+		return nil
+	},
+}
 
-			// Protocol configuration
-			if server.DiscoveryMetadata.Protocol != nil {
-				fmt.Fprintf(w, "\nProtocol Configuration:\n")
-				if server.DiscoveryMetadata.Protocol.PrimaryProtocol != "" {
-					version := server.DiscoveryMetadata.Protocol.PrimaryVersion
-					if version != "" {
-						fmt.Fprintf(w, "  Primary:\t%s %s\n", server.DiscoveryMetadata.Protocol.PrimaryProtocol, version)
-					} else {
-						fmt.Fprintf(w, "  Primary:\t%s\n", server.DiscoveryMetadata.Protocol.PrimaryProtocol)
-					}
-				}
-				if server.DiscoveryMetadata.Protocol.FallbackProtocol != "" {
-					fmt.Fprintf(w, "  Fallback:\t%s\n", server.DiscoveryMetadata.Protocol.FallbackProtocol)
-					if server.DiscoveryMetadata.Protocol.FallbackReason != "" {
-						fmt.Fprintf(w, "  Fallback Reason:\t%s\n", server.DiscoveryMetadata.Protocol.FallbackReason)
-					}
-				}
-				if server.DiscoveryMetadata.Protocol.ConsoleType != "" {
-					fmt.Fprintf(w, "  Console Type:\t%s\n", server.DiscoveryMetadata.Protocol.ConsoleType)
-				}
-				if server.DiscoveryMetadata.Protocol.ConsolePath != "" {
-					fmt.Fprintf(w, "  Console Path:\t%s\n", server.DiscoveryMetadata.Protocol.ConsolePath)
-				}
-				if server.DiscoveryMetadata.Protocol.VNCTransport != "" {
-					fmt.Fprintf(w, "  VNC Transport:\t%s\n", server.DiscoveryMetadata.Protocol.VNCTransport)
-				}
-			}
+// writeDiscoveryMetadata writes everything the agent reported of how it
+// discovered a server's BMC, section by section
+func writeDiscoveryMetadata(w io.Writer, dm *types.DiscoveryMetadata) {
+	// Discovery information
+	fmt.Fprintf(w, "\nDiscovery Information:\n")
+	if dm.DiscoveryMethod != "" {
+		fmt.Fprintf(w, "  Method:\t%s\n", formatDiscoveryMethod(dm.DiscoveryMethod))
+	}
+	if dm.DiscoverySource != "" {
+		fmt.Fprintf(w, "  Source:\t%s\n", dm.DiscoverySource)
+	}
+	if !dm.DiscoveredAt.IsZero() {
+		fmt.Fprintf(w, "  Discovered:\t%s\n", dm.DiscoveredAt.Format("2006-01-02 15:04:05"))
+	}
+	if dm.ConfigSource != "" {
+		fmt.Fprintf(w, "  Config File:\t%s\n", dm.ConfigSource)
+	}
 
-			// Endpoint details
-			if server.DiscoveryMetadata.Endpoints != nil {
-				fmt.Fprintf(w, "\nEndpoint Details:\n")
-				if server.DiscoveryMetadata.Endpoints.ControlEndpoint != "" {
-					fmt.Fprintf(w, "  Control:\t%s", server.DiscoveryMetadata.Endpoints.ControlEndpoint)
-					if server.DiscoveryMetadata.Endpoints.ControlScheme != "" {
-						fmt.Fprintf(w, " (%s)", server.DiscoveryMetadata.Endpoints.ControlScheme)
-					}
-					fmt.Fprintf(w, "\n")
-				}
-				if server.DiscoveryMetadata.Endpoints.ConsoleEndpoint != "" {
-					fmt.Fprintf(w, "  Console:\t%s\n", server.DiscoveryMetadata.Endpoints.ConsoleEndpoint)
-				}
-				if server.DiscoveryMetadata.Endpoints.VNCEndpoint != "" {
-					fmt.Fprintf(w, "  VNC:\t%s\n", server.DiscoveryMetadata.Endpoints.VNCEndpoint)
-				}
-			}
+	// Vendor information
+	if dm.Vendor != nil {
+		fmt.Fprintf(w, "\nVendor Information:\n")
+		if dm.Vendor.Manufacturer != "" {
+			fmt.Fprintf(w, "  Manufacturer:\t%s\n", dm.Vendor.Manufacturer)
+		}
+		if dm.Vendor.Model != "" {
+			fmt.Fprintf(w, "  Model:\t%s\n", dm.Vendor.Model)
+		}
+		if dm.Vendor.FirmwareVersion != "" {
+			fmt.Fprintf(w, "  Firmware:\t%s\n", dm.Vendor.FirmwareVersion)
+		}
+		if dm.Vendor.BMCVersion != "" {
+			fmt.Fprintf(w, "  BMC Version:\t%s\n", dm.Vendor.BMCVersion)
+		}
+	}
 
-			// Security configuration
-			if server.DiscoveryMetadata.Security != nil {
-				fmt.Fprintf(w, "\nSecurity Configuration:\n")
-				fmt.Fprintf(w, "  TLS Enabled:\t%t\n", server.DiscoveryMetadata.Security.TLSEnabled)
-				if server.DiscoveryMetadata.Security.TLSEnabled {
-					fmt.Fprintf(w, "  TLS Verify:\t%t\n", server.DiscoveryMetadata.Security.TLSVerify)
-				}
-				if server.DiscoveryMetadata.Security.AuthMethod != "" {
-					fmt.Fprintf(w, "  Auth Method:\t%s\n", server.DiscoveryMetadata.Security.AuthMethod)
-				}
-				if server.DiscoveryMetadata.Security.VNCAuthType != "" {
-					fmt.Fprintf(w, "  VNC Auth:\t%s", server.DiscoveryMetadata.Security.VNCAuthType)
-					if server.DiscoveryMetadata.Security.VNCPasswordLength > 0 {
-						fmt.Fprintf(w, " (%d chars)", server.DiscoveryMetadata.Security.VNCPasswordLength)
-					}
-					fmt.Fprintf(w, "\n")
-				}
+	// Protocol configuration
+	if dm.Protocol != nil {
+		fmt.Fprintf(w, "\nProtocol Configuration:\n")
+		if dm.Protocol.PrimaryProtocol != "" {
+			version := dm.Protocol.PrimaryVersion
+			if version != "" {
+				fmt.Fprintf(w, "  Primary:\t%s %s\n", dm.Protocol.PrimaryProtocol, version)
+			} else {
+				fmt.Fprintf(w, "  Primary:\t%s\n", dm.Protocol.PrimaryProtocol)
 			}
-
-			// Network information
-			if server.DiscoveryMetadata.Network != nil {
-				fmt.Fprintf(w, "\nNetwork Information:\n")
-				if server.DiscoveryMetadata.Network.IPAddress != "" {
-					fmt.Fprintf(w, "  IP Address:\t%s\n", server.DiscoveryMetadata.Network.IPAddress)
-				}
-				if server.DiscoveryMetadata.Network.MACAddress != "" {
-					fmt.Fprintf(w, "  MAC Address:\t%s\n", server.DiscoveryMetadata.Network.MACAddress)
-				}
-				fmt.Fprintf(w, "  Reachable:\t%t\n", server.DiscoveryMetadata.Network.Reachable)
-				if server.DiscoveryMetadata.Network.LatencyMs > 0 {
-					fmt.Fprintf(w, "  Latency:\t%dms\n", server.DiscoveryMetadata.Network.LatencyMs)
-				}
+		}
+		if dm.Protocol.FallbackProtocol != "" {
+			fmt.Fprintf(w, "  Fallback:\t%s\n", dm.Protocol.FallbackProtocol)
+			if dm.Protocol.FallbackReason != "" {
+				fmt.Fprintf(w, "  Fallback Reason:\t%s\n", dm.Protocol.FallbackReason)
 			}
+		}
+		if dm.Protocol.ConsoleType != "" {
+			fmt.Fprintf(w, "  Console Type:\t%s\n", dm.Protocol.ConsoleType)
+		}
+		if dm.Protocol.ConsolePath != "" {
+			fmt.Fprintf(w, "  Console Path:\t%s\n", dm.Protocol.ConsolePath)
+		}
+		if dm.Protocol.VNCTransport != "" {
+			fmt.Fprintf(w, "  VNC Transport:\t%s\n", dm.Protocol.VNCTransport)
+		}
+	}
 
-			// Capabilities
-			if server.DiscoveryMetadata.Capabilities != nil {
-				if len(server.DiscoveryMetadata.Capabilities.SupportedFeatures) > 0 {
-					fmt.Fprintf(w, "\nCapabilities:\n")
-					fmt.Fprintf(w, "  Supported:\t%v\n", server.DiscoveryMetadata.Capabilities.SupportedFeatures)
-				}
-				if len(server.DiscoveryMetadata.Capabilities.UnsupportedFeatures) > 0 {
-					fmt.Fprintf(w, "  Unsupported:\t%v\n", server.DiscoveryMetadata.Capabilities.UnsupportedFeatures)
-				}
-				if len(server.DiscoveryMetadata.Capabilities.DiscoveryErrors) > 0 {
-					fmt.Fprintf(w, "  Errors:\t%v\n", server.DiscoveryMetadata.Capabilities.DiscoveryErrors)
-				}
-				if len(server.DiscoveryMetadata.Capabilities.DiscoveryWarnings) > 0 {
-					fmt.Fprintf(w, "  Warnings:\t%v\n", server.DiscoveryMetadata.Capabilities.DiscoveryWarnings)
-				}
+	// Endpoint details
+	if dm.Endpoints != nil {
+		fmt.Fprintf(w, "\nEndpoint Details:\n")
+		if dm.Endpoints.ControlEndpoint != "" {
+			fmt.Fprintf(w, "  Control:\t%s", dm.Endpoints.ControlEndpoint)
+			if dm.Endpoints.ControlScheme != "" {
+				fmt.Fprintf(w, " (%s)", dm.Endpoints.ControlScheme)
 			}
+			fmt.Fprintf(w, "\n")
+		}
+		if dm.Endpoints.ConsoleEndpoint != "" {
+			fmt.Fprintf(w, "  Console:\t%s\n", dm.Endpoints.ConsoleEndpoint)
+		}
+		if dm.Endpoints.VNCEndpoint != "" {
+			fmt.Fprintf(w, "  VNC:\t%s\n", dm.Endpoints.VNCEndpoint)
+		}
+	}
 
-			// Additional info
-			if len(server.DiscoveryMetadata.AdditionalInfo) > 0 {
-				fmt.Fprintf(w, "\nAdditional Information:\n")
-				for key, value := range server.DiscoveryMetadata.AdditionalInfo {
-					fmt.Fprintf(w, "  %s:\t%s\n", key, value)
-				}
+	// Security configuration
+	if dm.Security != nil {
+		fmt.Fprintf(w, "\nSecurity Configuration:\n")
+		fmt.Fprintf(w, "  TLS Enabled:\t%t\n", dm.Security.TLSEnabled)
+		if dm.Security.TLSEnabled {
+			fmt.Fprintf(w, "  TLS Verify:\t%t\n", dm.Security.TLSVerify)
+		}
+		if dm.Security.AuthMethod != "" {
+			fmt.Fprintf(w, "  Auth Method:\t%s\n", dm.Security.AuthMethod)
+		}
+		if dm.Security.VNCAuthType != "" {
+			fmt.Fprintf(w, "  VNC Auth:\t%s", dm.Security.VNCAuthType)
+			if dm.Security.VNCPasswordLength > 0 {
+				fmt.Fprintf(w, " (%d chars)", dm.Security.VNCPasswordLength)
 			}
+			fmt.Fprintf(w, "\n")
 		}
+	}
 
-		w.Flush()
+	// Network information
+	if dm.Network != nil {
+		fmt.Fprintf(w, "\nNetwork Information:\n")
+		if dm.Network.Hostname != "" {
+			fmt.Fprintf(w, "  Hostname:\t%s\n", dm.Network.Hostname)
+		}
+		if dm.Network.IPAddress != "" {
+			fmt.Fprintf(w, "  IP Address:\t%s\n", dm.Network.IPAddress)
+		}
+		if dm.Network.MACAddress != "" {
+			fmt.Fprintf(w, "  MAC Address:\t%s\n", dm.Network.MACAddress)
+		}
+		fmt.Fprintf(w, "  Reachable:\t%t\n", dm.Network.Reachable)
+		if dm.Network.LatencyMs > 0 {
+			fmt.Fprintf(w, "  Latency:\t%dms\n", dm.Network.LatencyMs)
+		}
+	}
 
-		return nil
-	},
+	// Capabilities
+	if dm.Capabilities != nil {
+		if len(dm.Capabilities.SupportedFeatures) > 0 {
+			fmt.Fprintf(w, "\nCapabilities:\n")
+			fmt.Fprintf(w, "  Supported:\t%v\n", dm.Capabilities.SupportedFeatures)
+		}
+		if len(dm.Capabilities.UnsupportedFeatures) > 0 {
+			fmt.Fprintf(w, "  Unsupported:\t%v\n", dm.Capabilities.UnsupportedFeatures)
+		}
+		if len(dm.Capabilities.DiscoveryErrors) > 0 {
+			fmt.Fprintf(w, "  Errors:\t%v\n", dm.Capabilities.DiscoveryErrors)
+		}
+		if len(dm.Capabilities.DiscoveryWarnings) > 0 {
+			fmt.Fprintf(w, "  Warnings:\t%v\n", dm.Capabilities.DiscoveryWarnings)
+		}
+	}
+
+	// Additional info
+	if len(dm.AdditionalInfo) > 0 {
+		fmt.Fprintf(w, "\nAdditional Information:\n")
+		keys := make([]string, 0, len(dm.AdditionalInfo))
+		for key := range dm.AdditionalInfo {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(w, "  %s:\t%s\n", key, dm.AdditionalInfo[key])
+		}
+	}
 }
 
 var listCmd = &cobra.Command{
@@ -383,58 +413,229 @@ var listCmd = &cobra.Command{
 		client := client.New(GetConfig())
 		ctx := context.Background()
 
-		servers, err := client.ListServers(ctx)
+		formatter, err := output.NewFromCmd(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to list servers: %w", err)
+			return err
 		}
 
-		// Get output format
-		format, err := output.GetFormatFromCmd(cmd)
+		if watchRequested(cmd) {
+			return watchServerList(cmd, client, formatter)
+		}
+
+		servers, err := listServers(ctx, cmd, client)
 		if err != nil {
 			return err
 		}
 
-		formatter := output.New(format)
-
-		// If JSON format, output raw data and return
-		if formatter.IsJSON() {
+		// If JSON/YAML format, output raw data and return
+		if formatter.IsStructured() {
 			return formatter.Output(servers)
 		}
 
-		// Text format - use existing tabwriter formatting
 		if len(servers) == 0 {
 			fmt.Println("No servers found")
 			return nil
 		}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(w, "SERVER ID\tBMC TYPE\tSTATUS\tDATACENTER\tCONSOLE\tVNC\n")
+		return formatter.OutputTable(serverTable(servers...))
+	},
+}
+
+// watchServerList refreshes the server list until interrupted.
+//
+// On a terminal the table is redrawn in place with changed rows highlighted.
+// Otherwise the full table is printed once and only changed rows are
+// appended afterwards. JSON/YAML output emits a new document on every change.
+func watchServerList(cmd *cobra.Command, bmcClient *client.Client, formatter *output.Formatter) error {
+	tracker := watch.NewTracker()
+	redraw := watchRedraw() && !formatter.IsStructured()
+
+	return runWatch(cmd, func(ctx context.Context) error {
+		servers, err := listServers(ctx, cmd, bmcClient)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			encoded, err := json.Marshal(servers)
+			if err != nil {
+				return err
+			}
+			primed := tracker.Primed()
+			if changes := tracker.Update(map[string]string{"servers": string(encoded)}); !primed || changes.Any() {
+				return formatter.Output(servers)
+			}
+			return nil
+		}
+
+		table := serverTable(servers...)
+		current := make(map[string]string, table.Len())
+		for _, row := range table.Rows() {
+			current[row[0]] = strings.Join(row, "\t")
+		}
+		primed := tracker.Primed()
+		changes := tracker.Update(current)
+
+		if redraw {
+			for i, row := range table.Rows() {
+				if changes.Changed[row[0]] {
+					table.Highlight(i)
+				}
+			}
+			watchHeader(cmd)
+			if table.Len() == 0 {
+				fmt.Println("No servers found")
+			} else if err := formatter.OutputTable(table); err != nil {
+				return err
+			}
+			if len(changes.Removed) > 0 {
+				sort.Strings(changes.Removed)
+				fmt.Printf("\nRemoved: %s\n", strings.Join(changes.Removed, ", "))
+			}
+			return nil
+		}
+
+		if !primed {
+			return formatter.OutputTable(table)
+		}
+
+		var changed []client.ServerInfo
 		for _, server := range servers {
-			// Determine BMC type from control endpoint
-			bmcType := "N/A"
-			if server.GetPrimaryControlEndpoint() != nil {
-				bmcType = formatBMCType(server.GetPrimaryControlEndpoint().Type)
+			if changes.Changed[server.ID] {
+				changed = append(changed, server)
+			}
+		}
+		if len(changed) > 0 {
+			formatter.SetNoHeaders(true)
+			if err := formatter.OutputTable(serverTable(changed...)); err != nil {
+				return err
 			}
+		}
+		sort.Strings(changes.Removed)
+		for _, serverID := range changes.Removed {
+			fmt.Printf("%s removed\n", serverID)
+		}
+		return nil
+	})
+}
+
+// listServers lists the servers visible to the current user, restricted to
+// the datacenter given by --datacenter, with their power state when --power
+// is given
+func listServers(ctx context.Context, cmd *cobra.Command, bmcClient *client.Client) ([]client.ServerInfo, error) {
+	listServers := bmcClient.ListServers
+	if power, _ := cmd.Flags().GetBool("power"); power {
+		listServers = bmcClient.ListServersWithPowerState
+	}
+	servers, err := listServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	datacenterID, _ := cmd.Flags().GetString("datacenter")
+	if datacenterID == "" {
+		return servers, nil
+	}
 
-			// Check console availability (SOL)
-			consoleAvailable := "N/A"
-			if server.SOLEndpoint != nil {
-				consoleAvailable = "✓"
+	var filtered []client.ServerInfo
+	for _, server := range servers {
+		if server.DatacenterID == datacenterID {
+			filtered = append(filtered, server)
+		}
+	}
+	return filtered, nil
+}
+
+// serverTable builds the table used by server list and server show. The
+// POWER column is shown when power states were listed.
+func serverTable(servers ...client.ServerInfo) *output.Table {
+	showPower := false
+	for _, server := range servers {
+		if server.PowerState != "" {
+			showPower = true
+			break
+		}
+	}
+
+	columns := []output.Column{
+		{Key: "id", Header: "SERVER ID"},
+		{Key: "bmc_type", Header: "BMC TYPE"},
+		{Key: "status", Header: "STATUS"},
+	}
+	if showPower {
+		columns = append(columns, output.Column{Key: "power_state", Header: "POWER"})
+	}
+	columns = append(columns,
+		output.Column{Key: "datacenter", Header: "DATACENTER"},
+		output.Column{Key: "console", Header: "CONSOLE"},
+		output.Column{Key: "vnc", Header: "VNC"},
+		output.Column{Key: "name", Header: "NAME", Wide: true},
+		output.Column{Key: "endpoint", Header: "ENDPOINT", Wide: true},
+		output.Column{Key: "protocols", Header: "PROTOCOLS", Wide: true},
+		output.Column{Key: "features", Header: "FEATURES", Wide: true},
+	)
+	table := output.NewTable(columns...)
+
+	for _, server := range servers {
+		// Determine BMC type and endpoint from the primary control endpoint
+		bmcType := "N/A"
+		endpoint := "-"
+		if primary := server.GetPrimaryControlEndpoint(); primary != nil {
+			bmcType = formatBMCType(primary.Type)
+			if primary.Endpoint != "" {
+				endpoint = primary.Endpoint
 			}
+		}
 
-			// Check VNC availability
-			vncAvailable := "N/A"
-			if server.VNCEndpoint != nil {
-				vncAvailable = "✓"
+		// Check console availability (SOL)
+		consoleAvailable := "N/A"
+		if server.SOLEndpoint != nil {
+			consoleAvailable = "✓"
+		}
+
+		// Check VNC availability
+		vncAvailable := "N/A"
+		if server.VNCEndpoint != nil {
+			vncAvailable = "✓"
+		}
+
+		name := "-"
+		for _, key := range serverNameMetadataKeys {
+			if value := server.Metadata[key]; value != "" {
+				name = value
+				break
 			}
+		}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-				server.ID, bmcType, server.Status, server.DatacenterID, consoleAvailable, vncAvailable)
+		protocols := make([]string, 0, len(server.ControlEndpoints))
+		for _, controlEndpoint := range server.ControlEndpoints {
+			protocols = append(protocols, formatBMCType(controlEndpoint.Type))
 		}
-		w.Flush()
 
-		return nil
-	},
+		row := []string{server.ID, bmcType, server.Status}
+		if showPower {
+			row = append(row, valueOrDash(server.PowerState))
+		}
+		table.AddRow(append(row,
+			server.DatacenterID,
+			consoleAvailable,
+			vncAvailable,
+			name,
+			endpoint,
+			joinOrDash(protocols),
+			joinOrDash(server.Features),
+		)...)
+	}
+
+	return table
+}
+
+// joinOrDash joins values with commas, returning "-" for an empty list
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
 }
 
 func init() {
@@ -445,6 +646,16 @@ func init() {
 	output.AddFormatFlag(showCmd)
 	output.AddFormatFlag(listCmd)
 
+	// Add watch flags to refresh the list periodically
+	addWatchFlags(listCmd)
+
+	// Add datacenter filter to the list command
+	listCmd.Flags().String("datacenter", "", "Only list servers in this datacenter")
+	listCmd.RegisterFlagCompletionFunc("datacenter", completeDatacenters)
+
+	// Add power flag to show the live power state of each server
+	listCmd.Flags().Bool("power", false, "Show the power state of each server, queried from the gateways")
+
 	// Add metadata flag to show full discovery metadata
 	showCmd.Flags().Bool("metadata", false, "Show full discovery metadata details")
 }
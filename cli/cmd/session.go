@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+
+	"cli/pkg/client"
+	"cli/pkg/output"
+)
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Console session administration commands",
+	Long:  "Commands for inspecting and terminating the console sessions open on the regional gateways (requires an admin account)",
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List open console sessions",
+	Long: `List the SOL and VNC console sessions open on the regional gateways, with
+their owner and the clients currently attached. IDLE is the time since a
+client last sent console input or attached to the session.
+
+All gateways registered with the BMC Manager are queried. Use --gateway to
+query a single gateway by ID.`,
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		gatewayID, _ := cmd.Flags().GetString("gateway")
+		serverID, _ := cmd.Flags().GetString("server")
+		customerID, _ := cmd.Flags().GetString("customer")
+
+		sessions, failures, err := client.ListConsoleSessions(ctx, &gatewayv1.ListConsoleSessionsRequest{
+			ServerId:   serverID,
+			CustomerId: customerID,
+		}, gatewayID)
+		if err != nil {
+			return fmt.Errorf("failed to list console sessions: %w", err)
+		}
+
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "Warning: gateway %s could not be queried: %v\n", failure.GatewayID, failure.Err)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Handle JSON/YAML output formats
+		if formatter.IsStructured() {
+			return outputConsoleSessionsData(formatter, sessions)
+		}
+
+		if formatter.IsTable() {
+			return formatter.OutputTable(consoleSessionsTable(sessions))
+		}
+
+		// Default text output
+		if len(sessions) == 0 {
+			fmt.Println("No open console sessions")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SESSION ID\tTYPE\tSERVER\tCUSTOMER\tGATEWAY\tAGE\tIDLE\tCLIENTS")
+		for _, s := range sessions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				s.Session.SessionId,
+				strings.ToUpper(s.Session.Type),
+				s.Session.ServerId,
+				sessionOwner(s.Session),
+				s.GatewayID,
+				time.Since(s.Session.CreatedAt.AsTime()).Round(time.Second),
+				sessionIdle(s.Session),
+				sessionClients(s.Session))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var sessionTerminateCmd = &cobra.Command{
+	Use:   "terminate <session-id>",
+	Short: "Forcibly close a console session",
+	Long: `Close a console session and disconnect every client attached to it. The
+clients are shown a notice including the optional --reason.
+
+All gateways registered with the BMC Manager are tried until one holds the
+session. Use --gateway to target a single gateway by ID.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessionID := args[0]
+		client := client.New(GetConfig())
+		ctx := context.Background()
+
+		gatewayID, _ := cmd.Flags().GetString("gateway")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		gatewayID, disconnected, err := client.TerminateConsoleSession(ctx, sessionID, reason, gatewayID)
+		if err != nil {
+			return fmt.Errorf("failed to terminate console session: %w", err)
+		}
+
+		formatter, err := output.NewFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		if formatter.IsStructured() {
+			return formatter.Output(map[string]interface{}{
+				"session_id":           sessionID,
+				"gateway_id":           gatewayID,
+				"disconnected_streams": disconnected,
+			})
+		}
+
+		fmt.Printf("Terminated console session %s on gateway %s (%d client(s) disconnected)\n", sessionID, gatewayID, disconnected)
+		return nil
+	},
+}
+
+// consoleSessionsTable lists one console session per row
+func consoleSessionsTable(sessions []client.GatewayConsoleSession) *output.Table {
+	table := output.NewTable(
+		output.Column{Key: "id", Header: "SESSION ID"},
+		output.Column{Key: "type", Header: "TYPE"},
+		output.Column{Key: "server", Header: "SERVER"},
+		output.Column{Key: "customer", Header: "CUSTOMER"},
+		output.Column{Key: "gateway", Header: "GATEWAY"},
+		output.Column{Key: "age", Header: "AGE"},
+		output.Column{Key: "idle", Header: "IDLE"},
+		output.Column{Key: "clients", Header: "CLIENTS"},
+		output.Column{Key: "region", Header: "REGION", Wide: true},
+		output.Column{Key: "agent", Header: "AGENT", Wide: true},
+		output.Column{Key: "expires", Header: "EXPIRES", Wide: true},
+	)
+
+	for _, s := range sessions {
+		table.AddRow(
+			s.Session.SessionId,
+			strings.ToUpper(s.Session.Type),
+			s.Session.ServerId,
+			sessionOwner(s.Session),
+			s.GatewayID,
+			time.Since(s.Session.CreatedAt.AsTime()).Round(time.Second).String(),
+			sessionIdle(s.Session),
+			sessionClients(s.Session),
+			s.Region,
+			valueOrUnknown(s.Session.AgentId),
+			s.Session.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+		)
+	}
+	return table
+}
+
+func outputConsoleSessionsData(formatter *output.Formatter, sessions []client.GatewayConsoleSession) error {
+	data := make([]map[string]interface{}, 0, len(sessions))
+	for _, s := range sessions {
+		streams := make([]map[string]interface{}, 0, len(s.Session.Streams))
+		for _, stream := range s.Session.Streams {
+			streams = append(streams, map[string]interface{}{
+				"client_address": stream.ClientAddress,
+				"transport":      stream.Transport,
+				"attached_at":    stream.AttachedAt.AsTime(),
+			})
+		}
+
+		data = append(data, map[string]interface{}{
+			"session_id":       s.Session.SessionId,
+			"type":             s.Session.Type,
+			"server_id":        s.Session.ServerId,
+			"customer_id":      s.Session.CustomerId,
+			"customer_email":   s.Session.CustomerEmail,
+			"impersonated_by":  s.Session.ImpersonatedBy,
+			"agent_id":         s.Session.AgentId,
+			"gateway_id":       s.GatewayID,
+			"region":           s.Region,
+			"created_at":       s.Session.CreatedAt.AsTime(),
+			"expires_at":       s.Session.ExpiresAt.AsTime(),
+			"last_activity_at": s.Session.LastActivityAt.AsTime(),
+			"streams":          streams,
+		})
+	}
+
+	return formatter.Output(map[string]interface{}{"sessions": data})
+}
+
+// sessionOwner prefers the owner's email over their customer ID, and names
+// the admin who opened the session on the owner's behalf
+func sessionOwner(session *gatewayv1.ConsoleSessionInfo) string {
+	owner := session.CustomerId
+	if session.CustomerEmail != "" {
+		owner = session.CustomerEmail
+	}
+	if session.ImpersonatedBy != "" {
+		owner += " (by " + session.ImpersonatedBy + ")"
+	}
+	return owner
+}
+
+// sessionIdle returns how long ago a client last used the session, "-" for
+// gateways not reporting it
+func sessionIdle(session *gatewayv1.ConsoleSessionInfo) string {
+	if session.LastActivityAt == nil {
+		return "-"
+	}
+	return time.Since(session.LastActivityAt.AsTime()).Round(time.Second).String()
+}
+
+// sessionClients summarizes the attached clients, e.g. "10.0.0.5:51234 (connect)"
+func sessionClients(session *gatewayv1.ConsoleSessionInfo) string {
+	if len(session.Streams) == 0 {
+		return "none"
+	}
+	clients := make([]string, 0, len(session.Streams))
+	for _, stream := range session.Streams {
+		clients = append(clients, fmt.Sprintf("%s (%s)", stream.ClientAddress, stream.Transport))
+	}
+	return strings.Join(clients, ", ")
+}
+
+func init() {
+	output.AddFormatFlag(sessionListCmd)
+	sessionListCmd.Flags().String("gateway", "", "Only query the gateway with this ID")
+	sessionListCmd.Flags().String("server", "", "Only list sessions of this server")
+	sessionListCmd.Flags().String("customer", "", "Only list sessions owned by this customer ID")
+	sessionListCmd.RegisterFlagCompletionFunc("gateway", completeGatewayIDs)
+	sessionListCmd.RegisterFlagCompletionFunc("server", completeServerIDs)
+
+	output.AddFormatFlag(sessionTerminateCmd)
+	sessionTerminateCmd.Flags().String("gateway", "", "Gateway holding the session")
+	sessionTerminateCmd.Flags().String("reason", "", "Reason shown to the disconnected clients")
+	sessionTerminateCmd.RegisterFlagCompletionFunc("gateway", completeGatewayIDs)
+
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionTerminateCmd)
+	rootCmd.AddCommand(sessionCmd)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"cli/pkg/watch"
+)
+
+// addWatchFlags adds the --watch and --interval flags to a command
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "Watch for changes and refresh the output until interrupted")
+	cmd.Flags().Duration("interval", watch.DefaultInterval, "Refresh interval in watch mode")
+}
+
+// watchRequested reports whether --watch was set on the command
+func watchRequested(cmd *cobra.Command) bool {
+	watching, _ := cmd.Flags().GetBool("watch")
+	return watching
+}
+
+// watchInterval returns the --interval flag value
+func watchInterval(cmd *cobra.Command) time.Duration {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	return interval
+}
+
+// runWatch calls refresh at the configured interval until Ctrl+C
+func runWatch(cmd *cobra.Command, refresh func(ctx context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return watch.Run(ctx, watchInterval(cmd), os.Stderr, refresh)
+}
+
+// watchRedraw reports whether watch mode should redraw the screen in place.
+// When stdout is not a terminal, only changes are appended instead.
+func watchRedraw() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// watchHeader clears the screen and prints the watch header for cmd
+func watchHeader(cmd *cobra.Command) {
+	watch.Redraw(os.Stdout, cmd.CommandPath(), watchInterval(cmd))
+}
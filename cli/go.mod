@@ -17,6 +17,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/net v0.44.0
 	golang.org/x/term v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
 	manager v0.0.0-00010101000000-000000000000
 )
 
@@ -37,5 +38,4 @@ require (
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+	"manager/gen/manager/v1/managerv1connect"
+
+	"cli/pkg/config"
+)
+
+// mockAgentGateway answers GetAgentStatus for a fixed set of agents
+type mockAgentGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	gatewayID  string
+	agents     map[string]bool
+	authHeader string
+}
+
+func (g *mockAgentGateway) GetAgentStatus(
+	_ context.Context,
+	req *connect.Request[gatewayv1.GetAgentStatusRequest],
+) (*connect.Response[gatewayv1.GetAgentStatusResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	if !g.agents[req.Msg.AgentId] {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", req.Msg.AgentId))
+	}
+	return connect.NewResponse(&gatewayv1.GetAgentStatusResponse{
+		Agent: &gatewayv1.AgentStatus{
+			AgentId:   req.Msg.AgentId,
+			GatewayId: g.gatewayID,
+		},
+	}), nil
+}
+
+// mockGatewayDirectory lists the configured gateway endpoints
+type mockGatewayDirectory struct {
+	managerv1connect.UnimplementedBMCManagerServiceHandler
+	endpoints []string
+}
+
+func (m *mockGatewayDirectory) ListGateways(
+	_ context.Context,
+	_ *connect.Request[managerv1.ListGatewaysRequest],
+) (*connect.Response[managerv1.ListGatewaysResponse], error) {
+	resp := &managerv1.ListGatewaysResponse{}
+	for i, endpoint := range m.endpoints {
+		resp.Gateways = append(resp.Gateways, &managerv1.RegionalGateway{
+			Id:       fmt.Sprintf("gateway-%d", i+1),
+			Endpoint: endpoint,
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// newH2CServer serves handler over HTTP/2 cleartext like the real gateway
+func newH2CServer(t *testing.T, path string, handler http.Handler) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_GetAgentStatus(t *testing.T) {
+	gateway1 := &mockAgentGateway{gatewayID: "gateway-1", agents: map[string]bool{}}
+	gateway2 := &mockAgentGateway{gatewayID: "gateway-2", agents: map[string]bool{"agent-dc1": true}}
+
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(gateway1)
+	gw1Server := newH2CServer(t, path, handler)
+	path, handler = gatewayv1connect.NewGatewayServiceHandler(gateway2)
+	gw2Server := newH2CServer(t, path, handler)
+
+	path, handler = managerv1connect.NewBMCManagerServiceHandler(&mockGatewayDirectory{
+		endpoints: []string{gw1Server.URL, gw2Server.URL},
+	})
+	managerServer := newH2CServer(t, path, handler)
+
+	cfg := &config.Config{
+		Manager: config.ManagerConfig{Endpoint: managerServer.URL},
+		Auth: config.AuthConfig{
+			AccessToken:    "admin-token",
+			TokenExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+
+	ctx := context.Background()
+
+	t.Run("discovers gateway via manager", func(t *testing.T) {
+		status, err := New(cfg).GetAgentStatus(ctx, "agent-dc1", "")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-dc1", status.AgentId)
+		assert.Equal(t, "gateway-2", status.GatewayId)
+		assert.Equal(t, "Bearer admin-token", gateway2.authHeader)
+	})
+
+	t.Run("explicit gateway", func(t *testing.T) {
+		_, err := New(cfg).GetAgentStatus(ctx, "agent-dc1", gw1Server.URL)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found on any gateway")
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		_, err := New(cfg).GetAgentStatus(ctx, "agent-missing", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "agent agent-missing not found on any gateway")
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		expired := *cfg
+		expired.Auth.TokenExpiresAt = time.Now().Add(-time.Minute)
+		_, err := New(&expired).GetAgentStatus(ctx, "agent-dc1", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to ensure valid token")
+	})
+}
+
+func TestClient_ListGateways(t *testing.T) {
+	path, handler := managerv1connect.NewBMCManagerServiceHandler(&mockGatewayDirectory{
+		endpoints: []string{"http://gw1:8081", "http://gw2:8081"},
+	})
+	managerServer := newH2CServer(t, path, handler)
+
+	cfg := &config.Config{
+		Manager: config.ManagerConfig{Endpoint: managerServer.URL},
+		Auth: config.AuthConfig{
+			AccessToken:    "token",
+			TokenExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+
+	gateways, err := New(cfg).ListGateways(context.Background())
+	require.NoError(t, err)
+	require.Len(t, gateways, 2)
+	assert.Equal(t, "gateway-1", gateways[0].ID)
+	assert.Equal(t, "http://gw2:8081", gateways[1].Endpoint)
+
+	cfg.Auth.AccessToken = ""
+	_, err = New(cfg).ListGateways(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to ensure valid token")
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"connectrpc.com/connect"
 
 	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
 
 	"cli/pkg/config"
 )
@@ -19,20 +22,42 @@ type Client struct {
 	httpClient    *http.Client
 	managerClient *BMCManagerClient
 	gatewayCache  map[string]*RegionalGatewayClient
+
+	// Server locations resolved by the manager, also kept in locationStore
+	// for later invocations when a location cache TTL is configured
+	locations     map[string]*ServerLocation
+	locationStore *config.LocationStore
+
+	maintenanceOverride bool
+	idempotencyKey      string
 }
 
 func New(cfg *config.Config) *Client {
-	return &Client{
+	client := &Client{
 		config:        cfg,
 		httpClient:    &http.Client{},
 		managerClient: NewBMCManagerClient(cfg),
 		gatewayCache:  make(map[string]*RegionalGatewayClient),
+		locations:     make(map[string]*ServerLocation),
+	}
+	if cfg.Gateway.LocationCacheTTL > 0 {
+		// Without a store every command asks the manager, nothing else changes
+		if store, err := config.DefaultLocationStore(cfg.Gateway.LocationCacheTTL); err == nil {
+			client.locationStore = store
+		}
 	}
+	return client
 }
 
 // Authenticate performs initial authentication with BMC Manager
 func (c *Client) Authenticate(ctx context.Context, email, password string) error {
-	result, err := c.managerClient.Authenticate(ctx, email, password)
+	return c.AuthenticateWithTOTP(ctx, email, password, "")
+}
+
+// AuthenticateWithTOTP authenticates an account with two-factor
+// authentication, totpCode being a code of its authenticator app
+func (c *Client) AuthenticateWithTOTP(ctx context.Context, email, password, totpCode string) error {
+	result, err := c.managerClient.AuthenticateWithTOTP(ctx, email, password, totpCode)
 	if err != nil {
 		return err
 	}
@@ -43,6 +68,48 @@ func (c *Client) Authenticate(ctx context.Context, email, password string) error
 	return nil
 }
 
+// ChangePassword sets the password of the logged-in account
+func (c *Client) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ChangePassword(ctx, currentPassword, newPassword)
+}
+
+// EnrollTOTP generates a TOTP secret for the logged-in account
+func (c *Client) EnrollTOTP(ctx context.Context) (*managerv1.EnrollTOTPResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.EnrollTOTP(ctx)
+}
+
+// ConfirmTOTP enables two-factor authentication of the logged-in account
+func (c *Client) ConfirmTOTP(ctx context.Context, code string) error {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ConfirmTOTP(ctx, code)
+}
+
+// DisableTOTP disables two-factor authentication of the logged-in account
+func (c *Client) DisableTOTP(ctx context.Context, code string) error {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.DisableTOTP(ctx, code)
+}
+
+// ResetPassword sets the password of an account with a reset token issued
+// by an admin
+func (c *Client) ResetPassword(ctx context.Context, email, resetToken, newPassword string) error {
+	return c.managerClient.ResetPassword(ctx, email, resetToken, newPassword)
+}
+
 // getGatewayClientWithServerToken returns a gateway client with server-specific token
 func (c *Client) getGatewayClientWithServerToken(ctx context.Context, serverID string) (*RegionalGatewayClient, string, error) {
 	// Ensure we have a valid token
@@ -56,22 +123,89 @@ func (c *Client) getGatewayClientWithServerToken(ctx context.Context, serverID s
 		return nil, "", fmt.Errorf("failed to get server token: %w", err)
 	}
 
-	// Get server location from BMC Manager
-	location, err := c.managerClient.GetServerLocation(ctx, serverID)
+	// Route to the regional gateway handling the server
+	location, err := c.serverLocation(ctx, serverID)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get server location: %w", err)
 	}
 
-	// Check cache for existing gateway client
-	if client, exists := c.gatewayCache[location.RegionalGatewayEndpoint]; exists {
-		return client, serverToken.Token, nil
+	// We use the server token instead of a delegated token
+	return c.cachedGatewayClient(location.RegionalGatewayEndpoint), serverToken.Token, nil
+}
+
+// serverLocation returns the location of a server, from the location cache
+// or else from the BMC Manager
+func (c *Client) serverLocation(ctx context.Context, serverID string) (*ServerLocation, error) {
+	if location, exists := c.locations[serverID]; exists {
+		return location, nil
 	}
 
-	// Create new gateway client (we'll use server token instead of delegated token)
-	gatewayClient := NewRegionalGatewayClient(c.config, location.RegionalGatewayEndpoint, "")
-	c.gatewayCache[location.RegionalGatewayEndpoint] = gatewayClient
+	// The cache is best effort: an unreadable cache is a miss
+	if c.locationStore != nil {
+		if cached, err := c.locationStore.Get(serverID); err == nil && cached != nil {
+			location := &ServerLocation{
+				ServerID:                cached.ServerID,
+				RegionalGatewayID:       cached.GatewayID,
+				RegionalGatewayEndpoint: cached.GatewayEndpoint,
+				DatacenterID:            cached.DatacenterID,
+			}
+			c.locations[serverID] = location
+			return location, nil
+		}
+	}
 
-	return gatewayClient, serverToken.Token, nil
+	location, err := c.managerClient.GetServerLocation(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	c.locations[serverID] = location
+
+	if c.locationStore != nil {
+		_ = c.locationStore.Put(config.CachedLocation{
+			ServerID:        serverID,
+			GatewayID:       location.RegionalGatewayID,
+			GatewayEndpoint: location.RegionalGatewayEndpoint,
+			DatacenterID:    location.DatacenterID,
+		})
+	}
+	return location, nil
+}
+
+// forgetGateway drops the cached locations of the servers handled by the
+// gateway at endpoint, so that they are looked up again
+func (c *Client) forgetGateway(endpoint string) {
+	for serverID, location := range c.locations {
+		if location.RegionalGatewayEndpoint == endpoint {
+			delete(c.locations, serverID)
+		}
+	}
+	if c.locationStore != nil {
+		_ = c.locationStore.RemoveEndpoint(endpoint)
+	}
+}
+
+// cachedGatewayClient returns the cached client of a gateway endpoint. When
+// the gateway is unreachable or does not know a server, the servers cached
+// on it are forgotten: they may have moved to another region.
+func (c *Client) cachedGatewayClient(endpoint string) *RegionalGatewayClient {
+	if gatewayClient, exists := c.gatewayCache[endpoint]; exists {
+		return gatewayClient
+	}
+
+	invalidate := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resp, err := next(ctx, req)
+			if code := connect.CodeOf(err); code == connect.CodeUnavailable || code == connect.CodeNotFound {
+				c.forgetGateway(endpoint)
+			}
+			return resp, err
+		}
+	})
+	gatewayClient := NewRegionalGatewayClient(c.config, endpoint, "", connect.WithInterceptors(invalidate))
+	gatewayClient.SetMaintenanceOverride(c.maintenanceOverride)
+	gatewayClient.SetIdempotencyKey(c.idempotencyKey)
+	c.gatewayCache[endpoint] = gatewayClient
+	return gatewayClient
 }
 
 // getGatewayClient returns a cached or new Regional Gateway client for a server (legacy method)
@@ -94,6 +228,9 @@ type ServerInfo struct {
 	DatacenterID      string                      `json:"datacenter_id"`
 	Metadata          map[string]string           `json:"metadata"`
 	DiscoveryMetadata *types.DiscoveryMetadata    `json:"discovery_metadata,omitempty"`
+	PowerState        string                      `json:"power_state,omitempty"`
+	MaintenanceMode   bool                        `json:"maintenance_mode"`
+	Notes             string                      `json:"notes,omitempty"`
 }
 
 // GetPrimaryControlEndpoint returns the primary control endpoint.
@@ -145,19 +282,31 @@ func (c *Client) GetServer(ctx context.Context, serverID string) (*ServerInfo, e
 		DatacenterID:      server.DatacenterID,
 		Metadata:          server.Metadata,
 		DiscoveryMetadata: server.DiscoveryMetadata,
+		MaintenanceMode:   server.MaintenanceMode,
+		Notes:             server.Notes,
 	}
 
 	return serverInfo, nil
 }
 
 func (c *Client) ListServers(ctx context.Context) ([]ServerInfo, error) {
+	return c.listServers(ctx, false)
+}
+
+// ListServersWithPowerState lists the servers with their power state, queried
+// by the manager from the gateways
+func (c *Client) ListServersWithPowerState(ctx context.Context) ([]ServerInfo, error) {
+	return c.listServers(ctx, true)
+}
+
+func (c *Client) listServers(ctx context.Context, includePowerState bool) ([]ServerInfo, error) {
 	// Ensure we have a valid token
 	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
 		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
 	}
 
 	// Get servers from BMC Manager (new BMC-centric architecture)
-	servers, err := c.managerClient.ListServers(ctx)
+	servers, powerStates, err := c.managerClient.listServers(ctx, includePowerState)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list servers from manager: %w", err)
 	}
@@ -177,6 +326,9 @@ func (c *Client) ListServers(ctx context.Context) ([]ServerInfo, error) {
 			DatacenterID:      server.DatacenterID,
 			Metadata:          server.Metadata,
 			DiscoveryMetadata: server.DiscoveryMetadata,
+			PowerState:        powerStates[server.ID],
+			MaintenanceMode:   server.MaintenanceMode,
+			Notes:             server.Notes,
 		}
 
 		serverInfos = append(serverInfos, serverInfo)
@@ -185,6 +337,208 @@ func (c *Client) ListServers(ctx context.Context) ([]ServerInfo, error) {
 	return serverInfos, nil
 }
 
+// ListGateways returns the regional gateways registered with the BMC Manager
+func (c *Client) ListGateways(ctx context.Context) ([]RegionalGateway, error) {
+	// Ensure we have a valid token
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ListGateways(ctx)
+}
+
+// ExportUsage exports the console minutes, power operations and console data
+// of customers over a month, e.g. 2024-06, as a csv or json document
+// (requires an admin account)
+func (c *Client) ExportUsage(ctx context.Context, month, format, customerID string) (*managerv1.ExportUsageResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ExportUsage(ctx, month, format, customerID)
+}
+
+// ListGatewayHealth returns the gateways registered with the BMC Manager and
+// their health, including those awaiting approval (requires an admin account)
+func (c *Client) ListGatewayHealth(ctx context.Context) ([]*managerv1.GatewayHealth, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ListGatewayHealth(ctx)
+}
+
+// ApproveGateway approves a gateway awaiting approval, so that its servers
+// can be reached (requires an admin account)
+func (c *Client) ApproveGateway(ctx context.Context, gatewayID string) (*managerv1.GatewayHealth, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ApproveGateway(ctx, gatewayID)
+}
+
+// ListCustomers returns the customers of the BMC Manager (requires an admin
+// account)
+func (c *Client) ListCustomers(ctx context.Context) ([]*managerv1.CustomerSummary, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ListCustomers(ctx)
+}
+
+// CreateCustomer creates a customer account and returns it with its API key
+// (requires an admin account)
+func (c *Client) CreateCustomer(ctx context.Context, email, customerID string, isAdmin bool) (*managerv1.CreateCustomerResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.CreateCustomer(ctx, email, customerID, isAdmin)
+}
+
+// IssueAPIKey issues a new API key to a customer, revoking its previous key
+// (requires an admin account)
+func (c *Client) IssueAPIKey(ctx context.Context, customerID string) (string, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return "", fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.IssueAPIKey(ctx, customerID)
+}
+
+// SetCustomerMFAPolicy sets whether the console sessions of a customer
+// require two-factor authentication (requires an admin account)
+func (c *Client) SetCustomerMFAPolicy(ctx context.Context, customerID string, requireMFA, resetTOTP bool) (*managerv1.SetCustomerMFAPolicyResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.SetCustomerMFAPolicy(ctx, customerID, requireMFA, resetTOTP)
+}
+
+// SetCustomerIPAllowlist restricts the source addresses gateways accept for
+// the BMCs of a customer, lifting the restriction without cidrs (requires an
+// admin account)
+func (c *Client) SetCustomerIPAllowlist(ctx context.Context, customerID string, cidrs []string) (*managerv1.SetCustomerIPAllowlistResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.SetCustomerIPAllowlist(ctx, customerID, cidrs)
+}
+
+// ResetCustomerPassword issues a one-time token letting a customer set a new
+// password, unlocking its account (requires an admin account)
+func (c *Client) ResetCustomerPassword(ctx context.Context, customerID string) (*managerv1.ResetCustomerPasswordResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ResetCustomerPassword(ctx, customerID)
+}
+
+// DisableCustomer revokes the access of a customer and terminates its
+// console sessions, or re-enables it with enable (requires an admin account)
+func (c *Client) DisableCustomer(ctx context.Context, customerID, reason string, enable bool) (*managerv1.DisableCustomerResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.DisableCustomer(ctx, customerID, reason, enable)
+}
+
+// DeleteCustomer deletes a customer, assigning the servers it owns to
+// reassignTo (requires an admin account)
+func (c *Client) DeleteCustomer(ctx context.Context, customerID, reassignTo, reason string) (*managerv1.DeleteCustomerResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.DeleteCustomer(ctx, customerID, reassignTo, reason)
+}
+
+// AssignServer assigns a server to a customer (requires an admin account)
+func (c *Client) AssignServer(ctx context.Context, serverID, customerID string) (*managerv1.AssignServerResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.AssignServer(ctx, serverID, customerID)
+}
+
+// ImportServers registers the servers of a csv or yaml inventory document,
+// reporting each row (requires an admin account). Dry runs only validate it.
+func (c *Client) ImportServers(ctx context.Context, data []byte, format string, dryRun bool) (*managerv1.ImportServersResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ImportServers(ctx, data, format, dryRun)
+}
+
+// ExportServers exports the servers as a csv or yaml inventory document,
+// without their passwords (requires an admin account)
+func (c *Client) ExportServers(ctx context.Context, format, customerID string) (*managerv1.ExportServersResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ExportServers(ctx, format, customerID)
+}
+
+// ListAuditEvents returns the changes made by admins, newest first
+// (requires an admin account)
+func (c *Client) ListAuditEvents(ctx context.Context, filter *managerv1.ListAuditEventsRequest) ([]*managerv1.AuditEvent, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ListAuditEvents(ctx, filter)
+}
+
+// ListConnectivityAudits returns the last connectivity audit of each server
+// (requires an admin account)
+func (c *Client) ListConnectivityAudits(ctx context.Context, filter *managerv1.ListConnectivityAuditsRequest) (*managerv1.ListConnectivityAuditsResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.ListConnectivityAudits(ctx, filter)
+}
+
+// RunConnectivityAudit audits the credentials and consoles of a server's BMC
+// now (requires an admin account)
+func (c *Client) RunConnectivityAudit(ctx context.Context, serverID string) (*managerv1.ConnectivityAudit, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.RunConnectivityAudit(ctx, serverID)
+}
+
+// SetMaintenanceOverride sets whether destructive power operations override
+// the maintenance window in progress on their server. Gateways audit the
+// overrides, and only administrators may override windows rejecting
+// operations.
+func (c *Client) SetMaintenanceOverride(override bool) {
+	c.maintenanceOverride = override
+	for _, gatewayClient := range c.gatewayCache {
+		gatewayClient.SetMaintenanceOverride(override)
+	}
+}
+
+// SetIdempotencyKey sets the idempotency key sent with power operations.
+// Gateways run an operation once per key and server, and return its result
+// to the retries sent with the key within their window.
+func (c *Client) SetIdempotencyKey(key string) {
+	c.idempotencyKey = key
+	for _, gatewayClient := range c.gatewayCache {
+		gatewayClient.SetIdempotencyKey(key)
+	}
+}
+
 // BMC operation methods that delegate to regional gateways using server tokens
 
 func (c *Client) PowerOn(ctx context.Context, serverID string) error {
@@ -203,6 +557,24 @@ func (c *Client) PowerOff(ctx context.Context, serverID string) error {
 	return gatewayClient.PowerOffWithToken(ctx, serverID, serverToken)
 }
 
+func (c *Client) ForceOff(ctx context.Context, serverID string) error {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return gatewayClient.ForceOffWithToken(ctx, serverID, serverToken)
+}
+
+// GracefulShutdown asks the OS of a server to shut down. When forceAfter is
+// set, the server is forced off if it is still on after that time.
+func (c *Client) GracefulShutdown(ctx context.Context, serverID string, forceAfter time.Duration) (*gatewayv1.GracefulShutdownResponse, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.GracefulShutdownWithToken(ctx, serverID, forceAfter, serverToken)
+}
+
 func (c *Client) PowerCycle(ctx context.Context, serverID string) error {
 	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
 	if err != nil {
@@ -227,6 +599,62 @@ func (c *Client) Reset(ctx context.Context, serverID string) error {
 	return gatewayClient.ResetWithToken(ctx, serverID, serverToken)
 }
 
+func (c *Client) DiagnosticInterrupt(ctx context.Context, serverID string) error {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return gatewayClient.DiagnosticInterruptWithToken(ctx, serverID, serverToken)
+}
+
+// RunPowerOperation performs a power operation and waits until the BMC
+// completes it, calling onProgress (if not nil) as it runs. Unlike PowerOn,
+// Reset, etc., it does not return when the BMC accepts the operation.
+func (c *Client) RunPowerOperation(ctx context.Context, serverID string, operation gatewayv1.PowerOperation, onProgress func(*gatewayv1.PowerOperationProgress)) error {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return gatewayClient.RunPowerOperationWithToken(ctx, serverID, operation, serverToken, onProgress)
+}
+
+// StartPowerOperation starts a power operation tracked by the gateway of the
+// server, and returns at once with it. GetOperation, ListOperations and
+// WatchOperation follow it.
+func (c *Client) StartPowerOperation(ctx context.Context, serverID string, operation gatewayv1.PowerOperation) (*gatewayv1.Operation, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.StartPowerOperationWithToken(ctx, serverID, operation, serverToken)
+}
+
+func (c *Client) GetOperation(ctx context.Context, serverID, operationID string) (*gatewayv1.Operation, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.GetOperationWithToken(ctx, serverID, operationID, serverToken)
+}
+
+func (c *Client) ListOperations(ctx context.Context, serverID string) ([]*gatewayv1.Operation, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.ListOperationsWithToken(ctx, serverID, serverToken)
+}
+
+// WatchOperation follows an operation until it completes, calling onUpdate
+// (if not nil) each time it changes, and returns its last state
+func (c *Client) WatchOperation(ctx context.Context, serverID, operationID string, onUpdate func(*gatewayv1.Operation)) (*gatewayv1.Operation, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.WatchOperationWithToken(ctx, serverID, operationID, serverToken, onUpdate)
+}
+
 func (c *Client) GetBMCInfo(ctx context.Context, serverID string) (*gatewayv1.BMCInfo, error) {
 	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
 	if err != nil {
@@ -235,6 +663,225 @@ func (c *Client) GetBMCInfo(ctx context.Context, serverID string) (*gatewayv1.BM
 	return gatewayClient.GetBMCInfoWithToken(ctx, serverID, serverToken)
 }
 
+// GetBMCNetworkConfig retrieves the network configuration of the management
+// interfaces of a server's BMC
+func (c *Client) GetBMCNetworkConfig(ctx context.Context, serverID string) (*gatewayv1.GetBMCNetworkConfigResponse, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.GetBMCNetworkConfigWithToken(ctx, serverID, serverToken)
+}
+
+// DeepHealthCheck checks each hop from the gateway to a server's BMC.
+// checkConsoles also checks the SOL and VNC endpoints.
+func (c *Client) DeepHealthCheck(ctx context.Context, serverID string, checkConsoles bool) (*gatewayv1.DeepHealthCheckResponse, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.DeepHealthCheckWithToken(ctx, serverID, checkConsoles, serverToken)
+}
+
+// SetChassisIdentify sets the identify (locate) LED of a server. duration is
+// only used when blinking.
+func (c *Client) SetChassisIdentify(ctx context.Context, serverID string, state gatewayv1.IdentifyState, duration time.Duration) (*gatewayv1.SetChassisIdentifyResponse, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.SetChassisIdentifyWithToken(ctx, serverID, state, duration, serverToken)
+}
+
+// Agent diagnostics methods
+
+// GetAgentStatus looks up a Local Agent on a regional gateway.
+// If gatewayURL is empty, every gateway known to the BMC Manager is queried
+// until one reports the agent.
+func (c *Client) GetAgentStatus(ctx context.Context, agentID, gatewayURL string) (*gatewayv1.AgentStatus, error) {
+	// Ensure we have a valid token
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	var endpoints []string
+	if gatewayURL != "" {
+		endpoints = []string{gatewayURL}
+	} else {
+		gateways, err := c.managerClient.ListGateways(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, gateway := range gateways {
+			endpoints = append(endpoints, gateway.Endpoint)
+		}
+		if len(endpoints) == 0 {
+			return nil, fmt.Errorf("no regional gateways registered with the manager")
+		}
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		status, err := c.cachedGatewayClient(endpoint).GetAgentStatusWithToken(ctx, agentID, c.config.Auth.AccessToken)
+		if err == nil {
+			return status, nil
+		}
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			lastErr = fmt.Errorf("gateway %s: %w", endpoint, err)
+		}
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("agent %s not found on any gateway", agentID)
+}
+
+// GetGatewayStatus returns the agents, console session counts and BMC
+// endpoint mappings of a gateway registered with the BMC Manager (requires
+// an admin account)
+func (c *Client) GetGatewayStatus(ctx context.Context, gatewayID string) (*gatewayv1.GetGatewayStatusResponse, error) {
+	if gatewayID == "" {
+		return nil, fmt.Errorf("gateway ID is required")
+	}
+	gateways, err := c.adminGateways(ctx, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.cachedGatewayClient(gateways[0].Endpoint).GetGatewayStatusWithToken(ctx, c.config.Auth.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("gateway %s: %w", gatewayID, err)
+	}
+	return status, nil
+}
+
+// SetManagerLogLevel changes the log levels of the manager; an empty request
+// returns the current levels (requires an admin account)
+func (c *Client) SetManagerLogLevel(ctx context.Context, change *managerv1.SetLogLevelRequest) (*managerv1.SetLogLevelResponse, error) {
+	if err := c.managerClient.EnsureValidToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ensure valid token: %w", err)
+	}
+
+	return c.managerClient.SetLogLevel(ctx, change)
+}
+
+// SetGatewayLogLevel changes the log levels of a gateway; an empty request
+// returns the current levels (requires an admin account)
+func (c *Client) SetGatewayLogLevel(ctx context.Context, gatewayID string, change *gatewayv1.SetLogLevelRequest) (*gatewayv1.SetLogLevelResponse, error) {
+	if gatewayID == "" {
+		return nil, fmt.Errorf("gateway ID is required")
+	}
+	gateways, err := c.adminGateways(ctx, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := c.cachedGatewayClient(gateways[0].Endpoint).SetLogLevelWithToken(ctx, change, c.config.Auth.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("gateway %s: %w", gatewayID, err)
+	}
+	return levels, nil
+}
+
+// Console session administration methods
+
+// GatewayConsoleSession is a console session and the gateway holding it
+type GatewayConsoleSession struct {
+	GatewayID string
+	Region    string
+	Session   *gatewayv1.ConsoleSessionInfo
+}
+
+// GatewayError reports a gateway that could not be queried
+type GatewayError struct {
+	GatewayID string
+	Err       error
+}
+
+// ListConsoleSessions lists the console sessions open on the regional
+// gateways, oldest first. If gatewayID is empty, every gateway known to the
+// BMC Manager is queried; gateways that fail are returned alongside the
+// sessions of the others.
+func (c *Client) ListConsoleSessions(ctx context.Context, filter *gatewayv1.ListConsoleSessionsRequest, gatewayID string) ([]GatewayConsoleSession, []GatewayError, error) {
+	gateways, err := c.adminGateways(ctx, gatewayID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sessions []GatewayConsoleSession
+	var failures []GatewayError
+	for _, gateway := range gateways {
+		infos, err := c.cachedGatewayClient(gateway.Endpoint).ListConsoleSessionsWithToken(ctx, filter, c.config.Auth.AccessToken)
+		if err != nil {
+			failures = append(failures, GatewayError{GatewayID: gateway.ID, Err: err})
+			continue
+		}
+		for _, info := range infos {
+			sessions = append(sessions, GatewayConsoleSession{GatewayID: gateway.ID, Region: gateway.Region, Session: info})
+		}
+	}
+
+	if len(sessions) == 0 && len(failures) == len(gateways) {
+		return nil, nil, fmt.Errorf("gateway %s: %w", failures[0].GatewayID, failures[0].Err)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Session.CreatedAt.AsTime().Before(sessions[j].Session.CreatedAt.AsTime())
+	})
+	return sessions, failures, nil
+}
+
+// TerminateConsoleSession closes a console session and disconnects its
+// clients. If gatewayID is empty, every gateway known to the BMC Manager is
+// tried until one holds the session. It returns the ID of that gateway and
+// the number of disconnected client streams.
+func (c *Client) TerminateConsoleSession(ctx context.Context, sessionID, reason, gatewayID string) (string, int32, error) {
+	gateways, err := c.adminGateways(ctx, gatewayID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var lastErr error
+	for _, gateway := range gateways {
+		disconnected, err := c.cachedGatewayClient(gateway.Endpoint).TerminateConsoleSessionWithToken(ctx, sessionID, reason, c.config.Auth.AccessToken)
+		if err == nil {
+			return gateway.ID, disconnected, nil
+		}
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			lastErr = fmt.Errorf("gateway %s: %w", gateway.ID, err)
+		}
+	}
+
+	if lastErr != nil {
+		return "", 0, lastErr
+	}
+	return "", 0, fmt.Errorf("console session %s not found on any gateway", sessionID)
+}
+
+// adminGateways returns the gateways to query for administration RPCs, all
+// of them or the one with gatewayID
+func (c *Client) adminGateways(ctx context.Context, gatewayID string) ([]RegionalGateway, error) {
+	gateways, err := c.ListGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if gatewayID != "" {
+		for _, gateway := range gateways {
+			if gateway.ID == gatewayID {
+				return []RegionalGateway{gateway}, nil
+			}
+		}
+		return nil, fmt.Errorf("gateway %s is not registered with the manager", gatewayID)
+	}
+
+	if len(gateways) == 0 {
+		return nil, fmt.Errorf("no regional gateways registered with the manager")
+	}
+	return gateways, nil
+}
+
 // VNC session management methods
 
 type VNCSession struct {
@@ -246,17 +893,33 @@ type VNCSession struct {
 
 type SOLSession struct {
 	ID                string `json:"id"`
+	ServerID          string `json:"server_id,omitempty"`
 	WebsocketEndpoint string `json:"websocket_endpoint"`
 	ConsoleURL        string `json:"console_url"`
 	ExpiresAt         string `json:"expires_at"`
 }
 
-func (c *Client) CreateVNCSession(ctx context.Context, serverID string) (*VNCSession, error) {
+// SOLSerialSettings are the serial settings requested for a SOL session.
+// Zero fields leave the BMC's setting unchanged.
+type SOLSerialSettings struct {
+	BaudRate    int
+	FlowControl string // "none", "hardware" or "software"
+}
+
+// VNCSessionOptions configure a VNC session
+type VNCSessionOptions struct {
+	ReadOnly       bool   // Observe the console without keyboard or pointer input
+	KeyboardLayout string // Layout of the viewer's keyboard, e.g. "de"; empty for "us"
+}
+
+// CreateVNCSession creates a VNC session, read-only to observe the console
+// without sending keyboard or pointer input
+func (c *Client) CreateVNCSession(ctx context.Context, serverID string, opts VNCSessionOptions) (*VNCSession, error) {
 	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
 	if err != nil {
 		return nil, err
 	}
-	return gatewayClient.CreateVNCSessionWithToken(ctx, serverID, serverToken)
+	return gatewayClient.CreateVNCSessionWithToken(ctx, serverID, serverToken, opts)
 }
 
 func (c *Client) GetVNCSession(ctx context.Context, sessionID string) (*VNCSession, error) {
@@ -286,11 +949,18 @@ func (c *Client) CloseVNCSession(ctx context.Context, sessionID string) error {
 // SOL session management methods
 
 func (c *Client) CreateSOLSession(ctx context.Context, serverID string) (*SOLSession, error) {
+	return c.CreateSOLSessionWithSettings(ctx, serverID, SOLSerialSettings{}, false)
+}
+
+// CreateSOLSessionWithSettings creates a SOL session whose serial settings
+// are applied to the BMC when the console connects. The input of read-only
+// sessions is dropped by the gateway.
+func (c *Client) CreateSOLSessionWithSettings(ctx context.Context, serverID string, settings SOLSerialSettings, readOnly bool) (*SOLSession, error) {
 	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
 	if err != nil {
 		return nil, err
 	}
-	return gatewayClient.CreateSOLSessionWithToken(ctx, serverID, serverToken)
+	return gatewayClient.CreateSOLSessionWithToken(ctx, serverID, serverToken, settings, readOnly)
 }
 
 func (c *Client) GetSOLSession(ctx context.Context, sessionID string) (*SOLSession, error) {
@@ -317,11 +987,37 @@ func (c *Client) CloseSOLSession(ctx context.Context, sessionID string) error {
 	return fmt.Errorf("SOL session not found: %s", sessionID)
 }
 
+// GetServerSOLSession retrieves a SOL session of the given server. Unlike
+// GetSOLSession it does not depend on previously used gateways, so it works
+// for sessions created by an earlier CLI invocation.
+func (c *Client) GetServerSOLSession(ctx context.Context, serverID, sessionID string) (*SOLSession, error) {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayClient.GetSOLSessionWithToken(ctx, sessionID, serverToken)
+}
+
+// CloseServerSOLSession closes a SOL session of the given server
+func (c *Client) CloseServerSOLSession(ctx context.Context, serverID, sessionID string) error {
+	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	return gatewayClient.CloseSOLSessionWithToken(ctx, sessionID, serverToken)
+}
+
 // StreamConsoleData opens a bidirectional stream for console data
 func (c *Client) StreamConsoleData(ctx context.Context, serverID, sessionID string) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
+	return c.StreamConsoleDataWithOptions(ctx, serverID, sessionID, ConsoleStreamOptions{})
+}
+
+// StreamConsoleDataWithOptions opens a bidirectional stream for console data
+// with handshake options, e.g. to take over a busy console
+func (c *Client) StreamConsoleDataWithOptions(ctx context.Context, serverID, sessionID string, opts ConsoleStreamOptions) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
 	gatewayClient, serverToken, err := c.getGatewayClientWithServerToken(ctx, serverID)
 	if err != nil {
 		return nil, err
 	}
-	return gatewayClient.StreamConsoleDataWithToken(ctx, sessionID, serverID, serverToken)
+	return gatewayClient.StreamConsoleDataWithToken(ctx, sessionID, serverID, serverToken, opts)
 }
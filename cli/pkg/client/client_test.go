@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"testing"
+	"time"
 
 	"cli/pkg/config"
 	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
 )
 
 func TestNew(t *testing.T) {
@@ -192,6 +194,17 @@ func TestClient_PowerOperationsError(t *testing.T) {
 			name:      "PowerOff",
 			operation: func() error { return client.PowerOff(ctx, "server-1") },
 		},
+		{
+			name:      "ForceOff",
+			operation: func() error { return client.ForceOff(ctx, "server-1") },
+		},
+		{
+			name: "GracefulShutdown",
+			operation: func() error {
+				_, err := client.GracefulShutdown(ctx, "server-1", time.Minute)
+				return err
+			},
+		},
 		{
 			name:      "PowerCycle",
 			operation: func() error { return client.PowerCycle(ctx, "server-1") },
@@ -200,6 +213,17 @@ func TestClient_PowerOperationsError(t *testing.T) {
 			name:      "Reset",
 			operation: func() error { return client.Reset(ctx, "server-1") },
 		},
+		{
+			name:      "DiagnosticInterrupt",
+			operation: func() error { return client.DiagnosticInterrupt(ctx, "server-1") },
+		},
+		{
+			name: "SetChassisIdentify",
+			operation: func() error {
+				_, err := client.SetChassisIdentify(ctx, "server-1", gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK, 5*time.Minute)
+				return err
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -239,7 +263,7 @@ func TestClient_VNCSessionOperationsError(t *testing.T) {
 	ctx := context.Background()
 
 	// Test CreateVNCSession
-	_, err := client.CreateVNCSession(ctx, "server-1")
+	_, err := client.CreateVNCSession(ctx, "server-1", VNCSessionOptions{})
 	if err == nil {
 		t.Error("Expected CreateVNCSession to fail with invalid manager endpoint")
 	}
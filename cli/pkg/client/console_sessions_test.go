@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"manager/gen/manager/v1/managerv1connect"
+
+	"cli/pkg/config"
+)
+
+// mockConsoleGateway serves the console session administration RPCs
+type mockConsoleGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	sessions   map[string]*gatewayv1.ConsoleSessionInfo
+	terminated []string
+	reason     string
+	authorized string
+}
+
+func (g *mockConsoleGateway) ListConsoleSessions(
+	_ context.Context,
+	req *connect.Request[gatewayv1.ListConsoleSessionsRequest],
+) (*connect.Response[gatewayv1.ListConsoleSessionsResponse], error) {
+	resp := &gatewayv1.ListConsoleSessionsResponse{}
+	for _, session := range g.sessions {
+		if req.Msg.ServerId == "" || session.ServerId == req.Msg.ServerId {
+			resp.Sessions = append(resp.Sessions, session)
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (g *mockConsoleGateway) TerminateConsoleSession(
+	_ context.Context,
+	req *connect.Request[gatewayv1.TerminateConsoleSessionRequest],
+) (*connect.Response[gatewayv1.TerminateConsoleSessionResponse], error) {
+	if _, ok := g.sessions[req.Msg.SessionId]; !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", req.Msg.SessionId))
+	}
+	g.terminated = append(g.terminated, req.Msg.SessionId)
+	g.reason = req.Msg.Reason
+	return connect.NewResponse(&gatewayv1.TerminateConsoleSessionResponse{DisconnectedStreams: 2}), nil
+}
+
+func (g *mockConsoleGateway) GetGatewayStatus(
+	_ context.Context,
+	req *connect.Request[gatewayv1.GetGatewayStatusRequest],
+) (*connect.Response[gatewayv1.GetGatewayStatusResponse], error) {
+	g.authorized = req.Header().Get("Authorization")
+	return connect.NewResponse(&gatewayv1.GetGatewayStatusResponse{
+		Sessions: &gatewayv1.ConsoleSessionCounts{Total: int32(len(g.sessions))},
+	}), nil
+}
+
+func TestClient_ConsoleSessions(t *testing.T) {
+	now := time.Now()
+	gateway1 := &mockConsoleGateway{sessions: map[string]*gatewayv1.ConsoleSessionInfo{
+		"sol-new": {SessionId: "sol-new", ServerId: "server-1", CreatedAt: timestamppb.New(now)},
+	}}
+	gateway2 := &mockConsoleGateway{sessions: map[string]*gatewayv1.ConsoleSessionInfo{
+		"vnc-old": {SessionId: "vnc-old", ServerId: "server-2", CreatedAt: timestamppb.New(now.Add(-time.Hour))},
+	}}
+
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(gateway1)
+	gw1Server := newH2CServer(t, path, handler)
+	path, handler = gatewayv1connect.NewGatewayServiceHandler(gateway2)
+	gw2Server := newH2CServer(t, path, handler)
+
+	path, handler = managerv1connect.NewBMCManagerServiceHandler(&mockGatewayDirectory{
+		// Nothing listens on the discard port
+		endpoints: []string{gw1Server.URL, gw2Server.URL, "http://127.0.0.1:9"},
+	})
+	managerServer := newH2CServer(t, path, handler)
+
+	cfg := &config.Config{
+		Manager: config.ManagerConfig{Endpoint: managerServer.URL},
+		Auth: config.AuthConfig{
+			AccessToken:    "admin-token",
+			TokenExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+
+	ctx := context.Background()
+
+	t.Run("list across gateways", func(t *testing.T) {
+		sessions, failures, err := New(cfg).ListConsoleSessions(ctx, &gatewayv1.ListConsoleSessionsRequest{}, "")
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "vnc-old", sessions[0].Session.SessionId, "sessions should be listed oldest first")
+		assert.Equal(t, "gateway-2", sessions[0].GatewayID)
+		assert.Equal(t, "gateway-1", sessions[1].GatewayID)
+
+		require.Len(t, failures, 1)
+		assert.Equal(t, "gateway-3", failures[0].GatewayID)
+	})
+
+	t.Run("list single gateway", func(t *testing.T) {
+		sessions, failures, err := New(cfg).ListConsoleSessions(ctx, &gatewayv1.ListConsoleSessionsRequest{ServerId: "server-1"}, "gateway-1")
+		require.NoError(t, err)
+		assert.Empty(t, failures)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "sol-new", sessions[0].Session.SessionId)
+
+		_, _, err = New(cfg).ListConsoleSessions(ctx, &gatewayv1.ListConsoleSessionsRequest{}, "gateway-3")
+		require.Error(t, err, "listing fails when every queried gateway fails")
+
+		_, _, err = New(cfg).ListConsoleSessions(ctx, &gatewayv1.ListConsoleSessionsRequest{}, "gateway-9")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not registered with the manager")
+	})
+
+	t.Run("terminate finds the gateway", func(t *testing.T) {
+		gatewayID, disconnected, err := New(cfg).TerminateConsoleSession(ctx, "vnc-old", "stuck", "")
+		require.NoError(t, err)
+		assert.Equal(t, "gateway-2", gatewayID)
+		assert.Equal(t, int32(2), disconnected)
+		assert.Equal(t, []string{"vnc-old"}, gateway2.terminated)
+		assert.Equal(t, "stuck", gateway2.reason)
+	})
+
+	t.Run("gateway status", func(t *testing.T) {
+		status, err := New(cfg).GetGatewayStatus(ctx, "gateway-2")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), status.Sessions.Total)
+		assert.Equal(t, "Bearer admin-token", gateway2.authorized)
+
+		_, err = New(cfg).GetGatewayStatus(ctx, "gateway-9")
+		assert.Contains(t, err.Error(), "not registered with the manager")
+	})
+
+	t.Run("terminate unknown session", func(t *testing.T) {
+		_, _, err := New(cfg).TerminateConsoleSession(ctx, "sol-missing", "", "gateway-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "console session sol-missing not found on any gateway")
+	})
+}
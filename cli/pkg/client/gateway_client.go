@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
+	commonv1 "core/gen/common/v1"
 	gatewayv1 "gateway/gen/gateway/v1"
 	"gateway/gen/gateway/v1/gatewayv1connect"
 
@@ -23,9 +25,16 @@ type RegionalGatewayClient struct {
 	endpoint       string
 	delegatedToken string
 	httpClient     *http.Client
+
+	// Override the maintenance windows of servers on destructive power
+	// operations
+	maintenanceOverride bool
+
+	// Key of the power operations, for the gateway to deduplicate retries
+	idempotencyKey string
 }
 
-func NewRegionalGatewayClient(cfg *config.Config, endpoint, delegatedToken string) *RegionalGatewayClient {
+func NewRegionalGatewayClient(cfg *config.Config, endpoint, delegatedToken string, opts ...connect.ClientOption) *RegionalGatewayClient {
 	// Create HTTP client with HTTP/2 support for streaming RPCs
 	// Gateway uses h2c (HTTP/2 cleartext) for bidirectional streaming
 	httpClient := &http.Client{
@@ -37,7 +46,7 @@ func NewRegionalGatewayClient(cfg *config.Config, endpoint, delegatedToken strin
 			},
 		},
 	}
-	client := gatewayv1connect.NewGatewayServiceClient(httpClient, endpoint)
+	client := gatewayv1connect.NewGatewayServiceClient(httpClient, endpoint, opts...)
 
 	return &RegionalGatewayClient{
 		client:         client,
@@ -48,11 +57,23 @@ func NewRegionalGatewayClient(cfg *config.Config, endpoint, delegatedToken strin
 	}
 }
 
+// SetMaintenanceOverride sets whether destructive power operations override
+// the maintenance window in progress on their server
+func (c *RegionalGatewayClient) SetMaintenanceOverride(override bool) {
+	c.maintenanceOverride = override
+}
+
+// SetIdempotencyKey sets the idempotency key sent with power operations
+func (c *RegionalGatewayClient) SetIdempotencyKey(key string) {
+	c.idempotencyKey = key
+}
+
 // BMC Power Operations
 
 func (c *RegionalGatewayClient) PowerOn(ctx context.Context, serverID string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:       serverID,
+		IdempotencyKey: c.idempotencyKey,
 	})
 
 	c.addAuthHeaders(req)
@@ -71,7 +92,9 @@ func (c *RegionalGatewayClient) PowerOn(ctx context.Context, serverID string) er
 
 func (c *RegionalGatewayClient) PowerOff(ctx context.Context, serverID string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeaders(req)
@@ -90,7 +113,9 @@ func (c *RegionalGatewayClient) PowerOff(ctx context.Context, serverID string) e
 
 func (c *RegionalGatewayClient) PowerCycle(ctx context.Context, serverID string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeaders(req)
@@ -109,7 +134,9 @@ func (c *RegionalGatewayClient) PowerCycle(ctx context.Context, serverID string)
 
 func (c *RegionalGatewayClient) Reset(ctx context.Context, serverID string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeaders(req)
@@ -144,7 +171,8 @@ func (c *RegionalGatewayClient) GetPowerStatus(ctx context.Context, serverID str
 // BMC Power Operations with server-specific tokens
 func (c *RegionalGatewayClient) PowerOnWithToken(ctx context.Context, serverID, serverToken string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:       serverID,
+		IdempotencyKey: c.idempotencyKey,
 	})
 
 	c.addAuthHeadersWithToken(req, serverToken)
@@ -163,7 +191,9 @@ func (c *RegionalGatewayClient) PowerOnWithToken(ctx context.Context, serverID,
 
 func (c *RegionalGatewayClient) PowerOffWithToken(ctx context.Context, serverID, serverToken string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeadersWithToken(req, serverToken)
@@ -180,9 +210,53 @@ func (c *RegionalGatewayClient) PowerOffWithToken(ctx context.Context, serverID,
 	return nil
 }
 
+func (c *RegionalGatewayClient) ForceOffWithToken(ctx context.Context, serverID, serverToken string) error {
+	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.ForceOff(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to force off server: %w", err)
+	}
+
+	if !resp.Msg.Success {
+		return fmt.Errorf("force off failed: %s", resp.Msg.Message)
+	}
+
+	return nil
+}
+
+func (c *RegionalGatewayClient) GracefulShutdownWithToken(ctx context.Context, serverID string, forceAfter time.Duration, serverToken string) (*gatewayv1.GracefulShutdownResponse, error) {
+	req := connect.NewRequest(&gatewayv1.GracefulShutdownRequest{
+		ServerId:            serverID,
+		ForceAfterSeconds:   int32(forceAfter / time.Second),
+		MaintenanceOverride: c.maintenanceOverride,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.GracefulShutdown(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to shut down server: %w", err)
+	}
+
+	if !resp.Msg.Success {
+		return nil, fmt.Errorf("graceful shutdown failed: %s", resp.Msg.Message)
+	}
+
+	return resp.Msg, nil
+}
+
 func (c *RegionalGatewayClient) PowerCycleWithToken(ctx context.Context, serverID, serverToken string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeadersWithToken(req, serverToken)
@@ -201,7 +275,9 @@ func (c *RegionalGatewayClient) PowerCycleWithToken(ctx context.Context, serverI
 
 func (c *RegionalGatewayClient) ResetWithToken(ctx context.Context, serverID, serverToken string) error {
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: serverID,
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
 	})
 
 	c.addAuthHeadersWithToken(req, serverToken)
@@ -218,6 +294,140 @@ func (c *RegionalGatewayClient) ResetWithToken(ctx context.Context, serverID, se
 	return nil
 }
 
+func (c *RegionalGatewayClient) DiagnosticInterruptWithToken(ctx context.Context, serverID, serverToken string) error {
+	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
+		ServerId:            serverID,
+		MaintenanceOverride: c.maintenanceOverride,
+		IdempotencyKey:      c.idempotencyKey,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.DiagnosticInterrupt(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to send diagnostic interrupt: %w", err)
+	}
+
+	if !resp.Msg.Success {
+		return fmt.Errorf("diagnostic interrupt failed: %s", resp.Msg.Message)
+	}
+
+	return nil
+}
+
+// RunPowerOperationWithToken performs a power operation and waits until the
+// BMC completes it, calling onProgress (if not nil) for each progress report
+func (c *RegionalGatewayClient) RunPowerOperationWithToken(ctx context.Context, serverID string, operation gatewayv1.PowerOperation, serverToken string, onProgress func(*gatewayv1.PowerOperationProgress)) error {
+	req := connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+		ServerId:            serverID,
+		Operation:           operation,
+		MaintenanceOverride: c.maintenanceOverride,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	stream, err := c.client.RunPowerOperation(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start power operation: %w", err)
+	}
+	defer stream.Close()
+
+	done := false
+	for stream.Receive() {
+		progress := stream.Msg()
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		done = done || progress.Done
+	}
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("power operation failed: %w", err)
+	}
+	if !done {
+		return fmt.Errorf("power operation did not complete")
+	}
+	return nil
+}
+
+// StartPowerOperationWithToken starts a power operation tracked by the
+// gateway, and returns at once
+func (c *RegionalGatewayClient) StartPowerOperationWithToken(ctx context.Context, serverID string, operation gatewayv1.PowerOperation, serverToken string) (*gatewayv1.Operation, error) {
+	req := connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+		ServerId:            serverID,
+		Operation:           operation,
+		MaintenanceOverride: c.maintenanceOverride,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.StartPowerOperation(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start power operation: %w", err)
+	}
+	return resp.Msg.Operation, nil
+}
+
+func (c *RegionalGatewayClient) GetOperationWithToken(ctx context.Context, serverID, operationID, serverToken string) (*gatewayv1.Operation, error) {
+	req := connect.NewRequest(&gatewayv1.GetOperationRequest{
+		ServerId:    serverID,
+		OperationId: operationID,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.GetOperation(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operation: %w", err)
+	}
+	return resp.Msg.Operation, nil
+}
+
+func (c *RegionalGatewayClient) ListOperationsWithToken(ctx context.Context, serverID, serverToken string) ([]*gatewayv1.Operation, error) {
+	req := connect.NewRequest(&gatewayv1.ListOperationsRequest{
+		ServerId: serverID,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.ListOperations(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+	return resp.Msg.Operations, nil
+}
+
+// WatchOperationWithToken follows an operation until it completes, calling
+// onUpdate (if not nil) each time it changes, and returns its last state
+func (c *RegionalGatewayClient) WatchOperationWithToken(ctx context.Context, serverID, operationID, serverToken string, onUpdate func(*gatewayv1.Operation)) (*gatewayv1.Operation, error) {
+	req := connect.NewRequest(&gatewayv1.GetOperationRequest{
+		ServerId:    serverID,
+		OperationId: operationID,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	stream, err := c.client.WatchOperation(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch operation: %w", err)
+	}
+	defer stream.Close()
+
+	var last *gatewayv1.Operation
+	for stream.Receive() {
+		last = stream.Msg()
+		if onUpdate != nil {
+			onUpdate(last)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to watch operation: %w", err)
+	}
+	if last == nil || last.State == gatewayv1.OperationState_OPERATION_STATE_RUNNING {
+		return nil, fmt.Errorf("operation did not complete")
+	}
+	return last, nil
+}
+
 func (c *RegionalGatewayClient) GetPowerStatusWithToken(ctx context.Context, serverID, serverToken string) (string, error) {
 	req := connect.NewRequest(&gatewayv1.PowerStatusRequest{
 		ServerId: serverID,
@@ -248,6 +458,139 @@ func (c *RegionalGatewayClient) GetBMCInfoWithToken(ctx context.Context, serverI
 	return resp.Msg.Info, nil
 }
 
+func (c *RegionalGatewayClient) GetBMCNetworkConfigWithToken(ctx context.Context, serverID, serverToken string) (*gatewayv1.GetBMCNetworkConfigResponse, error) {
+	req := connect.NewRequest(&gatewayv1.GetBMCNetworkConfigRequest{
+		ServerId: serverID,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.GetBMCNetworkConfig(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BMC network configuration: %w", err)
+	}
+
+	return resp.Msg, nil
+}
+
+func (c *RegionalGatewayClient) DeepHealthCheckWithToken(ctx context.Context, serverID string, checkConsoles bool, serverToken string) (*gatewayv1.DeepHealthCheckResponse, error) {
+	req := connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+		ServerId:      serverID,
+		CheckConsoles: checkConsoles,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.DeepHealthCheck(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run health check: %w", err)
+	}
+
+	return resp.Msg, nil
+}
+
+func (c *RegionalGatewayClient) SetChassisIdentifyWithToken(ctx context.Context, serverID string, state gatewayv1.IdentifyState, duration time.Duration, serverToken string) (*gatewayv1.SetChassisIdentifyResponse, error) {
+	req := connect.NewRequest(&gatewayv1.SetChassisIdentifyRequest{
+		ServerId:        serverID,
+		State:           state,
+		DurationSeconds: int32(duration / time.Second),
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.SetChassisIdentify(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set chassis identify: %w", err)
+	}
+
+	if !resp.Msg.Success {
+		return nil, fmt.Errorf("chassis identify failed: %s", resp.Msg.Message)
+	}
+
+	return resp.Msg, nil
+}
+
+// Agent diagnostics
+
+// GetAgentStatusWithToken retrieves the gateway's view of a Local Agent using an access token
+func (c *RegionalGatewayClient) GetAgentStatusWithToken(ctx context.Context, agentID, token string) (*gatewayv1.AgentStatus, error) {
+	req := connect.NewRequest(&gatewayv1.GetAgentStatusRequest{
+		AgentId: agentID,
+	})
+
+	c.addAuthHeadersWithToken(req, token)
+
+	resp, err := c.client.GetAgentStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent status: %w", err)
+	}
+
+	return resp.Msg.Agent, nil
+}
+
+// GetGatewayStatusWithToken retrieves the agents, console session counts and
+// BMC endpoint mappings of the gateway using an access token
+func (c *RegionalGatewayClient) GetGatewayStatusWithToken(ctx context.Context, token string) (*gatewayv1.GetGatewayStatusResponse, error) {
+	req := connect.NewRequest(&gatewayv1.GetGatewayStatusRequest{})
+
+	c.addAuthHeadersWithToken(req, token)
+
+	resp, err := c.client.GetGatewayStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway status: %w", err)
+	}
+
+	return resp.Msg, nil
+}
+
+// SetLogLevelWithToken changes the log levels of the gateway using an access
+// token; an empty request returns the current levels
+func (c *RegionalGatewayClient) SetLogLevelWithToken(ctx context.Context, change *gatewayv1.SetLogLevelRequest, token string) (*gatewayv1.SetLogLevelResponse, error) {
+	req := connect.NewRequest(change)
+
+	c.addAuthHeadersWithToken(req, token)
+
+	resp, err := c.client.SetLogLevel(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set log level: %w", err)
+	}
+
+	return resp.Msg, nil
+}
+
+// Console session administration
+
+// ListConsoleSessionsWithToken lists the console sessions open on the gateway using an access token
+func (c *RegionalGatewayClient) ListConsoleSessionsWithToken(ctx context.Context, filter *gatewayv1.ListConsoleSessionsRequest, token string) ([]*gatewayv1.ConsoleSessionInfo, error) {
+	req := connect.NewRequest(filter)
+
+	c.addAuthHeadersWithToken(req, token)
+
+	resp, err := c.client.ListConsoleSessions(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list console sessions: %w", err)
+	}
+
+	return resp.Msg.Sessions, nil
+}
+
+// TerminateConsoleSessionWithToken closes a console session on the gateway using an access token
+func (c *RegionalGatewayClient) TerminateConsoleSessionWithToken(ctx context.Context, sessionID, reason, token string) (int32, error) {
+	req := connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{
+		SessionId: sessionID,
+		Reason:    reason,
+	})
+
+	c.addAuthHeadersWithToken(req, token)
+
+	resp, err := c.client.TerminateConsoleSession(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to terminate console session: %w", err)
+	}
+
+	return resp.Msg.DisconnectedStreams, nil
+}
+
 // CreateVNCSession creates a new VNC console session
 func (c *RegionalGatewayClient) CreateVNCSession(ctx context.Context, serverID string) (*VNCSession, error) {
 	req := connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{
@@ -275,9 +618,11 @@ func (c *RegionalGatewayClient) CreateVNCSession(ctx context.Context, serverID s
 }
 
 // CreateVNCSessionWithToken creates a new VNC console session using server-specific token
-func (c *RegionalGatewayClient) CreateVNCSessionWithToken(ctx context.Context, serverID, serverToken string) (*VNCSession, error) {
+func (c *RegionalGatewayClient) CreateVNCSessionWithToken(ctx context.Context, serverID, serverToken string, opts VNCSessionOptions) (*VNCSession, error) {
 	req := connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{
-		ServerId: serverID,
+		ServerId:       serverID,
+		ReadOnly:       opts.ReadOnly,
+		KeyboardLayout: opts.KeyboardLayout,
 	})
 
 	c.addAuthHeadersWithToken(req, serverToken)
@@ -370,10 +715,18 @@ func (c *RegionalGatewayClient) CreateSOLSession(ctx context.Context, serverID s
 }
 
 // CreateSOLSessionWithToken creates a new SOL console session using server-specific token
-func (c *RegionalGatewayClient) CreateSOLSessionWithToken(ctx context.Context, serverID, serverToken string) (*SOLSession, error) {
-	req := connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+func (c *RegionalGatewayClient) CreateSOLSessionWithToken(ctx context.Context, serverID, serverToken string, settings SOLSerialSettings, readOnly bool) (*SOLSession, error) {
+	msg := &gatewayv1.CreateSOLSessionRequest{
 		ServerId: serverID,
-	})
+		ReadOnly: readOnly,
+	}
+	if settings != (SOLSerialSettings{}) {
+		msg.Config = &commonv1.SOLConfig{
+			BaudRate:    int32(settings.BaudRate),
+			FlowControl: settings.FlowControl,
+		}
+	}
+	req := connect.NewRequest(msg)
 
 	c.addAuthHeadersWithToken(req, serverToken)
 
@@ -438,80 +791,117 @@ func (c *RegionalGatewayClient) CloseSOLSession(ctx context.Context, sessionID s
 	return nil
 }
 
+// GetSOLSessionWithToken retrieves an existing SOL session using server-specific token
+func (c *RegionalGatewayClient) GetSOLSessionWithToken(ctx context.Context, sessionID, serverToken string) (*SOLSession, error) {
+	req := connect.NewRequest(&gatewayv1.GetSOLSessionRequest{
+		SessionId: sessionID,
+	})
+
+	c.addAuthHeadersWithToken(req, serverToken)
+
+	resp, err := c.client.GetSOLSession(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SOL session: %w", err)
+	}
+
+	if resp.Msg.Session == nil {
+		return nil, fmt.Errorf("SOL session not found")
+	}
+
+	session := &SOLSession{
+		ID:                resp.Msg.Session.Id,
+		ServerID:          resp.Msg.Session.ServerId,
+		WebsocketEndpoint: resp.Msg.Session.WebsocketEndpoint,
+		ConsoleURL:        resp.Msg.Session.ConsoleUrl,
+	}
+
+	if resp.Msg.Session.ExpiresAt != nil {
+		session.ExpiresAt = resp.Msg.Session.ExpiresAt.AsTime().String()
+	}
+
+	return session, nil
+}
+
+// CloseSOLSessionWithToken closes an active SOL session using server-specific token
+func (c *RegionalGatewayClient) CloseSOLSessionWithToken(ctx context.Context, sessionID, serverToken string) error {
+	req := connect.NewRequest(&gatewayv1.CloseSOLSessionRequest{
+		SessionId: sessionID,
+	})
+	c.addAuthHeadersWithToken(req, serverToken)
+	_, err := c.client.CloseSOLSession(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to close SOL session: %w", err)
+	}
+	return nil
+}
+
+// ConsoleStreamOptions configures the handshake of a console stream
+type ConsoleStreamOptions struct {
+	// ForceTakeover disconnects the session currently attached to the
+	// server's SOL console instead of failing with a busy error
+	ForceTakeover bool
+}
+
 // StreamConsoleData opens a bidirectional stream for SOL console data
 func (c *RegionalGatewayClient) StreamConsoleData(ctx context.Context, sessionID, serverID string) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
+	return c.StreamConsoleDataWithOptions(ctx, sessionID, serverID, ConsoleStreamOptions{})
+}
+
+// StreamConsoleDataWithOptions opens a bidirectional stream for SOL console
+// data and waits for the gateway to connect to the console, so that errors
+// such as a busy console are returned here
+func (c *RegionalGatewayClient) StreamConsoleDataWithOptions(ctx context.Context, sessionID, serverID string, opts ConsoleStreamOptions) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
 	// Create bidirectional stream
 	stream := c.client.StreamConsoleData(ctx)
 
 	// Send initial handshake
 	handshake := &gatewayv1.ConsoleDataChunk{
-		SessionId:   sessionID,
-		ServerId:    serverID,
-		IsHandshake: true,
+		SessionId:     sessionID,
+		ServerId:      serverID,
+		IsHandshake:   true,
+		ForceTakeover: opts.ForceTakeover,
 	}
 
 	if err := stream.Send(handshake); err != nil {
 		return nil, fmt.Errorf("failed to send handshake: %w", err)
 	}
 
+	// Wait for the handshake acknowledgment
+	if _, err := stream.Receive(); err != nil {
+		_ = stream.CloseRequest()
+		return nil, err
+	}
+
 	return stream, nil
 }
 
 // StreamConsoleDataWithToken opens a bidirectional stream for SOL console data using server token
-func (c *RegionalGatewayClient) StreamConsoleDataWithToken(ctx context.Context, sessionID, serverID, serverToken string) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
+func (c *RegionalGatewayClient) StreamConsoleDataWithToken(ctx context.Context, sessionID, serverID, serverToken string, opts ConsoleStreamOptions) (*connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk], error) {
 	// Note: Connect doesn't support adding headers to streaming RPCs after creation
 	// We need to use HTTP interceptors or context metadata instead
 	// For now, we'll use the regular method and rely on session authentication
-	return c.StreamConsoleData(ctx, sessionID, serverID)
+	return c.StreamConsoleDataWithOptions(ctx, sessionID, serverID, opts)
 }
 
-func addAuthHeaders[T any](req *connect.Request[T], token string) {
+// addAuthHeaders sets the bearer token on any request, so that no RPC of the
+// gateway is sent without it
+func addAuthHeaders(req connect.AnyRequest, token string) {
 	if token != "" {
 		req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
 }
 
-func (c *RegionalGatewayClient) addAuthHeaders(req interface{}) {
+func (c *RegionalGatewayClient) addAuthHeaders(req connect.AnyRequest) {
 	// Use delegated token for gateway authentication
 	token := c.delegatedToken
 	if token == "" {
 		token = c.config.Auth.AccessToken // Fallback to config token
 	}
 
-	switch r := req.(type) {
-	case *connect.Request[gatewayv1.PowerOperationRequest]:
-		addAuthHeaders(r, token)
-	case *connect.Request[gatewayv1.PowerStatusRequest]:
-		addAuthHeaders(r, token)
-	case *connect.Request[gatewayv1.CreateVNCSessionRequest]:
-		addAuthHeaders(r, token)
-	case *connect.Request[gatewayv1.GetVNCSessionRequest]:
-		addAuthHeaders(r, token)
-	case *connect.Request[gatewayv1.CloseVNCSessionRequest]:
-		addAuthHeaders(r, token)
-	}
+	addAuthHeaders(req, token)
 }
 
-func (c *RegionalGatewayClient) addAuthHeadersWithToken(req interface{}, serverToken string) {
+func (c *RegionalGatewayClient) addAuthHeadersWithToken(req connect.AnyRequest, serverToken string) {
 	// Use server-specific token for gateway authentication
-	switch r := req.(type) {
-	case *connect.Request[gatewayv1.PowerOperationRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.PowerStatusRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.GetBMCInfoRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.CreateVNCSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.GetVNCSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.CloseVNCSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.CreateSOLSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.GetSOLSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	case *connect.Request[gatewayv1.CloseSOLSessionRequest]:
-		addAuthHeaders(r, serverToken)
-	}
+	addAuthHeaders(req, serverToken)
 }
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+
+	"cli/pkg/config"
+)
+
+// mockKeyGateway records the idempotency keys of power operations
+type mockKeyGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	keys []string
+}
+
+func (g *mockKeyGateway) PowerOn(
+	_ context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	g.keys = append(g.keys, req.Msg.IdempotencyKey)
+	return connect.NewResponse(&gatewayv1.PowerOperationResponse{Success: true}), nil
+}
+
+func TestClient_SetIdempotencyKey(t *testing.T) {
+	gateway := &mockKeyGateway{}
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(gateway)
+	gwServer := newH2CServer(t, path, handler)
+
+	bmcClient := New(&config.Config{})
+	gatewayClient := bmcClient.cachedGatewayClient(gwServer.URL)
+	ctx := context.Background()
+
+	require.NoError(t, gatewayClient.PowerOnWithToken(ctx, "server-1", "server-token"))
+
+	// Cached clients and clients created later take the key
+	bmcClient.SetIdempotencyKey("retry-1")
+	require.NoError(t, gatewayClient.PowerOnWithToken(ctx, "server-1", "server-token"))
+	delete(bmcClient.gatewayCache, gwServer.URL)
+	require.NoError(t, bmcClient.cachedGatewayClient(gwServer.URL).PowerOnWithToken(ctx, "server-1", "server-token"))
+
+	assert.Equal(t, []string{"", "retry-1", "retry-1"}, gateway.keys)
+}
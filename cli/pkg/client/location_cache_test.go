@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+	"manager/gen/manager/v1/managerv1connect"
+
+	"cli/pkg/config"
+)
+
+// mockLocationManager resolves servers to gateways and counts the lookups
+type mockLocationManager struct {
+	managerv1connect.UnimplementedBMCManagerServiceHandler
+	endpoints map[string]string
+	lookups   int
+}
+
+func (m *mockLocationManager) GetServerToken(
+	_ context.Context,
+	req *connect.Request[managerv1.GetServerTokenRequest],
+) (*connect.Response[managerv1.GetServerTokenResponse], error) {
+	return connect.NewResponse(&managerv1.GetServerTokenResponse{
+		Token:     "token-" + req.Msg.ServerId,
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}), nil
+}
+
+func (m *mockLocationManager) GetServerLocation(
+	_ context.Context,
+	req *connect.Request[managerv1.GetServerLocationRequest],
+) (*connect.Response[managerv1.GetServerLocationResponse], error) {
+	m.lookups++
+	endpoint, ok := m.endpoints[req.Msg.ServerId]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+	return connect.NewResponse(&managerv1.GetServerLocationResponse{
+		RegionalGatewayId:       "gateway-" + req.Msg.ServerId,
+		RegionalGatewayEndpoint: endpoint,
+	}), nil
+}
+
+// mockPowerGateway reports the power status of the servers it handles
+type mockPowerGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	servers map[string]bool
+}
+
+func (g *mockPowerGateway) GetPowerStatus(
+	_ context.Context,
+	req *connect.Request[gatewayv1.PowerStatusRequest],
+) (*connect.Response[gatewayv1.PowerStatusResponse], error) {
+	if !g.servers[req.Msg.ServerId] {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", req.Msg.ServerId))
+	}
+	return connect.NewResponse(&gatewayv1.PowerStatusResponse{State: gatewayv1.PowerState_POWER_STATE_ON}), nil
+}
+
+func TestClient_ServerLocationCache(t *testing.T) {
+	usGateway := &mockPowerGateway{servers: map[string]bool{"server-1": true}}
+	euGateway := &mockPowerGateway{servers: map[string]bool{}}
+
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(usGateway)
+	usServer := newH2CServer(t, path, handler)
+	path, handler = gatewayv1connect.NewGatewayServiceHandler(euGateway)
+	euServer := newH2CServer(t, path, handler)
+
+	manager := &mockLocationManager{endpoints: map[string]string{"server-1": usServer.URL}}
+	path, handler = managerv1connect.NewBMCManagerServiceHandler(manager)
+	managerServer := newH2CServer(t, path, handler)
+
+	cfg := &config.Config{
+		Manager: config.ManagerConfig{Endpoint: managerServer.URL},
+		Auth: config.AuthConfig{
+			AccessToken:    "access-token",
+			TokenExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+	store := config.NewLocationStore(filepath.Join(t.TempDir(), "locations.json"), time.Hour)
+	newClient := func() *Client {
+		client := New(cfg)
+		client.locationStore = store
+		return client
+	}
+
+	ctx := context.Background()
+
+	t.Run("lookup is reused", func(t *testing.T) {
+		client := newClient()
+		for i := 0; i < 2; i++ {
+			status, err := client.GetPowerStatus(ctx, "server-1")
+			require.NoError(t, err)
+			assert.Equal(t, "POWER_STATE_ON", status)
+		}
+		assert.Equal(t, 1, manager.lookups)
+	})
+
+	t.Run("lookup is reused across invocations", func(t *testing.T) {
+		_, err := newClient().GetPowerStatus(ctx, "server-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, manager.lookups)
+	})
+
+	t.Run("moved server is looked up again", func(t *testing.T) {
+		manager.endpoints["server-1"] = euServer.URL
+		usGateway.servers = map[string]bool{}
+		euGateway.servers = map[string]bool{"server-1": true}
+
+		_, err := newClient().GetPowerStatus(ctx, "server-1")
+		require.Error(t, err, "the cached gateway no longer handles the server")
+
+		cached, err := store.Get("server-1")
+		require.NoError(t, err)
+		assert.Nil(t, cached, "the stale location should be forgotten")
+
+		status, err := newClient().GetPowerStatus(ctx, "server-1")
+		require.NoError(t, err)
+		assert.Equal(t, "POWER_STATE_ON", status)
+		assert.Equal(t, 2, manager.lookups)
+	})
+
+	t.Run("disabled cache", func(t *testing.T) {
+		client := New(cfg)
+		assert.Nil(t, client.locationStore, "a zero TTL disables the location cache")
+	})
+}
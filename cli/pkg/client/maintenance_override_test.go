@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+
+	"cli/pkg/config"
+)
+
+// mockOverrideGateway records the maintenance overrides of power operations
+type mockOverrideGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	overrides []bool
+}
+
+func (g *mockOverrideGateway) PowerCycle(
+	_ context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	g.overrides = append(g.overrides, req.Msg.MaintenanceOverride)
+	return connect.NewResponse(&gatewayv1.PowerOperationResponse{Success: true}), nil
+}
+
+func (g *mockOverrideGateway) GracefulShutdown(
+	_ context.Context,
+	req *connect.Request[gatewayv1.GracefulShutdownRequest],
+) (*connect.Response[gatewayv1.GracefulShutdownResponse], error) {
+	g.overrides = append(g.overrides, req.Msg.MaintenanceOverride)
+	return connect.NewResponse(&gatewayv1.GracefulShutdownResponse{Success: true}), nil
+}
+
+func TestClient_SetMaintenanceOverride(t *testing.T) {
+	gateway := &mockOverrideGateway{}
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(gateway)
+	gwServer := newH2CServer(t, path, handler)
+
+	bmcClient := New(&config.Config{})
+	gatewayClient := bmcClient.cachedGatewayClient(gwServer.URL)
+	ctx := context.Background()
+
+	require.NoError(t, gatewayClient.PowerCycleWithToken(ctx, "server-1", "server-token"))
+
+	// Cached clients and clients created later take the override
+	bmcClient.SetMaintenanceOverride(true)
+	require.NoError(t, gatewayClient.PowerCycleWithToken(ctx, "server-1", "server-token"))
+	delete(bmcClient.gatewayCache, gwServer.URL)
+	_, err := bmcClient.cachedGatewayClient(gwServer.URL).GracefulShutdownWithToken(ctx, "server-1", 0, "server-token")
+	require.NoError(t, err)
+
+	assert.Equal(t, []bool{false, true, true}, gateway.overrides)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"core/domain"
@@ -20,6 +21,7 @@ import (
 // BMCManagerClient handles authentication and server location resolution
 type BMCManagerClient struct {
 	client     managerv1connect.BMCManagerServiceClient
+	admin      managerv1connect.AdminServiceClient
 	config     *config.Config
 	httpClient *http.Client
 }
@@ -30,6 +32,7 @@ func NewBMCManagerClient(cfg *config.Config) *BMCManagerClient {
 
 	return &BMCManagerClient{
 		client:     client,
+		admin:      managerv1connect.NewAdminServiceClient(httpClient, cfg.Manager.Endpoint),
 		config:     cfg,
 		httpClient: httpClient,
 	}
@@ -37,9 +40,16 @@ func NewBMCManagerClient(cfg *config.Config) *BMCManagerClient {
 
 // Authenticate performs initial authentication with BMC Manager
 func (c *BMCManagerClient) Authenticate(ctx context.Context, email, password string) (*AuthResult, error) {
+	return c.AuthenticateWithTOTP(ctx, email, password, "")
+}
+
+// AuthenticateWithTOTP authenticates an account with two-factor
+// authentication, totpCode being a code of its authenticator app
+func (c *BMCManagerClient) AuthenticateWithTOTP(ctx context.Context, email, password, totpCode string) (*AuthResult, error) {
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
 		Email:    email,
 		Password: password,
+		TotpCode: totpCode,
 	})
 
 	resp, err := c.client.Authenticate(ctx, req)
@@ -64,6 +74,78 @@ func (c *BMCManagerClient) Authenticate(ctx context.Context, email, password str
 	}, nil
 }
 
+// ChangePassword sets the password of the authenticated account.
+// currentPassword is empty for accounts without a password.
+func (c *BMCManagerClient) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	req := connect.NewRequest(&managerv1.ChangePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	c.addAuthHeaders(req)
+
+	if _, err := c.client.ChangePassword(ctx, req); err != nil {
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets the password of an account with the reset token an
+// admin issued, without authenticating
+func (c *BMCManagerClient) ResetPassword(ctx context.Context, email, resetToken, newPassword string) error {
+	req := connect.NewRequest(&managerv1.ResetPasswordRequest{
+		Email:       email,
+		ResetToken:  resetToken,
+		NewPassword: newPassword,
+	})
+
+	if _, err := c.client.ResetPassword(ctx, req); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	return nil
+}
+
+// IsTOTPCodeRequired reports whether authentication failed for lack of a
+// two-factor code, the password being right
+func IsTOTPCodeRequired(err error) bool {
+	return connect.CodeOf(err) == connect.CodeUnauthenticated && strings.Contains(err.Error(), "two-factor code required")
+}
+
+// EnrollTOTP generates a TOTP secret for the authenticated account, enabled
+// by ConfirmTOTP
+func (c *BMCManagerClient) EnrollTOTP(ctx context.Context) (*managerv1.EnrollTOTPResponse, error) {
+	req := connect.NewRequest(&managerv1.EnrollTOTPRequest{})
+	c.addAuthHeaders(req)
+
+	resp, err := c.client.EnrollTOTP(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll two-factor authentication: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// ConfirmTOTP enables two-factor authentication with a code of the
+// enrolled secret
+func (c *BMCManagerClient) ConfirmTOTP(ctx context.Context, code string) error {
+	req := connect.NewRequest(&managerv1.ConfirmTOTPRequest{Code: code})
+	c.addAuthHeaders(req)
+
+	if _, err := c.client.ConfirmTOTP(ctx, req); err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	return nil
+}
+
+// DisableTOTP disables two-factor authentication with a current code
+func (c *BMCManagerClient) DisableTOTP(ctx context.Context, code string) error {
+	req := connect.NewRequest(&managerv1.DisableTOTPRequest{Code: code})
+	c.addAuthHeaders(req)
+
+	if _, err := c.client.DisableTOTP(ctx, req); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	return nil
+}
+
 // RefreshToken refreshes the access token using the refresh token
 func (c *BMCManagerClient) RefreshToken(ctx context.Context) (*AuthResult, error) {
 	if c.config.Auth.RefreshToken == "" {
@@ -143,23 +225,44 @@ func (c *BMCManagerClient) ListGateways(ctx context.Context) ([]RegionalGateway,
 
 // ListServers returns all servers accessible to the authenticated customer
 func (c *BMCManagerClient) ListServers(ctx context.Context) ([]domain.Server, error) {
-	req := connect.NewRequest(&managerv1.ListServersRequest{})
+	servers, _, err := c.listServers(ctx, false)
+	return servers, err
+}
+
+// ListServersWithPowerState returns all servers accessible to the
+// authenticated customer, with their power state (on, off, cycling or
+// unknown) by server ID
+func (c *BMCManagerClient) ListServersWithPowerState(ctx context.Context) ([]domain.Server, map[string]string, error) {
+	return c.listServers(ctx, true)
+}
+
+// listServers lists the servers, querying their power states when
+// includePowerState is set
+func (c *BMCManagerClient) listServers(ctx context.Context, includePowerState bool) ([]domain.Server, map[string]string, error) {
+	req := connect.NewRequest(&managerv1.ListServersRequest{IncludePowerState: includePowerState})
 	c.addAuthHeaders(req)
 
 	resp, err := c.client.ListServers(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list servers: %w", err)
+		return nil, nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	var powerStates map[string]string
+	if includePowerState {
+		powerStates = make(map[string]string, len(resp.Msg.Servers))
 	}
 
 	var servers []domain.Server
 	for _, server := range resp.Msg.Servers {
 		clientServer := domain.Server{
-			ID:           server.Id,
-			CustomerID:   server.CustomerId,
-			DatacenterID: server.DatacenterId,
-			Features:     server.Features,
-			Status:       server.Status,
-			Metadata:     server.Metadata,
+			ID:              server.Id,
+			CustomerID:      server.CustomerId,
+			DatacenterID:    server.DatacenterId,
+			Features:        server.Features,
+			Status:          server.Status,
+			Metadata:        server.Metadata,
+			MaintenanceMode: server.MaintenanceMode,
+			Notes:           server.Notes,
 		}
 
 		// Convert control endpoints
@@ -219,9 +322,12 @@ func (c *BMCManagerClient) ListServers(ctx context.Context) ([]domain.Server, er
 		}
 
 		servers = append(servers, clientServer)
+		if powerStates != nil {
+			powerStates[server.Id] = server.PowerState
+		}
 	}
 
-	return servers, nil
+	return servers, powerStates, nil
 }
 
 // GetServer returns detailed information about a specific server
@@ -238,12 +344,14 @@ func (c *BMCManagerClient) GetServer(ctx context.Context, serverID string) (*dom
 
 	server := resp.Msg.Server
 	clientServer := &domain.Server{
-		ID:           server.Id,
-		CustomerID:   server.CustomerId,
-		DatacenterID: server.DatacenterId,
-		Features:     server.Features,
-		Status:       server.Status,
-		Metadata:     server.Metadata,
+		ID:              server.Id,
+		CustomerID:      server.CustomerId,
+		DatacenterID:    server.DatacenterId,
+		Features:        server.Features,
+		Status:          server.Status,
+		Metadata:        server.Metadata,
+		MaintenanceMode: server.MaintenanceMode,
+		Notes:           server.Notes,
 	}
 
 	// Convert control endpoints
@@ -343,6 +451,271 @@ func (c *BMCManagerClient) GetServerToken(ctx context.Context, serverID string)
 	}, nil
 }
 
+// ExportUsage exports the usage of customers over a month, e.g. 2024-06, as
+// a csv or json document (requires an admin account). An empty customerID
+// exports all customers.
+func (c *BMCManagerClient) ExportUsage(ctx context.Context, month, format, customerID string) (*managerv1.ExportUsageResponse, error) {
+	req := connect.NewRequest(&managerv1.ExportUsageRequest{
+		Month:      month,
+		Format:     format,
+		CustomerId: customerID,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ExportUsage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export usage: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// ListGatewayHealth returns the gateways registered with the manager and
+// their health, including those awaiting approval (requires an admin account)
+func (c *BMCManagerClient) ListGatewayHealth(ctx context.Context) ([]*managerv1.GatewayHealth, error) {
+	req := connect.NewRequest(&managerv1.GetGatewayHealthRequest{})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.GetGatewayHealth(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gateways: %w", err)
+	}
+	return resp.Msg.Gateways, nil
+}
+
+// ApproveGateway approves a gateway awaiting approval (requires an admin
+// account)
+func (c *BMCManagerClient) ApproveGateway(ctx context.Context, gatewayID string) (*managerv1.GatewayHealth, error) {
+	req := connect.NewRequest(&managerv1.ApproveGatewayRequest{GatewayId: gatewayID})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ApproveGateway(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve gateway: %w", err)
+	}
+	return resp.Msg.Gateway, nil
+}
+
+// ListCustomers returns the customers with their server counts (requires an
+// admin account)
+func (c *BMCManagerClient) ListCustomers(ctx context.Context) ([]*managerv1.CustomerSummary, error) {
+	req := connect.NewRequest(&managerv1.ListAllCustomersRequest{PageSize: 500})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ListAllCustomers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customers: %w", err)
+	}
+	return resp.Msg.Customers, nil
+}
+
+// CreateCustomer creates a customer account, returning its API key (requires
+// an admin account). An empty customerID identifies the customer by email.
+func (c *BMCManagerClient) CreateCustomer(ctx context.Context, email, customerID string, isAdmin bool) (*managerv1.CreateCustomerResponse, error) {
+	req := connect.NewRequest(&managerv1.CreateCustomerRequest{
+		Email:      email,
+		CustomerId: customerID,
+		IsAdmin:    isAdmin,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.CreateCustomer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// IssueAPIKey issues a new API key to a customer, revoking its previous key
+// (requires an admin account)
+func (c *BMCManagerClient) IssueAPIKey(ctx context.Context, customerID string) (string, error) {
+	req := connect.NewRequest(&managerv1.IssueAPIKeyRequest{CustomerId: customerID})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.IssueAPIKey(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue API key: %w", err)
+	}
+	return resp.Msg.ApiKey, nil
+}
+
+// ResetCustomerPassword issues a password reset token to a customer
+// (requires an admin account)
+func (c *BMCManagerClient) ResetCustomerPassword(ctx context.Context, customerID string) (*managerv1.ResetCustomerPasswordResponse, error) {
+	req := connect.NewRequest(&managerv1.ResetCustomerPasswordRequest{CustomerId: customerID})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ResetCustomerPassword(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset customer password: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// SetCustomerMFAPolicy sets whether the console sessions of a customer
+// require two-factor authentication, resetting its authenticator with
+// resetTOTP (requires an admin account)
+func (c *BMCManagerClient) SetCustomerMFAPolicy(ctx context.Context, customerID string, requireMFA, resetTOTP bool) (*managerv1.SetCustomerMFAPolicyResponse, error) {
+	req := connect.NewRequest(&managerv1.SetCustomerMFAPolicyRequest{
+		CustomerId: customerID,
+		RequireMfa: requireMFA,
+		ResetTotp:  resetTOTP,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.SetCustomerMFAPolicy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set two-factor policy: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// SetCustomerIPAllowlist sets the source IP ranges gateways accept for the
+// BMCs of a customer, any without cidrs (requires an admin account)
+func (c *BMCManagerClient) SetCustomerIPAllowlist(ctx context.Context, customerID string, cidrs []string) (*managerv1.SetCustomerIPAllowlistResponse, error) {
+	req := connect.NewRequest(&managerv1.SetCustomerIPAllowlistRequest{
+		CustomerId: customerID,
+		Cidrs:      cidrs,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.SetCustomerIPAllowlist(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set IP allowlist: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// DisableCustomer disables a customer, or re-enables it with enable
+// (requires an admin account)
+func (c *BMCManagerClient) DisableCustomer(ctx context.Context, customerID, reason string, enable bool) (*managerv1.DisableCustomerResponse, error) {
+	req := connect.NewRequest(&managerv1.DisableCustomerRequest{
+		CustomerId: customerID,
+		Reason:     reason,
+		Enable:     enable,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.DisableCustomer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable customer: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// DeleteCustomer deletes a customer, assigning its servers to reassignTo
+// (requires an admin account)
+func (c *BMCManagerClient) DeleteCustomer(ctx context.Context, customerID, reassignTo, reason string) (*managerv1.DeleteCustomerResponse, error) {
+	req := connect.NewRequest(&managerv1.DeleteCustomerRequest{
+		CustomerId: customerID,
+		ReassignTo: reassignTo,
+		Reason:     reason,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.DeleteCustomer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete customer: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// AssignServer assigns a server to a customer (requires an admin account)
+func (c *BMCManagerClient) AssignServer(ctx context.Context, serverID, customerID string) (*managerv1.AssignServerResponse, error) {
+	req := connect.NewRequest(&managerv1.AssignServerRequest{ServerId: serverID, CustomerId: customerID})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.AssignServer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign server: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// ImportServers registers the servers of an inventory document, csv or yaml
+// (requires an admin account). Dry runs only validate it.
+func (c *BMCManagerClient) ImportServers(ctx context.Context, data []byte, format string, dryRun bool) (*managerv1.ImportServersResponse, error) {
+	req := connect.NewRequest(&managerv1.ImportServersRequest{
+		Data:   data,
+		Format: format,
+		DryRun: dryRun,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ImportServers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import servers: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// ExportServers exports the servers as a csv or yaml inventory document
+// (requires an admin account). An empty customerID exports all customers.
+func (c *BMCManagerClient) ExportServers(ctx context.Context, format, customerID string) (*managerv1.ExportServersResponse, error) {
+	req := connect.NewRequest(&managerv1.ExportServersRequest{
+		Format:         format,
+		CustomerFilter: customerID,
+	})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ExportServers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export servers: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// ListAuditEvents returns the changes made by admins, newest first (requires
+// an admin account)
+func (c *BMCManagerClient) ListAuditEvents(ctx context.Context, filter *managerv1.ListAuditEventsRequest) ([]*managerv1.AuditEvent, error) {
+	req := connect.NewRequest(filter)
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ListAuditEvents(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return resp.Msg.Events, nil
+}
+
+// ListConnectivityAudits returns the last connectivity audit of each server
+// (requires an admin account)
+func (c *BMCManagerClient) ListConnectivityAudits(ctx context.Context, filter *managerv1.ListConnectivityAuditsRequest) (*managerv1.ListConnectivityAuditsResponse, error) {
+	req := connect.NewRequest(filter)
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.ListConnectivityAudits(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connectivity audits: %w", err)
+	}
+	return resp.Msg, nil
+}
+
+// RunConnectivityAudit audits the connectivity of a server now (requires an
+// admin account)
+func (c *BMCManagerClient) RunConnectivityAudit(ctx context.Context, serverID string) (*managerv1.ConnectivityAudit, error) {
+	req := connect.NewRequest(&managerv1.RunConnectivityAuditRequest{ServerId: serverID})
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.RunConnectivityAudit(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run connectivity audit: %w", err)
+	}
+	return resp.Msg.Audit, nil
+}
+
+// SetLogLevel changes the log levels of the manager; an empty request returns
+// the current levels (requires an admin account)
+func (c *BMCManagerClient) SetLogLevel(ctx context.Context, change *managerv1.SetLogLevelRequest) (*managerv1.SetLogLevelResponse, error) {
+	req := connect.NewRequest(change)
+	c.addAuthHeaders(req)
+
+	resp, err := c.admin.SetLogLevel(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set log level: %w", err)
+	}
+	return resp.Msg, nil
+}
+
 func addAuthHeadersManager[T any](req *connect.Request[T], token string) {
 	if token != "" {
 		req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -363,6 +736,50 @@ func (c *BMCManagerClient) addAuthHeaders(req interface{}) {
 		addAuthHeadersManager(r, c.config.Auth.AccessToken)
 	case *connect.Request[managerv1.GetServerTokenRequest]:
 		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ExportUsageRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.GetGatewayHealthRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ApproveGatewayRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ListAllCustomersRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.CreateCustomerRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.IssueAPIKeyRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ResetCustomerPasswordRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ChangePasswordRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.EnrollTOTPRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ConfirmTOTPRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.DisableTOTPRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.SetCustomerMFAPolicyRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.SetCustomerIPAllowlistRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.DisableCustomerRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.DeleteCustomerRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.AssignServerRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ListAuditEventsRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.SetLogLevelRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ImportServersRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ExportServersRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.ListConnectivityAuditsRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
+	case *connect.Request[managerv1.RunConnectivityAuditRequest]:
+		addAuthHeadersManager(r, c.config.Auth.AccessToken)
 	}
 }
 
@@ -2,14 +2,17 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	managerv1 "manager/gen/manager/v1"
+	"manager/gen/manager/v1/managerv1connect"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"cli/pkg/config"
 
@@ -314,3 +317,265 @@ func TestBMCManagerClient_RegressAuthHeaderMissing(t *testing.T) {
 		"REGRESSION: Authorization header should be sent with ListServers request. "+
 			"This test guards against the type matching bug that caused 'missing authorization header' errors.")
 }
+
+// powerStateManager serves ListServers, with power states when requested
+type powerStateManager struct {
+	managerv1connect.UnimplementedBMCManagerServiceHandler
+}
+
+func (m *powerStateManager) ListServers(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListServersRequest],
+) (*connect.Response[managerv1.ListServersResponse], error) {
+	servers := []*managerv1.Server{{Id: "server-1"}, {Id: "server-2"}}
+	if req.Msg.IncludePowerState {
+		servers[0].PowerState = "on"
+		servers[1].PowerState = "unknown"
+	}
+	return connect.NewResponse(&managerv1.ListServersResponse{Servers: servers}), nil
+}
+
+func TestBMCManagerClient_ListServersWithPowerState(t *testing.T) {
+	_, handler := managerv1connect.NewBMCManagerServiceHandler(&powerStateManager{})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewBMCManagerClient(&config.Config{
+		Manager: config.ManagerConfig{Endpoint: server.URL},
+		Auth:    config.AuthConfig{AccessToken: "test-token", TokenExpiresAt: time.Now().Add(time.Hour)},
+	})
+
+	servers, powerStates, err := client.ListServersWithPowerState(context.Background())
+	if err != nil {
+		t.Fatalf("ListServersWithPowerState failed: %v", err)
+	}
+	assert.Len(t, servers, 2)
+	assert.Equal(t, map[string]string{"server-1": "on", "server-2": "unknown"}, powerStates)
+
+	servers, err = client.ListServers(context.Background())
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+	assert.Len(t, servers, 2)
+}
+
+// usageAdmin is an admin service exporting usage
+type usageAdmin struct {
+	managerv1connect.UnimplementedAdminServiceHandler
+	authorization string
+	request       *managerv1.ExportUsageRequest
+}
+
+func (a *usageAdmin) ExportUsage(ctx context.Context, req *connect.Request[managerv1.ExportUsageRequest]) (*connect.Response[managerv1.ExportUsageResponse], error) {
+	a.authorization = req.Header().Get("Authorization")
+	a.request = req.Msg
+	return connect.NewResponse(&managerv1.ExportUsageResponse{
+		Month:       req.Msg.Month,
+		ContentType: "text/csv",
+		Data:        []byte("month,customer_id\n2024-06,customer-1\n"),
+	}), nil
+}
+
+func TestBMCManagerClient_ExportUsage(t *testing.T) {
+	admin := &usageAdmin{}
+	_, handler := managerv1connect.NewAdminServiceHandler(admin)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewBMCManagerClient(&config.Config{
+		Manager: config.ManagerConfig{Endpoint: server.URL},
+		Auth:    config.AuthConfig{AccessToken: "test-token", TokenExpiresAt: time.Now().Add(time.Hour)},
+	})
+
+	resp, err := client.ExportUsage(context.Background(), "2024-06", "csv", "customer-1")
+	if err != nil {
+		t.Fatalf("ExportUsage failed: %v", err)
+	}
+	assert.Equal(t, "Bearer test-token", admin.authorization)
+	assert.Equal(t, "2024-06", admin.request.Month)
+	assert.Equal(t, "csv", admin.request.Format)
+	assert.Equal(t, "customer-1", admin.request.CustomerId)
+	assert.Equal(t, "month,customer_id\n2024-06,customer-1\n", string(resp.Data))
+}
+
+// customerAdmin is an admin service creating customers and assigning servers
+type customerAdmin struct {
+	managerv1connect.UnimplementedAdminServiceHandler
+	authorization []string
+}
+
+func (a *customerAdmin) CreateCustomer(ctx context.Context, req *connect.Request[managerv1.CreateCustomerRequest]) (*connect.Response[managerv1.CreateCustomerResponse], error) {
+	a.authorization = append(a.authorization, req.Header().Get("Authorization"))
+	return connect.NewResponse(&managerv1.CreateCustomerResponse{
+		Customer: &managerv1.CustomerSummary{CustomerId: req.Msg.CustomerId, Email: req.Msg.Email, IsAdmin: req.Msg.IsAdmin},
+		ApiKey:   "bmc_key",
+	}), nil
+}
+
+func (a *customerAdmin) AssignServer(ctx context.Context, req *connect.Request[managerv1.AssignServerRequest]) (*connect.Response[managerv1.AssignServerResponse], error) {
+	a.authorization = append(a.authorization, req.Header().Get("Authorization"))
+	if req.Msg.ServerId != "server-1" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found"))
+	}
+	return connect.NewResponse(&managerv1.AssignServerResponse{
+		Server:             &managerv1.Server{Id: req.Msg.ServerId, CustomerId: req.Msg.CustomerId},
+		PreviousCustomerId: "customer-1",
+	}), nil
+}
+
+func (a *customerAdmin) SetCustomerIPAllowlist(ctx context.Context, req *connect.Request[managerv1.SetCustomerIPAllowlistRequest]) (*connect.Response[managerv1.SetCustomerIPAllowlistResponse], error) {
+	a.authorization = append(a.authorization, req.Header().Get("Authorization"))
+	return connect.NewResponse(&managerv1.SetCustomerIPAllowlistResponse{CustomerId: req.Msg.CustomerId, Cidrs: req.Msg.Cidrs}), nil
+}
+
+func TestBMCManagerClient_CustomerAdministration(t *testing.T) {
+	admin := &customerAdmin{}
+	_, handler := managerv1connect.NewAdminServiceHandler(admin)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewBMCManagerClient(&config.Config{
+		Manager: config.ManagerConfig{Endpoint: server.URL},
+		Auth:    config.AuthConfig{AccessToken: "test-token", TokenExpiresAt: time.Now().Add(time.Hour)},
+	})
+	ctx := context.Background()
+
+	created, err := client.CreateCustomer(ctx, "ops@example.com", "customer-2", true)
+	if err != nil {
+		t.Fatalf("CreateCustomer failed: %v", err)
+	}
+	assert.Equal(t, "customer-2", created.Customer.CustomerId)
+	assert.True(t, created.Customer.IsAdmin)
+	assert.Equal(t, "bmc_key", created.ApiKey)
+
+	assigned, err := client.AssignServer(ctx, "server-1", "customer-2")
+	if err != nil {
+		t.Fatalf("AssignServer failed: %v", err)
+	}
+	assert.Equal(t, "customer-2", assigned.Server.CustomerId)
+	assert.Equal(t, "customer-1", assigned.PreviousCustomerId)
+
+	_, err = client.AssignServer(ctx, "server-2", "customer-2")
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	allowlist, err := client.SetCustomerIPAllowlist(ctx, "customer-2", []string{"10.8.0.0/16"})
+	if err != nil {
+		t.Fatalf("SetCustomerIPAllowlist failed: %v", err)
+	}
+	assert.Equal(t, []string{"10.8.0.0/16"}, allowlist.Cidrs)
+
+	assert.Equal(t, []string{"Bearer test-token", "Bearer test-token", "Bearer test-token", "Bearer test-token"}, admin.authorization)
+}
+
+// passwordManager is a manager service changing and resetting passwords
+type passwordManager struct {
+	managerv1connect.UnimplementedBMCManagerServiceHandler
+	authorization []string
+	changed       *managerv1.ChangePasswordRequest
+	reset         *managerv1.ResetPasswordRequest
+}
+
+func (m *passwordManager) ChangePassword(ctx context.Context, req *connect.Request[managerv1.ChangePasswordRequest]) (*connect.Response[managerv1.ChangePasswordResponse], error) {
+	m.authorization = append(m.authorization, req.Header().Get("Authorization"))
+	m.changed = req.Msg
+	return connect.NewResponse(&managerv1.ChangePasswordResponse{}), nil
+}
+
+func (m *passwordManager) ResetPassword(ctx context.Context, req *connect.Request[managerv1.ResetPasswordRequest]) (*connect.Response[managerv1.ResetPasswordResponse], error) {
+	m.authorization = append(m.authorization, req.Header().Get("Authorization"))
+	m.reset = req.Msg
+	return connect.NewResponse(&managerv1.ResetPasswordResponse{}), nil
+}
+
+func TestBMCManagerClient_Passwords(t *testing.T) {
+	manager := &passwordManager{}
+	_, handler := managerv1connect.NewBMCManagerServiceHandler(manager)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := NewBMCManagerClient(&config.Config{
+		Manager: config.ManagerConfig{Endpoint: server.URL},
+		Auth:    config.AuthConfig{AccessToken: "test-token", TokenExpiresAt: time.Now().Add(time.Hour)},
+	})
+	ctx := context.Background()
+
+	if err := client.ChangePassword(ctx, "old password", "new password"); err != nil {
+		t.Fatalf("ChangePassword failed: %v", err)
+	}
+	assert.Equal(t, "old password", manager.changed.CurrentPassword)
+	assert.Equal(t, "new password", manager.changed.NewPassword)
+
+	if err := client.ResetPassword(ctx, "ops@example.com", "reset-token", "newer password"); err != nil {
+		t.Fatalf("ResetPassword failed: %v", err)
+	}
+	assert.Equal(t, "reset-token", manager.reset.ResetToken)
+
+	// Resets authenticate with their token alone
+	assert.Equal(t, []string{"Bearer test-token", ""}, manager.authorization)
+}
+
+// twoFactorManager is a manager service requiring two-factor codes
+type twoFactorManager struct {
+	managerv1connect.UnimplementedBMCManagerServiceHandler
+	authorization []string
+}
+
+func (m *twoFactorManager) Authenticate(ctx context.Context, req *connect.Request[managerv1.AuthenticateRequest]) (*connect.Response[managerv1.AuthenticateResponse], error) {
+	if req.Msg.TotpCode == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("two-factor code required"))
+	}
+	return connect.NewResponse(&managerv1.AuthenticateResponse{
+		AccessToken: "mfa-token",
+		ExpiresAt:   timestamppb.New(time.Now().Add(time.Hour)),
+		Customer:    &managerv1.Customer{Id: "customer-1", Email: req.Msg.Email},
+	}), nil
+}
+
+func (m *twoFactorManager) EnrollTOTP(ctx context.Context, req *connect.Request[managerv1.EnrollTOTPRequest]) (*connect.Response[managerv1.EnrollTOTPResponse], error) {
+	m.authorization = append(m.authorization, req.Header().Get("Authorization"))
+	return connect.NewResponse(&managerv1.EnrollTOTPResponse{Secret: "SECRET"}), nil
+}
+
+func (m *twoFactorManager) ConfirmTOTP(ctx context.Context, req *connect.Request[managerv1.ConfirmTOTPRequest]) (*connect.Response[managerv1.ConfirmTOTPResponse], error) {
+	m.authorization = append(m.authorization, req.Header().Get("Authorization"))
+	return connect.NewResponse(&managerv1.ConfirmTOTPResponse{}), nil
+}
+
+func (m *twoFactorManager) DisableTOTP(ctx context.Context, req *connect.Request[managerv1.DisableTOTPRequest]) (*connect.Response[managerv1.DisableTOTPResponse], error) {
+	m.authorization = append(m.authorization, req.Header().Get("Authorization"))
+	return connect.NewResponse(&managerv1.DisableTOTPResponse{}), nil
+}
+
+func TestBMCManagerClient_TwoFactor(t *testing.T) {
+	manager := &twoFactorManager{}
+	_, handler := managerv1connect.NewBMCManagerServiceHandler(manager)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	cfg := &config.Config{
+		Manager: config.ManagerConfig{Endpoint: server.URL},
+		Auth:    config.AuthConfig{AccessToken: "test-token", TokenExpiresAt: time.Now().Add(time.Hour)},
+	}
+	client := NewBMCManagerClient(cfg)
+	ctx := context.Background()
+
+	enrolled, err := client.EnrollTOTP(ctx)
+	if err != nil {
+		t.Fatalf("EnrollTOTP failed: %v", err)
+	}
+	assert.Equal(t, "SECRET", enrolled.Secret)
+	if err := client.ConfirmTOTP(ctx, "123456"); err != nil {
+		t.Fatalf("ConfirmTOTP failed: %v", err)
+	}
+	if err := client.DisableTOTP(ctx, "654321"); err != nil {
+		t.Fatalf("DisableTOTP failed: %v", err)
+	}
+	assert.Equal(t, []string{"Bearer test-token", "Bearer test-token", "Bearer test-token"}, manager.authorization)
+
+	_, err = client.Authenticate(ctx, "ops@example.com", "password")
+	assert.True(t, IsTOTPCodeRequired(err))
+	if _, err := client.AuthenticateWithTOTP(ctx, "ops@example.com", "password", "123456"); err != nil {
+		t.Fatalf("AuthenticateWithTOTP failed: %v", err)
+	}
+	assert.Equal(t, "mfa-token", cfg.Auth.AccessToken)
+}
@@ -8,9 +8,13 @@ import (
 	"testing"
 	"time"
 
-	"cli/pkg/config"
-
+	"connectrpc.com/connect"
 	"github.com/stretchr/testify/assert"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+
+	"cli/pkg/config"
 )
 
 // TestRegression_TokenExpirationAndAuthHeaders is a comprehensive test that guards
@@ -233,3 +237,33 @@ func TestRegression_MissingAuthHeaderTypeBug(t *testing.T) {
 	assert.Contains(t, authHeaderReceived, "Bearer test-token-should-be-sent",
 		"Authorization header should contain the correct token")
 }
+
+// authGateway records the Authorization header of the requests it serves
+type authGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	headers []string
+}
+
+func (g *authGateway) SetChassisIdentify(
+	_ context.Context,
+	req *connect.Request[gatewayv1.SetChassisIdentifyRequest],
+) (*connect.Response[gatewayv1.SetChassisIdentifyResponse], error) {
+	g.headers = append(g.headers, req.Header().Get("Authorization"))
+	return connect.NewResponse(&gatewayv1.SetChassisIdentifyResponse{Success: true}), nil
+}
+
+// TestRegression_GatewayAuthHeaderTypeBug guards requests left out of a type
+// switch on the request types, which reached the gateway without the server
+// token
+func TestRegression_GatewayAuthHeaderTypeBug(t *testing.T) {
+	gateway := &authGateway{}
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(gateway)
+	gwServer := newH2CServer(t, path, handler)
+
+	gatewayClient := NewRegionalGatewayClient(&config.Config{}, gwServer.URL, "")
+	_, err := gatewayClient.SetChassisIdentifyWithToken(context.Background(), "server-1", gatewayv1.IdentifyState_IDENTIFY_STATE_ON, 0, "server-token")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Bearer server-token"}, gateway.headers,
+		"REGRESSION: Every gateway request should carry the server token")
+}
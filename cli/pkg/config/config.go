@@ -15,6 +15,10 @@ type Config struct {
 	Auth    AuthConfig    `mapstructure:"auth"`
 	// Legacy gateway config for backward compatibility
 	Gateway GatewayConfig `mapstructure:"gateway"`
+	Console ConsoleConfig `mapstructure:"console"`
+	// Language of the messages printed by commands ("en" or "ja"), defaults
+	// to the language of the locale environment (LC_ALL, LC_MESSAGES, LANG)
+	Language string `mapstructure:"language"`
 }
 
 type ManagerConfig struct {
@@ -23,6 +27,15 @@ type ManagerConfig struct {
 
 type GatewayConfig struct {
 	URL string `mapstructure:"url"`
+	// LocationCacheTTL is how long the regional gateway of a server, resolved
+	// through the manager, is reused by later commands. Zero disables the cache.
+	LocationCacheTTL time.Duration `mapstructure:"location_cache_ttl"`
+}
+
+type ConsoleConfig struct {
+	// EscapeKey starts console escape sequences, in caret notation ("^]"),
+	// as a single character, or "none" to disable them
+	EscapeKey string `mapstructure:"escape_key"`
 }
 
 type AuthConfig struct {
@@ -59,10 +72,15 @@ func Load() (*Config, error) {
 	viper.BindEnv("auth.email")
 	viper.BindEnv("auth.api_key")
 	viper.BindEnv("gateway.url")
+	viper.BindEnv("gateway.location_cache_ttl")
+	viper.BindEnv("console.escape_key")
+	viper.BindEnv("language")
 
 	// Set defaults
 	viper.SetDefault("manager.endpoint", "http://localhost:8080")
 	viper.SetDefault("gateway.url", "http://localhost:8081") // Legacy - Gateway on 8081
+	viper.SetDefault("gateway.location_cache_ttl", "10m")
+	viper.SetDefault("console.escape_key", "^]")
 
 	// Read config file if it exists
 	if err := viper.ReadInConfig(); err != nil {
@@ -96,12 +114,15 @@ func (c *Config) Save() error {
 	// Update viper with current config values
 	viper.Set("manager.endpoint", c.Manager.Endpoint)
 	viper.Set("gateway.url", c.Gateway.URL)
+	viper.Set("gateway.location_cache_ttl", c.Gateway.LocationCacheTTL.String())
 	viper.Set("auth.access_token", c.Auth.AccessToken)
 	viper.Set("auth.refresh_token", c.Auth.RefreshToken)
 	viper.Set("auth.token_expires_at", c.Auth.TokenExpiresAt)
 	viper.Set("auth.email", c.Auth.Email)
 	viper.Set("auth.api_key", c.Auth.APIKey)
 	viper.Set("auth.token", c.Auth.Token)
+	viper.Set("console.escape_key", c.Console.EscapeKey)
+	viper.Set("language", c.Language)
 
 	return viper.WriteConfig()
 }
@@ -32,6 +32,14 @@ func TestConfig_Load(t *testing.T) {
 	if config.Gateway.URL != "http://localhost:8081" {
 		t.Errorf("Expected default Gateway URL 'http://localhost:8081', got '%s'", config.Gateway.URL)
 	}
+
+	if config.Gateway.LocationCacheTTL != 10*time.Minute {
+		t.Errorf("Expected default location cache TTL 10m, got %s", config.Gateway.LocationCacheTTL)
+	}
+
+	if config.Console.EscapeKey != "^]" {
+		t.Errorf("Expected default console escape key '^]', got '%s'", config.Console.EscapeKey)
+	}
 }
 
 func TestConfig_LoadWithFile(t *testing.T) {
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CachedLocation records which regional gateway handles a server, as
+// resolved by the BMC Manager
+type CachedLocation struct {
+	ServerID        string    `json:"server_id"`
+	GatewayID       string    `json:"gateway_id"`
+	GatewayEndpoint string    `json:"gateway_endpoint"`
+	DatacenterID    string    `json:"datacenter_id,omitempty"`
+	CachedAt        time.Time `json:"cached_at"`
+}
+
+// LocationStore caches server locations across CLI invocations, so that
+// commands reach the regional gateway of a server without asking the manager
+// every time
+type LocationStore struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewLocationStore creates a location store backed by the file at path,
+// whose entries are used for ttl
+func NewLocationStore(path string, ttl time.Duration) *LocationStore {
+	return &LocationStore{path: path, ttl: ttl, now: time.Now}
+}
+
+// DefaultLocationStore returns the location store in the CLI config directory
+func DefaultLocationStore(ttl time.Duration) (*LocationStore, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return NewLocationStore(filepath.Join(homeDir, ".bmc-cli", "locations.json"), ttl), nil
+}
+
+// Get returns the location of a server, or nil if it is not cached or has
+// expired
+func (s *LocationStore) Get(serverID string) (*CachedLocation, error) {
+	locations, err := s.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, location := range locations {
+		if location.ServerID == serverID {
+			return &location, nil
+		}
+	}
+	return nil, nil
+}
+
+// Put caches the location of a server, replacing any previous one. Expired
+// locations are pruned.
+func (s *LocationStore) Put(location CachedLocation) error {
+	locations, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	if location.CachedAt.IsZero() {
+		location.CachedAt = s.now()
+	}
+	kept := []CachedLocation{location}
+	for _, existing := range locations {
+		if existing.ServerID != location.ServerID {
+			kept = append(kept, existing)
+		}
+	}
+	return s.save(kept)
+}
+
+// RemoveEndpoint forgets the locations of all servers handled by the gateway
+// at endpoint, for when that gateway no longer answers for them
+func (s *LocationStore) RemoveEndpoint(endpoint string) error {
+	locations, err := s.list()
+	if err != nil {
+		return err
+	}
+
+	kept := make([]CachedLocation, 0, len(locations))
+	for _, location := range locations {
+		if location.GatewayEndpoint != endpoint {
+			kept = append(kept, location)
+		}
+	}
+	if len(kept) == len(locations) {
+		return nil
+	}
+	return s.save(kept)
+}
+
+// list returns the locations that have not expired
+func (s *LocationStore) list() ([]CachedLocation, error) {
+	locations, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	active := make([]CachedLocation, 0, len(locations))
+	for _, location := range locations {
+		if now.Sub(location.CachedAt) < s.ttl {
+			active = append(active, location)
+		}
+	}
+	return active, nil
+}
+
+func (s *LocationStore) load() ([]CachedLocation, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read location cache: %w", err)
+	}
+
+	var locations []CachedLocation
+	if err := json.Unmarshal(data, &locations); err != nil {
+		return nil, fmt.Errorf("failed to parse location cache %s: %w", s.path, err)
+	}
+	return locations, nil
+}
+
+func (s *LocationStore) save(locations []CachedLocation) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(locations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode location cache: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write location cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLocationStore_PutGetRemoveEndpoint(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	store := NewLocationStore(filepath.Join(t.TempDir(), "nested", "locations.json"), 10*time.Minute)
+	store.now = func() time.Time { return now }
+
+	// A missing file is an empty store
+	location, err := store.Get("server-001")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if location != nil {
+		t.Fatalf("Expected no location, got %+v", location)
+	}
+
+	locations := []CachedLocation{
+		{ServerID: "server-001", GatewayID: "gateway-us", GatewayEndpoint: "http://gw-us:8081"},
+		{ServerID: "server-002", GatewayID: "gateway-us", GatewayEndpoint: "http://gw-us:8081"},
+		{ServerID: "server-003", GatewayID: "gateway-eu", GatewayEndpoint: "http://gw-eu:8081"},
+		{ServerID: "server-004", GatewayID: "gateway-eu", GatewayEndpoint: "http://gw-eu:8081", CachedAt: now.Add(-time.Hour)},
+	}
+	for _, location := range locations {
+		if err := store.Put(location); err != nil {
+			t.Fatalf("Put(%s) failed: %v", location.ServerID, err)
+		}
+	}
+
+	location, err = store.Get("server-003")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if location == nil || location.GatewayEndpoint != "http://gw-eu:8081" || !location.CachedAt.Equal(now) {
+		t.Errorf("Expected server-003 on gateway-eu cached now, got %+v", location)
+	}
+
+	if location, _ := store.Get("server-004"); location != nil {
+		t.Errorf("Expected expired location to be dropped, got %+v", location)
+	}
+
+	// Putting an existing location replaces it
+	if err := store.Put(CachedLocation{ServerID: "server-001", GatewayID: "gateway-eu", GatewayEndpoint: "http://gw-eu:8081"}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if err := store.RemoveEndpoint("http://gw-us:8081"); err != nil {
+		t.Fatalf("RemoveEndpoint failed: %v", err)
+	}
+	if location, _ := store.Get("server-002"); location != nil {
+		t.Errorf("Expected the locations of gateway-us to be removed, got %+v", location)
+	}
+	if location, _ := store.Get("server-001"); location == nil || location.GatewayID != "gateway-eu" {
+		t.Errorf("Expected server-001 to be kept on gateway-eu, got %+v", location)
+	}
+
+	// Locations persist across stores and expire after the TTL
+	reopened := NewLocationStore(store.path, 10*time.Minute)
+	reopened.now = func() time.Time { return now.Add(5 * time.Minute) }
+	if location, _ := reopened.Get("server-003"); location == nil {
+		t.Error("Expected server-003 to be read back")
+	}
+	reopened.now = func() time.Time { return now.Add(10 * time.Minute) }
+	if location, _ := reopened.Get("server-003"); location != nil {
+		t.Errorf("Expected server-003 to expire, got %+v", location)
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DetachedSession records a console session that was detached from and is
+// still open on the gateway
+type DetachedSession struct {
+	ID         string    `json:"id"`
+	ServerID   string    `json:"server_id"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	DetachedAt time.Time `json:"detached_at"`
+}
+
+// Expired reports whether the gateway has dropped the session
+func (s DetachedSession) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && now.After(s.ExpiresAt)
+}
+
+// SessionStore keeps track of detached console sessions so that they can be
+// reattached by ID from a later CLI invocation
+type SessionStore struct {
+	path string
+	now  func() time.Time
+}
+
+// NewSessionStore creates a session store backed by the file at path
+func NewSessionStore(path string) *SessionStore {
+	return &SessionStore{path: path, now: time.Now}
+}
+
+// DefaultSessionStore returns the session store in the CLI config directory
+func DefaultSessionStore() (*SessionStore, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return NewSessionStore(filepath.Join(homeDir, ".bmc-cli", "sessions.json")), nil
+}
+
+// List returns the sessions that have not expired, most recently detached first
+func (s *SessionStore) List() ([]DetachedSession, error) {
+	sessions, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	active := make([]DetachedSession, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.Expired(now) {
+			active = append(active, session)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].DetachedAt.After(active[j].DetachedAt)
+	})
+	return active, nil
+}
+
+// Get returns the session with the given ID, or nil if it is not recorded or
+// has expired
+func (s *SessionStore) Get(sessionID string) (*DetachedSession, error) {
+	sessions, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+// Put records a detached session, replacing any previous record with the
+// same ID. Expired sessions are pruned.
+func (s *SessionStore) Put(session DetachedSession) error {
+	sessions, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	kept := []DetachedSession{session}
+	for _, existing := range sessions {
+		if existing.ID != session.ID {
+			kept = append(kept, existing)
+		}
+	}
+	return s.save(kept)
+}
+
+// Remove forgets a session. Removing an unknown session is not an error.
+func (s *SessionStore) Remove(sessionID string) error {
+	sessions, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	kept := make([]DetachedSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.ID != sessionID {
+			kept = append(kept, session)
+		}
+	}
+	if len(kept) == len(sessions) {
+		return nil
+	}
+	return s.save(kept)
+}
+
+func (s *SessionStore) load() ([]DetachedSession, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session store: %w", err)
+	}
+
+	var sessions []DetachedSession
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse session store %s: %w", s.path, err)
+	}
+	return sessions, nil
+}
+
+func (s *SessionStore) save(sessions []DetachedSession) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session store: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session store: %w", err)
+	}
+	return nil
+}
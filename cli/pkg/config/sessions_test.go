@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionStore_PutGetRemove(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	store := NewSessionStore(filepath.Join(t.TempDir(), "nested", "sessions.json"))
+	store.now = func() time.Time { return now }
+
+	// A missing file is an empty store
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("Expected no sessions, got %d", len(sessions))
+	}
+
+	older := DetachedSession{ID: "sol-1", ServerID: "server-001", DetachedAt: now.Add(-time.Hour)}
+	newer := DetachedSession{ID: "sol-2", ServerID: "server-002", DetachedAt: now, ExpiresAt: now.Add(time.Hour)}
+	expired := DetachedSession{ID: "sol-3", ServerID: "server-003", DetachedAt: now, ExpiresAt: now.Add(-time.Minute)}
+	for _, session := range []DetachedSession{older, newer, expired} {
+		if err := store.Put(session); err != nil {
+			t.Fatalf("Put(%s) failed: %v", session.ID, err)
+		}
+	}
+
+	sessions, err = store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "sol-2" || sessions[1].ID != "sol-1" {
+		t.Fatalf("Expected [sol-2 sol-1], got %+v", sessions)
+	}
+
+	session, err := store.Get("sol-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if session == nil || session.ServerID != "server-001" {
+		t.Errorf("Expected sol-1 on server-001, got %+v", session)
+	}
+
+	if session, _ := store.Get("sol-3"); session != nil {
+		t.Errorf("Expected expired session to be dropped, got %+v", session)
+	}
+
+	// Putting an existing session replaces it
+	older.ServerID = "server-009"
+	if err := store.Put(older); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if session, _ := store.Get("sol-1"); session == nil || session.ServerID != "server-009" {
+		t.Errorf("Expected sol-1 to be updated, got %+v", session)
+	}
+
+	if err := store.Remove("sol-1"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := store.Remove("unknown"); err != nil {
+		t.Errorf("Remove of unknown session failed: %v", err)
+	}
+	sessions, _ = store.List()
+	if len(sessions) != 1 || sessions[0].ID != "sol-2" {
+		t.Errorf("Expected [sol-2] after remove, got %+v", sessions)
+	}
+
+	info, err := os.Stat(store.path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected session store mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+func TestSessionStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("Failed to write store: %v", err)
+	}
+
+	if _, err := NewSessionStore(path).List(); err == nil {
+		t.Error("Expected error for invalid session store")
+	}
+}
@@ -0,0 +1,62 @@
+// Package messages translates the messages the CLI prints for users.
+//
+// Progress and status messages of the commands are translated, in the
+// language of the configuration or else of the locale environment. Errors,
+// command help and machine-readable output stay in English.
+package messages
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+
+	"core/i18n"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var (
+	bundle  *i18n.Bundle
+	printer *i18n.Printer
+)
+
+func init() {
+	locales, err := fs.Sub(localeFS, "locales")
+	if err != nil {
+		panic(err)
+	}
+	if bundle, err = i18n.NewBundle(locales); err != nil {
+		panic(err)
+	}
+	printer = bundle.Printer(i18n.DefaultLanguage)
+}
+
+// Bundle returns the translations of the CLI messages
+func Bundle() *i18n.Bundle {
+	return bundle
+}
+
+// SetLanguage selects the language of the messages: lang when the messages
+// are translated to it, or else the language of the locale environment, or
+// else English. It returns the selected language.
+func SetLanguage(lang string) string {
+	printer = bundle.Printer(bundle.Match(lang, localeLanguage()))
+	return printer.Language()
+}
+
+// localeLanguage returns the locale of messages, from the first set of
+// LC_ALL, LC_MESSAGES and LANG as in POSIX
+func localeLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// Sprintf formats the message of key in the selected language
+func Sprintf(key string, args ...any) string {
+	return printer.Sprintf(key, args...)
+}
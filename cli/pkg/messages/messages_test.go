@@ -0,0 +1,59 @@
+package messages
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMessagesComplete ensures every message is translated to every
+// language, with the same number of arguments
+func TestMessagesComplete(t *testing.T) {
+	english := bundle.Printer("en").Messages("")
+	for _, lang := range bundle.Languages() {
+		if missing := bundle.Missing(lang); len(missing) > 0 {
+			t.Errorf("Messages missing in %q: %v", lang, missing)
+		}
+		for key, message := range bundle.Printer(lang).Messages("") {
+			if strings.Count(message, "%") != strings.Count(english[key], "%") {
+				t.Errorf("Message %q in %q has different arguments than in English", key, lang)
+			}
+		}
+	}
+}
+
+// TestSetLanguage ensures the configured language wins over the locale
+// environment, and messages default to English
+func TestSetLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		env      map[string]string
+		expected string
+	}{
+		{name: "default", expected: "en"},
+		{name: "configured", lang: "ja", expected: "ja"},
+		{name: "LANG", env: map[string]string{"LANG": "ja_JP.UTF-8"}, expected: "ja"},
+		{name: "LC_ALL over LANG", env: map[string]string{"LC_ALL": "C", "LANG": "ja_JP.UTF-8"}, expected: "en"},
+		{name: "LC_MESSAGES", env: map[string]string{"LC_MESSAGES": "ja_JP", "LANG": "en_US.UTF-8"}, expected: "ja"},
+		{name: "configured over locale", lang: "en", env: map[string]string{"LANG": "ja_JP.UTF-8"}, expected: "en"},
+		{name: "unknown configured", lang: "fr", env: map[string]string{"LANG": "ja_JP.UTF-8"}, expected: "ja"},
+	}
+
+	defer SetLanguage("en")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if lang := SetLanguage(tt.lang); lang != tt.expected {
+				t.Errorf("Expected language %q, got %q", tt.expected, lang)
+			}
+		})
+	}
+
+	t.Setenv("LANG", "")
+	SetLanguage("ja")
+	if message := Sprintf("power.powered_on", "srv-1"); message != "サーバー srv-1 の電源をオンにしました" {
+		t.Errorf("Unexpected Japanese message %q", message)
+	}
+}
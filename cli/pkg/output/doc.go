@@ -1,5 +1,11 @@
 // Package output provides reusable output formatting utilities for CLI commands.
 //
-// This package allows commands to easily support multiple output formats (text, JSON)
-// without duplicating formatting logic.
+// This package allows commands to easily support multiple output formats (text, JSON,
+// YAML, table, wide) without duplicating formatting logic.
+//
+// Commands describe tabular data with a Table whose columns have stable keys.
+// The table format shows the default columns, wide adds the columns marked
+// Wide, and --columns selects and orders columns explicitly. For list commands
+// text output is the table; for single-resource commands text is a detailed
+// view and the table formats render a one-row summary.
 package output
@@ -1,12 +1,15 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // Format represents the output format type
@@ -17,12 +20,23 @@ const (
 	FormatText Format = "text"
 	// FormatJSON is the JSON output format
 	FormatJSON Format = "json"
+	// FormatYAML is the YAML output format
+	FormatYAML Format = "yaml"
+	// FormatTable renders data as a table of the default columns
+	FormatTable Format = "table"
+	// FormatWide renders data as a table including the wide columns
+	FormatWide Format = "wide"
 )
 
+// formats lists the supported formats in the order shown in help and errors
+var formats = []Format{FormatText, FormatJSON, FormatYAML, FormatTable, FormatWide}
+
 // Formatter handles different output formats
 type Formatter struct {
-	format Format
-	writer io.Writer
+	format    Format
+	columns   []string
+	noHeaders bool
+	writer    io.Writer
 }
 
 // New creates a new Formatter with the specified format
@@ -33,17 +47,52 @@ func New(format Format) *Formatter {
 	}
 }
 
+// NewFromCmd creates a Formatter from the --output and --columns flags of a command
+func NewFromCmd(cmd *cobra.Command) (*Formatter, error) {
+	format, err := GetFormatFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := GetColumnsFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	formatter := New(format)
+	if len(columns) > 0 {
+		if formatter.IsStructured() {
+			return nil, fmt.Errorf("--columns cannot be used with %s output", format)
+		}
+		formatter.SetColumns(columns)
+	}
+
+	return formatter, nil
+}
+
 // SetWriter sets a custom writer for output (useful for testing)
 func (f *Formatter) SetWriter(w io.Writer) {
 	f.writer = w
 }
 
+// SetNoHeaders omits the header row from table output
+func (f *Formatter) SetNoHeaders(noHeaders bool) {
+	f.noHeaders = noHeaders
+}
+
+// SetColumns restricts table output to the given column keys, in order
+func (f *Formatter) SetColumns(columns []string) {
+	f.columns = columns
+}
+
 // Output writes the data in the configured format
 func (f *Formatter) Output(data interface{}) error {
 	switch f.format {
 	case FormatJSON:
 		return f.outputJSON(data)
-	case FormatText:
+	case FormatYAML:
+		return f.outputYAML(data)
+	case FormatText, FormatTable, FormatWide:
 		// For text format, we expect the caller to handle formatting
 		// This is just a fallback
 		fmt.Fprintf(f.writer, "%v\n", data)
@@ -60,20 +109,84 @@ func (f *Formatter) outputJSON(data interface{}) error {
 	return encoder.Encode(data)
 }
 
+// outputYAML outputs data as YAML.
+//
+// Data is first encoded as JSON so that field names and omitempty rules
+// follow the json struct tags, exactly matching the JSON output.
+func (f *Formatter) outputYAML(data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// Decoding into a node keeps the JSON field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return fmt.Errorf("failed to convert output to YAML: %w", err)
+	}
+	resetYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	_, err = f.writer.Write(buf.Bytes())
+	return err
+}
+
+// resetYAMLStyle switches a node decoded from JSON to block style.
+// The encoder still quotes strings that would otherwise be ambiguous.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
 // IsJSON returns true if the format is JSON
 func (f *Formatter) IsJSON() bool {
 	return f.format == FormatJSON
 }
 
+// IsYAML returns true if the format is YAML
+func (f *Formatter) IsYAML() bool {
+	return f.format == FormatYAML
+}
+
+// IsStructured returns true for machine-readable formats (JSON, YAML)
+// that should be written with Output instead of a table or text layout
+func (f *Formatter) IsStructured() bool {
+	return f.format == FormatJSON || f.format == FormatYAML
+}
+
 // IsText returns true if the format is text
 func (f *Formatter) IsText() bool {
 	return f.format == FormatText
 }
 
-// AddFormatFlag adds a --output flag to a cobra command
+// IsTable returns true if the output should be rendered as a table.
+// This is the case for the table and wide formats, and for text output
+// when specific columns were requested.
+func (f *Formatter) IsTable() bool {
+	return f.format == FormatTable || f.format == FormatWide || (f.format == FormatText && len(f.columns) > 0)
+}
+
+// AddFormatFlag adds the --output and --columns flags to a cobra command
 // This should be called in the init() function for commands that support output formatting
 func AddFormatFlag(cmd *cobra.Command) {
-	cmd.Flags().StringP("output", "o", "text", "Output format (text|json)")
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+
+	cmd.Flags().StringP("output", "o", "text", fmt.Sprintf("Output format (%s)", strings.Join(names, "|")))
+	cmd.Flags().StringSlice("columns", nil, "Comma-separated list of columns to show in table output (e.g. id,status)")
 }
 
 // GetFormatFromCmd extracts the output format from a cobra command's flags
@@ -84,10 +197,36 @@ func GetFormatFromCmd(cmd *cobra.Command) (Format, error) {
 	}
 
 	format := Format(formatStr)
-	switch format {
-	case FormatText, FormatJSON:
-		return format, nil
-	default:
-		return FormatText, fmt.Errorf("invalid output format: %s (must be 'text' or 'json')", formatStr)
+	for _, supported := range formats {
+		if format == supported {
+			return format, nil
+		}
+	}
+
+	names := make([]string, len(formats))
+	for i, supported := range formats {
+		names[i] = fmt.Sprintf("'%s'", supported)
+	}
+	return FormatText, fmt.Errorf("invalid output format: %s (must be one of %s)", formatStr, strings.Join(names, ", "))
+}
+
+// GetColumnsFromCmd extracts the requested column keys from a cobra command's flags.
+// Commands without a --columns flag return no columns.
+func GetColumnsFromCmd(cmd *cobra.Command) ([]string, error) {
+	if cmd.Flags().Lookup("columns") == nil {
+		return nil, nil
+	}
+
+	values, err := cmd.Flags().GetStringSlice("columns")
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			columns = append(columns, value)
+		}
 	}
+	return columns, nil
 }
@@ -66,6 +66,105 @@ func TestFormatter_OutputJSON(t *testing.T) {
 	}
 }
 
+func TestFormatter_OutputYAML(t *testing.T) {
+	data := struct {
+		ID       string            `json:"id"`
+		Status   string            `json:"status"`
+		Port     int               `json:"port"`
+		Enabled  string            `json:"enabled"`
+		Features []string          `json:"features"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+	}{
+		ID:       "server-001",
+		Status:   "active",
+		Port:     623,
+		Enabled:  "true",
+		Features: []string{"power", "console"},
+	}
+
+	var buf bytes.Buffer
+	formatter := New(FormatYAML)
+	formatter.SetWriter(&buf)
+
+	if err := formatter.Output(data); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	want := `id: server-001
+status: active
+port: 623
+enabled: "true"
+features:
+  - power
+  - console
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Output() YAML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatter_IsStructured(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   bool
+	}{
+		{format: FormatText, want: false},
+		{format: FormatJSON, want: true},
+		{format: FormatYAML, want: true},
+		{format: FormatTable, want: false},
+		{format: FormatWide, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			if got := New(tt.format).IsStructured(); got != tt.want {
+				t.Errorf("IsStructured() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFromCmd(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		columns   string
+		wantTable bool
+		wantErr   bool
+	}{
+		{name: "text", output: "text", wantTable: false},
+		{name: "text with columns", output: "text", columns: "id,status", wantTable: true},
+		{name: "table", output: "table", wantTable: true},
+		{name: "wide with columns", output: "wide", columns: "id", wantTable: true},
+		{name: "json", output: "json", wantTable: false},
+		{name: "json with columns", output: "json", columns: "id", wantErr: true},
+		{name: "yaml with columns", output: "yaml", columns: "id", wantErr: true},
+		{name: "invalid format", output: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "test"}
+			AddFormatFlag(cmd)
+			cmd.Flags().Set("output", tt.output)
+			if tt.columns != "" {
+				cmd.Flags().Set("columns", tt.columns)
+			}
+
+			formatter, err := NewFromCmd(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFromCmd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := formatter.IsTable(); got != tt.wantTable {
+				t.Errorf("IsTable() = %v, want %v", got, tt.wantTable)
+			}
+		})
+	}
+}
+
 func TestFormatter_IsJSON(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -114,6 +213,24 @@ func TestGetFormatFromCmd(t *testing.T) {
 			want:      FormatText,
 			wantErr:   false,
 		},
+		{
+			name:      "yaml format",
+			flagValue: "yaml",
+			want:      FormatYAML,
+			wantErr:   false,
+		},
+		{
+			name:      "table format",
+			flagValue: "table",
+			want:      FormatTable,
+			wantErr:   false,
+		},
+		{
+			name:      "wide format",
+			flagValue: "wide",
+			want:      FormatWide,
+			wantErr:   false,
+		},
 		{
 			name:       "invalid format",
 			flagValue:  "xml",
@@ -171,4 +288,8 @@ func TestAddFormatFlag(t *testing.T) {
 	if flag.DefValue != "text" {
 		t.Errorf("AddFormatFlag() default value = %q, want %q", flag.DefValue, "text")
 	}
+
+	if cmd.Flags().Lookup("columns") == nil {
+		t.Error("AddFormatFlag() did not add 'columns' flag")
+	}
 }
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// progressBarWidth is the number of cells of a progress bar
+const progressBarWidth = 30
+
+// Progress reports the progress of a long-running operation, e.g. a power
+// operation that the BMC runs as a task.
+//
+// On a terminal the bar is redrawn in place. Otherwise a line is written for
+// every change, so that logs of scripted runs stay readable.
+type Progress struct {
+	out    io.Writer
+	redraw bool
+	last   string
+	drawn  bool
+}
+
+// NewProgress creates a progress reporter writing to out, redrawing in place
+// when redraw is set
+func NewProgress(out io.Writer, redraw bool) *Progress {
+	return &Progress{out: out, redraw: redraw}
+}
+
+// Update reports the progress of the operation. percent is between 0 and
+// 100, or negative when unknown; status describes the current step.
+func (p *Progress) Update(percent int, status string) {
+	line := formatProgress(percent, status)
+	if line == p.last {
+		return
+	}
+	p.last = line
+
+	if p.redraw {
+		fmt.Fprintf(p.out, "\r\033[K%s", line)
+		p.drawn = true
+		return
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+// Finish ends the progress line, so that following output starts on a new
+// line
+func (p *Progress) Finish() {
+	if p.redraw && p.drawn {
+		fmt.Fprintln(p.out)
+		p.drawn = false
+	}
+}
+
+// formatProgress renders a progress line, e.g.
+// "[#############-----------------]  45% Running: Applying update". The
+// percentage reads "--" when unknown.
+func formatProgress(percent int, status string) string {
+	filled, shown := 0, "  --"
+	if percent >= 0 {
+		percent = min(percent, 100)
+		filled = percent * progressBarWidth / 100
+		shown = fmt.Sprintf("%3d%%", percent)
+	}
+
+	line := fmt.Sprintf("[%s%s] %s", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), shown)
+	if status != "" {
+		line += " " + status
+	}
+	return line
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		percent int
+		status  string
+		want    string
+	}{
+		{0, "New", "[------------------------------]   0% New"},
+		{45, "Running: Applying update", "[#############-----------------]  45% Running: Applying update"},
+		{100, "", "[##############################] 100%"},
+		{150, "Completed", "[##############################] 100% Completed"},
+		{-1, "Running", "[------------------------------]   -- Running"},
+	}
+	for _, tt := range tests {
+		if got := formatProgress(tt.percent, tt.status); got != tt.want {
+			t.Errorf("formatProgress(%d, %q) = %q, want %q", tt.percent, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		progress := NewProgress(&buf, false)
+		progress.Update(10, "Running")
+		progress.Update(10, "Running") // unchanged, not repeated
+		progress.Update(100, "Completed")
+		progress.Finish()
+
+		want := "[###---------------------------]  10% Running\n" +
+			"[##############################] 100% Completed\n"
+		if buf.String() != want {
+			t.Errorf("got %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("redraw", func(t *testing.T) {
+		var buf bytes.Buffer
+		progress := NewProgress(&buf, true)
+		progress.Update(10, "Running")
+		progress.Update(100, "Completed")
+		progress.Finish()
+
+		want := "\r\033[K[###---------------------------]  10% Running" +
+			"\r\033[K[##############################] 100% Completed\n"
+		if buf.String() != want {
+			t.Errorf("got %q, want %q", buf.String(), want)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// Column describes a table column
+type Column struct {
+	// Key selects the column with --columns (e.g. "bmc_type")
+	Key string
+	// Header is the column heading (e.g. "BMC TYPE")
+	Header string
+	// Wide columns are only shown with -o wide or when selected explicitly
+	Wide bool
+}
+
+// ANSI sequences used to highlight rows. Both prefixes have the same length
+// so that highlighted and plain rows stay aligned by the tabwriter.
+const (
+	highlightOn  = "\033[7m"
+	highlightOff = "\033[0m"
+)
+
+// Table holds rows of string values for the table and wide formats
+type Table struct {
+	columns     []Column
+	rows        [][]string
+	highlighted map[int]bool
+}
+
+// NewTable creates an empty table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow appends a row. Values are given in column order; missing values
+// are left empty and extra values are ignored.
+func (t *Table) AddRow(values ...string) {
+	row := make([]string, len(t.columns))
+	copy(row, values)
+	t.rows = append(t.rows, row)
+}
+
+// Highlight marks a row (by insertion index) to be rendered in reverse video,
+// e.g. to show rows that changed while watching
+func (t *Table) Highlight(row int) {
+	if t.highlighted == nil {
+		t.highlighted = make(map[int]bool)
+	}
+	t.highlighted[row] = true
+}
+
+// Rows returns the row values in column order
+func (t *Table) Rows() [][]string {
+	return t.rows
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// ColumnKeys returns the keys of all columns, in order
+func (t *Table) ColumnKeys() []string {
+	keys := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		keys[i] = column.Key
+	}
+	return keys
+}
+
+// selectColumns returns the indexes of the columns to render
+func (t *Table) selectColumns(keys []string, wide bool) ([]int, error) {
+	var indexes []int
+
+	if len(keys) == 0 {
+		for i, column := range t.columns {
+			if wide || !column.Wide {
+				indexes = append(indexes, i)
+			}
+		}
+		return indexes, nil
+	}
+
+	for _, key := range keys {
+		index := t.columnIndex(key)
+		if index < 0 {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", key, strings.Join(t.ColumnKeys(), ", "))
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// columnIndex finds a column by key, ignoring case and accepting '-' for '_'
+func (t *Table) columnIndex(key string) int {
+	key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	for i, column := range t.columns {
+		if column.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// OutputTable renders the table with the selected columns.
+// The default columns are shown unless the format is wide or columns were
+// selected with SetColumns.
+func (f *Formatter) OutputTable(t *Table) error {
+	indexes, err := t.selectColumns(f.columns, f.format == FormatWide)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(f.writer, 0, 0, 2, ' ', 0)
+
+	// Every line gets an escape prefix of equal length when any row is
+	// highlighted, otherwise the first column would be misaligned
+	highlight := len(t.highlighted) > 0
+	writeLine := func(values []string, on bool) {
+		line := strings.Join(values, "\t")
+		if highlight {
+			prefix := highlightOff
+			if on {
+				prefix = highlightOn
+			}
+			line = prefix + line + highlightOff
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	if !f.noHeaders {
+		headers := make([]string, len(indexes))
+		for i, index := range indexes {
+			headers[i] = t.columns[index].Header
+		}
+		writeLine(headers, false)
+	}
+
+	for rowIndex, row := range t.rows {
+		values := make([]string, len(indexes))
+		for i, index := range indexes {
+			values[i] = row[index]
+		}
+		writeLine(values, t.highlighted[rowIndex])
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newTestTable() *Table {
+	table := NewTable(
+		Column{Key: "id", Header: "SERVER ID"},
+		Column{Key: "status", Header: "STATUS"},
+		Column{Key: "bmc_type", Header: "BMC TYPE"},
+		Column{Key: "endpoint", Header: "ENDPOINT", Wide: true},
+	)
+	table.AddRow("server-001", "active", "ipmi", "192.168.1.100:623")
+	table.AddRow("server-002", "offline", "redfish") // missing wide value
+	return table
+}
+
+func TestFormatter_OutputTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		columns []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "default columns",
+			format: FormatTable,
+			want: []string{
+				"SERVER ID   STATUS   BMC TYPE",
+				"server-001  active   ipmi",
+				"server-002  offline  redfish",
+			},
+		},
+		{
+			name:   "text renders like table",
+			format: FormatText,
+			want: []string{
+				"SERVER ID   STATUS   BMC TYPE",
+				"server-001  active   ipmi",
+				"server-002  offline  redfish",
+			},
+		},
+		{
+			name:   "wide includes wide columns",
+			format: FormatWide,
+			want: []string{
+				"SERVER ID   STATUS   BMC TYPE  ENDPOINT",
+				"server-001  active   ipmi      192.168.1.100:623",
+				"server-002  offline  redfish   ",
+			},
+		},
+		{
+			name:    "selected columns in order",
+			format:  FormatTable,
+			columns: []string{"BMC-Type", "id", "endpoint"},
+			want: []string{
+				"BMC TYPE  SERVER ID   ENDPOINT",
+				"ipmi      server-001  192.168.1.100:623",
+				"redfish   server-002  ",
+			},
+		},
+		{
+			name:    "unknown column",
+			format:  FormatTable,
+			columns: []string{"id", "rack"},
+			wantErr: `unknown column "rack" (available: id, status, bmc_type, endpoint)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := New(tt.format)
+			formatter.SetWriter(&buf)
+			formatter.SetColumns(tt.columns)
+
+			err := formatter.OutputTable(newTestTable())
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("OutputTable() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OutputTable() unexpected error: %v", err)
+			}
+
+			got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if len(got) != len(tt.want) {
+				t.Fatalf("OutputTable() = %d lines, want %d:\n%s", len(got), len(tt.want), buf.String())
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("OutputTable() line %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTable_AddRow(t *testing.T) {
+	table := NewTable(Column{Key: "id", Header: "ID"}, Column{Key: "status", Header: "STATUS"})
+	table.AddRow("a", "active", "ignored")
+	table.AddRow("b")
+
+	if table.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", table.Len())
+	}
+	if len(table.rows[0]) != 2 || table.rows[1][1] != "" {
+		t.Errorf("AddRow() rows = %v, want values padded/truncated to column count", table.rows)
+	}
+}
+
+func TestFormatter_OutputTableHighlight(t *testing.T) {
+	table := NewTable(Column{Key: "id", Header: "ID"}, Column{Key: "state", Header: "STATE"})
+	table.AddRow("server-001", "on")
+	table.AddRow("server-002", "off")
+	table.Highlight(1)
+
+	var buf bytes.Buffer
+	formatter := New(FormatTable)
+	formatter.SetWriter(&buf)
+	formatter.SetNoHeaders(true)
+
+	if err := formatter.OutputTable(table); err != nil {
+		t.Fatalf("OutputTable() unexpected error: %v", err)
+	}
+
+	want := highlightOff + "server-001  on" + highlightOff + "\n" +
+		highlightOn + "server-002  off" + highlightOff + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("OutputTable() = %q, want %q", got, want)
+	}
+}
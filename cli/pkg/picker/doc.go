@@ -0,0 +1,13 @@
+// Package picker provides server reference resolution and an interactive
+// selection prompt for CLI commands.
+//
+// Commands accept a server reference that may be a full server ID, a unique
+// prefix, a metadata name (e.g. the "name" or "hostname" metadata key), or a
+// fuzzy subsequence such as "d1-100" for "server-d-1-192-168-1-100-623".
+//
+// Resolve applies these rules in order of precedence (exact, prefix, fuzzy)
+// and reports ambiguity with a ranked list of candidates. Pick presents a
+// line-based prompt that lets the user refine the filter or select a
+// candidate by number when no reference was given or the reference is
+// ambiguous.
+package picker
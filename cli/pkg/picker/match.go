@@ -0,0 +1,208 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoMatch is returned when no candidate matches the query
+	ErrNoMatch = errors.New("no matching server")
+	// ErrAmbiguous is returned when more than one candidate matches the query
+	ErrAmbiguous = errors.New("ambiguous server reference")
+)
+
+// Candidate is a selectable item, typically a server
+type Candidate struct {
+	// ID is the canonical identifier returned on selection
+	ID string
+	// Aliases are alternative names that also match (e.g. metadata names)
+	Aliases []string
+	// Description is extra context shown next to the ID in the picker
+	Description string
+}
+
+// names returns the ID followed by all aliases
+func (c Candidate) names() []string {
+	return append([]string{c.ID}, c.Aliases...)
+}
+
+// AmbiguousError lists the candidates that matched an ambiguous query
+type AmbiguousError struct {
+	Query      string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousError) Error() string {
+	ids := make([]string, 0, len(e.Candidates))
+	for i, c := range e.Candidates {
+		if i == 5 {
+			ids = append(ids, fmt.Sprintf("... and %d more", len(e.Candidates)-i))
+			break
+		}
+		ids = append(ids, c.ID)
+	}
+	return fmt.Sprintf("%s %q matches %d servers: %s", ErrAmbiguous, e.Query, len(e.Candidates), strings.Join(ids, ", "))
+}
+
+func (e *AmbiguousError) Unwrap() error {
+	return ErrAmbiguous
+}
+
+// Resolve maps a query to a single candidate.
+//
+// Exact matches on ID or alias take precedence, then unique prefix matches,
+// then unique fuzzy matches. When several candidates match at the highest
+// applicable level, an *AmbiguousError carrying the ranked matches is
+// returned. Matching is case-insensitive.
+func Resolve(query string, candidates []Candidate) (Candidate, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return Candidate{}, ErrNoMatch
+	}
+
+	// Exact ID match always wins, even if an alias elsewhere matches too
+	for _, c := range candidates {
+		if strings.ToLower(c.ID) == q {
+			return c, nil
+		}
+	}
+
+	var exact, prefix []Candidate
+	for _, c := range candidates {
+		for _, name := range c.names() {
+			n := strings.ToLower(name)
+			if n == q {
+				exact = append(exact, c)
+				break
+			}
+			if strings.HasPrefix(n, q) {
+				prefix = append(prefix, c)
+				break
+			}
+		}
+	}
+
+	switch {
+	case len(exact) == 1:
+		return exact[0], nil
+	case len(exact) > 1:
+		return Candidate{}, &AmbiguousError{Query: query, Candidates: exact}
+	case len(prefix) == 1:
+		return prefix[0], nil
+	case len(prefix) > 1:
+		return Candidate{}, &AmbiguousError{Query: query, Candidates: Rank(query, prefix)}
+	}
+
+	fuzzy := Rank(query, candidates)
+	switch len(fuzzy) {
+	case 0:
+		return Candidate{}, fmt.Errorf("%w: %q", ErrNoMatch, query)
+	case 1:
+		return fuzzy[0], nil
+	default:
+		return Candidate{}, &AmbiguousError{Query: query, Candidates: fuzzy}
+	}
+}
+
+// Rank returns the candidates that fuzzy-match the query, best match first.
+// An empty query matches every candidate in its original order.
+func Rank(query string, candidates []Candidate) []Candidate {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return append([]Candidate(nil), candidates...)
+	}
+
+	type scored struct {
+		candidate Candidate
+		score     int
+	}
+
+	var matches []scored
+	for _, c := range candidates {
+		best := -1
+		for _, name := range c.names() {
+			if s := Score(q, strings.ToLower(name)); s > best {
+				best = s
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, scored{candidate: c, score: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if len(matches[i].candidate.ID) != len(matches[j].candidate.ID) {
+			return len(matches[i].candidate.ID) < len(matches[j].candidate.ID)
+		}
+		return matches[i].candidate.ID < matches[j].candidate.ID
+	})
+
+	result := make([]Candidate, len(matches))
+	for i, m := range matches {
+		result[i] = m.candidate
+	}
+	return result
+}
+
+// Score computes a fuzzy subsequence score of query against target.
+// It returns -1 if query is not a subsequence of target. Higher scores
+// indicate better matches: consecutive characters and matches at word
+// boundaries (after '-', '_', '.', ':' or '/') are rewarded, gaps are
+// penalized. Both arguments are compared as-is, callers should normalize case.
+func Score(query, target string) int {
+	if query == "" {
+		return 0
+	}
+
+	score := 0
+	ti := 0
+	prevMatch := -2
+	for qi := 0; qi < len(query); qi++ {
+		found := false
+		for ; ti < len(target); ti++ {
+			if target[ti] != query[qi] {
+				continue
+			}
+			switch {
+			case ti == prevMatch+1:
+				score += 5 // consecutive
+			case ti == 0 || isBoundary(target[ti-1]):
+				score += 3 // start of a segment
+			default:
+				score++
+				if prevMatch >= 0 {
+					score -= min(ti-prevMatch-1, 3) // gap penalty
+				}
+			}
+			if ti == 0 {
+				score += 2 // prefix bonus
+			}
+			prevMatch = ti
+			ti++
+			found = true
+			break
+		}
+		if !found {
+			return -1
+		}
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+func isBoundary(b byte) bool {
+	switch b {
+	case '-', '_', '.', ':', '/', ' ':
+		return true
+	}
+	return false
+}
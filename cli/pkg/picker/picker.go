@@ -0,0 +1,98 @@
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrCancelled is returned when the user exits the picker without selecting
+var ErrCancelled = errors.New("selection cancelled")
+
+// maxDisplayed caps how many candidates are listed per prompt
+const maxDisplayed = 20
+
+// Picker is a line-based interactive selection prompt
+type Picker struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New creates a Picker reading answers from in and writing prompts to out
+func New(in io.Reader, out io.Writer) *Picker {
+	return &Picker{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Pick prompts the user to choose one of the candidates.
+//
+// The list is filtered with the initial query (which may be empty). At each
+// prompt the user may enter a number to select the listed candidate, any other
+// text to re-filter the full candidate list, or an empty line to cancel.
+func (p *Picker) Pick(title, query string, candidates []Candidate) (Candidate, error) {
+	if len(candidates) == 0 {
+		return Candidate{}, ErrNoMatch
+	}
+
+	for {
+		matches := Rank(query, candidates)
+		if len(matches) == 1 && query != "" {
+			return matches[0], nil
+		}
+
+		p.render(title, query, matches)
+
+		fmt.Fprint(p.out, "> ")
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return Candidate{}, ErrCancelled
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return Candidate{}, ErrCancelled
+		}
+
+		if n, convErr := strconv.Atoi(line); convErr == nil {
+			if n >= 1 && n <= min(len(matches), maxDisplayed) {
+				return matches[n-1], nil
+			}
+			fmt.Fprintf(p.out, "Invalid selection %d\n", n)
+			continue
+		}
+
+		query = line
+	}
+}
+
+func (p *Picker) render(title, query string, matches []Candidate) {
+	if query != "" {
+		fmt.Fprintf(p.out, "%s (filter: %q)\n", title, query)
+	} else {
+		fmt.Fprintf(p.out, "%s\n", title)
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintln(p.out, "  no matches, type a different filter")
+		return
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for i, c := range matches {
+		if i == maxDisplayed {
+			break
+		}
+		fmt.Fprintf(w, "  %d)\t%s\t%s\n", i+1, c.ID, c.Description)
+	}
+	w.Flush()
+
+	if len(matches) > maxDisplayed {
+		fmt.Fprintf(p.out, "  ... and %d more, type to narrow the list\n", len(matches)-maxDisplayed)
+	}
+	fmt.Fprintln(p.out, "Enter a number to select, text to filter, or an empty line to cancel.")
+}
@@ -0,0 +1,126 @@
+package picker
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testCandidates = []Candidate{
+	{ID: "server-d-1-192-168-1-100-623", Aliases: []string{"web-01"}},
+	{ID: "server-d-1-192-168-1-101-623", Aliases: []string{"web-02"}},
+	{ID: "server-d-2-10-0-0-5-623", Aliases: []string{"db-primary"}},
+	{ID: "srv-001"},
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		want      string
+		wantErr   error
+		wantCount int
+	}{
+		{name: "exact id", query: "srv-001", want: "srv-001"},
+		{name: "exact id case insensitive", query: "SRV-001", want: "srv-001"},
+		{name: "exact alias", query: "db-primary", want: "server-d-2-10-0-0-5-623"},
+		{name: "unique prefix", query: "server-d-2", want: "server-d-2-10-0-0-5-623"},
+		{name: "unique alias prefix", query: "web-02", want: "server-d-1-192-168-1-101-623"},
+		{name: "ambiguous prefix", query: "server-d-1", wantErr: ErrAmbiguous, wantCount: 2},
+		{name: "unique fuzzy", query: "d1100", want: "server-d-1-192-168-1-100-623"},
+		{name: "ambiguous fuzzy", query: "web", wantErr: ErrAmbiguous, wantCount: 2},
+		{name: "no match", query: "zzz", wantErr: ErrNoMatch},
+		{name: "empty query", query: "  ", wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(tt.query, testCandidates)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+				}
+				var ambiguous *AmbiguousError
+				if errors.As(err, &ambiguous) && len(ambiguous.Candidates) != tt.wantCount {
+					t.Errorf("Resolve() ambiguous candidates = %d, want %d", len(ambiguous.Candidates), tt.wantCount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	ranked := Rank("web01", testCandidates)
+	if len(ranked) == 0 {
+		t.Fatal("Rank() returned no matches")
+	}
+	if ranked[0].ID != "server-d-1-192-168-1-100-623" {
+		t.Errorf("Rank() best match = %q, want alias web-01 owner", ranked[0].ID)
+	}
+
+	if all := Rank("", testCandidates); len(all) != len(testCandidates) {
+		t.Errorf("Rank() with empty query = %d candidates, want %d", len(all), len(testCandidates))
+	}
+}
+
+func TestScore(t *testing.T) {
+	if Score("xyz", "server") != -1 {
+		t.Error("Score() should return -1 for non-subsequence")
+	}
+	if Score("srv", "srv-001") <= Score("srv", "server-v") {
+		t.Error("Score() should prefer consecutive prefix matches")
+	}
+	if Score("d2", "server-d-2") <= Score("d2", "serverd12") {
+		t.Error("Score() should prefer segment boundary matches")
+	}
+}
+
+func TestPicker_Pick(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		query   string
+		want    string
+		wantErr error
+	}{
+		{name: "select by number", input: "4\n", want: "srv-001"},
+		{name: "filter then select", input: "web\n2\n", want: "server-d-1-192-168-1-101-623"},
+		{name: "filter to single match", input: "db\n", want: "server-d-2-10-0-0-5-623"},
+		{name: "initial query narrows list", query: "server-d-1", input: "1\n", want: "server-d-1-192-168-1-100-623"},
+		{name: "invalid number retries", input: "9\n1\n", want: "server-d-1-192-168-1-100-623"},
+		{name: "empty line cancels", input: "\n", wantErr: ErrCancelled},
+		{name: "eof cancels", input: "", wantErr: ErrCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := New(strings.NewReader(tt.input), &out)
+
+			got, err := p.Pick("Select a server", tt.query, testCandidates)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Pick() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pick() unexpected error: %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Pick() = %q, want %q", got.ID, tt.want)
+			}
+			if !strings.Contains(out.String(), "Select a server") {
+				t.Errorf("Pick() output missing title: %s", out.String())
+			}
+		})
+	}
+}
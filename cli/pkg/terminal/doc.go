@@ -16,7 +16,7 @@
 //   - Terminal raw mode: Character-by-character input using golang.org/x/term
 //   - Bidirectional streaming: Real-time data flow in both directions
 //   - Connect RPC: Type-safe protobuf messages (ConsoleDataChunk)
-//   - Clean exit: Ctrl+] followed by 'q' to gracefully close the session
+//   - Escape sequences: Ctrl+] followed by 'q' to close or 'd' to detach
 //
 // # USAGE
 //
@@ -28,6 +28,12 @@
 //	# Terminal streaming (advanced, for automation)
 //	bmc-cli server console <server-id> --terminal
 //
+//	# Terminal streaming with a timestamped copy of the output, e.g. boot logs
+//	bmc-cli server console <server-id> --terminal --log-file boot.log
+//
+//	# Non-interactive command for runbooks (see Exec)
+//	bmc-cli server console exec <server-id> --command "ls /" --expect '\$ $'
+//
 // Programmatic usage:
 //
 //	// Create SOL session to get session ID
@@ -57,17 +63,29 @@
 // serial console interaction. Raw mode is automatically restored
 // when the session ends.
 //
-// Exit sequences:
+// The terminal size is sent when the session starts and again on every
+// SIGWINCH, as a ConsoleDataChunk carrying only the resize field. The agent
+// applies it where the console supports a size; IPMI SOL and Redfish serial
+// consoles have none and ignore it.
+//
+// Escape sequences (the escape key is configurable with SetEscapeKey):
 //   - Ctrl+] then 'q': Clean exit with goodbye message
+//   - Ctrl+] then 'd': Detach, leaving the SOL session open on the gateway
+//   - Ctrl+] twice: Send a literal Ctrl+] to the server
 //   - Ctrl+C: Interrupt signal (detected in raw mode as byte 0x03) with graceful cleanup
 //
+// Detached reports whether the user detached, so that the caller keeps the
+// session instead of closing it and can reattach with a new stream later.
+//
 // # STREAMING PROTOCOL
 //
 // Uses Connect RPC bidirectional streaming with ConsoleDataChunk messages.
 // Handshake flow:
 //  1. CLI sends handshake chunk with IsHandshake=true
 //  2. Gateway validates session and routes to the agent
-//  3. Agent establishes BMC SOL connection
+//  3. Agent reserves the server's SOL console, rejecting the stream if
+//     another session holds it unless ForceTakeover is set, and establishes
+//     the BMC SOL connection
 //  4. Bidirectional data streaming begins
 //
 // # ERROR HANDLING
@@ -82,7 +100,7 @@
 //   - streamToStdout: Reads from Connect stream → writes to stdout
 //   - stdinToStream: Reads from stdin → writes to Connect stream
 //
-// A mutex protects shared state during exit sequence detection and cleanup.
+// A mutex protects shared state during escape sequence detection and cleanup.
 //
 // # COMPARISON WITH WEB CONSOLE
 //
@@ -0,0 +1,146 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultEscapeKey is the default escape key in caret notation (Ctrl+]).
+// This follows the convention used by telnet and other terminal programs.
+const DefaultEscapeKey = "^]"
+
+// defaultEscapeByte is DefaultEscapeKey as typed on the keyboard
+const defaultEscapeByte = 0x1D
+
+// Commands recognised after the escape key
+const (
+	// escapeCommandQuit closes the console session
+	escapeCommandQuit = 'q'
+
+	// escapeCommandDetach disconnects while leaving the session open on the
+	// gateway, so that it can be reattached later
+	escapeCommandDetach = 'd'
+)
+
+// escapeAction is the result of scanning input for the escape sequence
+type escapeAction int
+
+const (
+	escapeActionNone escapeAction = iota
+	escapeActionQuit
+	escapeActionDetach
+)
+
+// ParseEscapeKey parses an escape key given in caret notation ("^]", "^A",
+// "^?"), as a single ASCII character, or as "none" to disable the escape
+// sequence. Zero is returned for "none".
+func ParseEscapeKey(key string) (byte, error) {
+	switch {
+	case strings.EqualFold(key, "none"):
+		return 0, nil
+
+	case len(key) == 2 && key[0] == '^':
+		c := key[1]
+		if c == '?' {
+			return 0x7F, nil
+		}
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < '@' || c > '_' {
+			return 0, fmt.Errorf("invalid escape key %q: ^ must be followed by a letter or one of @[\\]^_?", key)
+		}
+		return c & 0x1F, nil
+
+	case len(key) == 1 && key[0] > ' ' && key[0] < 0x7F:
+		if key[0] == escapeCommandQuit || key[0] == escapeCommandDetach {
+			return 0, fmt.Errorf("invalid escape key %q: conflicts with an escape command", key)
+		}
+		return key[0], nil
+	}
+
+	return 0, fmt.Errorf("invalid escape key %q: use caret notation such as ^], a single character, or none", key)
+}
+
+// FormatEscapeKey describes an escape key for help messages, e.g. "Ctrl+]"
+func FormatEscapeKey(key byte) string {
+	switch {
+	case key == 0:
+		return "none"
+	case key == 0x7F:
+		return "Ctrl+?"
+	case key < ' ':
+		return fmt.Sprintf("Ctrl+%c", key|0x40)
+	default:
+		return fmt.Sprintf("'%c'", key)
+	}
+}
+
+// SetEscapeKey configures the escape key, as returned by ParseEscapeKey.
+// A zero key disables the escape sequence.
+//
+// This method must be called before Start().
+func (t *SOLTerminal) SetEscapeKey(key byte) {
+	t.escapeKey = key
+}
+
+// Detached reports whether the session ended because the user detached,
+// leaving the SOL session open on the gateway.
+func (t *SOLTerminal) Detached() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.detached
+}
+
+// escapeHelp describes the escape sequence for the connect banner
+func (t *SOLTerminal) escapeHelp() string {
+	if t.escapeKey == 0 {
+		return "Press Ctrl+C to exit."
+	}
+	key := FormatEscapeKey(t.escapeKey)
+	return fmt.Sprintf("Press %s then '%c' to exit, %s then '%c' to detach.",
+		key, escapeCommandQuit, key, escapeCommandDetach)
+}
+
+// checkEscapeSequence scans input for the escape key followed by a command.
+//
+// It returns the input to forward to the console and the requested action.
+// The escape key itself is held back until the next byte: pressing it twice
+// sends it once, and any other byte sends both. State is kept across calls so
+// that the sequence may be split across multiple reads.
+//
+// Thread safety: This method is protected by the SOLTerminal mutex.
+func (t *SOLTerminal) checkEscapeSequence(data []byte) ([]byte, escapeAction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.escapeKey == 0 {
+		return data, escapeActionNone
+	}
+
+	forward := make([]byte, 0, len(data))
+	for _, b := range data {
+		if t.escapePressed {
+			t.escapePressed = false
+			switch b {
+			case escapeCommandQuit:
+				return forward, escapeActionQuit
+			case escapeCommandDetach:
+				return forward, escapeActionDetach
+			case t.escapeKey:
+				forward = append(forward, b)
+			default:
+				forward = append(forward, t.escapeKey, b)
+			}
+			continue
+		}
+
+		if b == t.escapeKey {
+			t.escapePressed = true
+			continue
+		}
+		forward = append(forward, b)
+	}
+
+	return forward, escapeActionNone
+}
@@ -0,0 +1,233 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+const (
+	// DefaultExecTimeout bounds a non-interactive command when no timeout is given
+	DefaultExecTimeout = 30 * time.Second
+
+	// DefaultExecIdleTimeout is how long the console must stay quiet before a
+	// command without an expected pattern is considered complete
+	DefaultExecIdleTimeout = 2 * time.Second
+)
+
+// ErrExpectTimeout is returned by Exec when the expected pattern did not
+// appear in the console output before the timeout
+var ErrExpectTimeout = errors.New("timed out waiting for expected console output")
+
+// ConsoleStream is the bidirectional console stream used by Exec. It is
+// satisfied by the Connect stream returned by client.StreamConsoleData.
+type ConsoleStream interface {
+	Send(*gatewayv1.ConsoleDataChunk) error
+	Receive() (*gatewayv1.ConsoleDataChunk, error)
+	CloseRequest() error
+}
+
+// ExecOptions configures a non-interactive console command
+type ExecOptions struct {
+	// Command is sent to the console followed by a carriage return
+	Command string
+
+	// Expect is matched against the output received after the command. When
+	// nil, output is captured until the console is idle for IdleTimeout.
+	Expect *regexp.Regexp
+
+	// Timeout bounds the whole exchange (DefaultExecTimeout if zero)
+	Timeout time.Duration
+
+	// IdleTimeout applies when Expect is nil (DefaultExecIdleTimeout if zero)
+	IdleTimeout time.Duration
+}
+
+// ExecResult is the outcome of a non-interactive console command
+type ExecResult struct {
+	// Output is the console output received after the command, with the
+	// command echo removed and line endings normalized to \n
+	Output string
+
+	// Matched reports whether Expect matched the output
+	Matched bool
+
+	// Duration is the time from sending the command until completion
+	Duration time.Duration
+}
+
+// Exec sends a command to a SOL console and captures its output until the
+// expected pattern appears, the console goes idle (when no pattern is given)
+// or the timeout expires.
+//
+// The partial result is returned along with ErrExpectTimeout when the
+// pattern was not seen in time. The stream is closed before Exec returns.
+//
+// Example:
+//
+//	stream, err := client.StreamConsoleData(ctx, serverID, session.ID)
+//	if err != nil {
+//	    return err
+//	}
+//	result, err := terminal.Exec(ctx, stream, session.ID, terminal.ExecOptions{
+//	    Command: "uptime",
+//	    Expect:  regexp.MustCompile(`\$ $`),
+//	})
+func Exec(ctx context.Context, stream ConsoleStream, sessionID string, opts ExecOptions) (*ExecResult, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultExecTimeout
+	}
+	idleTimeout := opts.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultExecIdleTimeout
+	}
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		_ = stream.Send(&gatewayv1.ConsoleDataChunk{
+			SessionId:   sessionID,
+			CloseStream: true,
+		})
+		_ = stream.CloseRequest()
+	}()
+
+	// Receive in the background so that timeouts are honoured while the
+	// stream blocks
+	dataCh := make(chan []byte, 64)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Receive()
+			if err == nil && msg.ErrorCode != "" {
+				err = consoleError(msg)
+			} else if err == nil && msg.CloseStream {
+				err = io.EOF
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if len(msg.Data) == 0 {
+				continue
+			}
+			select {
+			case dataCh <- msg.Data:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	if err := stream.Send(&gatewayv1.ConsoleDataChunk{
+		SessionId: sessionID,
+		Data:      []byte(opts.Command + "\r"),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send command: %w", err)
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	// The idle timer only completes commands without an expected pattern
+	idle := time.NewTimer(idleTimeout)
+	defer idle.Stop()
+	var idleCh <-chan time.Time
+	if opts.Expect == nil {
+		idleCh = idle.C
+	}
+
+	var raw bytes.Buffer
+	result := func() *ExecResult {
+		return &ExecResult{
+			Output:   stripCommandEcho(normalizeLineEndings(raw.String()), opts.Command),
+			Duration: time.Since(start),
+		}
+	}
+	matched := func() (*ExecResult, bool) {
+		r := result()
+		r.Matched = opts.Expect != nil && opts.Expect.MatchString(r.Output)
+		return r, r.Matched
+	}
+
+	for {
+		select {
+		case data := <-dataCh:
+			raw.Write(data)
+			if r, ok := matched(); ok {
+				return r, nil
+			}
+			idle.Reset(idleTimeout)
+
+		case err := <-errCh:
+			// Keep output received before the stream ended
+			for drained := false; !drained; {
+				select {
+				case data := <-dataCh:
+					raw.Write(data)
+				default:
+					drained = true
+				}
+			}
+			r, ok := matched()
+			switch {
+			case ok:
+				return r, nil
+			case !errors.Is(err, io.EOF):
+				return r, fmt.Errorf("stream receive error: %w", err)
+			case opts.Expect != nil:
+				return r, fmt.Errorf("console closed before expected output")
+			default:
+				return r, nil
+			}
+
+		case <-idleCh:
+			return result(), nil
+
+		case <-deadline.C:
+			if opts.Expect == nil {
+				return result(), nil
+			}
+			return result(), ErrExpectTimeout
+
+		case <-ctx.Done():
+			return result(), ctx.Err()
+		}
+	}
+}
+
+// normalizeLineEndings converts \r\n and bare \r to \n
+func normalizeLineEndings(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// stripCommandEcho removes the echoed command line from the start of the
+// output, so that patterns are not matched against the command itself.
+// While the echo is still arriving, no output is reported.
+func stripCommandEcho(output, command string) string {
+	if command == "" {
+		return output
+	}
+
+	line, rest, complete := strings.Cut(strings.TrimLeft(output, "\n"), "\n")
+	if !complete {
+		if strings.Contains(line, command) || strings.HasPrefix(command, strings.TrimSpace(line)) {
+			return ""
+		}
+		return output
+	}
+	if strings.Contains(line, command) {
+		return rest
+	}
+	return output
+}
@@ -0,0 +1,188 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// fakeConsole replies to the first data chunk with the scripted output
+type fakeConsole struct {
+	mu     sync.Mutex
+	sent   []*gatewayv1.ConsoleDataChunk
+	reply  []string
+	final  *gatewayv1.ConsoleDataChunk // Sent after the reply, e.g. an error chunk
+	err    error
+	recvCh chan *gatewayv1.ConsoleDataChunk
+	closed bool
+}
+
+func newFakeConsole(reply ...string) *fakeConsole {
+	return &fakeConsole{reply: reply, recvCh: make(chan *gatewayv1.ConsoleDataChunk, len(reply)+2)}
+}
+
+func (c *fakeConsole) Send(chunk *gatewayv1.ConsoleDataChunk) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sent = append(c.sent, chunk)
+	if len(chunk.Data) > 0 && len(c.sent) == 1 {
+		for _, data := range c.reply {
+			c.recvCh <- &gatewayv1.ConsoleDataChunk{Data: []byte(data)}
+		}
+		if c.final != nil {
+			c.recvCh <- c.final
+		}
+		if c.err != nil {
+			close(c.recvCh)
+		}
+	}
+	return nil
+}
+
+func (c *fakeConsole) Receive() (*gatewayv1.ConsoleDataChunk, error) {
+	chunk, ok := <-c.recvCh
+	if !ok {
+		return nil, c.err
+	}
+	return chunk, nil
+}
+
+func (c *fakeConsole) CloseRequest() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	return nil
+}
+
+func TestExec(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		reply       []string
+		streamErr   error
+		expect      string
+		wantOutput  string
+		wantMatched bool
+		wantErr     error
+	}{
+		{
+			name:        "pattern after echo",
+			command:     "ls /",
+			reply:       []string{"ls /\r\n", "bin  etc\r\n", "root@host:~$ "},
+			expect:      `\$ $`,
+			wantOutput:  "bin  etc\nroot@host:~$ ",
+			wantMatched: true,
+		},
+		{
+			name:        "echo split across chunks is not matched",
+			command:     "echo $HOME",
+			reply:       []string{"echo $", "HOME\r\n/root\r\n# "},
+			expect:      `#|\$`,
+			wantOutput:  "/root\n# ",
+			wantMatched: true,
+		},
+		{
+			name:       "timeout",
+			command:    "ls /",
+			reply:      []string{"ls /\r\n", "still booting"},
+			expect:     `login:`,
+			wantOutput: "still booting",
+			wantErr:    ErrExpectTimeout,
+		},
+		{
+			name:       "idle without pattern",
+			command:    "uptime",
+			reply:      []string{"uptime\r\n", " 10:00 up 1 day\r\n"},
+			wantOutput: " 10:00 up 1 day\n",
+		},
+		{
+			name:        "stream closed after output",
+			command:     "ls /",
+			reply:       []string{"ls /\r\n", "bin\r\n$ "},
+			streamErr:   io.EOF,
+			expect:      `\$ $`,
+			wantOutput:  "bin\n$ ",
+			wantMatched: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			console := newFakeConsole(tt.reply...)
+			console.err = tt.streamErr
+
+			opts := ExecOptions{
+				Command:     tt.command,
+				Timeout:     200 * time.Millisecond,
+				IdleTimeout: 50 * time.Millisecond,
+			}
+			if tt.expect != "" {
+				opts.Expect = regexp.MustCompile(tt.expect)
+			}
+
+			result, err := Exec(context.Background(), console, "sol-1", opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Exec() error = %v, want %v", err, tt.wantErr)
+			}
+			if result.Output != tt.wantOutput {
+				t.Errorf("Output = %q, want %q", result.Output, tt.wantOutput)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+
+			if got := string(console.sent[0].Data); got != tt.command+"\r" {
+				t.Errorf("sent %q, want %q", got, tt.command+"\r")
+			}
+			last := console.sent[len(console.sent)-1]
+			if !last.CloseStream || !console.closed {
+				t.Error("stream was not closed")
+			}
+		})
+	}
+}
+
+func TestExecConsoleError(t *testing.T) {
+	console := newFakeConsole("ls /\r\n")
+	console.final = &gatewayv1.ConsoleDataChunk{
+		CloseStream:  true,
+		ErrorCode:    "bmc_unreachable",
+		ErrorMessage: "SOL read error: connection refused",
+	}
+
+	_, err := Exec(context.Background(), console, "sol-1", ExecOptions{
+		Command: "ls /",
+		Expect:  regexp.MustCompile(`\$ $`),
+		Timeout: time.Second,
+	})
+	if err == nil || !strings.Contains(err.Error(), "console stream failed (bmc_unreachable): SOL read error: connection refused") {
+		t.Fatalf("Exec() error = %v, want the console stream error", err)
+	}
+}
+
+func TestStripCommandEcho(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: "ls /\nbin\n", expected: "bin\n"},
+		{output: "\n$ ls /\nbin\n", expected: "bin\n"},
+		{output: "ls", expected: ""},
+		{output: "bin\n$ ", expected: "bin\n$ "},
+	}
+
+	for _, tt := range tests {
+		if got := stripCommandEcho(tt.output, "ls /"); got != tt.expected {
+			t.Errorf("stripCommandEcho(%q) = %q, want %q", tt.output, got, tt.expected)
+		}
+	}
+}
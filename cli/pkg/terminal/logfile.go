@@ -0,0 +1,133 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// consoleLogTimeFormat is the timestamp prefixed to every logged line
+const consoleLogTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ConsoleLog writes console output to a file, prefixing every line with the
+// time it was received.
+//
+// Line endings are normalized so that carriage returns used by the console to
+// redraw a line start a new timestamped line instead of overwriting it.
+type ConsoleLog struct {
+	mu sync.Mutex
+
+	// w receives the timestamped output
+	w io.Writer
+
+	// closer closes the underlying file, if the log owns one
+	closer io.Closer
+
+	// now returns the timestamp for new lines
+	now func() time.Time
+
+	// midLine is true when the last write did not end a line
+	midLine bool
+
+	// pendingCR is true when the last byte written was a carriage return,
+	// so that a following \n is not logged as an empty line
+	pendingCR bool
+
+	// closed is set by Close, later writes are discarded
+	closed bool
+}
+
+// NewConsoleLog creates a console log writing to w
+func NewConsoleLog(w io.Writer) *ConsoleLog {
+	return &ConsoleLog{w: w, now: time.Now}
+}
+
+// OpenConsoleLog opens path for appending and returns a console log writing
+// to it. The file is created with owner-only permissions since console output
+// may contain credentials.
+func OpenConsoleLog(path string) (*ConsoleLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open console log: %w", err)
+	}
+
+	log := NewConsoleLog(file)
+	log.closer = file
+	return log, nil
+}
+
+// Write logs console output. It implements io.Writer.
+func (l *ConsoleLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return len(p), nil
+	}
+
+	out := make([]byte, 0, len(p)+64)
+	for _, b := range p {
+		if b == '\n' && l.pendingCR {
+			// Second half of \r\n, the line was already ended
+			l.pendingCR = false
+			continue
+		}
+		l.pendingCR = b == '\r'
+
+		if !l.midLine {
+			out = append(out, l.now().Format(consoleLogTimeFormat)...)
+			out = append(out, ' ')
+			l.midLine = true
+		}
+
+		if b == '\r' || b == '\n' {
+			out = append(out, '\n')
+			l.midLine = false
+			continue
+		}
+		out = append(out, b)
+	}
+
+	if _, err := l.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Mark writes a timestamped annotation on its own line, e.g. to delimit
+// sessions in a log file shared by several runs
+func (l *ConsoleLog) Mark(format string, args ...interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+
+	prefix := ""
+	if l.midLine {
+		prefix = "\n"
+		l.midLine = false
+	}
+	l.pendingCR = false
+
+	_, err := fmt.Fprintf(l.w, "%s%s --- %s ---\n", prefix, l.now().Format(consoleLogTimeFormat), fmt.Sprintf(format, args...))
+	return err
+}
+
+// Close stops logging and closes the log file, if the log owns one. Later
+// writes are discarded. Close is safe to call multiple times.
+func (l *ConsoleLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closed = true
+	if l.closer == nil {
+		return nil
+	}
+	err := l.closer.Close()
+	l.closer = nil
+	return err
+}
@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleLogTimestampsLines(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewConsoleLog(&buf)
+	log.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 6000000, time.UTC) }
+
+	writes := []string{"BIOS v1.0\r", "\nBooting", " kernel\r\n", "\rProgress 50%\rProgress 100%\n"}
+	for _, w := range writes {
+		n, err := log.Write([]byte(w))
+		if err != nil {
+			t.Fatalf("Write(%q) error: %v", w, err)
+		}
+		if n != len(w) {
+			t.Errorf("Write(%q) = %d, want %d", w, n, len(w))
+		}
+	}
+
+	ts := "2025-01-02T03:04:05.006Z "
+	expected := ts + "BIOS v1.0\n" +
+		ts + "Booting kernel\n" +
+		ts + "\n" +
+		ts + "Progress 50%\n" +
+		ts + "Progress 100%\n"
+	if buf.String() != expected {
+		t.Errorf("log =\n%q\nwant\n%q", buf.String(), expected)
+	}
+}
+
+func TestConsoleLogMarkAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "console.log")
+
+	// Existing content is preserved
+	if err := os.WriteFile(path, []byte("previous run\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := OpenConsoleLog(path)
+	if err != nil {
+		t.Fatalf("OpenConsoleLog() error: %v", err)
+	}
+	if err := log.Mark("session %s started", "sol-1"); err != nil {
+		t.Fatalf("Mark() error: %v", err)
+	}
+	log.Write([]byte("login: "))
+	log.Mark("session %s ended", "sol-1")
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("second Close() error: %v", err)
+	}
+
+	// Writes after close are discarded
+	if _, err := log.Write([]byte("late")); err != nil {
+		t.Fatalf("Write() after Close error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(lines), data)
+	}
+	if lines[0] != "previous run" {
+		t.Errorf("line 0 = %q, want previous content", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " --- session sol-1 started ---") {
+		t.Errorf("line 1 = %q, want start marker", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], " login: ") {
+		t.Errorf("line 2 = %q, want console output", lines[2])
+	}
+	if !strings.HasSuffix(lines[3], " --- session sol-1 ended ---") {
+		t.Errorf("line 3 = %q, want end marker", lines[3])
+	}
+}
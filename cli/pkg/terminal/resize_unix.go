@@ -0,0 +1,14 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize relays terminal resize signals (SIGWINCH) to ch
+func notifyResize(ch chan<- os.Signal) {
+	signal.Notify(ch, syscall.SIGWINCH)
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package terminal
+
+import "os"
+
+// notifyResize is a no-op on Windows, which has no resize signal. The initial
+// terminal size is still sent when the session starts.
+func notifyResize(ch chan<- os.Signal) {}
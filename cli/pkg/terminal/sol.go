@@ -16,16 +16,6 @@ import (
 	gatewayv1 "gateway/gen/gateway/v1"
 )
 
-const (
-	// exitSequence1 is the first byte of the exit sequence (Ctrl+]).
-	// This follows the convention used by telnet and other terminal programs.
-	exitSequence1 = 0x1D // Ctrl+]
-
-	// exitSequence2 is the second byte of the exit sequence ('q').
-	// After pressing Ctrl+], the user must press 'q' to exit.
-	exitSequence2 = 'q'
-)
-
 // SOLTerminal handles terminal streaming for Serial-over-LAN console using Connect RPC.
 //
 // SOLTerminal provides a bridge between the local terminal (stdin/stdout) and a remote
@@ -56,15 +46,27 @@ type SOLTerminal struct {
 	// mu protects concurrent access to shared state
 	mu sync.Mutex
 
+	// sendMu serializes sends on the stream, which is not safe for concurrent use
+	sendMu sync.Mutex
+
 	// done signals that the session should terminate
 	done chan struct{}
 
-	// exitPressed tracks whether Ctrl+] was pressed (first byte of exit sequence)
-	exitPressed bool
+	// escapeKey is the first byte of the escape sequence, zero if disabled
+	escapeKey byte
+
+	// escapePressed tracks whether the escape key was the last byte read
+	escapePressed bool
+
+	// detached is set when the user detached instead of closing the session
+	detached bool
 
 	// rawMode controls whether to preserve terminal control sequences (true)
 	// or convert them for append-only output (false, default)
 	rawMode bool
+
+	// log receives a timestamped copy of the console output, if set
+	log *ConsoleLog
 }
 
 // NewSOLTerminal creates a new SOL terminal handler with Connect bidirectional streaming.
@@ -95,6 +97,7 @@ func NewSOLTerminal(stream *connect.BidiStreamForClient[gatewayv1.ConsoleDataChu
 		stdin:     os.Stdin,
 		stdout:    os.Stdout,
 		done:      make(chan struct{}),
+		escapeKey: defaultEscapeByte,
 		rawMode:   false, // Default to append-only mode
 	}
 }
@@ -112,11 +115,25 @@ func (t *SOLTerminal) SetRawMode(raw bool) {
 	t.rawMode = raw
 }
 
+// SetLogFile tees all console output received from the server to the file
+// at path, with every line prefixed by the time it was received. The file is
+// appended to if it exists and is closed by Close.
+//
+// This method must be called before Start().
+func (t *SOLTerminal) SetLogFile(path string) error {
+	log, err := OpenConsoleLog(path)
+	if err != nil {
+		return err
+	}
+	t.log = log
+	return nil
+}
+
 // Start begins the terminal streaming session.
 //
 // This method sets the terminal to raw mode, starts bidirectional streaming goroutines,
 // and blocks until the session ends due to:
-//   - User exit sequence (Ctrl+] then 'q')
+//   - User escape sequence (Ctrl+] then 'q' to exit or 'd' to detach by default)
 //   - Interrupt signal (Ctrl+C, detected as raw byte 0x03)
 //   - Context cancellation
 //   - Stream error
@@ -136,15 +153,26 @@ func (t *SOLTerminal) Start(ctx context.Context) error {
 	clearScreen()
 
 	// Print initial message to stderr (stdout is for console data only)
-	fmt.Fprintln(os.Stderr, "Connected to server console. Press Ctrl+C or Ctrl+] then 'q' to exit.")
+	fmt.Fprintln(os.Stderr, "Connected to server console. "+t.escapeHelp())
 	fmt.Fprintln(os.Stderr, "----------------------------------------")
 
+	if t.log != nil {
+		_ = t.log.Mark("session %s started", t.sessionID)
+	}
+
 	// Set terminal to raw mode
 	if err := t.setRawMode(); err != nil {
 		return fmt.Errorf("failed to set raw mode: %w", err)
 	}
 	defer t.restore()
 
+	// Tell the remote side about the terminal size, and keep it updated
+	t.sendResize()
+	resizeCh := make(chan os.Signal, 1)
+	notifyResize(resizeCh)
+	defer signal.Stop(resizeCh)
+	go t.watchResize(ctx, resizeCh)
+
 	// Handle cleanup on interrupt
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
@@ -197,13 +225,15 @@ func (t *SOLTerminal) Start(ctx context.Context) error {
 //
 // This goroutine continuously receives ConsoleDataChunk messages from the Connect stream
 // and writes the data to stdout. It handles:
-//   - CloseStream signals from the server
+//   - CloseStream signals and errors reported by the server
 //   - Stream errors and EOF
 //   - Context cancellation
 //   - Done channel signals
 //
 // This method runs in its own goroutine and returns when the stream ends.
 func (t *SOLTerminal) streamToStdout(ctx context.Context) error {
+	log := t.log
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -220,7 +250,10 @@ func (t *SOLTerminal) streamToStdout(ctx context.Context) error {
 				return fmt.Errorf("stream receive error: %w", err)
 			}
 
-			// Check for close signal
+			// Check for a failure reported by the agent, then for close signal
+			if msg.ErrorCode != "" {
+				return consoleError(msg)
+			}
 			if msg.CloseStream {
 				return io.EOF
 			}
@@ -236,23 +269,37 @@ func (t *SOLTerminal) streamToStdout(ctx context.Context) error {
 				if _, err := t.stdout.Write(data); err != nil {
 					return fmt.Errorf("failed to write to stdout: %w", err)
 				}
+
+				// A failing log must not interrupt the console session
+				if log != nil {
+					if _, err := log.Write(msg.Data); err != nil {
+						fmt.Fprintf(os.Stderr, "\r\nWarning: console logging stopped: %v\r\n", err)
+						log = nil
+					}
+				}
 			}
 		}
 	}
 }
 
+// consoleError returns the failure carried by an error chunk, e.g. a BMC
+// rejecting the agent's credentials
+func consoleError(msg *gatewayv1.ConsoleDataChunk) error {
+	return fmt.Errorf("console stream failed (%s): %s", msg.ErrorCode, msg.ErrorMessage)
+}
+
 // stdinToStream reads from stdin and writes to Connect stream.
 //
 // This goroutine continuously reads from stdin and sends ConsoleDataChunk messages
 // to the Connect stream. It handles:
-//   - Exit sequence detection (Ctrl+] then 'q')
+//   - Escape sequence detection (exit and detach)
 //   - Ctrl+C interrupt signal (raw byte 0x03)
 //   - Context cancellation
 //   - Done channel signals
 //   - Stream send errors
 //
 // Each chunk of data read from stdin is sent as a separate ConsoleDataChunk with
-// the session ID. The exit sequence check happens before sending data.
+// the session ID. Input preceding an escape sequence is sent before acting on it.
 //
 // This method runs in its own goroutine and returns when the user exits or the stream ends.
 func (t *SOLTerminal) stdinToStream(ctx context.Context) error {
@@ -301,54 +348,87 @@ func (t *SOLTerminal) stdinToStream(ctx context.Context) error {
 					}
 				}
 
-				// Check for exit sequence
-				if t.checkExitSequence(data) {
-					fmt.Fprintln(os.Stderr, "\n----------------------------------------")
-					fmt.Fprintln(os.Stderr, "Console closed by user.")
-					close(t.done)
-					return io.EOF
-				}
+				// Check for escape sequence
+				data, action := t.checkEscapeSequence(data)
 
 				// Send data to Connect stream
-				chunk := &gatewayv1.ConsoleDataChunk{
-					SessionId: t.sessionID,
-					Data:      data,
+				if len(data) > 0 {
+					chunk := &gatewayv1.ConsoleDataChunk{
+						SessionId: t.sessionID,
+						Data:      data,
+					}
+
+					if err := t.send(chunk); err != nil {
+						return fmt.Errorf("failed to send to stream: %w", err)
+					}
 				}
 
-				if err := t.stream.Send(chunk); err != nil {
-					return fmt.Errorf("failed to send to stream: %w", err)
+				switch action {
+				case escapeActionQuit:
+					fmt.Fprintln(os.Stderr, "\n----------------------------------------")
+					fmt.Fprintln(os.Stderr, "Console closed by user.")
+					close(t.done)
+					return io.EOF
+				case escapeActionDetach:
+					t.mu.Lock()
+					t.detached = true
+					t.mu.Unlock()
+					fmt.Fprintln(os.Stderr, "\n----------------------------------------")
+					fmt.Fprintln(os.Stderr, "Detached from console session.")
+					close(t.done)
+					return io.EOF
 				}
 			}
 		}
 	}
 }
 
-// checkExitSequence checks if the exit sequence was pressed.
-//
-// The exit sequence is Ctrl+] (0x1D) followed by 'q'. This method maintains state
-// across calls to handle the sequence split across multiple read operations.
-//
-// Returns true if the complete exit sequence is detected, false otherwise.
-//
-// Thread safety: This method is protected by the SOLTerminal mutex.
-func (t *SOLTerminal) checkExitSequence(data []byte) bool {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+// send sends a chunk on the Connect stream. Input, resize and close chunks
+// are sent from different goroutines.
+func (t *SOLTerminal) send(chunk *gatewayv1.ConsoleDataChunk) error {
+	t.sendMu.Lock()
+	defer t.sendMu.Unlock()
+	return t.stream.Send(chunk)
+}
 
-	for _, b := range data {
-		if t.exitPressed {
-			// Previous byte was Ctrl+], check for 'q'
-			if b == exitSequence2 {
-				return true
-			}
-			t.exitPressed = false
-		} else if b == exitSequence1 {
-			// Got Ctrl+], wait for next byte
-			t.exitPressed = true
+// watchResize sends the new terminal size whenever the local terminal is
+// resized, until the session ends.
+func (t *SOLTerminal) watchResize(ctx context.Context, resizeCh <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.done:
+			return
+		case <-resizeCh:
+			t.sendResize()
 		}
 	}
+}
+
+// sendResize sends the current terminal size as a control chunk.
+//
+// Full-screen programs on the server otherwise assume 80x24. The agent applies
+// the size where the underlying console supports it; serial consoles that have
+// no notion of size ignore it. Nothing is sent when stdout is not a terminal.
+func (t *SOLTerminal) sendResize() {
+	cols, rows, err := term.GetSize(int(t.stdout.Fd()))
+	if err != nil || cols <= 0 || rows <= 0 {
+		return
+	}
 
-	return false
+	_ = t.send(resizeChunk(t.sessionID, cols, rows))
+}
+
+// resizeChunk builds a control chunk carrying the terminal size
+func resizeChunk(sessionID string, cols, rows int) *gatewayv1.ConsoleDataChunk {
+	return &gatewayv1.ConsoleDataChunk{
+		SessionId: sessionID,
+		Resize: &gatewayv1.TerminalSize{
+			Cols: uint32(cols),
+			Rows: uint32(rows),
+		},
+	}
 }
 
 // setRawMode sets the terminal to raw mode for character-by-character input.
@@ -409,13 +489,18 @@ func (t *SOLTerminal) Close() error {
 		close(t.done)
 	}
 
+	if t.log != nil {
+		_ = t.log.Mark("session %s ended", t.sessionID)
+		_ = t.log.Close()
+	}
+
 	if t.stream != nil {
 		// Send close signal
 		closeChunk := &gatewayv1.ConsoleDataChunk{
 			SessionId:   t.sessionID,
 			CloseStream: true,
 		}
-		_ = t.stream.Send(closeChunk)
+		_ = t.send(closeChunk)
 
 		// Close the stream
 		return t.stream.CloseRequest()
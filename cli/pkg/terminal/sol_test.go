@@ -1,81 +1,158 @@
 package terminal
 
 import (
+	"bytes"
 	"testing"
 )
 
-// TestCheckExitSequence verifies exit sequence detection logic.
+// TestCheckEscapeSequence verifies escape sequence detection logic.
 //
 // Tests various input scenarios including:
-//   - Complete exit sequence (Ctrl+] then 'q')
+//   - Exit and detach sequences (Ctrl+] then 'q' or 'd')
 //   - Incomplete sequences
 //   - Normal text input
-//   - Exit sequence split across multiple calls
-func TestCheckExitSequence(t *testing.T) {
+//   - Escape key pressed twice to send it to the console
+func TestCheckEscapeSequence(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    []byte
-		expected bool
+		name        string
+		input       []byte
+		wantForward []byte
+		wantAction  escapeAction
 	}{
 		{
-			name:     "exit sequence ctrl+] then q",
-			input:    []byte{0x1D, 'q'},
-			expected: true,
+			name:        "exit sequence ctrl+] then q",
+			input:       []byte{0x1D, 'q'},
+			wantForward: []byte{},
+			wantAction:  escapeActionQuit,
 		},
 		{
-			name:     "ctrl+] but not q",
-			input:    []byte{0x1D, 'x'},
-			expected: false,
+			name:        "detach sequence ctrl+] then d",
+			input:       []byte("ls\x1Dd"),
+			wantForward: []byte("ls"),
+			wantAction:  escapeActionDetach,
 		},
 		{
-			name:     "normal text",
-			input:    []byte("hello world"),
-			expected: false,
+			name:        "ctrl+] but not q",
+			input:       []byte{0x1D, 'x'},
+			wantForward: []byte{0x1D, 'x'},
+			wantAction:  escapeActionNone,
 		},
 		{
-			name:     "ctrl+] at end",
-			input:    []byte("test\x1D"),
-			expected: false,
+			name:        "ctrl+] pressed twice",
+			input:       []byte{0x1D, 0x1D, 'q'},
+			wantForward: []byte{0x1D, 'q'},
+			wantAction:  escapeActionNone,
 		},
 		{
-			name:     "split across calls - ctrl+]",
-			input:    []byte{0x1D},
-			expected: false,
+			name:        "normal text",
+			input:       []byte("hello world"),
+			wantForward: []byte("hello world"),
+			wantAction:  escapeActionNone,
+		},
+		{
+			name:        "ctrl+] at end",
+			input:       []byte("test\x1D"),
+			wantForward: []byte("test"),
+			wantAction:  escapeActionNone,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			terminal := &SOLTerminal{
-				done: make(chan struct{}),
+				done:      make(chan struct{}),
+				escapeKey: defaultEscapeByte,
 			}
 
-			result := terminal.checkExitSequence(tt.input)
-			if result != tt.expected {
-				t.Errorf("checkExitSequence(%q) = %v, want %v", tt.input, result, tt.expected)
+			forward, action := terminal.checkEscapeSequence(tt.input)
+			if action != tt.wantAction {
+				t.Errorf("checkEscapeSequence(%q) action = %v, want %v", tt.input, action, tt.wantAction)
+			}
+			if !bytes.Equal(forward, tt.wantForward) {
+				t.Errorf("checkEscapeSequence(%q) forward = %q, want %q", tt.input, forward, tt.wantForward)
 			}
 		})
 	}
 }
 
-// TestCheckExitSequenceSplitAcrossCalls verifies state preservation across multiple calls.
+// TestCheckEscapeSequenceSplitAcrossCalls verifies state preservation across multiple calls.
 //
-// This test ensures that the exit sequence can be detected even when Ctrl+] and 'q'
+// This test ensures that the escape sequence can be detected even when Ctrl+] and 'q'
 // arrive in separate stdin read operations.
-func TestCheckExitSequenceSplitAcrossCalls(t *testing.T) {
+func TestCheckEscapeSequenceSplitAcrossCalls(t *testing.T) {
 	terminal := &SOLTerminal{
-		done: make(chan struct{}),
+		done:      make(chan struct{}),
+		escapeKey: defaultEscapeByte,
 	}
 
 	// First call with Ctrl+]
-	result := terminal.checkExitSequence([]byte{0x1D})
-	if result {
+	if _, action := terminal.checkEscapeSequence([]byte{0x1D}); action != escapeActionNone {
 		t.Error("First call with Ctrl+] should not exit")
 	}
 
 	// Second call with 'q' should trigger exit
-	result = terminal.checkExitSequence([]byte{'q'})
-	if !result {
+	if _, action := terminal.checkEscapeSequence([]byte{'q'}); action != escapeActionQuit {
 		t.Error("Second call with 'q' after Ctrl+] should exit")
 	}
 }
+
+// TestCheckEscapeSequenceDisabled verifies input is forwarded untouched without an escape key.
+func TestCheckEscapeSequenceDisabled(t *testing.T) {
+	terminal := &SOLTerminal{done: make(chan struct{})}
+
+	input := []byte{0x1D, 'q'}
+	forward, action := terminal.checkEscapeSequence(input)
+	if action != escapeActionNone || !bytes.Equal(forward, input) {
+		t.Errorf("checkEscapeSequence(%q) = %q, %v; want input forwarded", input, forward, action)
+	}
+}
+
+// TestParseEscapeKey verifies the accepted escape key notations.
+func TestParseEscapeKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    byte
+		wantErr bool
+	}{
+		{key: DefaultEscapeKey, want: 0x1D},
+		{key: "^a", want: 0x01},
+		{key: "^B", want: 0x02},
+		{key: "^?", want: 0x7F},
+		{key: "~", want: '~'},
+		{key: "none", want: 0},
+		{key: "", wantErr: true},
+		{key: "^1", wantErr: true},
+		{key: "q", wantErr: true},
+		{key: "ctrl-a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEscapeKey(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEscapeKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseEscapeKey(%q) = %#x, want %#x", tt.key, got, tt.want)
+		}
+	}
+
+	if got := FormatEscapeKey(0x1D); got != "Ctrl+]" {
+		t.Errorf("FormatEscapeKey(0x1D) = %q, want Ctrl+]", got)
+	}
+}
+
+// TestResizeChunk verifies terminal size control chunks carry no console data.
+func TestResizeChunk(t *testing.T) {
+	chunk := resizeChunk("sol-1", 132, 43)
+
+	if chunk.SessionId != "sol-1" {
+		t.Errorf("SessionId = %q, want sol-1", chunk.SessionId)
+	}
+	if len(chunk.Data) != 0 || chunk.IsHandshake || chunk.CloseStream {
+		t.Errorf("resize chunk should only carry the size, got %+v", chunk)
+	}
+	if chunk.Resize.GetCols() != 132 || chunk.Resize.GetRows() != 43 {
+		t.Errorf("Resize = %dx%d, want 132x43", chunk.Resize.GetCols(), chunk.Resize.GetRows())
+	}
+}
@@ -0,0 +1,8 @@
+// Package watch implements periodic refresh loops for CLI commands that
+// support --watch, similar to kubectl get --watch.
+//
+// Run invokes a refresh function immediately and then at a fixed interval
+// until the context is cancelled (typically by Ctrl+C). Tracker remembers the
+// rendered value of each resource between refreshes so commands can highlight
+// rows that changed, were added, or disappeared.
+package watch
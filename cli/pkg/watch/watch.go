@@ -0,0 +1,104 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultInterval is the refresh interval used when none is configured
+const DefaultInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// Run calls refresh immediately and then every interval until ctx is done.
+//
+// Errors returned by refresh are written to errOut and the loop continues,
+// so transient failures (e.g. a BMC that is power cycling) do not end the
+// watch. Run returns nil when ctx is cancelled.
+func Run(ctx context.Context, interval time.Duration, errOut io.Writer, refresh func(ctx context.Context) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := refresh(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(errOut, "%s refresh failed: %v\n", time.Now().Format("15:04:05"), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Redraw clears the terminal and writes a header line with the command
+// being watched, the interval and the current time
+func Redraw(w io.Writer, command string, interval time.Duration) {
+	fmt.Fprint(w, clearScreen)
+	fmt.Fprintf(w, "Every %s: %s  (%s)\n\n", interval, command, time.Now().Format("2006-01-02 15:04:05"))
+}
+
+// Tracker records resource values between refreshes
+type Tracker struct {
+	previous map[string]string
+	primed   bool
+}
+
+// NewTracker creates an empty Tracker
+func NewTracker() *Tracker {
+	return &Tracker{previous: make(map[string]string)}
+}
+
+// Changes describes the difference between two refreshes
+type Changes struct {
+	// Changed holds keys whose value differs from the previous refresh,
+	// including keys that were not present before
+	Changed map[string]bool
+	// Removed lists keys present in the previous refresh but not this one
+	Removed []string
+}
+
+// Any returns true if anything changed
+func (c Changes) Any() bool {
+	return len(c.Changed) > 0 || len(c.Removed) > 0
+}
+
+// Update records the current values and returns what changed.
+// The first update establishes the baseline and reports no changes.
+func (t *Tracker) Update(current map[string]string) Changes {
+	changes := Changes{Changed: make(map[string]bool)}
+
+	if t.primed {
+		for key, value := range current {
+			if previous, exists := t.previous[key]; !exists || previous != value {
+				changes.Changed[key] = true
+			}
+		}
+		for key := range t.previous {
+			if _, exists := current[key]; !exists {
+				changes.Removed = append(changes.Removed, key)
+			}
+		}
+	}
+
+	t.previous = make(map[string]string, len(current))
+	for key, value := range current {
+		t.previous[key] = value
+	}
+	t.primed = true
+
+	return changes
+}
+
+// Primed returns true once the baseline has been recorded
+func (t *Tracker) Primed() bool {
+	return t.primed
+}
@@ -0,0 +1,77 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errOut bytes.Buffer
+	calls := 0
+	err := Run(ctx, time.Millisecond, &errOut, func(ctx context.Context) error {
+		calls++
+		switch calls {
+		case 2:
+			return errors.New("bmc unreachable")
+		case 3:
+			cancel()
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Run() refresh calls = %d, want 3", calls)
+	}
+	if !strings.Contains(errOut.String(), "refresh failed: bmc unreachable") {
+		t.Errorf("Run() should report refresh errors, got %q", errOut.String())
+	}
+}
+
+func TestRun_InvalidInterval(t *testing.T) {
+	err := Run(context.Background(), 0, &bytes.Buffer{}, func(context.Context) error { return nil })
+	if err == nil {
+		t.Fatal("Run() with zero interval should fail")
+	}
+}
+
+func TestTracker_Update(t *testing.T) {
+	tracker := NewTracker()
+
+	first := tracker.Update(map[string]string{"srv-1": "on", "srv-2": "off"})
+	if first.Any() {
+		t.Errorf("first Update() should report no changes, got %+v", first)
+	}
+	if !tracker.Primed() {
+		t.Error("Primed() should be true after first Update()")
+	}
+
+	second := tracker.Update(map[string]string{"srv-1": "off", "srv-2": "off", "srv-3": "on"})
+	var changed []string
+	for key := range second.Changed {
+		changed = append(changed, key)
+	}
+	sort.Strings(changed)
+	if strings.Join(changed, ",") != "srv-1,srv-3" {
+		t.Errorf("Update() changed = %v, want [srv-1 srv-3]", changed)
+	}
+
+	third := tracker.Update(map[string]string{"srv-1": "off"})
+	sort.Strings(third.Removed)
+	if strings.Join(third.Removed, ",") != "srv-2,srv-3" {
+		t.Errorf("Update() removed = %v, want [srv-2 srv-3]", third.Removed)
+	}
+	if len(third.Changed) != 0 {
+		t.Errorf("Update() changed = %v, want none", third.Changed)
+	}
+}
@@ -1,6 +1,10 @@
 package auth
 
-import "time"
+import (
+	"time"
+
+	"core/domain"
+)
 
 // ServerContext contains the BMC endpoint information that can be encrypted in JWT tokens
 type ServerContext struct {
@@ -11,8 +15,60 @@ type ServerContext struct {
 	Features     []string  `json:"features"`
 	DatacenterID string    `json:"datacenter_id"`
 	Permissions  []string  `json:"permissions"`
+	Maintenance  bool      `json:"maintenance,omitempty"`
 	IssuedAt     time.Time `json:"iat"`
 	ExpiresAt    time.Time `json:"exp"`
+
+	// Maintenance windows of the server in progress during the token's
+	// lifetime
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows,omitempty"`
+
+	// Console session limits of the customer of the token, nil for none
+	SessionQuota *SessionQuota `json:"session_quota,omitempty"`
+
+	// Source IP ranges the customer of the token accesses BMCs from, see
+	// SourceAllowed. Empty for any.
+	AllowedSourceRanges []string `json:"allowed_source_ranges,omitempty"`
+
+	// Email of the admin who obtained the token to open consoles on behalf
+	// of the customer, empty for the customer's own tokens
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
+}
+
+// SessionQuota limits the console sessions of a customer on a gateway. Zero
+// limits are unlimited.
+type SessionQuota struct {
+	CustomerID    string `json:"customer_id"`
+	MaxConcurrent int    `json:"max_concurrent,omitempty"` // Console sessions open at once
+	PerMinute     int    `json:"per_minute,omitempty"`     // Console sessions created per minute
+}
+
+// MaintenanceWindow is a scheduled maintenance of the server of a token
+type MaintenanceWindow struct {
+	ID       int64     `json:"id"`
+	Policy   string    `json:"policy"` // A domain.MaintenancePolicy
+	Reason   string    `json:"reason,omitempty"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// ActiveMaintenanceWindow returns the maintenance window in progress at a
+// time, one rejecting operations when windows overlap, nil when there is none
+func (sc *ServerContext) ActiveMaintenanceWindow(at time.Time) *MaintenanceWindow {
+	var active *MaintenanceWindow
+	for i := range sc.MaintenanceWindows {
+		window := &sc.MaintenanceWindows[i]
+		if at.Before(window.StartsAt) || !at.Before(window.EndsAt) {
+			continue
+		}
+		if window.Policy == string(domain.MaintenancePolicyReject) {
+			return window
+		}
+		if active == nil {
+			active = window
+		}
+	}
+	return active
 }
 
 // HasPermission checks if the server context has a specific permission
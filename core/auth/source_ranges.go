@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ParseSourceRange parses an entry of a source IP allowlist: a CIDR range,
+// e.g. "10.8.0.0/16", or a single address
+func ParseSourceRange(source string) (netip.Prefix, error) {
+	source = strings.TrimSpace(source)
+	if strings.Contains(source, "/") {
+		prefix, err := netip.ParsePrefix(source)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid source range %q: %w", source, err)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(source)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid source range %q: %w", source, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// SourceAllowed reports whether a client address, "host:port" or a bare IP,
+// is in an allowlist of source ranges. Empty allowlists allow any address.
+// Invalid entries match nothing, so that a corrupt allowlist fails closed.
+func SourceAllowed(ranges []string, clientAddress string) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+
+	host := clientAddress
+	if h, _, err := net.SplitHostPort(clientAddress); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap().WithZone("")
+
+	for _, source := range ranges {
+		if prefix, err := ParseSourceRange(source); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
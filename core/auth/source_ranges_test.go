@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSourceRange(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    string
+		wantErr bool
+	}{
+		{source: "10.8.0.0/16", want: "10.8.0.0/16"},
+		{source: "10.8.1.2/16", want: "10.8.0.0/16"},
+		{source: " 203.0.113.7 ", want: "203.0.113.7/32"},
+		{source: "2001:db8::/32", want: "2001:db8::/32"},
+		{source: "::ffff:192.0.2.1", want: "192.0.2.1/32"},
+		{source: "10.8.0.0/33", wantErr: true},
+		{source: "vpn.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		prefix, err := ParseSourceRange(tt.source)
+		if tt.wantErr {
+			assert.Error(t, err, tt.source)
+			continue
+		}
+		if assert.NoError(t, err, tt.source) {
+			assert.Equal(t, tt.want, prefix.String())
+		}
+	}
+}
+
+func TestSourceAllowed(t *testing.T) {
+	ranges := []string{"10.8.0.0/16", "2001:db8::/32"}
+
+	assert.True(t, SourceAllowed(ranges, "10.8.3.4:51234"))
+	assert.True(t, SourceAllowed(ranges, "10.8.3.4"))
+	assert.True(t, SourceAllowed(ranges, "[2001:db8::1]:443"))
+	assert.True(t, SourceAllowed(ranges, "[::ffff:10.8.0.1]:443"), "IPv4-mapped addresses match IPv4 ranges")
+	assert.False(t, SourceAllowed(ranges, "192.0.2.1:51234"))
+	assert.False(t, SourceAllowed(ranges, "not an address"))
+
+	assert.True(t, SourceAllowed(nil, "192.0.2.1:51234"), "empty allowlists allow any address")
+	assert.False(t, SourceAllowed([]string{"garbage"}, "192.0.2.1:51234"), "invalid allowlists fail closed")
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/rs/zerolog"
 	"gopkg.in/yaml.v3"
+
+	"core/logging"
 )
 
 // CommonConfig contains configuration common to all services
@@ -20,7 +22,7 @@ type CommonConfig struct {
 
 // LogConfig configures logging behavior
 type LogConfig struct {
-	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=trace debug info warn warning error fatal panic"`
 	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
 	Debug  bool   `yaml:"debug" env:"DEBUG" default:"false"`
 }
@@ -49,7 +51,7 @@ func (c *LogConfig) ConfigureZerolog() {
 			level = zerolog.PanicLevel
 		}
 	}
-	zerolog.SetGlobalLevel(level)
+	logging.SetLevel(level)
 }
 
 // DatabaseConfig contains database connection configuration
@@ -65,9 +67,70 @@ type TLSConfig struct {
 	KeyFile  string `yaml:"key_file"`
 }
 
+// TracingConfig configures OpenTelemetry tracing. Spans are exported over
+// OTLP/HTTP, e.g. to Jaeger or Tempo.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled" env:"TRACING_ENABLED" default:"false"`
+	// OTLP/HTTP collector URL, e.g. http://tempo:4318. When empty the
+	// exporter's defaults and OTEL_EXPORTER_OTLP_* variables apply.
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
+}
+
+// Validate checks the tracing configuration
+func (c *TracingConfig) Validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample_ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+	return nil
+}
+
+// EventBusConfig configures publishing of system events to a message bus,
+// e.g. NATS JetStream or Kafka
+type EventBusConfig struct {
+	Enabled bool `yaml:"enabled" env:"EVENT_BUS_ENABLED" default:"false"`
+	// Driver is "nats" or "kafka"
+	Driver string `yaml:"driver" env:"EVENT_BUS_DRIVER" default:"nats"`
+	// Comma-separated NATS server URLs or Kafka broker addresses
+	URL string `yaml:"url" env:"EVENT_BUS_URL"`
+	// Events are published to <subject_prefix>.<event type>, a NATS subject
+	// or a Kafka topic
+	SubjectPrefix string `yaml:"subject_prefix" env:"EVENT_BUS_SUBJECT_PREFIX" default:"conduit.events"`
+	// JetStream stream capturing the events, created when missing. NATS only.
+	Stream string `yaml:"stream" env:"EVENT_BUS_STREAM" default:"CONDUIT_EVENTS"`
+	// NATS credentials file. NATS only.
+	CredentialsFile string        `yaml:"credentials_file" env:"EVENT_BUS_CREDENTIALS_FILE"`
+	Timeout         time.Duration `yaml:"timeout" env:"EVENT_BUS_TIMEOUT" default:"5s"`
+	// Events buffered before new ones are dropped
+	QueueSize int `yaml:"queue_size" env:"EVENT_BUS_QUEUE_SIZE" default:"1000"`
+}
+
+// Validate checks the event bus configuration
+func (c *EventBusConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Driver != "nats" && c.Driver != "kafka" {
+		return fmt.Errorf("event_bus driver must be nats or kafka, got %q", c.Driver)
+	}
+	if c.URL == "" {
+		return fmt.Errorf("event_bus url is required")
+	}
+	if c.SubjectPrefix == "" {
+		return fmt.Errorf("event_bus subject_prefix is required")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("event_bus timeout must be positive")
+	}
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("event_bus queue_size must be positive")
+	}
+	return nil
+}
+
 // AuthConfig contains authentication configuration
 type AuthConfig struct {
-	JWTSecretKey string `yaml:"-" env:"JWT_SECRET_KEY"`
+	JWTSecretKey string `yaml:"-" env:"JWT_SECRET_KEY" secret:"true"`
 }
 
 // LoaderConfig configures how configuration is loaded
@@ -113,6 +176,11 @@ func (l *ConfigLoader) Load(target interface{}) error {
 		return fmt.Errorf("failed to load from environment: %w", err)
 	}
 
+	// 5. Check the validate tags of the fields
+	if err := ValidateStruct(target); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return nil
 }
 
@@ -143,7 +211,7 @@ func (l *ConfigLoader) setDefaultsRecursive(v reflect.Value) error {
 		}
 
 		// Handle nested structs
-		if field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct) {
+		if isStructField(field.Type()) {
 			if err := l.setDefaultsRecursive(field); err != nil {
 				return err
 			}
@@ -222,10 +290,13 @@ func (l *ConfigLoader) loadEnvironmentFile(filename string) error {
 
 // loadFromEnv loads configuration from environment variables
 func (l *ConfigLoader) loadFromEnv(target interface{}) error {
-	return l.loadFromEnvRecursive(reflect.ValueOf(target), "")
+	return l.loadFromEnvRecursive(reflect.ValueOf(target), "", "")
 }
 
-func (l *ConfigLoader) loadFromEnvRecursive(v reflect.Value, prefix string) error {
+// loadFromEnvRecursive loads the fields of a struct from the environment.
+// prefix names the struct by its YAML path and legacyPrefix by its Go field
+// names, as variables were named before.
+func (l *ConfigLoader) loadFromEnvRecursive(v reflect.Value, prefix, legacyPrefix string) error {
 	if v.Kind() == reflect.Ptr {
 		if v.IsNil() {
 			v.Set(reflect.New(v.Type().Elem()))
@@ -247,48 +318,104 @@ func (l *ConfigLoader) loadFromEnvRecursive(v reflect.Value, prefix string) erro
 		}
 
 		// Handle nested structs
-		if field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct) {
-			nestedPrefix := prefix
-			if prefix != "" {
-				nestedPrefix += "_"
-			}
-			nestedPrefix += strings.ToUpper(fieldType.Name)
-			if err := l.loadFromEnvRecursive(field, nestedPrefix); err != nil {
+		if isStructField(field.Type()) {
+			name, legacyName := structEnvPrefixes(prefix, legacyPrefix, fieldType)
+			if err := l.loadFromEnvRecursive(field, name, legacyName); err != nil {
 				return err
 			}
 			continue
 		}
 
-		// Get environment variable name from tag or generate from field name
-		envName := fieldType.Tag.Get("env")
-		if envName == "" {
-			envName = prefix
-			if prefix != "" {
-				envName += "_"
+		// Get environment variable names from tag or generate from field name
+		for _, envName := range fieldEnvNames(prefix, legacyPrefix, fieldType) {
+			set, err := l.loadFieldFromEnv(field, fieldType, envName)
+			if err != nil {
+				return err
 			}
-			envName += strings.ToUpper(fieldType.Name)
-		}
-
-		// Check for service-specific override
-		if l.config.ServiceName != "" {
-			serviceSpecificName := strings.ToUpper(l.config.ServiceName) + "_" + envName
-			if value, exists := os.LookupEnv(serviceSpecificName); exists {
-				if err := l.setFieldValue(field, value); err != nil {
-					return fmt.Errorf("failed to set field %s from env %s: %w", fieldType.Name, serviceSpecificName, err)
-				}
-				continue
+			if set {
+				break
 			}
 		}
+	}
+
+	return nil
+}
 
-		// Check for regular environment variable
-		if value, exists := os.LookupEnv(envName); exists {
+// loadFieldFromEnv sets a field from an environment variable, preferring
+// its service-specific override, and returns whether one was set
+func (l *ConfigLoader) loadFieldFromEnv(field reflect.Value, fieldType reflect.StructField, envName string) (bool, error) {
+	// Check for service-specific override
+	if l.config.ServiceName != "" {
+		serviceSpecificName := strings.ToUpper(l.config.ServiceName) + "_" + envName
+		if value, exists := os.LookupEnv(serviceSpecificName); exists {
 			if err := l.setFieldValue(field, value); err != nil {
-				return fmt.Errorf("failed to set field %s from env %s: %w", fieldType.Name, envName, err)
+				return false, fmt.Errorf("failed to set field %s from env %s: %w", fieldType.Name, serviceSpecificName, err)
 			}
+			return true, nil
 		}
 	}
 
-	return nil
+	// Check for regular environment variable
+	if value, exists := os.LookupEnv(envName); exists {
+		if err := l.setFieldValue(field, value); err != nil {
+			return false, fmt.Errorf("failed to set field %s from env %s: %w", fieldType.Name, envName, err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// isStructField returns true for the nested structs of configurations,
+// whose fields are loaded recursively
+func isStructField(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct)
+}
+
+// joinEnvName appends a name to the prefix of its struct
+func joinEnvName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	if name == "" {
+		return prefix
+	}
+	return prefix + "_" + name
+}
+
+// yamlEnvName returns the upper-cased YAML key of a field, "" for inline
+// fields, and its Go name for fields not loaded from YAML
+func yamlEnvName(field reflect.StructField) string {
+	name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch {
+	case options == "inline":
+		return ""
+	case name == "" || name == "-":
+		return strings.ToUpper(field.Name)
+	}
+	return strings.ToUpper(name)
+}
+
+// structEnvPrefixes returns the prefixes of the variables of a nested
+// struct: its YAML path, e.g. MANAGER_RATE_LIMIT, and the legacy path of its
+// Go field names, e.g. MANAGER_RATELIMIT
+func structEnvPrefixes(prefix, legacyPrefix string, field reflect.StructField) (string, string) {
+	return joinEnvName(prefix, yamlEnvName(field)), joinEnvName(legacyPrefix, strings.ToUpper(field.Name))
+}
+
+// fieldEnvNames returns the environment variables of a field, in order of
+// precedence: its env tag, else the YAML path of the field, e.g.
+// MANAGER_RATE_LIMIT_BURST_SIZE, followed by the legacy path of its Go field
+// names, e.g. MANAGER_RATELIMIT_BURSTSIZE
+func fieldEnvNames(prefix, legacyPrefix string, field reflect.StructField) []string {
+	if name := field.Tag.Get("env"); name != "" {
+		return []string{name}
+	}
+	name := joinEnvName(prefix, yamlEnvName(field))
+	legacyName := joinEnvName(legacyPrefix, strings.ToUpper(field.Name))
+	if name == legacyName {
+		return []string{name}
+	}
+	return []string{name, legacyName}
 }
 
 // setFieldValue sets a field value from a string
@@ -331,6 +458,18 @@ func (l *ConfigLoader) setFieldValue(field reflect.Value, value string) error {
 			return fmt.Errorf("invalid float value: %s", value)
 		}
 		field.SetFloat(floatVal)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type: %s", field.Type())
+		}
+		// Comma-separated lists, e.g. ADMIN_EMAILS=ops@example.com,sre@example.com
+		values := reflect.MakeSlice(field.Type(), 0, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = reflect.Append(values, reflect.ValueOf(item).Convert(field.Type().Elem()))
+			}
+		}
+		field.Set(values)
 	default:
 		return fmt.Errorf("unsupported field type: %s", field.Type())
 	}
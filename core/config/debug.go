@@ -0,0 +1,86 @@
+package config
+
+import (
+	"crypto/subtle"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DebugConfig configures the debug endpoints of a service
+type DebugConfig struct {
+	// Serve the effective configuration, with secrets redacted, on /config
+	ConfigEndpoint bool `yaml:"config_endpoint" env:"DEBUG_CONFIG_ENDPOINT" default:"false"`
+	// Serve net/http/pprof on /debug/pprof/ and expvar on /debug/vars, to
+	// requests with the debug token, or to loopback clients without one
+	Diagnostics bool `yaml:"diagnostics" env:"DEBUG_DIAGNOSTICS" default:"false"`
+	// Bearer token of the debug endpoints. Those changing the service at
+	// runtime, such as /debug/log-level on agents, are disabled without it;
+	// the diagnostics endpoints require it from every client once set.
+	Token string `yaml:"-" env:"DEBUG_TOKEN" secret:"true"`
+}
+
+// Validate validates the debug configuration
+func (c *DebugConfig) Validate() error {
+	if c.Token != "" && len(c.Token) < 16 {
+		return fmt.Errorf("debug token must be at least 16 characters")
+	}
+	return nil
+}
+
+// publishRuntimeVars publishes runtime counters next to the memstats and
+// cmdline variables of expvar, once per process
+var publishRuntimeVars = sync.OnceFunc(func() {
+	started := time.Now()
+	expvar.Publish("goroutines", expvar.Func(func() interface{} { return runtime.NumGoroutine() }))
+	expvar.Publish("uptime_seconds", expvar.Func(func() interface{} { return int64(time.Since(started).Seconds()) }))
+})
+
+// DiagnosticsHandler serves the profiles of net/http/pprof on /debug/pprof/
+// and the expvar variables on /debug/vars. Services mount it on both paths
+// when Diagnostics is enabled. Requests need the debug token when one is
+// set, even from loopback, since a reverse proxy on the host makes remote
+// requests look local. Without a token, only loopback clients are served,
+// e.g. through an SSH port forward.
+func (c DebugConfig) DiagnosticsHandler() http.Handler {
+	publishRuntimeVars()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	expected := []byte("Bearer " + c.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized := isLoopback(r.RemoteAddr)
+		if c.Token != "" {
+			authorized = subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) == 1
+		}
+		if !authorized {
+			log.Warn().Str("remote_addr", r.RemoteAddr).Str("path", r.URL.Path).Msg("Rejected diagnostics request")
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// isLoopback returns true for loopback remote addresses
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
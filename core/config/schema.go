@@ -0,0 +1,388 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct tags of configuration fields, besides yaml:
+//
+//	env:"NAME"          environment variable overriding the field
+//	default:"value"     value of the field unless set
+//	validate:"rules"    comma-separated rules: required, min=N, max=N and
+//	                    oneof=a b c. min and max bound the length of strings
+//	                    and lists, and the value of numbers and durations.
+//	secret:"true"       redacted from the effective configuration
+
+// Redacted replaces set secrets in the effective configuration
+const Redacted = "[REDACTED]"
+
+// fieldKey returns the YAML key of a field, its lower-cased environment
+// variable for fields only set from the environment, or "" for fields
+// neither loaded from YAML nor from the environment
+func fieldKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return strings.ToLower(field.Tag.Get("env"))
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// isInline returns true for fields whose keys are those of their parent
+func isInline(field reflect.StructField) bool {
+	_, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	return options == "inline"
+}
+
+// joinKey appends a key to the path of its parent
+func joinKey(path, key string) string {
+	if key == "" {
+		return path
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// ValidateStruct checks the fields of a configuration against their validate
+// tags. Errors name the fields by their YAML path, e.g. "gateway.port".
+func ValidateStruct(target interface{}) error {
+	return validateRecursive(reflect.ValueOf(target), "")
+}
+
+func validateRecursive(v reflect.Value, path string) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		key := joinKey(path, fieldKey(fieldType))
+		if isInline(fieldType) {
+			key = path
+		}
+		if isStructField(field.Type()) {
+			if err := validateRecursive(field, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rules := fieldType.Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+		for _, rule := range strings.Split(rules, ",") {
+			if err := validateRule(field, key, strings.TrimSpace(rule)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateRule checks a field against one rule of its validate tag
+func validateRule(field reflect.Value, key, rule string) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if field.IsZero() || (field.Kind() == reflect.Slice && field.Len() == 0) {
+			return fmt.Errorf("%s is required", key)
+		}
+	case "oneof":
+		if field.Kind() != reflect.String {
+			return fmt.Errorf("%s: oneof only applies to strings", key)
+		}
+		value := field.String()
+		allowed := strings.Fields(arg)
+		if value == "" {
+			return nil
+		}
+		for _, a := range allowed {
+			if strings.EqualFold(value, a) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+	case "min", "max":
+		value, bound, unit, err := ruleOperands(field, arg)
+		if err != nil {
+			return fmt.Errorf("%s: invalid %s rule: %w", key, name, err)
+		}
+		if name == "min" && value < bound {
+			return fmt.Errorf("%s must be at least %s%s", key, arg, unit)
+		}
+		if name == "max" && value > bound {
+			return fmt.Errorf("%s must be at most %s%s", key, arg, unit)
+		}
+	default:
+		return fmt.Errorf("%s: unknown validation rule %q", key, rule)
+	}
+	return nil
+}
+
+// ruleOperands returns the value of a field compared by min and max rules,
+// and the bound of the rule
+func ruleOperands(field reflect.Value, arg string) (value, bound float64, unit string, err error) {
+	switch field.Kind() {
+	case reflect.String, reflect.Slice:
+		bound, err = strconv.ParseFloat(arg, 64)
+		return float64(field.Len()), bound, " characters", err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			duration, err := time.ParseDuration(arg)
+			return float64(field.Int()), float64(duration), "", err
+		}
+		bound, err = strconv.ParseFloat(arg, 64)
+		return float64(field.Int()), bound, "", err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bound, err = strconv.ParseFloat(arg, 64)
+		return float64(field.Uint()), bound, "", err
+	case reflect.Float32, reflect.Float64:
+		bound, err = strconv.ParseFloat(arg, 64)
+		return field.Float(), bound, "", err
+	}
+	return 0, 0, "", fmt.Errorf("unsupported field type %s", field.Type())
+}
+
+// Effective returns the configuration as nested maps keyed like its YAML,
+// secrets redacted. Fields only set from the environment are keyed by their
+// lower-cased variable.
+func Effective(target interface{}) map[string]interface{} {
+	values, _ := effectiveValue(reflect.ValueOf(target), false).(map[string]interface{})
+	return values
+}
+
+func effectiveValue(v reflect.Value, secret bool) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if secret {
+		if v.IsZero() {
+			return ""
+		}
+		return Redacted
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		values := make(map[string]interface{})
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			fieldType := t.Field(i)
+			if !fieldType.IsExported() {
+				continue
+			}
+			value := effectiveValue(v.Field(i), fieldType.Tag.Get("secret") == "true")
+			if nested, ok := value.(map[string]interface{}); ok && isInline(fieldType) {
+				for key, nestedValue := range nested {
+					values[key] = nestedValue
+				}
+				continue
+			}
+			if key := fieldKey(fieldType); key != "" {
+				values[key] = value
+			}
+		}
+		return values
+	case reflect.Slice, reflect.Array:
+		values := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, effectiveValue(v.Index(i), false))
+		}
+		return values
+	case reflect.Map:
+		values := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values[fmt.Sprint(iter.Key().Interface())] = effectiveValue(iter.Value(), false)
+		}
+		return values
+	case reflect.String:
+		return redactURL(v.String())
+	}
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+	return v.Interface()
+}
+
+// redactURL hides the passwords of URLs, e.g. of proxies and message buses
+func redactURL(value string) string {
+	if !strings.Contains(value, "://") || !strings.Contains(value, "@") {
+		return value
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return value
+}
+
+// ConfigHandler serves the effective configuration returned by current, with
+// secrets redacted. current is called on each request, so that reloaded
+// configurations are served.
+func ConfigHandler(service string, current func() interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]interface{}{
+			"service": service,
+			"config":  Effective(current()),
+		})
+	})
+}
+
+// EnvVar documents an environment variable of a configuration
+type EnvVar struct {
+	Name string
+	// YAML path of the field, empty for fields only set from the environment
+	Key        string
+	Type       string
+	Default    string
+	Validation string
+	Secret     bool
+}
+
+// EnvVars lists the environment variables of the fields of a configuration,
+// named as the loader of a service reads them. Each can also be prefixed with
+// the upper-cased service name, e.g. GATEWAY_LOG_LEVEL, which takes
+// precedence.
+func EnvVars(target interface{}) []EnvVar {
+	var vars []EnvVar
+	collectEnvVars(reflect.TypeOf(target), "", "", "", &vars)
+	return vars
+}
+
+func collectEnvVars(t reflect.Type, prefix, legacyPrefix, path string, vars *[]EnvVar) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := joinKey(path, fieldKey(field))
+		switch {
+		case isInline(field):
+			key = path
+		case strings.HasPrefix(field.Tag.Get("yaml"), "-"):
+			key = ""
+		}
+		if isStructField(field.Type) {
+			name, legacyName := structEnvPrefixes(prefix, legacyPrefix, field)
+			collectEnvVars(field.Type, name, legacyName, key, vars)
+			continue
+		}
+		if !loadableFromEnv(field.Type) {
+			continue
+		}
+
+		*vars = append(*vars, EnvVar{
+			Name:       fieldEnvNames(prefix, legacyPrefix, field)[0],
+			Key:        key,
+			Type:       typeName(field.Type),
+			Default:    field.Tag.Get("default"),
+			Validation: field.Tag.Get("validate"),
+			Secret:     field.Tag.Get("secret") == "true",
+		})
+	}
+}
+
+// loadableFromEnv returns true for the field types set from environment
+// variables
+func loadableFromEnv(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// typeName names the type of a field in the documentation
+func typeName(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case t.Kind() == reflect.Slice:
+		return "list"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return "integer"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "number"
+	}
+	return t.Kind().String()
+}
+
+// WriteEnvDocs writes the environment variables of a configuration as a
+// Markdown table
+func WriteEnvDocs(w io.Writer, service string, target interface{}) error {
+	fmt.Fprintf(w, "## %s\n\n", service)
+	fmt.Fprintf(w, "Variables can be prefixed with `%s_`, which takes precedence.\n\n", strings.ToUpper(service))
+	fmt.Fprintln(w, "| Variable | YAML key | Type | Default | Notes |")
+	fmt.Fprintln(w, "|----------|----------|------|---------|-------|")
+	for _, v := range EnvVars(target) {
+		var notes []string
+		if v.Secret {
+			notes = append(notes, "secret")
+		}
+		if v.Validation != "" {
+			notes = append(notes, "`"+v.Validation+"`")
+		}
+		if v.Type == "list" {
+			notes = append(notes, "comma-separated")
+		}
+		key := "-"
+		if v.Key != "" {
+			key = "`" + v.Key + "`"
+		}
+		defaultValue := "-"
+		if v.Default != "" {
+			defaultValue = "`" + v.Default + "`"
+		}
+		if _, err := fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n", v.Name, key, v.Type, defaultValue, strings.Join(notes, ", ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
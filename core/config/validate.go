@@ -0,0 +1,295 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// CheckStatus is the outcome of a check of a validation report
+type CheckStatus string
+
+const (
+	CheckOK      CheckStatus = "ok"
+	CheckWarning CheckStatus = "warning"
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped"
+)
+
+// DefaultCheckTimeout bounds each connectivity check of a validation
+const DefaultCheckTimeout = 5 * time.Second
+
+// Check is one check of a validation report
+type Check struct {
+	Name   string      `json:"name"`
+	Target string      `json:"target,omitempty"`
+	Status CheckStatus `json:"status"`
+	Detail string      `json:"detail,omitempty"`
+	// Duration of connectivity checks
+	DurationMs int64 `json:"duration_ms,omitempty"`
+}
+
+// ValidationReport is the result of validating the configuration of a
+// service without starting it
+type ValidationReport struct {
+	Service    string  `json:"service"`
+	ConfigFile string  `json:"config_file"`
+	EnvFile    string  `json:"env_file"`
+	Valid      bool    `json:"valid"`
+	Checks     []Check `json:"checks"`
+}
+
+// NewValidationReport returns an empty report of the configuration of a
+// service
+func NewValidationReport(service, configFile, envFile string) *ValidationReport {
+	return &ValidationReport{
+		Service:    service,
+		ConfigFile: configFile,
+		EnvFile:    envFile,
+		Valid:      true,
+		Checks:     []Check{},
+	}
+}
+
+// Add records a check, failed checks invalidating the report
+func (r *ValidationReport) Add(check Check) {
+	if check.Status == CheckFailed {
+		r.Valid = false
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// AddResult records a check of the configuration, failed when err is set
+func (r *ValidationReport) AddResult(name string, err error) {
+	if err != nil {
+		r.Add(Check{Name: name, Status: CheckFailed, Detail: err.Error()})
+		return
+	}
+	r.Add(Check{Name: name, Status: CheckOK})
+}
+
+// AddFiles records the configuration and environment files found, warning
+// when the service runs on defaults and environment variables only
+func (r *ValidationReport) AddFiles() {
+	if r.ConfigFile == "" {
+		r.Add(Check{Name: "config file", Status: CheckWarning, Detail: "no configuration file found, using defaults and environment variables"})
+	} else {
+		r.Add(Check{Name: "config file", Target: r.ConfigFile, Status: CheckOK})
+	}
+	if r.EnvFile != "" {
+		r.Add(Check{Name: "env file", Target: r.EnvFile, Status: CheckOK})
+	}
+}
+
+// Write prints the report as a table, or as JSON when format is "json"
+func (r *ValidationReport) Write(w io.Writer, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tTARGET\tSTATUS\tDETAIL")
+	for _, check := range r.Checks {
+		detail := check.Detail
+		if detail == "" && check.DurationMs > 0 {
+			detail = fmt.Sprintf("%dms", check.DurationMs)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", check.Name, dash(check.Target), check.Status, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	result := "valid"
+	if !r.Valid {
+		result = "invalid"
+	}
+	_, err := fmt.Fprintf(w, "\n%s configuration is %s\n", r.Service, result)
+	return err
+}
+
+// dash returns "-" for empty table cells
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// defaultPorts are the ports of the endpoint URLs without one
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ipmi":  "623",
+	"vnc":   "5900",
+	"nats":  "4222",
+}
+
+// EndpointAddress returns the host:port of an endpoint given as a URL or as
+// host[:port]. defaultPort applies to addresses without a port whose scheme
+// has no well-known one.
+func EndpointAddress(endpoint, defaultPort string) (string, error) {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		if u.Hostname() == "" {
+			return "", fmt.Errorf("invalid endpoint %q: host is required", endpoint)
+		}
+		port := u.Port()
+		if port == "" {
+			port = defaultPorts[strings.ToLower(u.Scheme)]
+		}
+		if port == "" {
+			port = defaultPort
+		}
+		if port == "" {
+			return "", fmt.Errorf("invalid endpoint %q: port is required", endpoint)
+		}
+		return net.JoinHostPort(u.Hostname(), port), nil
+	}
+
+	if host, port, err := net.SplitHostPort(endpoint); err == nil {
+		if host == "" {
+			return "", fmt.Errorf("invalid endpoint %q: host is required", endpoint)
+		}
+		return net.JoinHostPort(host, port), nil
+	}
+	host := strings.Trim(endpoint, "[]")
+	if host == "" {
+		return "", fmt.Errorf("endpoint is empty")
+	}
+	if defaultPort == "" {
+		return "", fmt.Errorf("invalid endpoint %q: port is required", endpoint)
+	}
+	return net.JoinHostPort(host, defaultPort), nil
+}
+
+// DialFunc opens connections for connectivity checks
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// CheckReachable connects to the TCP address of an endpoint with dial, or
+// directly when dial is nil
+func CheckReachable(ctx context.Context, name, endpoint, defaultPort string, dial DialFunc) Check {
+	check := Check{Name: name, Target: endpoint}
+	address, err := EndpointAddress(endpoint, defaultPort)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Detail = err.Error()
+		return check
+	}
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	conn, err := dial(ctx, "tcp", address)
+	check.DurationMs = max(time.Since(start).Milliseconds(), 1)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Detail = fmt.Sprintf("unreachable: %v", err)
+		return check
+	}
+	conn.Close()
+	check.Status = CheckOK
+	return check
+}
+
+// CheckResolvable resolves the host of an endpoint, for UDP protocols such
+// as IPMI that cannot be checked without a session
+func CheckResolvable(ctx context.Context, name, endpoint, defaultPort string) Check {
+	check := Check{Name: name, Target: endpoint}
+	address, err := EndpointAddress(endpoint, defaultPort)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Detail = err.Error()
+		return check
+	}
+	host, _, _ := net.SplitHostPort(address)
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	check.DurationMs = max(time.Since(start).Milliseconds(), 1)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Detail = fmt.Sprintf("failed to resolve %s: %v", host, err)
+		return check
+	}
+	check.Status = CheckOK
+	check.Detail = fmt.Sprintf("resolves to %s, UDP not probed", strings.Join(addrs, ", "))
+	return check
+}
+
+// CheckTLS checks that the certificate and key of an enabled TLS
+// configuration load
+func (r *ValidationReport) CheckTLS(c TLSConfig) {
+	if !c.Enabled {
+		return
+	}
+	if _, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile); err != nil {
+		r.Add(Check{Name: "tls", Target: c.CertFile, Status: CheckFailed, Detail: err.Error()})
+		return
+	}
+	r.Add(Check{Name: "tls", Target: c.CertFile, Status: CheckOK})
+}
+
+// CheckTracing checks that the collector of enabled tracing is reachable
+func (r *ValidationReport) CheckTracing(ctx context.Context, c TracingConfig) {
+	if !c.Enabled {
+		return
+	}
+	if c.Endpoint == "" {
+		r.Add(Check{Name: "tracing", Status: CheckSkipped, Detail: "no endpoint, the OTEL_EXPORTER_OTLP_* variables apply"})
+		return
+	}
+	r.Add(CheckReachable(ctx, "tracing", c.Endpoint, "4318", nil))
+}
+
+// CheckEventBus checks that the servers of an enabled event bus are
+// reachable
+func (r *ValidationReport) CheckEventBus(ctx context.Context, c EventBusConfig) {
+	if !c.Enabled {
+		return
+	}
+	defaultPort := "4222"
+	if c.Driver == "kafka" {
+		defaultPort = "9092"
+	}
+	for _, server := range strings.Split(c.URL, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			r.Add(CheckReachable(ctx, "event bus", server, defaultPort, nil))
+		}
+	}
+}
+
+// CheckListen checks that the service can listen on its address. Addresses
+// in use only warn, as validating the configuration of a running service is
+// expected to find its own.
+func CheckListen(address string) Check {
+	check := Check{Name: "listen address", Target: address}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		check.Status = CheckWarning
+		check.Detail = err.Error()
+		return check
+	}
+	listener.Close()
+	check.Status = CheckOK
+	return check
+}
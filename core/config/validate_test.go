@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestEndpointAddress(t *testing.T) {
+	tests := []struct {
+		endpoint    string
+		defaultPort string
+		expected    string
+		expectError bool
+	}{
+		{"http://manager:8080", "", "manager:8080", false},
+		{"https://gateway.example.com", "", "gateway.example.com:443", false},
+		{"ws://bmc/console", "", "bmc:80", false},
+		{"ipmi://10.0.0.5", "", "10.0.0.5:623", false},
+		{"10.0.0.5:623", "", "10.0.0.5:623", false},
+		{"[fd00::5]:623", "", "[fd00::5]:623", false},
+		{"bmc-01", "5900", "bmc-01:5900", false},
+		{"socks5://jump:1080", "", "jump:1080", false},
+		{"custom://host", "", "", true},
+		{"http://:8080", "", "", true},
+		{"bmc-01", "", "", true},
+		{"", "623", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			address, err := EndpointAddress(tt.endpoint, tt.defaultPort)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got %s", address)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if address != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, address)
+			}
+		})
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	check := CheckReachable(context.Background(), "manager", "http://"+listener.Addr().String(), "", nil)
+	if check.Status != CheckOK {
+		t.Errorf("Expected ok, got %s: %s", check.Status, check.Detail)
+	}
+
+	refused := func(ctx context.Context, network, address string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	check = CheckReachable(context.Background(), "manager", "http://manager:8080", "", refused)
+	if check.Status != CheckFailed || !strings.Contains(check.Detail, "connection refused") {
+		t.Errorf("Expected a failed check, got %s: %s", check.Status, check.Detail)
+	}
+
+	check = CheckReachable(context.Background(), "manager", "custom://manager", "", nil)
+	if check.Status != CheckFailed {
+		t.Errorf("Expected invalid endpoints to fail, got %s", check.Status)
+	}
+}
+
+func TestValidationReport(t *testing.T) {
+	report := NewValidationReport("gateway", "", "")
+	report.AddFiles()
+	report.AddResult("configuration", nil)
+	if !report.Valid {
+		t.Fatal("Expected warnings to keep the report valid")
+	}
+	if report.Checks[0].Status != CheckWarning {
+		t.Errorf("Expected a warning without a config file, got %s", report.Checks[0].Status)
+	}
+
+	report.Add(Check{Name: "manager", Target: "http://manager:8080", Status: CheckFailed, Detail: "unreachable"})
+	if report.Valid {
+		t.Error("Expected failed checks to invalidate the report")
+	}
+
+	var text bytes.Buffer
+	if err := report.Write(&text, "text"); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	if !strings.Contains(text.String(), "http://manager:8080") || !strings.Contains(text.String(), "gateway configuration is invalid") {
+		t.Errorf("Unexpected text report:\n%s", text.String())
+	}
+
+	var encoded bytes.Buffer
+	if err := report.Write(&encoded, "json"); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	var decoded ValidationReport
+	if err := json.Unmarshal(encoded.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	if decoded.Valid || len(decoded.Checks) != 3 {
+		t.Errorf("Unexpected JSON report: %s", encoded.String())
+	}
+}
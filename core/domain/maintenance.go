@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// MaintenancePolicy decides what happens to the destructive power operations
+// of servers during a maintenance window
+type MaintenancePolicy string
+
+const (
+	// MaintenancePolicyReject rejects destructive operations, administrators
+	// may still override it
+	MaintenancePolicyReject MaintenancePolicy = "reject"
+	// MaintenancePolicyRequireOverride accepts destructive operations that
+	// explicitly override the maintenance window
+	MaintenancePolicyRequireOverride MaintenancePolicy = "require_override"
+)
+
+// Valid reports whether p is a known maintenance policy
+func (p MaintenancePolicy) Valid() bool {
+	return p == MaintenancePolicyReject || p == MaintenancePolicyRequireOverride
+}
+
+// MaintenanceWindow is a scheduled maintenance of a server, or of the group of
+// servers whose metadata holds all of its labels
+type MaintenanceWindow struct {
+	ID        int64             `json:"id" db:"id"`
+	ServerID  string            `json:"server_id,omitempty" db:"server_id"` // Empty for a group of servers
+	Labels    map[string]string `json:"labels,omitempty" db:"labels"`       // Labels of the group, when ServerID is empty
+	Policy    MaintenancePolicy `json:"policy" db:"policy"`
+	Reason    string            `json:"reason,omitempty" db:"reason"`
+	StartsAt  time.Time         `json:"starts_at" db:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at" db:"ends_at"`
+	CreatedBy string            `json:"created_by" db:"created_by"` // Email of the administrator
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// Matches reports whether the window applies to a server
+func (w *MaintenanceWindow) Matches(server *Server) bool {
+	if w.ServerID != "" {
+		return w.ServerID == server.ID
+	}
+	if len(w.Labels) == 0 {
+		return false
+	}
+	for key, value := range w.Labels {
+		if server.Metadata[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether the window is in progress at any time of
+// [start, end)
+func (w *MaintenanceWindow) Overlaps(start, end time.Time) bool {
+	return w.StartsAt.Before(end) && w.EndsAt.After(start)
+}
@@ -20,8 +20,15 @@ type Server struct {
 	Status            string                      `json:"status" db:"status"`
 	Metadata          map[string]string           `json:"metadata" db:"metadata"`
 	DiscoveryMetadata *types.DiscoveryMetadata    `json:"discovery_metadata,omitempty" db:"discovery_metadata"`
+	ExternalID        string                      `json:"external_id,omitempty" db:"external_id"`
+	MaintenanceMode   bool                        `json:"maintenance_mode" db:"maintenance_mode"`
+	Notes             string                      `json:"notes,omitempty" db:"notes"`
 	CreatedAt         time.Time                   `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time                   `json:"updated_at" db:"updated_at"`
+
+	// Maintenance windows of the server loaded by the manager to issue
+	// server tokens, not stored with the server
+	MaintenanceWindows []*MaintenanceWindow `json:"maintenance_windows,omitempty" db:"-"`
 }
 
 // GetPrimaryControlEndpoint returns the control endpoint matching PrimaryProtocol.
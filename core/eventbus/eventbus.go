@@ -0,0 +1,149 @@
+// Package eventbus publishes system events to a message bus so that
+// downstream systems, such as a CMDB, billing or alerting, can consume them.
+//
+// Two drivers are available: NATS JetStream and Kafka. Each event is
+// published as the JSON encoding of events.Event to the subject (NATS) or
+// topic (Kafka) <subject_prefix>.<event type>, e.g.
+// conduit.events.power.operation_executed.
+//
+// The package is a module of its own so that services only pull in the bus
+// clients when they import it.
+//
+// Example usage:
+//
+//	bus, err := eventbus.Connect(ctx, cfg.EventBus)
+//	defer bus.Close()
+//
+//	publisher := eventbus.NewPublisher(bus, cfg.EventBus)
+//	publisher.Start(ctx)
+//	publisher.Publish(events.New(events.GatewayOffline, "manager", data))
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/config"
+	"core/events"
+)
+
+// ErrQueueFull is reported to the observer of a Publisher for the events it
+// dropped because its queue was full
+var ErrQueueFull = errors.New("event bus queue full")
+
+// Bus is a connection to a message bus
+type Bus interface {
+	// Publish sends an event and waits until the bus acknowledges it
+	Publish(ctx context.Context, event events.Event) error
+	Close() error
+}
+
+// Connect connects to the bus selected by cfg.Driver
+func Connect(ctx context.Context, cfg config.EventBusConfig) (Bus, error) {
+	switch cfg.Driver {
+	case "nats":
+		return connectNATS(ctx, cfg)
+	case "kafka":
+		return newKafka(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported event bus driver %q", cfg.Driver)
+	}
+}
+
+// Subject returns the NATS subject or Kafka topic events of type t are
+// published to
+func Subject(prefix string, t events.Type) string {
+	return prefix + "." + string(t)
+}
+
+// servers splits a comma-separated list of server URLs or broker addresses
+func servers(url string) []string {
+	var list []string
+	for _, server := range strings.Split(url, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			list = append(list, server)
+		}
+	}
+	return list
+}
+
+// Publisher publishes events to a Bus in the background, so that publishing
+// never blocks request handling. It implements events.Publisher.
+type Publisher struct {
+	bus     Bus
+	queue   chan events.Event
+	timeout time.Duration
+	observe func(events.Event, error)
+}
+
+// NewPublisher creates a publisher buffering up to cfg.QueueSize events
+func NewPublisher(bus Bus, cfg config.EventBusConfig) *Publisher {
+	return &Publisher{
+		bus:     bus,
+		queue:   make(chan events.Event, cfg.QueueSize),
+		timeout: cfg.Timeout,
+	}
+}
+
+// SetObserver sets the function receiving the outcome of every event: nil
+// when the bus acknowledged it, ErrQueueFull when it was dropped, or the
+// publish error. Used to record metrics.
+func (p *Publisher) SetObserver(observe func(event events.Event, err error)) {
+	p.observe = observe
+}
+
+// Publish queues an event. It never blocks: the event is dropped when the
+// queue is full.
+func (p *Publisher) Publish(event events.Event) {
+	select {
+	case p.queue <- event:
+	default:
+		log.Warn().
+			Str("event_id", event.ID).
+			Str("event_type", string(event.Type)).
+			Msg("Event bus queue full, dropping event")
+		p.report(event, ErrQueueFull)
+	}
+}
+
+// Start publishes queued events until ctx ends
+func (p *Publisher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-p.queue:
+				p.send(ctx, event)
+			}
+		}
+	}()
+}
+
+// send publishes one event. Both drivers retry transient failures, so an
+// event that could not be published within the timeout is dropped.
+func (p *Publisher) send(ctx context.Context, event events.Event) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err := p.bus.Publish(ctx, event)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("event_id", event.ID).
+			Str("event_type", string(event.Type)).
+			Msg("Failed to publish event to the event bus")
+	}
+	p.report(event, err)
+}
+
+func (p *Publisher) report(event events.Event, err error) {
+	if p.observe != nil {
+		p.observe(event, err)
+	}
+}
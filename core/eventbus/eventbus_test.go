@@ -0,0 +1,141 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"core/config"
+	"core/events"
+)
+
+// fakeBus records published events, failing on demand
+type fakeBus struct {
+	mu        sync.Mutex
+	published []events.Event
+	err       error
+}
+
+func (b *fakeBus) Publish(ctx context.Context, event events.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *fakeBus) Close() error {
+	return nil
+}
+
+// outcome is the result of publishing an event, as seen by an observer
+type outcome struct {
+	eventID string
+	err     error
+}
+
+func newObservedPublisher(bus Bus, queueSize int) (*Publisher, chan outcome) {
+	publisher := NewPublisher(bus, config.EventBusConfig{QueueSize: queueSize, Timeout: time.Second})
+	outcomes := make(chan outcome, 10)
+	publisher.SetObserver(func(event events.Event, err error) {
+		outcomes <- outcome{event.ID, err}
+	})
+	return publisher, outcomes
+}
+
+func waitOutcome(t *testing.T, outcomes <-chan outcome) outcome {
+	t.Helper()
+	select {
+	case o := <-outcomes:
+		return o
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the event to be published")
+		return outcome{}
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	bus := &fakeBus{}
+	publisher, outcomes := newObservedPublisher(bus, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisher.Start(ctx)
+
+	event := events.New(events.ServerDiscovered, "manager", map[string]any{"server_id": "srv-1"})
+	publisher.Publish(event)
+
+	if o := waitOutcome(t, outcomes); o.eventID != event.ID || o.err != nil {
+		t.Errorf("Expected event %s to be published, got %+v", event.ID, o)
+	}
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if len(bus.published) != 1 || bus.published[0].ID != event.ID {
+		t.Errorf("Expected the bus to receive event %s, got %v", event.ID, bus.published)
+	}
+}
+
+func TestPublisherReportsFailures(t *testing.T) {
+	publishErr := errors.New("no responders")
+	publisher, outcomes := newObservedPublisher(&fakeBus{err: publishErr}, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	publisher.Start(ctx)
+
+	publisher.Publish(events.New(events.GatewayOffline, "manager", nil))
+	if o := waitOutcome(t, outcomes); !errors.Is(o.err, publishErr) {
+		t.Errorf("Expected error %v, got %v", publishErr, o.err)
+	}
+}
+
+func TestPublisherDropsWhenQueueFull(t *testing.T) {
+	// Not started, so the queue is never drained
+	publisher, outcomes := newObservedPublisher(&fakeBus{}, 1)
+
+	publisher.Publish(events.New(events.GatewayOffline, "manager", nil))
+	dropped := events.New(events.GatewayOffline, "manager", nil)
+	publisher.Publish(dropped)
+
+	if o := waitOutcome(t, outcomes); o.eventID != dropped.ID || !errors.Is(o.err, ErrQueueFull) {
+		t.Errorf("Expected event %s to be dropped, got %+v", dropped.ID, o)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	if got := Subject("conduit.events", events.PowerOperationExecuted); got != "conduit.events.power.operation_executed" {
+		t.Errorf("Unexpected subject %q", got)
+	}
+}
+
+func TestPartitionKey(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]any
+		want string
+	}{
+		{"server events are keyed by server", map[string]any{"server_id": "srv-1", "gateway_id": "gw-1"}, "srv-1"},
+		{"gateway events are keyed by gateway", map[string]any{"gateway_id": "gw-1"}, "gw-1"},
+		{"other events are keyed by ID", nil, "evt-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := events.Event{ID: "evt-1", Type: events.ServerDiscovered, Data: tt.data}
+			if got := partitionKey(event); got != tt.want {
+				t.Errorf("Expected key %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConnectRejectsUnknownDriver(t *testing.T) {
+	if _, err := Connect(context.Background(), config.EventBusConfig{Driver: "rabbitmq"}); err == nil {
+		t.Error("Expected an error for an unsupported driver")
+	}
+}
@@ -0,0 +1,18 @@
+module core/eventbus
+
+go 1.25.1
+
+require (
+	github.com/nats-io/nats.go v1.46.1
+	github.com/rs/zerolog v1.34.0
+	github.com/segmentio/kafka-go v0.4.49
+)
+
+require (
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/nats-io/nkeys v0.4.11 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+)
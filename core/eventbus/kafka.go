@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"core/config"
+	"core/events"
+)
+
+// kafkaBus publishes events to Kafka, one topic per event type
+type kafkaBus struct {
+	writer *kafka.Writer
+	prefix string
+}
+
+// newKafka creates a Kafka producer. Brokers are contacted on the first
+// publish.
+func newKafka(cfg config.EventBusConfig) *kafkaBus {
+	return &kafkaBus{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(servers(cfg.URL)...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+			WriteTimeout:           cfg.Timeout,
+			// Events are published one at a time, don't wait for a batch to fill
+			BatchTimeout: 10 * time.Millisecond,
+		},
+		prefix: cfg.SubjectPrefix,
+	}
+}
+
+// Publish sends an event keyed by partitionKey
+func (b *kafkaBus) Publish(ctx context.Context, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	return b.writer.WriteMessages(ctx, kafka.Message{
+		Topic: Subject(b.prefix, event.Type),
+		Key:   []byte(partitionKey(event)),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(event.ID)},
+		},
+	})
+}
+
+// Close flushes pending messages and closes the connections to the brokers
+func (b *kafkaBus) Close() error {
+	return b.writer.Close()
+}
+
+// partitionKey returns the key of an event's message: the server ID when the
+// event concerns a server, so that its events stay ordered, then the gateway
+// ID, then the event ID
+func partitionKey(event events.Event) string {
+	for _, field := range []string{"server_id", "gateway_id"} {
+		if key, ok := event.Data[field].(string); ok && key != "" {
+			return key
+		}
+	}
+	return event.ID
+}
@@ -0,0 +1,92 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"core/config"
+	"core/events"
+)
+
+// natsBus publishes events to NATS JetStream
+type natsBus struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+// connectNATS connects to the NATS servers and creates the stream when it is
+// configured and missing
+func connectNATS(ctx context.Context, cfg config.EventBusConfig) (*natsBus, error) {
+	opts := []nats.Option{
+		nats.Name("conduit-bmc"),
+		nats.Timeout(cfg.Timeout),
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+
+	conn, err := nats.Connect(strings.Join(servers(cfg.URL), ","), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	if cfg.Stream != "" {
+		if err := ensureStream(ctx, js, cfg.Stream, cfg.SubjectPrefix); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return &natsBus{conn: conn, js: js, prefix: cfg.SubjectPrefix}, nil
+}
+
+// ensureStream creates a stream capturing all event subjects unless it exists.
+// An existing stream is left untouched, so operators may tune its retention.
+func ensureStream(ctx context.Context, js jetstream.JetStream, name, prefix string) error {
+	_, err := js.Stream(ctx, name)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, jetstream.ErrStreamNotFound) {
+		return fmt.Errorf("failed to look up stream %s: %w", name, err)
+	}
+
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     name,
+		Subjects: []string{prefix + ".>"},
+	})
+	if err != nil && !errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("failed to create stream %s: %w", name, err)
+	}
+	return nil
+}
+
+// Publish sends an event with its ID as the message ID, so that JetStream
+// discards duplicates within the stream's deduplication window
+func (b *natsBus) Publish(ctx context.Context, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = b.js.Publish(ctx, Subject(b.prefix, event.Type), data, jetstream.WithMsgID(event.ID))
+	return err
+}
+
+// Close flushes pending messages and closes the connection
+func (b *natsBus) Close() error {
+	return b.conn.Drain()
+}
@@ -0,0 +1,81 @@
+// Package events defines the system events emitted by BMC services, such as
+// a server being discovered or a gateway going offline. Events are delivered
+// to external systems, e.g. by the manager's webhooks.
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Type identifies the kind of an event
+type Type string
+
+const (
+	ServerDiscovered       Type = "server.discovered"        // A gateway reported a BMC endpoint for the first time
+	ServerUnreachable      Type = "server.unreachable"       // A server can no longer be reached through its gateway
+	PowerOperationExecuted Type = "power.operation_executed" // A power operation was proxied to a BMC
+	ConsoleSessionOpened   Type = "console.session_opened"   // A SOL or VNC console stream was established
+	ConsoleSessionClosed   Type = "console.session_closed"   // A SOL or VNC console stream ended
+	GatewayOffline         Type = "gateway.offline"          // A gateway stopped re-registering with the manager
+	MaintenanceOverridden  Type = "maintenance.overridden"   // A power operation overrode a maintenance window
+	ServerDriftDetected    Type = "server.drift_detected"    // A static host's BMC no longer matches its declared configuration
+	ServerDriftResolved    Type = "server.drift_resolved"    // A static host's BMC matches its declared configuration again
+)
+
+// Types lists all event types
+var Types = []Type{
+	ServerDiscovered,
+	ServerUnreachable,
+	PowerOperationExecuted,
+	ConsoleSessionOpened,
+	ConsoleSessionClosed,
+	GatewayOffline,
+	MaintenanceOverridden,
+	ServerDriftDetected,
+	ServerDriftResolved,
+}
+
+// Valid reports whether t is a known event type
+func (t Type) Valid() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a system event. Data holds the event-specific fields, e.g.
+// "server_id" or "gateway_id", and is serialized as a JSON object.
+type Event struct {
+	ID     string         `json:"id"`
+	Type   Type           `json:"type"`
+	Source string         `json:"source"` // Service that emitted the event, e.g. "manager"
+	Time   time.Time      `json:"time"`
+	Data   map[string]any `json:"data"`
+}
+
+// New creates an event of the given type with a random ID
+func New(eventType Type, source string, data map[string]any) Event {
+	return Event{
+		ID:     newID(),
+		Type:   eventType,
+		Source: source,
+		Time:   time.Now().UTC(),
+		Data:   data,
+	}
+}
+
+// Publisher accepts events for delivery
+type Publisher interface {
+	Publish(event Event)
+}
+
+// newID returns a random 128-bit hex identifier
+func newID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
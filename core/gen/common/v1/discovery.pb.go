@@ -552,6 +552,7 @@ type NetworkInfo struct {
 	VlanId         string                 `protobuf:"bytes,4,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
 	Reachable      bool                   `protobuf:"varint,5,opt,name=reachable,proto3" json:"reachable,omitempty"`
 	LatencyMs      int32                  `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"` // Ping latency from agent
+	Hostname       string                 `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`                     // DNS name of the endpoint, ip_address is its last resolved address
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -628,6 +629,13 @@ func (x *NetworkInfo) GetLatencyMs() int32 {
 	return 0
 }
 
+func (x *NetworkInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 // CapabilityInfo contains discovered capabilities
 type CapabilityInfo struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
@@ -750,7 +758,7 @@ const file_common_v1_discovery_proto_rawDesc = "" +
 	"authMethod\x12\"\n" +
 	"\rvnc_auth_type\x18\x04 \x01(\tR\vvncAuthType\x12.\n" +
 	"\x13vnc_password_length\x18\x05 \x01(\x05R\x11vncPasswordLength\x12*\n" +
-	"\x11ipmi_cipher_suite\x18\x06 \x01(\tR\x0fipmiCipherSuite\"\xcc\x01\n" +
+	"\x11ipmi_cipher_suite\x18\x06 \x01(\tR\x0fipmiCipherSuite\"\xe8\x01\n" +
 	"\vNetworkInfo\x12\x1d\n" +
 	"\n" +
 	"ip_address\x18\x01 \x01(\tR\tipAddress\x12\x1f\n" +
@@ -760,7 +768,8 @@ const file_common_v1_discovery_proto_rawDesc = "" +
 	"\avlan_id\x18\x04 \x01(\tR\x06vlanId\x12\x1c\n" +
 	"\treachable\x18\x05 \x01(\bR\treachable\x12\x1d\n" +
 	"\n" +
-	"latency_ms\x18\x06 \x01(\x05R\tlatencyMs\"\xcc\x01\n" +
+	"latency_ms\x18\x06 \x01(\x05R\tlatencyMs\x12\x1a\n" +
+	"\bhostname\x18\a \x01(\tR\bhostname\"\xcc\x01\n" +
 	"\x0eCapabilityInfo\x12-\n" +
 	"\x12supported_features\x18\x01 \x03(\tR\x11supportedFeatures\x121\n" +
 	"\x14unsupported_features\x18\x02 \x03(\tR\x13unsupportedFeatures\x12)\n" +
@@ -0,0 +1,201 @@
+// Package i18n translates user-facing messages of the web UI and the CLI.
+//
+// Messages are loaded from one JSON file per language, named after the
+// language ("en.json", "ja.json"), mapping message keys to fmt format
+// strings. Messages missing in a language fall back to English.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages
+const (
+	English  = "en"
+	Japanese = "ja"
+)
+
+// DefaultLanguage is used when no supported language is requested
+const DefaultLanguage = English
+
+// Languages lists the languages user-facing messages are translated to
+var Languages = []string{English, Japanese}
+
+// Known reports whether lang, or its base language, is in Languages
+func Known(lang string) bool {
+	base := Normalize(lang)
+	for _, known := range Languages {
+		if base == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns the base language of a language tag or locale, in lower
+// case: "ja-JP" and "ja_JP.UTF-8" are "ja". The "C" and "POSIX" locales have
+// no language and are "".
+func Normalize(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, "-_.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ToLower(tag)
+	if tag == "c" || tag == "posix" || tag == "*" {
+		return ""
+	}
+	return tag
+}
+
+// Bundle holds the messages of each language
+type Bundle struct {
+	messages map[string]map[string]string
+}
+
+// NewBundle loads the message files of fsys. The file of the default
+// language is required.
+func NewBundle(fsys fs.FS) (*Bundle, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{messages: make(map[string]map[string]string)}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("invalid message file %s: %w", file, err)
+		}
+		b.messages[Normalize(strings.TrimSuffix(path.Base(file), ".json"))] = messages
+	}
+
+	if _, ok := b.messages[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("missing message file %s.json", DefaultLanguage)
+	}
+	return b, nil
+}
+
+// Languages returns the languages of the bundle, sorted
+func (b *Bundle) Languages() []string {
+	langs := make([]string, 0, len(b.messages))
+	for lang := range b.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Supports reports whether the bundle has messages in lang, or its base
+// language
+func (b *Bundle) Supports(lang string) bool {
+	_, ok := b.messages[Normalize(lang)]
+	return ok
+}
+
+// Missing returns the keys of the default language missing in lang, sorted
+func (b *Bundle) Missing(lang string) []string {
+	messages := b.messages[Normalize(lang)]
+	var missing []string
+	for key := range b.messages[DefaultLanguage] {
+		if _, ok := messages[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Match returns the first of langs the bundle supports, as a base language,
+// or the default language
+func (b *Bundle) Match(langs ...string) string {
+	for _, lang := range langs {
+		if b.Supports(lang) {
+			return Normalize(lang)
+		}
+	}
+	return DefaultLanguage
+}
+
+// MatchAcceptLanguage returns the supported language preferred in an
+// Accept-Language header, or "" when it names none
+func (b *Bundle) MatchAcceptLanguage(header string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > bestQuality && b.Supports(tag) {
+			best, bestQuality = Normalize(tag), quality
+		}
+	}
+	return best
+}
+
+// Printer returns the printer of lang, of the default language when the
+// bundle does not support it
+func (b *Bundle) Printer(lang string) *Printer {
+	lang = b.Match(lang)
+	return &Printer{
+		lang:     lang,
+		messages: b.messages[lang],
+		fallback: b.messages[DefaultLanguage],
+	}
+}
+
+// Printer formats the messages of a language
+type Printer struct {
+	lang     string
+	messages map[string]string
+	fallback map[string]string
+}
+
+// Language returns the language of the printer
+func (p *Printer) Language() string {
+	return p.lang
+}
+
+// Sprintf formats the message of key with args, as fmt.Sprintf. Unknown keys
+// are formatted as messages.
+func (p *Printer) Sprintf(key string, args ...any) string {
+	format, ok := p.messages[key]
+	if !ok {
+		format, ok = p.fallback[key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Messages returns the unformatted messages whose key starts with prefix,
+// for translations made by page scripts
+func (p *Printer) Messages(prefix string) map[string]string {
+	messages := make(map[string]string)
+	for _, source := range []map[string]string{p.fallback, p.messages} {
+		for key, message := range source {
+			if strings.HasPrefix(key, prefix) {
+				messages[key] = message
+			}
+		}
+	}
+	return messages
+}
@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func testBundle(t *testing.T) *Bundle {
+	t.Helper()
+	b, err := NewBundle(fstest.MapFS{
+		"en.json": {Data: []byte(`{"greeting": "Hello %s", "power.on": "Power On", "only.english": "English only"}`)},
+		"ja.json": {Data: []byte(`{"greeting": "こんにちは %s", "power.on": "電源オン"}`)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load bundle: %v", err)
+	}
+	return b
+}
+
+func TestNewBundleRequiresDefaultLanguage(t *testing.T) {
+	_, err := NewBundle(fstest.MapFS{"ja.json": {Data: []byte(`{}`)}})
+	if err == nil {
+		t.Fatal("Expected an error without the default language")
+	}
+
+	_, err = NewBundle(fstest.MapFS{"en.json": {Data: []byte(`not json`)}})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid message file")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"ja":          "ja",
+		"ja-JP":       "ja",
+		"ja_JP.UTF-8": "ja",
+		"EN-us":       "en",
+		"C":           "",
+		"POSIX":       "",
+		"*":           "",
+		"":            "",
+	}
+	for tag, expected := range tests {
+		if got := Normalize(tag); got != expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", tag, got, expected)
+		}
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	b := testBundle(t)
+
+	ja := b.Printer("ja-JP")
+	if ja.Language() != Japanese {
+		t.Errorf("Expected language %q, got %q", Japanese, ja.Language())
+	}
+	if got := ja.Sprintf("greeting", "server-1"); got != "こんにちは server-1" {
+		t.Errorf("Unexpected translation: %q", got)
+	}
+	if got := ja.Sprintf("only.english"); got != "English only" {
+		t.Errorf("Expected the English fallback, got %q", got)
+	}
+	if got := ja.Sprintf("unknown.key"); got != "unknown.key" {
+		t.Errorf("Expected the key of unknown messages, got %q", got)
+	}
+
+	if got := b.Printer("fr").Language(); got != English {
+		t.Errorf("Expected unsupported languages to use %q, got %q", English, got)
+	}
+
+	expected := map[string]string{"power.on": "電源オン"}
+	if got := ja.Messages("power."); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Messages() = %v, expected %v", got, expected)
+	}
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	b := testBundle(t)
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: ""},
+		{header: "ja", expected: Japanese},
+		{header: "ja-JP,ja;q=0.9,en-US;q=0.8,en;q=0.7", expected: Japanese},
+		{header: "en-US,en;q=0.9,ja;q=0.8", expected: English},
+		{header: "fr-FR,fr;q=0.9,ja;q=0.5", expected: Japanese},
+		{header: "fr-FR,de;q=0.9", expected: ""},
+		{header: "ja;q=0, en;q=0.1", expected: English},
+		{header: "ja;q=bad", expected: ""},
+	}
+	for _, tt := range tests {
+		if got := b.MatchAcceptLanguage(tt.header); got != tt.expected {
+			t.Errorf("MatchAcceptLanguage(%q) = %q, expected %q", tt.header, got, tt.expected)
+		}
+	}
+}
+
+func TestMissing(t *testing.T) {
+	b := testBundle(t)
+	if got := b.Missing(Japanese); !reflect.DeepEqual(got, []string{"only.english"}) {
+		t.Errorf("Missing() = %v", got)
+	}
+	if !Known("ja_JP.UTF-8") || Known("fr") {
+		t.Error("Unexpected known languages")
+	}
+}
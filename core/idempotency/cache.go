@@ -0,0 +1,164 @@
+// Package idempotency deduplicates the operations retried by clients with
+// the same idempotency key, such as power operations whose response was lost
+// and that a script or the CLI sends again.
+//
+// Cache runs the operation of a key once: calls with the key of a running
+// operation wait for it and share its result, and calls with the key of an
+// operation completed within the window get its result back without running
+// it again. Operations failing with an error are forgotten once completed, so
+// that a retry runs them again. A cache keeps at most MaxEntries keys, clients
+// sending new keys evict the oldest results.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultWindow is how long the results of operations are kept by default
+const DefaultWindow = 10 * time.Minute
+
+// MaxKeyLength is the length of the longest idempotency key accepted
+const MaxKeyLength = 128
+
+// MaxEntries is the number of keys a cache keeps at most, running or
+// completed, so that clients sending new keys cannot grow it without bound
+const MaxEntries = 10000
+
+// ReplayedHeader is set to "true" on the responses returned from the cache
+// rather than by running the operation
+const ReplayedHeader = "Idempotent-Replayed"
+
+// ErrKeyReused is returned when a key is sent with an operation other than
+// the one it ran
+var ErrKeyReused = errors.New("idempotency key already used for another operation")
+
+// ErrTooManyKeys is returned when MaxEntries operations are running with a
+// key, leaving no completed result to evict for a new key
+var ErrTooManyKeys = errors.New("too many operations running with an idempotency key, retry later")
+
+// ValidateKey checks that a client's key is at most MaxKeyLength long. Empty
+// keys are valid, and not deduplicated.
+func ValidateKey(key string) error {
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", MaxKeyLength)
+	}
+	return nil
+}
+
+// Cache keeps the results of the operations run with an idempotency key for
+// a window after they complete. The zero window disables it.
+type Cache[T any] struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry[T]
+}
+
+// entry is an operation run with a key, done once it completed
+type entry[T any] struct {
+	operation   string
+	done        chan struct{}
+	result      T
+	err         error
+	completedAt time.Time
+}
+
+// NewCache returns a cache keeping results for window
+func NewCache[T any](window time.Duration) *Cache[T] {
+	return &Cache[T]{window: window, maxEntries: MaxEntries, now: time.Now, entries: make(map[string]*entry[T])}
+}
+
+// Window returns how long results are kept
+func (c *Cache[T]) Window() time.Duration {
+	return c.window
+}
+
+// Do runs fn as the operation of key, unless the key ran it within the
+// window; replayed is then true and the result is the one of that run. Calls
+// with an empty key, or when the window is zero, always run fn. A key sent
+// with another operation than the one it ran fails with ErrKeyReused, and a
+// new key when the cache is full of running operations with ErrTooManyKeys.
+//
+// Calls waiting for the run of another call stop waiting when ctx ends; the
+// run itself is not stopped.
+func (c *Cache[T]) Do(ctx context.Context, key, operation string, fn func() (T, error)) (result T, replayed bool, err error) {
+	if key == "" || c.window <= 0 {
+		result, err = fn()
+		return result, false, err
+	}
+
+	c.mu.Lock()
+	c.expireLocked()
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		if e.operation != operation {
+			return result, false, ErrKeyReused
+		}
+		select {
+		case <-e.done:
+			return e.result, true, e.err
+		case <-ctx.Done():
+			return result, false, ctx.Err()
+		}
+	}
+	if len(c.entries) >= c.maxEntries && !c.evictOldestLocked() {
+		c.mu.Unlock()
+		return result, false, ErrTooManyKeys
+	}
+	e := &entry[T]{operation: operation, done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.result, e.err = fn()
+
+	c.mu.Lock()
+	e.completedAt = c.now()
+	if e.err != nil {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(e.done)
+
+	return e.result, false, e.err
+}
+
+// Len returns the number of keys running or kept
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expireLocked()
+	return len(c.entries)
+}
+
+// expireLocked forgets the results kept for longer than the window
+func (c *Cache[T]) expireLocked() {
+	expired := c.now().Add(-c.window)
+	for key, e := range c.entries {
+		if !e.completedAt.IsZero() && e.completedAt.Before(expired) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evictOldestLocked forgets the result completed first, returning false when
+// every operation is still running
+func (c *Cache[T]) evictOldestLocked() bool {
+	var oldestKey string
+	var oldest time.Time
+	for key, e := range c.entries {
+		if !e.completedAt.IsZero() && (oldest.IsZero() || e.completedAt.Before(oldest)) {
+			oldestKey, oldest = key, e.completedAt
+		}
+	}
+	if oldest.IsZero() {
+		return false
+	}
+	delete(c.entries, oldestKey)
+	return true
+}
@@ -0,0 +1,191 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache_ReplaysWithinWindow(t *testing.T) {
+	cache := NewCache[string](time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	runs := 0
+	run := func() (string, error) {
+		runs++
+		return "cycled", nil
+	}
+
+	result, replayed, err := cache.Do(context.Background(), "key-1", "PowerCycle", run)
+	if err != nil || replayed || result != "cycled" {
+		t.Fatalf("Do() = %q, %v, %v, want the run's result", result, replayed, err)
+	}
+	result, replayed, err = cache.Do(context.Background(), "key-1", "PowerCycle", run)
+	if err != nil || !replayed || result != "cycled" {
+		t.Fatalf("Do() = %q, %v, %v, want the replayed result", result, replayed, err)
+	}
+	if runs != 1 {
+		t.Errorf("Expected a single run, got %d", runs)
+	}
+
+	// Other keys run, and expired keys run again
+	if _, replayed, _ := cache.Do(context.Background(), "key-2", "PowerCycle", run); replayed {
+		t.Error("Expected another key to run")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, replayed, _ := cache.Do(context.Background(), "key-1", "PowerCycle", run); replayed {
+		t.Error("Expected the expired key to run again")
+	}
+	if runs != 3 {
+		t.Errorf("Expected 3 runs, got %d", runs)
+	}
+	if n := cache.Len(); n != 1 {
+		t.Errorf("Expected key-2 forgotten, got %d keys", n)
+	}
+}
+
+func TestCache_JoinsRunningOperation(t *testing.T) {
+	cache := NewCache[int](time.Minute)
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var runs sync.WaitGroup
+	runs.Add(1)
+	go func() {
+		defer runs.Done()
+		cache.Do(context.Background(), "key-1", "PowerOn", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		result, replayed, err := cache.Do(context.Background(), "key-1", "PowerOn", func() (int, error) {
+			t.Error("Expected the running operation to be joined")
+			return 2, nil
+		})
+		if err != nil || !replayed || result != 1 {
+			t.Errorf("Do() = %d, %v, %v, want the running operation's result", result, replayed, err)
+		}
+	}()
+
+	// Waiters stop waiting when their context ends
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := cache.Do(ctx, "key-1", "PowerOn", func() (int, error) { return 3, nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled waiter to return, got %v", err)
+	}
+
+	close(release)
+	<-done
+	runs.Wait()
+}
+
+func TestCache_ForgetsFailures(t *testing.T) {
+	cache := NewCache[string](time.Minute)
+	failure := errors.New("agent unavailable")
+
+	if _, _, err := cache.Do(context.Background(), "key-1", "Reset", func() (string, error) { return "", failure }); !errors.Is(err, failure) {
+		t.Fatalf("Expected the failure, got %v", err)
+	}
+	result, replayed, err := cache.Do(context.Background(), "key-1", "Reset", func() (string, error) { return "reset", nil })
+	if err != nil || replayed || result != "reset" {
+		t.Errorf("Do() = %q, %v, %v, want the retry to run", result, replayed, err)
+	}
+}
+
+func TestCache_KeyReused(t *testing.T) {
+	cache := NewCache[string](time.Minute)
+	cache.Do(context.Background(), "key-1", "PowerOn", func() (string, error) { return "on", nil })
+
+	if _, _, err := cache.Do(context.Background(), "key-1", "PowerCycle", func() (string, error) { return "cycled", nil }); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Expected ErrKeyReused, got %v", err)
+	}
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	cache := NewCache[string](time.Minute)
+	cache.maxEntries = 2
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	run := func() (string, error) { return "on", nil }
+
+	for _, key := range []string{"key-1", "key-2", "key-3"} {
+		if _, _, err := cache.Do(context.Background(), key, "PowerOn", run); err != nil {
+			t.Fatalf("Do(%s) failed: %v", key, err)
+		}
+		now = now.Add(time.Second)
+	}
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Expected 2 keys kept, got %d", n)
+	}
+	// The oldest result is evicted for the new key
+	if _, replayed, _ := cache.Do(context.Background(), "key-1", "PowerOn", run); replayed {
+		t.Error("Expected the evicted key to run again")
+	}
+	if _, replayed, _ := cache.Do(context.Background(), "key-3", "PowerOn", run); !replayed {
+		t.Error("Expected the newest key to be replayed")
+	}
+
+	// Running operations are never evicted, new keys are rejected instead
+	release := make(chan struct{})
+	var runs sync.WaitGroup
+	for _, key := range []string{"key-4", "key-5"} {
+		started := make(chan struct{})
+		runs.Add(1)
+		go func() {
+			defer runs.Done()
+			cache.Do(context.Background(), key, "PowerOn", func() (string, error) {
+				close(started)
+				<-release
+				return "on", nil
+			})
+		}()
+		<-started
+	}
+	if _, _, err := cache.Do(context.Background(), "key-6", "PowerOn", run); !errors.Is(err, ErrTooManyKeys) {
+		t.Errorf("Expected ErrTooManyKeys, got %v", err)
+	}
+	close(release)
+	runs.Wait()
+}
+
+func TestCache_Disabled(t *testing.T) {
+	for name, cache := range map[string]*Cache[int]{
+		"zero window": NewCache[int](0),
+		"empty key":   NewCache[int](time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := ""
+			if name == "zero window" {
+				key = "key-1"
+			}
+			runs := 0
+			for range 2 {
+				cache.Do(context.Background(), key, "PowerOn", func() (int, error) {
+					runs++
+					return runs, nil
+				})
+			}
+			if runs != 2 {
+				t.Errorf("Expected every call to run, got %d runs", runs)
+			}
+		})
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	if err := ValidateKey("3f2c7a9e-5b1d-4c8e-9a6f-0d2e4b7c1a35"); err != nil {
+		t.Errorf("Expected a UUID key to be valid, got %v", err)
+	}
+	if err := ValidateKey(string(make([]byte, MaxKeyLength+1))); err == nil {
+		t.Error("Expected a key longer than MaxKeyLength to be rejected")
+	}
+}
@@ -2,6 +2,8 @@ package identity
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 )
 
@@ -9,26 +11,64 @@ import (
 // This is used by the manager to create synthetic server IDs for BMC endpoints reported by gateways.
 //
 // The format is: bmc-{datacenter_id}-{sanitized_endpoint}
-// where sanitized_endpoint has colons (:) and dots (.) replaced with hyphens (-).
+// where sanitized_endpoint has colons (:) and dots (.) replaced with hyphens (-),
+// and the brackets of IPv6 literals removed.
 // Slashes (/) are NOT replaced to maintain URL structure visibility.
 //
 // Examples:
 //   - "http://localhost:9001" in "dc-local-dev" -> "bmc-dc-local-dev-http-//localhost-9001"
 //   - "http://redfish-01:8000" in "dc-east-1" -> "bmc-dc-east-1-http-//redfish-01-8000"
 //   - "192.168.1.100:623" in "dc-west-2" -> "bmc-dc-west-2-192-168-1-100-623"
+//   - "[fd00::10]:623" in "dc-west-2" -> "bmc-dc-west-2-fd00--10-623"
 func GenerateServerIDFromBMCEndpoint(datacenterID, bmcEndpoint string) string {
 	// Sanitize endpoint: replace : and . with -, but NOT /
-	sanitizedEndpoint := strings.ReplaceAll(
-		strings.ReplaceAll(bmcEndpoint, ":", "-"),
-		".", "-")
+	sanitizedEndpoint := strings.NewReplacer(":", "-", ".", "-", "[", "", "]", "").Replace(bmcEndpoint)
 
 	return fmt.Sprintf("bmc-%s-%s", datacenterID, sanitizedEndpoint)
 }
 
 // SanitizeBMCEndpointForID sanitizes a BMC endpoint string for use in server IDs.
-// Replaces : and . with -, but preserves / for URL structure visibility.
+// Replaces : and . with - and removes IPv6 brackets, but preserves / for URL
+// structure visibility.
 func SanitizeBMCEndpointForID(endpoint string) string {
-	return strings.ReplaceAll(
-		strings.ReplaceAll(endpoint, ":", "-"),
-		".", "-")
+	return strings.NewReplacer(":", "-", ".", "-", "[", "", "]", "").Replace(endpoint)
+}
+
+// NormalizeBMCEndpoint returns a BMC endpoint, given as a URL or as
+// host[:port], with its DNS name in lower case and without the trailing dot
+// of a fully qualified name. DNS names are case insensitive, so the endpoints
+// of a BMC then have the same server ID. Endpoints with an IP address are
+// returned unchanged.
+//
+// Examples:
+//   - "BMC-01.DC.example.com.:623" -> "bmc-01.dc.example.com:623"
+//   - "https://BMC-01.dc.example.com/redfish/v1" -> "https://bmc-01.dc.example.com/redfish/v1"
+func NormalizeBMCEndpoint(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" {
+			return endpoint
+		}
+		host := normalizeHostname(u.Hostname())
+		if port := u.Port(); port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+		u.Host = host
+		return u.String()
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return normalizeHostname(endpoint)
+	}
+	return net.JoinHostPort(normalizeHostname(host), port)
+}
+
+// normalizeHostname returns a DNS name in lower case without its trailing
+// dot, or an IP address unchanged
+func normalizeHostname(host string) string {
+	if net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
 }
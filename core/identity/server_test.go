@@ -0,0 +1,36 @@
+package identity
+
+import "testing"
+
+func TestGenerateServerIDFromBMCEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:9001":     "bmc-dc-1-http-//localhost-9001",
+		"192.168.1.100:623":         "bmc-dc-1-192-168-1-100-623",
+		"[fd00::10]:623":            "bmc-dc-1-fd00--10-623",
+		"bmc-01.dc.example.com:623": "bmc-dc-1-bmc-01-dc-example-com-623",
+	}
+	for endpoint, want := range tests {
+		if got := GenerateServerIDFromBMCEndpoint("dc-1", endpoint); got != want {
+			t.Errorf("GenerateServerIDFromBMCEndpoint(%s) = %s, want %s", endpoint, got, want)
+		}
+	}
+}
+
+func TestNormalizeBMCEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"BMC-01.DC.example.com:623":                "bmc-01.dc.example.com:623",
+		"bmc-01.dc.example.com.:623":               "bmc-01.dc.example.com:623",
+		"BMC-01":                                   "bmc-01",
+		"https://BMC-01.dc.example.com":            "https://bmc-01.dc.example.com",
+		"https://BMC-01.dc.example.com.:8443":      "https://bmc-01.dc.example.com:8443",
+		"https://BMC-01.dc.example.com/redfish/v1": "https://bmc-01.dc.example.com/redfish/v1",
+		"192.168.1.100:623":                        "192.168.1.100:623",
+		"[FD00::10]:623":                           "[FD00::10]:623",
+		"https://[fd00::10]:443":                   "https://[fd00::10]:443",
+	}
+	for endpoint, want := range tests {
+		if got := NormalizeBMCEndpoint(endpoint); got != want {
+			t.Errorf("NormalizeBMCEndpoint(%s) = %s, want %s", endpoint, got, want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Log levels can be changed at runtime, for the whole service and per
+// module, e.g. to enable debug logging of SOL sessions during an incident
+// without restarting and dropping the sessions. A module is a Go package,
+// named by its import path or its last elements: "sol", "pkg/sol" or
+// "local-agent/pkg/sol".
+//
+// Module levels are applied by ModuleLevelHook, which services install on
+// their logger:
+//
+//	log.Logger = log.Output(logging.NewRedactWriter(out)).Hook(logging.ModuleLevelHook)
+var levels = &levelState{
+	base:    zerolog.InfoLevel,
+	modules: make(map[string]zerolog.Level),
+}
+
+// levelState is the base level of the service and the levels of modules
+type levelState struct {
+	mu      sync.RWMutex
+	base    zerolog.Level
+	modules map[string]zerolog.Level
+	// packages caches the package of the callers of log events
+	packages sync.Map
+}
+
+// ParseLevel parses a level name, accepting "warning" for "warn" and
+// "disabled" to silence a module
+func ParseLevel(name string) (zerolog.Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+	if name == "" {
+		return zerolog.NoLevel, fmt.Errorf("empty log level")
+	}
+	return zerolog.ParseLevel(name)
+}
+
+// SetLevel sets the base level of the service, that of modules without a
+// level of their own
+func SetLevel(level zerolog.Level) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.base = level
+	levels.applyLocked()
+}
+
+// SetModuleLevel sets the level of a module
+func SetModuleLevel(module string, level zerolog.Level) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.modules[strings.Trim(module, "/")] = level
+	levels.applyLocked()
+}
+
+// ClearModuleLevel makes a module log at the base level again
+func ClearModuleLevel(module string) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	delete(levels.modules, strings.Trim(module, "/"))
+	levels.applyLocked()
+}
+
+// ClearModuleLevels makes all modules log at the base level
+func ClearModuleLevels() {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.modules = make(map[string]zerolog.Level)
+	levels.applyLocked()
+}
+
+// applyLocked lowers the global zerolog level to the most verbose level, so
+// that the events of modules logging below the base level are created; the
+// hook then discards those of the other modules
+func (s *levelState) applyLocked() {
+	global := s.base
+	for _, level := range s.modules {
+		if level < global {
+			global = level
+		}
+	}
+	zerolog.SetGlobalLevel(global)
+}
+
+// ModuleLevelHook discards the events below the level of the module logging
+// them. Without module levels, the global level alone filters events and the
+// hook returns at once.
+var ModuleLevelHook zerolog.Hook = zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, _ string) {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	if len(levels.modules) == 0 {
+		return
+	}
+	if level < levels.levelLocked(levels.callerPackage()) {
+		e.Discard()
+	}
+})
+
+// levelLocked returns the level of a package: that of the longest module
+// naming it, or the base level
+func (s *levelState) levelLocked(pkg string) zerolog.Level {
+	level, matched := s.base, ""
+	for module, moduleLevel := range s.modules {
+		if len(module) > len(matched) && (pkg == module || strings.HasSuffix(pkg, "/"+module)) {
+			level, matched = moduleLevel, module
+		}
+	}
+	return level
+}
+
+// callerPackage returns the import path of the package logging the event,
+// the first caller outside zerolog
+func (s *levelState) callerPackage() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if cached, ok := s.packages.Load(frame.PC); ok {
+			return cached.(string)
+		}
+		pkg := packageOf(frame.Function)
+		if pkg != "github.com/rs/zerolog" && pkg != "github.com/rs/zerolog/log" {
+			s.packages.Store(frame.PC, pkg)
+			return pkg
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// packageOf returns the package of a function name such as
+// "local-agent/pkg/sol.(*Session).run"
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// LevelStatus describes the log levels of a service
+type LevelStatus struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+// CurrentLevels returns the base level and the levels of modules
+func CurrentLevels() LevelStatus {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	status := LevelStatus{Level: levels.base.String()}
+	if len(levels.modules) > 0 {
+		status.Modules = make(map[string]string, len(levels.modules))
+		for module, level := range levels.modules {
+			status.Modules[module] = level.String()
+		}
+	}
+	return status
+}
+
+// LevelChange is a change of the log levels of a service
+type LevelChange struct {
+	// Base level, unchanged when empty
+	Level string `json:"level,omitempty"`
+	// Levels of modules; an empty level makes the module log at the base
+	// level again
+	Modules map[string]string `json:"modules,omitempty"`
+	// Clear the levels of all modules before applying Modules
+	ResetModules bool `json:"reset_modules,omitempty"`
+}
+
+// ApplyLevels applies a change of the log levels. Nothing is changed when
+// one of the levels is invalid.
+func ApplyLevels(change LevelChange) error {
+	var base zerolog.Level
+	if change.Level != "" {
+		level, err := ParseLevel(change.Level)
+		if err != nil {
+			return fmt.Errorf("invalid log level %q", change.Level)
+		}
+		base = level
+	}
+	modules := make(map[string]zerolog.Level, len(change.Modules))
+	for module, name := range change.Modules {
+		if strings.Trim(module, "/") == "" {
+			return fmt.Errorf("empty module name")
+		}
+		if name == "" {
+			continue
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return fmt.Errorf("invalid log level %q of module %s", name, module)
+		}
+		modules[module] = level
+	}
+
+	if change.ResetModules {
+		ClearModuleLevels()
+	}
+	if change.Level != "" {
+		SetLevel(base)
+	}
+	for module := range change.Modules {
+		if level, ok := modules[module]; ok {
+			SetModuleLevel(module, level)
+		} else {
+			ClearModuleLevel(module)
+		}
+	}
+	return nil
+}
+
+// LevelHandler serves the log levels: GET returns the current levels and
+// PUT applies the LevelChange of the JSON body, then returns the new levels.
+// Callers authenticate the requests.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var change LevelChange
+			if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+				http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := ApplyLevels(change); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			levels := CurrentLevels()
+			log.Warn().
+				Str("remote_addr", r.RemoteAddr).
+				Str("level", levels.Level).
+				Interface("modules", levels.Modules).
+				Msg("Log levels changed")
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CurrentLevels())
+	})
+}
@@ -0,0 +1,159 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// resetLevels restores the levels changed by a test
+func resetLevels(t *testing.T) {
+	global := zerolog.GlobalLevel()
+	t.Cleanup(func() {
+		levels.mu.Lock()
+		levels.base = zerolog.InfoLevel
+		levels.modules = make(map[string]zerolog.Level)
+		levels.mu.Unlock()
+		zerolog.SetGlobalLevel(global)
+	})
+}
+
+func TestModuleLevelHook(t *testing.T) {
+	resetLevels(t)
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Hook(ModuleLevelHook)
+
+	SetLevel(zerolog.InfoLevel)
+	logger.Debug().Msg("base debug")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected debug events to be dropped at the info level: %s", buf.String())
+	}
+
+	// Tests log from core/logging
+	SetModuleLevel("logging", zerolog.DebugLevel)
+	logger.Debug().Msg("module debug")
+	if !strings.Contains(buf.String(), "module debug") {
+		t.Errorf("Expected debug events of the module to be written: %s", buf.String())
+	}
+
+	buf.Reset()
+	ClearModuleLevel("logging")
+	SetModuleLevel("local-agent/pkg/sol", zerolog.TraceLevel)
+	if zerolog.GlobalLevel() != zerolog.TraceLevel {
+		t.Errorf("Expected the global level lowered to trace, got %s", zerolog.GlobalLevel())
+	}
+	logger.Debug().Msg("other module debug")
+	logger.Info().Msg("other module info")
+	if strings.Contains(buf.String(), "other module debug") || !strings.Contains(buf.String(), "other module info") {
+		t.Errorf("Expected events of other modules filtered at the base level: %s", buf.String())
+	}
+
+	buf.Reset()
+	SetModuleLevel("core/logging", zerolog.ErrorLevel)
+	SetModuleLevel("logging", zerolog.DebugLevel)
+	logger.Warn().Msg("longest match")
+	if buf.Len() != 0 {
+		t.Errorf("Expected the longest module to apply: %s", buf.String())
+	}
+}
+
+func TestLevelState_levelLocked(t *testing.T) {
+	s := &levelState{base: zerolog.InfoLevel, modules: map[string]zerolog.Level{
+		"sol":                 zerolog.DebugLevel,
+		"internal/gateway":    zerolog.TraceLevel,
+		"local-agent/pkg/vnc": zerolog.WarnLevel,
+	}}
+
+	tests := map[string]zerolog.Level{
+		"local-agent/pkg/sol":      zerolog.DebugLevel,
+		"local-agent/internal/sol": zerolog.DebugLevel,
+		"gateway/internal/gateway": zerolog.TraceLevel,
+		"local-agent/pkg/vnc":      zerolog.WarnLevel,
+		"local-agent/pkg/console":  zerolog.InfoLevel,
+		"core/solar":               zerolog.InfoLevel,
+	}
+	for pkg, expected := range tests {
+		if level := s.levelLocked(pkg); level != expected {
+			t.Errorf("%s: expected %s, got %s", pkg, expected, level)
+		}
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	tests := map[string]string{
+		"local-agent/pkg/sol.(*Session).run":        "local-agent/pkg/sol",
+		"gateway/internal/gateway.NewHandler.func1": "gateway/internal/gateway",
+		"main.main":                          "main",
+		"github.com/rs/zerolog.(*Event).Msg": "github.com/rs/zerolog",
+		"github.com/rs/zerolog/log.Info":     "github.com/rs/zerolog/log",
+	}
+	for function, expected := range tests {
+		if pkg := packageOf(function); pkg != expected {
+			t.Errorf("%s: expected %s, got %s", function, expected, pkg)
+		}
+	}
+}
+
+func TestApplyLevels(t *testing.T) {
+	resetLevels(t)
+
+	if err := ApplyLevels(LevelChange{Level: "debug", Modules: map[string]string{"sol": "verbose"}}); err == nil {
+		t.Fatal("Expected invalid module levels to be rejected")
+	}
+	if CurrentLevels().Level != "info" {
+		t.Errorf("Expected nothing changed by an invalid change, got %+v", CurrentLevels())
+	}
+
+	if err := ApplyLevels(LevelChange{Level: "warning", Modules: map[string]string{"sol": "debug", "vnc": "trace"}}); err != nil {
+		t.Fatalf("ApplyLevels failed: %v", err)
+	}
+	status := CurrentLevels()
+	if status.Level != "warn" || status.Modules["sol"] != "debug" || status.Modules["vnc"] != "trace" {
+		t.Errorf("Unexpected levels: %+v", status)
+	}
+
+	if err := ApplyLevels(LevelChange{Modules: map[string]string{"vnc": ""}}); err != nil {
+		t.Fatalf("ApplyLevels failed: %v", err)
+	}
+	if status := CurrentLevels(); status.Level != "warn" || len(status.Modules) != 1 {
+		t.Errorf("Expected the vnc level cleared, got %+v", status)
+	}
+
+	if err := ApplyLevels(LevelChange{ResetModules: true}); err != nil {
+		t.Fatalf("ApplyLevels failed: %v", err)
+	}
+	if status := CurrentLevels(); len(status.Modules) != 0 || zerolog.GlobalLevel() != zerolog.WarnLevel {
+		t.Errorf("Expected the module levels reset, got %+v at %s", status, zerolog.GlobalLevel())
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	resetLevels(t)
+	handler := LevelHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("PUT", "/debug/log-level", strings.NewReader(`{"level":"debug","modules":{"sol":"trace"}}`)))
+	var status LevelStatus
+	if err := json.NewDecoder(recorder.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if status.Level != "debug" || status.Modules["sol"] != "trace" {
+		t.Errorf("Unexpected levels: %+v", status)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("PUT", "/debug/log-level", strings.NewReader(`{"level":"loud"}`)))
+	if recorder.Code != 400 {
+		t.Errorf("Expected 400 for invalid levels, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/debug/log-level", nil))
+	if recorder.Code != 405 {
+		t.Errorf("Expected 405 for POST, got %d", recorder.Code)
+	}
+}
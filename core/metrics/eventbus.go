@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"core/eventbus"
+	"core/events"
+)
+
+// EventBusObserver returns an observer of the events published to the event
+// bus, see eventbus.Publisher.SetObserver. It requires a Prometheus
+// CounterVec with labels [event_type, status], the status being success,
+// dropped when the publisher's queue was full, or error.
+func EventBusObserver(eventsTotal *prometheus.CounterVec) func(event events.Event, err error) {
+	return func(event events.Event, err error) {
+		status := "success"
+		switch {
+		case errors.Is(err, eventbus.ErrQueueFull):
+			status = "dropped"
+		case err != nil:
+			status = "error"
+		}
+		eventsTotal.WithLabelValues(string(event.Type), status).Inc()
+	}
+}
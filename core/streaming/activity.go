@@ -0,0 +1,37 @@
+package streaming
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Activity is the time a console session was last used, shared by the
+// streams of the session. It is safe for concurrent use; a nil Activity
+// records nothing.
+type Activity struct {
+	last atomic.Int64 // Unix nanoseconds
+}
+
+// NewActivity returns the activity of a session last used at
+func NewActivity(at time.Time) *Activity {
+	a := &Activity{}
+	a.last.Store(at.UnixNano())
+	return a
+}
+
+// Touch records that the session is used now
+func (a *Activity) Touch() {
+	if a == nil {
+		return
+	}
+	a.last.Store(time.Now().UnixNano())
+}
+
+// Last returns when the session was last used, the zero time for a nil
+// Activity
+func (a *Activity) Last() time.Time {
+	if a == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, a.last.Load())
+}
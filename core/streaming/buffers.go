@@ -0,0 +1,165 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultChunkSize is the default size of the buffers that TCP data is read
+// into, and so the maximum data size of the chunks read from TCP transports
+const DefaultChunkSize = 32 * 1024
+
+// maxPooledMessageSize caps the WebSocket message buffers returned to a pool,
+// so that a rare large message does not stay allocated
+const maxPooledMessageSize = 1 << 20
+
+// TCPBufferReader is implemented by transports able to read into a buffer
+// provided by the caller. Proxies with a buffer pool read such transports
+// into pooled buffers, which become the chunks' data without copying.
+type TCPBufferReader interface {
+	ReadBuffer(ctx context.Context, buf []byte) (int, error)
+}
+
+// ChunkReleaser is implemented by chunk factories pooling their chunks.
+// Proxies with a buffer pool release the data chunks they created once sent.
+type ChunkReleaser[T StreamChunk] interface {
+	ReleaseChunk(chunk T)
+}
+
+// BufferPool pools the data buffers of chunks, so that proxies don't
+// allocate a buffer for every chunk they send.
+//
+// A buffer returns to the pool as soon as the chunk holding it is sent:
+// streams proxied with a pool must not retain sent chunks or their data.
+// Connect streams marshal chunks in Send and can be used with a pool.
+type BufferPool struct {
+	chunkSize int
+	buffers   sync.Pool // *[]byte of chunkSize bytes, for TCP reads
+	messages  sync.Pool // *bytes.Buffer, for WebSocket messages
+}
+
+// NewBufferPool creates a pool of chunkSize buffers, DefaultChunkSize if
+// chunkSize is not positive
+func NewBufferPool(chunkSize int) *BufferPool {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	p := &BufferPool{chunkSize: chunkSize}
+	p.buffers.New = func() any {
+		buf := make([]byte, p.chunkSize)
+		return &buf
+	}
+	p.messages.New = func() any {
+		return new(bytes.Buffer)
+	}
+	return p
+}
+
+// ChunkSize returns the size of the buffers of the pool
+func (p *BufferPool) ChunkSize() int {
+	return p.chunkSize
+}
+
+// Get returns a buffer of ChunkSize bytes
+func (p *BufferPool) Get() *[]byte {
+	return p.buffers.Get().(*[]byte)
+}
+
+// Put returns a buffer obtained from Get to the pool. Nil buffers are
+// ignored.
+func (p *BufferPool) Put(buf *[]byte) {
+	if buf == nil || cap(*buf) != p.chunkSize {
+		return
+	}
+	*buf = (*buf)[:p.chunkSize]
+	p.buffers.Put(buf)
+}
+
+// read reads the next data of transport, into a pooled buffer when the pool
+// is set and transport supports it. The returned buffer, nil if not pooled,
+// must be put back once the data is sent.
+func (p *BufferPool) read(ctx context.Context, transport TCPReader) ([]byte, *[]byte, error) {
+	reader, ok := transport.(TCPBufferReader)
+	if p == nil || !ok {
+		data, err := transport.Read(ctx)
+		return data, nil, err
+	}
+
+	buf := p.Get()
+	n, err := reader.ReadBuffer(ctx, *buf)
+	if err != nil {
+		p.Put(buf)
+		return nil, nil, err
+	}
+	return (*buf)[:n], buf, nil
+}
+
+// errMessageTooLarge is returned by readMessage for messages over its limit
+var errMessageTooLarge = errors.New("WebSocket message too large")
+
+// readMessage reads the next WebSocket message, into a pooled buffer when
+// the pool is set. The returned buffer, nil if not pooled, must be put back
+// with putMessage once the data is sent. Messages over limit bytes, when
+// positive, fail with errMessageTooLarge; the rest of the message is left
+// unread.
+func (p *BufferPool) readMessage(conn *websocket.Conn, limit int64) (int, []byte, *bytes.Buffer, error) {
+	if p == nil && limit <= 0 {
+		messageType, data, err := conn.ReadMessage()
+		return messageType, data, nil, err
+	}
+
+	messageType, reader, err := conn.NextReader()
+	if err != nil {
+		return messageType, nil, nil, err
+	}
+	if limit > 0 {
+		reader = io.LimitReader(reader, limit+1)
+	}
+
+	var buf *bytes.Buffer
+	if p != nil {
+		buf = p.messages.Get().(*bytes.Buffer)
+	} else {
+		buf = &bytes.Buffer{}
+	}
+	if _, err := buf.ReadFrom(reader); err != nil {
+		p.putMessage(buf)
+		return messageType, nil, nil, err
+	}
+	if limit > 0 && int64(buf.Len()) > limit {
+		p.putMessage(buf)
+		return messageType, nil, nil, fmt.Errorf("%w: larger than %d bytes", errMessageTooLarge, limit)
+	}
+	if p == nil {
+		return messageType, buf.Bytes(), nil, nil
+	}
+	return messageType, buf.Bytes(), buf, nil
+}
+
+// putMessage returns a buffer obtained from readMessage to the pool. Nil
+// buffers are ignored.
+func (p *BufferPool) putMessage(buf *bytes.Buffer) {
+	if p == nil || buf == nil || buf.Cap() > maxPooledMessageSize {
+		return
+	}
+	buf.Reset()
+	p.messages.Put(buf)
+}
+
+// releaseChunk releases a sent data chunk to its factory, when both the pool
+// is set and the factory pools its chunks
+func releaseChunk[T StreamChunk](p *BufferPool, factory ChunkFactory[T], chunk T) {
+	if p == nil {
+		return
+	}
+	if releaser, ok := factory.(ChunkReleaser[T]); ok {
+		releaser.ReleaseChunk(chunk)
+	}
+}
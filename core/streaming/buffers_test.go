@@ -0,0 +1,265 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+// pooledChunkFactory pools its chunks, like the Connect chunk factories
+type pooledChunkFactory struct {
+	chunks   sync.Pool
+	released int
+}
+
+func (f *pooledChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *testChunk {
+	chunk, _ := f.chunks.Get().(*testChunk)
+	if chunk == nil {
+		chunk = &testChunk{}
+	}
+	*chunk = testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}
+	return chunk
+}
+
+func (f *pooledChunkFactory) ReleaseChunk(chunk *testChunk) {
+	f.released++
+	*chunk = testChunk{}
+	f.chunks.Put(chunk)
+}
+
+// marshalingStream copies the data of sent chunks, like Connect streams
+// marshaling them, then blocks on Receive until done is closed
+type marshalingStream struct {
+	mu   sync.Mutex
+	sent bytes.Buffer
+	done chan struct{}
+}
+
+func (s *marshalingStream) Send(chunk *testChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent.Write(chunk.data)
+	return nil
+}
+
+func (s *marshalingStream) Receive() (*testChunk, error) {
+	<-s.done
+	return nil, io.EOF
+}
+
+// bufferTransport returns reads of a fixed payload, then EOF, and implements
+// TCPBufferReader
+type bufferTransport struct {
+	payload []byte
+	reads   int
+}
+
+func (t *bufferTransport) Read(ctx context.Context) ([]byte, error) {
+	buf := make([]byte, len(t.payload))
+	n, err := t.ReadBuffer(ctx, buf)
+	return buf[:n], err
+}
+
+func (t *bufferTransport) ReadBuffer(ctx context.Context, buf []byte) (int, error) {
+	if t.reads == 0 {
+		return 0, io.EOF
+	}
+	t.reads--
+	return copy(buf, t.payload), nil
+}
+
+func (t *bufferTransport) Write(ctx context.Context, data []byte) error { return nil }
+func (t *bufferTransport) Close() error                                 { return nil }
+
+// unbufferedTransport only supports TCPReader.Read
+type unbufferedTransport struct {
+	inner *bufferTransport
+}
+
+func (t unbufferedTransport) Read(ctx context.Context) ([]byte, error) {
+	return t.inner.Read(ctx)
+}
+func (t unbufferedTransport) Write(ctx context.Context, data []byte) error { return nil }
+func (t unbufferedTransport) Close() error                                 { return nil }
+
+func TestBufferPool(t *testing.T) {
+	pool := NewBufferPool(0)
+	if pool.ChunkSize() != DefaultChunkSize {
+		t.Fatalf("Expected the default chunk size, got %d", pool.ChunkSize())
+	}
+
+	buf := pool.Get()
+	if len(*buf) != DefaultChunkSize {
+		t.Fatalf("Expected a %d bytes buffer, got %d", DefaultChunkSize, len(*buf))
+	}
+	*buf = (*buf)[:10]
+	pool.Put(buf)
+
+	// Foreign buffers and nil are ignored
+	other := make([]byte, 16)
+	pool.Put(&other)
+	pool.Put(nil)
+
+	if buf := pool.Get(); len(*buf) != DefaultChunkSize {
+		t.Errorf("Expected buffers of %d bytes from the pool, got %d", DefaultChunkSize, len(*buf))
+	}
+}
+
+func TestStreamToTCPProxy_BufferPool(t *testing.T) {
+	payload := []byte(strings.Repeat("framebuffer ", 100))
+
+	t.Run("pooled", func(t *testing.T) {
+		stream := &marshalingStream{done: make(chan struct{})}
+		defer close(stream.done)
+		transport := &bufferTransport{payload: payload, reads: 5}
+		factory := &pooledChunkFactory{}
+
+		proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), factory).
+			WithBufferPool(NewBufferPool(4096))
+		if err := proxy.ProxyFromStream(context.Background(), stream, transport); err != nil {
+			t.Fatalf("ProxyFromStream() error = %v", err)
+		}
+
+		if !bytes.Equal(stream.sent.Bytes(), bytes.Repeat(payload, 5)) {
+			t.Errorf("Unexpected data sent: %d bytes", stream.sent.Len())
+		}
+		if factory.released != 5 {
+			t.Errorf("Expected the 5 data chunks to be released, got %d", factory.released)
+		}
+	})
+
+	t.Run("transport without buffer reads", func(t *testing.T) {
+		stream := &marshalingStream{done: make(chan struct{})}
+		defer close(stream.done)
+		transport := unbufferedTransport{&bufferTransport{payload: payload, reads: 2}}
+
+		proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), testChunkFactory{}).
+			WithBufferPool(NewBufferPool(4096))
+		if err := proxy.ProxyFromStream(context.Background(), stream, transport); err != nil {
+			t.Fatalf("ProxyFromStream() error = %v", err)
+		}
+		if !bytes.Equal(stream.sent.Bytes(), bytes.Repeat(payload, 2)) {
+			t.Errorf("Unexpected data sent: %d bytes", stream.sent.Len())
+		}
+	})
+
+	t.Run("unpooled chunks are not released", func(t *testing.T) {
+		stream := &marshalingStream{done: make(chan struct{})}
+		defer close(stream.done)
+		transport := &bufferTransport{payload: payload, reads: 2}
+		factory := &pooledChunkFactory{}
+
+		proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), factory)
+		if err := proxy.ProxyFromStream(context.Background(), stream, transport); err != nil {
+			t.Fatalf("ProxyFromStream() error = %v", err)
+		}
+		if factory.released != 0 {
+			t.Errorf("Expected no chunk released without a pool, got %d", factory.released)
+		}
+	})
+}
+
+func TestBufferPool_ReadMessage(t *testing.T) {
+	messages := [][]byte{[]byte("first"), bytes.Repeat([]byte("x"), 100000), []byte("last")}
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, message := range messages {
+			conn.WriteMessage(websocket.BinaryMessage, message)
+		}
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	pool := NewBufferPool(0)
+	for _, expected := range messages {
+		messageType, data, buf, err := pool.readMessage(conn, 0)
+		if err != nil {
+			t.Fatalf("readMessage failed: %v", err)
+		}
+		if messageType != websocket.BinaryMessage || !bytes.Equal(data, expected) {
+			t.Errorf("Expected a %d bytes binary message, got %d bytes of type %d", len(expected), len(data), messageType)
+		}
+		pool.putMessage(buf)
+	}
+}
+
+// benchmarkSessions is the number of concurrent sessions of the proxy
+// benchmarks
+const benchmarkSessions = 128
+
+// BenchmarkStreamToTCPProxy proxies VNC-like traffic of concurrent sessions
+// from the BMC to the stream. Compare allocations with:
+//
+//	go test -run XXX -bench StreamToTCPProxy -benchmem
+func BenchmarkStreamToTCPProxy(b *testing.B) {
+	payload := bytes.Repeat([]byte{0xAB}, DefaultChunkSize)
+	const chunksPerSession = 16
+
+	run := func(b *testing.B, pool *BufferPool, factory ChunkFactory[*testChunk]) {
+		b.ReportAllocs()
+		b.SetBytes(int64(benchmarkSessions * chunksPerSession * len(payload)))
+
+		for b.Loop() {
+			var wg sync.WaitGroup
+			for range benchmarkSessions {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					stream := &marshalingStream{done: make(chan struct{})}
+					defer close(stream.done)
+					stream.sent.Grow(chunksPerSession * len(payload))
+
+					proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), factory).WithBufferPool(pool)
+					proxy.ProxyFromStream(context.Background(), stream, &bufferTransport{payload: payload, reads: chunksPerSession})
+				}()
+			}
+			wg.Wait()
+		}
+	}
+
+	b.Run("unpooled", func(b *testing.B) {
+		run(b, nil, testChunkFactory{})
+	})
+	b.Run("pooled", func(b *testing.B) {
+		run(b, NewBufferPool(DefaultChunkSize), &syncPooledChunkFactory{})
+	})
+}
+
+// syncPooledChunkFactory pools its chunks and is safe for concurrent use
+type syncPooledChunkFactory struct {
+	chunks sync.Pool
+}
+
+func (f *syncPooledChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *testChunk {
+	chunk, _ := f.chunks.Get().(*testChunk)
+	if chunk == nil {
+		chunk = &testChunk{}
+	}
+	*chunk = testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}
+	return chunk
+}
+
+func (f *syncPooledChunkFactory) ReleaseChunk(chunk *testChunk) {
+	*chunk = testChunk{}
+	f.chunks.Put(chunk)
+}
@@ -0,0 +1,179 @@
+package streaming
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HandshakeVersion is the version of the handshake protocol implemented by
+// this package. Version 1 handshakes only carry the session and server IDs,
+// version 2 handshakes also advertise Capabilities.
+const HandshakeVersion = 2
+
+// Handshake metadata keys of the capabilities advertised by version 2
+// handshakes, along with MetadataMaxChunkSize
+const (
+	MetadataProtocolVersion = "protocol-version"
+	MetadataFeatures        = "features"
+	MetadataProtocolVariant = "protocol-variant"
+	MetadataAuthToken       = "auth-token"
+)
+
+// Feature is an optional streaming feature, used on a stream only when both
+// ends advertise it
+type Feature string
+
+// Features known to this version of the package. Ends ignore the features
+// they don't know, so that new ones roll out one end at a time.
+const (
+	FeatureCompression Feature = "compression"
+	FeatureResume      Feature = "resume"
+	FeatureChecksums   Feature = "checksums" // CRC-32C of chunk data, see ChecksummedStream
+	FeatureKeepalive   Feature = "keepalive" // Ping chunks answered by the gateway, see KeepaliveStream
+)
+
+// Protocol variants of the data carried by streams
+const (
+	VariantRFB = "rfb" // VNC remote framebuffer protocol
+	VariantSOL = "sol" // Raw serial console bytes
+)
+
+// Capabilities are the handshake protocol version and the optional features
+// advertised by one end of a stream, or negotiated between both ends
+type Capabilities struct {
+	// Version of the handshake protocol, 1 for ends advertising nothing
+	Version int
+	// Features supported, in any order
+	Features []Feature
+	// MaxChunkSize is the largest chunk data accepted, 0 when unlimited and
+	// when fragments are not reassembled, see SplitLargeChunks
+	MaxChunkSize int
+	// Variant of the protocol carried in data chunks, empty for the default
+	Variant string
+	// AuthToken authenticates the end opening the stream, for transports
+	// without request headers. Never negotiated, see NegotiateCapabilities.
+	AuthToken string
+}
+
+// Has returns true when the feature is supported
+func (c Capabilities) Has(feature Feature) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// AddTo adds the capabilities to handshake metadata and returns it,
+// allocating the map when metadata is nil. A zero version advertises
+// HandshakeVersion.
+func (c Capabilities) AddTo(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	version := c.Version
+	if version == 0 {
+		version = HandshakeVersion
+	}
+	metadata[MetadataProtocolVersion] = strconv.Itoa(version)
+
+	if len(c.Features) > 0 {
+		features := make([]string, len(c.Features))
+		for i, feature := range c.Features {
+			features[i] = string(feature)
+		}
+		slices.Sort(features)
+		metadata[MetadataFeatures] = strings.Join(features, ",")
+	}
+	if c.Variant != "" {
+		metadata[MetadataProtocolVariant] = c.Variant
+	}
+	if c.AuthToken != "" {
+		metadata[MetadataAuthToken] = c.AuthToken
+	}
+	return AddMaxChunkSize(metadata, c.MaxChunkSize)
+}
+
+// CapabilitiesOf returns the capabilities advertised by handshake metadata.
+// Handshakes without a valid protocol version are version 1 handshakes,
+// advertising no feature nor variant.
+func CapabilitiesOf(metadata map[string]string) Capabilities {
+	capabilities := Capabilities{
+		Version:      1,
+		MaxChunkSize: MaxChunkSizeOf(metadata),
+	}
+
+	version, err := strconv.Atoi(metadata[MetadataProtocolVersion])
+	if err != nil || version < 2 {
+		return capabilities
+	}
+	capabilities.Version = version
+
+	for _, feature := range strings.Split(metadata[MetadataFeatures], ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			capabilities.Features = append(capabilities.Features, Feature(feature))
+		}
+	}
+	capabilities.Variant = metadata[MetadataProtocolVariant]
+	capabilities.AuthToken = metadata[MetadataAuthToken]
+	return capabilities
+}
+
+// NegotiateCapabilities returns the capabilities usable on a stream between
+// a local and a remote end: the lower version, the features both support,
+// the smaller chunk size when both reassemble fragments, and the variant
+// when both agree on it, else the default. The auth token is the remote's.
+func NegotiateCapabilities(local, remote Capabilities) Capabilities {
+	negotiated := Capabilities{
+		Version:   min(max(local.Version, 1), max(remote.Version, 1)),
+		AuthToken: remote.AuthToken,
+	}
+	if local.MaxChunkSize > 0 && remote.MaxChunkSize > 0 {
+		negotiated.MaxChunkSize = min(local.MaxChunkSize, remote.MaxChunkSize)
+	}
+	if negotiated.Version < 2 {
+		return negotiated
+	}
+
+	for _, feature := range local.Features {
+		if remote.Has(feature) && !negotiated.Has(feature) {
+			negotiated.Features = append(negotiated.Features, feature)
+		}
+	}
+	if local.Variant == remote.Variant {
+		negotiated.Variant = local.Variant
+	}
+	return negotiated
+}
+
+// WithCapabilities makes the helper advertise capabilities in the handshakes
+// and acknowledgments it sends, as a version 2 handshake. Factories unable to
+// create metadata chunks keep sending version 1 handshakes.
+func (h *HandshakeHelper[T]) WithCapabilities(capabilities Capabilities) *HandshakeHelper[T] {
+	if capabilities.Version == 0 {
+		capabilities.Version = HandshakeVersion
+	}
+	h.capabilities = &capabilities
+	return h
+}
+
+// Capabilities returns the capabilities advertised by the helper, those of
+// a version 1 handshake when none are set
+func (h *HandshakeHelper[T]) Capabilities() Capabilities {
+	if h.capabilities == nil {
+		return Capabilities{Version: 1}
+	}
+	return *h.capabilities
+}
+
+// Negotiate returns the capabilities usable on the stream, from those of the
+// helper and those advertised by the remote end's handshake
+func (h *HandshakeHelper[T]) Negotiate(handshake T) Capabilities {
+	return NegotiateCapabilities(h.Capabilities(), CapabilitiesOf(MetadataOf(handshake)))
+}
+
+// withCapabilities adds the helper's capabilities to handshake metadata
+func (h *HandshakeHelper[T]) withCapabilities(metadata map[string]string) map[string]string {
+	if h.capabilities == nil {
+		return metadata
+	}
+	return h.capabilities.AddTo(metadata)
+}
@@ -0,0 +1,155 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+)
+
+// metadataTestChunkFactory creates handshakes carrying metadata
+type metadataTestChunkFactory struct {
+	fragmentTestChunkFactory
+}
+
+func (metadataTestChunkFactory) NewHandshakeChunk(sessionID, serverID string, metadata map[string]string) *fragmentTestChunk {
+	return &fragmentTestChunk{testChunk: testChunk{isHandshake: true}, metadata: metadata}
+}
+
+func TestCapabilities_Metadata(t *testing.T) {
+	capabilities := Capabilities{
+		Version:      2,
+		Features:     []Feature{FeatureResume, FeatureCompression},
+		MaxChunkSize: 4096,
+		Variant:      VariantRFB,
+		AuthToken:    "token-1",
+	}
+
+	metadata := capabilities.AddTo(map[string]string{"traceparent": "00-trace"})
+	expected := map[string]string{
+		"traceparent":           "00-trace",
+		MetadataProtocolVersion: "2",
+		MetadataFeatures:        "compression,resume",
+		MetadataMaxChunkSize:    "4096",
+		MetadataProtocolVariant: "rfb",
+		MetadataAuthToken:       "token-1",
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("AddTo() = %v, expected %v", metadata, expected)
+	}
+
+	parsed := CapabilitiesOf(metadata)
+	if parsed.Version != 2 || parsed.MaxChunkSize != 4096 || parsed.Variant != VariantRFB || parsed.AuthToken != "token-1" {
+		t.Errorf("Unexpected capabilities %+v", parsed)
+	}
+	if !parsed.Has(FeatureCompression) || !parsed.Has(FeatureResume) {
+		t.Errorf("Expected both features, got %v", parsed.Features)
+	}
+}
+
+func TestCapabilitiesOf_Version1(t *testing.T) {
+	for _, metadata := range []map[string]string{
+		nil,
+		{"traceparent": "00-trace"},
+		{MetadataProtocolVersion: "abc", MetadataFeatures: "resume"},
+		{MetadataFeatures: "resume", MetadataProtocolVariant: "rfb"},
+	} {
+		capabilities := CapabilitiesOf(metadata)
+		if capabilities.Version != 1 || len(capabilities.Features) != 0 || capabilities.Variant != "" {
+			t.Errorf("CapabilitiesOf(%v) = %+v, expected a version 1 handshake", metadata, capabilities)
+		}
+	}
+}
+
+func TestNegotiateCapabilities(t *testing.T) {
+	local := Capabilities{
+		Version:      2,
+		Features:     []Feature{FeatureCompression, FeatureResume},
+		MaxChunkSize: 32768,
+		Variant:      VariantRFB,
+	}
+
+	tests := []struct {
+		name     string
+		remote   Capabilities
+		expected Capabilities
+	}{
+		{
+			name:     "same version",
+			remote:   Capabilities{Version: 2, Features: []Feature{FeatureResume, "future"}, MaxChunkSize: 8192, Variant: VariantRFB, AuthToken: "token-1"},
+			expected: Capabilities{Version: 2, Features: []Feature{FeatureResume}, MaxChunkSize: 8192, Variant: VariantRFB, AuthToken: "token-1"},
+		},
+		{
+			name:     "version 1 remote",
+			remote:   Capabilities{Version: 1},
+			expected: Capabilities{Version: 1},
+		},
+		{
+			name:     "newer remote",
+			remote:   Capabilities{Version: 3, Features: []Feature{FeatureCompression}, Variant: VariantRFB},
+			expected: Capabilities{Version: 2, Features: []Feature{FeatureCompression}, Variant: VariantRFB},
+		},
+		{
+			name:     "other variant",
+			remote:   Capabilities{Version: 2, Variant: "rfb-tight", MaxChunkSize: 65536},
+			expected: Capabilities{Version: 2, MaxChunkSize: 32768},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if negotiated := NegotiateCapabilities(local, tt.remote); !reflect.DeepEqual(negotiated, tt.expected) {
+				t.Errorf("NegotiateCapabilities() = %+v, expected %+v", negotiated, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandshakeHelper_Capabilities(t *testing.T) {
+	t.Run("advertised", func(t *testing.T) {
+		queue := &chunkQueue{}
+		helper := NewHandshakeHelper[*fragmentTestChunk](metadataTestChunkFactory{}).
+			WithCapabilities(Capabilities{MaxChunkSize: 4096, Variant: VariantSOL})
+
+		if err := helper.SendHandshakeWithMetadata(queue, "session-1", "server-1", map[string]string{"traceparent": "00-trace"}); err != nil {
+			t.Fatalf("SendHandshakeWithMetadata() error = %v", err)
+		}
+		if err := helper.SendHandshakeAck(queue, "session-1", "server-1"); err != nil {
+			t.Fatalf("SendHandshakeAck() error = %v", err)
+		}
+
+		for _, chunk := range queue.chunks {
+			capabilities := CapabilitiesOf(chunk.metadata)
+			if capabilities.Version != HandshakeVersion || capabilities.MaxChunkSize != 4096 || capabilities.Variant != VariantSOL {
+				t.Errorf("Unexpected capabilities %+v", capabilities)
+			}
+		}
+		if queue.chunks[0].metadata["traceparent"] != "00-trace" {
+			t.Errorf("Expected the handshake metadata to be kept, got %v", queue.chunks[0].metadata)
+		}
+
+		negotiated := helper.Negotiate(queue.chunks[1])
+		if negotiated.Version != HandshakeVersion || negotiated.Variant != VariantSOL {
+			t.Errorf("Unexpected negotiated capabilities %+v", negotiated)
+		}
+	})
+
+	t.Run("factory without metadata", func(t *testing.T) {
+		queue := &chunkQueue{}
+		helper := NewHandshakeHelper[*fragmentTestChunk](fragmentTestChunkFactory{}).
+			WithCapabilities(Capabilities{Variant: VariantSOL})
+
+		if err := helper.SendHandshake(queue, "session-1", "server-1"); err != nil {
+			t.Fatalf("SendHandshake() error = %v", err)
+		}
+		if chunk := queue.chunks[0]; !chunk.isHandshake || chunk.metadata != nil {
+			t.Errorf("Expected a version 1 handshake, got %+v", chunk)
+		}
+	})
+
+	t.Run("without capabilities", func(t *testing.T) {
+		helper := NewHandshakeHelper[*fragmentTestChunk](metadataTestChunkFactory{})
+		handshake := &fragmentTestChunk{metadata: Capabilities{Variant: VariantSOL}.AddTo(nil)}
+		if negotiated := helper.Negotiate(handshake); negotiated.Version != 1 {
+			t.Errorf("Expected version 1, got %+v", negotiated)
+		}
+	})
+}
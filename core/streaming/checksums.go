@@ -0,0 +1,134 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sync"
+)
+
+// ErrChecksumMismatch is returned when the data of a received chunk does not
+// match the checksum it carries, i.e. was corrupted in transit
+var ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+
+// castagnoli is the CRC-32C table, computed in hardware on amd64 and arm64
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksum returns the CRC-32C checksum of chunk data
+func Checksum(data []byte) uint32 {
+	return crc32.Checksum(data, castagnoli)
+}
+
+// ChecksumChunkFactory sets and reads the checksums carried by chunks. The
+// checksums of the protobuf generated types are optional fields, whose
+// presence generic code cannot test.
+type ChecksumChunkFactory[T StreamChunk] interface {
+	SetChecksum(chunk T, checksum uint32)
+	ChecksumOf(chunk T) (checksum uint32, ok bool)
+}
+
+// ChecksummedStream wraps a chunk stream, adding the checksum of their data
+// to the data chunks it sends and verifying the checksums of the chunks it
+// receives.
+//
+// Checksums are only sent once the remote end advertised FeatureChecksums in
+// its handshake. Received chunks are verified whenever they carry one, so
+// that data sent before the remote end learned of the local one's handshake
+// is accepted without checksum.
+type ChecksummedStream[T StreamChunk] struct {
+	stream  ChunkStream[T]
+	factory ChecksumChunkFactory[T] // nil when chunks carry no checksum
+	enabled bool
+	observe func(error)
+
+	mu      sync.Mutex
+	sending bool // Negotiated, false until the remote end advertised checksums
+}
+
+// VerifyChecksums wraps stream so that the chunks it sends carry a checksum
+// once negotiated, and the checksums of those it receives are verified. The
+// factory must implement ChecksumChunkFactory for chunks to carry checksums.
+// Disabled, the stream sends no checksum, as when the local end does not
+// advertise FeatureChecksums.
+func VerifyChecksums[T StreamChunk](stream ChunkStream[T], factory ChunkFactory[T], enabled bool) *ChecksummedStream[T] {
+	checksums, _ := factory.(ChecksumChunkFactory[T])
+	return &ChecksummedStream[T]{stream: stream, factory: checksums, enabled: enabled}
+}
+
+// WithCorruptionObserver sets the function told of every received chunk
+// failing its checksum, e.g. to count corruption events
+func (s *ChecksummedStream[T]) WithCorruptionObserver(observe func(error)) *ChecksummedStream[T] {
+	s.observe = observe
+	return s
+}
+
+// Negotiate starts sending checksums when the handshake of the remote end
+// advertises them, for handshakes received before wrapping the stream.
+// Handshakes received through Receive are negotiated automatically.
+func (s *ChecksummedStream[T]) Negotiate(handshake T) {
+	if !s.enabled || s.factory == nil {
+		return
+	}
+	if !CapabilitiesOf(MetadataOf(handshake)).Has(FeatureChecksums) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sending = true
+}
+
+// Sending returns true once the chunks sent carry checksums
+func (s *ChecksummedStream[T]) Sending() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sending
+}
+
+// Send forwards a chunk, adding the checksum of its data once negotiated
+func (s *ChecksummedStream[T]) Send(chunk T) error {
+	if len(chunk.GetData()) > 0 && !chunk.GetIsHandshake() && s.Sending() {
+		s.factory.SetChecksum(chunk, Checksum(chunk.GetData()))
+	}
+	return s.stream.Send(chunk)
+}
+
+// Receive reads the next chunk, failing with ErrChecksumMismatch, along with
+// a StreamError of code ErrorCodeDataCorrupted, when its data does not match
+// its checksum
+func (s *ChecksummedStream[T]) Receive() (T, error) {
+	var zero T
+	chunk, err := s.stream.Receive()
+	if err != nil {
+		return zero, err
+	}
+	if chunk.GetIsHandshake() {
+		s.Negotiate(chunk)
+	}
+	if s.factory == nil {
+		return chunk, nil
+	}
+
+	expected, ok := s.factory.ChecksumOf(chunk)
+	if !ok {
+		return chunk, nil
+	}
+	if checksum := Checksum(chunk.GetData()); checksum != expected {
+		err := fmt.Errorf("%w: %w", ErrChecksumMismatch, NewStreamError(ErrorCodeDataCorrupted,
+			"chunk of %d bytes has CRC-32C %08x, expected %08x", len(chunk.GetData()), checksum, expected))
+		if s.observe != nil {
+			s.observe(err)
+		}
+		return zero, err
+	}
+	return chunk, nil
+}
+
+// CloseRequest closes the sending side of streams that have one, such as
+// Connect client streams
+func (s *ChecksummedStream[T]) CloseRequest() error {
+	if closer, ok := s.stream.(interface{ CloseRequest() error }); ok {
+		return closer.CloseRequest()
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+type checksumTestChunk struct {
+	testChunk
+	metadata  map[string]string
+	checksum  *uint32
+	errorCode ErrorCode
+}
+
+func (c *checksumTestChunk) GetMetadata() map[string]string { return c.metadata }
+
+type checksumTestChunkFactory struct{}
+
+func (checksumTestChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *checksumTestChunk {
+	return &checksumTestChunk{testChunk: testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}}
+}
+
+func (checksumTestChunkFactory) NewErrorChunk(sessionID, serverID string, err *StreamError) *checksumTestChunk {
+	return &checksumTestChunk{errorCode: err.Code}
+}
+
+func (checksumTestChunkFactory) SetChecksum(chunk *checksumTestChunk, checksum uint32) {
+	chunk.checksum = &checksum
+}
+
+func (checksumTestChunkFactory) ChecksumOf(chunk *checksumTestChunk) (uint32, bool) {
+	if chunk.checksum == nil {
+		return 0, false
+	}
+	return *chunk.checksum, true
+}
+
+// checksumQueue is a stream whose sent chunks are received in order
+type checksumQueue struct {
+	chunks []*checksumTestChunk
+}
+
+func (q *checksumQueue) Send(chunk *checksumTestChunk) error {
+	q.chunks = append(q.chunks, chunk)
+	return nil
+}
+
+func (q *checksumQueue) Receive() (*checksumTestChunk, error) {
+	if len(q.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := q.chunks[0]
+	q.chunks = q.chunks[1:]
+	return chunk, nil
+}
+
+func handshakeWithFeatures(features ...Feature) *checksumTestChunk {
+	return &checksumTestChunk{
+		testChunk: testChunk{isHandshake: true},
+		metadata:  Capabilities{Features: features}.AddTo(nil),
+	}
+}
+
+func TestChecksummedStream_Negotiated(t *testing.T) {
+	queue := &checksumQueue{}
+	sender := VerifyChecksums(queue, checksumTestChunkFactory{}, true)
+	sender.Negotiate(handshakeWithFeatures(FeatureChecksums))
+	if !sender.Sending() {
+		t.Fatal("Expected checksums negotiated")
+	}
+
+	if err := sender.Send(&checksumTestChunk{testChunk: testChunk{data: []byte("framebuffer")}}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := sender.Send(&checksumTestChunk{testChunk: testChunk{closeStream: true}}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if checksum := queue.chunks[0].checksum; checksum == nil || *checksum != Checksum([]byte("framebuffer")) {
+		t.Errorf("Expected the CRC-32C of the data, got %v", checksum)
+	}
+	if queue.chunks[1].checksum != nil {
+		t.Error("Expected no checksum on the close chunk")
+	}
+
+	receiver := VerifyChecksums(queue, checksumTestChunkFactory{}, true)
+	chunk, err := receiver.Receive()
+	if err != nil || string(chunk.data) != "framebuffer" {
+		t.Fatalf("Expected the data chunk, got %v (%v)", chunk, err)
+	}
+}
+
+func TestChecksummedStream_NotNegotiated(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		handshake *checksumTestChunk
+	}{
+		{"remote without checksums", true, handshakeWithFeatures(FeatureCompression)},
+		{"version 1 remote", true, &checksumTestChunk{testChunk: testChunk{isHandshake: true}}},
+		{"disabled locally", false, handshakeWithFeatures(FeatureChecksums)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &checksumQueue{}
+			stream := VerifyChecksums(queue, checksumTestChunkFactory{}, tt.enabled)
+			stream.Negotiate(tt.handshake)
+
+			if err := stream.Send(&checksumTestChunk{testChunk: testChunk{data: []byte("data")}}); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+			if queue.chunks[0].checksum != nil {
+				t.Error("Expected no checksum")
+			}
+		})
+	}
+}
+
+func TestChecksummedStream_NegotiatesReceivedHandshake(t *testing.T) {
+	queue := &checksumQueue{chunks: []*checksumTestChunk{handshakeWithFeatures(FeatureChecksums)}}
+	stream := VerifyChecksums(queue, checksumTestChunkFactory{}, true)
+
+	if _, err := stream.Receive(); err != nil {
+		t.Fatalf("Receive failed: %v", err)
+	}
+	if !stream.Sending() {
+		t.Error("Expected checksums negotiated from the received handshake")
+	}
+}
+
+func TestChecksummedStream_Corruption(t *testing.T) {
+	checksum := Checksum([]byte("framebuffer"))
+	queue := &checksumQueue{chunks: []*checksumTestChunk{
+		{testChunk: testChunk{data: []byte("framebuffeR")}, checksum: &checksum},
+	}}
+
+	var observed []error
+	stream := VerifyChecksums(queue, checksumTestChunkFactory{}, true).
+		WithCorruptionObserver(func(err error) { observed = append(observed, err) })
+
+	_, err := stream.Receive()
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expected ErrChecksumMismatch, got %v", err)
+	}
+	if streamErr := AsStreamError(err); streamErr == nil || streamErr.Code != ErrorCodeDataCorrupted {
+		t.Errorf("Expected a %s stream error, got %v", ErrorCodeDataCorrupted, err)
+	}
+	if len(observed) != 1 {
+		t.Errorf("Expected the corruption observed once, got %d", len(observed))
+	}
+}
+
+func TestChecksummedStream_InjectedCorruption(t *testing.T) {
+	queue := &checksumQueue{}
+	faulty := InjectFaults(queue, NewFaultInjector(FaultConfig{CorruptProbability: 1}), checksumTestChunkFactory{})
+	sender := VerifyChecksums(faulty, checksumTestChunkFactory{}, true)
+	sender.Negotiate(handshakeWithFeatures(FeatureChecksums))
+
+	data := []byte("framebuffer")
+	if err := sender.Send(&checksumTestChunk{testChunk: testChunk{data: data}}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if string(data) != "framebuffer" {
+		t.Errorf("Expected the sent data untouched, got %q", data)
+	}
+
+	receiver := VerifyChecksums(queue, checksumTestChunkFactory{}, true)
+	if _, err := receiver.Receive(); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected the corruption detected, got %v", err)
+	}
+}
+
+func TestStreamToTCPProxy_ReportsCorruption(t *testing.T) {
+	checksum := Checksum([]byte("keys"))
+	queue := &checksumQueue{chunks: []*checksumTestChunk{
+		{testChunk: testChunk{data: []byte("keyz")}, checksum: &checksum},
+	}}
+	transport := &testTransport{reads: make(chan []byte)}
+	defer close(transport.reads)
+
+	proxy := NewStreamToTCPProxy[*checksumTestChunk]("session-1", "server-1", zerolog.Nop(), checksumTestChunkFactory{})
+	if err := proxy.ProxyFromStream(context.Background(), VerifyChecksums(queue, checksumTestChunkFactory{}, true), transport); err != nil {
+		t.Fatalf("ProxyFromStream() error = %v", err)
+	}
+	if len(transport.writes) != 0 {
+		t.Errorf("Expected no corrupted data to reach the BMC, got %q", transport.writes)
+	}
+	if len(queue.chunks) != 1 || queue.chunks[0].errorCode != ErrorCodeDataCorrupted {
+		t.Errorf("Expected a %s error chunk sent, got %v", ErrorCodeDataCorrupted, queue.chunks)
+	}
+}
@@ -8,7 +8,24 @@
 //   - StreamChunk interface for streaming data
 //   - ChunkFactory interface for creating chunks
 //   - WebSocketToStreamProxy and StreamToWebSocketProxy for bidirectional translation
-//   - HandshakeHelper to manage initial stream handshakes
+//   - HandshakeHelper to manage initial stream handshakes, advertising and
+//     negotiating Capabilities in version 2 handshakes
+//   - StreamError and error chunks to report classified stream failures
+//   - Handshake metadata, e.g. to propagate the trace context of a stream's setup
+//   - SessionRecorder to log an audit summary of each stream when it closes,
+//     and Activity to track when a session last received console input
+//   - FaultInjector and InjectFaults to inject delays, drops, partial writes,
+//     corruptions and disconnects into streams, in tests and staging
+//   - BufferPool to reuse the data buffers and chunks of proxied streams
+//     across sessions
+//   - SplitLargeChunks to split messages over the chunk size negotiated in
+//     the handshake into fragments, and reassemble them
+//   - VerifyChecksums to add the CRC-32C of their data to the chunks sent and
+//     detect the corrupted chunks received, once negotiated
+//   - WithKeepalive to exchange ping chunks, so that agents tell when the
+//     gateway of a stream is gone
+//   - TokenSigner and VerifyStreamToken to authenticate the streams the
+//     gateway opens to agents with a signed token in the handshake
 //
 // Example usage (VNC):
 //
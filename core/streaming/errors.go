@@ -0,0 +1,98 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorCode classifies why a console stream failed, so that clients can show
+// an actionable message instead of a bare disconnect
+type ErrorCode string
+
+const (
+	ErrorCodeAgentUnavailable   ErrorCode = "agent_unavailable"   // No agent reachable for the server
+	ErrorCodeServerNotFound     ErrorCode = "server_not_found"    // The agent does not know the server
+	ErrorCodeFeatureUnsupported ErrorCode = "feature_unsupported" // The BMC has no SOL or VNC endpoint
+	ErrorCodeBMCUnreachable     ErrorCode = "bmc_unreachable"     // The agent could not connect to the BMC
+	ErrorCodeBMCAuthFailed      ErrorCode = "bmc_auth_failed"     // The BMC rejected the agent's credentials
+	ErrorCodeSessionBusy        ErrorCode = "session_busy"        // Another stream holds the BMC's console
+	ErrorCodeSessionLimit       ErrorCode = "session_limit"       // The agent's console session limit is reached
+	ErrorCodeInvalidSettings    ErrorCode = "invalid_settings"    // The BMC cannot apply the requested serial settings
+	ErrorCodeUnauthenticated    ErrorCode = "unauthenticated"     // The agent rejected the gateway's stream token
+	ErrorCodeDataCorrupted      ErrorCode = "data_corrupted"      // A received chunk failed its checksum
+	ErrorCodeMessageTooLarge    ErrorCode = "message_too_large"   // A client message exceeded the size limit
+	ErrorCodeInternal           ErrorCode = "internal"            // Any other failure
+)
+
+// CloseStreamError is the WebSocket close code sent to browsers when a
+// console stream failed with a StreamError. The close reason is the error
+// formatted by CloseReason.
+const CloseStreamError = 4000
+
+// maxCloseReasonLength is the longest WebSocket close reason: control frame
+// payloads are limited to 125 bytes, including the 2-byte close code
+const maxCloseReasonLength = 123
+
+// StreamError is a structured console stream failure. Agents send it in an
+// error chunk before closing the stream and the gateway forwards it to the
+// client.
+type StreamError struct {
+	Code    ErrorCode
+	Message string
+}
+
+// NewStreamError creates a StreamError with a formatted message
+func NewStreamError(code ErrorCode, format string, args ...any) *StreamError {
+	return &StreamError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// CloseReason formats the error as a WebSocket close reason, "<code>: <message>",
+// truncating the message to fit a close frame
+func (e *StreamError) CloseReason() string {
+	return CloseReason(e.Error())
+}
+
+// CloseReason truncates reason to fit a WebSocket close frame, without
+// splitting a UTF-8 sequence
+func CloseReason(reason string) string {
+	if len(reason) > maxCloseReasonLength {
+		reason = strings.ToValidUTF8(reason[:maxCloseReasonLength], "")
+	}
+	return reason
+}
+
+// AsStreamError returns the StreamError in err's chain, or nil
+func AsStreamError(err error) *StreamError {
+	var streamErr *StreamError
+	if errors.As(err, &streamErr) {
+		return streamErr
+	}
+	return nil
+}
+
+// ErrorChunk is implemented by chunks that can carry a StreamError
+// Protobuf generated types (VNCDataChunk, ConsoleDataChunk) implement this
+type ErrorChunk interface {
+	GetErrorCode() string
+	GetErrorMessage() string
+}
+
+// ErrorChunkFactory creates error chunks
+type ErrorChunkFactory[T StreamChunk] interface {
+	NewErrorChunk(sessionID, serverID string, err *StreamError) T
+}
+
+// StreamErrorOf returns the error carried by a chunk, or nil for data and
+// control chunks
+func StreamErrorOf(chunk StreamChunk) *StreamError {
+	errorChunk, ok := chunk.(ErrorChunk)
+	if !ok || errorChunk.GetErrorCode() == "" {
+		return nil
+	}
+	return &StreamError{Code: ErrorCode(errorChunk.GetErrorCode()), Message: errorChunk.GetErrorMessage()}
+}
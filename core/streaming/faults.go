@@ -0,0 +1,234 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the error of every failure caused by a FaultInjector,
+// so that tests and logs can tell injected faults from real ones
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultConfig configures the faults injected into chunk streams.
+// Probabilities apply to each data chunk and range from 0 to 1.
+type FaultConfig struct {
+	// DelayProbability delays a chunk by up to MaxDelay
+	DelayProbability float64
+	MaxDelay         time.Duration
+
+	// DropProbability silently drops a chunk
+	DropProbability float64
+
+	// PartialWriteProbability forwards the first part of a chunk's data and
+	// then breaks the stream, like a connection lost mid-write
+	PartialWriteProbability float64
+
+	// DisconnectProbability breaks the stream before a chunk
+	DisconnectProbability float64
+
+	// CorruptProbability flips a bit of a chunk's data, keeping its
+	// checksum, see ChecksummedStream
+	CorruptProbability float64
+
+	// Seed makes the faults reproducible, 0 picks a random seed
+	Seed uint64
+}
+
+// FaultInjector decides which faults to inject into the streams it wraps. It
+// is safe for concurrent use and shared by all the streams of a service.
+type FaultInjector struct {
+	config FaultConfig
+	sleep  func(time.Duration)
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewFaultInjector creates a fault injector
+func NewFaultInjector(config FaultConfig) *FaultInjector {
+	seed := config.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &FaultInjector{
+		config: config,
+		sleep:  time.Sleep,
+		rng:    rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// fault is the fault injected for one chunk
+type fault int
+
+const (
+	faultNone fault = iota
+	faultDrop
+	faultPartialWrite
+	faultDisconnect
+	faultCorrupt
+)
+
+// next draws the delay and fault of a data chunk, the data length bounds
+// the cut of a partial write and the byte flipped by a corruption
+func (f *FaultInjector) next(dataLength int) (delay time.Duration, kind fault, cut int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.config.MaxDelay > 0 && f.rng.Float64() < f.config.DelayProbability {
+		delay = time.Duration(f.rng.Int64N(int64(f.config.MaxDelay)) + 1)
+	}
+
+	switch {
+	case f.rng.Float64() < f.config.DisconnectProbability:
+		kind = faultDisconnect
+	case f.rng.Float64() < f.config.DropProbability:
+		kind = faultDrop
+	case dataLength > 1 && f.rng.Float64() < f.config.PartialWriteProbability:
+		kind = faultPartialWrite
+		cut = 1 + f.rng.IntN(dataLength-1)
+	case f.rng.Float64() < f.config.CorruptProbability:
+		kind = faultCorrupt
+		cut = f.rng.IntN(dataLength)
+	}
+	return delay, kind, cut
+}
+
+// ChunkStream is a bidirectional chunk stream, as seen from either end
+type ChunkStream[T StreamChunk] interface {
+	Send(T) error
+	Receive() (T, error)
+}
+
+// FaultyStream wraps a chunk stream and injects faults into its data chunks.
+// Handshake and close chunks pass through untouched, so that faults hit
+// established streams. Once broken, the stream fails every call.
+type FaultyStream[T StreamChunk] struct {
+	stream   ChunkStream[T]
+	injector *FaultInjector
+	factory  ChunkFactory[T]
+
+	mu     sync.Mutex
+	broken error
+}
+
+// InjectFaults wraps stream so that injector decides the faults of its data
+// chunks. The factory rebuilds the chunks cut by partial writes. A nil
+// injector leaves the stream untouched.
+func InjectFaults[T StreamChunk](stream ChunkStream[T], injector *FaultInjector, factory ChunkFactory[T]) *FaultyStream[T] {
+	return &FaultyStream[T]{stream: stream, injector: injector, factory: factory}
+}
+
+// Send forwards a chunk, unless a fault drops it, cuts it or breaks the
+// stream
+func (s *FaultyStream[T]) Send(chunk T) error {
+	if err := s.brokenErr(); err != nil {
+		return err
+	}
+	if !s.injectable(chunk) {
+		return s.stream.Send(chunk)
+	}
+
+	delay, kind, cut := s.injector.next(len(chunk.GetData()))
+	if delay > 0 {
+		s.injector.sleep(delay)
+	}
+
+	switch kind {
+	case faultDrop:
+		return nil
+	case faultDisconnect:
+		return s.breakStream(fmt.Errorf("%w: stream disconnected", ErrInjectedFault))
+	case faultPartialWrite:
+		if err := s.stream.Send(s.cut(chunk, cut)); err != nil {
+			return err
+		}
+		return s.breakStream(fmt.Errorf("%w: partial write of %d/%d bytes: %w", ErrInjectedFault, cut, len(chunk.GetData()), io.ErrShortWrite))
+	case faultCorrupt:
+		return s.stream.Send(s.corrupt(chunk, cut))
+	}
+	return s.stream.Send(chunk)
+}
+
+// Receive reads the next chunk that a fault does not drop. A partial write
+// delivers the first part of the chunk and breaks the stream.
+func (s *FaultyStream[T]) Receive() (T, error) {
+	for {
+		var zero T
+		if err := s.brokenErr(); err != nil {
+			return zero, err
+		}
+
+		chunk, err := s.stream.Receive()
+		if err != nil || !s.injectable(chunk) {
+			return chunk, err
+		}
+
+		delay, kind, cut := s.injector.next(len(chunk.GetData()))
+		if delay > 0 {
+			s.injector.sleep(delay)
+		}
+
+		switch kind {
+		case faultDrop:
+			continue
+		case faultDisconnect:
+			return zero, s.breakStream(fmt.Errorf("%w: stream disconnected", ErrInjectedFault))
+		case faultPartialWrite:
+			s.breakStream(fmt.Errorf("%w: stream disconnected after a partial write", ErrInjectedFault))
+			return s.cut(chunk, cut), nil
+		case faultCorrupt:
+			return s.corrupt(chunk, cut), nil
+		}
+		return chunk, nil
+	}
+}
+
+// CloseRequest closes the sending side of streams that have one, such as
+// the client side of Connect streams
+func (s *FaultyStream[T]) CloseRequest() error {
+	if closer, ok := s.stream.(interface{ CloseRequest() error }); ok {
+		return closer.CloseRequest()
+	}
+	return nil
+}
+
+func (s *FaultyStream[T]) injectable(chunk T) bool {
+	return s.injector != nil && !chunk.GetIsHandshake() && !chunk.GetCloseStream() && len(chunk.GetData()) > 0
+}
+
+func (s *FaultyStream[T]) cut(chunk T, n int) T {
+	return s.factory.NewChunk(chunk.GetSessionId(), chunk.GetServerId(), chunk.GetData()[:n], false, false)
+}
+
+// corrupt returns a copy of chunk whose data has the lowest bit of byte i
+// flipped, with the checksum of the original data
+func (s *FaultyStream[T]) corrupt(chunk T, i int) T {
+	data := append([]byte(nil), chunk.GetData()...)
+	data[i] ^= 1
+	corrupted := s.factory.NewChunk(chunk.GetSessionId(), chunk.GetServerId(), data, false, false)
+	if checksums, ok := s.factory.(ChecksumChunkFactory[T]); ok {
+		if checksum, ok := checksums.ChecksumOf(chunk); ok {
+			checksums.SetChecksum(corrupted, checksum)
+		}
+	}
+	return corrupted
+}
+
+func (s *FaultyStream[T]) brokenErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.broken
+}
+
+func (s *FaultyStream[T]) breakStream(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.broken == nil {
+		s.broken = err
+	}
+	return s.broken
+}
@@ -0,0 +1,204 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// recordingStream records sent chunks and replays chunks to receive
+type recordingStream struct {
+	sent     []*testChunk
+	received []*testChunk
+}
+
+func (s *recordingStream) Send(chunk *testChunk) error {
+	s.sent = append(s.sent, chunk)
+	return nil
+}
+
+func (s *recordingStream) Receive() (*testChunk, error) {
+	if len(s.received) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.received[0]
+	s.received = s.received[1:]
+	return chunk, nil
+}
+
+func TestFaultyStream_NilInjector(t *testing.T) {
+	inner := &recordingStream{received: []*testChunk{{data: []byte("out")}}}
+	stream := InjectFaults[*testChunk](inner, nil, testChunkFactory{})
+
+	if err := stream.Send(&testChunk{data: []byte("in")}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	chunk, err := stream.Receive()
+	if err != nil || string(chunk.data) != "out" {
+		t.Fatalf("Expected chunk out, got %v (%v)", chunk, err)
+	}
+	if len(inner.sent) != 1 {
+		t.Errorf("Expected 1 chunk sent, got %d", len(inner.sent))
+	}
+}
+
+func TestFaultyStream_Drop(t *testing.T) {
+	inner := &recordingStream{received: []*testChunk{
+		{data: []byte("dropped")},
+		{closeStream: true},
+	}}
+	injector := NewFaultInjector(FaultConfig{DropProbability: 1})
+	stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+	if err := stream.Send(&testChunk{isHandshake: true}); err != nil {
+		t.Fatalf("Send handshake failed: %v", err)
+	}
+	if err := stream.Send(&testChunk{data: []byte("dropped")}); err != nil {
+		t.Fatalf("Expected dropped chunk to be sent without error, got %v", err)
+	}
+	if len(inner.sent) != 1 || !inner.sent[0].isHandshake {
+		t.Errorf("Expected only the handshake to be sent, got %v", inner.sent)
+	}
+
+	chunk, err := stream.Receive()
+	if err != nil || !chunk.closeStream {
+		t.Errorf("Expected the close chunk after the dropped one, got %v (%v)", chunk, err)
+	}
+}
+
+func TestFaultyStream_Disconnect(t *testing.T) {
+	inner := &recordingStream{}
+	injector := NewFaultInjector(FaultConfig{DisconnectProbability: 1})
+	stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+	if err := stream.Send(&testChunk{data: []byte("data")}); !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("Expected ErrInjectedFault, got %v", err)
+	}
+	if err := stream.Send(&testChunk{isHandshake: true}); !errors.Is(err, ErrInjectedFault) {
+		t.Errorf("Expected the broken stream to fail every send, got %v", err)
+	}
+	if _, err := stream.Receive(); !errors.Is(err, ErrInjectedFault) {
+		t.Errorf("Expected the broken stream to fail receives, got %v", err)
+	}
+	if len(inner.sent) != 0 {
+		t.Errorf("Expected nothing sent, got %v", inner.sent)
+	}
+}
+
+func TestFaultyStream_PartialWrite(t *testing.T) {
+	inner := &recordingStream{}
+	injector := NewFaultInjector(FaultConfig{PartialWriteProbability: 1})
+	stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+	err := stream.Send(&testChunk{data: []byte("0123456789")})
+	if !errors.Is(err, ErrInjectedFault) || !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("Expected an injected short write, got %v", err)
+	}
+	if len(inner.sent) != 1 {
+		t.Fatalf("Expected the first part to be sent, got %v", inner.sent)
+	}
+	if n := len(inner.sent[0].data); n == 0 || n >= 10 {
+		t.Errorf("Expected a cut between 1 and 9 bytes, got %d", n)
+	}
+
+	// One-byte chunks cannot be cut
+	inner = &recordingStream{}
+	stream = InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+	if err := stream.Send(&testChunk{data: []byte("x")}); err != nil {
+		t.Errorf("Expected one-byte chunk to be sent, got %v", err)
+	}
+}
+
+func TestFaultyStream_PartialRead(t *testing.T) {
+	inner := &recordingStream{received: []*testChunk{
+		{data: []byte("0123456789")},
+		{data: []byte("never read")},
+	}}
+	injector := NewFaultInjector(FaultConfig{PartialWriteProbability: 1})
+	stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+	chunk, err := stream.Receive()
+	if err != nil {
+		t.Fatalf("Expected the first part of the chunk, got %v", err)
+	}
+	if n := len(chunk.data); n == 0 || n >= 10 {
+		t.Errorf("Expected a cut between 1 and 9 bytes, got %d", n)
+	}
+	if _, err := stream.Receive(); !errors.Is(err, ErrInjectedFault) {
+		t.Errorf("Expected the stream to break after the partial read, got %v", err)
+	}
+}
+
+func TestFaultyStream_Delay(t *testing.T) {
+	inner := &recordingStream{}
+	injector := NewFaultInjector(FaultConfig{DelayProbability: 1, MaxDelay: 10 * time.Millisecond})
+	var delays []time.Duration
+	injector.sleep = func(d time.Duration) { delays = append(delays, d) }
+	stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+	for range 5 {
+		if err := stream.Send(&testChunk{data: []byte("data")}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	if len(delays) != 5 {
+		t.Fatalf("Expected 5 delays, got %d", len(delays))
+	}
+	for _, d := range delays {
+		if d <= 0 || d > 10*time.Millisecond {
+			t.Errorf("Expected a delay up to 10ms, got %s", d)
+		}
+	}
+	if len(inner.sent) != 5 {
+		t.Errorf("Expected delayed chunks to be sent, got %d", len(inner.sent))
+	}
+}
+
+func TestFaultInjector_Seed(t *testing.T) {
+	drops := func() []bool {
+		inner := &recordingStream{}
+		injector := NewFaultInjector(FaultConfig{DropProbability: 0.5, Seed: 42})
+		stream := InjectFaults[*testChunk](inner, injector, testChunkFactory{})
+
+		var dropped []bool
+		for range 20 {
+			before := len(inner.sent)
+			stream.Send(&testChunk{data: []byte("data")})
+			dropped = append(dropped, len(inner.sent) == before)
+		}
+		return dropped
+	}
+
+	first, second := drops(), drops()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same faults with the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestStreamToTCPProxy_InjectedDisconnect(t *testing.T) {
+	stream := &testStream{received: make(chan *testChunk, 1), sent: make(chan *testChunk, 1)}
+	stream.received <- &testChunk{data: []byte("keys")}
+	transport := &testTransport{reads: make(chan []byte)}
+	defer close(transport.reads)
+
+	var summary SessionSummary
+	recorder := NewSessionRecorder(SessionInfo{SessionID: "session-1"}, zerolog.Nop(), func(s SessionSummary) { summary = s })
+
+	injector := NewFaultInjector(FaultConfig{DisconnectProbability: 1})
+	proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), testChunkFactory{}).WithRecorder(recorder)
+	if err := proxy.ProxyFromStream(context.Background(), InjectFaults[*testChunk](stream, injector, testChunkFactory{}), transport); err != nil {
+		t.Fatalf("ProxyFromStream() error = %v", err)
+	}
+	if summary.Reason != DisconnectTransportError || !errors.Is(summary.Err, ErrInjectedFault) {
+		t.Errorf("Summary reason = %s (%v), want %s with an injected fault", summary.Reason, summary.Err, DisconnectTransportError)
+	}
+	if len(transport.writes) != 0 {
+		t.Errorf("Expected no data to reach the BMC, got %q", transport.writes)
+	}
+}
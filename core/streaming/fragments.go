@@ -0,0 +1,177 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// MetadataMaxChunkSize is the handshake metadata key advertising the largest
+// chunk data an end accepts. Ends advertising it also reassemble fragments.
+const MetadataMaxChunkSize = "max-chunk-size"
+
+// MaxMessageSize bounds the size of the messages reassembled from fragments
+const MaxMessageSize = 16 << 20
+
+// ErrFragmentSequence is returned when received fragments don't form a
+// message, e.g. when a fragment is missing
+var ErrFragmentSequence = errors.New("invalid fragment sequence")
+
+// FragmentChunk is implemented by chunks that can carry a fragment of a data
+// message split over several chunks
+// Protobuf generated types (VNCDataChunk) implement this
+type FragmentChunk interface {
+	GetFragment() uint32
+	GetMoreFragments() bool
+}
+
+// FragmentChunkFactory creates chunks carrying a fragment of a message
+type FragmentChunkFactory[T StreamChunk] interface {
+	NewFragmentChunk(sessionID, serverID string, data []byte, fragment uint32, moreFragments bool) T
+}
+
+// AddMaxChunkSize advertises the largest chunk data accepted in handshake
+// metadata and returns it, allocating the map when metadata is nil
+func AddMaxChunkSize(metadata map[string]string, size int) map[string]string {
+	if size <= 0 {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[MetadataMaxChunkSize] = strconv.Itoa(size)
+	return metadata
+}
+
+// MaxChunkSizeOf returns the largest chunk data advertised by handshake
+// metadata, 0 when absent or malformed
+func MaxChunkSizeOf(metadata map[string]string) int {
+	size, err := strconv.Atoi(metadata[MetadataMaxChunkSize])
+	if err != nil || size <= 0 {
+		return 0
+	}
+	return size
+}
+
+// FragmentedStream wraps a chunk stream, splitting the data chunks it sends
+// over the size accepted by the remote end into numbered fragments, and
+// reassembling the fragments it receives. Callers send and receive whole
+// messages.
+//
+// Chunks are only split once the remote end advertised MetadataMaxChunkSize
+// in its handshake, so that ends unaware of fragments never receive them.
+type FragmentedStream[T StreamChunk] struct {
+	stream       ChunkStream[T]
+	factory      ChunkFactory[T]
+	maxChunkSize int
+
+	mu        sync.Mutex
+	sendLimit int // Negotiated chunk size, 0 until the remote end advertised one
+
+	// Fragments received of the current message
+	pending      []byte
+	nextFragment uint32
+}
+
+// SplitLargeChunks wraps stream so that sent data chunks are split to at most
+// maxChunkSize bytes, or the size advertised by the remote end if smaller.
+// The factory must implement FragmentChunkFactory for chunks to be split.
+func SplitLargeChunks[T StreamChunk](stream ChunkStream[T], factory ChunkFactory[T], maxChunkSize int) *FragmentedStream[T] {
+	return &FragmentedStream[T]{stream: stream, factory: factory, maxChunkSize: maxChunkSize}
+}
+
+// Negotiate sets the size sent chunks are split to from the handshake of the
+// remote end, for handshakes received before wrapping the stream. Handshakes
+// received through Receive are negotiated automatically.
+func (s *FragmentedStream[T]) Negotiate(handshake T) {
+	peer := MaxChunkSizeOf(MetadataOf(handshake))
+	if peer <= 0 || s.maxChunkSize <= 0 {
+		return
+	}
+	if _, ok := s.factory.(FragmentChunkFactory[T]); !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendLimit = min(s.maxChunkSize, peer)
+}
+
+// ChunkSize returns the negotiated size sent chunks are split to, 0 when
+// chunks are not split
+func (s *FragmentedStream[T]) ChunkSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sendLimit
+}
+
+// Send forwards a chunk, split into fragments when its data is larger than
+// the negotiated chunk size
+func (s *FragmentedStream[T]) Send(chunk T) error {
+	limit := s.ChunkSize()
+	data := chunk.GetData()
+	if limit <= 0 || len(data) <= limit || chunk.GetIsHandshake() || chunk.GetCloseStream() {
+		return s.stream.Send(chunk)
+	}
+
+	factory := s.factory.(FragmentChunkFactory[T])
+	for fragment := uint32(0); len(data) > 0; fragment++ {
+		n := min(limit, len(data))
+		part := factory.NewFragmentChunk(chunk.GetSessionId(), chunk.GetServerId(), data[:n], fragment, n < len(data))
+		if err := s.stream.Send(part); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// Receive reads the next chunk, reassembling fragmented messages into one
+// data chunk
+func (s *FragmentedStream[T]) Receive() (T, error) {
+	for {
+		var zero T
+		chunk, err := s.stream.Receive()
+		if err != nil {
+			return zero, err
+		}
+		if chunk.GetIsHandshake() {
+			s.Negotiate(chunk)
+		}
+
+		fragment, ok := any(chunk).(FragmentChunk)
+		if !ok || (fragment.GetFragment() == 0 && !fragment.GetMoreFragments()) {
+			if s.nextFragment > 0 {
+				return zero, fmt.Errorf("%w: message interrupted after %d fragments", ErrFragmentSequence, s.nextFragment)
+			}
+			return chunk, nil
+		}
+
+		if fragment.GetFragment() != s.nextFragment {
+			return zero, fmt.Errorf("%w: received fragment %d, expected %d", ErrFragmentSequence, fragment.GetFragment(), s.nextFragment)
+		}
+		if len(s.pending)+len(chunk.GetData()) > MaxMessageSize {
+			return zero, fmt.Errorf("%w: message larger than %d bytes", ErrFragmentSequence, MaxMessageSize)
+		}
+		s.pending = append(s.pending, chunk.GetData()...)
+		s.nextFragment++
+
+		if fragment.GetMoreFragments() {
+			continue
+		}
+
+		data := s.pending
+		s.pending, s.nextFragment = nil, 0
+		return s.factory.NewChunk(chunk.GetSessionId(), chunk.GetServerId(), data, false, false), nil
+	}
+}
+
+// CloseRequest closes the sending side of streams that have one, such as
+// Connect client streams
+func (s *FragmentedStream[T]) CloseRequest() error {
+	if closer, ok := s.stream.(interface{ CloseRequest() error }); ok {
+		return closer.CloseRequest()
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package streaming
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+type fragmentTestChunk struct {
+	testChunk
+	metadata      map[string]string
+	fragment      uint32
+	moreFragments bool
+}
+
+func (c *fragmentTestChunk) GetMetadata() map[string]string { return c.metadata }
+func (c *fragmentTestChunk) GetFragment() uint32            { return c.fragment }
+func (c *fragmentTestChunk) GetMoreFragments() bool         { return c.moreFragments }
+
+type fragmentTestChunkFactory struct{}
+
+func (fragmentTestChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *fragmentTestChunk {
+	return &fragmentTestChunk{testChunk: testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}}
+}
+
+func (fragmentTestChunkFactory) NewFragmentChunk(sessionID, serverID string, data []byte, fragment uint32, moreFragments bool) *fragmentTestChunk {
+	return &fragmentTestChunk{testChunk: testChunk{data: data}, fragment: fragment, moreFragments: moreFragments}
+}
+
+// chunkQueue is a stream whose sent chunks are received in order
+type chunkQueue struct {
+	chunks []*fragmentTestChunk
+}
+
+func (q *chunkQueue) Send(chunk *fragmentTestChunk) error {
+	q.chunks = append(q.chunks, chunk)
+	return nil
+}
+
+func (q *chunkQueue) Receive() (*fragmentTestChunk, error) {
+	if len(q.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := q.chunks[0]
+	q.chunks = q.chunks[1:]
+	return chunk, nil
+}
+
+func handshakeWithChunkSize(size int) *fragmentTestChunk {
+	return &fragmentTestChunk{
+		testChunk: testChunk{isHandshake: true},
+		metadata:  AddMaxChunkSize(nil, size),
+	}
+}
+
+func TestFragmentedStream_SplitsAndReassembles(t *testing.T) {
+	queue := &chunkQueue{}
+	sender := SplitLargeChunks(queue, fragmentTestChunkFactory{}, 4096)
+	sender.Negotiate(handshakeWithChunkSize(1024))
+	if sender.ChunkSize() != 1024 {
+		t.Fatalf("Expected the smaller chunk size of the remote end, got %d", sender.ChunkSize())
+	}
+
+	message := bytes.Repeat([]byte("0123456789"), 500)
+	if err := sender.Send(fragmentTestChunkFactory{}.NewChunk("session-1", "server-1", message, false, false)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(queue.chunks) != 5 {
+		t.Fatalf("Expected 5 fragments, got %d", len(queue.chunks))
+	}
+	for i, chunk := range queue.chunks {
+		if chunk.fragment != uint32(i) || chunk.moreFragments != (i < 4) {
+			t.Errorf("Fragment %d: got index %d, more fragments %v", i, chunk.fragment, chunk.moreFragments)
+		}
+		if len(chunk.data) > 1024 {
+			t.Errorf("Fragment %d: %d bytes over the chunk size", i, len(chunk.data))
+		}
+	}
+
+	receiver := SplitLargeChunks(queue, fragmentTestChunkFactory{}, 4096)
+	chunk, err := receiver.Receive()
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if !bytes.Equal(chunk.GetData(), message) {
+		t.Errorf("Expected the reassembled message of %d bytes, got %d bytes", len(message), len(chunk.GetData()))
+	}
+}
+
+func TestFragmentedStream_Negotiation(t *testing.T) {
+	message := bytes.Repeat([]byte{0xAB}, 3000)
+
+	t.Run("remote end without chunk size", func(t *testing.T) {
+		queue := &chunkQueue{}
+		stream := SplitLargeChunks(queue, fragmentTestChunkFactory{}, 1024)
+		stream.Negotiate(&fragmentTestChunk{testChunk: testChunk{isHandshake: true}})
+
+		stream.Send(fragmentTestChunkFactory{}.NewChunk("session-1", "server-1", message, false, false))
+		if len(queue.chunks) != 1 {
+			t.Errorf("Expected the message sent whole, got %d chunks", len(queue.chunks))
+		}
+	})
+
+	t.Run("factory without fragments", func(t *testing.T) {
+		queue := &chunkQueue{}
+		stream := SplitLargeChunks[*fragmentTestChunk](queue, plainFactory{}, 1024)
+		stream.Negotiate(handshakeWithChunkSize(1024))
+		if stream.ChunkSize() != 0 {
+			t.Errorf("Expected no splitting, got chunk size %d", stream.ChunkSize())
+		}
+	})
+
+	t.Run("handshake received", func(t *testing.T) {
+		queue := &chunkQueue{chunks: []*fragmentTestChunk{handshakeWithChunkSize(2048)}}
+		stream := SplitLargeChunks(queue, fragmentTestChunkFactory{}, 4096)
+
+		chunk, err := stream.Receive()
+		if err != nil || !chunk.GetIsHandshake() {
+			t.Fatalf("Expected the handshake, got %v, %v", chunk, err)
+		}
+		if stream.ChunkSize() != 2048 {
+			t.Errorf("Expected the chunk size of the handshake, got %d", stream.ChunkSize())
+		}
+	})
+}
+
+// plainFactory creates chunks without fragments
+type plainFactory struct{}
+
+func (plainFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *fragmentTestChunk {
+	return &fragmentTestChunk{testChunk: testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}}
+}
+
+func TestFragmentedStream_InvalidSequence(t *testing.T) {
+	factory := fragmentTestChunkFactory{}
+	tests := []struct {
+		name   string
+		chunks []*fragmentTestChunk
+	}{
+		{
+			name: "missing fragment",
+			chunks: []*fragmentTestChunk{
+				factory.NewFragmentChunk("session-1", "server-1", []byte("a"), 0, true),
+				factory.NewFragmentChunk("session-1", "server-1", []byte("c"), 2, false),
+			},
+		},
+		{
+			name: "interrupted message",
+			chunks: []*fragmentTestChunk{
+				factory.NewFragmentChunk("session-1", "server-1", []byte("a"), 0, true),
+				factory.NewChunk("session-1", "server-1", []byte("b"), false, false),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := SplitLargeChunks(&chunkQueue{chunks: tt.chunks}, fragmentTestChunkFactory{}, 1024)
+			if _, err := stream.Receive(); !errors.Is(err, ErrFragmentSequence) {
+				t.Errorf("Expected ErrFragmentSequence, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMaxChunkSizeOf(t *testing.T) {
+	if size := MaxChunkSizeOf(AddMaxChunkSize(nil, 8192)); size != 8192 {
+		t.Errorf("Expected 8192, got %d", size)
+	}
+	for _, value := range []string{"", "abc", "-1"} {
+		if size := MaxChunkSizeOf(map[string]string{MetadataMaxChunkSize: value}); size != 0 {
+			t.Errorf("Expected 0 for %q, got %d", value, size)
+		}
+	}
+	if metadata := AddMaxChunkSize(nil, 0); metadata != nil {
+		t.Errorf("Expected no metadata for an unlimited chunk size, got %v", metadata)
+	}
+}
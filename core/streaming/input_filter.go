@@ -0,0 +1,206 @@
+package streaming
+
+import (
+	"encoding/binary"
+)
+
+// InputFilter filters the data a client sends towards the stream, e.g. to let
+// the client observe a console without controlling it
+type InputFilter interface {
+	// Filter returns the part of a message of the client that reaches the
+	// stream, empty to drop the message. It must not retain data.
+	Filter(data []byte) []byte
+}
+
+// DropInput drops all the data of clients, for read-only serial consoles
+var DropInput InputFilter = dropInput{}
+
+type dropInput struct{}
+
+func (dropInput) Filter([]byte) []byte { return nil }
+
+// RFB client messages, RFC 6143 section 7.5 and its extensions
+const (
+	rfbSetPixelFormat           = 0
+	rfbSetEncodings             = 2
+	rfbFramebufferUpdateRequest = 3
+	rfbKeyEvent                 = 4
+	rfbPointerEvent             = 5
+	rfbClientCutText            = 6
+	rfbEnableContinuousUpdates  = 150
+	rfbClientFence              = 248
+	rfbXVP                      = 250
+	rfbSetDesktopSize           = 251
+	rfbQEMU                     = 255
+)
+
+// States of the RFB client stream
+const (
+	rfbStateVersion = iota
+	rfbStateSecurity
+	rfbStateClientInit
+	rfbStateMessages
+	rfbStateBlocked
+	rfbStatePassThrough
+)
+
+// NewRFBViewOnlyFilter returns a filter of the RFB data of a VNC client,
+// dropping its keyboard, pointer, clipboard, desktop size and power messages.
+// The handshake and the messages requesting framebuffer updates reach the
+// stream, so the client keeps receiving the display. Unknown messages make
+// the filter drop the rest of the client's data, as their length is unknown.
+//
+// The filter expects the security type None, which agents offer clients.
+func NewRFBViewOnlyFilter() InputFilter {
+	return &rfbInputFilter{viewOnly: true}
+}
+
+// NewRFBKeymapFilter returns a filter of the RFB data of a VNC client,
+// translating the keysyms of its key events with keymap. Unknown messages
+// make the filter pass the rest of the client's data through untranslated.
+func NewRFBKeymapFilter(keymap Keymap) InputFilter {
+	return &rfbInputFilter{keymap: keymap}
+}
+
+// rfbInputFilter follows the messages of an RFB client across WebSocket
+// messages. Only the first bytes of a message, telling its length, are
+// buffered, and whole key events when translating them.
+type rfbInputFilter struct {
+	viewOnly bool   // Drop control messages, and the data after unknown ones
+	keymap   Keymap // Translates key events, when not nil
+
+	state     int
+	header    []byte // Bytes of the current message, until its length is known
+	remaining int    // Bytes of the current message still to come
+	pass      bool   // Whether the current message reaches the stream
+}
+
+func (f *rfbInputFilter) Filter(data []byte) []byte {
+	var out []byte
+	for len(data) > 0 {
+		if f.remaining > 0 {
+			n := min(f.remaining, len(data))
+			if f.pass {
+				out = append(out, data[:n]...)
+			}
+			data = data[n:]
+			f.remaining -= n
+			continue
+		}
+		if f.state == rfbStateBlocked {
+			break
+		}
+		if f.state == rfbStatePassThrough {
+			return append(out, data...)
+		}
+
+		f.header = append(f.header, data[0])
+		data = data[1:]
+		length, control, ok := f.measure()
+		if !ok && !f.viewOnly {
+			// Pass the unknown message and the rest through
+			f.state = rfbStatePassThrough
+			out = append(out, f.header...)
+			f.header = f.header[:0]
+			continue
+		}
+		if !ok {
+			f.state = rfbStateBlocked
+			f.header = f.header[:0]
+			break
+		}
+		if length == 0 {
+			continue // Length not known yet
+		}
+		pass := !(control && f.viewOnly)
+		if pass {
+			out = append(out, f.header...)
+		}
+		f.remaining = length - len(f.header)
+		f.pass = pass
+		f.header = f.header[:0]
+	}
+	return out
+}
+
+// measure returns the length of the current message from its first bytes, 0
+// when more bytes are needed, and whether it controls the console. It is not
+// ok for messages the filter does not know. Key events are translated once
+// complete.
+func (f *rfbInputFilter) measure() (length int, control, ok bool) {
+	header := f.header
+	switch f.state {
+	case rfbStateVersion:
+		// "RFB 003.008\n"
+		if len(header) < 12 {
+			return 0, false, true
+		}
+		if string(header[:4]) != "RFB " {
+			return 0, true, false
+		}
+		if minor := string(header[8:11]); minor >= "007" {
+			f.state = rfbStateSecurity
+		} else {
+			f.state = rfbStateClientInit
+		}
+		return 12, false, true
+	case rfbStateSecurity:
+		f.state = rfbStateClientInit
+		return 1, false, true
+	case rfbStateClientInit:
+		f.state = rfbStateMessages
+		return 1, false, true
+	}
+
+	switch header[0] {
+	case rfbSetPixelFormat:
+		return 20, false, true
+	case rfbSetEncodings:
+		if len(header) < 4 {
+			return 0, false, true
+		}
+		return 4 + 4*int(binary.BigEndian.Uint16(header[2:4])), false, true
+	case rfbFramebufferUpdateRequest, rfbEnableContinuousUpdates:
+		return 10, false, true
+	case rfbClientFence:
+		if len(header) < 9 {
+			return 0, false, true
+		}
+		return 9 + int(header[8]), false, true
+	case rfbKeyEvent:
+		if f.keymap == nil {
+			return 8, true, true
+		}
+		if len(header) < 8 {
+			return 0, true, true
+		}
+		if keysym, ok := f.keymap[binary.BigEndian.Uint32(header[4:8])]; ok {
+			binary.BigEndian.PutUint32(header[4:8], keysym)
+		}
+		return 8, true, true
+	case rfbPointerEvent:
+		return 6, true, true
+	case rfbClientCutText:
+		if len(header) < 8 {
+			return 0, true, true
+		}
+		// Extended clipboard messages have negative lengths
+		textLength := int64(int32(binary.BigEndian.Uint32(header[4:8])))
+		return 8 + int(max(textLength, -textLength)), true, true
+	case rfbXVP:
+		return 4, true, true
+	case rfbSetDesktopSize:
+		if len(header) < 8 {
+			return 0, true, true
+		}
+		return 8 + 16*int(header[6]), true, true
+	case rfbQEMU:
+		if len(header) < 2 {
+			return 0, true, true
+		}
+		if header[1] == 0 { // Extended key event
+			return 12, true, true
+		}
+	}
+	return 0, true, false
+}
@@ -0,0 +1,121 @@
+package streaming
+
+import (
+	"bytes"
+	"testing"
+)
+
+// rfbClientHandshake is the handshake of an RFB 3.8 client choosing the
+// security type None and a shared session
+var rfbClientHandshake = []byte("RFB 003.008\n\x01\x01")
+
+var (
+	rfbUpdateRequest = []byte{3, 1, 0, 0, 0, 0, 4, 0, 3, 0}
+	rfbEncodings     = []byte{2, 0, 0, 2, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0x21}
+	rfbKey           = []byte{4, 1, 0, 0, 0, 0, 0xff, 0x0d}
+	rfbPointer       = []byte{5, 1, 0, 10, 0, 20}
+	rfbCutText       = []byte{6, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c'}
+)
+
+func concat(messages ...[]byte) []byte {
+	return bytes.Join(messages, nil)
+}
+
+func TestDropInput(t *testing.T) {
+	if data := DropInput.Filter([]byte("reboot\r")); len(data) != 0 {
+		t.Errorf("Expected all input dropped, got %q", data)
+	}
+}
+
+func TestRFBViewOnlyFilter(t *testing.T) {
+	filter := NewRFBViewOnlyFilter()
+
+	if data := filter.Filter(rfbClientHandshake); !bytes.Equal(data, rfbClientHandshake) {
+		t.Fatalf("Expected the handshake to pass, got %v", data)
+	}
+
+	data := filter.Filter(concat(rfbEncodings, rfbKey, rfbUpdateRequest, rfbPointer, rfbCutText, rfbUpdateRequest))
+	if want := concat(rfbEncodings, rfbUpdateRequest, rfbUpdateRequest); !bytes.Equal(data, want) {
+		t.Errorf("Expected only the encodings and update requests, got %v", data)
+	}
+}
+
+func TestRFBViewOnlyFilter_SplitMessages(t *testing.T) {
+	filter := NewRFBViewOnlyFilter()
+	stream := concat(rfbClientHandshake, rfbUpdateRequest, rfbKey, rfbCutText, rfbEncodings, rfbPointer)
+
+	// Messages split at every byte reach the stream whole
+	var out []byte
+	for i := range stream {
+		out = append(out, filter.Filter(stream[i:i+1])...)
+	}
+	if want := concat(rfbClientHandshake, rfbUpdateRequest, rfbEncodings); !bytes.Equal(out, want) {
+		t.Errorf("Expected %v, got %v", want, out)
+	}
+}
+
+func TestRFBViewOnlyFilter_RFB33(t *testing.T) {
+	filter := NewRFBViewOnlyFilter()
+
+	// RFB 3.3 clients do not choose the security type
+	handshake := []byte("RFB 003.003\n\x01")
+	data := filter.Filter(concat(handshake, rfbKey, rfbUpdateRequest))
+	if want := concat(handshake, rfbUpdateRequest); !bytes.Equal(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+}
+
+func TestRFBViewOnlyFilter_UnknownMessage(t *testing.T) {
+	filter := NewRFBViewOnlyFilter()
+	filter.Filter(rfbClientHandshake)
+
+	data := filter.Filter(concat(rfbUpdateRequest, []byte{200, 1, 2, 3}, rfbUpdateRequest))
+	if !bytes.Equal(data, rfbUpdateRequest) {
+		t.Errorf("Expected the data after an unknown message dropped, got %v", data)
+	}
+	if data := filter.Filter(rfbUpdateRequest); len(data) != 0 {
+		t.Errorf("Expected the filter to stay blocked, got %v", data)
+	}
+}
+
+func TestRFBViewOnlyFilter_InvalidVersion(t *testing.T) {
+	filter := NewRFBViewOnlyFilter()
+	if data := filter.Filter([]byte("GET / HTTP/1.1\r\n")); len(data) != 0 {
+		t.Errorf("Expected a client without RFB version dropped, got %q", data)
+	}
+}
+
+// keyEvent returns a key press of a keysym
+func keyEvent(keysym uint32) []byte {
+	return []byte{4, 1, 0, 0, byte(keysym >> 24), byte(keysym >> 16), byte(keysym >> 8), byte(keysym)}
+}
+
+func TestRFBKeymapFilter(t *testing.T) {
+	filter := NewRFBKeymapFilter(Keymap{'z': 'y', 'y': 'z'})
+	stream := concat(rfbClientHandshake, keyEvent('z'), rfbPointer, keyEvent('a'), rfbUpdateRequest, keyEvent('y'))
+
+	// Key events split at every byte are translated whole
+	var out []byte
+	for i := range stream {
+		out = append(out, filter.Filter(stream[i:i+1])...)
+	}
+	want := concat(rfbClientHandshake, keyEvent('y'), rfbPointer, keyEvent('a'), rfbUpdateRequest, keyEvent('z'))
+	if !bytes.Equal(out, want) {
+		t.Errorf("Expected %v, got %v", want, out)
+	}
+}
+
+func TestRFBKeymapFilter_UnknownMessage(t *testing.T) {
+	filter := NewRFBKeymapFilter(Keymap{'z': 'y'})
+	filter.Filter(rfbClientHandshake)
+
+	// The data after an unknown message passes through untranslated
+	unknown := []byte{200, 1, 2, 3}
+	data := filter.Filter(concat(keyEvent('z'), unknown, keyEvent('z')))
+	if want := concat(keyEvent('y'), unknown, keyEvent('z')); !bytes.Equal(data, want) {
+		t.Errorf("Expected %v, got %v", want, data)
+	}
+	if data := filter.Filter(keyEvent('z')); !bytes.Equal(data, keyEvent('z')) {
+		t.Errorf("Expected the filter to keep passing data through, got %v", data)
+	}
+}
@@ -0,0 +1,135 @@
+package streaming
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PingChunk is implemented by chunks that can be keepalive pings
+// Protobuf generated types (VNCDataChunk, ConsoleDataChunk) implement this
+type PingChunk interface {
+	GetPing() bool
+}
+
+// PingChunkFactory creates keepalive ping chunks
+type PingChunkFactory[T StreamChunk] interface {
+	NewPingChunk(sessionID, serverID string) T
+}
+
+// KeepaliveStream wraps a chunk stream, exchanging ping chunks with the
+// remote end so that an end can tell when the other one is gone, e.g. after
+// a crash that left no time to close the stream.
+//
+// The end calling Ping sends the pings, once the remote end advertised
+// FeatureKeepalive in its handshake, and tracks when it last received a
+// chunk. The other end answers every ping it receives with a ping. Pings
+// are never returned by Receive. KeepaliveStream serializes its sends, so
+// that pings can be sent while the stream is proxied; it must wrap the
+// stream directly, below the other wrappers.
+type KeepaliveStream[T StreamChunk] struct {
+	stream    ChunkStream[T]
+	factory   PingChunkFactory[T] // nil when chunks cannot be pings
+	sessionID string
+	serverID  string
+
+	sendMu       sync.Mutex
+	negotiated   atomic.Bool
+	pinging      atomic.Bool
+	lastReceived atomic.Int64 // Unix nanoseconds
+}
+
+// WithKeepalive wraps stream so that it answers pings, and can send pings
+// with Ping once negotiated. The factory must implement PingChunkFactory for
+// pings to be sent or answered.
+func WithKeepalive[T StreamChunk](stream ChunkStream[T], factory ChunkFactory[T], sessionID, serverID string) *KeepaliveStream[T] {
+	pings, _ := factory.(PingChunkFactory[T])
+	s := &KeepaliveStream[T]{stream: stream, factory: pings, sessionID: sessionID, serverID: serverID}
+	s.lastReceived.Store(time.Now().UnixNano())
+	return s
+}
+
+// Negotiate enables the pings when the handshake of the remote end
+// advertises FeatureKeepalive, for handshakes received before wrapping the
+// stream. Handshakes received through Receive are negotiated automatically.
+func (s *KeepaliveStream[T]) Negotiate(handshake T) {
+	if s.factory != nil && CapabilitiesOf(MetadataOf(handshake)).Has(FeatureKeepalive) {
+		s.negotiated.Store(true)
+	}
+}
+
+// Negotiated returns true once the remote end advertised FeatureKeepalive
+func (s *KeepaliveStream[T]) Negotiated() bool {
+	return s.negotiated.Load()
+}
+
+// LastReceived returns when a chunk was last received, data or ping, or
+// when the stream was wrapped
+func (s *KeepaliveStream[T]) LastReceived() time.Time {
+	return time.Unix(0, s.lastReceived.Load())
+}
+
+// Ping sends a ping every interval until ctx ends, once negotiated; pings
+// received from then on are not answered. It returns at once when the
+// remote end does not support pings.
+func (s *KeepaliveStream[T]) Ping(ctx context.Context, interval time.Duration) {
+	if !s.Negotiated() || interval <= 0 {
+		return
+	}
+	s.pinging.Store(true)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Send(s.factory.NewPingChunk(s.sessionID, s.serverID)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Send forwards a chunk
+func (s *KeepaliveStream[T]) Send(chunk T) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.stream.Send(chunk)
+}
+
+// Receive reads the next chunk that is not a ping, answering the pings read
+// before it unless this end sends them
+func (s *KeepaliveStream[T]) Receive() (T, error) {
+	for {
+		chunk, err := s.stream.Receive()
+		if err != nil {
+			return chunk, err
+		}
+		s.lastReceived.Store(time.Now().UnixNano())
+
+		if chunk.GetIsHandshake() {
+			s.Negotiate(chunk)
+		}
+		if ping, ok := any(chunk).(PingChunk); !ok || !ping.GetPing() {
+			return chunk, nil
+		}
+		if s.factory != nil && !s.pinging.Load() {
+			if err := s.Send(s.factory.NewPingChunk(s.sessionID, s.serverID)); err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+	}
+}
+
+// CloseRequest closes the sending side of streams that have one, such as
+// Connect client streams
+func (s *KeepaliveStream[T]) CloseRequest() error {
+	if closer, ok := s.stream.(interface{ CloseRequest() error }); ok {
+		return closer.CloseRequest()
+	}
+	return nil
+}
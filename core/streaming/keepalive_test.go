@@ -0,0 +1,167 @@
+package streaming
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+type pingTestChunk struct {
+	testChunk
+	metadata map[string]string
+	ping     bool
+}
+
+func (c *pingTestChunk) GetMetadata() map[string]string { return c.metadata }
+func (c *pingTestChunk) GetPing() bool                  { return c.ping }
+
+type pingTestChunkFactory struct{}
+
+func (pingTestChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *pingTestChunk {
+	return &pingTestChunk{testChunk: testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}}
+}
+
+func (pingTestChunkFactory) NewPingChunk(sessionID, serverID string) *pingTestChunk {
+	return &pingTestChunk{ping: true}
+}
+
+// pingStream receives the given chunks, and records the chunks sent
+type pingStream struct {
+	mu       sync.Mutex
+	received []*pingTestChunk
+	sent     []*pingTestChunk
+}
+
+func (s *pingStream) Send(chunk *pingTestChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, chunk)
+	return nil
+}
+
+func (s *pingStream) Receive() (*pingTestChunk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.received) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.received[0]
+	s.received = s.received[1:]
+	return chunk, nil
+}
+
+func (s *pingStream) sentPings() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pings := 0
+	for _, chunk := range s.sent {
+		if chunk.ping {
+			pings++
+		}
+	}
+	return pings
+}
+
+func keepaliveHandshake(features ...Feature) *pingTestChunk {
+	return &pingTestChunk{
+		testChunk: testChunk{isHandshake: true},
+		metadata:  Capabilities{Features: features}.AddTo(nil),
+	}
+}
+
+func TestKeepaliveStream_AnswersPings(t *testing.T) {
+	stream := &pingStream{received: []*pingTestChunk{
+		{ping: true},
+		{ping: true},
+		{testChunk: testChunk{data: []byte("data")}},
+	}}
+	keepalive := WithKeepalive(stream, pingTestChunkFactory{}, "session-1", "server-1")
+
+	chunk, err := keepalive.Receive()
+	if err != nil || string(chunk.data) != "data" {
+		t.Fatalf("Expected the data chunk, got %v (%v)", chunk, err)
+	}
+	if pings := stream.sentPings(); pings != 2 {
+		t.Errorf("Expected both pings answered, got %d answers", pings)
+	}
+}
+
+func TestKeepaliveStream_Ping(t *testing.T) {
+	stream := &pingStream{}
+	keepalive := WithKeepalive(stream, pingTestChunkFactory{}, "session-1", "server-1")
+	keepalive.Negotiate(keepaliveHandshake(FeatureKeepalive))
+	if !keepalive.Negotiated() {
+		t.Fatal("Expected keepalive negotiated")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		keepalive.Ping(ctx, time.Millisecond)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for stream.sentPings() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	if pings := stream.sentPings(); pings < 2 {
+		t.Fatalf("Expected pings sent, got %d", pings)
+	}
+
+	// The answers to its pings are not answered, but tell that the remote
+	// end is alive
+	sent := stream.sentPings()
+	before := keepalive.LastReceived()
+	time.Sleep(time.Millisecond)
+	stream.received = []*pingTestChunk{{ping: true}}
+	if _, err := keepalive.Receive(); err != io.EOF {
+		t.Fatalf("Expected EOF after the ping, got %v", err)
+	}
+	if pings := stream.sentPings(); pings != sent {
+		t.Errorf("Expected the answer not answered, got %d pings sent after it", pings-sent)
+	}
+	if !keepalive.LastReceived().After(before) {
+		t.Error("Expected the answer to update the last received time")
+	}
+}
+
+func TestKeepaliveStream_NotNegotiated(t *testing.T) {
+	tests := []struct {
+		name      string
+		handshake *pingTestChunk
+	}{
+		{"remote without keepalive", keepaliveHandshake(FeatureChecksums)},
+		{"version 1 remote", &pingTestChunk{testChunk: testChunk{isHandshake: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &pingStream{}
+			keepalive := WithKeepalive(stream, pingTestChunkFactory{}, "session-1", "server-1")
+			keepalive.Negotiate(tt.handshake)
+
+			// Returns at once, without pinging
+			keepalive.Ping(context.Background(), time.Millisecond)
+			if pings := stream.sentPings(); pings != 0 {
+				t.Errorf("Expected no pings, got %d", pings)
+			}
+		})
+	}
+}
+
+func TestKeepaliveStream_NegotiatesReceivedHandshake(t *testing.T) {
+	stream := &pingStream{received: []*pingTestChunk{keepaliveHandshake(FeatureKeepalive)}}
+	keepalive := WithKeepalive(stream, pingTestChunkFactory{}, "session-1", "server-1")
+
+	chunk, err := keepalive.Receive()
+	if err != nil || !chunk.isHandshake {
+		t.Fatalf("Expected the handshake, got %v (%v)", chunk, err)
+	}
+	if !keepalive.Negotiated() {
+		t.Error("Expected keepalive negotiated from the received handshake")
+	}
+}
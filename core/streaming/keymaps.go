@@ -0,0 +1,170 @@
+package streaming
+
+import (
+	"slices"
+	"strings"
+)
+
+// Keymap translates the keysyms of RFB key events, from the characters a
+// viewer typed to the characters of the US keys at the same positions.
+//
+// BMC KVMs turn keysyms into the USB scan codes of the key producing them on
+// a US keyboard. A host using another layout reads those scan codes with its
+// own: typing "z" on a German keyboard sends the US Z key, which the host
+// reads as "y". Translating "z" to "y", the US key at the position of the
+// German Z key, makes the host read the character typed.
+type Keymap map[uint32]uint32
+
+// Keysyms of the modifiers and dead keys of keymaps, X11 keysymdef.h
+const (
+	keysymAltR            = 0xffea
+	keysymISOLevel3Shift  = 0xfe03
+	keysymModeSwitch      = 0xff7e
+	keysymDeadGrave       = 0xfe50
+	keysymDeadAcute       = 0xfe51
+	keysymDeadCircumflex  = 0xfe52
+	keysymDeadTilde       = 0xfe53
+	keysymDeadDiaeresis   = 0xfe57
+	keysymEuroSign        = 0x20ac
+	keysymUnicodeMappings = 0x01000000
+)
+
+// Combining diacritical marks standing for the dead keys of layouts
+const (
+	deadGrave      = "\u0300"
+	deadAcute      = "\u0301"
+	deadCircumflex = "\u0302"
+	deadTilde      = "\u0303"
+	deadDiaeresis  = "\u0308"
+)
+
+// keyboardLayout describes the characters of the keys of a layout, by rows
+// from the number row to the bottom row and by level. Keys at the same index
+// of a row are at the same position on every layout. Spaces mark keys
+// without a character at a level, and combining diacritical marks stand for
+// dead keys.
+type keyboardLayout struct {
+	base  [4]string
+	shift [4]string
+	altGr [4]string
+}
+
+// usLayout is the layout BMC KVMs assume
+var usLayout = keyboardLayout{
+	base:  [4]string{"`1234567890-=", `qwertyuiop[]\`, "asdfghjkl;'", "zxcvbnm,./"},
+	shift: [4]string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", `ASDFGHJKL:"`, "ZXCVBNM<>?"},
+}
+
+// keyboardLayouts are the layouts keymaps translate from, by name
+var keyboardLayouts = map[string]keyboardLayout{
+	// German (T1)
+	"de": {
+		base:  [4]string{deadCircumflex + "1234567890ß" + deadAcute, "qwertzuiopü+#", "asdfghjklöä", "yxcvbnm,.-"},
+		shift: [4]string{"°!\"§$%&/()=?" + deadGrave, "QWERTZUIOPÜ*'", "ASDFGHJKLÖÄ", "YXCVBNM;:_"},
+		altGr: [4]string{"  ²³   {[]}\\ ", "@ €        ~ ", "", "      µ   "},
+	},
+	// French (AZERTY)
+	"fr": {
+		base:  [4]string{"²&é\"'(-è_çà)=", "azertyuiop" + deadCircumflex + "$*", "qsdfghjklmù", "wxcvbn,;:!"},
+		shift: [4]string{" 1234567890°+", "AZERTYUIOP" + deadDiaeresis + "£µ", "QSDFGHJKLM%", "WXCVBN?./§"},
+		altGr: [4]string{"  ~#{[|`\\^@]}", "  €        ¤ ", "", ""},
+	},
+	// British
+	"gb": {
+		base:  [4]string{"`1234567890-=", "qwertyuiop[]#", "asdfghjkl;'", "zxcvbnm,./"},
+		shift: [4]string{"¬!\"£$%^&*()_+", "QWERTYUIOP{}~", "ASDFGHJKL:@", "ZXCVBNM<>?"},
+		altGr: [4]string{"¦   €        ", "", "", ""},
+	},
+	// Spanish
+	"es": {
+		base:  [4]string{"º1234567890'¡", "qwertyuiop" + deadGrave + "+ç", "asdfghjklñ" + deadAcute, "zxcvbnm,.-"},
+		shift: [4]string{"ª!\"·$%&/()=?¿", "QWERTYUIOP" + deadCircumflex + "*Ç", "ASDFGHJKLÑ" + deadDiaeresis, "ZXCVBNM;:_"},
+		altGr: [4]string{"\\|@#~€¬      ", "  €       []}", "          {", ""},
+	},
+	// Italian
+	"it": {
+		base:  [4]string{"\\1234567890'ì", "qwertyuiopè+ù", "asdfghjklòà", "zxcvbnm,.-"},
+		shift: [4]string{"|!\"£$%&/()=?^", "QWERTYUIOPé*§", "ASDFGHJKLç°", "ZXCVBNM;:_"},
+		altGr: [4]string{"", "  €       [] ", "         @#", ""},
+	},
+}
+
+// keymaps are the keymaps of keyboardLayouts, built once
+var keymaps = buildKeymaps()
+
+// KeyboardLayouts returns the names of the layouts keymaps exist for, sorted
+func KeyboardLayouts() []string {
+	names := make([]string, 0, len(keymaps))
+	for name := range keymaps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// KeymapOf returns the keymap translating from a layout, e.g. "de". Layout
+// names are case-insensitive, and "us" translates nothing.
+func KeymapOf(layout string) (Keymap, bool) {
+	keymap, ok := keymaps[strings.ToLower(layout)]
+	return keymap, ok
+}
+
+func buildKeymaps() map[string]Keymap {
+	maps := map[string]Keymap{"us": {}}
+	for name, layout := range keyboardLayouts {
+		maps[name] = layout.keymap()
+	}
+	return maps
+}
+
+// keymap maps the characters of a layout to the US characters of the same
+// key at the same level, and those typed with AltGr to the US character of
+// the key. AltGr becomes the right Alt key, which hosts read as AltGr.
+func (l keyboardLayout) keymap() Keymap {
+	keymap := Keymap{
+		keysymISOLevel3Shift: keysymAltR,
+		keysymModeSwitch:     keysymAltR,
+	}
+	add := func(chars, usChars string) {
+		us := []rune(usChars)
+		for i, char := range []rune(chars) {
+			if char == ' ' || i >= len(us) {
+				continue
+			}
+			keysym, usKeysym := keysymOf(char), keysymOf(us[i])
+			if _, exists := keymap[keysym]; !exists {
+				keymap[keysym] = usKeysym
+			}
+		}
+	}
+	for row := range l.base {
+		add(l.base[row], usLayout.base[row])
+		add(l.shift[row], usLayout.shift[row])
+		add(l.altGr[row], usLayout.base[row])
+	}
+	return keymap
+}
+
+// keysymOf returns the keysym of a character, or of the dead key a
+// combining diacritical mark stands for
+func keysymOf(char rune) uint32 {
+	switch char {
+	case '\u0300':
+		return keysymDeadGrave
+	case '\u0301':
+		return keysymDeadAcute
+	case '\u0302':
+		return keysymDeadCircumflex
+	case '\u0303':
+		return keysymDeadTilde
+	case '\u0308':
+		return keysymDeadDiaeresis
+	case '€':
+		return keysymEuroSign
+	}
+	// Latin-1 characters are their own keysym
+	if char < 0x100 {
+		return uint32(char)
+	}
+	return keysymUnicodeMappings | uint32(char)
+}
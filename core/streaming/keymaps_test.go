@@ -0,0 +1,59 @@
+package streaming
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestKeyboardLayouts_RowLengths(t *testing.T) {
+	for name, layout := range keyboardLayouts {
+		for row := range usLayout.base {
+			length := utf8.RuneCountInString(usLayout.base[row])
+			for level, chars := range map[string]string{"base": layout.base[row], "shift": layout.shift[row], "altGr": layout.altGr[row]} {
+				if n := utf8.RuneCountInString(chars); n > length || (level != "altGr" && n != length) {
+					t.Errorf("Layout %s row %d %s has %d keys, want %d", name, row, level, n, length)
+				}
+			}
+		}
+	}
+}
+
+func TestKeymapOf(t *testing.T) {
+	tests := []struct {
+		layout string
+		keysym uint32
+		want   uint32
+	}{
+		{"de", 'z', 'y'},
+		{"de", 'Y', 'Z'},
+		{"de", '@', 'q'},  // AltGr+Q
+		{"de", 0xf6, ';'}, // ö
+		{"de", keysymDeadCircumflex, '`'},
+		{"de", keysymISOLevel3Shift, keysymAltR},
+		{"DE", 'a', 'a'},
+		{"fr", 'a', 'q'},
+		{"fr", '1', '!'},
+		{"fr", keysymEuroSign, 'e'},
+		{"gb", '"', '@'},
+		{"gb", '@', '"'},
+		{"us", 'z', 'z'},
+	}
+
+	for _, tt := range tests {
+		keymap, ok := KeymapOf(tt.layout)
+		if !ok {
+			t.Fatalf("KeymapOf(%q) not found", tt.layout)
+		}
+		got, ok := keymap[tt.keysym]
+		if !ok {
+			got = tt.keysym
+		}
+		if got != tt.want {
+			t.Errorf("KeymapOf(%q)[%#x] = %#x, want %#x", tt.layout, tt.keysym, got, tt.want)
+		}
+	}
+
+	if _, ok := KeymapOf("dvorak"); ok {
+		t.Error("Expected no keymap for an unknown layout")
+	}
+}
@@ -0,0 +1,52 @@
+package streaming
+
+// MetadataChunk is implemented by chunks that can carry handshake metadata,
+// such as the trace context of the stream's setup
+// Protobuf generated types (VNCDataChunk, ConsoleDataChunk) implement this
+type MetadataChunk interface {
+	GetMetadata() map[string]string
+}
+
+// MetadataChunkFactory creates handshake chunks carrying metadata
+type MetadataChunkFactory[T StreamChunk] interface {
+	NewHandshakeChunk(sessionID, serverID string, metadata map[string]string) T
+}
+
+// SendHandshakeWithMetadata sends a handshake chunk carrying metadata, and
+// the helper's capabilities if any. When the factory cannot create such
+// chunks, a plain handshake is sent.
+func (h *HandshakeHelper[T]) SendHandshakeWithMetadata(
+	stream interface{ Send(T) error },
+	sessionID, serverID string,
+	metadata map[string]string,
+) error {
+	factory, ok := h.factory.(MetadataChunkFactory[T])
+	if !ok {
+		return stream.Send(h.factory.NewChunk(sessionID, serverID, nil, true, false))
+	}
+	return stream.Send(factory.NewHandshakeChunk(sessionID, serverID, h.withCapabilities(metadata)))
+}
+
+// SendHandshakeAckWithMetadata sends a handshake acknowledgment carrying
+// metadata, and the helper's capabilities if any. When the factory cannot
+// create such chunks, a plain acknowledgment is sent.
+func (h *HandshakeHelper[T]) SendHandshakeAckWithMetadata(
+	stream interface{ Send(T) error },
+	sessionID, serverID string,
+	metadata map[string]string,
+) error {
+	factory, ok := h.factory.(MetadataChunkFactory[T])
+	if !ok {
+		return stream.Send(h.factory.NewChunk(sessionID, serverID, nil, true, false))
+	}
+	return stream.Send(factory.NewHandshakeChunk(sessionID, serverID, h.withCapabilities(metadata)))
+}
+
+// MetadataOf returns the metadata carried by a chunk, or nil
+func MetadataOf(chunk StreamChunk) map[string]string {
+	metadataChunk, ok := chunk.(MetadataChunk)
+	if !ok {
+		return nil
+	}
+	return metadataChunk.GetMetadata()
+}
@@ -2,7 +2,10 @@ package streaming
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
@@ -31,6 +34,31 @@ type WebSocketToStreamProxy[T StreamChunk] struct {
 	serverID  string
 	logger    zerolog.Logger
 	factory   ChunkFactory[T]
+	recorder  *SessionRecorder
+	buffers   *BufferPool
+	control   ControlHandler[T]
+	notices   <-chan []byte
+	input     InputFilter
+	binary    bool
+
+	// Largest client message, 0 for no limit
+	maxMessageSize int64
+
+	// writeMu serializes WebSocket writes, from the stream and of notices
+	writeMu sync.Mutex
+}
+
+// ControlHandler translates control messages between a WebSocket client and
+// a stream, for clients sending their data in binary messages and control
+// messages, such as terminal resizes, in text messages
+type ControlHandler[T StreamChunk] interface {
+	// ControlChunk returns the chunk of a text message of the client, which
+	// the chunk must not retain
+	ControlChunk(sessionID, serverID string, message []byte) (T, error)
+
+	// HandshakeMessage returns the text message telling the client of the
+	// stream's handshake acknowledgment, or nil to tell nothing
+	HandshakeMessage(ack T) []byte
 }
 
 // NewWebSocketToStreamProxy creates a new WebSocket to stream proxy
@@ -49,7 +77,71 @@ func NewWebSocketToStreamProxy[T StreamChunk](
 	}
 }
 
+// WithRecorder records the data proxied and reports the stream's summary when
+// the proxy terminates. The WebSocket is the client side.
+func (p *WebSocketToStreamProxy[T]) WithRecorder(recorder *SessionRecorder) *WebSocketToStreamProxy[T] {
+	p.recorder = recorder
+	return p
+}
+
+// WithBufferPool reads WebSocket messages into buffers of pool, reused once
+// sent to the stream, which must not retain sent chunks
+func (p *WebSocketToStreamProxy[T]) WithBufferPool(pool *BufferPool) *WebSocketToStreamProxy[T] {
+	p.buffers = pool
+	return p
+}
+
+// WithControlHandler handles text messages of the WebSocket as control
+// messages of handler, and tells the client of the stream's handshake
+// acknowledgment. Binary messages remain data.
+func (p *WebSocketToStreamProxy[T]) WithControlHandler(handler ControlHandler[T]) *WebSocketToStreamProxy[T] {
+	p.control = handler
+	return p
+}
+
+// WithNotices sends the messages received from notices to the client in text
+// messages, between the stream's data, e.g. to warn that the session expires
+// soon. Clients must tell text messages apart from data.
+func (p *WebSocketToStreamProxy[T]) WithNotices(notices <-chan []byte) *WebSocketToStreamProxy[T] {
+	p.notices = notices
+	return p
+}
+
+// WithInputFilter passes the client's data through filter before it reaches
+// the stream, and drops the client's control messages: clients whose input is
+// filtered do not control the stream.
+func (p *WebSocketToStreamProxy[T]) WithInputFilter(filter InputFilter) *WebSocketToStreamProxy[T] {
+	p.input = filter
+	return p
+}
+
+// WithBinaryOnly passes raw data in binary messages only, for clients of a
+// binary WebSocket subprotocol, e.g. noVNC's "binary": text messages of the
+// client are dropped, and no control messages or notices are sent to it.
+func (p *WebSocketToStreamProxy[T]) WithBinaryOnly() *WebSocketToStreamProxy[T] {
+	p.binary = true
+	return p
+}
+
+// WithMaxMessageSize ends the proxy when the client sends a message larger
+// than size bytes, returning a message_too_large StreamError. Unlike the
+// WebSocket's read limit, the caller can tell the client why it is closed.
+func (p *WebSocketToStreamProxy[T]) WithMaxMessageSize(size int64) *WebSocketToStreamProxy[T] {
+	p.maxMessageSize = size
+	return p
+}
+
+// writeMessage writes a message to the WebSocket
+func (p *WebSocketToStreamProxy[T]) writeMessage(messageType int, data []byte) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	return p.wsConn.WriteMessage(messageType, data)
+}
+
 // ProxyToStream handles bidirectional proxying: WebSocket <-> buf Connect stream
+// It returns when either direction fails or ctx ends, e.g. when the session expires.
+// The returned error is the StreamError reported by the stream's error chunk, if any;
+// closing the WebSocket is left to the caller.
 func (p *WebSocketToStreamProxy[T]) ProxyToStream(
 	ctx context.Context,
 	stream interface {
@@ -58,33 +150,75 @@ func (p *WebSocketToStreamProxy[T]) ProxyToStream(
 		CloseRequest() error
 	},
 ) error {
-	errChan := make(chan error, 2)
+	errChan := make(chan streamEnd, 2)
 
 	// Goroutine: WebSocket -> Stream
 	go func() {
 		defer p.logger.Debug().Msg("WebSocket->Stream goroutine exiting")
 		for {
-			messageType, data, err := p.wsConn.ReadMessage()
+			messageType, data, buf, err := p.buffers.readMessage(p.wsConn, p.maxMessageSize)
+			if errors.Is(err, errMessageTooLarge) {
+				p.logger.Warn().Int64("max_message_size", p.maxMessageSize).Msg("WebSocket message too large")
+				errChan <- streamEnd{DisconnectTransportError, NewStreamError(ErrorCodeMessageTooLarge,
+					"message larger than %d bytes", p.maxMessageSize)}
+				return
+			}
 			if err != nil {
 				p.logger.Error().Err(err).Msg("WebSocket read error - connection may be closed")
-				errChan <- fmt.Errorf("WebSocket read error: %w", err)
+				errChan <- streamEnd{DisconnectClientClosed, fmt.Errorf("WebSocket read error: %w", err)}
 				return
 			}
 
 			// Only handle binary/text messages
 			if messageType != websocket.BinaryMessage && messageType != websocket.TextMessage {
 				p.logger.Debug().Int("message_type", messageType).Msg("Ignoring non-binary/text WebSocket message")
+				p.buffers.putMessage(buf)
+				continue
+			}
+			if p.binary && messageType == websocket.TextMessage {
+				p.logger.Debug().Msg("Ignoring text WebSocket message of binary client")
+				p.buffers.putMessage(buf)
+				continue
+			}
+
+			if p.control != nil && messageType == websocket.TextMessage {
+				if p.input != nil {
+					p.buffers.putMessage(buf)
+					continue
+				}
+				chunk, err := p.control.ControlChunk(p.sessionID, p.serverID, data)
+				p.buffers.putMessage(buf)
+				if err != nil {
+					p.logger.Warn().Err(err).Msg("Ignoring invalid WebSocket control message")
+					continue
+				}
+				if err := stream.Send(chunk); err != nil {
+					p.logger.Error().Err(err).Msg("Stream send error")
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream send error: %w", err)}
+					return
+				}
 				continue
 			}
 
+			if p.input != nil {
+				if data = p.input.Filter(data); len(data) == 0 {
+					p.buffers.putMessage(buf)
+					continue
+				}
+			}
+
 			p.logger.Debug().Int("bytes", len(data)).Msg("Proxying data from WebSocket to stream")
 
 			chunk := p.factory.NewChunk(p.sessionID, p.serverID, data, false, false)
-			if err := stream.Send(chunk); err != nil {
+			err = stream.Send(chunk)
+			releaseChunk(p.buffers, p.factory, chunk)
+			p.buffers.putMessage(buf)
+			if err != nil {
 				p.logger.Error().Err(err).Msg("Stream send error")
-				errChan <- fmt.Errorf("stream send error: %w", err)
+				errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream send error: %w", err)}
 				return
 			}
+			p.recorder.RecordInput(len(data))
 			p.logger.Debug().Msg("Successfully sent data to stream")
 		}
 	}()
@@ -96,20 +230,37 @@ func (p *WebSocketToStreamProxy[T]) ProxyToStream(
 			chunk, err := stream.Receive()
 			if err != nil {
 				p.logger.Error().Err(err).Msg("Stream receive error in WebSocket proxy")
-				errChan <- fmt.Errorf("stream receive error: %w", err)
+				errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream receive error: %w", err)}
+				return
+			}
+
+			// Check for a failure reported by the remote end
+			if streamErr := StreamErrorOf(chunk); streamErr != nil {
+				p.logger.Warn().Str("error_code", string(streamErr.Code)).Str("error", streamErr.Message).Msg("Stream reported an error")
+				errChan <- streamEnd{DisconnectStreamError, streamErr}
 				return
 			}
 
 			// Check for close signal
 			if chunk.GetCloseStream() {
 				p.logger.Debug().Msg("Received close signal from stream")
-				errChan <- fmt.Errorf("stream closed")
+				errChan <- streamEnd{DisconnectServerClosed, nil}
 				return
 			}
 
-			// Skip handshake responses
+			// Skip handshake responses, unless the client is told of them
 			if chunk.GetIsHandshake() {
 				p.logger.Debug().Msg("Received handshake response")
+				if p.control == nil || p.binary {
+					continue
+				}
+				if message := p.control.HandshakeMessage(chunk); message != nil {
+					if err := p.writeMessage(websocket.TextMessage, message); err != nil {
+						p.logger.Error().Err(err).Msg("WebSocket write error - connection may be closed")
+						errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("WebSocket write error: %w", err)}
+						return
+					}
+				}
 				continue
 			}
 
@@ -117,25 +268,57 @@ func (p *WebSocketToStreamProxy[T]) ProxyToStream(
 			if len(data) > 0 {
 				p.logger.Debug().Int("bytes", len(data)).Msg("Proxying data from stream to WebSocket")
 
-				if err := p.wsConn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				if err := p.writeMessage(websocket.BinaryMessage, data); err != nil {
 					p.logger.Error().Err(err).Msg("WebSocket write error - connection may be closed")
-					errChan <- fmt.Errorf("WebSocket write error: %w", err)
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("WebSocket write error: %w", err)}
 					return
 				}
+				p.recorder.RecordOutput(len(data))
 				p.logger.Debug().Msg("Successfully wrote data to WebSocket")
 			}
 		}
 	}()
 
-	// Wait for either direction to fail
-	err := <-errChan
-	p.logger.Debug().Err(err).Msg("Proxy terminated")
+	// Goroutine: Notices -> WebSocket
+	done := make(chan struct{})
+	defer close(done)
+	if p.notices != nil && !p.binary {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case notice, ok := <-p.notices:
+					if !ok {
+						return
+					}
+					if err := p.writeMessage(websocket.TextMessage, notice); err != nil {
+						p.logger.Debug().Err(err).Msg("Failed to send notice to WebSocket")
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Wait for either direction to fail, or for the context to end
+	var end streamEnd
+	select {
+	case end = <-errChan:
+	case <-ctx.Done():
+		end = streamEnd{DisconnectSessionEnded, context.Cause(ctx)}
+	}
+	p.logger.Debug().Err(end.err).Str("reason", string(end.reason)).Msg("Proxy terminated")
+	p.recorder.Close(end.reason, end.err)
 
 	// Send close signal
 	closeChunk := p.factory.NewChunk(p.sessionID, p.serverID, nil, false, true)
 	stream.Send(closeChunk)
 	stream.CloseRequest()
 
+	if streamErr := AsStreamError(end.err); streamErr != nil {
+		return streamErr
+	}
 	return nil
 }
 
@@ -146,6 +329,8 @@ type StreamToWebSocketProxy[T StreamChunk] struct {
 	serverID  string
 	logger    zerolog.Logger
 	factory   ChunkFactory[T]
+	recorder  *SessionRecorder
+	buffers   *BufferPool
 }
 
 // NewStreamToWebSocketProxy creates a new stream to WebSocket proxy
@@ -162,6 +347,20 @@ func NewStreamToWebSocketProxy[T StreamChunk](
 	}
 }
 
+// WithRecorder records the data proxied and reports the stream's summary when
+// the proxy terminates. The stream is the client side.
+func (p *StreamToWebSocketProxy[T]) WithRecorder(recorder *SessionRecorder) *StreamToWebSocketProxy[T] {
+	p.recorder = recorder
+	return p
+}
+
+// WithBufferPool reads WebSocket messages into buffers of pool, reused once
+// sent to the stream, which must not retain sent chunks
+func (p *StreamToWebSocketProxy[T]) WithBufferPool(pool *BufferPool) *StreamToWebSocketProxy[T] {
+	p.buffers = pool
+	return p
+}
+
 // ProxyFromStream handles bidirectional proxying: buf Connect stream <-> WebSocket
 func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 	ctx context.Context,
@@ -171,7 +370,7 @@ func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 	},
 	wsConn *websocket.Conn,
 ) error {
-	errChan := make(chan error, 2)
+	errChan := make(chan streamEnd, 2)
 
 	// Goroutine: Stream -> WebSocket
 	go func() {
@@ -179,14 +378,18 @@ func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 		for {
 			chunk, err := stream.Receive()
 			if err != nil {
-				errChan <- fmt.Errorf("stream receive error: %w", err)
+				if errors.Is(err, io.EOF) {
+					errChan <- streamEnd{DisconnectClientClosed, nil}
+				} else {
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream receive error: %w", err)}
+				}
 				return
 			}
 
 			// Check for close signal
 			if chunk.GetCloseStream() {
 				p.logger.Debug().Msg("Received close signal from stream")
-				errChan <- fmt.Errorf("stream closed")
+				errChan <- streamEnd{DisconnectClientClosed, nil}
 				return
 			}
 
@@ -200,9 +403,10 @@ func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 				// p.logger.Debug().Int("bytes", len(data)).Msg("Forwarding data from stream to WebSocket")
 
 				if err := wsConn.WriteMessage(websocket.BinaryMessage, data); err != nil {
-					errChan <- fmt.Errorf("WebSocket write error: %w", err)
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("WebSocket write error: %w", err)}
 					return
 				}
+				p.recorder.RecordInput(len(data))
 			}
 		}
 	}()
@@ -211,30 +415,36 @@ func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 	go func() {
 		defer p.logger.Debug().Msg("WebSocket->Stream goroutine exiting")
 		for {
-			messageType, data, err := wsConn.ReadMessage()
+			messageType, data, buf, err := p.buffers.readMessage(wsConn, 0)
 			if err != nil {
-				errChan <- fmt.Errorf("WebSocket read error: %w", err)
+				errChan <- streamEnd{DisconnectServerClosed, fmt.Errorf("WebSocket read error: %w", err)}
 				return
 			}
 
 			// Only handle binary/text messages
 			if messageType != websocket.BinaryMessage && messageType != websocket.TextMessage {
+				p.buffers.putMessage(buf)
 				continue
 			}
 
 			// p.logger.Debug().Int("bytes", len(data)).Msg("Forwarding data from WebSocket to stream")
 
 			chunk := p.factory.NewChunk(p.sessionID, p.serverID, data, false, false)
-			if err := stream.Send(chunk); err != nil {
-				errChan <- fmt.Errorf("stream send error: %w", err)
+			err = stream.Send(chunk)
+			releaseChunk(p.buffers, p.factory, chunk)
+			p.buffers.putMessage(buf)
+			if err != nil {
+				errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream send error: %w", err)}
 				return
 			}
+			p.recorder.RecordOutput(len(data))
 		}
 	}()
 
 	// Wait for either direction to fail
-	err := <-errChan
-	p.logger.Debug().Err(err).Msg("Proxy terminated")
+	end := <-errChan
+	p.logger.Debug().Err(end.err).Str("reason", string(end.reason)).Msg("Proxy terminated")
+	p.recorder.Close(end.reason, end.err)
 
 	// Send close signal
 	closeChunk := p.factory.NewChunk(p.sessionID, p.serverID, nil, false, true)
@@ -246,6 +456,8 @@ func (p *StreamToWebSocketProxy[T]) ProxyFromStream(
 // HandshakeHelper helps with initial stream handshakes
 type HandshakeHelper[T StreamChunk] struct {
 	factory ChunkFactory[T]
+	// Advertised in the handshakes sent, nil for version 1 handshakes
+	capabilities *Capabilities
 }
 
 // NewHandshakeHelper creates a handshake helper
@@ -253,11 +465,15 @@ func NewHandshakeHelper[T StreamChunk](factory ChunkFactory[T]) *HandshakeHelper
 	return &HandshakeHelper[T]{factory: factory}
 }
 
-// SendHandshake sends a handshake chunk
+// SendHandshake sends a handshake chunk, advertising the helper's
+// capabilities if any
 func (h *HandshakeHelper[T]) SendHandshake(
 	stream interface{ Send(T) error },
 	sessionID, serverID string,
 ) error {
+	if h.capabilities != nil {
+		return h.SendHandshakeWithMetadata(stream, sessionID, serverID, nil)
+	}
 	chunk := h.factory.NewChunk(sessionID, serverID, nil, true, false)
 	return stream.Send(chunk)
 }
@@ -266,23 +482,42 @@ func (h *HandshakeHelper[T]) SendHandshake(
 func (h *HandshakeHelper[T]) ReceiveHandshake(
 	stream interface{ Receive() (T, error) },
 ) (sessionID, serverID string, err error) {
+	chunk, err := h.ReceiveHandshakeChunk(stream)
+	if err != nil {
+		return "", "", err
+	}
+
+	return chunk.GetSessionId(), chunk.GetServerId(), nil
+}
+
+// ReceiveHandshakeChunk receives and validates a handshake chunk, returning
+// the whole chunk for protocol-specific handshake fields
+func (h *HandshakeHelper[T]) ReceiveHandshakeChunk(
+	stream interface{ Receive() (T, error) },
+) (T, error) {
+	var zero T
+
 	chunk, err := stream.Receive()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to receive handshake: %w", err)
+		return zero, fmt.Errorf("failed to receive handshake: %w", err)
 	}
 
 	if !chunk.GetIsHandshake() {
-		return "", "", fmt.Errorf("expected handshake chunk, got data chunk")
+		return zero, fmt.Errorf("expected handshake chunk, got data chunk")
 	}
 
-	return chunk.GetSessionId(), chunk.GetServerId(), nil
+	return chunk, nil
 }
 
-// SendHandshakeAck sends a handshake acknowledgment
+// SendHandshakeAck sends a handshake acknowledgment, advertising the
+// helper's capabilities if any
 func (h *HandshakeHelper[T]) SendHandshakeAck(
 	stream interface{ Send(T) error },
 	sessionID, serverID string,
 ) error {
+	if h.capabilities != nil {
+		return h.SendHandshakeAckWithMetadata(stream, sessionID, serverID, nil)
+	}
 	ackChunk := h.factory.NewChunk(sessionID, serverID, nil, true, false)
 	return stream.Send(ackChunk)
 }
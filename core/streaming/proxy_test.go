@@ -0,0 +1,141 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+// proxyTestStream is the agent end of a WebSocketToStreamProxy, copying the
+// chunks sent as the proxy may reuse their data
+type proxyTestStream struct {
+	testStream
+}
+
+func (s *proxyTestStream) Send(chunk *testChunk) error {
+	s.sent <- &testChunk{data: bytes.Clone(chunk.data), isHandshake: chunk.isHandshake, closeStream: chunk.closeStream}
+	return nil
+}
+
+func (s *proxyTestStream) CloseRequest() error { return nil }
+
+func TestWebSocketToStreamProxyBinaryOnly(t *testing.T) {
+	stream := &proxyTestStream{testStream{received: make(chan *testChunk, 3), sent: make(chan *testChunk, 3)}}
+	notices := make(chan []byte, 1)
+	notices <- []byte(`{"type":"session_expiring"}`)
+
+	done := make(chan error, 1)
+	upgrader := websocket.Upgrader{Subprotocols: []string{"binary"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		done <- NewWebSocketToStreamProxy[*testChunk](conn, "session-1", "server-1", zerolog.Nop(), testChunkFactory{}).
+			WithNotices(notices).
+			WithBinaryOnly().
+			ProxyToStream(context.Background(), stream)
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"binary"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+	if conn.Subprotocol() != "binary" {
+		t.Fatalf("Expected the binary subprotocol, got %q", conn.Subprotocol())
+	}
+
+	// Text messages of the client are not data
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"resize"}`))
+	conn.WriteMessage(websocket.BinaryMessage, []byte("keys"))
+	select {
+	case chunk := <-stream.sent:
+		if string(chunk.data) != "keys" {
+			t.Errorf("Expected the binary message to reach the stream, got %q", chunk.data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the client's data")
+	}
+
+	// Neither handshake acks nor notices reach the client
+	stream.received <- &testChunk{isHandshake: true}
+	stream.received <- &testChunk{data: []byte("framebuffer")}
+	stream.received <- &testChunk{closeStream: true}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if messageType != websocket.BinaryMessage || string(data) != "framebuffer" {
+		t.Errorf("Expected the framebuffer in a binary message, got %q of type %d", data, messageType)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("ProxyToStream() error = %v", err)
+	}
+	if messageType, data, err := conn.ReadMessage(); err == nil {
+		t.Errorf("Expected the WebSocket to close, got %q of type %d", data, messageType)
+	}
+}
+
+func TestWebSocketToStreamProxyMaxMessageSize(t *testing.T) {
+	for _, pool := range []*BufferPool{nil, NewBufferPool(DefaultChunkSize)} {
+		stream := &proxyTestStream{testStream{received: make(chan *testChunk), sent: make(chan *testChunk, 3)}}
+
+		done := make(chan error, 1)
+		upgrader := websocket.Upgrader{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				done <- err
+				return
+			}
+			defer conn.Close()
+			done <- NewWebSocketToStreamProxy[*testChunk](conn, "session-1", "server-1", zerolog.Nop(), testChunkFactory{}).
+				WithBufferPool(pool).
+				WithMaxMessageSize(8).
+				ProxyToStream(context.Background(), stream)
+		}))
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		if err != nil {
+			t.Fatalf("Dial failed: %v", err)
+		}
+		conn.WriteMessage(websocket.BinaryMessage, []byte("12345678"))
+		conn.WriteMessage(websocket.BinaryMessage, []byte("123456789"))
+
+		select {
+		case chunk := <-stream.sent:
+			if string(chunk.data) != "12345678" {
+				t.Errorf("Expected the message at the limit to reach the stream, got %q", chunk.data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the client's data")
+		}
+
+		select {
+		case err := <-done:
+			streamErr := AsStreamError(err)
+			if streamErr == nil || streamErr.Code != ErrorCodeMessageTooLarge {
+				t.Errorf("Expected a %s stream error, got %v", ErrorCodeMessageTooLarge, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the proxy to end")
+		}
+		conn.Close()
+		server.Close()
+	}
+}
@@ -0,0 +1,88 @@
+package streaming
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Handshake metadata keys carrying the serial settings requested for a SOL
+// session. Absent keys leave the BMC's setting unchanged.
+const (
+	MetadataSOLBaudRate    = "sol-baud-rate"
+	MetadataSOLFlowControl = "sol-flow-control"
+)
+
+// Flow control modes of a SOL serial port
+const (
+	FlowControlNone     = "none"
+	FlowControlHardware = "hardware"
+	FlowControlSoftware = "software"
+)
+
+// SOLBaudRates are the baud rates that can be requested for a SOL session,
+// the bit rates defined by IPMI SOL
+var SOLBaudRates = []int{9600, 19200, 38400, 57600, 115200}
+
+// SOLSettings are the serial settings requested for a SOL session. Zero
+// fields leave the BMC's setting unchanged.
+type SOLSettings struct {
+	BaudRate    int
+	FlowControl string
+}
+
+// IsZero returns true when no setting is requested
+func (s SOLSettings) IsZero() bool {
+	return s.BaudRate == 0 && s.FlowControl == ""
+}
+
+// Validate checks that the requested baud rate and flow control are supported
+func (s SOLSettings) Validate() error {
+	if s.BaudRate != 0 && !slices.Contains(SOLBaudRates, s.BaudRate) {
+		return fmt.Errorf("unsupported baud rate %d, expected one of %v", s.BaudRate, SOLBaudRates)
+	}
+	switch s.FlowControl {
+	case "", FlowControlNone, FlowControlHardware, FlowControlSoftware:
+		return nil
+	default:
+		return fmt.Errorf("unsupported flow control %q, expected %s, %s or %s",
+			s.FlowControl, FlowControlNone, FlowControlHardware, FlowControlSoftware)
+	}
+}
+
+// AddTo adds the requested settings to handshake metadata and returns it,
+// allocating the map when metadata is nil
+func (s SOLSettings) AddTo(metadata map[string]string) map[string]string {
+	if s.IsZero() {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	if s.BaudRate != 0 {
+		metadata[MetadataSOLBaudRate] = strconv.Itoa(s.BaudRate)
+	}
+	if s.FlowControl != "" {
+		metadata[MetadataSOLFlowControl] = s.FlowControl
+	}
+	return metadata
+}
+
+// SOLSettingsOf returns the serial settings carried by handshake metadata.
+// Settings that are malformed or unsupported are rejected.
+func SOLSettingsOf(metadata map[string]string) (SOLSettings, error) {
+	var settings SOLSettings
+	if value, ok := metadata[MetadataSOLBaudRate]; ok {
+		baudRate, err := strconv.Atoi(value)
+		if err != nil {
+			return SOLSettings{}, fmt.Errorf("invalid baud rate %q", value)
+		}
+		settings.BaudRate = baudRate
+	}
+	settings.FlowControl = metadata[MetadataSOLFlowControl]
+
+	if err := settings.Validate(); err != nil {
+		return SOLSettings{}, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,53 @@
+package streaming
+
+import "testing"
+
+func TestSOLSettingsMetadataRoundTrip(t *testing.T) {
+	settings := SOLSettings{BaudRate: 9600, FlowControl: FlowControlHardware}
+
+	metadata := settings.AddTo(map[string]string{"traceparent": "00-abc"})
+	if metadata["traceparent"] != "00-abc" {
+		t.Errorf("Expected existing metadata to be kept, got %v", metadata)
+	}
+
+	parsed, err := SOLSettingsOf(metadata)
+	if err != nil {
+		t.Fatalf("SOLSettingsOf failed: %v", err)
+	}
+	if parsed != settings {
+		t.Errorf("Expected %+v, got %+v", settings, parsed)
+	}
+}
+
+func TestSOLSettingsZero(t *testing.T) {
+	if metadata := (SOLSettings{}).AddTo(nil); metadata != nil {
+		t.Errorf("Expected no metadata for zero settings, got %v", metadata)
+	}
+
+	parsed, err := SOLSettingsOf(nil)
+	if err != nil {
+		t.Fatalf("SOLSettingsOf failed: %v", err)
+	}
+	if !parsed.IsZero() {
+		t.Errorf("Expected zero settings, got %+v", parsed)
+	}
+}
+
+func TestSOLSettingsOfRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"malformed baud rate", map[string]string{MetadataSOLBaudRate: "fast"}},
+		{"unsupported baud rate", map[string]string{MetadataSOLBaudRate: "14400"}},
+		{"unsupported flow control", map[string]string{MetadataSOLFlowControl: "xon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SOLSettingsOf(tt.metadata); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package streaming
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SummaryEvent is the "event" field of stream summary log entries, to filter
+// them in a SIEM
+const SummaryEvent = "console_stream_summary"
+
+// DisconnectReason classifies why a console stream ended
+type DisconnectReason string
+
+const (
+	DisconnectClientClosed   DisconnectReason = "client_closed"   // The browser, CLI or gateway side closed the stream
+	DisconnectServerClosed   DisconnectReason = "server_closed"   // The agent or BMC side closed the stream
+	DisconnectStreamError    DisconnectReason = "stream_error"    // The remote end reported a StreamError
+	DisconnectSessionEnded   DisconnectReason = "session_ended"   // The session expired or an admin disconnected the stream
+	DisconnectTransportError DisconnectReason = "transport_error" // A read or write failed
+)
+
+// SessionInfo identifies a console stream in its summary
+type SessionInfo struct {
+	SessionID  string
+	ServerID   string
+	Protocol   string // "vnc" or "sol"
+	Transport  string // "websocket" or "connect"
+	ClientAddr string // Address of the peer sending console input, e.g. the browser
+	ServerAddr string // Address of the peer sending console output, e.g. the agent
+}
+
+// SessionSummary describes a console stream once it ended. Input is the data
+// sent by the client towards the BMC (keystrokes, pointer events), output the
+// data sent by the BMC towards the client (console text, framebuffer updates).
+type SessionSummary struct {
+	SessionInfo
+	StartedAt time.Time
+	Duration  time.Duration
+	BytesIn   int64
+	BytesOut  int64
+	ChunksIn  int64
+	ChunksOut int64
+	Reason    DisconnectReason
+	Err       error // Why the stream ended, nil when it was closed cleanly
+}
+
+// SessionRecorder counts the data proxied by a console stream and reports its
+// summary when the stream ends. It is safe for concurrent use; a nil recorder
+// records nothing.
+type SessionRecorder struct {
+	info      SessionInfo
+	logger    zerolog.Logger
+	observers []func(SessionSummary)
+	startedAt time.Time
+	activity  *Activity
+
+	bytesIn   atomic.Int64
+	bytesOut  atomic.Int64
+	chunksIn  atomic.Int64
+	chunksOut atomic.Int64
+	closeOnce sync.Once
+}
+
+// NewSessionRecorder starts recording a console stream. The summary is logged
+// to logger and passed to observers, e.g. to update metrics.
+func NewSessionRecorder(info SessionInfo, logger zerolog.Logger, observers ...func(SessionSummary)) *SessionRecorder {
+	return &SessionRecorder{
+		info:      info,
+		logger:    logger,
+		observers: observers,
+		startedAt: time.Now(),
+	}
+}
+
+// WithActivity touches activity on every chunk of console input, e.g. the
+// activity of the stream's session. Console output does not count, as BMCs
+// send it with nobody at the console.
+func (r *SessionRecorder) WithActivity(activity *Activity) *SessionRecorder {
+	if r != nil {
+		r.activity = activity
+	}
+	return r
+}
+
+// RecordInput counts a chunk of console input
+func (r *SessionRecorder) RecordInput(bytes int) {
+	if r == nil {
+		return
+	}
+	r.bytesIn.Add(int64(bytes))
+	r.chunksIn.Add(1)
+	r.activity.Touch()
+}
+
+// RecordOutput counts a chunk of console output
+func (r *SessionRecorder) RecordOutput(bytes int) {
+	if r == nil {
+		return
+	}
+	r.bytesOut.Add(int64(bytes))
+	r.chunksOut.Add(1)
+}
+
+// Close reports the summary of the stream. Only the first call reports.
+func (r *SessionRecorder) Close(reason DisconnectReason, err error) {
+	if r == nil {
+		return
+	}
+	r.closeOnce.Do(func() {
+		summary := SessionSummary{
+			SessionInfo: r.info,
+			StartedAt:   r.startedAt,
+			Duration:    time.Since(r.startedAt),
+			BytesIn:     r.bytesIn.Load(),
+			BytesOut:    r.bytesOut.Load(),
+			ChunksIn:    r.chunksIn.Load(),
+			ChunksOut:   r.chunksOut.Load(),
+			Reason:      reason,
+			Err:         err,
+		}
+		summary.Log(r.logger)
+		for _, observe := range r.observers {
+			observe(summary)
+		}
+	})
+}
+
+// Log writes the summary as a single structured entry with flat, stable field
+// names
+func (s SessionSummary) Log(logger zerolog.Logger) {
+	event := logger.Info()
+	if s.Reason == DisconnectStreamError || s.Reason == DisconnectTransportError {
+		event = logger.Warn()
+	}
+	event.
+		Str("event", SummaryEvent).
+		Str("session_id", s.SessionID).
+		Str("server_id", s.ServerID).
+		Str("protocol", s.Protocol).
+		Str("transport", s.Transport).
+		Str("client_addr", s.ClientAddr).
+		Str("server_addr", s.ServerAddr).
+		Time("started_at", s.StartedAt).
+		Int64("duration_ms", s.Duration.Milliseconds()).
+		Int64("bytes_in", s.BytesIn).
+		Int64("bytes_out", s.BytesOut).
+		Int64("chunks_in", s.ChunksIn).
+		Int64("chunks_out", s.ChunksOut).
+		Str("disconnect_reason", string(s.Reason)).
+		AnErr("disconnect_error", s.Err).
+		Msg("Console stream closed")
+}
+
+// streamEnd is why one direction of a proxy stopped
+type streamEnd struct {
+	reason DisconnectReason
+	err    error
+}
@@ -0,0 +1,167 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type testChunk struct {
+	data        []byte
+	isHandshake bool
+	closeStream bool
+}
+
+func (c *testChunk) GetSessionId() string { return "session-1" }
+func (c *testChunk) GetServerId() string  { return "server-1" }
+func (c *testChunk) GetData() []byte      { return c.data }
+func (c *testChunk) GetIsHandshake() bool { return c.isHandshake }
+func (c *testChunk) GetCloseStream() bool { return c.closeStream }
+
+type testChunkFactory struct{}
+
+func (testChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *testChunk {
+	return &testChunk{data: data, isHandshake: isHandshake, closeStream: closeStream}
+}
+
+// testStream replays chunks, then blocks until the test ends
+type testStream struct {
+	received chan *testChunk
+	sent     chan *testChunk
+}
+
+func (s *testStream) Send(chunk *testChunk) error {
+	s.sent <- chunk
+	return nil
+}
+
+func (s *testStream) Receive() (*testChunk, error) {
+	chunk, ok := <-s.received
+	if !ok {
+		return nil, io.EOF
+	}
+	return chunk, nil
+}
+
+// testTransport returns one read, then EOF once closed
+type testTransport struct {
+	reads  chan []byte
+	writes [][]byte
+}
+
+func (t *testTransport) Read(ctx context.Context) ([]byte, error) {
+	data, ok := <-t.reads
+	if !ok {
+		return nil, io.EOF
+	}
+	return data, nil
+}
+
+func (t *testTransport) Write(ctx context.Context, data []byte) error {
+	t.writes = append(t.writes, data)
+	return nil
+}
+
+func (t *testTransport) Close() error { return nil }
+
+func TestStreamToTCPProxySummary(t *testing.T) {
+	stream := &testStream{received: make(chan *testChunk, 3), sent: make(chan *testChunk, 3)}
+	transport := &testTransport{reads: make(chan []byte, 1)}
+
+	// The BMC sends a framebuffer update, then the client sends input and closes
+	transport.reads <- []byte("framebuffer")
+	go func() {
+		<-stream.sent
+		stream.received <- &testChunk{data: []byte("keys")}
+		stream.received <- &testChunk{closeStream: true}
+	}()
+
+	var logs bytes.Buffer
+	var summary SessionSummary
+	recorder := NewSessionRecorder(SessionInfo{
+		SessionID:  "session-1",
+		ServerID:   "server-1",
+		Protocol:   "vnc",
+		Transport:  "connect",
+		ClientAddr: "10.0.0.1:50000",
+		ServerAddr: "10.0.1.1:5900",
+	}, zerolog.New(&logs), func(s SessionSummary) { summary = s })
+
+	proxy := NewStreamToTCPProxy[*testChunk]("session-1", "server-1", zerolog.Nop(), testChunkFactory{}).WithRecorder(recorder)
+	if err := proxy.ProxyFromStream(context.Background(), stream, transport); err != nil {
+		t.Fatalf("ProxyFromStream() error = %v", err)
+	}
+
+	if summary.Reason != DisconnectClientClosed || summary.Err != nil {
+		t.Errorf("Summary reason = %s (%v), want %s", summary.Reason, summary.Err, DisconnectClientClosed)
+	}
+	if summary.BytesIn != 4 || summary.ChunksIn != 1 || summary.BytesOut != 11 || summary.ChunksOut != 1 {
+		t.Errorf("Summary counts = in %d/%d, out %d/%d, want in 4/1, out 11/1",
+			summary.BytesIn, summary.ChunksIn, summary.BytesOut, summary.ChunksOut)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Summary log %q is not a single JSON entry: %v", logs.String(), err)
+	}
+	want := map[string]any{
+		"event":             SummaryEvent,
+		"session_id":        "session-1",
+		"protocol":          "vnc",
+		"client_addr":       "10.0.0.1:50000",
+		"server_addr":       "10.0.1.1:5900",
+		"bytes_in":          float64(4),
+		"bytes_out":         float64(11),
+		"disconnect_reason": string(DisconnectClientClosed),
+	}
+	for field, value := range want {
+		if entry[field] != value {
+			t.Errorf("Summary log %s = %v, want %v", field, entry[field], value)
+		}
+	}
+}
+
+func TestSessionRecorderReportsOnce(t *testing.T) {
+	calls := 0
+	recorder := NewSessionRecorder(SessionInfo{SessionID: "session-1"}, zerolog.Nop(), func(SessionSummary) { calls++ })
+
+	recorder.Close(DisconnectServerClosed, nil)
+	recorder.Close(DisconnectTransportError, io.ErrUnexpectedEOF)
+	if calls != 1 {
+		t.Errorf("Observer called %d times, want 1", calls)
+	}
+
+	// A nil recorder records nothing
+	var none *SessionRecorder
+	none.RecordInput(1)
+	none.Close(DisconnectClientClosed, nil)
+}
+
+func TestSessionRecorderActivity(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	activity := NewActivity(createdAt)
+	recorder := NewSessionRecorder(SessionInfo{SessionID: "session-1"}, zerolog.Nop()).WithActivity(activity)
+
+	// Console output arrives with nobody at the console
+	recorder.RecordOutput(64)
+	if !activity.Last().Equal(createdAt) {
+		t.Errorf("Expected output not to count as activity, last activity %v", activity.Last())
+	}
+
+	recorder.RecordInput(1)
+	if since := time.Since(activity.Last()); since > time.Minute {
+		t.Errorf("Expected input to touch the activity, last activity %v ago", since)
+	}
+
+	// A nil activity records nothing
+	var none *Activity
+	none.Touch()
+	if !none.Last().IsZero() {
+		t.Errorf("Expected the zero time, got %v", none.Last())
+	}
+}
@@ -33,6 +33,8 @@ type StreamToTCPProxy[T StreamChunk] struct {
 	serverID  string
 	logger    zerolog.Logger
 	factory   ChunkFactory[T]
+	recorder  *SessionRecorder
+	buffers   *BufferPool
 }
 
 // NewStreamToTCPProxy creates a new stream to TCP proxy
@@ -49,7 +51,24 @@ func NewStreamToTCPProxy[T StreamChunk](
 	}
 }
 
+// WithRecorder records the data proxied and reports the stream's summary when
+// the proxy terminates. The stream is the client side.
+func (p *StreamToTCPProxy[T]) WithRecorder(recorder *SessionRecorder) *StreamToTCPProxy[T] {
+	p.recorder = recorder
+	return p
+}
+
+// WithBufferPool reads transports implementing TCPBufferReader into buffers
+// of pool, reused once sent to the stream, which must not retain sent chunks.
+// The chunk size of the pool bounds the data of each chunk.
+func (p *StreamToTCPProxy[T]) WithBufferPool(pool *BufferPool) *StreamToTCPProxy[T] {
+	p.buffers = pool
+	return p
+}
+
 // ProxyFromStream handles bidirectional proxying: buf Connect stream <-> TCP connection
+// It returns when either direction fails or ctx ends, e.g. when the stream's
+// gateway is gone. Nothing more is sent to the stream once ctx ended.
 func (p *StreamToTCPProxy[T]) ProxyFromStream(
 	ctx context.Context,
 	stream interface {
@@ -58,7 +77,7 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 	},
 	transport TCPTransport,
 ) error {
-	errChan := make(chan error, 2)
+	errChan := make(chan streamEnd, 2)
 
 	// Goroutine: Stream -> TCP
 	go func() {
@@ -67,9 +86,9 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 			chunk, err := stream.Receive()
 			if err != nil {
 				if err == io.EOF {
-					errChan <- fmt.Errorf("stream closed by client")
+					errChan <- streamEnd{DisconnectClientClosed, nil}
 				} else {
-					errChan <- fmt.Errorf("stream receive error: %w", err)
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream receive error: %w", err)}
 				}
 				return
 			}
@@ -77,7 +96,7 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 			// Check for close signal
 			if chunk.GetCloseStream() {
 				p.logger.Debug().Msg("Received close signal from stream")
-				errChan <- fmt.Errorf("stream closed")
+				errChan <- streamEnd{DisconnectClientClosed, nil}
 				return
 			}
 
@@ -91,9 +110,10 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 				// p.logger.Debug().Int("bytes", len(data)).Msg("Forwarding data from stream to TCP")
 
 				if err := transport.Write(ctx, data); err != nil {
-					errChan <- fmt.Errorf("TCP write error: %w", err)
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("TCP write error: %w", err)}
 					return
 				}
+				p.recorder.RecordInput(len(data))
 			}
 		}
 	}()
@@ -102,16 +122,16 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 	go func() {
 		defer p.logger.Debug().Msg("TCP->Stream goroutine exiting")
 		for {
-			data, err := transport.Read(ctx)
+			data, buf, err := p.buffers.read(ctx, transport)
 			if err != nil {
 				if err == io.EOF {
-					errChan <- fmt.Errorf("TCP connection closed")
+					errChan <- streamEnd{DisconnectServerClosed, nil}
 				} else if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 					// Timeout is not fatal, continue reading
 					p.logger.Debug().Msg("TCP read timeout, continuing...")
 					continue
 				} else {
-					errChan <- fmt.Errorf("TCP read error: %w", err)
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("TCP read error: %w", err)}
 				}
 				return
 			}
@@ -120,23 +140,49 @@ func (p *StreamToTCPProxy[T]) ProxyFromStream(
 				// p.logger.Debug().Int("bytes", len(data)).Msg("Forwarding data from TCP to stream")
 
 				chunk := p.factory.NewChunk(p.sessionID, p.serverID, data, false, false)
-				if err := stream.Send(chunk); err != nil {
-					errChan <- fmt.Errorf("stream send error: %w", err)
+				err := stream.Send(chunk)
+				releaseChunk(p.buffers, p.factory, chunk)
+				p.buffers.Put(buf)
+				if err != nil {
+					errChan <- streamEnd{DisconnectTransportError, fmt.Errorf("stream send error: %w", err)}
 					return
 				}
+				p.recorder.RecordOutput(len(data))
+			} else {
+				p.buffers.Put(buf)
 			}
 		}
 	}()
 
-	// Wait for either direction to fail
-	err := <-errChan
-	p.logger.Debug().Err(err).Msg("TCP proxy terminated")
+	// Wait for either direction to fail, or for the context to end
+	var end streamEnd
+	select {
+	case end = <-errChan:
+	case <-ctx.Done():
+		end = streamEnd{DisconnectSessionEnded, context.Cause(ctx)}
+	}
+	p.logger.Debug().Err(end.err).Str("reason", string(end.reason)).Msg("TCP proxy terminated")
+	p.recorder.Close(end.reason, end.err)
 
 	// Close the transport
 	if closeErr := transport.Close(); closeErr != nil {
 		p.logger.Debug().Err(closeErr).Msg("Error closing TCP transport")
 	}
 
+	// Sends could block forever on a stream whose remote end is gone
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	// Tell the remote end why the stream failed, e.g. with a corrupted chunk,
+	// the error chunk closes the stream
+	if streamErr := AsStreamError(end.err); streamErr != nil {
+		if factory, ok := p.factory.(ErrorChunkFactory[T]); ok {
+			stream.Send(factory.NewErrorChunk(p.sessionID, p.serverID, streamErr))
+			return nil
+		}
+	}
+
 	// Send close signal to stream
 	closeChunk := p.factory.NewChunk(p.sessionID, p.serverID, nil, false, true)
 	stream.Send(closeChunk)
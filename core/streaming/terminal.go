@@ -0,0 +1,97 @@
+package streaming
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Handshake metadata keys carrying terminal hints. In a client's handshake
+// they describe the client terminal, in the agent's acknowledgment the
+// terminal the server's console is rendered for.
+const (
+	MetadataTerminalType = "terminal-type"
+	MetadataTerminalCols = "terminal-cols"
+	MetadataTerminalRows = "terminal-rows"
+)
+
+// MaxTerminalSize is the largest number of columns or rows of a terminal hint
+const MaxTerminalSize = 1000
+
+// TerminalHints describe a terminal: its TERM type, such as "xterm-256color"
+// or "vt100", and its size in character cells. Zero fields are unknown.
+type TerminalHints struct {
+	Type string
+	Cols int
+	Rows int
+}
+
+// IsZero returns true when nothing is known of the terminal
+func (h TerminalHints) IsZero() bool {
+	return h.Type == "" && h.Cols == 0 && h.Rows == 0
+}
+
+// HasSize returns true when the terminal size is known
+func (h TerminalHints) HasSize() bool {
+	return h.Cols != 0 && h.Rows != 0
+}
+
+// Validate checks that the terminal type is a terminfo name and that the
+// size, when given, has both dimensions within MaxTerminalSize
+func (h TerminalHints) Validate() error {
+	if len(h.Type) > 64 {
+		return fmt.Errorf("terminal type too long")
+	}
+	for _, c := range h.Type {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '+') {
+			return fmt.Errorf("invalid terminal type %q", h.Type)
+		}
+	}
+	if (h.Cols == 0) != (h.Rows == 0) {
+		return fmt.Errorf("terminal size needs both columns and rows, got %dx%d", h.Cols, h.Rows)
+	}
+	if h.Cols < 0 || h.Rows < 0 || h.Cols > MaxTerminalSize || h.Rows > MaxTerminalSize {
+		return fmt.Errorf("unsupported terminal size %dx%d, expected at most %dx%d", h.Cols, h.Rows, MaxTerminalSize, MaxTerminalSize)
+	}
+	return nil
+}
+
+// AddTo adds the known hints to handshake metadata and returns it,
+// allocating the map when metadata is nil
+func (h TerminalHints) AddTo(metadata map[string]string) map[string]string {
+	if h.IsZero() {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	if h.Type != "" {
+		metadata[MetadataTerminalType] = h.Type
+	}
+	if h.HasSize() {
+		metadata[MetadataTerminalCols] = strconv.Itoa(h.Cols)
+		metadata[MetadataTerminalRows] = strconv.Itoa(h.Rows)
+	}
+	return metadata
+}
+
+// TerminalHintsOf returns the terminal hints carried by handshake metadata.
+// Hints that are malformed or out of range are rejected.
+func TerminalHintsOf(metadata map[string]string) (TerminalHints, error) {
+	hints := TerminalHints{Type: metadata[MetadataTerminalType]}
+	for key, size := range map[string]*int{MetadataTerminalCols: &hints.Cols, MetadataTerminalRows: &hints.Rows} {
+		value, ok := metadata[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return TerminalHints{}, fmt.Errorf("invalid %s %q", key, value)
+		}
+		*size = n
+	}
+
+	if err := hints.Validate(); err != nil {
+		return TerminalHints{}, err
+	}
+	return hints, nil
+}
@@ -0,0 +1,55 @@
+package streaming
+
+import "testing"
+
+func TestTerminalHintsMetadataRoundTrip(t *testing.T) {
+	hints := TerminalHints{Type: "xterm-256color", Cols: 132, Rows: 43}
+
+	metadata := hints.AddTo(SOLSettings{BaudRate: 9600}.AddTo(nil))
+	if metadata[MetadataSOLBaudRate] != "9600" {
+		t.Errorf("Expected existing metadata to be kept, got %v", metadata)
+	}
+
+	parsed, err := TerminalHintsOf(metadata)
+	if err != nil {
+		t.Fatalf("TerminalHintsOf failed: %v", err)
+	}
+	if parsed != hints {
+		t.Errorf("Expected %+v, got %+v", hints, parsed)
+	}
+}
+
+func TestTerminalHintsZero(t *testing.T) {
+	if metadata := (TerminalHints{}).AddTo(nil); metadata != nil {
+		t.Errorf("Expected no metadata for zero hints, got %v", metadata)
+	}
+
+	parsed, err := TerminalHintsOf(map[string]string{MetadataTerminalType: "vt100"})
+	if err != nil {
+		t.Fatalf("TerminalHintsOf failed: %v", err)
+	}
+	if parsed.Type != "vt100" || parsed.HasSize() {
+		t.Errorf("Expected a type without size, got %+v", parsed)
+	}
+}
+
+func TestTerminalHintsOfRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"malformed columns", map[string]string{MetadataTerminalCols: "wide", MetadataTerminalRows: "24"}},
+		{"columns without rows", map[string]string{MetadataTerminalCols: "80"}},
+		{"negative size", map[string]string{MetadataTerminalCols: "-80", MetadataTerminalRows: "-24"}},
+		{"oversized", map[string]string{MetadataTerminalCols: "80", MetadataTerminalRows: "100000"}},
+		{"invalid type", map[string]string{MetadataTerminalType: "xterm; rm -rf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TerminalHintsOf(tt.metadata); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package streaming
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StreamTokenTTL is the validity of stream tokens, which authorize opening
+// a stream right after being signed
+const StreamTokenTTL = time.Minute
+
+// ErrInvalidStreamToken is returned when a stream token is missing,
+// malformed, expired, not signed by the expected key or issued for another
+// stream
+var ErrInvalidStreamToken = errors.New("invalid stream token")
+
+// streamTokenHeader is the JOSE header of stream tokens, compact JWS signed
+// with Ed25519
+var streamTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA","typ":"JWT"}`))
+
+// StreamClaims identify the stream a token authorizes
+type StreamClaims struct {
+	SessionID string `json:"sid"`
+	ServerID  string `json:"srv"`
+	Protocol  string `json:"proto"`         // "vnc" or "sol"
+	AgentID   string `json:"aud"`           // Agent the stream is opened to
+	ExpiresAt int64  `json:"exp,omitempty"` // Unix time
+}
+
+// TokenSigner signs the tokens the gateway presents in the handshakes of the
+// streams it opens to agents. Agents verify them with the signer's public
+// key, received when registering.
+type TokenSigner struct {
+	key ed25519.PrivateKey
+}
+
+// NewTokenSigner creates a signer from an Ed25519 seed, or from a random key
+// when seed is empty
+func NewTokenSigner(seed []byte) (*TokenSigner, error) {
+	if len(seed) == 0 {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate stream token key: %w", err)
+		}
+		return &TokenSigner{key: key}, nil
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("stream token key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	return &TokenSigner{key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// PublicKey returns the key verifying the signer's tokens
+func (s *TokenSigner) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign returns a token authorizing the stream of claims, valid for
+// StreamTokenTTL unless claims set an expiry
+func (s *TokenSigner) Sign(claims StreamClaims) (string, error) {
+	if claims.ExpiresAt == 0 {
+		claims.ExpiresAt = time.Now().Add(StreamTokenTTL).Unix()
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode stream token: %w", err)
+	}
+
+	signed := streamTokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature := ed25519.Sign(s.key, []byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifyStreamToken checks that token is signed by key, not expired at now,
+// and issued for the stream of expected, whose expiry is ignored
+func VerifyStreamToken(key ed25519.PublicKey, token string, expected StreamClaims, now time.Time) error {
+	if token == "" {
+		return fmt.Errorf("%w: no token in handshake", ErrInvalidStreamToken)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != streamTokenHeader {
+		return fmt.Errorf("%w: malformed token", ErrInvalidStreamToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return fmt.Errorf("%w: bad signature", ErrInvalidStreamToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: malformed claims", ErrInvalidStreamToken)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	var claims StreamClaims
+	if err := decoder.Decode(&claims); err != nil {
+		return fmt.Errorf("%w: malformed claims", ErrInvalidStreamToken)
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return fmt.Errorf("%w: token expired", ErrInvalidStreamToken)
+	}
+	claims.ExpiresAt = expected.ExpiresAt
+	if claims != expected {
+		return fmt.Errorf("%w: token issued for another stream", ErrInvalidStreamToken)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package streaming
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamToken_SignAndVerify(t *testing.T) {
+	signer, err := NewTokenSigner(nil)
+	if err != nil {
+		t.Fatalf("NewTokenSigner() error = %v", err)
+	}
+
+	claims := StreamClaims{SessionID: "session-1", ServerID: "server-1", Protocol: "vnc", AgentID: "agent-1"}
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if err := VerifyStreamToken(signer.PublicKey(), token, claims, time.Now()); err != nil {
+		t.Errorf("VerifyStreamToken() error = %v", err)
+	}
+}
+
+func TestStreamToken_Rejected(t *testing.T) {
+	signer, _ := NewTokenSigner(nil)
+	other, _ := NewTokenSigner(nil)
+
+	claims := StreamClaims{SessionID: "session-1", ServerID: "server-1", Protocol: "vnc", AgentID: "agent-1"}
+	token, _ := signer.Sign(claims)
+	parts := strings.Split(token, ".")
+
+	now := time.Now()
+	tests := []struct {
+		name     string
+		token    string
+		key      *TokenSigner
+		expected StreamClaims
+		now      time.Time
+	}{
+		{name: "missing token", token: "", key: signer, expected: claims, now: now},
+		{name: "malformed token", token: "abc", key: signer, expected: claims, now: now},
+		{name: "other key", token: token, key: other, expected: claims, now: now},
+		{name: "tampered claims", token: parts[0] + "." + parts[1] + "x." + parts[2], key: signer, expected: claims, now: now},
+		{name: "expired", token: token, key: signer, expected: claims, now: now.Add(2 * StreamTokenTTL)},
+		{
+			name:     "other session",
+			token:    token,
+			key:      signer,
+			expected: StreamClaims{SessionID: "session-2", ServerID: "server-1", Protocol: "vnc", AgentID: "agent-1"},
+			now:      now,
+		},
+		{
+			name:     "other protocol",
+			token:    token,
+			key:      signer,
+			expected: StreamClaims{SessionID: "session-1", ServerID: "server-1", Protocol: "sol", AgentID: "agent-1"},
+			now:      now,
+		},
+		{
+			name:     "other agent",
+			token:    token,
+			key:      signer,
+			expected: StreamClaims{SessionID: "session-1", ServerID: "server-1", Protocol: "vnc", AgentID: "agent-2"},
+			now:      now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyStreamToken(tt.key.PublicKey(), tt.token, tt.expected, tt.now)
+			if !errors.Is(err, ErrInvalidStreamToken) {
+				t.Errorf("Expected ErrInvalidStreamToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewTokenSigner_Seed(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, 32)
+	first, err := NewTokenSigner(seed)
+	if err != nil {
+		t.Fatalf("NewTokenSigner() error = %v", err)
+	}
+	second, _ := NewTokenSigner(seed)
+	if !first.PublicKey().Equal(second.PublicKey()) {
+		t.Error("Expected the same key from the same seed")
+	}
+
+	if _, err := NewTokenSigner([]byte("short")); err == nil {
+		t.Error("Expected an error for a seed of the wrong size")
+	}
+}
@@ -0,0 +1,5 @@
+module core/testing
+
+go 1.25.1
+
+require github.com/gorilla/websocket v1.5.3
@@ -0,0 +1,25 @@
+// Package redfishsim is an in-process Redfish BMC for tests. It serves the
+// resources the agent uses over real HTTP: the service root, one computer
+// system with its reset action and serial console, one manager, and the
+// session service.
+//
+// The simulator keeps the BMC state in memory so tests can drive a flow
+// through a client and then check its effect:
+//
+//	bmc := redfishsim.New(redfishsim.Options{})
+//	defer bmc.Close()
+//
+//	client.PowerOff(ctx, bmc.URL, bmc.Username(), bmc.Password())
+//	if bmc.PowerState() != redfishsim.PowerStateOff { ... }
+//
+// Requests authenticate with basic auth or with the X-Auth-Token of a
+// session, except the service root and session creation, as on real BMCs.
+//
+// The serial console is a WebSocket that echoes what it receives, like a
+// terminal with local echo. Tests can also push output to the connected
+// consoles with WriteConsole. Options.OpenBMC serves the console at the
+// obmc-console WebSocket of OpenBMC BMCs instead.
+//
+// This is a separate module so that its dependencies stay out of the
+// production modules, which only import it from tests.
+package redfishsim
@@ -0,0 +1,327 @@
+package redfishsim
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// odataID is a link to another resource
+type odataID struct {
+	ODataID string `json:"@odata.id"`
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	// The service root and session creation are reachable without
+	// credentials, the rest of the tree requires them
+	mux.HandleFunc("GET /redfish/v1", s.serviceRoot)
+	mux.HandleFunc("GET /redfish/v1/{$}", s.serviceRoot)
+	mux.HandleFunc("POST "+SessionsPath, s.createSessionHandler)
+
+	mux.HandleFunc("GET /redfish/v1/Systems", s.authorized(s.systems))
+	mux.HandleFunc("GET "+SystemPath, s.authorized(s.system))
+	mux.HandleFunc("POST "+ResetActionPath, s.authorized(s.resetHandler))
+	mux.HandleFunc("GET "+SerialConsolePath, s.authorized(s.serialConsole))
+	if s.opts.OpenBMC {
+		mux.HandleFunc("GET "+OBMCConsolePath, s.authorized(s.consoleConnect))
+	} else {
+		mux.HandleFunc("GET "+ConsoleConnectPath, s.authorized(s.consoleConnect))
+	}
+
+	mux.HandleFunc("GET /redfish/v1/Managers", s.authorized(s.managers))
+	mux.HandleFunc("GET "+ManagerPath, s.authorized(s.manager))
+	mux.HandleFunc("GET "+ManagerPath+"/NetworkProtocol", s.authorized(s.networkProtocol))
+
+	mux.HandleFunc("GET /redfish/v1/SessionService", s.authorized(s.sessionService))
+	mux.HandleFunc("GET "+SessionsPath, s.authorized(s.sessionsCollection))
+	mux.HandleFunc("GET "+SessionsPath+"/{id}", s.authorized(s.session))
+	mux.HandleFunc("DELETE "+SessionsPath+"/{id}", s.authorized(s.deleteSessionHandler))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Base.1.0.ResourceMissingAtURI", "Resource not found: "+r.URL.Path)
+	})
+	return mux
+}
+
+func (s *Server) serviceRoot(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":      ServiceRootPath,
+		"Id":             "RootService",
+		"Name":           "Root Service",
+		"RedfishVersion": "1.15.1",
+		"UUID":           "8f4a6a52-3d42-4c2e-9c57-5e5bd1a1b0f1",
+		"Systems":        odataID{"/redfish/v1/Systems"},
+		"Managers":       odataID{"/redfish/v1/Managers"},
+		"SessionService": odataID{"/redfish/v1/SessionService"},
+		"Links": map[string]any{
+			"Sessions": odataID{SessionsPath},
+		},
+	})
+}
+
+func (s *Server) systems(w http.ResponseWriter, r *http.Request) {
+	writeCollection(w, "Computer System Collection", "/redfish/v1/Systems", []string{SystemPath})
+}
+
+func (s *Server) system(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":    SystemPath,
+		"Id":           "1",
+		"Name":         "System",
+		"Manufacturer": s.opts.Manufacturer,
+		"Model":        s.opts.Model,
+		"SerialNumber": "SIM0001",
+		"PowerState":   s.PowerState(),
+		"Status": map[string]string{
+			"State":  "Enabled",
+			"Health": "OK",
+		},
+		"SerialConsole": odataID{SerialConsolePath},
+		"Actions": map[string]any{
+			"#ComputerSystem.Reset": map[string]any{
+				"target":                            ResetActionPath,
+				"ResetType@Redfish.AllowableValues": ResetTypes,
+			},
+		},
+	})
+}
+
+func (s *Server) resetHandler(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ResetType string `json:"ResetType"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.0.MalformedJSON", "Malformed reset request")
+		return
+	}
+	if !s.reset(payload.ResetType) {
+		writeError(w, http.StatusBadRequest, "Base.1.0.ActionParameterValueNotInList", "Unsupported ResetType: "+payload.ResetType)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serialConsole(w http.ResponseWriter, r *http.Request) {
+	resource := map[string]any{
+		"@odata.id":             SerialConsolePath,
+		"Id":                    "SerialConsole",
+		"ServiceEnabled":        !s.opts.DisableSerialConsole,
+		"ConnectTypesSupported": []string{"Oem"},
+		"Actions": map[string]any{
+			"#SerialConsole.Connect": map[string]string{
+				// The connect target is absolute, clients turn it into a
+				// WebSocket URL
+				"target": "http://" + r.Host + ConsoleConnectPath,
+			},
+		},
+	}
+	// OpenBMC has no connect action, its console is obmc-console's
+	if s.opts.OpenBMC {
+		resource["ConnectTypesSupported"] = []string{"IPMI", "SSH"}
+		delete(resource, "Actions")
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) consoleConnect(w http.ResponseWriter, r *http.Request) {
+	if s.opts.DisableSerialConsole {
+		writeError(w, http.StatusServiceUnavailable, "Base.1.0.ServiceDisabled", "Serial console is disabled")
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &console{conn: conn}
+	s.addConsole(c)
+	defer func() {
+		s.removeConsole(c)
+		conn.Close()
+	}()
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.recordConsoleInput(data)
+		if s.opts.OpenBMC {
+			messageType = websocket.BinaryMessage
+		}
+		if err := c.write(messageType, data); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) managers(w http.ResponseWriter, r *http.Request) {
+	writeCollection(w, "Manager Collection", "/redfish/v1/Managers", []string{ManagerPath})
+}
+
+func (s *Server) manager(w http.ResponseWriter, r *http.Request) {
+	maxConsoleSessions := 1
+	if s.opts.DisableSerialConsole {
+		maxConsoleSessions = 0
+	}
+
+	resource := map[string]any{
+		"@odata.id":       ManagerPath,
+		"Id":              "1",
+		"Name":            "Manager",
+		"ManagerType":     "BMC",
+		"Manufacturer":    s.opts.Manufacturer,
+		"Model":           s.opts.Model,
+		"FirmwareVersion": s.opts.FirmwareVersion,
+		"PowerState":      PowerStateOn,
+		"Status": map[string]string{
+			"State":  "Enabled",
+			"Health": "OK",
+		},
+		"NetworkProtocol": odataID{ManagerPath + "/NetworkProtocol"},
+		"SerialConsole": map[string]any{
+			"ServiceEnabled":        !s.opts.DisableSerialConsole,
+			"MaxConcurrentSessions": maxConsoleSessions,
+			"ConnectTypesSupported": []string{"Oem"},
+		},
+	}
+	if s.opts.OpenBMC {
+		resource["Oem"] = map[string]any{
+			"OpenBmc": map[string]any{"@odata.type": "#OpenBMCManager.v1_0_0.Manager"},
+		}
+		resource["GraphicalConsole"] = map[string]any{
+			"ServiceEnabled":        true,
+			"MaxConcurrentSessions": 4,
+			"ConnectTypesSupported": []string{"KVMIP"},
+		}
+		resource["SerialConsole"].(map[string]any)["ConnectTypesSupported"] = []string{"IPMI", "SSH"}
+	}
+	writeJSON(w, http.StatusOK, resource)
+}
+
+func (s *Server) networkProtocol(w http.ResponseWriter, r *http.Request) {
+	protocol := func(enabled bool, port int) map[string]any {
+		return map[string]any{"ProtocolEnabled": enabled, "Port": port}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id": ManagerPath + "/NetworkProtocol",
+		"Id":        "NetworkProtocol",
+		"Name":      "Manager Network Protocol",
+		"Status": map[string]string{
+			"State":  "Enabled",
+			"Health": "OK",
+		},
+		"HTTP":  protocol(true, 80),
+		"HTTPS": protocol(true, 443),
+		"SSH":   protocol(false, 22),
+		"IPMI":  protocol(true, 623),
+	})
+}
+
+func (s *Server) sessionService(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":      "/redfish/v1/SessionService",
+		"Id":             "SessionService",
+		"Name":           "Session Service",
+		"ServiceEnabled": true,
+		"SessionTimeout": 1800,
+		"Sessions":       odataID{SessionsPath},
+	})
+}
+
+func (s *Server) createSessionHandler(w http.ResponseWriter, r *http.Request) {
+	var credentials struct {
+		UserName string `json:"UserName"`
+		Password string `json:"Password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		writeError(w, http.StatusBadRequest, "Base.1.0.MalformedJSON", "Malformed session request")
+		return
+	}
+	if credentials.UserName != s.opts.Username || credentials.Password != s.opts.Password {
+		writeError(w, http.StatusUnauthorized, "Base.1.0.InsufficientPrivilege", "Invalid username or password")
+		return
+	}
+
+	id, token, ok := s.createSession()
+	if !ok {
+		writeError(w, http.StatusServiceUnavailable, "Base.1.0.SessionLimitExceeded", "The maximum number of user sessions is reached")
+		return
+	}
+
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", SessionsPath+"/"+id)
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"@odata.id": SessionsPath + "/" + id,
+		"Id":        id,
+		"Name":      "User Session",
+		"UserName":  credentials.UserName,
+	})
+}
+
+func (s *Server) sessionsCollection(w http.ResponseWriter, r *http.Request) {
+	ids := s.sessionIDs()
+	members := make([]string, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, SessionsPath+"/"+id)
+	}
+	writeCollection(w, "Session Collection", SessionsPath, members)
+}
+
+func (s *Server) session(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, open := range s.sessionIDs() {
+		if open == id {
+			writeJSON(w, http.StatusOK, map[string]string{
+				"@odata.id": SessionsPath + "/" + id,
+				"Id":        id,
+				"Name":      "User Session",
+				"UserName":  s.opts.Username,
+			})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Base.1.0.ResourceMissingAtURI", "Session not found: "+id)
+}
+
+func (s *Server) deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !s.deleteSession(id) {
+		writeError(w, http.StatusNotFound, "Base.1.0.ResourceMissingAtURI", "Session not found: "+id)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCollection(w http.ResponseWriter, name, path string, members []string) {
+	links := make([]odataID, 0, len(members))
+	for _, member := range members {
+		links = append(links, odataID{member})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"@odata.id":           path,
+		"Name":                name,
+		"Members":             links,
+		"Members@odata.count": len(links),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a Redfish error response
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
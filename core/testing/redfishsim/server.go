@@ -0,0 +1,331 @@
+package redfishsim
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Power states of the simulated system
+const (
+	PowerStateOn  = "On"
+	PowerStateOff = "Off"
+)
+
+// Resource paths of the simulated BMC
+const (
+	ServiceRootPath    = "/redfish/v1/"
+	SystemPath         = "/redfish/v1/Systems/1"
+	ResetActionPath    = SystemPath + "/Actions/ComputerSystem.Reset"
+	SerialConsolePath  = SystemPath + "/SerialConsole"
+	ConsoleConnectPath = SerialConsolePath + "/Actions/SerialConsole.Connect"
+	ManagerPath        = "/redfish/v1/Managers/1"
+	SessionsPath       = "/redfish/v1/SessionService/Sessions"
+
+	// OBMCConsolePath is the obmc-console WebSocket of OpenBMC BMCs
+	OBMCConsolePath = "/console0"
+)
+
+// ResetTypes are the ComputerSystem.Reset types the simulator accepts
+var ResetTypes = []string{
+	"On", "ForceOff", "GracefulShutdown", "GracefulRestart",
+	"ForceRestart", "PowerCycle", "PushPowerButton", "Nmi",
+}
+
+// Options configures a simulated BMC. Zero values select the defaults.
+type Options struct {
+	// Username and Password are the BMC credentials (admin/password)
+	Username string
+	Password string
+
+	// Manufacturer, Model and FirmwareVersion describe the BMC, the
+	// manufacturer drives the agent's vendor detection (Generic)
+	Manufacturer    string
+	Model           string
+	FirmwareVersion string
+
+	// PowerState is the initial power state of the system (On)
+	PowerState string
+
+	// MaxSessions is the session limit of the session service, creating a
+	// session beyond it fails with 503 like on real BMCs (4)
+	MaxSessions int
+
+	// DisableSerialConsole reports the serial console service as disabled
+	DisableSerialConsole bool
+
+	// OpenBMC serves the BMC like OpenBMC's bmcweb: the manager carries
+	// the OpenBmc Oem and a graphical console, and the serial console is
+	// the obmc-console WebSocket at OBMCConsolePath, sending binary
+	// messages, instead of the SerialConsole.Connect action
+	OpenBMC bool
+}
+
+// Server is a running simulated BMC
+type Server struct {
+	// URL is the BMC endpoint, e.g. http://127.0.0.1:38211
+	URL string
+
+	opts       Options
+	httpServer *httptest.Server
+	upgrader   websocket.Upgrader
+
+	mu           sync.Mutex
+	powerState   string
+	resets       []string
+	sessions     map[string]string // session ID to token
+	nextSession  int
+	consoles     map[*console]struct{}
+	consoleInput []byte
+}
+
+// console is a connected serial console client
+type console struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *console) write(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// New starts a simulated BMC on a local port. Close it when done.
+func New(opts Options) *Server {
+	if opts.Username == "" {
+		opts.Username = "admin"
+	}
+	if opts.Password == "" {
+		opts.Password = "password"
+	}
+	if opts.Manufacturer == "" {
+		opts.Manufacturer = "Generic"
+	}
+	if opts.Model == "" {
+		opts.Model = "Simulated BMC"
+	}
+	if opts.FirmwareVersion == "" {
+		opts.FirmwareVersion = "1.0.0"
+	}
+	if opts.PowerState == "" {
+		opts.PowerState = PowerStateOn
+	}
+	if opts.MaxSessions == 0 {
+		opts.MaxSessions = 4
+	}
+
+	s := &Server{
+		opts:       opts,
+		powerState: opts.PowerState,
+		sessions:   make(map[string]string),
+		consoles:   make(map[*console]struct{}),
+	}
+	s.httpServer = httptest.NewServer(s.routes())
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close closes the consoles and stops the BMC
+func (s *Server) Close() {
+	s.mu.Lock()
+	for c := range s.consoles {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+	s.httpServer.Close()
+}
+
+// Username returns the BMC username
+func (s *Server) Username() string {
+	return s.opts.Username
+}
+
+// Password returns the BMC password
+func (s *Server) Password() string {
+	return s.opts.Password
+}
+
+// PowerState returns the current power state of the system
+func (s *Server) PowerState() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.powerState
+}
+
+// SetPowerState changes the power state of the system
+func (s *Server) SetPowerState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.powerState = state
+}
+
+// Resets returns the reset types received, in order
+func (s *Server) Resets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.resets...)
+}
+
+// Sessions returns the number of open sessions
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// ConsoleInput returns everything written to the serial console
+func (s *Server) ConsoleInput() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]byte(nil), s.consoleInput...)
+}
+
+// ConsoleClients returns the number of connected serial consoles
+func (s *Server) ConsoleClients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.consoles)
+}
+
+// WriteConsole sends output to the connected serial consoles, as if the
+// host printed it
+func (s *Server) WriteConsole(data []byte) {
+	s.mu.Lock()
+	consoles := make([]*console, 0, len(s.consoles))
+	for c := range s.consoles {
+		consoles = append(consoles, c)
+	}
+	s.mu.Unlock()
+
+	messageType := websocket.TextMessage
+	if s.opts.OpenBMC {
+		messageType = websocket.BinaryMessage
+	}
+	for _, c := range consoles {
+		_ = c.write(messageType, data)
+	}
+}
+
+// reset applies a ComputerSystem.Reset type, it returns false for unknown
+// types
+func (s *Server) reset(resetType string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch resetType {
+	case "On", "ForceRestart", "GracefulRestart", "PowerCycle":
+		s.powerState = PowerStateOn
+	case "ForceOff", "GracefulShutdown":
+		s.powerState = PowerStateOff
+	case "PushPowerButton":
+		if s.powerState == PowerStateOn {
+			s.powerState = PowerStateOff
+		} else {
+			s.powerState = PowerStateOn
+		}
+	case "Nmi":
+	default:
+		return false
+	}
+	s.resets = append(s.resets, resetType)
+	return true
+}
+
+// createSession opens a session, it returns false when the session limit
+// is reached
+func (s *Server) createSession() (id, token string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.sessions) >= s.opts.MaxSessions {
+		return "", "", false
+	}
+
+	s.nextSession++
+	id = strconv.Itoa(s.nextSession)
+	token = newToken()
+	s.sessions[id] = token
+	return id, token, true
+}
+
+func (s *Server) deleteSession(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[id]; !ok {
+		return false
+	}
+	delete(s.sessions, id)
+	return true
+}
+
+func (s *Server) sessionIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.sessions))
+	for i := 1; i <= s.nextSession; i++ {
+		if _, ok := s.sessions[strconv.Itoa(i)]; ok {
+			ids = append(ids, strconv.Itoa(i))
+		}
+	}
+	return ids
+}
+
+func (s *Server) validToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.sessions {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// authorized rejects requests without valid credentials or session token
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Auth-Token"); token != "" && s.validToken(token) {
+			next(w, r)
+			return
+		}
+		if username, password, ok := r.BasicAuth(); ok && username == s.opts.Username && password == s.opts.Password {
+			next(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="Redfish"`)
+		writeError(w, http.StatusUnauthorized, "Base.1.0.InsufficientPrivilege", "Authentication required")
+	}
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) addConsole(c *console) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consoles[c] = struct{}{}
+}
+
+func (s *Server) removeConsole(c *console) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.consoles, c)
+}
+
+func (s *Server) recordConsoleInput(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consoleInput = append(s.consoleInput, data...)
+}
@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Interceptor traces Connect RPCs. Clients start a span per call and send its
+// context in the request headers; handlers continue the caller's trace. Add it
+// first to both handlers and clients, so that rejected calls are traced too.
+type Interceptor struct {
+	tracer trace.Tracer
+}
+
+// NewInterceptor creates a tracing interceptor using the global tracer
+// provider
+func NewInterceptor() *Interceptor {
+	return &Interceptor{tracer: otel.Tracer(instrumentationName)}
+}
+
+// WrapUnary implements connect.Interceptor
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		spec := req.Spec()
+		propagator := otel.GetTextMapPropagator()
+
+		kind := trace.SpanKindClient
+		if !spec.IsClient {
+			kind = trace.SpanKindServer
+			ctx = propagator.Extract(ctx, propagation.HeaderCarrier(req.Header()))
+		}
+
+		ctx, span := i.tracer.Start(ctx, spanName(spec.Procedure),
+			trace.WithSpanKind(kind),
+			trace.WithAttributes(rpcAttributes(spec.Procedure, req.Peer())...))
+		defer span.End()
+
+		if spec.IsClient {
+			propagator.Inject(ctx, propagation.HeaderCarrier(req.Header()))
+		}
+
+		resp, err := next(ctx, req)
+		recordRPCError(span, err)
+		return resp, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor. The span ends when the
+// response is closed or ctx ends, whichever comes first.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		ctx, span := i.tracer.Start(ctx, spanName(spec.Procedure),
+			trace.WithSpanKind(trace.SpanKindClient))
+
+		conn := next(ctx, spec)
+		span.SetAttributes(rpcAttributes(spec.Procedure, conn.Peer())...)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+
+		traced := &tracedClientConn{StreamingClientConn: conn, span: span}
+		context.AfterFunc(ctx, traced.finish)
+		return traced
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		spec := conn.Spec()
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+
+		ctx, span := i.tracer.Start(ctx, spanName(spec.Procedure),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(spec.Procedure, conn.Peer())...))
+		defer span.End()
+
+		err := next(ctx, conn)
+		recordRPCError(span, err)
+		return err
+	}
+}
+
+// tracedClientConn ends the span of a client stream once it is done
+type tracedClientConn struct {
+	connect.StreamingClientConn
+
+	span trace.Span
+	once sync.Once
+	err  error // First receive error other than io.EOF
+	mu   sync.Mutex
+}
+
+func (c *tracedClientConn) Receive(msg any) error {
+	err := c.StreamingClientConn.Receive(msg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		c.mu.Lock()
+		if c.err == nil {
+			c.err = err
+		}
+		c.mu.Unlock()
+	}
+	return err
+}
+
+func (c *tracedClientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	c.finish()
+	return err
+}
+
+func (c *tracedClientConn) finish() {
+	c.once.Do(func() {
+		c.mu.Lock()
+		recordRPCError(c.span, c.err)
+		c.mu.Unlock()
+		c.span.End()
+	})
+}
+
+// spanName returns the span name of a procedure, e.g.
+// "gateway.v1.GatewayService/PowerOn"
+func spanName(procedure string) string {
+	return strings.TrimPrefix(procedure, "/")
+}
+
+// rpcAttributes returns the semantic convention attributes of an RPC
+func rpcAttributes(procedure string, peer connect.Peer) []attribute.KeyValue {
+	service, method, _ := strings.Cut(spanName(procedure), "/")
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "connect_rpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	}
+	if peer.Addr != "" {
+		attrs = append(attrs, attribute.String("network.peer.address", peer.Addr))
+	}
+	return attrs
+}
+
+// recordRPCError marks a span as failed with the Connect code of err
+func recordRPCError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.SetAttributes(attribute.String("rpc.connect_rpc.error_code", connect.CodeOf(err).String()))
+	RecordError(span, err)
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const pingProcedure = "/test.v1.TestService/Ping"
+
+func TestInterceptorPropagatesTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var handlerSpan trace.SpanContext
+	mux := http.NewServeMux()
+	mux.Handle(pingProcedure, connect.NewUnaryHandler(pingProcedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(NewInterceptor()),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+pingProcedure,
+		connect.WithInterceptors(NewInterceptor()))
+
+	ctx, parent := Start(context.Background(), "power operation")
+	if _, err := client.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{})); err != nil {
+		t.Fatalf("CallUnary() error = %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Recorded %d spans, want 3 (server, client, parent)", len(spans))
+	}
+	serverSpan, clientSpan := spans[0], spans[1]
+
+	if serverSpan.SpanKind() != trace.SpanKindServer || clientSpan.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Span kinds = %v, %v, want server, client", serverSpan.SpanKind(), clientSpan.SpanKind())
+	}
+	if clientSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Client span is not a child of the caller's span")
+	}
+	if serverSpan.Parent().SpanID() != clientSpan.SpanContext().SpanID() {
+		t.Error("Server span is not a child of the client span")
+	}
+	if handlerSpan.TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("Handler trace ID = %s, want %s", handlerSpan.TraceID(), parent.SpanContext().TraceID())
+	}
+	if serverSpan.Name() != "test.v1.TestService/Ping" {
+		t.Errorf("Span name = %q, want test.v1.TestService/Ping", serverSpan.Name())
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	if metadata := Inject(context.Background()); metadata != nil {
+		t.Errorf("Inject() without a span = %v, want nil", metadata)
+	}
+
+	ctx, span := Start(context.Background(), "console connect")
+	defer span.End()
+
+	metadata := Inject(ctx)
+	if metadata["traceparent"] == "" {
+		t.Fatalf("Inject() = %v, want a traceparent", metadata)
+	}
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), metadata))
+	if extracted.TraceID() != span.SpanContext().TraceID() || extracted.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("Extract() = %v, want the span context of %v", extracted, span.SpanContext())
+	}
+}
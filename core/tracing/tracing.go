@@ -0,0 +1,118 @@
+// Package tracing provides OpenTelemetry tracing for the BMC management
+// services.
+//
+// Connect RPCs are traced by Interceptor, which propagates the W3C trace
+// context in request headers. Console streams last as long as the console, so
+// their handshakes get spans of their own whose context travels in the
+// metadata of the handshake chunk (see Inject and Extract).
+//
+// Example usage:
+//
+//	shutdown, err := tracing.Setup(ctx, "gateway", cfg.Tracing)
+//	defer shutdown(context.Background())
+//
+//	interceptors := connect.WithInterceptors(tracing.NewInterceptor(), authInterceptor)
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"core/config"
+)
+
+// instrumentationName names the tracer of all spans created by this package
+const instrumentationName = "conduit-bmc"
+
+func init() {
+	// Propagate trace context even when tracing is disabled, so that a
+	// service without an exporter does not break traces across it
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Setup installs the global tracer provider of a service. When tracing is
+// disabled spans are not recorded and the returned shutdown does nothing.
+// Call shutdown before exiting to flush the spans still buffered.
+func Setup(ctx context.Context, serviceName string, cfg config.TracingConfig) (shutdown func(context.Context) error, err error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := resource.Merge(
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+		resource.Environment(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span, a child of the span in ctx if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordError marks a span as failed with err. Nil errors are ignored.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject returns the trace context of ctx as handshake chunk metadata, or
+// nil when ctx carries no trace
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx with the trace context found in handshake chunk
+// metadata
+func Extract(ctx context.Context, metadata map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(metadata))
+}
+
+// ExtractHeader returns ctx with the trace context found in HTTP headers, e.g.
+// those of a WebSocket upgrade request
+func ExtractHeader(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
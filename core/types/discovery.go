@@ -94,7 +94,8 @@ type NetworkInfo struct {
 	NetworkSegment string `json:"network_segment"`
 	VLANId         string `json:"vlan_id"`
 	Reachable      bool   `json:"reachable"`
-	LatencyMs      int32  `json:"latency_ms"` // Ping latency from agent
+	LatencyMs      int32  `json:"latency_ms"`         // Ping latency from agent
+	Hostname       string `json:"hostname,omitempty"` // DNS name of the endpoint, IPAddress is its last resolved address
 }
 
 // CapabilityInfo contains discovered capabilities
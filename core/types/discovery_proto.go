@@ -74,6 +74,7 @@ func (dm *DiscoveryMetadata) ConvertToProto() *commonv1.DiscoveryMetadata {
 			VlanId:         dm.Network.VLANId,
 			Reachable:      dm.Network.Reachable,
 			LatencyMs:      dm.Network.LatencyMs,
+			Hostname:       dm.Network.Hostname,
 		}
 	}
 
@@ -157,6 +158,7 @@ func ConvertDiscoveryMetadataFromProto(proto *commonv1.DiscoveryMetadata) *Disco
 			VLANId:         proto.Network.VlanId,
 			Reachable:      proto.Network.Reachable,
 			LatencyMs:      proto.Network.LatencyMs,
+			Hostname:       proto.Network.Hostname,
 		}
 	}
 
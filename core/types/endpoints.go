@@ -18,6 +18,8 @@ type SOLEndpoint struct {
 	Username string     `json:"username"`
 	Password string     `json:"password"`
 	Config   *SOLConfig `json:"config"`
+	// Terminal the console is rendered for, told to web consoles
+	Terminal *TerminalConfig `json:"terminal,omitempty"`
 }
 
 // VNCEndpoint represents VNC/KVM access configuration.
@@ -44,6 +46,15 @@ type SOLConfig struct {
 	TimeoutSeconds int    `json:"timeout_seconds"`
 }
 
+// TerminalConfig describes the terminal a serial console is rendered for:
+// its TERM type, such as "vt100", and its size in character cells. Serial
+// lines carry no size, so hosts assume one, usually 80x24.
+type TerminalConfig struct {
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Cols int    `json:"cols,omitempty" yaml:"cols,omitempty"`
+	Rows int    `json:"rows,omitempty" yaml:"rows,omitempty"`
+}
+
 // VNCConfig holds VNC-specific configuration.
 type VNCConfig struct {
 	Protocol string `json:"protocol"`
@@ -0,0 +1,9 @@
+#!/bin/bash
+
+# Simulated host of ipmi_sim: a shell on the serial console, started on
+# power on and killed on power off
+
+stty -F /dev/ttyHOST1 sane 115200
+echo "ipmi-sim host booted" > /dev/ttyHOST1
+
+exec setsid /bin/sh -i < /dev/ttyHOST1 > /dev/ttyHOST1 2>&1
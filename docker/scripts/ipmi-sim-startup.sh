@@ -0,0 +1,58 @@
+#!/bin/bash
+set -e
+
+# ipmi_sim startup script for container environment
+# The serial console of the simulated host is a pty pair: ipmi_sim serves
+# /dev/ttyHOST0 over SOL, the host shell runs on /dev/ttyHOST1
+
+IPMI_USERNAME=${IPMI_USERNAME:-"ipmiusr"}
+IPMI_PASSWORD=${IPMI_PASSWORD:-"test"}
+IPMI_PORT=${IPMI_PORT:-623}
+IPMI_ADDRESS=${IPMI_ADDRESS:-"0.0.0.0"}
+
+echo "ipmi_sim Configuration:"
+echo "  IPMI Username: $IPMI_USERNAME"
+echo "  IPMI Port: $IPMI_PORT"
+echo "  IPMI Address: $IPMI_ADDRESS"
+
+echo "Creating serial console..."
+socat PTY,link=/dev/ttyHOST0,raw,echo=0 PTY,link=/dev/ttyHOST1,raw,echo=0 &
+
+timeout=10
+while [ ! -e /dev/ttyHOST0 ] || [ ! -e /dev/ttyHOST1 ]; do
+    if [ $timeout -le 0 ]; then
+        echo "ERROR: serial console not created after 10 seconds"
+        exit 1
+    fi
+    sleep 1
+    timeout=$((timeout-1))
+done
+
+cat > /etc/ipmi/lan.conf <<CONF
+name "ipmi-sim"
+
+set_working_mc 0x20
+
+  startlan 1
+    addr $IPMI_ADDRESS $IPMI_PORT
+    priv_limit admin
+    allowed_auths_callback none md2 md5 straight
+    allowed_auths_user none md2 md5 straight
+    allowed_auths_operator none md2 md5 straight
+    allowed_auths_admin none md2 md5 straight
+    guid a123456789abcdefa123456789abcdef
+  endlan
+
+  sol "/dev/ttyHOST0" 115200
+
+  # The host runs while the chassis is powered on
+  startcmd "/usr/local/bin/ipmi-sim-host.sh"
+  startnow true
+  poweroff_wait 2
+  kill_wait 2
+
+  user 2 true "$IPMI_USERNAME" "$IPMI_PASSWORD" admin 10 none md2 md5 straight
+CONF
+
+echo "Starting ipmi_sim..."
+exec ipmi_sim -n -c /etc/ipmi/lan.conf -f /etc/ipmi/ipmisim.emu -s /var/lib/ipmi
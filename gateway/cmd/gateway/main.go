@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"net"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
@@ -19,14 +22,25 @@ import (
 	"github.com/rs/zerolog/log"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	coreauth "core/auth"
 	baseconf "core/config"
+	"core/eventbus"
+	"core/logging"
+	coremetrics "core/metrics"
 	"core/streaming"
+	"core/tracing"
+	gatewayv1 "gateway/gen/gateway/v1"
 	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/agent"
 	"gateway/internal/gateway"
+	"gateway/internal/metering"
 	"gateway/internal/metrics"
+	"gateway/internal/probe"
 	"gateway/internal/session"
+	"gateway/internal/sli"
 	gatewaystreaming "gateway/internal/streaming"
 	"gateway/internal/webui"
 	"gateway/pkg/config"
@@ -35,15 +49,42 @@ import (
 
 func init() {
 	// Configure zerolog for human-friendly console output
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})
+	log.Logger = log.Output(logging.NewRedactWriter(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})).Hook(logging.ModuleLevelHook)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 }
 
 func main() {
+	// Parse command line flags
+	var validate bool
+	var validateFormat string
+	var envDocs bool
+	flag.BoolVar(&validate, "validate-config", false, "Validate the configuration and check the endpoints it declares, then exit")
+	flag.StringVar(&validateFormat, "validate-format", "text", "Format of the validation report: text or json")
+	flag.BoolVar(&envDocs, "env-docs", false, "Print the environment variables of the configuration as Markdown, then exit")
+	flag.Parse()
+
+	if envDocs {
+		if err := baseconf.WriteEnvDocs(os.Stdout, "gateway", &config.Config{}); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write environment variables")
+		}
+		return
+	}
+
 	// Load configuration
 	configFile := baseconf.FindConfigFile("gateway")
 	envFile := baseconf.FindEnvironmentFile("gateway")
 
+	if validate {
+		report := validateConfig(context.Background(), configFile, envFile)
+		if err := report.Write(os.Stdout, validateFormat); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write validation report")
+		}
+		if !report.Valid {
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.Load(configFile, envFile)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load configuration")
@@ -60,24 +101,187 @@ func main() {
 		Bool("debug", cfg.Log.Debug).
 		Msg("Log level configured")
 
+	// Install the tracer provider before any Connect client or handler is created
+	shutdownTracing, err := tracing.Setup(context.Background(), "gateway", cfg.Tracing)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+	defer shutdownTracing(context.Background())
+	log.Info().
+		Bool("enabled", cfg.Tracing.Enabled).
+		Str("endpoint", cfg.Tracing.Endpoint).
+		Float64("sample_ratio", cfg.Tracing.SampleRatio).
+		Msg("Tracing configured")
+
 	// Initialize JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg.Auth.JWTSecretKey)
 
 	// Initialize Gateway handler
-	gatewayHandler := gateway.NewGatewayHandler(cfg.Gateway.ManagerEndpoint, jwtManager, "gateway-01", cfg.Gateway.Region, cfg.GetListenAddress())
+	gatewayHandler := gateway.NewGatewayHandler(cfg.Gateway.ManagerEndpoint, jwtManager, cfg.Gateway.ID, cfg.Gateway.Region, cfg.Gateway.AdvertiseEndpoint, cfg.GetExternalURL())
+	gatewayHandler.SetManagerCredentials(cfg.Auth.ManagerEmail, cfg.Auth.ManagerPassword)
+	if cfg.Auth.DemoManagerAccount {
+		log.Warn().Str("email", cfg.Auth.ManagerEmail).Msg("Demo manager account enabled - for development stacks only")
+	}
 
-	// Start periodic gateway registration with manager
+	// Configure web console session storage
+	sessionConfig := cfg.Gateway.SessionManagement
+	if !sessionConfig.UseInMemoryStore {
+		sessionStore, err := session.NewSQLiteStore(sessionConfig.StorePath)
+		if err != nil {
+			log.Fatal().Err(err).Str("path", sessionConfig.StorePath).Msg("Failed to open web session store")
+		}
+		defer sessionStore.Close()
+		gatewayHandler.ConfigureWebSessions(sessionStore, sessionConfig.WebSessionTTL)
+	} else {
+		gatewayHandler.ConfigureWebSessions(session.NewInMemoryStore(), sessionConfig.WebSessionTTL)
+	}
+	gatewayHandler.SetConsoleSessionMaxLifetime(sessionConfig.ConsoleSessionMaxLifetime)
+	gatewayHandler.SetCSRFSecret(cfg.Auth.JWTSecretKey)
+	gatewayHandler.SetAccessTokenSecret(cfg.Auth.JWTSecretKey)
+
+	// Key of the tokens agents verify in console stream handshakes, random
+	// unless configured, so that agents pick up a new key on re-registration
+	streamTokenSeed, err := cfg.Auth.StreamTokenSeed()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid stream token key")
+	}
+	if err := gatewayHandler.SetStreamTokenKey(streamTokenSeed); err != nil {
+		log.Fatal().Err(err).Msg("Failed to set stream token key")
+	}
+	if streamTokenSeed == nil {
+		log.Info().Msg("No stream token key configured, generated one: agents re-register after restarts")
+	}
+	gatewayHandler.SetConsoleStreamObserver(metrics.ObserveConsoleStream)
+
+	// Reuse the connections to agents across calls and console streams
+	agentConnections := cfg.Gateway.AgentConnections
+	agentClients := agent.NewClientPool(agent.ClientPoolConfig{
+		MaxConns:       agentConnections.MaxConnections,
+		DialTimeout:    agentConnections.ConnectionTimeout,
+		IdleTimeout:    agentConnections.IdleTimeout,
+		RequestTimeout: agentConnections.RequestTimeout,
+	})
+	agentClients.SetObserver(metrics.ObserveAgentConnection)
+	gatewayHandler.SetAgentClients(agentClients)
+	gatewayHandler.SetStreamMaxChunkSize(agentConnections.MaxChunkSize)
+	gatewayHandler.SetStreamChecksums(agentConnections.Checksums)
+	gatewayHandler.SetStreamCorruptionObserver(metrics.ObserveCorruptChunk)
+
+	// Turn console WebSockets away once saturated, rather than running out
+	// of memory
+	limits := cfg.Gateway.Limits
+	gatewayHandler.SetStreamLimits(gateway.StreamLimits{
+		MaxSessions:         limits.MaxWebSocketSessions,
+		MaxCustomerSessions: limits.MaxCustomerSessions,
+		MaxBufferedBytes:    limits.MaxBufferedBytes,
+		MaxMessageSize:      limits.MaxMessageSize,
+		RetryAfter:          limits.RetryAfter,
+	})
+	gatewayHandler.SetStreamRejectionObserver(metrics.ObserveStreamRejection)
+
+	// Answer the retries of power operations with the result of the first
+	// request with their idempotency key
+	gatewayHandler.SetPowerIdempotencyWindow(cfg.Gateway.PowerIdempotencyWindow)
+	gatewayHandler.SetOperationLimits(cfg.Gateway.Operations.Retention, cfg.Gateway.Operations.Timeout)
+	gatewayHandler.SetOperationObserver(metrics.ObserveOperation)
+	gatewayHandler.SetPowerDeduplicationObserver(metrics.ObservePowerDeduplication)
+
+	// Ask the manager whether console sessions are still authorized when
+	// clients attach, e.g. after a customer's access was revoked
+	if validation := cfg.Gateway.SessionValidation; validation.Enabled {
+		gatewayHandler.EnableSessionValidation(validation.Timeout, validation.CacheTTL, validation.FailOpen)
+	}
+
+	if faults := cfg.Gateway.FaultInjection; faults.Enabled {
+		gatewayHandler.SetStreamFaults(streaming.NewFaultInjector(faults.StreamFaults()))
+		log.Warn().
+			Float64("delay_probability", faults.DelayProbability).
+			Dur("max_delay", faults.MaxDelay).
+			Float64("drop_probability", faults.DropProbability).
+			Float64("partial_write_probability", faults.PartialWriteProbability).
+			Float64("disconnect_probability", faults.DisconnectProbability).
+			Float64("corrupt_probability", faults.CorruptProbability).
+			Msg("Fault injection enabled on console streams, do not use in production")
+	}
+	log.Info().
+		Bool("in_memory", sessionConfig.UseInMemoryStore).
+		Dur("ttl", sessionConfig.WebSessionTTL).
+		Dur("cleanup_interval", sessionConfig.CleanupInterval).
+		Msg("Web session store configured")
+
+	// Publish system events to the event bus
 	ctx := context.Background()
+	if cfg.EventBus.Enabled {
+		bus, err := eventbus.Connect(ctx, cfg.EventBus)
+		if err != nil {
+			log.Fatal().Err(err).Str("driver", cfg.EventBus.Driver).Msg("Failed to connect to the event bus")
+		}
+		defer bus.Close()
+
+		publisher := eventbus.NewPublisher(bus, cfg.EventBus)
+		publisher.SetObserver(coremetrics.EventBusObserver(metrics.EventBusEventsTotal))
+		publisher.Start(ctx)
+
+		gatewayHandler.SetEventBus(publisher)
+		log.Info().
+			Str("driver", cfg.EventBus.Driver).
+			Str("subject_prefix", cfg.EventBus.SubjectPrefix).
+			Msg("Event bus publishing enabled")
+	}
+
+	// Start periodic gateway registration with manager
 	gatewayHandler.StartPeriodicRegistration(ctx)
+	gatewayHandler.StartWebSessionSweeper(ctx, sessionConfig.CleanupInterval)
+	agentClients.Start(ctx)
+
+	// Probe the links to agents to spot degraded datacenter connectivity
+	if probeConfig := cfg.Gateway.AgentProbes; probeConfig.Enabled {
+		prober := probe.NewProber(gatewayHandler.GetAgentRegistry(), func(endpoint string) probe.Client {
+			return agentClients.Client(endpoint)
+		}, probe.Config{
+			Interval:    probeConfig.Interval,
+			Timeout:     probeConfig.Timeout,
+			PayloadSize: probeConfig.PayloadSize,
+		})
+		prober.Start(ctx)
+		gatewayHandler.SetLinkStatus(func(agentID string) *gatewayv1.AgentLinkStatus {
+			if result, ok := prober.Result(agentID); ok {
+				return linkStatus(result)
+			}
+			return nil
+		})
+		log.Info().
+			Dur("interval", probeConfig.Interval).
+			Int("payload_size", probeConfig.PayloadSize).
+			Msg("Agent link probes started")
+	}
+
+	// Sample server power consumption for the manager's usage report
+	if meteringConfig := cfg.Gateway.PowerMetering; meteringConfig.Enabled {
+		sampler := metering.NewSampler(gatewayHandler.PowerMeteringTargets, func(endpoint string) metering.Client {
+			return agentClients.Client(endpoint)
+		}, metering.Config{
+			Interval: meteringConfig.Interval,
+			Timeout:  meteringConfig.Timeout,
+		})
+		sampler.SetObserver(metrics.ObservePowerSample)
+		gatewayHandler.SetPowerSampler(sampler)
+		sampler.Start(ctx)
+		log.Info().
+			Dur("interval", meteringConfig.Interval).
+			Msg("Power metering started")
+	}
 
 	// Create interceptors for authentication, token validation, and session management
 	// Order matters: auth extracts JWT → token validation validates it → session sets cookies
 	authInterceptor := gateway.NewAuthInterceptor(gatewayHandler)
 	sessionInterceptor := gateway.NewSessionCookieInterceptor(gatewayHandler)
 	interceptors := connect.WithInterceptors(
-		authInterceptor, // 1. Extract JWT from header or session cookie
-		gatewayHandler.TokenValidationInterceptor(), // 2. Validate the JWT token
-		sessionInterceptor,                          // 3. Set session cookies for CreateSOLSession/CreateVNCSession
+		tracing.NewInterceptor(),                    // 0. Trace every call, including rejected ones
+		authInterceptor,                             // 1. Extract JWT from header or session cookie
+		gatewayHandler.TokenValidationInterceptor(), // 2. Validate the JWT token of unary calls
+		gatewayHandler.SourceAllowlistInterceptor(), // 3. Enforce the IP allowlist of streams
+		sessionInterceptor,                          // 4. Set session cookies for CreateSOLSession/CreateVNCSession
 	)
 
 	// Create the Connect service handler
@@ -88,7 +292,20 @@ func main() {
 
 	log.Info().Msg("Gateway starting with shared webui templates")
 
-	corsHandler := setupRouter(path, cfg.Gateway.Region, cfg.Gateway.ManagerEndpoint, handler, gatewayHandler)
+	originPolicy := gateway.NewOriginPolicy(cfg.Gateway.AllowedOriginList())
+	portal := webui.NewPortalHandler(jwtManager, cfg.GetPortalManagerURL(), cfg.Gateway.WebUI.Language)
+	portalLogin := webui.NewPortalLoginHandler(cfg.GetPortalManagerURL(), cfg.Gateway.WebUI.Language)
+	var configHandler http.Handler
+	if cfg.Debug.ConfigEndpoint {
+		configHandler = baseconf.ConfigHandler("gateway", func() interface{} { return cfg })
+		log.Warn().Msg("Configuration debug endpoint enabled on /config")
+	}
+	var diagnostics http.Handler
+	if cfg.Debug.Diagnostics {
+		diagnostics = cfg.Debug.DiagnosticsHandler()
+		log.Warn().Msg("Diagnostics endpoints enabled on /debug/pprof/ and /debug/vars")
+	}
+	corsHandler := setupRouter(path, cfg.Gateway.Region, cfg.Gateway.WebUI.Language, handler, gatewayHandler, originPolicy, portal, portalLogin, configHandler, diagnostics)
 
 	// Start metrics collector for gauge metrics
 	metricsCollector := metrics.NewCollector(gatewayHandler, 15*time.Second)
@@ -104,8 +321,10 @@ func main() {
 
 	log.Info().
 		Str("address", cfg.GetListenAddress()).
-		Str("gateway_id", "gateway-01").
+		Str("gateway_id", cfg.Gateway.ID).
 		Str("region", cfg.Gateway.Region).
+		Str("advertise_endpoint", cfg.Gateway.AdvertiseEndpoint).
+		Str("external_url", cfg.GetExternalURL()).
 		Str("manager_endpoint", cfg.Gateway.ManagerEndpoint).
 		Str("rpc_path", path).
 		Bool("rate_limiting", cfg.Gateway.RateLimit.Enabled).
@@ -119,7 +338,10 @@ func main() {
 	}
 }
 
-func setupRouter(path, region, managerEndpoint string, handler http.Handler, gatewayHandler *gateway.RegionalGatewayHandler) http.Handler {
+// statusJSON encodes /status with the field names of the proto
+var statusJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+func setupRouter(path, region, language string, handler http.Handler, gatewayHandler *gateway.RegionalGatewayHandler, originPolicy *gateway.OriginPolicy, portal, portalLogin, configHandler, diagnostics http.Handler) http.Handler {
 	// Create a new Gorilla Mux router
 	r := mux.NewRouter()
 
@@ -140,67 +362,57 @@ func setupRouter(path, region, managerEndpoint string, handler http.Handler, gat
 		w.Write([]byte(`{"status": "healthy", "service": "gateway", "region": "` + region + `"}`))
 	}).Methods("GET")
 
-	// Add status endpoint (gateway-specific status)
+	// Add status endpoint (gateway-specific status), the JSON of GetGatewayStatus
 	r.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-
-		// Gather gateway status information
-		agentRegistry := gatewayHandler.GetAgentRegistry()
-		agents := agentRegistry.List()
-
-		// Build agent status list
-		agentStatuses := make([]map[string]interface{}, 0, len(agents))
-		for _, agent := range agents {
-			agentStatuses = append(agentStatuses, map[string]interface{}{
-				"id":         agent.ID,
-				"datacenter": agent.DatacenterID,
-				"endpoint":   agent.Endpoint,
-				"last_seen":  agent.LastSeen,
-				"status":     agent.Status,
-			})
-		}
-
-		// Get active session counts from handler
-		sessionCount := gatewayHandler.GetConsoleSessionCount()
-
-		status := map[string]interface{}{
-			"service":                 "gateway",
-			"region":                  region,
-			"manager_endpoint":        managerEndpoint,
-			"agents":                  agentStatuses,
-			"agent_count":             len(agents),
-			"active_console_sessions": sessionCount,
+		status, err := statusJSON.Marshal(gatewayHandler.GatewayStatus())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to encode status response")
+			http.Error(w, "Failed to encode status", http.StatusInternalServerError)
+			return
 		}
 
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(status); err != nil {
-			log.Error().Err(err).Msg("Failed to encode status response")
-		}
+		w.Write(status)
 	}).Methods("GET")
 
 	// Add Prometheus metrics endpoint
 	r.Handle("/metrics", promhttp.Handler()).Methods("GET")
 
-	// Create WebSocket upgrader
+	// Add the effective configuration, secrets redacted, when enabled
+	if configHandler != nil {
+		r.Handle("/config", configHandler).Methods("GET")
+	}
+
+	// Add pprof profiles and expvar variables, when enabled
+	if diagnostics != nil {
+		r.PathPrefix("/debug/pprof/").Handler(diagnostics)
+		r.Handle("/debug/vars", diagnostics).Methods("GET")
+	}
+
+	// Create WebSocket upgrader, rejecting cross-site handshakes
 	upgrader := websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			return true // Allow all origins for now
-		},
+		CheckOrigin: originPolicy.CheckWebSocketOrigin,
 	}
 
+	// VNC clients may ask for a subprotocol, which browsers require the
+	// handshake to select
+	vncUpgrader := upgrader
+	vncUpgrader.Subprotocols = gateway.VNCSubprotocols
+
 	// VNC HTML viewer handler (serves noVNC interface)
 	r.HandleFunc("/vnc/{sessionId}", func(w http.ResponseWriter, r *http.Request) {
-		vncViewerHandler(w, r, gatewayHandler)
+		vncViewerHandler(w, r, gatewayHandler, language)
 	}).Methods("GET")
 
 	// VNC WebSocket handler (for data streaming)
 	r.HandleFunc("/vnc/{sessionId}/ws", func(w http.ResponseWriter, r *http.Request) {
-		vncWebSocketHandler(w, r, gatewayHandler, &upgrader)
+		vncWebSocketHandler(w, r, gatewayHandler, &vncUpgrader)
 	}).Methods("GET")
 
 	// Console HTML viewer handler (serves console interface)
 	r.HandleFunc("/console/{sessionId}", func(w http.ResponseWriter, r *http.Request) {
-		consoleViewerHandler(w, r, gatewayHandler)
+		consoleViewerHandler(w, r, gatewayHandler, language)
 	}).Methods("GET")
 
 	// Console WebSocket handler (for terminal data streaming)
@@ -208,47 +420,103 @@ func setupRouter(path, region, managerEndpoint string, handler http.Handler, gat
 		consoleWebSocketHandler(w, r, gatewayHandler, &upgrader)
 	}).Methods("GET")
 
+	// Customer self-service portal (servers, power states, consoles, sessions)
+	r.Handle(webui.PortalPath, portal).Methods("GET")
+	r.Handle(webui.PortalLoginPath, portalLogin).Methods("GET")
+	r.Handle(webui.PortalLogoutPath, webui.NewPortalLogoutHandler()).Methods("GET")
+
+	// Script modules of the web UI pages, such as the console stream client
+	r.PathPrefix(webui.ScriptsPath).Handler(webui.NewScriptsHandler()).Methods("GET")
+
+	// Theme preference of the web UI pages
+	r.HandleFunc(webui.ThemePath, func(w http.ResponseWriter, r *http.Request) {
+		themePreferenceHandler(w, r, gatewayHandler)
+	}).Methods("POST")
+
 	// Add CORS and metrics middleware for web clients
-	corsHandler := addCORS(metrics.HTTPMetricsMiddleware(r))
+	corsHandler := originPolicy.CORS(metrics.HTTPMetricsMiddleware(r))
 
 	return corsHandler
 }
 
-// proxyVNCThroughAgent uses buf Connect streaming RPC to proxy VNC data between WebSocket and agent
-func proxyVNCThroughAgent(wsConn *websocket.Conn, vncSession *gateway.VNCSession, gatewayHandler *gateway.RegionalGatewayHandler) error {
+// linkStatus converts the last link probe of an agent for agent statuses
+func linkStatus(result probe.Result) *gatewayv1.AgentLinkStatus {
+	return &gatewayv1.AgentLinkStatus{
+		ProbedAt:    timestamppb.New(result.ProbedAt),
+		RttMs:       float64(result.RTT.Microseconds()) / 1000,
+		UploadBps:   result.UploadBPS,
+		DownloadBps: result.DownloadBPS,
+		Error:       result.Error,
+	}
+}
+
+// proxyVNCThroughAgent uses buf Connect streaming RPC to proxy VNC data between WebSocket and agent.
+// The agent stream ends with ctx, when the session expires or when the browser disconnects.
+// With notices, the browser is warned in text messages before the session expires.
+// Clients of the binary subprotocol exchange raw RFB in binary messages only.
+func proxyVNCThroughAgent(ctx context.Context, wsConn *websocket.Conn, vncSession *gateway.VNCSession, gatewayHandler *gateway.RegionalGatewayHandler, notices bool) error {
 	log.Info().
 		Str("session_id", vncSession.SessionID).
 		Str("server_id", vncSession.ServerID).
 		Str("agent_id", vncSession.AgentID).
 		Msg("Starting buf Connect streaming VNC proxy")
 
+	// Measure stream establishment and time to first byte for the console SLO
+	attempt := gatewayHandler.StartConsoleSLI(vncSession, "vnc")
+	defer attempt.Finish()
+
+	// Trace the stream setup, the agent continues the trace from the handshake
+	connectCtx, connectSpan := gateway.StartConsoleConnectSpan(ctx, vncSession)
+	defer connectSpan.End()
+
 	// Get agent information to create client connection
 	agentInfo := gatewayHandler.GetAgentRegistry().Get(vncSession.AgentID)
 	if agentInfo == nil {
-		return fmt.Errorf("agent not found: %s", vncSession.AgentID)
+		err := streaming.NewStreamError(streaming.ErrorCodeAgentUnavailable, "agent %s is not connected", vncSession.AgentID)
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		closeViewerWebSocket(wsConn, nil, err)
+		return err
 	}
 
-	// Create Connect client for the agent with HTTP/2 support
-	httpClient := &http.Client{
-		Transport: &http2.Transport{
-			AllowHTTP: true,
-			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
-				// Use plain HTTP connection for h2c (HTTP/2 without TLS)
-				return net.Dial(network, addr)
-			},
-		},
-	}
-	agentClient := gatewayv1connect.NewGatewayServiceClient(httpClient, agentInfo.Endpoint)
+	// Pooled agent client, multiplexing streams over HTTP/2
+	agentClient := gatewayHandler.AgentClients().StreamClient(agentInfo.Endpoint)
+
+	ctx, attached := gatewayHandler.TrackConsoleStream(ctx, vncSession.SessionID,
+		gateway.StreamTransportWebSocket, wsConn.RemoteAddr().String())
+	defer attached.Detach()
 
 	// Create bidirectional streaming connection to agent
-	ctx := context.Background()
 	stream := agentClient.StreamVNCData(ctx)
 
-	// Send initial handshake to agent
-	helper := streaming.NewHandshakeHelper(&gatewaystreaming.VNCChunkFactory{})
-	if err := helper.SendHandshake(stream, vncSession.SessionID, vncSession.ServerID); err != nil {
-		return fmt.Errorf("failed to send handshake to agent: %w", err)
+	// Send initial handshake to agent, advertising the chunk size accepted so
+	// that the agent splits larger framebuffer updates, and the token the
+	// agent authenticates the stream with
+	authToken, err := gatewayHandler.AgentStreamToken(vncSession)
+	if err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		streamErr := streaming.NewStreamError(streaming.ErrorCodeInternal, "failed to sign stream token: %v", err)
+		closeViewerWebSocket(wsConn, attached, streamErr)
+		return streamErr
+	}
+	helper := streaming.NewHandshakeHelper(&gatewaystreaming.VNCChunkFactory{}).
+		WithCapabilities(streaming.Capabilities{
+			Features:     gatewayHandler.StreamFeatures(),
+			MaxChunkSize: gatewayHandler.StreamMaxChunkSize(),
+			Variant:      streaming.VariantRFB,
+			AuthToken:    authToken,
+		})
+	if err := helper.SendHandshakeWithMetadata(stream, vncSession.SessionID, vncSession.ServerID, tracing.Inject(connectCtx)); err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		streamErr := streaming.NewStreamError(streaming.ErrorCodeAgentUnavailable, "failed to send handshake to agent: %v", err)
+		closeViewerWebSocket(wsConn, attached, streamErr)
+		return streamErr
 	}
+	attempt.Established()
+	connectSpan.End()
+	gatewayHandler.PublishConsoleSessionOpened(vncSession, "vnc")
 
 	log.Debug().Str("server_id", vncSession.ServerID).Msg("Sent VNC handshake to agent")
 
@@ -265,46 +533,98 @@ func proxyVNCThroughAgent(wsConn *websocket.Conn, vncSession *gateway.VNCSession
 		vncSession.ServerID,
 		logger,
 		&gatewaystreaming.VNCChunkFactory{},
-	)
+	).WithRecorder(attached.NewRecorder(vncSession, agentInfo.Endpoint)).
+		WithBufferPool(gatewayHandler.StreamBuffers()).
+		WithMaxMessageSize(gatewayHandler.StreamLimits().MaxMessageSize)
+	if wsConn.Subprotocol() == gateway.VNCSubprotocolBinary {
+		proxy.WithBinaryOnly()
+	} else if notices {
+		proxy.WithNotices(attached.WebSocketNotices())
+	}
+	if vncSession.ReadOnly {
+		proxy.WithInputFilter(streaming.NewRFBViewOnlyFilter())
+	} else if keymap, ok := streaming.KeymapOf(vncSession.KeyboardLayout); ok && len(keymap) > 0 {
+		proxy.WithInputFilter(streaming.NewRFBKeymapFilter(keymap))
+	}
 
-	return proxy.ProxyToStream(ctx, stream)
+	// Reassemble the messages the agent splits and verify the checksums of
+	// their fragments, negotiated from its handshake ack, answering the
+	// agent's pings
+	keepalive := streaming.WithKeepalive(stream, &gatewaystreaming.VNCChunkFactory{}, vncSession.SessionID, vncSession.ServerID)
+	faultyStream := streaming.InjectFaults(keepalive, gatewayHandler.StreamFaults(), &gatewaystreaming.VNCChunkFactory{})
+	checkedStream := streaming.VerifyChecksums(faultyStream, &gatewaystreaming.VNCChunkFactory{}, gatewayHandler.StreamChecksums()).
+		WithCorruptionObserver(gatewayHandler.StreamCorruptionObserver(vncSession))
+	splitStream := streaming.SplitLargeChunks(checkedStream, &gatewaystreaming.VNCChunkFactory{}, gatewayHandler.StreamMaxChunkSize())
+	err = proxy.ProxyToStream(ctx, sli.Observe(splitStream, attempt))
+	closeViewerWebSocket(wsConn, attached, err)
+	return err
 }
 
-// proxySOLThroughAgent establishes a SOL proxy connection through the appropriate agent
-func proxySOLThroughAgent(wsConn *websocket.Conn, solSession *gateway.SOLSession, gatewayHandler *gateway.RegionalGatewayHandler) error {
+// proxySOLThroughAgent establishes a SOL proxy connection through the appropriate agent.
+// The agent stream ends with ctx, when the session expires or when the browser disconnects.
+// With notices, the browser is warned in text messages before the session expires.
+func proxySOLThroughAgent(ctx context.Context, wsConn *websocket.Conn, solSession *gateway.SOLSession, gatewayHandler *gateway.RegionalGatewayHandler, terminal streaming.TerminalHints, notices bool) error {
 	log.Info().
 		Str("session_id", solSession.SessionID).
 		Str("server_id", solSession.ServerID).
 		Str("agent_id", solSession.AgentID).
 		Msg("Starting buf Connect streaming SOL proxy")
 
+	// Measure stream establishment and time to first byte for the console SLO
+	attempt := gatewayHandler.StartConsoleSLI(solSession, "sol")
+	defer attempt.Finish()
+
+	// Trace the stream setup, the agent continues the trace from the handshake
+	connectCtx, connectSpan := gateway.StartConsoleConnectSpan(ctx, solSession)
+	defer connectSpan.End()
+
 	// Get agent information to create client connection
 	agentInfo := gatewayHandler.GetAgentRegistry().Get(solSession.AgentID)
 	if agentInfo == nil {
-		return fmt.Errorf("agent not found: %s", solSession.AgentID)
+		err := streaming.NewStreamError(streaming.ErrorCodeAgentUnavailable, "agent %s is not connected", solSession.AgentID)
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		closeViewerWebSocket(wsConn, nil, err)
+		return err
 	}
 
-	// Create Connect client for the agent with HTTP/2 support
-	httpClient := &http.Client{
-		Transport: &http2.Transport{
-			AllowHTTP: true,
-			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
-				// Use plain HTTP connection for h2c (HTTP/2 without TLS)
-				return net.Dial(network, addr)
-			},
-		},
-	}
-	agentClient := gatewayv1connect.NewGatewayServiceClient(httpClient, agentInfo.Endpoint)
+	// Pooled agent client, multiplexing streams over HTTP/2
+	agentClient := gatewayHandler.AgentClients().StreamClient(agentInfo.Endpoint)
+
+	ctx, attached := gatewayHandler.TrackConsoleStream(ctx, solSession.SessionID,
+		gateway.StreamTransportWebSocket, wsConn.RemoteAddr().String())
+	defer attached.Detach()
 
 	// Create bidirectional streaming connection to agent
-	ctx := context.Background()
 	stream := agentClient.StreamConsoleData(ctx)
 
-	// Send initial handshake to agent
-	helper := streaming.NewHandshakeHelper(&gatewaystreaming.ConsoleChunkFactory{})
-	if err := helper.SendHandshake(stream, solSession.SessionID, solSession.ServerID); err != nil {
-		return fmt.Errorf("failed to send handshake to agent: %w", err)
+	// Send initial handshake to agent, with the session's serial settings, the
+	// browser's terminal and the token the agent authenticates the stream with
+	authToken, err := gatewayHandler.AgentStreamToken(solSession)
+	if err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		streamErr := streaming.NewStreamError(streaming.ErrorCodeInternal, "failed to sign stream token: %v", err)
+		closeViewerWebSocket(wsConn, attached, streamErr)
+		return streamErr
 	}
+	helper := streaming.NewHandshakeHelper(&gatewaystreaming.ConsoleChunkFactory{}).
+		WithCapabilities(streaming.Capabilities{
+			Features:  gatewayHandler.StreamFeatures(),
+			Variant:   streaming.VariantSOL,
+			AuthToken: authToken,
+		})
+	metadata := terminal.AddTo(solSession.SOLSettings.AddTo(tracing.Inject(connectCtx)))
+	if err := helper.SendHandshakeWithMetadata(stream, solSession.SessionID, solSession.ServerID, metadata); err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		streamErr := streaming.NewStreamError(streaming.ErrorCodeAgentUnavailable, "failed to send handshake to agent: %v", err)
+		closeViewerWebSocket(wsConn, attached, streamErr)
+		return streamErr
+	}
+	attempt.Established()
+	connectSpan.End()
+	gatewayHandler.PublishConsoleSessionOpened(solSession, "sol")
 
 	log.Debug().Str("server_id", solSession.ServerID).Msg("Sent SOL handshake to agent")
 
@@ -321,9 +641,30 @@ func proxySOLThroughAgent(wsConn *websocket.Conn, solSession *gateway.SOLSession
 		solSession.ServerID,
 		logger,
 		&gatewaystreaming.ConsoleChunkFactory{},
-	)
+	).WithRecorder(attached.NewRecorder(solSession, agentInfo.Endpoint)).
+		WithBufferPool(gatewayHandler.StreamBuffers()).
+		WithMaxMessageSize(gatewayHandler.StreamLimits().MaxMessageSize)
+
+	// Consoles negotiating their terminal send resizes as control messages,
+	// and are told the terminal the agent renders the console for
+	if !terminal.IsZero() {
+		proxy.WithControlHandler(&gatewaystreaming.TerminalControl{})
+	}
+	if notices {
+		proxy.WithNotices(attached.WebSocketNotices())
+	}
+	if solSession.ReadOnly {
+		proxy.WithInputFilter(streaming.DropInput)
+	}
 
-	return proxy.ProxyToStream(ctx, stream)
+	// Answer the agent's pings, so that it keeps the BMC connection open
+	keepalive := streaming.WithKeepalive(stream, &gatewaystreaming.ConsoleChunkFactory{}, solSession.SessionID, solSession.ServerID)
+	faultyStream := streaming.InjectFaults(keepalive, gatewayHandler.StreamFaults(), &gatewaystreaming.ConsoleChunkFactory{})
+	checkedStream := streaming.VerifyChecksums(faultyStream, &gatewaystreaming.ConsoleChunkFactory{}, gatewayHandler.StreamChecksums()).
+		WithCorruptionObserver(gatewayHandler.StreamCorruptionObserver(solSession))
+	err = proxy.ProxyToStream(ctx, sli.Observe(checkedStream, attempt))
+	closeViewerWebSocket(wsConn, attached, err)
+	return err
 }
 
 func vncWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler, upgrader *websocket.Upgrader) {
@@ -351,6 +692,17 @@ func vncWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHandler
 
 	log.Debug().Str("server_id", vncSession.ServerID).Msg("VNC WebSocket: Found session")
 
+	if !authorizeConsoleStream(w, r, gatewayHandler, vncSession) {
+		return
+	}
+
+	release, err := gatewayHandler.AdmitConsoleStream(vncSession.CustomerID)
+	if err != nil {
+		rejectSaturatedWebSocket(w, sessionID, err)
+		return
+	}
+	defer release()
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -362,10 +714,12 @@ func vncWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHandler
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", vncSession.ServerID).
+		Str("subprotocol", conn.Subprotocol()).
 		Msg("VNC WebSocket connection established")
 
 	// Use buf Connect RPC to request agent to start VNC proxy
-	err = proxyVNCThroughAgent(conn, vncSession, gatewayHandler)
+	notices := r.URL.Query().Get(gateway.ConsoleNoticesParam) == "1"
+	err = proxyVNCThroughAgent(tracing.ExtractHeader(r.Context(), r.Header), conn, vncSession, gatewayHandler, notices)
 	if err != nil {
 		log.Error().Err(err).Msg("VNC proxy failed")
 		return
@@ -374,7 +728,7 @@ func vncWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHandler
 	log.Info().Str("session_id", sessionID).Msg("VNC WebSocket connection closed")
 }
 
-func vncViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler) {
+func vncViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler, language string) {
 	// Extract session ID from URL parameters
 	vars := mux.Vars(r)
 	sessionID := vars["sessionId"]
@@ -397,9 +751,12 @@ func vncViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *ga
 
 	// Search for web session with this VNC session ID
 	webSession := findWebSessionByVNCSessionID(sessionStore, sessionID)
+	if !authorizeViewer(w, r, gatewayHandler, sessionID, webSession) {
+		return
+	}
 	if webSession != nil {
 		// Set the session cookie for the browser (infer security from request)
-		cookie := session.CreateSessionCookieForRequest(webSession.ID, int(session.DefaultSessionDuration.Seconds()), r)
+		cookie := session.CreateSessionCookieForRequest(webSession.ID, int(gatewayHandler.WebSessionTTL().Seconds()), r)
 		http.SetCookie(w, cookie)
 
 		log.Debug().
@@ -414,24 +771,29 @@ func vncViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *ga
 	}
 
 	// Generate WebSocket URL for this session
-	protocol := "ws"
-	if r.TLS != nil {
-		protocol = "wss"
-	}
-	wsURL := protocol + "://" + r.Host + "/vnc/" + sessionID + "/ws"
+	protocol := viewerWebSocketScheme(r)
+	wsURL := protocol + "://" + r.Host + "/vnc/" + sessionID + "/ws?" +
+		session.AccessTokenParam + "=" + url.QueryEscape(gatewayHandler.StreamAccessToken(vncSession))
 
 	// Prepare data for VNC template
+	lang := webui.RequestLanguage(r, language)
+	p := webui.Printer(lang)
 	data := webui.VNCData{
 		TemplateData: webui.TemplateData{
-			Title:         "VNC Console - " + vncSession.ServerID,
+			Title:         p.Sprintf("vnc.title", vncSession.ServerID),
 			IconText:      "VNC",
-			HeaderTitle:   "VNC Console - " + vncSession.ServerID,
-			InitialStatus: "Connecting...",
+			HeaderTitle:   p.Sprintf("vnc.title", vncSession.ServerID),
+			InitialStatus: p.Sprintf("js.status.connecting"),
+			CSRFToken:     viewerCSRFToken(gatewayHandler, webSession),
+			ServerID:      vncSession.ServerID,
+			Maintenance:   viewerMaintenance(gatewayHandler, webSession),
+			Theme:         viewerTheme(r, webSession),
+			Lang:          lang,
 		},
 		SessionID:       sessionID,
-		ServerID:        vncSession.ServerID,
 		GatewayEndpoint: r.Host,
 		WebSocketURL:    wsURL,
+		ReadOnly:        vncSession.ReadOnly,
 	}
 
 	// Render template
@@ -453,25 +815,8 @@ func vncViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *ga
 		Msg("Served VNC viewer")
 }
 
-// CORS middleware to handle web clients
-func addCORS(handler http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, Connect-Protocol-Version, Connect-Timeout-Ms")
-		w.Header().Set("Access-Control-Expose-Headers", "Connect-Protocol-Version, Connect-Timeout-Ms")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
-		handler.ServeHTTP(w, r)
-	})
-}
-
 // consoleViewerHandler serves the console HTML interface
-func consoleViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler) {
+func consoleViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler, language string) {
 	// Extract session ID from URL parameters
 	vars := mux.Vars(r)
 	sessionID := vars["sessionId"]
@@ -494,9 +839,12 @@ func consoleViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler
 
 	// Search for web session with this SOL session ID
 	webSession := findWebSessionBySOLSessionID(sessionStore, sessionID)
+	if !authorizeViewer(w, r, gatewayHandler, sessionID, webSession) {
+		return
+	}
 	if webSession != nil {
 		// Set the session cookie for the browser (infer security from request)
-		cookie := session.CreateSessionCookieForRequest(webSession.ID, int(session.DefaultSessionDuration.Seconds()), r)
+		cookie := session.CreateSessionCookieForRequest(webSession.ID, int(gatewayHandler.WebSessionTTL().Seconds()), r)
 		http.SetCookie(w, cookie)
 
 		log.Debug().
@@ -511,24 +859,29 @@ func consoleViewerHandler(w http.ResponseWriter, r *http.Request, gatewayHandler
 	}
 
 	// Generate WebSocket URL for this session
-	protocol := "ws"
-	if r.TLS != nil {
-		protocol = "wss"
-	}
-	wsURL := protocol + "://" + r.Host + "/console/" + sessionID + "/ws"
+	protocol := viewerWebSocketScheme(r)
+	wsURL := protocol + "://" + r.Host + "/console/" + sessionID + "/ws?" +
+		session.AccessTokenParam + "=" + url.QueryEscape(gatewayHandler.StreamAccessToken(solSession))
 
 	// Prepare data for console template
+	lang := webui.RequestLanguage(r, language)
+	p := webui.Printer(lang)
 	data := webui.ConsoleData{
 		TemplateData: webui.TemplateData{
-			Title:         "SOL Console - " + solSession.ServerID,
+			Title:         p.Sprintf("console.title", solSession.ServerID),
 			IconText:      "SOL",
-			HeaderTitle:   "SOL Console - " + solSession.ServerID,
-			InitialStatus: "Connecting...",
+			HeaderTitle:   p.Sprintf("console.title", solSession.ServerID),
+			InitialStatus: p.Sprintf("js.status.connecting"),
+			CSRFToken:     viewerCSRFToken(gatewayHandler, webSession),
+			ServerID:      solSession.ServerID,
+			Maintenance:   viewerMaintenance(gatewayHandler, webSession),
+			Theme:         viewerTheme(r, webSession),
+			Lang:          lang,
 		},
 		SessionID:       sessionID,
-		ServerID:        solSession.ServerID,
 		GatewayEndpoint: r.Host,
 		WebSocketURL:    wsURL,
+		ReadOnly:        solSession.ReadOnly,
 	}
 
 	// Render console template
@@ -568,6 +921,23 @@ func consoleWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHand
 		return
 	}
 
+	if !authorizeConsoleStream(w, r, gatewayHandler, solSession) {
+		return
+	}
+
+	terminal, err := consoleTerminalHints(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid terminal hints: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	release, err := gatewayHandler.AdmitConsoleStream(solSession.CustomerID)
+	if err != nil {
+		rejectSaturatedWebSocket(w, sessionID, err)
+		return
+	}
+	defer release()
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -585,7 +955,8 @@ func consoleWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHand
 	// The terminal client will connect and immediately start proxying SOL data
 
 	// Proxy SOL data through the agent
-	err = proxySOLThroughAgent(conn, solSession, gatewayHandler)
+	notices := r.URL.Query().Get(gateway.ConsoleNoticesParam) == "1"
+	err = proxySOLThroughAgent(tracing.ExtractHeader(r.Context(), r.Header), conn, solSession, gatewayHandler, terminal, notices)
 	if err != nil {
 		log.Error().Err(err).Msg("SOL proxy error")
 	}
@@ -593,6 +964,172 @@ func consoleWebSocketHandler(w http.ResponseWriter, r *http.Request, gatewayHand
 	log.Info().Str("session_id", sessionID).Msg("Console WebSocket connection closed")
 }
 
+// rejectSaturatedWebSocket turns a console WebSocket away with 503 when the
+// gateway reached one of its stream limits, telling the client when to retry
+func rejectSaturatedWebSocket(w http.ResponseWriter, sessionID string, err error) {
+	retryAfter := time.Second
+	var saturated *gateway.StreamSaturatedError
+	if errors.As(err, &saturated) && saturated.RetryAfter > retryAfter {
+		retryAfter = saturated.RetryAfter
+	}
+
+	log.Warn().Err(err).Str("session_id", sessionID).Msg("Console WebSocket rejected, gateway saturated")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	http.Error(w, fmt.Sprintf("Gateway busy: %v, retry later", err), http.StatusServiceUnavailable)
+}
+
+// consoleTerminalHints returns the terminal hints of a console WebSocket URL,
+// given by web consoles negotiating their terminal with the agent in the
+// term, cols and rows parameters
+func consoleTerminalHints(r *http.Request) (streaming.TerminalHints, error) {
+	query := r.URL.Query()
+	metadata := make(map[string]string)
+	for key, param := range map[string]string{
+		streaming.MetadataTerminalType: "term",
+		streaming.MetadataTerminalCols: "cols",
+		streaming.MetadataTerminalRows: "rows",
+	} {
+		if value := query.Get(param); value != "" {
+			metadata[key] = value
+		}
+	}
+	return streaming.TerminalHintsOf(metadata)
+}
+
+// closeViewerWebSocket closes a viewer WebSocket, telling the browser why the
+// console went away: the stream error reported by the agent, or the notice of
+// an admin termination or session expiry. attached is nil when the stream was
+// never attached.
+func closeViewerWebSocket(wsConn *websocket.Conn, attached *gateway.AttachedStream, err error) {
+	code, reason := websocket.CloseNormalClosure, ""
+	if streamErr := streaming.AsStreamError(err); streamErr != nil {
+		code, reason = streaming.CloseStreamError, streamErr.CloseReason()
+	} else if attached != nil {
+		if notice := attached.TerminationNotice(); notice != "" {
+			code, reason = websocket.ClosePolicyViolation, streaming.CloseReason(notice)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	wsConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+}
+
+// viewerWebSocketScheme returns the WebSocket scheme matching the scheme a
+// viewer page was served with, including behind TLS-terminating proxies
+func viewerWebSocketScheme(r *http.Request) string {
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		return "wss"
+	}
+	return "ws"
+}
+
+// authorizeViewer admits a viewer page request carrying the single-use token
+// of its viewer URL, or the session cookie set by an earlier visit so that
+// the page can be reloaded. It writes the error response otherwise.
+func authorizeViewer(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler, sessionID string, webSession *session.WebSession) bool {
+	if cookie, err := r.Cookie(session.CookieName); err == nil && webSession != nil && cookie.Value == webSession.ID {
+		return true
+	}
+
+	if err := gatewayHandler.RedeemViewerToken(sessionID, r.URL.Query().Get(session.AccessTokenParam)); err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", sessionID).
+			Str("remote_addr", r.RemoteAddr).
+			Msg("Rejected viewer request without a valid access token")
+		http.Error(w, "Console link is invalid, expired or already used", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeConsoleStream validates the access token of a console WebSocket
+// URL, the client's address against the customer's IP allowlist, and that
+// the manager still authorizes the session, before the connection is
+// upgraded. It writes the error response when the token is missing or
+// invalid, or the session is no longer authorized.
+func authorizeConsoleStream(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler, consoleSession *gateway.ConsoleSession) bool {
+	if err := gatewayHandler.CheckSourceAllowed(consoleSession, r.RemoteAddr); err != nil {
+		http.Error(w, "Console access is not allowed from this address", http.StatusForbidden)
+		return false
+	}
+
+	if err := gatewayHandler.VerifyStreamToken(consoleSession.SessionID, r.URL.Query().Get(session.AccessTokenParam)); err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", consoleSession.SessionID).
+			Str("remote_addr", r.RemoteAddr).
+			Msg("Rejected console WebSocket without a valid access token")
+		http.Error(w, "Console access token is invalid or expired", http.StatusForbidden)
+		return false
+	}
+
+	if err := gatewayHandler.AuthorizeConsoleSession(r.Context(), consoleSession); err != nil {
+		if errors.Is(err, gateway.ErrSessionRevoked) {
+			http.Error(w, "Console session is no longer authorized", http.StatusForbidden)
+		} else {
+			http.Error(w, "Console session authorization is unavailable", http.StatusServiceUnavailable)
+		}
+		return false
+	}
+	return true
+}
+
+// viewerCSRFToken returns the CSRF token rendered into a viewer page, empty
+// when the page has no web session
+func viewerCSRFToken(gatewayHandler *gateway.RegionalGatewayHandler, webSession *session.WebSession) string {
+	if webSession == nil {
+		return ""
+	}
+	return gatewayHandler.CSRFToken(webSession.ID)
+}
+
+// viewerMaintenance reports whether the power operations of a viewer page
+// are blocked by the maintenance of its server
+func viewerMaintenance(gatewayHandler *gateway.RegionalGatewayHandler, webSession *session.WebSession) bool {
+	if webSession == nil {
+		return false
+	}
+	return gatewayHandler.MaintenanceBlocksPower(webSession.CustomerJWT)
+}
+
+// viewerTheme returns the theme of a viewer page, the one chosen in its web
+// session or else in the browser
+func viewerTheme(r *http.Request, webSession *session.WebSession) string {
+	if webSession == nil {
+		return webui.RequestTheme(r)
+	}
+	return webui.RequestTheme(r, webSession.Theme)
+}
+
+// themePreferenceHandler stores the theme chosen on a web UI page. The theme
+// is saved in the page's web session when the request carries its cookie and
+// CSRF token, and in a cookie for the pages without a web session.
+func themePreferenceHandler(w http.ResponseWriter, r *http.Request, gatewayHandler *gateway.RegionalGatewayHandler) {
+	var req struct {
+		Theme string `json:"theme"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req); err != nil || !webui.ValidTheme(req.Theme) {
+		http.Error(w, "Invalid theme", http.StatusBadRequest)
+		return
+	}
+
+	csrfToken := r.Header.Get(session.CSRFHeaderName)
+	if webSessionID, err := session.GetSessionIDFromCookie(r); err == nil && csrfToken != "" {
+		err := gatewayHandler.SetWebSessionTheme(webSessionID, csrfToken, req.Theme)
+		if errors.Is(err, gateway.ErrInvalidCSRFToken) {
+			http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("web_session_id", webSessionID).Msg("Failed to save theme in web session")
+		}
+	}
+
+	http.SetCookie(w, webui.ThemeCookie(req.Theme, r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // findWebSessionBySOLSessionID finds a web session by SOL session ID
 func findWebSessionBySOLSessionID(sessionStore session.Store, solSessionID string) *session.WebSession {
 	webSession, err := sessionStore.GetBySOLSessionID(solSessionID)
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+
+	baseconf "core/config"
+	"gateway/pkg/config"
+)
+
+// validateConfig loads and validates the configuration, and checks that the
+// endpoints it declares are reachable, without starting the gateway
+func validateConfig(ctx context.Context, configFile, envFile string) *baseconf.ValidationReport {
+	report := baseconf.NewValidationReport("gateway", configFile, envFile)
+	report.AddFiles()
+
+	cfg, err := config.Load(configFile, envFile)
+	report.AddResult("configuration", err)
+	if err != nil {
+		return report
+	}
+
+	if cfg.Auth.DemoManagerAccount {
+		report.Add(baseconf.Check{
+			Name:   "manager account",
+			Target: cfg.Auth.ManagerEmail,
+			Status: baseconf.CheckWarning,
+			Detail: "demo account enabled, for development stacks only",
+		})
+	}
+
+	report.Add(baseconf.CheckListen(cfg.GetListenAddress()))
+	report.Add(baseconf.CheckReachable(ctx, "manager", cfg.Gateway.ManagerEndpoint, "", nil))
+	report.CheckTLS(cfg.TLS)
+	report.CheckTracing(ctx, cfg.Tracing)
+	report.CheckEventBus(ctx, cfg.EventBus)
+	return report
+}
@@ -23,6 +23,119 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PowerOperation selects the operation of RunPowerOperation
+type PowerOperation int32
+
+const (
+	PowerOperation_POWER_OPERATION_UNSPECIFIED          PowerOperation = 0
+	PowerOperation_POWER_OPERATION_ON                   PowerOperation = 1
+	PowerOperation_POWER_OPERATION_FORCE_OFF            PowerOperation = 2
+	PowerOperation_POWER_OPERATION_CYCLE                PowerOperation = 3
+	PowerOperation_POWER_OPERATION_RESET                PowerOperation = 4
+	PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT PowerOperation = 5 // Requires the power:diag permission
+)
+
+// Enum value maps for PowerOperation.
+var (
+	PowerOperation_name = map[int32]string{
+		0: "POWER_OPERATION_UNSPECIFIED",
+		1: "POWER_OPERATION_ON",
+		2: "POWER_OPERATION_FORCE_OFF",
+		3: "POWER_OPERATION_CYCLE",
+		4: "POWER_OPERATION_RESET",
+		5: "POWER_OPERATION_DIAGNOSTIC_INTERRUPT",
+	}
+	PowerOperation_value = map[string]int32{
+		"POWER_OPERATION_UNSPECIFIED":          0,
+		"POWER_OPERATION_ON":                   1,
+		"POWER_OPERATION_FORCE_OFF":            2,
+		"POWER_OPERATION_CYCLE":                3,
+		"POWER_OPERATION_RESET":                4,
+		"POWER_OPERATION_DIAGNOSTIC_INTERRUPT": 5,
+	}
+)
+
+func (x PowerOperation) Enum() *PowerOperation {
+	p := new(PowerOperation)
+	*p = x
+	return p
+}
+
+func (x PowerOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_v1_gateway_proto_enumTypes[0].Descriptor()
+}
+
+func (PowerOperation) Type() protoreflect.EnumType {
+	return &file_gateway_v1_gateway_proto_enumTypes[0]
+}
+
+func (x PowerOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerOperation.Descriptor instead.
+func (PowerOperation) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+// OperationState is the state of an operation started with
+// StartPowerOperation
+type OperationState int32
+
+const (
+	OperationState_OPERATION_STATE_UNSPECIFIED OperationState = 0
+	OperationState_OPERATION_STATE_RUNNING     OperationState = 1
+	OperationState_OPERATION_STATE_SUCCEEDED   OperationState = 2
+	OperationState_OPERATION_STATE_FAILED      OperationState = 3
+)
+
+// Enum value maps for OperationState.
+var (
+	OperationState_name = map[int32]string{
+		0: "OPERATION_STATE_UNSPECIFIED",
+		1: "OPERATION_STATE_RUNNING",
+		2: "OPERATION_STATE_SUCCEEDED",
+		3: "OPERATION_STATE_FAILED",
+	}
+	OperationState_value = map[string]int32{
+		"OPERATION_STATE_UNSPECIFIED": 0,
+		"OPERATION_STATE_RUNNING":     1,
+		"OPERATION_STATE_SUCCEEDED":   2,
+		"OPERATION_STATE_FAILED":      3,
+	}
+)
+
+func (x OperationState) Enum() *OperationState {
+	p := new(OperationState)
+	*p = x
+	return p
+}
+
+func (x OperationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_v1_gateway_proto_enumTypes[1].Descriptor()
+}
+
+func (OperationState) Type() protoreflect.EnumType {
+	return &file_gateway_v1_gateway_proto_enumTypes[1]
+}
+
+func (x OperationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationState.Descriptor instead.
+func (OperationState) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{1}
+}
+
 // PowerState represents the various power states a server can be in
 type PowerState int32
 
@@ -60,11 +173,11 @@ func (x PowerState) String() string {
 }
 
 func (PowerState) Descriptor() protoreflect.EnumDescriptor {
-	return file_gateway_v1_gateway_proto_enumTypes[0].Descriptor()
+	return file_gateway_v1_gateway_proto_enumTypes[2].Descriptor()
 }
 
 func (PowerState) Type() protoreflect.EnumType {
-	return &file_gateway_v1_gateway_proto_enumTypes[0]
+	return &file_gateway_v1_gateway_proto_enumTypes[2]
 }
 
 func (x PowerState) Number() protoreflect.EnumNumber {
@@ -73,7 +186,60 @@ func (x PowerState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PowerState.Descriptor instead.
 func (PowerState) EnumDescriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{0}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+// IdentifyState is the requested state of the chassis identify LED
+type IdentifyState int32
+
+const (
+	IdentifyState_IDENTIFY_STATE_UNSPECIFIED IdentifyState = 0
+	IdentifyState_IDENTIFY_STATE_OFF         IdentifyState = 1 // LED off
+	IdentifyState_IDENTIFY_STATE_ON          IdentifyState = 2 // LED lit until turned off
+	IdentifyState_IDENTIFY_STATE_BLINK       IdentifyState = 3 // LED blinking for duration_seconds, then off
+)
+
+// Enum value maps for IdentifyState.
+var (
+	IdentifyState_name = map[int32]string{
+		0: "IDENTIFY_STATE_UNSPECIFIED",
+		1: "IDENTIFY_STATE_OFF",
+		2: "IDENTIFY_STATE_ON",
+		3: "IDENTIFY_STATE_BLINK",
+	}
+	IdentifyState_value = map[string]int32{
+		"IDENTIFY_STATE_UNSPECIFIED": 0,
+		"IDENTIFY_STATE_OFF":         1,
+		"IDENTIFY_STATE_ON":          2,
+		"IDENTIFY_STATE_BLINK":       3,
+	}
+)
+
+func (x IdentifyState) Enum() *IdentifyState {
+	p := new(IdentifyState)
+	*p = x
+	return p
+}
+
+func (x IdentifyState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IdentifyState) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_v1_gateway_proto_enumTypes[3].Descriptor()
+}
+
+func (IdentifyState) Type() protoreflect.EnumType {
+	return &file_gateway_v1_gateway_proto_enumTypes[3]
+}
+
+func (x IdentifyState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IdentifyState.Descriptor instead.
+func (IdentifyState) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{3}
 }
 
 // ConsoleAvailability indicates which console types are available in the current boot phase
@@ -116,11 +282,11 @@ func (x ConsoleAvailability) String() string {
 }
 
 func (ConsoleAvailability) Descriptor() protoreflect.EnumDescriptor {
-	return file_gateway_v1_gateway_proto_enumTypes[1].Descriptor()
+	return file_gateway_v1_gateway_proto_enumTypes[4].Descriptor()
 }
 
 func (ConsoleAvailability) Type() protoreflect.EnumType {
-	return &file_gateway_v1_gateway_proto_enumTypes[1]
+	return &file_gateway_v1_gateway_proto_enumTypes[4]
 }
 
 func (x ConsoleAvailability) Number() protoreflect.EnumNumber {
@@ -129,7 +295,7 @@ func (x ConsoleAvailability) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConsoleAvailability.Descriptor instead.
 func (ConsoleAvailability) EnumDescriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{1}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{4}
 }
 
 // HealthCheckRequest - empty request for service health verification
@@ -225,10 +391,17 @@ func (x *HealthCheckResponse) GetTimestamp() *timestamppb.Timestamp {
 // PowerOperationRequest is used for all power operations (on, off, cycle, reset)
 // CLI sends server_id, Gateway resolves to BMC endpoint using delegated token
 type PowerOperationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to perform the power operation on
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to perform the power operation on
+	// Runs the operation during a maintenance window that requires an override.
+	// Overrides are audit logged.
+	MaintenanceOverride bool `protobuf:"varint,2,opt,name=maintenance_override,json=maintenanceOverride,proto3" json:"maintenance_override,omitempty"`
+	// Client-chosen key, e.g. a UUID, at most 128 characters. Retries with the
+	// same key within the idempotency window get the result of the first
+	// request back instead of running the operation again. Empty to always run.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PowerOperationRequest) Reset() {
@@ -268,6 +441,20 @@ func (x *PowerOperationRequest) GetServerId() string {
 	return ""
 }
 
+func (x *PowerOperationRequest) GetMaintenanceOverride() bool {
+	if x != nil {
+		return x.MaintenanceOverride
+	}
+	return false
+}
+
+func (x *PowerOperationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // PowerOperationResponse indicates the result of a power operation
 type PowerOperationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -321,29 +508,32 @@ func (x *PowerOperationResponse) GetMessage() string {
 	return ""
 }
 
-// PowerStatusRequest queries the current power state of a server
-// CLI sends server_id, Gateway resolves to BMC endpoint using delegated token
-type PowerStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to query power status for
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// GracefulShutdownRequest asks the OS of a server to shut down
+type GracefulShutdownRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	// Forces the server off if it is still on after this many seconds, 0 to
+	// leave it to the OS
+	ForceAfterSeconds   int32 `protobuf:"varint,2,opt,name=force_after_seconds,json=forceAfterSeconds,proto3" json:"force_after_seconds,omitempty"`
+	MaintenanceOverride bool  `protobuf:"varint,3,opt,name=maintenance_override,json=maintenanceOverride,proto3" json:"maintenance_override,omitempty"` // See PowerOperationRequest
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *PowerStatusRequest) Reset() {
-	*x = PowerStatusRequest{}
+func (x *GracefulShutdownRequest) Reset() {
+	*x = GracefulShutdownRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PowerStatusRequest) String() string {
+func (x *GracefulShutdownRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PowerStatusRequest) ProtoMessage() {}
+func (*GracefulShutdownRequest) ProtoMessage() {}
 
-func (x *PowerStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *GracefulShutdownRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -355,41 +545,57 @@ func (x *PowerStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PowerStatusRequest.ProtoReflect.Descriptor instead.
-func (*PowerStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GracefulShutdownRequest.ProtoReflect.Descriptor instead.
+func (*GracefulShutdownRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PowerStatusRequest) GetServerId() string {
+func (x *GracefulShutdownRequest) GetServerId() string {
 	if x != nil {
 		return x.ServerId
 	}
 	return ""
 }
 
-// PowerStatusResponse contains the current power state of a server
-type PowerStatusResponse struct {
+func (x *GracefulShutdownRequest) GetForceAfterSeconds() int32 {
+	if x != nil {
+		return x.ForceAfterSeconds
+	}
+	return 0
+}
+
+func (x *GracefulShutdownRequest) GetMaintenanceOverride() bool {
+	if x != nil {
+		return x.MaintenanceOverride
+	}
+	return false
+}
+
+// GracefulShutdownResponse indicates that the shutdown was requested; the OS
+// shuts down asynchronously
+type GracefulShutdownResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         PowerState             `protobuf:"varint,1,opt,name=state,proto3,enum=gateway.v1.PowerState" json:"state,omitempty"` // Current power state of the server
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                         // Additional status information or error details
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ForceAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=force_at,json=forceAt,proto3" json:"force_at,omitempty"` // When the server is forced off if still on, unset otherwise
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PowerStatusResponse) Reset() {
-	*x = PowerStatusResponse{}
+func (x *GracefulShutdownResponse) Reset() {
+	*x = GracefulShutdownResponse{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PowerStatusResponse) String() string {
+func (x *GracefulShutdownResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PowerStatusResponse) ProtoMessage() {}
+func (*GracefulShutdownResponse) ProtoMessage() {}
 
-func (x *PowerStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *GracefulShutdownResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -401,50 +607,57 @@ func (x *PowerStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PowerStatusResponse.ProtoReflect.Descriptor instead.
-func (*PowerStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GracefulShutdownResponse.ProtoReflect.Descriptor instead.
+func (*GracefulShutdownResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PowerStatusResponse) GetState() PowerState {
+func (x *GracefulShutdownResponse) GetSuccess() bool {
 	if x != nil {
-		return x.State
+		return x.Success
 	}
-	return PowerState_POWER_STATE_UNKNOWN
+	return false
 }
 
-func (x *PowerStatusResponse) GetMessage() string {
+func (x *GracefulShutdownResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// RegisterAgentRequest is sent by Local Agents to register with the Gateway
-type RegisterAgentRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	AgentId       string                     `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Unique identifier for this agent instance
-	DatacenterId  string                     `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"` // Datacenter where this agent is deployed
-	Endpoint      string                     `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                             // Agent's internal endpoint for callbacks (if any)
-	BmcEndpoints  []*BMCEndpointRegistration `protobuf:"bytes,4,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // Initial list of BMC endpoints managed by this agent
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GracefulShutdownResponse) GetForceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ForceAt
+	}
+	return nil
 }
 
-func (x *RegisterAgentRequest) Reset() {
-	*x = RegisterAgentRequest{}
+// RunPowerOperationRequest performs a power operation and follows it to
+// completion
+type RunPowerOperationRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ServerId            string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Operation           PowerOperation         `protobuf:"varint,2,opt,name=operation,proto3,enum=gateway.v1.PowerOperation" json:"operation,omitempty"`
+	MaintenanceOverride bool                   `protobuf:"varint,3,opt,name=maintenance_override,json=maintenanceOverride,proto3" json:"maintenance_override,omitempty"` // See PowerOperationRequest
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RunPowerOperationRequest) Reset() {
+	*x = RunPowerOperationRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterAgentRequest) String() string {
+func (x *RunPowerOperationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterAgentRequest) ProtoMessage() {}
+func (*RunPowerOperationRequest) ProtoMessage() {}
 
-func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+func (x *RunPowerOperationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,62 +669,60 @@ func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
-func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunPowerOperationRequest.ProtoReflect.Descriptor instead.
+func (*RunPowerOperationRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *RegisterAgentRequest) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
-}
-
-func (x *RegisterAgentRequest) GetDatacenterId() string {
+func (x *RunPowerOperationRequest) GetServerId() string {
 	if x != nil {
-		return x.DatacenterId
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *RegisterAgentRequest) GetEndpoint() string {
+func (x *RunPowerOperationRequest) GetOperation() PowerOperation {
 	if x != nil {
-		return x.Endpoint
+		return x.Operation
 	}
-	return ""
+	return PowerOperation_POWER_OPERATION_UNSPECIFIED
 }
 
-func (x *RegisterAgentRequest) GetBmcEndpoints() []*BMCEndpointRegistration {
+func (x *RunPowerOperationRequest) GetMaintenanceOverride() bool {
 	if x != nil {
-		return x.BmcEndpoints
+		return x.MaintenanceOverride
 	}
-	return nil
+	return false
 }
 
-// RegisterAgentResponse confirms agent registration
-type RegisterAgentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether registration was successful
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Success confirmation or error details
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// PowerOperationProgress reports the progress of a power operation. The last
+// message of the stream has done set; a failed operation ends the stream
+// with an error instead.
+type PowerOperationProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`                             // Redfish task running the operation, empty if the BMC completed it immediately
+	State           string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                                             // Redfish TaskState, e.g. "Running", "Completed"
+	PercentComplete int32                  `protobuf:"varint,3,opt,name=percent_complete,json=percentComplete,proto3" json:"percent_complete,omitempty"` // 0-100, -1 if the BMC does not report it
+	Message         string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                         // Human-readable status message
+	Done            bool                   `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`                                              // The operation completed successfully
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *RegisterAgentResponse) Reset() {
-	*x = RegisterAgentResponse{}
+func (x *PowerOperationProgress) Reset() {
+	*x = PowerOperationProgress{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterAgentResponse) String() string {
+func (x *PowerOperationProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterAgentResponse) ProtoMessage() {}
+func (*PowerOperationProgress) ProtoMessage() {}
 
-func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+func (x *PowerOperationProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -523,48 +734,76 @@ func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
-func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PowerOperationProgress.ProtoReflect.Descriptor instead.
+func (*PowerOperationProgress) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *RegisterAgentResponse) GetSuccess() bool {
+func (x *PowerOperationProgress) GetTaskId() string {
 	if x != nil {
-		return x.Success
+		return x.TaskId
 	}
-	return false
+	return ""
 }
 
-func (x *RegisterAgentResponse) GetMessage() string {
+func (x *PowerOperationProgress) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PowerOperationProgress) GetPercentComplete() int32 {
+	if x != nil {
+		return x.PercentComplete
+	}
+	return 0
+}
+
+func (x *PowerOperationProgress) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// AgentHeartbeatRequest maintains the agent connection and updates BMC endpoint inventory
-type AgentHeartbeatRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	AgentId       string                     `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Agent identifier from registration
-	BmcEndpoints  []*BMCEndpointRegistration `protobuf:"bytes,2,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // Current list of BMC endpoints (may have changed since registration)
+func (x *PowerOperationProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// Operation is a power operation tracked by the gateway
+type Operation struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServerId      string                  `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Operation     PowerOperation          `protobuf:"varint,3,opt,name=operation,proto3,enum=gateway.v1.PowerOperation" json:"operation,omitempty"`
+	State         OperationState          `protobuf:"varint,4,opt,name=state,proto3,enum=gateway.v1.OperationState" json:"state,omitempty"`
+	Progress      *PowerOperationProgress `protobuf:"bytes,5,opt,name=progress,proto3" json:"progress,omitempty"` // Last progress reported by the agent, nil before the first
+	Error         string                  `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`       // Why the operation failed
+	StartedAt     *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp  `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp  `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"` // Nil while running
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentHeartbeatRequest) Reset() {
-	*x = AgentHeartbeatRequest{}
+func (x *Operation) Reset() {
+	*x = Operation{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentHeartbeatRequest) String() string {
+func (x *Operation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentHeartbeatRequest) ProtoMessage() {}
+func (*Operation) ProtoMessage() {}
 
-func (x *AgentHeartbeatRequest) ProtoReflect() protoreflect.Message {
+func (x *Operation) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -576,110 +815,96 @@ func (x *AgentHeartbeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentHeartbeatRequest.ProtoReflect.Descriptor instead.
-func (*AgentHeartbeatRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *AgentHeartbeatRequest) GetAgentId() string {
+func (x *Operation) GetId() string {
 	if x != nil {
-		return x.AgentId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AgentHeartbeatRequest) GetBmcEndpoints() []*BMCEndpointRegistration {
+func (x *Operation) GetServerId() string {
 	if x != nil {
-		return x.BmcEndpoints
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-// AgentHeartbeatResponse acknowledges heartbeat and provides configuration
-type AgentHeartbeatResponse struct {
-	state                    protoimpl.MessageState `protogen:"open.v1"`
-	Success                  bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                                                     // Whether heartbeat was accepted
-	HeartbeatIntervalSeconds int32                  `protobuf:"varint,2,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"` // How often the agent should send heartbeats (e.g., 30 seconds)
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+func (x *Operation) GetOperation() PowerOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return PowerOperation_POWER_OPERATION_UNSPECIFIED
 }
 
-func (x *AgentHeartbeatResponse) Reset() {
-	*x = AgentHeartbeatResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Operation) GetState() OperationState {
+	if x != nil {
+		return x.State
+	}
+	return OperationState_OPERATION_STATE_UNSPECIFIED
 }
 
-func (x *AgentHeartbeatResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Operation) GetProgress() *PowerOperationProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
 }
 
-func (*AgentHeartbeatResponse) ProtoMessage() {}
-
-func (x *AgentHeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[9]
+func (x *Operation) GetError() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Error
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AgentHeartbeatResponse.ProtoReflect.Descriptor instead.
-func (*AgentHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{9}
+func (x *Operation) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
 }
 
-func (x *AgentHeartbeatResponse) GetSuccess() bool {
+func (x *Operation) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Success
+		return x.UpdatedAt
 	}
-	return false
+	return nil
 }
 
-func (x *AgentHeartbeatResponse) GetHeartbeatIntervalSeconds() int32 {
+func (x *Operation) GetCompletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.HeartbeatIntervalSeconds
+		return x.CompletedAt
 	}
-	return 0
+	return nil
 }
 
-// BMCEndpointRegistration describes a server with separate endpoint types
-// Agents register servers with distinct control, SOL, and VNC endpoints
-type BMCEndpointRegistration struct {
-	state             protoimpl.MessageState   `protogen:"open.v1"`
-	ServerId          string                   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                                           // Logical server identifier
-	ControlEndpoints  []*v1.BMCControlEndpoint `protobuf:"bytes,2,rep,name=control_endpoints,json=controlEndpoints,proto3" json:"control_endpoints,omitempty"`                                   // Multiple protocol support (RFD 006)
-	PrimaryProtocol   v1.BMCType               `protobuf:"varint,3,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"`              // Preferred protocol for operations
-	SolEndpoint       *v1.SOLEndpoint          `protobuf:"bytes,4,opt,name=sol_endpoint,json=solEndpoint,proto3" json:"sol_endpoint,omitempty"`                                                  // Serial-over-LAN access (optional)
-	VncEndpoint       *v1.VNCEndpoint          `protobuf:"bytes,5,opt,name=vnc_endpoint,json=vncEndpoint,proto3" json:"vnc_endpoint,omitempty"`                                                  // VNC/KVM access (optional)
-	Features          []string                 `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`                                                                           // High-level features (power, sensors, etc.)
-	Status            string                   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                                                               // Overall server status
-	Metadata          map[string]string        `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional server metadata
-	DiscoveryMetadata *v1.DiscoveryMetadata    `protobuf:"bytes,9,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"`                                // Discovery metadata (RFD 017)
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type StartPowerOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCEndpointRegistration) Reset() {
-	*x = BMCEndpointRegistration{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[10]
+func (x *StartPowerOperationResponse) Reset() {
+	*x = StartPowerOperationResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCEndpointRegistration) String() string {
+func (x *StartPowerOperationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BMCEndpointRegistration) ProtoMessage() {}
+func (*StartPowerOperationResponse) ProtoMessage() {}
 
-func (x *BMCEndpointRegistration) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[10]
+func (x *StartPowerOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,97 +915,93 @@ func (x *BMCEndpointRegistration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BMCEndpointRegistration.ProtoReflect.Descriptor instead.
-func (*BMCEndpointRegistration) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *BMCEndpointRegistration) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
+// Deprecated: Use StartPowerOperationResponse.ProtoReflect.Descriptor instead.
+func (*StartPowerOperationResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BMCEndpointRegistration) GetControlEndpoints() []*v1.BMCControlEndpoint {
+func (x *StartPowerOperationResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.ControlEndpoints
+		return x.Operation
 	}
 	return nil
 }
 
-func (x *BMCEndpointRegistration) GetPrimaryProtocol() v1.BMCType {
-	if x != nil {
-		return x.PrimaryProtocol
-	}
-	return v1.BMCType(0)
+// GetOperationRequest selects an operation of a server, authorized by the
+// server's token
+type GetOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCEndpointRegistration) GetSolEndpoint() *v1.SOLEndpoint {
-	if x != nil {
-		return x.SolEndpoint
-	}
-	return nil
+func (x *GetOperationRequest) Reset() {
+	*x = GetOperationRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCEndpointRegistration) GetVncEndpoint() *v1.VNCEndpoint {
-	if x != nil {
-		return x.VncEndpoint
-	}
-	return nil
+func (x *GetOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *BMCEndpointRegistration) GetFeatures() []string {
+func (*GetOperationRequest) ProtoMessage() {}
+
+func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[10]
 	if x != nil {
-		return x.Features
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BMCEndpointRegistration) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+// Deprecated: Use GetOperationRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BMCEndpointRegistration) GetMetadata() map[string]string {
+func (x *GetOperationRequest) GetServerId() string {
 	if x != nil {
-		return x.Metadata
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-func (x *BMCEndpointRegistration) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
+func (x *GetOperationRequest) GetOperationId() string {
 	if x != nil {
-		return x.DiscoveryMetadata
+		return x.OperationId
 	}
-	return nil
+	return ""
 }
 
-// CreateVNCSessionRequest creates a new VNC console session
-// CLI sends server_id, Gateway resolves to BMC endpoint using delegated token
-type CreateVNCSessionRequest struct {
+type GetOperationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID for which to create a VNC session
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVNCSessionRequest) Reset() {
-	*x = CreateVNCSessionRequest{}
+func (x *GetOperationResponse) Reset() {
+	*x = GetOperationResponse{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVNCSessionRequest) String() string {
+func (x *GetOperationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVNCSessionRequest) ProtoMessage() {}
+func (*GetOperationResponse) ProtoMessage() {}
 
-func (x *CreateVNCSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -792,43 +1013,39 @@ func (x *CreateVNCSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVNCSessionRequest.ProtoReflect.Descriptor instead.
-func (*CreateVNCSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOperationResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreateVNCSessionRequest) GetServerId() string {
+func (x *GetOperationResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.ServerId
+		return x.Operation
 	}
-	return ""
+	return nil
 }
 
-// CreateVNCSessionResponse provides the created VNC session details
-type CreateVNCSessionResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                         // Unique VNC session identifier
-	WebsocketEndpoint string                 `protobuf:"bytes,2,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for VNC streaming (e.g., "wss://gateway.example.com/vnc/session123")
-	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires and will be automatically closed
-	ViewerUrl         string                 `protobuf:"bytes,4,opt,name=viewer_url,json=viewerUrl,proto3" json:"viewer_url,omitempty"`                         // Direct URL to web-based VNC viewer
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type ListOperationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVNCSessionResponse) Reset() {
-	*x = CreateVNCSessionResponse{}
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVNCSessionResponse) String() string {
+func (x *ListOperationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVNCSessionResponse) ProtoMessage() {}
+func (*ListOperationsRequest) ProtoMessage() {}
 
-func (x *CreateVNCSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -840,61 +1057,39 @@ func (x *CreateVNCSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVNCSessionResponse.ProtoReflect.Descriptor instead.
-func (*CreateVNCSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CreateVNCSessionResponse) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *CreateVNCSessionResponse) GetWebsocketEndpoint() string {
-	if x != nil {
-		return x.WebsocketEndpoint
-	}
-	return ""
-}
-
-func (x *CreateVNCSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-func (x *CreateVNCSessionResponse) GetViewerUrl() string {
+func (x *ListOperationsRequest) GetServerId() string {
 	if x != nil {
-		return x.ViewerUrl
+		return x.ServerId
 	}
 	return ""
 }
 
-// GetVNCSessionRequest retrieves information about an existing VNC session
-type GetVNCSessionRequest struct {
+type ListOperationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The VNC session ID to retrieve information for
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVNCSessionRequest) Reset() {
-	*x = GetVNCSessionRequest{}
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVNCSessionRequest) String() string {
+func (x *ListOperationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVNCSessionRequest) ProtoMessage() {}
+func (*ListOperationsResponse) ProtoMessage() {}
 
-func (x *GetVNCSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -906,48 +1101,41 @@ func (x *GetVNCSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVNCSessionRequest.ProtoReflect.Descriptor instead.
-func (*GetVNCSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetVNCSessionRequest) GetSessionId() string {
+func (x *ListOperationsResponse) GetOperations() []*Operation {
 	if x != nil {
-		return x.SessionId
+		return x.Operations
 	}
-	return ""
+	return nil
 }
 
-// VNCSession contains detailed information about a VNC console session
-type VNCSession struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                        // Unique session identifier
-	CustomerId        string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                      // Customer ID that owns this session
-	ServerId          string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                            // Target server ID for this session
-	AgentId           string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                               // Agent ID handling the VNC connection
-	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                                // Session status (e.g., "active", "connecting", "expired", "closed")
-	WebsocketEndpoint string                 `protobuf:"bytes,6,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for VNC streaming
-	ViewerUrl         string                 `protobuf:"bytes,7,opt,name=viewer_url,json=viewerUrl,proto3" json:"viewer_url,omitempty"`                         // Web-based VNC viewer URL
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // When the session was created
-	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// PowerStatusRequest queries the current power state of a server
+// CLI sends server_id, Gateway resolves to BMC endpoint using delegated token
+type PowerStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to query power status for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VNCSession) Reset() {
-	*x = VNCSession{}
+func (x *PowerStatusRequest) Reset() {
+	*x = PowerStatusRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VNCSession) String() string {
+func (x *PowerStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VNCSession) ProtoMessage() {}
+func (*PowerStatusRequest) ProtoMessage() {}
 
-func (x *VNCSession) ProtoReflect() protoreflect.Message {
+func (x *PowerStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -959,96 +1147,41 @@ func (x *VNCSession) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VNCSession.ProtoReflect.Descriptor instead.
-func (*VNCSession) Descriptor() ([]byte, []int) {
+// Deprecated: Use PowerStatusRequest.ProtoReflect.Descriptor instead.
+func (*PowerStatusRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *VNCSession) GetId() string {
+func (x *PowerStatusRequest) GetServerId() string {
 	if x != nil {
-		return x.Id
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *VNCSession) GetCustomerId() string {
-	if x != nil {
-		return x.CustomerId
-	}
-	return ""
+// PowerStatusResponse contains the current power state of a server
+type PowerStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         PowerState             `protobuf:"varint,1,opt,name=state,proto3,enum=gateway.v1.PowerState" json:"state,omitempty"` // Current power state of the server
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                         // Additional status information or error details
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VNCSession) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
+func (x *PowerStatusResponse) Reset() {
+	*x = PowerStatusResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *VNCSession) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
-}
-
-func (x *VNCSession) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *VNCSession) GetWebsocketEndpoint() string {
-	if x != nil {
-		return x.WebsocketEndpoint
-	}
-	return ""
-}
-
-func (x *VNCSession) GetViewerUrl() string {
-	if x != nil {
-		return x.ViewerUrl
-	}
-	return ""
-}
-
-func (x *VNCSession) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *VNCSession) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-// GetVNCSessionResponse contains the requested VNC session information
-type GetVNCSessionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Session       *VNCSession            `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"` // The VNC session details, or error if not found/accessible
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetVNCSessionResponse) Reset() {
-	*x = GetVNCSessionResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetVNCSessionResponse) String() string {
+func (x *PowerStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVNCSessionResponse) ProtoMessage() {}
+func (*PowerStatusResponse) ProtoMessage() {}
 
-func (x *GetVNCSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *PowerStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1060,40 +1193,49 @@ func (x *GetVNCSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVNCSessionResponse.ProtoReflect.Descriptor instead.
-func (*GetVNCSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PowerStatusResponse.ProtoReflect.Descriptor instead.
+func (*PowerStatusResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetVNCSessionResponse) GetSession() *VNCSession {
+func (x *PowerStatusResponse) GetState() PowerState {
 	if x != nil {
-		return x.Session
+		return x.State
 	}
-	return nil
+	return PowerState_POWER_STATE_UNKNOWN
 }
 
-// CloseVNCSessionRequest terminates an active VNC session
-type CloseVNCSessionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The VNC session ID to close and clean up
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PowerStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *CloseVNCSessionRequest) Reset() {
-	*x = CloseVNCSessionRequest{}
+// SetChassisIdentifyRequest controls the chassis identify LED of a server
+type SetChassisIdentifyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	State           IdentifyState          `protobuf:"varint,2,opt,name=state,proto3,enum=gateway.v1.IdentifyState" json:"state,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Required for IDENTIFY_STATE_BLINK
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetChassisIdentifyRequest) Reset() {
+	*x = SetChassisIdentifyRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseVNCSessionRequest) String() string {
+func (x *SetChassisIdentifyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseVNCSessionRequest) ProtoMessage() {}
+func (*SetChassisIdentifyRequest) ProtoMessage() {}
 
-func (x *CloseVNCSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *SetChassisIdentifyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1105,39 +1247,56 @@ func (x *CloseVNCSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseVNCSessionRequest.ProtoReflect.Descriptor instead.
-func (*CloseVNCSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetChassisIdentifyRequest.ProtoReflect.Descriptor instead.
+func (*SetChassisIdentifyRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CloseVNCSessionRequest) GetSessionId() string {
+func (x *SetChassisIdentifyRequest) GetServerId() string {
 	if x != nil {
-		return x.SessionId
+		return x.ServerId
 	}
 	return ""
 }
 
-// CloseVNCSessionResponse confirms VNC session closure
-type CloseVNCSessionResponse struct {
+func (x *SetChassisIdentifyRequest) GetState() IdentifyState {
+	if x != nil {
+		return x.State
+	}
+	return IdentifyState_IDENTIFY_STATE_UNSPECIFIED
+}
+
+func (x *SetChassisIdentifyRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// SetChassisIdentifyResponse indicates the result of an identify request
+type SetChassisIdentifyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OffAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=off_at,json=offAt,proto3" json:"off_at,omitempty"` // When a blinking LED turns off, unset otherwise
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CloseVNCSessionResponse) Reset() {
-	*x = CloseVNCSessionResponse{}
+func (x *SetChassisIdentifyResponse) Reset() {
+	*x = SetChassisIdentifyResponse{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseVNCSessionResponse) String() string {
+func (x *SetChassisIdentifyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseVNCSessionResponse) ProtoMessage() {}
+func (*SetChassisIdentifyResponse) ProtoMessage() {}
 
-func (x *CloseVNCSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *SetChassisIdentifyResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1149,33 +1308,58 @@ func (x *CloseVNCSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseVNCSessionResponse.ProtoReflect.Descriptor instead.
-func (*CloseVNCSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetChassisIdentifyResponse.ProtoReflect.Descriptor instead.
+func (*SetChassisIdentifyResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{17}
 }
 
-// CreateSOLSessionRequest creates a new SOL console session
-type CreateSOLSessionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID for which to create a SOL session
+func (x *SetChassisIdentifyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetChassisIdentifyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetChassisIdentifyResponse) GetOffAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OffAt
+	}
+	return nil
+}
+
+// RegisterAgentRequest is sent by Local Agents to register with the Gateway
+type RegisterAgentRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	AgentId       string                     `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Unique identifier for this agent instance
+	DatacenterId  string                     `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"` // Datacenter where this agent is deployed
+	Endpoint      string                     `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                             // Agent's internal endpoint for callbacks (if any)
+	BmcEndpoints  []*BMCEndpointRegistration `protobuf:"bytes,4,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // Initial list of BMC endpoints managed by this agent
+	Version       string                     `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`                               // Agent build version
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSOLSessionRequest) Reset() {
-	*x = CreateSOLSessionRequest{}
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSOLSessionRequest) String() string {
+func (x *RegisterAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSOLSessionRequest) ProtoMessage() {}
+func (*RegisterAgentRequest) ProtoMessage() {}
 
-func (x *CreateSOLSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1187,43 +1371,72 @@ func (x *CreateSOLSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSOLSessionRequest.ProtoReflect.Descriptor instead.
-func (*CreateSOLSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CreateSOLSessionRequest) GetServerId() string {
+func (x *RegisterAgentRequest) GetAgentId() string {
 	if x != nil {
-		return x.ServerId
+		return x.AgentId
 	}
 	return ""
 }
 
-// CreateSOLSessionResponse provides the created SOL session details
-type CreateSOLSessionResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                         // Unique SOL session identifier
-	WebsocketEndpoint string                 `protobuf:"bytes,2,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for SOL streaming (e.g., "wss://gateway.example.com/sol/session123")
-	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires and will be automatically closed
-	ConsoleUrl        string                 `protobuf:"bytes,4,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`                      // Direct URL to web-based SOL console
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *RegisterAgentRequest) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
 }
 
-func (x *CreateSOLSessionResponse) Reset() {
-	*x = CreateSOLSessionResponse{}
+func (x *RegisterAgentRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *RegisterAgentRequest) GetBmcEndpoints() []*BMCEndpointRegistration {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// RegisterAgentResponse confirms agent registration
+type RegisterAgentResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether registration was successful
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Success confirmation or error details
+	// Ed25519 public key verifying the tokens the gateway signs in the
+	// handshakes of the console streams it opens to the agent
+	StreamTokenKey []byte `protobuf:"bytes,3,opt,name=stream_token_key,json=streamTokenKey,proto3" json:"stream_token_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
 	mi := &file_gateway_v1_gateway_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSOLSessionResponse) String() string {
+func (x *RegisterAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSOLSessionResponse) ProtoMessage() {}
+func (*RegisterAgentResponse) ProtoMessage() {}
 
-func (x *CreateSOLSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_gateway_v1_gateway_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1235,62 +1448,3613 @@ func (x *CreateSOLSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSOLSessionResponse.ProtoReflect.Descriptor instead.
-func (*CreateSOLSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
 	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CreateSOLSessionResponse) GetSessionId() string {
+func (x *RegisterAgentResponse) GetSuccess() bool {
 	if x != nil {
-		return x.SessionId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *CreateSOLSessionResponse) GetWebsocketEndpoint() string {
+func (x *RegisterAgentResponse) GetMessage() string {
 	if x != nil {
-		return x.WebsocketEndpoint
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CreateSOLSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+func (x *RegisterAgentResponse) GetStreamTokenKey() []byte {
 	if x != nil {
-		return x.ExpiresAt
+		return x.StreamTokenKey
 	}
 	return nil
 }
 
-func (x *CreateSOLSessionResponse) GetConsoleUrl() string {
+// AgentHeartbeatRequest maintains the agent connection and updates BMC endpoint inventory
+type AgentHeartbeatRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	AgentId       string                     `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                // Agent identifier from registration
+	BmcEndpoints  []*BMCEndpointRegistration `protobuf:"bytes,2,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // Current list of BMC endpoints (may have changed since registration)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentHeartbeatRequest) Reset() {
+	*x = AgentHeartbeatRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHeartbeatRequest) ProtoMessage() {}
+
+func (x *AgentHeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*AgentHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AgentHeartbeatRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentHeartbeatRequest) GetBmcEndpoints() []*BMCEndpointRegistration {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+// AgentHeartbeatResponse acknowledges heartbeat and provides configuration
+type AgentHeartbeatResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Success                  bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                                                     // Whether heartbeat was accepted
+	HeartbeatIntervalSeconds int32                  `protobuf:"varint,2,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"` // How often the agent should send heartbeats (e.g., 30 seconds)
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AgentHeartbeatResponse) Reset() {
+	*x = AgentHeartbeatResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHeartbeatResponse) ProtoMessage() {}
+
+func (x *AgentHeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*AgentHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AgentHeartbeatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AgentHeartbeatResponse) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+// ReportHostDriftRequest carries the static hosts whose drift changed since
+// the previous report
+type ReportHostDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Agent identifier from registration
+	Hosts         []*HostDrift           `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`                    // Hosts that drifted or got back in sync
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHostDriftRequest) Reset() {
+	*x = ReportHostDriftRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHostDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHostDriftRequest) ProtoMessage() {}
+
+func (x *ReportHostDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHostDriftRequest.ProtoReflect.Descriptor instead.
+func (*ReportHostDriftRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReportHostDriftRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReportHostDriftRequest) GetHosts() []*HostDrift {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+// HostDrift is the result of the reconciliation of a static host
+type HostDrift struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`    // Manager server ID, from the datacenter and primary BMC endpoint
+	HostId        string                 `protobuf:"bytes,2,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`          // Host ID in the agent configuration
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"` // When the BMC was checked
+	Findings      []*DriftFinding        `protobuf:"bytes,4,rep,name=findings,proto3" json:"findings,omitempty"`                    // Differences found, none when the host is in sync
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostDrift) Reset() {
+	*x = HostDrift{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostDrift) ProtoMessage() {}
+
+func (x *HostDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostDrift.ProtoReflect.Descriptor instead.
+func (*HostDrift) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HostDrift) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *HostDrift) GetHostId() string {
+	if x != nil {
+		return x.HostId
+	}
+	return ""
+}
+
+func (x *HostDrift) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *HostDrift) GetFindings() []*DriftFinding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+// DriftFinding is a difference between a BMC and its declared configuration
+type DriftFinding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         string                 `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`       // "credentials", "reachable", "sol_enabled", "vnc_reachable" or "user_privilege"
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // BMC endpoint checked
+	Desired       string                 `protobuf:"bytes,3,opt,name=desired,proto3" json:"desired,omitempty"`   // Declared value
+	Actual        string                 `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`     // Value found on the BMC, empty when it could not be read
+	Applied       bool                   `protobuf:"varint,5,opt,name=applied,proto3" json:"applied,omitempty"`  // Whether the agent re-applied the declared value
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`       // Why the check or the re-application failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriftFinding) Reset() {
+	*x = DriftFinding{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriftFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftFinding) ProtoMessage() {}
+
+func (x *DriftFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftFinding.ProtoReflect.Descriptor instead.
+func (*DriftFinding) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DriftFinding) GetCheck() string {
+	if x != nil {
+		return x.Check
+	}
+	return ""
+}
+
+func (x *DriftFinding) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *DriftFinding) GetDesired() string {
+	if x != nil {
+		return x.Desired
+	}
+	return ""
+}
+
+func (x *DriftFinding) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *DriftFinding) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *DriftFinding) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ReportHostDriftResponse acknowledges a drift report
+type ReportHostDriftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportHostDriftResponse) Reset() {
+	*x = ReportHostDriftResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportHostDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportHostDriftResponse) ProtoMessage() {}
+
+func (x *ReportHostDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportHostDriftResponse.ProtoReflect.Descriptor instead.
+func (*ReportHostDriftResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReportHostDriftResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// BMCEndpointRegistration describes a server with separate endpoint types
+// Agents register servers with distinct control, SOL, and VNC endpoints
+type BMCEndpointRegistration struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	ServerId          string                   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                                           // Logical server identifier
+	ControlEndpoints  []*v1.BMCControlEndpoint `protobuf:"bytes,2,rep,name=control_endpoints,json=controlEndpoints,proto3" json:"control_endpoints,omitempty"`                                   // Multiple protocol support (RFD 006)
+	PrimaryProtocol   v1.BMCType               `protobuf:"varint,3,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"`              // Preferred protocol for operations
+	SolEndpoint       *v1.SOLEndpoint          `protobuf:"bytes,4,opt,name=sol_endpoint,json=solEndpoint,proto3" json:"sol_endpoint,omitempty"`                                                  // Serial-over-LAN access (optional)
+	VncEndpoint       *v1.VNCEndpoint          `protobuf:"bytes,5,opt,name=vnc_endpoint,json=vncEndpoint,proto3" json:"vnc_endpoint,omitempty"`                                                  // VNC/KVM access (optional)
+	Features          []string                 `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`                                                                           // High-level features (power, sensors, etc.)
+	Status            string                   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                                                               // Overall server status
+	Metadata          map[string]string        `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional server metadata
+	DiscoveryMetadata *v1.DiscoveryMetadata    `protobuf:"bytes,9,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"`                                // Discovery metadata (RFD 017)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BMCEndpointRegistration) Reset() {
+	*x = BMCEndpointRegistration{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BMCEndpointRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BMCEndpointRegistration) ProtoMessage() {}
+
+func (x *BMCEndpointRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BMCEndpointRegistration.ProtoReflect.Descriptor instead.
+func (*BMCEndpointRegistration) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BMCEndpointRegistration) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *BMCEndpointRegistration) GetControlEndpoints() []*v1.BMCControlEndpoint {
+	if x != nil {
+		return x.ControlEndpoints
+	}
+	return nil
+}
+
+func (x *BMCEndpointRegistration) GetPrimaryProtocol() v1.BMCType {
+	if x != nil {
+		return x.PrimaryProtocol
+	}
+	return v1.BMCType(0)
+}
+
+func (x *BMCEndpointRegistration) GetSolEndpoint() *v1.SOLEndpoint {
+	if x != nil {
+		return x.SolEndpoint
+	}
+	return nil
+}
+
+func (x *BMCEndpointRegistration) GetVncEndpoint() *v1.VNCEndpoint {
+	if x != nil {
+		return x.VncEndpoint
+	}
+	return nil
+}
+
+func (x *BMCEndpointRegistration) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *BMCEndpointRegistration) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BMCEndpointRegistration) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BMCEndpointRegistration) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
+	if x != nil {
+		return x.DiscoveryMetadata
+	}
+	return nil
+}
+
+// GetAgentStatusRequest queries the state of a single Local Agent
+type GetAgentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Agent identifier from registration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentStatusRequest) Reset() {
+	*x = GetAgentStatusRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentStatusRequest) ProtoMessage() {}
+
+func (x *GetAgentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAgentStatusRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// GetAgentStatusResponse contains the agent status as seen by the gateway
+type GetAgentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *AgentStatus           `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentStatusResponse) Reset() {
+	*x = GetAgentStatusResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentStatusResponse) ProtoMessage() {}
+
+func (x *GetAgentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAgentStatusResponse) GetAgent() *AgentStatus {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+// ListAgentsRequest queries the Local Agents registered with a gateway
+type ListAgentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DatacenterId  string                 `protobuf:"bytes,1,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"` // Optional: only return agents of this datacenter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListAgentsRequest) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+// ListAgentsResponse lists the registered agents, by agent ID
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*AgentStatus         `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*AgentStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// AgentStatus describes a registered Local Agent from the gateway's point of view
+type AgentStatus struct {
+	state              protoimpl.MessageState    `protogen:"open.v1"`
+	AgentId            string                    `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                     // Agent identifier
+	DatacenterId       string                    `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                      // Datacenter where the agent is deployed
+	Endpoint           string                    `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                                  // Agent callback endpoint used by the gateway
+	Status             string                    `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                                      // Registry status ("active" or "stale")
+	Version            string                    `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`                                                    // Agent build version reported at registration
+	RegisteredAt       *timestamppb.Timestamp    `protobuf:"bytes,6,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`                      // When the agent first registered with this gateway
+	LastSeen           *timestamppb.Timestamp    `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                                  // Last registration or heartbeat received
+	ActiveSessionCount int32                     `protobuf:"varint,8,opt,name=active_session_count,json=activeSessionCount,proto3" json:"active_session_count,omitempty"` // Console sessions (VNC and SOL) currently routed through the agent
+	BmcEndpoints       []*AgentBMCEndpointStatus `protobuf:"bytes,9,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"`                      // BMC endpoints mapped to the agent
+	GatewayId          string                    `protobuf:"bytes,10,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`                              // Gateway that answered the query
+	Link               *AgentLinkStatus          `protobuf:"bytes,11,opt,name=link,proto3" json:"link,omitempty"`                                                         // Last link probe, unset until probed or with probes disabled
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentStatus) Reset() {
+	*x = AgentStatus{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStatus) ProtoMessage() {}
+
+func (x *AgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
+func (*AgentStatus) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AgentStatus) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetActiveSessionCount() int32 {
+	if x != nil {
+		return x.ActiveSessionCount
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetBmcEndpoints() []*AgentBMCEndpointStatus {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetLink() *AgentLinkStatus {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// AgentLinkStatus is the last probe of the gateway-agent link
+type AgentLinkStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProbedAt      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=probed_at,json=probedAt,proto3" json:"probed_at,omitempty"`
+	RttMs         float64                `protobuf:"fixed64,2,opt,name=rtt_ms,json=rttMs,proto3" json:"rtt_ms,omitempty"`                   // Round-trip time in milliseconds
+	UploadBps     float64                `protobuf:"fixed64,3,opt,name=upload_bps,json=uploadBps,proto3" json:"upload_bps,omitempty"`       // Bytes per second towards the agent
+	DownloadBps   float64                `protobuf:"fixed64,4,opt,name=download_bps,json=downloadBps,proto3" json:"download_bps,omitempty"` // Bytes per second from the agent
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                  // Why the probe failed, empty on success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentLinkStatus) Reset() {
+	*x = AgentLinkStatus{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentLinkStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentLinkStatus) ProtoMessage() {}
+
+func (x *AgentLinkStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentLinkStatus.ProtoReflect.Descriptor instead.
+func (*AgentLinkStatus) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AgentLinkStatus) GetProbedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProbedAt
+	}
+	return nil
+}
+
+func (x *AgentLinkStatus) GetRttMs() float64 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *AgentLinkStatus) GetUploadBps() float64 {
+	if x != nil {
+		return x.UploadBps
+	}
+	return 0
+}
+
+func (x *AgentLinkStatus) GetDownloadBps() float64 {
+	if x != nil {
+		return x.DownloadBps
+	}
+	return 0
+}
+
+func (x *AgentLinkStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// AgentBMCEndpointStatus describes a BMC endpoint mapped to an agent
+type AgentBMCEndpointStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                      // Logical server identifier
+	BmcEndpoint   string                 `protobuf:"bytes,2,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC control endpoint address
+	BmcType       v1.BMCType             `protobuf:"varint,3,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"` // Control protocol of the endpoint
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                          // Server status last reported by the agent
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                      // Last time the agent reported this endpoint
+	Features      []string               `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`                                      // Features reported for the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentBMCEndpointStatus) Reset() {
+	*x = AgentBMCEndpointStatus{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentBMCEndpointStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentBMCEndpointStatus) ProtoMessage() {}
+
+func (x *AgentBMCEndpointStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentBMCEndpointStatus.ProtoReflect.Descriptor instead.
+func (*AgentBMCEndpointStatus) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AgentBMCEndpointStatus) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AgentBMCEndpointStatus) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *AgentBMCEndpointStatus) GetBmcType() v1.BMCType {
+	if x != nil {
+		return x.BmcType
+	}
+	return v1.BMCType(0)
+}
+
+func (x *AgentBMCEndpointStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AgentBMCEndpointStatus) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *AgentBMCEndpointStatus) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// GetGatewayStatusRequest queries the status of the gateway
+type GetGatewayStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGatewayStatusRequest) Reset() {
+	*x = GetGatewayStatusRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGatewayStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayStatusRequest) ProtoMessage() {}
+
+func (x *GetGatewayStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetGatewayStatusRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{34}
+}
+
+// GetGatewayStatusResponse describes the gateway, its agents and its console sessions
+type GetGatewayStatusResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId        string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	Region           string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	ManagerEndpoint  string                 `protobuf:"bytes,3,opt,name=manager_endpoint,json=managerEndpoint,proto3" json:"manager_endpoint,omitempty"`    // Manager the gateway registers with
+	Agents           []*AgentStatus         `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`                                             // Registered agents, by agent ID
+	Sessions         *ConsoleSessionCounts  `protobuf:"bytes,5,opt,name=sessions,proto3" json:"sessions,omitempty"`                                         // Unexpired console sessions
+	EndpointMappings []*BMCEndpointMapping  `protobuf:"bytes,6,rep,name=endpoint_mappings,json=endpointMappings,proto3" json:"endpoint_mappings,omitempty"` // BMC endpoints routed through agents, by endpoint
+	GeneratedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetGatewayStatusResponse) Reset() {
+	*x = GetGatewayStatusResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGatewayStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayStatusResponse) ProtoMessage() {}
+
+func (x *GetGatewayStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetGatewayStatusResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetGatewayStatusResponse) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *GetGatewayStatusResponse) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetGatewayStatusResponse) GetManagerEndpoint() string {
+	if x != nil {
+		return x.ManagerEndpoint
+	}
+	return ""
+}
+
+func (x *GetGatewayStatusResponse) GetAgents() []*AgentStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *GetGatewayStatusResponse) GetSessions() *ConsoleSessionCounts {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *GetGatewayStatusResponse) GetEndpointMappings() []*BMCEndpointMapping {
+	if x != nil {
+		return x.EndpointMappings
+	}
+	return nil
+}
+
+func (x *GetGatewayStatusResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+// ConsoleSessionCounts counts the unexpired console sessions of a gateway
+type ConsoleSessionCounts struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Total           int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Vnc             int32                  `protobuf:"varint,2,opt,name=vnc,proto3" json:"vnc,omitempty"`
+	Sol             int32                  `protobuf:"varint,3,opt,name=sol,proto3" json:"sol,omitempty"`
+	AttachedStreams int32                  `protobuf:"varint,4,opt,name=attached_streams,json=attachedStreams,proto3" json:"attached_streams,omitempty"` // Client streams attached to the sessions
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConsoleSessionCounts) Reset() {
+	*x = ConsoleSessionCounts{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleSessionCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleSessionCounts) ProtoMessage() {}
+
+func (x *ConsoleSessionCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleSessionCounts.ProtoReflect.Descriptor instead.
+func (*ConsoleSessionCounts) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ConsoleSessionCounts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ConsoleSessionCounts) GetVnc() int32 {
+	if x != nil {
+		return x.Vnc
+	}
+	return 0
+}
+
+func (x *ConsoleSessionCounts) GetSol() int32 {
+	if x != nil {
+		return x.Sol
+	}
+	return 0
+}
+
+func (x *ConsoleSessionCounts) GetAttachedStreams() int32 {
+	if x != nil {
+		return x.AttachedStreams
+	}
+	return 0
+}
+
+// BMCEndpointMapping routes a BMC endpoint through the agent that registered it
+type BMCEndpointMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BmcEndpoint   string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC control endpoint address
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                      // Logical server identifier
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent reaching the BMC
+	DatacenterId  string                 `protobuf:"bytes,4,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`          // Datacenter of the agent
+	BmcType       v1.BMCType             `protobuf:"varint,5,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"` // Control protocol of the endpoint
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                          // Server status last reported by the agent
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                      // Last time the agent reported this endpoint
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BMCEndpointMapping) Reset() {
+	*x = BMCEndpointMapping{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BMCEndpointMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BMCEndpointMapping) ProtoMessage() {}
+
+func (x *BMCEndpointMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BMCEndpointMapping.ProtoReflect.Descriptor instead.
+func (*BMCEndpointMapping) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BMCEndpointMapping) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *BMCEndpointMapping) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *BMCEndpointMapping) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *BMCEndpointMapping) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *BMCEndpointMapping) GetBmcType() v1.BMCType {
+	if x != nil {
+		return x.BmcType
+	}
+	return v1.BMCType(0)
+}
+
+func (x *BMCEndpointMapping) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BMCEndpointMapping) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// ListActiveSessionsRequest queries the console connections of a Local Agent
+type ListActiveSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`    // Agent identifier from registration
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // Optional: only return sessions for this server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveSessionsRequest) Reset() {
+	*x = ListActiveSessionsRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveSessionsRequest) ProtoMessage() {}
+
+func (x *ListActiveSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListActiveSessionsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListActiveSessionsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// ListActiveSessionsResponse lists the BMC console connections held by an agent
+type ListActiveSessionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Sessions       []*ActiveSession       `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	MaxSolSessions int32                  `protobuf:"varint,2,opt,name=max_sol_sessions,json=maxSolSessions,proto3" json:"max_sol_sessions,omitempty"` // Concurrent SOL session limit of the agent (0 = unlimited)
+	MaxVncSessions int32                  `protobuf:"varint,3,opt,name=max_vnc_sessions,json=maxVncSessions,proto3" json:"max_vnc_sessions,omitempty"` // Concurrent VNC session limit of the agent (0 = unlimited)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListActiveSessionsResponse) Reset() {
+	*x = ListActiveSessionsResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveSessionsResponse) ProtoMessage() {}
+
+func (x *ListActiveSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListActiveSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListActiveSessionsResponse) GetSessions() []*ActiveSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListActiveSessionsResponse) GetMaxSolSessions() int32 {
+	if x != nil {
+		return x.MaxSolSessions
+	}
+	return 0
+}
+
+func (x *ListActiveSessionsResponse) GetMaxVncSessions() int32 {
+	if x != nil {
+		return x.MaxVncSessions
+	}
+	return 0
+}
+
+// ActiveSession describes a BMC console connection held open by an agent for a stream
+type ActiveSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Gateway session the stream belongs to
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`    // Logical server identifier
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`                    // Console protocol ("sol" or "vnc")
+	Endpoint      string                 `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                    // BMC console endpoint
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // When the BMC connection was opened
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveSession) Reset() {
+	*x = ActiveSession{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveSession) ProtoMessage() {}
+
+func (x *ActiveSession) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveSession.ProtoReflect.Descriptor instead.
+func (*ActiveSession) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ActiveSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ActiveSession) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ActiveSession) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ActiveSession) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ActiveSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+// ProbeLinkRequest is a link probe sent by a gateway to an agent
+type ProbeLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`                                // Upload payload, discarded by the agent
+	ResponseSize  int32                  `protobuf:"varint,2,opt,name=response_size,json=responseSize,proto3" json:"response_size,omitempty"` // Size of the payload to download, capped by the agent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeLinkRequest) Reset() {
+	*x = ProbeLinkRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeLinkRequest) ProtoMessage() {}
+
+func (x *ProbeLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeLinkRequest.ProtoReflect.Descriptor instead.
+func (*ProbeLinkRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ProbeLinkRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ProbeLinkRequest) GetResponseSize() int32 {
+	if x != nil {
+		return x.ResponseSize
+	}
+	return 0
+}
+
+// ProbeLinkResponse is the agent's reply to a link probe
+type ProbeLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"` // Download payload of the requested size
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProbeLinkResponse) Reset() {
+	*x = ProbeLinkResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProbeLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeLinkResponse) ProtoMessage() {}
+
+func (x *ProbeLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeLinkResponse.ProtoReflect.Descriptor instead.
+func (*ProbeLinkResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ProbeLinkResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// ListConsoleSessionsRequest queries the console sessions of the gateway.
+// All filters are optional.
+type ListConsoleSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`       // Only return sessions for this server
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Only return sessions opened by this customer (admins only when not the caller)
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`          // Only return sessions routed through this agent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsoleSessionsRequest) Reset() {
+	*x = ListConsoleSessionsRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsoleSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsoleSessionsRequest) ProtoMessage() {}
+
+func (x *ListConsoleSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsoleSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConsoleSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListConsoleSessionsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ListConsoleSessionsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ListConsoleSessionsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// ListConsoleSessionsResponse lists console sessions, oldest first
+type ListConsoleSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*ConsoleSessionInfo  `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsoleSessionsResponse) Reset() {
+	*x = ListConsoleSessionsResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsoleSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsoleSessionsResponse) ProtoMessage() {}
+
+func (x *ListConsoleSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsoleSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConsoleSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListConsoleSessionsResponse) GetSessions() []*ConsoleSessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// ConsoleSessionInfo describes a console session held by the gateway
+type ConsoleSessionInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                 // Session identifier
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                            // Console type ("sol" or "vnc")
+	ServerId       string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                    // Logical server identifier
+	CustomerId     string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`              // Customer that opened the session
+	CustomerEmail  string                 `protobuf:"bytes,5,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`     // Email of that customer, if known
+	AgentId        string                 `protobuf:"bytes,6,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                       // Agent serving the server's BMC
+	BmcEndpoint    string                 `protobuf:"bytes,7,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`           // BMC endpoint of the server
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                 // When the session was created
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                 // When the session expires
+	Streams        []*ConsoleStreamInfo   `protobuf:"bytes,10,rep,name=streams,proto3" json:"streams,omitempty"`                                     // Streams attached to the session
+	ReadOnly       bool                   `protobuf:"varint,11,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`                  // Client input is dropped
+	KeyboardLayout string                 `protobuf:"bytes,12,opt,name=keyboard_layout,json=keyboardLayout,proto3" json:"keyboard_layout,omitempty"` // Layout key events are translated from, VNC only
+	ImpersonatedBy string                 `protobuf:"bytes,13,opt,name=impersonated_by,json=impersonatedBy,proto3" json:"impersonated_by,omitempty"` // Admin who opened the session on behalf of the customer, if any
+	// Last console input of a client, or attach of a stream, to find idle sessions
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConsoleSessionInfo) Reset() {
+	*x = ConsoleSessionInfo{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleSessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleSessionInfo) ProtoMessage() {}
+
+func (x *ConsoleSessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleSessionInfo.ProtoReflect.Descriptor instead.
+func (*ConsoleSessionInfo) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ConsoleSessionInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ConsoleSessionInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ConsoleSessionInfo) GetStreams() []*ConsoleStreamInfo {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *ConsoleSessionInfo) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *ConsoleSessionInfo) GetKeyboardLayout() string {
+	if x != nil {
+		return x.KeyboardLayout
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetImpersonatedBy() string {
+	if x != nil {
+		return x.ImpersonatedBy
+	}
+	return ""
+}
+
+func (x *ConsoleSessionInfo) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+// ConsoleStreamInfo describes a client stream attached to a console session
+type ConsoleStreamInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientAddress string                 `protobuf:"bytes,1,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"` // Remote address of the client
+	Transport     string                 `protobuf:"bytes,2,opt,name=transport,proto3" json:"transport,omitempty"`                              // "connect" (CLI), "websocket" or "connect-web" (browser)
+	AttachedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=attached_at,json=attachedAt,proto3" json:"attached_at,omitempty"`          // When the stream was attached
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsoleStreamInfo) Reset() {
+	*x = ConsoleStreamInfo{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleStreamInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleStreamInfo) ProtoMessage() {}
+
+func (x *ConsoleStreamInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleStreamInfo.ProtoReflect.Descriptor instead.
+func (*ConsoleStreamInfo) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ConsoleStreamInfo) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *ConsoleStreamInfo) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *ConsoleStreamInfo) GetAttachedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttachedAt
+	}
+	return nil
+}
+
+// TerminateConsoleSessionRequest closes a console session
+type TerminateConsoleSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Session to terminate
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                        // Optional: shown to the disconnected clients and logged
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateConsoleSessionRequest) Reset() {
+	*x = TerminateConsoleSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateConsoleSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateConsoleSessionRequest) ProtoMessage() {}
+
+func (x *TerminateConsoleSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateConsoleSessionRequest.ProtoReflect.Descriptor instead.
+func (*TerminateConsoleSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TerminateConsoleSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TerminateConsoleSessionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// TerminateConsoleSessionResponse reports the effect of a termination
+type TerminateConsoleSessionResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DisconnectedStreams int32                  `protobuf:"varint,1,opt,name=disconnected_streams,json=disconnectedStreams,proto3" json:"disconnected_streams,omitempty"` // Number of attached streams that were disconnected
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TerminateConsoleSessionResponse) Reset() {
+	*x = TerminateConsoleSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateConsoleSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateConsoleSessionResponse) ProtoMessage() {}
+
+func (x *TerminateConsoleSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateConsoleSessionResponse.ProtoReflect.Descriptor instead.
+func (*TerminateConsoleSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TerminateConsoleSessionResponse) GetDisconnectedStreams() int32 {
+	if x != nil {
+		return x.DisconnectedStreams
+	}
+	return 0
+}
+
+// RenewConsoleSessionRequest extends a console session
+type RenewConsoleSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Session to extend
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewConsoleSessionRequest) Reset() {
+	*x = RenewConsoleSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewConsoleSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewConsoleSessionRequest) ProtoMessage() {}
+
+func (x *RenewConsoleSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewConsoleSessionRequest.ProtoReflect.Descriptor instead.
+func (*RenewConsoleSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RenewConsoleSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// RenewConsoleSessionResponse gives the new expiry of a renewed session
+type RenewConsoleSessionResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session now expires
+	WebsocketEndpoint string                 `protobuf:"bytes,2,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket URL with an access token valid until expires_at
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RenewConsoleSessionResponse) Reset() {
+	*x = RenewConsoleSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewConsoleSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewConsoleSessionResponse) ProtoMessage() {}
+
+func (x *RenewConsoleSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewConsoleSessionResponse.ProtoReflect.Descriptor instead.
+func (*RenewConsoleSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RenewConsoleSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RenewConsoleSessionResponse) GetWebsocketEndpoint() string {
+	if x != nil {
+		return x.WebsocketEndpoint
+	}
+	return ""
+}
+
+// CreateVNCSessionRequest creates a new VNC console session
+// CLI sends server_id, Gateway resolves to BMC endpoint using delegated token
+type CreateVNCSessionRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID for which to create a VNC session
+	// Drop keyboard and pointer input, to observe the display without control.
+	// Tokens with console:read may create read-only sessions.
+	ReadOnly bool `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Keyboard layout of the viewer (e.g. "de", "fr"). Key events are
+	// translated to the US keys BMC KVMs expect. Empty for "us".
+	KeyboardLayout string `protobuf:"bytes,3,opt,name=keyboard_layout,json=keyboardLayout,proto3" json:"keyboard_layout,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateVNCSessionRequest) Reset() {
+	*x = CreateVNCSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVNCSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVNCSessionRequest) ProtoMessage() {}
+
+func (x *CreateVNCSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVNCSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateVNCSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateVNCSessionRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateVNCSessionRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *CreateVNCSessionRequest) GetKeyboardLayout() string {
+	if x != nil {
+		return x.KeyboardLayout
+	}
+	return ""
+}
+
+// CreateVNCSessionResponse provides the created VNC session details
+type CreateVNCSessionResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                         // Unique VNC session identifier
+	WebsocketEndpoint string                 `protobuf:"bytes,2,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for VNC streaming (e.g., "wss://gateway.example.com/vnc/session123")
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires and will be automatically closed
+	ViewerUrl         string                 `protobuf:"bytes,4,opt,name=viewer_url,json=viewerUrl,proto3" json:"viewer_url,omitempty"`                         // Direct URL to web-based VNC viewer
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateVNCSessionResponse) Reset() {
+	*x = CreateVNCSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVNCSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVNCSessionResponse) ProtoMessage() {}
+
+func (x *CreateVNCSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVNCSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateVNCSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateVNCSessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CreateVNCSessionResponse) GetWebsocketEndpoint() string {
+	if x != nil {
+		return x.WebsocketEndpoint
+	}
+	return ""
+}
+
+func (x *CreateVNCSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateVNCSessionResponse) GetViewerUrl() string {
+	if x != nil {
+		return x.ViewerUrl
+	}
+	return ""
+}
+
+// GetVNCSessionRequest retrieves information about an existing VNC session
+type GetVNCSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The VNC session ID to retrieve information for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVNCSessionRequest) Reset() {
+	*x = GetVNCSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVNCSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVNCSessionRequest) ProtoMessage() {}
+
+func (x *GetVNCSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVNCSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetVNCSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetVNCSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// VNCSession contains detailed information about a VNC console session
+type VNCSession struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                        // Unique session identifier
+	CustomerId        string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                      // Customer ID that owns this session
+	ServerId          string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                            // Target server ID for this session
+	AgentId           string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                               // Agent ID handling the VNC connection
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                                // Session status (e.g., "active", "connecting", "expired", "closed")
+	WebsocketEndpoint string                 `protobuf:"bytes,6,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for VNC streaming
+	ViewerUrl         string                 `protobuf:"bytes,7,opt,name=viewer_url,json=viewerUrl,proto3" json:"viewer_url,omitempty"`                         // Web-based VNC viewer URL
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // When the session was created
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires
+	LastActivityAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`       // Last console input of a client, or attach of a stream
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VNCSession) Reset() {
+	*x = VNCSession{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VNCSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VNCSession) ProtoMessage() {}
+
+func (x *VNCSession) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VNCSession.ProtoReflect.Descriptor instead.
+func (*VNCSession) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *VNCSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VNCSession) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *VNCSession) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *VNCSession) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *VNCSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VNCSession) GetWebsocketEndpoint() string {
+	if x != nil {
+		return x.WebsocketEndpoint
+	}
+	return ""
+}
+
+func (x *VNCSession) GetViewerUrl() string {
+	if x != nil {
+		return x.ViewerUrl
+	}
+	return ""
+}
+
+func (x *VNCSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *VNCSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *VNCSession) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+// GetVNCSessionResponse contains the requested VNC session information
+type GetVNCSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *VNCSession            `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"` // The VNC session details, or error if not found/accessible
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVNCSessionResponse) Reset() {
+	*x = GetVNCSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVNCSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVNCSessionResponse) ProtoMessage() {}
+
+func (x *GetVNCSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVNCSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetVNCSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetVNCSessionResponse) GetSession() *VNCSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// CloseVNCSessionRequest terminates an active VNC session
+type CloseVNCSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The VNC session ID to close and clean up
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseVNCSessionRequest) Reset() {
+	*x = CloseVNCSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseVNCSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseVNCSessionRequest) ProtoMessage() {}
+
+func (x *CloseVNCSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseVNCSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseVNCSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CloseVNCSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// CloseVNCSessionResponse confirms VNC session closure
+type CloseVNCSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseVNCSessionResponse) Reset() {
+	*x = CloseVNCSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseVNCSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseVNCSessionResponse) ProtoMessage() {}
+
+func (x *CloseVNCSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseVNCSessionResponse.ProtoReflect.Descriptor instead.
+func (*CloseVNCSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{57}
+}
+
+// CreateSOLSessionRequest creates a new SOL console session
+type CreateSOLSessionRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID for which to create a SOL session
+	// Serial settings applied to the BMC for this session. Unset fields leave
+	// the BMC's setting unchanged; timeout_seconds is ignored.
+	Config *v1.SOLConfig `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// Drop all input, to observe the console without control. Tokens with
+	// console:read may create read-only sessions.
+	ReadOnly      bool `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSOLSessionRequest) Reset() {
+	*x = CreateSOLSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSOLSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSOLSessionRequest) ProtoMessage() {}
+
+func (x *CreateSOLSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSOLSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSOLSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateSOLSessionRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateSOLSessionRequest) GetConfig() *v1.SOLConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateSOLSessionRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+// CreateSOLSessionResponse provides the created SOL session details
+type CreateSOLSessionResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                         // Unique SOL session identifier
+	WebsocketEndpoint string                 `protobuf:"bytes,2,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for SOL streaming (e.g., "wss://gateway.example.com/sol/session123")
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires and will be automatically closed
+	ConsoleUrl        string                 `protobuf:"bytes,4,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`                      // Direct URL to web-based SOL console
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateSOLSessionResponse) Reset() {
+	*x = CreateSOLSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSOLSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSOLSessionResponse) ProtoMessage() {}
+
+func (x *CreateSOLSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSOLSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSOLSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateSOLSessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CreateSOLSessionResponse) GetWebsocketEndpoint() string {
+	if x != nil {
+		return x.WebsocketEndpoint
+	}
+	return ""
+}
+
+func (x *CreateSOLSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateSOLSessionResponse) GetConsoleUrl() string {
+	if x != nil {
+		return x.ConsoleUrl
+	}
+	return ""
+}
+
+// GetSOLSessionRequest retrieves information about an existing SOL session
+type GetSOLSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The SOL session ID to retrieve information for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSOLSessionRequest) Reset() {
+	*x = GetSOLSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSOLSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSOLSessionRequest) ProtoMessage() {}
+
+func (x *GetSOLSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSOLSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSOLSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetSOLSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// SOLSession contains detailed information about a SOL console session
+type SOLSession struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                        // Unique session identifier
+	CustomerId        string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                      // Customer ID that owns this session
+	ServerId          string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                            // Target server ID for this session
+	AgentId           string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                               // Agent ID handling the SOL connection
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                                // Session status (e.g., "active", "connecting", "expired", "closed")
+	WebsocketEndpoint string                 `protobuf:"bytes,6,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for SOL streaming
+	ConsoleUrl        string                 `protobuf:"bytes,7,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`                      // Web-based SOL console URL
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // When the session was created
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires
+	ReadOnly          bool                   `protobuf:"varint,10,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`                          // Client input is dropped
+	LastActivityAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`       // Last console input of a client, or attach of a stream
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SOLSession) Reset() {
+	*x = SOLSession{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SOLSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SOLSession) ProtoMessage() {}
+
+func (x *SOLSession) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SOLSession.ProtoReflect.Descriptor instead.
+func (*SOLSession) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SOLSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SOLSession) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SOLSession) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SOLSession) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SOLSession) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SOLSession) GetWebsocketEndpoint() string {
+	if x != nil {
+		return x.WebsocketEndpoint
+	}
+	return ""
+}
+
+func (x *SOLSession) GetConsoleUrl() string {
+	if x != nil {
+		return x.ConsoleUrl
+	}
+	return ""
+}
+
+func (x *SOLSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SOLSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SOLSession) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *SOLSession) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+// GetSOLSessionResponse contains the requested SOL session information
+type GetSOLSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *SOLSession            `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"` // The SOL session details, or error if not found/accessible
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSOLSessionResponse) Reset() {
+	*x = GetSOLSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSOLSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSOLSessionResponse) ProtoMessage() {}
+
+func (x *GetSOLSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSOLSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetSOLSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetSOLSessionResponse) GetSession() *SOLSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// CloseSOLSessionRequest terminates an active SOL session
+type CloseSOLSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The SOL session ID to close and clean up
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseSOLSessionRequest) Reset() {
+	*x = CloseSOLSessionRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseSOLSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseSOLSessionRequest) ProtoMessage() {}
+
+func (x *CloseSOLSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseSOLSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseSOLSessionRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CloseSOLSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// CloseSOLSessionResponse confirms SOL session closure
+type CloseSOLSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseSOLSessionResponse) Reset() {
+	*x = CloseSOLSessionResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseSOLSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseSOLSessionResponse) ProtoMessage() {}
+
+func (x *CloseSOLSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseSOLSessionResponse.ProtoReflect.Descriptor instead.
+func (*CloseSOLSessionResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{64}
+}
+
+// ReportAvailableEndpointsRequest reports BMC endpoints that this gateway can proxy
+// This is sent by Gateway -> Manager to register available BMC endpoints
+type ReportAvailableEndpointsRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	GatewayId     string                     `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`          // Gateway identifier
+	Region        string                     `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                 // Gateway region
+	BmcEndpoints  []*BMCEndpointAvailability `protobuf:"bytes,3,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // BMC endpoints available through this gateway
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportAvailableEndpointsRequest) Reset() {
+	*x = ReportAvailableEndpointsRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportAvailableEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAvailableEndpointsRequest) ProtoMessage() {}
+
+func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAvailableEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ReportAvailableEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReportAvailableEndpointsRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ReportAvailableEndpointsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ReportAvailableEndpointsRequest) GetBmcEndpoints() []*BMCEndpointAvailability {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+// BMCEndpointAvailability describes a BMC endpoint available through a gateway
+type BMCEndpointAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BmcEndpoint   string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC endpoint (e.g., "192.168.1.100:623")
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent that provides access to this endpoint
+	DatacenterId  string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`          // Datacenter containing this BMC
+	BmcType       v1.BMCType             `protobuf:"varint,4,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"` // Type of BMC interface
+	Features      []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                      // Available features
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                          // Endpoint status
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                      // When this endpoint was last verified
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BMCEndpointAvailability) Reset() {
+	*x = BMCEndpointAvailability{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BMCEndpointAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BMCEndpointAvailability) ProtoMessage() {}
+
+func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BMCEndpointAvailability.ProtoReflect.Descriptor instead.
+func (*BMCEndpointAvailability) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *BMCEndpointAvailability) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetBmcType() v1.BMCType {
+	if x != nil {
+		return x.BmcType
+	}
+	return v1.BMCType(0)
+}
+
+func (x *BMCEndpointAvailability) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *BMCEndpointAvailability) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// ReportAvailableEndpointsResponse confirms endpoint registration
+type ReportAvailableEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportAvailableEndpointsResponse) Reset() {
+	*x = ReportAvailableEndpointsResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportAvailableEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAvailableEndpointsResponse) ProtoMessage() {}
+
+func (x *ReportAvailableEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAvailableEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ReportAvailableEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ReportAvailableEndpointsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportAvailableEndpointsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// StartVNCProxyRequest asks an agent to start a VNC proxy to a BMC
+type StartVNCProxyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SessionId       string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                   // VNC session identifier
+	BmcEndpoint     string                 `protobuf:"bytes,2,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC endpoint to connect to (server ID)
+	BmcType         string                 `protobuf:"bytes,3,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"`                         // BMC type ("redfish" or "ipmi")
+	GatewayEndpoint string                 `protobuf:"bytes,4,opt,name=gateway_endpoint,json=gatewayEndpoint,proto3" json:"gateway_endpoint,omitempty"` // Gateway endpoint for reverse connection
+	AgentId         string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent ID to handle this session
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StartVNCProxyRequest) Reset() {
+	*x = StartVNCProxyRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartVNCProxyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartVNCProxyRequest) ProtoMessage() {}
+
+func (x *StartVNCProxyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartVNCProxyRequest.ProtoReflect.Descriptor instead.
+func (*StartVNCProxyRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *StartVNCProxyRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StartVNCProxyRequest) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *StartVNCProxyRequest) GetBmcType() string {
+	if x != nil {
+		return x.BmcType
+	}
+	return ""
+}
+
+func (x *StartVNCProxyRequest) GetGatewayEndpoint() string {
+	if x != nil {
+		return x.GatewayEndpoint
+	}
+	return ""
+}
+
+func (x *StartVNCProxyRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// StartVNCProxyResponse confirms VNC proxy startup
+type StartVNCProxyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                 // Whether the proxy was started successfully
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                  // Success message or error details
+	ProxyEndpoint string                 `protobuf:"bytes,3,opt,name=proxy_endpoint,json=proxyEndpoint,proto3" json:"proxy_endpoint,omitempty"` // Endpoint where the proxy is listening (if applicable)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartVNCProxyResponse) Reset() {
+	*x = StartVNCProxyResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartVNCProxyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartVNCProxyResponse) ProtoMessage() {}
+
+func (x *StartVNCProxyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartVNCProxyResponse.ProtoReflect.Descriptor instead.
+func (*StartVNCProxyResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *StartVNCProxyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartVNCProxyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartVNCProxyResponse) GetProxyEndpoint() string {
+	if x != nil {
+		return x.ProxyEndpoint
+	}
+	return ""
+}
+
+// VNCDataChunk represents a chunk of VNC data being streamed
+type VNCDataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                                                        // Session identifier for this VNC stream
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                                           // Server ID (used in initial handshake)
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                                                                   // Raw VNC protocol data
+	IsHandshake   bool                   `protobuf:"varint,4,opt,name=is_handshake,json=isHandshake,proto3" json:"is_handshake,omitempty"`                                                 // True if this is the initial connection handshake
+	CloseStream   bool                   `protobuf:"varint,5,opt,name=close_stream,json=closeStream,proto3" json:"close_stream,omitempty"`                                                 // True to signal stream closure
+	ErrorCode     string                 `protobuf:"bytes,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`                                                        // Set on the final chunk of a failed stream, see core/streaming ErrorCode
+	ErrorMessage  string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`                                               // Human-readable detail of error_code
+	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Handshake only: metadata such as the W3C trace context
+	Fragment      uint32                 `protobuf:"varint,9,opt,name=fragment,proto3" json:"fragment,omitempty"`                                                                          // Index of this fragment of a message split over several chunks, see core/streaming FragmentedStream
+	MoreFragments bool                   `protobuf:"varint,10,opt,name=more_fragments,json=moreFragments,proto3" json:"more_fragments,omitempty"`                                          // True when further fragments of the same message follow
+	Checksum      *uint32                `protobuf:"fixed32,11,opt,name=checksum,proto3,oneof" json:"checksum,omitempty"`                                                                  // CRC-32C of data, verified by the receiving end, see core/streaming ChecksummedStream
+	Ping          bool                   `protobuf:"varint,12,opt,name=ping,proto3" json:"ping,omitempty"`                                                                                 // Keepalive ping without data, answered by the gateway, see core/streaming KeepaliveStream
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VNCDataChunk) Reset() {
+	*x = VNCDataChunk{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VNCDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VNCDataChunk) ProtoMessage() {}
+
+func (x *VNCDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VNCDataChunk.ProtoReflect.Descriptor instead.
+func (*VNCDataChunk) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *VNCDataChunk) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *VNCDataChunk) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *VNCDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *VNCDataChunk) GetIsHandshake() bool {
+	if x != nil {
+		return x.IsHandshake
+	}
+	return false
+}
+
+func (x *VNCDataChunk) GetCloseStream() bool {
+	if x != nil {
+		return x.CloseStream
+	}
+	return false
+}
+
+func (x *VNCDataChunk) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *VNCDataChunk) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *VNCDataChunk) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *VNCDataChunk) GetFragment() uint32 {
+	if x != nil {
+		return x.Fragment
+	}
+	return 0
+}
+
+func (x *VNCDataChunk) GetMoreFragments() bool {
+	if x != nil {
+		return x.MoreFragments
+	}
+	return false
+}
+
+func (x *VNCDataChunk) GetChecksum() uint32 {
+	if x != nil && x.Checksum != nil {
+		return *x.Checksum
+	}
+	return 0
+}
+
+func (x *VNCDataChunk) GetPing() bool {
+	if x != nil {
+		return x.Ping
+	}
+	return false
+}
+
+// ConsoleDataChunk represents a chunk of console/SOL data being streamed
+type ConsoleDataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                                                         // Session identifier for this console stream
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                                            // Server ID (used in initial handshake)
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                                                                    // Raw console/SOL data
+	IsHandshake   bool                   `protobuf:"varint,4,opt,name=is_handshake,json=isHandshake,proto3" json:"is_handshake,omitempty"`                                                  // True if this is the initial connection handshake
+	CloseStream   bool                   `protobuf:"varint,5,opt,name=close_stream,json=closeStream,proto3" json:"close_stream,omitempty"`                                                  // True to signal stream closure
+	Resize        *TerminalSize          `protobuf:"bytes,6,opt,name=resize,proto3" json:"resize,omitempty"`                                                                                // Terminal size change (control chunk sent without data)
+	ForceTakeover bool                   `protobuf:"varint,7,opt,name=force_takeover,json=forceTakeover,proto3" json:"force_takeover,omitempty"`                                            // Handshake only: take over the server's SOL session if another stream holds it
+	ErrorCode     string                 `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`                                                         // Set on the final chunk of a failed stream, see core/streaming ErrorCode
+	ErrorMessage  string                 `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`                                                // Human-readable detail of error_code
+	Metadata      map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Handshake only: metadata such as the W3C trace context
+	StreamId      string                 `protobuf:"bytes,11,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`                                                           // Handshake ack of WatchConsoleData: the stream SendConsoleData sends to
+	Checksum      *uint32                `protobuf:"fixed32,12,opt,name=checksum,proto3,oneof" json:"checksum,omitempty"`                                                                   // CRC-32C of data, verified by the receiving end, see core/streaming ChecksummedStream
+	Ping          bool                   `protobuf:"varint,13,opt,name=ping,proto3" json:"ping,omitempty"`                                                                                  // Keepalive ping without data, answered by the gateway, see core/streaming KeepaliveStream
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsoleDataChunk) Reset() {
+	*x = ConsoleDataChunk{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleDataChunk) ProtoMessage() {}
+
+func (x *ConsoleDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleDataChunk.ProtoReflect.Descriptor instead.
+func (*ConsoleDataChunk) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ConsoleDataChunk) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ConsoleDataChunk) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ConsoleDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ConsoleDataChunk) GetIsHandshake() bool {
+	if x != nil {
+		return x.IsHandshake
+	}
+	return false
+}
+
+func (x *ConsoleDataChunk) GetCloseStream() bool {
+	if x != nil {
+		return x.CloseStream
+	}
+	return false
+}
+
+func (x *ConsoleDataChunk) GetResize() *TerminalSize {
+	if x != nil {
+		return x.Resize
+	}
+	return nil
+}
+
+func (x *ConsoleDataChunk) GetForceTakeover() bool {
+	if x != nil {
+		return x.ForceTakeover
+	}
+	return false
+}
+
+func (x *ConsoleDataChunk) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ConsoleDataChunk) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ConsoleDataChunk) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ConsoleDataChunk) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *ConsoleDataChunk) GetChecksum() uint32 {
+	if x != nil && x.Checksum != nil {
+		return *x.Checksum
+	}
+	return 0
+}
+
+func (x *ConsoleDataChunk) GetPing() bool {
+	if x != nil {
+		return x.Ping
+	}
+	return false
+}
+
+// SendConsoleDataRequest carries chunks of a browser client to its console stream
+type SendConsoleDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreamId      string                 `protobuf:"bytes,1,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"` // Stream opened by WatchConsoleData
+	Chunks        []*ConsoleDataChunk    `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`                     // Chunks in the order they were produced
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendConsoleDataRequest) Reset() {
+	*x = SendConsoleDataRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendConsoleDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendConsoleDataRequest) ProtoMessage() {}
+
+func (x *SendConsoleDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendConsoleDataRequest.ProtoReflect.Descriptor instead.
+func (*SendConsoleDataRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SendConsoleDataRequest) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *SendConsoleDataRequest) GetChunks() []*ConsoleDataChunk {
 	if x != nil {
-		return x.ConsoleUrl
+		return x.Chunks
 	}
-	return ""
+	return nil
 }
 
-// GetSOLSessionRequest retrieves information about an existing SOL session
-type GetSOLSessionRequest struct {
+// SendConsoleDataResponse acknowledges that the chunks were queued to the console
+type SendConsoleDataResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The SOL session ID to retrieve information for
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSOLSessionRequest) Reset() {
-	*x = GetSOLSessionRequest{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[20]
+func (x *SendConsoleDataResponse) Reset() {
+	*x = SendConsoleDataResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSOLSessionRequest) String() string {
+func (x *SendConsoleDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSOLSessionRequest) ProtoMessage() {}
+func (*SendConsoleDataResponse) ProtoMessage() {}
 
-func (x *GetSOLSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[20]
+func (x *SendConsoleDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,49 +5065,35 @@ func (x *GetSOLSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSOLSessionRequest.ProtoReflect.Descriptor instead.
-func (*GetSOLSessionRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *GetSOLSessionRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+// Deprecated: Use SendConsoleDataResponse.ProtoReflect.Descriptor instead.
+func (*SendConsoleDataResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{73}
 }
 
-// SOLSession contains detailed information about a SOL console session
-type SOLSession struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                        // Unique session identifier
-	CustomerId        string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                      // Customer ID that owns this session
-	ServerId          string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                            // Target server ID for this session
-	AgentId           string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                               // Agent ID handling the SOL connection
-	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                                                // Session status (e.g., "active", "connecting", "expired", "closed")
-	WebsocketEndpoint string                 `protobuf:"bytes,6,opt,name=websocket_endpoint,json=websocketEndpoint,proto3" json:"websocket_endpoint,omitempty"` // WebSocket endpoint for SOL streaming
-	ConsoleUrl        string                 `protobuf:"bytes,7,opt,name=console_url,json=consoleUrl,proto3" json:"console_url,omitempty"`                      // Web-based SOL console URL
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // When the session was created
-	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                         // When the session expires
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// TerminalSize is the size of the client terminal in character cells
+type TerminalSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cols          uint32                 `protobuf:"varint,1,opt,name=cols,proto3" json:"cols,omitempty"` // Number of columns
+	Rows          uint32                 `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"` // Number of rows
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SOLSession) Reset() {
-	*x = SOLSession{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[21]
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SOLSession) String() string {
+func (x *TerminalSize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SOLSession) ProtoMessage() {}
+func (*TerminalSize) ProtoMessage() {}
 
-func (x *SOLSession) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[21]
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,97 +5104,93 @@ func (x *SOLSession) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SOLSession.ProtoReflect.Descriptor instead.
-func (*SOLSession) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *SOLSession) GetId() string {
+func (x *TerminalSize) GetCols() uint32 {
 	if x != nil {
-		return x.Id
+		return x.Cols
 	}
-	return ""
+	return 0
 }
 
-func (x *SOLSession) GetCustomerId() string {
+func (x *TerminalSize) GetRows() uint32 {
 	if x != nil {
-		return x.CustomerId
+		return x.Rows
 	}
-	return ""
+	return 0
 }
 
-func (x *SOLSession) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
+// GetBMCInfoRequest requests hardware information from a BMC
+type GetBMCInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to retrieve BMC information for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SOLSession) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
+func (x *GetBMCInfoRequest) Reset() {
+	*x = GetBMCInfoRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SOLSession) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+func (x *GetBMCInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *SOLSession) GetWebsocketEndpoint() string {
-	if x != nil {
-		return x.WebsocketEndpoint
-	}
-	return ""
-}
+func (*GetBMCInfoRequest) ProtoMessage() {}
 
-func (x *SOLSession) GetConsoleUrl() string {
+func (x *GetBMCInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[75]
 	if x != nil {
-		return x.ConsoleUrl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SOLSession) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+// Deprecated: Use GetBMCInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetBMCInfoRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *SOLSession) GetExpiresAt() *timestamppb.Timestamp {
+func (x *GetBMCInfoRequest) GetServerId() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-// GetSOLSessionResponse contains the requested SOL session information
-type GetSOLSessionResponse struct {
+// GetBMCInfoResponse provides BMC hardware information
+type GetBMCInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Session       *SOLSession            `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"` // The SOL session details, or error if not found/accessible
+	Info          *BMCInfo               `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"` // BMC hardware information
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSOLSessionResponse) Reset() {
-	*x = GetSOLSessionResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[22]
+func (x *GetBMCInfoResponse) Reset() {
+	*x = GetBMCInfoResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSOLSessionResponse) String() string {
+func (x *GetBMCInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSOLSessionResponse) ProtoMessage() {}
+func (*GetBMCInfoResponse) ProtoMessage() {}
 
-func (x *GetSOLSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[22]
+func (x *GetBMCInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,41 +5201,46 @@ func (x *GetSOLSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSOLSessionResponse.ProtoReflect.Descriptor instead.
-func (*GetSOLSessionResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetBMCInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetBMCInfoResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetSOLSessionResponse) GetSession() *SOLSession {
+func (x *GetBMCInfoResponse) GetInfo() *BMCInfo {
 	if x != nil {
-		return x.Session
+		return x.Info
 	}
 	return nil
 }
 
-// CloseSOLSessionRequest terminates an active SOL session
-type CloseSOLSessionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // The SOL session ID to close and clean up
+// BMCInfo contains hardware information from a BMC
+type BMCInfo struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	BmcType string                 `protobuf:"bytes,1,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"` // "ipmi" or "redfish"
+	// Types that are valid to be assigned to Details:
+	//
+	//	*BMCInfo_IpmiInfo
+	//	*BMCInfo_RedfishInfo
+	Details       isBMCInfo_Details `protobuf_oneof:"details"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CloseSOLSessionRequest) Reset() {
-	*x = CloseSOLSessionRequest{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[23]
+func (x *BMCInfo) Reset() {
+	*x = BMCInfo{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseSOLSessionRequest) String() string {
+func (x *BMCInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseSOLSessionRequest) ProtoMessage() {}
+func (*BMCInfo) ProtoMessage() {}
 
-func (x *CloseSOLSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[23]
+func (x *BMCInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1500,40 +5251,83 @@ func (x *CloseSOLSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseSOLSessionRequest.ProtoReflect.Descriptor instead.
-func (*CloseSOLSessionRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use BMCInfo.ProtoReflect.Descriptor instead.
+func (*BMCInfo) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *CloseSOLSessionRequest) GetSessionId() string {
+func (x *BMCInfo) GetBmcType() string {
 	if x != nil {
-		return x.SessionId
+		return x.BmcType
 	}
 	return ""
 }
 
-// CloseSOLSessionResponse confirms SOL session closure
-type CloseSOLSessionResponse struct {
+func (x *BMCInfo) GetDetails() isBMCInfo_Details {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *BMCInfo) GetIpmiInfo() *IPMIInfo {
+	if x != nil {
+		if x, ok := x.Details.(*BMCInfo_IpmiInfo); ok {
+			return x.IpmiInfo
+		}
+	}
+	return nil
+}
+
+func (x *BMCInfo) GetRedfishInfo() *RedfishInfo {
+	if x != nil {
+		if x, ok := x.Details.(*BMCInfo_RedfishInfo); ok {
+			return x.RedfishInfo
+		}
+	}
+	return nil
+}
+
+type isBMCInfo_Details interface {
+	isBMCInfo_Details()
+}
+
+type BMCInfo_IpmiInfo struct {
+	IpmiInfo *IPMIInfo `protobuf:"bytes,2,opt,name=ipmi_info,json=ipmiInfo,proto3,oneof"`
+}
+
+type BMCInfo_RedfishInfo struct {
+	RedfishInfo *RedfishInfo `protobuf:"bytes,3,opt,name=redfish_info,json=redfishInfo,proto3,oneof"`
+}
+
+func (*BMCInfo_IpmiInfo) isBMCInfo_Details() {}
+
+func (*BMCInfo_RedfishInfo) isBMCInfo_Details() {}
+
+// GetBMCNetworkConfigRequest identifies the server whose BMC network
+// configuration is read
+type GetBMCNetworkConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CloseSOLSessionResponse) Reset() {
-	*x = CloseSOLSessionResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[24]
+func (x *GetBMCNetworkConfigRequest) Reset() {
+	*x = GetBMCNetworkConfigRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseSOLSessionResponse) String() string {
+func (x *GetBMCNetworkConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseSOLSessionResponse) ProtoMessage() {}
+func (*GetBMCNetworkConfigRequest) ProtoMessage() {}
 
-func (x *CloseSOLSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[24]
+func (x *GetBMCNetworkConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1544,37 +5338,42 @@ func (x *CloseSOLSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseSOLSessionResponse.ProtoReflect.Descriptor instead.
-func (*CloseSOLSessionResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use GetBMCNetworkConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetBMCNetworkConfigRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{78}
 }
 
-// ReportAvailableEndpointsRequest reports BMC endpoints that this gateway can proxy
-// This is sent by Gateway -> Manager to register available BMC endpoints
-type ReportAvailableEndpointsRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	GatewayId     string                     `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`          // Gateway identifier
-	Region        string                     `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                 // Gateway region
-	BmcEndpoints  []*BMCEndpointAvailability `protobuf:"bytes,3,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // BMC endpoints available through this gateway
+func (x *GetBMCNetworkConfigRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// GetBMCNetworkConfigResponse provides the management interfaces of a BMC
+type GetBMCNetworkConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BmcType       string                 `protobuf:"bytes,1,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"` // "ipmi" or "redfish"
+	Interfaces    []*BMCNetworkInterface `protobuf:"bytes,2,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportAvailableEndpointsRequest) Reset() {
-	*x = ReportAvailableEndpointsRequest{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[25]
+func (x *GetBMCNetworkConfigResponse) Reset() {
+	*x = GetBMCNetworkConfigResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportAvailableEndpointsRequest) String() string {
+func (x *GetBMCNetworkConfigResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportAvailableEndpointsRequest) ProtoMessage() {}
+func (*GetBMCNetworkConfigResponse) ProtoMessage() {}
 
-func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[25]
+func (x *GetBMCNetworkConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,64 +5381,60 @@ func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ReportAvailableEndpointsRequest.ProtoReflect.Descriptor instead.
-func (*ReportAvailableEndpointsRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{25}
+	return mi.MessageOf(x)
 }
 
-func (x *ReportAvailableEndpointsRequest) GetGatewayId() string {
-	if x != nil {
-		return x.GatewayId
-	}
-	return ""
+// Deprecated: Use GetBMCNetworkConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetBMCNetworkConfigResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *ReportAvailableEndpointsRequest) GetRegion() string {
+func (x *GetBMCNetworkConfigResponse) GetBmcType() string {
 	if x != nil {
-		return x.Region
+		return x.BmcType
 	}
 	return ""
 }
 
-func (x *ReportAvailableEndpointsRequest) GetBmcEndpoints() []*BMCEndpointAvailability {
+func (x *GetBMCNetworkConfigResponse) GetInterfaces() []*BMCNetworkInterface {
 	if x != nil {
-		return x.BmcEndpoints
+		return x.Interfaces
 	}
 	return nil
 }
 
-// BMCEndpointAvailability describes a BMC endpoint available through a gateway
-type BMCEndpointAvailability struct {
+// BMCNetworkInterface is the configuration of a management network
+// interface of a BMC. IPMI BMCs report the first LAN channel only.
+type BMCNetworkInterface struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BmcEndpoint   string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC endpoint (e.g., "192.168.1.100:623")
-	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent that provides access to this endpoint
-	DatacenterId  string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`          // Datacenter containing this BMC
-	BmcType       v1.BMCType             `protobuf:"varint,4,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"` // Type of BMC interface
-	Features      []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                      // Available features
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                          // Endpoint status
-	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                      // When this endpoint was last verified
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Redfish EthernetInterface ID, empty for IPMI
+	MacAddress    string                 `protobuf:"bytes,2,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	AddressOrigin string                 `protobuf:"bytes,3,opt,name=address_origin,json=addressOrigin,proto3" json:"address_origin,omitempty"` // "static" or "dhcp", empty when not reported
+	Ipv4Address   string                 `protobuf:"bytes,4,opt,name=ipv4_address,json=ipv4Address,proto3" json:"ipv4_address,omitempty"`
+	SubnetMask    string                 `protobuf:"bytes,5,opt,name=subnet_mask,json=subnetMask,proto3" json:"subnet_mask,omitempty"`
+	Gateway       string                 `protobuf:"bytes,6,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	VlanId        int32                  `protobuf:"varint,7,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`                     // 0 when no VLAN is enabled
+	Ipv6Addresses []string               `protobuf:"bytes,8,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"` // With their prefix length, e.g. fd00::10/64
+	Enabled       bool                   `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCEndpointAvailability) Reset() {
-	*x = BMCEndpointAvailability{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[26]
+func (x *BMCNetworkInterface) Reset() {
+	*x = BMCNetworkInterface{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCEndpointAvailability) String() string {
+func (x *BMCNetworkInterface) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BMCEndpointAvailability) ProtoMessage() {}
+func (*BMCNetworkInterface) ProtoMessage() {}
 
-func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[26]
+func (x *BMCNetworkInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,140 +5445,98 @@ func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BMCEndpointAvailability.ProtoReflect.Descriptor instead.
-func (*BMCEndpointAvailability) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use BMCNetworkInterface.ProtoReflect.Descriptor instead.
+func (*BMCNetworkInterface) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *BMCEndpointAvailability) GetBmcEndpoint() string {
+func (x *BMCNetworkInterface) GetId() string {
 	if x != nil {
-		return x.BmcEndpoint
+		return x.Id
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetAgentId() string {
+func (x *BMCNetworkInterface) GetMacAddress() string {
 	if x != nil {
-		return x.AgentId
+		return x.MacAddress
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetDatacenterId() string {
+func (x *BMCNetworkInterface) GetAddressOrigin() string {
 	if x != nil {
-		return x.DatacenterId
+		return x.AddressOrigin
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetBmcType() v1.BMCType {
+func (x *BMCNetworkInterface) GetIpv4Address() string {
 	if x != nil {
-		return x.BmcType
+		return x.Ipv4Address
 	}
-	return v1.BMCType(0)
+	return ""
 }
 
-func (x *BMCEndpointAvailability) GetFeatures() []string {
+func (x *BMCNetworkInterface) GetSubnetMask() string {
 	if x != nil {
-		return x.Features
+		return x.SubnetMask
 	}
-	return nil
+	return ""
 }
 
-func (x *BMCEndpointAvailability) GetStatus() string {
+func (x *BMCNetworkInterface) GetGateway() string {
 	if x != nil {
-		return x.Status
+		return x.Gateway
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetLastSeen() *timestamppb.Timestamp {
+func (x *BMCNetworkInterface) GetVlanId() int32 {
 	if x != nil {
-		return x.LastSeen
+		return x.VlanId
 	}
-	return nil
-}
-
-// ReportAvailableEndpointsResponse confirms endpoint registration
-type ReportAvailableEndpointsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ReportAvailableEndpointsResponse) Reset() {
-	*x = ReportAvailableEndpointsResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ReportAvailableEndpointsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*ReportAvailableEndpointsResponse) ProtoMessage() {}
-
-func (x *ReportAvailableEndpointsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[27]
+func (x *BMCNetworkInterface) GetIpv6Addresses() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Ipv6Addresses
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ReportAvailableEndpointsResponse.ProtoReflect.Descriptor instead.
-func (*ReportAvailableEndpointsResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{27}
+	return nil
 }
 
-func (x *ReportAvailableEndpointsResponse) GetSuccess() bool {
+func (x *BMCNetworkInterface) GetEnabled() bool {
 	if x != nil {
-		return x.Success
+		return x.Enabled
 	}
 	return false
 }
 
-func (x *ReportAvailableEndpointsResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-// StartVNCProxyRequest asks an agent to start a VNC proxy to a BMC
-type StartVNCProxyRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	SessionId       string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                   // VNC session identifier
-	BmcEndpoint     string                 `protobuf:"bytes,2,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`             // BMC endpoint to connect to (server ID)
-	BmcType         string                 `protobuf:"bytes,3,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"`                         // BMC type ("redfish" or "ipmi")
-	GatewayEndpoint string                 `protobuf:"bytes,4,opt,name=gateway_endpoint,json=gatewayEndpoint,proto3" json:"gateway_endpoint,omitempty"` // Gateway endpoint for reverse connection
-	AgentId         string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent ID to handle this session
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// DeepHealthCheckRequest identifies the server whose path is checked
+type DeepHealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CheckConsoles bool                   `protobuf:"varint,2,opt,name=check_consoles,json=checkConsoles,proto3" json:"check_consoles,omitempty"` // Also check the SOL and VNC endpoints
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartVNCProxyRequest) Reset() {
-	*x = StartVNCProxyRequest{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[28]
+func (x *DeepHealthCheckRequest) Reset() {
+	*x = DeepHealthCheckRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartVNCProxyRequest) String() string {
+func (x *DeepHealthCheckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartVNCProxyRequest) ProtoMessage() {}
+func (*DeepHealthCheckRequest) ProtoMessage() {}
 
-func (x *StartVNCProxyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[28]
+func (x *DeepHealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1794,71 +5547,51 @@ func (x *StartVNCProxyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartVNCProxyRequest.ProtoReflect.Descriptor instead.
-func (*StartVNCProxyRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{28}
-}
-
-func (x *StartVNCProxyRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *StartVNCProxyRequest) GetBmcEndpoint() string {
-	if x != nil {
-		return x.BmcEndpoint
-	}
-	return ""
-}
-
-func (x *StartVNCProxyRequest) GetBmcType() string {
-	if x != nil {
-		return x.BmcType
-	}
-	return ""
+// Deprecated: Use DeepHealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*DeepHealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *StartVNCProxyRequest) GetGatewayEndpoint() string {
+func (x *DeepHealthCheckRequest) GetServerId() string {
 	if x != nil {
-		return x.GatewayEndpoint
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *StartVNCProxyRequest) GetAgentId() string {
+func (x *DeepHealthCheckRequest) GetCheckConsoles() bool {
 	if x != nil {
-		return x.AgentId
+		return x.CheckConsoles
 	}
-	return ""
+	return false
 }
 
-// StartVNCProxyResponse confirms VNC proxy startup
-type StartVNCProxyResponse struct {
+// DeepHealthCheckResponse reports the hops from the gateway to a BMC, in
+// order
+type DeepHealthCheckResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                 // Whether the proxy was started successfully
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                  // Success message or error details
-	ProxyEndpoint string                 `protobuf:"bytes,3,opt,name=proxy_endpoint,json=proxyEndpoint,proto3" json:"proxy_endpoint,omitempty"` // Endpoint where the proxy is listening (if applicable)
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Healthy       bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"` // True when no hop failed
+	Hops          []*HealthCheckHop      `protobuf:"bytes,3,rep,name=hops,proto3" json:"hops,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartVNCProxyResponse) Reset() {
-	*x = StartVNCProxyResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[29]
+func (x *DeepHealthCheckResponse) Reset() {
+	*x = DeepHealthCheckResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartVNCProxyResponse) String() string {
+func (x *DeepHealthCheckResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartVNCProxyResponse) ProtoMessage() {}
+func (*DeepHealthCheckResponse) ProtoMessage() {}
 
-func (x *StartVNCProxyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[29]
+func (x *DeepHealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1869,59 +5602,59 @@ func (x *StartVNCProxyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartVNCProxyResponse.ProtoReflect.Descriptor instead.
-func (*StartVNCProxyResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use DeepHealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*DeepHealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *StartVNCProxyResponse) GetSuccess() bool {
+func (x *DeepHealthCheckResponse) GetServerId() string {
 	if x != nil {
-		return x.Success
+		return x.ServerId
 	}
-	return false
+	return ""
 }
 
-func (x *StartVNCProxyResponse) GetMessage() string {
+func (x *DeepHealthCheckResponse) GetHealthy() bool {
 	if x != nil {
-		return x.Message
+		return x.Healthy
 	}
-	return ""
+	return false
 }
 
-func (x *StartVNCProxyResponse) GetProxyEndpoint() string {
+func (x *DeepHealthCheckResponse) GetHops() []*HealthCheckHop {
 	if x != nil {
-		return x.ProxyEndpoint
+		return x.Hops
 	}
-	return ""
+	return nil
 }
 
-// VNCDataChunk represents a chunk of VNC data being streamed
-type VNCDataChunk struct {
+// HealthCheckHop is the status of one hop of a deep health check
+type HealthCheckHop struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`        // Session identifier for this VNC stream
-	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`           // Server ID (used in initial handshake)
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                   // Raw VNC protocol data
-	IsHandshake   bool                   `protobuf:"varint,4,opt,name=is_handshake,json=isHandshake,proto3" json:"is_handshake,omitempty"` // True if this is the initial connection handshake
-	CloseStream   bool                   `protobuf:"varint,5,opt,name=close_stream,json=closeStream,proto3" json:"close_stream,omitempty"` // True to signal stream closure
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                             // "agent", "bmc_control", "sol" or "vnc"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                         // "ok", "failed" or "skipped"
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`                         // Agent ID or BMC endpoint checked
+	LatencyMs     int64                  `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"` // Time taken by the check
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                           // Why the hop failed or was skipped
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VNCDataChunk) Reset() {
-	*x = VNCDataChunk{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[30]
+func (x *HealthCheckHop) Reset() {
+	*x = HealthCheckHop{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VNCDataChunk) String() string {
+func (x *HealthCheckHop) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VNCDataChunk) ProtoMessage() {}
+func (*HealthCheckHop) ProtoMessage() {}
 
-func (x *VNCDataChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[30]
+func (x *HealthCheckHop) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1932,73 +5665,69 @@ func (x *VNCDataChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VNCDataChunk.ProtoReflect.Descriptor instead.
-func (*VNCDataChunk) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use HealthCheckHop.ProtoReflect.Descriptor instead.
+func (*HealthCheckHop) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *VNCDataChunk) GetSessionId() string {
+func (x *HealthCheckHop) GetName() string {
 	if x != nil {
-		return x.SessionId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *VNCDataChunk) GetServerId() string {
+func (x *HealthCheckHop) GetStatus() string {
 	if x != nil {
-		return x.ServerId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *VNCDataChunk) GetData() []byte {
+func (x *HealthCheckHop) GetTarget() string {
 	if x != nil {
-		return x.Data
+		return x.Target
 	}
-	return nil
+	return ""
 }
 
-func (x *VNCDataChunk) GetIsHandshake() bool {
+func (x *HealthCheckHop) GetLatencyMs() int64 {
 	if x != nil {
-		return x.IsHandshake
+		return x.LatencyMs
 	}
-	return false
+	return 0
 }
 
-func (x *VNCDataChunk) GetCloseStream() bool {
+func (x *HealthCheckHop) GetError() string {
 	if x != nil {
-		return x.CloseStream
+		return x.Error
 	}
-	return false
+	return ""
 }
 
-// ConsoleDataChunk represents a chunk of console/SOL data being streamed
-type ConsoleDataChunk struct {
+// GetPowerReadingRequest identifies the server to read power consumption from
+type GetPowerReadingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`        // Session identifier for this console stream
-	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`           // Server ID (used in initial handshake)
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                   // Raw console/SOL data
-	IsHandshake   bool                   `protobuf:"varint,4,opt,name=is_handshake,json=isHandshake,proto3" json:"is_handshake,omitempty"` // True if this is the initial connection handshake
-	CloseStream   bool                   `protobuf:"varint,5,opt,name=close_stream,json=closeStream,proto3" json:"close_stream,omitempty"` // True to signal stream closure
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConsoleDataChunk) Reset() {
-	*x = ConsoleDataChunk{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[31]
+func (x *GetPowerReadingRequest) Reset() {
+	*x = GetPowerReadingRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConsoleDataChunk) String() string {
+func (x *GetPowerReadingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConsoleDataChunk) ProtoMessage() {}
+func (*GetPowerReadingRequest) ProtoMessage() {}
 
-func (x *ConsoleDataChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[31]
+func (x *GetPowerReadingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2009,69 +5738,41 @@ func (x *ConsoleDataChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConsoleDataChunk.ProtoReflect.Descriptor instead.
-func (*ConsoleDataChunk) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *ConsoleDataChunk) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+// Deprecated: Use GetPowerReadingRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerReadingRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *ConsoleDataChunk) GetServerId() string {
+func (x *GetPowerReadingRequest) GetServerId() string {
 	if x != nil {
 		return x.ServerId
 	}
 	return ""
 }
 
-func (x *ConsoleDataChunk) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *ConsoleDataChunk) GetIsHandshake() bool {
-	if x != nil {
-		return x.IsHandshake
-	}
-	return false
-}
-
-func (x *ConsoleDataChunk) GetCloseStream() bool {
-	if x != nil {
-		return x.CloseStream
-	}
-	return false
-}
-
-// GetBMCInfoRequest requests hardware information from a BMC
-type GetBMCInfoRequest struct {
+// GetPowerReadingResponse provides the current power consumption of a server
+type GetPowerReadingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to retrieve BMC information for
+	Reading       *PowerReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBMCInfoRequest) Reset() {
-	*x = GetBMCInfoRequest{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[32]
+func (x *GetPowerReadingResponse) Reset() {
+	*x = GetPowerReadingResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBMCInfoRequest) String() string {
+func (x *GetPowerReadingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBMCInfoRequest) ProtoMessage() {}
+func (*GetPowerReadingResponse) ProtoMessage() {}
 
-func (x *GetBMCInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[32]
+func (x *GetPowerReadingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2082,41 +5783,47 @@ func (x *GetBMCInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBMCInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetBMCInfoRequest) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use GetPowerReadingResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerReadingResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetBMCInfoRequest) GetServerId() string {
+func (x *GetPowerReadingResponse) GetReading() *PowerReading {
 	if x != nil {
-		return x.ServerId
+		return x.Reading
 	}
-	return ""
+	return nil
 }
 
-// GetBMCInfoResponse provides BMC hardware information
-type GetBMCInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Info          *BMCInfo               `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"` // BMC hardware information
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// PowerReading is a power consumption reading of a BMC
+type PowerReading struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ConsumedWatts   float64                `protobuf:"fixed64,1,opt,name=consumed_watts,json=consumedWatts,proto3" json:"consumed_watts,omitempty"`      // Current power consumption
+	AverageWatts    float64                `protobuf:"fixed64,2,opt,name=average_watts,json=averageWatts,proto3" json:"average_watts,omitempty"`         // Average over interval_minutes, 0 when not reported
+	MinWatts        float64                `protobuf:"fixed64,3,opt,name=min_watts,json=minWatts,proto3" json:"min_watts,omitempty"`                     // Minimum over interval_minutes, 0 when not reported
+	MaxWatts        float64                `protobuf:"fixed64,4,opt,name=max_watts,json=maxWatts,proto3" json:"max_watts,omitempty"`                     // Maximum over interval_minutes, 0 when not reported
+	IntervalMinutes int32                  `protobuf:"varint,5,opt,name=interval_minutes,json=intervalMinutes,proto3" json:"interval_minutes,omitempty"` // Interval of the average, min and max
+	CapacityWatts   float64                `protobuf:"fixed64,6,opt,name=capacity_watts,json=capacityWatts,proto3" json:"capacity_watts,omitempty"`      // Power supply capacity, 0 when not reported
+	ReadAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`                             // When the BMC was read
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetBMCInfoResponse) Reset() {
-	*x = GetBMCInfoResponse{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[33]
+func (x *PowerReading) Reset() {
+	*x = PowerReading{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBMCInfoResponse) String() string {
+func (x *PowerReading) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBMCInfoResponse) ProtoMessage() {}
+func (*PowerReading) ProtoMessage() {}
 
-func (x *GetBMCInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[33]
+func (x *PowerReading) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2127,46 +5834,83 @@ func (x *GetBMCInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBMCInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetBMCInfoResponse) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use PowerReading.ProtoReflect.Descriptor instead.
+func (*PowerReading) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *GetBMCInfoResponse) GetInfo() *BMCInfo {
+func (x *PowerReading) GetConsumedWatts() float64 {
 	if x != nil {
-		return x.Info
+		return x.ConsumedWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetAverageWatts() float64 {
+	if x != nil {
+		return x.AverageWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetMinWatts() float64 {
+	if x != nil {
+		return x.MinWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetMaxWatts() float64 {
+	if x != nil {
+		return x.MaxWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetIntervalMinutes() int32 {
+	if x != nil {
+		return x.IntervalMinutes
+	}
+	return 0
+}
+
+func (x *PowerReading) GetCapacityWatts() float64 {
+	if x != nil {
+		return x.CapacityWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
 	}
 	return nil
 }
 
-// BMCInfo contains hardware information from a BMC
-type BMCInfo struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	BmcType string                 `protobuf:"bytes,1,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"` // "ipmi" or "redfish"
-	// Types that are valid to be assigned to Details:
-	//
-	//	*BMCInfo_IpmiInfo
-	//	*BMCInfo_RedfishInfo
-	Details       isBMCInfo_Details `protobuf_oneof:"details"`
+// GetEnergyUsageRequest identifies the server to read energy consumption from
+type GetEnergyUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCInfo) Reset() {
-	*x = BMCInfo{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[34]
+func (x *GetEnergyUsageRequest) Reset() {
+	*x = GetEnergyUsageRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCInfo) String() string {
+func (x *GetEnergyUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BMCInfo) ProtoMessage() {}
+func (*GetEnergyUsageRequest) ProtoMessage() {}
 
-func (x *BMCInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[34]
+func (x *GetEnergyUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2177,59 +5921,71 @@ func (x *BMCInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BMCInfo.ProtoReflect.Descriptor instead.
-func (*BMCInfo) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use GetEnergyUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetEnergyUsageRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *BMCInfo) GetBmcType() string {
+func (x *GetEnergyUsageRequest) GetServerId() string {
 	if x != nil {
-		return x.BmcType
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *BMCInfo) GetDetails() isBMCInfo_Details {
-	if x != nil {
-		return x.Details
-	}
-	return nil
+// GetEnergyUsageResponse provides the energy counter of a server
+type GetEnergyUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnergyKwh     float64                `protobuf:"fixed64,1,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"` // Cumulative energy consumed, as counted by the BMC
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`            // When the BMC was read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCInfo) GetIpmiInfo() *IPMIInfo {
-	if x != nil {
-		if x, ok := x.Details.(*BMCInfo_IpmiInfo); ok {
-			return x.IpmiInfo
-		}
-	}
-	return nil
+func (x *GetEnergyUsageResponse) Reset() {
+	*x = GetEnergyUsageResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCInfo) GetRedfishInfo() *RedfishInfo {
+func (x *GetEnergyUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnergyUsageResponse) ProtoMessage() {}
+
+func (x *GetEnergyUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[88]
 	if x != nil {
-		if x, ok := x.Details.(*BMCInfo_RedfishInfo); ok {
-			return x.RedfishInfo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isBMCInfo_Details interface {
-	isBMCInfo_Details()
+// Deprecated: Use GetEnergyUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetEnergyUsageResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{88}
 }
 
-type BMCInfo_IpmiInfo struct {
-	IpmiInfo *IPMIInfo `protobuf:"bytes,2,opt,name=ipmi_info,json=ipmiInfo,proto3,oneof"`
+func (x *GetEnergyUsageResponse) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
 }
 
-type BMCInfo_RedfishInfo struct {
-	RedfishInfo *RedfishInfo `protobuf:"bytes,3,opt,name=redfish_info,json=redfishInfo,proto3,oneof"`
+func (x *GetEnergyUsageResponse) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
 }
 
-func (*BMCInfo_IpmiInfo) isBMCInfo_Details() {}
-
-func (*BMCInfo_RedfishInfo) isBMCInfo_Details() {}
-
 // IPMIInfo contains hardware information from IPMI mc info command
 type IPMIInfo struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
@@ -2249,7 +6005,7 @@ type IPMIInfo struct {
 
 func (x *IPMIInfo) Reset() {
 	*x = IPMIInfo{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[35]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2261,7 +6017,7 @@ func (x *IPMIInfo) String() string {
 func (*IPMIInfo) ProtoMessage() {}
 
 func (x *IPMIInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[35]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2274,7 +6030,7 @@ func (x *IPMIInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IPMIInfo.ProtoReflect.Descriptor instead.
 func (*IPMIInfo) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{35}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *IPMIInfo) GetDeviceId() string {
@@ -2365,7 +6121,7 @@ type RedfishInfo struct {
 
 func (x *RedfishInfo) Reset() {
 	*x = RedfishInfo{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[36]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2377,7 +6133,7 @@ func (x *RedfishInfo) String() string {
 func (*RedfishInfo) ProtoMessage() {}
 
 func (x *RedfishInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[36]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2390,7 +6146,7 @@ func (x *RedfishInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedfishInfo.ProtoReflect.Descriptor instead.
 func (*RedfishInfo) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{36}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *RedfishInfo) GetManagerId() string {
@@ -2468,7 +6224,7 @@ type NetworkProtocol struct {
 
 func (x *NetworkProtocol) Reset() {
 	*x = NetworkProtocol{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[37]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2480,7 +6236,7 @@ func (x *NetworkProtocol) String() string {
 func (*NetworkProtocol) ProtoMessage() {}
 
 func (x *NetworkProtocol) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[37]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2493,7 +6249,7 @@ func (x *NetworkProtocol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkProtocol.ProtoReflect.Descriptor instead.
 func (*NetworkProtocol) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{37}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *NetworkProtocol) GetName() string {
@@ -2539,7 +6295,7 @@ type SystemStatus struct {
 
 func (x *SystemStatus) Reset() {
 	*x = SystemStatus{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[38]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2551,7 +6307,7 @@ func (x *SystemStatus) String() string {
 func (*SystemStatus) ProtoMessage() {}
 
 func (x *SystemStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[38]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2564,7 +6320,7 @@ func (x *SystemStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
 func (*SystemStatus) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{38}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *SystemStatus) GetSystemId() string {
@@ -2670,7 +6426,7 @@ type BootSourceOverride struct {
 
 func (x *BootSourceOverride) Reset() {
 	*x = BootSourceOverride{}
-	mi := &file_gateway_v1_gateway_proto_msgTypes[39]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2682,7 +6438,7 @@ func (x *BootSourceOverride) String() string {
 func (*BootSourceOverride) ProtoMessage() {}
 
 func (x *BootSourceOverride) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_v1_gateway_proto_msgTypes[39]
+	mi := &file_gateway_v1_gateway_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2695,7 +6451,7 @@ func (x *BootSourceOverride) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BootSourceOverride.ProtoReflect.Descriptor instead.
 func (*BootSourceOverride) Descriptor() ([]byte, []int) {
-	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{39}
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *BootSourceOverride) GetTarget() string {
@@ -2719,6 +6475,118 @@ func (x *BootSourceOverride) GetMode() string {
 	return ""
 }
 
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                                                             // Base level: trace, debug, info, warn or error; unchanged when empty
+	ModuleLevels  map[string]string      `protobuf:"bytes,2,rep,name=module_levels,json=moduleLevels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Levels of Go packages, e.g. "streaming" or "internal/gateway"; an empty level clears one
+	ResetModules  bool                   `protobuf:"varint,3,opt,name=reset_modules,json=resetModules,proto3" json:"reset_modules,omitempty"`                                                                          // Clear the levels of all modules before applying module_levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetModuleLevels() map[string]string {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
+
+func (x *SetLogLevelRequest) GetResetModules() bool {
+	if x != nil {
+		return x.ResetModules
+	}
+	return false
+}
+
+type SetLogLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                                                             // Base level in effect
+	ModuleLevels  map[string]string      `protobuf:"bytes,2,rep,name=module_levels,json=moduleLevels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Levels of modules in effect
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_gateway_v1_gateway_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_v1_gateway_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_gateway_v1_gateway_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *SetLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelResponse) GetModuleLevels() map[string]string {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
+
 var File_gateway_v1_gateway_proto protoreflect.FileDescriptor
 
 const file_gateway_v1_gateway_proto_rawDesc = "" +
@@ -2728,31 +6596,104 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\x12HealthCheckRequest\"g\n" +
 	"\x13HealthCheckResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x128\n" +
-	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"4\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x90\x01\n" +
 	"\x15PowerOperationRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"L\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x121\n" +
+	"\x14maintenance_override\x18\x02 \x01(\bR\x13maintenanceOverride\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"L\n" +
 	"\x16PowerOperationResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"1\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x99\x01\n" +
+	"\x17GracefulShutdownRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12.\n" +
+	"\x13force_after_seconds\x18\x02 \x01(\x05R\x11forceAfterSeconds\x121\n" +
+	"\x14maintenance_override\x18\x03 \x01(\bR\x13maintenanceOverride\"\x85\x01\n" +
+	"\x18GracefulShutdownResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\bforce_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aforceAt\"\xa4\x01\n" +
+	"\x18RunPowerOperationRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x128\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x1a.gateway.v1.PowerOperationR\toperation\x121\n" +
+	"\x14maintenance_override\x18\x03 \x01(\bR\x13maintenanceOverride\"\xa0\x01\n" +
+	"\x16PowerOperationProgress\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12)\n" +
+	"\x10percent_complete\x18\x03 \x01(\x05R\x0fpercentComplete\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"\xaf\x03\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x128\n" +
+	"\toperation\x18\x03 \x01(\x0e2\x1a.gateway.v1.PowerOperationR\toperation\x120\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1a.gateway.v1.OperationStateR\x05state\x12>\n" +
+	"\bprogress\x18\x05 \x01(\v2\".gateway.v1.PowerOperationProgressR\bprogress\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"R\n" +
+	"\x1bStartPowerOperationResponse\x123\n" +
+	"\toperation\x18\x01 \x01(\v2\x15.gateway.v1.OperationR\toperation\"U\n" +
+	"\x13GetOperationRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\"K\n" +
+	"\x14GetOperationResponse\x123\n" +
+	"\toperation\x18\x01 \x01(\v2\x15.gateway.v1.OperationR\toperation\"4\n" +
+	"\x15ListOperationsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"O\n" +
+	"\x16ListOperationsResponse\x125\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x15.gateway.v1.OperationR\n" +
+	"operations\"1\n" +
 	"\x12PowerStatusRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"]\n" +
 	"\x13PowerStatusResponse\x12,\n" +
 	"\x05state\x18\x01 \x01(\x0e2\x16.gateway.v1.PowerStateR\x05state\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xbc\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x94\x01\n" +
+	"\x19SetChassisIdentifyRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12/\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x19.gateway.v1.IdentifyStateR\x05state\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x05R\x0fdurationSeconds\"\x83\x01\n" +
+	"\x1aSetChassisIdentifyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\x06off_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05offAt\"\xd6\x01\n" +
 	"\x14RegisterAgentRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
 	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12H\n" +
-	"\rbmc_endpoints\x18\x04 \x03(\v2#.gateway.v1.BMCEndpointRegistrationR\fbmcEndpoints\"K\n" +
+	"\rbmc_endpoints\x18\x04 \x03(\v2#.gateway.v1.BMCEndpointRegistrationR\fbmcEndpoints\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\"u\n" +
 	"\x15RegisterAgentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"|\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x10stream_token_key\x18\x03 \x01(\fR\x0estreamTokenKey\"|\n" +
 	"\x15AgentHeartbeatRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12H\n" +
 	"\rbmc_endpoints\x18\x02 \x03(\v2#.gateway.v1.BMCEndpointRegistrationR\fbmcEndpoints\"p\n" +
 	"\x16AgentHeartbeatResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12<\n" +
-	"\x1aheartbeat_interval_seconds\x18\x02 \x01(\x05R\x18heartbeatIntervalSeconds\"\xc4\x04\n" +
+	"\x1aheartbeat_interval_seconds\x18\x02 \x01(\x05R\x18heartbeatIntervalSeconds\"`\n" +
+	"\x16ReportHostDriftRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12+\n" +
+	"\x05hosts\x18\x02 \x03(\v2\x15.gateway.v1.HostDriftR\x05hosts\"\xb2\x01\n" +
+	"\tHostDrift\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x17\n" +
+	"\ahost_id\x18\x02 \x01(\tR\x06hostId\x129\n" +
+	"\n" +
+	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x124\n" +
+	"\bfindings\x18\x04 \x03(\v2\x18.gateway.v1.DriftFindingR\bfindings\"\xa2\x01\n" +
+	"\fDriftFinding\x12\x14\n" +
+	"\x05check\x18\x01 \x01(\tR\x05check\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x18\n" +
+	"\adesired\x18\x03 \x01(\tR\adesired\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\tR\x06actual\x12\x18\n" +
+	"\aapplied\x18\x05 \x01(\bR\aapplied\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"3\n" +
+	"\x17ReportHostDriftResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc4\x04\n" +
 	"\x17BMCEndpointRegistration\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12J\n" +
 	"\x11control_endpoints\x18\x02 \x03(\v2\x1d.common.v1.BMCControlEndpointR\x10controlEndpoints\x12=\n" +
@@ -2765,9 +6706,135 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\x12discovery_metadata\x18\t \x01(\v2\x1c.common.v1.DiscoveryMetadataR\x11discoveryMetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"6\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"2\n" +
+	"\x15GetAgentStatusRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"G\n" +
+	"\x16GetAgentStatusResponse\x12-\n" +
+	"\x05agent\x18\x01 \x01(\v2\x17.gateway.v1.AgentStatusR\x05agent\"8\n" +
+	"\x11ListAgentsRequest\x12#\n" +
+	"\rdatacenter_id\x18\x01 \x01(\tR\fdatacenterId\"E\n" +
+	"\x12ListAgentsResponse\x12/\n" +
+	"\x06agents\x18\x01 \x03(\v2\x17.gateway.v1.AgentStatusR\x06agents\"\xe0\x03\n" +
+	"\vAgentStatus\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
+	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12?\n" +
+	"\rregistered_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x127\n" +
+	"\tlast_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x120\n" +
+	"\x14active_session_count\x18\b \x01(\x05R\x12activeSessionCount\x12G\n" +
+	"\rbmc_endpoints\x18\t \x03(\v2\".gateway.v1.AgentBMCEndpointStatusR\fbmcEndpoints\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\n" +
+	" \x01(\tR\tgatewayId\x12/\n" +
+	"\x04link\x18\v \x01(\v2\x1b.gateway.v1.AgentLinkStatusR\x04link\"\xb9\x01\n" +
+	"\x0fAgentLinkStatus\x127\n" +
+	"\tprobed_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bprobedAt\x12\x15\n" +
+	"\x06rtt_ms\x18\x02 \x01(\x01R\x05rttMs\x12\x1d\n" +
+	"\n" +
+	"upload_bps\x18\x03 \x01(\x01R\tuploadBps\x12!\n" +
+	"\fdownload_bps\x18\x04 \x01(\x01R\vdownloadBps\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xf4\x01\n" +
+	"\x16AgentBMCEndpointStatus\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12!\n" +
+	"\fbmc_endpoint\x18\x02 \x01(\tR\vbmcEndpoint\x12-\n" +
+	"\bbmc_type\x18\x03 \x01(\x0e2\x12.common.v1.BMCTypeR\abmcType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x127\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\"\x19\n" +
+	"\x17GetGatewayStatusRequest\"\xf7\x02\n" +
+	"\x18GetGatewayStatusResponse\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12)\n" +
+	"\x10manager_endpoint\x18\x03 \x01(\tR\x0fmanagerEndpoint\x12/\n" +
+	"\x06agents\x18\x04 \x03(\v2\x17.gateway.v1.AgentStatusR\x06agents\x12<\n" +
+	"\bsessions\x18\x05 \x01(\v2 .gateway.v1.ConsoleSessionCountsR\bsessions\x12K\n" +
+	"\x11endpoint_mappings\x18\x06 \x03(\v2\x1e.gateway.v1.BMCEndpointMappingR\x10endpointMappings\x12=\n" +
+	"\fgenerated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"{\n" +
+	"\x14ConsoleSessionCounts\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x10\n" +
+	"\x03vnc\x18\x02 \x01(\x05R\x03vnc\x12\x10\n" +
+	"\x03sol\x18\x03 \x01(\x05R\x03sol\x12)\n" +
+	"\x10attached_streams\x18\x04 \x01(\x05R\x0fattachedStreams\"\x94\x02\n" +
+	"\x12BMCEndpointMapping\x12!\n" +
+	"\fbmc_endpoint\x18\x01 \x01(\tR\vbmcEndpoint\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12#\n" +
+	"\rdatacenter_id\x18\x04 \x01(\tR\fdatacenterId\x12-\n" +
+	"\bbmc_type\x18\x05 \x01(\x0e2\x12.common.v1.BMCTypeR\abmcType\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x127\n" +
+	"\tlast_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"S\n" +
+	"\x19ListActiveSessionsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\"\xa7\x01\n" +
+	"\x1aListActiveSessionsResponse\x125\n" +
+	"\bsessions\x18\x01 \x03(\v2\x19.gateway.v1.ActiveSessionR\bsessions\x12(\n" +
+	"\x10max_sol_sessions\x18\x02 \x01(\x05R\x0emaxSolSessions\x12(\n" +
+	"\x10max_vnc_sessions\x18\x03 \x01(\x05R\x0emaxVncSessions\"\xbe\x01\n" +
+	"\rActiveSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12\x1a\n" +
+	"\bendpoint\x18\x04 \x01(\tR\bendpoint\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"Q\n" +
+	"\x10ProbeLinkRequest\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\x12#\n" +
+	"\rresponse_size\x18\x02 \x01(\x05R\fresponseSize\"-\n" +
+	"\x11ProbeLinkResponse\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\"u\n" +
+	"\x1aListConsoleSessionsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\"Y\n" +
+	"\x1bListConsoleSessionsResponse\x12:\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1e.gateway.v1.ConsoleSessionInfoR\bsessions\"\xce\x04\n" +
+	"\x12ConsoleSessionInfo\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x04 \x01(\tR\n" +
+	"customerId\x12%\n" +
+	"\x0ecustomer_email\x18\x05 \x01(\tR\rcustomerEmail\x12\x19\n" +
+	"\bagent_id\x18\x06 \x01(\tR\aagentId\x12!\n" +
+	"\fbmc_endpoint\x18\a \x01(\tR\vbmcEndpoint\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x127\n" +
+	"\astreams\x18\n" +
+	" \x03(\v2\x1d.gateway.v1.ConsoleStreamInfoR\astreams\x12\x1b\n" +
+	"\tread_only\x18\v \x01(\bR\breadOnly\x12'\n" +
+	"\x0fkeyboard_layout\x18\f \x01(\tR\x0ekeyboardLayout\x12'\n" +
+	"\x0fimpersonated_by\x18\r \x01(\tR\x0eimpersonatedBy\x12D\n" +
+	"\x10last_activity_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"\x95\x01\n" +
+	"\x11ConsoleStreamInfo\x12%\n" +
+	"\x0eclient_address\x18\x01 \x01(\tR\rclientAddress\x12\x1c\n" +
+	"\ttransport\x18\x02 \x01(\tR\ttransport\x12;\n" +
+	"\vattached_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"attachedAt\"W\n" +
+	"\x1eTerminateConsoleSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"T\n" +
+	"\x1fTerminateConsoleSessionResponse\x121\n" +
+	"\x14disconnected_streams\x18\x01 \x01(\x05R\x13disconnectedStreams\";\n" +
+	"\x1aRenewConsoleSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x87\x01\n" +
+	"\x1bRenewConsoleSessionResponse\x129\n" +
+	"\n" +
+	"expires_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12-\n" +
+	"\x12websocket_endpoint\x18\x02 \x01(\tR\x11websocketEndpoint\"|\n" +
 	"\x17CreateVNCSessionRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"\xc2\x01\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tread_only\x18\x02 \x01(\bR\breadOnly\x12'\n" +
+	"\x0fkeyboard_layout\x18\x03 \x01(\tR\x0ekeyboardLayout\"\xc2\x01\n" +
 	"\x18CreateVNCSessionResponse\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
@@ -2778,7 +6845,7 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"viewer_url\x18\x04 \x01(\tR\tviewerUrl\"5\n" +
 	"\x14GetVNCSessionRequest\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"\xd1\x02\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x97\x03\n" +
 	"\n" +
 	"VNCSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
@@ -2793,15 +6860,19 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"I\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12D\n" +
+	"\x10last_activity_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"I\n" +
 	"\x15GetVNCSessionResponse\x120\n" +
 	"\asession\x18\x01 \x01(\v2\x16.gateway.v1.VNCSessionR\asession\"7\n" +
 	"\x16CloseVNCSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"\x19\n" +
-	"\x17CloseVNCSessionResponse\"6\n" +
+	"\x17CloseVNCSessionResponse\"\x81\x01\n" +
 	"\x17CreateSOLSessionRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"\xc4\x01\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12,\n" +
+	"\x06config\x18\x02 \x01(\v2\x14.common.v1.SOLConfigR\x06config\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\"\xc4\x01\n" +
 	"\x18CreateSOLSessionResponse\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
@@ -2812,7 +6883,7 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"consoleUrl\"5\n" +
 	"\x14GetSOLSessionRequest\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"\xd3\x02\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xb6\x03\n" +
 	"\n" +
 	"SOLSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
@@ -2827,7 +6898,10 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"I\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tread_only\x18\n" +
+	" \x01(\bR\breadOnly\x12D\n" +
+	"\x10last_activity_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"I\n" +
 	"\x15GetSOLSessionResponse\x120\n" +
 	"\asession\x18\x01 \x01(\v2\x16.gateway.v1.SOLSessionR\asession\"7\n" +
 	"\x16CloseSOLSessionRequest\x12\x1d\n" +
@@ -2860,21 +6934,55 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\x15StartVNCProxyResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
-	"\x0eproxy_endpoint\x18\x03 \x01(\tR\rproxyEndpoint\"\xa4\x01\n" +
+	"\x0eproxy_endpoint\x18\x03 \x01(\tR\rproxyEndpoint\"\xee\x03\n" +
 	"\fVNCDataChunk\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
 	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12!\n" +
 	"\fis_handshake\x18\x04 \x01(\bR\visHandshake\x12!\n" +
-	"\fclose_stream\x18\x05 \x01(\bR\vcloseStream\"\xa8\x01\n" +
+	"\fclose_stream\x18\x05 \x01(\bR\vcloseStream\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\x12B\n" +
+	"\bmetadata\x18\b \x03(\v2&.gateway.v1.VNCDataChunk.MetadataEntryR\bmetadata\x12\x1a\n" +
+	"\bfragment\x18\t \x01(\rR\bfragment\x12%\n" +
+	"\x0emore_fragments\x18\n" +
+	" \x01(\bR\rmoreFragments\x12\x1f\n" +
+	"\bchecksum\x18\v \x01(\aH\x00R\bchecksum\x88\x01\x01\x12\x12\n" +
+	"\x04ping\x18\f \x01(\bR\x04ping\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_checksum\"\xa9\x04\n" +
 	"\x10ConsoleDataChunk\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
 	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12!\n" +
 	"\fis_handshake\x18\x04 \x01(\bR\visHandshake\x12!\n" +
-	"\fclose_stream\x18\x05 \x01(\bR\vcloseStream\"0\n" +
+	"\fclose_stream\x18\x05 \x01(\bR\vcloseStream\x120\n" +
+	"\x06resize\x18\x06 \x01(\v2\x18.gateway.v1.TerminalSizeR\x06resize\x12%\n" +
+	"\x0eforce_takeover\x18\a \x01(\bR\rforceTakeover\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\t \x01(\tR\ferrorMessage\x12F\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2*.gateway.v1.ConsoleDataChunk.MetadataEntryR\bmetadata\x12\x1b\n" +
+	"\tstream_id\x18\v \x01(\tR\bstreamId\x12\x1f\n" +
+	"\bchecksum\x18\f \x01(\aH\x00R\bchecksum\x88\x01\x01\x12\x12\n" +
+	"\x04ping\x18\r \x01(\bR\x04ping\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_checksum\"k\n" +
+	"\x16SendConsoleDataRequest\x12\x1b\n" +
+	"\tstream_id\x18\x01 \x01(\tR\bstreamId\x124\n" +
+	"\x06chunks\x18\x02 \x03(\v2\x1c.gateway.v1.ConsoleDataChunkR\x06chunks\"\x19\n" +
+	"\x17SendConsoleDataResponse\"6\n" +
+	"\fTerminalSize\x12\x12\n" +
+	"\x04cols\x18\x01 \x01(\rR\x04cols\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\rR\x04rows\"0\n" +
 	"\x11GetBMCInfoRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"=\n" +
 	"\x12GetBMCInfoResponse\x12'\n" +
@@ -2883,7 +6991,58 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\bbmc_type\x18\x01 \x01(\tR\abmcType\x123\n" +
 	"\tipmi_info\x18\x02 \x01(\v2\x14.gateway.v1.IPMIInfoH\x00R\bipmiInfo\x12<\n" +
 	"\fredfish_info\x18\x03 \x01(\v2\x17.gateway.v1.RedfishInfoH\x00R\vredfishInfoB\t\n" +
-	"\adetails\"\xae\x03\n" +
+	"\adetails\"9\n" +
+	"\x1aGetBMCNetworkConfigRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"y\n" +
+	"\x1bGetBMCNetworkConfigResponse\x12\x19\n" +
+	"\bbmc_type\x18\x01 \x01(\tR\abmcType\x12?\n" +
+	"\n" +
+	"interfaces\x18\x02 \x03(\v2\x1f.gateway.v1.BMCNetworkInterfaceR\n" +
+	"interfaces\"\xa5\x02\n" +
+	"\x13BMCNetworkInterface\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vmac_address\x18\x02 \x01(\tR\n" +
+	"macAddress\x12%\n" +
+	"\x0eaddress_origin\x18\x03 \x01(\tR\raddressOrigin\x12!\n" +
+	"\fipv4_address\x18\x04 \x01(\tR\vipv4Address\x12\x1f\n" +
+	"\vsubnet_mask\x18\x05 \x01(\tR\n" +
+	"subnetMask\x12\x18\n" +
+	"\agateway\x18\x06 \x01(\tR\agateway\x12\x17\n" +
+	"\avlan_id\x18\a \x01(\x05R\x06vlanId\x12%\n" +
+	"\x0eipv6_addresses\x18\b \x03(\tR\ripv6Addresses\x12\x18\n" +
+	"\aenabled\x18\t \x01(\bR\aenabled\"\\\n" +
+	"\x16DeepHealthCheckRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12%\n" +
+	"\x0echeck_consoles\x18\x02 \x01(\bR\rcheckConsoles\"\x80\x01\n" +
+	"\x17DeepHealthCheckResponse\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\x12.\n" +
+	"\x04hops\x18\x03 \x03(\v2\x1a.gateway.v1.HealthCheckHopR\x04hops\"\x89\x01\n" +
+	"\x0eHealthCheckHop\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"5\n" +
+	"\x16GetPowerReadingRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"M\n" +
+	"\x17GetPowerReadingResponse\x122\n" +
+	"\areading\x18\x01 \x01(\v2\x18.gateway.v1.PowerReadingR\areading\"\x9b\x02\n" +
+	"\fPowerReading\x12%\n" +
+	"\x0econsumed_watts\x18\x01 \x01(\x01R\rconsumedWatts\x12#\n" +
+	"\raverage_watts\x18\x02 \x01(\x01R\faverageWatts\x12\x1b\n" +
+	"\tmin_watts\x18\x03 \x01(\x01R\bminWatts\x12\x1b\n" +
+	"\tmax_watts\x18\x04 \x01(\x01R\bmaxWatts\x12)\n" +
+	"\x10interval_minutes\x18\x05 \x01(\x05R\x0fintervalMinutes\x12%\n" +
+	"\x0ecapacity_watts\x18\x06 \x01(\x01R\rcapacityWatts\x123\n" +
+	"\aread_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"4\n" +
+	"\x15GetEnergyUsageRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"l\n" +
+	"\x16GetEnergyUsageResponse\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x01 \x01(\x01R\tenergyKwh\x123\n" +
+	"\aread_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"\xae\x03\n" +
 	"\bIPMIInfo\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12'\n" +
 	"\x0fdevice_revision\x18\x02 \x01(\tR\x0edeviceRevision\x12+\n" +
@@ -2938,29 +7097,69 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\x12BootSourceOverride\x12\x16\n" +
 	"\x06target\x18\x01 \x01(\tR\x06target\x12\x18\n" +
 	"\aenabled\x18\x02 \x01(\tR\aenabled\x12\x12\n" +
-	"\x04mode\x18\x03 \x01(\tR\x04mode*g\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\"\xe7\x01\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12U\n" +
+	"\rmodule_levels\x18\x02 \x03(\v20.gateway.v1.SetLogLevelRequest.ModuleLevelsEntryR\fmoduleLevels\x12#\n" +
+	"\rreset_modules\x18\x03 \x01(\bR\fresetModules\x1a?\n" +
+	"\x11ModuleLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc4\x01\n" +
+	"\x13SetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12V\n" +
+	"\rmodule_levels\x18\x02 \x03(\v21.gateway.v1.SetLogLevelResponse.ModuleLevelsEntryR\fmoduleLevels\x1a?\n" +
+	"\x11ModuleLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\xc8\x01\n" +
+	"\x0ePowerOperation\x12\x1f\n" +
+	"\x1bPOWER_OPERATION_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12POWER_OPERATION_ON\x10\x01\x12\x1d\n" +
+	"\x19POWER_OPERATION_FORCE_OFF\x10\x02\x12\x19\n" +
+	"\x15POWER_OPERATION_CYCLE\x10\x03\x12\x19\n" +
+	"\x15POWER_OPERATION_RESET\x10\x04\x12(\n" +
+	"$POWER_OPERATION_DIAGNOSTIC_INTERRUPT\x10\x05*\x89\x01\n" +
+	"\x0eOperationState\x12\x1f\n" +
+	"\x1bOPERATION_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17OPERATION_STATE_RUNNING\x10\x01\x12\x1d\n" +
+	"\x19OPERATION_STATE_SUCCEEDED\x10\x02\x12\x1a\n" +
+	"\x16OPERATION_STATE_FAILED\x10\x03*g\n" +
 	"\n" +
 	"PowerState\x12\x17\n" +
 	"\x13POWER_STATE_UNKNOWN\x10\x00\x12\x12\n" +
 	"\x0ePOWER_STATE_ON\x10\x01\x12\x13\n" +
 	"\x0fPOWER_STATE_OFF\x10\x02\x12\x17\n" +
-	"\x13POWER_STATE_CYCLING\x10\x03*\xbb\x01\n" +
+	"\x13POWER_STATE_CYCLING\x10\x03*x\n" +
+	"\rIdentifyState\x12\x1e\n" +
+	"\x1aIDENTIFY_STATE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12IDENTIFY_STATE_OFF\x10\x01\x12\x15\n" +
+	"\x11IDENTIFY_STATE_ON\x10\x02\x12\x18\n" +
+	"\x14IDENTIFY_STATE_BLINK\x10\x03*\xbb\x01\n" +
 	"\x13ConsoleAvailability\x12 \n" +
 	"\x1cCONSOLE_AVAILABILITY_UNKNOWN\x10\x00\x12\x1d\n" +
 	"\x19CONSOLE_AVAILABILITY_BOTH\x10\x01\x12!\n" +
 	"\x1dCONSOLE_AVAILABILITY_VNC_ONLY\x10\x02\x12!\n" +
 	"\x1dCONSOLE_AVAILABILITY_SOL_ONLY\x10\x03\x12\x1d\n" +
-	"\x19CONSOLE_AVAILABILITY_NONE\x10\x042\x8f\f\n" +
+	"\x19CONSOLE_AVAILABILITY_NONE\x10\x042\x8e\x1e\n" +
 	"\x0eGatewayService\x12N\n" +
 	"\vHealthCheck\x12\x1e.gateway.v1.HealthCheckRequest\x1a\x1f.gateway.v1.HealthCheckResponse\x12T\n" +
 	"\rRegisterAgent\x12 .gateway.v1.RegisterAgentRequest\x1a!.gateway.v1.RegisterAgentResponse\x12W\n" +
-	"\x0eAgentHeartbeat\x12!.gateway.v1.AgentHeartbeatRequest\x1a\".gateway.v1.AgentHeartbeatResponse\x12P\n" +
+	"\x0eAgentHeartbeat\x12!.gateway.v1.AgentHeartbeatRequest\x1a\".gateway.v1.AgentHeartbeatResponse\x12Z\n" +
+	"\x0fReportHostDrift\x12\".gateway.v1.ReportHostDriftRequest\x1a#.gateway.v1.ReportHostDriftResponse\x12P\n" +
 	"\aPowerOn\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12Q\n" +
-	"\bPowerOff\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12S\n" +
+	"\bPowerOff\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12]\n" +
+	"\x10GracefulShutdown\x12#.gateway.v1.GracefulShutdownRequest\x1a$.gateway.v1.GracefulShutdownResponse\x12Q\n" +
+	"\bForceOff\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12S\n" +
 	"\n" +
 	"PowerCycle\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12N\n" +
-	"\x05Reset\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12Q\n" +
-	"\x0eGetPowerStatus\x12\x1e.gateway.v1.PowerStatusRequest\x1a\x1f.gateway.v1.PowerStatusResponse\x12]\n" +
+	"\x05Reset\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12\\\n" +
+	"\x13DiagnosticInterrupt\x12!.gateway.v1.PowerOperationRequest\x1a\".gateway.v1.PowerOperationResponse\x12_\n" +
+	"\x11RunPowerOperation\x12$.gateway.v1.RunPowerOperationRequest\x1a\".gateway.v1.PowerOperationProgress0\x01\x12d\n" +
+	"\x13StartPowerOperation\x12$.gateway.v1.RunPowerOperationRequest\x1a'.gateway.v1.StartPowerOperationResponse\x12Q\n" +
+	"\fGetOperation\x12\x1f.gateway.v1.GetOperationRequest\x1a .gateway.v1.GetOperationResponse\x12W\n" +
+	"\x0eListOperations\x12!.gateway.v1.ListOperationsRequest\x1a\".gateway.v1.ListOperationsResponse\x12J\n" +
+	"\x0eWatchOperation\x12\x1f.gateway.v1.GetOperationRequest\x1a\x15.gateway.v1.Operation0\x01\x12Q\n" +
+	"\x0eGetPowerStatus\x12\x1e.gateway.v1.PowerStatusRequest\x1a\x1f.gateway.v1.PowerStatusResponse\x12c\n" +
+	"\x12SetChassisIdentify\x12%.gateway.v1.SetChassisIdentifyRequest\x1a&.gateway.v1.SetChassisIdentifyResponse\x12]\n" +
 	"\x10CreateVNCSession\x12#.gateway.v1.CreateVNCSessionRequest\x1a$.gateway.v1.CreateVNCSessionResponse\x12T\n" +
 	"\rGetVNCSession\x12 .gateway.v1.GetVNCSessionRequest\x1a!.gateway.v1.GetVNCSessionResponse\x12Z\n" +
 	"\x0fCloseVNCSession\x12\".gateway.v1.CloseVNCSessionRequest\x1a#.gateway.v1.CloseVNCSessionResponse\x12T\n" +
@@ -2969,9 +7168,25 @@ const file_gateway_v1_gateway_proto_rawDesc = "" +
 	"\rGetSOLSession\x12 .gateway.v1.GetSOLSessionRequest\x1a!.gateway.v1.GetSOLSessionResponse\x12Z\n" +
 	"\x0fCloseSOLSession\x12\".gateway.v1.CloseSOLSessionRequest\x1a#.gateway.v1.CloseSOLSessionResponse\x12G\n" +
 	"\rStreamVNCData\x12\x18.gateway.v1.VNCDataChunk\x1a\x18.gateway.v1.VNCDataChunk(\x010\x01\x12S\n" +
-	"\x11StreamConsoleData\x12\x1c.gateway.v1.ConsoleDataChunk\x1a\x1c.gateway.v1.ConsoleDataChunk(\x010\x01\x12K\n" +
+	"\x11StreamConsoleData\x12\x1c.gateway.v1.ConsoleDataChunk\x1a\x1c.gateway.v1.ConsoleDataChunk(\x010\x01\x12P\n" +
+	"\x10WatchConsoleData\x12\x1c.gateway.v1.ConsoleDataChunk\x1a\x1c.gateway.v1.ConsoleDataChunk0\x01\x12Z\n" +
+	"\x0fSendConsoleData\x12\".gateway.v1.SendConsoleDataRequest\x1a#.gateway.v1.SendConsoleDataResponse\x12K\n" +
+	"\n" +
+	"GetBMCInfo\x12\x1d.gateway.v1.GetBMCInfoRequest\x1a\x1e.gateway.v1.GetBMCInfoResponse\x12f\n" +
+	"\x13GetBMCNetworkConfig\x12&.gateway.v1.GetBMCNetworkConfigRequest\x1a'.gateway.v1.GetBMCNetworkConfigResponse\x12Z\n" +
+	"\x0fDeepHealthCheck\x12\".gateway.v1.DeepHealthCheckRequest\x1a#.gateway.v1.DeepHealthCheckResponse\x12Z\n" +
+	"\x0fGetPowerReading\x12\".gateway.v1.GetPowerReadingRequest\x1a#.gateway.v1.GetPowerReadingResponse\x12W\n" +
+	"\x0eGetEnergyUsage\x12!.gateway.v1.GetEnergyUsageRequest\x1a\".gateway.v1.GetEnergyUsageResponse\x12W\n" +
+	"\x0eGetAgentStatus\x12!.gateway.v1.GetAgentStatusRequest\x1a\".gateway.v1.GetAgentStatusResponse\x12K\n" +
 	"\n" +
-	"GetBMCInfo\x12\x1d.gateway.v1.GetBMCInfoRequest\x1a\x1e.gateway.v1.GetBMCInfoResponseB\"Z gateway/gen/gateway/v1;gatewayv1b\x06proto3"
+	"ListAgents\x12\x1d.gateway.v1.ListAgentsRequest\x1a\x1e.gateway.v1.ListAgentsResponse\x12c\n" +
+	"\x12ListActiveSessions\x12%.gateway.v1.ListActiveSessionsRequest\x1a&.gateway.v1.ListActiveSessionsResponse\x12]\n" +
+	"\x10GetGatewayStatus\x12#.gateway.v1.GetGatewayStatusRequest\x1a$.gateway.v1.GetGatewayStatusResponse\x12H\n" +
+	"\tProbeLink\x12\x1c.gateway.v1.ProbeLinkRequest\x1a\x1d.gateway.v1.ProbeLinkResponse\x12f\n" +
+	"\x13ListConsoleSessions\x12&.gateway.v1.ListConsoleSessionsRequest\x1a'.gateway.v1.ListConsoleSessionsResponse\x12r\n" +
+	"\x17TerminateConsoleSession\x12*.gateway.v1.TerminateConsoleSessionRequest\x1a+.gateway.v1.TerminateConsoleSessionResponse\x12f\n" +
+	"\x13RenewConsoleSession\x12&.gateway.v1.RenewConsoleSessionRequest\x1a'.gateway.v1.RenewConsoleSessionResponse\x12N\n" +
+	"\vSetLogLevel\x12\x1e.gateway.v1.SetLogLevelRequest\x1a\x1f.gateway.v1.SetLogLevelResponseB\"Z gateway/gen/gateway/v1;gatewayv1b\x06proto3"
 
 var (
 	file_gateway_v1_gateway_proto_rawDescOnce sync.Once
@@ -2985,131 +7200,299 @@ func file_gateway_v1_gateway_proto_rawDescGZIP() []byte {
 	return file_gateway_v1_gateway_proto_rawDescData
 }
 
-var file_gateway_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_gateway_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_gateway_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_gateway_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 102)
 var file_gateway_v1_gateway_proto_goTypes = []any{
-	(PowerState)(0),                          // 0: gateway.v1.PowerState
-	(ConsoleAvailability)(0),                 // 1: gateway.v1.ConsoleAvailability
-	(*HealthCheckRequest)(nil),               // 2: gateway.v1.HealthCheckRequest
-	(*HealthCheckResponse)(nil),              // 3: gateway.v1.HealthCheckResponse
-	(*PowerOperationRequest)(nil),            // 4: gateway.v1.PowerOperationRequest
-	(*PowerOperationResponse)(nil),           // 5: gateway.v1.PowerOperationResponse
-	(*PowerStatusRequest)(nil),               // 6: gateway.v1.PowerStatusRequest
-	(*PowerStatusResponse)(nil),              // 7: gateway.v1.PowerStatusResponse
-	(*RegisterAgentRequest)(nil),             // 8: gateway.v1.RegisterAgentRequest
-	(*RegisterAgentResponse)(nil),            // 9: gateway.v1.RegisterAgentResponse
-	(*AgentHeartbeatRequest)(nil),            // 10: gateway.v1.AgentHeartbeatRequest
-	(*AgentHeartbeatResponse)(nil),           // 11: gateway.v1.AgentHeartbeatResponse
-	(*BMCEndpointRegistration)(nil),          // 12: gateway.v1.BMCEndpointRegistration
-	(*CreateVNCSessionRequest)(nil),          // 13: gateway.v1.CreateVNCSessionRequest
-	(*CreateVNCSessionResponse)(nil),         // 14: gateway.v1.CreateVNCSessionResponse
-	(*GetVNCSessionRequest)(nil),             // 15: gateway.v1.GetVNCSessionRequest
-	(*VNCSession)(nil),                       // 16: gateway.v1.VNCSession
-	(*GetVNCSessionResponse)(nil),            // 17: gateway.v1.GetVNCSessionResponse
-	(*CloseVNCSessionRequest)(nil),           // 18: gateway.v1.CloseVNCSessionRequest
-	(*CloseVNCSessionResponse)(nil),          // 19: gateway.v1.CloseVNCSessionResponse
-	(*CreateSOLSessionRequest)(nil),          // 20: gateway.v1.CreateSOLSessionRequest
-	(*CreateSOLSessionResponse)(nil),         // 21: gateway.v1.CreateSOLSessionResponse
-	(*GetSOLSessionRequest)(nil),             // 22: gateway.v1.GetSOLSessionRequest
-	(*SOLSession)(nil),                       // 23: gateway.v1.SOLSession
-	(*GetSOLSessionResponse)(nil),            // 24: gateway.v1.GetSOLSessionResponse
-	(*CloseSOLSessionRequest)(nil),           // 25: gateway.v1.CloseSOLSessionRequest
-	(*CloseSOLSessionResponse)(nil),          // 26: gateway.v1.CloseSOLSessionResponse
-	(*ReportAvailableEndpointsRequest)(nil),  // 27: gateway.v1.ReportAvailableEndpointsRequest
-	(*BMCEndpointAvailability)(nil),          // 28: gateway.v1.BMCEndpointAvailability
-	(*ReportAvailableEndpointsResponse)(nil), // 29: gateway.v1.ReportAvailableEndpointsResponse
-	(*StartVNCProxyRequest)(nil),             // 30: gateway.v1.StartVNCProxyRequest
-	(*StartVNCProxyResponse)(nil),            // 31: gateway.v1.StartVNCProxyResponse
-	(*VNCDataChunk)(nil),                     // 32: gateway.v1.VNCDataChunk
-	(*ConsoleDataChunk)(nil),                 // 33: gateway.v1.ConsoleDataChunk
-	(*GetBMCInfoRequest)(nil),                // 34: gateway.v1.GetBMCInfoRequest
-	(*GetBMCInfoResponse)(nil),               // 35: gateway.v1.GetBMCInfoResponse
-	(*BMCInfo)(nil),                          // 36: gateway.v1.BMCInfo
-	(*IPMIInfo)(nil),                         // 37: gateway.v1.IPMIInfo
-	(*RedfishInfo)(nil),                      // 38: gateway.v1.RedfishInfo
-	(*NetworkProtocol)(nil),                  // 39: gateway.v1.NetworkProtocol
-	(*SystemStatus)(nil),                     // 40: gateway.v1.SystemStatus
-	(*BootSourceOverride)(nil),               // 41: gateway.v1.BootSourceOverride
-	nil,                                      // 42: gateway.v1.BMCEndpointRegistration.MetadataEntry
-	nil,                                      // 43: gateway.v1.SystemStatus.OemHealthEntry
-	(*timestamppb.Timestamp)(nil),            // 44: google.protobuf.Timestamp
-	(*v1.BMCControlEndpoint)(nil),            // 45: common.v1.BMCControlEndpoint
-	(v1.BMCType)(0),                          // 46: common.v1.BMCType
-	(*v1.SOLEndpoint)(nil),                   // 47: common.v1.SOLEndpoint
-	(*v1.VNCEndpoint)(nil),                   // 48: common.v1.VNCEndpoint
-	(*v1.DiscoveryMetadata)(nil),             // 49: common.v1.DiscoveryMetadata
+	(PowerOperation)(0),                      // 0: gateway.v1.PowerOperation
+	(OperationState)(0),                      // 1: gateway.v1.OperationState
+	(PowerState)(0),                          // 2: gateway.v1.PowerState
+	(IdentifyState)(0),                       // 3: gateway.v1.IdentifyState
+	(ConsoleAvailability)(0),                 // 4: gateway.v1.ConsoleAvailability
+	(*HealthCheckRequest)(nil),               // 5: gateway.v1.HealthCheckRequest
+	(*HealthCheckResponse)(nil),              // 6: gateway.v1.HealthCheckResponse
+	(*PowerOperationRequest)(nil),            // 7: gateway.v1.PowerOperationRequest
+	(*PowerOperationResponse)(nil),           // 8: gateway.v1.PowerOperationResponse
+	(*GracefulShutdownRequest)(nil),          // 9: gateway.v1.GracefulShutdownRequest
+	(*GracefulShutdownResponse)(nil),         // 10: gateway.v1.GracefulShutdownResponse
+	(*RunPowerOperationRequest)(nil),         // 11: gateway.v1.RunPowerOperationRequest
+	(*PowerOperationProgress)(nil),           // 12: gateway.v1.PowerOperationProgress
+	(*Operation)(nil),                        // 13: gateway.v1.Operation
+	(*StartPowerOperationResponse)(nil),      // 14: gateway.v1.StartPowerOperationResponse
+	(*GetOperationRequest)(nil),              // 15: gateway.v1.GetOperationRequest
+	(*GetOperationResponse)(nil),             // 16: gateway.v1.GetOperationResponse
+	(*ListOperationsRequest)(nil),            // 17: gateway.v1.ListOperationsRequest
+	(*ListOperationsResponse)(nil),           // 18: gateway.v1.ListOperationsResponse
+	(*PowerStatusRequest)(nil),               // 19: gateway.v1.PowerStatusRequest
+	(*PowerStatusResponse)(nil),              // 20: gateway.v1.PowerStatusResponse
+	(*SetChassisIdentifyRequest)(nil),        // 21: gateway.v1.SetChassisIdentifyRequest
+	(*SetChassisIdentifyResponse)(nil),       // 22: gateway.v1.SetChassisIdentifyResponse
+	(*RegisterAgentRequest)(nil),             // 23: gateway.v1.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),            // 24: gateway.v1.RegisterAgentResponse
+	(*AgentHeartbeatRequest)(nil),            // 25: gateway.v1.AgentHeartbeatRequest
+	(*AgentHeartbeatResponse)(nil),           // 26: gateway.v1.AgentHeartbeatResponse
+	(*ReportHostDriftRequest)(nil),           // 27: gateway.v1.ReportHostDriftRequest
+	(*HostDrift)(nil),                        // 28: gateway.v1.HostDrift
+	(*DriftFinding)(nil),                     // 29: gateway.v1.DriftFinding
+	(*ReportHostDriftResponse)(nil),          // 30: gateway.v1.ReportHostDriftResponse
+	(*BMCEndpointRegistration)(nil),          // 31: gateway.v1.BMCEndpointRegistration
+	(*GetAgentStatusRequest)(nil),            // 32: gateway.v1.GetAgentStatusRequest
+	(*GetAgentStatusResponse)(nil),           // 33: gateway.v1.GetAgentStatusResponse
+	(*ListAgentsRequest)(nil),                // 34: gateway.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),               // 35: gateway.v1.ListAgentsResponse
+	(*AgentStatus)(nil),                      // 36: gateway.v1.AgentStatus
+	(*AgentLinkStatus)(nil),                  // 37: gateway.v1.AgentLinkStatus
+	(*AgentBMCEndpointStatus)(nil),           // 38: gateway.v1.AgentBMCEndpointStatus
+	(*GetGatewayStatusRequest)(nil),          // 39: gateway.v1.GetGatewayStatusRequest
+	(*GetGatewayStatusResponse)(nil),         // 40: gateway.v1.GetGatewayStatusResponse
+	(*ConsoleSessionCounts)(nil),             // 41: gateway.v1.ConsoleSessionCounts
+	(*BMCEndpointMapping)(nil),               // 42: gateway.v1.BMCEndpointMapping
+	(*ListActiveSessionsRequest)(nil),        // 43: gateway.v1.ListActiveSessionsRequest
+	(*ListActiveSessionsResponse)(nil),       // 44: gateway.v1.ListActiveSessionsResponse
+	(*ActiveSession)(nil),                    // 45: gateway.v1.ActiveSession
+	(*ProbeLinkRequest)(nil),                 // 46: gateway.v1.ProbeLinkRequest
+	(*ProbeLinkResponse)(nil),                // 47: gateway.v1.ProbeLinkResponse
+	(*ListConsoleSessionsRequest)(nil),       // 48: gateway.v1.ListConsoleSessionsRequest
+	(*ListConsoleSessionsResponse)(nil),      // 49: gateway.v1.ListConsoleSessionsResponse
+	(*ConsoleSessionInfo)(nil),               // 50: gateway.v1.ConsoleSessionInfo
+	(*ConsoleStreamInfo)(nil),                // 51: gateway.v1.ConsoleStreamInfo
+	(*TerminateConsoleSessionRequest)(nil),   // 52: gateway.v1.TerminateConsoleSessionRequest
+	(*TerminateConsoleSessionResponse)(nil),  // 53: gateway.v1.TerminateConsoleSessionResponse
+	(*RenewConsoleSessionRequest)(nil),       // 54: gateway.v1.RenewConsoleSessionRequest
+	(*RenewConsoleSessionResponse)(nil),      // 55: gateway.v1.RenewConsoleSessionResponse
+	(*CreateVNCSessionRequest)(nil),          // 56: gateway.v1.CreateVNCSessionRequest
+	(*CreateVNCSessionResponse)(nil),         // 57: gateway.v1.CreateVNCSessionResponse
+	(*GetVNCSessionRequest)(nil),             // 58: gateway.v1.GetVNCSessionRequest
+	(*VNCSession)(nil),                       // 59: gateway.v1.VNCSession
+	(*GetVNCSessionResponse)(nil),            // 60: gateway.v1.GetVNCSessionResponse
+	(*CloseVNCSessionRequest)(nil),           // 61: gateway.v1.CloseVNCSessionRequest
+	(*CloseVNCSessionResponse)(nil),          // 62: gateway.v1.CloseVNCSessionResponse
+	(*CreateSOLSessionRequest)(nil),          // 63: gateway.v1.CreateSOLSessionRequest
+	(*CreateSOLSessionResponse)(nil),         // 64: gateway.v1.CreateSOLSessionResponse
+	(*GetSOLSessionRequest)(nil),             // 65: gateway.v1.GetSOLSessionRequest
+	(*SOLSession)(nil),                       // 66: gateway.v1.SOLSession
+	(*GetSOLSessionResponse)(nil),            // 67: gateway.v1.GetSOLSessionResponse
+	(*CloseSOLSessionRequest)(nil),           // 68: gateway.v1.CloseSOLSessionRequest
+	(*CloseSOLSessionResponse)(nil),          // 69: gateway.v1.CloseSOLSessionResponse
+	(*ReportAvailableEndpointsRequest)(nil),  // 70: gateway.v1.ReportAvailableEndpointsRequest
+	(*BMCEndpointAvailability)(nil),          // 71: gateway.v1.BMCEndpointAvailability
+	(*ReportAvailableEndpointsResponse)(nil), // 72: gateway.v1.ReportAvailableEndpointsResponse
+	(*StartVNCProxyRequest)(nil),             // 73: gateway.v1.StartVNCProxyRequest
+	(*StartVNCProxyResponse)(nil),            // 74: gateway.v1.StartVNCProxyResponse
+	(*VNCDataChunk)(nil),                     // 75: gateway.v1.VNCDataChunk
+	(*ConsoleDataChunk)(nil),                 // 76: gateway.v1.ConsoleDataChunk
+	(*SendConsoleDataRequest)(nil),           // 77: gateway.v1.SendConsoleDataRequest
+	(*SendConsoleDataResponse)(nil),          // 78: gateway.v1.SendConsoleDataResponse
+	(*TerminalSize)(nil),                     // 79: gateway.v1.TerminalSize
+	(*GetBMCInfoRequest)(nil),                // 80: gateway.v1.GetBMCInfoRequest
+	(*GetBMCInfoResponse)(nil),               // 81: gateway.v1.GetBMCInfoResponse
+	(*BMCInfo)(nil),                          // 82: gateway.v1.BMCInfo
+	(*GetBMCNetworkConfigRequest)(nil),       // 83: gateway.v1.GetBMCNetworkConfigRequest
+	(*GetBMCNetworkConfigResponse)(nil),      // 84: gateway.v1.GetBMCNetworkConfigResponse
+	(*BMCNetworkInterface)(nil),              // 85: gateway.v1.BMCNetworkInterface
+	(*DeepHealthCheckRequest)(nil),           // 86: gateway.v1.DeepHealthCheckRequest
+	(*DeepHealthCheckResponse)(nil),          // 87: gateway.v1.DeepHealthCheckResponse
+	(*HealthCheckHop)(nil),                   // 88: gateway.v1.HealthCheckHop
+	(*GetPowerReadingRequest)(nil),           // 89: gateway.v1.GetPowerReadingRequest
+	(*GetPowerReadingResponse)(nil),          // 90: gateway.v1.GetPowerReadingResponse
+	(*PowerReading)(nil),                     // 91: gateway.v1.PowerReading
+	(*GetEnergyUsageRequest)(nil),            // 92: gateway.v1.GetEnergyUsageRequest
+	(*GetEnergyUsageResponse)(nil),           // 93: gateway.v1.GetEnergyUsageResponse
+	(*IPMIInfo)(nil),                         // 94: gateway.v1.IPMIInfo
+	(*RedfishInfo)(nil),                      // 95: gateway.v1.RedfishInfo
+	(*NetworkProtocol)(nil),                  // 96: gateway.v1.NetworkProtocol
+	(*SystemStatus)(nil),                     // 97: gateway.v1.SystemStatus
+	(*BootSourceOverride)(nil),               // 98: gateway.v1.BootSourceOverride
+	(*SetLogLevelRequest)(nil),               // 99: gateway.v1.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),              // 100: gateway.v1.SetLogLevelResponse
+	nil,                                      // 101: gateway.v1.BMCEndpointRegistration.MetadataEntry
+	nil,                                      // 102: gateway.v1.VNCDataChunk.MetadataEntry
+	nil,                                      // 103: gateway.v1.ConsoleDataChunk.MetadataEntry
+	nil,                                      // 104: gateway.v1.SystemStatus.OemHealthEntry
+	nil,                                      // 105: gateway.v1.SetLogLevelRequest.ModuleLevelsEntry
+	nil,                                      // 106: gateway.v1.SetLogLevelResponse.ModuleLevelsEntry
+	(*timestamppb.Timestamp)(nil),            // 107: google.protobuf.Timestamp
+	(*v1.BMCControlEndpoint)(nil),            // 108: common.v1.BMCControlEndpoint
+	(v1.BMCType)(0),                          // 109: common.v1.BMCType
+	(*v1.SOLEndpoint)(nil),                   // 110: common.v1.SOLEndpoint
+	(*v1.VNCEndpoint)(nil),                   // 111: common.v1.VNCEndpoint
+	(*v1.DiscoveryMetadata)(nil),             // 112: common.v1.DiscoveryMetadata
+	(*v1.SOLConfig)(nil),                     // 113: common.v1.SOLConfig
 }
 var file_gateway_v1_gateway_proto_depIdxs = []int32{
-	44, // 0: gateway.v1.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 1: gateway.v1.PowerStatusResponse.state:type_name -> gateway.v1.PowerState
-	12, // 2: gateway.v1.RegisterAgentRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointRegistration
-	12, // 3: gateway.v1.AgentHeartbeatRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointRegistration
-	45, // 4: gateway.v1.BMCEndpointRegistration.control_endpoints:type_name -> common.v1.BMCControlEndpoint
-	46, // 5: gateway.v1.BMCEndpointRegistration.primary_protocol:type_name -> common.v1.BMCType
-	47, // 6: gateway.v1.BMCEndpointRegistration.sol_endpoint:type_name -> common.v1.SOLEndpoint
-	48, // 7: gateway.v1.BMCEndpointRegistration.vnc_endpoint:type_name -> common.v1.VNCEndpoint
-	42, // 8: gateway.v1.BMCEndpointRegistration.metadata:type_name -> gateway.v1.BMCEndpointRegistration.MetadataEntry
-	49, // 9: gateway.v1.BMCEndpointRegistration.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
-	44, // 10: gateway.v1.CreateVNCSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
-	44, // 11: gateway.v1.VNCSession.created_at:type_name -> google.protobuf.Timestamp
-	44, // 12: gateway.v1.VNCSession.expires_at:type_name -> google.protobuf.Timestamp
-	16, // 13: gateway.v1.GetVNCSessionResponse.session:type_name -> gateway.v1.VNCSession
-	44, // 14: gateway.v1.CreateSOLSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
-	44, // 15: gateway.v1.SOLSession.created_at:type_name -> google.protobuf.Timestamp
-	44, // 16: gateway.v1.SOLSession.expires_at:type_name -> google.protobuf.Timestamp
-	23, // 17: gateway.v1.GetSOLSessionResponse.session:type_name -> gateway.v1.SOLSession
-	28, // 18: gateway.v1.ReportAvailableEndpointsRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointAvailability
-	46, // 19: gateway.v1.BMCEndpointAvailability.bmc_type:type_name -> common.v1.BMCType
-	44, // 20: gateway.v1.BMCEndpointAvailability.last_seen:type_name -> google.protobuf.Timestamp
-	36, // 21: gateway.v1.GetBMCInfoResponse.info:type_name -> gateway.v1.BMCInfo
-	37, // 22: gateway.v1.BMCInfo.ipmi_info:type_name -> gateway.v1.IPMIInfo
-	38, // 23: gateway.v1.BMCInfo.redfish_info:type_name -> gateway.v1.RedfishInfo
-	39, // 24: gateway.v1.RedfishInfo.network_protocols:type_name -> gateway.v1.NetworkProtocol
-	40, // 25: gateway.v1.RedfishInfo.system_status:type_name -> gateway.v1.SystemStatus
-	41, // 26: gateway.v1.SystemStatus.boot_source:type_name -> gateway.v1.BootSourceOverride
-	43, // 27: gateway.v1.SystemStatus.oem_health:type_name -> gateway.v1.SystemStatus.OemHealthEntry
-	1,  // 28: gateway.v1.SystemStatus.console_availability:type_name -> gateway.v1.ConsoleAvailability
-	2,  // 29: gateway.v1.GatewayService.HealthCheck:input_type -> gateway.v1.HealthCheckRequest
-	8,  // 30: gateway.v1.GatewayService.RegisterAgent:input_type -> gateway.v1.RegisterAgentRequest
-	10, // 31: gateway.v1.GatewayService.AgentHeartbeat:input_type -> gateway.v1.AgentHeartbeatRequest
-	4,  // 32: gateway.v1.GatewayService.PowerOn:input_type -> gateway.v1.PowerOperationRequest
-	4,  // 33: gateway.v1.GatewayService.PowerOff:input_type -> gateway.v1.PowerOperationRequest
-	4,  // 34: gateway.v1.GatewayService.PowerCycle:input_type -> gateway.v1.PowerOperationRequest
-	4,  // 35: gateway.v1.GatewayService.Reset:input_type -> gateway.v1.PowerOperationRequest
-	6,  // 36: gateway.v1.GatewayService.GetPowerStatus:input_type -> gateway.v1.PowerStatusRequest
-	13, // 37: gateway.v1.GatewayService.CreateVNCSession:input_type -> gateway.v1.CreateVNCSessionRequest
-	15, // 38: gateway.v1.GatewayService.GetVNCSession:input_type -> gateway.v1.GetVNCSessionRequest
-	18, // 39: gateway.v1.GatewayService.CloseVNCSession:input_type -> gateway.v1.CloseVNCSessionRequest
-	30, // 40: gateway.v1.GatewayService.StartVNCProxy:input_type -> gateway.v1.StartVNCProxyRequest
-	20, // 41: gateway.v1.GatewayService.CreateSOLSession:input_type -> gateway.v1.CreateSOLSessionRequest
-	22, // 42: gateway.v1.GatewayService.GetSOLSession:input_type -> gateway.v1.GetSOLSessionRequest
-	25, // 43: gateway.v1.GatewayService.CloseSOLSession:input_type -> gateway.v1.CloseSOLSessionRequest
-	32, // 44: gateway.v1.GatewayService.StreamVNCData:input_type -> gateway.v1.VNCDataChunk
-	33, // 45: gateway.v1.GatewayService.StreamConsoleData:input_type -> gateway.v1.ConsoleDataChunk
-	34, // 46: gateway.v1.GatewayService.GetBMCInfo:input_type -> gateway.v1.GetBMCInfoRequest
-	3,  // 47: gateway.v1.GatewayService.HealthCheck:output_type -> gateway.v1.HealthCheckResponse
-	9,  // 48: gateway.v1.GatewayService.RegisterAgent:output_type -> gateway.v1.RegisterAgentResponse
-	11, // 49: gateway.v1.GatewayService.AgentHeartbeat:output_type -> gateway.v1.AgentHeartbeatResponse
-	5,  // 50: gateway.v1.GatewayService.PowerOn:output_type -> gateway.v1.PowerOperationResponse
-	5,  // 51: gateway.v1.GatewayService.PowerOff:output_type -> gateway.v1.PowerOperationResponse
-	5,  // 52: gateway.v1.GatewayService.PowerCycle:output_type -> gateway.v1.PowerOperationResponse
-	5,  // 53: gateway.v1.GatewayService.Reset:output_type -> gateway.v1.PowerOperationResponse
-	7,  // 54: gateway.v1.GatewayService.GetPowerStatus:output_type -> gateway.v1.PowerStatusResponse
-	14, // 55: gateway.v1.GatewayService.CreateVNCSession:output_type -> gateway.v1.CreateVNCSessionResponse
-	17, // 56: gateway.v1.GatewayService.GetVNCSession:output_type -> gateway.v1.GetVNCSessionResponse
-	19, // 57: gateway.v1.GatewayService.CloseVNCSession:output_type -> gateway.v1.CloseVNCSessionResponse
-	31, // 58: gateway.v1.GatewayService.StartVNCProxy:output_type -> gateway.v1.StartVNCProxyResponse
-	21, // 59: gateway.v1.GatewayService.CreateSOLSession:output_type -> gateway.v1.CreateSOLSessionResponse
-	24, // 60: gateway.v1.GatewayService.GetSOLSession:output_type -> gateway.v1.GetSOLSessionResponse
-	26, // 61: gateway.v1.GatewayService.CloseSOLSession:output_type -> gateway.v1.CloseSOLSessionResponse
-	32, // 62: gateway.v1.GatewayService.StreamVNCData:output_type -> gateway.v1.VNCDataChunk
-	33, // 63: gateway.v1.GatewayService.StreamConsoleData:output_type -> gateway.v1.ConsoleDataChunk
-	35, // 64: gateway.v1.GatewayService.GetBMCInfo:output_type -> gateway.v1.GetBMCInfoResponse
-	47, // [47:65] is the sub-list for method output_type
-	29, // [29:47] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	107, // 0: gateway.v1.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	107, // 1: gateway.v1.GracefulShutdownResponse.force_at:type_name -> google.protobuf.Timestamp
+	0,   // 2: gateway.v1.RunPowerOperationRequest.operation:type_name -> gateway.v1.PowerOperation
+	0,   // 3: gateway.v1.Operation.operation:type_name -> gateway.v1.PowerOperation
+	1,   // 4: gateway.v1.Operation.state:type_name -> gateway.v1.OperationState
+	12,  // 5: gateway.v1.Operation.progress:type_name -> gateway.v1.PowerOperationProgress
+	107, // 6: gateway.v1.Operation.started_at:type_name -> google.protobuf.Timestamp
+	107, // 7: gateway.v1.Operation.updated_at:type_name -> google.protobuf.Timestamp
+	107, // 8: gateway.v1.Operation.completed_at:type_name -> google.protobuf.Timestamp
+	13,  // 9: gateway.v1.StartPowerOperationResponse.operation:type_name -> gateway.v1.Operation
+	13,  // 10: gateway.v1.GetOperationResponse.operation:type_name -> gateway.v1.Operation
+	13,  // 11: gateway.v1.ListOperationsResponse.operations:type_name -> gateway.v1.Operation
+	2,   // 12: gateway.v1.PowerStatusResponse.state:type_name -> gateway.v1.PowerState
+	3,   // 13: gateway.v1.SetChassisIdentifyRequest.state:type_name -> gateway.v1.IdentifyState
+	107, // 14: gateway.v1.SetChassisIdentifyResponse.off_at:type_name -> google.protobuf.Timestamp
+	31,  // 15: gateway.v1.RegisterAgentRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointRegistration
+	31,  // 16: gateway.v1.AgentHeartbeatRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointRegistration
+	28,  // 17: gateway.v1.ReportHostDriftRequest.hosts:type_name -> gateway.v1.HostDrift
+	107, // 18: gateway.v1.HostDrift.checked_at:type_name -> google.protobuf.Timestamp
+	29,  // 19: gateway.v1.HostDrift.findings:type_name -> gateway.v1.DriftFinding
+	108, // 20: gateway.v1.BMCEndpointRegistration.control_endpoints:type_name -> common.v1.BMCControlEndpoint
+	109, // 21: gateway.v1.BMCEndpointRegistration.primary_protocol:type_name -> common.v1.BMCType
+	110, // 22: gateway.v1.BMCEndpointRegistration.sol_endpoint:type_name -> common.v1.SOLEndpoint
+	111, // 23: gateway.v1.BMCEndpointRegistration.vnc_endpoint:type_name -> common.v1.VNCEndpoint
+	101, // 24: gateway.v1.BMCEndpointRegistration.metadata:type_name -> gateway.v1.BMCEndpointRegistration.MetadataEntry
+	112, // 25: gateway.v1.BMCEndpointRegistration.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
+	36,  // 26: gateway.v1.GetAgentStatusResponse.agent:type_name -> gateway.v1.AgentStatus
+	36,  // 27: gateway.v1.ListAgentsResponse.agents:type_name -> gateway.v1.AgentStatus
+	107, // 28: gateway.v1.AgentStatus.registered_at:type_name -> google.protobuf.Timestamp
+	107, // 29: gateway.v1.AgentStatus.last_seen:type_name -> google.protobuf.Timestamp
+	38,  // 30: gateway.v1.AgentStatus.bmc_endpoints:type_name -> gateway.v1.AgentBMCEndpointStatus
+	37,  // 31: gateway.v1.AgentStatus.link:type_name -> gateway.v1.AgentLinkStatus
+	107, // 32: gateway.v1.AgentLinkStatus.probed_at:type_name -> google.protobuf.Timestamp
+	109, // 33: gateway.v1.AgentBMCEndpointStatus.bmc_type:type_name -> common.v1.BMCType
+	107, // 34: gateway.v1.AgentBMCEndpointStatus.last_seen:type_name -> google.protobuf.Timestamp
+	36,  // 35: gateway.v1.GetGatewayStatusResponse.agents:type_name -> gateway.v1.AgentStatus
+	41,  // 36: gateway.v1.GetGatewayStatusResponse.sessions:type_name -> gateway.v1.ConsoleSessionCounts
+	42,  // 37: gateway.v1.GetGatewayStatusResponse.endpoint_mappings:type_name -> gateway.v1.BMCEndpointMapping
+	107, // 38: gateway.v1.GetGatewayStatusResponse.generated_at:type_name -> google.protobuf.Timestamp
+	109, // 39: gateway.v1.BMCEndpointMapping.bmc_type:type_name -> common.v1.BMCType
+	107, // 40: gateway.v1.BMCEndpointMapping.last_seen:type_name -> google.protobuf.Timestamp
+	45,  // 41: gateway.v1.ListActiveSessionsResponse.sessions:type_name -> gateway.v1.ActiveSession
+	107, // 42: gateway.v1.ActiveSession.started_at:type_name -> google.protobuf.Timestamp
+	50,  // 43: gateway.v1.ListConsoleSessionsResponse.sessions:type_name -> gateway.v1.ConsoleSessionInfo
+	107, // 44: gateway.v1.ConsoleSessionInfo.created_at:type_name -> google.protobuf.Timestamp
+	107, // 45: gateway.v1.ConsoleSessionInfo.expires_at:type_name -> google.protobuf.Timestamp
+	51,  // 46: gateway.v1.ConsoleSessionInfo.streams:type_name -> gateway.v1.ConsoleStreamInfo
+	107, // 47: gateway.v1.ConsoleSessionInfo.last_activity_at:type_name -> google.protobuf.Timestamp
+	107, // 48: gateway.v1.ConsoleStreamInfo.attached_at:type_name -> google.protobuf.Timestamp
+	107, // 49: gateway.v1.RenewConsoleSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 50: gateway.v1.CreateVNCSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 51: gateway.v1.VNCSession.created_at:type_name -> google.protobuf.Timestamp
+	107, // 52: gateway.v1.VNCSession.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 53: gateway.v1.VNCSession.last_activity_at:type_name -> google.protobuf.Timestamp
+	59,  // 54: gateway.v1.GetVNCSessionResponse.session:type_name -> gateway.v1.VNCSession
+	113, // 55: gateway.v1.CreateSOLSessionRequest.config:type_name -> common.v1.SOLConfig
+	107, // 56: gateway.v1.CreateSOLSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 57: gateway.v1.SOLSession.created_at:type_name -> google.protobuf.Timestamp
+	107, // 58: gateway.v1.SOLSession.expires_at:type_name -> google.protobuf.Timestamp
+	107, // 59: gateway.v1.SOLSession.last_activity_at:type_name -> google.protobuf.Timestamp
+	66,  // 60: gateway.v1.GetSOLSessionResponse.session:type_name -> gateway.v1.SOLSession
+	71,  // 61: gateway.v1.ReportAvailableEndpointsRequest.bmc_endpoints:type_name -> gateway.v1.BMCEndpointAvailability
+	109, // 62: gateway.v1.BMCEndpointAvailability.bmc_type:type_name -> common.v1.BMCType
+	107, // 63: gateway.v1.BMCEndpointAvailability.last_seen:type_name -> google.protobuf.Timestamp
+	102, // 64: gateway.v1.VNCDataChunk.metadata:type_name -> gateway.v1.VNCDataChunk.MetadataEntry
+	79,  // 65: gateway.v1.ConsoleDataChunk.resize:type_name -> gateway.v1.TerminalSize
+	103, // 66: gateway.v1.ConsoleDataChunk.metadata:type_name -> gateway.v1.ConsoleDataChunk.MetadataEntry
+	76,  // 67: gateway.v1.SendConsoleDataRequest.chunks:type_name -> gateway.v1.ConsoleDataChunk
+	82,  // 68: gateway.v1.GetBMCInfoResponse.info:type_name -> gateway.v1.BMCInfo
+	94,  // 69: gateway.v1.BMCInfo.ipmi_info:type_name -> gateway.v1.IPMIInfo
+	95,  // 70: gateway.v1.BMCInfo.redfish_info:type_name -> gateway.v1.RedfishInfo
+	85,  // 71: gateway.v1.GetBMCNetworkConfigResponse.interfaces:type_name -> gateway.v1.BMCNetworkInterface
+	88,  // 72: gateway.v1.DeepHealthCheckResponse.hops:type_name -> gateway.v1.HealthCheckHop
+	91,  // 73: gateway.v1.GetPowerReadingResponse.reading:type_name -> gateway.v1.PowerReading
+	107, // 74: gateway.v1.PowerReading.read_at:type_name -> google.protobuf.Timestamp
+	107, // 75: gateway.v1.GetEnergyUsageResponse.read_at:type_name -> google.protobuf.Timestamp
+	96,  // 76: gateway.v1.RedfishInfo.network_protocols:type_name -> gateway.v1.NetworkProtocol
+	97,  // 77: gateway.v1.RedfishInfo.system_status:type_name -> gateway.v1.SystemStatus
+	98,  // 78: gateway.v1.SystemStatus.boot_source:type_name -> gateway.v1.BootSourceOverride
+	104, // 79: gateway.v1.SystemStatus.oem_health:type_name -> gateway.v1.SystemStatus.OemHealthEntry
+	4,   // 80: gateway.v1.SystemStatus.console_availability:type_name -> gateway.v1.ConsoleAvailability
+	105, // 81: gateway.v1.SetLogLevelRequest.module_levels:type_name -> gateway.v1.SetLogLevelRequest.ModuleLevelsEntry
+	106, // 82: gateway.v1.SetLogLevelResponse.module_levels:type_name -> gateway.v1.SetLogLevelResponse.ModuleLevelsEntry
+	5,   // 83: gateway.v1.GatewayService.HealthCheck:input_type -> gateway.v1.HealthCheckRequest
+	23,  // 84: gateway.v1.GatewayService.RegisterAgent:input_type -> gateway.v1.RegisterAgentRequest
+	25,  // 85: gateway.v1.GatewayService.AgentHeartbeat:input_type -> gateway.v1.AgentHeartbeatRequest
+	27,  // 86: gateway.v1.GatewayService.ReportHostDrift:input_type -> gateway.v1.ReportHostDriftRequest
+	7,   // 87: gateway.v1.GatewayService.PowerOn:input_type -> gateway.v1.PowerOperationRequest
+	7,   // 88: gateway.v1.GatewayService.PowerOff:input_type -> gateway.v1.PowerOperationRequest
+	9,   // 89: gateway.v1.GatewayService.GracefulShutdown:input_type -> gateway.v1.GracefulShutdownRequest
+	7,   // 90: gateway.v1.GatewayService.ForceOff:input_type -> gateway.v1.PowerOperationRequest
+	7,   // 91: gateway.v1.GatewayService.PowerCycle:input_type -> gateway.v1.PowerOperationRequest
+	7,   // 92: gateway.v1.GatewayService.Reset:input_type -> gateway.v1.PowerOperationRequest
+	7,   // 93: gateway.v1.GatewayService.DiagnosticInterrupt:input_type -> gateway.v1.PowerOperationRequest
+	11,  // 94: gateway.v1.GatewayService.RunPowerOperation:input_type -> gateway.v1.RunPowerOperationRequest
+	11,  // 95: gateway.v1.GatewayService.StartPowerOperation:input_type -> gateway.v1.RunPowerOperationRequest
+	15,  // 96: gateway.v1.GatewayService.GetOperation:input_type -> gateway.v1.GetOperationRequest
+	17,  // 97: gateway.v1.GatewayService.ListOperations:input_type -> gateway.v1.ListOperationsRequest
+	15,  // 98: gateway.v1.GatewayService.WatchOperation:input_type -> gateway.v1.GetOperationRequest
+	19,  // 99: gateway.v1.GatewayService.GetPowerStatus:input_type -> gateway.v1.PowerStatusRequest
+	21,  // 100: gateway.v1.GatewayService.SetChassisIdentify:input_type -> gateway.v1.SetChassisIdentifyRequest
+	56,  // 101: gateway.v1.GatewayService.CreateVNCSession:input_type -> gateway.v1.CreateVNCSessionRequest
+	58,  // 102: gateway.v1.GatewayService.GetVNCSession:input_type -> gateway.v1.GetVNCSessionRequest
+	61,  // 103: gateway.v1.GatewayService.CloseVNCSession:input_type -> gateway.v1.CloseVNCSessionRequest
+	73,  // 104: gateway.v1.GatewayService.StartVNCProxy:input_type -> gateway.v1.StartVNCProxyRequest
+	63,  // 105: gateway.v1.GatewayService.CreateSOLSession:input_type -> gateway.v1.CreateSOLSessionRequest
+	65,  // 106: gateway.v1.GatewayService.GetSOLSession:input_type -> gateway.v1.GetSOLSessionRequest
+	68,  // 107: gateway.v1.GatewayService.CloseSOLSession:input_type -> gateway.v1.CloseSOLSessionRequest
+	75,  // 108: gateway.v1.GatewayService.StreamVNCData:input_type -> gateway.v1.VNCDataChunk
+	76,  // 109: gateway.v1.GatewayService.StreamConsoleData:input_type -> gateway.v1.ConsoleDataChunk
+	76,  // 110: gateway.v1.GatewayService.WatchConsoleData:input_type -> gateway.v1.ConsoleDataChunk
+	77,  // 111: gateway.v1.GatewayService.SendConsoleData:input_type -> gateway.v1.SendConsoleDataRequest
+	80,  // 112: gateway.v1.GatewayService.GetBMCInfo:input_type -> gateway.v1.GetBMCInfoRequest
+	83,  // 113: gateway.v1.GatewayService.GetBMCNetworkConfig:input_type -> gateway.v1.GetBMCNetworkConfigRequest
+	86,  // 114: gateway.v1.GatewayService.DeepHealthCheck:input_type -> gateway.v1.DeepHealthCheckRequest
+	89,  // 115: gateway.v1.GatewayService.GetPowerReading:input_type -> gateway.v1.GetPowerReadingRequest
+	92,  // 116: gateway.v1.GatewayService.GetEnergyUsage:input_type -> gateway.v1.GetEnergyUsageRequest
+	32,  // 117: gateway.v1.GatewayService.GetAgentStatus:input_type -> gateway.v1.GetAgentStatusRequest
+	34,  // 118: gateway.v1.GatewayService.ListAgents:input_type -> gateway.v1.ListAgentsRequest
+	43,  // 119: gateway.v1.GatewayService.ListActiveSessions:input_type -> gateway.v1.ListActiveSessionsRequest
+	39,  // 120: gateway.v1.GatewayService.GetGatewayStatus:input_type -> gateway.v1.GetGatewayStatusRequest
+	46,  // 121: gateway.v1.GatewayService.ProbeLink:input_type -> gateway.v1.ProbeLinkRequest
+	48,  // 122: gateway.v1.GatewayService.ListConsoleSessions:input_type -> gateway.v1.ListConsoleSessionsRequest
+	52,  // 123: gateway.v1.GatewayService.TerminateConsoleSession:input_type -> gateway.v1.TerminateConsoleSessionRequest
+	54,  // 124: gateway.v1.GatewayService.RenewConsoleSession:input_type -> gateway.v1.RenewConsoleSessionRequest
+	99,  // 125: gateway.v1.GatewayService.SetLogLevel:input_type -> gateway.v1.SetLogLevelRequest
+	6,   // 126: gateway.v1.GatewayService.HealthCheck:output_type -> gateway.v1.HealthCheckResponse
+	24,  // 127: gateway.v1.GatewayService.RegisterAgent:output_type -> gateway.v1.RegisterAgentResponse
+	26,  // 128: gateway.v1.GatewayService.AgentHeartbeat:output_type -> gateway.v1.AgentHeartbeatResponse
+	30,  // 129: gateway.v1.GatewayService.ReportHostDrift:output_type -> gateway.v1.ReportHostDriftResponse
+	8,   // 130: gateway.v1.GatewayService.PowerOn:output_type -> gateway.v1.PowerOperationResponse
+	8,   // 131: gateway.v1.GatewayService.PowerOff:output_type -> gateway.v1.PowerOperationResponse
+	10,  // 132: gateway.v1.GatewayService.GracefulShutdown:output_type -> gateway.v1.GracefulShutdownResponse
+	8,   // 133: gateway.v1.GatewayService.ForceOff:output_type -> gateway.v1.PowerOperationResponse
+	8,   // 134: gateway.v1.GatewayService.PowerCycle:output_type -> gateway.v1.PowerOperationResponse
+	8,   // 135: gateway.v1.GatewayService.Reset:output_type -> gateway.v1.PowerOperationResponse
+	8,   // 136: gateway.v1.GatewayService.DiagnosticInterrupt:output_type -> gateway.v1.PowerOperationResponse
+	12,  // 137: gateway.v1.GatewayService.RunPowerOperation:output_type -> gateway.v1.PowerOperationProgress
+	14,  // 138: gateway.v1.GatewayService.StartPowerOperation:output_type -> gateway.v1.StartPowerOperationResponse
+	16,  // 139: gateway.v1.GatewayService.GetOperation:output_type -> gateway.v1.GetOperationResponse
+	18,  // 140: gateway.v1.GatewayService.ListOperations:output_type -> gateway.v1.ListOperationsResponse
+	13,  // 141: gateway.v1.GatewayService.WatchOperation:output_type -> gateway.v1.Operation
+	20,  // 142: gateway.v1.GatewayService.GetPowerStatus:output_type -> gateway.v1.PowerStatusResponse
+	22,  // 143: gateway.v1.GatewayService.SetChassisIdentify:output_type -> gateway.v1.SetChassisIdentifyResponse
+	57,  // 144: gateway.v1.GatewayService.CreateVNCSession:output_type -> gateway.v1.CreateVNCSessionResponse
+	60,  // 145: gateway.v1.GatewayService.GetVNCSession:output_type -> gateway.v1.GetVNCSessionResponse
+	62,  // 146: gateway.v1.GatewayService.CloseVNCSession:output_type -> gateway.v1.CloseVNCSessionResponse
+	74,  // 147: gateway.v1.GatewayService.StartVNCProxy:output_type -> gateway.v1.StartVNCProxyResponse
+	64,  // 148: gateway.v1.GatewayService.CreateSOLSession:output_type -> gateway.v1.CreateSOLSessionResponse
+	67,  // 149: gateway.v1.GatewayService.GetSOLSession:output_type -> gateway.v1.GetSOLSessionResponse
+	69,  // 150: gateway.v1.GatewayService.CloseSOLSession:output_type -> gateway.v1.CloseSOLSessionResponse
+	75,  // 151: gateway.v1.GatewayService.StreamVNCData:output_type -> gateway.v1.VNCDataChunk
+	76,  // 152: gateway.v1.GatewayService.StreamConsoleData:output_type -> gateway.v1.ConsoleDataChunk
+	76,  // 153: gateway.v1.GatewayService.WatchConsoleData:output_type -> gateway.v1.ConsoleDataChunk
+	78,  // 154: gateway.v1.GatewayService.SendConsoleData:output_type -> gateway.v1.SendConsoleDataResponse
+	81,  // 155: gateway.v1.GatewayService.GetBMCInfo:output_type -> gateway.v1.GetBMCInfoResponse
+	84,  // 156: gateway.v1.GatewayService.GetBMCNetworkConfig:output_type -> gateway.v1.GetBMCNetworkConfigResponse
+	87,  // 157: gateway.v1.GatewayService.DeepHealthCheck:output_type -> gateway.v1.DeepHealthCheckResponse
+	90,  // 158: gateway.v1.GatewayService.GetPowerReading:output_type -> gateway.v1.GetPowerReadingResponse
+	93,  // 159: gateway.v1.GatewayService.GetEnergyUsage:output_type -> gateway.v1.GetEnergyUsageResponse
+	33,  // 160: gateway.v1.GatewayService.GetAgentStatus:output_type -> gateway.v1.GetAgentStatusResponse
+	35,  // 161: gateway.v1.GatewayService.ListAgents:output_type -> gateway.v1.ListAgentsResponse
+	44,  // 162: gateway.v1.GatewayService.ListActiveSessions:output_type -> gateway.v1.ListActiveSessionsResponse
+	40,  // 163: gateway.v1.GatewayService.GetGatewayStatus:output_type -> gateway.v1.GetGatewayStatusResponse
+	47,  // 164: gateway.v1.GatewayService.ProbeLink:output_type -> gateway.v1.ProbeLinkResponse
+	49,  // 165: gateway.v1.GatewayService.ListConsoleSessions:output_type -> gateway.v1.ListConsoleSessionsResponse
+	53,  // 166: gateway.v1.GatewayService.TerminateConsoleSession:output_type -> gateway.v1.TerminateConsoleSessionResponse
+	55,  // 167: gateway.v1.GatewayService.RenewConsoleSession:output_type -> gateway.v1.RenewConsoleSessionResponse
+	100, // 168: gateway.v1.GatewayService.SetLogLevel:output_type -> gateway.v1.SetLogLevelResponse
+	126, // [126:169] is the sub-list for method output_type
+	83,  // [83:126] is the sub-list for method input_type
+	83,  // [83:83] is the sub-list for extension type_name
+	83,  // [83:83] is the sub-list for extension extendee
+	0,   // [0:83] is the sub-list for field type_name
 }
 
 func init() { file_gateway_v1_gateway_proto_init() }
@@ -3117,7 +7500,9 @@ func file_gateway_v1_gateway_proto_init() {
 	if File_gateway_v1_gateway_proto != nil {
 		return
 	}
-	file_gateway_v1_gateway_proto_msgTypes[34].OneofWrappers = []any{
+	file_gateway_v1_gateway_proto_msgTypes[70].OneofWrappers = []any{}
+	file_gateway_v1_gateway_proto_msgTypes[71].OneofWrappers = []any{}
+	file_gateway_v1_gateway_proto_msgTypes[77].OneofWrappers = []any{
 		(*BMCInfo_IpmiInfo)(nil),
 		(*BMCInfo_RedfishInfo)(nil),
 	}
@@ -3126,8 +7511,8 @@ func file_gateway_v1_gateway_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gateway_v1_gateway_proto_rawDesc), len(file_gateway_v1_gateway_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   42,
+			NumEnums:      5,
+			NumMessages:   102,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
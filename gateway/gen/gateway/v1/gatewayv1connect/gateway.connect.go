@@ -42,18 +42,47 @@ const (
 	// GatewayServiceAgentHeartbeatProcedure is the fully-qualified name of the GatewayService's
 	// AgentHeartbeat RPC.
 	GatewayServiceAgentHeartbeatProcedure = "/gateway.v1.GatewayService/AgentHeartbeat"
+	// GatewayServiceReportHostDriftProcedure is the fully-qualified name of the GatewayService's
+	// ReportHostDrift RPC.
+	GatewayServiceReportHostDriftProcedure = "/gateway.v1.GatewayService/ReportHostDrift"
 	// GatewayServicePowerOnProcedure is the fully-qualified name of the GatewayService's PowerOn RPC.
 	GatewayServicePowerOnProcedure = "/gateway.v1.GatewayService/PowerOn"
 	// GatewayServicePowerOffProcedure is the fully-qualified name of the GatewayService's PowerOff RPC.
 	GatewayServicePowerOffProcedure = "/gateway.v1.GatewayService/PowerOff"
+	// GatewayServiceGracefulShutdownProcedure is the fully-qualified name of the GatewayService's
+	// GracefulShutdown RPC.
+	GatewayServiceGracefulShutdownProcedure = "/gateway.v1.GatewayService/GracefulShutdown"
+	// GatewayServiceForceOffProcedure is the fully-qualified name of the GatewayService's ForceOff RPC.
+	GatewayServiceForceOffProcedure = "/gateway.v1.GatewayService/ForceOff"
 	// GatewayServicePowerCycleProcedure is the fully-qualified name of the GatewayService's PowerCycle
 	// RPC.
 	GatewayServicePowerCycleProcedure = "/gateway.v1.GatewayService/PowerCycle"
 	// GatewayServiceResetProcedure is the fully-qualified name of the GatewayService's Reset RPC.
 	GatewayServiceResetProcedure = "/gateway.v1.GatewayService/Reset"
+	// GatewayServiceDiagnosticInterruptProcedure is the fully-qualified name of the GatewayService's
+	// DiagnosticInterrupt RPC.
+	GatewayServiceDiagnosticInterruptProcedure = "/gateway.v1.GatewayService/DiagnosticInterrupt"
+	// GatewayServiceRunPowerOperationProcedure is the fully-qualified name of the GatewayService's
+	// RunPowerOperation RPC.
+	GatewayServiceRunPowerOperationProcedure = "/gateway.v1.GatewayService/RunPowerOperation"
+	// GatewayServiceStartPowerOperationProcedure is the fully-qualified name of the GatewayService's
+	// StartPowerOperation RPC.
+	GatewayServiceStartPowerOperationProcedure = "/gateway.v1.GatewayService/StartPowerOperation"
+	// GatewayServiceGetOperationProcedure is the fully-qualified name of the GatewayService's
+	// GetOperation RPC.
+	GatewayServiceGetOperationProcedure = "/gateway.v1.GatewayService/GetOperation"
+	// GatewayServiceListOperationsProcedure is the fully-qualified name of the GatewayService's
+	// ListOperations RPC.
+	GatewayServiceListOperationsProcedure = "/gateway.v1.GatewayService/ListOperations"
+	// GatewayServiceWatchOperationProcedure is the fully-qualified name of the GatewayService's
+	// WatchOperation RPC.
+	GatewayServiceWatchOperationProcedure = "/gateway.v1.GatewayService/WatchOperation"
 	// GatewayServiceGetPowerStatusProcedure is the fully-qualified name of the GatewayService's
 	// GetPowerStatus RPC.
 	GatewayServiceGetPowerStatusProcedure = "/gateway.v1.GatewayService/GetPowerStatus"
+	// GatewayServiceSetChassisIdentifyProcedure is the fully-qualified name of the GatewayService's
+	// SetChassisIdentify RPC.
+	GatewayServiceSetChassisIdentifyProcedure = "/gateway.v1.GatewayService/SetChassisIdentify"
 	// GatewayServiceCreateVNCSessionProcedure is the fully-qualified name of the GatewayService's
 	// CreateVNCSession RPC.
 	GatewayServiceCreateVNCSessionProcedure = "/gateway.v1.GatewayService/CreateVNCSession"
@@ -81,9 +110,54 @@ const (
 	// GatewayServiceStreamConsoleDataProcedure is the fully-qualified name of the GatewayService's
 	// StreamConsoleData RPC.
 	GatewayServiceStreamConsoleDataProcedure = "/gateway.v1.GatewayService/StreamConsoleData"
+	// GatewayServiceWatchConsoleDataProcedure is the fully-qualified name of the GatewayService's
+	// WatchConsoleData RPC.
+	GatewayServiceWatchConsoleDataProcedure = "/gateway.v1.GatewayService/WatchConsoleData"
+	// GatewayServiceSendConsoleDataProcedure is the fully-qualified name of the GatewayService's
+	// SendConsoleData RPC.
+	GatewayServiceSendConsoleDataProcedure = "/gateway.v1.GatewayService/SendConsoleData"
 	// GatewayServiceGetBMCInfoProcedure is the fully-qualified name of the GatewayService's GetBMCInfo
 	// RPC.
 	GatewayServiceGetBMCInfoProcedure = "/gateway.v1.GatewayService/GetBMCInfo"
+	// GatewayServiceGetBMCNetworkConfigProcedure is the fully-qualified name of the GatewayService's
+	// GetBMCNetworkConfig RPC.
+	GatewayServiceGetBMCNetworkConfigProcedure = "/gateway.v1.GatewayService/GetBMCNetworkConfig"
+	// GatewayServiceDeepHealthCheckProcedure is the fully-qualified name of the GatewayService's
+	// DeepHealthCheck RPC.
+	GatewayServiceDeepHealthCheckProcedure = "/gateway.v1.GatewayService/DeepHealthCheck"
+	// GatewayServiceGetPowerReadingProcedure is the fully-qualified name of the GatewayService's
+	// GetPowerReading RPC.
+	GatewayServiceGetPowerReadingProcedure = "/gateway.v1.GatewayService/GetPowerReading"
+	// GatewayServiceGetEnergyUsageProcedure is the fully-qualified name of the GatewayService's
+	// GetEnergyUsage RPC.
+	GatewayServiceGetEnergyUsageProcedure = "/gateway.v1.GatewayService/GetEnergyUsage"
+	// GatewayServiceGetAgentStatusProcedure is the fully-qualified name of the GatewayService's
+	// GetAgentStatus RPC.
+	GatewayServiceGetAgentStatusProcedure = "/gateway.v1.GatewayService/GetAgentStatus"
+	// GatewayServiceListAgentsProcedure is the fully-qualified name of the GatewayService's ListAgents
+	// RPC.
+	GatewayServiceListAgentsProcedure = "/gateway.v1.GatewayService/ListAgents"
+	// GatewayServiceListActiveSessionsProcedure is the fully-qualified name of the GatewayService's
+	// ListActiveSessions RPC.
+	GatewayServiceListActiveSessionsProcedure = "/gateway.v1.GatewayService/ListActiveSessions"
+	// GatewayServiceGetGatewayStatusProcedure is the fully-qualified name of the GatewayService's
+	// GetGatewayStatus RPC.
+	GatewayServiceGetGatewayStatusProcedure = "/gateway.v1.GatewayService/GetGatewayStatus"
+	// GatewayServiceProbeLinkProcedure is the fully-qualified name of the GatewayService's ProbeLink
+	// RPC.
+	GatewayServiceProbeLinkProcedure = "/gateway.v1.GatewayService/ProbeLink"
+	// GatewayServiceListConsoleSessionsProcedure is the fully-qualified name of the GatewayService's
+	// ListConsoleSessions RPC.
+	GatewayServiceListConsoleSessionsProcedure = "/gateway.v1.GatewayService/ListConsoleSessions"
+	// GatewayServiceTerminateConsoleSessionProcedure is the fully-qualified name of the
+	// GatewayService's TerminateConsoleSession RPC.
+	GatewayServiceTerminateConsoleSessionProcedure = "/gateway.v1.GatewayService/TerminateConsoleSession"
+	// GatewayServiceRenewConsoleSessionProcedure is the fully-qualified name of the GatewayService's
+	// RenewConsoleSession RPC.
+	GatewayServiceRenewConsoleSessionProcedure = "/gateway.v1.GatewayService/RenewConsoleSession"
+	// GatewayServiceSetLogLevelProcedure is the fully-qualified name of the GatewayService's
+	// SetLogLevel RPC.
+	GatewayServiceSetLogLevelProcedure = "/gateway.v1.GatewayService/SetLogLevel"
 )
 
 // GatewayServiceClient is a client for the gateway.v1.GatewayService service.
@@ -96,16 +170,49 @@ type GatewayServiceClient interface {
 	// AgentHeartbeat maintains the agent connection and provides server status updates
 	// Agents send periodic heartbeats to keep the connection alive and update server state
 	AgentHeartbeat(context.Context, *connect.Request[v1.AgentHeartbeatRequest]) (*connect.Response[v1.AgentHeartbeatResponse], error)
+	// ReportHostDrift allows agents reconciling their static hosts to report the
+	// BMCs that no longer match the declared configuration, and those back in sync
+	// The gateway publishes the changes as events, relayed to the manager
+	ReportHostDrift(context.Context, *connect.Request[v1.ReportHostDriftRequest]) (*connect.Response[v1.ReportHostDriftResponse], error)
 	// PowerOn sends power-on command to the server's BMC
 	PowerOn(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
-	// PowerOff sends graceful or forced power-off command to the server's BMC
+	// PowerOff forces the server off, like ForceOff. Kept for compatibility
 	PowerOff(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// GracefulShutdown asks the OS to shut down (ACPI power button), optionally
+	// forcing the server off if it is still on after a timeout
+	GracefulShutdown(context.Context, *connect.Request[v1.GracefulShutdownRequest]) (*connect.Response[v1.GracefulShutdownResponse], error)
+	// ForceOff cuts the power of the server immediately, without notifying the OS
+	ForceOff(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
 	// PowerCycle performs a power cycle (off then on) operation
 	PowerCycle(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
 	// Reset performs a hard reset of the server (equivalent to reset button)
 	Reset(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// DiagnosticInterrupt sends a non-maskable interrupt (NMI) to the server,
+	// making the OS of a hung machine panic and write a crash dump. Requires
+	// the power:diag permission.
+	DiagnosticInterrupt(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// RunPowerOperation performs a power operation and streams its progress
+	// until the BMC completes it. Redfish BMCs running the operation as a task
+	// report progress while it runs; other operations complete with a single
+	// message.
+	RunPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest]) (*connect.ServerStreamForClient[v1.PowerOperationProgress], error)
+	// StartPowerOperation starts a power operation, tracked by the gateway
+	// until the BMC completes it, and returns at once with its ID. Clients
+	// poll it with GetOperation, or follow it with WatchOperation.
+	StartPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest]) (*connect.Response[v1.StartPowerOperationResponse], error)
+	// GetOperation returns the state of an operation of a server
+	GetOperation(context.Context, *connect.Request[v1.GetOperationRequest]) (*connect.Response[v1.GetOperationResponse], error)
+	// ListOperations lists the operations of a server tracked by the gateway,
+	// most recent first
+	ListOperations(context.Context, *connect.Request[v1.ListOperationsRequest]) (*connect.Response[v1.ListOperationsResponse], error)
+	// WatchOperation streams the state of an operation each time it changes,
+	// until it completes
+	WatchOperation(context.Context, *connect.Request[v1.GetOperationRequest]) (*connect.ServerStreamForClient[v1.Operation], error)
 	// GetPowerStatus queries the current power state of the server
 	GetPowerStatus(context.Context, *connect.Request[v1.PowerStatusRequest]) (*connect.Response[v1.PowerStatusResponse], error)
+	// SetChassisIdentify turns the chassis identify (locate) LED on or off, or
+	// blinks it for a duration, so that datacenter staff can find the server
+	SetChassisIdentify(context.Context, *connect.Request[v1.SetChassisIdentifyRequest]) (*connect.Response[v1.SetChassisIdentifyResponse], error)
 	// CreateVNCSession creates a VNC console session for remote access
 	CreateVNCSession(context.Context, *connect.Request[v1.CreateVNCSessionRequest]) (*connect.Response[v1.CreateVNCSessionResponse], error)
 	// GetVNCSession retrieves information about an existing VNC session
@@ -126,9 +233,60 @@ type GatewayServiceClient interface {
 	// Streaming RPC for SOL/Console data (Gateway <-> Agent bidirectional streaming)
 	// Gateway initiates this stream to agent, then bidirectionally streams console data
 	StreamConsoleData(context.Context) *connect.BidiStreamForClient[v1.ConsoleDataChunk, v1.ConsoleDataChunk]
+	// WatchConsoleData streams a console to browser Connect and gRPC-Web clients, which cannot
+	// stream requests. The request is the handshake of StreamConsoleData; the handshake ack
+	// carries the stream_id that SendConsoleData sends the client's chunks to.
+	WatchConsoleData(context.Context, *connect.Request[v1.ConsoleDataChunk]) (*connect.ServerStreamForClient[v1.ConsoleDataChunk], error)
+	// SendConsoleData sends input, resize and close chunks to a stream of WatchConsoleData.
+	// Calls must not overlap, so that the chunks reach the console in order.
+	SendConsoleData(context.Context, *connect.Request[v1.SendConsoleDataRequest]) (*connect.Response[v1.SendConsoleDataResponse], error)
 	// GetBMCInfo retrieves detailed hardware information from the BMC
 	// This returns firmware version, manufacturer details, and capabilities
 	GetBMCInfo(context.Context, *connect.Request[v1.GetBMCInfoRequest]) (*connect.Response[v1.GetBMCInfoResponse], error)
+	// GetBMCNetworkConfig returns the network configuration of the BMC's own
+	// management interfaces: addresses, masks, gateways and VLANs, from IPMI
+	// LAN parameters or the Redfish Manager's EthernetInterfaces
+	GetBMCNetworkConfig(context.Context, *connect.Request[v1.GetBMCNetworkConfigRequest]) (*connect.Response[v1.GetBMCNetworkConfigResponse], error)
+	// DeepHealthCheck checks each hop from the gateway to a server's BMC: the
+	// agent, the BMC control endpoint and, optionally, the SOL and VNC
+	// endpoints. Failed hops are reported in the response, not as errors.
+	DeepHealthCheck(context.Context, *connect.Request[v1.DeepHealthCheckRequest]) (*connect.Response[v1.DeepHealthCheckResponse], error)
+	// GetPowerReading returns the current power consumption of a server, as
+	// metered by its BMC (Redfish PowerControl)
+	GetPowerReading(context.Context, *connect.Request[v1.GetPowerReadingRequest]) (*connect.Response[v1.GetPowerReadingResponse], error)
+	// GetEnergyUsage returns the cumulative energy consumed by a server, for
+	// BMCs that meter energy (Redfish EnvironmentMetrics)
+	GetEnergyUsage(context.Context, *connect.Request[v1.GetEnergyUsageRequest]) (*connect.Response[v1.GetEnergyUsageResponse], error)
+	// GetAgentStatus returns registration details, BMC endpoint inventory and session activity
+	// for a Local Agent connected to this gateway (admin only)
+	GetAgentStatus(context.Context, *connect.Request[v1.GetAgentStatusRequest]) (*connect.Response[v1.GetAgentStatusResponse], error)
+	// ListAgents returns the status of every Local Agent registered with this gateway,
+	// with their BMC endpoints (admin only)
+	ListAgents(context.Context, *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error)
+	// ListActiveSessions returns the BMC console connections held open by a Local Agent.
+	// The gateway forwards the request to the agent; restricted to admin tokens.
+	ListActiveSessions(context.Context, *connect.Request[v1.ListActiveSessionsRequest]) (*connect.Response[v1.ListActiveSessionsResponse], error)
+	// GetGatewayStatus returns the registered agents with their link probes, the console
+	// session counts and the BMC endpoint mappings of this gateway (admin only)
+	GetGatewayStatus(context.Context, *connect.Request[v1.GetGatewayStatusRequest]) (*connect.Response[v1.GetGatewayStatusResponse], error)
+	// ProbeLink measures the gateway-agent link: the agent discards the payload and replies
+	// with response_size bytes. Gateways call it periodically on each registered agent to
+	// track round-trip time and throughput.
+	ProbeLink(context.Context, *connect.Request[v1.ProbeLinkRequest]) (*connect.Response[v1.ProbeLinkResponse], error)
+	// ListConsoleSessions returns the VNC and SOL sessions held by this gateway, with the
+	// customer that opened them and the streams currently attached. Admins list all
+	// sessions, other customers only their own.
+	ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error)
+	// TerminateConsoleSession closes a console session and disconnects its attached streams.
+	// Restricted to admin tokens.
+	TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error)
+	// RenewConsoleSession extends a console session, and the streams attached to it, which
+	// are warned shortly before their session expires. Requires a token of the session's server.
+	RenewConsoleSession(context.Context, *connect.Request[v1.RenewConsoleSessionRequest]) (*connect.Response[v1.RenewConsoleSessionResponse], error)
+	// SetLogLevel changes the log levels of the gateway at runtime, e.g. to enable debug logging
+	// during an incident without restarting and dropping console sessions. An empty request
+	// returns the current levels. Restricted to admin tokens.
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewGatewayServiceClient constructs a client for the gateway.v1.GatewayService service. By
@@ -160,6 +318,12 @@ func NewGatewayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(gatewayServiceMethods.ByName("AgentHeartbeat")),
 			connect.WithClientOptions(opts...),
 		),
+		reportHostDrift: connect.NewClient[v1.ReportHostDriftRequest, v1.ReportHostDriftResponse](
+			httpClient,
+			baseURL+GatewayServiceReportHostDriftProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ReportHostDrift")),
+			connect.WithClientOptions(opts...),
+		),
 		powerOn: connect.NewClient[v1.PowerOperationRequest, v1.PowerOperationResponse](
 			httpClient,
 			baseURL+GatewayServicePowerOnProcedure,
@@ -172,6 +336,18 @@ func NewGatewayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(gatewayServiceMethods.ByName("PowerOff")),
 			connect.WithClientOptions(opts...),
 		),
+		gracefulShutdown: connect.NewClient[v1.GracefulShutdownRequest, v1.GracefulShutdownResponse](
+			httpClient,
+			baseURL+GatewayServiceGracefulShutdownProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GracefulShutdown")),
+			connect.WithClientOptions(opts...),
+		),
+		forceOff: connect.NewClient[v1.PowerOperationRequest, v1.PowerOperationResponse](
+			httpClient,
+			baseURL+GatewayServiceForceOffProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ForceOff")),
+			connect.WithClientOptions(opts...),
+		),
 		powerCycle: connect.NewClient[v1.PowerOperationRequest, v1.PowerOperationResponse](
 			httpClient,
 			baseURL+GatewayServicePowerCycleProcedure,
@@ -184,12 +360,54 @@ func NewGatewayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(gatewayServiceMethods.ByName("Reset")),
 			connect.WithClientOptions(opts...),
 		),
+		diagnosticInterrupt: connect.NewClient[v1.PowerOperationRequest, v1.PowerOperationResponse](
+			httpClient,
+			baseURL+GatewayServiceDiagnosticInterruptProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("DiagnosticInterrupt")),
+			connect.WithClientOptions(opts...),
+		),
+		runPowerOperation: connect.NewClient[v1.RunPowerOperationRequest, v1.PowerOperationProgress](
+			httpClient,
+			baseURL+GatewayServiceRunPowerOperationProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("RunPowerOperation")),
+			connect.WithClientOptions(opts...),
+		),
+		startPowerOperation: connect.NewClient[v1.RunPowerOperationRequest, v1.StartPowerOperationResponse](
+			httpClient,
+			baseURL+GatewayServiceStartPowerOperationProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("StartPowerOperation")),
+			connect.WithClientOptions(opts...),
+		),
+		getOperation: connect.NewClient[v1.GetOperationRequest, v1.GetOperationResponse](
+			httpClient,
+			baseURL+GatewayServiceGetOperationProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetOperation")),
+			connect.WithClientOptions(opts...),
+		),
+		listOperations: connect.NewClient[v1.ListOperationsRequest, v1.ListOperationsResponse](
+			httpClient,
+			baseURL+GatewayServiceListOperationsProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ListOperations")),
+			connect.WithClientOptions(opts...),
+		),
+		watchOperation: connect.NewClient[v1.GetOperationRequest, v1.Operation](
+			httpClient,
+			baseURL+GatewayServiceWatchOperationProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("WatchOperation")),
+			connect.WithClientOptions(opts...),
+		),
 		getPowerStatus: connect.NewClient[v1.PowerStatusRequest, v1.PowerStatusResponse](
 			httpClient,
 			baseURL+GatewayServiceGetPowerStatusProcedure,
 			connect.WithSchema(gatewayServiceMethods.ByName("GetPowerStatus")),
 			connect.WithClientOptions(opts...),
 		),
+		setChassisIdentify: connect.NewClient[v1.SetChassisIdentifyRequest, v1.SetChassisIdentifyResponse](
+			httpClient,
+			baseURL+GatewayServiceSetChassisIdentifyProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("SetChassisIdentify")),
+			connect.WithClientOptions(opts...),
+		),
 		createVNCSession: connect.NewClient[v1.CreateVNCSessionRequest, v1.CreateVNCSessionResponse](
 			httpClient,
 			baseURL+GatewayServiceCreateVNCSessionProcedure,
@@ -244,35 +462,150 @@ func NewGatewayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(gatewayServiceMethods.ByName("StreamConsoleData")),
 			connect.WithClientOptions(opts...),
 		),
+		watchConsoleData: connect.NewClient[v1.ConsoleDataChunk, v1.ConsoleDataChunk](
+			httpClient,
+			baseURL+GatewayServiceWatchConsoleDataProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("WatchConsoleData")),
+			connect.WithClientOptions(opts...),
+		),
+		sendConsoleData: connect.NewClient[v1.SendConsoleDataRequest, v1.SendConsoleDataResponse](
+			httpClient,
+			baseURL+GatewayServiceSendConsoleDataProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("SendConsoleData")),
+			connect.WithClientOptions(opts...),
+		),
 		getBMCInfo: connect.NewClient[v1.GetBMCInfoRequest, v1.GetBMCInfoResponse](
 			httpClient,
 			baseURL+GatewayServiceGetBMCInfoProcedure,
 			connect.WithSchema(gatewayServiceMethods.ByName("GetBMCInfo")),
 			connect.WithClientOptions(opts...),
 		),
+		getBMCNetworkConfig: connect.NewClient[v1.GetBMCNetworkConfigRequest, v1.GetBMCNetworkConfigResponse](
+			httpClient,
+			baseURL+GatewayServiceGetBMCNetworkConfigProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetBMCNetworkConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		deepHealthCheck: connect.NewClient[v1.DeepHealthCheckRequest, v1.DeepHealthCheckResponse](
+			httpClient,
+			baseURL+GatewayServiceDeepHealthCheckProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("DeepHealthCheck")),
+			connect.WithClientOptions(opts...),
+		),
+		getPowerReading: connect.NewClient[v1.GetPowerReadingRequest, v1.GetPowerReadingResponse](
+			httpClient,
+			baseURL+GatewayServiceGetPowerReadingProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetPowerReading")),
+			connect.WithClientOptions(opts...),
+		),
+		getEnergyUsage: connect.NewClient[v1.GetEnergyUsageRequest, v1.GetEnergyUsageResponse](
+			httpClient,
+			baseURL+GatewayServiceGetEnergyUsageProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetEnergyUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentStatus: connect.NewClient[v1.GetAgentStatusRequest, v1.GetAgentStatusResponse](
+			httpClient,
+			baseURL+GatewayServiceGetAgentStatusProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetAgentStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		listAgents: connect.NewClient[v1.ListAgentsRequest, v1.ListAgentsResponse](
+			httpClient,
+			baseURL+GatewayServiceListAgentsProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ListAgents")),
+			connect.WithClientOptions(opts...),
+		),
+		listActiveSessions: connect.NewClient[v1.ListActiveSessionsRequest, v1.ListActiveSessionsResponse](
+			httpClient,
+			baseURL+GatewayServiceListActiveSessionsProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ListActiveSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		getGatewayStatus: connect.NewClient[v1.GetGatewayStatusRequest, v1.GetGatewayStatusResponse](
+			httpClient,
+			baseURL+GatewayServiceGetGatewayStatusProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("GetGatewayStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		probeLink: connect.NewClient[v1.ProbeLinkRequest, v1.ProbeLinkResponse](
+			httpClient,
+			baseURL+GatewayServiceProbeLinkProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ProbeLink")),
+			connect.WithClientOptions(opts...),
+		),
+		listConsoleSessions: connect.NewClient[v1.ListConsoleSessionsRequest, v1.ListConsoleSessionsResponse](
+			httpClient,
+			baseURL+GatewayServiceListConsoleSessionsProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("ListConsoleSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		terminateConsoleSession: connect.NewClient[v1.TerminateConsoleSessionRequest, v1.TerminateConsoleSessionResponse](
+			httpClient,
+			baseURL+GatewayServiceTerminateConsoleSessionProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("TerminateConsoleSession")),
+			connect.WithClientOptions(opts...),
+		),
+		renewConsoleSession: connect.NewClient[v1.RenewConsoleSessionRequest, v1.RenewConsoleSessionResponse](
+			httpClient,
+			baseURL+GatewayServiceRenewConsoleSessionProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("RenewConsoleSession")),
+			connect.WithClientOptions(opts...),
+		),
+		setLogLevel: connect.NewClient[v1.SetLogLevelRequest, v1.SetLogLevelResponse](
+			httpClient,
+			baseURL+GatewayServiceSetLogLevelProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("SetLogLevel")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // gatewayServiceClient implements GatewayServiceClient.
 type gatewayServiceClient struct {
-	healthCheck       *connect.Client[v1.HealthCheckRequest, v1.HealthCheckResponse]
-	registerAgent     *connect.Client[v1.RegisterAgentRequest, v1.RegisterAgentResponse]
-	agentHeartbeat    *connect.Client[v1.AgentHeartbeatRequest, v1.AgentHeartbeatResponse]
-	powerOn           *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
-	powerOff          *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
-	powerCycle        *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
-	reset             *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
-	getPowerStatus    *connect.Client[v1.PowerStatusRequest, v1.PowerStatusResponse]
-	createVNCSession  *connect.Client[v1.CreateVNCSessionRequest, v1.CreateVNCSessionResponse]
-	getVNCSession     *connect.Client[v1.GetVNCSessionRequest, v1.GetVNCSessionResponse]
-	closeVNCSession   *connect.Client[v1.CloseVNCSessionRequest, v1.CloseVNCSessionResponse]
-	startVNCProxy     *connect.Client[v1.StartVNCProxyRequest, v1.StartVNCProxyResponse]
-	createSOLSession  *connect.Client[v1.CreateSOLSessionRequest, v1.CreateSOLSessionResponse]
-	getSOLSession     *connect.Client[v1.GetSOLSessionRequest, v1.GetSOLSessionResponse]
-	closeSOLSession   *connect.Client[v1.CloseSOLSessionRequest, v1.CloseSOLSessionResponse]
-	streamVNCData     *connect.Client[v1.VNCDataChunk, v1.VNCDataChunk]
-	streamConsoleData *connect.Client[v1.ConsoleDataChunk, v1.ConsoleDataChunk]
-	getBMCInfo        *connect.Client[v1.GetBMCInfoRequest, v1.GetBMCInfoResponse]
+	healthCheck             *connect.Client[v1.HealthCheckRequest, v1.HealthCheckResponse]
+	registerAgent           *connect.Client[v1.RegisterAgentRequest, v1.RegisterAgentResponse]
+	agentHeartbeat          *connect.Client[v1.AgentHeartbeatRequest, v1.AgentHeartbeatResponse]
+	reportHostDrift         *connect.Client[v1.ReportHostDriftRequest, v1.ReportHostDriftResponse]
+	powerOn                 *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	powerOff                *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	gracefulShutdown        *connect.Client[v1.GracefulShutdownRequest, v1.GracefulShutdownResponse]
+	forceOff                *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	powerCycle              *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	reset                   *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	diagnosticInterrupt     *connect.Client[v1.PowerOperationRequest, v1.PowerOperationResponse]
+	runPowerOperation       *connect.Client[v1.RunPowerOperationRequest, v1.PowerOperationProgress]
+	startPowerOperation     *connect.Client[v1.RunPowerOperationRequest, v1.StartPowerOperationResponse]
+	getOperation            *connect.Client[v1.GetOperationRequest, v1.GetOperationResponse]
+	listOperations          *connect.Client[v1.ListOperationsRequest, v1.ListOperationsResponse]
+	watchOperation          *connect.Client[v1.GetOperationRequest, v1.Operation]
+	getPowerStatus          *connect.Client[v1.PowerStatusRequest, v1.PowerStatusResponse]
+	setChassisIdentify      *connect.Client[v1.SetChassisIdentifyRequest, v1.SetChassisIdentifyResponse]
+	createVNCSession        *connect.Client[v1.CreateVNCSessionRequest, v1.CreateVNCSessionResponse]
+	getVNCSession           *connect.Client[v1.GetVNCSessionRequest, v1.GetVNCSessionResponse]
+	closeVNCSession         *connect.Client[v1.CloseVNCSessionRequest, v1.CloseVNCSessionResponse]
+	startVNCProxy           *connect.Client[v1.StartVNCProxyRequest, v1.StartVNCProxyResponse]
+	createSOLSession        *connect.Client[v1.CreateSOLSessionRequest, v1.CreateSOLSessionResponse]
+	getSOLSession           *connect.Client[v1.GetSOLSessionRequest, v1.GetSOLSessionResponse]
+	closeSOLSession         *connect.Client[v1.CloseSOLSessionRequest, v1.CloseSOLSessionResponse]
+	streamVNCData           *connect.Client[v1.VNCDataChunk, v1.VNCDataChunk]
+	streamConsoleData       *connect.Client[v1.ConsoleDataChunk, v1.ConsoleDataChunk]
+	watchConsoleData        *connect.Client[v1.ConsoleDataChunk, v1.ConsoleDataChunk]
+	sendConsoleData         *connect.Client[v1.SendConsoleDataRequest, v1.SendConsoleDataResponse]
+	getBMCInfo              *connect.Client[v1.GetBMCInfoRequest, v1.GetBMCInfoResponse]
+	getBMCNetworkConfig     *connect.Client[v1.GetBMCNetworkConfigRequest, v1.GetBMCNetworkConfigResponse]
+	deepHealthCheck         *connect.Client[v1.DeepHealthCheckRequest, v1.DeepHealthCheckResponse]
+	getPowerReading         *connect.Client[v1.GetPowerReadingRequest, v1.GetPowerReadingResponse]
+	getEnergyUsage          *connect.Client[v1.GetEnergyUsageRequest, v1.GetEnergyUsageResponse]
+	getAgentStatus          *connect.Client[v1.GetAgentStatusRequest, v1.GetAgentStatusResponse]
+	listAgents              *connect.Client[v1.ListAgentsRequest, v1.ListAgentsResponse]
+	listActiveSessions      *connect.Client[v1.ListActiveSessionsRequest, v1.ListActiveSessionsResponse]
+	getGatewayStatus        *connect.Client[v1.GetGatewayStatusRequest, v1.GetGatewayStatusResponse]
+	probeLink               *connect.Client[v1.ProbeLinkRequest, v1.ProbeLinkResponse]
+	listConsoleSessions     *connect.Client[v1.ListConsoleSessionsRequest, v1.ListConsoleSessionsResponse]
+	terminateConsoleSession *connect.Client[v1.TerminateConsoleSessionRequest, v1.TerminateConsoleSessionResponse]
+	renewConsoleSession     *connect.Client[v1.RenewConsoleSessionRequest, v1.RenewConsoleSessionResponse]
+	setLogLevel             *connect.Client[v1.SetLogLevelRequest, v1.SetLogLevelResponse]
 }
 
 // HealthCheck calls gateway.v1.GatewayService.HealthCheck.
@@ -290,6 +623,11 @@ func (c *gatewayServiceClient) AgentHeartbeat(ctx context.Context, req *connect.
 	return c.agentHeartbeat.CallUnary(ctx, req)
 }
 
+// ReportHostDrift calls gateway.v1.GatewayService.ReportHostDrift.
+func (c *gatewayServiceClient) ReportHostDrift(ctx context.Context, req *connect.Request[v1.ReportHostDriftRequest]) (*connect.Response[v1.ReportHostDriftResponse], error) {
+	return c.reportHostDrift.CallUnary(ctx, req)
+}
+
 // PowerOn calls gateway.v1.GatewayService.PowerOn.
 func (c *gatewayServiceClient) PowerOn(ctx context.Context, req *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
 	return c.powerOn.CallUnary(ctx, req)
@@ -300,6 +638,16 @@ func (c *gatewayServiceClient) PowerOff(ctx context.Context, req *connect.Reques
 	return c.powerOff.CallUnary(ctx, req)
 }
 
+// GracefulShutdown calls gateway.v1.GatewayService.GracefulShutdown.
+func (c *gatewayServiceClient) GracefulShutdown(ctx context.Context, req *connect.Request[v1.GracefulShutdownRequest]) (*connect.Response[v1.GracefulShutdownResponse], error) {
+	return c.gracefulShutdown.CallUnary(ctx, req)
+}
+
+// ForceOff calls gateway.v1.GatewayService.ForceOff.
+func (c *gatewayServiceClient) ForceOff(ctx context.Context, req *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
+	return c.forceOff.CallUnary(ctx, req)
+}
+
 // PowerCycle calls gateway.v1.GatewayService.PowerCycle.
 func (c *gatewayServiceClient) PowerCycle(ctx context.Context, req *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
 	return c.powerCycle.CallUnary(ctx, req)
@@ -310,11 +658,46 @@ func (c *gatewayServiceClient) Reset(ctx context.Context, req *connect.Request[v
 	return c.reset.CallUnary(ctx, req)
 }
 
+// DiagnosticInterrupt calls gateway.v1.GatewayService.DiagnosticInterrupt.
+func (c *gatewayServiceClient) DiagnosticInterrupt(ctx context.Context, req *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
+	return c.diagnosticInterrupt.CallUnary(ctx, req)
+}
+
+// RunPowerOperation calls gateway.v1.GatewayService.RunPowerOperation.
+func (c *gatewayServiceClient) RunPowerOperation(ctx context.Context, req *connect.Request[v1.RunPowerOperationRequest]) (*connect.ServerStreamForClient[v1.PowerOperationProgress], error) {
+	return c.runPowerOperation.CallServerStream(ctx, req)
+}
+
+// StartPowerOperation calls gateway.v1.GatewayService.StartPowerOperation.
+func (c *gatewayServiceClient) StartPowerOperation(ctx context.Context, req *connect.Request[v1.RunPowerOperationRequest]) (*connect.Response[v1.StartPowerOperationResponse], error) {
+	return c.startPowerOperation.CallUnary(ctx, req)
+}
+
+// GetOperation calls gateway.v1.GatewayService.GetOperation.
+func (c *gatewayServiceClient) GetOperation(ctx context.Context, req *connect.Request[v1.GetOperationRequest]) (*connect.Response[v1.GetOperationResponse], error) {
+	return c.getOperation.CallUnary(ctx, req)
+}
+
+// ListOperations calls gateway.v1.GatewayService.ListOperations.
+func (c *gatewayServiceClient) ListOperations(ctx context.Context, req *connect.Request[v1.ListOperationsRequest]) (*connect.Response[v1.ListOperationsResponse], error) {
+	return c.listOperations.CallUnary(ctx, req)
+}
+
+// WatchOperation calls gateway.v1.GatewayService.WatchOperation.
+func (c *gatewayServiceClient) WatchOperation(ctx context.Context, req *connect.Request[v1.GetOperationRequest]) (*connect.ServerStreamForClient[v1.Operation], error) {
+	return c.watchOperation.CallServerStream(ctx, req)
+}
+
 // GetPowerStatus calls gateway.v1.GatewayService.GetPowerStatus.
 func (c *gatewayServiceClient) GetPowerStatus(ctx context.Context, req *connect.Request[v1.PowerStatusRequest]) (*connect.Response[v1.PowerStatusResponse], error) {
 	return c.getPowerStatus.CallUnary(ctx, req)
 }
 
+// SetChassisIdentify calls gateway.v1.GatewayService.SetChassisIdentify.
+func (c *gatewayServiceClient) SetChassisIdentify(ctx context.Context, req *connect.Request[v1.SetChassisIdentifyRequest]) (*connect.Response[v1.SetChassisIdentifyResponse], error) {
+	return c.setChassisIdentify.CallUnary(ctx, req)
+}
+
 // CreateVNCSession calls gateway.v1.GatewayService.CreateVNCSession.
 func (c *gatewayServiceClient) CreateVNCSession(ctx context.Context, req *connect.Request[v1.CreateVNCSessionRequest]) (*connect.Response[v1.CreateVNCSessionResponse], error) {
 	return c.createVNCSession.CallUnary(ctx, req)
@@ -360,11 +743,86 @@ func (c *gatewayServiceClient) StreamConsoleData(ctx context.Context) *connect.B
 	return c.streamConsoleData.CallBidiStream(ctx)
 }
 
+// WatchConsoleData calls gateway.v1.GatewayService.WatchConsoleData.
+func (c *gatewayServiceClient) WatchConsoleData(ctx context.Context, req *connect.Request[v1.ConsoleDataChunk]) (*connect.ServerStreamForClient[v1.ConsoleDataChunk], error) {
+	return c.watchConsoleData.CallServerStream(ctx, req)
+}
+
+// SendConsoleData calls gateway.v1.GatewayService.SendConsoleData.
+func (c *gatewayServiceClient) SendConsoleData(ctx context.Context, req *connect.Request[v1.SendConsoleDataRequest]) (*connect.Response[v1.SendConsoleDataResponse], error) {
+	return c.sendConsoleData.CallUnary(ctx, req)
+}
+
 // GetBMCInfo calls gateway.v1.GatewayService.GetBMCInfo.
 func (c *gatewayServiceClient) GetBMCInfo(ctx context.Context, req *connect.Request[v1.GetBMCInfoRequest]) (*connect.Response[v1.GetBMCInfoResponse], error) {
 	return c.getBMCInfo.CallUnary(ctx, req)
 }
 
+// GetBMCNetworkConfig calls gateway.v1.GatewayService.GetBMCNetworkConfig.
+func (c *gatewayServiceClient) GetBMCNetworkConfig(ctx context.Context, req *connect.Request[v1.GetBMCNetworkConfigRequest]) (*connect.Response[v1.GetBMCNetworkConfigResponse], error) {
+	return c.getBMCNetworkConfig.CallUnary(ctx, req)
+}
+
+// DeepHealthCheck calls gateway.v1.GatewayService.DeepHealthCheck.
+func (c *gatewayServiceClient) DeepHealthCheck(ctx context.Context, req *connect.Request[v1.DeepHealthCheckRequest]) (*connect.Response[v1.DeepHealthCheckResponse], error) {
+	return c.deepHealthCheck.CallUnary(ctx, req)
+}
+
+// GetPowerReading calls gateway.v1.GatewayService.GetPowerReading.
+func (c *gatewayServiceClient) GetPowerReading(ctx context.Context, req *connect.Request[v1.GetPowerReadingRequest]) (*connect.Response[v1.GetPowerReadingResponse], error) {
+	return c.getPowerReading.CallUnary(ctx, req)
+}
+
+// GetEnergyUsage calls gateway.v1.GatewayService.GetEnergyUsage.
+func (c *gatewayServiceClient) GetEnergyUsage(ctx context.Context, req *connect.Request[v1.GetEnergyUsageRequest]) (*connect.Response[v1.GetEnergyUsageResponse], error) {
+	return c.getEnergyUsage.CallUnary(ctx, req)
+}
+
+// GetAgentStatus calls gateway.v1.GatewayService.GetAgentStatus.
+func (c *gatewayServiceClient) GetAgentStatus(ctx context.Context, req *connect.Request[v1.GetAgentStatusRequest]) (*connect.Response[v1.GetAgentStatusResponse], error) {
+	return c.getAgentStatus.CallUnary(ctx, req)
+}
+
+// ListAgents calls gateway.v1.GatewayService.ListAgents.
+func (c *gatewayServiceClient) ListAgents(ctx context.Context, req *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error) {
+	return c.listAgents.CallUnary(ctx, req)
+}
+
+// ListActiveSessions calls gateway.v1.GatewayService.ListActiveSessions.
+func (c *gatewayServiceClient) ListActiveSessions(ctx context.Context, req *connect.Request[v1.ListActiveSessionsRequest]) (*connect.Response[v1.ListActiveSessionsResponse], error) {
+	return c.listActiveSessions.CallUnary(ctx, req)
+}
+
+// GetGatewayStatus calls gateway.v1.GatewayService.GetGatewayStatus.
+func (c *gatewayServiceClient) GetGatewayStatus(ctx context.Context, req *connect.Request[v1.GetGatewayStatusRequest]) (*connect.Response[v1.GetGatewayStatusResponse], error) {
+	return c.getGatewayStatus.CallUnary(ctx, req)
+}
+
+// ProbeLink calls gateway.v1.GatewayService.ProbeLink.
+func (c *gatewayServiceClient) ProbeLink(ctx context.Context, req *connect.Request[v1.ProbeLinkRequest]) (*connect.Response[v1.ProbeLinkResponse], error) {
+	return c.probeLink.CallUnary(ctx, req)
+}
+
+// ListConsoleSessions calls gateway.v1.GatewayService.ListConsoleSessions.
+func (c *gatewayServiceClient) ListConsoleSessions(ctx context.Context, req *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error) {
+	return c.listConsoleSessions.CallUnary(ctx, req)
+}
+
+// TerminateConsoleSession calls gateway.v1.GatewayService.TerminateConsoleSession.
+func (c *gatewayServiceClient) TerminateConsoleSession(ctx context.Context, req *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error) {
+	return c.terminateConsoleSession.CallUnary(ctx, req)
+}
+
+// RenewConsoleSession calls gateway.v1.GatewayService.RenewConsoleSession.
+func (c *gatewayServiceClient) RenewConsoleSession(ctx context.Context, req *connect.Request[v1.RenewConsoleSessionRequest]) (*connect.Response[v1.RenewConsoleSessionResponse], error) {
+	return c.renewConsoleSession.CallUnary(ctx, req)
+}
+
+// SetLogLevel calls gateway.v1.GatewayService.SetLogLevel.
+func (c *gatewayServiceClient) SetLogLevel(ctx context.Context, req *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return c.setLogLevel.CallUnary(ctx, req)
+}
+
 // GatewayServiceHandler is an implementation of the gateway.v1.GatewayService service.
 type GatewayServiceHandler interface {
 	// Health check endpoint for monitoring and load balancer health probes
@@ -375,16 +833,49 @@ type GatewayServiceHandler interface {
 	// AgentHeartbeat maintains the agent connection and provides server status updates
 	// Agents send periodic heartbeats to keep the connection alive and update server state
 	AgentHeartbeat(context.Context, *connect.Request[v1.AgentHeartbeatRequest]) (*connect.Response[v1.AgentHeartbeatResponse], error)
+	// ReportHostDrift allows agents reconciling their static hosts to report the
+	// BMCs that no longer match the declared configuration, and those back in sync
+	// The gateway publishes the changes as events, relayed to the manager
+	ReportHostDrift(context.Context, *connect.Request[v1.ReportHostDriftRequest]) (*connect.Response[v1.ReportHostDriftResponse], error)
 	// PowerOn sends power-on command to the server's BMC
 	PowerOn(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
-	// PowerOff sends graceful or forced power-off command to the server's BMC
+	// PowerOff forces the server off, like ForceOff. Kept for compatibility
 	PowerOff(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// GracefulShutdown asks the OS to shut down (ACPI power button), optionally
+	// forcing the server off if it is still on after a timeout
+	GracefulShutdown(context.Context, *connect.Request[v1.GracefulShutdownRequest]) (*connect.Response[v1.GracefulShutdownResponse], error)
+	// ForceOff cuts the power of the server immediately, without notifying the OS
+	ForceOff(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
 	// PowerCycle performs a power cycle (off then on) operation
 	PowerCycle(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
 	// Reset performs a hard reset of the server (equivalent to reset button)
 	Reset(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// DiagnosticInterrupt sends a non-maskable interrupt (NMI) to the server,
+	// making the OS of a hung machine panic and write a crash dump. Requires
+	// the power:diag permission.
+	DiagnosticInterrupt(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error)
+	// RunPowerOperation performs a power operation and streams its progress
+	// until the BMC completes it. Redfish BMCs running the operation as a task
+	// report progress while it runs; other operations complete with a single
+	// message.
+	RunPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest], *connect.ServerStream[v1.PowerOperationProgress]) error
+	// StartPowerOperation starts a power operation, tracked by the gateway
+	// until the BMC completes it, and returns at once with its ID. Clients
+	// poll it with GetOperation, or follow it with WatchOperation.
+	StartPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest]) (*connect.Response[v1.StartPowerOperationResponse], error)
+	// GetOperation returns the state of an operation of a server
+	GetOperation(context.Context, *connect.Request[v1.GetOperationRequest]) (*connect.Response[v1.GetOperationResponse], error)
+	// ListOperations lists the operations of a server tracked by the gateway,
+	// most recent first
+	ListOperations(context.Context, *connect.Request[v1.ListOperationsRequest]) (*connect.Response[v1.ListOperationsResponse], error)
+	// WatchOperation streams the state of an operation each time it changes,
+	// until it completes
+	WatchOperation(context.Context, *connect.Request[v1.GetOperationRequest], *connect.ServerStream[v1.Operation]) error
 	// GetPowerStatus queries the current power state of the server
 	GetPowerStatus(context.Context, *connect.Request[v1.PowerStatusRequest]) (*connect.Response[v1.PowerStatusResponse], error)
+	// SetChassisIdentify turns the chassis identify (locate) LED on or off, or
+	// blinks it for a duration, so that datacenter staff can find the server
+	SetChassisIdentify(context.Context, *connect.Request[v1.SetChassisIdentifyRequest]) (*connect.Response[v1.SetChassisIdentifyResponse], error)
 	// CreateVNCSession creates a VNC console session for remote access
 	CreateVNCSession(context.Context, *connect.Request[v1.CreateVNCSessionRequest]) (*connect.Response[v1.CreateVNCSessionResponse], error)
 	// GetVNCSession retrieves information about an existing VNC session
@@ -405,9 +896,60 @@ type GatewayServiceHandler interface {
 	// Streaming RPC for SOL/Console data (Gateway <-> Agent bidirectional streaming)
 	// Gateway initiates this stream to agent, then bidirectionally streams console data
 	StreamConsoleData(context.Context, *connect.BidiStream[v1.ConsoleDataChunk, v1.ConsoleDataChunk]) error
+	// WatchConsoleData streams a console to browser Connect and gRPC-Web clients, which cannot
+	// stream requests. The request is the handshake of StreamConsoleData; the handshake ack
+	// carries the stream_id that SendConsoleData sends the client's chunks to.
+	WatchConsoleData(context.Context, *connect.Request[v1.ConsoleDataChunk], *connect.ServerStream[v1.ConsoleDataChunk]) error
+	// SendConsoleData sends input, resize and close chunks to a stream of WatchConsoleData.
+	// Calls must not overlap, so that the chunks reach the console in order.
+	SendConsoleData(context.Context, *connect.Request[v1.SendConsoleDataRequest]) (*connect.Response[v1.SendConsoleDataResponse], error)
 	// GetBMCInfo retrieves detailed hardware information from the BMC
 	// This returns firmware version, manufacturer details, and capabilities
 	GetBMCInfo(context.Context, *connect.Request[v1.GetBMCInfoRequest]) (*connect.Response[v1.GetBMCInfoResponse], error)
+	// GetBMCNetworkConfig returns the network configuration of the BMC's own
+	// management interfaces: addresses, masks, gateways and VLANs, from IPMI
+	// LAN parameters or the Redfish Manager's EthernetInterfaces
+	GetBMCNetworkConfig(context.Context, *connect.Request[v1.GetBMCNetworkConfigRequest]) (*connect.Response[v1.GetBMCNetworkConfigResponse], error)
+	// DeepHealthCheck checks each hop from the gateway to a server's BMC: the
+	// agent, the BMC control endpoint and, optionally, the SOL and VNC
+	// endpoints. Failed hops are reported in the response, not as errors.
+	DeepHealthCheck(context.Context, *connect.Request[v1.DeepHealthCheckRequest]) (*connect.Response[v1.DeepHealthCheckResponse], error)
+	// GetPowerReading returns the current power consumption of a server, as
+	// metered by its BMC (Redfish PowerControl)
+	GetPowerReading(context.Context, *connect.Request[v1.GetPowerReadingRequest]) (*connect.Response[v1.GetPowerReadingResponse], error)
+	// GetEnergyUsage returns the cumulative energy consumed by a server, for
+	// BMCs that meter energy (Redfish EnvironmentMetrics)
+	GetEnergyUsage(context.Context, *connect.Request[v1.GetEnergyUsageRequest]) (*connect.Response[v1.GetEnergyUsageResponse], error)
+	// GetAgentStatus returns registration details, BMC endpoint inventory and session activity
+	// for a Local Agent connected to this gateway (admin only)
+	GetAgentStatus(context.Context, *connect.Request[v1.GetAgentStatusRequest]) (*connect.Response[v1.GetAgentStatusResponse], error)
+	// ListAgents returns the status of every Local Agent registered with this gateway,
+	// with their BMC endpoints (admin only)
+	ListAgents(context.Context, *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error)
+	// ListActiveSessions returns the BMC console connections held open by a Local Agent.
+	// The gateway forwards the request to the agent; restricted to admin tokens.
+	ListActiveSessions(context.Context, *connect.Request[v1.ListActiveSessionsRequest]) (*connect.Response[v1.ListActiveSessionsResponse], error)
+	// GetGatewayStatus returns the registered agents with their link probes, the console
+	// session counts and the BMC endpoint mappings of this gateway (admin only)
+	GetGatewayStatus(context.Context, *connect.Request[v1.GetGatewayStatusRequest]) (*connect.Response[v1.GetGatewayStatusResponse], error)
+	// ProbeLink measures the gateway-agent link: the agent discards the payload and replies
+	// with response_size bytes. Gateways call it periodically on each registered agent to
+	// track round-trip time and throughput.
+	ProbeLink(context.Context, *connect.Request[v1.ProbeLinkRequest]) (*connect.Response[v1.ProbeLinkResponse], error)
+	// ListConsoleSessions returns the VNC and SOL sessions held by this gateway, with the
+	// customer that opened them and the streams currently attached. Admins list all
+	// sessions, other customers only their own.
+	ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error)
+	// TerminateConsoleSession closes a console session and disconnects its attached streams.
+	// Restricted to admin tokens.
+	TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error)
+	// RenewConsoleSession extends a console session, and the streams attached to it, which
+	// are warned shortly before their session expires. Requires a token of the session's server.
+	RenewConsoleSession(context.Context, *connect.Request[v1.RenewConsoleSessionRequest]) (*connect.Response[v1.RenewConsoleSessionResponse], error)
+	// SetLogLevel changes the log levels of the gateway at runtime, e.g. to enable debug logging
+	// during an incident without restarting and dropping console sessions. An empty request
+	// returns the current levels. Restricted to admin tokens.
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewGatewayServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -435,6 +977,12 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 		connect.WithSchema(gatewayServiceMethods.ByName("AgentHeartbeat")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceReportHostDriftHandler := connect.NewUnaryHandler(
+		GatewayServiceReportHostDriftProcedure,
+		svc.ReportHostDrift,
+		connect.WithSchema(gatewayServiceMethods.ByName("ReportHostDrift")),
+		connect.WithHandlerOptions(opts...),
+	)
 	gatewayServicePowerOnHandler := connect.NewUnaryHandler(
 		GatewayServicePowerOnProcedure,
 		svc.PowerOn,
@@ -447,6 +995,18 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 		connect.WithSchema(gatewayServiceMethods.ByName("PowerOff")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceGracefulShutdownHandler := connect.NewUnaryHandler(
+		GatewayServiceGracefulShutdownProcedure,
+		svc.GracefulShutdown,
+		connect.WithSchema(gatewayServiceMethods.ByName("GracefulShutdown")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceForceOffHandler := connect.NewUnaryHandler(
+		GatewayServiceForceOffProcedure,
+		svc.ForceOff,
+		connect.WithSchema(gatewayServiceMethods.ByName("ForceOff")),
+		connect.WithHandlerOptions(opts...),
+	)
 	gatewayServicePowerCycleHandler := connect.NewUnaryHandler(
 		GatewayServicePowerCycleProcedure,
 		svc.PowerCycle,
@@ -459,12 +1019,54 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 		connect.WithSchema(gatewayServiceMethods.ByName("Reset")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceDiagnosticInterruptHandler := connect.NewUnaryHandler(
+		GatewayServiceDiagnosticInterruptProcedure,
+		svc.DiagnosticInterrupt,
+		connect.WithSchema(gatewayServiceMethods.ByName("DiagnosticInterrupt")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceRunPowerOperationHandler := connect.NewServerStreamHandler(
+		GatewayServiceRunPowerOperationProcedure,
+		svc.RunPowerOperation,
+		connect.WithSchema(gatewayServiceMethods.ByName("RunPowerOperation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceStartPowerOperationHandler := connect.NewUnaryHandler(
+		GatewayServiceStartPowerOperationProcedure,
+		svc.StartPowerOperation,
+		connect.WithSchema(gatewayServiceMethods.ByName("StartPowerOperation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceGetOperationHandler := connect.NewUnaryHandler(
+		GatewayServiceGetOperationProcedure,
+		svc.GetOperation,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetOperation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceListOperationsHandler := connect.NewUnaryHandler(
+		GatewayServiceListOperationsProcedure,
+		svc.ListOperations,
+		connect.WithSchema(gatewayServiceMethods.ByName("ListOperations")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceWatchOperationHandler := connect.NewServerStreamHandler(
+		GatewayServiceWatchOperationProcedure,
+		svc.WatchOperation,
+		connect.WithSchema(gatewayServiceMethods.ByName("WatchOperation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	gatewayServiceGetPowerStatusHandler := connect.NewUnaryHandler(
 		GatewayServiceGetPowerStatusProcedure,
 		svc.GetPowerStatus,
 		connect.WithSchema(gatewayServiceMethods.ByName("GetPowerStatus")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceSetChassisIdentifyHandler := connect.NewUnaryHandler(
+		GatewayServiceSetChassisIdentifyProcedure,
+		svc.SetChassisIdentify,
+		connect.WithSchema(gatewayServiceMethods.ByName("SetChassisIdentify")),
+		connect.WithHandlerOptions(opts...),
+	)
 	gatewayServiceCreateVNCSessionHandler := connect.NewUnaryHandler(
 		GatewayServiceCreateVNCSessionProcedure,
 		svc.CreateVNCSession,
@@ -519,12 +1121,102 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 		connect.WithSchema(gatewayServiceMethods.ByName("StreamConsoleData")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceWatchConsoleDataHandler := connect.NewServerStreamHandler(
+		GatewayServiceWatchConsoleDataProcedure,
+		svc.WatchConsoleData,
+		connect.WithSchema(gatewayServiceMethods.ByName("WatchConsoleData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceSendConsoleDataHandler := connect.NewUnaryHandler(
+		GatewayServiceSendConsoleDataProcedure,
+		svc.SendConsoleData,
+		connect.WithSchema(gatewayServiceMethods.ByName("SendConsoleData")),
+		connect.WithHandlerOptions(opts...),
+	)
 	gatewayServiceGetBMCInfoHandler := connect.NewUnaryHandler(
 		GatewayServiceGetBMCInfoProcedure,
 		svc.GetBMCInfo,
 		connect.WithSchema(gatewayServiceMethods.ByName("GetBMCInfo")),
 		connect.WithHandlerOptions(opts...),
 	)
+	gatewayServiceGetBMCNetworkConfigHandler := connect.NewUnaryHandler(
+		GatewayServiceGetBMCNetworkConfigProcedure,
+		svc.GetBMCNetworkConfig,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetBMCNetworkConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceDeepHealthCheckHandler := connect.NewUnaryHandler(
+		GatewayServiceDeepHealthCheckProcedure,
+		svc.DeepHealthCheck,
+		connect.WithSchema(gatewayServiceMethods.ByName("DeepHealthCheck")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceGetPowerReadingHandler := connect.NewUnaryHandler(
+		GatewayServiceGetPowerReadingProcedure,
+		svc.GetPowerReading,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetPowerReading")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceGetEnergyUsageHandler := connect.NewUnaryHandler(
+		GatewayServiceGetEnergyUsageProcedure,
+		svc.GetEnergyUsage,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetEnergyUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceGetAgentStatusHandler := connect.NewUnaryHandler(
+		GatewayServiceGetAgentStatusProcedure,
+		svc.GetAgentStatus,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetAgentStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceListAgentsHandler := connect.NewUnaryHandler(
+		GatewayServiceListAgentsProcedure,
+		svc.ListAgents,
+		connect.WithSchema(gatewayServiceMethods.ByName("ListAgents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceListActiveSessionsHandler := connect.NewUnaryHandler(
+		GatewayServiceListActiveSessionsProcedure,
+		svc.ListActiveSessions,
+		connect.WithSchema(gatewayServiceMethods.ByName("ListActiveSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceGetGatewayStatusHandler := connect.NewUnaryHandler(
+		GatewayServiceGetGatewayStatusProcedure,
+		svc.GetGatewayStatus,
+		connect.WithSchema(gatewayServiceMethods.ByName("GetGatewayStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceProbeLinkHandler := connect.NewUnaryHandler(
+		GatewayServiceProbeLinkProcedure,
+		svc.ProbeLink,
+		connect.WithSchema(gatewayServiceMethods.ByName("ProbeLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceListConsoleSessionsHandler := connect.NewUnaryHandler(
+		GatewayServiceListConsoleSessionsProcedure,
+		svc.ListConsoleSessions,
+		connect.WithSchema(gatewayServiceMethods.ByName("ListConsoleSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceTerminateConsoleSessionHandler := connect.NewUnaryHandler(
+		GatewayServiceTerminateConsoleSessionProcedure,
+		svc.TerminateConsoleSession,
+		connect.WithSchema(gatewayServiceMethods.ByName("TerminateConsoleSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceRenewConsoleSessionHandler := connect.NewUnaryHandler(
+		GatewayServiceRenewConsoleSessionProcedure,
+		svc.RenewConsoleSession,
+		connect.WithSchema(gatewayServiceMethods.ByName("RenewConsoleSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceSetLogLevelHandler := connect.NewUnaryHandler(
+		GatewayServiceSetLogLevelProcedure,
+		svc.SetLogLevel,
+		connect.WithSchema(gatewayServiceMethods.ByName("SetLogLevel")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/gateway.v1.GatewayService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case GatewayServiceHealthCheckProcedure:
@@ -533,16 +1225,36 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 			gatewayServiceRegisterAgentHandler.ServeHTTP(w, r)
 		case GatewayServiceAgentHeartbeatProcedure:
 			gatewayServiceAgentHeartbeatHandler.ServeHTTP(w, r)
+		case GatewayServiceReportHostDriftProcedure:
+			gatewayServiceReportHostDriftHandler.ServeHTTP(w, r)
 		case GatewayServicePowerOnProcedure:
 			gatewayServicePowerOnHandler.ServeHTTP(w, r)
 		case GatewayServicePowerOffProcedure:
 			gatewayServicePowerOffHandler.ServeHTTP(w, r)
+		case GatewayServiceGracefulShutdownProcedure:
+			gatewayServiceGracefulShutdownHandler.ServeHTTP(w, r)
+		case GatewayServiceForceOffProcedure:
+			gatewayServiceForceOffHandler.ServeHTTP(w, r)
 		case GatewayServicePowerCycleProcedure:
 			gatewayServicePowerCycleHandler.ServeHTTP(w, r)
 		case GatewayServiceResetProcedure:
 			gatewayServiceResetHandler.ServeHTTP(w, r)
+		case GatewayServiceDiagnosticInterruptProcedure:
+			gatewayServiceDiagnosticInterruptHandler.ServeHTTP(w, r)
+		case GatewayServiceRunPowerOperationProcedure:
+			gatewayServiceRunPowerOperationHandler.ServeHTTP(w, r)
+		case GatewayServiceStartPowerOperationProcedure:
+			gatewayServiceStartPowerOperationHandler.ServeHTTP(w, r)
+		case GatewayServiceGetOperationProcedure:
+			gatewayServiceGetOperationHandler.ServeHTTP(w, r)
+		case GatewayServiceListOperationsProcedure:
+			gatewayServiceListOperationsHandler.ServeHTTP(w, r)
+		case GatewayServiceWatchOperationProcedure:
+			gatewayServiceWatchOperationHandler.ServeHTTP(w, r)
 		case GatewayServiceGetPowerStatusProcedure:
 			gatewayServiceGetPowerStatusHandler.ServeHTTP(w, r)
+		case GatewayServiceSetChassisIdentifyProcedure:
+			gatewayServiceSetChassisIdentifyHandler.ServeHTTP(w, r)
 		case GatewayServiceCreateVNCSessionProcedure:
 			gatewayServiceCreateVNCSessionHandler.ServeHTTP(w, r)
 		case GatewayServiceGetVNCSessionProcedure:
@@ -561,8 +1273,38 @@ func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.Handler
 			gatewayServiceStreamVNCDataHandler.ServeHTTP(w, r)
 		case GatewayServiceStreamConsoleDataProcedure:
 			gatewayServiceStreamConsoleDataHandler.ServeHTTP(w, r)
+		case GatewayServiceWatchConsoleDataProcedure:
+			gatewayServiceWatchConsoleDataHandler.ServeHTTP(w, r)
+		case GatewayServiceSendConsoleDataProcedure:
+			gatewayServiceSendConsoleDataHandler.ServeHTTP(w, r)
 		case GatewayServiceGetBMCInfoProcedure:
 			gatewayServiceGetBMCInfoHandler.ServeHTTP(w, r)
+		case GatewayServiceGetBMCNetworkConfigProcedure:
+			gatewayServiceGetBMCNetworkConfigHandler.ServeHTTP(w, r)
+		case GatewayServiceDeepHealthCheckProcedure:
+			gatewayServiceDeepHealthCheckHandler.ServeHTTP(w, r)
+		case GatewayServiceGetPowerReadingProcedure:
+			gatewayServiceGetPowerReadingHandler.ServeHTTP(w, r)
+		case GatewayServiceGetEnergyUsageProcedure:
+			gatewayServiceGetEnergyUsageHandler.ServeHTTP(w, r)
+		case GatewayServiceGetAgentStatusProcedure:
+			gatewayServiceGetAgentStatusHandler.ServeHTTP(w, r)
+		case GatewayServiceListAgentsProcedure:
+			gatewayServiceListAgentsHandler.ServeHTTP(w, r)
+		case GatewayServiceListActiveSessionsProcedure:
+			gatewayServiceListActiveSessionsHandler.ServeHTTP(w, r)
+		case GatewayServiceGetGatewayStatusProcedure:
+			gatewayServiceGetGatewayStatusHandler.ServeHTTP(w, r)
+		case GatewayServiceProbeLinkProcedure:
+			gatewayServiceProbeLinkHandler.ServeHTTP(w, r)
+		case GatewayServiceListConsoleSessionsProcedure:
+			gatewayServiceListConsoleSessionsHandler.ServeHTTP(w, r)
+		case GatewayServiceTerminateConsoleSessionProcedure:
+			gatewayServiceTerminateConsoleSessionHandler.ServeHTTP(w, r)
+		case GatewayServiceRenewConsoleSessionProcedure:
+			gatewayServiceRenewConsoleSessionHandler.ServeHTTP(w, r)
+		case GatewayServiceSetLogLevelProcedure:
+			gatewayServiceSetLogLevelHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -584,6 +1326,10 @@ func (UnimplementedGatewayServiceHandler) AgentHeartbeat(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.AgentHeartbeat is not implemented"))
 }
 
+func (UnimplementedGatewayServiceHandler) ReportHostDrift(context.Context, *connect.Request[v1.ReportHostDriftRequest]) (*connect.Response[v1.ReportHostDriftResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ReportHostDrift is not implemented"))
+}
+
 func (UnimplementedGatewayServiceHandler) PowerOn(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.PowerOn is not implemented"))
 }
@@ -592,6 +1338,14 @@ func (UnimplementedGatewayServiceHandler) PowerOff(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.PowerOff is not implemented"))
 }
 
+func (UnimplementedGatewayServiceHandler) GracefulShutdown(context.Context, *connect.Request[v1.GracefulShutdownRequest]) (*connect.Response[v1.GracefulShutdownResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GracefulShutdown is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ForceOff(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ForceOff is not implemented"))
+}
+
 func (UnimplementedGatewayServiceHandler) PowerCycle(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.PowerCycle is not implemented"))
 }
@@ -600,10 +1354,38 @@ func (UnimplementedGatewayServiceHandler) Reset(context.Context, *connect.Reques
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.Reset is not implemented"))
 }
 
+func (UnimplementedGatewayServiceHandler) DiagnosticInterrupt(context.Context, *connect.Request[v1.PowerOperationRequest]) (*connect.Response[v1.PowerOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.DiagnosticInterrupt is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) RunPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest], *connect.ServerStream[v1.PowerOperationProgress]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.RunPowerOperation is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) StartPowerOperation(context.Context, *connect.Request[v1.RunPowerOperationRequest]) (*connect.Response[v1.StartPowerOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.StartPowerOperation is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) GetOperation(context.Context, *connect.Request[v1.GetOperationRequest]) (*connect.Response[v1.GetOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetOperation is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ListOperations(context.Context, *connect.Request[v1.ListOperationsRequest]) (*connect.Response[v1.ListOperationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ListOperations is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) WatchOperation(context.Context, *connect.Request[v1.GetOperationRequest], *connect.ServerStream[v1.Operation]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.WatchOperation is not implemented"))
+}
+
 func (UnimplementedGatewayServiceHandler) GetPowerStatus(context.Context, *connect.Request[v1.PowerStatusRequest]) (*connect.Response[v1.PowerStatusResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetPowerStatus is not implemented"))
 }
 
+func (UnimplementedGatewayServiceHandler) SetChassisIdentify(context.Context, *connect.Request[v1.SetChassisIdentifyRequest]) (*connect.Response[v1.SetChassisIdentifyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.SetChassisIdentify is not implemented"))
+}
+
 func (UnimplementedGatewayServiceHandler) CreateVNCSession(context.Context, *connect.Request[v1.CreateVNCSessionRequest]) (*connect.Response[v1.CreateVNCSessionResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.CreateVNCSession is not implemented"))
 }
@@ -640,6 +1422,66 @@ func (UnimplementedGatewayServiceHandler) StreamConsoleData(context.Context, *co
 	return connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.StreamConsoleData is not implemented"))
 }
 
+func (UnimplementedGatewayServiceHandler) WatchConsoleData(context.Context, *connect.Request[v1.ConsoleDataChunk], *connect.ServerStream[v1.ConsoleDataChunk]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.WatchConsoleData is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) SendConsoleData(context.Context, *connect.Request[v1.SendConsoleDataRequest]) (*connect.Response[v1.SendConsoleDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.SendConsoleData is not implemented"))
+}
+
 func (UnimplementedGatewayServiceHandler) GetBMCInfo(context.Context, *connect.Request[v1.GetBMCInfoRequest]) (*connect.Response[v1.GetBMCInfoResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetBMCInfo is not implemented"))
 }
+
+func (UnimplementedGatewayServiceHandler) GetBMCNetworkConfig(context.Context, *connect.Request[v1.GetBMCNetworkConfigRequest]) (*connect.Response[v1.GetBMCNetworkConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetBMCNetworkConfig is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) DeepHealthCheck(context.Context, *connect.Request[v1.DeepHealthCheckRequest]) (*connect.Response[v1.DeepHealthCheckResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.DeepHealthCheck is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) GetPowerReading(context.Context, *connect.Request[v1.GetPowerReadingRequest]) (*connect.Response[v1.GetPowerReadingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetPowerReading is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) GetEnergyUsage(context.Context, *connect.Request[v1.GetEnergyUsageRequest]) (*connect.Response[v1.GetEnergyUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetEnergyUsage is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) GetAgentStatus(context.Context, *connect.Request[v1.GetAgentStatusRequest]) (*connect.Response[v1.GetAgentStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetAgentStatus is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ListAgents(context.Context, *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ListAgents is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ListActiveSessions(context.Context, *connect.Request[v1.ListActiveSessionsRequest]) (*connect.Response[v1.ListActiveSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ListActiveSessions is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) GetGatewayStatus(context.Context, *connect.Request[v1.GetGatewayStatusRequest]) (*connect.Response[v1.GetGatewayStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.GetGatewayStatus is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ProbeLink(context.Context, *connect.Request[v1.ProbeLinkRequest]) (*connect.Response[v1.ProbeLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ProbeLink is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.ListConsoleSessions is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.TerminateConsoleSession is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) RenewConsoleSession(context.Context, *connect.Request[v1.RenewConsoleSessionRequest]) (*connect.Response[v1.RenewConsoleSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.RenewConsoleSession is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gateway.v1.GatewayService.SetLogLevel is not implemented"))
+}
@@ -0,0 +1,232 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+
+	"core/tracing"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+)
+
+// Transports of the connections to agents, as reported to the observer
+const (
+	TransportUnary  = "unary"  // HTTP/1.1 keep-alive connections of unary calls
+	TransportStream = "stream" // Multiplexed h2c connections of streams
+)
+
+// ClientPoolConfig configures a ClientPool
+type ClientPoolConfig struct {
+	MaxConns       int           // Connections open to each agent for unary calls, at most
+	DialTimeout    time.Duration // Timeout to establish a connection to an agent
+	IdleTimeout    time.Duration // Idle connections, and clients of agents unused for as long, are closed
+	RequestTimeout time.Duration // Timeout of unary calls; streams end with their context
+}
+
+// DefaultClientPoolConfig returns the configuration of a pool matching the
+// gateway defaults
+func DefaultClientPoolConfig() ClientPoolConfig {
+	return ClientPoolConfig{
+		MaxConns:       100,
+		DialTimeout:    30 * time.Second,
+		IdleTimeout:    90 * time.Second,
+		RequestTimeout: 30 * time.Second,
+	}
+}
+
+// ClientPool caches the RPC clients of agents, so that calls and streams to
+// an agent reuse established connections instead of dialing one each.
+//
+// Unary calls go over HTTP/1.1 keep-alive connections. Streams go over h2c,
+// which VNC and SOL need for bidirectional streaming, and are multiplexed
+// over a single connection per agent.
+type ClientPool struct {
+	config          ClientPoolConfig
+	transport       *http.Transport
+	streamTransport *http2.Transport
+	unaryClient     *http.Client
+	streamClient    *http.Client
+
+	// Receives every connection used for a call, e.g. for metrics
+	observe func(transport string, reused bool)
+
+	mu      sync.Mutex
+	clients map[string]*pooledClients // agent endpoint -> clients
+}
+
+// pooledClients are the RPC clients of an agent
+type pooledClients struct {
+	unary    gatewayv1connect.GatewayServiceClient
+	stream   gatewayv1connect.GatewayServiceClient
+	lastUsed time.Time
+}
+
+// NewClientPool creates an empty pool
+func NewClientPool(config ClientPoolConfig) *ClientPool {
+	dialer := &net.Dialer{Timeout: config.DialTimeout, KeepAlive: 30 * time.Second}
+
+	p := &ClientPool{
+		config: config,
+		transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         dialer.DialContext,
+			MaxConnsPerHost:     config.MaxConns,
+			MaxIdleConnsPerHost: config.MaxConns,
+			IdleConnTimeout:     config.IdleTimeout,
+		},
+		streamTransport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+				// Plain connection for h2c (HTTP/2 without TLS)
+				return dialer.DialContext(ctx, network, addr)
+			},
+			IdleConnTimeout: config.IdleTimeout,
+			// Ping connections without traffic, so that streams don't start
+			// on a connection the network silently dropped
+			ReadIdleTimeout: 30 * time.Second,
+			PingTimeout:     15 * time.Second,
+		},
+		clients: make(map[string]*pooledClients),
+	}
+	p.unaryClient = &http.Client{
+		Transport: &observedTransport{next: p.transport, pool: p, transport: TransportUnary},
+		Timeout:   config.RequestTimeout,
+	}
+	p.streamClient = &http.Client{
+		Transport: &observedTransport{next: p.streamTransport, pool: p, transport: TransportStream},
+	}
+	return p
+}
+
+// SetObserver sets the function receiving every connection used for a call,
+// with whether it was reused
+func (p *ClientPool) SetObserver(observe func(transport string, reused bool)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.observe = observe
+}
+
+// Client returns the client of unary calls to the agent at endpoint
+func (p *ClientPool) Client(endpoint string) gatewayv1connect.GatewayServiceClient {
+	return p.get(endpoint).unary
+}
+
+// StreamClient returns the client of streams to the agent at endpoint. Its
+// calls have no timeout, as console streams and power operation tasks last
+// for minutes: they end with their context.
+func (p *ClientPool) StreamClient(endpoint string) gatewayv1connect.GatewayServiceClient {
+	return p.get(endpoint).stream
+}
+
+// get returns the clients of endpoint, creating them on first use
+func (p *ClientPool) get(endpoint string) *pooledClients {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clients, exists := p.clients[endpoint]
+	if !exists {
+		clients = &pooledClients{
+			unary: gatewayv1connect.NewGatewayServiceClient(p.unaryClient, endpoint,
+				connect.WithInterceptors(tracing.NewInterceptor())),
+			stream: gatewayv1connect.NewGatewayServiceClient(p.streamClient, endpoint,
+				connect.WithInterceptors(tracing.NewInterceptor())),
+		}
+		p.clients[endpoint] = clients
+	}
+	clients.lastUsed = time.Now()
+	return clients
+}
+
+// Len returns the number of agents with cached clients
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// Sweep forgets the clients of agents unused since the idle timeout and
+// closes idle connections, returning the number of agents forgotten
+func (p *ClientPool) Sweep(now time.Time) int {
+	p.mu.Lock()
+	removed := 0
+	for endpoint, clients := range p.clients {
+		if now.Sub(clients.lastUsed) > p.config.IdleTimeout {
+			delete(p.clients, endpoint)
+			removed++
+		}
+	}
+	p.mu.Unlock()
+
+	if removed > 0 {
+		p.CloseIdleConnections()
+	}
+	return removed
+}
+
+// CloseIdleConnections closes the connections not used by a call
+func (p *ClientPool) CloseIdleConnections() {
+	p.transport.CloseIdleConnections()
+	p.streamTransport.CloseIdleConnections()
+}
+
+// Start sweeps the pool every idle timeout until ctx ends, then closes its
+// idle connections
+func (p *ClientPool) Start(ctx context.Context) {
+	if p.config.IdleTimeout <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.config.IdleTimeout)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				p.CloseIdleConnections()
+				return
+			case now := <-ticker.C:
+				p.Sweep(now)
+			}
+		}
+	}()
+}
+
+// connectionObserver returns the observer, nil if unset
+func (p *ClientPool) connectionObserver() func(transport string, reused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.observe
+}
+
+// observedTransport reports the connections used by its requests to the
+// observer of the pool
+type observedTransport struct {
+	next      http.RoundTripper
+	pool      *ClientPool
+	transport string
+}
+
+func (t *observedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	observe := t.pool.connectionObserver()
+	if observe == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			observe(t.transport, info.Reused)
+		},
+	}
+	return t.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
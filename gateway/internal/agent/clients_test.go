@@ -0,0 +1,155 @@
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+)
+
+// healthyAgent is an agent RPC server answering health checks
+type healthyAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+}
+
+func (a *healthyAgent) HealthCheck(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.HealthCheckRequest],
+) (*connect.Response[gatewayv1.HealthCheckResponse], error) {
+	return connect.NewResponse(&gatewayv1.HealthCheckResponse{Status: "ok"}), nil
+}
+
+// newAgentServer serves a healthy agent over HTTP/1.1 and h2c, like agents do
+func newAgentServer(t *testing.T) *httptest.Server {
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(&healthyAgent{})
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// connectionRecorder records the connections reported by a pool
+type connectionRecorder struct {
+	mu          sync.Mutex
+	connections []string
+}
+
+func (r *connectionRecorder) observe(transport string, reused bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state := "new"
+	if reused {
+		state = "reused"
+	}
+	r.connections = append(r.connections, transport+" "+state)
+}
+
+func (r *connectionRecorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.connections...)
+}
+
+func TestClientPool_ReusesConnections(t *testing.T) {
+	server := newAgentServer(t)
+
+	pool := NewClientPool(DefaultClientPoolConfig())
+	defer pool.CloseIdleConnections()
+	recorder := &connectionRecorder{}
+	pool.SetObserver(recorder.observe)
+
+	for range 2 {
+		if _, err := pool.Client(server.URL).HealthCheck(context.Background(), connect.NewRequest(&gatewayv1.HealthCheckRequest{})); err != nil {
+			t.Fatalf("Unary call failed: %v", err)
+		}
+	}
+	for range 2 {
+		if _, err := pool.StreamClient(server.URL).HealthCheck(context.Background(), connect.NewRequest(&gatewayv1.HealthCheckRequest{})); err != nil {
+			t.Fatalf("Stream client call failed: %v", err)
+		}
+	}
+
+	expected := []string{"unary new", "unary reused", "stream new", "stream reused"}
+	connections := recorder.recorded()
+	if len(connections) != len(expected) {
+		t.Fatalf("Expected connections %v, got %v", expected, connections)
+	}
+	for i := range expected {
+		if connections[i] != expected[i] {
+			t.Errorf("Connection %d: expected %q, got %q", i, expected[i], connections[i])
+		}
+	}
+
+	if pool.Len() != 1 {
+		t.Errorf("Expected clients of 1 agent, got %d", pool.Len())
+	}
+}
+
+func TestClientPool_CachesClients(t *testing.T) {
+	pool := NewClientPool(DefaultClientPoolConfig())
+
+	if pool.Client("http://agent-1:8080") != pool.Client("http://agent-1:8080") {
+		t.Error("Expected the same unary client for an agent")
+	}
+	if pool.StreamClient("http://agent-1:8080") != pool.StreamClient("http://agent-1:8080") {
+		t.Error("Expected the same stream client for an agent")
+	}
+	if pool.Client("http://agent-1:8080") == pool.Client("http://agent-2:8080") {
+		t.Error("Expected distinct clients for distinct agents")
+	}
+}
+
+func TestClientPool_Sweep(t *testing.T) {
+	config := DefaultClientPoolConfig()
+	config.IdleTimeout = time.Minute
+	pool := NewClientPool(config)
+
+	pool.Client("http://agent-1:8080")
+	pool.StreamClient("http://agent-2:8080")
+
+	if removed := pool.Sweep(time.Now()); removed != 0 {
+		t.Errorf("Expected recently used clients to be kept, %d removed", removed)
+	}
+
+	pool.Client("http://agent-2:8080")
+	pool.mu.Lock()
+	pool.clients["http://agent-1:8080"].lastUsed = time.Now().Add(-2 * time.Minute)
+	pool.mu.Unlock()
+
+	if removed := pool.Sweep(time.Now()); removed != 1 {
+		t.Errorf("Expected 1 idle agent removed, got %d", removed)
+	}
+	if pool.Len() != 1 {
+		t.Errorf("Expected clients of 1 agent, got %d", pool.Len())
+	}
+}
+
+func TestClientPool_RequestTimeout(t *testing.T) {
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer server.Close()
+	defer close(blocked)
+
+	config := DefaultClientPoolConfig()
+	config.RequestTimeout = 50 * time.Millisecond
+	pool := NewClientPool(config)
+
+	_, err := pool.Client(server.URL).HealthCheck(context.Background(), connect.NewRequest(&gatewayv1.HealthCheckRequest{}))
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Errorf("Expected the unary call to time out, got %v", err)
+	}
+}
@@ -10,6 +10,8 @@ type Info struct {
 	ID           string
 	DatacenterID string
 	Endpoint     string
+	Version      string
+	RegisteredAt time.Time
 	LastSeen     time.Time
 	Status       string
 }
@@ -27,21 +29,34 @@ func NewRegistry() *Registry {
 	}
 }
 
-// Register adds or updates an agent in the registry
+// Register adds or updates an agent in the registry.
+// Re-registrations keep the original registration time.
 func (r *Registry) Register(info *Info) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if existing, exists := r.agents[info.ID]; exists && !existing.RegisteredAt.IsZero() {
+		info.RegisteredAt = existing.RegisteredAt
+	} else if info.RegisteredAt.IsZero() {
+		info.RegisteredAt = time.Now()
+	}
+
 	info.Status = "active"
 	r.agents[info.ID] = info
 }
 
-// Get retrieves agent information by ID
+// Get retrieves agent information by ID. It returns a copy, like List, since
+// heartbeats update the registered agent concurrently.
 func (r *Registry) Get(agentID string) *Info {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return r.agents[agentID]
+	agent, exists := r.agents[agentID]
+	if !exists {
+		return nil
+	}
+	agentCopy := *agent
+	return &agentCopy
 }
 
 // UpdateLastSeen updates the last seen timestamp for an agent
@@ -51,6 +51,40 @@ func TestRegistry_Register(t *testing.T) {
 	}
 }
 
+func TestRegistry_RegisterPreservesRegisteredAt(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.Register(&Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Version:      "v1.0.0",
+		LastSeen:     time.Now(),
+	})
+
+	first := registry.Get("agent-1")
+	if first.RegisteredAt.IsZero() {
+		t.Fatal("Expected RegisteredAt to be set on first registration")
+	}
+	registeredAt := first.RegisteredAt
+
+	// Re-registration replaces the entry but keeps the original registration time
+	registry.Register(&Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Version:      "v1.1.0",
+		LastSeen:     time.Now().Add(time.Minute),
+	})
+
+	retrieved := registry.Get("agent-1")
+	if !retrieved.RegisteredAt.Equal(registeredAt) {
+		t.Errorf("Expected RegisteredAt %v to be preserved, got %v", registeredAt, retrieved.RegisteredAt)
+	}
+
+	if retrieved.Version != "v1.1.0" {
+		t.Errorf("Expected version 'v1.1.0', got '%s'", retrieved.Version)
+	}
+}
+
 func TestRegistry_Get(t *testing.T) {
 	registry := NewRegistry()
 
@@ -78,6 +112,13 @@ func TestRegistry_Get(t *testing.T) {
 	if retrieved.ID != info.ID {
 		t.Errorf("Expected ID '%s', got '%s'", info.ID, retrieved.ID)
 	}
+
+	// Later updates do not change the returned snapshot
+	lastSeen := retrieved.LastSeen
+	registry.UpdateLastSeen("agent-1", lastSeen.Add(time.Minute))
+	if !retrieved.LastSeen.Equal(lastSeen) {
+		t.Error("Expected Get to return a copy of the agent")
+	}
 }
 
 func TestRegistry_UpdateLastSeen(t *testing.T) {
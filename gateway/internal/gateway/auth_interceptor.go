@@ -17,9 +17,10 @@ import (
 // FLOW:
 // 1. Browser sends request with session cookie (set during CreateSOLSession/CreateVNCSession)
 // 2. Interceptor extracts session ID from cookie
-// 3. Interceptor looks up web session to get JWT
-// 4. Interceptor adds JWT to context with key "token"
-// 5. RPC handlers (PowerOn, PowerOff, etc.) extract token from context
+// 3. Interceptor checks the X-CSRF-Token header rendered into the viewer page
+// 4. Interceptor looks up web session to get JWT
+// 5. Interceptor adds JWT to context with key "token"
+// 6. RPC handlers (PowerOn, PowerOff, etc.) extract token from context
 //
 // This allows web consoles to use the same RPC handlers as the CLI,
 // but authenticate via session cookies instead of Authorization headers.
@@ -40,7 +41,7 @@ func NewAuthInterceptor(handler *RegionalGatewayHandler) *AuthInterceptor {
 func (i *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 		// Extract JWT from either Authorization header or session cookie
-		jwt := i.extractJWT(ctx, req)
+		jwt := i.extractJWT(ctx, req.Header(), req.Spec().Procedure)
 
 		// Add JWT to context if found
 		if jwt != "" {
@@ -57,15 +58,22 @@ func (i *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc)
 	return next // No special handling needed for streaming
 }
 
-// WrapStreamingHandler implements connect.Interceptor for server streaming
+// WrapStreamingHandler implements connect.Interceptor for server streaming.
+// Console streams authenticate with their session, but handlers of other
+// streams (RunPowerOperation) extract the token like unary handlers.
 func (i *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
-	return next // No special handling needed for streaming
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if jwt := i.extractJWT(ctx, conn.RequestHeader(), conn.Spec().Procedure); jwt != "" {
+			ctx = context.WithValue(ctx, "token", jwt)
+		}
+		return next(ctx, conn)
+	}
 }
 
 // extractJWT extracts JWT from Authorization header or session cookie
-func (i *AuthInterceptor) extractJWT(ctx context.Context, req connect.AnyRequest) string {
+func (i *AuthInterceptor) extractJWT(ctx context.Context, header http.Header, procedure string) string {
 	// First try Authorization header (for CLI/API calls)
-	authHeader := req.Header().Get("Authorization")
+	authHeader := header.Get("Authorization")
 	if authHeader != "" {
 		jwt, err := coreauth.ExtractJWTFromAuthHeader(authHeader)
 		if err == nil && jwt != "" {
@@ -108,6 +116,16 @@ func (i *AuthInterceptor) extractJWT(ctx context.Context, req connect.AnyRequest
 		return ""
 	}
 
+	// Browsers attach the cookie to cross-site requests too, so only requests
+	// carrying the session's CSRF token are authenticated with it
+	if !i.handler.csrf.Valid(cookie.Value, header.Get(session.CSRFHeaderName)) {
+		log.Warn().
+			Str("procedure", procedure).
+			Str("origin", httpReqPtr.Header.Get("Origin")).
+			Msg("Rejected session cookie without a valid CSRF token")
+		return ""
+	}
+
 	// Look up web session
 	sessionStore := i.handler.GetWebSessionStore()
 	webSession, err := sessionStore.Get(cookie.Value)
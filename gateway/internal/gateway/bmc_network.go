@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// GetBMCNetworkConfig proxies a read of the network configuration of a
+// server's BMC to its agent. It is authorized like BMC info, with
+// power:read.
+func (h *RegionalGatewayHandler) GetBMCNetworkConfig(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetBMCNetworkConfigRequest],
+) (*connect.Response[gatewayv1.GetBMCNetworkConfigResponse], error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:read") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for BMC network configuration"))
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
+	}
+
+	log.Info().
+		Str("server_id", serverContext.ServerID).
+		Str("bmc_endpoint", serverContext.BMCEndpoint).
+		Str("agent_id", mapping.AgentID).
+		Msg("Proxying BMC network configuration request to agent")
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+
+	resp, err := agentClient.GetBMCNetworkConfig(ctx, connect.NewRequest(&gatewayv1.GetBMCNetworkConfigRequest{
+		ServerId: mapping.ServerID,
+	}))
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("bmc_endpoint", serverContext.BMCEndpoint).
+			Str("agent_id", mapping.AgentID).
+			Msg("BMC network configuration request failed")
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// Browsers cannot stream request bodies, so their Connect and gRPC-Web
+// clients split a console stream in two: WatchConsoleData streams the
+// console's chunks to the browser, and SendConsoleData calls send the
+// browser's chunks to the stream.
+
+// browserInputQueue is the number of chunks of a browser stream queued for
+// its agent. SendConsoleData waits while the queue is full.
+const browserInputQueue = 64
+
+// browserConsoleStream is the client end of a WatchConsoleData stream,
+// receiving the chunks of SendConsoleData calls
+type browserConsoleStream struct {
+	ctx       context.Context // Ends with the WatchConsoleData call
+	sessionID string
+	stream    *connect.ServerStream[gatewayv1.ConsoleDataChunk]
+	input     chan *gatewayv1.ConsoleDataChunk
+}
+
+// Receive returns the next chunk sent by the browser, io.EOF once the
+// browser stopped watching the console
+func (s *browserConsoleStream) Receive() (*gatewayv1.ConsoleDataChunk, error) {
+	select {
+	case chunk := <-s.input:
+		return chunk, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+// Send streams a chunk to the browser
+func (s *browserConsoleStream) Send(chunk *gatewayv1.ConsoleDataChunk) error {
+	return s.stream.Send(chunk)
+}
+
+// newBrowserStreamID returns an unguessable ID of a browser console stream
+func newBrowserStreamID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate stream ID: %w", err)
+	}
+	return "stream-" + hex.EncodeToString(b), nil
+}
+
+// authorizeBrowserStream checks that the token of a request is one of the
+// server and customer of a console session
+func (h *RegionalGatewayHandler) authorizeBrowserStream(ctx context.Context, sessionID string) error {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	session, exists := h.GetConsoleSessionByID(sessionID)
+	if !exists {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", sessionID))
+	}
+	if session.ServerID != serverContext.ServerID || session.CustomerID != serverContext.CustomerID {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("access denied"))
+	}
+	return nil
+}
+
+// WatchConsoleData streams a console to a browser. The request is the
+// stream's handshake, and the handshake ack gives the stream ID that the
+// browser's SendConsoleData calls send to. Requires a token of the session's
+// server.
+func (h *RegionalGatewayHandler) WatchConsoleData(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ConsoleDataChunk],
+	stream *connect.ServerStream[gatewayv1.ConsoleDataChunk],
+) error {
+	handshake := req.Msg
+	if !handshake.IsHandshake {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected handshake chunk, got data chunk"))
+	}
+	if err := h.authorizeBrowserStream(ctx, handshake.SessionId); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("session_id", handshake.SessionId).
+		Str("protocol", req.Peer().Protocol).
+		Msg("New browser console streaming connection")
+
+	streamID, err := newBrowserStreamID()
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	client := &browserConsoleStream{
+		ctx:       ctx,
+		sessionID: handshake.SessionId,
+		stream:    stream,
+		input:     make(chan *gatewayv1.ConsoleDataChunk, browserInputQueue),
+	}
+
+	h.mu.Lock()
+	h.browserStreams[streamID] = client
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.browserStreams, streamID)
+		h.mu.Unlock()
+	}()
+
+	sendAck := func(metadata map[string]string) error {
+		return stream.Send(&gatewayv1.ConsoleDataChunk{
+			SessionId:   handshake.SessionId,
+			ServerId:    handshake.ServerId,
+			IsHandshake: true,
+			Metadata:    metadata,
+			StreamId:    streamID,
+		})
+	}
+	return h.streamConsole(ctx, client, handshake, StreamTransportBrowserConnect, req.Peer().Addr, sendAck)
+}
+
+// SendConsoleData sends the chunks of a browser to its WatchConsoleData
+// stream. Requires a token of the session's server.
+func (h *RegionalGatewayHandler) SendConsoleData(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SendConsoleDataRequest],
+) (*connect.Response[gatewayv1.SendConsoleDataResponse], error) {
+	if _, err := h.extractServerContextFromJWT(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	h.mu.RLock()
+	client, exists := h.browserStreams[req.Msg.StreamId]
+	h.mu.RUnlock()
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console stream not found: %s", req.Msg.StreamId))
+	}
+	if err := h.authorizeBrowserStream(ctx, client.sessionID); err != nil {
+		return nil, err
+	}
+
+	for _, chunk := range req.Msg.Chunks {
+		if chunk.IsHandshake {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("console stream already established"))
+		}
+		select {
+		case client.input <- chunk:
+		case <-client.ctx.Done():
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console stream ended: %s", req.Msg.StreamId))
+		case <-ctx.Done():
+			return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+		}
+	}
+
+	return connect.NewResponse(&gatewayv1.SendConsoleDataResponse{}), nil
+}
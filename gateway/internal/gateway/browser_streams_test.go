@@ -0,0 +1,329 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"core/domain"
+	"core/streaming"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/agent"
+	managermodels "manager/pkg/models"
+)
+
+// echoConsoleAgent is an agent RPC server whose console echoes the data it
+// receives, rendered for a vt100 terminal. It checksums the chunks of the
+// gateways advertising checksums.
+type echoConsoleAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	resizes chan *gatewayv1.TerminalSize
+
+	checksums chan uint32 // Checksums of the data received, may be nil
+	corrupt   bool        // Echoes data that fails its checksum
+}
+
+func (a *echoConsoleAgent) StreamConsoleData(
+	ctx context.Context,
+	stream *connect.BidiStream[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk],
+) error {
+	handshake, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	ack := &gatewayv1.ConsoleDataChunk{SessionId: handshake.SessionId, ServerId: handshake.ServerId, IsHandshake: true}
+	if handshake.Metadata[streaming.MetadataTerminalType] != "" {
+		ack.Metadata = map[string]string{streaming.MetadataTerminalType: "vt100"}
+	}
+	checksums := streaming.CapabilitiesOf(handshake.Metadata).Has(streaming.FeatureChecksums)
+	if checksums {
+		ack.Metadata = streaming.Capabilities{Features: []streaming.Feature{streaming.FeatureChecksums}}.AddTo(ack.Metadata)
+	}
+	if err := stream.Send(ack); err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Receive()
+		if errors.Is(err, io.EOF) || (err == nil && chunk.CloseStream) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if chunk.Resize != nil {
+			a.resizes <- chunk.Resize
+		}
+		if chunk.Checksum != nil && a.checksums != nil {
+			a.checksums <- *chunk.Checksum
+		}
+		if len(chunk.Data) > 0 {
+			output := &gatewayv1.ConsoleDataChunk{Data: bytes.ToUpper(chunk.Data)}
+			if checksums {
+				checksum := streaming.Checksum(output.Data)
+				if a.corrupt {
+					checksum ^= 1
+				}
+				output.Checksum = &checksum
+			}
+			if err := stream.Send(output); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// newBrowserConsoleGateway serves a gateway, with its authentication, in
+// front of an echoing console agent, and creates a SOL session of the BMC
+// 192.168.1.100:623. The returned client speaks gRPC-Web like a browser.
+func newBrowserConsoleGateway(t *testing.T, agentService *echoConsoleAgent) (*RegionalGatewayHandler, gatewayv1connect.GatewayServiceClient, string) {
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	agentMux := http.NewServeMux()
+	agentMux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(h2c.NewHandler(agentMux, &http2.Server{}))
+	t.Cleanup(agentServer.Close)
+
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	path, rpcHandler = gatewayv1connect.NewGatewayServiceHandler(handler,
+		connect.WithInterceptors(NewAuthInterceptor(handler), handler.TokenValidationInterceptor()))
+	gatewayMux := http.NewServeMux()
+	gatewayMux.Handle(path, rpcHandler)
+	gatewayServer := httptest.NewServer(gatewayMux)
+	t.Cleanup(gatewayServer.Close)
+
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+	session, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+		ServerId: "192.168.1.100:623",
+	}))
+	require.NoError(t, err)
+
+	client := gatewayv1connect.NewGatewayServiceClient(http.DefaultClient, gatewayServer.URL, connect.WithGRPCWeb())
+	return handler, client, session.Msg.SessionId
+}
+
+// newWatchConsoleDataRequest creates a request authenticated with a token of
+// the console's server
+func newWatchConsoleDataRequest(handshake *gatewayv1.ConsoleDataChunk) *connect.Request[gatewayv1.ConsoleDataChunk] {
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+	req := connect.NewRequest(handshake)
+	req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+	return req
+}
+
+// newSendConsoleDataRequest creates a request authenticated with a token of
+// the console's server
+func newSendConsoleDataRequest(streamID string, chunks ...*gatewayv1.ConsoleDataChunk) *connect.Request[gatewayv1.SendConsoleDataRequest] {
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+	req := connect.NewRequest(&gatewayv1.SendConsoleDataRequest{StreamId: streamID, Chunks: chunks})
+	req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+	return req
+}
+
+func TestWatchConsoleData(t *testing.T) {
+	agentService := &echoConsoleAgent{resizes: make(chan *gatewayv1.TerminalSize, 1)}
+	handler, client, sessionID := newBrowserConsoleGateway(t, agentService)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{
+		SessionId:   sessionID,
+		ServerId:    "192.168.1.100:623",
+		IsHandshake: true,
+		Metadata: map[string]string{
+			streaming.MetadataTerminalType: "xterm-256color",
+			streaming.MetadataTerminalCols: "80",
+			streaming.MetadataTerminalRows: "24",
+		},
+	}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.True(t, stream.Receive(), "expected a handshake ack: %v", stream.Err())
+	ack := stream.Msg()
+	require.True(t, ack.IsHandshake)
+	require.NotEmpty(t, ack.StreamId)
+	require.Equal(t, "vt100", ack.Metadata[streaming.MetadataTerminalType])
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+	sessions, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, sessions.Msg.Sessions, 1)
+	require.Len(t, sessions.Msg.Sessions[0].Streams, 1)
+	require.Equal(t, StreamTransportBrowserConnect, sessions.Msg.Sessions[0].Streams[0].Transport)
+
+	// Input reaches the console in order, across calls
+	_, err = client.SendConsoleData(ctx, newSendConsoleDataRequest(ack.StreamId,
+		&gatewayv1.ConsoleDataChunk{Data: []byte("ls")},
+		&gatewayv1.ConsoleDataChunk{Resize: &gatewayv1.TerminalSize{Cols: 120, Rows: 40}},
+	))
+	require.NoError(t, err)
+	_, err = client.SendConsoleData(ctx, newSendConsoleDataRequest(ack.StreamId, &gatewayv1.ConsoleDataChunk{Data: []byte(" -l")}))
+	require.NoError(t, err)
+
+	var output []byte
+	for len(output) < len("LS -L") && stream.Receive() {
+		output = append(output, stream.Msg().Data...)
+	}
+	require.Equal(t, "LS -L", string(output))
+	require.Equal(t, uint32(120), (<-agentService.resizes).Cols)
+
+	// Closing the stream ends the watch
+	_, err = client.SendConsoleData(ctx, newSendConsoleDataRequest(ack.StreamId, &gatewayv1.ConsoleDataChunk{CloseStream: true}))
+	require.NoError(t, err)
+	for stream.Receive() {
+	}
+	require.NoError(t, stream.Err())
+	require.Eventually(t, func() bool {
+		handler.mu.RLock()
+		defer handler.mu.RUnlock()
+		return len(handler.browserStreams) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = client.SendConsoleData(ctx, newSendConsoleDataRequest(ack.StreamId, &gatewayv1.ConsoleDataChunk{Data: []byte("x")}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestWatchConsoleData_Errors(t *testing.T) {
+	agentService := &echoConsoleAgent{resizes: make(chan *gatewayv1.TerminalSize, 1)}
+	_, client, sessionID := newBrowserConsoleGateway(t, agentService)
+	ctx := context.Background()
+
+	t.Run("not a handshake", func(t *testing.T) {
+		stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{SessionId: sessionID}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{SessionId: "sol-unknown", IsHandshake: true}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("invalid terminal", func(t *testing.T) {
+		stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{
+			SessionId:   sessionID,
+			IsHandshake: true,
+			Metadata:    map[string]string{streaming.MetadataTerminalCols: "80"},
+		}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		stream, err := client.WatchConsoleData(ctx, connect.NewRequest(&gatewayv1.ConsoleDataChunk{SessionId: sessionID, IsHandshake: true}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("token of another server", func(t *testing.T) {
+		other := createAuthenticatedContextWithPermissions("192.168.1.200:623", "customer-1", []string{"console:write"})
+		req := connect.NewRequest(&gatewayv1.ConsoleDataChunk{SessionId: sessionID, IsHandshake: true})
+		req.Header().Set("Authorization", "Bearer "+other.Value("token").(string))
+		stream, err := client.WatchConsoleData(ctx, req)
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		req := connect.NewRequest(&gatewayv1.ConsoleDataChunk{SessionId: sessionID, IsHandshake: true})
+		req.Header().Set("Authorization", "Bearer not-a-token")
+		stream, err := client.WatchConsoleData(ctx, req)
+		require.NoError(t, err)
+		defer stream.Close()
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("unknown stream", func(t *testing.T) {
+		_, err := client.SendConsoleData(ctx, newSendConsoleDataRequest("stream-unknown", &gatewayv1.ConsoleDataChunk{Data: []byte("x")}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("other server", func(t *testing.T) {
+		stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{SessionId: sessionID, IsHandshake: true}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.True(t, stream.Receive(), "expected a handshake ack: %v", stream.Err())
+
+		other := createAuthenticatedContextWithPermissions("192.168.1.200:623", "customer-1", []string{"console:write"})
+		req := connect.NewRequest(&gatewayv1.SendConsoleDataRequest{
+			StreamId: stream.Msg().StreamId,
+			Chunks:   []*gatewayv1.ConsoleDataChunk{{Data: []byte("reboot\r")}},
+		})
+		req.Header().Set("Authorization", "Bearer "+other.Value("token").(string))
+		_, err = client.SendConsoleData(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestWatchConsoleData_Checksums(t *testing.T) {
+	agentService := &echoConsoleAgent{checksums: make(chan uint32, 1), corrupt: true}
+	handler, client, sessionID := newBrowserConsoleGateway(t, agentService)
+	corruptions := make(chan string, 1)
+	handler.SetStreamChecksums(true)
+	handler.SetStreamCorruptionObserver(func(protocol string) { corruptions <- protocol })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{
+		SessionId:   sessionID,
+		ServerId:    "192.168.1.100:623",
+		IsHandshake: true,
+	}))
+	require.NoError(t, err)
+	defer stream.Close()
+	require.True(t, stream.Receive(), "expected a handshake ack: %v", stream.Err())
+	streamID := stream.Msg().StreamId
+
+	// Input reaches the agent with its checksum
+	_, err = client.SendConsoleData(ctx, newSendConsoleDataRequest(streamID, &gatewayv1.ConsoleDataChunk{Data: []byte("ls")}))
+	require.NoError(t, err)
+	require.Equal(t, streaming.Checksum([]byte("ls")), <-agentService.checksums)
+
+	// Corrupted output ends the stream with an error chunk
+	require.True(t, stream.Receive(), "expected an error chunk: %v", stream.Err())
+	require.Empty(t, stream.Msg().Data)
+	require.Equal(t, string(streaming.ErrorCodeDataCorrupted), stream.Msg().ErrorCode)
+	require.Equal(t, "sol", <-corruptions)
+}
@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"core/streaming"
+	"gateway/internal/session"
+)
+
+// viewerTokenTTL bounds how long after issue a viewer URL can be opened
+const viewerTokenTTL = 5 * time.Minute
+
+// newConsoleSessionID returns an unguessable console session ID such as
+// "vnc-3f2a...", prefixed with the session type
+func newConsoleSessionID(sessionType string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return sessionType + "-" + hex.EncodeToString(b), nil
+}
+
+// SetAccessTokenSecret keys the access tokens of console URLs, so that
+// issued URLs stay valid across restarts
+func (h *RegionalGatewayHandler) SetAccessTokenSecret(secret string) {
+	h.accessTokens = session.NewAccessSigner(secret)
+}
+
+// SetStreamTokenKey keys the tokens agents verify in console stream
+// handshakes with an Ed25519 seed, so that agents registered before a
+// restart keep accepting streams
+func (h *RegionalGatewayHandler) SetStreamTokenKey(seed []byte) error {
+	signer, err := streaming.NewTokenSigner(seed)
+	if err != nil {
+		return err
+	}
+	h.agentStreamTokens = signer
+	return nil
+}
+
+// AgentStreamToken returns the token authorizing the gateway to open a
+// stream of a console session on the session's agent
+func (h *RegionalGatewayHandler) AgentStreamToken(consoleSession *ConsoleSession) (string, error) {
+	return h.agentStreamTokens.Sign(streaming.StreamClaims{
+		SessionID: consoleSession.SessionID,
+		ServerID:  consoleSession.ServerID,
+		Protocol:  consoleSession.Type,
+		AgentID:   consoleSession.AgentID,
+	})
+}
+
+// viewerURL returns the single-use viewer URL of a console session
+func (h *RegionalGatewayHandler) viewerURL(consoleSession *ConsoleSession) string {
+	path := "console"
+	if consoleSession.Type == "vnc" {
+		path = "vnc"
+	}
+
+	expiresAt := time.Now().Add(viewerTokenTTL)
+	if consoleSession.ExpiresAt.Before(expiresAt) {
+		expiresAt = consoleSession.ExpiresAt
+	}
+
+	token := h.accessTokens.Sign(session.ScopeViewer, consoleSession.SessionID, expiresAt)
+	return withAccessToken(fmt.Sprintf("%s/%s/%s", h.externalURL, path, consoleSession.SessionID), token)
+}
+
+// webSocketURL returns the external WebSocket URL of a path, using wss://
+// when the gateway is reached over https://
+func (h *RegionalGatewayHandler) webSocketURL(path string) string {
+	if rest, ok := strings.CutPrefix(h.externalURL, "https://"); ok {
+		return "wss://" + rest + path
+	}
+	return "ws://" + strings.TrimPrefix(h.externalURL, "http://") + path
+}
+
+// StreamAccessToken returns a token opening the WebSocket of a console
+// session until the session expires. Viewer pages reuse it to reconnect.
+func (h *RegionalGatewayHandler) StreamAccessToken(consoleSession *ConsoleSession) string {
+	return h.accessTokens.Sign(session.ScopeStream, consoleSession.SessionID, consoleSession.ExpiresAt)
+}
+
+// RedeemViewerToken consumes the access token of a viewer URL
+func (h *RegionalGatewayHandler) RedeemViewerToken(sessionID, token string) error {
+	return h.accessTokens.Redeem(session.ScopeViewer, sessionID, token)
+}
+
+// VerifyStreamToken checks the access token of a console WebSocket URL
+func (h *RegionalGatewayHandler) VerifyStreamToken(sessionID, token string) error {
+	return h.accessTokens.Verify(session.ScopeStream, sessionID, token)
+}
+
+// withAccessToken appends an access token to a console URL
+func withAccessToken(rawURL, token string) string {
+	return rawURL + "?" + session.AccessTokenParam + "=" + url.QueryEscape(token)
+}
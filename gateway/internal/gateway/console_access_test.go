@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway/internal/session"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsoleSessionID(t *testing.T) {
+	first, err := newConsoleSessionID("vnc")
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^vnc-[0-9a-f]{32}$`), first)
+
+	second, err := newConsoleSessionID("vnc")
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+}
+
+func TestConsoleAccessTokens(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	consoleSession := &ConsoleSession{SessionID: "vnc-1", Type: "vnc", ExpiresAt: time.Now().Add(time.Hour)}
+
+	viewerURL, err := url.Parse(handler.viewerURL(consoleSession))
+	require.NoError(t, err)
+	require.Equal(t, "/vnc/vnc-1", viewerURL.Path)
+
+	viewerToken := viewerURL.Query().Get(session.AccessTokenParam)
+	require.NoError(t, handler.RedeemViewerToken("vnc-1", viewerToken))
+	require.ErrorIs(t, handler.RedeemViewerToken("vnc-1", viewerToken), session.ErrAccessTokenUsed, "viewer URLs are single-use")
+	require.ErrorIs(t, handler.VerifyStreamToken("vnc-1", viewerToken), session.ErrAccessTokenInvalid, "viewer tokens do not open streams")
+
+	streamToken := handler.StreamAccessToken(consoleSession)
+	require.NoError(t, handler.VerifyStreamToken("vnc-1", streamToken))
+	require.NoError(t, handler.VerifyStreamToken("vnc-1", streamToken), "stream tokens allow reconnecting")
+	require.ErrorIs(t, handler.VerifyStreamToken("vnc-2", streamToken), session.ErrAccessTokenInvalid)
+
+	t.Run("viewer token expires with the session", func(t *testing.T) {
+		expired := &ConsoleSession{SessionID: "sol-1", Type: "sol", ExpiresAt: time.Now().Add(-time.Second)}
+		viewerURL, err := url.Parse(handler.viewerURL(expired))
+		require.NoError(t, err)
+		require.Equal(t, "/console/sol-1", viewerURL.Path)
+		require.ErrorIs(t, handler.RedeemViewerToken("sol-1", viewerURL.Query().Get(session.AccessTokenParam)), session.ErrAccessTokenExpired)
+	})
+}
+
+func TestConsoleURLsUseExternalURL(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	consoleSession := &ConsoleSession{SessionID: "sol-1", Type: "sol", ExpiresAt: time.Now().Add(time.Hour)}
+
+	require.Equal(t, "ws://test-gateway:8081/console/sol-1/ws", handler.webSocketURL("/console/sol-1/ws"))
+
+	handler.externalURL = "https://gateway.example.com"
+	require.Equal(t, "wss://gateway.example.com/console/sol-1/ws", handler.webSocketURL("/console/sol-1/ws"))
+	require.True(t, strings.HasPrefix(handler.viewerURL(consoleSession), "https://gateway.example.com/console/sol-1?token="))
+}
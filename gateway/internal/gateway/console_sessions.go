@@ -0,0 +1,664 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonauth "core/auth"
+	"core/streaming"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managermodels "manager/pkg/models"
+)
+
+// Console stream transports
+const (
+	StreamTransportConnect   = "connect"   // CLI terminal streaming over Connect RPC
+	StreamTransportWebSocket = "websocket" // Browser console or VNC viewer
+	// Browser console over Connect or gRPC-Web, see WatchConsoleData
+	StreamTransportBrowserConnect = "connect-web"
+)
+
+// Console session lifetimes, from their creation or renewal
+const (
+	vncSessionTTL = time.Hour
+	solSessionTTL = 2 * time.Hour
+
+	// consoleExpiryWarning is how long before their session expires attached
+	// streams are warned, so that the client can renew it
+	consoleExpiryWarning = time.Minute
+
+	// DefaultConsoleSessionMaxLifetime bounds how long renewals can keep a
+	// console session open, from its creation
+	DefaultConsoleSessionMaxLifetime = 12 * time.Hour
+)
+
+// SetConsoleSessionMaxLifetime sets how long renewals can keep a console
+// session open, from its creation. It must be called before the gateway
+// starts serving requests.
+func (h *RegionalGatewayHandler) SetConsoleSessionMaxLifetime(lifetime time.Duration) {
+	if lifetime > 0 {
+		h.consoleMaxLifetime = lifetime
+	}
+}
+
+// consoleSessionTTL returns the lifetime of the console sessions of a type
+func consoleSessionTTL(sessionType string) time.Duration {
+	if sessionType == "vnc" {
+		return vncSessionTTL
+	}
+	return solSessionTTL
+}
+
+// LastActivityAt returns when a client last sent console input to the
+// session or attached a stream to it, initially its creation time
+func (s *ConsoleSession) LastActivityAt() time.Time {
+	if s.Activity == nil {
+		return s.CreatedAt
+	}
+	return s.Activity.Last()
+}
+
+// errConsoleSessionExpired is the cancellation cause of streams whose
+// session expired
+var errConsoleSessionExpired = errors.New("console session expired")
+
+// AttachedStream is a client stream attached to a console session. Its
+// context is cancelled when an admin terminates the session or the session
+// expires, and it is warned shortly before the session expires.
+type AttachedStream struct {
+	handler       *RegionalGatewayHandler
+	sessionID     string
+	key           uint64
+	transport     string
+	clientAddress string
+	attachedAt    time.Time
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	// Timers of the session's expiry and of its warning, reset on renewal
+	expiryTimer  *time.Timer
+	warningTimer *time.Timer
+	warnings     chan time.Time
+
+	mu         sync.Mutex
+	terminated bool
+	reason     string
+}
+
+// SetConsoleStreamObserver sets the function receiving the summary of every
+// closed console stream
+func (h *RegionalGatewayHandler) SetConsoleStreamObserver(observe func(streaming.SessionSummary)) {
+	h.consoleStreamObserver = observe
+}
+
+// SetStreamFaults sets the fault injector of console streams to agents, nil
+// disables fault injection
+func (h *RegionalGatewayHandler) SetStreamFaults(injector *streaming.FaultInjector) {
+	h.streamFaults = injector
+}
+
+// StreamFaults returns the fault injector of console streams to agents, nil
+// when fault injection is disabled
+func (h *RegionalGatewayHandler) StreamFaults() *streaming.FaultInjector {
+	return h.streamFaults
+}
+
+// SetStreamMaxChunkSize sets the largest data of the VNC stream chunks
+// exchanged with agents, negotiated in the stream handshakes
+func (h *RegionalGatewayHandler) SetStreamMaxChunkSize(size int) {
+	h.streamMaxChunkSize = size
+}
+
+// StreamMaxChunkSize returns the largest data of the VNC stream chunks
+// exchanged with agents
+func (h *RegionalGatewayHandler) StreamMaxChunkSize() int {
+	return h.streamMaxChunkSize
+}
+
+// SetStreamChecksums enables the checksums of the console stream chunks
+// exchanged with agents, negotiated in the stream handshakes
+func (h *RegionalGatewayHandler) SetStreamChecksums(enabled bool) {
+	h.streamChecksums = enabled
+}
+
+// StreamChecksums returns true when the console stream chunks exchanged with
+// agents supporting it carry checksums
+func (h *RegionalGatewayHandler) StreamChecksums() bool {
+	return h.streamChecksums
+}
+
+// StreamFeatures returns the optional features advertised in the handshakes
+// of the console streams opened to agents
+func (h *RegionalGatewayHandler) StreamFeatures() []streaming.Feature {
+	// Agents are always answered when they ping
+	features := []streaming.Feature{streaming.FeatureKeepalive}
+	if h.streamChecksums {
+		features = append(features, streaming.FeatureChecksums)
+	}
+	return features
+}
+
+// SetStreamCorruptionObserver sets the function told of every chunk received
+// from agents failing its checksum, with the session type
+func (h *RegionalGatewayHandler) SetStreamCorruptionObserver(observe func(protocol string)) {
+	h.streamCorruptionObserver = observe
+}
+
+// StreamCorruptionObserver returns the corruption observer of the agent
+// stream of a console session, logging the corrupted chunks
+func (h *RegionalGatewayHandler) StreamCorruptionObserver(consoleSession *ConsoleSession) func(error) {
+	return func(err error) {
+		log.Warn().Err(err).
+			Str("session_id", consoleSession.SessionID).
+			Str("server_id", consoleSession.ServerID).
+			Str("agent_id", consoleSession.AgentID).
+			Str("protocol", consoleSession.Type).
+			Msg("Corrupted chunk received from agent")
+		if observe := h.streamCorruptionObserver; observe != nil {
+			observe(consoleSession.Type)
+		}
+	}
+}
+
+// StreamBuffers returns the buffer pool shared by the WebSocket proxies of
+// console streams
+func (h *RegionalGatewayHandler) StreamBuffers() *streaming.BufferPool {
+	return h.streamBuffers
+}
+
+// AttachConsoleStream registers a client stream of a console session. The
+// stream must use the returned stream's Context for its agent connection and
+// call Detach when it ends. The context derives from ctx, typically the
+// client's request, and ends when the session expires.
+func (h *RegionalGatewayHandler) AttachConsoleStream(ctx context.Context, sessionID, transport, clientAddress string) *AttachedStream {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	streamCtx, cancel := context.WithCancelCause(ctx)
+
+	h.nextStreamKey++
+	stream := &AttachedStream{
+		handler:       h,
+		sessionID:     sessionID,
+		key:           h.nextStreamKey,
+		transport:     transport,
+		clientAddress: clientAddress,
+		attachedAt:    time.Now(),
+		ctx:           streamCtx,
+		cancel:        cancel,
+		warnings:      make(chan time.Time, 1),
+	}
+
+	// The stream must not outlive its session
+	if session, exists := h.consoleSessions[sessionID]; exists {
+		stream.scheduleExpiryLocked(session.ExpiresAt)
+		session.Activity.Touch()
+	}
+
+	if h.consoleStreams[sessionID] == nil {
+		h.consoleStreams[sessionID] = make(map[uint64]*AttachedStream)
+	}
+	h.consoleStreams[sessionID][stream.key] = stream
+	return stream
+}
+
+// TrackConsoleStream attaches a client stream of a console session, so that
+// admins can list it and disconnect it, see AttachConsoleStream. It returns
+// the context of the stream's agent connection, which ends when an admin
+// disconnects it or its session expires, and the stream. Callers defer its
+// Detach, which unregisters it when the client leaves.
+func (h *RegionalGatewayHandler) TrackConsoleStream(ctx context.Context, sessionID, transport, clientAddress string) (context.Context, *AttachedStream) {
+	stream := h.AttachConsoleStream(ctx, sessionID, transport, clientAddress)
+	return stream.Context(), stream
+}
+
+// scheduleExpiryLocked disconnects the stream when its session expires at
+// expiresAt, after a warning. A warning not yet received is discarded.
+// Callers hold the handler's mu.
+func (s *AttachedStream) scheduleExpiryLocked(expiresAt time.Time) {
+	if s.expiryTimer != nil {
+		s.expiryTimer.Stop()
+		s.warningTimer.Stop()
+	}
+	select {
+	case <-s.warnings:
+	default:
+	}
+
+	s.expiryTimer = time.AfterFunc(time.Until(expiresAt), func() {
+		s.cancel(errConsoleSessionExpired)
+	})
+	s.warningTimer = time.AfterFunc(time.Until(expiresAt.Add(-consoleExpiryWarning)), func() {
+		select {
+		case s.warnings <- expiresAt:
+		default:
+		}
+	})
+}
+
+// Context returns the context of the stream
+func (s *AttachedStream) Context() context.Context {
+	return s.ctx
+}
+
+// ExpiryWarnings receives the expiry of the stream's session shortly before
+// it, or right away when the stream attaches later. Renewals reschedule the
+// warning.
+func (s *AttachedStream) ExpiryWarnings() <-chan time.Time {
+	return s.warnings
+}
+
+// ExpiryNotice returns the message shown to the client when its session
+// expires at expiresAt
+func ExpiryNotice(expiresAt time.Time) string {
+	remaining := max(time.Until(expiresAt).Round(time.Second), 0)
+	return fmt.Sprintf("[console session expires in %s, renew it to stay connected]", remaining)
+}
+
+// ConsoleNoticesParam is the parameter of console WebSocket URLs, set to 1,
+// with which clients ask for notices in text messages
+const ConsoleNoticesParam = "notices"
+
+// VNCSubprotocolBinary is the WebSocket subprotocol of VNC clients, such as
+// noVNC, exchanging raw RFB in binary messages only. Its clients get no
+// notices in text messages.
+const VNCSubprotocolBinary = "binary"
+
+// VNCSubprotocols are the WebSocket subprotocols VNC streams negotiate, in
+// order of preference. Clients may also ask for none.
+var VNCSubprotocols = []string{VNCSubprotocolBinary}
+
+// ConsoleNoticeSessionExpiring is the type of the notice warning WebSocket
+// clients that their session expires soon
+const ConsoleNoticeSessionExpiring = "session_expiring"
+
+// ConsoleNotice is a notice of the gateway to a WebSocket client, sent as a
+// JSON text message
+type ConsoleNotice struct {
+	Type      string    `json:"type"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Message   string    `json:"message"`
+}
+
+// WebSocketNotices returns the notices of the stream's expiry warnings, for
+// WebSocket clients telling text messages apart from data. It is closed when
+// the stream ends.
+func (s *AttachedStream) WebSocketNotices() <-chan []byte {
+	notices := make(chan []byte, 1)
+	go func() {
+		defer close(notices)
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case expiresAt := <-s.warnings:
+				notice, err := json.Marshal(ConsoleNotice{
+					Type:      ConsoleNoticeSessionExpiring,
+					ExpiresAt: expiresAt,
+					Message:   ExpiryNotice(expiresAt),
+				})
+				if err != nil {
+					continue
+				}
+				select {
+				case notices <- notice:
+				case <-s.ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return notices
+}
+
+// NewRecorder starts recording the console data the stream exchanges with
+// the agent at agentAddr. The proxy reports the stream's audit summary when
+// it ends, which is published as a console.session_closed event.
+func (s *AttachedStream) NewRecorder(consoleSession *ConsoleSession, agentAddr string) *streaming.SessionRecorder {
+	logger := log.With().
+		Str("agent_id", consoleSession.AgentID).
+		Str("customer_id", consoleSession.CustomerID).
+		Logger()
+
+	observers := []func(streaming.SessionSummary){
+		func(summary streaming.SessionSummary) {
+			s.handler.publishConsoleSessionClosed(consoleSession, summary)
+		},
+	}
+	if observe := s.handler.consoleStreamObserver; observe != nil {
+		observers = append(observers, observe)
+	}
+
+	return streaming.NewSessionRecorder(streaming.SessionInfo{
+		SessionID:  s.sessionID,
+		ServerID:   consoleSession.ServerID,
+		Protocol:   consoleSession.Type,
+		Transport:  s.transport,
+		ClientAddr: s.clientAddress,
+		ServerAddr: agentAddr,
+	}, logger, observers...).WithActivity(consoleSession.Activity)
+}
+
+// Detach unregisters the stream. It is safe to call multiple times.
+func (s *AttachedStream) Detach() {
+	s.cancel(nil)
+
+	h := s.handler
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if s.expiryTimer != nil {
+		s.expiryTimer.Stop()
+		s.warningTimer.Stop()
+	}
+
+	streams := h.consoleStreams[s.sessionID]
+	delete(streams, s.key)
+	if len(streams) == 0 {
+		delete(h.consoleStreams, s.sessionID)
+	}
+}
+
+// TerminationNotice returns the message shown to the client when an admin
+// terminated the session or the session expired, or an empty string otherwise
+func (s *AttachedStream) TerminationNotice() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.terminated {
+		if context.Cause(s.ctx) == errConsoleSessionExpired {
+			return "[console session expired]"
+		}
+		return ""
+	}
+	if s.reason == "" {
+		return "[console session terminated by an administrator]"
+	}
+	return fmt.Sprintf("[console session terminated by an administrator: %s]", s.reason)
+}
+
+// terminate disconnects the stream
+func (s *AttachedStream) terminate(reason string) {
+	s.mu.Lock()
+	s.terminated = true
+	s.reason = reason
+	s.mu.Unlock()
+
+	s.cancel(nil)
+}
+
+// ListConsoleSessions returns the console sessions held by the gateway. Admins
+// list all sessions, other customers only their own.
+func (h *RegionalGatewayHandler) ListConsoleSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListConsoleSessionsRequest],
+) (*connect.Response[gatewayv1.ListConsoleSessionsResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	filter := req.Msg
+	customerID := filter.CustomerId
+	if !claims.IsAdmin {
+		if customerID != "" && customerID != claims.CustomerID {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required to list other customers' sessions"))
+		}
+		customerID = claims.CustomerID
+	}
+	now := time.Now()
+
+	h.mu.RLock()
+	sessions := make([]*gatewayv1.ConsoleSessionInfo, 0, len(h.consoleSessions))
+	for _, session := range h.consoleSessions {
+		if now.After(session.ExpiresAt) ||
+			(filter.ServerId != "" && session.ServerID != filter.ServerId) ||
+			(customerID != "" && session.CustomerID != customerID) ||
+			(filter.AgentId != "" && session.AgentID != filter.AgentId) {
+			continue
+		}
+		sessions = append(sessions, h.consoleSessionInfoLocked(session))
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		ci, cj := sessions[i].CreatedAt.AsTime(), sessions[j].CreatedAt.AsTime()
+		if !ci.Equal(cj) {
+			return ci.Before(cj)
+		}
+		return sessions[i].SessionId < sessions[j].SessionId
+	})
+
+	log.Debug().
+		Str("customer_id", claims.CustomerID).
+		Int("sessions", len(sessions)).
+		Msg("Console sessions listed")
+
+	return connect.NewResponse(&gatewayv1.ListConsoleSessionsResponse{Sessions: sessions}), nil
+}
+
+// consoleSessionInfoLocked describes a session and its attached streams.
+// Callers hold mu.
+func (h *RegionalGatewayHandler) consoleSessionInfoLocked(session *ConsoleSession) *gatewayv1.ConsoleSessionInfo {
+	info := &gatewayv1.ConsoleSessionInfo{
+		SessionId:      session.SessionID,
+		Type:           session.Type,
+		ServerId:       session.ServerID,
+		CustomerId:     session.CustomerID,
+		CustomerEmail:  session.CustomerEmail,
+		AgentId:        session.AgentID,
+		BmcEndpoint:    session.BMCEndpoint,
+		CreatedAt:      timestamppb.New(session.CreatedAt),
+		ExpiresAt:      timestamppb.New(session.ExpiresAt),
+		ReadOnly:       session.ReadOnly,
+		KeyboardLayout: session.KeyboardLayout,
+		ImpersonatedBy: session.ImpersonatedBy,
+		LastActivityAt: timestamppb.New(session.LastActivityAt()),
+	}
+
+	streams := make([]*AttachedStream, 0, len(h.consoleStreams[session.SessionID]))
+	for _, stream := range h.consoleStreams[session.SessionID] {
+		streams = append(streams, stream)
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].key < streams[j].key
+	})
+
+	for _, stream := range streams {
+		info.Streams = append(info.Streams, &gatewayv1.ConsoleStreamInfo{
+			ClientAddress: stream.clientAddress,
+			Transport:     stream.transport,
+			AttachedAt:    timestamppb.New(stream.attachedAt),
+		})
+	}
+	return info
+}
+
+// TerminateConsoleSession closes a console session and disconnects its
+// attached streams (admin only)
+func (h *RegionalGatewayHandler) TerminateConsoleSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.TerminateConsoleSessionRequest],
+) (*connect.Response[gatewayv1.TerminateConsoleSessionResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	sessionID := req.Msg.SessionId
+	if sessionID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("session_id is required"))
+	}
+
+	h.mu.Lock()
+	session, exists := h.consoleSessions[sessionID]
+	if !exists {
+		h.mu.Unlock()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", sessionID))
+	}
+	delete(h.consoleSessions, sessionID)
+
+	streams := make([]*AttachedStream, 0, len(h.consoleStreams[sessionID]))
+	for _, stream := range h.consoleStreams[sessionID] {
+		streams = append(streams, stream)
+	}
+	h.mu.Unlock()
+
+	// Streams detach themselves once their proxy has stopped
+	for _, stream := range streams {
+		stream.terminate(req.Msg.Reason)
+	}
+
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("server_id", session.ServerID).
+		Str("session_customer_id", session.CustomerID).
+		Str("customer_id", claims.CustomerID).
+		Str("reason", req.Msg.Reason).
+		Int("disconnected_streams", len(streams)).
+		Msg("Console session terminated by admin")
+
+	return connect.NewResponse(&gatewayv1.TerminateConsoleSessionResponse{
+		DisconnectedStreams: int32(len(streams)),
+	}), nil
+}
+
+// RenewConsoleSession extends a console session by its lifetime from now, and
+// reschedules the expiry of its attached streams. The token must be of the
+// session's server and customer, and the manager must still authorize the
+// session.
+func (h *RegionalGatewayHandler) RenewConsoleSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RenewConsoleSessionRequest],
+) (*connect.Response[gatewayv1.RenewConsoleSessionResponse], error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	sessionID := req.Msg.SessionId
+	if sessionID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("session_id is required"))
+	}
+
+	session, exists := h.GetConsoleSessionByID(sessionID)
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", sessionID))
+	}
+	if session.ServerID != serverContext.ServerID || session.CustomerID != serverContext.CustomerID {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("access denied"))
+	}
+	if !hasConsolePermission(serverContext, session.ReadOnly) {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for console access"))
+	}
+
+	// The manager may have revoked the session since it was created
+	if err := h.AuthorizeConsoleSession(ctx, session); err != nil {
+		if errors.Is(err, ErrSessionRevoked) {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	// Sessions are shared with the streams that got them, so the renewed
+	// session replaces the stored one
+	h.mu.Lock()
+	session, exists = h.consoleSessions[sessionID]
+	if !exists {
+		h.mu.Unlock()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", sessionID))
+	}
+	now := time.Now()
+	endOfLife := session.CreatedAt.Add(h.consoleMaxLifetime)
+	if !now.Before(endOfLife) {
+		h.mu.Unlock()
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf(
+			"console session %s reached its maximum lifetime of %s, create a new session", sessionID, h.consoleMaxLifetime))
+	}
+	renewed := *session
+	renewed.ExpiresAt = now.Add(consoleSessionTTL(session.Type))
+	if renewed.ExpiresAt.After(endOfLife) {
+		renewed.ExpiresAt = endOfLife
+	}
+	h.consoleSessions[sessionID] = &renewed
+	for _, stream := range h.consoleStreams[sessionID] {
+		stream.scheduleExpiryLocked(renewed.ExpiresAt)
+	}
+	streams := len(h.consoleStreams[sessionID])
+	h.mu.Unlock()
+
+	path := "/console/" + sessionID + "/ws"
+	if renewed.Type == "vnc" {
+		path = "/vnc/" + sessionID + "/ws"
+	}
+
+	log.Info().
+		Str("session_id", sessionID).
+		Str("server_id", renewed.ServerID).
+		Str("customer_id", renewed.CustomerID).
+		Time("expires_at", renewed.ExpiresAt).
+		Int("attached_streams", streams).
+		Msg("Console session renewed")
+
+	return connect.NewResponse(&gatewayv1.RenewConsoleSessionResponse{
+		ExpiresAt:         timestamppb.New(renewed.ExpiresAt),
+		WebsocketEndpoint: withAccessToken(h.webSocketURL(path), h.StreamAccessToken(&renewed)),
+	}), nil
+}
+
+// hasConsolePermission returns true when the server context may open console
+// sessions, read-only ones needing only console:read
+func hasConsolePermission(serverContext *commonauth.ServerContext, readOnly bool) bool {
+	if serverContext.HasPermission("console:write") || serverContext.HasPermission("console:access") {
+		return true
+	}
+	return readOnly && serverContext.HasPermission("console:read")
+}
+
+// errConsoleMFARequired refuses console sessions to customers whose policy
+// requires a second factor they did not log in with
+var errConsoleMFARequired = connect.NewError(connect.CodePermissionDenied,
+	fmt.Errorf("two-factor authentication required for console access, log in again with a two-factor code"))
+
+// checkConsoleMFA enforces the two-factor policy the manager put in the
+// server token
+func (h *RegionalGatewayHandler) checkConsoleMFA(ctx context.Context) error {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		// Fallback for tests or direct calls, like extractServerContextFromJWT
+		token, ok := ctx.Value("token").(string)
+		if !ok {
+			return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("no token found in context"))
+		}
+		var err error
+		if claims, _, err = h.validateToken(token); err != nil {
+			return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token: %w", err))
+		}
+	}
+	if claims.MFARequired && !claims.MFA {
+		return errConsoleMFARequired
+	}
+	return nil
+}
+
+// claimsEmail returns the email of the authenticated customer, if known
+func claimsEmail(ctx context.Context) string {
+	if claims, ok := ctx.Value("claims").(*managermodels.AuthClaims); ok {
+		return claims.Email
+	}
+	return ""
+}
@@ -0,0 +1,515 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	commonauth "core/auth"
+	"core/events"
+	"core/streaming"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerauth "manager/pkg/auth"
+	managermodels "manager/pkg/models"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListConsoleSessions(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+
+	handler.mu.Lock()
+	handler.consoleSessions["sol-2"] = &ConsoleSession{SessionID: "sol-2", Type: "sol", ServerID: "server-a", CustomerID: "customer-1", CustomerEmail: "ops@example.com", AgentID: "agent-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	handler.consoleSessions["vnc-1"] = &ConsoleSession{SessionID: "vnc-1", Type: "vnc", ServerID: "server-a", CustomerID: "customer-2", AgentID: "agent-1", CreatedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)}
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "server-b", CustomerID: "customer-1", AgentID: "agent-2", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Minute)}
+	handler.mu.Unlock()
+
+	stream := handler.AttachConsoleStream(context.Background(), "sol-2", StreamTransportConnect, "10.1.2.3:50000")
+	defer stream.Detach()
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+
+	resp, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Sessions, 2, "expired sessions should not be listed")
+	require.Equal(t, "vnc-1", resp.Msg.Sessions[0].SessionId, "sessions should be listed oldest first")
+	require.Empty(t, resp.Msg.Sessions[0].Streams)
+
+	sol := resp.Msg.Sessions[1]
+	require.Equal(t, "sol-2", sol.SessionId)
+	require.Equal(t, "sol", sol.Type)
+	require.Equal(t, "customer-1", sol.CustomerId)
+	require.Equal(t, "ops@example.com", sol.CustomerEmail)
+	require.Len(t, sol.Streams, 1)
+	require.Equal(t, "10.1.2.3:50000", sol.Streams[0].ClientAddress)
+	require.Equal(t, StreamTransportConnect, sol.Streams[0].Transport)
+
+	t.Run("filters", func(t *testing.T) {
+		resp, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{CustomerId: "customer-2"}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Sessions, 1)
+		require.Equal(t, "vnc-1", resp.Msg.Sessions[0].SessionId)
+
+		resp, err = handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{ServerId: "server-a", AgentId: "agent-2"}))
+		require.NoError(t, err)
+		require.Empty(t, resp.Msg.Sessions)
+	})
+
+	t.Run("detached streams are not listed", func(t *testing.T) {
+		other := handler.AttachConsoleStream(context.Background(), "vnc-1", StreamTransportWebSocket, "10.1.2.4:50001")
+		other.Detach()
+		other.Detach()
+
+		resp, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{ServerId: "server-a"}))
+		require.NoError(t, err)
+		require.Empty(t, resp.Msg.Sessions[0].Streams)
+	})
+
+	t.Run("customers list their own sessions", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-2"})
+		resp, err := handler.ListConsoleSessions(ctx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Sessions, 1)
+		require.Equal(t, "vnc-1", resp.Msg.Sessions[0].SessionId)
+
+		_, err = handler.ListConsoleSessions(ctx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{CustomerId: "customer-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := handler.ListConsoleSessions(context.Background(), connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
+
+func TestTerminateConsoleSession(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "server-a", CustomerID: "customer-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	handler.mu.Unlock()
+
+	cli := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportConnect, "10.1.2.3:50000")
+	defer cli.Detach()
+	browser := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportWebSocket, "10.1.2.4:50001")
+	defer browser.Detach()
+	require.Empty(t, cli.TerminationNotice())
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+
+	resp, err := handler.TerminateConsoleSession(adminCtx, connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{
+		SessionId: "sol-1",
+		Reason:    "stuck session",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.Msg.DisconnectedStreams)
+
+	for _, stream := range []*AttachedStream{cli, browser} {
+		select {
+		case <-stream.Context().Done():
+		default:
+			t.Fatal("terminated stream context should be cancelled")
+		}
+		require.Equal(t, "[console session terminated by an administrator: stuck session]", stream.TerminationNotice())
+	}
+
+	_, exists := handler.GetConsoleSessionByID("sol-1")
+	require.False(t, exists, "terminated session should be removed")
+
+	t.Run("unknown session", func(t *testing.T) {
+		_, err := handler.TerminateConsoleSession(adminCtx, connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{SessionId: "sol-1"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("session required", func(t *testing.T) {
+		_, err := handler.TerminateConsoleSession(adminCtx, connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("non-admin denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+		_, err := handler.TerminateConsoleSession(ctx, connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{SessionId: "sol-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestAttachedStreamEndsWithSession(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", CreatedAt: now, ExpiresAt: now.Add(50 * time.Millisecond)}
+	handler.mu.Unlock()
+
+	stream := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	defer stream.Detach()
+
+	select {
+	case <-stream.Context().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream context should end when the session expires")
+	}
+	require.Equal(t, "[console session expired]", stream.TerminationNotice())
+
+	t.Run("client disconnect", func(t *testing.T) {
+		handler.mu.Lock()
+		handler.consoleSessions["sol-2"] = &ConsoleSession{SessionID: "sol-2", Type: "sol", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+		handler.mu.Unlock()
+
+		requestCtx, cancel := context.WithCancel(context.Background())
+		stream := handler.AttachConsoleStream(requestCtx, "sol-2", StreamTransportWebSocket, "10.1.2.3:50001")
+		defer stream.Detach()
+
+		cancel()
+		<-stream.Context().Done()
+		require.Empty(t, stream.TerminationNotice(), "a disconnected client gets no notice")
+	})
+}
+
+func TestAttachedStreamPublishesSessionClosed(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+
+	session := &ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "server-a", CustomerID: "customer-1", AgentID: "agent-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = session
+	handler.mu.Unlock()
+
+	stream := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	recorder := stream.NewRecorder(session, "agent-1:8081")
+	recorder.RecordInput(12)
+	recorder.RecordOutput(300)
+	recorder.RecordOutput(200)
+	recorder.Close(streaming.DisconnectClientClosed, nil)
+	stream.Detach()
+
+	pending := handler.eventOutbox.Drain()
+	require.Len(t, pending, 1)
+	require.Equal(t, events.ConsoleSessionClosed, pending[0].Type)
+
+	data := pending[0].Data
+	require.Equal(t, "sol-1", data["session_id"])
+	require.Equal(t, "server-a", data["server_id"])
+	require.Equal(t, "customer-1", data["customer_id"])
+	require.Equal(t, StreamTransportWebSocket, data["transport"])
+	require.Equal(t, int64(12), data["bytes_in"])
+	require.Equal(t, int64(500), data["bytes_out"])
+	require.Equal(t, "client_closed", data["reason"])
+	require.GreaterOrEqual(t, data["duration_ms"], int64(0))
+}
+
+func TestAttachedStreamWarnsBeforeExpiry(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+	expiresAt := now.Add(consoleExpiryWarning + 50*time.Millisecond)
+
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", CreatedAt: now, ExpiresAt: expiresAt}
+	handler.mu.Unlock()
+
+	stream := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	defer stream.Detach()
+	notices := stream.WebSocketNotices()
+
+	var notice ConsoleNotice
+	select {
+	case data := <-notices:
+		require.NoError(t, json.Unmarshal(data, &notice))
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream should be warned before the session expires")
+	}
+	require.Equal(t, ConsoleNoticeSessionExpiring, notice.Type)
+	require.True(t, notice.ExpiresAt.Equal(expiresAt))
+	require.Contains(t, notice.Message, "console session expires in")
+
+	select {
+	case <-stream.Context().Done():
+		t.Fatal("stream should stay open until the session expires")
+	default:
+	}
+
+	stream.Detach()
+	select {
+	case _, ok := <-notices:
+		require.False(t, ok, "notices should be closed when the stream ends")
+	case <-time.After(5 * time.Second):
+		t.Fatal("notices should be closed when the stream ends")
+	}
+}
+
+func TestRenewConsoleSession(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+
+	handler.mu.Lock()
+	handler.consoleSessions["vnc-1"] = &ConsoleSession{SessionID: "vnc-1", Type: "vnc", ServerID: "server-a", CustomerID: "customer-1", CreatedAt: now, ExpiresAt: now.Add(100 * time.Millisecond)}
+	handler.mu.Unlock()
+
+	stream := handler.AttachConsoleStream(context.Background(), "vnc-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	defer stream.Detach()
+
+	// The warning is due right away, the session expiring within a minute
+	select {
+	case <-stream.ExpiryWarnings():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream should be warned before the session expires")
+	}
+
+	ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+		ServerID:    "server-a",
+		CustomerID:  "customer-1",
+		Permissions: []string{"console:access"},
+	})
+
+	resp, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+	require.NoError(t, err)
+	expiresAt := resp.Msg.ExpiresAt.AsTime()
+	require.WithinDuration(t, time.Now().Add(vncSessionTTL), expiresAt, time.Minute)
+	require.True(t, strings.HasPrefix(resp.Msg.WebsocketEndpoint, "ws://test-gateway:8081/vnc/vnc-1/ws?"), resp.Msg.WebsocketEndpoint)
+
+	session, exists := handler.GetConsoleSessionByID("vnc-1")
+	require.True(t, exists)
+	require.True(t, session.ExpiresAt.Equal(expiresAt))
+
+	// The attached stream outlives the previous expiry
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-stream.Context().Done():
+		t.Fatal("renewed stream should stay open")
+	default:
+	}
+	select {
+	case <-stream.ExpiryWarnings():
+		t.Fatal("renewed stream should not be warned yet")
+	default:
+	}
+
+	t.Run("other server denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-b",
+			CustomerID:  "customer-1",
+			Permissions: []string{"console:access"},
+		})
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("other customer denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-a",
+			CustomerID:  "customer-2",
+			Permissions: []string{"console:access"},
+		})
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("console permission required", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-a",
+			CustomerID:  "customer-1",
+			Permissions: []string{"power:read"},
+		})
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-2"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("session required", func(t *testing.T) {
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("renewal bounded by the maximum lifetime", func(t *testing.T) {
+		handler.SetConsoleSessionMaxLifetime(90 * time.Minute)
+		defer handler.SetConsoleSessionMaxLifetime(DefaultConsoleSessionMaxLifetime)
+
+		handler.mu.Lock()
+		createdAt := time.Now().Add(-time.Hour)
+		handler.consoleSessions["vnc-old"] = &ConsoleSession{SessionID: "vnc-old", Type: "vnc", ServerID: "server-a", CustomerID: "customer-1", CreatedAt: createdAt, ExpiresAt: time.Now().Add(time.Minute)}
+		handler.mu.Unlock()
+
+		resp, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-old"}))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.ExpiresAt.AsTime().Equal(createdAt.Add(90*time.Minute)), resp.Msg.ExpiresAt.AsTime())
+
+		handler.mu.Lock()
+		handler.consoleSessions["vnc-old"].CreatedAt = time.Now().Add(-2 * time.Hour)
+		handler.mu.Unlock()
+
+		_, err = handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-old"}))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := handler.RenewConsoleSession(context.Background(), connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
+
+func TestConsoleSessionActivity(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	createdAt := time.Now().Add(-time.Hour)
+
+	handler.mu.Lock()
+	handler.consoleSessions["vnc-1"] = &ConsoleSession{SessionID: "vnc-1", Type: "vnc", ServerID: "server-a", CustomerID: "customer-1", CreatedAt: createdAt, ExpiresAt: time.Now().Add(time.Hour), Activity: streaming.NewActivity(createdAt)}
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "server-a", CustomerID: "customer-1", CreatedAt: createdAt, ExpiresAt: time.Now().Add(time.Hour)}
+	handler.mu.Unlock()
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+	lastActivity := func(sessionID string) time.Time {
+		resp, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+		require.NoError(t, err)
+		for _, session := range resp.Msg.Sessions {
+			if session.SessionId == sessionID {
+				return session.LastActivityAt.AsTime()
+			}
+		}
+		t.Fatalf("session %s not listed", sessionID)
+		return time.Time{}
+	}
+
+	require.WithinDuration(t, createdAt, lastActivity("vnc-1"), time.Millisecond)
+	require.WithinDuration(t, createdAt, lastActivity("sol-1"), time.Millisecond, "sessions without activity should report their creation")
+
+	// Attaching a stream is activity
+	stream := handler.AttachConsoleStream(context.Background(), "vnc-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	defer stream.Detach()
+	attachedAt := lastActivity("vnc-1")
+	require.WithinDuration(t, time.Now(), attachedAt, time.Minute)
+
+	session, exists := handler.GetConsoleSessionByID("vnc-1")
+	require.True(t, exists)
+	recorder := stream.NewRecorder(session, "agent:8080")
+
+	// Console output is not
+	time.Sleep(time.Millisecond)
+	recorder.RecordOutput(1024)
+	require.True(t, lastActivity("vnc-1").Equal(attachedAt))
+
+	recorder.RecordInput(8)
+	inputAt := lastActivity("vnc-1")
+	require.True(t, inputAt.After(attachedAt))
+
+	t.Run("kept on renewal", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-a",
+			CustomerID:  "customer-1",
+			Permissions: []string{"console:access"},
+		})
+		_, err := handler.RenewConsoleSession(ctx, connect.NewRequest(&gatewayv1.RenewConsoleSessionRequest{SessionId: "vnc-1"}))
+		require.NoError(t, err)
+		require.True(t, lastActivity("vnc-1").Equal(inputAt))
+
+		// Streams of the previous copy of the session still update it
+		time.Sleep(time.Millisecond)
+		recorder.RecordInput(8)
+		require.True(t, lastActivity("vnc-1").After(inputAt))
+	})
+
+	t.Run("reported by GetVNCSession", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-a",
+			CustomerID:  "customer-1",
+			Permissions: []string{"console:access"},
+		})
+		resp, err := handler.GetVNCSession(ctx, connect.NewRequest(&gatewayv1.GetVNCSessionRequest{SessionId: "vnc-1"}))
+		require.NoError(t, err)
+		require.Equal(t, "server-a", resp.Msg.Session.ServerId)
+		require.True(t, resp.Msg.Session.LastActivityAt.AsTime().Equal(lastActivity("vnc-1")))
+
+		_, err = handler.GetVNCSession(ctx, connect.NewRequest(&gatewayv1.GetVNCSessionRequest{SessionId: "sol-1"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "SOL sessions are not VNC sessions")
+
+		other := context.WithValue(context.Background(), "server_context", &commonauth.ServerContext{
+			ServerID:    "server-a",
+			CustomerID:  "customer-2",
+			Permissions: []string{"console:access"},
+		})
+		_, err = handler.GetVNCSession(other, connect.NewRequest(&gatewayv1.GetVNCSessionRequest{SessionId: "vnc-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestConsoleSessions_RequireMFA(t *testing.T) {
+	handler := newSessionQuotaGateway()
+	server := testTokenServer("192.168.1.100:623", "owner-1")
+	customer := &managermodels.Customer{ID: "customer-1", Email: "customer-1@example.com"}
+	mfaContext := func(mfa managerauth.MFAClaims) context.Context {
+		token, err := handler.jwtManager.GenerateServerTokenWithMFA(customer, server, []string{"console:write"}, nil, mfa)
+		require.NoError(t, err)
+		return context.WithValue(context.Background(), "token", token)
+	}
+	vncReq := connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{ServerId: "192.168.1.100:623"})
+	solReq := connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: "192.168.1.100:623"})
+
+	ctx := mfaContext(managerauth.MFAClaims{Required: true})
+	_, err := handler.CreateVNCSession(ctx, vncReq)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	require.Contains(t, err.Error(), "two-factor authentication required")
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	ctx = mfaContext(managerauth.MFAClaims{Verified: true, Required: true})
+	_, err = handler.CreateVNCSession(ctx, vncReq)
+	require.NoError(t, err)
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.NoError(t, err)
+
+	// Customers without the policy need no second factor
+	_, err = handler.CreateSOLSession(mfaContext(managerauth.MFAClaims{}), solReq)
+	require.NoError(t, err)
+}
+
+func TestConsoleSessions_IPAllowlist(t *testing.T) {
+	handler := newSessionQuotaGateway()
+	server := testTokenServer("192.168.1.100:623", "owner-1")
+	customer := &managermodels.Customer{ID: "customer-1", Email: "customer-1@example.com", AllowedSourceRanges: []string{"10.0.0.0/8"}}
+	token, err := handler.jwtManager.GenerateServerTokenWithMFA(customer, server, []string{"console:write"}, nil, managerauth.MFAClaims{})
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), "token", token)
+
+	resp, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: "192.168.1.100:623"}))
+	require.NoError(t, err)
+	session, exists := handler.GetConsoleSessionByID(resp.Msg.SessionId)
+	require.True(t, exists)
+	require.Equal(t, []string{"10.0.0.0/8"}, session.AllowedSourceRanges)
+
+	require.NoError(t, handler.CheckSourceAllowed(session, "10.1.2.3:50000"))
+	err = handler.CheckSourceAllowed(session, "203.0.113.7:50000")
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Streams are refused outside the allowlist, before attaching
+	err = handler.streamConsole(ctx, nil, &gatewayv1.ConsoleDataChunk{SessionId: resp.Msg.SessionId}, StreamTransportConnect, "203.0.113.7:50000", nil)
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestConsoleSessions_Impersonation(t *testing.T) {
+	handler := newSessionQuotaGateway()
+	server := testTokenServer("192.168.1.100:623", "customer-1")
+	customer := &managermodels.Customer{ID: "customer-1", Email: "customer-1@example.com"}
+	token, err := handler.jwtManager.GenerateImpersonationToken(customer, server, []string{"console:access", "vnc"}, "admin@example.com", managerauth.MFAClaims{})
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), "token", token)
+
+	resp, err := handler.CreateVNCSession(ctx, connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{ServerId: "192.168.1.100:623"}))
+	require.NoError(t, err)
+	session, exists := handler.GetConsoleSessionByID(resp.Msg.SessionId)
+	require.True(t, exists)
+	require.Equal(t, "customer-1", session.CustomerID)
+	require.Equal(t, "admin@example.com", session.ImpersonatedBy)
+
+	handler.mu.RLock()
+	info := handler.consoleSessionInfoLocked(session)
+	handler.mu.RUnlock()
+	require.Equal(t, "admin@example.com", info.ImpersonatedBy)
+}
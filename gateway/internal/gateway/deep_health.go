@@ -0,0 +1,141 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// Hops and statuses of deep health checks, see gatewayv1.HealthCheckHop
+const (
+	healthHopAgent   = "agent"
+	healthHopControl = "bmc_control"
+	healthHopSOL     = "sol"
+	healthHopVNC     = "vnc"
+
+	healthStatusOK      = "ok"
+	healthStatusFailed  = "failed"
+	healthStatusSkipped = "skipped"
+)
+
+// DeepHealthCheck checks the path from the gateway to a server's BMC hop by
+// hop, so that support can tell where it is broken: the agent is probed over
+// its link, then the agent checks the BMC endpoints. The hops after a failed
+// one are skipped. It is authorized like BMC info, with power:read.
+func (h *RegionalGatewayHandler) DeepHealthCheck(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.DeepHealthCheckRequest],
+) (*connect.Response[gatewayv1.DeepHealthCheckResponse], error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:read") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for health checks"))
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	hops := []*gatewayv1.HealthCheckHop{{Name: healthHopAgent, Target: mapping.AgentID}}
+	agentHop := hops[0]
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		agentHop.Status = healthStatusFailed
+		agentHop.Error = "agent is not registered with the gateway"
+		return h.deepHealthCheckResponse(req.Msg, serverContext.BMCEndpoint, hops), nil
+	}
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+
+	start := time.Now()
+	_, err = agentClient.ProbeLink(ctx, connect.NewRequest(&gatewayv1.ProbeLinkRequest{}))
+	agentHop.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		agentHop.Status = healthStatusFailed
+		agentHop.Error = fmt.Sprintf("agent at %s is unreachable: %v", agentInfo.Endpoint, err)
+		return h.deepHealthCheckResponse(req.Msg, serverContext.BMCEndpoint, hops), nil
+	}
+	agentHop.Status = healthStatusOK
+
+	resp, err := agentClient.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+		ServerId:      mapping.ServerID,
+		CheckConsoles: req.Msg.CheckConsoles,
+	}))
+	if err != nil {
+		message := err.Error()
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			message = "agent does not support deep health checks, upgrade it"
+		}
+		hops = append(hops, &gatewayv1.HealthCheckHop{
+			Name:   healthHopControl,
+			Target: serverContext.BMCEndpoint,
+			Status: healthStatusFailed,
+			Error:  message,
+		})
+		return h.deepHealthCheckResponse(req.Msg, serverContext.BMCEndpoint, hops), nil
+	}
+	hops = append(hops, resp.Msg.Hops...)
+
+	return h.deepHealthCheckResponse(req.Msg, serverContext.BMCEndpoint, hops), nil
+}
+
+// deepHealthCheckResponse completes the hops of a deep health check with the
+// ones skipped after a failure, and logs its outcome
+func (h *RegionalGatewayHandler) deepHealthCheckResponse(
+	req *gatewayv1.DeepHealthCheckRequest,
+	bmcEndpoint string,
+	hops []*gatewayv1.HealthCheckHop,
+) *connect.Response[gatewayv1.DeepHealthCheckResponse] {
+	names := []string{healthHopAgent, healthHopControl}
+	if req.CheckConsoles {
+		names = append(names, healthHopSOL, healthHopVNC)
+	}
+	for _, name := range names[min(len(hops), len(names)):] {
+		hops = append(hops, &gatewayv1.HealthCheckHop{
+			Name:   name,
+			Status: healthStatusSkipped,
+			Error:  "a previous hop failed",
+		})
+	}
+
+	healthy := true
+	failed := ""
+	for _, hop := range hops {
+		if hop.Status == healthStatusFailed {
+			healthy = false
+			if failed == "" {
+				failed = hop.Name
+			}
+		}
+	}
+
+	log.Info().
+		Str("server_id", req.ServerId).
+		Str("bmc_endpoint", bmcEndpoint).
+		Bool("healthy", healthy).
+		Str("failed_hop", failed).
+		Msg("Deep health check completed")
+
+	return connect.NewResponse(&gatewayv1.DeepHealthCheckResponse{
+		ServerId: req.ServerId,
+		Healthy:  healthy,
+		Hops:     hops,
+	})
+}
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	managermodels "manager/pkg/models"
+)
+
+// SetLinkStatus sets the lookup of the last link probes of agents, reported
+// with the agent statuses
+func (h *RegionalGatewayHandler) SetLinkStatus(linkStatus func(agentID string) *gatewayv1.AgentLinkStatus) {
+	h.linkStatus = linkStatus
+}
+
+// GetGatewayStatus returns the registered agents, the console session counts
+// and the BMC endpoint mappings of the gateway. Like ListAgents it spans
+// customers, so it is restricted to admin tokens.
+func (h *RegionalGatewayHandler) GetGatewayStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetGatewayStatusRequest],
+) (*connect.Response[gatewayv1.GetGatewayStatusResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	return connect.NewResponse(h.GatewayStatus()), nil
+}
+
+// GatewayStatus describes the gateway, its agents and its console sessions,
+// for GetGatewayStatus and /status
+func (h *RegionalGatewayHandler) GatewayStatus() *gatewayv1.GetGatewayStatusResponse {
+	now := time.Now()
+	agents := h.agentRegistry.List()
+
+	status := &gatewayv1.GetGatewayStatusResponse{
+		GatewayId:       h.gatewayID,
+		Region:          h.region,
+		ManagerEndpoint: h.bmcManagerEndpoint,
+		Agents:          make([]*gatewayv1.AgentStatus, 0, len(agents)),
+		Sessions:        &gatewayv1.ConsoleSessionCounts{},
+		GeneratedAt:     timestamppb.New(now),
+	}
+
+	h.mu.RLock()
+	for _, agentInfo := range agents {
+		status.Agents = append(status.Agents, h.agentStatusLocked(agentInfo, now))
+	}
+	for _, mapping := range h.bmcEndpointMapping {
+		status.EndpointMappings = append(status.EndpointMappings, &gatewayv1.BMCEndpointMapping{
+			BmcEndpoint:  mapping.BMCEndpoint,
+			ServerId:     mapping.ServerID,
+			AgentId:      mapping.AgentID,
+			DatacenterId: mapping.DatacenterID,
+			BmcType:      convertBMCTypeToManagerProto(mapping.BMCType),
+			Status:       mapping.Status,
+			LastSeen:     timestamppb.New(mapping.LastSeen),
+		})
+	}
+	for sessionID, session := range h.consoleSessions {
+		if !now.Before(session.ExpiresAt) {
+			continue
+		}
+		status.Sessions.Total++
+		switch session.Type {
+		case "vnc":
+			status.Sessions.Vnc++
+		case "sol":
+			status.Sessions.Sol++
+		}
+		status.Sessions.AttachedStreams += int32(len(h.consoleStreams[sessionID]))
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(status.Agents, func(i, j int) bool {
+		return status.Agents[i].AgentId < status.Agents[j].AgentId
+	})
+	sort.Slice(status.EndpointMappings, func(i, j int) bool {
+		return status.EndpointMappings[i].BmcEndpoint < status.EndpointMappings[j].BmcEndpoint
+	})
+
+	return status
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,18 +15,26 @@ import (
 
 	commonauth "core/auth"
 	"core/domain"
+	"core/events"
 	commonv1 "core/gen/common/v1"
+	"core/idempotency"
+	"core/streaming"
+	"core/tracing"
 	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
-	"gateway/gen/gateway/v1/gatewayv1connect"
 	"gateway/internal/agent"
+	"gateway/internal/metering"
+	"gateway/internal/outbox"
 	"gateway/internal/session"
+	"gateway/internal/sli"
 	"gateway/pkg/server_context"
 	managerv1 "manager/gen/manager/v1"
 	"manager/gen/manager/v1/managerv1connect"
 	"manager/pkg/auth"
+	managermodels "manager/pkg/models"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -32,19 +42,40 @@ import (
 const (
 	PowerOpPowerOn    = "PowerOn"
 	PowerOpPowerOff   = "PowerOff"
+	PowerOpForceOff   = "ForceOff"
 	PowerOpPowerCycle = "PowerCycle"
 	PowerOpReset      = "Reset"
+
+	PowerOpDiagnosticInterrupt = "DiagnosticInterrupt"
+	PowerOpGracefulShutdown    = "GracefulShutdown"
 )
 
 // ConsoleSession represents a unified session for both VNC and SOL console access
 type ConsoleSession struct {
-	SessionID   string
-	ServerID    string
-	BMCEndpoint string
-	AgentID     string
-	CustomerID  string
-	CreatedAt   time.Time
-	ExpiresAt   time.Time
+	SessionID      string
+	Type           string // "sol" or "vnc"
+	ServerID       string
+	BMCEndpoint    string
+	AgentID        string
+	CustomerID     string
+	CustomerEmail  string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	SOLSettings    streaming.SOLSettings // Serial settings requested for a SOL session
+	ReadOnly       bool                  // Client input is dropped, to observe the console
+	KeyboardLayout string                // Layout VNC key events are translated from, empty for "us"
+	ImpersonatedBy string                // Admin who opened the session on behalf of the customer, if any
+
+	// Last console input of the session's streams, shared with the renewed
+	// copies of the session. Nil for the session's creation time.
+	Activity *streaming.Activity
+
+	// Customer whose session quota the session counts against, if any
+	QuotaCustomerID string
+
+	// Source IP ranges clients attach from, from the customer's allowlist.
+	// Empty for any.
+	AllowedSourceRanges []string
 }
 
 // Legacy type aliases for backward compatibility
@@ -58,10 +89,13 @@ type RegionalGatewayHandler struct {
 	serverContextDecryptor *server_context.ServerContextDecryptor
 	gatewayID              string
 	region                 string
-	externalEndpoint       string // External endpoint for VNC/console URLs
+	endpoint               string // Endpoint registered with the manager, advertised to clients
+	externalURL            string // Base URL of VNC/console URLs, e.g. https://gateway.example.com
 	managerClient          managerv1connect.BMCManagerServiceClient
-	httpClient             *http.Client
-	testMode               bool // Skip external calls during testing
+	managerEmail           string // Account authenticating with the manager
+	managerPassword        string
+	agentClients           *agent.ClientPool // RPC clients of the agents, reusing connections
+	testMode               bool              // Skip external calls during testing
 
 	// In-memory state (rebuilt on restart via agent re-registration).
 	agentRegistry *agent.Registry
@@ -69,16 +103,73 @@ type RegionalGatewayHandler struct {
 	bmcEndpointMapping map[string]*domain.AgentBMCMapping
 	// Unified console session store (works for both VNC and SOL)
 	consoleSessions map[string]*ConsoleSession
+	// session_id -> client streams attached to the session
+	consoleStreams map[string]map[uint64]*AttachedStream
+	nextStreamKey  uint64
+	// stream_id -> console streams of browsers, see WatchConsoleData
+	browserStreams map[string]*browserConsoleStream
+	// customer_id -> console session creations in the last minute, for
+	// session quotas
+	sessionCreations map[string][]time.Time
+	// Revoked customer tokens, synced from the manager
+	tokenRevocations tokenRevocations
+	// How long renewals can keep a console session open
+	consoleMaxLifetime time.Duration
 	// Web session store for cookie-based authentication
 	webSessionStore session.Store
-	mu              sync.RWMutex
+	webSessionTTL   time.Duration
+	// CSRF tokens of cookie-authenticated viewer pages
+	csrf *session.CSRFProtector
+	// Signed access tokens embedded in console URLs
+	accessTokens *session.AccessSigner
+	// Console session SLI measurements pending report to the manager
+	consoleSLIs *sli.Recorder
+	// System events pending report to the manager
+	eventOutbox *outbox.Outbox
+	// Publishes system events to the event bus, may be nil
+	eventBus events.Publisher
+	// Samples server power consumption for the usage report, may be nil
+	powerSampler *metering.Sampler
+	// Results of the power operations sent with an idempotency key
+	powerOperations *idempotency.Cache[*gatewayv1.PowerOperationResponse]
+	// Told of the power operations answered from powerOperations, e.g. for
+	// metrics
+	powerDeduplicationObserver func(operation string)
+	// Operations started with StartPowerOperation
+	operations *operationTracker
+	// Told of the operations of operations once completed, e.g. for metrics
+	operationObserver func(op *gatewayv1.Operation)
+	// Returns the last link probe of an agent, nil until probed, for agent
+	// statuses. May be nil.
+	linkStatus func(agentID string) *gatewayv1.AgentLinkStatus
+	// Receives the summary of every closed console stream, e.g. for metrics
+	consoleStreamObserver func(streaming.SessionSummary)
+	// Injects faults into console streams to agents, nil unless testing
+	// resilience
+	streamFaults *streaming.FaultInjector
+	// Pools the buffers of browser messages proxied to agents
+	streamBuffers *streaming.BufferPool
+	// Largest data of VNC stream chunks exchanged with agents
+	streamMaxChunkSize int
+	// Checksum the chunks exchanged with agents supporting it
+	streamChecksums bool
+	// Counts the open console WebSockets against their limits
+	streamAdmission streamAdmission
+	// Told of the corrupted chunks received from agents, e.g. for metrics
+	streamCorruptionObserver func(protocol string)
+	// Signs the tokens agents verify in console stream handshakes
+	agentStreamTokens *streaming.TokenSigner
+	// Asks the manager whether console sessions are still authorized when
+	// clients attach, nil when disabled
+	sessionValidation *sessionValidator
+	mu                sync.RWMutex
 }
 
 // NewGatewayHandler creates a GatewayHandler.
 func NewGatewayHandler(
 	bmcManagerEndpoint string,
 	jwtManager *auth.JWTManager,
-	gatewayID, region, externalEndpoint string,
+	gatewayID, region, endpoint, externalURL string,
 ) *RegionalGatewayHandler {
 	// Create HTTP client for manager communication
 	httpClient := &http.Client{
@@ -89,25 +180,48 @@ func NewGatewayHandler(
 	managerClient := managerv1connect.NewBMCManagerServiceClient(
 		httpClient,
 		bmcManagerEndpoint,
+		connect.WithInterceptors(tracing.NewInterceptor()),
 	)
 
 	// Create server context decryptor with same key as JWT manager.
 	serverContextDecryptor := server_context.NewServerContextDecryptor("your-secret-key-change-in-production")
 
+	// Random stream token key until one is configured
+	agentStreamTokens, err := streaming.NewTokenSigner(nil)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	return &RegionalGatewayHandler{
 		bmcManagerEndpoint:     bmcManagerEndpoint,
 		jwtManager:             jwtManager,
 		serverContextDecryptor: serverContextDecryptor,
 		gatewayID:              gatewayID,
 		region:                 region,
-		externalEndpoint:       externalEndpoint,
+		endpoint:               strings.TrimSuffix(endpoint, "/"),
+		externalURL:            strings.TrimSuffix(externalURL, "/"),
 		managerClient:          managerClient,
-		httpClient:             httpClient,
+		agentClients:           agent.NewClientPool(agent.DefaultClientPoolConfig()),
+		streamBuffers:          streaming.NewBufferPool(0),
+		streamMaxChunkSize:     streaming.DefaultChunkSize,
+		streamAdmission:        streamAdmission{limits: DefaultStreamLimits()},
+		powerOperations:        idempotency.NewCache[*gatewayv1.PowerOperationResponse](idempotency.DefaultWindow),
+		operations:             newOperationTracker(DefaultOperationRetention, DefaultOperationTimeout),
+		agentStreamTokens:      agentStreamTokens,
 		testMode:               false,
 		agentRegistry:          agent.NewRegistry(),
 		bmcEndpointMapping:     make(map[string]*domain.AgentBMCMapping),
 		webSessionStore:        session.NewInMemoryStore(),
+		webSessionTTL:          session.DefaultSessionDuration,
+		csrf:                   session.NewCSRFProtector(""),
+		accessTokens:           session.NewAccessSigner(""),
 		consoleSessions:        make(map[string]*ConsoleSession),
+		consoleStreams:         make(map[string]map[uint64]*AttachedStream),
+		browserStreams:         make(map[string]*browserConsoleStream),
+		sessionCreations:       make(map[string][]time.Time),
+		consoleMaxLifetime:     DefaultConsoleSessionMaxLifetime,
+		consoleSLIs:            sli.NewRecorder(sli.DefaultMaxPending),
+		eventOutbox:            outbox.New(outbox.DefaultMaxPending),
 	}
 }
 
@@ -119,6 +233,7 @@ func (h *RegionalGatewayHandler) TokenValidationInterceptor() connect.UnaryInter
 			// Skip validation for agent registration and health checks
 			if req.Spec().Procedure == "/gateway.v1.GatewayService/RegisterAgent" ||
 				req.Spec().Procedure == "/gateway.v1.GatewayService/AgentHeartbeat" ||
+				req.Spec().Procedure == "/gateway.v1.GatewayService/ReportHostDrift" ||
 				req.Spec().Procedure == "/gateway.v1.GatewayService/HealthCheck" {
 				return next(ctx, req)
 			}
@@ -139,7 +254,7 @@ func (h *RegionalGatewayHandler) TokenValidationInterceptor() connect.UnaryInter
 				Str("procedure", req.Spec().Procedure).
 				Msg("Validating server token")
 
-			claims, serverContext, err := h.jwtManager.ValidateServerToken(token)
+			claims, serverContext, err := h.validateToken(token)
 			if err != nil {
 				log.Error().
 					Err(err).
@@ -167,10 +282,23 @@ func (h *RegionalGatewayHandler) TokenValidationInterceptor() connect.UnaryInter
 					Features:     serverContext.Features,
 					DatacenterID: serverContext.DatacenterID,
 					Permissions:  serverContext.Permissions,
+					Maintenance:  serverContext.Maintenance,
 					IssuedAt:     serverContext.IssuedAt,
 					ExpiresAt:    serverContext.ExpiresAt,
+
+					MaintenanceWindows: serverContext.MaintenanceWindows,
+					SessionQuota:       serverContext.SessionQuota,
+
+					AllowedSourceRanges: serverContext.AllowedSourceRanges,
+
+					ImpersonatedBy: serverContext.ImpersonatedBy,
 				}
 				ctx = context.WithValue(ctx, "server_context", gatewayServerContext)
+
+				// Customers may restrict their BMC access to their networks
+				if err := checkSourceAllowed(claims.CustomerID, serverContext.AllowedSourceRanges, req.Peer().Addr); err != nil {
+					return nil, err
+				}
 			}
 
 			// Token is already in context from AuthInterceptor
@@ -208,6 +336,7 @@ func (h *RegionalGatewayHandler) RegisterAgent(
 	log.Info().
 		Str("agent_id", req.Msg.AgentId).
 		Str("datacenter_id", req.Msg.DatacenterId).
+		Str("version", req.Msg.Version).
 		Msg("Agent registration")
 
 	h.mu.Lock()
@@ -218,12 +347,14 @@ func (h *RegionalGatewayHandler) RegisterAgent(
 		ID:           req.Msg.AgentId,
 		DatacenterID: req.Msg.DatacenterId,
 		Endpoint:     req.Msg.Endpoint,
+		Version:      req.Msg.Version,
 		LastSeen:     time.Now(),
 	}
 	h.agentRegistry.Register(agentInfo)
 
 	// Update BMC endpoint mappings (no more server concepts at gateway level)
 	// Process ALL control endpoints for each server (RFD 006 multi-protocol support)
+	registered := make(map[string]bool)
 	for _, bmcEndpoint := range req.Msg.BmcEndpoints {
 		// Convert protobuf metadata map to Go map
 		metadata := make(map[string]string)
@@ -256,6 +387,7 @@ func (h *RegionalGatewayHandler) RegisterAgent(
 					DiscoveryMetadata: types.ConvertDiscoveryMetadataFromProto(bmcEndpoint.DiscoveryMetadata),
 				}
 				h.bmcEndpointMapping[bmcEndpointAddr] = mapping
+				registered[bmcEndpointAddr] = true
 				log.Debug().
 					Str("server_id", bmcEndpoint.ServerId).
 					Str("bmc_endpoint", bmcEndpointAddr).
@@ -268,6 +400,19 @@ func (h *RegionalGatewayHandler) RegisterAgent(
 		}
 	}
 
+	// A registration lists all servers of the agent: deregister the endpoints
+	// it no longer lists, e.g. static hosts removed from its configuration
+	for bmcEndpointAddr, mapping := range h.bmcEndpointMapping {
+		if mapping.AgentID == req.Msg.AgentId && !registered[bmcEndpointAddr] {
+			delete(h.bmcEndpointMapping, bmcEndpointAddr)
+			log.Info().
+				Str("server_id", mapping.ServerID).
+				Str("bmc_endpoint", bmcEndpointAddr).
+				Str("agent_id", req.Msg.AgentId).
+				Msg("Removed BMC endpoint no longer registered by agent")
+		}
+	}
+
 	// Report available BMC endpoints to manager (new architecture)
 	// TODO: Temporarily disabled to fix agent registration timeout issue
 	// The manager reporting call is hanging and causing agent timeouts
@@ -287,8 +432,9 @@ func (h *RegionalGatewayHandler) RegisterAgent(
 	}()
 
 	resp := &gatewayv1.RegisterAgentResponse{
-		Success: true,
-		Message: fmt.Sprintf("Agent %s registered successfully with %d BMC endpoints", req.Msg.AgentId, len(req.Msg.BmcEndpoints)),
+		Success:        true,
+		Message:        fmt.Sprintf("Agent %s registered successfully with %d BMC endpoints", req.Msg.AgentId, len(req.Msg.BmcEndpoints)),
+		StreamTokenKey: h.agentStreamTokens.PublicKey(),
 	}
 
 	return connect.NewResponse(resp), nil
@@ -356,6 +502,191 @@ func (h *RegionalGatewayHandler) AgentHeartbeat(
 	return connect.NewResponse(resp), nil
 }
 
+// GetAgentStatus returns the gateway's view of a registered Local Agent.
+// Agent inventory spans customers, so this is restricted to admin tokens.
+func (h *RegionalGatewayHandler) GetAgentStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetAgentStatusRequest],
+) (*connect.Response[gatewayv1.GetAgentStatusResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	if req.Msg.AgentId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id is required"))
+	}
+
+	agentInfo := h.agentRegistry.Get(req.Msg.AgentId)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", req.Msg.AgentId))
+	}
+
+	h.mu.RLock()
+	status := h.agentStatusLocked(agentInfo, time.Now())
+	h.mu.RUnlock()
+
+	log.Debug().
+		Str("agent_id", agentInfo.ID).
+		Str("customer_id", claims.CustomerID).
+		Int("bmc_endpoints", len(status.BmcEndpoints)).
+		Msg("Agent status requested")
+
+	return connect.NewResponse(&gatewayv1.GetAgentStatusResponse{Agent: status}), nil
+}
+
+// ListAgents returns the gateway's view of all its registered Local Agents,
+// optionally of a single datacenter. Restricted to admin tokens like
+// GetAgentStatus.
+func (h *RegionalGatewayHandler) ListAgents(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListAgentsRequest],
+) (*connect.Response[gatewayv1.ListAgentsResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	var agents []*agent.Info
+	if req.Msg.DatacenterId != "" {
+		agents = h.agentRegistry.GetByDatacenter(req.Msg.DatacenterId)
+	} else {
+		agents = h.agentRegistry.List()
+	}
+
+	now := time.Now()
+	statuses := make([]*gatewayv1.AgentStatus, 0, len(agents))
+
+	h.mu.RLock()
+	for _, agentInfo := range agents {
+		statuses = append(statuses, h.agentStatusLocked(agentInfo, now))
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].AgentId < statuses[j].AgentId
+	})
+
+	log.Debug().
+		Str("customer_id", claims.CustomerID).
+		Str("datacenter_id", req.Msg.DatacenterId).
+		Int("agents", len(statuses)).
+		Msg("Agents listed")
+
+	return connect.NewResponse(&gatewayv1.ListAgentsResponse{Agents: statuses}), nil
+}
+
+// agentStatusLocked describes a registered agent, with its BMC endpoints and
+// the console sessions routed through it. Callers hold mu.
+func (h *RegionalGatewayHandler) agentStatusLocked(agentInfo *agent.Info, now time.Time) *gatewayv1.AgentStatus {
+	status := &gatewayv1.AgentStatus{
+		AgentId:      agentInfo.ID,
+		DatacenterId: agentInfo.DatacenterID,
+		Endpoint:     agentInfo.Endpoint,
+		Status:       agentInfo.Status,
+		Version:      agentInfo.Version,
+		RegisteredAt: timestamppb.New(agentInfo.RegisteredAt),
+		LastSeen:     timestamppb.New(agentInfo.LastSeen),
+		GatewayId:    h.gatewayID,
+	}
+	if h.linkStatus != nil {
+		status.Link = h.linkStatus(agentInfo.ID)
+	}
+
+	for _, mapping := range h.bmcEndpointMapping {
+		if mapping.AgentID != agentInfo.ID {
+			continue
+		}
+		status.BmcEndpoints = append(status.BmcEndpoints, &gatewayv1.AgentBMCEndpointStatus{
+			ServerId:    mapping.ServerID,
+			BmcEndpoint: mapping.BMCEndpoint,
+			BmcType:     convertBMCTypeToManagerProto(mapping.BMCType),
+			Status:      mapping.Status,
+			LastSeen:    timestamppb.New(mapping.LastSeen),
+			Features:    mapping.Features,
+		})
+	}
+	for _, session := range h.consoleSessions {
+		if session.AgentID == agentInfo.ID && now.Before(session.ExpiresAt) {
+			status.ActiveSessionCount++
+		}
+	}
+
+	sort.Slice(status.BmcEndpoints, func(i, j int) bool {
+		if status.BmcEndpoints[i].ServerId != status.BmcEndpoints[j].ServerId {
+			return status.BmcEndpoints[i].ServerId < status.BmcEndpoints[j].ServerId
+		}
+		return status.BmcEndpoints[i].BmcEndpoint < status.BmcEndpoints[j].BmcEndpoint
+	})
+
+	return status
+}
+
+// ListActiveSessions returns the BMC console connections held open by a
+// Local Agent, as reported by the agent itself. Like GetAgentStatus it spans
+// customers, so it is restricted to admin tokens.
+func (h *RegionalGatewayHandler) ListActiveSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListActiveSessionsRequest],
+) (*connect.Response[gatewayv1.ListActiveSessionsResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	if req.Msg.AgentId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id is required"))
+	}
+
+	agentInfo := h.agentRegistry.Get(req.Msg.AgentId)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", req.Msg.AgentId))
+	}
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+
+	resp, err := agentClient.ListActiveSessions(ctx, connect.NewRequest(&gatewayv1.ListActiveSessionsRequest{
+		AgentId:  agentInfo.ID,
+		ServerId: req.Msg.ServerId,
+	}))
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("agent_id", agentInfo.ID).
+			Msg("List active sessions request failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("agent_id", agentInfo.ID).
+		Str("customer_id", claims.CustomerID).
+		Int("sessions", len(resp.Msg.Sessions)).
+		Msg("Agent sessions requested")
+
+	return resp, nil
+}
+
+// ProbeLink is implemented by agents: gateways probe the links to their
+// agents, not the other way around
+func (h *RegionalGatewayHandler) ProbeLink(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ProbeLinkRequest],
+) (*connect.Response[gatewayv1.ProbeLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("gateways do not implement ProbeLink"))
+}
+
 // extractServerContextFromJWT extracts server context from JWT token in the
 // request.
 func (h *RegionalGatewayHandler) extractServerContextFromJWT(
@@ -374,7 +705,7 @@ func (h *RegionalGatewayHandler) extractServerContextFromJWT(
 	}
 
 	// Validate the JWT token
-	_, managerServerContext, err := h.jwtManager.ValidateServerToken(token)
+	_, managerServerContext, err := h.validateToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate server token: %w", err)
 	}
@@ -393,8 +724,16 @@ func (h *RegionalGatewayHandler) extractServerContextFromJWT(
 		Features:     managerServerContext.Features,
 		DatacenterID: managerServerContext.DatacenterID,
 		Permissions:  managerServerContext.Permissions,
+		Maintenance:  managerServerContext.Maintenance,
 		IssuedAt:     managerServerContext.IssuedAt,
 		ExpiresAt:    managerServerContext.ExpiresAt,
+
+		MaintenanceWindows: managerServerContext.MaintenanceWindows,
+		SessionQuota:       managerServerContext.SessionQuota,
+
+		AllowedSourceRanges: managerServerContext.AllowedSourceRanges,
+
+		ImpersonatedBy: managerServerContext.ImpersonatedBy,
 	}
 
 	return gatewayServerContext, nil
@@ -424,8 +763,12 @@ func (h *RegionalGatewayHandler) PowerOn(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
 	}
 
+	if err := h.authorizeMaintenance(serverContext, PowerOpPowerOn, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
 	// Forward directly to agent using BMC endpoint from token
-	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerOn)
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerOn, req.Msg.IdempotencyKey)
 }
 
 // PowerOff executes a PowerOff power operation.
@@ -449,8 +792,41 @@ func (h *RegionalGatewayHandler) PowerOff(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
 	}
 
+	if err := h.authorizeMaintenance(serverContext, PowerOpPowerOff, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
 	// Forward directly to agent using BMC endpoint from token
-	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerOff)
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerOff, req.Msg.IdempotencyKey)
+}
+
+// ForceOff executes a ForceOff power operation.
+func (h *RegionalGatewayHandler) ForceOff(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	// Extract server context from JWT token
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	// Validate server ID matches token context
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	// Check permissions
+	if !serverContext.HasPermission("power:write") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
+	}
+
+	if err := h.authorizeMaintenance(serverContext, PowerOpForceOff, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
+	// Forward directly to agent using BMC endpoint from token
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpForceOff, req.Msg.IdempotencyKey)
 }
 
 // PowerCycle executes a PowerCycle power operation.
@@ -474,8 +850,12 @@ func (h *RegionalGatewayHandler) PowerCycle(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
 	}
 
+	if err := h.authorizeMaintenance(serverContext, PowerOpPowerCycle, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
 	// Forward directly to agent using BMC endpoint from token
-	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerCycle)
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpPowerCycle, req.Msg.IdempotencyKey)
 }
 
 // Reset executes a Reset power operation.
@@ -499,8 +879,42 @@ func (h *RegionalGatewayHandler) Reset(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
 	}
 
+	if err := h.authorizeMaintenance(serverContext, PowerOpReset, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
 	// Forward directly to agent using BMC endpoint from token
-	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpReset)
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpReset, req.Msg.IdempotencyKey)
+}
+
+// DiagnosticInterrupt sends an NMI to the server. It crashes the OS, so it
+// requires power:diag rather than power:write.
+func (h *RegionalGatewayHandler) DiagnosticInterrupt(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	// Extract server context from JWT token
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	// Validate server ID matches token context
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	// Check permissions
+	if !serverContext.HasPermission("power:diag") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for diagnostic interrupt"))
+	}
+
+	if err := h.authorizeMaintenance(serverContext, PowerOpDiagnosticInterrupt, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
+	// Forward directly to agent using BMC endpoint from token
+	return h.proxyPowerOperation(ctx, serverContext.BMCEndpoint, PowerOpDiagnosticInterrupt, req.Msg.IdempotencyKey)
 }
 
 // GetPowerStatus obtains the power status.
@@ -545,11 +959,8 @@ func (h *RegionalGatewayHandler) GetPowerStatus(
 		Str("agent_endpoint", agentInfo.Endpoint).
 		Msg("Proxying power status request to agent")
 
-	// Create RPC client for the agent
-	agentClient := gatewayv1connect.NewGatewayServiceClient(
-		h.httpClient,
-		agentInfo.Endpoint,
-	)
+	// Pooled RPC client for the agent
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
 
 	// Create request for power status
 	agentReq := connect.NewRequest(&gatewayv1.PowerStatusRequest{
@@ -618,11 +1029,8 @@ func (h *RegionalGatewayHandler) GetBMCInfo(
 		Str("agent_endpoint", agentInfo.Endpoint).
 		Msg("Proxying BMC info request to agent")
 
-	// Create RPC client for the agent
-	agentClient := gatewayv1connect.NewGatewayServiceClient(
-		h.httpClient,
-		agentInfo.Endpoint,
-	)
+	// Pooled RPC client for the agent
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
 
 	// Create request for BMC info
 	agentReq := connect.NewRequest(&gatewayv1.GetBMCInfoRequest{
@@ -653,7 +1061,8 @@ func (h *RegionalGatewayHandler) GetBMCInfo(
 func (h *RegionalGatewayHandler) proxyPowerOperation(
 	ctx context.Context,
 	bmcEndpoint,
-	operation string,
+	operation,
+	idempotencyKey string,
 ) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
 	h.mu.RLock()
 	mapping, exists := h.bmcEndpointMapping[bmcEndpoint]
@@ -668,6 +1077,23 @@ func (h *RegionalGatewayHandler) proxyPowerOperation(
 		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
 	}
 
+	// Retries with the idempotency key of an operation get its result
+	return h.deduplicatePowerOperation(ctx, mapping.ServerID, operation, idempotencyKey,
+		func(ctx context.Context) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+			return h.callPowerOperation(ctx, agentInfo, mapping, operation, idempotencyKey)
+		})
+}
+
+// callPowerOperation performs a power operation on the agent of a server
+func (h *RegionalGatewayHandler) callPowerOperation(
+	ctx context.Context,
+	agentInfo *agent.Info,
+	mapping *domain.AgentBMCMapping,
+	operation,
+	idempotencyKey string,
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	bmcEndpoint := mapping.BMCEndpoint
+
 	log.Info().
 		Str("operation", operation).
 		Str("bmc_endpoint", bmcEndpoint).
@@ -675,16 +1101,16 @@ func (h *RegionalGatewayHandler) proxyPowerOperation(
 		Str("agent_endpoint", agentInfo.Endpoint).
 		Msg("Proxying power operation to agent")
 
-	// Create RPC client for the agent
-	agentClient := gatewayv1connect.NewGatewayServiceClient(
-		h.httpClient,
-		agentInfo.Endpoint,
-	)
+	// Pooled RPC client for the agent
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
 
 	// Create request for the power operation
 	// Note: We pass the server_id from the mapping, not the BMC endpoint
+	// The agent also deduplicates the key, for the retries of calls failing
+	// here, e.g. timing out, that the agent completed
 	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
-		ServerId: mapping.ServerID,
+		ServerId:       mapping.ServerID,
+		IdempotencyKey: idempotencyKey,
 	})
 
 	// Call the appropriate operation on the agent
@@ -696,10 +1122,14 @@ func (h *RegionalGatewayHandler) proxyPowerOperation(
 		resp, err = agentClient.PowerOn(ctx, req)
 	case PowerOpPowerOff:
 		resp, err = agentClient.PowerOff(ctx, req)
+	case PowerOpForceOff:
+		resp, err = agentClient.ForceOff(ctx, req)
 	case PowerOpPowerCycle:
 		resp, err = agentClient.PowerCycle(ctx, req)
 	case PowerOpReset:
 		resp, err = agentClient.Reset(ctx, req)
+	case PowerOpDiagnosticInterrupt:
+		resp, err = agentClient.DiagnosticInterrupt(ctx, req)
 	default:
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown power operation: %s", operation))
 	}
@@ -711,6 +1141,7 @@ func (h *RegionalGatewayHandler) proxyPowerOperation(
 			Str("bmc_endpoint", bmcEndpoint).
 			Str("agent_id", mapping.AgentID).
 			Msg("Power operation failed")
+		h.publishPowerOperation(mapping, operation, false, err.Error())
 		return nil, err
 	}
 
@@ -720,9 +1151,28 @@ func (h *RegionalGatewayHandler) proxyPowerOperation(
 		Bool("success", resp.Msg.Success).
 		Msg("Power operation completed")
 
+	h.publishPowerOperation(mapping, operation, resp.Msg.Success, resp.Msg.Message)
 	return resp, nil
 }
 
+// publishPowerOperation records a power operation proxied to an agent for
+// the event report. detail is the error or the agent's message.
+func (h *RegionalGatewayHandler) publishPowerOperation(mapping *domain.AgentBMCMapping, operation string, success bool, detail string) {
+	data := map[string]any{
+		"gateway_id":    h.gatewayID,
+		"server_id":     mapping.ServerID,
+		"bmc_endpoint":  mapping.BMCEndpoint,
+		"agent_id":      mapping.AgentID,
+		"datacenter_id": mapping.DatacenterID,
+		"operation":     operation,
+		"success":       success,
+	}
+	if !success {
+		data["error"] = detail
+	}
+	h.publishEvent(events.New(events.PowerOperationExecuted, "gateway", data))
+}
+
 // VNC Console Session Management
 
 // CreateVNCSession creates a new VNC console session for remote access
@@ -741,10 +1191,23 @@ func (h *RegionalGatewayHandler) CreateVNCSession(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
 	}
 
-	// Check permissions for console access (accept either console:write or console:access)
-	if !serverContext.HasPermission("console:write") && !serverContext.HasPermission("console:access") {
+	// Check permissions for console access (accept either console:write or
+	// console:access, or console:read for read-only sessions)
+	if !hasConsolePermission(serverContext, req.Msg.ReadOnly) {
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for console access"))
 	}
+	if err := h.checkConsoleMFA(ctx); err != nil {
+		return nil, err
+	}
+
+	keyboardLayout := strings.ToLower(req.Msg.KeyboardLayout)
+	if keyboardLayout == "us" {
+		keyboardLayout = ""
+	}
+	if _, ok := streaming.KeymapOf(keyboardLayout); keyboardLayout != "" && !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown keyboard layout %q, supported layouts: %s",
+			req.Msg.KeyboardLayout, strings.Join(streaming.KeyboardLayouts(), ", ")))
+	}
 
 	// TODO: Re-enable feature check once agents properly report console/kvm features
 	// For now, skip feature validation to allow VNC session creation for testing
@@ -767,32 +1230,42 @@ func (h *RegionalGatewayHandler) CreateVNCSession(
 		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
 	}
 
-	// Generate unique session ID using timestamp (same format as SOL for consistency)
-	sessionID := fmt.Sprintf("vnc-%d", time.Now().UnixNano())
-
-	// Create WebSocket endpoint URL using external endpoint
-	websocketEndpoint := fmt.Sprintf("ws://%s/vnc/%s/ws", h.externalEndpoint, sessionID)
-
-	// Create viewer URL using external endpoint
-	viewerURL := fmt.Sprintf("http://%s/vnc/%s", h.externalEndpoint, sessionID)
+	// Generate an unguessable session ID, as it appears in the viewer URL
+	sessionID, err := newConsoleSessionID("vnc")
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
-	// Set expiration time (1 hour from now)
-	expiresAt := time.Now().Add(time.Hour)
+	now := time.Now()
+	expiresAt := now.Add(vncSessionTTL)
 
 	// Store the console session (works for both VNC and SOL)
-	h.mu.Lock()
-	h.consoleSessions[sessionID] = &ConsoleSession{
-		SessionID:   sessionID,
-		ServerID:    serverContext.ServerID,
-		BMCEndpoint: serverContext.BMCEndpoint,
-		AgentID:     mapping.AgentID,
-		CustomerID:  serverContext.CustomerID,
-		CreatedAt:   time.Now(),
-		ExpiresAt:   expiresAt,
+	consoleSession := &ConsoleSession{
+		SessionID:      sessionID,
+		Type:           "vnc",
+		ServerID:       serverContext.ServerID,
+		BMCEndpoint:    serverContext.BMCEndpoint,
+		AgentID:        mapping.AgentID,
+		CustomerID:     serverContext.CustomerID,
+		CustomerEmail:  claimsEmail(ctx),
+		CreatedAt:      now,
+		ExpiresAt:      expiresAt,
+		ReadOnly:       req.Msg.ReadOnly,
+		KeyboardLayout: keyboardLayout,
+		ImpersonatedBy: serverContext.ImpersonatedBy,
+		Activity:       streaming.NewActivity(now),
+
+		AllowedSourceRanges: serverContext.AllowedSourceRanges,
+	}
+	if err := h.storeConsoleSession(consoleSession, serverContext.SessionQuota); err != nil {
+		return nil, err
 	}
-	h.mu.Unlock()
 
-	log.Info().Str("session_id", sessionID).Str("server_id", serverContext.ServerID).Str("customer_id", serverContext.CustomerID).Msg("Created VNC session")
+	// Create WebSocket endpoint and single-use viewer URLs using external endpoint
+	websocketEndpoint := withAccessToken(h.webSocketURL("/vnc/"+sessionID+"/ws"), h.StreamAccessToken(consoleSession))
+	viewerURL := h.viewerURL(consoleSession)
+
+	log.Info().Str("session_id", sessionID).Str("server_id", serverContext.ServerID).Str("customer_id", serverContext.CustomerID).Bool("read_only", req.Msg.ReadOnly).Str("keyboard_layout", keyboardLayout).Str("impersonated_by", serverContext.ImpersonatedBy).Msg("Created VNC session")
 
 	resp := &gatewayv1.CreateVNCSessionResponse{
 		SessionId:         sessionID,
@@ -840,38 +1313,115 @@ func (h *RegionalGatewayHandler) GetConsoleSessionByID(sessionID string) (*Conso
 	return session, true
 }
 
+// StartConsoleSLI begins measuring a console stream for the SLO report. The
+// returned attempt must be finished when the stream ends.
+func (h *RegionalGatewayHandler) StartConsoleSLI(consoleSession *ConsoleSession, sessionType string) *sli.Attempt {
+	datacenterID := ""
+	if agentInfo := h.agentRegistry.Get(consoleSession.AgentID); agentInfo != nil {
+		datacenterID = agentInfo.DatacenterID
+	}
+	return h.consoleSLIs.Start(consoleSession.SessionID, consoleSession.AgentID, datacenterID, sessionType)
+}
+
+// SetEventBus sets the publisher of the event bus, which receives the
+// gateway's events as they happen, in addition to the event report
+func (h *RegionalGatewayHandler) SetEventBus(publisher events.Publisher) {
+	h.eventBus = publisher
+}
+
+// publishEvent queues an event for the event report to the manager and
+// publishes it to the event bus
+func (h *RegionalGatewayHandler) publishEvent(event events.Event) {
+	h.eventOutbox.Publish(event)
+	if h.eventBus != nil {
+		h.eventBus.Publish(event)
+	}
+}
+
+// PublishConsoleSessionOpened records that a console stream of a session was
+// established with its agent, for the event report
+func (h *RegionalGatewayHandler) PublishConsoleSessionOpened(consoleSession *ConsoleSession, sessionType string) {
+	h.publishEvent(events.New(events.ConsoleSessionOpened, "gateway", map[string]any{
+		"gateway_id":   h.gatewayID,
+		"session_id":   consoleSession.SessionID,
+		"session_type": sessionType,
+		"server_id":    consoleSession.ServerID,
+		"customer_id":  consoleSession.CustomerID,
+		"agent_id":     consoleSession.AgentID,
+	}))
+}
+
+// publishConsoleSessionClosed records that a console stream of a session
+// ended, with its duration and data transferred, for the event report
+func (h *RegionalGatewayHandler) publishConsoleSessionClosed(consoleSession *ConsoleSession, summary streaming.SessionSummary) {
+	h.publishEvent(events.New(events.ConsoleSessionClosed, "gateway", map[string]any{
+		"gateway_id":   h.gatewayID,
+		"session_id":   consoleSession.SessionID,
+		"session_type": consoleSession.Type,
+		"server_id":    consoleSession.ServerID,
+		"customer_id":  consoleSession.CustomerID,
+		"agent_id":     consoleSession.AgentID,
+		"transport":    summary.Transport,
+		"started_at":   summary.StartedAt.UTC().Format(time.RFC3339),
+		"duration_ms":  summary.Duration.Milliseconds(),
+		"bytes_in":     summary.BytesIn,
+		"bytes_out":    summary.BytesOut,
+		"reason":       string(summary.Reason),
+	}))
+}
+
 // GetAgentRegistry returns the agent registry for accessing agent information
 func (h *RegionalGatewayHandler) GetAgentRegistry() *agent.Registry {
 	return h.agentRegistry
 }
 
+// SetAgentClients sets the pool of RPC clients used to reach the agents
+func (h *RegionalGatewayHandler) SetAgentClients(pool *agent.ClientPool) {
+	h.agentClients = pool
+}
+
+// AgentClients returns the pool of RPC clients used to reach the agents, to
+// share its connections with the console proxies
+func (h *RegionalGatewayHandler) AgentClients() *agent.ClientPool {
+	return h.agentClients
+}
+
 // GetVNCSession retrieves information about an existing VNC session
 func (h *RegionalGatewayHandler) GetVNCSession(
 	ctx context.Context,
 	req *connect.Request[gatewayv1.GetVNCSessionRequest],
 ) (*connect.Response[gatewayv1.GetVNCSessionResponse], error) {
-	// Get claims from context
-	claims, ok := ctx.Value("claims").(*commonauth.AuthClaims)
-	if !ok {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	// Extract server context from JWT token
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
 	}
 
 	sessionID := req.Msg.SessionId
 
-	// TODO: Implement actual session storage and retrieval
-	// For now, simulate a session response
-	log.Debug().Str("session_id", sessionID).Str("customer_id", claims.CustomerID).Msg("Retrieved VNC session")
+	// Retrieve session
+	consoleSession, exists := h.GetConsoleSessionByID(sessionID)
+	if !exists || consoleSession.Type != "vnc" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("VNC session not found: %s", sessionID))
+	}
+
+	// Verify customer owns this session
+	if consoleSession.CustomerID != serverContext.CustomerID {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("access denied"))
+	}
 
+	// Build response, issuing a fresh single-use viewer URL
 	vncSession := &gatewayv1.VNCSession{
 		Id:                sessionID,
-		CustomerId:        claims.CustomerID,
-		ServerId:          "server-001", // TODO: Get from actual session storage
-		AgentId:           "agent-001",  // TODO: Get from actual session storage
+		CustomerId:        consoleSession.CustomerID,
+		ServerId:          consoleSession.ServerID,
+		AgentId:           consoleSession.AgentID,
 		Status:            "active",
-		WebsocketEndpoint: fmt.Sprintf("ws://%s/vnc/%s/ws", h.externalEndpoint, sessionID),
-		ViewerUrl:         fmt.Sprintf("http://%s/vnc/%s", h.externalEndpoint, sessionID),
-		CreatedAt:         timestamppb.Now(),
-		ExpiresAt:         timestamppb.New(time.Now().Add(time.Hour)),
+		WebsocketEndpoint: withAccessToken(h.webSocketURL("/vnc/"+sessionID+"/ws"), h.StreamAccessToken(consoleSession)),
+		ViewerUrl:         h.viewerURL(consoleSession),
+		CreatedAt:         timestamppb.New(consoleSession.CreatedAt),
+		ExpiresAt:         timestamppb.New(consoleSession.ExpiresAt),
+		LastActivityAt:    timestamppb.New(consoleSession.LastActivityAt()),
 	}
 
 	resp := &gatewayv1.GetVNCSessionResponse{
@@ -922,9 +1472,22 @@ func (h *RegionalGatewayHandler) CreateSOLSession(
 	}
 
 	// Check permissions for console access
-	if !serverContext.HasPermission("console:write") && !serverContext.HasPermission("console:access") {
+	if !hasConsolePermission(serverContext, req.Msg.ReadOnly) {
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for console access"))
 	}
+	if err := h.checkConsoleMFA(ctx); err != nil {
+		return nil, err
+	}
+
+	// Serial settings are applied by the agent when the stream connects,
+	// reject unsupported ones before handing out a session
+	solSettings := streaming.SOLSettings{
+		BaudRate:    int(req.Msg.GetConfig().GetBaudRate()),
+		FlowControl: req.Msg.GetConfig().GetFlowControl(),
+	}
+	if err := solSettings.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
 	// Find the agent that handles this server's BMC
 	h.mu.RLock()
@@ -940,39 +1503,54 @@ func (h *RegionalGatewayHandler) CreateSOLSession(
 		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
 	}
 
-	// Generate unique session ID
-	sessionID := fmt.Sprintf("sol-%d", time.Now().UnixNano())
+	// Generate an unguessable session ID, as it appears in the console URL
+	sessionID, err := newConsoleSessionID("sol")
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 	now := time.Now()
-	expiresAt := now.Add(2 * time.Hour) // 2 hour session
+	expiresAt := now.Add(solSessionTTL)
 
 	// Create console session (unified for both VNC and SOL)
 	consoleSession := &ConsoleSession{
-		SessionID:   sessionID,
-		ServerID:    req.Msg.ServerId,
-		BMCEndpoint: serverContext.BMCEndpoint,
-		AgentID:     mapping.AgentID,
-		CustomerID:  serverContext.CustomerID,
-		CreatedAt:   now,
-		ExpiresAt:   expiresAt,
+		SessionID:      sessionID,
+		Type:           "sol",
+		ServerID:       req.Msg.ServerId,
+		BMCEndpoint:    serverContext.BMCEndpoint,
+		AgentID:        mapping.AgentID,
+		CustomerID:     serverContext.CustomerID,
+		CustomerEmail:  claimsEmail(ctx),
+		CreatedAt:      now,
+		ExpiresAt:      expiresAt,
+		SOLSettings:    solSettings,
+		ReadOnly:       req.Msg.ReadOnly,
+		ImpersonatedBy: serverContext.ImpersonatedBy,
+		Activity:       streaming.NewActivity(now),
+
+		AllowedSourceRanges: serverContext.AllowedSourceRanges,
+	}
+
+	// Store session, within the customer's session quota
+	if err := h.storeConsoleSession(consoleSession, serverContext.SessionQuota); err != nil {
+		return nil, err
 	}
 
-	// Store session
-	h.mu.Lock()
-	h.consoleSessions[sessionID] = consoleSession
-	h.mu.Unlock()
-
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", req.Msg.ServerId).
 		Str("customer_id", serverContext.CustomerID).
 		Str("agent_id", mapping.AgentID).
+		Int("baud_rate", solSettings.BaudRate).
+		Str("flow_control", solSettings.FlowControl).
+		Bool("read_only", req.Msg.ReadOnly).
+		Str("impersonated_by", serverContext.ImpersonatedBy).
 		Msg("Created SOL session")
 
 	// Build WebSocket endpoint for SOL streaming
-	wsEndpoint := fmt.Sprintf("ws://%s/sol/%s", h.externalEndpoint, sessionID)
+	wsEndpoint := withAccessToken(h.webSocketURL("/console/"+sessionID+"/ws"), h.StreamAccessToken(consoleSession))
 
-	// Build console URL - direct link to web-based SOL console
-	consoleURL := fmt.Sprintf("http://%s/console/%s", h.externalEndpoint, sessionID)
+	// Build console URL - single-use link to web-based SOL console
+	consoleURL := h.viewerURL(consoleSession)
 
 	resp := &gatewayv1.CreateSOLSessionResponse{
 		SessionId:         sessionID,
@@ -1013,9 +1591,9 @@ func (h *RegionalGatewayHandler) GetSOLSession(
 		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("access denied"))
 	}
 
-	// Build response
-	wsEndpoint := fmt.Sprintf("ws://%s/sol/%s", h.externalEndpoint, sessionID)
-	consoleURL := fmt.Sprintf("http://%s/console/%s", h.externalEndpoint, sessionID)
+	// Build response, issuing a fresh single-use console URL
+	wsEndpoint := withAccessToken(h.webSocketURL("/console/"+sessionID+"/ws"), h.StreamAccessToken(solSession))
+	consoleURL := h.viewerURL(solSession)
 
 	session := &gatewayv1.SOLSession{
 		Id:                sessionID,
@@ -1027,6 +1605,8 @@ func (h *RegionalGatewayHandler) GetSOLSession(
 		ConsoleUrl:        consoleURL,
 		CreatedAt:         timestamppb.New(solSession.CreatedAt),
 		ExpiresAt:         timestamppb.New(solSession.ExpiresAt),
+		ReadOnly:          solSession.ReadOnly,
+		LastActivityAt:    timestamppb.New(solSession.LastActivityAt()),
 	}
 
 	resp := &gatewayv1.GetSOLSessionResponse{
@@ -1080,19 +1660,17 @@ func (h *RegionalGatewayHandler) StartVNCProxy(
 	// Build the agent VNC WebSocket URL
 	// The agent expects: /vnc/{sessionId}/{bmcHost}?type={bmcType}
 	// Extract just the host:port from the agent endpoint (remove http:// if present)
-	agentHost := agentInfo.Endpoint
-	if strings.HasPrefix(agentHost, "http://") {
-		agentHost = strings.TrimPrefix(agentHost, "http://")
-	}
+	agentHost := endpointHost(agentInfo.Endpoint)
 
 	// Extract just the host:port from the BMC endpoint for the URL path
-	bmcHost := req.Msg.BmcEndpoint
-	if strings.HasPrefix(bmcHost, "http://") {
-		bmcHost = strings.TrimPrefix(bmcHost, "http://")
-	}
+	bmcHost := endpointHost(req.Msg.BmcEndpoint)
 
-	agentVNCURL := fmt.Sprintf("ws://%s/vnc/%s/%s?type=%s",
-		agentHost, req.Msg.SessionId, bmcHost, req.Msg.BmcType)
+	agentVNCURL := (&url.URL{
+		Scheme:   "ws",
+		Host:     agentHost,
+		Path:     "/vnc/" + req.Msg.SessionId + "/" + bmcHost,
+		RawQuery: url.Values{"type": {req.Msg.BmcType}}.Encode(),
+	}).String()
 
 	log.Debug().
 		Str("agent_vnc_url", agentVNCURL).
@@ -1110,12 +1688,30 @@ func (h *RegionalGatewayHandler) StartVNCProxy(
 	return connect.NewResponse(resp), nil
 }
 
+// endpointHost returns the host:port of an http:// endpoint, keeping the
+// brackets of IPv6 literals, or the endpoint itself when it has no scheme
+func endpointHost(endpoint string) string {
+	if !strings.HasPrefix(endpoint, "http://") {
+		return endpoint
+	}
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return strings.TrimPrefix(endpoint, "http://")
+}
+
+// SetManagerCredentials sets the account the gateway authenticates with at
+// the manager.
+func (h *RegionalGatewayHandler) SetManagerCredentials(email, password string) {
+	h.managerEmail = email
+	h.managerPassword = password
+}
+
 // authenticateWithManager gets an authentication token from the manager.
 func (h *RegionalGatewayHandler) authenticateWithManager(ctx context.Context) (string, error) {
-	// Use test credentials that match the test manager setup
 	authReq := &managerv1.AuthenticateRequest{
-		Email:    "test@example.com",
-		Password: "password",
+		Email:    h.managerEmail,
+		Password: h.managerPassword,
 	}
 
 	resp, err := h.managerClient.Authenticate(ctx, connect.NewRequest(authReq))
@@ -1141,7 +1737,7 @@ func (h *RegionalGatewayHandler) registerGatewayWithManager(ctx context.Context)
 	registerReq := &managerv1.RegisterGatewayRequest{
 		GatewayId:     h.gatewayID,
 		Region:        h.region,
-		Endpoint:      fmt.Sprintf("http://localhost:8081"), // TODO: Make this configurable
+		Endpoint:      h.endpoint,
 		DatacenterIds: datacenterIDs,
 	}
 
@@ -1150,8 +1746,12 @@ func (h *RegionalGatewayHandler) registerGatewayWithManager(ctx context.Context)
 	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	// Register with manager
-	_, err = h.managerClient.RegisterGateway(ctx, req)
-	return err
+	resp, err := h.managerClient.RegisterGateway(ctx, req)
+	if err != nil {
+		return err
+	}
+	h.tokenRevocations.replace(resp.Msg.TokenRevocations)
+	return nil
 }
 
 // getDatacenterIDs returns the list of datacenters this gateway currently
@@ -1206,6 +1806,18 @@ func (h *RegionalGatewayHandler) StartPeriodicRegistration(ctx context.Context)
 				} else {
 					log.Debug().Str("gateway_id", h.gatewayID).Msg("Successfully re-registered gateway with manager")
 				}
+
+				if err := h.reportConsoleSLIsToManager(ctx); err != nil {
+					log.Warn().Err(err).Msg("Failed to report console SLIs to manager")
+				}
+
+				if err := h.reportEventsToManager(ctx); err != nil {
+					log.Warn().Err(err).Msg("Failed to report events to manager")
+				}
+
+				if err := h.reportPowerReadingsToManager(ctx); err != nil {
+					log.Warn().Err(err).Msg("Failed to report power readings to manager")
+				}
 			}
 		}
 	}()
@@ -1283,6 +1895,106 @@ func (h *RegionalGatewayHandler) reportEndpointsToManager(ctx context.Context) e
 	return nil
 }
 
+// reportConsoleSLIsToManager sends buffered console session measurements to
+// the manager. Measurements are kept for the next attempt if reporting fails.
+func (h *RegionalGatewayHandler) reportConsoleSLIsToManager(ctx context.Context) error {
+	// Skip manager reporting in test mode
+	if h.testMode {
+		return nil
+	}
+
+	sessions := h.consoleSLIs.Drain()
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	reportReq := &managerv1.ReportConsoleSLIsRequest{
+		GatewayId: h.gatewayID,
+		Sessions:  make([]*managerv1.ConsoleSessionSLI, 0, len(sessions)),
+	}
+	for _, s := range sessions {
+		reportReq.Sessions = append(reportReq.Sessions, &managerv1.ConsoleSessionSLI{
+			SessionId:         s.SessionID,
+			AgentId:           s.AgentID,
+			DatacenterId:      s.DatacenterID,
+			SessionType:       s.SessionType,
+			StartedAt:         timestamppb.New(s.StartedAt),
+			Established:       s.Established,
+			FirstByte:         s.FirstByte,
+			TimeToFirstByteMs: s.TimeToFirstByte.Milliseconds(),
+			Error:             s.Error,
+		})
+	}
+
+	token, err := h.authenticateWithManager(ctx)
+	if err != nil {
+		h.consoleSLIs.Requeue(sessions)
+		return fmt.Errorf("failed to authenticate with manager: %w", err)
+	}
+
+	req := connect.NewRequest(reportReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	if _, err := h.managerClient.ReportConsoleSLIs(ctx, req); err != nil {
+		h.consoleSLIs.Requeue(sessions)
+		return fmt.Errorf("failed to report console SLIs to manager: %w", err)
+	}
+
+	log.Debug().Int("session_count", len(sessions)).Msg("Reported console SLIs to manager")
+	return nil
+}
+
+// reportEventsToManager sends buffered system events to the manager, which
+// delivers them to webhook subscribers. Events are kept for the next attempt
+// if reporting fails.
+func (h *RegionalGatewayHandler) reportEventsToManager(ctx context.Context) error {
+	// Skip manager reporting in test mode
+	if h.testMode {
+		return nil
+	}
+
+	pending := h.eventOutbox.Drain()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	reportReq := &managerv1.ReportEventsRequest{
+		GatewayId: h.gatewayID,
+		Events:    make([]*managerv1.SystemEvent, 0, len(pending)),
+	}
+	for _, event := range pending {
+		data, err := structpb.NewStruct(event.Data)
+		if err != nil {
+			log.Warn().Err(err).Str("event_id", event.ID).Msg("Dropping event with unsupported data")
+			continue
+		}
+		reportReq.Events = append(reportReq.Events, &managerv1.SystemEvent{
+			Id:     event.ID,
+			Type:   string(event.Type),
+			Source: event.Source,
+			Time:   timestamppb.New(event.Time),
+			Data:   data,
+		})
+	}
+
+	token, err := h.authenticateWithManager(ctx)
+	if err != nil {
+		h.eventOutbox.Requeue(pending)
+		return fmt.Errorf("failed to authenticate with manager: %w", err)
+	}
+
+	req := connect.NewRequest(reportReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	if _, err := h.managerClient.ReportEvents(ctx, req); err != nil {
+		h.eventOutbox.Requeue(pending)
+		return fmt.Errorf("failed to report events to manager: %w", err)
+	}
+
+	log.Debug().Int("event_count", len(reportReq.Events)).Msg("Reported events to manager")
+	return nil
+}
+
 // convertBMCTypeToManagerProto converts model BMC type to manager protobuf BMC
 // type.
 func convertBMCTypeToManagerProto(bmcType types.BMCType) commonv1.BMCType {
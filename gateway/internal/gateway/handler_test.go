@@ -4,16 +4,25 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
 	commonauth "core/auth"
 	"core/domain"
+	"core/events"
 	commonv1 "core/gen/common/v1"
+	"core/idempotency"
+	"core/streaming"
 	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
 	"gateway/internal/agent"
+	"gateway/internal/outbox"
+	"gateway/internal/session"
+	"gateway/internal/sli"
 	"gateway/pkg/server_context"
 	"manager/pkg/auth"
 	managermodels "manager/pkg/models"
@@ -46,33 +55,57 @@ func convertAuthClaimsFromManager(claims *managermodels.AuthClaims) *commonauth.
 func newGatewayHandler(gatewayID, region string) *RegionalGatewayHandler {
 	jwtManager := auth.NewJWTManager("test-secret")
 	serverContextDecryptor := server_context.NewServerContextDecryptor("test-secret")
+	agentStreamTokens, _ := streaming.NewTokenSigner(nil)
 	return &RegionalGatewayHandler{
 		bmcManagerEndpoint:     "http://localhost:8080",
 		jwtManager:             jwtManager,
 		serverContextDecryptor: serverContextDecryptor,
 		gatewayID:              gatewayID,
 		region:                 region,
-		externalEndpoint:       "test-gateway:8081",
-		managerClient:          nil,            // No client needed for testing
-		httpClient:             &http.Client{}, // Initialize HTTP client for agent RPC calls
+		externalURL:            "http://test-gateway:8081",
+		managerClient:          nil, // No client needed for testing
+		agentClients:           agent.NewClientPool(agent.DefaultClientPoolConfig()),
 		testMode:               true,
 		agentRegistry:          agent.NewRegistry(),
 		bmcEndpointMapping:     make(map[string]*domain.AgentBMCMapping),
 		consoleSessions:        make(map[string]*ConsoleSession),
+		consoleStreams:         make(map[string]map[uint64]*AttachedStream),
+		browserStreams:         make(map[string]*browserConsoleStream),
+		sessionCreations:       make(map[string][]time.Time),
+		consoleMaxLifetime:     DefaultConsoleSessionMaxLifetime,
+		webSessionStore:        session.NewInMemoryStore(),
+		csrf:                   session.NewCSRFProtector("test-secret"),
+		accessTokens:           session.NewAccessSigner("test-secret"),
+		agentStreamTokens:      agentStreamTokens,
+		consoleSLIs:            sli.NewRecorder(sli.DefaultMaxPending),
+		eventOutbox:            outbox.New(0),
+		powerOperations:        idempotency.NewCache[*gatewayv1.PowerOperationResponse](idempotency.DefaultWindow),
+		operations:             newOperationTracker(DefaultOperationRetention, DefaultOperationTimeout),
 	}
 }
 
 // createAuthenticatedContext creates a context with a valid server token for testing
 func createAuthenticatedContext(serverID, customerID string) context.Context {
-	// Use the same secret key as the test handler
-	jwtManager := auth.NewJWTManager("test-secret")
+	return createAuthenticatedContextWithPermissions(serverID, customerID, []string{"power:read", "power:write", "console:read", "sensors:read"})
+}
 
-	customer := &domain.Customer{
-		ID:    customerID,
-		Email: "test@example.com",
-	}
+// createAuthenticatedContextWithPermissions creates a context with a valid
+// server token granting permissions
+func createAuthenticatedContextWithPermissions(serverID, customerID string, permissions []string) context.Context {
+	return createServerTokenContext(testTokenServer(serverID, customerID), permissions)
+}
 
-	server := &domain.Server{
+// createMaintenanceContext creates a context with a valid server token of a
+// server in maintenance
+func createMaintenanceContext(serverID, customerID string, permissions []string) context.Context {
+	server := testTokenServer(serverID, customerID)
+	server.MaintenanceMode = true
+	return createServerTokenContext(server, permissions)
+}
+
+// testTokenServer returns the server of test server tokens
+func testTokenServer(serverID, customerID string) *domain.Server {
+	return &domain.Server{
 		ID:         serverID,
 		CustomerID: customerID,
 		ControlEndpoints: []*types.BMCControlEndpoint{
@@ -85,8 +118,18 @@ func createAuthenticatedContext(serverID, customerID string) context.Context {
 		Features:        []string{"power", "console", "sensors"},
 		DatacenterID:    "dc-1",
 	}
+}
+
+// createServerTokenContext creates a context with a valid server token of a
+// server granting permissions
+func createServerTokenContext(server *domain.Server, permissions []string) context.Context {
+	// Use the same secret key as the test handler
+	jwtManager := auth.NewJWTManager("test-secret")
 
-	permissions := []string{"power:read", "power:write", "console:read", "sensors:read"}
+	customer := &domain.Customer{
+		ID:    server.CustomerID,
+		Email: "test@example.com",
+	}
 
 	token, err := jwtManager.GenerateServerToken(convertCustomerToManager(customer), server, permissions)
 	if err != nil {
@@ -175,6 +218,16 @@ func TestRegisterAgent(t *testing.T) {
 		t.Error("Registration should be successful")
 	}
 
+	// Verify the agent can authenticate the gateway's console streams
+	token, err := handler.AgentStreamToken(&ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "test-server-1", AgentID: "agent-1"})
+	if err != nil {
+		t.Fatalf("AgentStreamToken failed: %v", err)
+	}
+	expected := streaming.StreamClaims{SessionID: "sol-1", ServerID: "test-server-1", Protocol: "sol", AgentID: "agent-1"}
+	if err := streaming.VerifyStreamToken(resp.Msg.StreamTokenKey, token, expected, time.Now()); err != nil {
+		t.Errorf("Stream token not verified by the registered key: %v", err)
+	}
+
 	// Verify agent was registered
 	agentInfo := handler.agentRegistry.Get("agent-1")
 	if agentInfo == nil {
@@ -216,6 +269,49 @@ func TestRegisterAgent(t *testing.T) {
 	}
 }
 
+func TestRegisterAgentDeregistersRemovedServers(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	register := func(agentID string, endpoints ...string) {
+		bmcEndpoints := make([]*gatewayv1.BMCEndpointRegistration, len(endpoints))
+		for i, endpoint := range endpoints {
+			bmcEndpoints[i] = &gatewayv1.BMCEndpointRegistration{
+				ServerId: "server-" + endpoint,
+				ControlEndpoints: []*commonv1.BMCControlEndpoint{
+					{Endpoint: endpoint, Type: commonv1.BMCType_BMC_IPMI},
+				},
+			}
+		}
+		req := connect.NewRequest(&gatewayv1.RegisterAgentRequest{
+			AgentId:      agentID,
+			DatacenterId: "dc-1",
+			Endpoint:     "http://" + agentID + ":8080",
+			BmcEndpoints: bmcEndpoints,
+		})
+		if _, err := handler.RegisterAgent(context.Background(), req); err != nil {
+			t.Fatalf("RegisterAgent failed: %v", err)
+		}
+	}
+
+	register("agent-1", "192.168.1.100:623", "192.168.1.101:623")
+	register("agent-2", "192.168.2.100:623")
+
+	// agent-1 no longer lists 192.168.1.101 after a configuration reload
+	register("agent-1", "192.168.1.100:623", "192.168.1.102:623")
+
+	handler.mu.RLock()
+	defer handler.mu.RUnlock()
+
+	for _, endpoint := range []string{"192.168.1.100:623", "192.168.1.102:623", "192.168.2.100:623"} {
+		if handler.bmcEndpointMapping[endpoint] == nil {
+			t.Errorf("Expected mapping of %s to be kept", endpoint)
+		}
+	}
+	if handler.bmcEndpointMapping["192.168.1.101:623"] != nil {
+		t.Error("Expected mapping of the removed server to be deleted")
+	}
+}
+
 func TestAgentHeartbeat(t *testing.T) {
 	handler := newGatewayHandler("gateway-1", "us-west-1")
 
@@ -283,8 +379,272 @@ func TestAgentHeartbeat(t *testing.T) {
 	}
 }
 
+func TestGetAgentStatus(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	registerReq := connect.NewRequest(&gatewayv1.RegisterAgentRequest{
+		AgentId:      "agent-1",
+		DatacenterId: "dc-1",
+		Endpoint:     "http://agent:8080",
+		Version:      "v1.2.3",
+		BmcEndpoints: []*gatewayv1.BMCEndpointRegistration{
+			{
+				ServerId: "server-b",
+				ControlEndpoints: []*commonv1.BMCControlEndpoint{
+					{Endpoint: "192.168.1.101:623", Type: commonv1.BMCType_BMC_IPMI},
+				},
+				Features: []string{"power"},
+				Status:   "reachable",
+			},
+			{
+				ServerId: "server-a",
+				ControlEndpoints: []*commonv1.BMCControlEndpoint{
+					{Endpoint: "http://192.168.1.100:8000", Type: commonv1.BMCType_BMC_REDFISH},
+				},
+				Features: []string{"power", "console"},
+				Status:   "reachable",
+			},
+		},
+	})
+	if _, err := handler.RegisterAgent(context.Background(), registerReq); err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+
+	// Endpoint owned by another agent must not be reported
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["10.0.0.5:623"] = &domain.AgentBMCMapping{
+		ServerID:    "server-other",
+		BMCEndpoint: "10.0.0.5:623",
+		AgentID:     "agent-2",
+	}
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", AgentID: "agent-1", ExpiresAt: time.Now().Add(time.Hour)}
+	handler.consoleSessions["vnc-1"] = &ConsoleSession{SessionID: "vnc-1", AgentID: "agent-1", ExpiresAt: time.Now().Add(-time.Minute)}
+	handler.consoleSessions["vnc-2"] = &ConsoleSession{SessionID: "vnc-2", AgentID: "agent-2", ExpiresAt: time.Now().Add(time.Hour)}
+	handler.mu.Unlock()
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+
+	resp, err := handler.GetAgentStatus(adminCtx, connect.NewRequest(&gatewayv1.GetAgentStatusRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+
+	status := resp.Msg.Agent
+	require.Equal(t, "agent-1", status.AgentId)
+	require.Equal(t, "dc-1", status.DatacenterId)
+	require.Equal(t, "v1.2.3", status.Version)
+	require.Equal(t, "active", status.Status)
+	require.Equal(t, "gateway-1", status.GatewayId)
+	require.NotNil(t, status.RegisteredAt)
+	require.NotNil(t, status.LastSeen)
+	require.Equal(t, int32(1), status.ActiveSessionCount, "expired and foreign sessions should not be counted")
+
+	require.Len(t, status.BmcEndpoints, 2)
+	require.Equal(t, "server-a", status.BmcEndpoints[0].ServerId)
+	require.Equal(t, commonv1.BMCType_BMC_REDFISH, status.BmcEndpoints[0].BmcType)
+	require.Equal(t, "server-b", status.BmcEndpoints[1].ServerId)
+	require.Equal(t, "192.168.1.101:623", status.BmcEndpoints[1].BmcEndpoint)
+
+	t.Run("unknown agent", func(t *testing.T) {
+		_, err := handler.GetAgentStatus(adminCtx, connect.NewRequest(&gatewayv1.GetAgentStatusRequest{AgentId: "agent-missing"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("non-admin denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+		_, err := handler.GetAgentStatus(ctx, connect.NewRequest(&gatewayv1.GetAgentStatusRequest{AgentId: "agent-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := handler.GetAgentStatus(context.Background(), connect.NewRequest(&gatewayv1.GetAgentStatusRequest{AgentId: "agent-1"}))
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
+
+func TestListAgents(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	for _, registration := range []*gatewayv1.RegisterAgentRequest{
+		{AgentId: "agent-2", DatacenterId: "dc-2", Endpoint: "http://agent-2:8080"},
+		{
+			AgentId:      "agent-1",
+			DatacenterId: "dc-1",
+			Endpoint:     "http://agent-1:8080",
+			BmcEndpoints: []*gatewayv1.BMCEndpointRegistration{
+				{
+					ServerId: "server-a",
+					ControlEndpoints: []*commonv1.BMCControlEndpoint{
+						{Endpoint: "192.168.1.100:623", Type: commonv1.BMCType_BMC_IPMI},
+					},
+					Status: "reachable",
+				},
+			},
+		},
+	} {
+		_, err := handler.RegisterAgent(context.Background(), connect.NewRequest(registration))
+		require.NoError(t, err)
+	}
+
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", AgentID: "agent-1", ExpiresAt: time.Now().Add(time.Hour)}
+	handler.mu.Unlock()
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+
+	resp, err := handler.ListAgents(adminCtx, connect.NewRequest(&gatewayv1.ListAgentsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Agents, 2)
+	require.Equal(t, "agent-1", resp.Msg.Agents[0].AgentId, "agents should be listed by ID")
+	require.Equal(t, int32(1), resp.Msg.Agents[0].ActiveSessionCount)
+	require.Len(t, resp.Msg.Agents[0].BmcEndpoints, 1)
+	require.Equal(t, "server-a", resp.Msg.Agents[0].BmcEndpoints[0].ServerId)
+	require.Equal(t, "agent-2", resp.Msg.Agents[1].AgentId)
+	require.Empty(t, resp.Msg.Agents[1].BmcEndpoints)
+
+	t.Run("datacenter filter", func(t *testing.T) {
+		resp, err := handler.ListAgents(adminCtx, connect.NewRequest(&gatewayv1.ListAgentsRequest{DatacenterId: "dc-2"}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Agents, 1)
+		require.Equal(t, "agent-2", resp.Msg.Agents[0].AgentId)
+	})
+
+	t.Run("non-admin denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+		_, err := handler.ListAgents(ctx, connect.NewRequest(&gatewayv1.ListAgentsRequest{}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestGetGatewayStatus(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	_, err := handler.RegisterAgent(context.Background(), connect.NewRequest(&gatewayv1.RegisterAgentRequest{
+		AgentId:      "agent-1",
+		DatacenterId: "dc-1",
+		Endpoint:     "http://agent-1:8080",
+		BmcEndpoints: []*gatewayv1.BMCEndpointRegistration{
+			{
+				ServerId: "server-a",
+				ControlEndpoints: []*commonv1.BMCControlEndpoint{
+					{Endpoint: "192.168.1.100:623", Type: commonv1.BMCType_BMC_IPMI},
+				},
+				Status: "reachable",
+			},
+		},
+	}))
+	require.NoError(t, err)
+	handler.SetLinkStatus(func(agentID string) *gatewayv1.AgentLinkStatus {
+		return &gatewayv1.AgentLinkStatus{RttMs: 12.5}
+	})
+
+	now := time.Now()
+	handler.mu.Lock()
+	handler.consoleSessions["sol-1"] = &ConsoleSession{SessionID: "sol-1", Type: "sol", AgentID: "agent-1", ExpiresAt: now.Add(time.Hour)}
+	handler.consoleSessions["vnc-1"] = &ConsoleSession{SessionID: "vnc-1", Type: "vnc", AgentID: "agent-1", ExpiresAt: now.Add(time.Hour)}
+	handler.consoleSessions["vnc-2"] = &ConsoleSession{SessionID: "vnc-2", Type: "vnc", AgentID: "agent-1", ExpiresAt: now.Add(-time.Minute)}
+	handler.mu.Unlock()
+	stream := handler.AttachConsoleStream(context.Background(), "sol-1", StreamTransportWebSocket, "10.1.2.3:50000")
+	defer stream.Detach()
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+	resp, err := handler.GetGatewayStatus(adminCtx, connect.NewRequest(&gatewayv1.GetGatewayStatusRequest{}))
+	require.NoError(t, err)
+	status := resp.Msg
+	require.Equal(t, "gateway-1", status.GatewayId)
+	require.Equal(t, "us-west-1", status.Region)
+	require.Len(t, status.Agents, 1)
+	require.Equal(t, 12.5, status.Agents[0].Link.RttMs)
+	require.Equal(t, int32(2), status.Agents[0].ActiveSessionCount)
+
+	require.Equal(t, int32(2), status.Sessions.Total, "expired sessions are not counted")
+	require.Equal(t, int32(1), status.Sessions.Vnc)
+	require.Equal(t, int32(1), status.Sessions.Sol)
+	require.Equal(t, int32(1), status.Sessions.AttachedStreams)
+
+	require.Len(t, status.EndpointMappings, 1)
+	require.Equal(t, "192.168.1.100:623", status.EndpointMappings[0].BmcEndpoint)
+	require.Equal(t, "agent-1", status.EndpointMappings[0].AgentId)
+	require.Equal(t, "dc-1", status.EndpointMappings[0].DatacenterId)
+
+	t.Run("non-admin denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+		_, err := handler.GetGatewayStatus(ctx, connect.NewRequest(&gatewayv1.GetGatewayStatusRequest{}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+// sessionListingAgent is an agent RPC server reporting fixed active sessions
+type sessionListingAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	requests []*gatewayv1.ListActiveSessionsRequest
+}
+
+func (a *sessionListingAgent) ListActiveSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListActiveSessionsRequest],
+) (*connect.Response[gatewayv1.ListActiveSessionsResponse], error) {
+	a.requests = append(a.requests, req.Msg)
+	return connect.NewResponse(&gatewayv1.ListActiveSessionsResponse{
+		Sessions: []*gatewayv1.ActiveSession{
+			{SessionId: "sol-1", ServerId: "server-a", Protocol: "sol", Endpoint: "ipmi://192.168.1.100:623"},
+		},
+		MaxSolSessions: 10,
+		MaxVncSessions: 5,
+	}), nil
+}
+
+func TestListActiveSessions(t *testing.T) {
+	agentHandler := &sessionListingAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentHandler)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+
+	resp, err := handler.ListActiveSessions(adminCtx, connect.NewRequest(&gatewayv1.ListActiveSessionsRequest{
+		AgentId:  "agent-1",
+		ServerId: "server-a",
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Sessions, 1)
+	require.Equal(t, "sol-1", resp.Msg.Sessions[0].SessionId)
+	require.Equal(t, int32(10), resp.Msg.MaxSolSessions)
+	require.Equal(t, int32(5), resp.Msg.MaxVncSessions)
+
+	require.Len(t, agentHandler.requests, 1)
+	require.Equal(t, "agent-1", agentHandler.requests[0].AgentId)
+	require.Equal(t, "server-a", agentHandler.requests[0].ServerId, "server filter should be forwarded")
+
+	t.Run("unknown agent", func(t *testing.T) {
+		_, err := handler.ListActiveSessions(adminCtx, connect.NewRequest(&gatewayv1.ListActiveSessionsRequest{AgentId: "agent-missing"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("agent required", func(t *testing.T) {
+		_, err := handler.ListActiveSessions(adminCtx, connect.NewRequest(&gatewayv1.ListActiveSessionsRequest{}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("non-admin denied", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+		_, err := handler.ListActiveSessions(ctx, connect.NewRequest(&gatewayv1.ListActiveSessionsRequest{AgentId: "agent-1"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
 func TestProxyPowerOperation(t *testing.T) {
 	handler := newGatewayHandler("gateway-1", "us-west-1")
+	bus := outbox.New(0)
+	handler.SetEventBus(bus)
 
 	// Register agent
 	agentInfo := &agent.Info{
@@ -311,7 +671,7 @@ func TestProxyPowerOperation(t *testing.T) {
 	handler.mu.Unlock()
 
 	// Test power operation - expect connection error since agent doesn't exist
-	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.100:623", PowerOpPowerOn)
+	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.100:623", PowerOpPowerOn, "")
 
 	// We expect an error here because the agent endpoint doesn't actually exist
 	if err == nil {
@@ -327,12 +687,26 @@ func TestProxyPowerOperation(t *testing.T) {
 	if connectErr.Code() != connect.CodeUnavailable {
 		t.Errorf("Expected Unavailable error code, got %v", connectErr.Code())
 	}
+
+	// The failed operation is reported to the manager
+	pending := handler.eventOutbox.Drain()
+	if len(pending) != 1 || pending[0].Type != events.PowerOperationExecuted {
+		t.Fatalf("Expected one power operation event, got %+v", pending)
+	}
+	if pending[0].Data["server_id"] != "test-server-1" || pending[0].Data["success"] != false {
+		t.Errorf("Unexpected power operation event data: %+v", pending[0].Data)
+	}
+
+	// and published to the event bus
+	if published := bus.Drain(); len(published) != 1 || published[0].ID != pending[0].ID {
+		t.Errorf("Expected the power operation event on the event bus, got %+v", published)
+	}
 }
 
 func TestProxyPowerOperation_BMCEndpointNotFound(t *testing.T) {
 	handler := newGatewayHandler("gateway-1", "us-west-1")
 
-	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.200:623", PowerOpPowerOn)
+	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.200:623", PowerOpPowerOn, "")
 
 	if err == nil {
 		t.Error("Expected error for non-existent BMC endpoint")
@@ -361,7 +735,7 @@ func TestProxyPowerOperation_AgentNotAvailable(t *testing.T) {
 	}
 	handler.mu.Unlock()
 
-	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.100:623", PowerOpPowerOn)
+	_, err := handler.proxyPowerOperation(context.Background(), "192.168.1.100:623", PowerOpPowerOn, "")
 
 	if err == nil {
 		t.Error("Expected error for unavailable agent")
@@ -418,6 +792,12 @@ func TestPowerOperations(t *testing.T) {
 		t.Error("Expected connection error for PowerOff")
 	}
 
+	// Test ForceOff - expect connection error since agent doesn't exist
+	_, err = handler.ForceOff(ctx, req)
+	if err == nil {
+		t.Error("Expected connection error for ForceOff")
+	}
+
 	// Test PowerCycle - expect connection error since agent doesn't exist
 	_, err = handler.PowerCycle(ctx, req)
 	if err == nil {
@@ -556,6 +936,22 @@ func TestCreateVNCSession(t *testing.T) {
 	if resp.Msg.ExpiresAt == nil {
 		t.Error("Expires at should not be nil")
 	}
+
+	viewerURL, err := url.Parse(resp.Msg.ViewerUrl)
+	if err != nil {
+		t.Fatalf("Invalid viewer URL: %v", err)
+	}
+	if err := handler.RedeemViewerToken(resp.Msg.SessionId, viewerURL.Query().Get(session.AccessTokenParam)); err != nil {
+		t.Errorf("Viewer URL should carry a valid access token: %v", err)
+	}
+
+	wsURL, err := url.Parse(resp.Msg.WebsocketEndpoint)
+	if err != nil {
+		t.Fatalf("Invalid WebSocket endpoint: %v", err)
+	}
+	if err := handler.VerifyStreamToken(resp.Msg.SessionId, wsURL.Query().Get(session.AccessTokenParam)); err != nil {
+		t.Errorf("WebSocket endpoint should carry a valid access token: %v", err)
+	}
 }
 
 func TestGetDatacenterIDs(t *testing.T) {
@@ -1004,3 +1400,700 @@ func TestRegisterAgentWithMultipleControlEndpoints(t *testing.T) {
 		}
 	}
 }
+
+func TestPowerMeteringTargets(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	handler.agentRegistry.Register(&agent.Info{ID: "agent-1", DatacenterID: "dc-1", Endpoint: "http://agent-1:8080"})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["http://192.168.1.100:8000"] = &domain.AgentBMCMapping{
+		ServerID:     "server-a",
+		BMCEndpoint:  "http://192.168.1.100:8000",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeRedfish,
+	}
+	// Servers of unregistered agents cannot be sampled
+	handler.bmcEndpointMapping["10.0.0.5:623"] = &domain.AgentBMCMapping{
+		ServerID:     "server-other",
+		BMCEndpoint:  "10.0.0.5:623",
+		AgentID:      "agent-2",
+		DatacenterID: "dc-2",
+	}
+	handler.mu.Unlock()
+
+	targets := handler.PowerMeteringTargets()
+	require.Len(t, targets, 1)
+	require.Equal(t, "server-a", targets[0].ServerID)
+	require.Equal(t, "http://192.168.1.100:8000", targets[0].BMCEndpoint)
+	require.Equal(t, "dc-1", targets[0].DatacenterID)
+	require.Equal(t, "http://agent-1:8080", targets[0].AgentEndpoint)
+}
+
+// identifyAgent is an agent RPC server recording chassis identify requests
+type identifyAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	requests []*gatewayv1.SetChassisIdentifyRequest
+}
+
+func (a *identifyAgent) SetChassisIdentify(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SetChassisIdentifyRequest],
+) (*connect.Response[gatewayv1.SetChassisIdentifyResponse], error) {
+	a.requests = append(a.requests, req.Msg)
+	return connect.NewResponse(&gatewayv1.SetChassisIdentifyResponse{Success: true}), nil
+}
+
+func TestSetChassisIdentify(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &identifyAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+
+	t.Run("proxied to agent", func(t *testing.T) {
+		resp, err := handler.SetChassisIdentify(ctx, connect.NewRequest(&gatewayv1.SetChassisIdentifyRequest{
+			ServerId:        "192.168.1.100:623",
+			State:           gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK,
+			DurationSeconds: 300,
+		}))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Success)
+
+		// The agent is called with its own ID of the server
+		require.Len(t, agentService.requests, 1)
+		require.Equal(t, "bmc-dc-1-192.168.1.100:623", agentService.requests[0].ServerId)
+		require.Equal(t, gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK, agentService.requests[0].State)
+		require.Equal(t, int32(300), agentService.requests[0].DurationSeconds)
+	})
+
+	t.Run("server ID mismatch", func(t *testing.T) {
+		_, err := handler.SetChassisIdentify(ctx, connect.NewRequest(&gatewayv1.SetChassisIdentifyRequest{
+			ServerId: "other-server",
+			State:    gatewayv1.IdentifyState_IDENTIFY_STATE_ON,
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+// networkConfigAgent is an agent RPC server reporting the network
+// configuration of a BMC
+type networkConfigAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	serverIDs []string
+}
+
+func (a *networkConfigAgent) GetBMCNetworkConfig(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetBMCNetworkConfigRequest],
+) (*connect.Response[gatewayv1.GetBMCNetworkConfigResponse], error) {
+	a.serverIDs = append(a.serverIDs, req.Msg.ServerId)
+	return connect.NewResponse(&gatewayv1.GetBMCNetworkConfigResponse{
+		BmcType: "ipmi",
+		Interfaces: []*gatewayv1.BMCNetworkInterface{
+			{Ipv4Address: "192.168.1.100", SubnetMask: "255.255.255.0", Gateway: "192.168.1.1", VlanId: 100, Enabled: true},
+		},
+	}), nil
+}
+
+func TestGetBMCNetworkConfig(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &networkConfigAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	t.Run("proxied to agent", func(t *testing.T) {
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		resp, err := handler.GetBMCNetworkConfig(ctx, connect.NewRequest(&gatewayv1.GetBMCNetworkConfigRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Interfaces, 1)
+		require.Equal(t, int32(100), resp.Msg.Interfaces[0].VlanId)
+
+		// The agent is called with its own ID of the server
+		require.Equal(t, []string{"bmc-dc-1-192.168.1.100:623"}, agentService.serverIDs)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:access"})
+		_, err := handler.GetBMCNetworkConfig(ctx, connect.NewRequest(&gatewayv1.GetBMCNetworkConfigRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+// healthAgent is an agent RPC server answering link probes and deep health
+// checks
+type healthAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	requests []*gatewayv1.DeepHealthCheckRequest
+}
+
+func (a *healthAgent) ProbeLink(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ProbeLinkRequest],
+) (*connect.Response[gatewayv1.ProbeLinkResponse], error) {
+	return connect.NewResponse(&gatewayv1.ProbeLinkResponse{}), nil
+}
+
+func (a *healthAgent) DeepHealthCheck(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.DeepHealthCheckRequest],
+) (*connect.Response[gatewayv1.DeepHealthCheckResponse], error) {
+	a.requests = append(a.requests, req.Msg)
+	hops := []*gatewayv1.HealthCheckHop{{Name: "bmc_control", Status: "ok", Target: "192.168.1.100:623"}}
+	if req.Msg.CheckConsoles {
+		hops = append(hops,
+			&gatewayv1.HealthCheckHop{Name: "sol", Status: "ok", Target: "192.168.1.100:623"},
+			&gatewayv1.HealthCheckHop{Name: "vnc", Status: "failed", Target: "192.168.1.100:5900", Error: "connection refused"},
+		)
+	}
+	return connect.NewResponse(&gatewayv1.DeepHealthCheckResponse{ServerId: req.Msg.ServerId, Hops: hops}), nil
+}
+
+// probeOnlyAgent is an agent RPC server of a version without deep health
+// checks
+type probeOnlyAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+}
+
+func (a *probeOnlyAgent) ProbeLink(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ProbeLinkRequest],
+) (*connect.Response[gatewayv1.ProbeLinkResponse], error) {
+	return connect.NewResponse(&gatewayv1.ProbeLinkResponse{}), nil
+}
+
+func TestDeepHealthCheck(t *testing.T) {
+	newHandler := func(t *testing.T, agentService gatewayv1connect.GatewayServiceHandler) *RegionalGatewayHandler {
+		handler := newGatewayHandler("gateway-1", "us-west-1")
+
+		if agentService != nil {
+			path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+			mux := http.NewServeMux()
+			mux.Handle(path, rpcHandler)
+			agentServer := httptest.NewServer(mux)
+			t.Cleanup(agentServer.Close)
+
+			handler.agentRegistry.Register(&agent.Info{
+				ID:           "agent-1",
+				DatacenterID: "dc-1",
+				Endpoint:     agentServer.URL,
+				LastSeen:     time.Now(),
+			})
+		}
+
+		handler.mu.Lock()
+		handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+			ServerID:     "bmc-dc-1-192.168.1.100:623",
+			BMCEndpoint:  "192.168.1.100:623",
+			AgentID:      "agent-1",
+			DatacenterID: "dc-1",
+			BMCType:      types.BMCTypeIPMI,
+			Status:       "reachable",
+			LastSeen:     time.Now(),
+		}
+		handler.mu.Unlock()
+		return handler
+	}
+
+	hopStatuses := func(hops []*gatewayv1.HealthCheckHop) []string {
+		var statuses []string
+		for _, hop := range hops {
+			statuses = append(statuses, hop.Name+"="+hop.Status)
+		}
+		return statuses
+	}
+
+	t.Run("all hops", func(t *testing.T) {
+		agentService := &healthAgent{}
+		handler := newHandler(t, agentService)
+
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		resp, err := handler.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Healthy)
+		require.Equal(t, []string{"agent=ok", "bmc_control=ok"}, hopStatuses(resp.Msg.Hops))
+		require.Equal(t, "agent-1", resp.Msg.Hops[0].Target)
+
+		// The agent is called with its own ID of the server
+		require.Len(t, agentService.requests, 1)
+		require.Equal(t, "bmc-dc-1-192.168.1.100:623", agentService.requests[0].ServerId)
+	})
+
+	t.Run("failed console", func(t *testing.T) {
+		handler := newHandler(t, &healthAgent{})
+
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		resp, err := handler.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId:      "192.168.1.100:623",
+			CheckConsoles: true,
+		}))
+		require.NoError(t, err)
+		require.False(t, resp.Msg.Healthy)
+		require.Equal(t, []string{"agent=ok", "bmc_control=ok", "sol=ok", "vnc=failed"}, hopStatuses(resp.Msg.Hops))
+		require.Equal(t, "connection refused", resp.Msg.Hops[3].Error)
+	})
+
+	t.Run("agent not registered", func(t *testing.T) {
+		handler := newHandler(t, nil)
+
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		resp, err := handler.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId:      "192.168.1.100:623",
+			CheckConsoles: true,
+		}))
+		require.NoError(t, err)
+		require.False(t, resp.Msg.Healthy)
+		require.Equal(t, []string{"agent=failed", "bmc_control=skipped", "sol=skipped", "vnc=skipped"}, hopStatuses(resp.Msg.Hops))
+	})
+
+	t.Run("agent without deep health checks", func(t *testing.T) {
+		handler := newHandler(t, &probeOnlyAgent{})
+
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		resp, err := handler.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.NoError(t, err)
+		require.False(t, resp.Msg.Healthy)
+		require.Equal(t, []string{"agent=ok", "bmc_control=failed"}, hopStatuses(resp.Msg.Hops))
+		require.Contains(t, resp.Msg.Hops[1].Error, "does not support deep health checks")
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		handler := newHandler(t, &healthAgent{})
+
+		ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:access"})
+		_, err := handler.DeepHealthCheck(ctx, connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+// diagAgent is an agent RPC server recording diagnostic interrupts
+type diagAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	serverIDs []string
+}
+
+func (a *diagAgent) DiagnosticInterrupt(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	a.serverIDs = append(a.serverIDs, req.Msg.ServerId)
+	return connect.NewResponse(&gatewayv1.PowerOperationResponse{Success: true}), nil
+}
+
+func TestDiagnosticInterrupt(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &diagAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{ServerId: "192.168.1.100:623"})
+
+	t.Run("requires power:diag", func(t *testing.T) {
+		ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+		_, err := handler.DiagnosticInterrupt(ctx, req)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		require.Empty(t, agentService.serverIDs)
+	})
+
+	t.Run("proxied to agent", func(t *testing.T) {
+		ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"power:read", "power:diag"})
+		resp, err := handler.DiagnosticInterrupt(ctx, req)
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Success)
+		require.Equal(t, []string{"bmc-dc-1-192.168.1.100:623"}, agentService.serverIDs)
+
+		// Reported like the other power operations
+		pending := handler.eventOutbox.Drain()
+		require.Len(t, pending, 1)
+		require.Equal(t, events.PowerOperationExecuted, pending[0].Type)
+		require.Equal(t, PowerOpDiagnosticInterrupt, pending[0].Data["operation"])
+	})
+}
+
+// shutdownAgent is an agent RPC server recording graceful shutdowns
+type shutdownAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	requests []*gatewayv1.GracefulShutdownRequest
+}
+
+func (a *shutdownAgent) GracefulShutdown(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GracefulShutdownRequest],
+) (*connect.Response[gatewayv1.GracefulShutdownResponse], error) {
+	a.requests = append(a.requests, req.Msg)
+	return connect.NewResponse(&gatewayv1.GracefulShutdownResponse{Success: true, Message: "requested"}), nil
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &shutdownAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	ctx := createAuthenticatedContext("192.168.1.100:623", "customer-1")
+	resp, err := handler.GracefulShutdown(ctx, connect.NewRequest(&gatewayv1.GracefulShutdownRequest{
+		ServerId:          "192.168.1.100:623",
+		ForceAfterSeconds: 120,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success)
+
+	require.Len(t, agentService.requests, 1)
+	require.Equal(t, "bmc-dc-1-192.168.1.100:623", agentService.requests[0].ServerId)
+	require.Equal(t, int32(120), agentService.requests[0].ForceAfterSeconds)
+
+	pending := handler.eventOutbox.Drain()
+	require.Len(t, pending, 1)
+	require.Equal(t, PowerOpGracefulShutdown, pending[0].Data["operation"])
+}
+
+func TestCreateSOLSession_SerialSettings(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     "http://agent-1:8080",
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+
+	t.Run("settings stored for the handshake", func(t *testing.T) {
+		resp, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+			ServerId: "192.168.1.100:623",
+			Config:   &commonv1.SOLConfig{BaudRate: 9600, FlowControl: "hardware"},
+		}))
+		require.NoError(t, err)
+
+		solSession, ok := handler.GetSOLSessionByID(resp.Msg.SessionId)
+		require.True(t, ok)
+		require.Equal(t, streaming.SOLSettings{BaudRate: 9600, FlowControl: "hardware"}, solSession.SOLSettings)
+		require.Equal(t, map[string]string{
+			streaming.MetadataSOLBaudRate:    "9600",
+			streaming.MetadataSOLFlowControl: "hardware",
+		}, solSession.SOLSettings.AddTo(nil))
+	})
+
+	t.Run("no settings", func(t *testing.T) {
+		resp, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+			ServerId: "192.168.1.100:623",
+		}))
+		require.NoError(t, err)
+
+		solSession, ok := handler.GetSOLSessionByID(resp.Msg.SessionId)
+		require.True(t, ok)
+		require.True(t, solSession.SOLSettings.IsZero())
+	})
+
+	t.Run("unsupported baud rate", func(t *testing.T) {
+		_, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+			ServerId: "192.168.1.100:623",
+			Config:   &commonv1.SOLConfig{BaudRate: 14400},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("unsupported flow control", func(t *testing.T) {
+		_, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+			ServerId: "192.168.1.100:623",
+			Config:   &commonv1.SOLConfig{FlowControl: "xon"},
+		}))
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestCreateConsoleSession_ReadOnly(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     "http://agent-1:8080",
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	// Support staff observing a customer's console only read it
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:read"})
+
+	sol, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{
+		ServerId: "192.168.1.100:623",
+		ReadOnly: true,
+	}))
+	require.NoError(t, err)
+	solSession, ok := handler.GetSOLSessionByID(sol.Msg.SessionId)
+	require.True(t, ok)
+	require.True(t, solSession.ReadOnly)
+
+	vnc, err := handler.CreateVNCSession(ctx, connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{
+		ServerId: "192.168.1.100:623",
+		ReadOnly: true,
+	}))
+	require.NoError(t, err)
+	vncSession, ok := handler.GetVNCSessionByID(vnc.Msg.SessionId)
+	require.True(t, ok)
+	require.True(t, vncSession.ReadOnly)
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+	list, err := handler.ListConsoleSessions(adminCtx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Sessions, 2)
+	for _, info := range list.Msg.Sessions {
+		require.True(t, info.ReadOnly, "session %s should be listed read-only", info.SessionId)
+	}
+
+	t.Run("interactive sessions need console access", func(t *testing.T) {
+		_, err := handler.CreateSOLSession(ctx, connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: "192.168.1.100:623"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		_, err = handler.CreateVNCSession(ctx, connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{ServerId: "192.168.1.100:623"}))
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+func TestCreateVNCSession_KeyboardLayout(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     "http://agent-1:8080",
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"DE", "de"},
+		{"us", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		vnc, err := handler.CreateVNCSession(ctx, connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{
+			ServerId:       "192.168.1.100:623",
+			KeyboardLayout: tt.layout,
+		}))
+		require.NoError(t, err)
+		vncSession, ok := handler.GetVNCSessionByID(vnc.Msg.SessionId)
+		require.True(t, ok)
+		require.Equal(t, tt.want, vncSession.KeyboardLayout, "layout %q", tt.layout)
+	}
+
+	_, err := handler.CreateVNCSession(ctx, connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{
+		ServerId:       "192.168.1.100:623",
+		KeyboardLayout: "dvorak",
+	}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestSetWebSessionTheme(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+	require.NoError(t, handler.webSessionStore.Create(&session.WebSession{ID: "web-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}))
+
+	require.NoError(t, handler.SetWebSessionTheme("web-1", handler.CSRFToken("web-1"), "light"))
+	webSession, err := handler.webSessionStore.Get("web-1")
+	require.NoError(t, err)
+	require.Equal(t, "light", webSession.Theme)
+
+	err = handler.SetWebSessionTheme("web-1", "forged", "dark")
+	require.ErrorIs(t, err, ErrInvalidCSRFToken)
+
+	err = handler.SetWebSessionTheme("web-2", handler.CSRFToken("web-2"), "dark")
+	require.ErrorIs(t, err, session.ErrSessionNotFound)
+}
+
+func TestStartVNCProxy_URL(t *testing.T) {
+	tests := []struct {
+		name          string
+		agentEndpoint string
+		bmcEndpoint   string
+		want          string
+	}{
+		{
+			name:          "IPv4",
+			agentEndpoint: "http://10.0.0.5:8090",
+			bmcEndpoint:   "192.168.1.100:5900",
+			want:          "ws://10.0.0.5:8090/vnc/vnc-1/192.168.1.100:5900?type=native",
+		},
+		{
+			name:          "IPv6 agent and BMC",
+			agentEndpoint: "http://[fd00::5]:8090",
+			bmcEndpoint:   "[fd00::10]:5900",
+			want:          "ws://[fd00::5]:8090/vnc/vnc-1/%5Bfd00::10%5D:5900?type=native",
+		},
+		{
+			name:          "IPv6 BMC URL",
+			agentEndpoint: "[fd00::5]:8090",
+			bmcEndpoint:   "http://[fd00::10]:8000",
+			want:          "ws://[fd00::5]:8090/vnc/vnc-1/%5Bfd00::10%5D:8000?type=native",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newGatewayHandler("gateway-1", "us-west-1")
+			handler.agentRegistry.Register(&agent.Info{
+				ID:       "agent-1",
+				Endpoint: tt.agentEndpoint,
+				LastSeen: time.Now(),
+			})
+
+			resp, err := handler.StartVNCProxy(context.Background(), connect.NewRequest(&gatewayv1.StartVNCProxyRequest{
+				AgentId:     "agent-1",
+				SessionId:   "vnc-1",
+				BmcEndpoint: tt.bmcEndpoint,
+				BmcType:     "native",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, resp.Msg.ProxyEndpoint)
+
+			proxyURL, err := url.Parse(resp.Msg.ProxyEndpoint)
+			require.NoError(t, err)
+			require.Equal(t, "/vnc/vnc-1/"+strings.TrimPrefix(tt.bmcEndpoint, "http://"), proxyURL.Path)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/events"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// ReportHostDrift publishes the drift of the static hosts reconciled by an
+// agent: a ServerDriftDetected event for the hosts whose BMC differs from
+// their configuration, a ServerDriftResolved event for those back in sync.
+// The events reach the manager with the event report. Like heartbeats, it is
+// only accepted from registered agents.
+func (h *RegionalGatewayHandler) ReportHostDrift(
+	_ context.Context,
+	req *connect.Request[gatewayv1.ReportHostDriftRequest],
+) (*connect.Response[gatewayv1.ReportHostDriftResponse], error) {
+	agentInfo := h.agentRegistry.Get(req.Msg.AgentId)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", req.Msg.AgentId))
+	}
+
+	for _, host := range req.Msg.Hosts {
+		if host.ServerId == "" {
+			continue
+		}
+
+		checkedAt := time.Now()
+		if host.CheckedAt != nil {
+			checkedAt = host.CheckedAt.AsTime()
+		}
+		data := map[string]any{
+			"gateway_id":    h.gatewayID,
+			"agent_id":      agentInfo.ID,
+			"datacenter_id": agentInfo.DatacenterID,
+			"server_id":     host.ServerId,
+			"host_id":       host.HostId,
+			"checked_at":    checkedAt.UTC().Format(time.RFC3339),
+		}
+
+		if len(host.Findings) == 0 {
+			log.Info().
+				Str("agent_id", agentInfo.ID).
+				Str("server_id", host.ServerId).
+				Msg("Static host back in sync with its configuration")
+			h.publishEvent(events.New(events.ServerDriftResolved, "gateway", data))
+			continue
+		}
+
+		findings := make([]any, 0, len(host.Findings))
+		for _, finding := range host.Findings {
+			findings = append(findings, map[string]any{
+				"check":    finding.Check,
+				"endpoint": finding.Endpoint,
+				"desired":  finding.Desired,
+				"actual":   finding.Actual,
+				"applied":  finding.Applied,
+				"error":    finding.Error,
+			})
+		}
+		data["findings"] = findings
+
+		log.Warn().
+			Str("agent_id", agentInfo.ID).
+			Str("server_id", host.ServerId).
+			Int("findings", len(findings)).
+			Msg("Static host drifted from its configuration")
+		h.publishEvent(events.New(events.ServerDriftDetected, "gateway", data))
+	}
+
+	return connect.NewResponse(&gatewayv1.ReportHostDriftResponse{Success: true}), nil
+}
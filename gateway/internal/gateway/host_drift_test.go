@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/events"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/internal/agent"
+)
+
+func TestReportHostDrift(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     "http://agent:8080",
+		LastSeen:     time.Now(),
+	})
+
+	checkedAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	resp, err := handler.ReportHostDrift(context.Background(), connect.NewRequest(&gatewayv1.ReportHostDriftRequest{
+		AgentId: "agent-1",
+		Hosts: []*gatewayv1.HostDrift{
+			{
+				ServerId:  "bmc-dc-1-192.168.1.100:623",
+				HostId:    "server-1",
+				CheckedAt: timestamppb.New(checkedAt),
+				Findings: []*gatewayv1.DriftFinding{
+					{Check: "sol_enabled", Endpoint: "192.168.1.100:623", Desired: "true", Actual: "false", Applied: true},
+				},
+			},
+			{ServerId: "bmc-dc-1-192.168.1.101:623", HostId: "server-2", CheckedAt: timestamppb.New(checkedAt)},
+		},
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success)
+
+	pending := handler.eventOutbox.Drain()
+	require.Len(t, pending, 2)
+
+	require.Equal(t, events.ServerDriftDetected, pending[0].Type)
+	require.Equal(t, "bmc-dc-1-192.168.1.100:623", pending[0].Data["server_id"])
+	require.Equal(t, "dc-1", pending[0].Data["datacenter_id"])
+	require.Equal(t, "2026-03-02T10:00:00Z", pending[0].Data["checked_at"])
+	findings := pending[0].Data["findings"].([]any)
+	require.Len(t, findings, 1)
+	require.Equal(t, "sol_enabled", findings[0].(map[string]any)["check"])
+	require.Equal(t, true, findings[0].(map[string]any)["applied"])
+
+	require.Equal(t, events.ServerDriftResolved, pending[1].Type)
+	require.Equal(t, "server-2", pending[1].Data["host_id"])
+	require.NotContains(t, pending[1].Data, "findings")
+
+	t.Run("unknown agent", func(t *testing.T) {
+		_, err := handler.ReportHostDrift(context.Background(), connect.NewRequest(&gatewayv1.ReportHostDriftRequest{AgentId: "agent-missing"}))
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// SetChassisIdentify proxies a change of the identify (locate) LED of a
+// server to its agent. It is authorized like power operations, with
+// power:write.
+func (h *RegionalGatewayHandler) SetChassisIdentify(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SetChassisIdentifyRequest],
+) (*connect.Response[gatewayv1.SetChassisIdentifyResponse], error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:write") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for chassis identify"))
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
+	}
+
+	log.Info().
+		Str("server_id", serverContext.ServerID).
+		Str("bmc_endpoint", serverContext.BMCEndpoint).
+		Str("agent_id", mapping.AgentID).
+		Str("state", req.Msg.State.String()).
+		Int32("duration_seconds", req.Msg.DurationSeconds).
+		Msg("Proxying chassis identify request to agent")
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+
+	resp, err := agentClient.SetChassisIdentify(ctx, connect.NewRequest(&gatewayv1.SetChassisIdentifyRequest{
+		ServerId:        mapping.ServerID,
+		State:           req.Msg.State,
+		DurationSeconds: req.Msg.DurationSeconds,
+	}))
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("bmc_endpoint", serverContext.BMCEndpoint).
+			Str("agent_id", mapping.AgentID).
+			Msg("Chassis identify request failed")
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/logging"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managermodels "manager/pkg/models"
+)
+
+// SetLogLevel changes the log levels of the gateway at runtime, without
+// dropping its console sessions. An empty request returns the current
+// levels. Restricted to admin tokens.
+func (h *RegionalGatewayHandler) SetLogLevel(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SetLogLevelRequest],
+) (*connect.Response[gatewayv1.SetLogLevelResponse], error) {
+	claims, ok := ctx.Value("claims").(*managermodels.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to get auth claims"))
+	}
+
+	if !claims.IsAdmin {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
+	}
+
+	change := logging.LevelChange{
+		Level:        req.Msg.Level,
+		Modules:      req.Msg.ModuleLevels,
+		ResetModules: req.Msg.ResetModules,
+	}
+	if err := logging.ApplyLevels(change); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	levels := logging.CurrentLevels()
+	if change.Level != "" || len(change.Modules) > 0 || change.ResetModules {
+		log.Warn().
+			Str("admin", claims.Email).
+			Str("level", levels.Level).
+			Interface("modules", levels.Modules).
+			Msg("Log levels changed")
+	}
+
+	return connect.NewResponse(&gatewayv1.SetLogLevelResponse{
+		Level:        levels.Level,
+		ModuleLevels: levels.Modules,
+	}), nil
+}
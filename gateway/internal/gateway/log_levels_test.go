@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"core/logging"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managermodels "manager/pkg/models"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	global := zerolog.GlobalLevel()
+	t.Cleanup(func() {
+		require.NoError(t, logging.ApplyLevels(logging.LevelChange{Level: "info", ResetModules: true}))
+		zerolog.SetGlobalLevel(global)
+	})
+
+	customerCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "customer-1"})
+	_, err := handler.SetLogLevel(customerCtx, connect.NewRequest(&gatewayv1.SetLogLevelRequest{Level: "debug"}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	require.Equal(t, "info", logging.CurrentLevels().Level)
+
+	adminCtx := context.WithValue(context.Background(), "claims", &managermodels.AuthClaims{CustomerID: "admin", IsAdmin: true})
+	resp, err := handler.SetLogLevel(adminCtx, connect.NewRequest(&gatewayv1.SetLogLevelRequest{
+		ModuleLevels: map[string]string{"streaming": "trace"},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "info", resp.Msg.Level)
+	require.Equal(t, map[string]string{"streaming": "trace"}, resp.Msg.ModuleLevels)
+	require.Equal(t, zerolog.TraceLevel, zerolog.GlobalLevel())
+
+	_, err = handler.SetLogLevel(adminCtx, connect.NewRequest(&gatewayv1.SetLogLevelRequest{ModuleLevels: map[string]string{"": "debug"}}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
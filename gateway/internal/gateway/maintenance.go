@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	commonauth "core/auth"
+	"core/domain"
+	"core/events"
+)
+
+// maintenanceOverridePermission lets administrators operate the power of
+// servers in maintenance
+const maintenanceOverridePermission = "power:maintenance"
+
+// checkMaintenance rejects power operations on a server in maintenance,
+// unless the token carries the maintenance override
+func checkMaintenance(serverContext *commonauth.ServerContext) error {
+	if serverContext.Maintenance && !serverContext.HasPermission(maintenanceOverridePermission) {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("server %s is in maintenance", serverContext.ServerID))
+	}
+	return nil
+}
+
+// destructivePowerOperation reports whether a power operation interrupts the
+// server, and is therefore held back by maintenance windows
+func destructivePowerOperation(operation string) bool {
+	return operation != PowerOpPowerOn
+}
+
+// authorizeMaintenance checks a power operation against the maintenance of
+// its server: the maintenance flag, then the maintenance window in progress.
+// Destructive operations during a window are rejected unless the request
+// overrides it, which administrators alone may do for reject windows.
+// Overrides are audit logged and published as events.
+func (h *RegionalGatewayHandler) authorizeMaintenance(serverContext *commonauth.ServerContext, operation string, override bool) error {
+	if err := checkMaintenance(serverContext); err != nil {
+		return err
+	}
+	if !destructivePowerOperation(operation) {
+		return nil
+	}
+
+	window := serverContext.ActiveMaintenanceWindow(time.Now())
+	if window == nil {
+		return nil
+	}
+
+	allowed := override
+	if window.Policy == string(domain.MaintenancePolicyReject) {
+		allowed = override && serverContext.HasPermission(maintenanceOverridePermission)
+	}
+	if !allowed {
+		return connect.NewError(connect.CodeFailedPrecondition, maintenanceWindowError(serverContext.ServerID, window))
+	}
+
+	log.Warn().
+		Str("customer_id", serverContext.CustomerID).
+		Str("server_id", serverContext.ServerID).
+		Str("operation", operation).
+		Int64("maintenance_window_id", window.ID).
+		Str("policy", window.Policy).
+		Msg("Power operation overrode a maintenance window")
+
+	h.publishEvent(events.New(events.MaintenanceOverridden, "gateway", map[string]any{
+		"gateway_id":            h.gatewayID,
+		"server_id":             serverContext.ServerID,
+		"customer_id":           serverContext.CustomerID,
+		"operation":             operation,
+		"maintenance_window_id": window.ID,
+		"policy":                window.Policy,
+	}))
+	return nil
+}
+
+// maintenanceWindowError describes the maintenance window blocking an
+// operation
+func maintenanceWindowError(serverID string, window *commonauth.MaintenanceWindow) error {
+	message := fmt.Sprintf("server %s is in a maintenance window until %s", serverID, window.EndsAt.UTC().Format(time.RFC3339))
+	if window.Reason != "" {
+		message += fmt.Sprintf(" (%s)", window.Reason)
+	}
+	if window.Policy == string(domain.MaintenancePolicyReject) {
+		return fmt.Errorf("%s; only administrators can override it", message)
+	}
+	return fmt.Errorf("%s; retry with the maintenance override", message)
+}
+
+// MaintenanceBlocksPower reports whether the power operations of a server
+// token are blocked by the maintenance of its server, for the web UI pages to
+// tell it
+func (h *RegionalGatewayHandler) MaintenanceBlocksPower(token string) bool {
+	_, serverContext, err := h.validateToken(token)
+	if err != nil || serverContext == nil {
+		return false
+	}
+	return checkMaintenance(&commonauth.ServerContext{
+		ServerID:    serverContext.ServerID,
+		Permissions: serverContext.Permissions,
+		Maintenance: serverContext.Maintenance,
+	}) != nil
+}
@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	commonauth "core/auth"
+	"core/domain"
+	"core/events"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/agent"
+)
+
+func TestPowerOperations_Maintenance(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &diagAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{ServerId: "192.168.1.100:623"})
+
+	t.Run("blocked for customers", func(t *testing.T) {
+		ctx := createMaintenanceContext("192.168.1.100:623", "customer-1", []string{"power:read", "power:write", "power:diag"})
+		_, err := handler.PowerCycle(ctx, req)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		_, err = handler.DiagnosticInterrupt(ctx, req)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		require.Empty(t, agentService.serverIDs)
+	})
+
+	t.Run("allowed with the maintenance override", func(t *testing.T) {
+		ctx := createMaintenanceContext("192.168.1.100:623", "admin-1", []string{"power:read", "power:diag", maintenanceOverridePermission})
+		resp, err := handler.DiagnosticInterrupt(ctx, req)
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Success)
+		require.Equal(t, []string{"bmc-dc-1-192.168.1.100:623"}, agentService.serverIDs)
+	})
+}
+
+func TestPowerOperations_MaintenanceWindows(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	agentService := &diagAgent{}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(mux)
+	defer agentServer.Close()
+
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	windowContext := func(policy domain.MaintenancePolicy, customerID string, permissions []string) context.Context {
+		server := testTokenServer("192.168.1.100:623", customerID)
+		server.MaintenanceWindows = []*domain.MaintenanceWindow{{
+			ID:       7,
+			ServerID: server.ID,
+			Policy:   policy,
+			Reason:   "firmware upgrade",
+			StartsAt: time.Now().Add(-time.Minute),
+			EndsAt:   time.Now().Add(time.Hour),
+		}}
+		return createServerTokenContext(server, permissions)
+	}
+	customerPermissions := []string{"power:read", "power:write", "power:diag"}
+	diag := func(override bool) *connect.Request[gatewayv1.PowerOperationRequest] {
+		return connect.NewRequest(&gatewayv1.PowerOperationRequest{ServerId: "192.168.1.100:623", MaintenanceOverride: override})
+	}
+
+	t.Run("require override without override", func(t *testing.T) {
+		ctx := windowContext(domain.MaintenancePolicyRequireOverride, "customer-1", customerPermissions)
+		_, err := handler.DiagnosticInterrupt(ctx, diag(false))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		require.Contains(t, err.Error(), "firmware upgrade")
+		require.Contains(t, err.Error(), "retry with the maintenance override")
+		require.Empty(t, agentService.serverIDs)
+	})
+
+	t.Run("require override with override", func(t *testing.T) {
+		ctx := windowContext(domain.MaintenancePolicyRequireOverride, "customer-1", customerPermissions)
+		resp, err := handler.DiagnosticInterrupt(ctx, diag(true))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Success)
+
+		pending := handler.eventOutbox.Drain()
+		require.Len(t, pending, 2)
+		require.Equal(t, events.MaintenanceOverridden, pending[0].Type)
+		require.Equal(t, "customer-1", pending[0].Data["customer_id"])
+		require.Equal(t, PowerOpDiagnosticInterrupt, pending[0].Data["operation"])
+		require.Equal(t, int64(7), pending[0].Data["maintenance_window_id"])
+		require.Equal(t, events.PowerOperationExecuted, pending[1].Type)
+	})
+
+	t.Run("reject overridden by customers", func(t *testing.T) {
+		agentService.serverIDs = nil
+		ctx := windowContext(domain.MaintenancePolicyReject, "customer-1", customerPermissions)
+		_, err := handler.DiagnosticInterrupt(ctx, diag(true))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		require.Contains(t, err.Error(), "only administrators")
+		require.Empty(t, agentService.serverIDs)
+		require.Empty(t, handler.eventOutbox.Drain())
+	})
+
+	t.Run("reject overridden by administrators", func(t *testing.T) {
+		adminPermissions := []string{"power:read", "power:diag", maintenanceOverridePermission}
+		ctx := windowContext(domain.MaintenancePolicyReject, "admin-1", adminPermissions)
+		_, err := handler.DiagnosticInterrupt(ctx, diag(false))
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		resp, err := handler.DiagnosticInterrupt(ctx, diag(true))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Success)
+		require.Equal(t, []string{"bmc-dc-1-192.168.1.100:623"}, agentService.serverIDs)
+	})
+}
+
+func TestAuthorizeMaintenance_Windows(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	now := time.Now()
+	serverContext := &commonauth.ServerContext{
+		ServerID:    "server-1",
+		Permissions: []string{"power:write"},
+		MaintenanceWindows: []commonauth.MaintenanceWindow{
+			{ID: 1, Policy: string(domain.MaintenancePolicyReject), StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+			{ID: 2, Policy: string(domain.MaintenancePolicyReject), StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+		},
+	}
+
+	// Windows not in progress do not block
+	require.NoError(t, handler.authorizeMaintenance(serverContext, PowerOpPowerCycle, false))
+
+	serverContext.MaintenanceWindows = append(serverContext.MaintenanceWindows, commonauth.MaintenanceWindow{
+		ID: 3, Policy: string(domain.MaintenancePolicyReject), StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Minute),
+	})
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(handler.authorizeMaintenance(serverContext, PowerOpPowerCycle, false)))
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(handler.authorizeMaintenance(serverContext, PowerOpGracefulShutdown, true)))
+
+	// Powering on does not interrupt the server
+	require.NoError(t, handler.authorizeMaintenance(serverContext, PowerOpPowerOn, false))
+}
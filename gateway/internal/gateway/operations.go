@@ -0,0 +1,296 @@
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// Default bounds of the operations started with StartPowerOperation
+const (
+	DefaultOperationRetention = time.Hour
+	DefaultOperationTimeout   = 30 * time.Minute
+)
+
+// operationTracker keeps the operations started with StartPowerOperation,
+// for their retention once completed. Operations are kept in memory: they
+// are lost, and stop being followed, when the gateway restarts.
+type operationTracker struct {
+	retention time.Duration
+	timeout   time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	operations map[string]*trackedOperation
+}
+
+// trackedOperation is an operation with the channel closed, and replaced,
+// each time it changes
+type trackedOperation struct {
+	operation *gatewayv1.Operation
+	changed   chan struct{}
+}
+
+func newOperationTracker(retention, timeout time.Duration) *operationTracker {
+	return &operationTracker{
+		retention:  retention,
+		timeout:    timeout,
+		now:        time.Now,
+		operations: make(map[string]*trackedOperation),
+	}
+}
+
+// newOperationID returns an unguessable ID of an operation
+func newOperationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate operation ID: %w", err)
+	}
+	return "op-" + hex.EncodeToString(b), nil
+}
+
+// start tracks a new running operation of a server and returns it
+func (t *operationTracker) start(serverID string, operation gatewayv1.PowerOperation) (*gatewayv1.Operation, error) {
+	id, err := newOperationID()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expireLocked()
+
+	now := timestamppb.New(t.now())
+	op := &gatewayv1.Operation{
+		Id:        id,
+		ServerId:  serverID,
+		Operation: operation,
+		State:     gatewayv1.OperationState_OPERATION_STATE_RUNNING,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	t.operations[id] = &trackedOperation{operation: op, changed: make(chan struct{})}
+	return proto.Clone(op).(*gatewayv1.Operation), nil
+}
+
+// update changes an operation with change, wakes up its watchers, and
+// returns a copy of the changed operation
+func (t *operationTracker) update(id string, change func(*gatewayv1.Operation)) *gatewayv1.Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.operations[id]
+	if !ok {
+		return nil
+	}
+	change(tracked.operation)
+	tracked.operation.UpdatedAt = timestamppb.New(t.now())
+	close(tracked.changed)
+	tracked.changed = make(chan struct{})
+	return proto.Clone(tracked.operation).(*gatewayv1.Operation)
+}
+
+// progress records the last progress reported for an operation
+func (t *operationTracker) progress(id string, progress *gatewayv1.PowerOperationProgress) {
+	t.update(id, func(op *gatewayv1.Operation) {
+		op.Progress = progress
+	})
+}
+
+// complete records the outcome of an operation, failed when err is not nil
+func (t *operationTracker) complete(id string, err error) *gatewayv1.Operation {
+	return t.update(id, func(op *gatewayv1.Operation) {
+		op.State = gatewayv1.OperationState_OPERATION_STATE_SUCCEEDED
+		if err != nil {
+			op.State = gatewayv1.OperationState_OPERATION_STATE_FAILED
+			op.Error = err.Error()
+		}
+		op.CompletedAt = timestamppb.New(t.now())
+	})
+}
+
+// get returns a copy of an operation of a server, with the channel closed
+// when it next changes
+func (t *operationTracker) get(serverID, id string) (*gatewayv1.Operation, <-chan struct{}, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expireLocked()
+
+	tracked, ok := t.operations[id]
+	if !ok || tracked.operation.ServerId != serverID {
+		return nil, nil, false
+	}
+	return proto.Clone(tracked.operation).(*gatewayv1.Operation), tracked.changed, true
+}
+
+// list returns copies of the operations of a server, most recent first
+func (t *operationTracker) list(serverID string) []*gatewayv1.Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expireLocked()
+
+	var operations []*gatewayv1.Operation
+	for _, tracked := range t.operations {
+		if tracked.operation.ServerId == serverID {
+			operations = append(operations, proto.Clone(tracked.operation).(*gatewayv1.Operation))
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].StartedAt.AsTime().After(operations[j].StartedAt.AsTime())
+	})
+	return operations
+}
+
+// expireLocked forgets the operations completed longer than the retention ago
+func (t *operationTracker) expireLocked() {
+	expired := t.now().Add(-t.retention)
+	for id, tracked := range t.operations {
+		if completedAt := tracked.operation.CompletedAt; completedAt != nil && completedAt.AsTime().Before(expired) {
+			delete(t.operations, id)
+		}
+	}
+}
+
+// SetOperationLimits sets how long completed operations started with
+// StartPowerOperation are kept, and how long operations may run before the
+// gateway stops following them
+func (h *RegionalGatewayHandler) SetOperationLimits(retention, timeout time.Duration) {
+	h.operations = newOperationTracker(retention, timeout)
+}
+
+// SetOperationObserver sets the function told of every operation started
+// with StartPowerOperation once it completes, e.g. for metrics
+func (h *RegionalGatewayHandler) SetOperationObserver(observe func(op *gatewayv1.Operation)) {
+	h.operationObserver = observe
+}
+
+// StartPowerOperation starts a power operation like RunPowerOperation, and
+// returns at once with the operation tracking it. The gateway follows the
+// operation until the BMC completes it, or for the operation timeout.
+func (h *RegionalGatewayHandler) StartPowerOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RunPowerOperationRequest],
+) (*connect.Response[gatewayv1.StartPowerOperationResponse], error) {
+	mapping, agentInfo, operation, err := h.authorizeRunPowerOperation(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := h.operations.start(req.Msg.ServerId, req.Msg.Operation)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// The operation outlives the call, keeping its values for tracing
+	runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.operations.timeout)
+	go func() {
+		defer cancel()
+		err := h.relayPowerOperation(runCtx, mapping, agentInfo, req.Msg.Operation, operation, func(progress *gatewayv1.PowerOperationProgress) error {
+			h.operations.progress(op.Id, progress)
+			return nil
+		})
+		if completed := h.operations.complete(op.Id, err); completed != nil && h.operationObserver != nil {
+			h.operationObserver(completed)
+		}
+	}()
+
+	log.Info().
+		Str("operation_id", op.Id).
+		Str("operation", operation).
+		Str("server_id", op.ServerId).
+		Msg("Started power operation")
+
+	return connect.NewResponse(&gatewayv1.StartPowerOperationResponse{Operation: op}), nil
+}
+
+// authorizeOperations checks that the caller's server token may read the
+// operations of the requested server
+func (h *RegionalGatewayHandler) authorizeOperations(ctx context.Context, serverID string) error {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != serverID {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:read") {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
+	}
+	return nil
+}
+
+// GetOperation returns an operation of a server started with
+// StartPowerOperation
+func (h *RegionalGatewayHandler) GetOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetOperationRequest],
+) (*connect.Response[gatewayv1.GetOperationResponse], error) {
+	if err := h.authorizeOperations(ctx, req.Msg.ServerId); err != nil {
+		return nil, err
+	}
+
+	op, _, ok := h.operations.get(req.Msg.ServerId, req.Msg.OperationId)
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("operation not found: %s", req.Msg.OperationId))
+	}
+	return connect.NewResponse(&gatewayv1.GetOperationResponse{Operation: op}), nil
+}
+
+// ListOperations lists the operations of a server started with
+// StartPowerOperation, running or completed within the retention
+func (h *RegionalGatewayHandler) ListOperations(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListOperationsRequest],
+) (*connect.Response[gatewayv1.ListOperationsResponse], error) {
+	if err := h.authorizeOperations(ctx, req.Msg.ServerId); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&gatewayv1.ListOperationsResponse{
+		Operations: h.operations.list(req.Msg.ServerId),
+	}), nil
+}
+
+// WatchOperation streams an operation of a server each time it changes, and
+// returns once it completed
+func (h *RegionalGatewayHandler) WatchOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetOperationRequest],
+	stream *connect.ServerStream[gatewayv1.Operation],
+) error {
+	if err := h.authorizeOperations(ctx, req.Msg.ServerId); err != nil {
+		return err
+	}
+
+	for {
+		op, changed, ok := h.operations.get(req.Msg.ServerId, req.Msg.OperationId)
+		if !ok {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("operation not found: %s", req.Msg.OperationId))
+		}
+		if err := stream.Send(op); err != nil {
+			return err
+		}
+		if op.State != gatewayv1.OperationState_OPERATION_STATE_RUNNING {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
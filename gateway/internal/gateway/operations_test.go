@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+)
+
+// withServerToken authenticates a request with a token of the server
+// 192.168.1.100:623 granting permissions
+func withServerToken[T any](msg *T, permissions []string) *connect.Request[T] {
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", permissions)
+	req := connect.NewRequest(msg)
+	req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+	return req
+}
+
+// watchOperation collects the states of an operation streamed until it
+// completes
+func watchOperation(t *testing.T, client gatewayv1connect.GatewayServiceClient, operationID string) []*gatewayv1.Operation {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchOperation(ctx, withServerToken(&gatewayv1.GetOperationRequest{
+		ServerId:    "192.168.1.100:623",
+		OperationId: operationID,
+	}, []string{"power:read"}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var states []*gatewayv1.Operation
+	for stream.Receive() {
+		states = append(states, stream.Msg())
+	}
+	require.NoError(t, stream.Err())
+	return states
+}
+
+func TestStartPowerOperation(t *testing.T) {
+	agentService := &taskAgent{
+		progress: []*gatewayv1.PowerOperationProgress{
+			{TaskId: "1", State: "Running", PercentComplete: 40, Message: "Powering off"},
+			{TaskId: "1", State: "Completed", PercentComplete: 100, Message: "Power cycle completed", Done: true},
+		},
+	}
+	_, client := newPowerOperationGateway(t, agentService)
+
+	resp, err := client.StartPowerOperation(context.Background(), withServerToken(&gatewayv1.RunPowerOperationRequest{
+		ServerId:  "192.168.1.100:623",
+		Operation: gatewayv1.PowerOperation_POWER_OPERATION_CYCLE,
+	}, []string{"power:read", "power:write"}))
+	require.NoError(t, err)
+	started := resp.Msg.Operation
+	require.NotEmpty(t, started.Id)
+	require.Equal(t, gatewayv1.OperationState_OPERATION_STATE_RUNNING, started.State)
+
+	states := watchOperation(t, client, started.Id)
+	last := states[len(states)-1]
+	require.Equal(t, gatewayv1.OperationState_OPERATION_STATE_SUCCEEDED, last.State)
+	require.True(t, last.Progress.Done)
+	require.NotNil(t, last.CompletedAt)
+
+	got, err := client.GetOperation(context.Background(), withServerToken(&gatewayv1.GetOperationRequest{
+		ServerId:    "192.168.1.100:623",
+		OperationId: started.Id,
+	}, []string{"power:read"}))
+	require.NoError(t, err)
+	require.Equal(t, gatewayv1.OperationState_OPERATION_STATE_SUCCEEDED, got.Msg.Operation.State)
+
+	list, err := client.ListOperations(context.Background(), withServerToken(&gatewayv1.ListOperationsRequest{
+		ServerId: "192.168.1.100:623",
+	}, []string{"power:read"}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Operations, 1)
+	require.Equal(t, started.Id, list.Msg.Operations[0].Id)
+}
+
+func TestStartPowerOperation_Failed(t *testing.T) {
+	agentService := &taskAgent{
+		progress: []*gatewayv1.PowerOperationProgress{
+			{TaskId: "1", State: "Running", PercentComplete: -1},
+		},
+	}
+	_, client := newPowerOperationGateway(t, agentService)
+
+	resp, err := client.StartPowerOperation(context.Background(), withServerToken(&gatewayv1.RunPowerOperationRequest{
+		ServerId:  "192.168.1.100:623",
+		Operation: gatewayv1.PowerOperation_POWER_OPERATION_RESET,
+	}, []string{"power:read", "power:write"}))
+	require.NoError(t, err)
+
+	states := watchOperation(t, client, resp.Msg.Operation.Id)
+	last := states[len(states)-1]
+	require.Equal(t, gatewayv1.OperationState_OPERATION_STATE_FAILED, last.State)
+	require.Contains(t, last.Error, "before the operation completed")
+}
+
+func TestOperations_Authorization(t *testing.T) {
+	handler, client := newPowerOperationGateway(t, &taskAgent{})
+
+	// Starting requires power:write, like RunPowerOperation
+	_, err := client.StartPowerOperation(context.Background(), withServerToken(&gatewayv1.RunPowerOperationRequest{
+		ServerId:  "192.168.1.100:623",
+		Operation: gatewayv1.PowerOperation_POWER_OPERATION_ON,
+	}, []string{"power:read"}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// The operations of other servers are not found
+	other, err := handler.operations.start("192.168.1.101:623", gatewayv1.PowerOperation_POWER_OPERATION_ON)
+	require.NoError(t, err)
+	_, err = client.GetOperation(context.Background(), withServerToken(&gatewayv1.GetOperationRequest{
+		ServerId:    "192.168.1.100:623",
+		OperationId: other.Id,
+	}, []string{"power:read"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = client.ListOperations(context.Background(), withServerToken(&gatewayv1.ListOperationsRequest{
+		ServerId: "192.168.1.101:623",
+	}, []string{"power:read"}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestOperationTracker_Retention(t *testing.T) {
+	tracker := newOperationTracker(time.Hour, time.Minute)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	running, err := tracker.start("server-1", gatewayv1.PowerOperation_POWER_OPERATION_CYCLE)
+	require.NoError(t, err)
+	completed, err := tracker.start("server-1", gatewayv1.PowerOperation_POWER_OPERATION_ON)
+	require.NoError(t, err)
+	tracker.complete(completed.Id, nil)
+
+	_, changed, ok := tracker.get("server-1", running.Id)
+	require.True(t, ok)
+	tracker.progress(running.Id, &gatewayv1.PowerOperationProgress{State: "Running"})
+	select {
+	case <-changed:
+	default:
+		t.Fatal("Expected watchers told of the progress")
+	}
+
+	// Completed operations are forgotten after the retention, running ones
+	// are kept
+	now = now.Add(2 * time.Hour)
+	operations := tracker.list("server-1")
+	require.Len(t, operations, 1)
+	require.Equal(t, running.Id, operations[0].Id)
+	require.Equal(t, "Running", operations[0].Progress.State)
+}
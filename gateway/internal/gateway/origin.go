@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OriginPolicy decides which browser origins may open console WebSockets and
+// make CORS requests to the gateway. The gateway's own origin is always
+// allowed, so the embedded VNC and console viewers work without configuration.
+//
+// Browsers send session cookies with WebSocket handshakes regardless of the
+// page's origin, so without this check any site could open a console on
+// behalf of a logged-in user (cross-site WebSocket hijacking).
+type OriginPolicy struct {
+	allowAll bool
+	allowed  map[string]bool
+}
+
+// NewOriginPolicy creates a policy allowing the given origins, e.g.
+// "https://console.example.com". The "*" origin allows any origin.
+func NewOriginPolicy(origins []string) *OriginPolicy {
+	policy := &OriginPolicy{allowed: make(map[string]bool)}
+	for _, origin := range origins {
+		if origin == "*" {
+			policy.allowAll = true
+			continue
+		}
+		policy.allowed[normalizeOrigin(origin)] = true
+	}
+	return policy
+}
+
+// Allowed reports whether the request's origin is allowed. Requests without
+// an Origin header come from non-browser clients such as the CLI and are
+// always allowed.
+func (p *OriginPolicy) Allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || p.allowAll {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return p.allowed[normalizeOrigin(origin)]
+}
+
+// CheckWebSocketOrigin is a websocket.Upgrader CheckOrigin function. Rejected
+// handshakes fail with 403 Forbidden.
+func (p *OriginPolicy) CheckWebSocketOrigin(r *http.Request) bool {
+	if p.Allowed(r) {
+		return true
+	}
+
+	log.Warn().
+		Str("origin", r.Header.Get("Origin")).
+		Str("host", r.Host).
+		Str("path", r.URL.Path).
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Rejected cross-origin WebSocket connection")
+	return false
+}
+
+// CORS adds CORS headers for allowed origins. Disallowed origins get no CORS
+// headers, so browsers block the response.
+func (p *OriginPolicy) CORS(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && p.Allowed(r) {
+			if p.allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				// Cookies are only sent to explicitly allowed origins
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			// Connect and gRPC-Web headers of browser clients
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-CSRF-Token, Connect-Protocol-Version, Connect-Timeout-Ms, X-Grpc-Web, X-User-Agent, Grpc-Timeout")
+			w.Header().Set("Access-Control-Expose-Headers", "Connect-Protocol-Version, Connect-Timeout-Ms, Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin")
+		}
+		w.Header().Add("Vary", "Origin")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// normalizeOrigin lowercases an origin and strips any trailing slash
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
@@ -0,0 +1,117 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/internal/session"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+)
+
+func newOriginRequest(method, host, origin string) *http.Request {
+	r := httptest.NewRequest(method, "http://"+host+"/console/sol-1/ws", nil)
+	if origin != "" {
+		r.Header.Set("Origin", origin)
+	}
+	return r
+}
+
+func TestOriginPolicyAllowed(t *testing.T) {
+	policy := NewOriginPolicy([]string{"https://Portal.example.com/"})
+
+	tests := []struct {
+		name    string
+		host    string
+		origin  string
+		allowed bool
+	}{
+		{name: "no origin header", host: "gateway:8081", allowed: true},
+		{name: "same origin", host: "gateway:8081", origin: "http://gateway:8081", allowed: true},
+		{name: "listed origin", host: "gateway:8081", origin: "https://portal.example.com", allowed: true},
+		{name: "cross-site origin", host: "gateway:8081", origin: "https://evil.example.com", allowed: false},
+		{name: "same host on another port", host: "gateway:8081", origin: "http://gateway:9000", allowed: false},
+		{name: "malformed origin", host: "gateway:8081", origin: "null", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newOriginRequest(http.MethodGet, tt.host, tt.origin)
+			require.Equal(t, tt.allowed, policy.Allowed(r))
+			require.Equal(t, tt.allowed, policy.CheckWebSocketOrigin(r))
+		})
+	}
+
+	t.Run("wildcard", func(t *testing.T) {
+		policy := NewOriginPolicy([]string{"*"})
+		require.True(t, policy.Allowed(newOriginRequest(http.MethodGet, "gateway:8081", "https://evil.example.com")))
+	})
+}
+
+func TestOriginPolicyCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := NewOriginPolicy([]string{"https://portal.example.com"}).CORS(next)
+
+	t.Run("allowed origin", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newOriginRequest(http.MethodPost, "gateway:8081", "https://portal.example.com"))
+		require.Equal(t, http.StatusTeapot, rec.Code)
+		require.Equal(t, "https://portal.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+		require.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), session.CSRFHeaderName)
+		require.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "X-Grpc-Web")
+		require.Contains(t, rec.Header().Get("Access-Control-Expose-Headers"), "Grpc-Status")
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newOriginRequest(http.MethodPost, "gateway:8081", "https://evil.example.com"))
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Origin", rec.Header().Get("Vary"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newOriginRequest(http.MethodOptions, "gateway:8081", "https://portal.example.com"))
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestAuthInterceptorRequiresCSRFToken(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	interceptor := NewAuthInterceptor(handler)
+
+	now := time.Now()
+	require.NoError(t, handler.webSessionStore.Create(&session.WebSession{
+		ID:             "web-1",
+		CustomerJWT:    "customer-jwt",
+		CreatedAt:      now,
+		LastActivityAt: now,
+		ExpiresAt:      now.Add(time.Hour),
+		TokenExpiresAt: now.Add(time.Hour),
+		TokenRenewalAt: now.Add(time.Hour),
+	}))
+
+	extract := func(csrfToken string) string {
+		httpReq := httptest.NewRequest(http.MethodPost, "/gateway.v1.GatewayService/PowerOn", nil)
+		httpReq.AddCookie(&http.Cookie{Name: session.CookieName, Value: "web-1"})
+		ctx := WithHTTPRequest(context.Background(), httpReq)
+
+		req := connect.NewRequest(&gatewayv1.PowerOperationRequest{})
+		if csrfToken != "" {
+			req.Header().Set(session.CSRFHeaderName, csrfToken)
+		}
+		return interceptor.extractJWT(ctx, req.Header(), req.Spec().Procedure)
+	}
+
+	require.Equal(t, "customer-jwt", extract(handler.CSRFToken("web-1")))
+	require.Empty(t, extract(""), "cookie without CSRF token should not authenticate")
+	require.Empty(t, extract(handler.CSRFToken("web-2")), "CSRF token of another session should be rejected")
+}
@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/idempotency"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// SetPowerIdempotencyWindow sets how long the results of power operations
+// sent with an idempotency key are returned to their retries, 0 to run every
+// request
+func (h *RegionalGatewayHandler) SetPowerIdempotencyWindow(window time.Duration) {
+	h.powerOperations = idempotency.NewCache[*gatewayv1.PowerOperationResponse](window)
+}
+
+// SetPowerDeduplicationObserver sets the function told of every power
+// operation answered with the result of an earlier request with its key
+func (h *RegionalGatewayHandler) SetPowerDeduplicationObserver(observe func(operation string)) {
+	h.powerDeduplicationObserver = observe
+}
+
+// deduplicatePowerOperation runs a power operation of a server once per
+// idempotency key within the window. Retries get the result of the first
+// request, or wait for it while it runs, with the Idempotent-Replayed
+// header. Failed operations run again when retried.
+func (h *RegionalGatewayHandler) deduplicatePowerOperation(
+	ctx context.Context,
+	serverID, operation, idempotencyKey string,
+	run func(ctx context.Context) (*connect.Response[gatewayv1.PowerOperationResponse], error),
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	if err := idempotency.ValidateKey(idempotencyKey); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if idempotencyKey == "" || h.powerOperations.Window() <= 0 {
+		return run(ctx)
+	}
+
+	// Keyed operations complete when their client stops waiting, so that
+	// its retry gets the result rather than running them again
+	detached := context.WithoutCancel(ctx)
+	result, replayed, err := h.powerOperations.Do(ctx, serverID+"/"+idempotencyKey, operation,
+		func() (*gatewayv1.PowerOperationResponse, error) {
+			resp, err := run(detached)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		})
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if errors.Is(err, idempotency.ErrTooManyKeys) {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(result)
+	if replayed {
+		log.Info().
+			Str("operation", operation).
+			Str("server_id", serverID).
+			Str("idempotency_key", idempotencyKey).
+			Msg("Power operation retried with its idempotency key, returning the first result")
+		resp.Header().Set(idempotency.ReplayedHeader, "true")
+		if h.powerDeduplicationObserver != nil {
+			h.powerDeduplicationObserver(operation)
+		}
+	}
+	return resp, nil
+}
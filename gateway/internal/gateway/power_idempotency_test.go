@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/idempotency"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// newPowerCycleRequest creates a power cycle request with an idempotency key,
+// authenticated with a server token granting power:write
+func newPowerCycleRequest(idempotencyKey string) *connect.Request[gatewayv1.PowerOperationRequest] {
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"power:write"})
+	req := connect.NewRequest(&gatewayv1.PowerOperationRequest{
+		ServerId:       "192.168.1.100:623",
+		IdempotencyKey: idempotencyKey,
+	})
+	req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+	return req
+}
+
+func TestPowerOperation_IdempotencyKey(t *testing.T) {
+	agentService := &taskAgent{}
+	handler, client := newPowerOperationGateway(t, agentService)
+	var deduplicated []string
+	handler.SetPowerDeduplicationObserver(func(operation string) { deduplicated = append(deduplicated, operation) })
+	ctx := context.Background()
+
+	first, err := client.PowerCycle(ctx, newPowerCycleRequest("key-1"))
+	require.NoError(t, err)
+	assert.Empty(t, first.Header().Get(idempotency.ReplayedHeader))
+
+	// The retry gets the first result back without cycling the server again
+	retry, err := client.PowerCycle(ctx, newPowerCycleRequest("key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "power cycle 1", retry.Msg.Message)
+	assert.Equal(t, "true", retry.Header().Get(idempotency.ReplayedHeader))
+	require.Len(t, agentService.cycles, 1)
+	assert.Equal(t, "key-1", agentService.cycles[0].IdempotencyKey, "the key is passed on to the agent")
+	assert.Equal(t, []string{PowerOpPowerCycle}, deduplicated)
+
+	// Other keys, and requests without one, run
+	_, err = client.PowerCycle(ctx, newPowerCycleRequest("key-2"))
+	require.NoError(t, err)
+	_, err = client.PowerCycle(ctx, newPowerCycleRequest(""))
+	require.NoError(t, err)
+	_, err = client.PowerCycle(ctx, newPowerCycleRequest(""))
+	require.NoError(t, err)
+	assert.Len(t, agentService.cycles, 4)
+}
+
+func TestPowerOperation_IdempotencyKeyDisabled(t *testing.T) {
+	agentService := &taskAgent{}
+	handler, client := newPowerOperationGateway(t, agentService)
+	handler.SetPowerIdempotencyWindow(0)
+
+	for range 2 {
+		_, err := client.PowerCycle(context.Background(), newPowerCycleRequest("key-1"))
+		require.NoError(t, err)
+	}
+	assert.Len(t, agentService.cycles, 2)
+}
+
+func TestPowerOperation_IdempotencyKeyTooLong(t *testing.T) {
+	agentService := &taskAgent{}
+	_, client := newPowerOperationGateway(t, agentService)
+
+	_, err := client.PowerCycle(context.Background(), newPowerCycleRequest(strings.Repeat("k", idempotency.MaxKeyLength+1)))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Empty(t, agentService.cycles)
+}
@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/metering"
+	managerv1 "manager/gen/manager/v1"
+)
+
+// GetPowerReading proxies a power consumption reading of a server to its
+// agent
+func (h *RegionalGatewayHandler) GetPowerReading(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetPowerReadingRequest],
+) (*connect.Response[gatewayv1.GetPowerReadingResponse], error) {
+	agentClient, serverID, err := h.meteringAgentClient(ctx, req.Msg.ServerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return agentClient.GetPowerReading(ctx, connect.NewRequest(&gatewayv1.GetPowerReadingRequest{
+		ServerId: serverID,
+	}))
+}
+
+// GetEnergyUsage proxies an energy counter reading of a server to its agent
+func (h *RegionalGatewayHandler) GetEnergyUsage(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetEnergyUsageRequest],
+) (*connect.Response[gatewayv1.GetEnergyUsageResponse], error) {
+	agentClient, serverID, err := h.meteringAgentClient(ctx, req.Msg.ServerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return agentClient.GetEnergyUsage(ctx, connect.NewRequest(&gatewayv1.GetEnergyUsageRequest{
+		ServerId: serverID,
+	}))
+}
+
+// meteringAgentClient authorizes a metering request for a server and returns
+// the RPC client of the agent reaching its BMC. Metering reads are authorized
+// like power status, with power:read.
+func (h *RegionalGatewayHandler) meteringAgentClient(ctx context.Context, serverID string) (gatewayv1connect.GatewayServiceClient, string, error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != serverID {
+		return nil, "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:read") {
+		return nil, "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power metering"))
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, "", connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		return nil, "", connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
+	}
+
+	log.Debug().
+		Str("server_id", serverContext.ServerID).
+		Str("bmc_endpoint", serverContext.BMCEndpoint).
+		Str("agent_id", mapping.AgentID).
+		Msg("Proxying power metering request to agent")
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+	return agentClient, serverContext.ServerID, nil
+}
+
+// SetPowerSampler sets the sampler whose samples are reported to the manager
+// for the power usage report
+func (h *RegionalGatewayHandler) SetPowerSampler(sampler *metering.Sampler) {
+	h.powerSampler = sampler
+}
+
+// PowerMeteringTargets returns the servers reachable through active agents,
+// for the power sampler
+func (h *RegionalGatewayHandler) PowerMeteringTargets() []metering.Target {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	targets := make([]metering.Target, 0, len(h.bmcEndpointMapping))
+	for _, mapping := range h.bmcEndpointMapping {
+		agentInfo := h.agentRegistry.Get(mapping.AgentID)
+		if agentInfo == nil || agentInfo.Status != "active" {
+			continue
+		}
+		targets = append(targets, metering.Target{
+			ServerID:      mapping.ServerID,
+			BMCEndpoint:   mapping.BMCEndpoint,
+			DatacenterID:  mapping.DatacenterID,
+			AgentEndpoint: agentInfo.Endpoint,
+		})
+	}
+	return targets
+}
+
+// reportPowerReadingsToManager sends buffered power samples to the manager.
+// Samples are kept for the next attempt if reporting fails.
+func (h *RegionalGatewayHandler) reportPowerReadingsToManager(ctx context.Context) error {
+	// Skip manager reporting in test mode
+	if h.testMode || h.powerSampler == nil {
+		return nil
+	}
+
+	samples := h.powerSampler.Drain()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	reportReq := &managerv1.ReportPowerReadingsRequest{
+		GatewayId: h.gatewayID,
+		Samples:   make([]*managerv1.PowerSample, 0, len(samples)),
+	}
+	for _, s := range samples {
+		reportReq.Samples = append(reportReq.Samples, &managerv1.PowerSample{
+			BmcEndpoint:   s.BMCEndpoint,
+			DatacenterId:  s.DatacenterID,
+			SampledAt:     timestamppb.New(s.SampledAt),
+			ConsumedWatts: s.ConsumedWatts,
+			EnergyKwh:     s.EnergyKWh,
+		})
+	}
+
+	token, err := h.authenticateWithManager(ctx)
+	if err != nil {
+		h.powerSampler.Requeue(samples)
+		return fmt.Errorf("failed to authenticate with manager: %w", err)
+	}
+
+	req := connect.NewRequest(reportReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	if _, err := h.managerClient.ReportPowerReadings(ctx, req); err != nil {
+		h.powerSampler.Requeue(samples)
+		return fmt.Errorf("failed to report power readings to manager: %w", err)
+	}
+
+	log.Debug().Int("sample_count", len(samples)).Msg("Reported power readings to manager")
+	return nil
+}
@@ -0,0 +1,161 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/internal/agent"
+)
+
+// runPowerOperations maps the operations of RunPowerOperation to the power
+// operation names used in logs and events
+var runPowerOperations = map[gatewayv1.PowerOperation]string{
+	gatewayv1.PowerOperation_POWER_OPERATION_ON:                   PowerOpPowerOn,
+	gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF:            PowerOpForceOff,
+	gatewayv1.PowerOperation_POWER_OPERATION_CYCLE:                PowerOpPowerCycle,
+	gatewayv1.PowerOperation_POWER_OPERATION_RESET:                PowerOpReset,
+	gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT: PowerOpDiagnosticInterrupt,
+}
+
+// RunPowerOperation proxies a power operation to the agent of a server and
+// relays its progress until the BMC completes it. It is authorized like the
+// unary power operations: power:write, or power:diag for a diagnostic
+// interrupt.
+func (h *RegionalGatewayHandler) RunPowerOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RunPowerOperationRequest],
+	stream *connect.ServerStream[gatewayv1.PowerOperationProgress],
+) error {
+	mapping, agentInfo, operation, err := h.authorizeRunPowerOperation(ctx, req.Msg)
+	if err != nil {
+		return err
+	}
+
+	return h.relayPowerOperation(ctx, mapping, agentInfo, req.Msg.Operation, operation, func(progress *gatewayv1.PowerOperationProgress) error {
+		if err := stream.Send(progress); err != nil {
+			// The caller went away: the operation still runs on the BMC
+			log.Debug().Err(err).Str("bmc_endpoint", mapping.BMCEndpoint).Msg("Failed to relay power operation progress")
+			return err
+		}
+		return nil
+	})
+}
+
+// authorizeRunPowerOperation checks that the caller's server token may run
+// the requested operation, and returns the agent serving the server with the
+// operation's name
+func (h *RegionalGatewayHandler) authorizeRunPowerOperation(
+	ctx context.Context,
+	req *gatewayv1.RunPowerOperationRequest,
+) (*domain.AgentBMCMapping, *agent.Info, string, error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, nil, "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != req.ServerId {
+		return nil, nil, "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	operation, ok := runPowerOperations[req.Operation]
+	if !ok {
+		return nil, nil, "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown power operation: %s", req.Operation))
+	}
+
+	permission := "power:write"
+	if operation == PowerOpDiagnosticInterrupt {
+		permission = "power:diag"
+	}
+	if !serverContext.HasPermission(permission) {
+		return nil, nil, "", connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
+	}
+
+	if err := h.authorizeMaintenance(serverContext, operation, req.MaintenanceOverride); err != nil {
+		return nil, nil, "", err
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, nil, "", connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		return nil, nil, "", connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
+	}
+
+	return mapping, agentInfo, operation, nil
+}
+
+// relayPowerOperation runs a power operation on the agent of its server,
+// calling send with each progress message until the BMC completes it, and
+// publishes its outcome. It fails when send does.
+func (h *RegionalGatewayHandler) relayPowerOperation(
+	ctx context.Context,
+	mapping *domain.AgentBMCMapping,
+	agentInfo *agent.Info,
+	powerOperation gatewayv1.PowerOperation,
+	operation string,
+	send func(*gatewayv1.PowerOperationProgress) error,
+) error {
+	log.Info().
+		Str("operation", operation).
+		Str("bmc_endpoint", mapping.BMCEndpoint).
+		Str("agent_id", mapping.AgentID).
+		Str("agent_endpoint", agentInfo.Endpoint).
+		Msg("Proxying power operation with progress to agent")
+
+	// Tasks outlive the timeout of unary calls: stream over a client without
+	// timeout, bounded by the caller's context
+	agentStream, err := h.agentClients.StreamClient(agentInfo.Endpoint).RunPowerOperation(ctx, connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+		ServerId:  mapping.ServerID,
+		Operation: powerOperation,
+	}))
+	if err != nil {
+		h.publishPowerOperation(mapping, operation, false, err.Error())
+		return err
+	}
+	defer agentStream.Close()
+
+	var last *gatewayv1.PowerOperationProgress
+	for agentStream.Receive() {
+		last = agentStream.Msg()
+		if err := send(last); err != nil {
+			return err
+		}
+	}
+
+	if err := agentStream.Err(); err != nil {
+		log.Error().
+			Err(err).
+			Str("operation", operation).
+			Str("bmc_endpoint", mapping.BMCEndpoint).
+			Str("agent_id", mapping.AgentID).
+			Msg("Power operation failed")
+		h.publishPowerOperation(mapping, operation, false, err.Error())
+		return err
+	}
+	if last == nil || !last.Done {
+		err := errors.New("agent ended the stream before the operation completed")
+		h.publishPowerOperation(mapping, operation, false, err.Error())
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	log.Info().
+		Str("operation", operation).
+		Str("bmc_endpoint", mapping.BMCEndpoint).
+		Str("task_id", last.TaskId).
+		Msg("Power operation completed")
+
+	h.publishPowerOperation(mapping, operation, true, last.Message)
+	return nil
+}
@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"core/domain"
+	"core/events"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/agent"
+	managermodels "manager/pkg/models"
+)
+
+// taskAgent is an agent RPC server reporting the progress of fixed power
+// operation tasks, and counting its power cycles
+type taskAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	requests []*gatewayv1.RunPowerOperationRequest
+	progress []*gatewayv1.PowerOperationProgress
+	cycles   []*gatewayv1.PowerOperationRequest
+}
+
+func (a *taskAgent) PowerCycle(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	a.cycles = append(a.cycles, req.Msg)
+	return connect.NewResponse(&gatewayv1.PowerOperationResponse{
+		Success: true,
+		Message: fmt.Sprintf("power cycle %d", len(a.cycles)),
+	}), nil
+}
+
+func (a *taskAgent) RunPowerOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RunPowerOperationRequest],
+	stream *connect.ServerStream[gatewayv1.PowerOperationProgress],
+) error {
+	a.requests = append(a.requests, req.Msg)
+	for _, progress := range a.progress {
+		if err := stream.Send(progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newPowerOperationGateway serves a gateway, with its authentication, in
+// front of an agent managing the BMC 192.168.1.100:623
+func newPowerOperationGateway(t *testing.T, agentService *taskAgent) (*RegionalGatewayHandler, gatewayv1connect.GatewayServiceClient) {
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agentService)
+	agentMux := http.NewServeMux()
+	agentMux.Handle(path, rpcHandler)
+	agentServer := httptest.NewServer(h2c.NewHandler(agentMux, &http2.Server{}))
+	t.Cleanup(agentServer.Close)
+
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     agentServer.URL,
+		LastSeen:     time.Now(),
+	})
+
+	handler.mu.Lock()
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "bmc-dc-1-192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeRedfish,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	handler.mu.Unlock()
+
+	path, rpcHandler = gatewayv1connect.NewGatewayServiceHandler(handler,
+		connect.WithInterceptors(NewAuthInterceptor(handler), handler.SourceAllowlistInterceptor()))
+	gatewayMux := http.NewServeMux()
+	gatewayMux.Handle(path, rpcHandler)
+	gatewayServer := httptest.NewServer(gatewayMux)
+	t.Cleanup(gatewayServer.Close)
+
+	return handler, gatewayv1connect.NewGatewayServiceClient(http.DefaultClient, gatewayServer.URL)
+}
+
+// newPowerOperationRequest creates a request authenticated with a server
+// token granting permissions
+func newPowerOperationRequest(operation gatewayv1.PowerOperation, permissions []string) *connect.Request[gatewayv1.RunPowerOperationRequest] {
+	ctx := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", permissions)
+	req := connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+		ServerId:  "192.168.1.100:623",
+		Operation: operation,
+	})
+	req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+	return req
+}
+
+// receiveProgress collects the progress messages of a stream
+func receiveProgress(stream *connect.ServerStreamForClient[gatewayv1.PowerOperationProgress]) ([]*gatewayv1.PowerOperationProgress, error) {
+	defer stream.Close()
+
+	var progress []*gatewayv1.PowerOperationProgress
+	for stream.Receive() {
+		progress = append(progress, stream.Msg())
+	}
+	return progress, stream.Err()
+}
+
+func TestRunPowerOperation(t *testing.T) {
+	agentService := &taskAgent{
+		progress: []*gatewayv1.PowerOperationProgress{
+			{TaskId: "1", State: "Running", PercentComplete: 40, Message: "Powering off"},
+			{TaskId: "1", State: "Completed", PercentComplete: 100, Message: "Power cycle completed", Done: true},
+		},
+	}
+	handler, client := newPowerOperationGateway(t, agentService)
+
+	stream, err := client.RunPowerOperation(context.Background(),
+		newPowerOperationRequest(gatewayv1.PowerOperation_POWER_OPERATION_CYCLE, []string{"power:read", "power:write"}))
+	require.NoError(t, err)
+	progress, err := receiveProgress(stream)
+	require.NoError(t, err)
+
+	require.Len(t, progress, 2)
+	require.Equal(t, int32(40), progress[0].PercentComplete)
+	require.True(t, progress[1].Done)
+
+	require.Len(t, agentService.requests, 1)
+	require.Equal(t, "bmc-dc-1-192.168.1.100:623", agentService.requests[0].ServerId)
+	require.Equal(t, gatewayv1.PowerOperation_POWER_OPERATION_CYCLE, agentService.requests[0].Operation)
+
+	// Reported like the unary power operations, once completed
+	pending := handler.eventOutbox.Drain()
+	require.Len(t, pending, 1)
+	require.Equal(t, events.PowerOperationExecuted, pending[0].Type)
+	require.Equal(t, PowerOpPowerCycle, pending[0].Data["operation"])
+}
+
+func TestRunPowerOperation_Errors(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
+		agentService := &taskAgent{}
+		_, client := newPowerOperationGateway(t, agentService)
+
+		stream, err := client.RunPowerOperation(context.Background(), connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+			ServerId:  "192.168.1.100:623",
+			Operation: gatewayv1.PowerOperation_POWER_OPERATION_ON,
+		}))
+		require.NoError(t, err)
+		_, err = receiveProgress(stream)
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		require.Empty(t, agentService.requests)
+	})
+
+	t.Run("source address outside the allowlist", func(t *testing.T) {
+		agentService := &taskAgent{}
+		handler, client := newPowerOperationGateway(t, agentService)
+
+		customer := &managermodels.Customer{ID: "customer-1", Email: "customer-1@example.com", AllowedSourceRanges: []string{"10.0.0.0/8"}}
+		token, err := handler.jwtManager.GenerateServerToken(customer, testTokenServer("192.168.1.100:623", "customer-1"), []string{"power:read", "power:write"})
+		require.NoError(t, err)
+		req := connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+			ServerId:  "192.168.1.100:623",
+			Operation: gatewayv1.PowerOperation_POWER_OPERATION_CYCLE,
+		})
+		req.Header().Set("Authorization", "Bearer "+token)
+		stream, err := client.RunPowerOperation(context.Background(), req)
+		require.NoError(t, err)
+		_, err = receiveProgress(stream)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		require.Empty(t, agentService.requests)
+	})
+
+	t.Run("diagnostic interrupt requires power:diag", func(t *testing.T) {
+		agentService := &taskAgent{}
+		_, client := newPowerOperationGateway(t, agentService)
+
+		stream, err := client.RunPowerOperation(context.Background(),
+			newPowerOperationRequest(gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT, []string{"power:read", "power:write"}))
+		require.NoError(t, err)
+		_, err = receiveProgress(stream)
+		require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		require.Empty(t, agentService.requests)
+	})
+
+	t.Run("server in maintenance", func(t *testing.T) {
+		agentService := &taskAgent{}
+		_, client := newPowerOperationGateway(t, agentService)
+
+		ctx := createMaintenanceContext("192.168.1.100:623", "customer-1", []string{"power:read", "power:write"})
+		req := connect.NewRequest(&gatewayv1.RunPowerOperationRequest{
+			ServerId:  "192.168.1.100:623",
+			Operation: gatewayv1.PowerOperation_POWER_OPERATION_CYCLE,
+		})
+		req.Header().Set("Authorization", "Bearer "+ctx.Value("token").(string))
+		stream, err := client.RunPowerOperation(context.Background(), req)
+		require.NoError(t, err)
+		_, err = receiveProgress(stream)
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		require.Empty(t, agentService.requests)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		agentService := &taskAgent{}
+		_, client := newPowerOperationGateway(t, agentService)
+
+		stream, err := client.RunPowerOperation(context.Background(),
+			newPowerOperationRequest(gatewayv1.PowerOperation_POWER_OPERATION_UNSPECIFIED, []string{"power:read", "power:write"}))
+		require.NoError(t, err)
+		_, err = receiveProgress(stream)
+		require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("stream ended before completion", func(t *testing.T) {
+		agentService := &taskAgent{
+			progress: []*gatewayv1.PowerOperationProgress{
+				{TaskId: "1", State: "Running", PercentComplete: -1},
+			},
+		}
+		handler, client := newPowerOperationGateway(t, agentService)
+
+		stream, err := client.RunPowerOperation(context.Background(),
+			newPowerOperationRequest(gatewayv1.PowerOperation_POWER_OPERATION_RESET, []string{"power:read", "power:write"}))
+		require.NoError(t, err)
+		progress, err := receiveProgress(stream)
+		require.Len(t, progress, 1)
+		require.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+
+		pending := handler.eventOutbox.Drain()
+		require.Len(t, pending, 1)
+		require.False(t, pending[0].Data["success"].(bool))
+	})
+}
@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -55,7 +57,7 @@ func (h *RegionalGatewayHandler) CreateWebSessionForConsole(
 		CustomerJWT:    customerJWT,
 		CreatedAt:      now,
 		LastActivityAt: now,
-		ExpiresAt:      now.Add(session.DefaultSessionDuration), // 24 hours
+		ExpiresAt:      now.Add(h.webSessionTTL),
 		TokenExpiresAt: tokenExpiresAt,
 		TokenRenewalAt: tokenRenewalAt,
 		CustomerID:     claims.CustomerID,
@@ -83,3 +85,58 @@ func (h *RegionalGatewayHandler) CreateWebSessionForConsole(
 func (h *RegionalGatewayHandler) GetWebSessionStore() session.Store {
 	return h.webSessionStore
 }
+
+// ConfigureWebSessions replaces the web session store and lifetime. It must
+// be called before the gateway starts serving requests.
+func (h *RegionalGatewayHandler) ConfigureWebSessions(store session.Store, ttl time.Duration) {
+	h.webSessionStore = store
+	if ttl > 0 {
+		h.webSessionTTL = ttl
+	}
+}
+
+// WebSessionTTL returns the lifetime of web sessions and their cookies
+func (h *RegionalGatewayHandler) WebSessionTTL() time.Duration {
+	return h.webSessionTTL
+}
+
+// SetCSRFSecret keys the CSRF tokens of viewer pages, so that they stay
+// valid across restarts when web sessions are persisted
+func (h *RegionalGatewayHandler) SetCSRFSecret(secret string) {
+	h.csrf = session.NewCSRFProtector(secret)
+}
+
+// CSRFToken returns the CSRF token that cookie-authenticated requests of a
+// web session must send in the X-CSRF-Token header
+func (h *RegionalGatewayHandler) CSRFToken(webSessionID string) string {
+	return h.csrf.Token(webSessionID)
+}
+
+// ErrInvalidCSRFToken is returned for cookie-authenticated requests without
+// the CSRF token of their web session
+var ErrInvalidCSRFToken = errors.New("invalid CSRF token")
+
+// SetWebSessionTheme records the web UI theme chosen in the pages of a web
+// session, so that its viewers render with it. csrfToken is the token
+// rendered into the pages.
+func (h *RegionalGatewayHandler) SetWebSessionTheme(webSessionID, csrfToken, theme string) error {
+	if !h.csrf.Valid(webSessionID, csrfToken) {
+		return ErrInvalidCSRFToken
+	}
+
+	webSession, err := h.webSessionStore.Get(webSessionID)
+	if err != nil {
+		return err
+	}
+
+	// Stores may hand out the session they hold, update a copy
+	updated := *webSession
+	updated.Theme = theme
+	return h.webSessionStore.Update(&updated)
+}
+
+// StartWebSessionSweeper periodically purges expired web sessions until ctx
+// is cancelled
+func (h *RegionalGatewayHandler) StartWebSessionSweeper(ctx context.Context, interval time.Duration) {
+	session.StartExpirySweeper(ctx, h.webSessionStore, interval)
+}
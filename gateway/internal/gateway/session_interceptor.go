@@ -127,10 +127,10 @@ func (i *SessionCookieInterceptor) handleSOLSessionResponse(
 	var cookie *http.Cookie
 	if ok {
 		// Use request-aware cookie creation for proper HTTP/HTTPS detection
-		cookie = session.CreateSessionCookieForRequest(webSession.ID, int(session.DefaultSessionDuration.Seconds()), httpReq)
+		cookie = session.CreateSessionCookieForRequest(webSession.ID, int(i.handler.WebSessionTTL().Seconds()), httpReq)
 	} else {
 		// Fallback to default (assumes HTTPS)
-		cookie = session.CreateSessionCookie(webSession.ID, int(session.DefaultSessionDuration.Seconds()))
+		cookie = session.CreateSessionCookie(webSession.ID, int(i.handler.WebSessionTTL().Seconds()))
 	}
 
 	http.SetCookie(w, cookie)
@@ -188,10 +188,10 @@ func (i *SessionCookieInterceptor) handleVNCSessionResponse(
 	var cookie *http.Cookie
 	if ok {
 		// Use request-aware cookie creation for proper HTTP/HTTPS detection
-		cookie = session.CreateSessionCookieForRequest(webSession.ID, int(session.DefaultSessionDuration.Seconds()), httpReq)
+		cookie = session.CreateSessionCookieForRequest(webSession.ID, int(i.handler.WebSessionTTL().Seconds()), httpReq)
 	} else {
 		// Fallback to default (assumes HTTPS)
-		cookie = session.CreateSessionCookie(webSession.ID, int(session.DefaultSessionDuration.Seconds()))
+		cookie = session.CreateSessionCookie(webSession.ID, int(i.handler.WebSessionTTL().Seconds()))
 	}
 
 	http.SetCookie(w, cookie)
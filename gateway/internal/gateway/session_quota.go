@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+
+	commonauth "core/auth"
+)
+
+// sessionConnectGrace is how long a new console session counts as in use
+// before a client attaches to it
+const sessionConnectGrace = time.Minute
+
+// sessionRateWindow is the window of the per-minute session quota
+const sessionRateWindow = time.Minute
+
+// storeConsoleSession stores a new console session, unless it exceeds the
+// session quota of the server token: the console sessions of its customer in
+// use on this gateway, and those created in the last minute. Sessions are in
+// use while clients are attached, or shortly after their creation until one
+// attaches.
+//
+// The quota is counted per gateway: each gateway only knows its own
+// sessions, so a customer whose servers are behind several gateways may
+// reach the limits on each of them.
+func (h *RegionalGatewayHandler) storeConsoleSession(consoleSession *ConsoleSession, quota *commonauth.SessionQuota) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := consoleSession.CreatedAt
+	h.pruneSessionCreations(now)
+
+	if quota == nil {
+		h.consoleSessions[consoleSession.SessionID] = consoleSession
+		return nil
+	}
+
+	if quota.MaxConcurrent > 0 {
+		inUse := 0
+		for _, session := range h.consoleSessions {
+			if session.QuotaCustomerID == quota.CustomerID && h.consoleSessionInUse(session, now) {
+				inUse++
+			}
+		}
+		if inUse >= quota.MaxConcurrent {
+			return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf(
+				"customer %s has %d console sessions in use on this gateway, the limit is %d: close a console before opening another",
+				quota.CustomerID, inUse, quota.MaxConcurrent))
+		}
+	}
+
+	if quota.PerMinute > 0 {
+		recent := h.sessionCreations[quota.CustomerID]
+		if len(recent) >= quota.PerMinute {
+			retryIn := recent[0].Add(sessionRateWindow).Sub(now).Round(time.Second)
+			return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf(
+				"customer %s created %d console sessions in the last minute, the limit is %d: retry in %s",
+				quota.CustomerID, len(recent), quota.PerMinute, retryIn))
+		}
+		h.sessionCreations[quota.CustomerID] = append(recent, now)
+	}
+
+	consoleSession.QuotaCustomerID = quota.CustomerID
+	h.consoleSessions[consoleSession.SessionID] = consoleSession
+	return nil
+}
+
+// consoleSessionInUse reports whether a console session counts against the
+// quota of its customer. The caller must hold h.mu.
+func (h *RegionalGatewayHandler) consoleSessionInUse(session *ConsoleSession, now time.Time) bool {
+	if !now.Before(session.ExpiresAt) {
+		return false
+	}
+	return len(h.consoleStreams[session.SessionID]) > 0 || now.Sub(session.CreatedAt) < sessionConnectGrace
+}
+
+// pruneSessionCreations forgets the session creations of all customers that
+// left the rate window, so that customers no longer creating sessions do not
+// accumulate. The caller must hold h.mu.
+func (h *RegionalGatewayHandler) pruneSessionCreations(now time.Time) {
+	for customerID, creations := range h.sessionCreations {
+		recent := slices.DeleteFunc(creations, func(createdAt time.Time) bool {
+			return now.Sub(createdAt) >= sessionRateWindow
+		})
+		if len(recent) == 0 {
+			delete(h.sessionCreations, customerID)
+		} else {
+			h.sessionCreations[customerID] = recent
+		}
+	}
+}
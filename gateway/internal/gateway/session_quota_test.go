@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	commonauth "core/auth"
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/internal/agent"
+)
+
+// newSessionQuotaGateway returns a gateway serving the console of server
+// 192.168.1.100:623
+func newSessionQuotaGateway() *RegionalGatewayHandler {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.agentRegistry.Register(&agent.Info{
+		ID:           "agent-1",
+		DatacenterID: "dc-1",
+		Endpoint:     "http://agent:8080",
+		LastSeen:     time.Now(),
+	})
+	handler.bmcEndpointMapping["192.168.1.100:623"] = &domain.AgentBMCMapping{
+		ServerID:     "192.168.1.100:623",
+		BMCEndpoint:  "192.168.1.100:623",
+		AgentID:      "agent-1",
+		DatacenterID: "dc-1",
+		BMCType:      types.BMCTypeIPMI,
+		Status:       "reachable",
+		LastSeen:     time.Now(),
+	}
+	return handler
+}
+
+// createQuotaContext creates a context with a server token limiting the
+// console sessions of a customer
+func createQuotaContext(t *testing.T, handler *RegionalGatewayHandler, quota *commonauth.SessionQuota) context.Context {
+	t.Helper()
+	server := testTokenServer("192.168.1.100:623", "owner-1")
+	customer := convertCustomerToManager(&domain.Customer{ID: quota.CustomerID, Email: quota.CustomerID + "@example.com"})
+	token, err := handler.jwtManager.GenerateServerTokenWithQuota(customer, server, []string{"console:write"}, quota)
+	require.NoError(t, err)
+	return context.WithValue(context.Background(), "token", token)
+}
+
+func TestConsoleSessions_ConcurrentQuota(t *testing.T) {
+	handler := newSessionQuotaGateway()
+	ctx := createQuotaContext(t, handler, &commonauth.SessionQuota{CustomerID: "customer-1", MaxConcurrent: 2})
+	vncReq := connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{ServerId: "192.168.1.100:623"})
+	solReq := connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: "192.168.1.100:623"})
+
+	first, err := handler.CreateVNCSession(ctx, vncReq)
+	require.NoError(t, err)
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.NoError(t, err)
+
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	require.Contains(t, err.Error(), "2 console sessions in use")
+
+	// Other customers have quotas of their own
+	otherCtx := createQuotaContext(t, handler, &commonauth.SessionQuota{CustomerID: "customer-2", MaxConcurrent: 2})
+	_, err = handler.CreateVNCSession(otherCtx, vncReq)
+	require.NoError(t, err)
+
+	// Sessions no client attached to are released after the connect grace,
+	// attached ones stay in use
+	handler.mu.Lock()
+	for _, session := range handler.consoleSessions {
+		session.CreatedAt = session.CreatedAt.Add(-sessionConnectGrace)
+	}
+	handler.consoleStreams[first.Msg.SessionId] = map[uint64]*AttachedStream{1: {}}
+	handler.mu.Unlock()
+
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.NoError(t, err)
+	_, err = handler.CreateSOLSession(ctx, solReq)
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestConsoleSessions_RateQuota(t *testing.T) {
+	handler := newSessionQuotaGateway()
+	ctx := createQuotaContext(t, handler, &commonauth.SessionQuota{CustomerID: "customer-1", PerMinute: 2})
+	req := connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: "192.168.1.100:623"})
+
+	for i := 0; i < 2; i++ {
+		_, err := handler.CreateSOLSession(ctx, req)
+		require.NoError(t, err)
+	}
+	_, err := handler.CreateSOLSession(ctx, req)
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	require.Contains(t, err.Error(), "retry in")
+
+	// Creations older than a minute no longer count
+	handler.mu.Lock()
+	creations := handler.sessionCreations["customer-1"]
+	for i := range creations {
+		creations[i] = creations[i].Add(-time.Minute)
+	}
+	handler.mu.Unlock()
+
+	_, err = handler.CreateSOLSession(ctx, req)
+	require.NoError(t, err)
+
+	// The expired creations were forgotten, those of customers no longer
+	// creating sessions too
+	handler.mu.Lock()
+	require.Len(t, handler.sessionCreations["customer-1"], 1)
+	handler.sessionCreations["customer-2"] = []time.Time{time.Now().Add(-2 * time.Minute)}
+	handler.mu.Unlock()
+
+	_, err = handler.CreateSOLSession(ctx, req)
+	require.NoError(t, err)
+	handler.mu.Lock()
+	require.NotContains(t, handler.sessionCreations, "customer-2")
+	handler.mu.Unlock()
+
+	// Tokens without a quota are not limited
+	unlimited := createAuthenticatedContextWithPermissions("192.168.1.100:623", "customer-1", []string{"console:write"})
+	for i := 0; i < 3; i++ {
+		_, err := handler.CreateSOLSession(unlimited, req)
+		require.NoError(t, err)
+	}
+}
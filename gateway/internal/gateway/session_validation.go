@@ -0,0 +1,154 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	managerv1 "manager/gen/manager/v1"
+)
+
+// ErrSessionRevoked is returned when the manager no longer authorizes a
+// console session, e.g. because the customer's access was revoked
+var ErrSessionRevoked = errors.New("console session no longer authorized")
+
+// ErrSessionValidationUnavailable is returned when the manager could not be
+// asked whether a console session is authorized, and validation fails closed
+var ErrSessionValidationUnavailable = errors.New("console session authorization unavailable")
+
+// sessionValidator asks the manager whether console sessions are still
+// authorized when clients attach to them, reusing each decision for cacheTTL
+// so that reconnecting viewers don't call the manager every time
+type sessionValidator struct {
+	validate func(ctx context.Context, req *managerv1.ValidateSessionRequest) (*managerv1.ValidateSessionResponse, error)
+	timeout  time.Duration
+	cacheTTL time.Duration
+	failOpen bool
+	now      func() time.Time
+
+	mu        sync.Mutex
+	decisions map[string]sessionDecision // By session ID
+}
+
+// sessionDecision is a cached decision of the manager
+type sessionDecision struct {
+	reason    string // Why the session is no longer authorized, empty when it is
+	expiresAt time.Time
+}
+
+// check returns nil when the session of req is authorized, ErrSessionRevoked
+// with the manager's reason when it no longer is
+func (v *sessionValidator) check(ctx context.Context, req *managerv1.ValidateSessionRequest) error {
+	now := v.now()
+
+	v.mu.Lock()
+	decision, cached := v.decisions[req.SessionId]
+	v.mu.Unlock()
+
+	if !cached || !now.Before(decision.expiresAt) {
+		validateCtx, cancel := context.WithTimeout(ctx, v.timeout)
+		resp, err := v.validate(validateCtx, req)
+		cancel()
+		if err != nil {
+			if v.failOpen {
+				log.Warn().Err(err).
+					Str("session_id", req.SessionId).
+					Msg("Manager unreachable, accepting console session without validation")
+				return nil
+			}
+			return fmt.Errorf("%w: %v", ErrSessionValidationUnavailable, err)
+		}
+
+		decision = sessionDecision{expiresAt: now.Add(v.cacheTTL)}
+		if !resp.Valid {
+			decision.reason = resp.Reason
+			if decision.reason == "" {
+				decision.reason = "rejected by the manager"
+			}
+		}
+		v.store(req.SessionId, decision, now)
+	}
+
+	if decision.reason != "" {
+		return fmt.Errorf("%w: %s", ErrSessionRevoked, decision.reason)
+	}
+	return nil
+}
+
+// store caches a decision, dropping the expired ones
+func (v *sessionValidator) store(sessionID string, decision sessionDecision, now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for id, cached := range v.decisions {
+		if !now.Before(cached.expiresAt) {
+			delete(v.decisions, id)
+		}
+	}
+	if v.cacheTTL > 0 {
+		v.decisions[sessionID] = decision
+	}
+}
+
+// EnableSessionValidation makes clients attaching to console sessions be
+// authorized by the manager, see AuthorizeConsoleSession. With failOpen,
+// attaches are accepted when the manager cannot be reached.
+func (h *RegionalGatewayHandler) EnableSessionValidation(timeout, cacheTTL time.Duration, failOpen bool) {
+	h.sessionValidation = &sessionValidator{
+		validate:  h.validateSessionWithManager,
+		timeout:   timeout,
+		cacheTTL:  cacheTTL,
+		failOpen:  failOpen,
+		now:       time.Now,
+		decisions: make(map[string]sessionDecision),
+	}
+}
+
+// AuthorizeConsoleSession checks with the manager that a client may still
+// attach to a console session, which the gateway holds in memory until it
+// expires even when the customer's access is revoked meanwhile. Returns
+// ErrSessionRevoked when it no longer may, nil when validation is disabled.
+func (h *RegionalGatewayHandler) AuthorizeConsoleSession(ctx context.Context, consoleSession *ConsoleSession) error {
+	if h.sessionValidation == nil {
+		return nil
+	}
+
+	err := h.sessionValidation.check(ctx, &managerv1.ValidateSessionRequest{
+		GatewayId:   h.gatewayID,
+		SessionId:   consoleSession.SessionID,
+		ServerId:    consoleSession.ServerID,
+		CustomerId:  consoleSession.CustomerID,
+		SessionType: consoleSession.Type,
+	})
+	if err != nil {
+		log.Warn().Err(err).
+			Str("session_id", consoleSession.SessionID).
+			Str("server_id", consoleSession.ServerID).
+			Str("customer_id", consoleSession.CustomerID).
+			Msg("Rejected attach to console session")
+	}
+	return err
+}
+
+// validateSessionWithManager asks the manager whether a console session is
+// still authorized
+func (h *RegionalGatewayHandler) validateSessionWithManager(ctx context.Context, validateReq *managerv1.ValidateSessionRequest) (*managerv1.ValidateSessionResponse, error) {
+	token, err := h.authenticateWithManager(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with manager: %w", err)
+	}
+
+	req := connect.NewRequest(validateReq)
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := h.managerClient.ValidateSession(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate session with manager: %w", err)
+	}
+	return resp.Msg, nil
+}
@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	managerv1 "manager/gen/manager/v1"
+)
+
+// stubManager answers ValidateSession calls, counting them
+type stubManager struct {
+	calls int
+	resp  *managerv1.ValidateSessionResponse
+	err   error
+}
+
+func (m *stubManager) validate(_ context.Context, _ *managerv1.ValidateSessionRequest) (*managerv1.ValidateSessionResponse, error) {
+	m.calls++
+	return m.resp, m.err
+}
+
+func newTestSessionValidator(manager *stubManager, failOpen bool, now *time.Time) *sessionValidator {
+	return &sessionValidator{
+		validate:  manager.validate,
+		timeout:   time.Second,
+		cacheTTL:  30 * time.Second,
+		failOpen:  failOpen,
+		now:       func() time.Time { return *now },
+		decisions: make(map[string]sessionDecision),
+	}
+}
+
+func TestSessionValidator_CachesDecisions(t *testing.T) {
+	now := time.Now()
+	manager := &stubManager{resp: &managerv1.ValidateSessionResponse{Valid: true}}
+	validator := newTestSessionValidator(manager, false, &now)
+	req := &managerv1.ValidateSessionRequest{SessionId: "sol-1", ServerId: "server-1"}
+
+	require.NoError(t, validator.check(context.Background(), req))
+	require.NoError(t, validator.check(context.Background(), req))
+	require.Equal(t, 1, manager.calls, "decision should be reused within the cache TTL")
+
+	// The customer is revoked meanwhile: seen once the decision expires
+	manager.resp = &managerv1.ValidateSessionResponse{Valid: false, Reason: "customer access revoked"}
+	now = now.Add(31 * time.Second)
+	err := validator.check(context.Background(), req)
+	require.ErrorIs(t, err, ErrSessionRevoked)
+	require.Contains(t, err.Error(), "customer access revoked")
+	require.Equal(t, 2, manager.calls)
+
+	// Rejections are cached too
+	require.ErrorIs(t, validator.check(context.Background(), req), ErrSessionRevoked)
+	require.Equal(t, 2, manager.calls)
+}
+
+func TestSessionValidator_ManagerUnreachable(t *testing.T) {
+	now := time.Now()
+	req := &managerv1.ValidateSessionRequest{SessionId: "vnc-1", ServerId: "server-1"}
+
+	manager := &stubManager{err: errors.New("connection refused")}
+	require.NoError(t, newTestSessionValidator(manager, true, &now).check(context.Background(), req))
+
+	validator := newTestSessionValidator(manager, false, &now)
+	require.ErrorIs(t, validator.check(context.Background(), req), ErrSessionValidationUnavailable)
+
+	// Failures are not cached
+	manager.err, manager.resp = nil, &managerv1.ValidateSessionResponse{Valid: true}
+	require.NoError(t, validator.check(context.Background(), req))
+}
+
+func TestAuthorizeConsoleSession_Disabled(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+
+	consoleSession := &ConsoleSession{SessionID: "sol-1", Type: "sol", ServerID: "server-1", CustomerID: "customer-1"}
+	require.NoError(t, handler.AuthorizeConsoleSession(context.Background(), consoleSession))
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// GracefulShutdown proxies a graceful shutdown of a server to its agent. The
+// agent forces the server off after the request's timeout, so the call
+// returns as soon as the shutdown is requested.
+func (h *RegionalGatewayHandler) GracefulShutdown(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GracefulShutdownRequest],
+) (*connect.Response[gatewayv1.GracefulShutdownResponse], error) {
+	serverContext, err := h.extractServerContextFromJWT(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid server context: %w", err))
+	}
+
+	if serverContext.ServerID != req.Msg.ServerId {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server ID mismatch"))
+	}
+
+	if !serverContext.HasPermission("power:write") {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("insufficient permissions for power operations"))
+	}
+
+	if err := h.authorizeMaintenance(serverContext, PowerOpGracefulShutdown, req.Msg.MaintenanceOverride); err != nil {
+		return nil, err
+	}
+
+	h.mu.RLock()
+	mapping, exists := h.bmcEndpointMapping[serverContext.BMCEndpoint]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("BMC endpoint not found: %s", serverContext.BMCEndpoint))
+	}
+
+	agentInfo := h.agentRegistry.Get(mapping.AgentID)
+	if agentInfo == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", mapping.AgentID))
+	}
+
+	log.Info().
+		Str("bmc_endpoint", serverContext.BMCEndpoint).
+		Str("agent_id", mapping.AgentID).
+		Int32("force_after_seconds", req.Msg.ForceAfterSeconds).
+		Msg("Proxying graceful shutdown to agent")
+
+	agentClient := h.agentClients.Client(agentInfo.Endpoint)
+
+	resp, err := agentClient.GracefulShutdown(ctx, connect.NewRequest(&gatewayv1.GracefulShutdownRequest{
+		ServerId:          mapping.ServerID,
+		ForceAfterSeconds: req.Msg.ForceAfterSeconds,
+	}))
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("bmc_endpoint", serverContext.BMCEndpoint).
+			Str("agent_id", mapping.AgentID).
+			Msg("Graceful shutdown failed")
+		h.publishPowerOperation(mapping, PowerOpGracefulShutdown, false, err.Error())
+		return nil, err
+	}
+
+	h.publishPowerOperation(mapping, PowerOpGracefulShutdown, resp.Msg.Success, resp.Msg.Message)
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	commonauth "core/auth"
+)
+
+// checkSourceAllowed enforces the source IP allowlist of a customer, from
+// its server tokens, against the address of a client
+func checkSourceAllowed(customerID string, ranges []string, clientAddress string) error {
+	if commonauth.SourceAllowed(ranges, clientAddress) {
+		return nil
+	}
+
+	log.Warn().
+		Str("customer_id", customerID).
+		Str("remote_addr", clientAddress).
+		Strs("allowed_source_ranges", ranges).
+		Msg("Rejected BMC access from a source address outside the customer's allowlist")
+	return connect.NewError(connect.CodePermissionDenied,
+		fmt.Errorf("source address %s is not in the IP allowlist of customer %s", clientAddress, customerID))
+}
+
+// CheckSourceAllowed enforces the source IP allowlist of the customer of a
+// console session against the address of a client attaching to it, e.g. the
+// remote address of a WebSocket upgrade
+func (h *RegionalGatewayHandler) CheckSourceAllowed(consoleSession *ConsoleSession, clientAddress string) error {
+	return checkSourceAllowed(consoleSession.CustomerID, consoleSession.AllowedSourceRanges, clientAddress)
+}
+
+// sourceAllowlistInterceptor enforces the source IP allowlist of the server
+// tokens of streaming RPCs, see SourceAllowlistInterceptor
+type sourceAllowlistInterceptor struct {
+	handler *RegionalGatewayHandler
+}
+
+// SourceAllowlistInterceptor enforces the source IP allowlist of the server
+// tokens of streaming RPCs, such as RunPowerOperation and WatchOperation,
+// which TokenValidationInterceptor only enforces for unary RPCs. It expects
+// the AuthInterceptor to have added the token to the context. Streams
+// without a valid server token are left to their handlers: console streams
+// authenticate with their session, whose allowlist streamConsole enforces.
+func (h *RegionalGatewayHandler) SourceAllowlistInterceptor() connect.Interceptor {
+	return &sourceAllowlistInterceptor{handler: h}
+}
+
+// WrapUnary implements connect.Interceptor, unary RPCs are checked by
+// TokenValidationInterceptor
+func (i *sourceAllowlistInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+// WrapStreamingClient implements connect.Interceptor
+func (i *sourceAllowlistInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor
+func (i *sourceAllowlistInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if token, ok := ctx.Value("token").(string); ok && token != "" {
+			claims, serverContext, err := i.handler.validateToken(token)
+			if err == nil && serverContext != nil {
+				if err := checkSourceAllowed(claims.CustomerID, serverContext.AllowedSourceRanges, conn.Peer().Addr); err != nil {
+					return err
+				}
+			}
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// Reasons of StreamSaturatedError, also the label of the rejection metric
+const (
+	SaturatedSessions         = "sessions"
+	SaturatedCustomerSessions = "customer_sessions"
+	SaturatedBufferedBytes    = "buffered_bytes"
+)
+
+// StreamLimits caps the console streams served by the gateway at once, its
+// WebSockets and Connect streams, so that a burst of viewers is turned away
+// rather than exhausting its memory. A zero cap is disabled.
+type StreamLimits struct {
+	// Streams open at once, across customers
+	MaxSessions int
+	// Streams of a customer open at once
+	MaxCustomerSessions int
+	// Bytes reserved by the open streams for their buffers: each reserves
+	// MaxMessageSize for a browser message and one agent chunk
+	MaxBufferedBytes int64
+	// Largest browser message, larger ones close the WebSocket with a
+	// message_too_large stream error
+	MaxMessageSize int64
+	// Told to clients turned away, in the Retry-After header
+	RetryAfter time.Duration
+}
+
+// DefaultStreamLimits returns the limits of gateways not configuring them
+func DefaultStreamLimits() StreamLimits {
+	return StreamLimits{
+		MaxSessions:         1000,
+		MaxCustomerSessions: 20,
+		MaxBufferedBytes:    512 << 20,
+		MaxMessageSize:      256 << 10,
+		RetryAfter:          30 * time.Second,
+	}
+}
+
+// StreamSaturatedError is returned when opening a console stream would
+// exceed one of the stream limits
+type StreamSaturatedError struct {
+	// One of the Saturated reasons
+	Reason string
+	Limit  int64
+	// How long clients should wait before retrying
+	RetryAfter time.Duration
+}
+
+func (e *StreamSaturatedError) Error() string {
+	switch e.Reason {
+	case SaturatedCustomerSessions:
+		return fmt.Sprintf("the customer has its limit of %d console connections open on this gateway", e.Limit)
+	case SaturatedBufferedBytes:
+		return fmt.Sprintf("the console connections of this gateway reserve its limit of %d buffered bytes", e.Limit)
+	default:
+		return fmt.Sprintf("this gateway serves its limit of %d console connections", e.Limit)
+	}
+}
+
+// streamAdmission counts the open console streams against the stream limits
+type streamAdmission struct {
+	mu        sync.Mutex
+	limits    StreamLimits
+	sessions  int
+	customers map[string]int
+	buffered  int64
+	// Told of the streams turned away, e.g. for metrics
+	observer func(reason string)
+}
+
+// SetStreamLimits sets the limits of the console streams opened from now on
+func (h *RegionalGatewayHandler) SetStreamLimits(limits StreamLimits) {
+	h.streamAdmission.mu.Lock()
+	defer h.streamAdmission.mu.Unlock()
+	h.streamAdmission.limits = limits
+}
+
+// StreamLimits returns the limits of the console streams
+func (h *RegionalGatewayHandler) StreamLimits() StreamLimits {
+	h.streamAdmission.mu.Lock()
+	defer h.streamAdmission.mu.Unlock()
+	return h.streamAdmission.limits
+}
+
+// SetStreamRejectionObserver sets the function told of the reason of every
+// console stream turned away
+func (h *RegionalGatewayHandler) SetStreamRejectionObserver(observer func(reason string)) {
+	h.streamAdmission.mu.Lock()
+	defer h.streamAdmission.mu.Unlock()
+	h.streamAdmission.observer = observer
+}
+
+// StreamUsage returns the console streams open and the bytes they reserve
+func (h *RegionalGatewayHandler) StreamUsage() (sessions int, bufferedBytes int64) {
+	h.streamAdmission.mu.Lock()
+	defer h.streamAdmission.mu.Unlock()
+	return h.streamAdmission.sessions, h.streamAdmission.buffered
+}
+
+// AdmitConsoleStream counts a console stream of a customer about to be
+// opened, a WebSocket or a Connect stream, against the stream limits. It
+// returns a *StreamSaturatedError when a limit is reached, or the function to
+// call once the stream is closed.
+func (h *RegionalGatewayHandler) AdmitConsoleStream(customerID string) (func(), error) {
+	chunkSize := int64(h.StreamMaxChunkSize())
+
+	a := &h.streamAdmission
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	reserved := a.limits.MaxMessageSize + chunkSize
+	var saturated *StreamSaturatedError
+	switch {
+	case a.limits.MaxSessions > 0 && a.sessions >= a.limits.MaxSessions:
+		saturated = &StreamSaturatedError{Reason: SaturatedSessions, Limit: int64(a.limits.MaxSessions)}
+	case a.limits.MaxCustomerSessions > 0 && a.customers[customerID] >= a.limits.MaxCustomerSessions:
+		saturated = &StreamSaturatedError{Reason: SaturatedCustomerSessions, Limit: int64(a.limits.MaxCustomerSessions)}
+	case a.limits.MaxBufferedBytes > 0 && a.buffered+reserved > a.limits.MaxBufferedBytes:
+		saturated = &StreamSaturatedError{Reason: SaturatedBufferedBytes, Limit: a.limits.MaxBufferedBytes}
+	}
+	if saturated != nil {
+		saturated.RetryAfter = a.limits.RetryAfter
+		if a.observer != nil {
+			a.observer(saturated.Reason)
+		}
+		return nil, saturated
+	}
+
+	if a.customers == nil {
+		a.customers = make(map[string]int)
+	}
+	a.sessions++
+	a.customers[customerID]++
+	a.buffered += reserved
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			a.sessions--
+			a.buffered -= reserved
+			if a.customers[customerID]--; a.customers[customerID] <= 0 {
+				delete(a.customers, customerID)
+			}
+		})
+	}, nil
+}
+
+// saturatedStreamError turns a Connect console stream away with
+// ResourceExhausted, telling the client when to retry in Retry-After
+func saturatedStreamError(err error) error {
+	connectErr := connect.NewError(connect.CodeResourceExhausted, err)
+	var saturated *StreamSaturatedError
+	if errors.As(err, &saturated) && saturated.RetryAfter > 0 {
+		connectErr.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(saturated.RetryAfter.Seconds()))))
+	}
+	return connectErr
+}
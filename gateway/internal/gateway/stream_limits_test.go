@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// requireSaturated asserts that err is a StreamSaturatedError of reason
+func requireSaturated(t *testing.T, err error, reason string) {
+	t.Helper()
+	var saturated *StreamSaturatedError
+	require.True(t, errors.As(err, &saturated), "expected a saturation error, got %v", err)
+	assert.Equal(t, reason, saturated.Reason)
+	assert.Equal(t, 10*time.Second, saturated.RetryAfter)
+}
+
+func TestAdmitConsoleStream_SessionLimits(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.SetStreamLimits(StreamLimits{MaxSessions: 3, MaxCustomerSessions: 2, RetryAfter: 10 * time.Second})
+	var rejected []string
+	handler.SetStreamRejectionObserver(func(reason string) { rejected = append(rejected, reason) })
+
+	releaseFirst, err := handler.AdmitConsoleStream("customer-1")
+	require.NoError(t, err)
+	_, err = handler.AdmitConsoleStream("customer-1")
+	require.NoError(t, err)
+
+	_, err = handler.AdmitConsoleStream("customer-1")
+	requireSaturated(t, err, SaturatedCustomerSessions)
+
+	_, err = handler.AdmitConsoleStream("customer-2")
+	require.NoError(t, err)
+	_, err = handler.AdmitConsoleStream("customer-3")
+	requireSaturated(t, err, SaturatedSessions)
+
+	// Closing a WebSocket frees its place, once
+	releaseFirst()
+	releaseFirst()
+	sessions, _ := handler.StreamUsage()
+	assert.Equal(t, 2, sessions)
+	_, err = handler.AdmitConsoleStream("customer-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{SaturatedCustomerSessions, SaturatedSessions}, rejected)
+}
+
+func TestAdmitConsoleStream_BufferedBytes(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.SetStreamMaxChunkSize(32 << 10)
+	handler.SetStreamLimits(StreamLimits{MaxBufferedBytes: 200 << 10, MaxMessageSize: 64 << 10, RetryAfter: 10 * time.Second})
+
+	// Each WebSocket reserves its largest message and an agent chunk
+	releases := make([]func(), 0, 2)
+	for range 2 {
+		release, err := handler.AdmitConsoleStream("customer-1")
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	_, buffered := handler.StreamUsage()
+	assert.Equal(t, int64(192<<10), buffered)
+
+	_, err := handler.AdmitConsoleStream("customer-2")
+	requireSaturated(t, err, SaturatedBufferedBytes)
+
+	for _, release := range releases {
+		release()
+	}
+	sessions, buffered := handler.StreamUsage()
+	assert.Zero(t, sessions)
+	assert.Zero(t, buffered)
+}
+
+func TestAdmitConsoleStream_Disabled(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	handler.SetStreamLimits(StreamLimits{})
+
+	for range 100 {
+		_, err := handler.AdmitConsoleStream("customer-1")
+		require.NoError(t, err)
+	}
+}
+
+func TestAdmitConsoleStream_ConnectStreams(t *testing.T) {
+	agentService := &echoConsoleAgent{resizes: make(chan *gatewayv1.TerminalSize, 1)}
+	handler, client, sessionID := newBrowserConsoleGateway(t, agentService)
+	handler.SetStreamLimits(StreamLimits{MaxCustomerSessions: 1, RetryAfter: 10 * time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	watch := func() *connect.ServerStreamForClient[gatewayv1.ConsoleDataChunk] {
+		stream, err := client.WatchConsoleData(ctx, newWatchConsoleDataRequest(&gatewayv1.ConsoleDataChunk{
+			SessionId:   sessionID,
+			ServerId:    "192.168.1.100:623",
+			IsHandshake: true,
+		}))
+		require.NoError(t, err)
+		return stream
+	}
+
+	first := watch()
+	require.True(t, first.Receive(), "expected a handshake ack: %v", first.Err())
+	sessions, _ := handler.StreamUsage()
+	assert.Equal(t, 1, sessions)
+
+	// Streams beyond the customer's cap are turned away
+	second := watch()
+	defer second.Close()
+	require.False(t, second.Receive())
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(second.Err()))
+	var connectErr *connect.Error
+	require.True(t, errors.As(second.Err(), &connectErr))
+	assert.Equal(t, "10", connectErr.Meta().Get("Retry-After"))
+
+	// Closing the stream frees its place
+	_, err := client.SendConsoleData(ctx, newSendConsoleDataRequest(first.Msg().StreamId, &gatewayv1.ConsoleDataChunk{CloseStream: true}))
+	require.NoError(t, err)
+	for first.Receive() {
+	}
+	require.NoError(t, first.Close())
+	require.Eventually(t, func() bool {
+		sessions, _ := handler.StreamUsage()
+		return sessions == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -2,18 +2,20 @@ package gateway
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
-	"net"
-	"net/http"
+	"io"
+	"sync"
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog/log"
-	"golang.org/x/net/http2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"core/streaming"
+	"core/tracing"
 	gatewayv1 "gateway/gen/gateway/v1"
-	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/sli"
 	gatewaystreaming "gateway/internal/streaming"
 )
 
@@ -27,6 +29,14 @@ func (h *RegionalGatewayHandler) StreamVNCData(
 		fmt.Errorf("gateway does not accept incoming VNC streams - gateway initiates streams to agents"))
 }
 
+// consoleClientStream is the client end of a console stream, a CLI's
+// bidirectional stream or a browser's pair of WatchConsoleData and
+// SendConsoleData calls
+type consoleClientStream interface {
+	Receive() (*gatewayv1.ConsoleDataChunk, error)
+	Send(*gatewayv1.ConsoleDataChunk) error
+}
+
 // StreamConsoleData handles console data streaming from CLI clients
 // This proxies the stream between CLI and the appropriate agent
 func (h *RegionalGatewayHandler) StreamConsoleData(
@@ -37,92 +47,299 @@ func (h *RegionalGatewayHandler) StreamConsoleData(
 
 	// Receive handshake from CLI to get session and server info
 	helper := streaming.NewHandshakeHelper(&gatewaystreaming.ConsoleChunkFactory{})
-	sessionID, serverID, err := helper.ReceiveHandshake(clientStream)
+	handshake, err := helper.ReceiveHandshakeChunk(clientStream)
 	if err != nil {
 		return fmt.Errorf("failed to receive handshake from CLI: %w", err)
 	}
 
+	sendAck := func(metadata map[string]string) error {
+		if metadata == nil {
+			return helper.SendHandshakeAck(clientStream, handshake.SessionId, handshake.ServerId)
+		}
+		return helper.SendHandshakeAckWithMetadata(clientStream, handshake.SessionId, handshake.ServerId, metadata)
+	}
+	return h.streamConsole(ctx, clientStream, handshake, StreamTransportConnect, clientStream.Peer().Addr, sendAck)
+}
+
+// streamConsole connects a client stream whose handshake was received to the
+// agent of its session, and proxies it. sendAck acknowledges the handshake
+// once the agent connected to the BMC, with the terminal hints of the agent
+// when the client gave its own.
+func (h *RegionalGatewayHandler) streamConsole(
+	ctx context.Context,
+	clientStream consoleClientStream,
+	handshake *gatewayv1.ConsoleDataChunk,
+	transport, clientAddress string,
+	sendAck func(metadata map[string]string) error,
+) error {
+	sessionID, serverID := handshake.SessionId, handshake.ServerId
+
+	// Clients negotiating their terminal describe it in the handshake, like
+	// web consoles do in their WebSocket URL
+	terminal, err := streaming.TerminalHintsOf(handshake.Metadata)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid terminal hints: %w", err))
+	}
+
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", serverID).
-		Msg("Console handshake received from CLI")
+		Bool("force_takeover", handshake.ForceTakeover).
+		Msg("Console handshake received from client")
 
 	// Get the SOL session to find which agent to connect to
 	solSession, exists := h.GetSOLSessionByID(sessionID)
 	if !exists {
 		return connect.NewError(connect.CodeNotFound, fmt.Errorf("SOL session not found: %s", sessionID))
 	}
+	if err := h.CheckSourceAllowed(solSession, clientAddress); err != nil {
+		return err
+	}
+
+	// The manager may have revoked the session since it was created
+	if err := h.AuthorizeConsoleSession(ctx, solSession); err != nil {
+		if errors.Is(err, ErrSessionRevoked) {
+			return connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	// Observers must not disconnect the session holding the console
+	if solSession.ReadOnly && handshake.ForceTakeover {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("read-only sessions cannot take over the console"))
+	}
+
+	// Connect streams count against the stream limits like WebSockets
+	release, err := h.AdmitConsoleStream(solSession.CustomerID)
+	if err != nil {
+		return saturatedStreamError(err)
+	}
+	defer release()
+
+	ctx, attached := h.TrackConsoleStream(ctx, sessionID, transport, clientAddress)
+	defer attached.Detach()
+
+	// Measure stream establishment and time to first byte for the console SLO
+	attempt := h.StartConsoleSLI(solSession, "sol")
+	defer attempt.Finish()
+
+	// Trace the stream setup until the agent acknowledges it
+	connectCtx, connectSpan := StartConsoleConnectSpan(ctx, solSession)
+	defer connectSpan.End()
 
 	// Get agent information
 	agentInfo := h.agentRegistry.Get(solSession.AgentID)
 	if agentInfo == nil {
-		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available: %s", solSession.AgentID))
+		err := streaming.NewStreamError(streaming.ErrorCodeAgentUnavailable, "agent not available: %s", solSession.AgentID)
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		return connect.NewError(connect.CodeUnavailable, err)
 	}
 
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", serverID).
 		Str("agent_id", solSession.AgentID).
-		Msg("Proxying CLI console stream to agent")
-
-	// Create HTTP client with HTTP/2 support
-	httpClient := &http2.Transport{
-		AllowHTTP: true,
-		DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
-			return net.Dial(network, addr)
-		},
-	}
+		Msg("Proxying client console stream to agent")
 
-	// Create agent client
-	agentClient := gatewayv1connect.NewGatewayServiceClient(&http.Client{Transport: httpClient}, agentInfo.Endpoint)
+	// Pooled agent client, multiplexing streams over HTTP/2
+	agentClient := h.agentClients.StreamClient(agentInfo.Endpoint)
 
 	// Create stream to agent
 	agentStream := agentClient.StreamConsoleData(ctx)
 
-	// Send handshake to agent
-	if err := helper.SendHandshake(agentStream, sessionID, serverID); err != nil {
+	// Send handshake to agent, passing on the CLI's takeover request and the
+	// session's serial settings, with the token the agent authenticates the
+	// stream with
+	authToken, err := h.AgentStreamToken(solSession)
+	if err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to sign stream token: %w", err))
+	}
+	capabilities := streaming.Capabilities{
+		Version:   streaming.HandshakeVersion,
+		Features:  h.StreamFeatures(),
+		Variant:   streaming.VariantSOL,
+		AuthToken: authToken,
+	}
+	if err := agentStream.Send(&gatewayv1.ConsoleDataChunk{
+		SessionId:     sessionID,
+		ServerId:      serverID,
+		IsHandshake:   true,
+		ForceTakeover: handshake.ForceTakeover,
+		Metadata:      capabilities.AddTo(terminal.AddTo(solSession.SOLSettings.AddTo(tracing.Inject(connectCtx)))),
+	}); err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
 		return fmt.Errorf("failed to send handshake to agent: %w", err)
 	}
 
 	log.Debug().Str("server_id", serverID).Msg("Sent console handshake to agent")
 
-	// Send handshake ack back to CLI
-	if err := helper.SendHandshakeAck(clientStream, sessionID, serverID); err != nil {
+	// Wait for the agent to connect to the BMC, so that rejections such as a
+	// busy SOL console reach the CLI with their code
+	ack, err := agentStream.Receive()
+	if err != nil {
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		log.Warn().Err(err).
+			Str("session_id", sessionID).
+			Str("server_id", serverID).
+			Msg("Agent rejected console stream")
+		return err
+	}
+	if streamErr := streaming.StreamErrorOf(ack); streamErr != nil {
+		attempt.Fail(streamErr)
+		tracing.RecordError(connectSpan, streamErr)
+		log.Warn().
+			Str("session_id", sessionID).
+			Str("server_id", serverID).
+			Str("error_code", string(streamErr.Code)).
+			Str("error", streamErr.Message).
+			Msg("Agent rejected console stream")
+		return connect.NewError(streamErrorCode(streamErr.Code), streamErr)
+	}
+	if !ack.IsHandshake {
+		err := fmt.Errorf("expected handshake ack from agent, got data chunk")
+		attempt.Fail(err)
+		tracing.RecordError(connectSpan, err)
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	attempt.Established()
+	connectSpan.End()
+	h.PublishConsoleSessionOpened(solSession, "sol")
+
+	// Agents predating capabilities acknowledge with version 1 handshakes
+	negotiated := streaming.NegotiateCapabilities(capabilities, streaming.CapabilitiesOf(ack.Metadata))
+	log.Debug().
+		Str("session_id", sessionID).
+		Int("protocol_version", negotiated.Version).
+		Msg("Console stream handshake negotiated with agent")
+
+	// Send handshake ack back to CLI, telling clients negotiating their
+	// terminal the one the agent renders the console for
+	var ackMetadata map[string]string
+	if !terminal.IsZero() {
+		agentTerminal, _ := streaming.TerminalHintsOf(ack.Metadata)
+		ackMetadata = agentTerminal.AddTo(map[string]string{})
+	}
+	if err := sendAck(ackMetadata); err != nil {
 		return fmt.Errorf("failed to send handshake ack to CLI: %w", err)
 	}
 
-	// Proxy bidirectionally between CLI and agent
-	return h.proxyConsoleStreams(ctx, clientStream, agentStream, sessionID, serverID)
+	// Proxy bidirectionally between CLI and agent, answering the agent's
+	// pings so that it keeps the BMC connection open
+	recorder := attached.NewRecorder(solSession, agentInfo.Endpoint)
+	keepalive := streaming.WithKeepalive(agentStream, &gatewaystreaming.ConsoleChunkFactory{}, sessionID, serverID)
+	faultyStream := streaming.InjectFaults(keepalive, h.streamFaults, &gatewaystreaming.ConsoleChunkFactory{})
+	checkedStream := streaming.VerifyChecksums(faultyStream, &gatewaystreaming.ConsoleChunkFactory{}, h.streamChecksums).
+		WithCorruptionObserver(h.StreamCorruptionObserver(solSession))
+	checkedStream.Negotiate(ack)
+	return h.proxyConsoleStreams(ctx, clientStream, sli.Observe(checkedStream, attempt), attempt, attached, recorder, solSession.ReadOnly, sessionID, serverID)
+}
+
+// StartConsoleConnectSpan starts the span of a console stream's setup. Its
+// context travels to the agent in the handshake metadata.
+func StartConsoleConnectSpan(ctx context.Context, consoleSession *ConsoleSession) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "gateway.ConnectConsole",
+		attribute.String("console.protocol", consoleSession.Type),
+		attribute.String("console.session_id", consoleSession.SessionID),
+		attribute.String("server.id", consoleSession.ServerID),
+		attribute.String("agent.id", consoleSession.AgentID),
+	)
+}
+
+// streamErrorCode maps the code of a stream error reported by an agent to a
+// Connect code, so that the CLI can react to it like to the agent's own error
+func streamErrorCode(code streaming.ErrorCode) connect.Code {
+	switch code {
+	case streaming.ErrorCodeAgentUnavailable, streaming.ErrorCodeBMCUnreachable:
+		return connect.CodeUnavailable
+	case streaming.ErrorCodeServerNotFound:
+		return connect.CodeNotFound
+	case streaming.ErrorCodeFeatureUnsupported:
+		return connect.CodeUnimplemented
+	case streaming.ErrorCodeBMCAuthFailed:
+		return connect.CodePermissionDenied
+	case streaming.ErrorCodeSessionBusy:
+		return connect.CodeFailedPrecondition
+	case streaming.ErrorCodeSessionLimit, streaming.ErrorCodeMessageTooLarge:
+		return connect.CodeResourceExhausted
+	case streaming.ErrorCodeInvalidSettings:
+		return connect.CodeInvalidArgument
+	case streaming.ErrorCodeUnauthenticated:
+		return connect.CodeUnauthenticated
+	case streaming.ErrorCodeDataCorrupted:
+		return connect.CodeDataLoss
+	default:
+		return connect.CodeInternal
+	}
 }
 
 // proxyConsoleStreams proxies console data bidirectionally between CLI and agent
 func (h *RegionalGatewayHandler) proxyConsoleStreams(
 	ctx context.Context,
-	clientStream *connect.BidiStream[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk],
-	agentStream *connect.BidiStreamForClient[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk],
+	clientStream consoleClientStream,
+	agentStream sli.AgentStream[*gatewayv1.ConsoleDataChunk],
+	attempt *sli.Attempt,
+	attached *AttachedStream,
+	recorder *streaming.SessionRecorder,
+	readOnly bool,
 	sessionID, serverID string,
 ) error {
-	errChan := make(chan error, 2)
+	type proxyEnd struct {
+		reason streaming.DisconnectReason
+		err    error
+	}
+	errChan := make(chan proxyEnd, 2)
+
+	// Console data and expiry warnings are both sent to the CLI
+	var sendMu sync.Mutex
+	sendToClient := func(chunk *gatewayv1.ConsoleDataChunk) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return clientStream.Send(chunk)
+	}
 
 	// Goroutine: Agent -> CLI
 	go func() {
 		defer log.Debug().Msg("Agent->CLI console proxy goroutine exiting")
 		for {
 			chunk, err := agentStream.Receive()
+			if streamErr := streaming.AsStreamError(err); streamErr != nil {
+				// Tell the CLI why the stream failed, e.g. with a corrupted chunk
+				sendToClient(&gatewayv1.ConsoleDataChunk{
+					SessionId:    sessionID,
+					ServerId:     serverID,
+					ErrorCode:    string(streamErr.Code),
+					ErrorMessage: streamErr.Message,
+				})
+				errChan <- proxyEnd{streaming.DisconnectStreamError, streamErr}
+				return
+			}
 			if err != nil {
-				errChan <- fmt.Errorf("agent stream receive error: %w", err)
+				errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("agent stream receive error: %w", err)}
 				return
 			}
 
 			// Forward to CLI
-			if err := clientStream.Send(chunk); err != nil {
-				errChan <- fmt.Errorf("client stream send error: %w", err)
+			if err := sendToClient(chunk); err != nil {
+				errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("client stream send error: %w", err)}
 				return
 			}
+			if len(chunk.Data) > 0 {
+				recorder.RecordOutput(len(chunk.Data))
+			}
 
 			// Check for close signal
+			if streamErr := streaming.StreamErrorOf(chunk); streamErr != nil {
+				errChan <- proxyEnd{streaming.DisconnectStreamError, streamErr}
+				return
+			}
 			if chunk.CloseStream {
 				log.Debug().Msg("Received close signal from agent")
+				errChan <- proxyEnd{streaming.DisconnectServerClosed, nil}
 				return
 			}
 		}
@@ -134,27 +351,73 @@ func (h *RegionalGatewayHandler) proxyConsoleStreams(
 		for {
 			chunk, err := clientStream.Receive()
 			if err != nil {
-				errChan <- fmt.Errorf("client stream receive error: %w", err)
+				if errors.Is(err, io.EOF) {
+					errChan <- proxyEnd{streaming.DisconnectClientClosed, nil}
+				} else {
+					errChan <- proxyEnd{streaming.DisconnectClientClosed, fmt.Errorf("client stream receive error: %w", err)}
+				}
 				return
 			}
 
+			// Read-only clients only close the stream
+			if readOnly && !chunk.CloseStream {
+				continue
+			}
+
 			// Forward to agent
 			if err := agentStream.Send(chunk); err != nil {
-				errChan <- fmt.Errorf("agent stream send error: %w", err)
+				errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("agent stream send error: %w", err)}
 				return
 			}
+			if len(chunk.Data) > 0 {
+				recorder.RecordInput(len(chunk.Data))
+			}
 
 			// Check for close signal
 			if chunk.CloseStream {
 				log.Debug().Msg("Received close signal from CLI")
+				errChan <- proxyEnd{streaming.DisconnectClientClosed, nil}
+				return
+			}
+		}
+	}()
+
+	// Goroutine: expiry warnings -> CLI, written to the terminal
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
 				return
+			case expiresAt := <-attached.ExpiryWarnings():
+				sendToClient(&gatewayv1.ConsoleDataChunk{
+					SessionId: sessionID,
+					ServerId:  serverID,
+					Data:      []byte("\r\n" + ExpiryNotice(expiresAt) + "\r\n"),
+				})
 			}
 		}
 	}()
 
 	// Wait for either direction to fail
-	err := <-errChan
-	log.Info().Err(err).Msg("Console proxy terminated")
+	end := <-errChan
+	if attached.TerminationNotice() != "" {
+		// The agent stream failed because the session expired or was terminated
+		end = proxyEnd{streaming.DisconnectSessionEnded, context.Cause(ctx)}
+	}
+	log.Info().Err(end.err).Str("reason", string(end.reason)).Msg("Console proxy terminated")
+	recorder.Close(end.reason, end.err)
+
+	// Stop measuring before tearing down the agent stream
+	attempt.Finish()
+
+	// Tell the CLI why the console went away when an admin terminated it
+	if notice := attached.TerminationNotice(); notice != "" {
+		sendToClient(&gatewayv1.ConsoleDataChunk{
+			SessionId: sessionID,
+			ServerId:  serverID,
+			Data:      []byte("\r\n" + notice + "\r\n"),
+		})
+	}
 
 	// Send close signals
 	closeChunk := &gatewayv1.ConsoleDataChunk{
@@ -162,7 +425,7 @@ func (h *RegionalGatewayHandler) proxyConsoleStreams(
 		ServerId:    serverID,
 		CloseStream: true,
 	}
-	clientStream.Send(closeChunk)
+	sendToClient(closeChunk)
 	agentStream.Send(closeChunk)
 
 	return nil
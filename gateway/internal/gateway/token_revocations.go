@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/auth"
+	managermodels "manager/pkg/models"
+)
+
+// errTokenRevoked rejects the tokens issued before their customer was
+// disabled or deleted
+var errTokenRevoked = errors.New("token revoked, authenticate again")
+
+// tokenRevocations holds the revocations of customer tokens, synced from the
+// manager each time the gateway registers. Tokens are validated locally, so
+// without them the tokens of disabled customers would keep working until
+// they expire.
+type tokenRevocations struct {
+	mu        sync.RWMutex
+	revokedAt map[string]time.Time // By customer ID
+}
+
+// replace replaces the revocations with those sent by the manager
+func (r *tokenRevocations) replace(revocations []*managerv1.TokenRevocation) {
+	revokedAt := make(map[string]time.Time, len(revocations))
+	for _, revocation := range revocations {
+		revokedAt[revocation.CustomerId] = revocation.RevokedAt.AsTime()
+	}
+
+	r.mu.Lock()
+	r.revokedAt = revokedAt
+	r.mu.Unlock()
+}
+
+// revoked reports whether a token of a customer issued at issuedAt is
+// revoked. Tokens without an issue time are revoked with all the others.
+func (r *tokenRevocations) revoked(customerID string, issuedAt time.Time) bool {
+	r.mu.RLock()
+	revokedAt, ok := r.revokedAt[customerID]
+	r.mu.RUnlock()
+	return ok && !issuedAt.After(revokedAt)
+}
+
+// validateToken validates a token issued by the manager, rejecting those
+// the manager revoked
+func (h *RegionalGatewayHandler) validateToken(token string) (*managermodels.AuthClaims, *auth.ServerContext, error) {
+	claims, serverContext, err := h.jwtManager.ValidateServerToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if h.tokenRevocations.revoked(claims.CustomerID, claims.IssuedAt) {
+		return nil, nil, errTokenRevoked
+	}
+	return claims, serverContext, nil
+}
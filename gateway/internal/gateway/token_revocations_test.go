@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	managerv1 "manager/gen/manager/v1"
+)
+
+func TestValidateToken_Revocations(t *testing.T) {
+	handler := newGatewayHandler("gateway-1", "us-west-1")
+	server := testTokenServer("192.168.1.100:623", "customer-1")
+	customer := convertCustomerToManager(&domain.Customer{ID: "customer-1", Email: "customer-1@example.com"})
+	token, err := handler.jwtManager.GenerateServerToken(customer, server, []string{"power:read"})
+	require.NoError(t, err)
+
+	_, _, err = handler.validateToken(token)
+	require.NoError(t, err, "tokens are valid until revoked")
+
+	// Revocations of other customers or older than the token leave it valid
+	handler.tokenRevocations.replace([]*managerv1.TokenRevocation{
+		{CustomerId: "customer-1", RevokedAt: timestamppb.New(time.Now().Add(-time.Hour))},
+		{CustomerId: "customer-2", RevokedAt: timestamppb.Now()},
+	})
+	_, _, err = handler.validateToken(token)
+	require.NoError(t, err)
+
+	handler.tokenRevocations.replace([]*managerv1.TokenRevocation{
+		{CustomerId: "customer-1", RevokedAt: timestamppb.Now()},
+	})
+	_, _, err = handler.validateToken(token)
+	assert.ErrorIs(t, err, errTokenRevoked)
+
+	// Each sync replaces the previous revocations
+	handler.tokenRevocations.replace(nil)
+	_, _, err = handler.validateToken(token)
+	assert.NoError(t, err)
+}
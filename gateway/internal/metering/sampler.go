@@ -0,0 +1,215 @@
+// Package metering samples the power consumption of the servers reachable
+// through the gateway's agents, and buffers the samples until they are
+// reported to the manager, which stores them for usage reports and
+// chargeback.
+package metering
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// DefaultMaxPending bounds the number of buffered samples when the manager is
+// unreachable. The oldest samples are dropped first.
+const DefaultMaxPending = 50000
+
+// sampleConcurrency bounds the servers sampled at the same time
+const sampleConcurrency = 16
+
+// Outcomes of a sample, for observers
+const (
+	StatusSuccess     = "success"
+	StatusUnsupported = "unsupported" // The BMC does not meter power
+	StatusError       = "error"
+)
+
+// Client is the part of the agent RPC client used to sample power
+type Client interface {
+	GetPowerReading(ctx context.Context, req *connect.Request[gatewayv1.GetPowerReadingRequest]) (*connect.Response[gatewayv1.GetPowerReadingResponse], error)
+	GetEnergyUsage(ctx context.Context, req *connect.Request[gatewayv1.GetEnergyUsageRequest]) (*connect.Response[gatewayv1.GetEnergyUsageResponse], error)
+}
+
+// Config configures a Sampler
+type Config struct {
+	Interval   time.Duration // Time between sampling rounds
+	Timeout    time.Duration // Timeout of the sample of one server
+	MaxPending int           // Samples buffered until reported, 0 for DefaultMaxPending
+}
+
+// Target is a server to sample
+type Target struct {
+	ServerID      string // ID of the server on its agent
+	BMCEndpoint   string
+	DatacenterID  string
+	AgentEndpoint string
+}
+
+// Sample is the power consumption of a server at a point in time
+type Sample struct {
+	BMCEndpoint   string
+	DatacenterID  string
+	SampledAt     time.Time
+	ConsumedWatts float64
+	EnergyKWh     float64 // Cumulative energy counter, 0 if the BMC does not report it
+}
+
+// Sampler periodically samples the power consumption of targets
+type Sampler struct {
+	targets   func() []Target
+	newClient func(endpoint string) Client
+	config    Config
+	observe   func(status string)
+
+	mu      sync.Mutex
+	pending []Sample
+}
+
+// NewSampler creates a sampler of the servers returned by targets,
+// connecting to their agents with the clients returned by newClient
+func NewSampler(targets func() []Target, newClient func(endpoint string) Client, config Config) *Sampler {
+	if config.MaxPending <= 0 {
+		config.MaxPending = DefaultMaxPending
+	}
+	return &Sampler{
+		targets:   targets,
+		newClient: newClient,
+		config:    config,
+	}
+}
+
+// SetObserver sets the function receiving the outcome of every sample, one
+// of the Status constants. Used to record metrics.
+func (s *Sampler) SetObserver(observe func(status string)) {
+	s.observe = observe
+}
+
+// Start samples all targets every interval until ctx ends
+func (s *Sampler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.SampleAll(ctx)
+			}
+		}
+	}()
+}
+
+// SampleAll samples the targets concurrently and buffers the samples of the
+// servers whose BMC reports their power consumption
+func (s *Sampler) SampleAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, sampleConcurrency)
+
+	for _, target := range s.targets() {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(target Target) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sample, ok := s.Sample(ctx, target)
+			if ok {
+				s.add(sample)
+			}
+		}(target)
+	}
+	wg.Wait()
+}
+
+// Sample reads the power consumption of a target. It returns false when the
+// reading failed or the BMC does not meter power.
+func (s *Sampler) Sample(ctx context.Context, target Target) (Sample, bool) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	client := s.newClient(target.AgentEndpoint)
+
+	resp, err := client.GetPowerReading(ctx, connect.NewRequest(&gatewayv1.GetPowerReadingRequest{
+		ServerId: target.ServerID,
+	}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			s.record(StatusUnsupported)
+			return Sample{}, false
+		}
+		s.record(StatusError)
+		log.Debug().
+			Err(err).
+			Str("bmc_endpoint", target.BMCEndpoint).
+			Msg("Power reading failed")
+		return Sample{}, false
+	}
+
+	sample := Sample{
+		BMCEndpoint:   target.BMCEndpoint,
+		DatacenterID:  target.DatacenterID,
+		SampledAt:     time.Now(),
+		ConsumedWatts: resp.Msg.GetReading().GetConsumedWatts(),
+	}
+
+	// Energy counters are optional; power readings alone are integrated by
+	// the manager
+	energy, err := client.GetEnergyUsage(ctx, connect.NewRequest(&gatewayv1.GetEnergyUsageRequest{
+		ServerId: target.ServerID,
+	}))
+	if err == nil {
+		sample.EnergyKWh = energy.Msg.EnergyKwh
+	}
+
+	s.record(StatusSuccess)
+	return sample, true
+}
+
+// Drain returns and clears all buffered samples
+func (s *Sampler) Drain() []Sample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := s.pending
+	s.pending = nil
+	return pending
+}
+
+// Requeue puts samples back in front of the buffer, e.g. after a failed
+// report, so they are included in the next drain
+func (s *Sampler) Requeue(pending []Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(append([]Sample(nil), pending...), s.pending...)
+	s.trim()
+}
+
+// record reports the outcome of a sample to the observer
+func (s *Sampler) record(status string) {
+	if s.observe != nil {
+		s.observe(status)
+	}
+}
+
+// add buffers a sample
+func (s *Sampler) add(sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, sample)
+	s.trim()
+}
+
+// trim drops the oldest samples beyond MaxPending. Callers hold mu.
+func (s *Sampler) trim() {
+	if excess := len(s.pending) - s.config.MaxPending; excess > 0 {
+		s.pending = append([]Sample(nil), s.pending[excess:]...)
+	}
+}
@@ -0,0 +1,114 @@
+package metering
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+)
+
+// meteringAgent is an agent RPC server metering the power of its servers
+type meteringAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	watts  map[string]float64 // server_id -> consumed watts
+	energy map[string]float64 // server_id -> energy counter, if reported
+}
+
+func (a *meteringAgent) GetPowerReading(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetPowerReadingRequest],
+) (*connect.Response[gatewayv1.GetPowerReadingResponse], error) {
+	watts, ok := a.watts[req.Msg.ServerId]
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not metered"))
+	}
+	return connect.NewResponse(&gatewayv1.GetPowerReadingResponse{
+		Reading: &gatewayv1.PowerReading{ConsumedWatts: watts},
+	}), nil
+}
+
+func (a *meteringAgent) GetEnergyUsage(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetEnergyUsageRequest],
+) (*connect.Response[gatewayv1.GetEnergyUsageResponse], error) {
+	energy, ok := a.energy[req.Msg.ServerId]
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not metered"))
+	}
+	return connect.NewResponse(&gatewayv1.GetEnergyUsageResponse{EnergyKwh: energy}), nil
+}
+
+func newTestSampler(t *testing.T, targets []Target, maxPending int) *Sampler {
+	agent := &meteringAgent{
+		watts:  map[string]float64{"srv-1": 350, "srv-2": 120},
+		energy: map[string]float64{"srv-1": 1520.5},
+	}
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(agent)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	for i := range targets {
+		targets[i].AgentEndpoint = server.URL
+	}
+	return NewSampler(func() []Target { return targets }, func(endpoint string) Client {
+		return gatewayv1connect.NewGatewayServiceClient(http.DefaultClient, endpoint)
+	}, Config{Interval: time.Minute, Timeout: 5 * time.Second, MaxPending: maxPending})
+}
+
+func TestSampleAll(t *testing.T) {
+	sampler := newTestSampler(t, []Target{
+		{ServerID: "srv-1", BMCEndpoint: "https://10.0.0.1", DatacenterID: "dc-1"},
+		{ServerID: "srv-2", BMCEndpoint: "https://10.0.0.2", DatacenterID: "dc-1"},
+		// IPMI servers are not metered and produce no samples
+		{ServerID: "srv-3", BMCEndpoint: "10.0.0.3:623", DatacenterID: "dc-1"},
+	}, 0)
+
+	sampler.SampleAll(context.Background())
+
+	samples := map[string]Sample{}
+	for _, sample := range sampler.Drain() {
+		samples[sample.BMCEndpoint] = sample
+	}
+	require.Len(t, samples, 2)
+
+	require.Equal(t, 350.0, samples["https://10.0.0.1"].ConsumedWatts)
+	require.Equal(t, 1520.5, samples["https://10.0.0.1"].EnergyKWh)
+	require.Equal(t, "dc-1", samples["https://10.0.0.1"].DatacenterID)
+	require.False(t, samples["https://10.0.0.1"].SampledAt.IsZero())
+
+	require.Equal(t, 120.0, samples["https://10.0.0.2"].ConsumedWatts)
+	require.Zero(t, samples["https://10.0.0.2"].EnergyKWh)
+
+	require.Empty(t, sampler.Drain())
+}
+
+func TestRequeueKeepsNewestSamples(t *testing.T) {
+	sampler := newTestSampler(t, []Target{
+		{ServerID: "srv-1", BMCEndpoint: "https://10.0.0.1"},
+	}, 2)
+
+	sampler.SampleAll(context.Background())
+	failed := sampler.Drain()
+	require.Len(t, failed, 1)
+
+	sampler.SampleAll(context.Background())
+	sampler.SampleAll(context.Background())
+	sampler.Requeue(failed)
+
+	// The requeued sample is the oldest and is dropped first
+	pending := sampler.Drain()
+	require.Len(t, pending, 2)
+	for _, sample := range pending {
+		require.True(t, sample.SampledAt.After(failed[0].SampledAt))
+	}
+}
@@ -97,6 +97,8 @@ func (c *Collector) collectAgentMetrics() {
 	for key, count := range agentCounts {
 		AgentsTotal.WithLabelValues(key.datacenter, key.status).Set(float64(count))
 	}
+
+	AgentClientsCached.Set(float64(c.handler.AgentClients().Len()))
 }
 
 // collectSessionMetrics updates session-related metrics
@@ -109,4 +111,8 @@ func (c *Collector) collectSessionMetrics() {
 	// For now, we set total sessions without type/customer breakdown
 	// TODO: Extend gateway handler to provide sessions by type
 	SessionsTotal.WithLabelValues("sol", "all").Set(float64(sessionCount))
+
+	webSockets, buffered := c.handler.StreamUsage()
+	ConsoleWebSockets.Set(float64(webSockets))
+	ConsoleBufferedBytes.Set(float64(buffered))
 }
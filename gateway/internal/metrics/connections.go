@@ -0,0 +1,8 @@
+package metrics
+
+import "strconv"
+
+// ObserveAgentConnection records a connection to an agent used by a call
+func ObserveAgentConnection(transport string, reused bool) {
+	AgentConnectionsTotal.WithLabelValues(transport, strconv.FormatBool(reused)).Inc()
+}
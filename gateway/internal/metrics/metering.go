@@ -0,0 +1,6 @@
+package metrics
+
+// ObservePowerSample records the outcome of a server power sample
+func ObservePowerSample(status string) {
+	PowerSamplesTotal.WithLabelValues(status).Inc()
+}
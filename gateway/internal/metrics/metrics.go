@@ -41,6 +41,86 @@ var (
 		[]string{"agent_id"},
 	)
 
+	// Agent Link Probes
+
+	AgentLinkRTT = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_agent_link_rtt_seconds",
+			Help: "Round-trip time of the link to the agent, as last probed",
+		},
+		[]string{"agent_id", "datacenter"},
+	)
+
+	AgentLinkThroughput = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_agent_link_throughput_bytes_per_second",
+			Help: "Throughput of the link to the agent, as last probed (upload: towards the agent)",
+		},
+		[]string{"agent_id", "datacenter", "direction"},
+	)
+
+	AgentLinkProbesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_agent_link_probes_total",
+			Help: "Total number of agent link probes",
+		},
+		[]string{"agent_id", "status"},
+	)
+
+	// Agent Client Pool
+
+	AgentConnectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_agent_connections_total",
+			Help: "Total number of connections to agents used by calls (reused: from the pool, rather than dialed)",
+		},
+		[]string{"transport", "reused"},
+	)
+
+	AgentClientsCached = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gateway_agent_clients_cached",
+			Help: "Number of agents with pooled RPC clients",
+		},
+	)
+
+	// Power Operations
+
+	PowerOperationsDeduplicatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_power_operations_deduplicated_total",
+			Help: "Total number of power operations answered with the result of an earlier request with their idempotency key",
+		},
+		[]string{"operation"},
+	)
+
+	OperationsCompletedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_operations_completed_total",
+			Help: "Total number of power operations started with StartPowerOperation that completed",
+		},
+		[]string{"operation", "state"},
+	)
+
+	OperationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gateway_operation_duration_seconds",
+			Help:    "Time from the start of power operations started with StartPowerOperation to their completion",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800},
+		},
+		[]string{"operation"},
+	)
+
+	// Power Metering
+
+	PowerSamplesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_power_samples_total",
+			Help: "Total number of server power samples (unsupported: the BMC does not meter power)",
+		},
+		[]string{"status"},
+	)
+
 	// Session Management
 
 	SessionsTotal = promauto.NewGaugeVec(
@@ -138,6 +218,81 @@ var (
 		[]string{"type", "error_type"},
 	)
 
+	// Console Streams
+
+	ConsoleStreamBytesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_console_stream_bytes_total",
+			Help: "Total number of console bytes proxied by closed streams (in: towards the BMC, out: towards the client)",
+		},
+		[]string{"type", "transport", "direction"},
+	)
+
+	ConsoleStreamChunksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_console_stream_chunks_total",
+			Help: "Total number of console chunks proxied by closed streams",
+		},
+		[]string{"type", "transport", "direction"},
+	)
+
+	ConsoleStreamsClosedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_console_streams_closed_total",
+			Help: "Total number of closed console streams by disconnect reason",
+		},
+		[]string{"type", "transport", "reason"},
+	)
+
+	ConsoleStreamDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gateway_console_stream_duration_seconds",
+			Help:    "Console stream lifetime in seconds",
+			Buckets: []float64{1, 10, 60, 300, 900, 1800, 3600, 7200},
+		},
+		[]string{"type", "transport"},
+	)
+
+	ConsoleStreamCorruptChunksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_console_stream_corrupt_chunks_total",
+			Help: "Total number of console chunks received from agents failing their checksum",
+		},
+		[]string{"type"},
+	)
+
+	ConsoleStreamRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_console_stream_rejections_total",
+			Help: "Total number of console WebSockets turned away by stream limits, by limit",
+		},
+		[]string{"limit"},
+	)
+
+	ConsoleWebSockets = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gateway_console_websockets",
+			Help: "Number of open console WebSockets counted against the stream limits",
+		},
+	)
+
+	ConsoleBufferedBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gateway_console_buffered_bytes",
+			Help: "Bytes reserved for the buffers of open console WebSockets",
+		},
+	)
+
+	// Event Bus
+
+	EventBusEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_eventbus_events_total",
+			Help: "Total number of events published to the event bus by outcome",
+		},
+		[]string{"event_type", "status"},
+	)
+
 	// HTTP/RPC Metrics
 
 	HTTPRequestsTotal = promauto.NewCounterVec(
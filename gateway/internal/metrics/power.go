@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"strings"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// ObservePowerDeduplication records a power operation retried with its
+// idempotency key, answered without running it again
+func ObservePowerDeduplication(operation string) {
+	PowerOperationsDeduplicatedTotal.WithLabelValues(operation).Inc()
+}
+
+// ObserveOperation records a power operation started with
+// StartPowerOperation once it completed
+func ObserveOperation(op *gatewayv1.Operation) {
+	operation := strings.ToLower(strings.TrimPrefix(op.Operation.String(), "POWER_OPERATION_"))
+	state := strings.ToLower(strings.TrimPrefix(op.State.String(), "OPERATION_STATE_"))
+	OperationsCompletedTotal.WithLabelValues(operation, state).Inc()
+	OperationDuration.WithLabelValues(operation).Observe(op.CompletedAt.AsTime().Sub(op.StartedAt.AsTime()).Seconds())
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"core/streaming"
+)
+
+// ObserveConsoleStream records the summary of a closed console stream
+func ObserveConsoleStream(summary streaming.SessionSummary) {
+	ConsoleStreamBytesTotal.WithLabelValues(summary.Protocol, summary.Transport, "in").Add(float64(summary.BytesIn))
+	ConsoleStreamBytesTotal.WithLabelValues(summary.Protocol, summary.Transport, "out").Add(float64(summary.BytesOut))
+	ConsoleStreamChunksTotal.WithLabelValues(summary.Protocol, summary.Transport, "in").Add(float64(summary.ChunksIn))
+	ConsoleStreamChunksTotal.WithLabelValues(summary.Protocol, summary.Transport, "out").Add(float64(summary.ChunksOut))
+	ConsoleStreamsClosedTotal.WithLabelValues(summary.Protocol, summary.Transport, string(summary.Reason)).Inc()
+	ConsoleStreamDuration.WithLabelValues(summary.Protocol, summary.Transport).Observe(summary.Duration.Seconds())
+}
+
+// ObserveCorruptChunk records a console chunk received from an agent failing
+// its checksum
+func ObserveCorruptChunk(protocol string) {
+	ConsoleStreamCorruptChunksTotal.WithLabelValues(protocol).Inc()
+}
+
+// ObserveStreamRejection records a console WebSocket turned away by a stream
+// limit
+func ObserveStreamRejection(reason string) {
+	ConsoleStreamRejectionsTotal.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,73 @@
+// Package outbox buffers the system events observed by the gateway, such as
+// power operations and console sessions, until they are reported to the
+// manager, which delivers them to webhook subscribers.
+package outbox
+
+import (
+	"sync"
+
+	"core/events"
+)
+
+// DefaultMaxPending bounds the number of buffered events when the manager is
+// unreachable. The oldest events are dropped first.
+const DefaultMaxPending = 10000
+
+// Outbox buffers events until they are drained
+type Outbox struct {
+	mu         sync.Mutex
+	pending    []events.Event
+	maxPending int
+}
+
+// New creates an outbox buffering at most maxPending events
+func New(maxPending int) *Outbox {
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPending
+	}
+	return &Outbox{maxPending: maxPending}
+}
+
+// Publish buffers an event
+func (o *Outbox) Publish(event events.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pending = append(o.pending, event)
+	o.trim()
+}
+
+// Drain returns and clears all buffered events
+func (o *Outbox) Drain() []events.Event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	pending := o.pending
+	o.pending = nil
+	return pending
+}
+
+// Requeue puts events back in front of the buffer, e.g. after a failed
+// report, so they are included in the next drain
+func (o *Outbox) Requeue(pending []events.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pending = append(append([]events.Event(nil), pending...), o.pending...)
+	o.trim()
+}
+
+// Pending returns the number of buffered events
+func (o *Outbox) Pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return len(o.pending)
+}
+
+// trim drops the oldest events beyond maxPending. Callers hold mu.
+func (o *Outbox) trim() {
+	if excess := len(o.pending) - o.maxPending; excess > 0 {
+		o.pending = append([]events.Event(nil), o.pending[excess:]...)
+	}
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/events"
+)
+
+func TestOutbox_RequeueAndBound(t *testing.T) {
+	o := New(3)
+
+	for _, id := range []string{"a", "b"} {
+		o.Publish(events.Event{ID: id, Type: events.PowerOperationExecuted})
+	}
+
+	drained := o.Drain()
+	require.Len(t, drained, 2)
+	assert.Zero(t, o.Pending())
+
+	o.Publish(events.Event{ID: "c", Type: events.ConsoleSessionOpened})
+	o.Requeue(drained)
+	assert.Equal(t, 3, o.Pending())
+
+	// Exceeding the bound drops the oldest events
+	o.Publish(events.Event{ID: "d", Type: events.ConsoleSessionOpened})
+
+	var ids []string
+	for _, event := range o.Drain() {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, ids)
+}
@@ -0,0 +1,238 @@
+// Package probe measures the links between the gateway and its agents:
+// round-trip time, and upload and download throughput. Agents are probed
+// periodically, so that a degraded WAN link to a datacenter shows up in
+// /status and in metrics before customers report laggy consoles.
+package probe
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/internal/agent"
+	"gateway/internal/metrics"
+)
+
+// rttSamples is the number of empty probes per round; the fastest one is the
+// round-trip time, as slower ones include queuing on the link
+const rttSamples = 3
+
+// Client is the part of the agent RPC client used to probe links
+type Client interface {
+	ProbeLink(ctx context.Context, req *connect.Request[gatewayv1.ProbeLinkRequest]) (*connect.Response[gatewayv1.ProbeLinkResponse], error)
+}
+
+// Config configures a Prober
+type Config struct {
+	Interval    time.Duration // Time between probe rounds
+	Timeout     time.Duration // Timeout of the probes of one agent
+	PayloadSize int           // Bytes transferred each way to measure throughput, 0 to skip
+}
+
+// Result is the outcome of the last probe of an agent
+type Result struct {
+	AgentID      string
+	DatacenterID string
+	ProbedAt     time.Time
+	RTT          time.Duration
+	UploadBPS    float64 // Bytes per second towards the agent
+	DownloadBPS  float64 // Bytes per second from the agent
+	Error        string  // Why the probe failed, empty on success
+}
+
+// Prober periodically probes the links to the agents of a registry
+type Prober struct {
+	registry  *agent.Registry
+	newClient func(endpoint string) Client
+	config    Config
+
+	mu      sync.RWMutex
+	results map[string]Result // agent_id -> last result
+}
+
+// NewProber creates a prober of the agents in registry, connecting to them
+// with the clients returned by newClient
+func NewProber(registry *agent.Registry, newClient func(endpoint string) Client, config Config) *Prober {
+	return &Prober{
+		registry:  registry,
+		newClient: newClient,
+		config:    config,
+		results:   make(map[string]Result),
+	}
+}
+
+// Start probes all agents every interval until ctx ends
+func (p *Prober) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.ProbeAll(ctx)
+			}
+		}
+	}()
+}
+
+// ProbeAll probes the active agents concurrently and forgets the results of
+// agents that are no longer registered
+func (p *Prober) ProbeAll(ctx context.Context) {
+	agents := p.registry.List()
+
+	var wg sync.WaitGroup
+	for _, info := range agents {
+		if info.Status != "active" {
+			continue
+		}
+		wg.Add(1)
+		go func(info *agent.Info) {
+			defer wg.Done()
+			p.record(p.Probe(ctx, info))
+		}(info)
+	}
+	wg.Wait()
+
+	registered := make(map[string]bool, len(agents))
+	for _, info := range agents {
+		registered[info.ID] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for agentID := range p.results {
+		if !registered[agentID] {
+			delete(p.results, agentID)
+			metrics.AgentLinkRTT.DeletePartialMatch(prometheus.Labels{"agent_id": agentID})
+			metrics.AgentLinkThroughput.DeletePartialMatch(prometheus.Labels{"agent_id": agentID})
+		}
+	}
+}
+
+// Probe measures the link to an agent
+func (p *Prober) Probe(ctx context.Context, info *agent.Info) Result {
+	result := Result{
+		AgentID:      info.ID,
+		DatacenterID: info.DatacenterID,
+		ProbedAt:     time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	client := p.newClient(info.Endpoint)
+
+	for i := 0; i < rttSamples; i++ {
+		elapsed, err := p.exchange(ctx, client, nil, 0)
+		if err != nil {
+			result.Error = fmt.Sprintf("round-trip probe failed: %v", err)
+			return result
+		}
+		if result.RTT == 0 || elapsed < result.RTT {
+			result.RTT = elapsed
+		}
+	}
+
+	if p.config.PayloadSize <= 0 {
+		return result
+	}
+
+	payload := make([]byte, p.config.PayloadSize)
+	if _, err := rand.Read(payload); err != nil {
+		result.Error = fmt.Sprintf("failed to generate probe payload: %v", err)
+		return result
+	}
+
+	elapsed, err := p.exchange(ctx, client, payload, 0)
+	if err != nil {
+		result.Error = fmt.Sprintf("upload probe failed: %v", err)
+		return result
+	}
+	result.UploadBPS = throughput(p.config.PayloadSize, elapsed, result.RTT)
+
+	elapsed, err = p.exchange(ctx, client, nil, p.config.PayloadSize)
+	if err != nil {
+		result.Error = fmt.Sprintf("download probe failed: %v", err)
+		return result
+	}
+	result.DownloadBPS = throughput(p.config.PayloadSize, elapsed, result.RTT)
+
+	return result
+}
+
+// Result returns the last probe result of an agent
+func (p *Prober) Result(agentID string) (Result, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result, ok := p.results[agentID]
+	return result, ok
+}
+
+// exchange sends one probe and returns how long the agent took to reply
+func (p *Prober) exchange(ctx context.Context, client Client, payload []byte, responseSize int) (time.Duration, error) {
+	start := time.Now()
+	resp, err := client.ProbeLink(ctx, connect.NewRequest(&gatewayv1.ProbeLinkRequest{
+		Payload:      payload,
+		ResponseSize: int32(responseSize),
+	}))
+	elapsed := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Msg.Payload) != responseSize {
+		return 0, fmt.Errorf("agent replied with %d bytes, want %d", len(resp.Msg.Payload), responseSize)
+	}
+	return elapsed, nil
+}
+
+// record stores a result and updates the link metrics
+func (p *Prober) record(result Result) {
+	p.mu.Lock()
+	p.results[result.AgentID] = result
+	p.mu.Unlock()
+
+	if result.Error != "" {
+		metrics.AgentLinkProbesTotal.WithLabelValues(result.AgentID, "error").Inc()
+		log.Warn().
+			Str("agent_id", result.AgentID).
+			Str("datacenter_id", result.DatacenterID).
+			Str("error", result.Error).
+			Msg("Agent link probe failed")
+		return
+	}
+
+	metrics.AgentLinkProbesTotal.WithLabelValues(result.AgentID, "success").Inc()
+	metrics.AgentLinkRTT.WithLabelValues(result.AgentID, result.DatacenterID).Set(result.RTT.Seconds())
+	if p.config.PayloadSize > 0 {
+		metrics.AgentLinkThroughput.WithLabelValues(result.AgentID, result.DatacenterID, "upload").Set(result.UploadBPS)
+		metrics.AgentLinkThroughput.WithLabelValues(result.AgentID, result.DatacenterID, "download").Set(result.DownloadBPS)
+	}
+
+	log.Debug().
+		Str("agent_id", result.AgentID).
+		Dur("rtt", result.RTT).
+		Float64("upload_bps", result.UploadBPS).
+		Float64("download_bps", result.DownloadBPS).
+		Msg("Agent link probed")
+}
+
+// throughput returns the bytes per second of a transfer of size bytes that
+// took elapsed, excluding the round-trip time of the exchange
+func throughput(size int, elapsed, rtt time.Duration) float64 {
+	transfer := elapsed - rtt
+	if transfer <= 0 {
+		// The payload fits in the link's buffers; elapsed is the best bound
+		transfer = elapsed
+	}
+	return float64(size) / transfer.Seconds()
+}
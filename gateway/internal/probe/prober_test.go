@@ -0,0 +1,97 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	"gateway/internal/agent"
+)
+
+// echoAgent is an agent RPC server answering link probes
+type echoAgent struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	uploaded int
+}
+
+func (a *echoAgent) ProbeLink(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ProbeLinkRequest],
+) (*connect.Response[gatewayv1.ProbeLinkResponse], error) {
+	a.uploaded += len(req.Msg.Payload)
+	return connect.NewResponse(&gatewayv1.ProbeLinkResponse{
+		Payload: make([]byte, req.Msg.ResponseSize),
+	}), nil
+}
+
+func newAgentServer(t *testing.T, handler gatewayv1connect.GatewayServiceHandler) *httptest.Server {
+	path, rpcHandler := gatewayv1connect.NewGatewayServiceHandler(handler)
+	mux := http.NewServeMux()
+	mux.Handle(path, rpcHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestProber(registry *agent.Registry, payloadSize int) *Prober {
+	return NewProber(registry, func(endpoint string) Client {
+		return gatewayv1connect.NewGatewayServiceClient(http.DefaultClient, endpoint)
+	}, Config{Interval: time.Minute, Timeout: 5 * time.Second, PayloadSize: payloadSize})
+}
+
+func TestProbeAll(t *testing.T) {
+	echo := &echoAgent{}
+	healthy := newAgentServer(t, echo)
+	// Agents running a version without ProbeLink fail their probes
+	outdated := newAgentServer(t, &gatewayv1connect.UnimplementedGatewayServiceHandler{})
+
+	registry := agent.NewRegistry()
+	registry.Register(&agent.Info{ID: "agent-1", DatacenterID: "dc-1", Endpoint: healthy.URL})
+	registry.Register(&agent.Info{ID: "agent-2", DatacenterID: "dc-2", Endpoint: outdated.URL})
+
+	prober := newTestProber(registry, 64*1024)
+	prober.ProbeAll(context.Background())
+
+	result, ok := prober.Result("agent-1")
+	require.True(t, ok)
+	require.Empty(t, result.Error)
+	require.Equal(t, "dc-1", result.DatacenterID)
+	require.Positive(t, result.RTT)
+	require.Positive(t, result.UploadBPS)
+	require.Positive(t, result.DownloadBPS)
+	require.Equal(t, 64*1024, echo.uploaded)
+
+	result, ok = prober.Result("agent-2")
+	require.True(t, ok)
+	require.Contains(t, result.Error, "round-trip probe failed")
+
+	t.Run("removed agents are forgotten", func(t *testing.T) {
+		registry.Remove("agent-2")
+		prober.ProbeAll(context.Background())
+
+		_, ok := prober.Result("agent-2")
+		require.False(t, ok)
+		_, ok = prober.Result("agent-1")
+		require.True(t, ok)
+	})
+}
+
+func TestProbeRTTOnly(t *testing.T) {
+	echo := &echoAgent{}
+	server := newAgentServer(t, echo)
+
+	prober := newTestProber(agent.NewRegistry(), 0)
+	result := prober.Probe(context.Background(), &agent.Info{ID: "agent-1", Endpoint: server.URL})
+
+	require.Empty(t, result.Error)
+	require.Positive(t, result.RTT)
+	require.Zero(t, result.UploadBPS)
+	require.Zero(t, echo.uploaded)
+}
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccessTokenParam is the URL query parameter carrying console access tokens
+const AccessTokenParam = "token"
+
+// Access token scopes
+const (
+	ScopeViewer = "viewer" // Opens a viewer page, once
+	ScopeStream = "stream" // Opens the WebSocket of a console session
+)
+
+// Access token errors
+var (
+	ErrAccessTokenInvalid = &Error{Code: "access_token_invalid", Message: "Access token is missing or invalid"}
+	ErrAccessTokenExpired = &Error{Code: "access_token_expired", Message: "Access token has expired"}
+	ErrAccessTokenUsed    = &Error{Code: "access_token_used", Message: "Access token has already been used"}
+)
+
+// AccessSigner issues and verifies the HMAC-signed access tokens embedded in
+// console URLs. A token is bound to a scope and a console session ID and
+// carries its own expiry, so verification needs no storage; only redeemed
+// single-use tokens are remembered until they expire.
+type AccessSigner struct {
+	key []byte
+
+	mu       sync.Mutex
+	redeemed map[string]time.Time // nonce -> token expiry
+}
+
+// NewAccessSigner creates a signer keyed with secret. With an empty secret a
+// random key is used, and tokens do not survive a gateway restart.
+func NewAccessSigner(secret string) *AccessSigner {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic("failed to generate access token key: " + err.Error())
+		}
+	}
+	return &AccessSigner{key: key, redeemed: make(map[string]time.Time)}
+}
+
+// Sign returns a token granting scope on a console session until expiresAt
+func (s *AccessSigner) Sign(scope, sessionID string, expiresAt time.Time) string {
+	payload := make([]byte, 24)
+	if _, err := rand.Read(payload[:16]); err != nil {
+		panic("failed to generate access token nonce: " + err.Error())
+	}
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(scope, sessionID, encoded)
+}
+
+// Verify checks that token grants scope on a console session and has not
+// expired
+func (s *AccessSigner) Verify(scope, sessionID, token string) error {
+	_, err := s.verify(scope, sessionID, token)
+	return err
+}
+
+// Redeem verifies token like Verify and consumes it, so that any later use
+// fails with ErrAccessTokenUsed
+func (s *AccessSigner) Redeem(scope, sessionID, token string) error {
+	expiresAt, err := s.verify(scope, sessionID, token)
+	if err != nil {
+		return err
+	}
+
+	nonce, _, _ := strings.Cut(token, ".")
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired tokens fail verification anyway, so forget them
+	for used, expiry := range s.redeemed {
+		if now.After(expiry) {
+			delete(s.redeemed, used)
+		}
+	}
+
+	if _, used := s.redeemed[nonce]; used {
+		return ErrAccessTokenUsed
+	}
+	s.redeemed[nonce] = expiresAt
+	return nil
+}
+
+// verify returns the expiry of a valid token
+func (s *AccessSigner) verify(scope, sessionID, token string) (time.Time, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || sessionID == "" {
+		return time.Time{}, ErrAccessTokenInvalid
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(scope, sessionID, encoded))) {
+		return time.Time{}, ErrAccessTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(payload) != 24 {
+		return time.Time{}, ErrAccessTokenInvalid
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if time.Now().After(expiresAt) {
+		return time.Time{}, ErrAccessTokenExpired
+	}
+	return expiresAt, nil
+}
+
+func (s *AccessSigner) signature(scope, sessionID, encodedPayload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte("access:" + scope + ":" + sessionID + ":" + encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
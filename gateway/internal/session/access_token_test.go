@@ -0,0 +1,61 @@
+package session
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccessSignerVerify(t *testing.T) {
+	signer := NewAccessSigner("secret")
+	token := signer.Sign(ScopeStream, "sol-1", time.Now().Add(time.Hour))
+
+	if err := signer.Verify(ScopeStream, "sol-1", token); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if err := signer.Verify(ScopeStream, "sol-1", token); err != nil {
+		t.Errorf("Verify should not consume the token: %v", err)
+	}
+	if err := NewAccessSigner("secret").Verify(ScopeStream, "sol-1", token); err != nil {
+		t.Errorf("token should stay valid with the same secret: %v", err)
+	}
+
+	invalid := map[string]func() error{
+		"other session": func() error { return signer.Verify(ScopeStream, "sol-2", token) },
+		"other scope":   func() error { return signer.Verify(ScopeViewer, "sol-1", token) },
+		"other secret":  func() error { return NewAccessSigner("other-secret").Verify(ScopeStream, "sol-1", token) },
+		"empty token":   func() error { return signer.Verify(ScopeStream, "sol-1", "") },
+		"tampered expiry": func() error {
+			payload, signature, _ := strings.Cut(token, ".")
+			return signer.Verify(ScopeStream, "sol-1", payload[:len(payload)-1]+"A."+signature)
+		},
+	}
+	for name, verify := range invalid {
+		if err := verify(); !errors.Is(err, ErrAccessTokenInvalid) {
+			t.Errorf("%s: expected ErrAccessTokenInvalid, got %v", name, err)
+		}
+	}
+
+	expired := signer.Sign(ScopeStream, "sol-1", time.Now().Add(-time.Second))
+	if err := signer.Verify(ScopeStream, "sol-1", expired); !errors.Is(err, ErrAccessTokenExpired) {
+		t.Errorf("expected ErrAccessTokenExpired, got %v", err)
+	}
+}
+
+func TestAccessSignerRedeem(t *testing.T) {
+	signer := NewAccessSigner("secret")
+	token := signer.Sign(ScopeViewer, "vnc-1", time.Now().Add(time.Minute))
+
+	if err := signer.Redeem(ScopeViewer, "vnc-1", token); err != nil {
+		t.Fatalf("Redeem failed: %v", err)
+	}
+	if err := signer.Redeem(ScopeViewer, "vnc-1", token); !errors.Is(err, ErrAccessTokenUsed) {
+		t.Errorf("expected ErrAccessTokenUsed on second use, got %v", err)
+	}
+
+	other := signer.Sign(ScopeViewer, "vnc-1", time.Now().Add(time.Minute))
+	if err := signer.Redeem(ScopeViewer, "vnc-1", other); err != nil {
+		t.Errorf("a newly issued token should be redeemable: %v", err)
+	}
+}
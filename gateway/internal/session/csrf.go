@@ -0,0 +1,47 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// CSRFHeaderName is the header carrying the CSRF token of cookie-authenticated
+// requests. Viewer pages receive the token when they are rendered.
+const CSRFHeaderName = "X-CSRF-Token"
+
+// CSRFProtector issues and verifies CSRF tokens bound to web sessions. Tokens
+// are an HMAC of the session ID, so they need no storage and stay valid as
+// long as the secret does.
+type CSRFProtector struct {
+	key []byte
+}
+
+// NewCSRFProtector creates a protector keyed with secret. With an empty
+// secret a random key is used, and tokens do not survive a gateway restart.
+func NewCSRFProtector(secret string) *CSRFProtector {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic("failed to generate CSRF key: " + err.Error())
+		}
+	}
+	return &CSRFProtector{key: key}
+}
+
+// Token returns the CSRF token of a web session
+func (p *CSRFProtector) Token(webSessionID string) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte("csrf:" + webSessionID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Valid reports whether token is the CSRF token of a web session
+func (p *CSRFProtector) Valid(webSessionID, token string) bool {
+	if webSessionID == "" || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(p.Token(webSessionID)))
+}
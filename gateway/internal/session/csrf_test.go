@@ -0,0 +1,27 @@
+package session
+
+import "testing"
+
+func TestCSRFProtector(t *testing.T) {
+	protector := NewCSRFProtector("secret")
+
+	token := protector.Token("web-1")
+	if token == "" {
+		t.Fatal("Token returned an empty token")
+	}
+	if !protector.Valid("web-1", token) {
+		t.Error("token should be valid for its session")
+	}
+	if protector.Valid("web-2", token) {
+		t.Error("token should not be valid for another session")
+	}
+	if protector.Valid("web-1", "") {
+		t.Error("empty token should not be valid")
+	}
+	if NewCSRFProtector("other-secret").Valid("web-1", token) {
+		t.Error("token should not be valid under another secret")
+	}
+	if !NewCSRFProtector("secret").Valid("web-1", token) {
+		t.Error("token should stay valid with the same secret")
+	}
+}
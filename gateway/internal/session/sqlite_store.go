@@ -0,0 +1,343 @@
+package session
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore implements Store interface on a SQLite database, so that web
+// sessions survive gateway restarts. Timestamps are stored as Unix
+// nanoseconds.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+const webSessionColumns = `id, sol_session_id, vnc_session_id, customer_jwt, created_at, last_activity_at,
+	expires_at, token_expires_at, token_renewal_at, customer_id, server_id, theme`
+
+// NewSQLiteStore opens (creating if needed) the session database at path.
+// Use ":memory:" for a private in-memory database. The database holds the
+// customers' JWTs, so it is only readable by the gateway's user.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	if err := createPrivateFile(path); err != nil {
+		return nil, fmt.Errorf("failed to create session database: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session database: %w", err)
+	}
+
+	// SQLite serializes writes, and each connection to ":memory:" would open
+	// a separate database
+	db.SetMaxOpenConns(1)
+
+	store := &SQLiteStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate session database: %w", err)
+	}
+
+	return store, nil
+}
+
+// createPrivateFile creates the database file at path, and its directory,
+// accessible by the current user only, and restricts an existing file the
+// same way. In-memory databases and URIs are left to SQLite.
+func createPrivateFile(path string) error {
+	if path == ":memory:" || strings.HasPrefix(path, "file:") {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0600)
+}
+
+// Close closes the database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) migrate() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS web_sessions (
+		id TEXT PRIMARY KEY,
+		sol_session_id TEXT NOT NULL DEFAULT '',
+		vnc_session_id TEXT NOT NULL DEFAULT '',
+		customer_jwt TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		last_activity_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL,
+		token_expires_at INTEGER NOT NULL,
+		token_renewal_at INTEGER NOT NULL,
+		customer_id TEXT NOT NULL DEFAULT '',
+		server_id TEXT NOT NULL DEFAULT '',
+		theme TEXT NOT NULL DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_web_sessions_sol ON web_sessions (sol_session_id);
+	CREATE INDEX IF NOT EXISTS idx_web_sessions_vnc ON web_sessions (vnc_session_id);
+	CREATE INDEX IF NOT EXISTS idx_web_sessions_expires ON web_sessions (expires_at);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// Databases created before web sessions recorded a theme
+	hasTheme, err := s.hasColumn("web_sessions", "theme")
+	if err != nil {
+		return err
+	}
+	if !hasTheme {
+		if _, err := s.db.Exec(`ALTER TABLE web_sessions ADD COLUMN theme TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasColumn reports whether a table has a column
+func (s *SQLiteStore) hasColumn(table, column string) (bool, error) {
+	rows, err := s.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// Create adds a new session to the store
+func (s *SQLiteStore) Create(session *WebSession) error {
+	_, err := s.db.Exec(`INSERT INTO web_sessions (`+webSessionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		session.ID,
+		session.SOLSessionID,
+		session.VNCSessionID,
+		session.CustomerJWT,
+		session.CreatedAt.UnixNano(),
+		session.LastActivityAt.UnixNano(),
+		session.ExpiresAt.UnixNano(),
+		session.TokenExpiresAt.UnixNano(),
+		session.TokenRenewalAt.UnixNano(),
+		session.CustomerID,
+		session.ServerID,
+		session.Theme,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create web session: %w", err)
+	}
+
+	log.Debug().
+		Str("session_id", session.ID).
+		Str("customer_id", session.CustomerID).
+		Str("server_id", session.ServerID).
+		Time("expires_at", session.ExpiresAt).
+		Msg("Created web session")
+
+	return nil
+}
+
+// Get retrieves a session by ID
+func (s *SQLiteStore) Get(sessionID string) (*WebSession, error) {
+	return s.getWhere("id = ?", sessionID)
+}
+
+// Update updates an existing session
+func (s *SQLiteStore) Update(session *WebSession) error {
+	result, err := s.db.Exec(`UPDATE web_sessions SET
+		sol_session_id = ?, vnc_session_id = ?, customer_jwt = ?, created_at = ?, last_activity_at = ?,
+		expires_at = ?, token_expires_at = ?, token_renewal_at = ?, customer_id = ?, server_id = ?, theme = ?
+		WHERE id = ?`,
+		session.SOLSessionID,
+		session.VNCSessionID,
+		session.CustomerJWT,
+		session.CreatedAt.UnixNano(),
+		session.LastActivityAt.UnixNano(),
+		session.ExpiresAt.UnixNano(),
+		session.TokenExpiresAt.UnixNano(),
+		session.TokenRenewalAt.UnixNano(),
+		session.CustomerID,
+		session.ServerID,
+		session.Theme,
+		session.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update web session: %w", err)
+	}
+	if err := requireAffected(result); err != nil {
+		return err
+	}
+
+	log.Debug().
+		Str("session_id", session.ID).
+		Msg("Updated web session")
+
+	return nil
+}
+
+// Delete removes a session from the store
+func (s *SQLiteStore) Delete(sessionID string) error {
+	if _, err := s.db.Exec(`DELETE FROM web_sessions WHERE id = ?`, sessionID); err != nil {
+		return fmt.Errorf("failed to delete web session: %w", err)
+	}
+
+	log.Debug().
+		Str("session_id", sessionID).
+		Msg("Deleted web session")
+
+	return nil
+}
+
+// UpdateActivity updates the last activity time for a session
+func (s *SQLiteStore) UpdateActivity(sessionID string) error {
+	result, err := s.db.Exec(`UPDATE web_sessions SET last_activity_at = ? WHERE id = ?`, time.Now().UnixNano(), sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to update web session activity: %w", err)
+	}
+	return requireAffected(result)
+}
+
+// GetBySOLSessionID finds a web session by its associated SOL session ID
+func (s *SQLiteStore) GetBySOLSessionID(solSessionID string) (*WebSession, error) {
+	return s.getWhere("sol_session_id = ?", solSessionID)
+}
+
+// GetByVNCSessionID finds a web session by its associated VNC session ID
+func (s *SQLiteStore) GetByVNCSessionID(vncSessionID string) (*WebSession, error) {
+	return s.getWhere("vnc_session_id = ?", vncSessionID)
+}
+
+// GetSessionsNeedingRenewal returns sessions that need token renewal
+func (s *SQLiteStore) GetSessionsNeedingRenewal() []*WebSession {
+	now := time.Now().UnixNano()
+	rows, err := s.db.Query(`SELECT `+webSessionColumns+` FROM web_sessions
+		WHERE expires_at >= ? AND token_renewal_at < ? AND token_expires_at > ?`, now, now, now)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to query web sessions needing renewal")
+		return nil
+	}
+	defer rows.Close()
+
+	var needsRenewal []*WebSession
+	for rows.Next() {
+		session, err := scanWebSession(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read web session")
+			continue
+		}
+		needsRenewal = append(needsRenewal, session)
+	}
+	return needsRenewal
+}
+
+// DeleteExpired removes all expired sessions
+func (s *SQLiteStore) DeleteExpired() int {
+	result, err := s.db.Exec(`DELETE FROM web_sessions WHERE expires_at < ?`, time.Now().UnixNano())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to delete expired web sessions")
+		return 0
+	}
+
+	deleted, _ := result.RowsAffected()
+	if deleted > 0 {
+		log.Info().
+			Int64("count", deleted).
+			Msg("Cleaned up expired web sessions")
+	}
+
+	return int(deleted)
+}
+
+// getWhere returns the most recent session matching a single-argument
+// condition, reporting expired sessions as ErrSessionExpired
+func (s *SQLiteStore) getWhere(condition string, arg string) (*WebSession, error) {
+	row := s.db.QueryRow(`SELECT `+webSessionColumns+` FROM web_sessions WHERE `+condition+
+		` ORDER BY created_at DESC LIMIT 1`, arg)
+
+	session, err := scanWebSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web session: %w", err)
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionExpired
+	}
+
+	return session, nil
+}
+
+// scanWebSession reads a row selected with webSessionColumns
+func scanWebSession(row interface{ Scan(...any) error }) (*WebSession, error) {
+	var (
+		session                                                       WebSession
+		createdAt, lastActivityAt, expiresAt, tokenExpiresAt, renewAt int64
+	)
+
+	err := row.Scan(
+		&session.ID,
+		&session.SOLSessionID,
+		&session.VNCSessionID,
+		&session.CustomerJWT,
+		&createdAt,
+		&lastActivityAt,
+		&expiresAt,
+		&tokenExpiresAt,
+		&renewAt,
+		&session.CustomerID,
+		&session.ServerID,
+		&session.Theme,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.CreatedAt = time.Unix(0, createdAt)
+	session.LastActivityAt = time.Unix(0, lastActivityAt)
+	session.ExpiresAt = time.Unix(0, expiresAt)
+	session.TokenExpiresAt = time.Unix(0, tokenExpiresAt)
+	session.TokenRenewalAt = time.Unix(0, renewAt)
+	return &session, nil
+}
+
+// requireAffected reports ErrSessionNotFound when an update matched no session
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestWebSession(id string, expiresAt time.Time) *WebSession {
+	now := time.Now()
+	return &WebSession{
+		ID:             id,
+		SOLSessionID:   "sol-" + id,
+		VNCSessionID:   "vnc-" + id,
+		CustomerJWT:    "jwt-" + id,
+		CreatedAt:      now,
+		LastActivityAt: now,
+		ExpiresAt:      expiresAt,
+		TokenExpiresAt: now.Add(time.Hour),
+		TokenRenewalAt: now.Add(-time.Minute),
+		CustomerID:     "customer-1",
+		ServerID:       "server-1",
+	}
+}
+
+func TestSQLiteStorePersistsSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	created := newTestWebSession("web-1", time.Now().Add(time.Hour))
+	if err := store.Create(created); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	store.Close()
+
+	// Sessions survive reopening the database, e.g. a gateway restart
+	store, err = NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("Reopening store failed: %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get("web-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.CustomerJWT != "jwt-web-1" || got.ServerID != "server-1" || !got.ExpiresAt.Equal(created.ExpiresAt) {
+		t.Errorf("Unexpected session after reopen: %+v", got)
+	}
+
+	if got, err := store.GetBySOLSessionID("sol-web-1"); err != nil || got.ID != "web-1" {
+		t.Errorf("GetBySOLSessionID = %v, %v", got, err)
+	}
+	if got, err := store.GetByVNCSessionID("vnc-web-1"); err != nil || got.ID != "web-1" {
+		t.Errorf("GetByVNCSessionID = %v, %v", got, err)
+	}
+
+	got.CustomerJWT = "renewed-jwt"
+	got.Theme = "light"
+	if err := store.Update(got); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got, _ := store.Get("web-1"); got.CustomerJWT != "renewed-jwt" || got.Theme != "light" {
+		t.Errorf("Expected renewed JWT and light theme, got %q and %q", got.CustomerJWT, got.Theme)
+	}
+
+	if renewals := store.GetSessionsNeedingRenewal(); len(renewals) != 1 {
+		t.Errorf("Expected 1 session needing renewal, got %d", len(renewals))
+	}
+
+	if err := store.Delete("web-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get("web-1"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound after delete, got %v", err)
+	}
+	if err := store.Update(got); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound updating a deleted session, got %v", err)
+	}
+	if err := store.UpdateActivity("web-1"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound for activity of a deleted session, got %v", err)
+	}
+}
+
+func TestSQLiteStoreFileIsPrivate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "sessions.db")
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	defer store.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected session database mode 0600, got %o", info.Mode().Perm())
+	}
+
+	info, err = os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0700 {
+		t.Errorf("Expected session database directory mode 0700, got %o", info.Mode().Perm())
+	}
+}
+
+func TestSQLiteStoreMigratesThemeColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	// Database created before web sessions recorded a theme
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE web_sessions (
+		id TEXT PRIMARY KEY,
+		sol_session_id TEXT NOT NULL DEFAULT '',
+		vnc_session_id TEXT NOT NULL DEFAULT '',
+		customer_jwt TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		last_activity_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL,
+		token_expires_at INTEGER NOT NULL,
+		token_renewal_at INTEGER NOT NULL,
+		customer_id TEXT NOT NULL DEFAULT '',
+		server_id TEXT NOT NULL DEFAULT ''
+	);
+	INSERT INTO web_sessions VALUES ('web-1', '', '', 'jwt', 0, 0, 9000000000000000000, 0, 0, 'customer-1', 'server-1');`)
+	db.Close()
+	if err != nil {
+		t.Fatalf("Failed to create old schema: %v", err)
+	}
+
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed on old schema: %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get("web-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Theme != "" {
+		t.Errorf("Expected no theme for migrated session, got %q", got.Theme)
+	}
+}
+
+func TestSQLiteStoreExpiry(t *testing.T) {
+	store, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	defer store.Close()
+
+	store.Create(newTestWebSession("expired", time.Now().Add(-time.Minute)))
+	store.Create(newTestWebSession("active", time.Now().Add(time.Hour)))
+
+	if _, err := store.Get("expired"); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Expected ErrSessionExpired, got %v", err)
+	}
+
+	if deleted := store.DeleteExpired(); deleted != 1 {
+		t.Errorf("Expected 1 expired session deleted, got %d", deleted)
+	}
+	if _, err := store.Get("expired"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("Expected ErrSessionNotFound after sweep, got %v", err)
+	}
+	if _, err := store.Get("active"); err != nil {
+		t.Errorf("Active session should survive the sweep: %v", err)
+	}
+}
+
+func TestExpirySweeper(t *testing.T) {
+	store := NewInMemoryStore()
+	store.Create(newTestWebSession("expired", time.Now().Add(-time.Minute)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartExpirySweeper(ctx, store, 10*time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := store.Get("expired"); errors.Is(err, ErrSessionNotFound) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expired session was not swept")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -22,6 +22,7 @@ type WebSession struct {
 	TokenRenewalAt time.Time // When to renew (before expiration)
 	CustomerID     string    // Extracted from JWT
 	ServerID       string    // Server this session is for
+	Theme          string    // Web UI theme chosen in the session's pages, empty for the default
 }
 
 // Store defines the interface for session storage
@@ -43,7 +44,8 @@ type Store interface {
 	DeleteExpired() int
 }
 
-// InMemoryStore implements Store interface with in-memory storage
+// InMemoryStore implements Store interface with in-memory storage. Sessions
+// are lost when the gateway restarts.
 type InMemoryStore struct {
 	sessions map[string]*WebSession
 	mu       sync.RWMutex
@@ -51,14 +53,9 @@ type InMemoryStore struct {
 
 // NewInMemoryStore creates a new in-memory session store
 func NewInMemoryStore() *InMemoryStore {
-	store := &InMemoryStore{
+	return &InMemoryStore{
 		sessions: make(map[string]*WebSession),
 	}
-
-	// Start cleanup worker
-	go store.cleanupWorker()
-
-	return store
 }
 
 // Create adds a new session to the store
@@ -226,16 +223,6 @@ func (s *InMemoryStore) DeleteExpired() int {
 	return deleted
 }
 
-// cleanupWorker runs periodic cleanup of expired sessions
-func (s *InMemoryStore) cleanupWorker() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		s.DeleteExpired()
-	}
-}
-
 // GenerateSecureSessionID generates a cryptographically secure random session ID
 func GenerateSecureSessionID() (string, error) {
 	b := make([]byte, 32) // 256 bits
@@ -0,0 +1,31 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSweepInterval is how often expired sessions are purged by default
+const DefaultSweepInterval = 5 * time.Minute
+
+// StartExpirySweeper purges expired sessions from the store every interval
+// until ctx is cancelled
+func StartExpirySweeper(ctx context.Context, store Store, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				store.DeleteExpired()
+			}
+		}
+	}()
+}
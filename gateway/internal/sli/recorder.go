@@ -0,0 +1,166 @@
+// Package sli records console session service level indicators on the
+// gateway: whether each console stream was established with its agent and
+// how long it took for the first console byte to arrive. Measurements are
+// buffered until they are reported to the manager.
+package sli
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMaxPending bounds the number of buffered measurements when the
+// manager is unreachable. The oldest measurements are dropped first.
+const DefaultMaxPending = 10000
+
+// Session is the outcome of one console stream establishment
+type Session struct {
+	SessionID       string
+	AgentID         string
+	DatacenterID    string
+	SessionType     string // "sol" or "vnc"
+	StartedAt       time.Time
+	Established     bool
+	FirstByte       bool
+	TimeToFirstByte time.Duration
+	Error           string
+}
+
+// Recorder buffers console session measurements until they are drained
+type Recorder struct {
+	mu         sync.Mutex
+	pending    []Session
+	maxPending int
+	now        func() time.Time
+}
+
+// NewRecorder creates a recorder buffering at most maxPending measurements
+func NewRecorder(maxPending int) *Recorder {
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPending
+	}
+	return &Recorder{
+		maxPending: maxPending,
+		now:        time.Now,
+	}
+}
+
+// Start begins measuring a console stream. The returned Attempt must be
+// finished once the stream ends.
+func (r *Recorder) Start(sessionID, agentID, datacenterID, sessionType string) *Attempt {
+	return &Attempt{
+		recorder: r,
+		session: Session{
+			SessionID:    sessionID,
+			AgentID:      agentID,
+			DatacenterID: datacenterID,
+			SessionType:  sessionType,
+			StartedAt:    r.now(),
+		},
+	}
+}
+
+// Drain returns and clears all buffered measurements
+func (r *Recorder) Drain() []Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := r.pending
+	r.pending = nil
+	return sessions
+}
+
+// Requeue puts measurements back in front of the buffer, e.g. after a failed
+// report, so they are included in the next drain
+func (r *Recorder) Requeue(sessions []Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(append([]Session(nil), sessions...), r.pending...)
+	r.trim()
+}
+
+// Pending returns the number of buffered measurements
+func (r *Recorder) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.pending)
+}
+
+func (r *Recorder) add(session Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = append(r.pending, session)
+	r.trim()
+}
+
+// trim drops the oldest measurements beyond maxPending. Callers hold mu.
+func (r *Recorder) trim() {
+	if excess := len(r.pending) - r.maxPending; excess > 0 {
+		r.pending = append([]Session(nil), r.pending[excess:]...)
+	}
+}
+
+// Attempt tracks a single console stream. Its methods are safe to call from
+// the goroutines proxying each direction of the stream.
+type Attempt struct {
+	recorder *Recorder
+	mu       sync.Mutex
+	session  Session
+	finished bool
+}
+
+// Established marks the stream as established with the agent
+func (a *Attempt) Established() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.finished || a.session.Error != "" {
+		return
+	}
+	a.session.Established = true
+}
+
+// FirstByte records the time to first byte when the first console data
+// arrives from the agent. Later calls are ignored.
+func (a *Attempt) FirstByte() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.finished || a.session.FirstByte {
+		return
+	}
+	a.session.FirstByte = true
+	a.session.Established = true
+	a.session.TimeToFirstByte = a.recorder.now().Sub(a.session.StartedAt)
+}
+
+// Fail marks the stream as failed unless console data was already received;
+// errors after the first byte are ordinary disconnects, not establishment
+// failures
+func (a *Attempt) Fail(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.finished || a.session.FirstByte || err == nil {
+		return
+	}
+	a.session.Established = false
+	a.session.Error = err.Error()
+}
+
+// Finish records the measurement. Later calls are ignored.
+func (a *Attempt) Finish() {
+	a.mu.Lock()
+	if a.finished {
+		a.mu.Unlock()
+		return
+	}
+	a.finished = true
+	session := a.session
+	a.mu.Unlock()
+
+	a.recorder.add(session)
+}
@@ -0,0 +1,120 @@
+package sli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a recorder clock that advances by step on every call
+func fakeClock(start time.Time, step time.Duration) func() time.Time {
+	now := start
+	return func() time.Time {
+		current := now
+		now = now.Add(step)
+		return current
+	}
+}
+
+func TestAttempt_Outcomes(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		run      func(a *Attempt)
+		expected Session
+	}{
+		{
+			name: "first byte",
+			run: func(a *Attempt) {
+				a.Established()
+				a.FirstByte()
+				a.FirstByte()
+				a.Fail(errors.New("client disconnected"))
+			},
+			expected: Session{Established: true, FirstByte: true, TimeToFirstByte: 250 * time.Millisecond},
+		},
+		{
+			name:     "established without output",
+			run:      func(a *Attempt) { a.Established() },
+			expected: Session{Established: true},
+		},
+		{
+			name: "failed before first byte",
+			run: func(a *Attempt) {
+				a.Established()
+				a.Fail(errors.New("agent stream receive error"))
+				a.Established()
+			},
+			expected: Session{Error: "agent stream receive error"},
+		},
+		{
+			name:     "never established",
+			run:      func(a *Attempt) {},
+			expected: Session{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecorder(0)
+			r.now = fakeClock(start, 250*time.Millisecond)
+
+			a := r.Start("sol-1", "agent-1", "dc-1", "sol")
+			tt.run(a)
+			a.Finish()
+			a.Finish()
+
+			sessions := r.Drain()
+			require.Len(t, sessions, 1)
+
+			expected := tt.expected
+			expected.SessionID = "sol-1"
+			expected.AgentID = "agent-1"
+			expected.DatacenterID = "dc-1"
+			expected.SessionType = "sol"
+			expected.StartedAt = start
+			assert.Equal(t, expected, sessions[0])
+
+			assert.Empty(t, r.Drain())
+		})
+	}
+}
+
+func TestAttempt_IgnoredAfterFinish(t *testing.T) {
+	r := NewRecorder(0)
+	a := r.Start("sol-1", "agent-1", "dc-1", "sol")
+	a.Finish()
+	a.FirstByte()
+
+	sessions := r.Drain()
+	require.Len(t, sessions, 1)
+	assert.False(t, sessions[0].FirstByte)
+}
+
+func TestRecorder_RequeueAndBound(t *testing.T) {
+	r := NewRecorder(3)
+
+	for _, id := range []string{"a", "b"} {
+		r.Start(id, "agent-1", "dc-1", "sol").Finish()
+	}
+
+	drained := r.Drain()
+	require.Len(t, drained, 2)
+
+	r.Start("c", "agent-1", "dc-1", "sol").Finish()
+	r.Requeue(drained)
+	assert.Equal(t, 3, r.Pending())
+
+	// Exceeding the bound drops the oldest measurements
+	r.Start("d", "agent-1", "dc-1", "vnc").Finish()
+
+	var ids []string
+	for _, s := range r.Drain() {
+		ids = append(ids, s.SessionID)
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, ids)
+}
@@ -0,0 +1,63 @@
+package sli
+
+import "errors"
+
+// errClosedBeforeData is recorded when the agent ends a stream without
+// sending any console data
+var errClosedBeforeData = errors.New("agent closed the stream before sending console data")
+
+// Chunk is the part of a console or VNC data chunk needed for measurements
+type Chunk interface {
+	GetData() []byte
+	GetIsHandshake() bool
+	GetCloseStream() bool
+}
+
+// AgentStream is a bidirectional stream from the gateway to an agent
+type AgentStream[T Chunk] interface {
+	Send(T) error
+	Receive() (T, error)
+	CloseRequest() error
+}
+
+// ObservedStream wraps an agent stream and reports the first console data,
+// and any failure before it, to an Attempt
+type ObservedStream[T Chunk] struct {
+	stream  AgentStream[T]
+	attempt *Attempt
+}
+
+// Observe wraps stream so that receiving from it updates attempt
+func Observe[T Chunk](stream AgentStream[T], attempt *Attempt) *ObservedStream[T] {
+	return &ObservedStream[T]{stream: stream, attempt: attempt}
+}
+
+// Send forwards a chunk to the agent
+func (s *ObservedStream[T]) Send(chunk T) error {
+	return s.stream.Send(chunk)
+}
+
+// Receive reads a chunk from the agent, recording the first data chunk and
+// any error or close before it
+func (s *ObservedStream[T]) Receive() (T, error) {
+	chunk, err := s.stream.Receive()
+	if err != nil {
+		s.attempt.Fail(err)
+		return chunk, err
+	}
+
+	switch {
+	case chunk.GetCloseStream():
+		s.attempt.Fail(errClosedBeforeData)
+	case !chunk.GetIsHandshake() && len(chunk.GetData()) > 0:
+		s.attempt.FirstByte()
+	}
+	return chunk, nil
+}
+
+// CloseRequest finishes the measurement before closing the stream, so that
+// errors caused by the gateway tearing the stream down are not counted
+func (s *ObservedStream[T]) CloseRequest() error {
+	s.attempt.Finish()
+	return s.stream.CloseRequest()
+}
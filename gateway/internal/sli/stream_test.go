@@ -0,0 +1,99 @@
+package sli
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// fakeAgentStream replays chunks, then returns io.EOF
+type fakeAgentStream struct {
+	chunks []*gatewayv1.ConsoleDataChunk
+	err    error
+	closed bool
+}
+
+func (s *fakeAgentStream) Send(*gatewayv1.ConsoleDataChunk) error { return nil }
+
+func (s *fakeAgentStream) Receive() (*gatewayv1.ConsoleDataChunk, error) {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *fakeAgentStream) CloseRequest() error {
+	s.closed = true
+	return nil
+}
+
+func TestObservedStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		stream      *fakeAgentStream
+		receives    int
+		established bool
+		firstByte   bool
+		errText     string
+	}{
+		{
+			name: "data after handshake",
+			stream: &fakeAgentStream{chunks: []*gatewayv1.ConsoleDataChunk{
+				{IsHandshake: true},
+				{Data: []byte("login: ")},
+			}},
+			receives:    3,
+			established: true,
+			firstByte:   true,
+		},
+		{
+			name:     "agent error before data",
+			stream:   &fakeAgentStream{err: errors.New("bmc unreachable")},
+			receives: 1,
+			errText:  "bmc unreachable",
+		},
+		{
+			name: "agent closes before data",
+			stream: &fakeAgentStream{chunks: []*gatewayv1.ConsoleDataChunk{
+				{IsHandshake: true},
+				{CloseStream: true},
+			}},
+			receives: 2,
+			errText:  errClosedBeforeData.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecorder(0)
+			attempt := r.Start("sol-1", "agent-1", "dc-1", "sol")
+			attempt.Established()
+
+			observed := Observe[*gatewayv1.ConsoleDataChunk](tt.stream, attempt)
+			for i := 0; i < tt.receives; i++ {
+				observed.Receive()
+			}
+			require.NoError(t, observed.CloseRequest())
+			assert.True(t, tt.stream.closed)
+
+			// Errors after the stream was closed by the gateway are not counted
+			attempt.Fail(errors.New("late error"))
+
+			sessions := r.Drain()
+			require.Len(t, sessions, 1)
+			assert.Equal(t, tt.established, sessions[0].Established)
+			assert.Equal(t, tt.firstByte, sessions[0].FirstByte)
+			assert.Equal(t, tt.errText, sessions[0].Error)
+		})
+	}
+}
@@ -1,38 +1,148 @@
 package streaming
 
 import (
+	"sync"
+
 	"core/streaming"
 	gatewayv1 "gateway/gen/gateway/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Data chunks released by proxies once sent, reused by the factories
+var (
+	vncChunks     = sync.Pool{New: func() any { return new(gatewayv1.VNCDataChunk) }}
+	consoleChunks = sync.Pool{New: func() any { return new(gatewayv1.ConsoleDataChunk) }}
 )
 
 // VNCChunkFactory creates VNC data chunks for streaming
 type VNCChunkFactory struct{}
 
 func (f *VNCChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *gatewayv1.VNCDataChunk {
+	chunk := vncChunks.Get().(*gatewayv1.VNCDataChunk)
+	chunk.SessionId = sessionID
+	chunk.ServerId = serverID
+	chunk.Data = data
+	chunk.IsHandshake = isHandshake
+	chunk.CloseStream = closeStream
+	return chunk
+}
+
+// NewFragmentChunk creates a chunk carrying a fragment of a split message
+func (f *VNCChunkFactory) NewFragmentChunk(sessionID, serverID string, data []byte, fragment uint32, moreFragments bool) *gatewayv1.VNCDataChunk {
+	chunk := f.NewChunk(sessionID, serverID, data, false, false)
+	chunk.Fragment = fragment
+	chunk.MoreFragments = moreFragments
+	return chunk
+}
+
+// SetChecksum sets the checksum of a chunk's data
+func (f *VNCChunkFactory) SetChecksum(chunk *gatewayv1.VNCDataChunk, checksum uint32) {
+	chunk.Checksum = &checksum
+}
+
+// ChecksumOf returns the checksum of a chunk's data, false when it carries none
+func (f *VNCChunkFactory) ChecksumOf(chunk *gatewayv1.VNCDataChunk) (uint32, bool) {
+	if chunk.Checksum == nil {
+		return 0, false
+	}
+	return *chunk.Checksum, true
+}
+
+// NewPingChunk creates a keepalive ping
+func (f *VNCChunkFactory) NewPingChunk(sessionID, serverID string) *gatewayv1.VNCDataChunk {
+	return &gatewayv1.VNCDataChunk{
+		SessionId: sessionID,
+		ServerId:  serverID,
+		Ping:      true,
+	}
+}
+
+// ReleaseChunk returns a sent chunk to the pool of NewChunk
+func (f *VNCChunkFactory) ReleaseChunk(chunk *gatewayv1.VNCDataChunk) {
+	proto.Reset(chunk)
+	vncChunks.Put(chunk)
+}
+
+func (f *VNCChunkFactory) NewHandshakeChunk(sessionID, serverID string, metadata map[string]string) *gatewayv1.VNCDataChunk {
 	return &gatewayv1.VNCDataChunk{
 		SessionId:   sessionID,
 		ServerId:    serverID,
-		Data:        data,
-		IsHandshake: isHandshake,
-		CloseStream: closeStream,
+		IsHandshake: true,
+		Metadata:    metadata,
 	}
 }
 
-// Ensure VNCDataChunk implements StreamChunk interface
-var _ streaming.StreamChunk = (*gatewayv1.VNCDataChunk)(nil)
+// Ensure VNCDataChunk implements StreamChunk, ErrorChunk, MetadataChunk, FragmentChunk and PingChunk interfaces
+var (
+	_ streaming.StreamChunk                                   = (*gatewayv1.VNCDataChunk)(nil)
+	_ streaming.ErrorChunk                                    = (*gatewayv1.VNCDataChunk)(nil)
+	_ streaming.MetadataChunk                                 = (*gatewayv1.VNCDataChunk)(nil)
+	_ streaming.FragmentChunk                                 = (*gatewayv1.VNCDataChunk)(nil)
+	_ streaming.ChunkReleaser[*gatewayv1.VNCDataChunk]        = (*VNCChunkFactory)(nil)
+	_ streaming.FragmentChunkFactory[*gatewayv1.VNCDataChunk] = (*VNCChunkFactory)(nil)
+	_ streaming.ChecksumChunkFactory[*gatewayv1.VNCDataChunk] = (*VNCChunkFactory)(nil)
+	_ streaming.PingChunkFactory[*gatewayv1.VNCDataChunk]     = (*VNCChunkFactory)(nil)
+	_ streaming.PingChunk                                     = (*gatewayv1.VNCDataChunk)(nil)
+)
 
 // ConsoleChunkFactory creates console data chunks for streaming
 type ConsoleChunkFactory struct{}
 
 func (f *ConsoleChunkFactory) NewChunk(sessionID, serverID string, data []byte, isHandshake, closeStream bool) *gatewayv1.ConsoleDataChunk {
+	chunk := consoleChunks.Get().(*gatewayv1.ConsoleDataChunk)
+	chunk.SessionId = sessionID
+	chunk.ServerId = serverID
+	chunk.Data = data
+	chunk.IsHandshake = isHandshake
+	chunk.CloseStream = closeStream
+	return chunk
+}
+
+// SetChecksum sets the checksum of a chunk's data
+func (f *ConsoleChunkFactory) SetChecksum(chunk *gatewayv1.ConsoleDataChunk, checksum uint32) {
+	chunk.Checksum = &checksum
+}
+
+// ChecksumOf returns the checksum of a chunk's data, false when it carries none
+func (f *ConsoleChunkFactory) ChecksumOf(chunk *gatewayv1.ConsoleDataChunk) (uint32, bool) {
+	if chunk.Checksum == nil {
+		return 0, false
+	}
+	return *chunk.Checksum, true
+}
+
+// NewPingChunk creates a keepalive ping
+func (f *ConsoleChunkFactory) NewPingChunk(sessionID, serverID string) *gatewayv1.ConsoleDataChunk {
+	return &gatewayv1.ConsoleDataChunk{
+		SessionId: sessionID,
+		ServerId:  serverID,
+		Ping:      true,
+	}
+}
+
+// ReleaseChunk returns a sent chunk to the pool of NewChunk
+func (f *ConsoleChunkFactory) ReleaseChunk(chunk *gatewayv1.ConsoleDataChunk) {
+	proto.Reset(chunk)
+	consoleChunks.Put(chunk)
+}
+
+func (f *ConsoleChunkFactory) NewHandshakeChunk(sessionID, serverID string, metadata map[string]string) *gatewayv1.ConsoleDataChunk {
 	return &gatewayv1.ConsoleDataChunk{
 		SessionId:   sessionID,
 		ServerId:    serverID,
-		Data:        data,
-		IsHandshake: isHandshake,
-		CloseStream: closeStream,
+		IsHandshake: true,
+		Metadata:    metadata,
 	}
 }
 
-// Ensure ConsoleDataChunk implements StreamChunk interface
-var _ streaming.StreamChunk = (*gatewayv1.ConsoleDataChunk)(nil)
+// Ensure ConsoleDataChunk implements StreamChunk, ErrorChunk, MetadataChunk and PingChunk interfaces
+var (
+	_ streaming.StreamChunk                                       = (*gatewayv1.ConsoleDataChunk)(nil)
+	_ streaming.ErrorChunk                                        = (*gatewayv1.ConsoleDataChunk)(nil)
+	_ streaming.MetadataChunk                                     = (*gatewayv1.ConsoleDataChunk)(nil)
+	_ streaming.ChunkReleaser[*gatewayv1.ConsoleDataChunk]        = (*ConsoleChunkFactory)(nil)
+	_ streaming.ChecksumChunkFactory[*gatewayv1.ConsoleDataChunk] = (*ConsoleChunkFactory)(nil)
+	_ streaming.PingChunkFactory[*gatewayv1.ConsoleDataChunk]     = (*ConsoleChunkFactory)(nil)
+	_ streaming.PingChunk                                         = (*gatewayv1.ConsoleDataChunk)(nil)
+)
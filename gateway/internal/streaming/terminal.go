@@ -0,0 +1,68 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"core/streaming"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// Control messages of terminal WebSocket clients, sent as JSON text messages
+const (
+	// TerminalResizeMessage is sent by the client when its terminal is resized
+	TerminalResizeMessage = "resize"
+	// TerminalHintsMessage tells the client the terminal the server's console
+	// is rendered for, from the agent's handshake acknowledgment
+	TerminalHintsMessage = "terminal"
+)
+
+// TerminalMessage is a control message of a terminal WebSocket client
+type TerminalMessage struct {
+	Type string `json:"type"`
+	Term string `json:"term,omitempty"`
+	Cols int    `json:"cols,omitempty"`
+	Rows int    `json:"rows,omitempty"`
+}
+
+// TerminalControl translates the control messages of the web console: resize
+// messages become resize chunks, and the terminal hints of the agent's
+// handshake acknowledgment are told to the client
+type TerminalControl struct{}
+
+// ControlChunk returns the resize chunk of a resize message
+func (c *TerminalControl) ControlChunk(sessionID, serverID string, message []byte) (*gatewayv1.ConsoleDataChunk, error) {
+	var msg TerminalMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return nil, fmt.Errorf("invalid control message: %w", err)
+	}
+	if msg.Type != TerminalResizeMessage {
+		return nil, fmt.Errorf("unknown control message %q", msg.Type)
+	}
+
+	size := streaming.TerminalHints{Cols: msg.Cols, Rows: msg.Rows}
+	if err := size.Validate(); err != nil || !size.HasSize() {
+		return nil, fmt.Errorf("invalid terminal size %dx%d", msg.Cols, msg.Rows)
+	}
+	return &gatewayv1.ConsoleDataChunk{
+		SessionId: sessionID,
+		ServerId:  serverID,
+		Resize:    &gatewayv1.TerminalSize{Cols: uint32(msg.Cols), Rows: uint32(msg.Rows)},
+	}, nil
+}
+
+// HandshakeMessage returns the terminal message of the hints carried by the
+// agent's acknowledgment, nil when it carries none
+func (c *TerminalControl) HandshakeMessage(ack *gatewayv1.ConsoleDataChunk) []byte {
+	hints, err := streaming.TerminalHintsOf(ack.GetMetadata())
+	if err != nil || hints.IsZero() {
+		return nil
+	}
+	message, err := json.Marshal(TerminalMessage{Type: TerminalHintsMessage, Term: hints.Type, Cols: hints.Cols, Rows: hints.Rows})
+	if err != nil {
+		return nil
+	}
+	return message
+}
+
+var _ streaming.ControlHandler[*gatewayv1.ConsoleDataChunk] = (*TerminalControl)(nil)
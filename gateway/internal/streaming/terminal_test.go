@@ -0,0 +1,57 @@
+package streaming
+
+import (
+	"encoding/json"
+	"testing"
+
+	"core/streaming"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+func TestTerminalControlResize(t *testing.T) {
+	control := &TerminalControl{}
+
+	chunk, err := control.ControlChunk("sol-1", "server-1", []byte(`{"type":"resize","cols":132,"rows":43}`))
+	if err != nil {
+		t.Fatalf("ControlChunk failed: %v", err)
+	}
+	if chunk.SessionId != "sol-1" || chunk.ServerId != "server-1" {
+		t.Errorf("Expected the chunk of the stream's session, got %s/%s", chunk.SessionId, chunk.ServerId)
+	}
+	if chunk.Resize.GetCols() != 132 || chunk.Resize.GetRows() != 43 || len(chunk.Data) != 0 {
+		t.Errorf("Expected a 132x43 resize chunk without data, got %v", chunk)
+	}
+
+	for _, message := range []string{
+		`resize`,
+		`{"type":"paste","cols":80,"rows":24}`,
+		`{"type":"resize","cols":80}`,
+		`{"type":"resize","cols":80,"rows":5000}`,
+	} {
+		if _, err := control.ControlChunk("sol-1", "server-1", []byte(message)); err == nil {
+			t.Errorf("Expected %s to be rejected", message)
+		}
+	}
+}
+
+func TestTerminalControlHandshakeMessage(t *testing.T) {
+	control := &TerminalControl{}
+
+	ack := &gatewayv1.ConsoleDataChunk{
+		IsHandshake: true,
+		Metadata:    streaming.TerminalHints{Type: "vt100", Cols: 80, Rows: 24}.AddTo(nil),
+	}
+	var msg TerminalMessage
+	if err := json.Unmarshal(control.HandshakeMessage(ack), &msg); err != nil {
+		t.Fatalf("Expected a JSON terminal message: %v", err)
+	}
+	expected := TerminalMessage{Type: TerminalHintsMessage, Term: "vt100", Cols: 80, Rows: 24}
+	if msg != expected {
+		t.Errorf("Expected %+v, got %+v", expected, msg)
+	}
+
+	// Agents predating terminal hints acknowledge without them
+	if message := control.HandshakeMessage(&gatewayv1.ConsoleDataChunk{IsHandshake: true}); message != nil {
+		t.Errorf("Expected no message without hints, got %s", message)
+	}
+}
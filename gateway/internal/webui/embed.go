@@ -3,30 +3,61 @@ package webui
 import (
 	"embed"
 	"html/template"
+	"io/fs"
+
+	"core/i18n"
 )
 
 //go:embed templates/*
 var embedFS embed.FS
 
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// messages holds the translations of the pages
+var messages *i18n.Bundle
+
 var templates *template.Template
 var vncTemplates *template.Template
 var consoleTemplates *template.Template
+var portalTemplates *template.Template
+var portalLoginTemplates *template.Template
 
 func init() {
 	var err error
 
+	locales, err := fs.Sub(localeFS, "locales")
+	if err != nil {
+		panic("Failed to open locales: " + err.Error())
+	}
+	messages, err = i18n.NewBundle(locales)
+	if err != nil {
+		panic("Failed to load messages: " + err.Error())
+	}
+
 	// Parse VNC templates separately (base.html + vnc.html)
-	vncTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/vnc.html", "templates/bmc_info_sidebar.html", "templates/boot_status_sidebar.html")
+	vncTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/vnc.html", "templates/bmc_info_sidebar.html", "templates/boot_status_sidebar.html", "templates/power_controls_sidebar.html")
 	if err != nil {
 		panic("Failed to parse VNC templates: " + err.Error())
 	}
 
 	// Parse Console templates separately (base.html + console.html)
-	consoleTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/console.html", "templates/bmc_info_sidebar.html", "templates/boot_status_sidebar.html")
+	consoleTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/console.html", "templates/bmc_info_sidebar.html", "templates/boot_status_sidebar.html", "templates/power_controls_sidebar.html")
 	if err != nil {
 		panic("Failed to parse Console templates: " + err.Error())
 	}
 
+	// Parse customer portal templates separately (base.html + portal.html)
+	portalTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/portal.html")
+	if err != nil {
+		panic("Failed to parse portal templates: " + err.Error())
+	}
+
+	portalLoginTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/portal_login.html")
+	if err != nil {
+		panic("Failed to parse portal login templates: " + err.Error())
+	}
+
 	// Keep old templates variable for backwards compatibility
 	templates = consoleTemplates
 }
@@ -0,0 +1,27 @@
+package webui
+
+import (
+	"net/http"
+
+	"core/i18n"
+)
+
+// Languages returns the languages the pages are translated to
+func Languages() []string {
+	return messages.Languages()
+}
+
+// Printer returns the printer of the page messages in lang
+func Printer(lang string) *i18n.Printer {
+	return messages.Printer(lang)
+}
+
+// RequestLanguage returns the language of a page, the one preferred in the
+// Accept-Language header of r, or else defaultLanguage when the pages are
+// translated to it, or else English
+func RequestLanguage(r *http.Request, defaultLanguage string) string {
+	if lang := messages.MatchAcceptLanguage(r.Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	return messages.Match(defaultLanguage)
+}
@@ -0,0 +1,150 @@
+package webui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMessagesComplete ensures every page message is translated to every
+// language, with the same arguments
+func TestMessagesComplete(t *testing.T) {
+	english := Printer("en").Messages("")
+	for _, lang := range Languages() {
+		if missing := messages.Missing(lang); len(missing) > 0 {
+			t.Errorf("Messages missing in %q: %v", lang, missing)
+		}
+		for key, message := range Printer(lang).Messages("") {
+			if verbs(message) != verbs(english[key]) {
+				t.Errorf("Message %q in %q has verbs %q, expected %q", key, lang, verbs(message), verbs(english[key]))
+			}
+		}
+	}
+}
+
+// verbs returns the formatting verbs of message, in order
+func verbs(message string) string {
+	var out []string
+	for i := 0; i < len(message)-1; i++ {
+		if message[i] == '%' {
+			out = append(out, message[i:i+2])
+			i++
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// TestRequestLanguage ensures the Accept-Language header wins over the
+// configured default, and pages default to English
+func TestRequestLanguage(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		defaultLanguage string
+		expected        string
+	}{
+		{name: "default", expected: "en"},
+		{name: "configured default", defaultLanguage: "ja", expected: "ja"},
+		{name: "unknown configured default", defaultLanguage: "fr", expected: "en"},
+		{name: "header", acceptLanguage: "ja-JP,ja;q=0.9,en;q=0.8", expected: "ja"},
+		{name: "header over default", acceptLanguage: "en-US", defaultLanguage: "ja", expected: "en"},
+		{name: "quality", acceptLanguage: "en;q=0.5, ja;q=0.8", expected: "ja"},
+		{name: "untranslated header", acceptLanguage: "fr-FR, de", defaultLanguage: "ja", expected: "ja"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if lang := RequestLanguage(req, tt.defaultLanguage); lang != tt.expected {
+				t.Errorf("Expected language %q, got %q", tt.expected, lang)
+			}
+		})
+	}
+}
+
+// TestViewerTranslation ensures the viewers render their labels and script
+// messages in the language of the page
+func TestViewerTranslation(t *testing.T) {
+	templateData := TemplateData{
+		Title:         "Test",
+		IconText:      "BMC",
+		HeaderTitle:   "Test",
+		InitialStatus: "Connecting",
+		ServerID:      "test-server-1",
+		Theme:         ThemeDark,
+		Lang:          "ja",
+	}
+
+	renders := map[string]func() (io.Reader, error){
+		"VNC": func() (io.Reader, error) {
+			return RenderVNC(VNCData{TemplateData: templateData, SessionID: "vnc-session"})
+		},
+		"Console": func() (io.Reader, error) {
+			return RenderConsole(ConsoleData{TemplateData: templateData, SessionID: "sol-session"})
+		},
+	}
+
+	for name, render := range renders {
+		t.Run(name, func(t *testing.T) {
+			reader, err := render()
+			if err != nil {
+				t.Fatalf("Failed to render %s template: %v", name, err)
+			}
+			contentBytes, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Failed to read rendered %s template: %v", name, err)
+			}
+			content := string(contentBytes)
+
+			for _, element := range []string{
+				`<html lang="ja"`,
+				`メインコンテンツへスキップ`,
+				`aria-label="test-server-1 の電源操作"`,
+				`aria-label="test-server-1 の電源をオン"`,
+				`const MESSAGES = {`,
+				`"js.power.unknown":"不明"`,
+			} {
+				if !strings.Contains(content, element) {
+					t.Errorf("%s template missing %s", name, element)
+				}
+			}
+			if strings.Contains(content, "Power operations for") {
+				t.Errorf("%s template contains untranslated power labels", name)
+			}
+		})
+	}
+}
+
+// TestPortalLanguage ensures the portal pages follow Accept-Language, then
+// the configured default language
+func TestPortalLanguage(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		defaultLanguage string
+		expected        string
+	}{
+		{name: "english", expected: "Sign In - BMC Portal"},
+		{name: "configured default", defaultLanguage: "ja", expected: "サインイン - BMC ポータル"},
+		{name: "header", acceptLanguage: "ja-JP", expected: "サインイン - BMC ポータル"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, PortalLoginPath, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			NewPortalLoginHandler("https://manager.example.com", tt.defaultLanguage).ServeHTTP(rec, req)
+			if content := rec.Body.String(); !strings.Contains(content, tt.expected) {
+				t.Errorf("Expected login page titled %q", tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package webui
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"manager/pkg/auth"
+	"manager/pkg/models"
+)
+
+// PortalCookieName is the cookie holding the customer token of the portal,
+// set by the login page
+const PortalCookieName = "portal_token"
+
+// Paths of the customer portal
+const (
+	PortalPath       = "/portal"
+	PortalLoginPath  = "/portal/login"
+	PortalLogoutPath = "/portal/logout"
+)
+
+// PortalHandler serves the customer portal, where customers list their
+// servers and console sessions, check power states and open consoles
+type PortalHandler struct {
+	jwtManager      *auth.JWTManager
+	managerURL      string
+	defaultLanguage string
+}
+
+// NewPortalHandler creates a customer portal handler calling the manager at
+// managerURL, in defaultLanguage for browsers preferring no translated
+// language
+func NewPortalHandler(jwtManager *auth.JWTManager, managerURL, defaultLanguage string) *PortalHandler {
+	return &PortalHandler{
+		jwtManager:      jwtManager,
+		managerURL:      managerURL,
+		defaultLanguage: defaultLanguage,
+	}
+}
+
+// ServeHTTP handles requests to /portal. Customers without a valid token are
+// redirected to the login page.
+func (h *PortalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, token, ok := h.authenticate(r)
+	if !ok {
+		http.Redirect(w, r, PortalLoginPath, http.StatusSeeOther)
+		return
+	}
+
+	lang := RequestLanguage(r, h.defaultLanguage)
+	p := Printer(lang)
+	data := PortalData{
+		TemplateData: TemplateData{
+			Title:         p.Sprintf("portal.title"),
+			IconText:      "BMC",
+			HeaderTitle:   p.Sprintf("portal.header"),
+			InitialStatus: p.Sprintf("js.status.loading"),
+			Theme:         RequestTheme(r),
+			Lang:          lang,
+		},
+		CustomerEmail: claims.Email,
+		Token:         token,
+		ManagerURL:    h.managerURL,
+	}
+
+	reader, err := RenderPortal(data)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render portal template")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	serveHTML(w, reader)
+}
+
+// authenticate validates the customer token of a request, from the
+// Authorization header or the portal cookie
+func (h *PortalHandler) authenticate(r *http.Request) (*models.AuthClaims, string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		cookie, err := r.Cookie(PortalCookieName)
+		if err != nil || cookie.Value == "" {
+			return nil, "", false
+		}
+		token = cookie.Value
+	}
+
+	claims, err := h.jwtManager.ValidateToken(token)
+	if err != nil {
+		log.Debug().Err(err).Msg("Rejected portal token")
+		return nil, "", false
+	}
+	return claims, token, true
+}
+
+// PortalLoginHandler serves the login page of the customer portal
+type PortalLoginHandler struct {
+	managerURL      string
+	defaultLanguage string
+}
+
+// NewPortalLoginHandler creates a login page handler authenticating
+// customers with the manager at managerURL, in defaultLanguage for browsers
+// preferring no translated language
+func NewPortalLoginHandler(managerURL, defaultLanguage string) *PortalLoginHandler {
+	return &PortalLoginHandler{managerURL: managerURL, defaultLanguage: defaultLanguage}
+}
+
+// ServeHTTP handles requests to /portal/login
+func (h *PortalLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	lang := RequestLanguage(r, h.defaultLanguage)
+	p := Printer(lang)
+	data := PortalData{
+		TemplateData: TemplateData{
+			Title:         p.Sprintf("portal_login.title"),
+			IconText:      "BMC",
+			HeaderTitle:   p.Sprintf("portal_login.header"),
+			InitialStatus: p.Sprintf("js.status.signed_out"),
+			Theme:         RequestTheme(r),
+			Lang:          lang,
+		},
+		ManagerURL: h.managerURL,
+	}
+
+	reader, err := RenderPortalLogin(data)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render portal login template")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	serveHTML(w, reader)
+}
+
+// PortalLogoutHandler clears the portal cookie
+type PortalLogoutHandler struct{}
+
+// NewPortalLogoutHandler creates a portal logout handler
+func NewPortalLogoutHandler() *PortalLogoutHandler {
+	return &PortalLogoutHandler{}
+}
+
+// ServeHTTP handles requests to /portal/logout
+func (h *PortalLogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     PortalCookieName,
+		Value:    "",
+		Path:     PortalPath,
+		MaxAge:   -1,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, PortalLoginPath, http.StatusSeeOther)
+}
+
+// serveHTML writes a rendered page
+func serveHTML(w http.ResponseWriter, reader io.Reader) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, reader)
+}
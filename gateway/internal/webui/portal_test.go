@@ -0,0 +1,96 @@
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"manager/pkg/auth"
+	"manager/pkg/models"
+)
+
+// TestPortalHandler ensures the portal is rendered for signed-in customers
+// and redirects anyone else to the login page
+func TestPortalHandler(t *testing.T) {
+	jwtManager := auth.NewJWTManager("test-secret-key-for-customer-portal")
+	handler := NewPortalHandler(jwtManager, "https://manager.example.com", "")
+
+	token, err := jwtManager.GenerateToken(&models.Customer{ID: "customer-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to generate customer token: %v", err)
+	}
+	otherToken, err := auth.NewJWTManager("another-secret-key").GenerateToken(&models.Customer{ID: "customer-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+		status int
+	}{
+		{name: "cookie", cookie: token, status: http.StatusOK},
+		{name: "authorization header", header: "Bearer " + token, status: http.StatusOK},
+		{name: "invalid token", cookie: otherToken, status: http.StatusSeeOther},
+		{name: "anonymous", status: http.StatusSeeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, PortalPath, nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: PortalCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				if location := rec.Header().Get("Location"); location != PortalLoginPath {
+					t.Errorf("Expected redirect to %s, got %q", PortalLoginPath, location)
+				}
+				return
+			}
+
+			content := rec.Body.String()
+			for _, element := range []string{"user@example.com", "https://manager.example.com", "ListServers", "GetPowerStatus", "CreateSOLSession", "CreateVNCSession", "ListConsoleSessions"} {
+				if !strings.Contains(content, element) {
+					t.Errorf("Rendered portal missing expected element: %s", element)
+				}
+			}
+		})
+	}
+}
+
+// TestPortalLoginAndLogout ensures the login page authenticates with the
+// manager and logging out clears the portal cookie
+func TestPortalLoginAndLogout(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewPortalLoginHandler("https://manager.example.com", "").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PortalLoginPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected login page, got status %d", rec.Code)
+	}
+	content := rec.Body.String()
+	for _, element := range []string{"https://manager.example.com", "Authenticate", PortalCookieName} {
+		if !strings.Contains(content, element) {
+			t.Errorf("Rendered login page missing expected element: %s", element)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	NewPortalLogoutHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PortalLogoutPath, nil))
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != PortalLoginPath {
+		t.Fatalf("Expected redirect to the login page, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != PortalCookieName || cookies[0].MaxAge >= 0 {
+		t.Errorf("Expected the portal cookie to be cleared, got %v", cookies)
+	}
+}
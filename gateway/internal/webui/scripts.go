@@ -0,0 +1,23 @@
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// ScriptsPath serves the scripts of the pages that are modules of their own,
+// such as the console stream client at /webui/scripts/console-stream.js
+const ScriptsPath = "/webui/scripts/"
+
+//go:embed scripts/*.js
+var scriptFS embed.FS
+
+// NewScriptsHandler serves the scripts under ScriptsPath
+func NewScriptsHandler() http.Handler {
+	scripts, err := fs.Sub(scriptFS, "scripts")
+	if err != nil {
+		panic("Failed to open scripts: " + err.Error())
+	}
+	return http.StripPrefix(ScriptsPath, http.FileServerFS(scripts))
+}
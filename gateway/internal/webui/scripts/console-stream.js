@@ -0,0 +1,399 @@
+// @ts-check
+/**
+ * Console streams of the gateway for browsers, over the Connect protocol.
+ *
+ * Browsers cannot stream request bodies, so a console stream is a
+ * WatchConsoleData call streaming the console's chunks, and SendConsoleData
+ * calls sending the browser's chunks to it. Both carry the ConsoleDataChunk
+ * messages of gateway.v1 that the CLI streams, in their JSON mapping.
+ *
+ * The module has no dependencies. The JSDoc types let TypeScript check its
+ * users with checkJs, or with a declaration file generated by tsc.
+ *
+ * @example
+ * import { ConsoleStream } from '/webui/scripts/console-stream.js';
+ *
+ * const stream = new ConsoleStream({
+ *     headers: { 'X-CSRF-Token': csrfToken },
+ *     onData: data => term.write(data),
+ * });
+ * await stream.open({ sessionId, serverId });
+ * term.onData(data => stream.send(data));
+ * await stream.closed;
+ *
+ * @module console-stream
+ */
+
+/**
+ * TerminalSize of gateway.v1: the size of the client terminal in character
+ * cells
+ * @typedef {Object} TerminalSize
+ * @property {number} cols
+ * @property {number} rows
+ */
+
+/**
+ * ConsoleDataChunk of gateway.v1, in the protobuf JSON mapping. Fields at
+ * their default value are omitted.
+ * @typedef {Object} ConsoleDataChunk
+ * @property {string} [sessionId]
+ * @property {string} [serverId]
+ * @property {string} [data] Console data, base64 encoded
+ * @property {boolean} [isHandshake]
+ * @property {boolean} [closeStream]
+ * @property {TerminalSize} [resize]
+ * @property {boolean} [forceTakeover]
+ * @property {string} [errorCode] See core/streaming ErrorCode
+ * @property {string} [errorMessage]
+ * @property {Record<string, string>} [metadata]
+ * @property {string} [streamId]
+ */
+
+/**
+ * The handshake opening a console stream
+ * @typedef {Object} ConsoleHandshake
+ * @property {string} sessionId SOL session created with CreateSOLSession
+ * @property {string} [serverId]
+ * @property {boolean} [forceTakeover] Take over the console from the session holding it
+ * @property {string} [terminalType] TERM type of the browser's terminal, e.g. "xterm-256color"
+ * @property {number} [cols]
+ * @property {number} [rows]
+ */
+
+/**
+ * @typedef {Object} ConsoleStreamOptions
+ * @property {string} [baseUrl] URL of the gateway, by default the page's origin
+ * @property {Record<string, string>} [headers] Headers of every call, such as X-CSRF-Token
+ * @property {(data: Uint8Array) => void} onData Receives the console output
+ * @property {(chunk: ConsoleDataChunk) => void} [onChunk] Receives the other chunks,
+ *     such as the agent's close chunk
+ */
+
+const SERVICE_PATH = '/gateway.v1.GatewayService/';
+
+// Flags of Connect stream envelopes
+const FLAG_END_STREAM = 0x02;
+
+const textEncoder = new TextEncoder();
+const textDecoder = new TextDecoder();
+
+/** An error of a Connect call, with its Connect code such as "not_found" */
+export class ConnectError extends Error {
+    /**
+     * @param {string} code
+     * @param {string} message
+     */
+    constructor(code, message) {
+        super(message ? `[${code}] ${message}` : `[${code}]`);
+        this.name = 'ConnectError';
+        this.code = code;
+        this.rawMessage = message;
+    }
+}
+
+/**
+ * @param {Uint8Array} bytes
+ * @returns {string}
+ */
+function toBase64(bytes) {
+    let binary = '';
+    for (let i = 0; i < bytes.length; i += 0x8000) {
+        binary += String.fromCharCode(...bytes.subarray(i, i + 0x8000));
+    }
+    return btoa(binary);
+}
+
+/**
+ * @param {string} base64
+ * @returns {Uint8Array}
+ */
+function fromBase64(base64) {
+    const binary = atob(base64);
+    const bytes = new Uint8Array(binary.length);
+    for (let i = 0; i < binary.length; i++) {
+        bytes[i] = binary.charCodeAt(i);
+    }
+    return bytes;
+}
+
+/**
+ * Encodes a message in a Connect stream envelope
+ * @param {unknown} message
+ * @returns {Uint8Array}
+ */
+function envelope(message) {
+    const json = textEncoder.encode(JSON.stringify(message));
+    const out = new Uint8Array(5 + json.length);
+    new DataView(out.buffer).setUint32(1, json.length);
+    out.set(json, 5);
+    return out;
+}
+
+/**
+ * Reads the envelopes of a Connect streaming response
+ * @param {ReadableStream<Uint8Array>} body
+ * @returns {AsyncGenerator<{flags: number, message: any}>}
+ */
+async function* readEnvelopes(body) {
+    const reader = body.getReader();
+    let buffer = new Uint8Array(0);
+    try {
+        for (;;) {
+            while (buffer.length >= 5) {
+                const length = new DataView(buffer.buffer, buffer.byteOffset).getUint32(1);
+                if (buffer.length < 5 + length) {
+                    break;
+                }
+                const flags = buffer[0];
+                const message = JSON.parse(textDecoder.decode(buffer.subarray(5, 5 + length)));
+                buffer = buffer.subarray(5 + length);
+                yield { flags, message };
+            }
+
+            const { done, value } = await reader.read();
+            if (done) {
+                if (buffer.length > 0) {
+                    throw new ConnectError('data_loss', 'truncated stream');
+                }
+                return;
+            }
+            const next = new Uint8Array(buffer.length + value.length);
+            next.set(buffer);
+            next.set(value, buffer.length);
+            buffer = next;
+        }
+    } finally {
+        reader.releaseLock();
+    }
+}
+
+/**
+ * Returns the error of a failed Connect response
+ * @param {Response} response
+ * @returns {Promise<ConnectError>}
+ */
+async function responseError(response) {
+    try {
+        const error = await response.json();
+        return new ConnectError(error.code || 'unknown', error.message || '');
+    } catch (e) {
+        return new ConnectError('unknown', `HTTP ${response.status}`);
+    }
+}
+
+/**
+ * A console stream of the gateway, following the CLI's chunk protocol
+ */
+export class ConsoleStream {
+    /** @param {ConsoleStreamOptions} options */
+    constructor(options) {
+        this.baseUrl = (options.baseUrl || window.location.origin).replace(/\/$/, '');
+        this.headers = options.headers || {};
+        this.onData = options.onData;
+        this.onChunk = options.onChunk || (() => {});
+
+        this.sessionId = '';
+        /** Stream ID given by the handshake ack */
+        this.streamId = '';
+        /** Terminal the agent renders the console for, from the handshake ack */
+        this.terminal = /** @type {Record<string, string>} */ ({});
+
+        /** @type {ConsoleDataChunk[]} */
+        this.queue = [];
+        this.sending = false;
+        this.aborter = new AbortController();
+
+        /**
+         * Settles when the stream ends, rejecting with a ConnectError when it
+         * failed
+         * @type {Promise<void>}
+         */
+        this.closed = new Promise((resolve, reject) => {
+            this.resolveClosed = resolve;
+            this.rejectClosed = reject;
+        });
+        // Failures are reported to the callers of open or awaiting closed
+        this.closed.catch(() => {});
+    }
+
+    /**
+     * Opens the stream, resolving once the agent connected to the console
+     * @param {ConsoleHandshake} handshake
+     * @returns {Promise<void>}
+     */
+    async open(handshake) {
+        /** @type {ConsoleDataChunk} */
+        const chunk = {
+            sessionId: handshake.sessionId,
+            serverId: handshake.serverId || '',
+            isHandshake: true,
+            forceTakeover: Boolean(handshake.forceTakeover),
+        };
+        const metadata = /** @type {Record<string, string>} */ ({});
+        if (handshake.terminalType) {
+            metadata['terminal-type'] = handshake.terminalType;
+        }
+        if (handshake.cols && handshake.rows) {
+            metadata['terminal-cols'] = String(handshake.cols);
+            metadata['terminal-rows'] = String(handshake.rows);
+        }
+        if (Object.keys(metadata).length > 0) {
+            chunk.metadata = metadata;
+        }
+        this.sessionId = handshake.sessionId;
+
+        const response = await fetch(this.baseUrl + SERVICE_PATH + 'WatchConsoleData', {
+            method: 'POST',
+            credentials: 'include',
+            headers: {
+                ...this.headers,
+                'Content-Type': 'application/connect+json',
+                'Connect-Protocol-Version': '1',
+            },
+            body: envelope(chunk),
+            signal: this.aborter.signal,
+        });
+        if (!response.ok || !response.body) {
+            const error = await responseError(response);
+            this.rejectClosed(error);
+            throw error;
+        }
+
+        const envelopes = readEnvelopes(response.body);
+        /** @type {ConsoleDataChunk | undefined} */
+        let ack;
+        try {
+            ack = await this.next(envelopes);
+        } catch (error) {
+            this.rejectClosed(error);
+            throw error;
+        }
+        if (!ack || !ack.isHandshake) {
+            const error = new ConnectError('internal', 'expected a handshake ack');
+            this.rejectClosed(error);
+            throw error;
+        }
+        this.streamId = ack.streamId || '';
+        this.terminal = ack.metadata || {};
+
+        this.receive(envelopes);
+    }
+
+    /**
+     * Reads the next chunk of the stream, undefined at its end
+     * @param {AsyncGenerator<{flags: number, message: any}>} envelopes
+     * @returns {Promise<ConsoleDataChunk | undefined>}
+     */
+    async next(envelopes) {
+        const { done, value } = await envelopes.next();
+        if (done) {
+            throw new ConnectError('internal', 'stream ended without end-stream message');
+        }
+        if (value.flags & FLAG_END_STREAM) {
+            if (value.message.error) {
+                throw new ConnectError(value.message.error.code, value.message.error.message || '');
+            }
+            return undefined;
+        }
+        return value.message;
+    }
+
+    /**
+     * Dispatches the chunks of the stream until it ends
+     * @param {AsyncGenerator<{flags: number, message: any}>} envelopes
+     */
+    async receive(envelopes) {
+        try {
+            for (;;) {
+                const chunk = await this.next(envelopes);
+                if (!chunk) {
+                    break;
+                }
+                if (chunk.data) {
+                    this.onData(fromBase64(chunk.data));
+                }
+                if (!chunk.data || chunk.closeStream || chunk.errorCode) {
+                    this.onChunk(chunk);
+                }
+            }
+            this.resolveClosed();
+        } catch (error) {
+            if (this.aborter.signal.aborted) {
+                this.resolveClosed();
+            } else {
+                this.rejectClosed(error);
+            }
+        }
+    }
+
+    /**
+     * Sends input to the console
+     * @param {Uint8Array | string} data
+     */
+    send(data) {
+        const bytes = typeof data === 'string' ? textEncoder.encode(data) : data;
+        this.enqueue({ data: toBase64(bytes) });
+    }
+
+    /**
+     * Tells the agent that the terminal was resized
+     * @param {number} cols
+     * @param {number} rows
+     */
+    resize(cols, rows) {
+        this.enqueue({ resize: { cols, rows } });
+    }
+
+    /** Closes the stream, ending the console connection of the agent */
+    close() {
+        this.enqueue({ closeStream: true });
+    }
+
+    /** Abandons the stream without telling the agent, e.g. when leaving the page */
+    abort() {
+        this.aborter.abort();
+    }
+
+    /**
+     * Queues a chunk, sent in order with the chunks queued before it
+     * @param {ConsoleDataChunk} chunk
+     */
+    enqueue(chunk) {
+        if (!this.streamId) {
+            throw new ConnectError('failed_precondition', 'console stream not open');
+        }
+        this.queue.push({ sessionId: this.sessionId, ...chunk });
+        if (!this.sending) {
+            this.flush();
+        }
+    }
+
+    /** Sends the queued chunks, one SendConsoleData call at a time */
+    async flush() {
+        this.sending = true;
+        try {
+            while (this.queue.length > 0) {
+                const chunks = this.queue.splice(0, this.queue.length);
+                const response = await fetch(this.baseUrl + SERVICE_PATH + 'SendConsoleData', {
+                    method: 'POST',
+                    credentials: 'include',
+                    headers: {
+                        ...this.headers,
+                        'Content-Type': 'application/json',
+                        'Connect-Protocol-Version': '1',
+                    },
+                    body: JSON.stringify({ streamId: this.streamId, chunks }),
+                });
+                if (!response.ok) {
+                    throw await responseError(response);
+                }
+            }
+        } catch (error) {
+            // Input cannot be sent, e.g. once the stream ended
+            this.queue = [];
+            console.warn('ConsoleStream: failed to send console data:', error);
+        } finally {
+            this.sending = false;
+        }
+    }
+}
@@ -0,0 +1,30 @@
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScriptsHandler(t *testing.T) {
+	handler := NewScriptsHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ScriptsPath+"console-stream.js", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the console stream script, got status %d", rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.Contains(contentType, "javascript") {
+		t.Errorf("Expected a JavaScript content type, got %q", contentType)
+	}
+	if !strings.Contains(rec.Body.String(), "export class ConsoleStream") {
+		t.Error("Expected the ConsoleStream class")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ScriptsPath+"missing.js", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected missing scripts not found, got status %d", rec.Code)
+	}
+}
@@ -11,24 +11,48 @@ type TemplateData struct {
 	IconText      string
 	HeaderTitle   string
 	InitialStatus string
+	CSRFToken     string // Sent as X-CSRF-Token with the page's RPC calls
+	ServerID      string // Server shown in the BMC sidebars, empty on pages without them
+	Maintenance   bool   // Whether the server's power operations are blocked by its maintenance
+	Theme         string // ThemeDark or ThemeLight
+	Lang          string // Language of the page, see RequestLanguage
+}
+
+// T returns the message of key in the page's language, formatted with args
+func (d TemplateData) T(key string, args ...any) string {
+	return messages.Printer(d.Lang).Sprintf(key, args...)
+}
+
+// ScriptMessages returns the messages translated by the page's scripts, with
+// their "js." keys
+func (d TemplateData) ScriptMessages() map[string]string {
+	return messages.Printer(d.Lang).Messages("js.")
 }
 
 // VNCData represents data specific to VNC templates
 type VNCData struct {
 	TemplateData
 	SessionID       string
-	ServerID        string
 	GatewayEndpoint string
 	WebSocketURL    string
+	ReadOnly        bool // The gateway drops the viewer's input
 }
 
 // ConsoleData represents data specific to console templates
 type ConsoleData struct {
 	TemplateData
 	SessionID       string
-	ServerID        string
 	GatewayEndpoint string
 	WebSocketURL    string
+	ReadOnly        bool // The gateway drops the viewer's input
+}
+
+// PortalData represents data specific to the customer portal templates
+type PortalData struct {
+	TemplateData
+	CustomerEmail string
+	Token         string // Customer token of the page's RPC calls, empty on the login page
+	ManagerURL    string // Manager base URL called from the browser
 }
 
 // RenderVNC renders the VNC viewer template
@@ -50,3 +74,23 @@ func RenderConsole(data ConsoleData) (io.Reader, error) {
 	}
 	return &buf, nil
 }
+
+// RenderPortal renders the customer portal template
+func RenderPortal(data PortalData) (io.Reader, error) {
+	var buf bytes.Buffer
+	err := portalTemplates.ExecuteTemplate(&buf, "portal.html", data)
+	if err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// RenderPortalLogin renders the customer portal login template
+func RenderPortalLogin(data PortalData) (io.Reader, error) {
+	var buf bytes.Buffer
+	err := portalLoginTemplates.ExecuteTemplate(&buf, "portal_login.html", data)
+	if err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
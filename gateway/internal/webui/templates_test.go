@@ -3,6 +3,7 @@ package webui
 import (
 	"html/template"
 	"io"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -15,9 +16,9 @@ func TestVNCTemplateRendering(t *testing.T) {
 			IconText:      "🖥️",
 			HeaderTitle:   "VNC Console",
 			InitialStatus: "Connecting",
+			ServerID:      "test-server-456",
 		},
 		SessionID:       "test-session-123",
-		ServerID:        "test-server-456",
 		GatewayEndpoint: "gateway.example.com",
 		WebSocketURL:    "ws://gateway.example.com/vnc",
 	}
@@ -62,9 +63,9 @@ func TestConsoleTemplateRendering(t *testing.T) {
 			IconText:      "📟",
 			HeaderTitle:   "SOL Console",
 			InitialStatus: "Connecting",
+			ServerID:      "test-server-012",
 		},
 		SessionID:       "test-session-789",
-		ServerID:        "test-server-012",
 		GatewayEndpoint: "gateway.example.com",
 		WebSocketURL:    "ws://gateway.example.com/console",
 	}
@@ -92,6 +93,7 @@ func TestConsoleTemplateRendering(t *testing.T) {
 		"test-server-012",
 		"gateway.example.com",
 		"SOL Console Session",
+		"/webui/scripts/console-stream.js",
 	}
 
 	for _, element := range expectedElements {
@@ -101,6 +103,76 @@ func TestConsoleTemplateRendering(t *testing.T) {
 	}
 }
 
+// TestConsoleTemplateMaintenance ensures that the power controls of a server
+// in maintenance are disabled behind a banner
+func TestConsoleTemplateMaintenance(t *testing.T) {
+	render := func(maintenance bool) string {
+		reader, err := RenderConsole(ConsoleData{
+			TemplateData: TemplateData{ServerID: "test-server-012", Maintenance: maintenance, Lang: "en"},
+			SessionID:    "test-session-789",
+		})
+		if err != nil {
+			t.Fatalf("Failed to render Console template: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read rendered Console template: %v", err)
+		}
+		return string(content)
+	}
+
+	content := render(true)
+	if !strings.Contains(content, `id="maintenance-banner"`) {
+		t.Error("Expected the maintenance banner")
+	}
+	if !strings.Contains(content, `id="power-on-btn" disabled`) {
+		t.Error("Expected the power controls to be disabled")
+	}
+
+	content = render(false)
+	if strings.Contains(content, `id="maintenance-banner"`) || strings.Contains(content, `id="power-on-btn" disabled`) {
+		t.Error("Expected no maintenance banner outside maintenance")
+	}
+}
+
+// TestTemplateReadOnly ensures that read-only viewers take no input and
+// have no key controls
+func TestTemplateReadOnly(t *testing.T) {
+	read := func(reader io.Reader, err error) string {
+		if err != nil {
+			t.Fatalf("Failed to render template: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read rendered template: %v", err)
+		}
+		return string(content)
+	}
+	templateData := TemplateData{ServerID: "test-server-012", Lang: "en"}
+	readOnly := regexp.MustCompile(`const READ_ONLY = *true *;`)
+
+	console := read(RenderConsole(ConsoleData{TemplateData: templateData, SessionID: "test-session-789", ReadOnly: true}))
+	if !readOnly.MatchString(console) || !strings.Contains(console, "View Only") {
+		t.Error("Expected a read-only console")
+	}
+	if strings.Contains(console, "sendSpecialKey('ESC')") {
+		t.Error("Expected no special keys in a read-only console")
+	}
+
+	vnc := read(RenderVNC(VNCData{TemplateData: templateData, SessionID: "test-session-123", ReadOnly: true}))
+	if !readOnly.MatchString(vnc) || !strings.Contains(vnc, `aria-pressed="true"`) {
+		t.Error("Expected a read-only VNC viewer")
+	}
+	if strings.Contains(vnc, `onclick="sendCtrlAltDel()"`) {
+		t.Error("Expected no Ctrl+Alt+Del in a read-only VNC viewer")
+	}
+
+	console = read(RenderConsole(ConsoleData{TemplateData: templateData, SessionID: "test-session-789"}))
+	if readOnly.MatchString(console) || !strings.Contains(console, "sendSpecialKey('ESC')") {
+		t.Error("Expected an interactive console")
+	}
+}
+
 // TestTemplateIntegrity ensures all required template files are parsed
 func TestTemplateIntegrity(t *testing.T) {
 	tests := []struct {
@@ -116,6 +188,7 @@ func TestTemplateIntegrity(t *testing.T) {
 				"base.html",
 				"bmc_info_sidebar",
 				"boot_status_sidebar",
+				"power_controls_sidebar",
 			},
 		},
 		{
@@ -126,6 +199,23 @@ func TestTemplateIntegrity(t *testing.T) {
 				"base.html",
 				"bmc_info_sidebar",
 				"boot_status_sidebar",
+				"power_controls_sidebar",
+			},
+		},
+		{
+			name:      "Portal templates",
+			templates: portalTemplates,
+			expected: []string{
+				"portal.html",
+				"base.html",
+			},
+		},
+		{
+			name:      "Portal login templates",
+			templates: portalLoginTemplates,
+			expected: []string{
+				"portal_login.html",
+				"base.html",
 			},
 		},
 	}
@@ -152,6 +242,7 @@ func TestTemplateConsistency(t *testing.T) {
 		"base.html",
 		"bmc_info_sidebar",
 		"boot_status_sidebar",
+		"power_controls_sidebar",
 	}
 
 	for _, tmplName := range sharedTemplates {
@@ -0,0 +1,47 @@
+package webui
+
+import "net/http"
+
+// Web UI themes
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
+// ThemePath is the endpoint storing the theme chosen on a page
+const ThemePath = "/webui/theme"
+
+// ThemeCookieName is the cookie remembering the theme of pages without a web
+// session, such as the portal
+const ThemeCookieName = "bmc_theme"
+
+// ValidTheme reports whether theme is a known theme
+func ValidTheme(theme string) bool {
+	return theme == ThemeDark || theme == ThemeLight
+}
+
+// RequestTheme returns the theme of a page, the first known theme of
+// preferred, the theme cookie of r, or the dark theme
+func RequestTheme(r *http.Request, preferred ...string) string {
+	for _, theme := range preferred {
+		if ValidTheme(theme) {
+			return theme
+		}
+	}
+	if cookie, err := r.Cookie(ThemeCookieName); err == nil && ValidTheme(cookie.Value) {
+		return cookie.Value
+	}
+	return ThemeDark
+}
+
+// ThemeCookie returns the cookie remembering theme in the browser
+func ThemeCookie(theme string, r *http.Request) *http.Cookie {
+	return &http.Cookie{
+		Name:     ThemeCookieName,
+		Value:    theme,
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,107 @@
+package webui
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRequestTheme ensures the preferred theme wins over the theme cookie,
+// and pages default to the dark theme
+func TestRequestTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		cookie    string
+		preferred []string
+		expected  string
+	}{
+		{name: "default", expected: ThemeDark},
+		{name: "cookie", cookie: ThemeLight, expected: ThemeLight},
+		{name: "unknown cookie", cookie: "solarized", expected: ThemeDark},
+		{name: "preferred", cookie: ThemeLight, preferred: []string{ThemeDark}, expected: ThemeDark},
+		{name: "empty preferred", cookie: ThemeLight, preferred: []string{""}, expected: ThemeLight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: ThemeCookieName, Value: tt.cookie})
+			}
+			if theme := RequestTheme(req, tt.preferred...); theme != tt.expected {
+				t.Errorf("Expected theme %q, got %q", tt.expected, theme)
+			}
+		})
+	}
+}
+
+// TestViewerAccessibility ensures the viewers render their theme, and their
+// power controls as a labelled toolbar reachable from the keyboard
+func TestViewerAccessibility(t *testing.T) {
+	templateData := TemplateData{
+		Title:         "Test",
+		IconText:      "BMC",
+		HeaderTitle:   "Test",
+		InitialStatus: "Connecting",
+		ServerID:      "test-server-1",
+		Theme:         ThemeLight,
+	}
+
+	renders := map[string]func() (io.Reader, error){
+		"VNC": func() (io.Reader, error) {
+			return RenderVNC(VNCData{TemplateData: templateData, SessionID: "vnc-session"})
+		},
+		"Console": func() (io.Reader, error) {
+			return RenderConsole(ConsoleData{TemplateData: templateData, SessionID: "sol-session"})
+		},
+	}
+
+	for name, render := range renders {
+		t.Run(name, func(t *testing.T) {
+			reader, err := render()
+			if err != nil {
+				t.Fatalf("Failed to render %s template: %v", name, err)
+			}
+			contentBytes, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Failed to read rendered %s template: %v", name, err)
+			}
+			content := string(contentBytes)
+
+			for _, element := range []string{
+				`data-theme="light"`,
+				`id="theme-toggle"`,
+				`role="toolbar" aria-label="Power operations for test-server-1"`,
+				`aria-label="Power on test-server-1"`,
+				`aria-label="Power off test-server-1"`,
+				`aria-label="Reset test-server-1"`,
+				`aria-label="Power cycle test-server-1"`,
+				`aria-keyshortcuts="Alt+Shift+P"`,
+				`id="main-content"`,
+				ThemePath,
+			} {
+				if !strings.Contains(content, element) {
+					t.Errorf("Rendered %s template missing expected element: %s", name, element)
+				}
+			}
+		})
+	}
+}
+
+// TestPortalThemeCookie ensures pages without a web session use the theme
+// cookie
+func TestPortalThemeCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, PortalLoginPath, nil)
+	req.AddCookie(&http.Cookie{Name: ThemeCookieName, Value: ThemeLight})
+	rec := httptest.NewRecorder()
+	NewPortalLoginHandler("https://manager.example.com", "").ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `data-theme="light"`) {
+		t.Error("Portal login page does not use the theme cookie")
+	}
+}
@@ -1,11 +1,21 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"core/config"
+	"core/i18n"
+	"core/logging"
+	"core/streaming"
 
 	"github.com/rs/zerolog"
 )
@@ -23,11 +33,20 @@ type Config struct {
 
 	// TLS configuration
 	TLS config.TLSConfig `yaml:"tls"`
+
+	// Tracing configuration
+	Tracing config.TracingConfig `yaml:"tracing"`
+
+	// Event bus configuration
+	EventBus config.EventBusConfig `yaml:"event_bus"`
+
+	// Debug endpoints
+	Debug config.DebugConfig `yaml:"debug"`
 }
 
 // LogConfig contains gateway-specific logging configuration
 type LogConfig struct {
-	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=trace debug info warn warning error fatal panic"`
 	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
 	Debug  bool   `yaml:"debug" env:"DEBUG" default:"false"`
 }
@@ -56,16 +75,58 @@ func (c *LogConfig) ConfigureZerolog() {
 			level = zerolog.PanicLevel
 		}
 	}
-	zerolog.SetGlobalLevel(level)
+	logging.SetLevel(level)
 }
 
 // AuthConfig contains gateway-specific authentication configuration
 type AuthConfig struct {
-	JWTSecretKey string `yaml:"-" env:"JWT_SECRET_KEY"`
+	JWTSecretKey string `yaml:"-" env:"JWT_SECRET_KEY" secret:"true"`
+
+	// Base64-encoded 32-byte Ed25519 seed signing the tokens agents verify
+	// in console stream handshakes. When empty, a key is generated at
+	// startup and agents receive the new key when they re-register.
+	StreamTokenKey string `yaml:"-" env:"GATEWAY_STREAM_TOKEN_KEY" secret:"true"`
+
+	// Account the gateway authenticates with at the manager to register and
+	// report. Required unless DemoManagerAccount is set.
+	ManagerEmail    string `yaml:"manager_email" env:"GATEWAY_MANAGER_EMAIL"`
+	ManagerPassword string `yaml:"-" env:"GATEWAY_MANAGER_PASSWORD" secret:"true"`
+
+	// DemoManagerAccount authenticates with the demo account of development
+	// stacks, whose manager accepts any password (AUTH_DEMO_ACCOUNTS), when
+	// no account is configured
+	DemoManagerAccount bool `yaml:"demo_manager_account" env:"GATEWAY_DEMO_MANAGER_ACCOUNT" default:"false"`
+}
+
+// Demo account of development stacks, see AuthConfig.DemoManagerAccount
+const (
+	demoManagerEmail    = "test@example.com"
+	demoManagerPassword = "password"
+)
+
+// StreamTokenSeed returns the decoded stream token key, nil when unset
+func (a AuthConfig) StreamTokenSeed() ([]byte, error) {
+	if a.StreamTokenKey == "" {
+		return nil, nil
+	}
+	seed, err := base64.StdEncoding.DecodeString(a.StreamTokenKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("stream token key must be a base64-encoded %d-byte seed", ed25519.SeedSize)
+	}
+	return seed, nil
 }
 
 // GatewayConfig contains gateway-specific configuration
 type GatewayConfig struct {
+	// ID the gateway registers with at the manager, unique across gateways.
+	// Defaults to "gateway-<hostname>", so that gateways of a region running
+	// on different hosts get distinct IDs.
+	ID string `yaml:"id" env:"GATEWAY_ID"`
+
+	// Endpoint the manager advertises to clients to reach this gateway.
+	// Defaults to the external URL.
+	AdvertiseEndpoint string `yaml:"advertise_endpoint" env:"GATEWAY_ADVERTISE_ENDPOINT"`
+
 	// Server configuration
 	Host string `yaml:"host" env:"GATEWAY_HOST" default:"0.0.0.0"`
 	Port int    `yaml:"port" env:"GATEWAY_PORT" default:"8081"`
@@ -73,6 +134,14 @@ type GatewayConfig struct {
 	// External service configuration
 	ManagerEndpoint string `yaml:"manager_endpoint" env:"BMC_MANAGER_ENDPOINT" default:"http://localhost:8080"`
 
+	// Public base URL of the gateway (e.g. "https://gateway.example.com"),
+	// used in the console and WebSocket URLs returned to clients. Set it when
+	// the gateway runs behind a TLS-terminating load balancer. When empty,
+	// URLs use ExternalScheme and the listen address, or the hostname when
+	// listening on all interfaces.
+	ExternalURL    string `yaml:"external_url" env:"GATEWAY_EXTERNAL_URL"`
+	ExternalScheme string `yaml:"external_scheme" env:"GATEWAY_EXTERNAL_SCHEME" default:"http"`
+
 	// Region and datacenter configuration
 	Region      string   `yaml:"region" default:"default"`
 	Datacenters []string `yaml:"datacenters"` // TODO: Not currently used in code
@@ -83,17 +152,59 @@ type GatewayConfig struct {
 	// WebSocket configuration (TODO: Not currently used in code)
 	WebSocket WebSocketConfig `yaml:"websocket"`
 
-	// Session management (TODO: Not currently used in code)
+	// Session management (only the web session settings are currently used)
 	SessionManagement SessionManagementConfig `yaml:"session_management"`
 
 	// Web UI configuration (TODO: Not currently used in code)
 	WebUI WebUIConfig `yaml:"webui"`
 
-	// Agent connection management (TODO: Not currently used in code)
+	// Pooled connections to agents (only the pool settings are currently used)
 	AgentConnections AgentConnectionConfig `yaml:"agent_connections"`
 
+	// Periodic latency and throughput probes of the links to agents
+	AgentProbes AgentProbeConfig `yaml:"agent_probes"`
+
+	// Periodic power consumption samples of servers, for the usage report
+	PowerMetering PowerMeteringConfig `yaml:"power_metering"`
+
+	// Authorization of console sessions by the manager when clients attach
+	SessionValidation SessionValidationConfig `yaml:"session_validation"`
+
+	// Caps of the console WebSockets served at once, beyond which clients
+	// are told to retry later
+	Limits LimitsConfig `yaml:"limits"`
+
+	// How long the results of power operations sent with an idempotency key
+	// are returned to their retries instead of running them again, 0 to run
+	// every request
+	PowerIdempotencyWindow time.Duration `yaml:"power_idempotency_window" env:"GATEWAY_POWER_IDEMPOTENCY_WINDOW" default:"10m"`
+
+	// Power operations started with StartPowerOperation, tracked until done
+	Operations OperationsConfig `yaml:"operations"`
+
 	// Rate limiting (only .Enabled is currently used)
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// Faults injected into console streams to agents, to exercise the
+	// resilience of proxies and clients in staging. Never enable it in
+	// production.
+	FaultInjection FaultInjectionConfig `yaml:"fault_injection"`
+
+	// Comma-separated browser origins (e.g. "https://console.example.com")
+	// allowed to open console WebSockets and make CORS requests, in addition
+	// to the gateway's own origin. "*" allows any origin.
+	AllowedOrigins string `yaml:"allowed_origins" env:"GATEWAY_ALLOWED_ORIGINS"`
+}
+
+// AllowedOriginList returns the configured allowed origins
+func (c *GatewayConfig) AllowedOriginList() []string {
+	var origins []string
+	for _, origin := range strings.Split(c.AllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
 }
 
 // ProxyConfig configures proxy behavior
@@ -131,20 +242,29 @@ type WebSocketConfig struct {
 }
 
 // SessionManagementConfig configures session management
-// TODO: Not currently used in code - reserved for future implementation
+// Note: Only the web session settings (WebSessionTTL, CleanupInterval and
+// storage) and ConsoleSessionMaxLifetime are currently used in code
 type SessionManagementConfig struct {
-	ProxySessionTTL    time.Duration `yaml:"proxy_session_ttl" default:"1h"`
-	VNCSessionTTL      time.Duration `yaml:"vnc_session_ttl" default:"4h"`
-	ConsoleSessionTTL  time.Duration `yaml:"console_session_ttl" default:"2h"`
-	CleanupInterval    time.Duration `yaml:"cleanup_interval" default:"5m"`
+	ProxySessionTTL    time.Duration `yaml:"proxy_session_ttl" default:"1h"`   // TODO: Not currently used
+	VNCSessionTTL      time.Duration `yaml:"vnc_session_ttl" default:"4h"`     // TODO: Not currently used
+	ConsoleSessionTTL  time.Duration `yaml:"console_session_ttl" default:"2h"` // TODO: Not currently used
 	SessionTokenLength int           `yaml:"session_token_length" default:"32"`
 
-	// Session storage configuration
-	UseInMemoryStore bool `yaml:"use_in_memory_store" default:"true"`
+	// Web console sessions (session cookies of the browser consoles)
+	WebSessionTTL   time.Duration `yaml:"web_session_ttl" env:"SESSION_WEB_SESSION_TTL" default:"24h"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"SESSION_CLEANUP_INTERVAL" default:"5m"`
+
+	// Console sessions can be renewed until this long after their creation
+	ConsoleSessionMaxLifetime time.Duration `yaml:"console_session_max_lifetime" env:"SESSION_CONSOLE_SESSION_MAX_LIFETIME" default:"12h"`
+
+	// Web session storage: in memory (lost on restart), or a SQLite database
+	// at StorePath when UseInMemoryStore is false
+	UseInMemoryStore bool   `yaml:"use_in_memory_store" env:"SESSION_USE_IN_MEMORY_STORE" default:"true"`
+	StorePath        string `yaml:"store_path" env:"SESSION_STORE_PATH" default:"gateway-sessions.db"`
 }
 
 // WebUIConfig configures the web user interface
-// TODO: Not currently used in code - reserved for future implementation
+// TODO: Only PortalManagerURL and Language are currently used in code
 type WebUIConfig struct {
 	Enabled      bool   `yaml:"enabled" default:"true"`
 	StaticPath   string `yaml:"static_path" default:"/static"`
@@ -160,19 +280,133 @@ type WebUIConfig struct {
 	// Console viewer configuration
 	ConsoleViewerURL  string `yaml:"console_viewer_url" default:"/console"`
 	ConsoleScrollback int    `yaml:"console_scrollback" default:"1000"`
+
+	// Manager base URL the customer portal calls from the browser, when the
+	// manager endpoint is not reachable by customers. Defaults to the
+	// manager endpoint.
+	PortalManagerURL string `yaml:"portal_manager_url" env:"GATEWAY_PORTAL_MANAGER_URL"`
+
+	// Language of the pages for browsers whose Accept-Language names no
+	// translated language ("en" or "ja"). Defaults to English.
+	Language string `yaml:"language" env:"GATEWAY_WEBUI_LANGUAGE"`
 }
 
-// AgentConnectionConfig configures agent connection management
-// TODO: Not currently used in code - reserved for future implementation
+// AgentConnectionConfig configures the connections to agents, pooled and
+// reused by the RPC calls and console streams of the gateway
 type AgentConnectionConfig struct {
-	MaxConnections      int           `yaml:"max_connections" default:"100"`
-	ConnectionTimeout   time.Duration `yaml:"connection_timeout" default:"30s"`
+	// Connections open to each agent for unary calls, at most; further calls
+	// wait for one. Streams are multiplexed over HTTP/2 connections.
+	MaxConnections    int           `yaml:"max_connections" env:"GATEWAY_AGENT_MAX_CONNECTIONS" default:"100"`
+	ConnectionTimeout time.Duration `yaml:"connection_timeout" env:"GATEWAY_AGENT_CONNECTION_TIMEOUT" default:"30s"`
+	// Idle connections are closed after this time
+	IdleTimeout time.Duration `yaml:"idle_timeout" env:"GATEWAY_AGENT_IDLE_TIMEOUT" default:"90s"`
+	// Timeout of unary calls to agents, e.g. power operations
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"GATEWAY_AGENT_REQUEST_TIMEOUT" default:"30s"`
+	// Largest data of the VNC stream chunks exchanged with agents, larger
+	// messages are split. The agent's own limit applies when smaller.
+	MaxChunkSize int `yaml:"max_chunk_size" env:"GATEWAY_AGENT_MAX_CHUNK_SIZE" default:"32768"`
+
+	// Checksum the data of the console stream chunks exchanged with agents
+	// supporting it, to detect the chunks corrupted on the way
+	Checksums bool `yaml:"checksums" env:"GATEWAY_AGENT_CHECKSUMS" default:"true"`
+
+	// TODO: Not currently used in code - reserved for future implementation
 	HeartbeatInterval   time.Duration `yaml:"heartbeat_interval" default:"30s"`
 	HeartbeatTimeout    time.Duration `yaml:"heartbeat_timeout" default:"90s"`
 	ReconnectBackoff    time.Duration `yaml:"reconnect_backoff" default:"5s"`
 	MaxReconnectBackoff time.Duration `yaml:"max_reconnect_backoff" default:"300s"`
 }
 
+// AgentProbeConfig configures the gateway-agent link probes
+type AgentProbeConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"GATEWAY_AGENT_PROBES_ENABLED" default:"true"`
+	Interval time.Duration `yaml:"interval" env:"GATEWAY_AGENT_PROBE_INTERVAL" default:"1m"`
+	Timeout  time.Duration `yaml:"timeout" env:"GATEWAY_AGENT_PROBE_TIMEOUT" default:"10s"`
+
+	// Bytes uploaded and downloaded to measure throughput, 0 to only
+	// measure round-trip time. Agents reply with at most 4 MiB.
+	PayloadSize int `yaml:"payload_size" env:"GATEWAY_AGENT_PROBE_PAYLOAD_SIZE" default:"262144"`
+}
+
+// SessionValidationConfig configures the manager's authorization of console
+// sessions when clients attach to them, so that sessions of revoked
+// customers or disabled servers are rejected before they expire
+type SessionValidationConfig struct {
+	Enabled bool          `yaml:"enabled" env:"GATEWAY_SESSION_VALIDATION_ENABLED" default:"true"`
+	Timeout time.Duration `yaml:"timeout" env:"GATEWAY_SESSION_VALIDATION_TIMEOUT" default:"5s"`
+
+	// How long a manager decision is reused for the attaches of a session
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"GATEWAY_SESSION_VALIDATION_CACHE_TTL" default:"30s"`
+
+	// Accept attaches when the manager cannot be reached, so that consoles
+	// stay available during manager outages
+	FailOpen bool `yaml:"fail_open" env:"GATEWAY_SESSION_VALIDATION_FAIL_OPEN" default:"true"`
+}
+
+// LimitsConfig caps the console WebSockets of browsers served at once, so
+// that the gateway turns clients away with 503 and Retry-After when
+// saturated rather than running out of memory. A zero cap is disabled.
+type LimitsConfig struct {
+	MaxWebSocketSessions int `yaml:"max_websocket_sessions" env:"GATEWAY_MAX_WEBSOCKET_SESSIONS" default:"1000"`
+	MaxCustomerSessions  int `yaml:"max_customer_sessions" env:"GATEWAY_MAX_CUSTOMER_SESSIONS" default:"20"`
+
+	// Bytes reserved for the buffers of the open WebSockets: each reserves
+	// its largest message and one agent chunk
+	MaxBufferedBytes int64 `yaml:"max_buffered_bytes" env:"GATEWAY_MAX_BUFFERED_BYTES" default:"536870912"`
+	// Largest message of browsers, e.g. a console paste, larger ones close
+	// the WebSocket with a message_too_large stream error
+	MaxMessageSize int64 `yaml:"max_message_size" env:"GATEWAY_MAX_WEBSOCKET_MESSAGE_SIZE" default:"262144"`
+
+	// Retry-After told to the clients turned away
+	RetryAfter time.Duration `yaml:"retry_after" env:"GATEWAY_LIMITS_RETRY_AFTER" default:"30s"`
+}
+
+// OperationsConfig bounds the power operations started with
+// StartPowerOperation, which the gateway follows in the background
+type OperationsConfig struct {
+	// How long completed operations are kept for GetOperation and
+	// ListOperations
+	Retention time.Duration `yaml:"retention" env:"GATEWAY_OPERATION_RETENTION" default:"1h"`
+	// How long the gateway follows an operation before failing it
+	Timeout time.Duration `yaml:"timeout" env:"GATEWAY_OPERATION_TIMEOUT" default:"30m"`
+}
+
+// PowerMeteringConfig configures the power consumption samples of servers,
+// reported to the manager for per-customer usage reports
+type PowerMeteringConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"GATEWAY_POWER_METERING_ENABLED" default:"false"`
+	Interval time.Duration `yaml:"interval" env:"GATEWAY_POWER_METERING_INTERVAL" default:"5m"`
+	Timeout  time.Duration `yaml:"timeout" env:"GATEWAY_POWER_METERING_TIMEOUT" default:"15s"`
+}
+
+// FaultInjectionConfig configures the faults injected into the data chunks
+// of console streams to agents. Probabilities are per chunk, from 0 to 1.
+type FaultInjectionConfig struct {
+	Enabled                 bool          `yaml:"enabled" env:"GATEWAY_FAULT_INJECTION_ENABLED" default:"false"`
+	DelayProbability        float64       `yaml:"delay_probability" env:"GATEWAY_FAULT_DELAY_PROBABILITY"`
+	MaxDelay                time.Duration `yaml:"max_delay" env:"GATEWAY_FAULT_MAX_DELAY" default:"500ms"`
+	DropProbability         float64       `yaml:"drop_probability" env:"GATEWAY_FAULT_DROP_PROBABILITY"`
+	PartialWriteProbability float64       `yaml:"partial_write_probability" env:"GATEWAY_FAULT_PARTIAL_WRITE_PROBABILITY"`
+	DisconnectProbability   float64       `yaml:"disconnect_probability" env:"GATEWAY_FAULT_DISCONNECT_PROBABILITY"`
+	CorruptProbability      float64       `yaml:"corrupt_probability" env:"GATEWAY_FAULT_CORRUPT_PROBABILITY"`
+
+	// Seed makes the faults reproducible, 0 picks a random seed
+	Seed uint64 `yaml:"seed" env:"GATEWAY_FAULT_SEED"`
+}
+
+// StreamFaults returns the streaming fault configuration
+func (c FaultInjectionConfig) StreamFaults() streaming.FaultConfig {
+	return streaming.FaultConfig{
+		DelayProbability:        c.DelayProbability,
+		MaxDelay:                c.MaxDelay,
+		DropProbability:         c.DropProbability,
+		PartialWriteProbability: c.PartialWriteProbability,
+		DisconnectProbability:   c.DisconnectProbability,
+		CorruptProbability:      c.CorruptProbability,
+		Seed:                    c.Seed,
+	}
+}
+
 // RateLimitConfig configures rate limiting
 // Note: Currently only .Enabled is used in code
 type RateLimitConfig struct {
@@ -200,6 +434,11 @@ func Load(configFile, envFile string) (*Config, error) {
 		return nil, fmt.Errorf("failed to load gateway configuration: %w", err)
 	}
 
+	if err := cfg.applyHostDefaults(); err != nil {
+		return nil, fmt.Errorf("failed to derive gateway identity: %w", err)
+	}
+	cfg.applyDemoManagerAccount()
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("gateway configuration validation failed: %w", err)
 	}
@@ -207,6 +446,66 @@ func Load(configFile, envFile string) (*Config, error) {
 	return cfg, nil
 }
 
+// hostname returns the name of the host, replaced in tests
+var hostname = os.Hostname
+
+// gatewayIDPattern restricts gateway IDs to names usable in logs, metrics
+// labels and URLs
+var gatewayIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,61}[a-z0-9])?$`)
+
+// applyHostDefaults derives the gateway ID and endpoints left empty from the
+// hostname
+func (c *Config) applyHostDefaults() error {
+	if c.Gateway.ID != "" && c.Gateway.ExternalURL != "" && c.Gateway.AdvertiseEndpoint != "" {
+		return nil
+	}
+
+	host, err := hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname, set the gateway id and external URL: %w", err)
+	}
+	host = strings.ToLower(host)
+
+	if c.Gateway.ID == "" {
+		id := "gateway-" + invalidIDChars.ReplaceAllString(host, "-")
+		if len(id) > 63 {
+			id = id[:63]
+		}
+		c.Gateway.ID = strings.TrimRight(id, "-._")
+	}
+
+	if c.Gateway.ExternalURL == "" {
+		externalHost := c.Gateway.Host
+		if ip := net.ParseIP(externalHost); externalHost == "" || (ip != nil && ip.IsUnspecified()) {
+			externalHost = host
+		}
+		c.Gateway.ExternalURL = c.Gateway.ExternalScheme + "://" + net.JoinHostPort(externalHost, strconv.Itoa(c.Gateway.Port))
+	}
+
+	if c.Gateway.AdvertiseEndpoint == "" {
+		c.Gateway.AdvertiseEndpoint = strings.TrimSuffix(c.Gateway.ExternalURL, "/")
+	}
+
+	return nil
+}
+
+// applyDemoManagerAccount fills in the manager account left empty with the
+// demo account, when enabled
+func (c *Config) applyDemoManagerAccount() {
+	if !c.Auth.DemoManagerAccount {
+		return
+	}
+	if c.Auth.ManagerEmail == "" {
+		c.Auth.ManagerEmail = demoManagerEmail
+	}
+	if c.Auth.ManagerPassword == "" {
+		c.Auth.ManagerPassword = demoManagerPassword
+	}
+}
+
+// invalidIDChars are replaced in gateway IDs derived from hostnames
+var invalidIDChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// Validate required configuration values (these now have defaults)
@@ -214,11 +513,45 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gateway manager endpoint is required")
 	}
 
+	if c.Auth.ManagerEmail == "" || c.Auth.ManagerPassword == "" {
+		return fmt.Errorf("gateway manager account is required, set GATEWAY_MANAGER_EMAIL and GATEWAY_MANAGER_PASSWORD, or GATEWAY_DEMO_MANAGER_ACCOUNT on development stacks")
+	}
+
 	// Validate port ranges
 	if c.Gateway.Port < 1 || c.Gateway.Port > 65535 {
 		return fmt.Errorf("gateway port must be between 1 and 65535")
 	}
 
+	// Validate external URL configuration
+	if c.Gateway.ExternalScheme != "http" && c.Gateway.ExternalScheme != "https" {
+		return fmt.Errorf("gateway external scheme must be http or https")
+	}
+
+	if c.Gateway.ExternalURL != "" {
+		if err := validateBaseURL(c.Gateway.ExternalURL); err != nil {
+			return fmt.Errorf("gateway external URL %w", err)
+		}
+	}
+
+	if c.Gateway.WebUI.PortalManagerURL != "" {
+		if err := validateBaseURL(c.Gateway.WebUI.PortalManagerURL); err != nil {
+			return fmt.Errorf("portal manager URL %w", err)
+		}
+	}
+
+	if c.Gateway.WebUI.Language != "" && !i18n.Known(c.Gateway.WebUI.Language) {
+		return fmt.Errorf("web UI language must be one of %s, got %q", strings.Join(i18n.Languages, ", "), c.Gateway.WebUI.Language)
+	}
+
+	// Validate identity
+	if !gatewayIDPattern.MatchString(c.Gateway.ID) {
+		return fmt.Errorf("gateway id must be 1 to 63 lower case letters, digits, '.', '_' or '-', got %q", c.Gateway.ID)
+	}
+
+	if err := validateBaseURL(c.Gateway.AdvertiseEndpoint); err != nil {
+		return fmt.Errorf("gateway advertise endpoint %w", err)
+	}
+
 	// Validate proxy configuration
 	if c.Gateway.Proxy.ReadTimeout <= 0 {
 		return fmt.Errorf("proxy read timeout must be positive")
@@ -254,6 +587,22 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("VNC session TTL must be positive")
 	}
 
+	if c.Gateway.SessionManagement.WebSessionTTL <= 0 {
+		return fmt.Errorf("web session TTL must be positive")
+	}
+
+	if c.Gateway.SessionManagement.CleanupInterval <= 0 {
+		return fmt.Errorf("session cleanup interval must be positive")
+	}
+
+	if c.Gateway.SessionManagement.ConsoleSessionMaxLifetime <= 0 {
+		return fmt.Errorf("console session max lifetime must be positive")
+	}
+
+	if !c.Gateway.SessionManagement.UseInMemoryStore && c.Gateway.SessionManagement.StorePath == "" {
+		return fmt.Errorf("session store path is required when the in-memory store is disabled")
+	}
+
 	if c.Gateway.SessionManagement.SessionTokenLength < 16 {
 		return fmt.Errorf("session token length must be at least 16")
 	}
@@ -267,6 +616,78 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("agent connection timeout must be positive")
 	}
 
+	if c.Gateway.AgentConnections.IdleTimeout <= 0 {
+		return fmt.Errorf("agent idle timeout must be positive")
+	}
+
+	if c.Gateway.AgentConnections.RequestTimeout <= 0 {
+		return fmt.Errorf("agent request timeout must be positive")
+	}
+
+	if size := c.Gateway.AgentConnections.MaxChunkSize; size < 1024 || size > streaming.MaxMessageSize {
+		return fmt.Errorf("agent max chunk size must be between 1024 and %d bytes", streaming.MaxMessageSize)
+	}
+
+	if _, err := c.Auth.StreamTokenSeed(); err != nil {
+		return err
+	}
+
+	// Validate agent probes
+	if c.Gateway.AgentProbes.Enabled {
+		if c.Gateway.AgentProbes.Interval <= 0 {
+			return fmt.Errorf("agent probe interval must be positive")
+		}
+		if c.Gateway.AgentProbes.Timeout <= 0 {
+			return fmt.Errorf("agent probe timeout must be positive")
+		}
+		if c.Gateway.AgentProbes.PayloadSize < 0 || c.Gateway.AgentProbes.PayloadSize > 4<<20 {
+			return fmt.Errorf("agent probe payload size must be between 0 and 4194304 bytes")
+		}
+	}
+
+	if c.Gateway.SessionValidation.Enabled {
+		if c.Gateway.SessionValidation.Timeout <= 0 {
+			return fmt.Errorf("session validation timeout must be positive")
+		}
+		if c.Gateway.SessionValidation.CacheTTL < 0 {
+			return fmt.Errorf("session validation cache TTL must not be negative")
+		}
+	}
+
+	// Validate stream limits
+	if limits := c.Gateway.Limits; limits.MaxWebSocketSessions < 0 || limits.MaxCustomerSessions < 0 || limits.MaxBufferedBytes < 0 {
+		return fmt.Errorf("gateway limits must not be negative")
+	}
+
+	if size := c.Gateway.Limits.MaxMessageSize; size < 1024 || size > streaming.MaxMessageSize {
+		return fmt.Errorf("max WebSocket message size must be between 1024 and %d bytes", streaming.MaxMessageSize)
+	}
+
+	if c.Gateway.Limits.RetryAfter < time.Second {
+		return fmt.Errorf("limits retry after must be at least 1s")
+	}
+
+	if c.Gateway.PowerIdempotencyWindow < 0 {
+		return fmt.Errorf("power idempotency window must not be negative")
+	}
+
+	if c.Gateway.Operations.Retention < time.Minute {
+		return fmt.Errorf("operation retention must be at least 1m")
+	}
+
+	if c.Gateway.Operations.Timeout < time.Minute {
+		return fmt.Errorf("operation timeout must be at least 1m")
+	}
+
+	if c.Gateway.PowerMetering.Enabled {
+		if c.Gateway.PowerMetering.Interval <= 0 {
+			return fmt.Errorf("power metering interval must be positive")
+		}
+		if c.Gateway.PowerMetering.Timeout <= 0 {
+			return fmt.Errorf("power metering timeout must be positive")
+		}
+	}
+
 	// Validate rate limiting
 	if c.Gateway.RateLimit.Enabled {
 		if c.Gateway.RateLimit.RequestsPerMinute <= 0 {
@@ -277,10 +698,76 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate fault injection
+	if faults := c.Gateway.FaultInjection; faults.Enabled {
+		probabilities := []struct {
+			name  string
+			value float64
+		}{
+			{"delay", faults.DelayProbability},
+			{"drop", faults.DropProbability},
+			{"partial write", faults.PartialWriteProbability},
+			{"disconnect", faults.DisconnectProbability},
+			{"corrupt", faults.CorruptProbability},
+		}
+		for _, probability := range probabilities {
+			if probability.value < 0 || probability.value > 1 {
+				return fmt.Errorf("fault injection %s probability must be between 0 and 1", probability.name)
+			}
+		}
+		if faults.DelayProbability > 0 && faults.MaxDelay <= 0 {
+			return fmt.Errorf("fault injection max delay must be positive")
+		}
+	}
+
+	// Validate tracing
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	// Validate event bus
+	if err := c.EventBus.Validate(); err != nil {
+		return err
+	}
+
+	// Validate debug endpoints
+	if err := c.Debug.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBaseURL checks that rawURL is an absolute http or https URL
+// without a path, completing the error message of its caller
+func validateBaseURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http or https URL")
+	}
+	if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+		return fmt.Errorf("must not have a path or query")
+	}
 	return nil
 }
 
 // GetListenAddress returns the address the gateway should listen on
 func (c *Config) GetListenAddress() string {
-	return fmt.Sprintf("%s:%d", c.Gateway.Host, c.Gateway.Port)
+	return net.JoinHostPort(c.Gateway.Host, strconv.Itoa(c.Gateway.Port))
+}
+
+// GetExternalURL returns the base URL of the console URLs returned to clients
+func (c *Config) GetExternalURL() string {
+	if c.Gateway.ExternalURL != "" {
+		return strings.TrimSuffix(c.Gateway.ExternalURL, "/")
+	}
+	return c.Gateway.ExternalScheme + "://" + c.GetListenAddress()
+}
+
+// GetPortalManagerURL returns the manager base URL of the customer portal
+func (c *Config) GetPortalManagerURL() string {
+	if c.Gateway.WebUI.PortalManagerURL != "" {
+		return strings.TrimSuffix(c.Gateway.WebUI.PortalManagerURL, "/")
+	}
+	return strings.TrimSuffix(c.Gateway.ManagerEndpoint, "/")
 }
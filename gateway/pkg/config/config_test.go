@@ -8,6 +8,13 @@ import (
 	"time"
 )
 
+// TestMain configures the manager account that every configuration requires
+func TestMain(m *testing.M) {
+	os.Setenv("GATEWAY_MANAGER_EMAIL", "gateway@example.com")
+	os.Setenv("GATEWAY_MANAGER_PASSWORD", "gateway password")
+	os.Exit(m.Run())
+}
+
 func TestGatewayConfigLoad(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -40,6 +47,8 @@ gateway:
     vnc_session_ttl: 8h
     redis_database: 1
     use_in_memory_store: false
+    store_path: /var/lib/gateway/sessions.db
+    web_session_ttl: 8h
   webui:
     enabled: false
     title: Test BMC Console
@@ -152,6 +161,14 @@ REDIS_PASSWORD=test-password
 		t.Errorf("Expected UseInMemoryStore false, got %v", cfg.Gateway.SessionManagement.UseInMemoryStore)
 	}
 
+	if cfg.Gateway.SessionManagement.StorePath != "/var/lib/gateway/sessions.db" {
+		t.Errorf("Expected StorePath '/var/lib/gateway/sessions.db', got '%s'", cfg.Gateway.SessionManagement.StorePath)
+	}
+
+	if cfg.Gateway.SessionManagement.WebSessionTTL != 8*time.Hour {
+		t.Errorf("Expected WebSessionTTL 8h, got %v", cfg.Gateway.SessionManagement.WebSessionTTL)
+	}
+
 	// Test Web UI configuration
 	if cfg.Gateway.WebUI.Enabled {
 		t.Errorf("Expected WebUI.Enabled false, got %v", cfg.Gateway.WebUI.Enabled)
@@ -252,6 +269,14 @@ func TestGatewayConfigDefaults(t *testing.T) {
 		t.Errorf("Expected default UseInMemoryStore true, got %v", cfg.Gateway.SessionManagement.UseInMemoryStore)
 	}
 
+	if cfg.Gateway.SessionManagement.WebSessionTTL != 24*time.Hour {
+		t.Errorf("Expected default WebSessionTTL 24h, got %v", cfg.Gateway.SessionManagement.WebSessionTTL)
+	}
+
+	if cfg.Gateway.SessionManagement.CleanupInterval != 5*time.Minute {
+		t.Errorf("Expected default CleanupInterval 5m, got %v", cfg.Gateway.SessionManagement.CleanupInterval)
+	}
+
 	// Test Web UI defaults
 	if !cfg.Gateway.WebUI.Enabled {
 		t.Errorf("Expected default WebUI.Enabled true, got %v", cfg.Gateway.WebUI.Enabled)
@@ -266,6 +291,86 @@ func TestGatewayConfigDefaults(t *testing.T) {
 		t.Errorf("Expected default AgentConnections.MaxConnections 100, got %d", cfg.Gateway.AgentConnections.MaxConnections)
 	}
 
+	if cfg.Gateway.AgentConnections.IdleTimeout != 90*time.Second {
+		t.Errorf("Expected default AgentConnections.IdleTimeout 90s, got %v", cfg.Gateway.AgentConnections.IdleTimeout)
+	}
+
+	if cfg.Gateway.AgentConnections.RequestTimeout != 30*time.Second {
+		t.Errorf("Expected default AgentConnections.RequestTimeout 30s, got %v", cfg.Gateway.AgentConnections.RequestTimeout)
+	}
+	if cfg.Gateway.AgentConnections.MaxChunkSize != 32768 {
+		t.Errorf("Expected default AgentConnections.MaxChunkSize 32768, got %d", cfg.Gateway.AgentConnections.MaxChunkSize)
+	}
+
+	// Test agent probe defaults
+	if !cfg.Gateway.AgentProbes.Enabled {
+		t.Errorf("Expected default AgentProbes.Enabled true, got %v", cfg.Gateway.AgentProbes.Enabled)
+	}
+
+	if cfg.Gateway.AgentProbes.Interval != time.Minute {
+		t.Errorf("Expected default AgentProbes.Interval 1m, got %v", cfg.Gateway.AgentProbes.Interval)
+	}
+
+	if cfg.Gateway.AgentProbes.PayloadSize != 256*1024 {
+		t.Errorf("Expected default AgentProbes.PayloadSize 262144, got %d", cfg.Gateway.AgentProbes.PayloadSize)
+	}
+
+	// Test session validation defaults
+	if !cfg.Gateway.SessionValidation.Enabled {
+		t.Errorf("Expected default SessionValidation.Enabled true, got %v", cfg.Gateway.SessionValidation.Enabled)
+	}
+
+	if cfg.Gateway.SessionValidation.CacheTTL != 30*time.Second {
+		t.Errorf("Expected default SessionValidation.CacheTTL 30s, got %v", cfg.Gateway.SessionValidation.CacheTTL)
+	}
+
+	if !cfg.Gateway.SessionValidation.FailOpen {
+		t.Errorf("Expected default SessionValidation.FailOpen true, got %v", cfg.Gateway.SessionValidation.FailOpen)
+	}
+
+	// Test power metering defaults
+	if cfg.Gateway.PowerMetering.Enabled {
+		t.Errorf("Expected default PowerMetering.Enabled false, got %v", cfg.Gateway.PowerMetering.Enabled)
+	}
+
+	if cfg.Gateway.PowerMetering.Interval != 5*time.Minute {
+		t.Errorf("Expected default PowerMetering.Interval 5m, got %v", cfg.Gateway.PowerMetering.Interval)
+	}
+
+	// Test stream limit defaults
+	if cfg.Gateway.Limits.MaxWebSocketSessions != 1000 {
+		t.Errorf("Expected default Limits.MaxWebSocketSessions 1000, got %v", cfg.Gateway.Limits.MaxWebSocketSessions)
+	}
+
+	if cfg.Gateway.Limits.MaxBufferedBytes != 512<<20 {
+		t.Errorf("Expected default Limits.MaxBufferedBytes 512 MiB, got %v", cfg.Gateway.Limits.MaxBufferedBytes)
+	}
+
+	if cfg.Gateway.Limits.RetryAfter != 30*time.Second {
+		t.Errorf("Expected default Limits.RetryAfter 30s, got %v", cfg.Gateway.Limits.RetryAfter)
+	}
+
+	if cfg.Gateway.PowerIdempotencyWindow != 10*time.Minute {
+		t.Errorf("Expected default PowerIdempotencyWindow 10m, got %v", cfg.Gateway.PowerIdempotencyWindow)
+	}
+
+	if cfg.Gateway.Operations.Retention != time.Hour {
+		t.Errorf("Expected default Operations.Retention 1h, got %v", cfg.Gateway.Operations.Retention)
+	}
+
+	if cfg.Gateway.Operations.Timeout != 30*time.Minute {
+		t.Errorf("Expected default Operations.Timeout 30m, got %v", cfg.Gateway.Operations.Timeout)
+	}
+
+	// Test fault injection defaults
+	if cfg.Gateway.FaultInjection.Enabled {
+		t.Errorf("Expected default FaultInjection.Enabled false, got %v", cfg.Gateway.FaultInjection.Enabled)
+	}
+
+	if cfg.Gateway.FaultInjection.MaxDelay != 500*time.Millisecond {
+		t.Errorf("Expected default FaultInjection.MaxDelay 500ms, got %v", cfg.Gateway.FaultInjection.MaxDelay)
+	}
+
 	// Test rate limiting defaults
 	if !cfg.Gateway.RateLimit.Enabled {
 		t.Errorf("Expected default RateLimit.Enabled true, got %v", cfg.Gateway.RateLimit.Enabled)
@@ -295,6 +400,95 @@ func TestGatewayConfigValidation(t *testing.T) {
 			expectError: true,
 			errorText:   "gateway port must be between 1 and 65535",
 		},
+		{
+			name: "invalid external scheme",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_EXTERNAL_SCHEME", "ftp")
+			},
+			expectError: true,
+			errorText:   "gateway external scheme must be http or https",
+		},
+		{
+			name: "relative external URL",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_EXTERNAL_URL", "gateway.example.com")
+			},
+			expectError: true,
+			errorText:   "gateway external URL must be an absolute http or https URL",
+		},
+		{
+			name: "external URL with path",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_EXTERNAL_URL", "https://lb.example.com/gateway")
+			},
+			expectError: true,
+			errorText:   "gateway external URL must not have a path or query",
+		},
+		{
+			name: "relative portal manager URL",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_PORTAL_MANAGER_URL", "manager.example.com")
+			},
+			expectError: true,
+			errorText:   "portal manager URL must be an absolute http or https URL",
+		},
+		{
+			name: "regional web UI language",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_WEBUI_LANGUAGE", "ja-JP")
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown web UI language",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_WEBUI_LANGUAGE", "fr")
+			},
+			expectError: true,
+			errorText:   `web UI language must be one of en, ja, got "fr"`,
+		},
+		{
+			name: "oversized agent probe payload",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_AGENT_PROBE_PAYLOAD_SIZE", "8388608")
+			},
+			expectError: true,
+			errorText:   "agent probe payload size must be between 0 and 4194304 bytes",
+		},
+		{
+			name: "non-positive power metering interval",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_POWER_METERING_ENABLED", "true")
+				os.Setenv("GATEWAY_POWER_METERING_INTERVAL", "0s")
+			},
+			expectError: true,
+			errorText:   "power metering interval must be positive",
+		},
+		{
+			name: "fault injection probability above 1",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_FAULT_INJECTION_ENABLED", "true")
+				os.Setenv("GATEWAY_FAULT_DROP_PROBABILITY", "1.5")
+			},
+			expectError: true,
+			errorText:   "fault injection drop probability must be between 0 and 1",
+		},
+		{
+			name: "non-positive session validation timeout",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_SESSION_VALIDATION_TIMEOUT", "0s")
+			},
+			expectError: true,
+			errorText:   "session validation timeout must be positive",
+		},
+		{
+			name: "short stream token key",
+			setupEnv: func() {
+				os.Setenv("GATEWAY_STREAM_TOKEN_KEY", "c2hvcnQ=")
+			},
+			expectError: true,
+			errorText:   "stream token key must be a base64-encoded 32-byte seed",
+		},
 		{
 			name: "valid configuration",
 			setupEnv: func() {
@@ -310,6 +504,28 @@ func TestGatewayConfigValidation(t *testing.T) {
 			// Clean up environment
 			os.Unsetenv("BMC_MANAGER_ENDPOINT")
 			os.Unsetenv("GATEWAY_PORT")
+			os.Unsetenv("GATEWAY_EXTERNAL_SCHEME")
+			os.Unsetenv("GATEWAY_EXTERNAL_URL")
+			os.Unsetenv("GATEWAY_PORTAL_MANAGER_URL")
+			os.Unsetenv("GATEWAY_WEBUI_LANGUAGE")
+			os.Unsetenv("GATEWAY_AGENT_PROBE_PAYLOAD_SIZE")
+			os.Unsetenv("GATEWAY_POWER_METERING_ENABLED")
+			os.Unsetenv("GATEWAY_POWER_METERING_INTERVAL")
+			os.Unsetenv("GATEWAY_FAULT_INJECTION_ENABLED")
+			os.Unsetenv("GATEWAY_FAULT_DROP_PROBABILITY")
+			os.Unsetenv("GATEWAY_STREAM_TOKEN_KEY")
+			os.Unsetenv("GATEWAY_SESSION_VALIDATION_TIMEOUT")
+			defer os.Unsetenv("GATEWAY_EXTERNAL_SCHEME")
+			defer os.Unsetenv("GATEWAY_EXTERNAL_URL")
+			defer os.Unsetenv("GATEWAY_PORTAL_MANAGER_URL")
+			defer os.Unsetenv("GATEWAY_WEBUI_LANGUAGE")
+			defer os.Unsetenv("GATEWAY_AGENT_PROBE_PAYLOAD_SIZE")
+			defer os.Unsetenv("GATEWAY_POWER_METERING_ENABLED")
+			defer os.Unsetenv("GATEWAY_POWER_METERING_INTERVAL")
+			defer os.Unsetenv("GATEWAY_FAULT_INJECTION_ENABLED")
+			defer os.Unsetenv("GATEWAY_FAULT_DROP_PROBABILITY")
+			defer os.Unsetenv("GATEWAY_STREAM_TOKEN_KEY")
+			defer os.Unsetenv("GATEWAY_SESSION_VALIDATION_TIMEOUT")
 
 			// Setup test environment
 			tt.setupEnv()
@@ -386,6 +602,66 @@ gateway:
 			expectError: true,
 			errorText:   "VNC frame rate must be between 1 and 60",
 		},
+		{
+			name: "negative WebSocket session limit",
+			configYAML: `
+gateway:
+  limits:
+    max_websocket_sessions: -1
+`,
+			expectError: true,
+			errorText:   "gateway limits must not be negative",
+		},
+		{
+			name: "WebSocket message size too small",
+			configYAML: `
+gateway:
+  limits:
+    max_message_size: 512
+`,
+			expectError: true,
+			errorText:   "max WebSocket message size must be between 1024 and 16777216 bytes",
+		},
+		{
+			name: "retry after below a second",
+			configYAML: `
+gateway:
+  limits:
+    retry_after: 500ms
+`,
+			expectError: true,
+			errorText:   "limits retry after must be at least 1s",
+		},
+		{
+			name: "negative power idempotency window",
+			configYAML: `
+gateway:
+  power_idempotency_window: -1m
+`,
+			expectError: true,
+			errorText:   "power idempotency window must not be negative",
+		},
+		{
+			name: "operation timeout too short",
+			configYAML: `
+gateway:
+  operations:
+    timeout: 10s
+`,
+			expectError: true,
+			errorText:   "operation timeout must be at least 1m",
+		},
+		{
+			name: "disabled stream limits",
+			configYAML: `
+gateway:
+  limits:
+    max_websocket_sessions: 0
+    max_customer_sessions: 0
+    max_buffered_bytes: 0
+`,
+			expectError: false,
+		},
 		{
 			name: "valid WebSocket config",
 			configYAML: `
@@ -423,6 +699,95 @@ gateway:
 	}
 }
 
+func TestGatewayConfigEventBusValidation(t *testing.T) {
+	// Set required environment variables
+	os.Setenv("BMC_MANAGER_ENDPOINT", "http://localhost:8080")
+	defer os.Unsetenv("BMC_MANAGER_ENDPOINT")
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "gateway.yaml")
+
+	tests := []struct {
+		name        string
+		configYAML  string
+		expectError bool
+		errorText   string
+	}{
+		{
+			name: "disabled event bus is not validated",
+			configYAML: `
+event_bus:
+  enabled: false
+  driver: rabbitmq
+`,
+			expectError: false,
+		},
+		{
+			name: "unknown driver",
+			configYAML: `
+event_bus:
+  enabled: true
+  driver: rabbitmq
+  url: amqp://localhost:5672
+`,
+			expectError: true,
+			errorText:   "event_bus driver must be nats or kafka",
+		},
+		{
+			name: "missing URL",
+			configYAML: `
+event_bus:
+  enabled: true
+`,
+			expectError: true,
+			errorText:   "event_bus url is required",
+		},
+		{
+			name: "valid NATS event bus",
+			configYAML: `
+event_bus:
+  enabled: true
+  url: nats://nats-1:4222,nats://nats-2:4222
+`,
+			expectError: false,
+		},
+		{
+			name: "valid Kafka event bus",
+			configYAML: `
+event_bus:
+  enabled: true
+  driver: kafka
+  url: kafka-1:9092,kafka-2:9092
+  subject_prefix: bmc
+`,
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := os.WriteFile(configFile, []byte(tt.configYAML), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			_, err = Load(configFile, "")
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !strings.Contains(err.Error(), tt.errorText) {
+					t.Errorf("Expected error containing '%s', got '%v'", tt.errorText, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestGatewayConfigGetListenAddress(t *testing.T) {
 	// Set required environment variables
 	os.Setenv("BMC_MANAGER_ENDPOINT", "http://localhost:8080")
@@ -452,6 +817,12 @@ func TestGatewayConfigGetListenAddress(t *testing.T) {
 			port:     "8081",
 			expected: ":8081",
 		},
+		{
+			name:     "IPv6 address",
+			host:     "::",
+			port:     "8081",
+			expected: "[::]:8081",
+		},
 	}
 
 	for _, tt := range tests {
@@ -473,3 +844,164 @@ func TestGatewayConfigGetListenAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayConfigAllowedOriginList(t *testing.T) {
+	cfg := GatewayConfig{AllowedOrigins: " https://portal.example.com, ,https://ops.example.com "}
+	origins := cfg.AllowedOriginList()
+	if strings.Join(origins, "|") != "https://portal.example.com|https://ops.example.com" {
+		t.Errorf("Expected trimmed origins, got %q", origins)
+	}
+
+	cfg.AllowedOrigins = ""
+	if origins := cfg.AllowedOriginList(); len(origins) != 0 {
+		t.Errorf("Expected no origins, got %q", origins)
+	}
+}
+
+func TestGatewayConfigGetExternalURL(t *testing.T) {
+	cfg := &Config{Gateway: GatewayConfig{Host: "0.0.0.0", Port: 8081, ExternalScheme: "http"}}
+	if got := cfg.GetExternalURL(); got != "http://0.0.0.0:8081" {
+		t.Errorf("Expected listen address URL, got %q", got)
+	}
+
+	cfg.Gateway.ExternalURL = "https://gateway.example.com/"
+	if got := cfg.GetExternalURL(); got != "https://gateway.example.com" {
+		t.Errorf("Expected configured external URL, got %q", got)
+	}
+}
+
+func TestGatewayConfigGetPortalManagerURL(t *testing.T) {
+	cfg := &Config{Gateway: GatewayConfig{ManagerEndpoint: "http://manager:8080/"}}
+	if got := cfg.GetPortalManagerURL(); got != "http://manager:8080" {
+		t.Errorf("Expected manager endpoint, got %q", got)
+	}
+
+	cfg.Gateway.WebUI.PortalManagerURL = "https://manager.example.com"
+	if got := cfg.GetPortalManagerURL(); got != "https://manager.example.com" {
+		t.Errorf("Expected configured portal manager URL, got %q", got)
+	}
+}
+
+func TestGatewayConfigIdentity(t *testing.T) {
+	hostname = func() (string, error) { return "GW-Host_01.dc1.example.com", nil }
+	defer func() { hostname = os.Hostname }()
+
+	os.Setenv("BMC_MANAGER_ENDPOINT", "http://localhost:8080")
+	defer os.Unsetenv("BMC_MANAGER_ENDPOINT")
+
+	t.Run("derived from hostname", func(t *testing.T) {
+		cfg, err := Load("", "")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Gateway.ID != "gateway-gw-host_01.dc1.example.com" {
+			t.Errorf("Expected ID derived from the hostname, got %q", cfg.Gateway.ID)
+		}
+		if got := cfg.GetExternalURL(); got != "http://gw-host_01.dc1.example.com:8081" {
+			t.Errorf("Expected external URL derived from the hostname, got %q", got)
+		}
+		if cfg.Gateway.AdvertiseEndpoint != cfg.GetExternalURL() {
+			t.Errorf("Expected advertise endpoint to default to the external URL, got %q", cfg.Gateway.AdvertiseEndpoint)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		os.Setenv("GATEWAY_ID", "gateway-us-east-2")
+		defer os.Unsetenv("GATEWAY_ID")
+		os.Setenv("GATEWAY_EXTERNAL_URL", "https://console.example.com")
+		defer os.Unsetenv("GATEWAY_EXTERNAL_URL")
+		os.Setenv("GATEWAY_ADVERTISE_ENDPOINT", "https://gw-2.us-east.example.com")
+		defer os.Unsetenv("GATEWAY_ADVERTISE_ENDPOINT")
+
+		cfg, err := Load("", "")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Gateway.ID != "gateway-us-east-2" {
+			t.Errorf("Expected configured ID, got %q", cfg.Gateway.ID)
+		}
+		if cfg.Gateway.AdvertiseEndpoint != "https://gw-2.us-east.example.com" {
+			t.Errorf("Expected configured advertise endpoint, got %q", cfg.Gateway.AdvertiseEndpoint)
+		}
+	})
+
+	t.Run("listen host", func(t *testing.T) {
+		os.Setenv("GATEWAY_HOST", "10.0.0.5")
+		defer os.Unsetenv("GATEWAY_HOST")
+
+		cfg, err := Load("", "")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if got := cfg.GetExternalURL(); got != "http://10.0.0.5:8081" {
+			t.Errorf("Expected external URL of the listen host, got %q", got)
+		}
+	})
+
+	invalid := map[string]struct{ key, value, errorText string }{
+		"invalid ID":                 {"GATEWAY_ID", "Gateway 01", "gateway id must be"},
+		"relative advertise":         {"GATEWAY_ADVERTISE_ENDPOINT", "gateway:8081", "gateway advertise endpoint must be an absolute"},
+		"advertise endpoint w/ path": {"GATEWAY_ADVERTISE_ENDPOINT", "http://gateway:8081/api", "gateway advertise endpoint must not have a path"},
+	}
+	for name, tt := range invalid {
+		t.Run(name, func(t *testing.T) {
+			os.Setenv(tt.key, tt.value)
+			defer os.Unsetenv(tt.key)
+
+			_, err := Load("", "")
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing '%s', got '%v'", tt.errorText, err)
+			}
+		})
+	}
+}
+
+func TestGatewayConfigManagerAccount(t *testing.T) {
+	os.Setenv("BMC_MANAGER_ENDPOINT", "http://localhost:8080")
+	defer os.Unsetenv("BMC_MANAGER_ENDPOINT")
+	defer os.Setenv("GATEWAY_MANAGER_EMAIL", "gateway@example.com")
+	defer os.Setenv("GATEWAY_MANAGER_PASSWORD", "gateway password")
+	defer os.Unsetenv("GATEWAY_DEMO_MANAGER_ACCOUNT")
+
+	t.Run("required", func(t *testing.T) {
+		os.Unsetenv("GATEWAY_MANAGER_EMAIL")
+		os.Setenv("GATEWAY_MANAGER_PASSWORD", "gateway password")
+
+		_, err := Load("", "")
+		if err == nil || !strings.Contains(err.Error(), "gateway manager account is required") {
+			t.Errorf("Expected missing manager account error, got %v", err)
+		}
+
+		os.Setenv("GATEWAY_MANAGER_EMAIL", "gateway@example.com")
+		os.Unsetenv("GATEWAY_MANAGER_PASSWORD")
+		_, err = Load("", "")
+		if err == nil || !strings.Contains(err.Error(), "gateway manager account is required") {
+			t.Errorf("Expected missing manager account error, got %v", err)
+		}
+	})
+
+	t.Run("demo account", func(t *testing.T) {
+		os.Unsetenv("GATEWAY_MANAGER_EMAIL")
+		os.Unsetenv("GATEWAY_MANAGER_PASSWORD")
+		os.Setenv("GATEWAY_DEMO_MANAGER_ACCOUNT", "true")
+
+		cfg, err := Load("", "")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Auth.ManagerEmail != demoManagerEmail || cfg.Auth.ManagerPassword != demoManagerPassword {
+			t.Errorf("Expected the demo account, got %q", cfg.Auth.ManagerEmail)
+		}
+
+		// A configured account takes precedence
+		os.Setenv("GATEWAY_MANAGER_EMAIL", "gateway@example.com")
+		os.Setenv("GATEWAY_MANAGER_PASSWORD", "gateway password")
+		cfg, err = Load("", "")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if cfg.Auth.ManagerEmail != "gateway@example.com" || cfg.Auth.ManagerPassword != "gateway password" {
+			t.Errorf("Expected the configured account, got %q", cfg.Auth.ManagerEmail)
+		}
+	})
+}
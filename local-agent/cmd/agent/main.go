@@ -12,26 +12,45 @@ import (
 	"github.com/rs/zerolog/log"
 
 	baseconf "core/config"
+	"core/logging"
+	"core/tracing"
 	"local-agent/internal/agent"
-	"local-agent/internal/discovery"
 	"local-agent/pkg/bmc"
+	"local-agent/pkg/bmcproxy"
 	"local-agent/pkg/config"
+	"local-agent/pkg/discovery"
+	"local-agent/pkg/hoststore"
 	"local-agent/pkg/ipmi"
+	"local-agent/pkg/netpolicy"
 	"local-agent/pkg/redfish"
+	"local-agent/pkg/tunnel"
 )
 
 func init() {
 	// Configure zerolog for human-friendly console output
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})
+	log.Logger = log.Output(logging.NewRedactWriter(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})).Hook(logging.ModuleLevelHook)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 }
 
 func main() {
 	// Parse command line flags
 	var configPath string
+	var validate bool
+	var validateFormat string
+	var envDocs bool
 	flag.StringVar(&configPath, "config", "", "Path to configuration file")
+	flag.BoolVar(&validate, "validate-config", false, "Validate the configuration and check the endpoints it declares, then exit")
+	flag.StringVar(&validateFormat, "validate-format", "text", "Format of the validation report: text or json")
+	flag.BoolVar(&envDocs, "env-docs", false, "Print the environment variables of the configuration as Markdown, then exit")
 	flag.Parse()
 
+	if envDocs {
+		if err := baseconf.WriteEnvDocs(os.Stdout, "agent", &config.Config{}); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write environment variables")
+		}
+		return
+	}
+
 	// Load configuration using standardized discovery
 	var configFile, envFile string
 	if configPath != "" {
@@ -52,6 +71,17 @@ func main() {
 	}
 	envFile = baseconf.FindEnvironmentFile("agent")
 
+	if validate {
+		report := validateConfig(context.Background(), configFile, envFile)
+		if err := report.Write(os.Stdout, validateFormat); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write validation report")
+		}
+		if !report.Valid {
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.Load(configFile, envFile)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load configuration")
@@ -76,6 +106,55 @@ func main() {
 		Int("static_hosts", len(cfg.Static.Hosts)).
 		Msg("Agent configuration")
 
+	// Install the tracer provider before any Connect client or handler is created
+	shutdownTracing, err := tracing.Setup(context.Background(), "local-agent", cfg.Tracing)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+	defer shutdownTracing(context.Background())
+	log.Info().
+		Bool("enabled", cfg.Tracing.Enabled).
+		Str("endpoint", cfg.Tracing.Endpoint).
+		Float64("sample_ratio", cfg.Tracing.SampleRatio).
+		Msg("Tracing configured")
+
+	// Restrict BMC connections to the allowed networks before any client
+	// connects
+	networkPolicy, err := netpolicy.New(cfg.Agent.Security.AllowedNetworks, cfg.Agent.Security.DenyPrivateNetworks)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid network policy")
+	}
+	netpolicy.SetDefault(networkPolicy)
+	log.Info().
+		Strs("allowed_networks", cfg.Agent.Security.AllowedNetworks).
+		Bool("deny_private_networks", cfg.Agent.Security.DenyPrivateNetworks).
+		Msg("Network policy configured")
+
+	// Configure the tunnels to isolated BMC networks, brought up by the agent
+	tunnelConfigs := tunnelConfigsOf(cfg.Agent.Tunnels)
+	tunnels, err := tunnel.NewManager(tunnelConfigs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid tunnel configuration")
+	}
+
+	// Route BMC connections through their proxies, and the tunnels' networks
+	// through their tunnel
+	proxyRules := tunnels.ProxyRules()
+	for _, rule := range cfg.Agent.BMCProxy.Rules {
+		proxyRules = append(proxyRules, bmcproxy.Rule{Destinations: rule.Destinations, URL: rule.URL})
+	}
+	proxyRouter, err := bmcproxy.New(cfg.Agent.BMCProxy.URL, proxyRules)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid BMC proxy configuration")
+	}
+	bmcproxy.SetDefault(proxyRouter)
+	if cfg.Agent.BMCProxy.URL != "" || len(cfg.Agent.BMCProxy.Rules) > 0 {
+		log.Info().
+			Bool("default_proxy", cfg.Agent.BMCProxy.URL != "").
+			Int("rules", len(cfg.Agent.BMCProxy.Rules)).
+			Msg("BMC proxies configured")
+	}
+
 	// Initialize BMC clients
 	ipmiClient := ipmi.NewClient()
 	redfishClient := redfish.NewClient()
@@ -88,6 +167,27 @@ func main() {
 
 	// Initialize agent
 	localAgent := agent.NewLocalAgent(cfg, discoveryService, bmcClient)
+	if configFile != "" {
+		localAgent.WatchConfig(configFile, envFile)
+	}
+
+	// Restore the hosts added through the hosts API
+	if cfg.Agent.HostsAPI.Token != "" {
+		hostStore, err := hoststore.Open(cfg.Agent.HostsAPI.StateFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to open host store")
+		}
+		localAgent.UseHostStore(hostStore)
+		log.Info().
+			Str("state_file", cfg.Agent.HostsAPI.StateFile).
+			Int("hosts", len(hostStore.Hosts())).
+			Msg("Hosts API enabled")
+	}
+
+	if len(tunnelConfigs) > 0 {
+		localAgent.UseTunnels(tunnels)
+		log.Info().Int("tunnels", len(tunnelConfigs)).Msg("BMC network tunnels configured")
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -125,3 +225,23 @@ func main() {
 
 	log.Info().Msg("Local Agent stopped")
 }
+
+// tunnelConfigsOf converts the tunnel configuration of the agent to the
+// configuration of the tunnel manager
+func tunnelConfigsOf(configs []config.TunnelConfig) []tunnel.Config {
+	tunnelConfigs := make([]tunnel.Config, 0, len(configs))
+	for _, t := range configs {
+		tunnelConfigs = append(tunnelConfigs, tunnel.Config{
+			Name:           t.Name,
+			Type:           tunnel.Type(t.Type),
+			Networks:       t.Networks,
+			ConfigFile:     t.ConfigFile,
+			Host:           t.Host,
+			Port:           t.Port,
+			IdentityFile:   t.IdentityFile,
+			KnownHostsFile: t.KnownHostsFile,
+			SOCKSAddress:   t.SOCKSAddress,
+		})
+	}
+	return tunnelConfigs
+}
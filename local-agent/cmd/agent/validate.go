@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	baseconf "core/config"
+	"core/types"
+	"local-agent/pkg/bmcproxy"
+	"local-agent/pkg/config"
+	"local-agent/pkg/netpolicy"
+	"local-agent/pkg/tunnel"
+)
+
+// validateConfig loads and validates the configuration, and checks that the
+// gateway and the BMCs it declares are reachable, without starting the
+// agent. BMC connections go through the configured proxies and network
+// policy, as they would once started; the tunnels are not brought up.
+func validateConfig(ctx context.Context, configFile, envFile string) *baseconf.ValidationReport {
+	report := baseconf.NewValidationReport("agent", configFile, envFile)
+	report.AddFiles()
+
+	cfg, err := config.Load(configFile, envFile)
+	report.AddResult("configuration", err)
+	if err != nil {
+		return report
+	}
+
+	networkPolicy, err := netpolicy.New(cfg.Agent.Security.AllowedNetworks, cfg.Agent.Security.DenyPrivateNetworks)
+	report.AddResult("network policy", err)
+	if err == nil {
+		netpolicy.SetDefault(networkPolicy)
+	}
+	tunnels := checkTunnels(ctx, report, cfg.Agent.Tunnels)
+	checkBMCProxy(ctx, report, cfg.Agent.BMCProxy)
+
+	report.Add(baseconf.CheckListen(fmt.Sprintf(":%d", cfg.Agent.HTTPPort)))
+	report.Add(baseconf.CheckReachable(ctx, "gateway", cfg.Agent.GatewayEndpoint, "", nil))
+	report.CheckTLS(cfg.TLS)
+	report.CheckTracing(ctx, cfg.Tracing)
+
+	for _, host := range cfg.Static.Hosts {
+		for _, endpoint := range host.ControlEndpoints {
+			ipmi := endpoint.ToTypesEndpoint().Type == types.BMCTypeIPMI
+			report.Add(checkBMCEndpoint(ctx, tunnels, "bmc "+host.ID, endpoint.Endpoint, ipmi, ""))
+		}
+		if host.SOLEndpoint != nil {
+			ipmi := host.SOLEndpoint.ToTypesEndpoint().Type == types.SOLTypeIPMI
+			report.Add(checkBMCEndpoint(ctx, tunnels, "sol "+host.ID, host.SOLEndpoint.Endpoint, ipmi, ""))
+		}
+		if host.VNCEndpoint != nil {
+			report.Add(checkBMCEndpoint(ctx, tunnels, "vnc "+host.ID, host.VNCEndpoint.Endpoint, false, "5900"))
+		}
+	}
+	return report
+}
+
+// tunnelNetworks are the BMC networks of a tunnel
+type tunnelNetworks struct {
+	name     string
+	prefixes []netip.Prefix
+}
+
+// checkTunnels checks the tunnel configuration and the jump hosts of SSH
+// tunnels, and returns the networks reached through the tunnels
+func checkTunnels(ctx context.Context, report *baseconf.ValidationReport, configs []config.TunnelConfig) []tunnelNetworks {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	_, err := tunnel.NewManager(tunnelConfigsOf(configs))
+	report.AddResult("tunnels", err)
+	if err != nil {
+		return nil
+	}
+
+	networks := make([]tunnelNetworks, 0, len(configs))
+	for _, t := range configs {
+		tn := tunnelNetworks{name: t.Name}
+		for _, network := range t.Networks {
+			if prefix, err := netip.ParsePrefix(network); err == nil {
+				tn.prefixes = append(tn.prefixes, prefix.Masked())
+			}
+		}
+		networks = append(networks, tn)
+
+		switch tunnel.Type(t.Type) {
+		case tunnel.TypeSSH:
+			endpoint := hostOf(t.Host)
+			if t.Port != 0 {
+				endpoint = net.JoinHostPort(endpoint, strconv.Itoa(t.Port))
+			}
+			report.Add(baseconf.CheckReachable(ctx, "tunnel "+t.Name, endpoint, "22", nil))
+		case tunnel.TypeWireGuard:
+			check := baseconf.Check{Name: "tunnel " + t.Name, Target: t.ConfigFile, Status: baseconf.CheckOK}
+			if _, err := os.Stat(t.ConfigFile); err != nil {
+				check.Status = baseconf.CheckFailed
+				check.Detail = err.Error()
+			}
+			report.Add(check)
+		}
+	}
+	return networks
+}
+
+// hostOf strips the user of an SSH [user@]host
+func hostOf(host string) string {
+	return host[strings.LastIndex(host, "@")+1:]
+}
+
+// checkBMCProxy checks the proxy configuration, installs its router for the
+// BMC checks, and checks that the proxies are reachable
+func checkBMCProxy(ctx context.Context, report *baseconf.ValidationReport, c config.BMCProxyConfig) {
+	if c.URL == "" && len(c.Rules) == 0 {
+		return
+	}
+
+	rules := make([]bmcproxy.Rule, 0, len(c.Rules))
+	for _, rule := range c.Rules {
+		rules = append(rules, bmcproxy.Rule{Destinations: rule.Destinations, URL: rule.URL})
+	}
+	router, err := bmcproxy.New(c.URL, rules)
+	report.AddResult("bmc proxy", err)
+	if err != nil {
+		return
+	}
+	bmcproxy.SetDefault(router)
+
+	proxies := []string{c.URL}
+	for _, rule := range c.Rules {
+		proxies = append(proxies, rule.URL)
+	}
+	checked := make(map[string]bool)
+	for _, proxyURL := range proxies {
+		if proxyURL == "" || proxyURL == bmcproxy.Direct || checked[proxyURL] {
+			continue
+		}
+		checked[proxyURL] = true
+		check := baseconf.CheckReachable(ctx, "bmc proxy", proxyURL, "", nil)
+		check.Target = redactedURL(proxyURL)
+		report.Add(check)
+	}
+}
+
+// redactedURL hides the password of proxy URLs in the report
+func redactedURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil {
+		return u.Redacted()
+	}
+	return raw
+}
+
+// checkBMCEndpoint checks a BMC endpoint. IPMI runs over UDP, so its hosts
+// are only resolved and checked against the network policy. Endpoints in
+// the networks of tunnels are skipped, the tunnels being down.
+func checkBMCEndpoint(ctx context.Context, tunnels []tunnelNetworks, name, endpoint string, ipmi bool, defaultPort string) baseconf.Check {
+	if addr, err := netip.ParseAddr(netpolicy.EndpointHost(endpoint)); err == nil {
+		for _, t := range tunnels {
+			for _, prefix := range t.prefixes {
+				if prefix.Contains(addr.Unmap()) {
+					return baseconf.Check{
+						Name:   name,
+						Target: endpoint,
+						Status: baseconf.CheckSkipped,
+						Detail: fmt.Sprintf("reached through tunnel %s, not established during validation", t.name),
+					}
+				}
+			}
+		}
+	}
+
+	if ipmi {
+		if err := netpolicy.CheckEndpoint(ctx, endpoint); err != nil {
+			return baseconf.Check{Name: name, Target: endpoint, Status: baseconf.CheckFailed, Detail: err.Error()}
+		}
+		return baseconf.CheckResolvable(ctx, name, endpoint, "623")
+	}
+	dialer := bmcproxy.NewDialer(baseconf.DefaultCheckTimeout)
+	return baseconf.CheckReachable(ctx, name, endpoint, defaultPort, dialer.DialContext)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	baseconf "core/config"
+)
+
+// listen accepts and closes TCP connections until the test ends
+func listen(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// closedAddress returns a local address nothing listens on
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+// checkStatuses indexes the statuses of a report by check name
+func checkStatuses(report *baseconf.ValidationReport) map[string]baseconf.CheckStatus {
+	statuses := make(map[string]baseconf.CheckStatus)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestValidateConfig(t *testing.T) {
+	gateway := listen(t)
+	redfish := listen(t)
+	unreachable := closedAddress(t)
+	_, httpPort, _ := net.SplitHostPort(closedAddress(t))
+
+	configFile := filepath.Join(t.TempDir(), "agent.yaml")
+	configContent := `
+agent:
+  datacenter_id: test-dc
+  gateway_endpoint: http://` + gateway + `
+  http_port: ` + httpPort + `
+static:
+  hosts:
+    - id: server-ipmi
+      control_endpoints:
+        - endpoint: 127.0.0.1:623
+    - id: server-redfish
+      control_endpoints:
+        - endpoint: http://` + redfish + `
+    - id: server-vnc
+      control_endpoints:
+      vnc_endpoint:
+        endpoint: ` + unreachable + `
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	report := validateConfig(context.Background(), configFile, "")
+	statuses := checkStatuses(report)
+	for name, expected := range map[string]baseconf.CheckStatus{
+		"configuration":      baseconf.CheckOK,
+		"gateway":            baseconf.CheckOK,
+		"bmc server-ipmi":    baseconf.CheckOK,
+		"bmc server-redfish": baseconf.CheckOK,
+		"vnc server-vnc":     baseconf.CheckFailed,
+	} {
+		if statuses[name] != expected {
+			t.Errorf("Expected %s to be %s, got %s", name, expected, statuses[name])
+		}
+	}
+	if report.Valid {
+		t.Error("Expected unreachable endpoints to invalidate the report")
+	}
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		if err := os.WriteFile(configFile, []byte("agent:\n  gateway_endpoint: \"\"\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		report := validateConfig(context.Background(), configFile, "")
+		if report.Valid || checkStatuses(report)["configuration"] != baseconf.CheckFailed {
+			t.Errorf("Expected the configuration check to fail: %+v", report.Checks)
+		}
+		if len(report.Checks) != 2 {
+			t.Errorf("Expected no connectivity checks without a configuration, got %+v", report.Checks)
+		}
+	})
+}
+
+func TestCheckBMCEndpoint_Tunnels(t *testing.T) {
+	tunnels := []tunnelNetworks{{name: "isolated", prefixes: []netip.Prefix{netip.MustParsePrefix("10.99.0.0/16")}}}
+
+	// BMCs behind tunnels are skipped, the tunnels being down
+	check := checkBMCEndpoint(context.Background(), tunnels, "bmc server-1", "https://10.99.0.5", false, "")
+	if check.Status != baseconf.CheckSkipped {
+		t.Errorf("Expected the endpoint to be skipped, got %s: %s", check.Status, check.Detail)
+	}
+
+	check = checkBMCEndpoint(context.Background(), tunnels, "bmc server-2", closedAddress(t), false, "")
+	if check.Status != baseconf.CheckFailed {
+		t.Errorf("Expected the endpoint to fail, got %s: %s", check.Status, check.Detail)
+	}
+}
@@ -13,6 +13,7 @@ require (
 	core v0.0.0-00010101000000-000000000000
 	gateway v0.0.0-00010101000000-000000000000
 	github.com/creack/pty v1.1.24
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/gorilla/mux v1.8.1
 	github.com/gorilla/websocket v1.5.3
 	github.com/prometheus/client_golang v1.23.2
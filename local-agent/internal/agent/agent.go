@@ -2,11 +2,14 @@ package agent
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
@@ -17,25 +20,38 @@ import (
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
+	baseconf "core/config"
 	"core/domain"
 	commonv1 "core/gen/common/v1"
+	"core/idempotency"
 	"core/identity"
+	"core/logging"
+	"core/streaming"
+	"core/tracing"
 	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
 	"gateway/gen/gateway/v1/gatewayv1connect"
-	"local-agent/internal/discovery"
 	"local-agent/internal/metrics"
+	"local-agent/internal/session"
 	solservice "local-agent/internal/sol"
 	"local-agent/pkg/bmc"
 	"local-agent/pkg/config"
+	"local-agent/pkg/discovery"
+	"local-agent/pkg/hoststore"
+	"local-agent/pkg/reconcile"
+	"local-agent/pkg/tunnel"
 )
 
 func init() {
 	// Configure zerolog for human-friendly console output
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})
+	log.Logger = log.Output(logging.NewRedactWriter(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})).Hook(logging.ModuleLevelHook)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 }
 
+// Version is the agent build version reported to the gateway on registration.
+// Overridden at build time with -ldflags "-X local-agent/internal/agent.Version=...".
+var Version = "dev"
+
 // LocalAgent represents a Local Agent that runs in each datacenter
 type LocalAgent struct {
 	config           *config.Config
@@ -48,6 +64,55 @@ type LocalAgent struct {
 	solService *solservice.Service
 	httpServer *http.Server
 
+	// sessions tracks the BMC console connections of active streams
+	sessions *session.Manager
+
+	// streamBuffers pools the buffers VNC data is read into, across streams
+	streamBuffers *streaming.BufferPool
+
+	// powerOperations keeps the results of the power operations sent with
+	// an idempotency key, see powerIdempotencyInterceptor
+	powerOperations *idempotency.Cache[*gatewayv1.PowerOperationResponse]
+
+	// streamTokenKey verifies the tokens of the console streams opened by
+	// the gateway, received at registration. streamTokensLegacy is set when
+	// the gateway predates stream tokens and sends no key.
+	streamTokenMu      sync.RWMutex
+	streamTokenKey     ed25519.PublicKey
+	streamTokensLegacy bool
+
+	// identifyTimers turn off the identify LEDs blinking for a duration the
+	// BMC cannot time itself, by server ID
+	identifyMu     sync.Mutex
+	identifyTimers map[string]*time.Timer
+
+	// shutdownWatches force off the servers still on after a graceful
+	// shutdown timeout, by server ID
+	shutdownMu      sync.Mutex
+	shutdownWatches map[string]*shutdownWatch
+
+	// Configuration files reloaded on change, see WatchConfig
+	configFile string
+	envFile    string
+	reloads    chan struct{}
+
+	// hostStore persists the hosts added through the hosts API, see
+	// UseHostStore
+	hostStore   *hoststore.Store
+	hostChanges chan struct{}
+
+	// tunnels to isolated BMC networks, see UseTunnels
+	tunnels *tunnel.Manager
+
+	// reconciler checks the static hosts against their BMCs when
+	// reconciliation is enabled, see reconcileHosts. hostDrift keeps the
+	// last result by host ID, reportedDrift the signature of the drift last
+	// reported to the gateway.
+	reconciler    *reconcile.Reconciler
+	driftMu       sync.Mutex
+	hostDrift     map[string]*reconcile.Result
+	reportedDrift map[string]string
+
 	// Current state
 	discoveredServers map[string]*domain.Server
 	registered        bool
@@ -61,11 +126,18 @@ func NewLocalAgent(cfg *config.Config, discoveryService *discovery.Service, bmcC
 	gatewayClient := gatewayv1connect.NewGatewayServiceClient(
 		httpClient,
 		cfg.Agent.GatewayEndpoint,
+		connect.WithInterceptors(tracing.NewInterceptor()),
 	)
 
 	// Initialize SOL service
 	solService := solservice.NewService()
 
+	// Track console streams, limiting concurrent BMC sessions per protocol
+	sessions := session.NewManager(map[session.Protocol]int{
+		session.ProtocolSOL: cfg.Agent.SerialConsole.MaxSessions,
+		session.ProtocolVNC: cfg.Agent.VNCConfig.MaxConnections,
+	})
+
 	agent := &LocalAgent{
 		config:            cfg,
 		discoveryService:  discoveryService,
@@ -73,7 +145,17 @@ func NewLocalAgent(cfg *config.Config, discoveryService *discovery.Service, bmcC
 		httpClient:        httpClient,
 		bmcClient:         bmcClient,
 		solService:        solService,
+		sessions:          sessions,
+		streamBuffers:     streaming.NewBufferPool(cfg.Agent.VNCConfig.ChunkSize),
+		powerOperations:   idempotency.NewCache[*gatewayv1.PowerOperationResponse](cfg.Agent.BMCOperations.PowerIdempotencyWindow),
+		identifyTimers:    make(map[string]*time.Timer),
+		shutdownWatches:   make(map[string]*shutdownWatch),
+		reloads:           make(chan struct{}, 1),
+		hostChanges:       make(chan struct{}, 1),
 		discoveredServers: make(map[string]*domain.Server),
+		reconciler:        reconcile.New(bmcClient, cfg.Static.Reconcile.Apply, cfg.Static.Reconcile.Timeout),
+		hostDrift:         make(map[string]*reconcile.Result),
+		reportedDrift:     make(map[string]string),
 	}
 
 	// Setup HTTP/Connect server
@@ -82,6 +164,12 @@ func NewLocalAgent(cfg *config.Config, discoveryService *discovery.Service, bmcC
 	return agent
 }
 
+// UseTunnels makes the agent bring up the tunnels of manager when it starts,
+// and supervise them until it stops. It must be called before Start.
+func (a *LocalAgent) UseTunnels(manager *tunnel.Manager) {
+	a.tunnels = manager
+}
+
 // validateDependencies checks that required system dependencies are available
 func (a *LocalAgent) validateDependencies() error {
 	// Check if IPMI discovery is enabled or any static IPMI servers exist
@@ -139,6 +227,7 @@ func (a *LocalAgent) validateDependencies() error {
 func (a *LocalAgent) Start(ctx context.Context) error {
 	log.Info().
 		Str("agent_id", a.config.Agent.ID).
+		Str("version", Version).
 		Int("port", a.config.Agent.HTTPPort).
 		Msg("Starting Local Agent")
 
@@ -147,11 +236,19 @@ func (a *LocalAgent) Start(ctx context.Context) error {
 		return fmt.Errorf("dependency validation failed: %w", err)
 	}
 
+	// Bring up the tunnels to isolated BMC networks before discovering them
+	if a.tunnels != nil {
+		a.tunnels.Start(ctx)
+	}
+
 	// Start metrics collector for gauge metrics
 	metricsCollector := metrics.NewCollector(a, 15*time.Second)
 	go metricsCollector.Start(ctx)
 	defer metricsCollector.Stop()
 
+	// Close the BMC connections of console streams whose gateway is gone
+	go a.reapOrphanedSessions(ctx)
+
 	// Start HTTP server in goroutine
 	go func() {
 		log.Info().
@@ -168,6 +265,11 @@ func (a *LocalAgent) Start(ctx context.Context) error {
 	// Initial registration with exponential backoff
 	a.retryRegistration(ctx)
 
+	// Verify the static hosts match their BMCs, reporting drift
+	if a.config.Static.Reconcile.Enabled {
+		go a.reconcileHosts(ctx)
+	}
+
 	// Start periodic discovery and heartbeat
 	ticker := time.NewTicker(a.config.Agent.BMCDiscovery.ScanInterval)
 	defer ticker.Stop()
@@ -175,11 +277,24 @@ func (a *LocalAgent) Start(ctx context.Context) error {
 	heartbeatTicker := time.NewTicker(30 * time.Second)
 	defer heartbeatTicker.Stop()
 
+	// Re-resolve the BMC endpoints named in DNS
+	dnsTicker := time.NewTicker(a.config.Agent.BMCDiscovery.DNSRefreshInterval)
+	defer dnsTicker.Stop()
+
 	// Retry ticker for failed registrations (starts disabled)
 	retryTicker := time.NewTicker(5 * time.Second)
 	retryTicker.Stop()
 	defer retryTicker.Stop()
 
+	// Reload static hosts when the configuration file changes
+	if a.configFile != "" {
+		if err := config.WatchFile(ctx, a.configFile, a.requestReload); err != nil {
+			log.Warn().Err(err).Msg("Static hosts will not be reloaded on configuration change")
+		} else {
+			log.Info().Str("config_file", a.configFile).Msg("Watching configuration file for static host changes")
+		}
+	}
+
 	log.Info().
 		Str("agent_id", a.config.Agent.ID).
 		Msg("Agent started successfully, entering main loop")
@@ -209,6 +324,30 @@ func (a *LocalAgent) Start(ctx context.Context) error {
 				}
 			}
 
+		case <-a.reloads:
+			if err := a.reloadStaticHosts(ctx); err != nil {
+				log.Warn().Err(err).Msg("Registration of reloaded static hosts failed")
+				a.registered = false
+				// Enable fast retry
+				retryTicker.Reset(5 * time.Second)
+			}
+
+		case <-a.hostChanges:
+			if err := a.syncStaticHosts(ctx); err != nil {
+				log.Warn().Err(err).Msg("Registration of hosts API changes failed")
+				a.registered = false
+				// Enable fast retry
+				retryTicker.Reset(5 * time.Second)
+			}
+
+		case <-dnsTicker.C:
+			if err := a.refreshAddresses(ctx); err != nil {
+				log.Warn().Err(err).Msg("Registration of changed BMC addresses failed")
+				a.registered = false
+				// Enable fast retry
+				retryTicker.Reset(5 * time.Second)
+			}
+
 		case <-heartbeatTicker.C:
 			if err := a.sendHeartbeat(ctx); err != nil {
 				log.Warn().Err(err).Msg("Heartbeat failed")
@@ -224,7 +363,13 @@ func (a *LocalAgent) Start(ctx context.Context) error {
 func (a *LocalAgent) Stop(ctx context.Context) error {
 	log.Info().Str("agent_id", a.config.Agent.ID).Msg("Stopping Local Agent")
 
-	// VNC streaming is now handled via gRPC streaming (no separate service)
+	// Close BMC connections of active console streams
+	a.sessions.CloseAll()
+
+	// Take down the tunnels to isolated BMC networks
+	if a.tunnels != nil {
+		a.tunnels.Stop()
+	}
 
 	// Stop SOL service
 	if a.solService != nil {
@@ -305,21 +450,7 @@ func (a *LocalAgent) discoverAndRegister(ctx context.Context) error {
 	// Index servers by both their config ID and BMC endpoint to handle manager's ID format
 	a.discoveredServers = make(map[string]*domain.Server)
 	for _, server := range servers {
-		// Index by original server ID
-		a.discoveredServers[server.ID] = server
-
-		// Also index by BMC endpoint for manager-generated IDs
-		if server.GetPrimaryControlEndpoint() != nil {
-			// Use shared logic to generate manager-compatible server ID
-			managerID := identity.GenerateServerIDFromBMCEndpoint(
-				a.config.Agent.DatacenterID,
-				server.GetPrimaryControlEndpoint().Endpoint)
-			a.discoveredServers[managerID] = server
-			log.Debug().
-				Str("config_id", server.ID).
-				Str("manager_id", managerID).
-				Msg("Indexed server for manager compatibility")
-		}
+		a.indexServer(server)
 	}
 
 	// Always register to keep server information up-to-date
@@ -339,6 +470,53 @@ func (a *LocalAgent) discoverAndRegister(ctx context.Context) error {
 	return nil
 }
 
+// refreshAddresses resolves the BMC endpoints named in DNS again, and
+// registers the servers with the gateway when an address changed. Servers
+// keep their IDs and endpoint mappings, keyed by name: the registration
+// updates the address in their discovery metadata.
+func (a *LocalAgent) refreshAddresses(ctx context.Context) error {
+	servers := a.servers()
+	changes := a.discoveryService.RefreshAddresses(ctx, servers)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	for _, change := range changes {
+		log.Info().
+			Str("server_id", change.ServerID).
+			Str("hostname", change.Hostname).
+			Str("previous_address", change.Previous).
+			Str("address", change.Current).
+			Msg("BMC endpoint resolves to a new address")
+	}
+
+	if err := a.registerWithGateway(ctx, servers); err != nil {
+		return fmt.Errorf("gateway registration failed: %w", err)
+	}
+	a.registered = true
+	return nil
+}
+
+// indexServer adds a server to the discovered servers, by both its config ID
+// and BMC endpoint to handle manager's ID format
+func (a *LocalAgent) indexServer(server *domain.Server) {
+	// Index by original server ID
+	a.discoveredServers[server.ID] = server
+
+	// Also index by BMC endpoint for manager-generated IDs
+	if server.GetPrimaryControlEndpoint() != nil {
+		// Use shared logic to generate manager-compatible server ID
+		managerID := identity.GenerateServerIDFromBMCEndpoint(
+			a.config.Agent.DatacenterID,
+			server.GetPrimaryControlEndpoint().Endpoint)
+		a.discoveredServers[managerID] = server
+		log.Debug().
+			Str("config_id", server.ID).
+			Str("manager_id", managerID).
+			Msg("Indexed server for manager compatibility")
+	}
+}
+
 // registerWithGateway registers this agent and its discovered servers with the Regional Gateway
 func (a *LocalAgent) registerWithGateway(ctx context.Context, servers []*domain.Server) error {
 	// Convert servers to BMC endpoint registrations
@@ -449,6 +627,7 @@ func (a *LocalAgent) registerWithGateway(ctx context.Context, servers []*domain.
 		DatacenterId: a.config.Agent.DatacenterID,
 		Endpoint:     a.config.Agent.Endpoint,
 		BmcEndpoints: bmcEndpoints,
+		Version:      Version,
 	})
 
 	// Call Regional Gateway
@@ -461,6 +640,10 @@ func (a *LocalAgent) registerWithGateway(ctx context.Context, servers []*domain.
 		return fmt.Errorf("registration rejected: %s", resp.Msg.Message)
 	}
 
+	if err := a.setStreamTokenKey(resp.Msg.StreamTokenKey); err != nil {
+		return err
+	}
+
 	log.Info().
 		Str("message", resp.Msg.Message).
 		Msg("Successfully registered with Regional Gateway")
@@ -596,6 +779,20 @@ func (a *LocalAgent) GetServerCount() int {
 	return len(a.discoveredServers)
 }
 
+// GetSessionCounts returns the number of active SOL and VNC sessions by
+// server ID
+func (a *LocalAgent) GetSessionCounts() (sol, vnc map[string]int) {
+	sol, vnc = make(map[string]int), make(map[string]int)
+	for _, info := range a.sessions.List() {
+		if info.Protocol == session.ProtocolVNC {
+			vnc[info.ServerID]++
+		} else {
+			sol[info.ServerID]++
+		}
+	}
+	return sol, vnc
+}
+
 // IsRegistered returns true if the agent is registered with the Regional Gateway
 func (a *LocalAgent) IsRegistered() bool {
 	return a.registered
@@ -606,7 +803,9 @@ func (a *LocalAgent) setupServer(port int) {
 	router := mux.NewRouter()
 
 	// Register Connect RPC service handler for streaming
-	path, handler := gatewayv1connect.NewGatewayServiceHandler(a)
+	path, handler := gatewayv1connect.NewGatewayServiceHandler(a,
+		connect.WithInterceptors(tracing.NewInterceptor(), a.powerIdempotencyInterceptor()),
+	)
 	router.PathPrefix(path).Handler(handler)
 
 	// Setup legacy HTTP routes
@@ -638,10 +837,46 @@ func (a *LocalAgent) setupHTTPRoutes(router *mux.Router) {
 	// Prometheus metrics endpoint
 	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
 
+	// Effective configuration, secrets redacted, when enabled
+	if a.config.Debug.ConfigEndpoint {
+		router.Handle("/config", baseconf.ConfigHandler("agent", func() interface{} { return a.config })).Methods("GET")
+	}
+
 	// VNC sessions now handled via gRPC streaming (no HTTP endpoint needed)
 
 	// Active SOL sessions endpoint
 	router.HandleFunc("/sol/sessions", a.handleSOLSessions).Methods("GET")
+
+	// Hosts API, enabled with a token
+	if a.config.Agent.HostsAPI.Token != "" {
+		a.setupHostsRoutes(router)
+	}
+
+	// pprof profiles and expvar variables, when enabled
+	if a.config.Debug.Diagnostics {
+		diagnostics := a.config.Debug.DiagnosticsHandler()
+		router.PathPrefix("/debug/pprof/").Handler(diagnostics)
+		router.Handle("/debug/vars", diagnostics).Methods("GET")
+		log.Warn().Msg("Diagnostics endpoints enabled on /debug/pprof/ and /debug/vars")
+	}
+
+	// Runtime log levels, enabled with the debug token
+	if a.config.Debug.Token != "" {
+		router.Handle("/debug/log-level", a.requireDebugToken(logging.LevelHandler())).Methods("GET", "PUT")
+	}
+}
+
+// requireDebugToken rejects requests without the debug bearer token
+func (a *LocalAgent) requireDebugToken(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + a.config.Debug.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			log.Warn().Str("remote_addr", r.RemoteAddr).Str("path", r.URL.Path).Msg("Rejected unauthenticated debug request")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 // handleHealth responds to health check requests
@@ -731,6 +966,7 @@ func (a *LocalAgent) handleStatus(w http.ResponseWriter, r *http.Request) {
 			"endpoint":      a.config.Agent.Endpoint,
 			"http_port":     a.config.Agent.HTTPPort,
 			"registered":    a.registered,
+			"version":       Version,
 		},
 		"discovery": map[string]interface{}{
 			"server_count": len(serverIDs),           // Unique server count
@@ -739,9 +975,16 @@ func (a *LocalAgent) handleStatus(w http.ResponseWriter, r *http.Request) {
 		},
 		"sessions": map[string]interface{}{
 			"sol_count": len(a.solService.GetActiveSessions()),
-			// VNC sessions now handled via gRPC streaming
+			// Console streams (SOL and VNC) tracked by the session manager
+			"stream_count": len(a.sessions.List()),
 		},
 	}
+	if a.tunnels != nil {
+		response["tunnels"] = a.tunnels.Status()
+	}
+	if a.config.Static.Reconcile.Enabled {
+		response["reconciliation"] = a.reconciliationStatus()
+	}
 
 	// Pretty print with indentation
 	encoder := json.NewEncoder(w)
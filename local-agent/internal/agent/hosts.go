@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/config"
+	"local-agent/pkg/hoststore"
+)
+
+// UseHostStore makes the hosts added through the hosts API, in store, part
+// of the agent's static hosts. It must be called before Start.
+func (a *LocalAgent) UseHostStore(store *hoststore.Store) {
+	a.hostStore = store
+	a.discoveryService.SetStaticHosts(a.staticHosts())
+}
+
+// setupHostsRoutes configures the hosts API, letting provisioning systems add
+// and remove BMC hosts without editing the configuration file:
+//
+//	GET    /hosts       lists the hosts added through the API
+//	PUT    /hosts/{id}  adds or replaces a host
+//	DELETE /hosts/{id}  removes a host added through the API
+func (a *LocalAgent) setupHostsRoutes(router *mux.Router) {
+	hosts := router.PathPrefix("/hosts").Subrouter()
+	hosts.Use(a.requireHostsAPIToken)
+	hosts.HandleFunc("", a.handleListHosts).Methods("GET")
+	hosts.HandleFunc("/{hostId}", a.handlePutHost).Methods("PUT")
+	hosts.HandleFunc("/{hostId}", a.handleDeleteHost).Methods("DELETE")
+}
+
+// requireHostsAPIToken rejects requests without the hosts API bearer token
+func (a *LocalAgent) requireHostsAPIToken(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + a.config.Agent.HostsAPI.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			log.Warn().Str("remote_addr", r.RemoteAddr).Str("path", r.URL.Path).Msg("Rejected unauthenticated hosts API request")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if a.hostStore == nil {
+			http.Error(w, "Host store not available", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostSummary describes a host in API responses, without its credentials
+type hostSummary struct {
+	ID              string `json:"id"`
+	CustomerID      string `json:"customer_id,omitempty"`
+	ControlEndpoint string `json:"control_endpoint"`
+	SOLEndpoint     string `json:"sol_endpoint,omitempty"`
+	VNCEndpoint     string `json:"vnc_endpoint,omitempty"`
+}
+
+func summarizeHost(host config.BMCHost) hostSummary {
+	return hostSummary{
+		ID:              host.ID,
+		CustomerID:      host.CustomerID,
+		ControlEndpoint: host.GetControlEndpoint(),
+		SOLEndpoint:     host.GetSOLEndpoint(),
+		VNCEndpoint:     host.GetVNCEndpoint(),
+	}
+}
+
+// handleListHosts lists the hosts added through the API
+func (a *LocalAgent) handleListHosts(w http.ResponseWriter, r *http.Request) {
+	stored := a.hostStore.Hosts()
+	hosts := make([]hostSummary, len(stored))
+	for i, host := range stored {
+		hosts[i] = summarizeHost(host)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"hosts": hosts})
+}
+
+// handlePutHost adds or replaces a host. Hosts of the configuration file
+// cannot be replaced through the API.
+func (a *LocalAgent) handlePutHost(w http.ResponseWriter, r *http.Request) {
+	hostID := mux.Vars(r)["hostId"]
+
+	var host config.BMCHost
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&host); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid host: %v", err), http.StatusBadRequest)
+		return
+	}
+	if host.ID == "" {
+		host.ID = hostID
+	}
+	if host.ID != hostID {
+		http.Error(w, fmt.Sprintf("Host id %s does not match the path", host.ID), http.StatusBadRequest)
+		return
+	}
+	if err := host.Validate(); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid host: %v", err), http.StatusBadRequest)
+		return
+	}
+	if a.isConfiguredHost(hostID) {
+		http.Error(w, fmt.Sprintf("Host %s is defined in the configuration file", hostID), http.StatusConflict)
+		return
+	}
+
+	created, err := a.hostStore.Put(host)
+	if err != nil {
+		log.Error().Err(err).Str("host_id", hostID).Msg("Failed to store host")
+		http.Error(w, "Failed to store host", http.StatusInternalServerError)
+		return
+	}
+	a.requestHostsSync()
+
+	log.Info().
+		Str("host_id", hostID).
+		Str("control", host.GetControlEndpoint()).
+		Bool("created", created).
+		Msg("Host stored through the hosts API")
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(summarizeHost(host))
+}
+
+// handleDeleteHost removes a host added through the API
+func (a *LocalAgent) handleDeleteHost(w http.ResponseWriter, r *http.Request) {
+	hostID := mux.Vars(r)["hostId"]
+
+	err := a.hostStore.Remove(hostID)
+	switch {
+	case errors.Is(err, hoststore.ErrNotFound) && a.isConfiguredHost(hostID):
+		http.Error(w, fmt.Sprintf("Host %s is defined in the configuration file", hostID), http.StatusConflict)
+		return
+	case errors.Is(err, hoststore.ErrNotFound):
+		http.Error(w, fmt.Sprintf("Host %s not found", hostID), http.StatusNotFound)
+		return
+	case err != nil:
+		log.Error().Err(err).Str("host_id", hostID).Msg("Failed to remove host")
+		http.Error(w, "Failed to remove host", http.StatusInternalServerError)
+		return
+	}
+	a.requestHostsSync()
+
+	log.Info().Str("host_id", hostID).Msg("Host removed through the hosts API")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isConfiguredHost returns true if a static host with this ID comes from the
+// configuration file rather than from the hosts API
+func (a *LocalAgent) isConfiguredHost(id string) bool {
+	for _, host := range a.hostStore.Hosts() {
+		if host.ID == id {
+			return false
+		}
+	}
+	for _, host := range a.discoveryService.StaticHosts() {
+		if host.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/idempotency"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/internal/metrics"
+)
+
+// powerIdempotencyInterceptor runs the power operations sent with an
+// idempotency key once per key and server within the window. The gateway
+// deduplicates the retries of its clients; the agent answers the calls the
+// gateway retries after they failed there, e.g. timing out, while the BMC
+// completed them.
+func (a *LocalAgent) powerIdempotencyInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			msg, ok := req.Any().(*gatewayv1.PowerOperationRequest)
+			if !ok || msg.IdempotencyKey == "" || a.powerOperations.Window() <= 0 {
+				return next(ctx, req)
+			}
+			if err := idempotency.ValidateKey(msg.IdempotencyKey); err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+
+			// Keyed operations complete when the gateway stops waiting, so
+			// that its retry gets the result
+			operation := path.Base(req.Spec().Procedure)
+			detached := context.WithoutCancel(ctx)
+			result, replayed, err := a.powerOperations.Do(ctx, msg.ServerId+"/"+msg.IdempotencyKey, operation,
+				func() (*gatewayv1.PowerOperationResponse, error) {
+					resp, err := next(detached, req)
+					if err != nil {
+						return nil, err
+					}
+					return resp.Any().(*gatewayv1.PowerOperationResponse), nil
+				})
+			if errors.Is(err, idempotency.ErrKeyReused) {
+				return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+			}
+			if errors.Is(err, idempotency.ErrTooManyKeys) {
+				return nil, connect.NewError(connect.CodeResourceExhausted, err)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			resp := connect.NewResponse(result)
+			if replayed {
+				log.Info().
+					Str("operation", operation).
+					Str("server_id", msg.ServerId).
+					Str("idempotency_key", msg.IdempotencyKey).
+					Msg("Power operation retried with its idempotency key, returning the first result")
+				resp.Header().Set(idempotency.ReplayedHeader, "true")
+				metrics.ObservePowerDeduplication(operation)
+			}
+			return resp, nil
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/identity"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/pkg/config"
+	"local-agent/pkg/reconcile"
+)
+
+// reconcileConcurrency bounds the hosts reconciled at once
+const reconcileConcurrency = 8
+
+// reconcileHosts checks the BMCs of the static hosts against their
+// configuration every reconcile interval, until ctx ends, and reports the
+// drift found to the gateway
+func (a *LocalAgent) reconcileHosts(ctx context.Context) {
+	settings := a.config.Static.Reconcile
+	log.Info().
+		Dur("interval", settings.Interval).
+		Bool("apply", settings.Apply).
+		Msg("Reconciling static hosts against their BMCs")
+
+	ticker := time.NewTicker(settings.Interval)
+	defer ticker.Stop()
+	for {
+		a.reconcileOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcileOnce reconciles every static host, then reports the hosts whose
+// drift changed since the last report: drift found, changed or resolved
+func (a *LocalAgent) reconcileOnce(ctx context.Context) {
+	hosts := a.discoveryService.StaticHosts()
+	results := make([]*reconcile.Result, len(hosts))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, reconcileConcurrency)
+	for i, host := range hosts {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = a.reconciler.Reconcile(ctx, host)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	a.driftMu.Lock()
+	current := make(map[string]*reconcile.Result, len(results))
+	var changed []*gatewayv1.HostDrift
+	for i, result := range results {
+		current[result.HostID] = result
+		if result.Signature() == a.reportedDrift[result.HostID] {
+			continue
+		}
+		if !result.InSync() {
+			log.Warn().
+				Str("host_id", result.HostID).
+				Interface("findings", result.Findings).
+				Msg("Static host drifted from its configuration")
+		} else {
+			log.Info().Str("host_id", result.HostID).Msg("Static host back in sync with its configuration")
+		}
+		changed = append(changed, a.hostDriftProto(hosts[i], result))
+	}
+	a.hostDrift = current
+	// Hosts removed from the configuration are forgotten
+	for hostID := range a.reportedDrift {
+		if _, ok := current[hostID]; !ok {
+			delete(a.reportedDrift, hostID)
+		}
+	}
+	a.driftMu.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+
+	// Changes not reported are reported again after the next reconciliation
+	req := connect.NewRequest(&gatewayv1.ReportHostDriftRequest{
+		AgentId: a.config.Agent.ID,
+		Hosts:   changed,
+	})
+	if _, err := a.gatewayClient.ReportHostDrift(ctx, req); err != nil {
+		log.Warn().Err(err).Int("hosts", len(changed)).Msg("Failed to report static host drift")
+		return
+	}
+
+	a.driftMu.Lock()
+	for _, result := range results {
+		a.reportedDrift[result.HostID] = result.Signature()
+	}
+	a.driftMu.Unlock()
+}
+
+// hostDriftProto converts the reconciliation result of host, identified by
+// the server ID the manager derives from its primary BMC endpoint
+func (a *LocalAgent) hostDriftProto(host config.BMCHost, result *reconcile.Result) *gatewayv1.HostDrift {
+	drift := &gatewayv1.HostDrift{
+		HostId:    host.ID,
+		CheckedAt: timestamppb.New(result.CheckedAt),
+	}
+	if primary := host.GetPrimaryControlEndpoint(); primary != nil {
+		drift.ServerId = identity.GenerateServerIDFromBMCEndpoint(
+			a.config.Agent.DatacenterID,
+			primary.ToTypesEndpoint().Endpoint)
+	}
+	for _, finding := range result.Findings {
+		drift.Findings = append(drift.Findings, &gatewayv1.DriftFinding{
+			Check:    finding.Check,
+			Endpoint: finding.Endpoint,
+			Desired:  finding.Desired,
+			Actual:   finding.Actual,
+			Applied:  finding.Applied,
+			Error:    finding.Error,
+		})
+	}
+	return drift
+}
+
+// reconciliationStatus summarizes the last reconciliation for the status
+// endpoint: the hosts checked and those that drifted
+func (a *LocalAgent) reconciliationStatus() map[string]interface{} {
+	a.driftMu.Lock()
+	defer a.driftMu.Unlock()
+
+	drifted := make([]*reconcile.Result, 0)
+	for _, result := range a.hostDrift {
+		if !result.InSync() {
+			drifted = append(drifted, result)
+		}
+	}
+	return map[string]interface{}{
+		"apply":       a.config.Static.Reconcile.Apply,
+		"host_count":  len(a.hostDrift),
+		"drift_count": len(drifted),
+		"drifted":     drifted,
+	}
+}
@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"local-agent/pkg/config"
+)
+
+// WatchConfig makes the agent reload its static hosts when configFile
+// changes, without a restart. It must be called before Start.
+func (a *LocalAgent) WatchConfig(configFile, envFile string) {
+	a.configFile = configFile
+	a.envFile = envFile
+}
+
+// requestReload schedules a reload in the main loop, where the discovered
+// servers are updated. Requests made while one is pending are merged.
+func (a *LocalAgent) requestReload() {
+	select {
+	case a.reloads <- struct{}{}:
+	default:
+	}
+}
+
+// requestHostsSync schedules applying the hosts API changes in the main loop,
+// like requestReload
+func (a *LocalAgent) requestHostsSync() {
+	select {
+	case a.hostChanges <- struct{}{}:
+	default:
+	}
+}
+
+// reloadStaticHosts loads the configuration again and applies the changes of
+// its static hosts
+func (a *LocalAgent) reloadStaticHosts(ctx context.Context) error {
+	cfg, err := config.Load(a.configFile, a.envFile)
+	if err != nil {
+		// Keep running with the current hosts until the file is fixed
+		log.Error().Err(err).Str("config_file", a.configFile).Msg("Failed to reload configuration, keeping current static hosts")
+		return nil
+	}
+
+	a.config.Static.Hosts = cfg.Static.Hosts
+	return a.syncStaticHosts(ctx)
+}
+
+// staticHosts returns the hosts of the configuration file followed by those
+// added through the hosts API. Configured hosts take precedence.
+func (a *LocalAgent) staticHosts() []config.BMCHost {
+	hosts := append([]config.BMCHost(nil), a.config.Static.Hosts...)
+	if a.hostStore == nil {
+		return hosts
+	}
+
+	configured := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		configured[host.ID] = true
+	}
+	for _, host := range a.hostStore.Hosts() {
+		if configured[host.ID] {
+			log.Warn().Str("host_id", host.ID).Msg("Host added through the hosts API is also configured, using the configuration file")
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// syncStaticHosts applies the changes of the static hosts: added hosts are
+// registered and removed hosts deregistered from the gateway. Console
+// sessions of changed and removed hosts are not interrupted: they keep the
+// BMC connection opened for them.
+func (a *LocalAgent) syncStaticHosts(ctx context.Context) error {
+	hosts := a.staticHosts()
+	diff := config.DiffStaticHosts(a.discoveryService.StaticHosts(), hosts)
+	if diff.IsEmpty() {
+		log.Debug().Msg("Static hosts unchanged")
+		return nil
+	}
+
+	a.discoveryService.SetStaticHosts(hosts)
+	a.applyStaticHostsDiff(ctx, diff)
+
+	log.Info().
+		Strs("added", hostIDs(diff.Added)).
+		Strs("removed", hostIDs(diff.Removed)).
+		Strs("changed", hostIDs(diff.Changed)).
+		Msg("Static hosts updated")
+
+	// The registration lists all current servers, the gateway drops the others
+	if err := a.registerWithGateway(ctx, a.servers()); err != nil {
+		return fmt.Errorf("gateway registration failed: %w", err)
+	}
+	a.registered = true
+	return nil
+}
+
+// applyStaticHostsDiff updates the discovered servers with changed static
+// hosts, leaving the other servers untouched
+func (a *LocalAgent) applyStaticHostsDiff(ctx context.Context, diff config.StaticHostsDiff) {
+	stale := make(map[string]bool, len(diff.Removed)+len(diff.Changed))
+	for _, host := range append(diff.Removed, diff.Changed...) {
+		stale[host.ID] = true
+	}
+	for key, server := range a.discoveredServers {
+		if stale[server.ID] {
+			delete(a.discoveredServers, key)
+		}
+	}
+
+	for _, server := range a.discoveryService.StaticServers(ctx, append(diff.Added, diff.Changed...)) {
+		a.indexServer(server)
+	}
+}
+
+// servers returns the discovered servers, indexed twice in discoveredServers,
+// once each and ordered by ID
+func (a *LocalAgent) servers() []*domain.Server {
+	seen := make(map[*domain.Server]bool, len(a.discoveredServers))
+	servers := make([]*domain.Server, 0, len(a.discoveredServers))
+	for _, server := range a.discoveredServers {
+		if !seen[server] {
+			seen[server] = true
+			servers = append(servers, server)
+		}
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].ID < servers[j].ID })
+	return servers
+}
+
+func hostIDs(hosts []config.BMCHost) []string {
+	ids := make([]string, len(hosts))
+	for i, host := range hosts {
+		ids[i] = host.ID
+	}
+	return ids
+}
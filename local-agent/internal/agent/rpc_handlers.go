@@ -2,27 +2,44 @@ package agent
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"core/domain"
 	gatewayv1 "gateway/gen/gateway/v1"
 	"local-agent/internal/metrics"
+	"local-agent/internal/session"
+	"local-agent/pkg/bmc"
+	"local-agent/pkg/redfish"
 )
 
 // RPC Handler Methods
 //
 // This file implements the GatewayService RPC interface that allows the gateway
 // to call the agent. The agent acts as a service provider for:
-// - Power operations (PowerOn, PowerOff, PowerCycle, Reset, GetPowerStatus)
+// - Power operations (PowerOn, PowerOff, GracefulShutdown, ForceOff, PowerCycle, Reset,
+//   DiagnosticInterrupt, GetPowerStatus), and RunPowerOperation streaming their progress
+// - Power metering (GetPowerReading, GetEnergyUsage)
+// - Chassis identify (SetChassisIdentify)
+// - BMC information (GetBMCInfo, GetBMCNetworkConfig)
+// - Reachability of a server's BMC endpoints (DeepHealthCheck)
 // - Streaming sessions (StreamVNCData, StreamConsoleData)
+// - Inventory of the BMC console connections held by streams (ListActiveSessions)
 //
 // Methods that return "Unimplemented" are part of the interface but are only
 // called ON the gateway (not on the agent), such as:
 // - Agent registration/heartbeat (agents call these on gateway)
+// - Agent status (gateway reports its own view of registered agents)
 // - Session management (gateway manages sessions, not agent)
 // - Server listing (gateway forwards to manager)
+// - Operation tracking (gateway follows the agent's RunPowerOperation)
 
 func (a *LocalAgent) HealthCheck(
 	ctx context.Context,
@@ -45,6 +62,106 @@ func (a *LocalAgent) AgentHeartbeat(
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement AgentHeartbeat"))
 }
 
+func (a *LocalAgent) ReportHostDrift(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ReportHostDriftRequest],
+) (*connect.Response[gatewayv1.ReportHostDriftResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement ReportHostDrift"))
+}
+
+func (a *LocalAgent) GetAgentStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetAgentStatusRequest],
+) (*connect.Response[gatewayv1.GetAgentStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement GetAgentStatus"))
+}
+
+func (a *LocalAgent) ListAgents(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListAgentsRequest],
+) (*connect.Response[gatewayv1.ListAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement ListAgents"))
+}
+
+func (a *LocalAgent) GetGatewayStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetGatewayStatusRequest],
+) (*connect.Response[gatewayv1.GetGatewayStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement GetGatewayStatus"))
+}
+
+func (a *LocalAgent) SetLogLevel(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SetLogLevelRequest],
+) (*connect.Response[gatewayv1.SetLogLevelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement SetLogLevel"))
+}
+
+func (a *LocalAgent) StartPowerOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RunPowerOperationRequest],
+) (*connect.Response[gatewayv1.StartPowerOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement StartPowerOperation"))
+}
+
+func (a *LocalAgent) GetOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetOperationRequest],
+) (*connect.Response[gatewayv1.GetOperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement GetOperation"))
+}
+
+func (a *LocalAgent) ListOperations(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListOperationsRequest],
+) (*connect.Response[gatewayv1.ListOperationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement ListOperations"))
+}
+
+func (a *LocalAgent) WatchOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetOperationRequest],
+	stream *connect.ServerStream[gatewayv1.Operation],
+) error {
+	return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement WatchOperation"))
+}
+
+func (a *LocalAgent) ListConsoleSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListConsoleSessionsRequest],
+) (*connect.Response[gatewayv1.ListConsoleSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement ListConsoleSessions"))
+}
+
+func (a *LocalAgent) TerminateConsoleSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.TerminateConsoleSessionRequest],
+) (*connect.Response[gatewayv1.TerminateConsoleSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement TerminateConsoleSession"))
+}
+
+func (a *LocalAgent) WatchConsoleData(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ConsoleDataChunk],
+	stream *connect.ServerStream[gatewayv1.ConsoleDataChunk],
+) error {
+	return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement WatchConsoleData"))
+}
+
+func (a *LocalAgent) SendConsoleData(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SendConsoleDataRequest],
+) (*connect.Response[gatewayv1.SendConsoleDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement SendConsoleData"))
+}
+
+func (a *LocalAgent) RenewConsoleSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RenewConsoleSessionRequest],
+) (*connect.Response[gatewayv1.RenewConsoleSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("agents do not implement RenewConsoleSession"))
+}
+
 func (a *LocalAgent) PowerOn(
 	ctx context.Context,
 	req *connect.Request[gatewayv1.PowerOperationRequest],
@@ -58,6 +175,8 @@ func (a *LocalAgent) PowerOn(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
 	}
 
+	a.clearPendingForcedShutdown(req.Msg.ServerId)
+
 	bmcType := string(server.GetPrimaryControlEndpoint().Type)
 
 	// Execute power on operation
@@ -90,6 +209,8 @@ func (a *LocalAgent) PowerOff(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
 	}
 
+	a.clearPendingForcedShutdown(req.Msg.ServerId)
+
 	bmcType := string(server.GetPrimaryControlEndpoint().Type)
 
 	// Execute power off operation
@@ -122,6 +243,8 @@ func (a *LocalAgent) PowerCycle(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
 	}
 
+	a.clearPendingForcedShutdown(req.Msg.ServerId)
+
 	bmcType := string(server.GetPrimaryControlEndpoint().Type)
 
 	// Execute power cycle operation
@@ -154,6 +277,8 @@ func (a *LocalAgent) Reset(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
 	}
 
+	a.clearPendingForcedShutdown(req.Msg.ServerId)
+
 	bmcType := string(server.GetPrimaryControlEndpoint().Type)
 
 	// Execute reset operation
@@ -173,6 +298,115 @@ func (a *LocalAgent) Reset(
 	return connect.NewResponse(resp), nil
 }
 
+// DiagnosticInterrupt sends an NMI to a server, e.g. to collect a crash dump
+// of a hung OS
+func (a *LocalAgent) DiagnosticInterrupt(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	start := time.Now()
+
+	// Find the server by ID
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "diag_interrupt", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	if err := a.bmcClient.DiagnosticInterrupt(ctx, server); err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "diag_interrupt", "failure").Inc()
+		metrics.BMCOperationDuration.WithLabelValues(bmcType, "diag_interrupt").Observe(time.Since(start).Seconds())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("diagnostic interrupt failed: %w", err))
+	}
+
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "diag_interrupt", "success").Inc()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "diag_interrupt").Observe(time.Since(start).Seconds())
+
+	resp := &gatewayv1.PowerOperationResponse{
+		Success: true,
+		Message: fmt.Sprintf("Diagnostic interrupt sent to server %s", req.Msg.ServerId),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// powerOperationLabels are the metric labels of the operations of
+// RunPowerOperation
+var powerOperationLabels = map[gatewayv1.PowerOperation]string{
+	gatewayv1.PowerOperation_POWER_OPERATION_ON:                   "power_on",
+	gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF:            "power_off",
+	gatewayv1.PowerOperation_POWER_OPERATION_CYCLE:                "power_cycle",
+	gatewayv1.PowerOperation_POWER_OPERATION_RESET:                "reset",
+	gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT: "diag_interrupt",
+}
+
+// RunPowerOperation performs a power operation and streams its progress
+// until the BMC completes it, for operations that Redfish BMCs run as tasks
+func (a *LocalAgent) RunPowerOperation(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.RunPowerOperationRequest],
+	stream *connect.ServerStream[gatewayv1.PowerOperationProgress],
+) error {
+	start := time.Now()
+
+	label, ok := powerOperationLabels[req.Msg.Operation]
+	if !ok {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported power operation: %s", req.Msg.Operation))
+	}
+
+	// Find the server by ID
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", label, "not_found").Inc()
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	// Diagnostic interrupts leave the power, and the shutdown, as they are
+	if req.Msg.Operation != gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT {
+		a.clearPendingForcedShutdown(req.Msg.ServerId)
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	var taskID string
+	onProgress := func(progress redfish.TaskProgress) {
+		taskID = progress.TaskID
+		log.Debug().
+			Str("server_id", req.Msg.ServerId).
+			Str("task_id", progress.TaskID).
+			Str("state", string(progress.State)).
+			Int("percent_complete", progress.PercentComplete).
+			Msg("Power operation progress")
+
+		if err := stream.Send(&gatewayv1.PowerOperationProgress{
+			TaskId:          progress.TaskID,
+			State:           string(progress.State),
+			PercentComplete: int32(progress.PercentComplete),
+			Message:         progress.Message,
+		}); err != nil {
+			log.Debug().Err(err).Str("server_id", req.Msg.ServerId).Msg("Failed to send power operation progress")
+		}
+	}
+
+	if err := a.bmcClient.RunPowerOperation(ctx, server, req.Msg.Operation, onProgress); err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, label, "failure").Inc()
+		metrics.BMCOperationDuration.WithLabelValues(bmcType, label).Observe(time.Since(start).Seconds())
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("%s failed: %w", strings.ReplaceAll(label, "_", " "), err))
+	}
+
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, label, "success").Inc()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, label).Observe(time.Since(start).Seconds())
+
+	return stream.Send(&gatewayv1.PowerOperationProgress{
+		TaskId:          taskID,
+		State:           string(redfish.TaskStateCompleted),
+		PercentComplete: 100,
+		Message:         fmt.Sprintf("Power operation completed for server %s", req.Msg.ServerId),
+		Done:            true,
+	})
+}
+
 func (a *LocalAgent) GetPowerStatus(
 	ctx context.Context,
 	req *connect.Request[gatewayv1.PowerStatusRequest],
@@ -217,6 +451,130 @@ func (a *LocalAgent) GetPowerStatus(
 	return connect.NewResponse(resp), nil
 }
 
+// identifyOffTimeout bounds turning off an identify LED when its duration
+// ends
+const identifyOffTimeout = 30 * time.Second
+
+// SetChassisIdentify turns the identify (locate) LED of a server on or off,
+// or makes it blink for a duration. The agent turns the LED off when the
+// duration ends unless the BMC does it.
+func (a *LocalAgent) SetChassisIdentify(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.SetChassisIdentifyRequest],
+) (*connect.Response[gatewayv1.SetChassisIdentifyResponse], error) {
+	start := time.Now()
+
+	state := req.Msg.State
+	duration := time.Duration(req.Msg.DurationSeconds) * time.Second
+	switch state {
+	case gatewayv1.IdentifyState_IDENTIFY_STATE_ON, gatewayv1.IdentifyState_IDENTIFY_STATE_OFF:
+	case gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK:
+		if duration <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("blink requires a positive duration"))
+		}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("identify state is required"))
+	}
+
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "identify", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	timed, err := a.bmcClient.SetChassisIdentify(ctx, server, state, duration)
+	if err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "identify", "failure").Inc()
+		metrics.BMCOperationDuration.WithLabelValues(bmcType, "identify").Observe(time.Since(start).Seconds())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("set chassis identify failed: %w", err))
+	}
+
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "identify", "success").Inc()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "identify").Observe(time.Since(start).Seconds())
+
+	// The new state replaces the pending turn-off of a previous blink, which
+	// is kept when the BMC call fails
+	if state == gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK && !timed {
+		a.startIdentifyTimer(req.Msg.ServerId, duration)
+	} else {
+		a.stopIdentifyTimer(req.Msg.ServerId)
+	}
+
+	resp := &gatewayv1.SetChassisIdentifyResponse{
+		Success: true,
+		Message: fmt.Sprintf("Identify LED %s for server %s", identifyStateName(state), req.Msg.ServerId),
+	}
+	if state == gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK {
+		resp.OffAt = timestamppb.New(time.Now().Add(duration))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// startIdentifyTimer turns off the identify LED of a server after duration,
+// replacing its pending turn-off
+func (a *LocalAgent) startIdentifyTimer(serverID string, duration time.Duration) {
+	a.identifyMu.Lock()
+	defer a.identifyMu.Unlock()
+
+	if previous, ok := a.identifyTimers[serverID]; ok {
+		previous.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(duration, func() {
+		a.identifyMu.Lock()
+		if a.identifyTimers[serverID] != timer {
+			// Replaced by a later request
+			a.identifyMu.Unlock()
+			return
+		}
+		delete(a.identifyTimers, serverID)
+		a.identifyMu.Unlock()
+
+		server := a.discoveredServers[serverID]
+		if server == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), identifyOffTimeout)
+		defer cancel()
+		if _, err := a.bmcClient.SetChassisIdentify(ctx, server, gatewayv1.IdentifyState_IDENTIFY_STATE_OFF, 0); err != nil {
+			log.Warn().Err(err).Str("server_id", serverID).Msg("Failed to turn off identify LED")
+			return
+		}
+		log.Debug().Str("server_id", serverID).Msg("Identify LED turned off")
+	})
+	a.identifyTimers[serverID] = timer
+}
+
+// stopIdentifyTimer cancels the pending turn-off of the identify LED of a
+// server
+func (a *LocalAgent) stopIdentifyTimer(serverID string) {
+	a.identifyMu.Lock()
+	defer a.identifyMu.Unlock()
+
+	if timer, ok := a.identifyTimers[serverID]; ok {
+		timer.Stop()
+		delete(a.identifyTimers, serverID)
+	}
+}
+
+// identifyStateName returns the lower case name of an identify state
+func identifyStateName(state gatewayv1.IdentifyState) string {
+	switch state {
+	case gatewayv1.IdentifyState_IDENTIFY_STATE_ON:
+		return "on"
+	case gatewayv1.IdentifyState_IDENTIFY_STATE_OFF:
+		return "off"
+	case gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK:
+		return "blinking"
+	default:
+		return "unknown"
+	}
+}
+
 func (a *LocalAgent) CreateVNCSession(
 	ctx context.Context,
 	req *connect.Request[gatewayv1.CreateVNCSessionRequest],
@@ -286,3 +644,290 @@ func (a *LocalAgent) GetBMCInfo(
 		Info: bmcInfo,
 	}), nil
 }
+
+// GetBMCNetworkConfig reports the network configuration of the management
+// interfaces of a server's BMC
+func (a *LocalAgent) GetBMCNetworkConfig(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetBMCNetworkConfigRequest],
+) (*connect.Response[gatewayv1.GetBMCNetworkConfigResponse], error) {
+	start := time.Now()
+
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "network_config", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	interfaces, err := a.bmcClient.GetNetworkConfig(ctx, server)
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "network_config").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "network_config", "failure").Inc()
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get BMC network configuration: %w", err))
+	}
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "network_config", "success").Inc()
+
+	return connect.NewResponse(&gatewayv1.GetBMCNetworkConfigResponse{
+		BmcType:    bmcType,
+		Interfaces: interfaces,
+	}), nil
+}
+
+// Hops and statuses of deep health checks, see gatewayv1.HealthCheckHop
+const (
+	healthHopControl = "bmc_control"
+	healthHopSOL     = "sol"
+	healthHopVNC     = "vnc"
+
+	healthStatusOK      = "ok"
+	healthStatusFailed  = "failed"
+	healthStatusSkipped = "skipped"
+)
+
+// consoleCheckTimeout bounds the connection to a console endpoint of a deep
+// health check
+const consoleCheckTimeout = 10 * time.Second
+
+// DeepHealthCheck checks the BMC control endpoint of a server with a power
+// state read, and optionally connects to its console endpoints
+func (a *LocalAgent) DeepHealthCheck(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.DeepHealthCheckRequest],
+) (*connect.Response[gatewayv1.DeepHealthCheckResponse], error) {
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "health_check", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	control := &gatewayv1.HealthCheckHop{
+		Name:   healthHopControl,
+		Target: server.GetPrimaryControlEndpoint().Endpoint,
+	}
+	start := time.Now()
+	_, err := a.bmcClient.GetPowerState(ctx, server)
+	control.LatencyMs = time.Since(start).Milliseconds()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "health_check").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "health_check", "failure").Inc()
+		control.Status = healthStatusFailed
+		control.Error = err.Error()
+	} else {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "health_check", "success").Inc()
+		control.Status = healthStatusOK
+	}
+	hops := []*gatewayv1.HealthCheckHop{control}
+
+	if req.Msg.CheckConsoles {
+		hops = append(hops, checkSOLHop(ctx, server, control), checkVNCHop(ctx, server))
+	}
+
+	healthy := true
+	for _, hop := range hops {
+		if hop.Status == healthStatusFailed {
+			healthy = false
+		}
+	}
+
+	log.Debug().
+		Str("server_id", req.Msg.ServerId).
+		Bool("healthy", healthy).
+		Msg("Deep health check completed")
+
+	return connect.NewResponse(&gatewayv1.DeepHealthCheckResponse{
+		ServerId: req.Msg.ServerId,
+		Healthy:  healthy,
+		Hops:     hops,
+	}), nil
+}
+
+// checkSOLHop connects to the serial console endpoint of a server. IPMI SOL
+// runs in the session of the control endpoint, so it shares its status.
+func checkSOLHop(ctx context.Context, server *domain.Server, control *gatewayv1.HealthCheckHop) *gatewayv1.HealthCheckHop {
+	hop := &gatewayv1.HealthCheckHop{Name: healthHopSOL}
+	if server.SOLEndpoint == nil {
+		hop.Status = healthStatusSkipped
+		hop.Error = "server has no SOL endpoint"
+		return hop
+	}
+	hop.Target = server.SOLEndpoint.Endpoint
+
+	address, err := bmc.SOLAddress(server.SOLEndpoint)
+	if err != nil {
+		hop.Status = healthStatusFailed
+		hop.Error = err.Error()
+		return hop
+	}
+	if address == "" {
+		if control.Status == healthStatusOK {
+			hop.Status = healthStatusOK
+		} else {
+			hop.Status = healthStatusSkipped
+			hop.Error = "IPMI SOL runs over the failed control endpoint"
+		}
+		return hop
+	}
+	return checkConsoleHop(ctx, hop, address)
+}
+
+// checkVNCHop connects to the VNC endpoint of a server
+func checkVNCHop(ctx context.Context, server *domain.Server) *gatewayv1.HealthCheckHop {
+	hop := &gatewayv1.HealthCheckHop{Name: healthHopVNC}
+	if server.VNCEndpoint == nil {
+		hop.Status = healthStatusSkipped
+		hop.Error = "server has no VNC endpoint"
+		return hop
+	}
+	hop.Target = server.VNCEndpoint.Endpoint
+
+	address, err := bmc.VNCAddress(server.VNCEndpoint)
+	if err != nil {
+		hop.Status = healthStatusFailed
+		hop.Error = err.Error()
+		return hop
+	}
+	return checkConsoleHop(ctx, hop, address)
+}
+
+// checkConsoleHop sets the status of a console hop from a connection to its
+// address
+func checkConsoleHop(ctx context.Context, hop *gatewayv1.HealthCheckHop, address string) *gatewayv1.HealthCheckHop {
+	start := time.Now()
+	err := bmc.CheckReachable(ctx, address, consoleCheckTimeout)
+	hop.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		hop.Status = healthStatusFailed
+		hop.Error = err.Error()
+		return hop
+	}
+	hop.Status = healthStatusOK
+	return hop
+}
+
+// GetPowerReading reports the power consumption of a server from its BMC
+func (a *LocalAgent) GetPowerReading(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetPowerReadingRequest],
+) (*connect.Response[gatewayv1.GetPowerReadingResponse], error) {
+	start := time.Now()
+
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "power_reading", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	reading, err := a.bmcClient.GetPowerReading(ctx, server)
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "power_reading").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, meteringError(bmcType, "power_reading", err)
+	}
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "power_reading", "success").Inc()
+
+	return connect.NewResponse(&gatewayv1.GetPowerReadingResponse{
+		Reading: reading,
+	}), nil
+}
+
+// GetEnergyUsage reports the cumulative energy consumed by a server from its
+// BMC
+func (a *LocalAgent) GetEnergyUsage(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetEnergyUsageRequest],
+) (*connect.Response[gatewayv1.GetEnergyUsageResponse], error) {
+	start := time.Now()
+
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "energy_usage", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	energy, err := a.bmcClient.GetEnergyUsage(ctx, server)
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "energy_usage").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, meteringError(bmcType, "energy_usage", err)
+	}
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "energy_usage", "success").Inc()
+
+	return connect.NewResponse(&gatewayv1.GetEnergyUsageResponse{
+		EnergyKwh: energy,
+		ReadAt:    timestamppb.Now(),
+	}), nil
+}
+
+// meteringError records a failed metering operation and converts its error,
+// reporting BMCs that do not meter power as unimplemented
+func meteringError(bmcType, operation string, err error) error {
+	if errors.Is(err, bmc.ErrNotSupported) {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, operation, "unsupported").Inc()
+		return connect.NewError(connect.CodeUnimplemented, err)
+	}
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, operation, "failure").Inc()
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("%s failed: %w", strings.ReplaceAll(operation, "_", " "), err))
+}
+
+// ListActiveSessions reports the BMC console connections currently held open
+// by SOL and VNC streams, along with the configured concurrency limits
+func (a *LocalAgent) ListActiveSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListActiveSessionsRequest],
+) (*connect.Response[gatewayv1.ListActiveSessionsResponse], error) {
+	if req.Msg.AgentId != "" && req.Msg.AgentId != a.config.Agent.ID {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", req.Msg.AgentId))
+	}
+
+	resp := &gatewayv1.ListActiveSessionsResponse{
+		MaxSolSessions: int32(a.sessions.Limit(session.ProtocolSOL)),
+		MaxVncSessions: int32(a.sessions.Limit(session.ProtocolVNC)),
+	}
+
+	for _, info := range a.sessions.List() {
+		if req.Msg.ServerId != "" && info.ServerID != req.Msg.ServerId {
+			continue
+		}
+		resp.Sessions = append(resp.Sessions, &gatewayv1.ActiveSession{
+			SessionId: info.SessionID,
+			ServerId:  info.ServerID,
+			Protocol:  string(info.Protocol),
+			Endpoint:  info.Endpoint,
+			StartedAt: timestamppb.New(info.StartedAt),
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// maxProbeResponseSize caps the download payload of link probes
+const maxProbeResponseSize = 4 << 20
+
+// ProbeLink answers a gateway's link probe with a payload of the requested
+// size, so that the gateway can measure round-trip time and throughput
+func (a *LocalAgent) ProbeLink(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ProbeLinkRequest],
+) (*connect.Response[gatewayv1.ProbeLinkResponse], error) {
+	size := int(req.Msg.ResponseSize)
+	if size < 0 || size > maxProbeResponseSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("response_size must be between 0 and %d", maxProbeResponseSize))
+	}
+
+	// Random bytes, so that compression does not skew the measured throughput
+	payload := make([]byte, size)
+	if _, err := rand.Read(payload); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate probe payload: %w", err))
+	}
+
+	return connect.NewResponse(&gatewayv1.ProbeLinkResponse{
+		Payload: payload,
+	}), nil
+}
@@ -0,0 +1,183 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/internal/metrics"
+)
+
+// shutdownPollInterval is the time between power state checks of a server
+// shutting down gracefully
+const shutdownPollInterval = 5 * time.Second
+
+// shutdownForceOffTimeout bounds forcing off a server still on after its
+// graceful shutdown timeout
+const shutdownForceOffTimeout = 30 * time.Second
+
+// shutdownWatch forces a server off if it is still on after a graceful
+// shutdown timeout
+type shutdownWatch struct {
+	cancel context.CancelFunc
+}
+
+// GracefulShutdown asks the OS of a server to shut down. When
+// force_after_seconds is set, the agent watches the power state and forces
+// the server off if it is still on after that time.
+func (a *LocalAgent) GracefulShutdown(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GracefulShutdownRequest],
+) (*connect.Response[gatewayv1.GracefulShutdownResponse], error) {
+	start := time.Now()
+
+	if req.Msg.ForceAfterSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("force_after_seconds must not be negative"))
+	}
+
+	// Find the server by ID
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "graceful_shutdown", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	// A new shutdown replaces the timeout of a previous one
+	a.cancelShutdownWatch(req.Msg.ServerId)
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	if err := a.bmcClient.GracefulShutdown(ctx, server); err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "graceful_shutdown", "failure").Inc()
+		metrics.BMCOperationDuration.WithLabelValues(bmcType, "graceful_shutdown").Observe(time.Since(start).Seconds())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("graceful shutdown failed: %w", err))
+	}
+
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "graceful_shutdown", "success").Inc()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "graceful_shutdown").Observe(time.Since(start).Seconds())
+
+	resp := &gatewayv1.GracefulShutdownResponse{
+		Success: true,
+		Message: fmt.Sprintf("Graceful shutdown requested for server %s", req.Msg.ServerId),
+	}
+	if req.Msg.ForceAfterSeconds > 0 {
+		timeout := time.Duration(req.Msg.ForceAfterSeconds) * time.Second
+		a.startShutdownWatch(req.Msg.ServerId, server, timeout)
+		resp.ForceAt = timestamppb.New(time.Now().Add(timeout))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// ForceOff cuts the power of a server without notifying the OS
+func (a *LocalAgent) ForceOff(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerOperationRequest],
+) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	start := time.Now()
+
+	// Find the server by ID
+	server := a.discoveredServers[req.Msg.ServerId]
+	if server == nil {
+		metrics.BMCOperationsTotal.WithLabelValues("unknown", "force_off", "not_found").Inc()
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+
+	a.cancelShutdownWatch(req.Msg.ServerId)
+
+	bmcType := string(server.GetPrimaryControlEndpoint().Type)
+
+	if err := a.bmcClient.ForceOff(ctx, server); err != nil {
+		metrics.BMCOperationsTotal.WithLabelValues(bmcType, "force_off", "failure").Inc()
+		metrics.BMCOperationDuration.WithLabelValues(bmcType, "force_off").Observe(time.Since(start).Seconds())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("force off failed: %w", err))
+	}
+
+	metrics.BMCOperationsTotal.WithLabelValues(bmcType, "force_off", "success").Inc()
+	metrics.BMCOperationDuration.WithLabelValues(bmcType, "force_off").Observe(time.Since(start).Seconds())
+
+	resp := &gatewayv1.PowerOperationResponse{
+		Success: true,
+		Message: fmt.Sprintf("Force off operation completed for server %s", req.Msg.ServerId),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// startShutdownWatch polls the power state of a server shutting down and
+// forces it off if it is still on after timeout
+func (a *LocalAgent) startShutdownWatch(serverID string, server *domain.Server, timeout time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	watch := &shutdownWatch{cancel: cancel}
+
+	a.shutdownMu.Lock()
+	a.shutdownWatches[serverID] = watch
+	a.shutdownMu.Unlock()
+
+	go func() {
+		defer a.endShutdownWatch(serverID, watch)
+
+		ticker := time.NewTicker(shutdownPollInterval)
+		defer ticker.Stop()
+		deadline := time.NewTimer(timeout)
+		defer deadline.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				state, err := a.bmcClient.GetPowerState(ctx, server)
+				if err == nil && strings.EqualFold(state, "off") {
+					log.Debug().Str("server_id", serverID).Msg("Server shut down gracefully")
+					return
+				}
+			case <-deadline.C:
+				log.Warn().
+					Str("server_id", serverID).
+					Dur("timeout", timeout).
+					Msg("Server still on after graceful shutdown timeout, forcing off")
+				forceCtx, cancel := context.WithTimeout(ctx, shutdownForceOffTimeout)
+				defer cancel()
+				if err := a.bmcClient.ForceOff(forceCtx, server); err != nil {
+					log.Error().Err(err).Str("server_id", serverID).Msg("Failed to force off server")
+				}
+				return
+			}
+		}
+	}()
+}
+
+// cancelShutdownWatch cancels the pending forced shutdown of a server
+func (a *LocalAgent) cancelShutdownWatch(serverID string) {
+	a.shutdownMu.Lock()
+	defer a.shutdownMu.Unlock()
+
+	if watch, ok := a.shutdownWatches[serverID]; ok {
+		watch.cancel()
+		delete(a.shutdownWatches, serverID)
+	}
+}
+
+// clearPendingForcedShutdown cancels the forced shutdown pending on a server
+// before an explicit power operation, which overrides it: forcing the power
+// off after e.g. a power on would undo what the operator asked for.
+func (a *LocalAgent) clearPendingForcedShutdown(serverID string) {
+	a.cancelShutdownWatch(serverID)
+}
+
+// endShutdownWatch forgets a finished watch unless it was replaced
+func (a *LocalAgent) endShutdownWatch(serverID string, watch *shutdownWatch) {
+	a.shutdownMu.Lock()
+	defer a.shutdownMu.Unlock()
+
+	watch.cancel()
+	if a.shutdownWatches[serverID] == watch {
+		delete(a.shutdownWatches, serverID)
+	}
+}
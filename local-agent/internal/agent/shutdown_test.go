@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"core/domain"
+	"core/testing/redfishsim"
+	"core/types"
+	"local-agent/pkg/bmc"
+	"local-agent/pkg/redfish"
+)
+
+// newShutdownTestAgent creates an agent managing one server, whose BMC is sim
+func newShutdownTestAgent(sim *redfishsim.Server) (*LocalAgent, *domain.Server) {
+	agent := &LocalAgent{
+		bmcClient:       bmc.NewClient(nil, redfish.NewClient()),
+		shutdownWatches: make(map[string]*shutdownWatch),
+	}
+	server := &domain.Server{
+		ID: "server-1",
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: sim.URL,
+			Type:     types.BMCTypeRedfish,
+			Username: sim.Username(),
+			Password: sim.Password(),
+		}},
+	}
+	return agent, server
+}
+
+func TestShutdownWatch_ForcesOffAfterTimeout(t *testing.T) {
+	// The simulated server stays on, as if its OS ignored the shutdown request
+	sim := redfishsim.New(redfishsim.Options{})
+	defer sim.Close()
+
+	agent, server := newShutdownTestAgent(sim)
+
+	agent.startShutdownWatch(server.ID, server, 50*time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !slices.Contains(sim.Resets(), redfish.ResetTypeForceOff) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the server to be forced off, resets: %v", sim.Resets())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if sim.PowerState() != redfishsim.PowerStateOff {
+		t.Errorf("Expected power state %s, got %s", redfishsim.PowerStateOff, sim.PowerState())
+	}
+
+	// The finished watch is forgotten
+	for {
+		agent.shutdownMu.Lock()
+		watching := len(agent.shutdownWatches)
+		agent.shutdownMu.Unlock()
+		if watching == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the watch to end, %d still running", watching)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestShutdownWatch_Cancelled(t *testing.T) {
+	sim := redfishsim.New(redfishsim.Options{})
+	defer sim.Close()
+
+	agent, server := newShutdownTestAgent(sim)
+
+	agent.startShutdownWatch(server.ID, server, 100*time.Millisecond)
+	agent.cancelShutdownWatch(server.ID)
+
+	time.Sleep(300 * time.Millisecond)
+	if resets := sim.Resets(); len(resets) != 0 {
+		t.Errorf("Expected no reset after cancelling the watch, got %v", resets)
+	}
+}
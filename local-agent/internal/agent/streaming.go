@@ -2,16 +2,27 @@ package agent
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"core/streaming"
+	"core/tracing"
+	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/internal/metrics"
+	"local-agent/internal/session"
 	agentstreaming "local-agent/internal/streaming"
 	"local-agent/pkg/sol"
 	"local-agent/pkg/vnc"
+	"local-agent/pkg/vnc/rfb"
 )
 
 // StreamVNCData implements bidirectional streaming for VNC data
@@ -19,38 +30,76 @@ import (
 func (a *LocalAgent) StreamVNCData(
 	ctx context.Context,
 	stream *connect.BidiStream[gatewayv1.VNCDataChunk, gatewayv1.VNCDataChunk],
-) error {
+) (err error) {
 	log.Info().Msg("New VNC streaming connection")
 
-	// Receive handshake from gateway
-	helper := streaming.NewHandshakeHelper(&agentstreaming.VNCChunkFactory{})
-	sessionID, serverID, err := helper.ReceiveHandshake(stream)
+	// Receive handshake from gateway, acknowledged with the agent's
+	// capabilities once the BMC is connected
+	chunkSize := a.config.Agent.VNCConfig.ChunkSize
+	helper := streaming.NewHandshakeHelper(&agentstreaming.VNCChunkFactory{}).
+		WithCapabilities(streaming.Capabilities{Features: a.streamFeatures(), MaxChunkSize: chunkSize, Variant: streaming.VariantRFB})
+	handshake, err := helper.ReceiveHandshakeChunk(stream)
 	if err != nil {
 		return err
 	}
+	sessionID, serverID := handshake.SessionId, handshake.ServerId
 
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", serverID).
+		Int("protocol_version", helper.Negotiate(handshake).Version).
 		Msg("VNC handshake received")
 
+	// Continue the gateway's trace until the stream is set up
+	ctx, connectSpan := startConnectSpan(ctx, handshake, "vnc")
+	defer func() {
+		tracing.RecordError(connectSpan, err)
+		connectSpan.End()
+	}()
+
+	// Tell the client why the stream could not be set up
+	defer func() {
+		reportStreamError(stream, &agentstreaming.VNCChunkFactory{}, sessionID, serverID, err)
+	}()
+
+	// Only the gateway opens streams, before anything is learned of the server
+	if err := a.authenticateStream(handshake, "vnc"); err != nil {
+		return err
+	}
+
 	// Look up server in discovered servers
 	server, exists := a.discoveredServers[serverID]
 	if !exists {
-		return fmt.Errorf("server %s not found", serverID)
+		return connect.NewError(connect.CodeNotFound,
+			streaming.NewStreamError(streaming.ErrorCodeServerNotFound, "server %s not found", serverID))
 	}
 
 	// Check if server has VNC endpoint
 	if server.VNCEndpoint == nil {
-		return fmt.Errorf("VNC not available for server %s", serverID)
+		return connect.NewError(connect.CodeUnimplemented,
+			streaming.NewStreamError(streaming.ErrorCodeFeatureUnsupported, "VNC not available for server %s", serverID))
 	}
 
+	// Reserve a session slot before connecting to the BMC
+	consoleSession, err := a.acquireSession(session.Info{
+		SessionID: sessionID,
+		ServerID:  serverID,
+		Protocol:  session.ProtocolVNC,
+		Endpoint:  server.VNCEndpoint.Endpoint,
+	})
+	if err != nil {
+		return err
+	}
+	defer a.releaseSession(consoleSession)
+	ctx = consoleSession.Context(ctx)
+
 	// Create VNC endpoint configuration
 	// Transport type is auto-detected from endpoint URL scheme
 	vncEndpoint := &vnc.Endpoint{
-		Endpoint: server.VNCEndpoint.Endpoint,
-		Username: server.VNCEndpoint.Username,
-		Password: server.VNCEndpoint.Password,
+		Endpoint:       server.VNCEndpoint.Endpoint,
+		Username:       server.VNCEndpoint.Username,
+		Password:       server.VNCEndpoint.Password,
+		MaxMessageSize: a.config.Agent.BMCWebSocket.MaxMessageSize,
 	}
 
 	// Add TLS configuration if present (for VeNCrypt, RFB-over-TLS, enterprise BMCs)
@@ -73,7 +122,7 @@ func (a *LocalAgent) StreamVNCData(
 	if err != nil {
 		return fmt.Errorf("failed to create VNC transport: %w", err)
 	}
-	defer vncTransport.Close()
+	consoleSession.Attach(vncTransport)
 
 	log.Debug().
 		Str("endpoint", server.VNCEndpoint.Endpoint).
@@ -81,7 +130,7 @@ func (a *LocalAgent) StreamVNCData(
 
 	// Connect to VNC endpoint
 	if err := vnc.ConnectTransport(ctx, vncTransport, vncEndpoint); err != nil {
-		return fmt.Errorf("failed to connect to VNC endpoint: %w", err)
+		return bmcError(err, "failed to connect to VNC endpoint")
 	}
 
 	// Determine transport type for logging
@@ -105,9 +154,17 @@ func (a *LocalAgent) StreamVNCData(
 	// handshake and proxies to the authenticated BMC connection.
 	rfbProxy := vnc.NewRFBProxyHandler(vncTransport)
 
+	// Split framebuffer updates larger than the chunk size accepted by both
+	// ends, as advertised in the handshakes, checksumming every fragment
+	keepalive := keepAlive(a, stream, &agentstreaming.VNCChunkFactory{}, handshake)
+	checkedStream := verifyChecksums(a, keepalive, &agentstreaming.VNCChunkFactory{}, sessionID, serverID, "vnc")
+	checkedStream.Negotiate(handshake)
+	splitStream := streaming.SplitLargeChunks(checkedStream, &agentstreaming.VNCChunkFactory{}, chunkSize)
+	splitStream.Negotiate(handshake)
+
 	// Create a stream adapter for the RFB proxy handler
 	streamAdapter := &vncStreamAdapter{
-		stream:    stream,
+		stream:    splitStream,
 		sessionID: sessionID,
 		serverID:  serverID,
 	}
@@ -124,6 +181,8 @@ func (a *LocalAgent) StreamVNCData(
 	if err := helper.SendHandshakeAck(stream, sessionID, serverID); err != nil {
 		return fmt.Errorf("failed to send handshake ack: %w", err)
 	}
+	connectSpan.End()
+	defer pingGateway(ctx, a, keepalive, consoleSession)()
 
 	// Use TCP streaming proxy to handle bidirectional data flow
 	logger := log.With().
@@ -133,19 +192,36 @@ func (a *LocalAgent) StreamVNCData(
 		Str("transport", transportType).
 		Logger()
 
+	// Throttle the framebuffer updates of BMCs repeating unchanged frames
+	var bmcTransport streaming.TCPTransport = vncTransport
+	if throttle := a.vncThrottle(); throttle.Enabled() {
+		throttled, err := vnc.Throttle(vncTransport, throttle)
+		if err != nil {
+			return fmt.Errorf("failed to throttle VNC updates: %w", err)
+		}
+		bmcTransport = throttled
+	}
+
 	proxy := streaming.NewStreamToTCPProxy(
 		sessionID,
 		serverID,
 		logger,
 		&agentstreaming.VNCChunkFactory{},
-	)
-
-	return proxy.ProxyFromStream(ctx, stream, vncTransport)
+	).WithRecorder(newStreamRecorder(streaming.SessionInfo{
+		SessionID:  sessionID,
+		ServerID:   serverID,
+		Protocol:   "vnc",
+		Transport:  "connect",
+		ClientAddr: stream.Peer().Addr,
+		ServerAddr: server.VNCEndpoint.Endpoint,
+	})).WithBufferPool(a.streamBuffers)
+
+	return proxy.ProxyFromStream(ctx, splitStream, bmcTransport)
 }
 
 // vncStreamAdapter adapts the gRPC stream to io.ReadWriter for RFB proxy
 type vncStreamAdapter struct {
-	stream    *connect.BidiStream[gatewayv1.VNCDataChunk, gatewayv1.VNCDataChunk]
+	stream    streaming.ChunkStream[*gatewayv1.VNCDataChunk]
 	sessionID string
 	serverID  string
 	readBuf   []byte
@@ -213,80 +289,560 @@ func (v *vncStreamAdapter) Write(p []byte) (int, error) {
 func (a *LocalAgent) StreamConsoleData(
 	ctx context.Context,
 	stream *connect.BidiStream[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk],
-) error {
+) (err error) {
 	log.Info().Msg("New console streaming connection")
 
 	// Receive handshake from gateway
-	helper := streaming.NewHandshakeHelper(&agentstreaming.ConsoleChunkFactory{})
-	sessionID, serverID, err := helper.ReceiveHandshake(stream)
+	helper := streaming.NewHandshakeHelper(&agentstreaming.ConsoleChunkFactory{}).
+		WithCapabilities(streaming.Capabilities{Features: a.streamFeatures(), Variant: streaming.VariantSOL})
+	handshake, err := helper.ReceiveHandshakeChunk(stream)
 	if err != nil {
 		return err
 	}
+	sessionID, serverID := handshake.SessionId, handshake.ServerId
 
 	log.Info().
 		Str("session_id", sessionID).
 		Str("server_id", serverID).
+		Int("protocol_version", helper.Negotiate(handshake).Version).
+		Bool("force_takeover", handshake.ForceTakeover).
 		Msg("Console handshake received")
 
+	// Continue the gateway's trace until the stream is set up
+	ctx, connectSpan := startConnectSpan(ctx, handshake, "sol")
+	defer func() {
+		tracing.RecordError(connectSpan, err)
+		connectSpan.End()
+	}()
+
+	// Tell the client why the stream could not be set up
+	defer func() {
+		reportStreamError(stream, &agentstreaming.ConsoleChunkFactory{}, sessionID, serverID, err)
+	}()
+
+	// Only the gateway opens streams, before anything is learned of the server
+	if err := a.authenticateStream(handshake, "sol"); err != nil {
+		return err
+	}
+
+	// Serial settings requested for the session, applied before connecting
+	solSettings, err := streaming.SOLSettingsOf(streaming.MetadataOf(handshake))
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument,
+			streaming.NewStreamError(streaming.ErrorCodeInvalidSettings, "%v", err))
+	}
+
+	// Terminal of web consoles negotiating it
+	clientTerminal, err := streaming.TerminalHintsOf(streaming.MetadataOf(handshake))
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument,
+			streaming.NewStreamError(streaming.ErrorCodeInvalidSettings, "%v", err))
+	}
+
 	// Look up server in discovered servers
 	server, exists := a.discoveredServers[serverID]
 	if !exists {
-		return fmt.Errorf("server %s not found", serverID)
+		return connect.NewError(connect.CodeNotFound,
+			streaming.NewStreamError(streaming.ErrorCodeServerNotFound, "server %s not found", serverID))
 	}
 
 	// Check if server has SOL endpoint
 	if server.SOLEndpoint == nil {
-		return fmt.Errorf("SOL not available for server %s", serverID)
+		return connect.NewError(connect.CodeUnimplemented,
+			streaming.NewStreamError(streaming.ErrorCodeFeatureUnsupported, "SOL not available for server %s", serverID))
 	}
 
-	log.Debug().
-		Str("endpoint", server.SOLEndpoint.Endpoint).
-		Str("type", server.SOLEndpoint.Type.String()).
-		Msg("Connecting to SOL endpoint")
-
 	// Create SOL client using the factory based on BMC type
 	solClient, err := sol.NewClient(server.SOLEndpoint.Type)
 	if err != nil {
-		return fmt.Errorf("failed to create SOL client: %w", err)
+		return connect.NewError(connect.CodeUnimplemented,
+			streaming.NewStreamError(streaming.ErrorCodeFeatureUnsupported, "failed to create SOL client: %v", err))
+	}
+
+	// The BMC allows a single SOL session: reserve it before connecting,
+	// preempting the current owner only when the client asked to
+	info := session.Info{
+		SessionID: sessionID,
+		ServerID:  serverID,
+		Protocol:  session.ProtocolSOL,
+		Endpoint:  server.SOLEndpoint.Endpoint,
+	}
+	var consoleSession *session.Session
+	if handshake.ForceTakeover {
+		consoleSession, err = a.takeOverSOLSession(ctx, solClient, info, server.SOLEndpoint.Username, server.SOLEndpoint.Password)
+	} else {
+		consoleSession, err = a.acquireSession(info)
+	}
+	if err != nil {
+		return err
 	}
+	defer a.releaseSession(consoleSession)
+	ctx = consoleSession.Context(ctx)
+
+	log.Debug().
+		Str("endpoint", server.SOLEndpoint.Endpoint).
+		Str("type", server.SOLEndpoint.Type.String()).
+		Msg("Connecting to SOL endpoint")
 
 	// Prepare SOL config, inheriting TLS settings from control endpoint
 	solConfig := sol.DefaultSOLConfig()
+	solConfig.MaxMessageSize = a.config.Agent.BMCWebSocket.MaxMessageSize
+	solConfig.MaxWriteSize = a.config.Agent.BMCWebSocket.MaxWriteSize
 	if server.GetPrimaryControlEndpoint() != nil && server.GetPrimaryControlEndpoint().TLS != nil {
 		solConfig.InsecureSkipVerify = server.GetPrimaryControlEndpoint().TLS.InsecureSkipVerify
 	} else {
 		// Default to true for BMCs (they typically use self-signed certs)
 		solConfig.InsecureSkipVerify = true
 	}
+	if !solSettings.IsZero() {
+		solConfig.BaudRate = solSettings.BaudRate
+		solConfig.FlowControl = solSettings.FlowControl
+		solConfig.ApplySerialSettings = true
+
+		log.Info().
+			Str("server_id", serverID).
+			Int("baud_rate", solSettings.BaudRate).
+			Str("flow_control", solSettings.FlowControl).
+			Msg("Applying SOL serial settings")
+	}
 
 	// Create SOL session
 	solSession, err := solClient.CreateSession(ctx, server.SOLEndpoint.Endpoint, server.SOLEndpoint.Username, server.SOLEndpoint.Password, solConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create SOL session: %w", err)
+		return bmcError(err, "failed to create SOL session")
 	}
-	defer solSession.Close()
+	consoleSession.Attach(solSession)
 
 	log.Info().
 		Str("server_id", serverID).
 		Msg("Connected to SOL endpoint")
 
+	// Apply the client's terminal size, and tell the client which terminal
+	// the console is rendered for
+	var ackMetadata map[string]string
+	if !clientTerminal.IsZero() {
+		resized := clientTerminal.HasSize() && a.resizeSOLSession(ctx, solSession,
+			&gatewayv1.TerminalSize{Cols: uint32(clientTerminal.Cols), Rows: uint32(clientTerminal.Rows)}, serverID)
+		consoleTerminal := consoleTerminalHints(server.SOLEndpoint, clientTerminal, resized)
+		ackMetadata = consoleTerminal.AddTo(nil)
+
+		log.Debug().
+			Str("server_id", serverID).
+			Str("client_terminal", clientTerminal.Type).
+			Str("console_terminal", consoleTerminal.Type).
+			Int("cols", consoleTerminal.Cols).
+			Int("rows", consoleTerminal.Rows).
+			Msg("Negotiated console terminal")
+	}
+
 	// Send handshake acknowledgment back to gateway
-	if err := helper.SendHandshakeAck(stream, sessionID, serverID); err != nil {
+	if err := helper.SendHandshakeAckWithMetadata(stream, sessionID, serverID, ackMetadata); err != nil {
 		return fmt.Errorf("failed to send handshake ack: %w", err)
 	}
+	connectSpan.End()
 
 	// Proxy SOL data bidirectionally between stream and SOL session
-	return a.proxySOLSession(ctx, stream, solSession, sessionID, serverID)
+	keepalive := keepAlive(a, stream, &agentstreaming.ConsoleChunkFactory{}, handshake)
+	defer pingGateway(ctx, a, keepalive, consoleSession)()
+	checkedStream := verifyChecksums(a, keepalive, &agentstreaming.ConsoleChunkFactory{}, sessionID, serverID, "sol")
+	checkedStream.Negotiate(handshake)
+	return a.proxySOLSession(ctx, checkedStream, stream.Peer().Addr, solSession, consoleSession)
+}
+
+// vncThrottle returns the throttling of the framebuffer updates of BMCs
+func (a *LocalAgent) vncThrottle() vnc.ThrottleConfig {
+	return vnc.ThrottleConfig{
+		MaxFPS:        a.config.Agent.VNCConfig.MaxFPS,
+		SkipUnchanged: a.config.Agent.VNCConfig.SkipUnchangedFrames,
+		OnSkip:        metrics.ObserveSkippedFrame,
+	}
+}
+
+// streamFeatures returns the optional features advertised in the handshake
+// acknowledgments of console streams
+func (a *LocalAgent) streamFeatures() []streaming.Feature {
+	var features []streaming.Feature
+	if a.config.Agent.StreamChecksums {
+		features = append(features, streaming.FeatureChecksums)
+	}
+	if a.config.Agent.StreamKeepalive.Interval > 0 {
+		features = append(features, streaming.FeatureKeepalive)
+	}
+	return features
+}
+
+// keepAlive wraps a console stream so that it can ping the gateway, when the
+// gateway's handshake advertises keepalive and the agent's configuration
+// enables it
+func keepAlive[T streaming.StreamChunk](
+	a *LocalAgent,
+	stream streaming.ChunkStream[T],
+	factory streaming.ChunkFactory[T],
+	handshake T,
+) *streaming.KeepaliveStream[T] {
+	keepalive := streaming.WithKeepalive(stream, factory, handshake.GetSessionId(), handshake.GetServerId())
+	if a.config.Agent.StreamKeepalive.Interval > 0 {
+		keepalive.Negotiate(handshake)
+	}
+	return keepalive
+}
+
+// pingGateway pings the gateway of a set up console stream and has its
+// session reaped once the gateway stops answering. The returned function
+// stops the pings, before the stream's handler returns: nothing may be sent
+// once it has.
+func pingGateway[T streaming.StreamChunk](
+	ctx context.Context,
+	a *LocalAgent,
+	keepalive *streaming.KeepaliveStream[T],
+	consoleSession *session.Session,
+) func() {
+	if !keepalive.Negotiated() {
+		return func() {}
+	}
+	consoleSession.WatchUpstream(keepalive.LastReceived)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		keepalive.Ping(ctx, a.config.Agent.StreamKeepalive.Interval)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// verifyChecksums wraps a console stream so that the chunks sent to the
+// gateway carry checksums once negotiated, and the corrupted chunks received
+// are logged and counted
+func verifyChecksums[T streaming.StreamChunk](
+	a *LocalAgent,
+	stream streaming.ChunkStream[T],
+	factory streaming.ChunkFactory[T],
+	sessionID, serverID, protocol string,
+) *streaming.ChecksummedStream[T] {
+	return streaming.VerifyChecksums(stream, factory, a.config.Agent.StreamChecksums).
+		WithCorruptionObserver(func(err error) {
+			log.Warn().Err(err).
+				Str("session_id", sessionID).
+				Str("server_id", serverID).
+				Str("protocol", protocol).
+				Msg("Corrupted chunk received from gateway")
+			metrics.ObserveCorruptChunk(protocol)
+		})
+}
+
+// newStreamRecorder starts recording a console stream for its audit summary
+// and metrics
+func newStreamRecorder(info streaming.SessionInfo) *streaming.SessionRecorder {
+	return streaming.NewSessionRecorder(info, log.Logger, metrics.ObserveConsoleStream)
+}
+
+// startConnectSpan starts the span of a console stream's setup, continuing
+// the trace carried in the handshake metadata
+func startConnectSpan(ctx context.Context, handshake streaming.StreamChunk, protocol string) (context.Context, trace.Span) {
+	return tracing.Start(tracing.Extract(ctx, streaming.MetadataOf(handshake)), "agent.ConnectConsole",
+		attribute.String("console.protocol", protocol),
+		attribute.String("console.session_id", handshake.GetSessionId()),
+		attribute.String("server.id", handshake.GetServerId()),
+	)
+}
+
+// setStreamTokenKey sets the key verifying the tokens of the console streams
+// opened by the gateway, from its registration response. Gateways that send
+// no key predate stream tokens, their streams are accepted without one.
+func (a *LocalAgent) setStreamTokenKey(key []byte) error {
+	if len(key) != 0 && len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid stream token key of %d bytes from gateway", len(key))
+	}
+
+	a.streamTokenMu.Lock()
+	defer a.streamTokenMu.Unlock()
+	a.streamTokenKey = ed25519.PublicKey(key)
+	a.streamTokensLegacy = len(key) == 0
+	if a.streamTokensLegacy {
+		log.Warn().Msg("Gateway sent no stream token key, console streams are not authenticated")
+	}
+	return nil
+}
+
+// authenticateStream verifies the token of a console stream handshake, so
+// that only the gateway the agent registered with opens streams to its BMCs.
+// Streams are rejected until the agent registered.
+func (a *LocalAgent) authenticateStream(handshake streaming.StreamChunk, protocol string) error {
+	a.streamTokenMu.RLock()
+	key, legacy := a.streamTokenKey, a.streamTokensLegacy
+	a.streamTokenMu.RUnlock()
+	if legacy {
+		return nil
+	}
+
+	err := streaming.ErrInvalidStreamToken
+	if key != nil {
+		err = streaming.VerifyStreamToken(key, streaming.CapabilitiesOf(streaming.MetadataOf(handshake)).AuthToken, streaming.StreamClaims{
+			SessionID: handshake.GetSessionId(),
+			ServerID:  handshake.GetServerId(),
+			Protocol:  protocol,
+			AgentID:   a.config.Agent.ID,
+		}, time.Now())
+	}
+	if err != nil {
+		log.Warn().Err(err).
+			Str("session_id", handshake.GetSessionId()).
+			Str("server_id", handshake.GetServerId()).
+			Str("protocol", protocol).
+			Msg("Rejecting unauthenticated console stream")
+		return connect.NewError(connect.CodeUnauthenticated,
+			streaming.NewStreamError(streaming.ErrorCodeUnauthenticated, "stream not authenticated by the gateway"))
+	}
+	return nil
+}
+
+// acquireSession reserves a console session slot for a stream. Streams over
+// the configured limit are rejected before any BMC connection is made.
+func (a *LocalAgent) acquireSession(info session.Info) (*session.Session, error) {
+	consoleSession, err := a.sessions.Acquire(info)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("session_id", info.SessionID).
+			Str("server_id", info.ServerID).
+			Str("protocol", string(info.Protocol)).
+			Msg("Rejecting console stream")
+
+		return nil, sessionError(err)
+	}
+
+	return consoleSession, nil
+}
+
+// takeOverSOLSession reserves the SOL session of a BMC for a stream that
+// asked for a forced takeover. The stream currently attached, if any, is
+// disconnected, then the BMC's SOL payload is deactivated so that sessions
+// opened outside the agent are dropped too before reactivating it.
+func (a *LocalAgent) takeOverSOLSession(ctx context.Context, solClient sol.Client, info session.Info, username, password string) (*session.Session, error) {
+	consoleSession, preempted, err := a.sessions.TakeOver(info)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("session_id", info.SessionID).
+			Str("server_id", info.ServerID).
+			Msg("Rejecting console takeover")
+		return nil, sessionError(err)
+	}
+
+	if preempted != nil {
+		log.Warn().
+			Str("session_id", info.SessionID).
+			Str("server_id", info.ServerID).
+			Str("preempted_session_id", preempted.Info().SessionID).
+			Msg("Console session taken over")
+	}
+
+	deactivator, ok := solClient.(sol.Deactivator)
+	if !ok {
+		return consoleSession, nil
+	}
+
+	err = deactivator.Deactivate(ctx, info.Endpoint, username, password)
+	switch {
+	case errors.Is(err, sol.ErrDeactivateNotSupported):
+		log.Debug().
+			Str("server_id", info.ServerID).
+			Msg("SOL deactivation not supported by SOL transport, reconnecting directly")
+	case err != nil:
+		// The BMC may have no active payload to deactivate, connecting
+		// reports any remaining problem
+		log.Warn().Err(err).
+			Str("server_id", info.ServerID).
+			Msg("Failed to deactivate SOL payload before takeover")
+	}
+
+	return consoleSession, nil
+}
+
+// sessionError maps session manager errors to Connect errors
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, session.ErrSessionBusy):
+		return connect.NewError(connect.CodeFailedPrecondition,
+			streaming.NewStreamError(streaming.ErrorCodeSessionBusy, "%v", err))
+	case errors.Is(err, session.ErrLimitReached):
+		return connect.NewError(connect.CodeResourceExhausted,
+			streaming.NewStreamError(streaming.ErrorCodeSessionLimit, "%v", err))
+	default:
+		return connect.NewError(connect.CodeUnavailable,
+			streaming.NewStreamError(streaming.ErrorCodeInternal, "%v", err))
+	}
+}
+
+// bmcError maps a failure to connect to a BMC's console to a Connect error,
+// telling rejected credentials and serial settings apart from an unreachable
+// BMC
+func bmcError(err error, action string) error {
+	if errors.Is(err, rfb.ErrAuthenticationFailed) || errors.Is(err, sol.ErrAuthenticationFailed) {
+		return connect.NewError(connect.CodePermissionDenied,
+			streaming.NewStreamError(streaming.ErrorCodeBMCAuthFailed, "%s: %v", action, err))
+	}
+	if errors.Is(err, sol.ErrSerialSettingsNotSupported) {
+		return connect.NewError(connect.CodeInvalidArgument,
+			streaming.NewStreamError(streaming.ErrorCodeInvalidSettings, "%s: %v", action, err))
+	}
+	return connect.NewError(connect.CodeUnavailable,
+		streaming.NewStreamError(streaming.ErrorCodeBMCUnreachable, "%s: %v", action, err))
+}
+
+// reportStreamError sends the StreamError carried by err, if any, to the
+// gateway in an error chunk before the stream ends
+func reportStreamError[T streaming.StreamChunk](
+	stream interface{ Send(T) error },
+	factory streaming.ErrorChunkFactory[T],
+	sessionID, serverID string,
+	err error,
+) {
+	streamErr := streaming.AsStreamError(err)
+	if streamErr == nil {
+		return
+	}
+
+	if sendErr := stream.Send(factory.NewErrorChunk(sessionID, serverID, streamErr)); sendErr != nil {
+		log.Debug().Err(sendErr).
+			Str("session_id", sessionID).
+			Str("error_code", string(streamErr.Code)).
+			Msg("Failed to send stream error chunk")
+	}
+}
+
+// releaseSession frees a stream's session slot and closes its BMC connection
+func (a *LocalAgent) releaseSession(consoleSession *session.Session) {
+	info := consoleSession.Info()
+	if err := consoleSession.Release(); err != nil {
+		log.Debug().Err(err).
+			Str("session_id", info.SessionID).
+			Str("server_id", info.ServerID).
+			Msg("Error closing BMC console connection")
+	}
+
+	log.Info().
+		Str("session_id", info.SessionID).
+		Str("server_id", info.ServerID).
+		Str("protocol", string(info.Protocol)).
+		Dur("duration", time.Since(info.StartedAt)).
+		Msg("Console session released")
+}
+
+// reapOrphanedSessions releases the sessions of console streams whose
+// gateway stopped answering pings for the keepalive timeout, until ctx ends
+func (a *LocalAgent) reapOrphanedSessions(ctx context.Context) {
+	keepalive := a.config.Agent.StreamKeepalive
+	if keepalive.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(keepalive.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, info := range a.sessions.ReapOrphans(time.Now().Add(-keepalive.Timeout)) {
+				log.Warn().
+					Str("session_id", info.SessionID).
+					Str("server_id", info.ServerID).
+					Str("protocol", string(info.Protocol)).
+					Dur("timeout", keepalive.Timeout).
+					Msg("Closed BMC connection of console stream whose gateway stopped answering")
+				metrics.ObserveOrphanedStream(string(info.Protocol))
+			}
+		}
+	}
+}
+
+// defaultConsoleTerminal is the size serial consoles are rendered for when
+// their host configures none, the size of BIOS setup screens and of serial
+// gettys
+var defaultConsoleTerminal = types.TerminalConfig{Cols: 80, Rows: 24}
+
+// consoleTerminalHints returns the terminal a SOL console is rendered for:
+// the type configured for the server or else the client's, and the client's
+// size when the console was resized to it, or else the size configured for
+// the server's serial line
+func consoleTerminalHints(endpoint *types.SOLEndpoint, client streaming.TerminalHints, resized bool) streaming.TerminalHints {
+	configured := defaultConsoleTerminal
+	if endpoint.Terminal != nil {
+		configured.Type = endpoint.Terminal.Type
+		if endpoint.Terminal.Cols != 0 && endpoint.Terminal.Rows != 0 {
+			configured.Cols, configured.Rows = endpoint.Terminal.Cols, endpoint.Terminal.Rows
+		}
+	}
+
+	hints := streaming.TerminalHints{Type: client.Type, Cols: configured.Cols, Rows: configured.Rows}
+	if configured.Type != "" {
+		hints.Type = configured.Type
+	}
+	if resized {
+		hints.Cols, hints.Rows = client.Cols, client.Rows
+	}
+	return hints
+}
+
+// resizeSOLSession applies a client terminal size to the SOL session and
+// reports whether it was applied. Serial consoles have no notion of size, so
+// failures are logged and ignored.
+func (a *LocalAgent) resizeSOLSession(ctx context.Context, solSession sol.Session, size *gatewayv1.TerminalSize, serverID string) bool {
+	resizer, ok := solSession.(sol.Resizer)
+	if !ok {
+		return false
+	}
+
+	err := resizer.Resize(ctx, int(size.Cols), int(size.Rows))
+	switch {
+	case errors.Is(err, sol.ErrResizeNotSupported):
+		log.Debug().
+			Str("server_id", serverID).
+			Msg("Terminal resize not supported by SOL transport, ignoring")
+	case err != nil:
+		log.Warn().Err(err).
+			Str("server_id", serverID).
+			Uint32("cols", size.Cols).
+			Uint32("rows", size.Rows).
+			Msg("Failed to resize SOL session")
+	default:
+		log.Debug().
+			Str("server_id", serverID).
+			Uint32("cols", size.Cols).
+			Uint32("rows", size.Rows).
+			Msg("Applied terminal size to SOL session")
+		return true
+	}
+	return false
 }
 
 // proxySOLSession proxies data between buf Connect stream and SOL session
 func (a *LocalAgent) proxySOLSession(
 	ctx context.Context,
-	stream *connect.BidiStream[gatewayv1.ConsoleDataChunk, gatewayv1.ConsoleDataChunk],
+	stream streaming.ChunkStream[*gatewayv1.ConsoleDataChunk],
+	clientAddr string,
 	solSession sol.Session,
-	sessionID, serverID string,
+	consoleSession *session.Session,
 ) error {
-	errChan := make(chan error, 2)
+	info := consoleSession.Info()
+	sessionID, serverID := info.SessionID, info.ServerID
+	recorder := newStreamRecorder(streaming.SessionInfo{
+		SessionID:  sessionID,
+		ServerID:   serverID,
+		Protocol:   "sol",
+		Transport:  "connect",
+		ClientAddr: clientAddr,
+		ServerAddr: info.Endpoint,
+	})
+
+	type proxyEnd struct {
+		reason streaming.DisconnectReason
+		err    error
+	}
+	errChan := make(chan proxyEnd, 2)
 
 	// Goroutine: SOL -> Stream (read from BMC, send to gateway)
 	go func() {
@@ -295,7 +851,12 @@ func (a *LocalAgent) proxySOLSession(
 			// Read from SOL session
 			data, err := solSession.Read(ctx)
 			if err != nil {
-				errChan <- fmt.Errorf("SOL read error: %w", err)
+				if ctx.Err() != nil {
+					errChan <- proxyEnd{streaming.DisconnectClientClosed, fmt.Errorf("SOL read error: %w", err)}
+				} else {
+					// The SOL session connects to the BMC on first read
+					errChan <- proxyEnd{streaming.DisconnectServerClosed, bmcError(err, "SOL read error")}
+				}
 				return
 			}
 
@@ -312,9 +873,10 @@ func (a *LocalAgent) proxySOLSession(
 				}
 
 				if err := stream.Send(chunk); err != nil {
-					errChan <- fmt.Errorf("stream send error: %w", err)
+					errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("stream send error: %w", err)}
 					return
 				}
+				recorder.RecordOutput(len(data))
 			}
 		}
 	}()
@@ -325,14 +887,18 @@ func (a *LocalAgent) proxySOLSession(
 		for {
 			chunk, err := stream.Receive()
 			if err != nil {
-				errChan <- fmt.Errorf("stream receive error: %w", err)
+				if errors.Is(err, io.EOF) {
+					errChan <- proxyEnd{streaming.DisconnectClientClosed, nil}
+				} else {
+					errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("stream receive error: %w", err)}
+				}
 				return
 			}
 
 			// Check for close signal
 			if chunk.CloseStream {
 				log.Debug().Msg("Received close signal from stream")
-				errChan <- fmt.Errorf("stream closed")
+				errChan <- proxyEnd{streaming.DisconnectClientClosed, nil}
 				return
 			}
 
@@ -341,21 +907,57 @@ func (a *LocalAgent) proxySOLSession(
 				continue
 			}
 
+			// Apply terminal size changes where the console supports it
+			if chunk.Resize != nil {
+				a.resizeSOLSession(ctx, solSession, chunk.Resize, serverID)
+			}
+
 			if len(chunk.Data) > 0 {
 				// log.Debug().Int("bytes", len(chunk.Data)).Msg("Forwarding data from stream to SOL")
 
 				// Write to SOL session
 				if err := solSession.Write(ctx, chunk.Data); err != nil {
-					errChan <- fmt.Errorf("SOL write error: %w", err)
+					errChan <- proxyEnd{streaming.DisconnectTransportError, fmt.Errorf("SOL write error: %w", err)}
 					return
 				}
+				recorder.RecordInput(len(chunk.Data))
 			}
 		}
 	}()
 
-	// Wait for either direction to fail
-	err := <-errChan
-	log.Info().Err(err).Msg("Console proxy terminated")
+	// Wait for either direction to fail, or the session to be reaped
+	var end proxyEnd
+	select {
+	case end = <-errChan:
+	case <-ctx.Done():
+		end = proxyEnd{streaming.DisconnectSessionEnded, context.Cause(ctx)}
+	}
+	takenBy := consoleSession.PreemptedBy()
+	if takenBy != "" {
+		end = proxyEnd{streaming.DisconnectSessionEnded, fmt.Errorf("console taken over by session %s", takenBy)}
+	}
+	log.Info().Err(end.err).Str("reason", string(end.reason)).Msg("Console proxy terminated")
+	recorder.Close(end.reason, end.err)
+
+	// Nothing can be told to a gateway that is gone
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	// Tell the client when the BMC went away, the error chunk closes the stream
+	if streaming.AsStreamError(end.err) != nil {
+		reportStreamError(stream, &agentstreaming.ConsoleChunkFactory{}, sessionID, serverID, end.err)
+		return nil
+	}
+
+	// Tell the client why the console went away when another stream took it
+	if takenBy != "" {
+		stream.Send(&gatewayv1.ConsoleDataChunk{
+			SessionId: sessionID,
+			ServerId:  serverID,
+			Data:      []byte(fmt.Sprintf("\r\n[console taken over by session %s]\r\n", takenBy)),
+		})
+	}
 
 	// Send close signal
 	closeChunk := &gatewayv1.ConsoleDataChunk{
@@ -9,6 +9,10 @@ import (
 type AgentState interface {
 	GetServerCount() int
 	IsRegistered() bool
+
+	// GetSessionCounts returns the number of active SOL and VNC sessions by
+	// server ID
+	GetSessionCounts() (sol, vnc map[string]int)
 }
 
 // Collector periodically updates gauge metrics from agent state
@@ -89,11 +93,16 @@ func (c *Collector) collectConnectionMetrics() {
 
 // collectSessionMetrics updates session-related metrics
 func (c *Collector) collectSessionMetrics() {
-	// Reset session metrics
+	sol, vnc := c.agent.GetSessionCounts()
+
+	// Reset so that servers without sessions left are no longer reported
 	SOLSessionsTotal.Reset()
-	VNCSessionsTotal.Reset()
+	for serverID, count := range sol {
+		SOLSessionsTotal.WithLabelValues(serverID).Set(float64(count))
+	}
 
-	// TODO: Implement session tracking
-	// Need to extend agent to expose active session counts
-	// For now, these will report 0
+	VNCSessionsTotal.Reset()
+	for serverID, count := range vnc {
+		VNCSessionsTotal.WithLabelValues(serverID).Set(float64(count))
+	}
 }
@@ -101,6 +101,14 @@ var (
 		[]string{"bmc_type", "error_type"},
 	)
 
+	PowerOperationsDeduplicatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_power_operations_deduplicated_total",
+			Help: "Total number of power operations answered with the result of an earlier call with their idempotency key",
+		},
+		[]string{"operation"},
+	)
+
 	// SOL/Console Sessions
 
 	SOLSessionsTotal = promauto.NewGaugeVec(
@@ -161,6 +169,55 @@ var (
 		[]string{"error_type"},
 	)
 
+	VNCSkippedFramesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "agent_vnc_skipped_frames_total",
+			Help: "Total number of unchanged VNC framebuffer updates skipped",
+		},
+	)
+
+	VNCSkippedBytesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "agent_vnc_skipped_bytes_total",
+			Help: "Total number of bytes of the unchanged VNC framebuffer updates skipped",
+		},
+	)
+
+	// Console Streams
+
+	ConsoleStreamsClosedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_console_streams_closed_total",
+			Help: "Total number of closed console streams by disconnect reason",
+		},
+		[]string{"type", "reason"},
+	)
+
+	ConsoleStreamDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "agent_console_stream_duration_seconds",
+			Help:    "Console stream lifetime in seconds",
+			Buckets: []float64{1, 10, 60, 300, 900, 1800, 3600, 7200},
+		},
+		[]string{"type"},
+	)
+
+	ConsoleStreamCorruptChunksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_console_stream_corrupt_chunks_total",
+			Help: "Total number of console chunks received from the gateway failing their checksum",
+		},
+		[]string{"type"},
+	)
+
+	ConsoleStreamsOrphanedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "agent_console_streams_orphaned_total",
+			Help: "Total number of console streams whose BMC connection was closed after their gateway stopped answering pings",
+		},
+		[]string{"type"},
+	)
+
 	// HTTP/RPC Metrics
 
 	HTTPRequestsTotal = promauto.NewCounterVec(
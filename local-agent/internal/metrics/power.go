@@ -0,0 +1,7 @@
+package metrics
+
+// ObservePowerDeduplication records a power operation answered with the
+// result of an earlier call sent with its idempotency key
+func ObservePowerDeduplication(operation string) {
+	PowerOperationsDeduplicatedTotal.WithLabelValues(operation).Inc()
+}
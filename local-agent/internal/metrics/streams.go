@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"core/streaming"
+)
+
+// ObserveConsoleStream records the summary of a closed console stream
+func ObserveConsoleStream(summary streaming.SessionSummary) {
+	bytesTotal := SOLBytesTotal
+	if summary.Protocol == "vnc" {
+		bytesTotal = VNCBytesTotal
+	}
+	bytesTotal.WithLabelValues("in").Add(float64(summary.BytesIn))
+	bytesTotal.WithLabelValues("out").Add(float64(summary.BytesOut))
+
+	ConsoleStreamsClosedTotal.WithLabelValues(summary.Protocol, string(summary.Reason)).Inc()
+	ConsoleStreamDuration.WithLabelValues(summary.Protocol).Observe(summary.Duration.Seconds())
+}
+
+// ObserveCorruptChunk records a console chunk received from the gateway
+// failing its checksum
+func ObserveCorruptChunk(protocol string) {
+	ConsoleStreamCorruptChunksTotal.WithLabelValues(protocol).Inc()
+}
+
+// ObserveOrphanedStream records a console stream reaped after its gateway
+// stopped answering pings
+func ObserveOrphanedStream(protocol string) {
+	ConsoleStreamsOrphanedTotal.WithLabelValues(protocol).Inc()
+}
+
+// ObserveSkippedFrame records an unchanged framebuffer update of a BMC
+// skipped, of the given size
+func ObserveSkippedFrame(bytes int) {
+	VNCSkippedFramesTotal.Inc()
+	VNCSkippedBytesTotal.Add(float64(bytes))
+}
@@ -0,0 +1,372 @@
+// Package session tracks the BMC console connections held open by the agent.
+//
+// Every console stream (SOL or VNC) acquires a slot from the Manager before
+// connecting to the BMC, attaches the BMC connection once it is established,
+// and releases the slot when the stream ends. Releasing closes the BMC-side
+// connection, so a session is never leaked when a stream drops, and Manager
+// enforces the configured number of concurrent sessions per protocol.
+//
+// Streams whose gateway answers keepalive pings watch their upstream: the
+// time a chunk was last received from it. ReapOrphans releases the sessions
+// whose upstream went silent, e.g. after a gateway crash, so that their BMC
+// connections don't stay open forever.
+//
+// Most BMCs allow a single SOL session at a time, and a second activation
+// either fails or silently kills the first. SOL sessions are therefore
+// exclusive per BMC endpoint: Acquire reports the current owner with a
+// BusyError, and TakeOver explicitly preempts it.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Protocol identifies the console protocol of a session
+type Protocol string
+
+const (
+	ProtocolSOL Protocol = "sol"
+	ProtocolVNC Protocol = "vnc"
+)
+
+var (
+	// ErrLimitReached is returned by Acquire when the protocol's concurrent
+	// session limit is reached
+	ErrLimitReached = errors.New("concurrent session limit reached")
+
+	// ErrClosed is returned by Acquire after CloseAll, during agent shutdown
+	ErrClosed = errors.New("session manager closed")
+
+	// ErrSessionBusy is returned by Acquire when another stream holds the
+	// SOL session of the same BMC. The error is a *BusyError.
+	ErrSessionBusy = errors.New("SOL session busy")
+
+	// ErrOrphaned is the cause of the context of a session reaped by
+	// ReapOrphans, whose upstream stopped answering
+	ErrOrphaned = errors.New("console stream upstream unresponsive")
+)
+
+// BusyError reports the session holding a BMC's SOL console
+type BusyError struct {
+	Owner Info
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("%s: server %s is attached to session %s since %s",
+		ErrSessionBusy, e.Owner.ServerID, e.Owner.SessionID, e.Owner.StartedAt.UTC().Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrSessionBusy) match
+func (e *BusyError) Is(target error) bool {
+	return target == ErrSessionBusy
+}
+
+// Info describes an active console session
+type Info struct {
+	SessionID string
+	ServerID  string
+	Protocol  Protocol
+	Endpoint  string
+	StartedAt time.Time
+}
+
+// Manager tracks active console sessions and enforces concurrency limits
+type Manager struct {
+	mu       sync.Mutex
+	limits   map[Protocol]int
+	sessions map[uint64]*Session
+	nextKey  uint64
+	closed   bool
+	now      func() time.Time
+}
+
+// NewManager creates a session manager. limits maps each protocol to its
+// maximum number of concurrent sessions; protocols without a positive limit
+// are unlimited.
+func NewManager(limits map[Protocol]int) *Manager {
+	copied := make(map[Protocol]int, len(limits))
+	for protocol, limit := range limits {
+		copied[protocol] = limit
+	}
+
+	return &Manager{
+		limits:   copied,
+		sessions: make(map[uint64]*Session),
+		now:      time.Now,
+	}
+}
+
+// Limit returns the concurrent session limit of a protocol, 0 if unlimited
+func (m *Manager) Limit(protocol Protocol) int {
+	if limit := m.limits[protocol]; limit > 0 {
+		return limit
+	}
+	return 0
+}
+
+// Acquire reserves a session slot. The caller must Release the returned
+// session when the stream ends, typically with defer. A SOL session is
+// rejected with a *BusyError while another one is open on the same endpoint.
+func (m *Manager) Acquire(info Info) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	if owner := m.ownerLocked(info); owner != nil {
+		return nil, &BusyError{Owner: owner.info}
+	}
+
+	return m.reserveLocked(info)
+}
+
+// TakeOver reserves a session slot like Acquire, preempting the session that
+// holds the SOL console of the same endpoint, if any. The preempted session is
+// released, closing its BMC connection, and returned so that the caller can
+// report the takeover; its PreemptedBy names the new session.
+func (m *Manager) TakeOver(info Info) (*Session, *Session, error) {
+	m.mu.Lock()
+
+	if m.closed {
+		m.mu.Unlock()
+		return nil, nil, ErrClosed
+	}
+
+	owner := m.ownerLocked(info)
+	if owner != nil {
+		delete(m.sessions, owner.key)
+	}
+
+	s, err := m.reserveLocked(info)
+	if err != nil {
+		// The owner keeps the console if the new session is not admitted
+		if owner != nil {
+			m.sessions[owner.key] = owner
+		}
+		m.mu.Unlock()
+		return nil, nil, err
+	}
+	m.mu.Unlock()
+
+	if owner != nil {
+		owner.preempt(info.SessionID)
+	}
+	return s, owner, nil
+}
+
+// ownerLocked returns the SOL session holding the console of info's
+// endpoint. Sessions without an endpoint are never exclusive.
+func (m *Manager) ownerLocked(info Info) *Session {
+	if info.Protocol != ProtocolSOL || info.Endpoint == "" {
+		return nil
+	}
+	for _, s := range m.sessions {
+		if s.info.Protocol == ProtocolSOL && s.info.Endpoint == info.Endpoint {
+			return s
+		}
+	}
+	return nil
+}
+
+// reserveLocked checks the protocol limit and registers a new session
+func (m *Manager) reserveLocked(info Info) (*Session, error) {
+	if limit := m.Limit(info.Protocol); limit > 0 {
+		active := 0
+		for _, s := range m.sessions {
+			if s.info.Protocol == info.Protocol {
+				active++
+			}
+		}
+		if active >= limit {
+			return nil, fmt.Errorf("%w: %d of %d %s sessions in use", ErrLimitReached, active, limit, info.Protocol)
+		}
+	}
+
+	if info.StartedAt.IsZero() {
+		info.StartedAt = m.now()
+	}
+
+	m.nextKey++
+	s := &Session{manager: m, key: m.nextKey, info: info}
+	m.sessions[s.key] = s
+	return s, nil
+}
+
+// List returns the active sessions, oldest first
+func (m *Manager) List() []Info {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := make([]Info, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		infos = append(infos, s.info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].StartedAt.Equal(infos[j].StartedAt) {
+			return infos[i].StartedAt.Before(infos[j].StartedAt)
+		}
+		return infos[i].SessionID < infos[j].SessionID
+	})
+	return infos
+}
+
+// ReapOrphans releases the sessions whose upstream was last seen before
+// staleBefore, closing their BMC connections and ending their contexts with
+// ErrOrphaned, and returns them. Sessions not watching their upstream are
+// never reaped.
+func (m *Manager) ReapOrphans(staleBefore time.Time) []Info {
+	m.mu.Lock()
+	var orphans []*Session
+	for _, s := range m.sessions {
+		if lastSeen := s.upstreamSeen(); !lastSeen.IsZero() && lastSeen.Before(staleBefore) {
+			orphans = append(orphans, s)
+		}
+	}
+	m.mu.Unlock()
+
+	infos := make([]Info, 0, len(orphans))
+	for _, s := range orphans {
+		s.mu.Lock()
+		released, cancel := s.released, s.cancel
+		s.mu.Unlock()
+		if released {
+			continue
+		}
+		if cancel != nil {
+			cancel(ErrOrphaned)
+		}
+		_ = s.Release()
+		infos = append(infos, s.info)
+	}
+	return infos
+}
+
+// CloseAll releases every session, closing their BMC connections, and
+// rejects new sessions. It is called when the agent shuts down.
+func (m *Manager) CloseAll() {
+	m.mu.Lock()
+	m.closed = true
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		sessions = append(sessions, s)
+	}
+	m.mu.Unlock()
+
+	for _, s := range sessions {
+		s.Release()
+	}
+}
+
+// Session is a slot held by one console stream
+type Session struct {
+	manager *Manager
+	key     uint64
+	info    Info
+
+	mu          sync.Mutex
+	conn        io.Closer
+	released    bool
+	preemptedBy string
+	upstream    func() time.Time
+	cancel      context.CancelCauseFunc
+}
+
+// Info returns the session description
+func (s *Session) Info() Info {
+	return s.info
+}
+
+// PreemptedBy returns the ID of the session that took over this one, or an
+// empty string if the session was not taken over
+func (s *Session) PreemptedBy() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.preemptedBy
+}
+
+// WatchUpstream makes the session an orphan when lastSeen, the time a chunk
+// was last received from the stream's gateway, gets older than the reaper's
+// timeout
+func (s *Session) WatchUpstream(lastSeen func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.upstream = lastSeen
+}
+
+// upstreamSeen returns when the upstream was last seen, zero when not watched
+func (s *Session) upstreamSeen() time.Time {
+	s.mu.Lock()
+	lastSeen := s.upstream
+	s.mu.Unlock()
+	if lastSeen == nil {
+		return time.Time{}
+	}
+	return lastSeen()
+}
+
+// Context returns a context of parent ending with ErrOrphaned when the
+// session is reaped, so that its stream ends even when blocked sending to
+// the gone upstream
+func (s *Session) Context(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancelCause(parent)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel = cancel
+	return ctx
+}
+
+// preempt records the session taking over and releases this one
+func (s *Session) preempt(sessionID string) {
+	s.mu.Lock()
+	s.preemptedBy = sessionID
+	s.mu.Unlock()
+
+	_ = s.Release()
+}
+
+// Attach registers the BMC connection of the session, which is closed on
+// Release. If the session was already released, e.g. by CloseAll while the
+// connection was being established, the connection is closed immediately.
+func (s *Session) Attach(conn io.Closer) {
+	s.mu.Lock()
+	if !s.released {
+		s.conn = conn
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	_ = conn.Close()
+}
+
+// Release frees the slot and closes the attached BMC connection. It is safe
+// to call multiple times.
+func (s *Session) Release() error {
+	s.mu.Lock()
+	if s.released {
+		s.mu.Unlock()
+		return nil
+	}
+	s.released = true
+	conn := s.conn
+	s.conn = nil
+	s.mu.Unlock()
+
+	s.manager.mu.Lock()
+	delete(s.manager.sessions, s.key)
+	s.manager.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
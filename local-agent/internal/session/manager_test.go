@@ -0,0 +1,233 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeConn counts Close calls
+type fakeConn struct {
+	closed int
+}
+
+func (c *fakeConn) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestManagerEnforcesLimits(t *testing.T) {
+	manager := NewManager(map[Protocol]int{ProtocolSOL: 2})
+
+	first, err := manager.Acquire(Info{SessionID: "sol-1", ServerID: "server-1", Protocol: ProtocolSOL})
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if _, err := manager.Acquire(Info{SessionID: "sol-2", ServerID: "server-2", Protocol: ProtocolSOL}); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	if _, err := manager.Acquire(Info{SessionID: "sol-3", Protocol: ProtocolSOL}); !errors.Is(err, ErrLimitReached) {
+		t.Fatalf("Expected ErrLimitReached, got %v", err)
+	}
+
+	// Protocols without a limit are unlimited
+	for i := 0; i < 5; i++ {
+		if _, err := manager.Acquire(Info{SessionID: "vnc", Protocol: ProtocolVNC}); err != nil {
+			t.Fatalf("VNC Acquire failed: %v", err)
+		}
+	}
+
+	// Releasing frees the slot
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := manager.Acquire(Info{SessionID: "sol-3", Protocol: ProtocolSOL}); err != nil {
+		t.Fatalf("Acquire after release failed: %v", err)
+	}
+
+	if manager.Limit(ProtocolSOL) != 2 || manager.Limit(ProtocolVNC) != 0 {
+		t.Errorf("Unexpected limits: sol=%d vnc=%d", manager.Limit(ProtocolSOL), manager.Limit(ProtocolVNC))
+	}
+}
+
+func TestSessionReleaseClosesConnection(t *testing.T) {
+	manager := NewManager(nil)
+	base := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	newer, _ := manager.Acquire(Info{SessionID: "sol-2", Protocol: ProtocolSOL, StartedAt: base.Add(time.Minute)})
+	older, _ := manager.Acquire(Info{SessionID: "sol-1", Protocol: ProtocolSOL, StartedAt: base})
+
+	infos := manager.List()
+	if len(infos) != 2 || infos[0].SessionID != "sol-1" || infos[1].SessionID != "sol-2" {
+		t.Fatalf("Expected sessions oldest first, got %+v", infos)
+	}
+
+	conn := &fakeConn{}
+	older.Attach(conn)
+	older.Release()
+	older.Release()
+	if conn.closed != 1 {
+		t.Errorf("Expected connection closed once, got %d", conn.closed)
+	}
+
+	infos = manager.List()
+	if len(infos) != 1 || infos[0].SessionID != "sol-2" {
+		t.Errorf("Expected [sol-2] after release, got %+v", infos)
+	}
+
+	newer.Release()
+	if len(manager.List()) != 0 {
+		t.Error("Expected no sessions after release")
+	}
+}
+
+func TestManagerCloseAll(t *testing.T) {
+	manager := NewManager(nil)
+
+	active, _ := manager.Acquire(Info{SessionID: "sol-1", Protocol: ProtocolSOL})
+	conn := &fakeConn{}
+	active.Attach(conn)
+
+	connecting, _ := manager.Acquire(Info{SessionID: "vnc-1", Protocol: ProtocolVNC})
+
+	manager.CloseAll()
+
+	if conn.closed != 1 {
+		t.Errorf("Expected attached connection to be closed, got %d closes", conn.closed)
+	}
+	if len(manager.List()) != 0 {
+		t.Error("Expected no sessions after CloseAll")
+	}
+
+	// A connection established after CloseAll is closed immediately
+	late := &fakeConn{}
+	connecting.Attach(late)
+	if late.closed != 1 {
+		t.Errorf("Expected late connection to be closed, got %d closes", late.closed)
+	}
+
+	if _, err := manager.Acquire(Info{SessionID: "sol-2", Protocol: ProtocolSOL}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after CloseAll, got %v", err)
+	}
+}
+
+func TestManagerSOLSessionsAreExclusivePerEndpoint(t *testing.T) {
+	manager := NewManager(nil)
+	startedAt := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	owner, err := manager.Acquire(Info{SessionID: "sol-1", ServerID: "server-1", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.1:623", StartedAt: startedAt})
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	_, err = manager.Acquire(Info{SessionID: "sol-2", ServerID: "server-1", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.1:623"})
+	if !errors.Is(err, ErrSessionBusy) {
+		t.Fatalf("Expected ErrSessionBusy, got %v", err)
+	}
+	var busy *BusyError
+	if !errors.As(err, &busy) || busy.Owner.SessionID != "sol-1" || !busy.Owner.StartedAt.Equal(startedAt) {
+		t.Errorf("Expected BusyError owned by sol-1, got %v", err)
+	}
+
+	// Other BMCs and other protocols are not affected
+	if _, err := manager.Acquire(Info{SessionID: "sol-3", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.2:623"}); err != nil {
+		t.Errorf("Acquire on another endpoint failed: %v", err)
+	}
+	if _, err := manager.Acquire(Info{SessionID: "vnc-1", Protocol: ProtocolVNC, Endpoint: "ipmi://10.0.0.1:623"}); err != nil {
+		t.Errorf("VNC Acquire on the same endpoint failed: %v", err)
+	}
+
+	// The endpoint is free again once the owner is released
+	owner.Release()
+	if _, err := manager.Acquire(Info{SessionID: "sol-2", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.1:623"}); err != nil {
+		t.Errorf("Acquire after release failed: %v", err)
+	}
+}
+
+func TestManagerTakeOver(t *testing.T) {
+	manager := NewManager(map[Protocol]int{ProtocolSOL: 2})
+	endpoint := "ipmi://10.0.0.1:623"
+
+	owner, _ := manager.Acquire(Info{SessionID: "sol-1", Protocol: ProtocolSOL, Endpoint: endpoint})
+	conn := &fakeConn{}
+	owner.Attach(conn)
+
+	taker, preempted, err := manager.TakeOver(Info{SessionID: "sol-2", Protocol: ProtocolSOL, Endpoint: endpoint})
+	if err != nil {
+		t.Fatalf("TakeOver failed: %v", err)
+	}
+	if preempted != owner {
+		t.Fatalf("Expected sol-1 to be preempted, got %+v", preempted)
+	}
+	if conn.closed != 1 {
+		t.Errorf("Expected preempted connection to be closed, got %d closes", conn.closed)
+	}
+	if owner.PreemptedBy() != "sol-2" || taker.PreemptedBy() != "" {
+		t.Errorf("Unexpected PreemptedBy: owner=%q taker=%q", owner.PreemptedBy(), taker.PreemptedBy())
+	}
+
+	infos := manager.List()
+	if len(infos) != 1 || infos[0].SessionID != "sol-2" {
+		t.Errorf("Expected [sol-2] after takeover, got %+v", infos)
+	}
+
+	// Taking over a free endpoint preempts nothing
+	_, preempted, err = manager.TakeOver(Info{SessionID: "sol-3", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.2:623"})
+	if err != nil || preempted != nil {
+		t.Errorf("Expected takeover of a free endpoint, got preempted=%v err=%v", preempted, err)
+	}
+
+	// Taking over a free endpoint is still subject to the session limit
+	if _, _, err := manager.TakeOver(Info{SessionID: "sol-4", Protocol: ProtocolSOL, Endpoint: "ipmi://10.0.0.3:623"}); !errors.Is(err, ErrLimitReached) {
+		t.Errorf("Expected ErrLimitReached, got %v", err)
+	}
+	if len(manager.List()) != 2 {
+		t.Errorf("Expected 2 sessions after rejected takeover, got %+v", manager.List())
+	}
+}
+
+func TestManagerReapOrphans(t *testing.T) {
+	manager := NewManager(nil)
+	now := time.Now()
+
+	orphan, err := manager.Acquire(Info{SessionID: "vnc-1", Protocol: ProtocolVNC})
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	orphanConn := &fakeConn{}
+	orphan.Attach(orphanConn)
+	orphan.WatchUpstream(func() time.Time { return now.Add(-2 * time.Minute) })
+	ctx := orphan.Context(context.Background())
+
+	healthy, err := manager.Acquire(Info{SessionID: "vnc-2", Protocol: ProtocolVNC})
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	healthy.WatchUpstream(func() time.Time { return now })
+
+	// Sessions of gateways not answering pings are never reaped
+	if _, err := manager.Acquire(Info{SessionID: "vnc-3", Protocol: ProtocolVNC}); err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	reaped := manager.ReapOrphans(now.Add(-time.Minute))
+	if len(reaped) != 1 || reaped[0].SessionID != "vnc-1" {
+		t.Fatalf("Expected vnc-1 to be reaped, got %v", reaped)
+	}
+	if orphanConn.closed != 1 {
+		t.Errorf("Expected the orphan's BMC connection to be closed once, got %d", orphanConn.closed)
+	}
+	if !errors.Is(context.Cause(ctx), ErrOrphaned) {
+		t.Errorf("Expected the orphan's context to end with ErrOrphaned, got %v", context.Cause(ctx))
+	}
+	if sessions := manager.List(); len(sessions) != 2 {
+		t.Errorf("Expected 2 sessions left, got %d", len(sessions))
+	}
+
+	// Released sessions are reaped once
+	if reaped := manager.ReapOrphans(now.Add(-time.Minute)); len(reaped) != 0 {
+		t.Errorf("Expected nothing more to reap, got %v", reaped)
+	}
+}
@@ -119,7 +119,7 @@ func (c *Client) PowerOn(ctx context.Context, server *domain.Server) error {
 	}
 }
 
-// PowerOff powers off a server
+// PowerOff forces a server off
 func (c *Client) PowerOff(ctx context.Context, server *domain.Server) error {
 	if server == nil {
 		return fmt.Errorf("server is nil")
@@ -164,6 +164,57 @@ func (c *Client) PowerOff(ctx context.Context, server *domain.Server) error {
 	}
 }
 
+// ForceOff forces a server off without notifying the OS. It is the same as
+// PowerOff, which has always been forced.
+func (c *Client) ForceOff(ctx context.Context, server *domain.Server) error {
+	return c.PowerOff(ctx, server)
+}
+
+// GracefulShutdown asks the OS of a server to shut down
+func (c *Client) GracefulShutdown(ctx context.Context, server *domain.Server) error {
+	if server == nil {
+		return fmt.Errorf("server is nil")
+	}
+
+	if len(server.ControlEndpoints) == 0 {
+		return fmt.Errorf("server has no control endpoint")
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint() // Use primary endpoint
+	if controlEndpoint == nil {
+		return fmt.Errorf("server has no primary control endpoint")
+	}
+
+	if c.ipmiClient == nil && controlEndpoint.Type == types.BMCTypeIPMI {
+		return fmt.Errorf("IPMI client is nil")
+	}
+
+	if c.redfishClient == nil && controlEndpoint.Type == types.BMCTypeRedfish {
+		return fmt.Errorf("Redfish client is nil")
+	}
+
+	endpoint := controlEndpoint.Endpoint
+	username := controlEndpoint.Username
+	password := controlEndpoint.Password
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeIPMI:
+		if err := c.ipmiClient.GracefulShutdown(ctx, endpoint, username, password); err != nil {
+			return fmt.Errorf("IPMI GracefulShutdown failed: %w", err)
+		}
+		return nil
+
+	case types.BMCTypeRedfish:
+		if err := c.redfishClient.GracefulShutdown(ctx, endpoint, username, password); err != nil {
+			return fmt.Errorf("redfish GracefulShutdown failed: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
+
 // PowerCycle power cycles a server
 func (c *Client) PowerCycle(ctx context.Context, server *domain.Server) error {
 	if server == nil {
@@ -250,6 +301,47 @@ func (c *Client) Reset(ctx context.Context, server *domain.Server) error {
 	}
 }
 
+// DiagnosticInterrupt sends a non-maskable interrupt (NMI) to a server
+func (c *Client) DiagnosticInterrupt(ctx context.Context, server *domain.Server) error {
+	if server == nil {
+		return fmt.Errorf("server is nil")
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint() // Use primary endpoint
+	if controlEndpoint == nil {
+		return fmt.Errorf("server has no primary control endpoint")
+	}
+
+	if c.ipmiClient == nil && controlEndpoint.Type == types.BMCTypeIPMI {
+		return fmt.Errorf("IPMI client is nil")
+	}
+
+	if c.redfishClient == nil && controlEndpoint.Type == types.BMCTypeRedfish {
+		return fmt.Errorf("redfish client is nil")
+	}
+
+	endpoint := controlEndpoint.Endpoint
+	username := controlEndpoint.Username
+	password := controlEndpoint.Password
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeIPMI:
+		if err := c.ipmiClient.DiagnosticInterrupt(ctx, endpoint, username, password); err != nil {
+			return fmt.Errorf("IPMI DiagnosticInterrupt failed: %w", err)
+		}
+		return nil
+
+	case types.BMCTypeRedfish:
+		if err := c.redfishClient.DiagnosticInterrupt(ctx, endpoint, username, password); err != nil {
+			return fmt.Errorf("redfish DiagnosticInterrupt failed: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
+
 // GetBMCInfo retrieves detailed BMC hardware information
 func (c *Client) GetBMCInfo(ctx context.Context, server *domain.Server) (*gatewayv1.BMCInfo, error) {
 	if server == nil {
@@ -2,10 +2,16 @@ package bmc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"core/domain"
 	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
 	"local-agent/pkg/ipmi"
 	"local-agent/pkg/redfish"
 )
@@ -167,8 +173,11 @@ func TestClient_AllOperations_NoControlEndpoint(t *testing.T) {
 	}{
 		{"PowerOn", func() error { return client.PowerOn(ctx, server) }},
 		{"PowerOff", func() error { return client.PowerOff(ctx, server) }},
+		{"GracefulShutdown", func() error { return client.GracefulShutdown(ctx, server) }},
+		{"ForceOff", func() error { return client.ForceOff(ctx, server) }},
 		{"PowerCycle", func() error { return client.PowerCycle(ctx, server) }},
 		{"Reset", func() error { return client.Reset(ctx, server) }},
+		{"DiagnosticInterrupt", func() error { return client.DiagnosticInterrupt(ctx, server) }},
 	}
 
 	for _, op := range operations {
@@ -202,8 +211,11 @@ func TestClient_AllOperations_UnsupportedType(t *testing.T) {
 	}{
 		{"PowerOn", func() error { return client.PowerOn(ctx, server) }},
 		{"PowerOff", func() error { return client.PowerOff(ctx, server) }},
+		{"GracefulShutdown", func() error { return client.GracefulShutdown(ctx, server) }},
+		{"ForceOff", func() error { return client.ForceOff(ctx, server) }},
 		{"PowerCycle", func() error { return client.PowerCycle(ctx, server) }},
 		{"Reset", func() error { return client.Reset(ctx, server) }},
+		{"DiagnosticInterrupt", func() error { return client.DiagnosticInterrupt(ctx, server) }},
 	}
 
 	for _, op := range operations {
@@ -277,3 +289,123 @@ func TestClient_Redfish_Routing(t *testing.T) {
 		}
 	}
 }
+
+func TestClient_PowerMetering_IPMINotSupported(t *testing.T) {
+	client := NewClient(ipmi.NewClient(), redfish.NewClient())
+
+	server := &domain.Server{
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: "192.168.1.100:623",
+			Type:     types.BMCTypeIPMI,
+		}},
+	}
+
+	ctx := context.Background()
+
+	if _, err := client.GetPowerReading(ctx, server); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported from GetPowerReading, got: %v", err)
+	}
+
+	if _, err := client.GetEnergyUsage(ctx, server); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported from GetEnergyUsage, got: %v", err)
+	}
+}
+
+func TestClient_SetChassisIdentify_Redfish(t *testing.T) {
+	var led string
+	bmcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/":
+			w.Write([]byte(`{"Systems": {"@odata.id": "/redfish/v1/Systems"}}`))
+		case "/redfish/v1/Systems":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`))
+		case "/redfish/v1/Systems/1":
+			if r.Method == "PATCH" {
+				var payload struct{ IndicatorLED string }
+				json.NewDecoder(r.Body).Decode(&payload)
+				led = payload.IndicatorLED
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`{"@odata.id": "/redfish/v1/Systems/1", "IndicatorLED": "Off"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer bmcServer.Close()
+
+	client := NewClient(ipmi.NewClient(), redfish.NewClient())
+	server := &domain.Server{
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: bmcServer.URL,
+			Type:     types.BMCTypeRedfish,
+		}},
+	}
+
+	timed, err := client.SetChassisIdentify(context.Background(), server, gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("SetChassisIdentify failed: %v", err)
+	}
+	if led != redfish.IndicatorLEDBlinking {
+		t.Errorf("Expected IndicatorLED Blinking, got %q", led)
+	}
+	// Redfish LEDs have no timer, the caller turns them off
+	if timed {
+		t.Error("Expected Redfish identify not to be timed by the BMC")
+	}
+}
+
+func TestClient_GetNetworkConfig_Redfish(t *testing.T) {
+	bmcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/Managers":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/1"}]}`))
+		case "/redfish/v1/Managers/1":
+			w.Write([]byte(`{"Id": "1", "EthernetInterfaces": {"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces"}}`))
+		case "/redfish/v1/Managers/1/EthernetInterfaces":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces/1"}]}`))
+		case "/redfish/v1/Managers/1/EthernetInterfaces/1":
+			w.Write([]byte(`{
+				"Id": "1",
+				"MACAddress": "d0:94:66:12:34:56",
+				"IPv4Addresses": [{"Address": "10.0.0.12", "SubnetMask": "255.255.255.0", "AddressOrigin": "DHCP", "Gateway": "10.0.0.1"}],
+				"IPv6Addresses": [{"Address": "fd00::12", "PrefixLength": 64}],
+				"VLAN": {"VLANEnable": false, "VLANId": 1}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer bmcServer.Close()
+
+	client := NewClient(ipmi.NewClient(), redfish.NewClient())
+	server := &domain.Server{
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: bmcServer.URL,
+			Type:     types.BMCTypeRedfish,
+		}},
+	}
+
+	interfaces, err := client.GetNetworkConfig(context.Background(), server)
+	if err != nil {
+		t.Fatalf("GetNetworkConfig failed: %v", err)
+	}
+	if len(interfaces) != 1 {
+		t.Fatalf("Expected 1 interface, got %d", len(interfaces))
+	}
+
+	iface := interfaces[0]
+	if iface.Ipv4Address != "10.0.0.12" || iface.AddressOrigin != "dhcp" || iface.Gateway != "10.0.0.1" {
+		t.Errorf("Unexpected IPv4 configuration %+v", iface)
+	}
+	if len(iface.Ipv6Addresses) != 1 || iface.Ipv6Addresses[0] != "fd00::12/64" {
+		t.Errorf("Expected the IPv6 address with its prefix, got %v", iface.Ipv6Addresses)
+	}
+	// Disabled VLANs are not reported
+	if iface.VlanId != 0 {
+		t.Errorf("Expected no VLAN, got %d", iface.VlanId)
+	}
+	if !iface.Enabled {
+		t.Error("Expected interfaces without InterfaceEnabled to be enabled")
+	}
+}
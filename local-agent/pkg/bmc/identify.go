@@ -0,0 +1,74 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+// SetChassisIdentify sets the identify (locate) LED of a server. A blinking
+// LED is turned off by the BMC after duration when timed reports true; the
+// caller turns it off otherwise, e.g. over Redfish or for IPMI durations
+// beyond ipmi.MaxIdentifyInterval.
+func (c *Client) SetChassisIdentify(ctx context.Context, server *domain.Server, state gatewayv1.IdentifyState, duration time.Duration) (timed bool, err error) {
+	if server == nil {
+		return false, fmt.Errorf("server is nil")
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint()
+	if controlEndpoint == nil {
+		return false, fmt.Errorf("server has no primary control endpoint")
+	}
+
+	endpoint := controlEndpoint.Endpoint
+	username := controlEndpoint.Username
+	password := controlEndpoint.Password
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return false, fmt.Errorf("IPMI client is nil")
+		}
+
+		// IPMI has no blink mode: the identify LED blinks whenever it is on
+		on, interval := true, time.Duration(0)
+		switch state {
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_OFF:
+			on = false
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK:
+			if duration >= time.Second && duration <= ipmi.MaxIdentifyInterval {
+				interval, timed = duration.Truncate(time.Second), true
+			}
+		}
+		if err := c.ipmiClient.ChassisIdentify(ctx, endpoint, username, password, on, interval); err != nil {
+			return false, fmt.Errorf("IPMI ChassisIdentify failed: %w", err)
+		}
+		return timed, nil
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return false, fmt.Errorf("redfish client is nil")
+		}
+
+		led := redfish.IndicatorLEDLit
+		switch state {
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_OFF:
+			led = redfish.IndicatorLEDOff
+		case gatewayv1.IdentifyState_IDENTIFY_STATE_BLINK:
+			led = redfish.IndicatorLEDBlinking
+		}
+		if err := c.redfishClient.SetIndicatorLED(ctx, endpoint, username, password, led); err != nil {
+			return false, fmt.Errorf("redfish SetIndicatorLED failed: %w", err)
+		}
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
@@ -0,0 +1,88 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+// GetNetworkConfig retrieves the network configuration of the management
+// interfaces of a server's BMC: the LAN channel of IPMI BMCs, the Manager's
+// EthernetInterfaces of Redfish BMCs
+func (c *Client) GetNetworkConfig(ctx context.Context, server *domain.Server) ([]*gatewayv1.BMCNetworkInterface, error) {
+	if server == nil {
+		return nil, fmt.Errorf("server is nil")
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint()
+	if controlEndpoint == nil {
+		return nil, fmt.Errorf("server has no primary control endpoint")
+	}
+
+	endpoint := controlEndpoint.Endpoint
+	username := controlEndpoint.Username
+	password := controlEndpoint.Password
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return nil, fmt.Errorf("IPMI client is nil")
+		}
+
+		lan, err := c.ipmiClient.GetLANConfig(ctx, endpoint, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("IPMI GetLANConfig failed: %w", err)
+		}
+		return []*gatewayv1.BMCNetworkInterface{{
+			MacAddress:    lan.MACAddress,
+			AddressOrigin: lan.AddressSource,
+			Ipv4Address:   lan.IPAddress,
+			SubnetMask:    lan.SubnetMask,
+			Gateway:       lan.DefaultGateway,
+			VlanId:        int32(lan.VLANID),
+			Enabled:       true,
+		}}, nil
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return nil, fmt.Errorf("redfish client is nil")
+		}
+
+		ethernetInterfaces, err := c.redfishClient.GetEthernetInterfaces(ctx, endpoint, username, password)
+		if err != nil {
+			return nil, fmt.Errorf("redfish GetEthernetInterfaces failed: %w", err)
+		}
+
+		interfaces := make([]*gatewayv1.BMCNetworkInterface, 0, len(ethernetInterfaces))
+		for _, ethernet := range ethernetInterfaces {
+			iface := &gatewayv1.BMCNetworkInterface{
+				Id:         ethernet.ID,
+				MacAddress: ethernet.MACAddress,
+				Enabled:    ethernet.InterfaceEnabled == nil || *ethernet.InterfaceEnabled,
+			}
+			// BMCs report a single IPv4 address per interface in practice
+			if len(ethernet.IPv4Addresses) > 0 {
+				ipv4 := ethernet.IPv4Addresses[0]
+				iface.Ipv4Address = ipv4.Address
+				iface.SubnetMask = ipv4.SubnetMask
+				iface.Gateway = ipv4.Gateway
+				iface.AddressOrigin = strings.ToLower(ipv4.AddressOrigin)
+			}
+			for _, ipv6 := range ethernet.IPv6Addresses {
+				iface.Ipv6Addresses = append(iface.Ipv6Addresses, fmt.Sprintf("%s/%d", ipv6.Address, ipv6.PrefixLength))
+			}
+			if ethernet.VLAN.VLANEnable {
+				iface.VlanId = ethernet.VLAN.VLANID
+			}
+			interfaces = append(interfaces, iface)
+		}
+		return interfaces, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
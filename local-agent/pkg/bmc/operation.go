@@ -0,0 +1,82 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/pkg/redfish"
+)
+
+// redfishResetTypes maps power operations to Redfish ResetTypes
+var redfishResetTypes = map[gatewayv1.PowerOperation]string{
+	gatewayv1.PowerOperation_POWER_OPERATION_ON:                   redfish.ResetTypeOn,
+	gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF:            redfish.ResetTypeForceOff,
+	gatewayv1.PowerOperation_POWER_OPERATION_CYCLE:                redfish.ResetTypePowerCycle,
+	gatewayv1.PowerOperation_POWER_OPERATION_RESET:                redfish.ResetTypeForceRestart,
+	gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT: redfish.ResetTypeNmi,
+}
+
+// RunPowerOperation performs a power operation and returns once the BMC
+// completed it. Redfish BMCs may run the operation as a task, whose progress
+// is reported to onProgress (if not nil); IPMI operations complete when the
+// command returns and report no progress.
+func (c *Client) RunPowerOperation(ctx context.Context, server *domain.Server, operation gatewayv1.PowerOperation, onProgress redfish.TaskProgressFunc) error {
+	if server == nil {
+		return fmt.Errorf("server is nil")
+	}
+
+	resetType, ok := redfishResetTypes[operation]
+	if !ok {
+		return fmt.Errorf("unsupported power operation: %s", operation)
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint()
+	if controlEndpoint == nil {
+		return fmt.Errorf("server has no primary control endpoint")
+	}
+
+	endpoint := controlEndpoint.Endpoint
+	username := controlEndpoint.Username
+	password := controlEndpoint.Password
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return fmt.Errorf("IPMI client is nil")
+		}
+
+		var err error
+		switch operation {
+		case gatewayv1.PowerOperation_POWER_OPERATION_ON:
+			err = c.ipmiClient.PowerOn(ctx, endpoint, username, password)
+		case gatewayv1.PowerOperation_POWER_OPERATION_FORCE_OFF:
+			err = c.ipmiClient.PowerOff(ctx, endpoint, username, password)
+		case gatewayv1.PowerOperation_POWER_OPERATION_CYCLE:
+			err = c.ipmiClient.PowerCycle(ctx, endpoint, username, password)
+		case gatewayv1.PowerOperation_POWER_OPERATION_RESET:
+			err = c.ipmiClient.Reset(ctx, endpoint, username, password)
+		case gatewayv1.PowerOperation_POWER_OPERATION_DIAGNOSTIC_INTERRUPT:
+			err = c.ipmiClient.DiagnosticInterrupt(ctx, endpoint, username, password)
+		}
+		if err != nil {
+			return fmt.Errorf("IPMI %s failed: %w", resetType, err)
+		}
+		return nil
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return fmt.Errorf("redfish client is nil")
+		}
+
+		if err := c.redfishClient.RunPowerAction(ctx, endpoint, username, password, resetType, onProgress); err != nil {
+			return fmt.Errorf("redfish %s failed: %w", resetType, err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
@@ -0,0 +1,88 @@
+package bmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"local-agent/pkg/redfish"
+)
+
+// ErrNotSupported indicates that the BMC of a server does not support an
+// operation, e.g. power metering over IPMI
+var ErrNotSupported = errors.New("operation not supported by BMC")
+
+// GetPowerReading retrieves the power consumption of a server. Only Redfish
+// BMCs report power readings.
+func (c *Client) GetPowerReading(ctx context.Context, server *domain.Server) (*gatewayv1.PowerReading, error) {
+	endpoint, err := c.meteringEndpoint(server)
+	if err != nil {
+		return nil, err
+	}
+
+	reading, err := c.redfishClient.GetPowerReading(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+	if err != nil {
+		if errors.Is(err, redfish.ErrMeteringNotSupported) {
+			return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+		return nil, fmt.Errorf("Redfish GetPowerReading failed: %w", err)
+	}
+
+	return &gatewayv1.PowerReading{
+		ConsumedWatts:   reading.ConsumedWatts,
+		AverageWatts:    reading.AverageWatts,
+		MinWatts:        reading.MinWatts,
+		MaxWatts:        reading.MaxWatts,
+		IntervalMinutes: int32(reading.IntervalMinutes),
+		CapacityWatts:   reading.CapacityWatts,
+		ReadAt:          timestamppb.Now(),
+	}, nil
+}
+
+// GetEnergyUsage retrieves the cumulative energy consumed by a server, in
+// kWh. Only Redfish BMCs implementing EnvironmentMetrics report it.
+func (c *Client) GetEnergyUsage(ctx context.Context, server *domain.Server) (float64, error) {
+	endpoint, err := c.meteringEndpoint(server)
+	if err != nil {
+		return 0, err
+	}
+
+	energy, err := c.redfishClient.GetEnergyUsage(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+	if err != nil {
+		if errors.Is(err, redfish.ErrMeteringNotSupported) {
+			return 0, fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+		return 0, fmt.Errorf("Redfish GetEnergyUsage failed: %w", err)
+	}
+	return energy, nil
+}
+
+// meteringEndpoint returns the primary control endpoint of a server if it
+// supports power metering
+func (c *Client) meteringEndpoint(server *domain.Server) (*types.BMCControlEndpoint, error) {
+	if server == nil {
+		return nil, fmt.Errorf("server is nil")
+	}
+
+	controlEndpoint := server.GetPrimaryControlEndpoint()
+	if controlEndpoint == nil {
+		return nil, fmt.Errorf("server has no primary control endpoint")
+	}
+
+	switch controlEndpoint.Type {
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return nil, fmt.Errorf("Redfish client is nil")
+		}
+		return controlEndpoint, nil
+	case types.BMCTypeIPMI:
+		return nil, fmt.Errorf("%w: power metering requires Redfish", ErrNotSupported)
+	default:
+		return nil, fmt.Errorf("unsupported BMC type: %s", controlEndpoint.Type)
+	}
+}
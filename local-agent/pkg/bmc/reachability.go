@@ -0,0 +1,104 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"core/types"
+	"local-agent/pkg/bmcproxy"
+)
+
+// ControlAddress returns the TCP address of the control endpoint of a
+// server. IPMI runs over UDP and has no TCP address: it returns "" for it.
+func ControlAddress(endpoint *types.BMCControlEndpoint) (string, error) {
+	if endpoint == nil || endpoint.Endpoint == "" {
+		return "", fmt.Errorf("server has no control endpoint")
+	}
+
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		return "", nil
+	case types.BMCTypeRedfish:
+		return endpointAddress(endpoint.Endpoint, "443")
+	default:
+		return "", fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
+
+// SOLAddress returns the TCP address the agent connects to for the serial
+// console of a server. IPMI SOL runs in the RMCP+ session of the control
+// endpoint and has no address of its own: it returns "" for it.
+func SOLAddress(endpoint *types.SOLEndpoint) (string, error) {
+	if endpoint == nil || endpoint.Endpoint == "" {
+		return "", fmt.Errorf("server has no SOL endpoint")
+	}
+
+	switch endpoint.Type {
+	case types.SOLTypeIPMI:
+		return "", nil
+	case types.SOLTypeRedfishSerial:
+		// The console WebSocket is served by the Redfish service
+		return endpointAddress(endpoint.Endpoint, "443")
+	default:
+		return "", fmt.Errorf("unsupported SOL type: %s", endpoint.Type)
+	}
+}
+
+// VNCAddress returns the TCP address the agent connects to for the VNC
+// console of a server
+func VNCAddress(endpoint *types.VNCEndpoint) (string, error) {
+	if endpoint == nil || endpoint.Endpoint == "" {
+		return "", fmt.Errorf("server has no VNC endpoint")
+	}
+
+	switch endpoint.Type {
+	case types.VNCTypeNative, types.VNCTypeNone:
+		return endpointAddress(endpoint.Endpoint, "5900")
+	case types.VNCTypeWebSocket:
+		return endpointAddress(endpoint.Endpoint, "443")
+	default:
+		return "", fmt.Errorf("unsupported VNC type: %s", endpoint.Type)
+	}
+}
+
+// CheckReachable opens and closes a TCP connection to address, through the
+// proxy of the BMC if it has one
+func CheckReachable(ctx context.Context, address string, timeout time.Duration) error {
+	conn, err := bmcproxy.NewDialer(timeout).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// endpointAddress returns the host:port of an endpoint given as a URL or as
+// host[:port]. URLs without a port use the default port of their scheme.
+func endpointAddress(endpoint, defaultPort string) (string, error) {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid endpoint URL %s: %w", endpoint, err)
+		}
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "http", "ws":
+				port = "80"
+			case "https", "wss":
+				port = "443"
+			default:
+				port = defaultPort
+			}
+		}
+		return net.JoinHostPort(u.Hostname(), port), nil
+	}
+
+	if host, port, err := net.SplitHostPort(endpoint); err == nil {
+		return net.JoinHostPort(host, port), nil
+	}
+	return net.JoinHostPort(strings.Trim(endpoint, "[]"), defaultPort), nil
+}
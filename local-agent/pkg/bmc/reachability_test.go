@@ -0,0 +1,108 @@
+package bmc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"core/types"
+)
+
+func TestControlAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *types.BMCControlEndpoint
+		want     string
+		wantErr  bool
+	}{
+		{"IPMI", &types.BMCControlEndpoint{Type: types.BMCTypeIPMI, Endpoint: "192.168.1.100:623"}, "", false},
+		{"Redfish URL", &types.BMCControlEndpoint{Type: types.BMCTypeRedfish, Endpoint: "https://192.168.1.100"}, "192.168.1.100:443", false},
+		{"Redfish host", &types.BMCControlEndpoint{Type: types.BMCTypeRedfish, Endpoint: "bmc.local"}, "bmc.local:443", false},
+		{"no endpoint", nil, "", true},
+		{"unsupported type", &types.BMCControlEndpoint{Type: "snmp", Endpoint: "192.168.1.100"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ControlAddress(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ControlAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ControlAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSOLAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *types.SOLEndpoint
+		want     string
+		wantErr  bool
+	}{
+		{"IPMI", &types.SOLEndpoint{Type: types.SOLTypeIPMI, Endpoint: "192.168.1.100:623"}, "", false},
+		{"Redfish URL", &types.SOLEndpoint{Type: types.SOLTypeRedfishSerial, Endpoint: "https://192.168.1.100"}, "192.168.1.100:443", false},
+		{"Redfish URL with port", &types.SOLEndpoint{Type: types.SOLTypeRedfishSerial, Endpoint: "http://bmc.local:8000"}, "bmc.local:8000", false},
+		{"no endpoint", nil, "", true},
+		{"unsupported type", &types.SOLEndpoint{Type: "telnet", Endpoint: "192.168.1.100"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SOLAddress(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SOLAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SOLAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVNCAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *types.VNCEndpoint
+		want     string
+	}{
+		{"native host", &types.VNCEndpoint{Type: types.VNCTypeNative, Endpoint: "192.168.1.100"}, "192.168.1.100:5900"},
+		{"native host and port", &types.VNCEndpoint{Type: types.VNCTypeNative, Endpoint: "192.168.1.100:5901"}, "192.168.1.100:5901"},
+		{"native IPv6", &types.VNCEndpoint{Type: types.VNCTypeNative, Endpoint: "[fd00::10]"}, "[fd00::10]:5900"},
+		{"native URL", &types.VNCEndpoint{Type: types.VNCTypeNative, Endpoint: "vnc://bmc.local"}, "bmc.local:5900"},
+		{"WebSocket", &types.VNCEndpoint{Type: types.VNCTypeWebSocket, Endpoint: "wss://bmc.local/kvm/0"}, "bmc.local:443"},
+		{"WebSocket with port", &types.VNCEndpoint{Type: types.VNCTypeWebSocket, Endpoint: "ws://bmc.local:8080/kvm/0"}, "bmc.local:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VNCAddress(tt.endpoint)
+			if err != nil {
+				t.Fatalf("VNCAddress() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("VNCAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	address := listener.Addr().String()
+
+	if err := CheckReachable(context.Background(), address, time.Second); err != nil {
+		t.Errorf("Expected %s to be reachable, got %v", address, err)
+	}
+
+	listener.Close()
+	if err := CheckReachable(context.Background(), address, time.Second); err == nil {
+		t.Errorf("Expected %s to be unreachable once closed", address)
+	}
+}
@@ -0,0 +1,148 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"core/types"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+// Privilege levels of BMC users, mapped to the privilege levels of IPMI LAN
+// channels and to the roles of Redfish accounts
+const (
+	PrivilegeAdministrator = ipmi.PrivilegeAdministrator
+	PrivilegeOperator      = ipmi.PrivilegeOperator
+	PrivilegeUser          = ipmi.PrivilegeUser
+)
+
+// redfishRoles maps privilege levels to Redfish account roles
+var redfishRoles = map[string]string{
+	PrivilegeAdministrator: redfish.RoleAdministrator,
+	PrivilegeOperator:      redfish.RoleOperator,
+	PrivilegeUser:          redfish.RoleReadOnly,
+}
+
+// CheckCredentials checks that the BMC of a control endpoint accepts its
+// credentials, by reading the power state of the server
+func (c *Client) CheckCredentials(ctx context.Context, endpoint *types.BMCControlEndpoint) error {
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return fmt.Errorf("IPMI client is nil")
+		}
+		_, err := c.ipmiClient.GetPowerState(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+		return err
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return fmt.Errorf("redfish client is nil")
+		}
+		_, err := c.redfishClient.GetPowerState(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+		return err
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
+
+// GetSOLEnabled reports whether the serial console of the BMC of a control
+// endpoint is enabled: SOL on its LAN channel for IPMI, the serial console
+// service of its Manager for Redfish
+func (c *Client) GetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint) (bool, error) {
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return false, fmt.Errorf("IPMI client is nil")
+		}
+		return c.ipmiClient.GetSOLEnabled(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return false, fmt.Errorf("redfish client is nil")
+		}
+		return c.redfishClient.GetSerialConsoleEnabled(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password)
+
+	default:
+		return false, fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
+
+// SetSOLEnabled enables or disables the serial console of the BMC of a
+// control endpoint, see GetSOLEnabled
+func (c *Client) SetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint, enabled bool) error {
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return fmt.Errorf("IPMI client is nil")
+		}
+		return c.ipmiClient.SetSOLEnabled(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, enabled)
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return fmt.Errorf("redfish client is nil")
+		}
+		return c.redfishClient.SetSerialConsoleEnabled(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, enabled)
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
+
+// GetUserPrivilege retrieves the privilege level of the user of a control
+// endpoint. Redfish roles other than those of the privilege levels are
+// returned in lower case.
+func (c *Client) GetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint) (string, error) {
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return "", fmt.Errorf("IPMI client is nil")
+		}
+		return c.ipmiClient.GetUserPrivilege(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, endpoint.Username)
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return "", fmt.Errorf("redfish client is nil")
+		}
+		account, err := c.redfishClient.GetAccount(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, endpoint.Username)
+		if err != nil {
+			return "", err
+		}
+		for privilege, role := range redfishRoles {
+			if account.RoleID == role {
+				return privilege, nil
+			}
+		}
+		return strings.ToLower(account.RoleID), nil
+
+	default:
+		return "", fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
+
+// SetUserPrivilege sets the privilege level of the user of a control
+// endpoint, one of the Privilege levels
+func (c *Client) SetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint, privilege string) error {
+	switch endpoint.Type {
+	case types.BMCTypeIPMI:
+		if c.ipmiClient == nil {
+			return fmt.Errorf("IPMI client is nil")
+		}
+		return c.ipmiClient.SetUserPrivilege(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, endpoint.Username, privilege)
+
+	case types.BMCTypeRedfish:
+		if c.redfishClient == nil {
+			return fmt.Errorf("redfish client is nil")
+		}
+		role, ok := redfishRoles[privilege]
+		if !ok {
+			return fmt.Errorf("unsupported privilege level %q", privilege)
+		}
+		return c.redfishClient.SetAccountRole(ctx, endpoint.Endpoint, endpoint.Username, endpoint.Password, endpoint.Username, role)
+
+	default:
+		return fmt.Errorf("unsupported BMC type: %s", endpoint.Type)
+	}
+}
@@ -0,0 +1,295 @@
+// Package bmcproxy routes the agent's connections to BMCs through proxies,
+// for BMC networks only reachable through a jump host. Connections go
+// through a SOCKS5 or HTTP CONNECT proxy selected by their destination, or
+// directly.
+//
+// The network policy still applies to the destination: with a policy, names
+// are resolved by the agent and the proxy is asked for the allowed address.
+// Without one, the proxy resolves names, for BMCs named in the DNS of the
+// isolated network.
+package bmcproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"local-agent/pkg/netpolicy"
+)
+
+// Direct is the URL of rules connecting without proxy
+const Direct = "direct"
+
+// ErrUDPProxied is returned for UDP protocols such as IPMI, which SOCKS5 and
+// HTTP CONNECT proxies do not carry, when their destination has a proxy
+var ErrUDPProxied = errors.New("UDP connections cannot go through a BMC proxy")
+
+// Rule routes the connections to destinations through a proxy
+type Rule struct {
+	// Destinations are networks in CIDR notation, IP addresses, host names,
+	// or domains as *.example.com
+	Destinations []string
+	// URL is socks5://[user:password@]host:port,
+	// http://[user:password@]host:port, or Direct
+	URL string
+}
+
+// Router selects the proxy of connections to BMCs. A nil Router connects
+// directly.
+type Router struct {
+	rules    []rule
+	fallback *url.URL // nil connects directly
+}
+
+type rule struct {
+	prefixes []netip.Prefix
+	hosts    []string // Lower case; domains start with a dot
+	proxy    *url.URL // nil connects directly
+}
+
+// New creates a router sending connections through the proxy of the first
+// rule matching their destination, else through defaultURL. An empty
+// defaultURL connects directly.
+func New(defaultURL string, rules []Rule) (*Router, error) {
+	r := &Router{}
+	if defaultURL != "" {
+		fallback, err := parseURL(defaultURL)
+		if err != nil {
+			return nil, err
+		}
+		r.fallback = fallback
+	}
+
+	for _, config := range rules {
+		proxyURL, err := parseURL(config.URL)
+		if err != nil {
+			return nil, err
+		}
+		if len(config.Destinations) == 0 {
+			return nil, fmt.Errorf("proxy rule %s has no destinations", config.URL)
+		}
+
+		rule := rule{proxy: proxyURL}
+		for _, destination := range config.Destinations {
+			if prefix, err := netip.ParsePrefix(destination); err == nil {
+				rule.prefixes = append(rule.prefixes, prefix.Masked())
+			} else if addr, err := netip.ParseAddr(destination); err == nil {
+				rule.prefixes = append(rule.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			} else if domain, ok := strings.CutPrefix(destination, "*."); ok && domain != "" {
+				rule.hosts = append(rule.hosts, "."+strings.ToLower(domain))
+			} else if destination != "" && !strings.ContainsAny(destination, "/*:") {
+				rule.hosts = append(rule.hosts, strings.ToLower(destination))
+			} else {
+				return nil, fmt.Errorf("invalid proxy destination %q", destination)
+			}
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+// parseURL parses the URL of a proxy, returning nil for Direct
+func parseURL(raw string) (*url.URL, error) {
+	if raw == Direct {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if u.Scheme != "socks5" && u.Scheme != "http" {
+		return nil, fmt.Errorf("invalid proxy URL %s: the scheme must be socks5 or http", u.Redacted())
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: host and port are required", u.Redacted())
+	}
+	return u, nil
+}
+
+// ProxyFor returns the URL of the proxy of connections to host, or nil when
+// they connect directly
+func (r *Router) ProxyFor(host string) *url.URL {
+	if r == nil {
+		return nil
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(strings.Trim(host, "[]"), "."))
+	addr, err := netip.ParseAddr(host)
+	isAddr := err == nil
+	for _, rule := range r.rules {
+		if isAddr {
+			for _, prefix := range rule.prefixes {
+				if prefix.Contains(addr.Unmap()) {
+					return rule.proxy
+				}
+			}
+			continue
+		}
+		for _, pattern := range rule.hosts {
+			if host == pattern || (strings.HasPrefix(pattern, ".") && strings.HasSuffix(host, pattern)) {
+				return rule.proxy
+			}
+		}
+	}
+	return r.fallback
+}
+
+// defaultRouter is the router of the BMC clients of the process
+var defaultRouter atomic.Pointer[Router]
+
+// SetDefault sets the router of the agent's BMC clients. It is set once at
+// startup, before any client connects.
+func SetDefault(r *Router) {
+	defaultRouter.Store(r)
+}
+
+// Default returns the router of the agent's BMC clients, nil when none is
+// set
+func Default() *Router {
+	return defaultRouter.Load()
+}
+
+// RequireDirect returns ErrUDPProxied when the default router sends the
+// connections to a BMC endpoint, given as a URL or as host[:port], through a
+// proxy
+func RequireDirect(endpoint string) error {
+	if proxyURL := Default().ProxyFor(netpolicy.EndpointHost(endpoint)); proxyURL != nil {
+		return fmt.Errorf("%w: %s has proxy %s", ErrUDPProxied, endpoint, proxyURL.Redacted())
+	}
+	return nil
+}
+
+// Dialer connects to BMCs through the proxy of their destination with the
+// default router, else directly. Both enforce the default network policy on
+// the destination. Dialing the proxy itself is not subject to the policy.
+type Dialer struct {
+	// Dialer connects directly, and to proxies without its Control
+	*net.Dialer
+}
+
+// NewDialer returns a dialer of connections to BMCs
+func NewDialer(timeout time.Duration) *Dialer {
+	return &Dialer{Dialer: netpolicy.Dialer(timeout)}
+}
+
+// DialContext connects to address, through its proxy if it has one
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+	proxyURL := Default().ProxyFor(host)
+	if proxyURL == nil {
+		return d.Dialer.DialContext(ctx, network, address)
+	}
+
+	// The proxy connects to the destination, which the policy must allow
+	if netpolicy.Default() != nil {
+		resolved, err := netpolicy.Default().ResolveHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		address = net.JoinHostPort(resolved, port)
+	}
+
+	if d.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.Timeout)
+		defer cancel()
+	}
+	forward := &net.Dialer{Timeout: d.Timeout, KeepAlive: d.KeepAlive}
+
+	var conn net.Conn
+	switch proxyURL.Scheme {
+	case "socks5":
+		conn, err = dialSOCKS5(ctx, forward, proxyURL, network, address)
+	default:
+		conn, err = dialHTTPConnect(ctx, forward, proxyURL, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s through proxy %s: %w", address, proxyURL.Redacted(), err)
+	}
+	return conn, nil
+}
+
+// dialSOCKS5 connects to address through a SOCKS5 proxy
+func dialSOCKS5(ctx context.Context, forward *net.Dialer, proxyURL *url.URL, network, address string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+	}
+	dialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, forward)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.(proxy.ContextDialer).DialContext(ctx, network, address)
+}
+
+// dialHTTPConnect connects to address through an HTTP proxy, with a CONNECT
+// request
+func dialHTTPConnect(ctx context.Context, forward *net.Dialer, proxyURL *url.URL, address string) (net.Conn, error) {
+	conn, err := forward.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused CONNECT: %s", resp.Status)
+	}
+
+	conn.SetDeadline(time.Time{})
+	if reader.Buffered() > 0 {
+		// The BMC spoke first, e.g. a VNC server: keep what was read
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes were read into a buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
@@ -0,0 +1,368 @@
+package bmcproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		defaultURL string
+		rules      []Rule
+	}{
+		{"unsupported scheme", "https://proxy.example.com:3128", nil},
+		{"missing port", "socks5://proxy.example.com", nil},
+		{"missing host", "http://:3128", nil},
+		{"rule without destinations", "", []Rule{{URL: "socks5://proxy.example.com:1080"}}},
+		{"rule with invalid URL", "", []Rule{{Destinations: []string{"10.0.0.0/8"}, URL: "proxy.example.com"}}},
+		{"invalid destination", "", []Rule{{Destinations: []string{"10.0.0.0/33"}, URL: Direct}}},
+		{"empty destination", "", []Rule{{Destinations: []string{""}, URL: Direct}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.defaultURL, tt.rules); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRouter_ProxyFor(t *testing.T) {
+	router, err := New("socks5://jump.dc.example.com:1080", []Rule{
+		{Destinations: []string{"10.20.0.0/16", "*.oob.example.com"}, URL: "http://proxy.oob.example.com:3128"},
+		{Destinations: []string{"192.168.1.100", "bmc-lab", "fd00:1::/64"}, URL: Direct},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"10.20.1.5", "http://proxy.oob.example.com:3128"},
+		{"bmc-01.oob.example.com", "http://proxy.oob.example.com:3128"},
+		{"BMC-01.OOB.example.com.", "http://proxy.oob.example.com:3128"},
+		{"oob.example.com", "socks5://jump.dc.example.com:1080"},
+		{"192.168.1.100", ""},
+		{"::ffff:192.168.1.100", ""},
+		{"[fd00:1::10]", ""},
+		{"bmc-lab", ""},
+		{"192.168.1.101", "socks5://jump.dc.example.com:1080"},
+		{"bmc-02.dc.example.com", "socks5://jump.dc.example.com:1080"},
+	}
+	for _, tt := range tests {
+		got := ""
+		if proxyURL := router.ProxyFor(tt.host); proxyURL != nil {
+			got = proxyURL.String()
+		}
+		if got != tt.want {
+			t.Errorf("ProxyFor(%s) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+
+	var direct *Router
+	if proxyURL := direct.ProxyFor("10.20.1.5"); proxyURL != nil {
+		t.Errorf("Expected a nil router to connect directly, got %s", proxyURL)
+	}
+}
+
+// setDefault sets the default router for the duration of a test
+func setDefault(t *testing.T, defaultURL string, rules []Rule) {
+	t.Helper()
+	router, err := New(defaultURL, rules)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	previous := Default()
+	SetDefault(router)
+	t.Cleanup(func() { SetDefault(previous) })
+}
+
+func TestRequireDirect(t *testing.T) {
+	setDefault(t, "socks5://jump.dc.example.com:1080", []Rule{
+		{Destinations: []string{"192.168.1.0/24"}, URL: Direct},
+	})
+
+	if err := RequireDirect("192.168.1.100:623"); err != nil {
+		t.Errorf("Expected a direct endpoint to be accepted, got %v", err)
+	}
+	err := RequireDirect("10.0.0.5:623")
+	if !errors.Is(err, ErrUDPProxied) {
+		t.Errorf("Expected ErrUDPProxied for a proxied endpoint, got %v", err)
+	}
+}
+
+// pipe copies between two connections until either closes
+func pipe(a, b net.Conn) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); io.Copy(a, b); a.Close() }()
+	go func() { defer wg.Done(); io.Copy(b, a); b.Close() }()
+	wg.Wait()
+}
+
+// testSOCKS5 is a minimal SOCKS5 proxy recording the requested addresses
+type testSOCKS5 struct {
+	listener  net.Listener
+	user      string
+	password  string
+	mu        sync.Mutex
+	requested []string
+}
+
+func newTestSOCKS5(t *testing.T, user, password string) *testSOCKS5 {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	s := &testSOCKS5{listener: listener, user: user, password: password}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *testSOCKS5) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(reader, methods); err != nil {
+		return
+	}
+	if s.user == "" {
+		conn.Write([]byte{5, 0})
+	} else {
+		conn.Write([]byte{5, 2})
+		// Username/password authentication, RFC 1929
+		credentials := make([]byte, 2)
+		if _, err := io.ReadFull(reader, credentials); err != nil {
+			return
+		}
+		user := make([]byte, credentials[1])
+		io.ReadFull(reader, user)
+		length, _ := reader.ReadByte()
+		password := make([]byte, length)
+		io.ReadFull(reader, password)
+		if string(user) != s.user || string(password) != s.password {
+			conn.Write([]byte{1, 1})
+			return
+		}
+		conn.Write([]byte{1, 0})
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(reader, request); err != nil {
+		return
+	}
+	var host string
+	switch request[3] {
+	case 1:
+		addr := make([]byte, 4)
+		io.ReadFull(reader, addr)
+		host = net.IP(addr).String()
+	case 3:
+		length, _ := reader.ReadByte()
+		name := make([]byte, length)
+		io.ReadFull(reader, name)
+		host = string(name)
+	case 4:
+		addr := make([]byte, 16)
+		io.ReadFull(reader, addr)
+		host = net.IP(addr).String()
+	}
+	port := make([]byte, 2)
+	io.ReadFull(reader, port)
+	address := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+
+	s.mu.Lock()
+	s.requested = append(s.requested, address)
+	s.mu.Unlock()
+
+	target, err := net.Dial("tcp", address)
+	if err != nil {
+		conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	pipe(&bufferedConn{Conn: conn, reader: reader}, target)
+}
+
+func (s *testSOCKS5) lastRequested() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requested) == 0 {
+		return ""
+	}
+	return s.requested[len(s.requested)-1]
+}
+
+// newTestHTTPProxy returns a proxy accepting CONNECT requests with the
+// Proxy-Authorization header auth, none when empty
+func newTestHTTPProxy(t *testing.T, auth string) *httptest.Server {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Proxy-Authorization") != auth {
+			http.Error(w, "proxy authentication required", http.StatusProxyAuthRequired)
+			return
+		}
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		conn, buffered, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			target.Close()
+			return
+		}
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		pipe(&bufferedConn{Conn: conn, reader: buffered.Reader}, target)
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+// getThroughDialer fetches url with connections from dialer
+func getThroughDialer(t *testing.T, dialer *Dialer, url string) string {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func newBackend(t *testing.T) *httptest.Server {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("redfish"))
+	}))
+	t.Cleanup(backend.Close)
+	return backend
+}
+
+func TestDialer_Direct(t *testing.T) {
+	backend := newBackend(t)
+	setDefault(t, "", nil)
+
+	if body := getThroughDialer(t, NewDialer(5*time.Second), backend.URL); body != "redfish" {
+		t.Errorf("Expected the backend's response, got %q", body)
+	}
+}
+
+func TestDialer_SOCKS5(t *testing.T) {
+	backend := newBackend(t)
+	socks := newTestSOCKS5(t, "agent", "secret")
+	setDefault(t, "socks5://agent:secret@"+socks.listener.Addr().String(), nil)
+
+	if body := getThroughDialer(t, NewDialer(5*time.Second), backend.URL); body != "redfish" {
+		t.Errorf("Expected the backend's response, got %q", body)
+	}
+	if got := socks.lastRequested(); got != backend.Listener.Addr().String() {
+		t.Errorf("Expected the proxy to connect to %s, got %q", backend.Listener.Addr(), got)
+	}
+}
+
+func TestDialer_SOCKS5_WrongCredentials(t *testing.T) {
+	backend := newBackend(t)
+	socks := newTestSOCKS5(t, "agent", "secret")
+	setDefault(t, "socks5://agent:wrong@"+socks.listener.Addr().String(), nil)
+
+	_, err := NewDialer(5*time.Second).DialContext(context.Background(), "tcp", backend.Listener.Addr().String())
+	if err == nil {
+		t.Fatal("Expected the proxy to refuse wrong credentials")
+	}
+}
+
+func TestDialer_HTTPConnect(t *testing.T) {
+	backend := newBackend(t)
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("agent:secret"))
+	proxy := newTestHTTPProxy(t, auth)
+	setDefault(t, "", []Rule{{
+		Destinations: []string{"127.0.0.0/8"},
+		URL:          "http://agent:secret@" + proxy.Listener.Addr().String(),
+	}})
+
+	if body := getThroughDialer(t, NewDialer(5*time.Second), backend.URL); body != "redfish" {
+		t.Errorf("Expected the backend's response, got %q", body)
+	}
+}
+
+func TestDialer_HTTPConnect_Refused(t *testing.T) {
+	backend := newBackend(t)
+	proxy := newTestHTTPProxy(t, "Basic expected")
+	setDefault(t, "http://"+proxy.Listener.Addr().String(), nil)
+
+	_, err := NewDialer(5*time.Second).DialContext(context.Background(), "tcp", backend.Listener.Addr().String())
+	if err == nil {
+		t.Fatal("Expected the proxy to refuse the CONNECT request")
+	}
+}
+
+func TestDialer_HTTPConnect_ServerSpeaksFirst(t *testing.T) {
+	// VNC servers send their version as soon as the connection opens
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("RFB 003.008\n"))
+		io.Copy(io.Discard, conn)
+	}()
+
+	proxy := newTestHTTPProxy(t, "")
+	setDefault(t, "http://"+proxy.Listener.Addr().String(), nil)
+
+	conn, err := NewDialer(5*time.Second).DialContext(context.Background(), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext failed: %v", err)
+	}
+	defer conn.Close()
+
+	version := make([]byte, 12)
+	if _, err := io.ReadFull(conn, version); err != nil {
+		t.Fatalf("Failed to read the server's version: %v", err)
+	}
+	if string(version) != "RFB 003.008\n" {
+		t.Errorf("Expected the server's version, got %q", version)
+	}
+}
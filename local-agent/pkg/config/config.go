@@ -3,12 +3,15 @@ package config
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 
 	"core/config"
+	"core/logging"
+	"core/streaming"
 	"core/types"
 )
 
@@ -23,13 +26,19 @@ type Config struct {
 	// TLS configuration
 	TLS config.TLSConfig `yaml:"tls"`
 
+	// Tracing configuration
+	Tracing config.TracingConfig `yaml:"tracing"`
+
+	// Debug endpoints
+	Debug config.DebugConfig `yaml:"debug"`
+
 	// Legacy static hosts configuration (for backward compatibility)
 	Static StaticConfig `yaml:"static"`
 }
 
 // LogConfig contains agent-specific logging configuration
 type LogConfig struct {
-	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=trace debug info warn warning error fatal panic"`
 	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
 	Debug  bool   `yaml:"debug" env:"DEBUG" default:"false"`
 }
@@ -58,7 +67,7 @@ func (c *LogConfig) ConfigureZerolog() {
 			level = zerolog.PanicLevel
 		}
 	}
-	zerolog.SetGlobalLevel(level)
+	logging.SetLevel(level)
 }
 
 // AgentConfig contains agent-specific configuration
@@ -73,19 +82,30 @@ type AgentConfig struct {
 	GatewayEndpoint string `yaml:"gateway_endpoint" env:"AGENT_GATEWAY_ENDPOINT" default:"http://localhost:8081"`
 
 	// Local HTTP server configuration
-	HTTPPort int    `yaml:"http_port" default:"8090"`
+	HTTPPort int    `yaml:"http_port" default:"8090" validate:"min=1,max=65535"`
 	Endpoint string `yaml:"endpoint"`
 
 	// BMC discovery and management
 	BMCDiscovery  BMCDiscoveryConfig  `yaml:"bmc_discovery"`
 	BMCOperations BMCOperationsConfig `yaml:"bmc_operations"` // TODO: Most fields not currently used
 
-	// VNC/KVM configuration (TODO: Not currently used in code)
+	// VNC/KVM configuration (only .MaxConnections is currently used)
 	VNCConfig VNCConfig `yaml:"vnc"`
 
-	// Serial console configuration (TODO: Not currently used in code)
+	// Serial console configuration (only .MaxSessions is currently used)
 	SerialConsole SerialConsoleConfig `yaml:"serial_console"`
 
+	// Checksum the data of the console stream chunks exchanged with gateways
+	// supporting it, to detect the chunks corrupted on the way
+	StreamChecksums bool `yaml:"stream_checksums" env:"AGENT_STREAM_CHECKSUMS" default:"true"`
+
+	// Ping the gateways supporting it on console streams, and close the BMC
+	// connections of the streams whose gateway stopped answering
+	StreamKeepalive StreamKeepaliveConfig `yaml:"stream_keepalive"`
+
+	// Message size limits of the WebSocket consoles of BMCs
+	BMCWebSocket BMCWebSocketConfig `yaml:"bmc_websocket"`
+
 	// Connection management (TODO: Not currently used in code)
 	ConnectionManagement ConnectionManagementConfig `yaml:"connection_management"`
 
@@ -94,6 +114,15 @@ type AgentConfig struct {
 
 	// Security configuration (only .EncryptionKey is currently used)
 	Security SecurityConfig `yaml:"security"`
+
+	// Local API adding and removing static hosts at runtime
+	HostsAPI HostsAPIConfig `yaml:"hosts_api"`
+
+	// Proxies of the BMC networks only reachable through a jump host
+	BMCProxy BMCProxyConfig `yaml:"bmc_proxy"`
+
+	// Tunnels to isolated BMC networks, supervised by the agent
+	Tunnels []TunnelConfig `yaml:"tunnels"`
 }
 
 // BMCDiscoveryConfig configures BMC discovery behavior
@@ -106,6 +135,10 @@ type BMCDiscoveryConfig struct {
 	ScanTimeout   time.Duration `yaml:"scan_timeout" default:"10s"`
 	MaxConcurrent int           `yaml:"max_concurrent" default:"50"`
 
+	// Interval between resolutions of the BMC endpoints named in DNS, whose
+	// addresses change with their DHCP leases
+	DNSRefreshInterval time.Duration `yaml:"dns_refresh_interval" default:"1m"`
+
 	// Discovery methods
 	EnablePortScan         bool `yaml:"enable_port_scan" default:"true"`
 	EnableIPMIDetection    bool `yaml:"enable_ipmi_detection" default:"true"`
@@ -118,7 +151,7 @@ type BMCDiscoveryConfig struct {
 // CredentialConfig contains BMC credentials for discovery
 type CredentialConfig struct {
 	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	Password string `yaml:"password" secret:"true"`
 }
 
 // BMCOperationsConfig configures BMC operation behavior
@@ -137,6 +170,10 @@ type BMCOperationsConfig struct {
 	// Concurrency
 	MaxConcurrentOperations int `yaml:"max_concurrent_operations" default:"10"`
 
+	// Idempotency: how long the results of power operations sent with an
+	// idempotency key are kept, for retries of the gateway; 0 disables it
+	PowerIdempotencyWindow time.Duration `yaml:"power_idempotency_window" env:"AGENT_POWER_IDEMPOTENCY_WINDOW" default:"10m"`
+
 	// Protocol-specific settings
 	IPMIConfig    IPMIConfig    `yaml:"ipmi"`
 	RedfishConfig RedfishConfig `yaml:"redfish"`
@@ -176,13 +213,23 @@ type RedfishConfig struct {
 }
 
 // VNCConfig configures VNC/KVM operations
-// TODO: Not currently used in code - reserved for future implementation
+// TODO: Only MaxConnections is used in code - other fields reserved for future implementation
 type VNCConfig struct {
-	Enabled        bool          `yaml:"enabled" default:"true"`
-	Port           int           `yaml:"port" default:"5900"`
-	BindAddress    string        `yaml:"bind_address" default:"127.0.0.1"`
+	Enabled     bool   `yaml:"enabled" default:"true"`
+	Port        int    `yaml:"port" default:"5900"`
+	BindAddress string `yaml:"bind_address" default:"127.0.0.1"`
+	// MaxConnections limits concurrent VNC streams to BMCs (0 = unlimited)
 	MaxConnections int           `yaml:"max_connections" default:"5"`
 	SessionTimeout time.Duration `yaml:"session_timeout" default:"4h"`
+	// ChunkSize is the size of the pooled buffers BMC data is read into, and
+	// so the maximum data size of the chunks streamed to the gateway
+	ChunkSize int `yaml:"chunk_size" env:"VNC_CHUNK_SIZE" default:"32768"`
+	// MaxFPS bounds the framebuffer updates requested from BMCs per second
+	// (0 = unlimited)
+	MaxFPS int `yaml:"max_fps" env:"VNC_MAX_FPS" default:"0"`
+	// SkipUnchangedFrames replaces the framebuffer updates of BMCs identical
+	// to the previous one with empty updates
+	SkipUnchangedFrames bool `yaml:"skip_unchanged_frames" env:"VNC_SKIP_UNCHANGED_FRAMES" default:"false"`
 
 	// VNC server configuration
 	FrameRate      int  `yaml:"frame_rate" default:"15"`
@@ -197,14 +244,35 @@ type VNCConfig struct {
 	AllowedOrigins       []string `yaml:"allowed_origins"`
 }
 
+// StreamKeepaliveConfig configures the keepalive pings of console streams.
+// A stream whose gateway answered no ping for Timeout, e.g. after the
+// gateway crashed mid-session, is closed along with its BMC connection.
+type StreamKeepaliveConfig struct {
+	// Interval between pings, 0 disables them
+	Interval time.Duration `yaml:"interval" env:"AGENT_STREAM_KEEPALIVE_INTERVAL" default:"15s"`
+	Timeout  time.Duration `yaml:"timeout" env:"AGENT_STREAM_KEEPALIVE_TIMEOUT" default:"1m"`
+}
+
+// BMCWebSocketConfig bounds the messages exchanged with the WebSocket VNC and
+// serial consoles of BMCs
+type BMCWebSocketConfig struct {
+	// Largest message read from a BMC, e.g. a framebuffer update; larger ones
+	// end the console stream
+	MaxMessageSize int64 `yaml:"max_message_size" env:"AGENT_BMC_WEBSOCKET_MAX_MESSAGE_SIZE" default:"16777216"`
+	// Largest serial console message written to a BMC: larger input, e.g. a
+	// paste, is split over several messages
+	MaxWriteSize int `yaml:"max_write_size" env:"AGENT_BMC_WEBSOCKET_MAX_WRITE_SIZE" default:"4096"`
+}
+
 // SerialConsoleConfig configures serial console operations
-// TODO: Not currently used in code - reserved for future implementation
+// TODO: Only MaxSessions is used in code - other fields reserved for future implementation
 type SerialConsoleConfig struct {
 	Enabled         bool          `yaml:"enabled" default:"true"`
 	DefaultBaudRate int           `yaml:"default_baud_rate" default:"115200"`
 	BufferSize      int           `yaml:"buffer_size" default:"8192"`
 	SessionTimeout  time.Duration `yaml:"session_timeout" default:"2h"`
-	MaxSessions     int           `yaml:"max_sessions" default:"10"`
+	// MaxSessions limits concurrent SOL streams to BMCs (0 = unlimited)
+	MaxSessions int `yaml:"max_sessions" default:"10"`
 
 	// Flow control
 	SupportedBaudRates []int    `yaml:"supported_baud_rates"`
@@ -251,13 +319,13 @@ type HealthMonitoringConfig struct {
 }
 
 // SecurityConfig configures security settings
-// Note: Currently only .EncryptionKey is used in code
 type SecurityConfig struct {
 	// Encryption
-	EncryptionKey         string `yaml:"-" env:"AGENT_ENCRYPTION_KEY"`
+	EncryptionKey         string `yaml:"-" env:"AGENT_ENCRYPTION_KEY" secret:"true"`
 	EnableTLSVerification bool   `yaml:"enable_tls_verification" default:"true"` // TODO: Not currently used
 
-	// Access control (TODO: Not currently used)
+	// Networks the agent's BMC clients may connect to, all when empty. Private,
+	// loopback and link-local addresses are denied with DenyPrivateNetworks.
 	AllowedNetworks     []string `yaml:"allowed_networks"`
 	DenyPrivateNetworks bool     `yaml:"deny_private_networks" default:"false"`
 
@@ -266,30 +334,107 @@ type SecurityConfig struct {
 	AuditLogPath       string `yaml:"audit_log_path" default:"/var/log/bmc-agent/audit.log"`
 }
 
+// HostsAPIConfig configures the local API adding and removing static hosts at
+// runtime. The API is disabled without a token.
+type HostsAPIConfig struct {
+	// Bearer token required by the API, at least 16 characters
+	Token string `yaml:"-" env:"AGENT_HOSTS_API_TOKEN" secret:"true"`
+	// File persisting the hosts added through the API across restarts
+	StateFile string `yaml:"state_file" env:"AGENT_HOSTS_STATE_FILE" default:"/var/lib/bmc-agent/hosts.json"`
+}
+
+// BMCProxyConfig configures the proxies of the connections to BMCs. URL is
+// the proxy of the datacenter, used by the destinations no rule matches;
+// connections are direct without it.
+type BMCProxyConfig struct {
+	URL   string         `yaml:"url" env:"AGENT_BMC_PROXY_URL"`
+	Rules []BMCProxyRule `yaml:"rules"`
+}
+
+// BMCProxyRule routes the connections to some BMCs through a proxy. The first
+// rule matching a destination applies.
+type BMCProxyRule struct {
+	// Networks in CIDR notation, IP addresses, host names, or domains as
+	// *.example.com
+	Destinations []string `yaml:"destinations"`
+	// socks5://[user:password@]host:port, http://[user:password@]host:port,
+	// or "direct"
+	URL string `yaml:"url"`
+}
+
+// TunnelConfig configures a WireGuard or SSH tunnel to isolated BMC networks
+type TunnelConfig struct {
+	Name string `yaml:"name"`
+	// "wireguard" or "ssh"
+	Type string `yaml:"type"`
+	// BMC networks reached through the tunnel, in CIDR notation
+	Networks []string `yaml:"networks"`
+
+	// WireGuard: wg-quick configuration, its base name being the interface
+	ConfigFile string `yaml:"config_file"`
+
+	// SSH: [user@]host of the jump host, and the local address of the SOCKS5
+	// proxy reaching the BMC networks from it
+	Host           string `yaml:"host"`
+	Port           int    `yaml:"port"`
+	IdentityFile   string `yaml:"identity_file"`
+	KnownHostsFile string `yaml:"known_hosts_file"`
+	SOCKSAddress   string `yaml:"socks_address"`
+}
+
 // Legacy configuration types for backward compatibility
 type StaticConfig struct {
 	Hosts []BMCHost `yaml:"hosts"`
+
+	// Verification of the BMCs of the static hosts against their declared
+	// configuration
+	Reconcile ReconcileConfig `yaml:"reconcile"`
+}
+
+// ReconcileConfig configures the reconciliation of the static hosts, treated
+// as the desired state of their BMCs: the agent checks every interval that
+// each BMC answers with its declared credentials and has the declared
+// consoles and user privileges, and reports the drift to the gateway.
+type ReconcileConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"AGENT_RECONCILE_ENABLED" default:"false"`
+	Interval time.Duration `yaml:"interval" env:"AGENT_RECONCILE_INTERVAL" default:"15m"`
+	// Time allowed to check a BMC
+	Timeout time.Duration `yaml:"timeout" env:"AGENT_RECONCILE_TIMEOUT" default:"1m"`
+	// Re-apply the settable configuration that drifted, SOL enablement and
+	// user privileges, rather than only reporting it
+	Apply bool `yaml:"apply" env:"AGENT_RECONCILE_APPLY" default:"false"`
 }
 
 type BMCHost struct {
-	ID               string                      `yaml:"id"`
-	CustomerID       string                      `yaml:"customer_id"`
-	ControlEndpoints []*ConfigBMCControlEndpoint `yaml:"control_endpoints"` // Multiple protocol support (required for RFD 006)
-	SOLEndpoint      *ConfigSOLEndpoint          `yaml:"sol_endpoint"`
-	VNCEndpoint      *ConfigVNCEndpoint          `yaml:"vnc_endpoint"`
-	Features         []string                    `yaml:"features"`
-	Metadata         map[string]string           `yaml:"metadata"`
+	ID               string                      `yaml:"id" json:"id"`
+	CustomerID       string                      `yaml:"customer_id" json:"customer_id,omitempty"`
+	ControlEndpoints []*ConfigBMCControlEndpoint `yaml:"control_endpoints" json:"control_endpoints,omitempty"` // Multiple protocol support (required for RFD 006)
+	SOLEndpoint      *ConfigSOLEndpoint          `yaml:"sol_endpoint" json:"sol_endpoint,omitempty"`
+	VNCEndpoint      *ConfigVNCEndpoint          `yaml:"vnc_endpoint" json:"vnc_endpoint,omitempty"`
+	Features         []string                    `yaml:"features" json:"features,omitempty"`
+	Metadata         map[string]string           `yaml:"metadata" json:"metadata,omitempty"`
+	// BMCFlavor is the BMC implementation, when known beforehand instead of
+	// detected: "openbmc"
+	BMCFlavor string `yaml:"bmc_flavor,omitempty" json:"bmc_flavor,omitempty"`
 }
 
+// BMC flavors of static hosts
+const (
+	BMCFlavorOpenBMC = "openbmc" // OpenBMC, with its obmc-console and KVM WebSockets
+)
+
 // ConfigBMCControlEndpoint is a config-specific wrapper around types.BMCControlEndpoint
 // that allows optional Type field for YAML parsing (type can be inferred from endpoint)
 type ConfigBMCControlEndpoint struct {
-	Endpoint     string           `yaml:"endpoint"`
-	Type         string           `yaml:"type,omitempty"` // Optional: inferred from endpoint if not specified
-	Username     string           `yaml:"username"`
-	Password     string           `yaml:"password"`
-	TLS          *types.TLSConfig `yaml:"tls"`
-	Capabilities []string         `yaml:"capabilities"`
+	Endpoint     string           `yaml:"endpoint" json:"endpoint"`
+	Type         string           `yaml:"type,omitempty" json:"type,omitempty"` // Optional: inferred from endpoint if not specified
+	Username     string           `yaml:"username" json:"username,omitempty"`
+	Password     string           `yaml:"password" json:"password,omitempty" secret:"true"`
+	TLS          *types.TLSConfig `yaml:"tls" json:"tls,omitempty"`
+	Capabilities []string         `yaml:"capabilities" json:"capabilities,omitempty"`
+	// Privilege level the BMC grants the user, checked by reconciliation:
+	// "administrator", "operator" or "user"
+	Privilege string `yaml:"privilege,omitempty" json:"privilege,omitempty"`
 }
 
 // ToTypesEndpoint converts this config endpoint to a core types endpoint
@@ -312,11 +457,13 @@ func (b *ConfigBMCControlEndpoint) ToTypesEndpoint() *types.BMCControlEndpoint {
 // ConfigSOLEndpoint is a config-specific wrapper around types.SOLEndpoint
 // that allows optional Type field for YAML parsing (type can be inferred from endpoint)
 type ConfigSOLEndpoint struct {
-	Type     string           `yaml:"type,omitempty"` // Optional: inferred from endpoint if not specified
-	Endpoint string           `yaml:"endpoint"`
-	Username string           `yaml:"username"`
-	Password string           `yaml:"password"`
-	Config   *types.SOLConfig `yaml:"config"`
+	Type     string           `yaml:"type,omitempty" json:"type,omitempty"` // Optional: inferred from endpoint if not specified
+	Endpoint string           `yaml:"endpoint" json:"endpoint"`
+	Username string           `yaml:"username" json:"username,omitempty"`
+	Password string           `yaml:"password" json:"password,omitempty" secret:"true"`
+	Config   *types.SOLConfig `yaml:"config" json:"config,omitempty"`
+	// Terminal the server's console is rendered for, 80x24 when unset
+	Terminal *types.TerminalConfig `yaml:"terminal,omitempty" json:"terminal,omitempty"`
 }
 
 // ToTypesEndpoint converts this config endpoint to a core types endpoint
@@ -332,17 +479,18 @@ func (s *ConfigSOLEndpoint) ToTypesEndpoint() *types.SOLEndpoint {
 		Username: s.Username,
 		Password: s.Password,
 		Config:   s.Config,
+		Terminal: s.Terminal,
 	}
 }
 
 // ConfigVNCEndpoint is a config-specific wrapper around types.VNCEndpoint
 // that allows optional Type field for YAML parsing (type can be inferred from endpoint)
 type ConfigVNCEndpoint struct {
-	Type     string           `yaml:"type,omitempty"` // Optional: inferred from endpoint scheme if not specified
-	Endpoint string           `yaml:"endpoint"`       // URL with scheme (ws://, wss://, vnc://) or host:port
-	Username string           `yaml:"username"`
-	Password string           `yaml:"password"`
-	Config   *types.VNCConfig `yaml:"config"`
+	Type     string           `yaml:"type,omitempty" json:"type,omitempty"` // Optional: inferred from endpoint scheme if not specified
+	Endpoint string           `yaml:"endpoint" json:"endpoint"`             // URL with scheme (ws://, wss://, vnc://) or host:port
+	Username string           `yaml:"username" json:"username,omitempty"`
+	Password string           `yaml:"password" json:"password,omitempty" secret:"true"`
+	Config   *types.VNCConfig `yaml:"config" json:"config,omitempty"`
 }
 
 // ToTypesEndpoint converts this config endpoint to a core types endpoint
@@ -361,6 +509,55 @@ func (v *ConfigVNCEndpoint) ToTypesEndpoint() *types.VNCEndpoint {
 	}
 }
 
+// Validate checks that a host has an ID and a control endpoint
+func (h *BMCHost) Validate() error {
+	if h.ID == "" {
+		return fmt.Errorf("host id is required")
+	}
+	if len(h.ControlEndpoints) == 0 {
+		return fmt.Errorf("host %s: at least one control endpoint is required", h.ID)
+	}
+	for _, endpoint := range h.ControlEndpoints {
+		if endpoint == nil || endpoint.Endpoint == "" {
+			return fmt.Errorf("host %s: control endpoint address is required", h.ID)
+		}
+		switch endpoint.Privilege {
+		case "", "administrator", "operator", "user":
+		default:
+			return fmt.Errorf("host %s: invalid privilege %q, expected administrator, operator or user", h.ID, endpoint.Privilege)
+		}
+	}
+	if h.SOLEndpoint != nil && h.SOLEndpoint.Endpoint == "" {
+		return fmt.Errorf("host %s: SOL endpoint address is required", h.ID)
+	}
+	if h.SOLEndpoint != nil && h.SOLEndpoint.Terminal != nil {
+		terminal := h.SOLEndpoint.Terminal
+		if err := (streaming.TerminalHints{Type: terminal.Type, Cols: terminal.Cols, Rows: terminal.Rows}).Validate(); err != nil {
+			return fmt.Errorf("host %s: SOL terminal: %w", h.ID, err)
+		}
+	}
+	if h.VNCEndpoint != nil && h.VNCEndpoint.Endpoint == "" {
+		return fmt.Errorf("host %s: VNC endpoint address is required", h.ID)
+	}
+	return h.validateBMCFlavor()
+}
+
+// validateBMCFlavor checks the BMC flavor hint of the host. OpenBMC
+// consoles are found through Redfish, which the primary endpoint must use.
+func (h *BMCHost) validateBMCFlavor() error {
+	switch h.BMCFlavor {
+	case "":
+	case BMCFlavorOpenBMC:
+		primary := h.GetPrimaryControlEndpoint()
+		if primary == nil || primary.ToTypesEndpoint().Type != types.BMCTypeRedfish {
+			return fmt.Errorf("host %s: bmc_flavor %s requires a Redfish primary control endpoint", h.ID, h.BMCFlavor)
+		}
+	default:
+		return fmt.Errorf("host %s: unknown bmc_flavor %q, expected %s", h.ID, h.BMCFlavor, BMCFlavorOpenBMC)
+	}
+	return nil
+}
+
 // GetControlEndpoint returns the primary BMC control endpoint (first in array)
 func (h *BMCHost) GetControlEndpoint() string {
 	if len(h.ControlEndpoints) == 0 {
@@ -432,6 +629,48 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Agent.BMCDiscovery.DNSRefreshInterval <= 0 {
+		return fmt.Errorf("dns refresh interval must be positive")
+	}
+
+	for _, host := range c.Static.Hosts {
+		if err := host.validateBMCFlavor(); err != nil {
+			return fmt.Errorf("invalid static host: %w", err)
+		}
+	}
+
+	// Validate static hosts reconciliation
+	if reconcile := c.Static.Reconcile; reconcile.Enabled {
+		if reconcile.Interval <= 0 {
+			return fmt.Errorf("reconcile interval must be positive")
+		}
+		if reconcile.Timeout <= 0 {
+			return fmt.Errorf("reconcile timeout must be positive")
+		}
+		for _, host := range c.Static.Hosts {
+			if err := host.Validate(); err != nil {
+				return fmt.Errorf("invalid static host: %w", err)
+			}
+		}
+	}
+
+	// Validate networks allowed for BMC connections
+	for _, network := range c.Agent.Security.AllowedNetworks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return fmt.Errorf("invalid allowed network %s: %w", network, err)
+		}
+	}
+
+	// Validate hosts API
+	if c.Agent.HostsAPI.Token != "" {
+		if len(c.Agent.HostsAPI.Token) < 16 {
+			return fmt.Errorf("hosts API token must be at least 16 characters")
+		}
+		if c.Agent.HostsAPI.StateFile == "" {
+			return fmt.Errorf("hosts API state file is required")
+		}
+	}
+
 	// Validate BMC discovery ports
 	if len(c.Agent.BMCDiscovery.IPMIPorts) == 0 {
 		c.Agent.BMCDiscovery.IPMIPorts = []int{623}
@@ -484,6 +723,33 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("VNC quality must be between 0 and 9")
 	}
 
+	if c.Agent.VNCConfig.ChunkSize < 1024 || c.Agent.VNCConfig.ChunkSize > 1024*1024 {
+		return fmt.Errorf("VNC chunk size must be between 1024 and 1048576 bytes")
+	}
+
+	if c.Agent.VNCConfig.MaxFPS < 0 || c.Agent.VNCConfig.MaxFPS > 60 {
+		return fmt.Errorf("VNC max FPS must be between 0 and 60")
+	}
+
+	if size := c.Agent.BMCWebSocket.MaxMessageSize; size < 64*1024 || size > 256*1024*1024 {
+		return fmt.Errorf("BMC WebSocket max message size must be between 65536 and 268435456 bytes")
+	}
+
+	if size := c.Agent.BMCWebSocket.MaxWriteSize; size < 64 || size > 1024*1024 {
+		return fmt.Errorf("BMC WebSocket max write size must be between 64 and 1048576 bytes")
+	}
+
+	if c.Agent.BMCOperations.PowerIdempotencyWindow < 0 {
+		return fmt.Errorf("power idempotency window must not be negative")
+	}
+
+	// Validate stream keepalive
+	if keepalive := c.Agent.StreamKeepalive; keepalive.Interval < 0 {
+		return fmt.Errorf("stream keepalive interval must not be negative")
+	} else if keepalive.Interval > 0 && keepalive.Timeout <= keepalive.Interval {
+		return fmt.Errorf("stream keepalive timeout must be longer than its interval")
+	}
+
 	// Validate health monitoring thresholds
 	if c.Agent.HealthMonitoring.CPUThreshold < 0 || c.Agent.HealthMonitoring.CPUThreshold > 100 {
 		return fmt.Errorf("CPU threshold must be between 0 and 100")
@@ -507,10 +773,20 @@ func (c *Config) Validate() error {
 		c.Agent.SerialConsole.FlowControlModes = []string{"none", "hardware", "software"}
 	}
 
+	// Validate tracing
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	// Validate debug endpoints
+	if err := c.Debug.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // GetVNCListenAddress returns the address the VNC server should listen on
 func (c *Config) GetVNCListenAddress() string {
-	return fmt.Sprintf("%s:%d", c.Agent.VNCConfig.BindAddress, c.Agent.VNCConfig.Port)
+	return net.JoinHostPort(c.Agent.VNCConfig.BindAddress, strconv.Itoa(c.Agent.VNCConfig.Port))
 }
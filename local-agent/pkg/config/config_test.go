@@ -28,6 +28,7 @@ agent:
   bmc_discovery:
     enabled: false
     scan_interval: 10m
+    dns_refresh_interval: 30s
     network_ranges:
       - 10.0.1.0/24
       - 10.0.2.0/24
@@ -89,6 +90,24 @@ agent:
       - 10.0.0.0/8
     deny_private_networks: true
     enable_audit_logging: false
+  bmc_proxy:
+    url: socks5://jump.dc.example.com:1080
+    rules:
+      - destinations: [10.20.0.0/16, "*.oob.example.com"]
+        url: http://proxy.oob.example.com:3128
+      - destinations: [192.168.1.100]
+        url: direct
+  tunnels:
+    - name: vlan-100
+      type: wireguard
+      networks: [10.30.0.0/16]
+      config_file: /etc/wireguard/wg-bmc.conf
+    - name: lab
+      type: ssh
+      networks: [172.30.0.0/24]
+      host: agent@jump.lab.example.com
+      port: 2222
+      socks_address: 127.0.0.1:1081
 
 tls:
   enabled: true
@@ -176,6 +195,10 @@ AGENT_ID=env-agent-001
 		t.Errorf("Expected ScanInterval 10m, got %v", cfg.Agent.BMCDiscovery.ScanInterval)
 	}
 
+	if cfg.Agent.BMCDiscovery.DNSRefreshInterval != 30*time.Second {
+		t.Errorf("Expected DNSRefreshInterval 30s, got %v", cfg.Agent.BMCDiscovery.DNSRefreshInterval)
+	}
+
 	if len(cfg.Agent.BMCDiscovery.NetworkRanges) != 2 {
 		t.Errorf("Expected 2 network ranges, got %d", len(cfg.Agent.BMCDiscovery.NetworkRanges))
 	}
@@ -273,6 +296,32 @@ AGENT_ID=env-agent-001
 		t.Errorf("Expected Security.DenyPrivateNetworks true, got %v", cfg.Agent.Security.DenyPrivateNetworks)
 	}
 
+	// Test BMC proxy configuration
+	if cfg.Agent.BMCProxy.URL != "socks5://jump.dc.example.com:1080" {
+		t.Errorf("Expected BMCProxy.URL socks5://jump.dc.example.com:1080, got '%s'", cfg.Agent.BMCProxy.URL)
+	}
+
+	if len(cfg.Agent.BMCProxy.Rules) != 2 {
+		t.Fatalf("Expected 2 BMC proxy rules, got %d", len(cfg.Agent.BMCProxy.Rules))
+	}
+
+	if rule := cfg.Agent.BMCProxy.Rules[0]; len(rule.Destinations) != 2 || rule.URL != "http://proxy.oob.example.com:3128" {
+		t.Errorf("Expected 2 destinations through http://proxy.oob.example.com:3128, got %v through '%s'", rule.Destinations, rule.URL)
+	}
+
+	// Test tunnel configuration
+	if len(cfg.Agent.Tunnels) != 2 {
+		t.Fatalf("Expected 2 tunnels, got %d", len(cfg.Agent.Tunnels))
+	}
+
+	if tunnel := cfg.Agent.Tunnels[0]; tunnel.Type != "wireguard" || tunnel.ConfigFile != "/etc/wireguard/wg-bmc.conf" {
+		t.Errorf("Expected a wireguard tunnel with /etc/wireguard/wg-bmc.conf, got %+v", tunnel)
+	}
+
+	if tunnel := cfg.Agent.Tunnels[1]; tunnel.Type != "ssh" || tunnel.Port != 2222 || tunnel.SOCKSAddress != "127.0.0.1:1081" {
+		t.Errorf("Expected an ssh tunnel on port 2222 with SOCKS address 127.0.0.1:1081, got %+v", tunnel)
+	}
+
 	// Test legacy static hosts
 	if len(cfg.Static.Hosts) != 1 {
 		t.Errorf("Expected 1 static host, got %d", len(cfg.Static.Hosts))
@@ -324,6 +373,19 @@ func TestAgentConfigDefaults(t *testing.T) {
 		t.Errorf("Expected default MaxConcurrent 50, got %d", cfg.Agent.BMCDiscovery.MaxConcurrent)
 	}
 
+	if cfg.Agent.BMCDiscovery.DNSRefreshInterval != time.Minute {
+		t.Errorf("Expected default DNSRefreshInterval 1m, got %v", cfg.Agent.BMCDiscovery.DNSRefreshInterval)
+	}
+
+	// Test static hosts reconciliation defaults
+	if cfg.Static.Reconcile.Enabled || cfg.Static.Reconcile.Apply {
+		t.Errorf("Expected reconciliation disabled by default, got %+v", cfg.Static.Reconcile)
+	}
+
+	if cfg.Static.Reconcile.Interval != 15*time.Minute {
+		t.Errorf("Expected default reconcile Interval 15m, got %v", cfg.Static.Reconcile.Interval)
+	}
+
 	// Test BMC operations defaults
 	if cfg.Agent.BMCOperations.OperationTimeout != 30*time.Second {
 		t.Errorf("Expected default OperationTimeout 30s, got %v", cfg.Agent.BMCOperations.OperationTimeout)
@@ -427,6 +489,26 @@ func TestAgentConfigValidation(t *testing.T) {
 			expectError: false, // This test no longer relevant since VNC_PORT env var was removed
 			errorText:   "",
 		},
+		{
+			name: "short hosts API token",
+			setupEnv: func() {
+				os.Setenv("AGENT_GATEWAY_ENDPOINT", "http://localhost:8081")
+				os.Setenv("AGENT_DATACENTER_ID", "dc-test")
+				os.Setenv("AGENT_HOSTS_API_TOKEN", "short")
+			},
+			expectError: true,
+			errorText:   "hosts API token must be at least 16 characters",
+		},
+		{
+			name: "short debug token",
+			setupEnv: func() {
+				os.Setenv("AGENT_GATEWAY_ENDPOINT", "http://localhost:8081")
+				os.Setenv("AGENT_DATACENTER_ID", "dc-test")
+				os.Setenv("DEBUG_TOKEN", "short")
+			},
+			expectError: true,
+			errorText:   "debug token must be at least 16 characters",
+		},
 		{
 			name: "valid configuration",
 			setupEnv: func() {
@@ -443,6 +525,10 @@ func TestAgentConfigValidation(t *testing.T) {
 			os.Unsetenv("AGENT_GATEWAY_ENDPOINT")
 			os.Unsetenv("AGENT_DATACENTER_ID")
 			os.Unsetenv("BMC_DISCOVERY_NETWORK_RANGES")
+			os.Unsetenv("AGENT_HOSTS_API_TOKEN")
+			defer os.Unsetenv("AGENT_HOSTS_API_TOKEN")
+			os.Unsetenv("DEBUG_TOKEN")
+			defer os.Unsetenv("DEBUG_TOKEN")
 
 			// Setup test environment
 			tt.setupEnv()
@@ -604,6 +690,36 @@ agent:
 			expectError: true,
 			errorText:   "VNC quality must be between 0 and 9",
 		},
+		{
+			name: "stream keepalive timeout within its interval",
+			configYAML: `
+agent:
+  stream_keepalive:
+    interval: 30s
+    timeout: 30s
+`,
+			expectError: true,
+			errorText:   "stream keepalive timeout must be longer than its interval",
+		},
+		{
+			name: "stream keepalive disabled",
+			configYAML: `
+agent:
+  stream_keepalive:
+    interval: 0s
+`,
+			expectError: false,
+		},
+		{
+			name: "negative power idempotency window",
+			configYAML: `
+agent:
+  bmc_operations:
+    power_idempotency_window: -1m
+`,
+			expectError: true,
+			errorText:   "power idempotency window must not be negative",
+		},
 		{
 			name: "valid VNC config",
 			configYAML: `
@@ -754,6 +870,12 @@ func TestAgentConfigGetVNCListenAddress(t *testing.T) {
 			port:        5902,
 			expected:    "192.168.1.100:5902",
 		},
+		{
+			name:        "IPv6 address",
+			bindAddress: `"fd00::10"`,
+			port:        5903,
+			expected:    "[fd00::10]:5903",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1109,3 +1231,90 @@ static:
 		t.Errorf("Expected empty VNC endpoint for nil, got '%s'", emptyHost.GetVNCEndpoint())
 	}
 }
+
+func TestBMCHostValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      BMCHost
+		errorText string
+	}{
+		{
+			name: "valid host",
+			host: BMCHost{ID: "server-1", ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}}},
+		},
+		{
+			name:      "missing ID",
+			host:      BMCHost{ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}}},
+			errorText: "host id is required",
+		},
+		{
+			name:      "missing control endpoint",
+			host:      BMCHost{ID: "server-1"},
+			errorText: "at least one control endpoint is required",
+		},
+		{
+			name: "empty SOL endpoint",
+			host: BMCHost{
+				ID:               "server-1",
+				ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}},
+				SOLEndpoint:      &ConfigSOLEndpoint{},
+			},
+			errorText: "SOL endpoint address is required",
+		},
+		{
+			name: "SOL terminal",
+			host: BMCHost{
+				ID:               "server-1",
+				ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}},
+				SOLEndpoint: &ConfigSOLEndpoint{
+					Endpoint: "192.168.1.100:623",
+					Terminal: &types.TerminalConfig{Type: "vt100", Cols: 100, Rows: 31},
+				},
+			},
+		},
+		{
+			name: "SOL terminal without rows",
+			host: BMCHost{
+				ID:               "server-1",
+				ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}},
+				SOLEndpoint: &ConfigSOLEndpoint{
+					Endpoint: "192.168.1.100:623",
+					Terminal: &types.TerminalConfig{Cols: 100},
+				},
+			},
+			errorText: "SOL terminal: terminal size needs both columns and rows",
+		},
+		{
+			name:      "unknown privilege",
+			host:      BMCHost{ID: "server-1", ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623", Privilege: "root"}}},
+			errorText: `invalid privilege "root"`,
+		},
+		{
+			name: "OpenBMC flavor",
+			host: BMCHost{ID: "server-1", BMCFlavor: BMCFlavorOpenBMC, ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "https://192.168.1.100"}}},
+		},
+		{
+			name:      "OpenBMC flavor without Redfish",
+			host:      BMCHost{ID: "server-1", BMCFlavor: BMCFlavorOpenBMC, ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "192.168.1.100:623"}}},
+			errorText: "bmc_flavor openbmc requires a Redfish primary control endpoint",
+		},
+		{
+			name:      "unknown flavor",
+			host:      BMCHost{ID: "server-1", BMCFlavor: "ilo", ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: "https://192.168.1.100"}}},
+			errorText: `unknown bmc_flavor "ilo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.host.Validate()
+			if tt.errorText == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing '%s', got '%v'", tt.errorText, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadDebounce groups the events of a single save: editors commonly
+// truncate and write, or write a temporary file and rename it
+const reloadDebounce = 500 * time.Millisecond
+
+// StaticHostsDiff lists the static hosts changed between two configurations
+type StaticHostsDiff struct {
+	Added   []BMCHost
+	Removed []BMCHost
+	// Changed holds the new definition of hosts whose ID is kept
+	Changed []BMCHost
+}
+
+// IsEmpty returns true if the static hosts are unchanged
+func (d StaticHostsDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffStaticHosts compares static hosts by ID. Hosts are returned in the
+// order of their configuration.
+func DiffStaticHosts(current, updated []BMCHost) StaticHostsDiff {
+	var diff StaticHostsDiff
+
+	currentByID := make(map[string]BMCHost, len(current))
+	for _, host := range current {
+		currentByID[host.ID] = host
+	}
+
+	updatedIDs := make(map[string]bool, len(updated))
+	for _, host := range updated {
+		updatedIDs[host.ID] = true
+
+		previous, exists := currentByID[host.ID]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, host)
+		case !reflect.DeepEqual(previous, host):
+			diff.Changed = append(diff.Changed, host)
+		}
+	}
+
+	for _, host := range current {
+		if !updatedIDs[host.ID] {
+			diff.Removed = append(diff.Removed, host)
+		}
+	}
+
+	return diff
+}
+
+// WatchFile calls onChange after configFile is written, created or replaced,
+// until ctx is done. The parent directory is watched rather than the file,
+// so that replacing the file, as editors and Kubernetes ConfigMap updates do,
+// is detected.
+func WatchFile(ctx context.Context, configFile string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	configFile = filepath.Clean(configFile)
+	dir := filepath.Dir(configFile)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		debounce := time.NewTimer(reloadDebounce)
+		debounce.Stop()
+		defer debounce.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Kubernetes swaps the ..data symlink to update mounted files
+				if filepath.Clean(event.Name) != configFile && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				debounce.Reset(reloadDebounce)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warn().Err(err).Str("config_file", configFile).Msg("Config watcher error")
+
+			case <-debounce.C:
+				onChange()
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func staticHost(id, endpoint string) BMCHost {
+	return BMCHost{
+		ID:               id,
+		ControlEndpoints: []*ConfigBMCControlEndpoint{{Endpoint: endpoint, Username: "admin"}},
+	}
+}
+
+func TestDiffStaticHosts(t *testing.T) {
+	current := []BMCHost{
+		staticHost("server-1", "192.168.1.100:623"),
+		staticHost("server-2", "192.168.1.101:623"),
+		staticHost("server-3", "192.168.1.102:623"),
+	}
+	updated := []BMCHost{
+		staticHost("server-1", "192.168.1.100:623"),
+		staticHost("server-3", "192.168.1.110:623"),
+		staticHost("server-4", "192.168.1.103:623"),
+	}
+
+	diff := DiffStaticHosts(current, updated)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != "server-4" {
+		t.Errorf("Expected server-4 added, got %v", hostIDs(diff.Added))
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "server-2" {
+		t.Errorf("Expected server-2 removed, got %v", hostIDs(diff.Removed))
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].ID != "server-3" {
+		t.Fatalf("Expected server-3 changed, got %v", hostIDs(diff.Changed))
+	}
+	if diff.Changed[0].GetControlEndpoint() != "192.168.1.110:623" {
+		t.Errorf("Expected the new definition of server-3, got %s", diff.Changed[0].GetControlEndpoint())
+	}
+
+	if !DiffStaticHosts(current, current).IsEmpty() {
+		t.Error("Expected no changes between identical hosts")
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "agent.yaml")
+	if err := os.WriteFile(configFile, []byte("static:\n  hosts: []\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	if err := WatchFile(ctx, configFile, func() { changes <- struct{}{} }); err != nil {
+		t.Fatalf("WatchFile failed: %v", err)
+	}
+
+	// Other files of the directory are ignored
+	if err := os.WriteFile(filepath.Join(filepath.Dir(configFile), "other.yaml"), nil, 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	// Replace the file the way editors do
+	tmpFile := configFile + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte("static:\n  hosts:\n    - id: server-1\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.Rename(tmpFile, configFile); err != nil {
+		t.Fatalf("Failed to replace config: %v", err)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a change notification")
+	}
+
+	// The events of a single save are merged
+	select {
+	case <-changes:
+		t.Error("Expected a single change notification")
+	case <-time.After(2 * reloadDebounce):
+	}
+}
+
+func hostIDs(hosts []BMCHost) []string {
+	ids := make([]string, len(hosts))
+	for i, host := range hosts {
+		ids[i] = host.ID
+	}
+	return ids
+}
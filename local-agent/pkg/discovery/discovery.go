@@ -0,0 +1,974 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"core/identity"
+	"core/types"
+	"local-agent/pkg/config"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/netpolicy"
+	"local-agent/pkg/redfish"
+)
+
+// Service handles BMC discovery in the local datacenter
+type Service struct {
+	ipmiClient    *ipmi.Client
+	redfishClient *redfish.Client
+	config        *config.Config
+
+	// staticHosts are the configured hosts and those added through the
+	// hosts API, replaced at runtime
+	mu          sync.RWMutex
+	staticHosts []config.BMCHost
+
+	// lookupHost resolves the names of BMC endpoints
+	lookupHost func(ctx context.Context, host string) ([]netip.Addr, error)
+
+	// ipmiDeviceInfo reads the Get Device ID and FRU inventory of IPMI BMCs
+	ipmiDeviceInfo func(ctx context.Context, endpoint, username, password string) (*ipmi.BMCInfo, error)
+}
+
+func NewService(ipmiClient *ipmi.Client, redfishClient *redfish.Client, cfg *config.Config) *Service {
+	s := &Service{
+		ipmiClient:    ipmiClient,
+		redfishClient: redfishClient,
+		config:        cfg,
+		staticHosts:   cfg.Static.Hosts,
+		lookupHost:    lookupNetIP,
+	}
+	if ipmiClient != nil {
+		s.ipmiDeviceInfo = ipmiClient.GetBMCInfo
+	}
+	return s
+}
+
+// DiscoverServers discovers all BMC endpoints combining static config and auto-discovery
+func (s *Service) DiscoverServers(ctx context.Context) ([]*domain.Server, error) {
+	log.Info().Msg("Starting BMC discovery")
+
+	var allServers []*domain.Server
+
+	// First, add statically configured servers
+	staticServers := s.loadStaticServers()
+	allServers = append(allServers, staticServers...)
+	log.Info().Int("count", len(staticServers)).Msg("Loaded static BMC hosts")
+
+	// Then perform auto-discovery if enabled
+	if s.config.Agent.BMCDiscovery.Enabled {
+		discoveredServers, err := s.performAutoDiscovery(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("Auto-discovery failed")
+		} else {
+			// Filter out any duplicates (static hosts that were also discovered)
+			discoveredServers = s.filterDuplicates(allServers, discoveredServers)
+			allServers = append(allServers, discoveredServers...)
+			log.Info().Int("count", len(discoveredServers)).Msg("Auto-discovery found additional servers")
+		}
+	} else {
+		log.Info().Msg("Auto-discovery disabled")
+	}
+
+	// Servers with an endpoint outside the allowed networks are never used
+	allServers = s.filterDenied(ctx, allServers)
+
+	log.Info().
+		Int("total", len(allServers)).
+		Int("static", len(staticServers)).
+		Int("discovered", len(allServers)-len(staticServers)).
+		Msg("Discovery completed")
+	return allServers, nil
+}
+
+// StaticHosts returns the static hosts
+func (s *Service) StaticHosts() []config.BMCHost {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.staticHosts
+}
+
+// SetStaticHosts replaces the static hosts, used by the following discoveries
+func (s *Service) SetStaticHosts(hosts []config.BMCHost) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staticHosts = hosts
+}
+
+// StaticServers converts static hosts to Server structs, dropping those with
+// an endpoint outside the allowed networks like DiscoverServers does
+func (s *Service) StaticServers(ctx context.Context, hosts []config.BMCHost) []*domain.Server {
+	return s.filterDenied(ctx, s.buildStaticServers(hosts))
+}
+
+// loadStaticServers converts configured static hosts to Server structs
+func (s *Service) loadStaticServers() []*domain.Server {
+	return s.buildStaticServers(s.StaticHosts())
+}
+
+// buildStaticServers converts static hosts to Server structs
+func (s *Service) buildStaticServers(hosts []config.BMCHost) []*domain.Server {
+	var servers []*domain.Server
+
+	for _, host := range hosts {
+		// Initialize metadata map if not present
+		metadata := host.Metadata
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+
+		server := &domain.Server{
+			ID:         host.ID,
+			CustomerID: host.CustomerID,
+			Features:   host.Features,
+			Status:     "configured", // Mark as configured vs discovered
+			Metadata:   metadata,
+		}
+
+		// Convert control endpoints
+		if len(host.ControlEndpoints) > 0 {
+			server.ControlEndpoints = make([]*types.BMCControlEndpoint, len(host.ControlEndpoints))
+			for i, endpoint := range host.ControlEndpoints {
+				server.ControlEndpoints[i] = endpoint.ToTypesEndpoint()
+				// Endpoints named in DNS map to a server whatever their case
+				server.ControlEndpoints[i].Endpoint = identity.NormalizeBMCEndpoint(endpoint.Endpoint)
+			}
+			// Set primary protocol to first endpoint's type
+			if len(server.ControlEndpoints) > 0 {
+				server.PrimaryProtocol = server.GetPrimaryControlEndpoint().Type
+			}
+		}
+
+		// Convert SOL endpoint
+		if host.SOLEndpoint != nil {
+			server.SOLEndpoint = host.SOLEndpoint.ToTypesEndpoint()
+			server.SOLEndpoint.Endpoint = identity.NormalizeBMCEndpoint(server.SOLEndpoint.Endpoint)
+		}
+
+		// Convert VNC endpoint
+		if host.VNCEndpoint != nil {
+			server.VNCEndpoint = host.VNCEndpoint.ToTypesEndpoint()
+			server.VNCEndpoint.Endpoint = identity.NormalizeBMCEndpoint(server.VNCEndpoint.Endpoint)
+		}
+
+		// If Redfish, perform API discovery if enabled
+		// Check primary endpoint (first in list) for Redfish protocol
+		if len(server.ControlEndpoints) > 0 && server.GetPrimaryControlEndpoint().Type == types.BMCTypeRedfish {
+			control := server.GetPrimaryControlEndpoint()
+			var info *redfish.SerialConsoleInfo
+			var err error
+			if host.BMCFlavor == config.BMCFlavorOpenBMC {
+				info, err = s.redfishClient.DiscoverSerialConsoleAs(context.Background(), redfish.VendorOpenBMC, control.Endpoint, control.Username, control.Password)
+			} else {
+				info, err = s.redfishClient.DiscoverSerialConsole(context.Background(), control.Endpoint, control.Username, control.Password)
+			}
+			if err != nil {
+				log.Warn().Err(err).Str("endpoint", control.Endpoint).Msg("Failed to discover SerialConsole for static server")
+				server.Metadata["discovery_error"] = err.Error()
+				// The consoles of hosts declared as OpenBMC are known
+				// without asking their BMC
+				if host.BMCFlavor == config.BMCFlavorOpenBMC {
+					s.applySerialConsoleInfo(server, redfish.OpenBMCConsoleInfo())
+				}
+			} else {
+				s.applySerialConsoleInfo(server, info)
+			}
+		}
+
+		// IPMI BMCs have no API discovery, their vendor and model come
+		// from Get Device ID and the FRU inventory
+		if len(server.ControlEndpoints) > 0 && server.GetPrimaryControlEndpoint().Type == types.BMCTypeIPMI {
+			control := server.GetPrimaryControlEndpoint()
+			s.applyIPMIDeviceInfo(context.Background(), server, []config.CredentialConfig{
+				{Username: control.Username, Password: control.Password},
+			})
+		}
+
+		// Build discovery metadata for static configuration
+		discoveryMetadata := s.buildDiscoveryMetadata(server, types.DiscoveryMethodStaticConfig, "config.yaml")
+		discoveryMetadata.DiscoveredAt = time.Now()
+		server.DiscoveryMetadata = discoveryMetadata
+
+		servers = append(servers, server)
+
+		vncEndpoint := "none"
+		if server.VNCEndpoint != nil {
+			vncEndpoint = server.VNCEndpoint.Endpoint
+		}
+
+		log.Debug().
+			Str("host_id", host.ID).
+			Str("control", host.GetControlEndpoint()).
+			Str("sol", host.GetSOLEndpoint()).
+			Str("vnc", vncEndpoint).
+			Msg("Loaded static BMC host")
+	}
+
+	return servers
+}
+
+// applySerialConsoleInfo configures the SOL endpoint of a Redfish server
+// from its serial console discovery results
+func (s *Service) applySerialConsoleInfo(server *domain.Server, info *redfish.SerialConsoleInfo) {
+	control := server.GetPrimaryControlEndpoint()
+	endpoint := control.Endpoint
+
+	// Store vendor information
+	server.Metadata["vendor"] = string(info.Vendor)
+
+	// Log discovery results for debugging
+	log.Debug().
+		Str("endpoint", endpoint).
+		Str("vendor", string(info.Vendor)).
+		Bool("supported", info.Supported).
+		Bool("fallbackToIPMI", info.FallbackToIPMI).
+		Str("serialPath", info.SerialPath).
+		Msg("Serial console discovery results")
+
+	// Configure SOL endpoint based on discovery
+	// Always override inferred/configured SOL endpoints with actual discovery results
+	// This ensures vendor-specific behavior (like iDRAC requiring IPMI fallback) is respected
+	if info.Supported && info.SerialPath != "" {
+		// Use Redfish serial console if supported
+		server.SOLEndpoint = &types.SOLEndpoint{
+			Type:     types.SOLTypeRedfishSerial,
+			Endpoint: endpoint + info.SerialPath,
+			Username: control.Username,
+			Password: control.Password,
+		}
+		log.Info().Str("endpoint", endpoint).Str("vendor", string(info.Vendor)).Msg("Using Redfish serial console")
+	} else if info.FallbackToIPMI {
+		// Fallback to IPMI SOL
+		log.Debug().Str("endpoint", endpoint).Msg("Attempting to build IPMI endpoint for fallback")
+		ipmiEndpoint, err := s.buildIPMIEndpoint(endpoint)
+		if err != nil {
+			log.Warn().Err(err).Str("endpoint", endpoint).Msg("Failed to build IPMI endpoint")
+		} else {
+			log.Debug().Str("ipmiEndpoint", ipmiEndpoint).Msg("Built IPMI endpoint successfully")
+			server.SOLEndpoint = &types.SOLEndpoint{
+				Type:     types.SOLTypeIPMI,
+				Endpoint: ipmiEndpoint,
+				Username: control.Username,
+				Password: control.Password,
+			}
+			server.Metadata["sol_fallback"] = "ipmi"
+			log.Info().
+				Str("endpoint", endpoint).
+				Str("ipmiEndpoint", ipmiEndpoint).
+				Str("vendor", string(info.Vendor)).
+				Msg("Using IPMI SOL fallback")
+		}
+	} else {
+		// No console support detected, clear any inferred SOL endpoint
+		server.SOLEndpoint = nil
+		log.Warn().Str("endpoint", endpoint).Str("vendor", string(info.Vendor)).Msg("No serial console support detected")
+	}
+
+	// Ensure FeatureConsole is included if supported or fallback
+	if info.Supported || info.FallbackToIPMI {
+		hasConsole := false
+		for _, f := range server.Features {
+			if f == string(types.FeatureConsole) {
+				hasConsole = true
+				break
+			}
+		}
+		if !hasConsole {
+			server.Features = append(server.Features, string(types.FeatureConsole))
+		}
+	}
+
+	// Use the KVM WebSocket of vendors serving one (OpenBMC), unless a VNC
+	// endpoint is configured
+	if info.KVMPath != "" && server.VNCEndpoint == nil {
+		server.VNCEndpoint = &types.VNCEndpoint{
+			Type:     types.VNCTypeWebSocket,
+			Endpoint: redfish.BuildWebSocketURL(endpoint, info.KVMPath),
+			Username: control.Username,
+			Password: control.Password,
+		}
+		if !slices.Contains(server.Features, string(types.FeatureVNC)) {
+			server.Features = append(server.Features, string(types.FeatureVNC))
+		}
+		log.Info().Str("endpoint", endpoint).Str("vendor", string(info.Vendor)).Msg("Using BMC KVM WebSocket")
+	}
+}
+
+// performAutoDiscovery runs the original auto-discovery logic
+func (s *Service) performAutoDiscovery(ctx context.Context) ([]*domain.Server, error) {
+	var allServers []*domain.Server
+	discovery := s.config.Agent.BMCDiscovery
+
+	// Get list of network interfaces and subnets to scan
+	subnets, err := s.getLocalSubnets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local subnets: %w", err)
+	}
+
+	for _, subnet := range subnets {
+		log.Info().Str("subnet", subnet).Msg("Scanning subnet")
+
+		// Discover IPMI BMCs
+		if discovery.EnableIPMIDetection {
+			ipmiServers, err := s.discoverIPMI(ctx, subnet)
+			if err != nil {
+				log.Warn().Str("subnet", subnet).Err(err).Msg("IPMI discovery failed")
+			} else {
+				allServers = append(allServers, ipmiServers...)
+			}
+		}
+
+		// Discover Redfish BMCs
+		if discovery.EnableRedfishDetection {
+			redfishServers, err := s.discoverRedfish(ctx, subnet)
+			if err != nil {
+				log.Warn().Str("subnet", subnet).Err(err).Msg("Redfish discovery failed")
+			} else {
+				allServers = append(allServers, redfishServers...)
+			}
+		}
+	}
+
+	return allServers, nil
+}
+
+// filterDuplicates removes discovered servers that match static configuration
+func (s *Service) filterDuplicates(staticServers, discoveredServers []*domain.Server) []*domain.Server {
+	var filtered []*domain.Server
+
+	// Create a map of static control endpoints for quick lookup
+	staticEndpoints := make(map[string]bool)
+	for _, server := range staticServers {
+		if len(server.ControlEndpoints) > 0 {
+			staticEndpoints[server.GetPrimaryControlEndpoint().Endpoint] = true
+		}
+	}
+	// Static hosts named in DNS are found by the scans at their address
+	addresses := staticAddresses(staticServers)
+
+	// Only include discovered servers that don't match static config
+	for _, server := range discoveredServers {
+		controlEndpoint := ""
+		if len(server.ControlEndpoints) > 0 {
+			controlEndpoint = server.GetPrimaryControlEndpoint().Endpoint
+		}
+		if !staticEndpoints[controlEndpoint] && !addresses[netpolicy.EndpointHost(controlEndpoint)] {
+			filtered = append(filtered, server)
+		} else {
+			log.Debug().Str("endpoint", controlEndpoint).Msg("Skipping discovered server (already configured statically)")
+		}
+	}
+
+	return filtered
+}
+
+// filterDenied removes servers with an endpoint that the network policy
+// denies, so that they are not registered with the gateway. Endpoints that
+// fail to resolve are kept: clients check the address again when they
+// connect.
+func (s *Service) filterDenied(ctx context.Context, servers []*domain.Server) []*domain.Server {
+	var filtered []*domain.Server
+	for _, server := range servers {
+		if endpoint, err := deniedEndpoint(ctx, server); err != nil {
+			log.Warn().
+				Err(err).
+				Str("server_id", server.ID).
+				Str("endpoint", endpoint).
+				Msg("Skipping server with an endpoint outside the allowed networks")
+			continue
+		}
+		filtered = append(filtered, server)
+	}
+	return filtered
+}
+
+// deniedEndpoint returns the first endpoint of a server that the network
+// policy denies, and the policy's error
+func deniedEndpoint(ctx context.Context, server *domain.Server) (string, error) {
+	var endpoints []string
+	for _, endpoint := range server.ControlEndpoints {
+		endpoints = append(endpoints, endpoint.Endpoint)
+	}
+	if server.SOLEndpoint != nil {
+		endpoints = append(endpoints, server.SOLEndpoint.Endpoint)
+	}
+	if server.VNCEndpoint != nil {
+		endpoints = append(endpoints, server.VNCEndpoint.Endpoint)
+	}
+
+	for _, endpoint := range endpoints {
+		if err := netpolicy.CheckEndpoint(ctx, endpoint); errors.Is(err, netpolicy.ErrDenied) {
+			return endpoint, err
+		}
+	}
+	return "", nil
+}
+
+// Discovery defaults, used when the configuration leaves them unset
+var (
+	defaultIPMIPorts    = []int{623}
+	defaultRedfishPorts = []int{443, 8443, 8080}
+
+	// defaultCredentials are tried when no default_credentials are configured
+	defaultCredentials = []config.CredentialConfig{{Username: "admin", Password: "password"}}
+)
+
+const (
+	defaultScanTimeout   = 10 * time.Second
+	defaultMaxConcurrent = 50
+	maxScannedHosts      = 100 // Addresses scanned per subnet
+)
+
+// ipmiPorts returns the ports probed for IPMI
+func (s *Service) ipmiPorts() []int {
+	if ports := s.config.Agent.BMCDiscovery.IPMIPorts; len(ports) > 0 {
+		return ports
+	}
+	return defaultIPMIPorts
+}
+
+// redfishPorts returns the ports probed for Redfish, in order of preference
+func (s *Service) redfishPorts() []int {
+	if ports := s.config.Agent.BMCDiscovery.RedfishPorts; len(ports) > 0 {
+		return ports
+	}
+	return defaultRedfishPorts
+}
+
+// credentials returns the credentials tried on discovered BMCs, in order
+func (s *Service) credentials() []config.CredentialConfig {
+	if credentials := s.config.Agent.BMCDiscovery.DefaultCredentials; len(credentials) > 0 {
+		return credentials
+	}
+	return defaultCredentials
+}
+
+// isAccessible runs a BMC accessibility check within scan_timeout
+func (s *Service) isAccessible(ctx context.Context, check func(ctx context.Context, endpoint string) bool, endpoint string) bool {
+	timeout := s.config.Agent.BMCDiscovery.ScanTimeout
+	if timeout <= 0 {
+		timeout = defaultScanTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return check(checkCtx, endpoint)
+}
+
+// scanSubnet probes the IPs of a subnet concurrently, up to max_concurrent
+// at a time. The servers found are returned in IP order.
+func (s *Service) scanSubnet(ctx context.Context, subnet string, probe func(ctx context.Context, ip net.IP) *domain.Server) ([]*domain.Server, error) {
+	// Parse subnet to get IP range
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet: %w", err)
+	}
+
+	maxConcurrent := s.config.Agent.BMCDiscovery.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+
+	ips := s.generateIPsFromSubnet(ipnet)
+	found := make([]*domain.Server, len(ips))
+	sem := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+
+	for i, ip := range ips {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			found[i] = probe(ctx, ip)
+		}()
+	}
+	wg.Wait()
+
+	var servers []*domain.Server
+	for _, server := range found {
+		if server != nil {
+			servers = append(servers, server)
+		}
+	}
+	return servers, ctx.Err()
+}
+
+// discoverIPMI discovers IPMI-enabled BMCs in a subnet
+func (s *Service) discoverIPMI(ctx context.Context, subnet string) ([]*domain.Server, error) {
+	log.Debug().Str("subnet", subnet).Msg("Discovering IPMI BMCs")
+
+	return s.scanSubnet(ctx, subnet, func(ctx context.Context, ip net.IP) *domain.Server {
+		for _, port := range s.ipmiPorts() {
+			endpoint := net.JoinHostPort(ip.String(), strconv.Itoa(port))
+			if !s.isAccessible(ctx, s.ipmiClient.IsAccessible, endpoint) {
+				continue
+			}
+
+			// The accessibility check is unauthenticated: the credentials
+			// are those the BMC accepts to read its device ID, or the
+			// first ones until the BMC is configured statically
+			credentials := s.credentials()[0]
+			id := scannedServerID(ip)
+			if port != defaultIPMIPorts[0] {
+				id = fmt.Sprintf("%s-%d", id, port)
+			}
+			server := &domain.Server{
+				ID:         id,
+				CustomerID: "customer-1", // TODO: Determine customer ownership
+				ControlEndpoints: []*types.BMCControlEndpoint{
+					{
+						Endpoint:     endpoint,
+						Type:         types.BMCTypeIPMI,
+						Username:     credentials.Username,
+						Password:     credentials.Password,
+						Capabilities: types.CapabilitiesToStrings(types.IPMICapabilities()),
+					},
+				},
+				PrimaryProtocol: types.BMCTypeIPMI,
+				Features: types.FeaturesToStrings([]types.Feature{
+					types.FeaturePower,
+					types.FeatureConsole,
+					types.FeatureVNC,
+					types.FeatureSensors,
+				}),
+				Status:   "active",
+				Metadata: make(map[string]string),
+			}
+			s.applyIPMIDeviceInfo(ctx, server, s.credentials())
+			s.setScanMetadata(server)
+			log.Info().Str("endpoint", endpoint).Str("vendor", server.Metadata["vendor"]).Msg("Found IPMI BMC")
+			return server
+		}
+		return nil
+	})
+}
+
+// applyIPMIDeviceInfo records the vendor, model and firmware of an IPMI
+// server read with the first credentials its BMC accepts, keeping the
+// credentials of the control endpoint and a discovery error if none does
+func (s *Service) applyIPMIDeviceInfo(ctx context.Context, server *domain.Server, candidates []config.CredentialConfig) {
+	if s.ipmiDeviceInfo == nil {
+		return
+	}
+
+	control := server.GetPrimaryControlEndpoint()
+	for i, credentials := range candidates {
+		info, err := s.ipmiDeviceInfo(ctx, control.Endpoint, credentials.Username, credentials.Password)
+		if err != nil {
+			log.Debug().Err(err).Str("endpoint", control.Endpoint).Str("username", credentials.Username).Msg("Failed to read IPMI device ID")
+			if i == 0 {
+				server.Metadata["discovery_error"] = err.Error()
+			}
+			continue
+		}
+		control.Username, control.Password = credentials.Username, credentials.Password
+		delete(server.Metadata, "discovery_error")
+
+		for key, value := range map[string]string{
+			"vendor":           info.Vendor,
+			"model":            info.Model,
+			"firmware_version": info.FirmwareVersion,
+			"ipmi_version":     info.IPMIVersion,
+		} {
+			if value != "" {
+				server.Metadata[key] = value
+			}
+		}
+		log.Debug().
+			Str("endpoint", control.Endpoint).
+			Str("vendor", info.Vendor).
+			Str("model", info.Model).
+			Str("firmware", info.FirmwareVersion).
+			Msg("IPMI device ID discovery results")
+		return
+	}
+
+	if reason, failed := server.Metadata["discovery_error"]; failed {
+		log.Warn().Str("endpoint", control.Endpoint).Str("error", reason).Msg("Failed to read IPMI device ID")
+	}
+}
+
+// discoverRedfish discovers Redfish-enabled BMCs in a subnet
+func (s *Service) discoverRedfish(ctx context.Context, subnet string) ([]*domain.Server, error) {
+	log.Debug().Str("subnet", subnet).Msg("Discovering Redfish BMCs")
+
+	return s.scanSubnet(ctx, subnet, func(ctx context.Context, ip net.IP) *domain.Server {
+		for _, port := range s.redfishPorts() {
+			endpoint := "https://" + net.JoinHostPort(ip.String(), strconv.Itoa(port))
+			if !s.isAccessible(ctx, s.redfishClient.IsAccessible, endpoint) {
+				continue
+			}
+
+			server := &domain.Server{
+				ID:         fmt.Sprintf("%s-%d", scannedServerID(ip), port),
+				CustomerID: "customer-1", // TODO: Determine customer ownership
+				ControlEndpoints: []*types.BMCControlEndpoint{
+					{
+						Endpoint:     endpoint,
+						Type:         types.BMCTypeRedfish,
+						Capabilities: types.CapabilitiesToStrings(types.RedfishCapabilities()),
+					},
+				},
+				PrimaryProtocol: types.BMCTypeRedfish,
+				Features: types.FeaturesToStrings([]types.Feature{
+					types.FeaturePower,
+					types.FeatureConsole,
+					types.FeatureVNC,
+					types.FeatureSensors,
+				}),
+				Status:   "active",
+				Metadata: make(map[string]string),
+			}
+
+			// Perform API discovery with the first credentials the BMC
+			// accepts, keeping the first credentials if none does
+			control := server.GetPrimaryControlEndpoint()
+			for i, credentials := range s.credentials() {
+				info, err := s.redfishClient.DiscoverSerialConsole(ctx, endpoint, credentials.Username, credentials.Password)
+				if err != nil {
+					log.Debug().Err(err).Str("endpoint", endpoint).Str("username", credentials.Username).Msg("Failed to discover SerialConsole")
+					if i == 0 {
+						control.Username, control.Password = credentials.Username, credentials.Password
+						server.Metadata["discovery_error"] = err.Error()
+					}
+					continue
+				}
+				control.Username, control.Password = credentials.Username, credentials.Password
+				delete(server.Metadata, "discovery_error")
+				if info.Supported && info.SerialPath == "" {
+					info.SerialPath = "/redfish/v1/Managers/1/SerialConsole" // Adjust based on actual path
+				}
+				s.applySerialConsoleInfo(server, info)
+				break
+			}
+			if reason, failed := server.Metadata["discovery_error"]; failed {
+				log.Warn().Str("endpoint", endpoint).Str("error", reason).Msg("Failed to discover SerialConsole")
+			}
+
+			s.setScanMetadata(server)
+			log.Info().Str("endpoint", endpoint).Msg("Found Redfish BMC")
+			return server // Found Redfish on this IP, no need to check other ports
+		}
+		return nil
+	})
+}
+
+// scannedServerID returns the ID of a server found at ip by a network scan,
+// e.g. server-192-168-1-100 or server-fd00--10
+func scannedServerID(ip net.IP) string {
+	return "server-" + strings.NewReplacer(".", "-", ":", "-").Replace(ip.String())
+}
+
+// setScanMetadata records how a scanned server was discovered
+func (s *Service) setScanMetadata(server *domain.Server) {
+	discoveryMetadata := s.buildDiscoveryMetadata(server, types.DiscoveryMethodNetworkScan, "")
+	discoveryMetadata.DiscoveredAt = time.Now()
+	server.DiscoveryMetadata = discoveryMetadata
+}
+
+// getLocalSubnets returns a list of local subnets to scan for BMCs
+func (s *Service) getLocalSubnets() ([]string, error) {
+	// If subnets are explicitly configured, use those
+	if len(s.config.Agent.BMCDiscovery.NetworkRanges) > 0 {
+		log.Info().Strs("subnets", s.config.Agent.BMCDiscovery.NetworkRanges).Msg("Using configured subnets")
+		return s.config.Agent.BMCDiscovery.NetworkRanges, nil
+	}
+
+	// Otherwise, auto-detect local subnets
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
+	}
+
+	var subnets []string
+
+	for _, iface := range interfaces {
+		// Skip loopback and down interfaces
+		if iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			log.Debug().Str("interface", iface.Name).Err(err).Msg("Failed to get interface addresses")
+			continue
+		}
+
+		for _, addr := range addrs {
+			var ipnet *net.IPNet
+			switch v := addr.(type) {
+			case *net.IPNet:
+				ipnet = v
+			case *net.IPAddr:
+				ipnet = &net.IPNet{IP: v.IP, Mask: v.IP.DefaultMask()}
+			}
+
+			// Look for typical BMC subnets (e.g., 192.168.x.x/24, 10.x.x.x/24,
+			// or IPv6 unique local fd00::/8)
+			if ipnet != nil && s.isBMCSubnet(ipnet) {
+				subnets = append(subnets, ipnet.String())
+			}
+		}
+	}
+
+	// If no suitable subnets found, add some common BMC subnets
+	if len(subnets) == 0 {
+		log.Info().Msg("No suitable subnets found, using defaults")
+		subnets = []string{
+			"192.168.1.0/24",  // Common home/small office
+			"192.168.10.0/24", // Common BMC subnet
+			"10.0.1.0/24",     // Common enterprise BMC
+		}
+	}
+
+	return subnets, nil
+}
+
+// isBMCSubnet checks if a subnet is likely to contain BMCs
+func (s *Service) isBMCSubnet(ipnet *net.IPNet) bool {
+	ip := ipnet.IP.To4()
+	if ip == nil {
+		// IPv6 unique local addresses, fc00::/7. Link-local and global
+		// addresses are not management networks.
+		return ipnet.IP.To16() != nil && ipnet.IP.IsPrivate()
+	}
+
+	// Look for private IP ranges that might contain BMCs
+	return (ip[0] == 192 && ip[1] == 168) || // 192.168.x.x
+		(ip[0] == 10) || // 10.x.x.x
+		(ip[0] == 172 && ip[1] >= 16 && ip[1] <= 31) // 172.16-31.x.x
+}
+
+// buildIPMIEndpoint converts a Redfish endpoint URL to an IPMI endpoint
+func (s *Service) buildIPMIEndpoint(redfishEndpoint string) (string, error) {
+	u, err := url.Parse(redfishEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	// Host without port nor IPv6 brackets
+	host := u.Hostname()
+
+	// Standard IPMI port is 623
+	return net.JoinHostPort(host, "623"), nil
+}
+
+// generateIPsFromSubnet generates a list of IPs to scan in a subnet
+func (s *Service) generateIPsFromSubnet(ipnet *net.IPNet) []net.IP {
+	var ips []net.IP
+
+	// For performance, limit scanning to first 254 IPs
+	// In production, this would be more sophisticated
+	ip := ipnet.IP.To4()
+	if ip == nil {
+		return generateIPv6Hosts(ipnet)
+	}
+
+	// Create base IP for iteration
+	base := make(net.IP, 4)
+	copy(base, ip)
+
+	// Scan the last octet (simplified approach)
+	for i := 1; i <= 254; i++ {
+		scanIP := make(net.IP, 4)
+		copy(scanIP, base)
+		scanIP[3] = byte(i)
+
+		if ipnet.Contains(scanIP) {
+			ips = append(ips, scanIP)
+		}
+
+		// Limit to prevent excessive scanning
+		if len(ips) >= maxScannedHosts {
+			break
+		}
+	}
+
+	return ips
+}
+
+// generateIPv6Hosts generates the IPs to scan in an IPv6 subnet. Subnets are
+// far too large to sweep, usually /64: the first addresses after the prefix
+// are scanned, where BMCs are numbered statically (e.g. fd00:10::1 to
+// fd00:10::64). Other addresses are scanned by listing narrower subnets in
+// network_ranges.
+func generateIPv6Hosts(ipnet *net.IPNet) []net.IP {
+	addr, ok := netip.AddrFromSlice(ipnet.IP.To16())
+	if !ok {
+		return nil
+	}
+	ones, _ := ipnet.Mask.Size()
+	prefix := netip.PrefixFrom(addr, ones).Masked()
+
+	var ips []net.IP
+	// The subnet-router anycast address, the prefix itself, is skipped,
+	// unless it is the only address
+	addr = prefix.Addr()
+	if prefix.Bits() < 128 {
+		addr = addr.Next()
+	}
+	for ; addr.IsValid() && prefix.Contains(addr) && len(ips) < maxScannedHosts; addr = addr.Next() {
+		ips = append(ips, net.IP(addr.AsSlice()))
+	}
+	return ips
+}
+
+// buildDiscoveryMetadata constructs discovery metadata for a server
+func (s *Service) buildDiscoveryMetadata(server *domain.Server, discoveryMethod types.DiscoveryMethod, configSource string) *types.DiscoveryMetadata {
+	metadata := &types.DiscoveryMetadata{
+		DiscoveryMethod: discoveryMethod,
+		DiscoveredAt:    time.Time{}, // Will be set by caller with time.Now()
+		DiscoverySource: s.config.Agent.ID,
+		ConfigSource:    configSource,
+		AdditionalInfo:  make(map[string]string),
+	}
+
+	// Build protocol configuration
+	if len(server.ControlEndpoints) > 0 {
+		protocol := &types.ProtocolConfig{
+			PrimaryProtocol: string(server.GetPrimaryControlEndpoint().Type),
+		}
+
+		// Add protocol version if known
+		if server.GetPrimaryControlEndpoint().Type == "ipmi" {
+			protocol.PrimaryVersion = "2.0" // Assume IPMI 2.0 unless the BMC reported it
+			if version := server.Metadata["ipmi_version"]; version != "" {
+				protocol.PrimaryVersion = version
+			}
+		}
+
+		// Check for fallback configuration
+		if val, ok := server.Metadata["sol_fallback"]; ok && val == "ipmi" {
+			protocol.FallbackProtocol = "ipmi"
+			protocol.FallbackReason = fmt.Sprintf("Vendor %s requires IPMI fallback for console", server.Metadata["vendor"])
+		}
+
+		// Set console type
+		if server.SOLEndpoint != nil {
+			protocol.ConsoleType = string(server.SOLEndpoint.Type)
+			// If it's a Redfish serial console, try to extract the path
+			if server.SOLEndpoint.Type == "redfish_serial" && strings.Contains(server.SOLEndpoint.Endpoint, "/redfish/") {
+				u, err := url.Parse(server.SOLEndpoint.Endpoint)
+				if err == nil {
+					protocol.ConsolePath = u.Path
+				}
+			}
+		}
+
+		// Set VNC transport
+		if server.VNCEndpoint != nil {
+			protocol.VNCTransport = string(server.VNCEndpoint.Type)
+		}
+
+		metadata.Protocol = protocol
+	}
+
+	// Build endpoint details
+	if len(server.ControlEndpoints) > 0 {
+		endpoints := &types.EndpointDetails{
+			ControlEndpoint: server.GetPrimaryControlEndpoint().Endpoint,
+		}
+
+		// Parse control endpoint URL to extract scheme and port
+		if u, err := url.Parse(server.GetPrimaryControlEndpoint().Endpoint); err == nil {
+			endpoints.ControlScheme = u.Scheme
+			if portStr := u.Port(); portStr != "" {
+				if port, err := fmt.Sscanf(portStr, "%d", &endpoints.ControlPort); err == nil && port == 1 {
+					// Port extracted successfully
+				}
+			}
+		}
+
+		if server.SOLEndpoint != nil {
+			endpoints.ConsoleEndpoint = server.SOLEndpoint.Endpoint
+		}
+
+		if server.VNCEndpoint != nil {
+			endpoints.VNCEndpoint = server.VNCEndpoint.Endpoint
+		}
+
+		metadata.Endpoints = endpoints
+	}
+
+	// Build security configuration
+	security := &types.SecurityConfig{
+		AuthMethod: "basic", // Default to basic auth
+	}
+
+	if len(server.ControlEndpoints) > 0 && server.GetPrimaryControlEndpoint().TLS != nil {
+		security.TLSEnabled = server.GetPrimaryControlEndpoint().TLS.Enabled
+		security.TLSVerify = !server.GetPrimaryControlEndpoint().TLS.InsecureSkipVerify
+	}
+
+	if server.VNCEndpoint != nil && server.VNCEndpoint.Password != "" {
+		security.VNCAuthType = "password"
+		security.VNCPasswordLength = int32(len(server.VNCEndpoint.Password))
+	}
+
+	metadata.Security = security
+
+	// Build network information
+	if len(server.ControlEndpoints) > 0 {
+		network := &types.NetworkInfo{
+			Reachable: true, // If we got here, it's reachable
+		}
+
+		endpoint := server.GetPrimaryControlEndpoint().Endpoint
+		if hostname := endpointHostname(endpoint); hostname != "" {
+			// The address of a BMC named in DNS may change, see
+			// RefreshAddresses
+			network.Hostname = hostname
+			address, err := s.resolveAddress(context.Background(), hostname)
+			if err != nil {
+				log.Debug().Err(err).Str("hostname", hostname).Msg("Failed to resolve BMC endpoint")
+			}
+			network.IPAddress = address
+		} else {
+			network.IPAddress = netpolicy.EndpointHost(endpoint)
+		}
+
+		metadata.Network = network
+	}
+
+	// Build capability information
+	capabilities := &types.CapabilityInfo{
+		SupportedFeatures: server.Features,
+	}
+
+	if discoveryError, ok := server.Metadata["discovery_error"]; ok {
+		capabilities.DiscoveryErrors = []string{discoveryError}
+	}
+
+	metadata.Capabilities = capabilities
+
+	// Build vendor information from metadata if available
+	vendor := types.VendorInfo{
+		Manufacturer:    server.Metadata["vendor"],
+		Model:           server.Metadata["model"],
+		FirmwareVersion: server.Metadata["firmware_version"],
+	}
+	if vendor != (types.VendorInfo{}) {
+		metadata.Vendor = &vendor
+	}
+
+	return metadata
+}
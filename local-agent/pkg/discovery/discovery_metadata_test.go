@@ -361,9 +361,7 @@ func TestDiscoveryMetadata_Integration(t *testing.T) {
 	}
 
 	// Create service (without actual clients since we're using IPMI)
-	service := &Service{
-		config: cfg,
-	}
+	service := NewService(nil, nil, cfg)
 
 	// Load static servers
 	servers := service.loadStaticServers()
@@ -0,0 +1,757 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"core/domain"
+	"core/testing/redfishsim"
+	"core/types"
+	"local-agent/pkg/config"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/netpolicy"
+	"local-agent/pkg/redfish"
+)
+
+func TestNewService(t *testing.T) {
+	ipmiClient := ipmi.NewClient()
+	redfishClient := redfish.NewClient()
+	cfg := &config.Config{}
+
+	service := NewService(ipmiClient, redfishClient, cfg)
+
+	if service == nil {
+		t.Fatal("Expected service to be created")
+	}
+
+	if service.ipmiClient == nil {
+		t.Error("Expected ipmiClient to be set")
+	}
+
+	if service.redfishClient == nil {
+		t.Error("Expected redfishClient to be set")
+	}
+
+	if service.config == nil {
+		t.Error("Expected config to be set")
+	}
+}
+
+func TestService_LoadStaticServers(t *testing.T) {
+	cfg := &config.Config{
+		Static: config.StaticConfig{
+			Hosts: []config.BMCHost{
+				{
+					ID: "test-server-1",
+					ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+						Type:     "ipmi",
+						Endpoint: "192.168.1.100:623",
+						Username: "admin",
+						Password: "password",
+					}},
+				},
+				{
+					ID: "test-server-2",
+					ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+						Type:     "redfish",
+						Endpoint: "https://192.168.1.101",
+						Username: "root",
+						Password: "secret",
+					}},
+				},
+			},
+		},
+	}
+
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), cfg)
+	servers := service.loadStaticServers()
+
+	if len(servers) != 2 {
+		t.Errorf("Expected 2 servers, got %d", len(servers))
+	}
+
+	// Check first server
+	if servers[0].ID != "test-server-1" {
+		t.Errorf("Expected ID 'test-server-1', got '%s'", servers[0].ID)
+	}
+
+	if servers[0].GetPrimaryControlEndpoint() == nil {
+		t.Fatal("Expected ControlEndpoint to be set")
+	}
+
+	if servers[0].GetPrimaryControlEndpoint().Type != types.BMCTypeIPMI {
+		t.Errorf("Expected type '%s', got '%s'", types.BMCTypeIPMI, servers[0].GetPrimaryControlEndpoint().Type)
+	}
+
+	if servers[0].GetPrimaryControlEndpoint().Endpoint != "192.168.1.100:623" {
+		t.Errorf("Expected endpoint '192.168.1.100:623', got '%s'", servers[0].GetPrimaryControlEndpoint().Endpoint)
+	}
+
+	// Check second server
+	if servers[1].ID != "test-server-2" {
+		t.Errorf("Expected ID 'test-server-2', got '%s'", servers[1].ID)
+	}
+
+	if servers[1].GetPrimaryControlEndpoint().Type != types.BMCTypeRedfish {
+		t.Errorf("Expected type '%s', got '%s'", types.BMCTypeRedfish, servers[1].GetPrimaryControlEndpoint().Type)
+	}
+}
+
+func TestService_LoadStaticServers_Empty(t *testing.T) {
+	cfg := &config.Config{
+		Static: config.StaticConfig{
+			Hosts: []config.BMCHost{},
+		},
+	}
+
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), cfg)
+	servers := service.loadStaticServers()
+
+	if len(servers) != 0 {
+		t.Errorf("Expected 0 servers, got %d", len(servers))
+	}
+}
+
+func TestService_LoadStaticServers_IPMIDeviceInfo(t *testing.T) {
+	cfg := &config.Config{
+		Static: config.StaticConfig{
+			Hosts: []config.BMCHost{{
+				ID: "test-server-1",
+				ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+					Type:     "ipmi",
+					Endpoint: "192.168.1.100:623",
+					Username: "ADMIN",
+					Password: "ADMIN",
+				}},
+			}},
+		},
+	}
+
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), cfg)
+	service.ipmiDeviceInfo = func(ctx context.Context, endpoint, username, password string) (*ipmi.BMCInfo, error) {
+		if endpoint != "192.168.1.100:623" || username != "ADMIN" {
+			t.Errorf("Unexpected device ID request to %s as %s", endpoint, username)
+		}
+		return &ipmi.BMCInfo{Vendor: "Supermicro", Model: "SYS-6029P-TR", FirmwareVersion: "3.88", IPMIVersion: "1.5"}, nil
+	}
+	servers := service.loadStaticServers()
+
+	if len(servers) != 1 {
+		t.Fatalf("Expected 1 server, got %d", len(servers))
+	}
+	metadata := servers[0].DiscoveryMetadata
+	want := types.VendorInfo{Manufacturer: "Supermicro", Model: "SYS-6029P-TR", FirmwareVersion: "3.88"}
+	if metadata.Vendor == nil || *metadata.Vendor != want {
+		t.Errorf("Expected vendor %+v, got %+v", want, metadata.Vendor)
+	}
+	if metadata.Protocol.PrimaryVersion != "1.5" {
+		t.Errorf("Expected the IPMI version of the BMC, got %s", metadata.Protocol.PrimaryVersion)
+	}
+}
+
+func TestService_ApplyIPMIDeviceInfo_Credentials(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+	service.ipmiDeviceInfo = func(ctx context.Context, endpoint, username, password string) (*ipmi.BMCInfo, error) {
+		if username != "ADMIN" {
+			return nil, errors.New("ipmitool failed: exit status 1")
+		}
+		return &ipmi.BMCInfo{Vendor: "Supermicro"}, nil
+	}
+	newServer := func() *domain.Server {
+		return &domain.Server{
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.100:623",
+				Type:     types.BMCTypeIPMI,
+				Username: "root",
+				Password: "calvin",
+			}},
+			Metadata: make(map[string]string),
+		}
+	}
+
+	// The first credentials the BMC accepts are kept
+	server := newServer()
+	service.applyIPMIDeviceInfo(context.Background(), server, []config.CredentialConfig{
+		{Username: "root", Password: "calvin"},
+		{Username: "ADMIN", Password: "ADMIN"},
+	})
+	if control := server.GetPrimaryControlEndpoint(); control.Username != "ADMIN" || control.Password != "ADMIN" {
+		t.Errorf("Expected the accepted credentials, got %s", control.Username)
+	}
+	if _, failed := server.Metadata["discovery_error"]; failed || server.Metadata["vendor"] != "Supermicro" {
+		t.Errorf("Expected the vendor without error, got %v", server.Metadata)
+	}
+
+	// Without accepted credentials, the error of the first is reported
+	server = newServer()
+	service.applyIPMIDeviceInfo(context.Background(), server, []config.CredentialConfig{
+		{Username: "root", Password: "calvin"},
+	})
+	if server.Metadata["discovery_error"] == "" {
+		t.Error("Expected a discovery error")
+	}
+	if _, ok := server.Metadata["vendor"]; ok {
+		t.Errorf("Unexpected vendor %q", server.Metadata["vendor"])
+	}
+}
+
+func TestService_DiscoverServers_StaticOnly(t *testing.T) {
+	cfg := &config.Config{
+		Agent: config.AgentConfig{
+			BMCDiscovery: config.BMCDiscoveryConfig{
+				Enabled: false, // Disable auto-discovery
+			},
+		},
+		Static: config.StaticConfig{
+			Hosts: []config.BMCHost{
+				{
+					ID: "static-server-1",
+					ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+						Type:     "ipmi",
+						Endpoint: "192.168.1.100:623",
+						Username: "admin",
+						Password: "password",
+					}},
+				},
+			},
+		},
+	}
+
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), cfg)
+	ctx := context.Background()
+
+	servers, err := service.DiscoverServers(ctx)
+
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if len(servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(servers))
+	}
+
+	if servers[0].ID != "static-server-1" {
+		t.Errorf("Expected ID 'static-server-1', got '%s'", servers[0].ID)
+	}
+}
+
+func TestService_FilterDuplicates(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	existing := []*domain.Server{
+		{
+			ID: "server-1",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.100:623",
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+		{
+			ID: "server-2",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.101:623",
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+	}
+
+	discovered := []*domain.Server{
+		{
+			ID: "discovered-1",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.100:623", // Duplicate
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+		{
+			ID: "discovered-2",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.102:623", // New
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+	}
+
+	filtered := service.filterDuplicates(existing, discovered)
+
+	// Should only have the new server (192.168.1.102:623)
+	if len(filtered) != 1 {
+		t.Errorf("Expected 1 server after filtering, got %d", len(filtered))
+	}
+
+	if filtered[0].GetPrimaryControlEndpoint().Endpoint != "192.168.1.102:623" {
+		t.Errorf("Expected endpoint '192.168.1.102:623', got '%s'", filtered[0].GetPrimaryControlEndpoint().Endpoint)
+	}
+}
+
+func TestServer_Features(t *testing.T) {
+	server := &domain.Server{
+		ID: "test-server",
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint:     "192.168.1.100:623",
+			Type:         types.BMCTypeIPMI,
+			Capabilities: []string{"power", "sensors"}},
+		},
+		Features: []string{"power_management", "monitoring"},
+		Status:   "active",
+	}
+
+	if len(server.Features) != 2 {
+		t.Errorf("Expected 2 features, got %d", len(server.Features))
+	}
+
+	if server.Status != "active" {
+		t.Errorf("Expected status 'active', got '%s'", server.Status)
+	}
+
+	if len(server.GetPrimaryControlEndpoint().Capabilities) != 2 {
+		t.Errorf("Expected 2 capabilities, got %d", len(server.GetPrimaryControlEndpoint().Capabilities))
+	}
+}
+
+func TestBMCControlEndpoint_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *types.BMCControlEndpoint
+		valid    bool
+	}{
+		{
+			name: "valid IPMI endpoint",
+			endpoint: &types.BMCControlEndpoint{
+				Endpoint: "192.168.1.100:623",
+				Type:     types.BMCTypeIPMI,
+				Username: "admin",
+				Password: "password",
+			},
+			valid: true,
+		},
+		{
+			name: "valid Redfish endpoint",
+			endpoint: &types.BMCControlEndpoint{
+				Endpoint: "https://192.168.1.100",
+				Type:     types.BMCTypeRedfish,
+				Username: "root",
+				Password: "secret",
+			},
+			valid: true,
+		},
+		{
+			name: "missing type",
+			endpoint: &types.BMCControlEndpoint{
+				Endpoint: "192.168.1.100:623",
+				Type:     types.BMCTypeNone,
+				Username: "admin",
+				Password: "password",
+			},
+			valid: false,
+		},
+		{
+			name: "missing endpoint",
+			endpoint: &types.BMCControlEndpoint{
+				Endpoint: "",
+				Type:     types.BMCTypeIPMI,
+				Username: "admin",
+				Password: "password",
+			},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Basic validation checks
+			hasEndpoint := tt.endpoint.Endpoint != ""
+			hasType := tt.endpoint.Type != ""
+			isValid := hasEndpoint && hasType
+
+			if isValid != tt.valid {
+				t.Errorf("Expected valid=%v, got valid=%v", tt.valid, isValid)
+			}
+		})
+	}
+}
+
+func TestSOLEndpoint_Types(t *testing.T) {
+	tests := []struct {
+		name     string
+		solType  types.SOLType
+		expected bool
+	}{
+		{"IPMI SOL", types.SOLTypeIPMI, true},
+		{"Redfish Serial", types.SOLTypeRedfishSerial, true},
+		{"Invalid", types.SOLType("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol := &types.SOLEndpoint{
+				Type:     tt.solType,
+				Endpoint: "test-endpoint",
+			}
+
+			// Check if type is one of the expected values
+			isValid := sol.Type == types.SOLTypeIPMI || sol.Type == types.SOLTypeRedfishSerial
+
+			if isValid != tt.expected {
+				t.Errorf("Expected valid=%v for type '%s'", tt.expected, tt.solType)
+			}
+		})
+	}
+}
+
+func TestVNCEndpoint_Types(t *testing.T) {
+	tests := []struct {
+		name    string
+		vncType types.VNCType
+		valid   bool
+	}{
+		{"Native", types.VNCTypeNative, true},
+		{"WebSocket", types.VNCTypeWebSocket, true},
+		{"Invalid", types.VNCType("invalid_type"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vnc := &types.VNCEndpoint{
+				Type:     tt.vncType,
+				Endpoint: "ws://test:6080",
+			}
+
+			// Check if type is one of the valid values
+			validTypes := map[types.VNCType]bool{
+				types.VNCTypeNative:    true,
+				types.VNCTypeWebSocket: true,
+			}
+
+			isValid := validTypes[vnc.Type]
+
+			if isValid != tt.valid {
+				t.Errorf("Expected valid=%v for type '%s'", tt.valid, tt.vncType)
+			}
+		})
+	}
+}
+
+func TestService_FilterDenied(t *testing.T) {
+	policy, err := netpolicy.New([]string{"192.168.1.0/24"}, false)
+	if err != nil {
+		t.Fatalf("netpolicy.New failed: %v", err)
+	}
+	netpolicy.SetDefault(policy)
+	t.Cleanup(func() { netpolicy.SetDefault(nil) })
+
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	servers := []*domain.Server{
+		{
+			ID: "allowed",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.100:623",
+				Type:     types.BMCTypeIPMI,
+			}},
+			SOLEndpoint: &types.SOLEndpoint{Endpoint: "192.168.1.100:623"},
+		},
+		{
+			ID: "denied-control",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "https://169.254.169.254/redfish/v1",
+				Type:     types.BMCTypeRedfish,
+			}},
+		},
+		{
+			// A poisoned console endpoint is enough to skip the server
+			ID: "denied-vnc",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.101:623",
+				Type:     types.BMCTypeIPMI,
+			}},
+			VNCEndpoint: &types.VNCEndpoint{Endpoint: "127.0.0.1:5900"},
+		},
+	}
+
+	filtered := service.filterDenied(context.Background(), servers)
+
+	if len(filtered) != 1 || filtered[0].ID != "allowed" {
+		t.Errorf("Expected only the allowed server, got %d servers", len(filtered))
+	}
+}
+
+// newRedfishScanTarget fronts a simulated Redfish BMC with TLS, as discovery
+// probes https endpoints, listening on addr. It returns the simulator, the
+// URL of the TLS server and its port.
+func newRedfishScanTarget(t *testing.T, addr string) (*redfishsim.Server, string, int) {
+	t.Helper()
+
+	bmc := redfishsim.New(redfishsim.Options{})
+	t.Cleanup(bmc.Close)
+	target, err := url.Parse(bmc.URL)
+	if err != nil {
+		t.Fatalf("Invalid simulator URL: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("Cannot listen on %s: %v", addr, err)
+	}
+	proxy := httptest.NewUnstartedServer(httputil.NewSingleHostReverseProxy(target))
+	proxy.Listener.Close()
+	proxy.Listener = listener
+	proxy.StartTLS()
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("Invalid proxy URL: %v", err)
+	}
+	port, err := strconv.Atoi(proxyURL.Port())
+	if err != nil {
+		t.Fatalf("Invalid proxy port: %v", err)
+	}
+	return bmc, proxy.URL, port
+}
+
+// redfishScanService returns a discovery service scanning subnet for Redfish
+// BMCs on port, trying a wrong default credential before those of bmc
+func redfishScanService(bmc *redfishsim.Server, subnet string, port int) *Service {
+	cfg := &config.Config{}
+	cfg.Agent.BMCDiscovery = config.BMCDiscoveryConfig{
+		Enabled:                true,
+		NetworkRanges:          []string{subnet},
+		RedfishPorts:           []int{port},
+		EnableRedfishDetection: true,
+		DefaultCredentials: []config.CredentialConfig{
+			{Username: "root", Password: "calvin"},
+			{Username: bmc.Username(), Password: bmc.Password()},
+		},
+	}
+	return NewService(ipmi.NewClient(), redfish.NewClient(), cfg)
+}
+
+func TestService_DiscoverServers_RedfishScan(t *testing.T) {
+	bmc, proxyURL, port := newRedfishScanTarget(t, "127.0.0.1:0")
+	service := redfishScanService(bmc, "127.0.0.1/32", port)
+
+	servers, err := service.DiscoverServers(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverServers failed: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("Expected 1 server, got %d", len(servers))
+	}
+
+	server := servers[0]
+	control := server.GetPrimaryControlEndpoint()
+	if control.Endpoint != proxyURL || control.Type != types.BMCTypeRedfish {
+		t.Errorf("Unexpected control endpoint %s (%s)", control.Endpoint, control.Type)
+	}
+	if control.Username != bmc.Username() || control.Password != bmc.Password() {
+		t.Errorf("Expected the credentials the BMC accepted, got %s", control.Username)
+	}
+	if _, failed := server.Metadata["discovery_error"]; failed {
+		t.Errorf("Unexpected discovery error %q", server.Metadata["discovery_error"])
+	}
+	if server.Metadata["vendor"] != string(redfish.VendorGeneric) {
+		t.Errorf("Expected the generic vendor, got %q", server.Metadata["vendor"])
+	}
+	if server.SOLEndpoint == nil || server.SOLEndpoint.Type != types.SOLTypeRedfishSerial {
+		t.Errorf("Expected a Redfish serial console, got %+v", server.SOLEndpoint)
+	}
+	if server.DiscoveryMetadata == nil || server.DiscoveryMetadata.DiscoveryMethod != types.DiscoveryMethodNetworkScan {
+		t.Errorf("Expected network scan discovery metadata, got %+v", server.DiscoveryMetadata)
+	}
+}
+
+func TestService_DiscoverServers_RedfishScanIPv6(t *testing.T) {
+	bmc, proxyURL, port := newRedfishScanTarget(t, "[::1]:0")
+	service := redfishScanService(bmc, "::1/128", port)
+
+	servers, err := service.DiscoverServers(context.Background())
+	if err != nil {
+		t.Fatalf("DiscoverServers failed: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("Expected 1 server, got %d", len(servers))
+	}
+
+	server := servers[0]
+	control := server.GetPrimaryControlEndpoint()
+	if want := "https://[::1]:" + strconv.Itoa(port); control.Endpoint != want || control.Endpoint != proxyURL {
+		t.Errorf("Expected the bracketed endpoint %s, got %s", want, control.Endpoint)
+	}
+	if want := "server---1-" + strconv.Itoa(port); server.ID != want {
+		t.Errorf("Expected server ID %s, got %s", want, server.ID)
+	}
+	if server.DiscoveryMetadata == nil || server.DiscoveryMetadata.Network == nil || server.DiscoveryMetadata.Network.IPAddress != "::1" {
+		t.Errorf("Expected the IPv6 address in the discovery metadata, got %+v", server.DiscoveryMetadata)
+	}
+}
+
+func TestService_GenerateIPsFromSubnet(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	tests := []struct {
+		subnet string
+		count  int
+		first  string
+		last   string
+	}{
+		{subnet: "192.168.1.0/24", count: 100, first: "192.168.1.1", last: "192.168.1.100"},
+		{subnet: "10.0.0.8/30", count: 4, first: "10.0.0.8", last: "10.0.0.11"},
+		{subnet: "fd00:10::/64", count: 100, first: "fd00:10::1", last: "fd00:10::64"},
+		{subnet: "fd00:10::/126", count: 3, first: "fd00:10::1", last: "fd00:10::3"},
+		{subnet: "2001:db8::ff00/120", count: 100, first: "2001:db8::ff01", last: "2001:db8::ff64"},
+		{subnet: "::1/128", count: 1, first: "::1", last: "::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subnet, func(t *testing.T) {
+			_, ipnet, err := net.ParseCIDR(tt.subnet)
+			if err != nil {
+				t.Fatalf("Invalid subnet: %v", err)
+			}
+			ips := service.generateIPsFromSubnet(ipnet)
+			if len(ips) != tt.count {
+				t.Fatalf("Expected %d IPs, got %d", tt.count, len(ips))
+			}
+			if ips[0].String() != tt.first || ips[len(ips)-1].String() != tt.last {
+				t.Errorf("Expected IPs %s to %s, got %s to %s", tt.first, tt.last, ips[0], ips[len(ips)-1])
+			}
+		})
+	}
+}
+
+func TestService_IsBMCSubnet(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	tests := map[string]bool{
+		"192.168.1.0/24": true,
+		"172.20.0.0/16":  true,
+		"8.8.8.0/24":     false,
+		"fd00:10::/64":   true,
+		"fe80::/64":      false,
+		"2001:db8::/64":  false,
+	}
+	for subnet, want := range tests {
+		_, ipnet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			t.Fatalf("Invalid subnet %s: %v", subnet, err)
+		}
+		if got := service.isBMCSubnet(ipnet); got != want {
+			t.Errorf("isBMCSubnet(%s) = %v, want %v", subnet, got, want)
+		}
+	}
+}
+
+func TestService_BuildIPMIEndpoint(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	tests := map[string]string{
+		"https://192.168.1.100:8443": "192.168.1.100:623",
+		"https://bmc-01.example.com": "bmc-01.example.com:623",
+		"https://[fd00::10]:443":     "[fd00::10]:623",
+		"https://[fd00::10]":         "[fd00::10]:623",
+	}
+	for redfishEndpoint, want := range tests {
+		got, err := service.buildIPMIEndpoint(redfishEndpoint)
+		if err != nil {
+			t.Fatalf("buildIPMIEndpoint(%s) failed: %v", redfishEndpoint, err)
+		}
+		if got != want {
+			t.Errorf("buildIPMIEndpoint(%s) = %s, want %s", redfishEndpoint, got, want)
+		}
+	}
+}
+
+func TestService_DiscoveryDefaults(t *testing.T) {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	if ports := service.ipmiPorts(); len(ports) != 1 || ports[0] != 623 {
+		t.Errorf("Expected the default IPMI port, got %v", ports)
+	}
+	if ports := service.redfishPorts(); len(ports) != 3 || ports[0] != 443 {
+		t.Errorf("Expected the default Redfish ports, got %v", ports)
+	}
+	if credentials := service.credentials(); len(credentials) != 1 || credentials[0].Username != "admin" {
+		t.Errorf("Expected the default credentials, got %v", credentials)
+	}
+
+	service.config.Agent.BMCDiscovery.DefaultCredentials = []config.CredentialConfig{{Username: "ADMIN", Password: "ADMIN"}}
+	if credentials := service.credentials(); len(credentials) != 1 || credentials[0].Username != "ADMIN" {
+		t.Errorf("Expected the configured credentials, got %v", credentials)
+	}
+}
+
+func TestService_ApplySerialConsoleInfo_OpenBMC(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{OpenBMC: true})
+	t.Cleanup(bmc.Close)
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+
+	server := &domain.Server{
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: bmc.URL,
+			Type:     types.BMCTypeRedfish,
+			Username: bmc.Username(),
+			Password: bmc.Password(),
+		}},
+		Metadata: make(map[string]string),
+	}
+	info, err := service.redfishClient.DiscoverSerialConsole(context.Background(), bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("DiscoverSerialConsole failed: %v", err)
+	}
+	service.applySerialConsoleInfo(server, info)
+
+	if server.Metadata["vendor"] != string(redfish.VendorOpenBMC) {
+		t.Errorf("Expected the OpenBMC vendor, got %q", server.Metadata["vendor"])
+	}
+	if server.SOLEndpoint == nil || server.SOLEndpoint.Endpoint != bmc.URL+redfish.OpenBMCConsolePath {
+		t.Errorf("Expected the obmc-console endpoint, got %+v", server.SOLEndpoint)
+	}
+	wantKVM := "ws" + strings.TrimPrefix(bmc.URL, "http") + redfish.OpenBMCKVMPath
+	if server.VNCEndpoint == nil || server.VNCEndpoint.Endpoint != wantKVM || server.VNCEndpoint.Type != types.VNCTypeWebSocket {
+		t.Errorf("Expected the KVM endpoint %s, got %+v", wantKVM, server.VNCEndpoint)
+	}
+	if !slices.Contains(server.Features, string(types.FeatureVNC)) {
+		t.Errorf("Expected the VNC feature, got %v", server.Features)
+	}
+}
+
+func TestService_LoadStaticServers_OpenBMCFlavor(t *testing.T) {
+	// The BMC is unreachable: the consoles come from the flavor alone
+	cfg := &config.Config{
+		Static: config.StaticConfig{
+			Hosts: []config.BMCHost{{
+				ID:        "openbmc-1",
+				BMCFlavor: config.BMCFlavorOpenBMC,
+				ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+					Endpoint: "https://127.0.0.1:1",
+					Username: "root",
+					Password: "0penBmc",
+				}},
+				VNCEndpoint: &config.ConfigVNCEndpoint{Endpoint: "127.0.0.1:5900"},
+			}},
+		},
+	}
+
+	servers := NewService(ipmi.NewClient(), redfish.NewClient(), cfg).loadStaticServers()
+	if len(servers) != 1 {
+		t.Fatalf("Expected 1 server, got %d", len(servers))
+	}
+	server := servers[0]
+	if server.SOLEndpoint == nil || server.SOLEndpoint.Endpoint != "https://127.0.0.1:1/console0" || server.SOLEndpoint.Type != types.SOLTypeRedfishSerial {
+		t.Errorf("Expected the obmc-console endpoint, got %+v", server.SOLEndpoint)
+	}
+	if server.VNCEndpoint == nil || server.VNCEndpoint.Endpoint != "127.0.0.1:5900" {
+		t.Errorf("Expected the configured VNC endpoint kept, got %+v", server.VNCEndpoint)
+	}
+	if server.Metadata["vendor"] != string(redfish.VendorOpenBMC) {
+		t.Errorf("Expected the OpenBMC vendor, got %q", server.Metadata["vendor"])
+	}
+}
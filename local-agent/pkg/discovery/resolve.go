@@ -0,0 +1,120 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"local-agent/pkg/netpolicy"
+)
+
+// resolveTimeout bounds the resolution of the name of a BMC endpoint
+const resolveTimeout = 5 * time.Second
+
+// AddressChange is a BMC endpoint name resolving to a new address, e.g.
+// after its DHCP lease changed
+type AddressChange struct {
+	ServerID string
+	Hostname string
+	Previous string
+	Current  string
+}
+
+// lookupNetIP resolves a host name to its addresses
+func lookupNetIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+// endpointHostname returns the DNS name of an endpoint given as a URL or as
+// host[:port], or an empty string when its host is an IP address
+func endpointHostname(endpoint string) string {
+	host := netpolicy.EndpointHost(endpoint)
+	if _, err := netip.ParseAddr(host); err == nil {
+		return ""
+	}
+	return host
+}
+
+// resolveAddress returns the first address of hostname that the network
+// policy allows
+func (s *Service) resolveAddress(ctx context.Context, hostname string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := s.lookupHost(ctx, hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", hostname, err)
+	}
+	for _, addr := range addrs {
+		if netpolicy.Default().Allows(addr) {
+			return addr.Unmap().String(), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s resolves to %v", netpolicy.ErrDenied, hostname, addrs)
+}
+
+// RefreshAddresses resolves again the names of the BMC endpoints of servers,
+// updating the address in their discovery metadata, and returns the changed
+// addresses. Servers keep their last address when their name fails to
+// resolve. Endpoints stay keyed by name, so a server keeps its ID and
+// mappings when its address changes.
+func (s *Service) RefreshAddresses(ctx context.Context, servers []*domain.Server) []AddressChange {
+	var changes []AddressChange
+	for _, server := range servers {
+		if server.DiscoveryMetadata == nil || server.DiscoveryMetadata.Network == nil {
+			continue
+		}
+		network := server.DiscoveryMetadata.Network
+		if network.Hostname == "" {
+			continue
+		}
+
+		address, err := s.resolveAddress(ctx, network.Hostname)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("server_id", server.ID).
+				Str("hostname", network.Hostname).
+				Str("address", network.IPAddress).
+				Msg("Failed to resolve BMC endpoint again, keeping its last address")
+			continue
+		}
+		if address == network.IPAddress {
+			continue
+		}
+
+		changes = append(changes, AddressChange{
+			ServerID: server.ID,
+			Hostname: network.Hostname,
+			Previous: network.IPAddress,
+			Current:  address,
+		})
+		network.IPAddress = address
+	}
+
+	if len(changes) > 0 {
+		// Kept-alive connections are still open to the previous addresses
+		s.redfishClient.CloseIdleConnections()
+	}
+	return changes
+}
+
+// staticAddresses returns the resolved addresses of the static servers whose
+// endpoints are DNS names
+func staticAddresses(staticServers []*domain.Server) map[string]bool {
+	addresses := make(map[string]bool)
+	for _, server := range staticServers {
+		if server.DiscoveryMetadata == nil || server.DiscoveryMetadata.Network == nil {
+			continue
+		}
+		if network := server.DiscoveryMetadata.Network; network.Hostname != "" && network.IPAddress != "" {
+			addresses[network.IPAddress] = true
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,163 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"core/domain"
+	"core/types"
+	"local-agent/pkg/config"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+// newResolvingService returns a discovery service resolving names with the
+// addresses of hosts, to be changed by tests
+func newResolvingService(hosts map[string]string) *Service {
+	service := NewService(ipmi.NewClient(), redfish.NewClient(), &config.Config{})
+	service.lookupHost = func(_ context.Context, host string) ([]netip.Addr, error) {
+		address, ok := hosts[host]
+		if !ok {
+			return nil, errors.New("no such host")
+		}
+		return []netip.Addr{netip.MustParseAddr(address)}, nil
+	}
+	return service
+}
+
+func TestEndpointHostname(t *testing.T) {
+	tests := map[string]string{
+		"bmc-01.dc.example.com:623":         "bmc-01.dc.example.com",
+		"https://bmc-01.dc.example.com":     "bmc-01.dc.example.com",
+		"https://bmc-01.dc.example.com:443": "bmc-01.dc.example.com",
+		"bmc-01":                            "bmc-01",
+		"192.168.1.100:623":                 "",
+		"https://192.168.1.100:8000":        "",
+		"[fd00::10]:623":                    "",
+	}
+	for endpoint, want := range tests {
+		if got := endpointHostname(endpoint); got != want {
+			t.Errorf("endpointHostname(%s) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestBuildDiscoveryMetadata_Hostname(t *testing.T) {
+	service := newResolvingService(map[string]string{"bmc-01.dc.example.com": "10.0.0.5"})
+
+	tests := []struct {
+		endpoint string
+		hostname string
+		address  string
+	}{
+		{endpoint: "bmc-01.dc.example.com:623", hostname: "bmc-01.dc.example.com", address: "10.0.0.5"},
+		{endpoint: "https://bmc-01.dc.example.com:443", hostname: "bmc-01.dc.example.com", address: "10.0.0.5"},
+		{endpoint: "bmc-02.dc.example.com:623", hostname: "bmc-02.dc.example.com", address: ""},
+		{endpoint: "192.168.1.100:623", hostname: "", address: "192.168.1.100"},
+	}
+	for _, tt := range tests {
+		server := &domain.Server{
+			ID: "server-1",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: tt.endpoint,
+				Type:     types.BMCTypeIPMI,
+			}},
+		}
+		metadata := service.buildDiscoveryMetadata(server, types.DiscoveryMethodStaticConfig, "config.yaml")
+		if metadata.Network == nil {
+			t.Fatalf("Expected network metadata for %s", tt.endpoint)
+		}
+		if metadata.Network.Hostname != tt.hostname || metadata.Network.IPAddress != tt.address {
+			t.Errorf("Expected hostname %q and address %q for %s, got %q and %q",
+				tt.hostname, tt.address, tt.endpoint, metadata.Network.Hostname, metadata.Network.IPAddress)
+		}
+	}
+}
+
+func TestService_RefreshAddresses(t *testing.T) {
+	hosts := map[string]string{
+		"bmc-01.dc.example.com": "10.0.0.5",
+		"bmc-02.dc.example.com": "10.0.0.6",
+	}
+	service := newResolvingService(hosts)
+
+	var servers []*domain.Server
+	for _, endpoint := range []string{"bmc-01.dc.example.com:623", "https://bmc-02.dc.example.com", "192.168.1.100:623"} {
+		server := &domain.Server{
+			ID: "server-" + endpoint,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: endpoint,
+				Type:     types.InferBMCType(endpoint),
+			}},
+		}
+		server.DiscoveryMetadata = service.buildDiscoveryMetadata(server, types.DiscoveryMethodStaticConfig, "config.yaml")
+		servers = append(servers, server)
+	}
+
+	if changes := service.RefreshAddresses(context.Background(), servers); len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %+v", changes)
+	}
+
+	// bmc-01 gets a new DHCP lease, bmc-02 is no longer resolved
+	hosts["bmc-01.dc.example.com"] = "10.0.0.42"
+	delete(hosts, "bmc-02.dc.example.com")
+
+	changes := service.RefreshAddresses(context.Background(), servers)
+	want := AddressChange{
+		ServerID: "server-bmc-01.dc.example.com:623",
+		Hostname: "bmc-01.dc.example.com",
+		Previous: "10.0.0.5",
+		Current:  "10.0.0.42",
+	}
+	if len(changes) != 1 || changes[0] != want {
+		t.Fatalf("Expected change %+v, got %+v", want, changes)
+	}
+	if got := servers[0].DiscoveryMetadata.Network.IPAddress; got != "10.0.0.42" {
+		t.Errorf("Expected the new address in the metadata, got %s", got)
+	}
+	if got := servers[1].DiscoveryMetadata.Network.IPAddress; got != "10.0.0.6" {
+		t.Errorf("Expected the last address of a name failing to resolve, got %s", got)
+	}
+	if got := servers[0].GetPrimaryControlEndpoint().Endpoint; got != "bmc-01.dc.example.com:623" {
+		t.Errorf("Expected the endpoint to keep its name, got %s", got)
+	}
+}
+
+func TestService_FilterDuplicates_Hostname(t *testing.T) {
+	service := newResolvingService(map[string]string{"bmc-01.dc.example.com": "192.168.1.100"})
+
+	static := service.buildStaticServers([]config.BMCHost{{
+		ID: "bmc-01",
+		ControlEndpoints: []*config.ConfigBMCControlEndpoint{{
+			Endpoint: "BMC-01.dc.example.com:623",
+			Type:     string(types.BMCTypeIPMI),
+		}},
+	}})
+	if got := static[0].GetPrimaryControlEndpoint().Endpoint; got != "bmc-01.dc.example.com:623" {
+		t.Errorf("Expected the endpoint's name in lower case, got %s", got)
+	}
+
+	discovered := []*domain.Server{
+		{
+			ID: "server-192-168-1-100",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.100:623", // The static host, by address
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+		{
+			ID: "server-192-168-1-101",
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "192.168.1.101:623",
+				Type:     types.BMCTypeIPMI,
+			}},
+		},
+	}
+
+	filtered := service.filterDuplicates(static, discovered)
+	if len(filtered) != 1 || filtered[0].ID != "server-192-168-1-101" {
+		t.Errorf("Expected only server-192-168-1-101, got %d servers", len(filtered))
+	}
+}
@@ -0,0 +1,144 @@
+// Package hoststore persists the BMC hosts added to an agent at runtime
+// through its hosts API, so that they survive restarts. Hosts are kept in a
+// JSON file holding their credentials, readable by the agent only.
+package hoststore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"local-agent/pkg/config"
+)
+
+// ErrNotFound is returned when removing a host that is not in the store
+var ErrNotFound = errors.New("host not found")
+
+// state is the content of the store file
+type state struct {
+	Hosts []config.BMCHost `json:"hosts"`
+}
+
+// Store holds the hosts added at runtime
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	hosts []config.BMCHost
+}
+
+// Open loads the store persisted at path. A missing file is an empty store,
+// created when the first host is added.
+func Open(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host store: %w", err)
+	}
+
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse host store %s: %w", path, err)
+	}
+	store.hosts = st.Hosts
+	return store, nil
+}
+
+// Hosts returns the stored hosts, in the order they were added
+func (s *Store) Hosts() []config.BMCHost {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]config.BMCHost(nil), s.hosts...)
+}
+
+// Put adds a host, or replaces the host with the same ID. It returns true if
+// the host was added.
+func (s *Store) Put(host config.BMCHost) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hosts := append([]config.BMCHost(nil), s.hosts...)
+	created := true
+	for i := range hosts {
+		if hosts[i].ID == host.ID {
+			hosts[i] = host
+			created = false
+			break
+		}
+	}
+	if created {
+		hosts = append(hosts, host)
+	}
+
+	if err := s.save(hosts); err != nil {
+		return false, err
+	}
+	s.hosts = hosts
+	return created, nil
+}
+
+// Remove removes the host with the given ID
+func (s *Store) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hosts := make([]config.BMCHost, 0, len(s.hosts))
+	for _, host := range s.hosts {
+		if host.ID != id {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) == len(s.hosts) {
+		return ErrNotFound
+	}
+
+	if err := s.save(hosts); err != nil {
+		return err
+	}
+	s.hosts = hosts
+	return nil
+}
+
+// save writes the hosts to a temporary file renamed over the store, so that
+// a crash never leaves a truncated store
+func (s *Store) save(hosts []config.BMCHost) error {
+	data, err := json.MarshalIndent(state{Hosts: hosts}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode host store: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create host store directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write host store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write host store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write host store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write host store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace host store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package hoststore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"local-agent/pkg/config"
+)
+
+func testHost(id, endpoint string) config.BMCHost {
+	return config.BMCHost{
+		ID: id,
+		ControlEndpoints: []*config.ConfigBMCControlEndpoint{
+			{Endpoint: endpoint, Username: "admin", Password: "secret"},
+		},
+	}
+}
+
+func TestStorePersistsHosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "hosts.json")
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if len(store.Hosts()) != 0 {
+		t.Fatalf("Expected an empty store, got %d hosts", len(store.Hosts()))
+	}
+
+	if created, err := store.Put(testHost("server-1", "192.168.1.100:623")); err != nil || !created {
+		t.Fatalf("Expected server-1 to be created, got %v, %v", created, err)
+	}
+	if _, err := store.Put(testHost("server-2", "192.168.1.101:623")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if created, err := store.Put(testHost("server-1", "192.168.1.110:623")); err != nil || created {
+		t.Fatalf("Expected server-1 to be replaced, got %v, %v", created, err)
+	}
+	if err := store.Remove("server-2"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Expected the store file to exist: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the store file to be private, got %v", info.Mode().Perm())
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	hosts := reopened.Hosts()
+	if len(hosts) != 1 || hosts[0].ID != "server-1" {
+		t.Fatalf("Expected server-1 only, got %+v", hosts)
+	}
+	if hosts[0].GetControlEndpoint() != "192.168.1.110:623" || hosts[0].ControlEndpoints[0].Password != "secret" {
+		t.Errorf("Expected the replaced definition of server-1, got %+v", hosts[0].ControlEndpoints[0])
+	}
+}
+
+func TestStoreRemoveUnknownHost(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "hosts.json"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := store.Remove("server-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestOpenRejectsCorruptStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("Failed to write store: %v", err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Expected an error for a corrupt store")
+	}
+}
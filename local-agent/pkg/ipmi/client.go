@@ -28,6 +28,7 @@ type BMCInfo struct {
 	Vendor          string
 	Model           string
 	FirmwareVersion string
+	IPMIVersion     string
 	Features        []string
 }
 
@@ -63,6 +64,11 @@ func (c *Client) PowerOff(ctx context.Context, endpoint, username, password stri
 	return c.subprocessClient.PowerOff(ctx, endpoint, username, password)
 }
 
+// GracefulShutdown asks the OS of the server to shut down
+func (c *Client) GracefulShutdown(ctx context.Context, endpoint, username, password string) error {
+	return c.subprocessClient.GracefulShutdown(ctx, endpoint, username, password)
+}
+
 // PowerCycle power cycles the server
 func (c *Client) PowerCycle(ctx context.Context, endpoint, username, password string) error {
 	return c.subprocessClient.PowerCycle(ctx, endpoint, username, password)
@@ -73,6 +79,16 @@ func (c *Client) Reset(ctx context.Context, endpoint, username, password string)
 	return c.subprocessClient.Reset(ctx, endpoint, username, password)
 }
 
+// DiagnosticInterrupt sends a diagnostic interrupt (NMI) to the server
+func (c *Client) DiagnosticInterrupt(ctx context.Context, endpoint, username, password string) error {
+	return c.subprocessClient.DiagnosticInterrupt(ctx, endpoint, username, password)
+}
+
+// ChassisIdentify controls the chassis identify LED of the server
+func (c *Client) ChassisIdentify(ctx context.Context, endpoint, username, password string, on bool, interval time.Duration) error {
+	return c.subprocessClient.ChassisIdentify(ctx, endpoint, username, password, on, interval)
+}
+
 // GetSensors retrieves sensor readings from the BMC
 func (c *Client) GetSensors(ctx context.Context, endpoint, username, password string) (map[string]interface{}, error) {
 	log.Debug().Str("endpoint", endpoint).Msg("Getting sensors")
@@ -98,6 +114,33 @@ func (c *Client) GetMCInfo(ctx context.Context, endpoint, username, password str
 	return c.subprocessClient.GetMCInfo(ctx, endpoint, username, password)
 }
 
+// GetLANConfig retrieves the network configuration of the BMC's LAN channel
+func (c *Client) GetLANConfig(ctx context.Context, endpoint, username, password string) (*LANConfig, error) {
+	return c.subprocessClient.GetLANConfig(ctx, endpoint, username, password)
+}
+
+// GetSOLEnabled reports whether SOL is enabled on the BMC's LAN channel
+func (c *Client) GetSOLEnabled(ctx context.Context, endpoint, username, password string) (bool, error) {
+	return c.subprocessClient.GetSOLEnabled(ctx, endpoint, username, password)
+}
+
+// SetSOLEnabled enables or disables SOL on the BMC's LAN channel
+func (c *Client) SetSOLEnabled(ctx context.Context, endpoint, username, password string, enabled bool) error {
+	return c.subprocessClient.SetSOLEnabled(ctx, endpoint, username, password, enabled)
+}
+
+// GetUserPrivilege retrieves the privilege level of a user on the BMC's LAN
+// channel
+func (c *Client) GetUserPrivilege(ctx context.Context, endpoint, username, password, user string) (string, error) {
+	return c.subprocessClient.GetUserPrivilege(ctx, endpoint, username, password, user)
+}
+
+// SetUserPrivilege sets the privilege level of a user on the BMC's LAN
+// channel
+func (c *Client) SetUserPrivilege(ctx context.Context, endpoint, username, password, user, privilege string) error {
+	return c.subprocessClient.SetUserPrivilege(ctx, endpoint, username, password, user, privilege)
+}
+
 // StartSOLSession starts a Serial-over-LAN console session
 func (c *Client) StartSOLSession(ctx context.Context, endpoint, username, password string) error {
 	log.Debug().Str("endpoint", endpoint).Msg("Starting SOL session")
@@ -0,0 +1,125 @@
+package ipmi
+
+import "strings"
+
+// parseMCInfo parses the output of ipmitool mc info, the BMC's Get Device ID
+// response, into its fields
+func parseMCInfo(output string) map[string]string {
+	info := make(map[string]string)
+
+	// Parse output line by line
+	// Example format:
+	// Device ID                 : 32
+	// Device Revision           : 1
+	// Firmware Revision         : 2.76
+	// IPMI Version              : 2.0
+	// Manufacturer ID           : 10876
+	// Manufacturer Name         : Supermicro
+	// Product ID                : 2402
+	// Device Available          : yes
+	// Provides Device SDRs      : yes
+	// Additional Device Support :
+	//     Sensor Device
+	//     SDR Repository Device
+	lines := strings.Split(output, "\n")
+	var additionalSupport []string
+	inAdditionalSection := false
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Check if we're in the Additional Device Support section
+		if strings.HasPrefix(line, "Additional Device Support") {
+			inAdditionalSection = true
+			continue
+		}
+
+		// If line starts with a letter and we're in additional section, it's a new field
+		if inAdditionalSection && len(line) > 0 && line[0] >= 'A' && line[0] <= 'Z' {
+			// Check if it contains a colon (new field)
+			if strings.Contains(line, ":") {
+				inAdditionalSection = false
+			} else {
+				// It's an additional device support item
+				additionalSupport = append(additionalSupport, line)
+				continue
+			}
+		}
+
+		// Parse key-value pairs
+		if strings.Contains(line, ":") {
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) == 2 {
+				key := strings.TrimSpace(parts[0])
+				value := strings.TrimSpace(parts[1])
+				info[key] = value
+			}
+		}
+	}
+
+	// Add additional device support as comma-separated string
+	if len(additionalSupport) > 0 {
+		info["Additional Device Support"] = strings.Join(additionalSupport, ", ")
+	}
+
+	return info
+}
+
+// parseFields parses the "Key : Value" lines of ipmitool output, such as fru
+// print or lan print, keeping the first value of fields repeated across FRU
+// devices and skipping empty values
+func parseFields(output string) map[string]string {
+	// Example fru print format:
+	// FRU Device Description : Builtin FRU Device (ID 0)
+	//  Board Mfg Date        : Mon Jan  1 00:00:00 1996
+	//  Board Mfg             : Supermicro
+	//  Board Product         : X11DPi-N
+	//  Product Manufacturer  : Supermicro
+	//  Product Name          : SYS-6029P-TR
+	fields := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if _, seen := fields[key]; seen || value == "" {
+			continue
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// bmcInfoFromDeviceID builds the BMC information from the fields of the Get
+// Device ID response and of the FRU inventory, which may be nil
+func bmcInfoFromDeviceID(mcInfo, fru map[string]string) *BMCInfo {
+	return &BMCInfo{
+		Vendor: firstKnown(
+			mcInfo["Manufacturer Name"],
+			fru["Product Manufacturer"],
+			fru["Board Mfg"],
+		),
+		Model: firstKnown(
+			fru["Product Name"],
+			fru["Board Product"],
+			mcInfo["Product Name"],
+		),
+		FirmwareVersion: mcInfo["Firmware Revision"],
+		IPMIVersion:     mcInfo["IPMI Version"],
+	}
+}
+
+// firstKnown returns the first of values ipmitool could name: it reports the
+// manufacturers and products missing from its tables as "Unknown (0x...)"
+func firstKnown(values ...string) string {
+	for _, value := range values {
+		if value != "" && !strings.HasPrefix(value, "Unknown") {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package ipmi
+
+import "testing"
+
+const supermicroMCInfo = `Device ID                 : 32
+Device Revision           : 1
+Firmware Revision         : 3.88
+IPMI Version              : 2.0
+Manufacturer ID           : 10876
+Manufacturer Name         : Supermicro
+Product ID                : 2137 (0x0859)
+Product Name              : Unknown (0x859)
+Device Available          : yes
+Provides Device SDRs      : no
+Additional Device Support :
+    Sensor Device
+    SDR Repository Device
+Aux Firmware Rev Info     :
+    0x00
+    0x00`
+
+const supermicroFRU = `FRU Device Description : Builtin FRU Device (ID 0)
+ Chassis Type          : Other
+ Board Mfg Date        : Mon Jan  1 00:00:00 1996
+ Board Mfg             : Supermicro
+ Board Product         : X11DPi-N
+ Board Serial          : WM193S600123
+ Product Manufacturer  : Supermicro
+ Product Name          : SYS-6029P-TR
+ Product Serial        :
+
+FRU Device Description : PSU1 (ID 1)
+ Product Name          : PWS-1K21P-1R`
+
+func TestParseMCInfo(t *testing.T) {
+	info := parseMCInfo(supermicroMCInfo)
+
+	if info["Firmware Revision"] != "3.88" {
+		t.Errorf("Expected firmware revision 3.88, got %q", info["Firmware Revision"])
+	}
+	if info["Manufacturer Name"] != "Supermicro" {
+		t.Errorf("Expected manufacturer Supermicro, got %q", info["Manufacturer Name"])
+	}
+	if want := "Sensor Device, SDR Repository Device"; info["Additional Device Support"] != want {
+		t.Errorf("Expected additional device support %q, got %q", want, info["Additional Device Support"])
+	}
+}
+
+func TestParseFRU(t *testing.T) {
+	fru := parseFields(supermicroFRU)
+
+	// The FRU inventory of the board comes first
+	if fru["Product Name"] != "SYS-6029P-TR" {
+		t.Errorf("Expected the board's product name, got %q", fru["Product Name"])
+	}
+	if fru["Board Mfg Date"] != "Mon Jan  1 00:00:00 1996" {
+		t.Errorf("Expected the full manufacturing date, got %q", fru["Board Mfg Date"])
+	}
+	if _, ok := fru["Product Serial"]; ok {
+		t.Errorf("Expected empty fields skipped, got %q", fru["Product Serial"])
+	}
+}
+
+func TestBMCInfoFromDeviceID(t *testing.T) {
+	tests := []struct {
+		name   string
+		mcInfo map[string]string
+		fru    map[string]string
+		want   BMCInfo
+	}{
+		{
+			name:   "device ID and FRU",
+			mcInfo: parseMCInfo(supermicroMCInfo),
+			fru:    parseFields(supermicroFRU),
+			want:   BMCInfo{Vendor: "Supermicro", Model: "SYS-6029P-TR", FirmwareVersion: "3.88", IPMIVersion: "2.0"},
+		},
+		{
+			name: "manufacturer unknown to ipmitool",
+			mcInfo: map[string]string{
+				"Firmware Revision": "1.10",
+				"IPMI Version":      "2.0",
+				"Manufacturer Name": "Unknown (0xB980)",
+				"Product Name":      "Unknown (0x1)",
+			},
+			fru: map[string]string{
+				"Board Mfg":     "Acme",
+				"Board Product": "AB-100",
+			},
+			want: BMCInfo{Vendor: "Acme", Model: "AB-100", FirmwareVersion: "1.10", IPMIVersion: "2.0"},
+		},
+		{
+			name: "no FRU inventory",
+			mcInfo: map[string]string{
+				"Firmware Revision": "2.76",
+				"IPMI Version":      "2.0",
+				"Manufacturer Name": "DELL Inc",
+				"Product Name":      "PowerEdge R640",
+			},
+			want: BMCInfo{Vendor: "DELL Inc", Model: "PowerEdge R640", FirmwareVersion: "2.76", IPMIVersion: "2.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bmcInfoFromDeviceID(tt.mcInfo, tt.fru)
+			if got.Vendor != tt.want.Vendor || got.Model != tt.want.Model ||
+				got.FirmwareVersion != tt.want.FirmwareVersion || got.IPMIVersion != tt.want.IPMIVersion {
+				t.Errorf("bmcInfoFromDeviceID() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package ipmi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LANConfig is the network configuration of the LAN channel of a BMC
+type LANConfig struct {
+	AddressSource  string // "static", "dhcp", "bios assigned" or "other"
+	IPAddress      string
+	SubnetMask     string
+	MACAddress     string
+	DefaultGateway string
+	VLANID         int // 0 when 802.1q tagging is disabled
+}
+
+// parseLANConfig parses the output of ipmitool lan print
+func parseLANConfig(output string) *LANConfig {
+	// Example format:
+	// Set in Progress         : Set Complete
+	// IP Address Source       : Static Address
+	// IP Address              : 10.0.0.12
+	// Subnet Mask             : 255.255.255.0
+	// MAC Address             : 0c:c4:7a:12:34:56
+	// Default Gateway IP      : 10.0.0.1
+	// 802.1q VLAN ID          : Disabled
+	fields := parseFields(output)
+
+	config := &LANConfig{
+		AddressSource:  strings.ToLower(strings.TrimSuffix(fields["IP Address Source"], " Address")),
+		IPAddress:      fields["IP Address"],
+		SubnetMask:     fields["Subnet Mask"],
+		MACAddress:     fields["MAC Address"],
+		DefaultGateway: fields["Default Gateway IP"],
+	}
+	if vlan, err := strconv.Atoi(fields["802.1q VLAN ID"]); err == nil {
+		config.VLANID = vlan
+	}
+	return config
+}
@@ -0,0 +1,58 @@
+package ipmi
+
+import "testing"
+
+func TestParseLANConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   LANConfig
+	}{
+		{
+			name: "static address on a VLAN",
+			output: `Set in Progress         : Set Complete
+Auth Type Support       : NONE MD2 MD5 PASSWORD
+IP Address Source       : Static Address
+IP Address              : 10.0.0.12
+Subnet Mask             : 255.255.255.0
+MAC Address             : 0c:c4:7a:12:34:56
+SNMP Community String   : public
+Default Gateway IP      : 10.0.0.1
+Default Gateway MAC     : 00:00:00:00:00:00
+802.1q VLAN ID          : 100
+802.1q VLAN Priority    : 0`,
+			want: LANConfig{
+				AddressSource:  "static",
+				IPAddress:      "10.0.0.12",
+				SubnetMask:     "255.255.255.0",
+				MACAddress:     "0c:c4:7a:12:34:56",
+				DefaultGateway: "10.0.0.1",
+				VLANID:         100,
+			},
+		},
+		{
+			name: "DHCP without VLAN",
+			output: `IP Address Source       : DHCP Address
+IP Address              : 192.168.1.50
+Subnet Mask             : 255.255.0.0
+MAC Address             : 00:1e:67:aa:bb:cc
+Default Gateway IP      : 192.168.0.1
+802.1q VLAN ID          : Disabled`,
+			want: LANConfig{
+				AddressSource:  "dhcp",
+				IPAddress:      "192.168.1.50",
+				SubnetMask:     "255.255.0.0",
+				MACAddress:     "00:1e:67:aa:bb:cc",
+				DefaultGateway: "192.168.0.1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLANConfig(tt.output); *got != tt.want {
+				t.Errorf("parseLANConfig() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
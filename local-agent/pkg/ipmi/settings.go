@@ -0,0 +1,95 @@
+package ipmi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LANChannel is the LAN channel whose SOL and user settings are read and
+// set, the only one of most BMCs
+const LANChannel = "1"
+
+// Privilege levels of the users of a LAN channel
+const (
+	PrivilegeCallback      = "callback"
+	PrivilegeUser          = "user"
+	PrivilegeOperator      = "operator"
+	PrivilegeAdministrator = "administrator"
+)
+
+// privilegeLevels maps privilege levels to their value in ipmitool user priv
+var privilegeLevels = map[string]string{
+	PrivilegeCallback:      "1",
+	PrivilegeUser:          "2",
+	PrivilegeOperator:      "3",
+	PrivilegeAdministrator: "4",
+}
+
+// User is a user of a LAN channel of a BMC
+type User struct {
+	ID        int
+	Name      string
+	Privilege string // One of the Privilege levels, "no access", or as printed by ipmitool when unknown
+}
+
+// parseSOLEnabled parses the Enabled field of the output of ipmitool sol info
+func parseSOLEnabled(output string) (bool, error) {
+	// Example format:
+	// Set in progress                 : set-complete
+	// Enabled                         : true
+	// Force Encryption                : false
+	enabled, ok := parseFields(output)["Enabled"]
+	if !ok {
+		return false, fmt.Errorf("no Enabled field in SOL configuration")
+	}
+	return strconv.ParseBool(enabled)
+}
+
+// parseUserList parses the output of ipmitool user list, skipping the slots
+// without a user name
+func parseUserList(output string) []User {
+	// Example format:
+	// ID  Name	     Callin  Link Auth	IPMI Msg   Channel Priv Limit
+	// 1                    true    false      false      Unknown (0x00)
+	// 2   admin            false   false      true       ADMINISTRATOR
+	// 3   operator         true    true       true       OPERATOR
+	var users []User
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		// The name, which may be empty or contain spaces, ends at the Callin
+		// column, followed by Link Auth and IPMI Msg
+		callin := 1
+		for callin < len(fields) && fields[callin] != "true" && fields[callin] != "false" {
+			callin++
+		}
+		if callin == 1 || callin+3 >= len(fields) {
+			continue
+		}
+
+		users = append(users, User{
+			ID:        id,
+			Name:      strings.Join(fields[1:callin], " "),
+			Privilege: strings.ToLower(strings.Join(fields[callin+3:], " ")),
+		})
+	}
+	return users
+}
+
+// findUser returns the user named name, or an error when the BMC has none
+func findUser(users []User, name string) (*User, error) {
+	for i := range users {
+		if users[i].Name == name {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no user %s on LAN channel %s", name, LANChannel)
+}
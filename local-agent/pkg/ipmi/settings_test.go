@@ -0,0 +1,57 @@
+package ipmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSOLEnabled(t *testing.T) {
+	output := `Set in progress                 : set-complete
+Enabled                         : false
+Force Encryption                : false
+Force Authentication            : false
+Privilege Level                 : USER
+Character Accumulate Level (ms) : 50
+Volatile Bit Rate (kbps)        : 115.2
+Non-Volatile Bit Rate (kbps)    : 115.2
+Payload Channel                 : 1 (0x01)
+Payload Port                    : 623`
+
+	enabled, err := parseSOLEnabled(output)
+	if err != nil {
+		t.Fatalf("parseSOLEnabled() error = %v", err)
+	}
+	if enabled {
+		t.Error("parseSOLEnabled() = true, want false")
+	}
+
+	if _, err := parseSOLEnabled("Set in progress : set-complete"); err == nil {
+		t.Error("parseSOLEnabled() without Enabled field should fail")
+	}
+}
+
+func TestParseUserList(t *testing.T) {
+	output := `ID  Name	     Callin  Link Auth	IPMI Msg   Channel Priv Limit
+1                    true    false      false      Unknown (0x00)
+2   ADMIN            false   false      true       ADMINISTRATOR
+3   ops team         true    true       true       OPERATOR
+4   monitor          true    false      true       NO ACCESS`
+
+	want := []User{
+		{ID: 2, Name: "ADMIN", Privilege: PrivilegeAdministrator},
+		{ID: 3, Name: "ops team", Privilege: PrivilegeOperator},
+		{ID: 4, Name: "monitor", Privilege: "no access"},
+	}
+	users := parseUserList(output)
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("parseUserList() = %+v, want %+v", users, want)
+	}
+
+	user, err := findUser(users, "ops team")
+	if err != nil || user.ID != 3 {
+		t.Errorf("findUser() = %+v, %v, want user 3", user, err)
+	}
+	if _, err := findUser(users, "root"); err == nil {
+		t.Error("findUser() of a missing user should fail")
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/bmcproxy"
+	"local-agent/pkg/netpolicy"
 )
 
 // SubprocessClient implements IPMI operations using ipmitool subprocess calls
@@ -26,11 +30,15 @@ func NewSubprocessClient() *SubprocessClient {
 
 // runIPMITool executes ipmitool with the given arguments
 func (c *SubprocessClient) runIPMITool(ctx context.Context, endpoint, username, password string, args ...string) (string, error) {
-	// Parse endpoint
-	host := endpoint
-	if strings.Contains(endpoint, ":") {
-		parts := strings.Split(endpoint, ":")
-		host = parts[0]
+	// IPMI runs over UDP, which BMC proxies do not carry
+	if err := bmcproxy.RequireDirect(endpoint); err != nil {
+		return "", err
+	}
+
+	// Resolve the endpoint's host, refusing hosts outside the allowed networks
+	host, err := netpolicy.ResolveEndpointHost(ctx, endpoint)
+	if err != nil {
+		return "", err
 	}
 
 	// Build ipmitool command
@@ -58,7 +66,7 @@ func (c *SubprocessClient) runIPMITool(ctx context.Context, endpoint, username,
 		Strs("args", args).
 		Msg("Executing ipmitool command")
 
-	err := cmd.Run()
+	err = cmd.Run()
 	if err != nil {
 		// If lanplus fails, try legacy lan interface
 		if strings.Contains(stderr.String(), "lanplus") || strings.Contains(err.Error(), "exit status") {
@@ -109,6 +117,19 @@ func (c *SubprocessClient) PowerOff(ctx context.Context, endpoint, username, pas
 	return nil
 }
 
+// GracefulShutdown asks the OS to shut down via ACPI using ipmitool
+func (c *SubprocessClient) GracefulShutdown(ctx context.Context, endpoint, username, password string) error {
+	log.Debug().Str("endpoint", endpoint).Msg("Shutting down server gracefully via ipmitool")
+
+	_, err := c.runIPMITool(ctx, endpoint, username, password, "chassis", "power", "soft")
+	if err != nil {
+		return fmt.Errorf("failed to shut down gracefully: %w", err)
+	}
+
+	log.Info().Str("endpoint", endpoint).Msg("Graceful shutdown requested successfully")
+	return nil
+}
+
 // PowerCycle power cycles the server using ipmitool
 func (c *SubprocessClient) PowerCycle(ctx context.Context, endpoint, username, password string) error {
 	log.Debug().Str("endpoint", endpoint).Msg("Power cycling server via ipmitool")
@@ -135,6 +156,48 @@ func (c *SubprocessClient) Reset(ctx context.Context, endpoint, username, passwo
 	return nil
 }
 
+// DiagnosticInterrupt sends a diagnostic interrupt (NMI) using ipmitool
+func (c *SubprocessClient) DiagnosticInterrupt(ctx context.Context, endpoint, username, password string) error {
+	log.Debug().Str("endpoint", endpoint).Msg("Sending diagnostic interrupt via ipmitool")
+
+	_, err := c.runIPMITool(ctx, endpoint, username, password, "chassis", "power", "diag")
+	if err != nil {
+		return fmt.Errorf("failed to send diagnostic interrupt: %w", err)
+	}
+
+	log.Info().Str("endpoint", endpoint).Msg("Diagnostic interrupt sent successfully")
+	return nil
+}
+
+// MaxIdentifyInterval is the longest identify interval an IPMI BMC accepts;
+// longer intervals must force the LED on and turn it off later
+const MaxIdentifyInterval = 255 * time.Second
+
+// ChassisIdentify controls the chassis identify LED using ipmitool. The LED
+// is turned off when on is false, forced on until turned off when interval is
+// 0, and on for interval otherwise.
+func (c *SubprocessClient) ChassisIdentify(ctx context.Context, endpoint, username, password string, on bool, interval time.Duration) error {
+	log.Debug().Str("endpoint", endpoint).Bool("on", on).Dur("interval", interval).Msg("Setting chassis identify via ipmitool")
+
+	arg := "0"
+	switch {
+	case !on:
+	case interval == 0:
+		arg = "force"
+	case interval < time.Second || interval > MaxIdentifyInterval:
+		return fmt.Errorf("identify interval must be between 1s and %s", MaxIdentifyInterval)
+	default:
+		arg = strconv.Itoa(int(interval.Seconds()))
+	}
+
+	if _, err := c.runIPMITool(ctx, endpoint, username, password, "chassis", "identify", arg); err != nil {
+		return fmt.Errorf("failed to set chassis identify: %w", err)
+	}
+
+	log.Info().Str("endpoint", endpoint).Str("interval", arg).Msg("Chassis identify set successfully")
+	return nil
+}
+
 // GetPowerState gets the current power state using ipmitool
 func (c *SubprocessClient) GetPowerState(ctx context.Context, endpoint, username, password string) (PowerState, error) {
 	log.Debug().Str("endpoint", endpoint).Msg("Getting power state via ipmitool")
@@ -157,38 +220,26 @@ func (c *SubprocessClient) GetPowerState(ctx context.Context, endpoint, username
 	return PowerStateUnknown, nil
 }
 
-// GetBMCInfo gets BMC information using ipmitool
+// GetBMCInfo gets the vendor, model and firmware of the BMC from its Get
+// Device ID response and, when readable, the FRU inventory of the board
 func (c *SubprocessClient) GetBMCInfo(ctx context.Context, endpoint, username, password string) (*BMCInfo, error) {
-	output, err := c.runIPMITool(ctx, endpoint, username, password, "bmc", "info")
+	mcInfo, err := c.GetMCInfo(ctx, endpoint, username, password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get BMC info: %w", err)
 	}
 
-	info := &BMCInfo{
-		Features: []string{"power", "sensors", "console"},
-	}
-
-	// Parse output for version, vendor, etc.
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "Firmware Revision") {
-			parts := strings.Split(line, ":")
-			if len(parts) == 2 {
-				info.FirmwareVersion = strings.TrimSpace(parts[1])
-			}
-		} else if strings.Contains(line, "Manufacturer") {
-			parts := strings.Split(line, ":")
-			if len(parts) == 2 {
-				info.Vendor = strings.TrimSpace(parts[1])
-			}
-		} else if strings.Contains(line, "Product") {
-			parts := strings.Split(line, ":")
-			if len(parts) == 2 {
-				info.Model = strings.TrimSpace(parts[1])
-			}
-		}
+	// Get Device ID only names the manufacturers and products ipmitool
+	// knows, the FRU inventory names the others
+	var fru map[string]string
+	output, err := c.runIPMITool(ctx, endpoint, username, password, "fru", "print", "0")
+	if err != nil {
+		log.Debug().Err(err).Str("endpoint", endpoint).Msg("Failed to read FRU inventory")
+	} else {
+		fru = parseFields(output)
 	}
 
+	info := bmcInfoFromDeviceID(mcInfo, fru)
+	info.Features = []string{"power", "sensors", "console"}
 	return info, nil
 }
 
@@ -199,67 +250,80 @@ func (c *SubprocessClient) GetMCInfo(ctx context.Context, endpoint, username, pa
 		return nil, fmt.Errorf("failed to get MC info: %w", err)
 	}
 
-	info := make(map[string]string)
-
-	// Parse output line by line
-	// Example format:
-	// Device ID                 : 32
-	// Device Revision           : 1
-	// Firmware Revision         : 2.76
-	// IPMI Version              : 2.0
-	// Manufacturer ID           : 10876
-	// Manufacturer Name         : Supermicro
-	// Product ID                : 2402
-	// Device Available          : yes
-	// Provides Device SDRs      : yes
-	// Additional Device Support :
-	//     Sensor Device
-	//     SDR Repository Device
-	lines := strings.Split(output, "\n")
-	var additionalSupport []string
-	inAdditionalSection := false
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+	return parseMCInfo(output), nil
+}
 
-		// Check if we're in the Additional Device Support section
-		if strings.HasPrefix(line, "Additional Device Support") {
-			inAdditionalSection = true
-			continue
-		}
+// GetLANConfig gets the network configuration of the first LAN channel of
+// the BMC using ipmitool lan print
+func (c *SubprocessClient) GetLANConfig(ctx context.Context, endpoint, username, password string) (*LANConfig, error) {
+	output, err := c.runIPMITool(ctx, endpoint, username, password, "lan", "print")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LAN configuration: %w", err)
+	}
 
-		// If line starts with a letter and we're in additional section, it's a new field
-		if inAdditionalSection && len(line) > 0 && line[0] >= 'A' && line[0] <= 'Z' {
-			// Check if it contains a colon (new field)
-			if strings.Contains(line, ":") {
-				inAdditionalSection = false
-			} else {
-				// It's an additional device support item
-				additionalSupport = append(additionalSupport, line)
-				continue
-			}
-		}
+	return parseLANConfig(output), nil
+}
 
-		// Parse key-value pairs
-		if strings.Contains(line, ":") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				info[key] = value
-			}
-		}
+// GetSOLEnabled reports whether SOL is enabled on the LAN channel of the BMC
+// using ipmitool sol info
+func (c *SubprocessClient) GetSOLEnabled(ctx context.Context, endpoint, username, password string) (bool, error) {
+	output, err := c.runIPMITool(ctx, endpoint, username, password, "sol", "info", LANChannel)
+	if err != nil {
+		return false, fmt.Errorf("failed to get SOL configuration: %w", err)
 	}
 
-	// Add additional device support as comma-separated string
-	if len(additionalSupport) > 0 {
-		info["Additional Device Support"] = strings.Join(additionalSupport, ", ")
+	return parseSOLEnabled(output)
+}
+
+// SetSOLEnabled enables or disables SOL on the LAN channel of the BMC using
+// ipmitool sol set
+func (c *SubprocessClient) SetSOLEnabled(ctx context.Context, endpoint, username, password string, enabled bool) error {
+	if _, err := c.runIPMITool(ctx, endpoint, username, password, "sol", "set", "enabled", strconv.FormatBool(enabled), LANChannel); err != nil {
+		return fmt.Errorf("failed to set SOL enabled: %w", err)
 	}
 
-	return info, nil
+	log.Info().Str("endpoint", endpoint).Bool("enabled", enabled).Msg("SOL enablement set successfully")
+	return nil
+}
+
+// GetUserPrivilege gets the privilege level of a user on the LAN channel of
+// the BMC using ipmitool user list
+func (c *SubprocessClient) GetUserPrivilege(ctx context.Context, endpoint, username, password, user string) (string, error) {
+	output, err := c.runIPMITool(ctx, endpoint, username, password, "user", "list", LANChannel)
+	if err != nil {
+		return "", fmt.Errorf("failed to list users: %w", err)
+	}
+
+	found, err := findUser(parseUserList(output), user)
+	if err != nil {
+		return "", err
+	}
+	return found.Privilege, nil
+}
+
+// SetUserPrivilege sets the privilege level of a user on the LAN channel of
+// the BMC using ipmitool user priv
+func (c *SubprocessClient) SetUserPrivilege(ctx context.Context, endpoint, username, password, user, privilege string) error {
+	level, ok := privilegeLevels[privilege]
+	if !ok {
+		return fmt.Errorf("unsupported privilege level %q", privilege)
+	}
+
+	output, err := c.runIPMITool(ctx, endpoint, username, password, "user", "list", LANChannel)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	found, err := findUser(parseUserList(output), user)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.runIPMITool(ctx, endpoint, username, password, "user", "priv", strconv.Itoa(found.ID), level, LANChannel); err != nil {
+		return fmt.Errorf("failed to set user privilege: %w", err)
+	}
+
+	log.Info().Str("endpoint", endpoint).Str("user", user).Str("privilege", privilege).Msg("User privilege set successfully")
+	return nil
 }
 
 // IsAccessible checks if IPMI is accessible using ipmitool
@@ -268,14 +332,19 @@ func (c *SubprocessClient) IsAccessible(ctx context.Context, endpoint string) bo
 	timeoutCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	host := endpoint
-	if strings.Contains(endpoint, ":") {
-		parts := strings.Split(endpoint, ":")
-		host = parts[0]
+	if err := bmcproxy.RequireDirect(endpoint); err != nil {
+		log.Debug().Err(err).Str("endpoint", endpoint).Msg("IPMI endpoint refused")
+		return false
+	}
+
+	host, err := netpolicy.ResolveEndpointHost(timeoutCtx, endpoint)
+	if err != nil {
+		log.Debug().Err(err).Str("endpoint", endpoint).Msg("IPMI endpoint refused")
+		return false
 	}
 
 	cmd := exec.CommandContext(timeoutCtx, "ipmitool", "-I", "lanplus", "-H", host, "chassis", "status")
-	err := cmd.Run()
+	err = cmd.Run()
 
 	// If lanplus fails, try lan
 	if err != nil {
@@ -0,0 +1,178 @@
+// Package netpolicy restricts the addresses the agent's BMC clients connect
+// to. A BMC endpoint comes from the static configuration or from discovery,
+// so a malicious config or a poisoned discovery could otherwise point the
+// agent at any host it can reach, such as its own status endpoint or a cloud
+// metadata service.
+//
+// Go clients check the address they dial, after name resolution, so a name
+// cannot be rebound after the check. Subprocess clients such as ipmitool are
+// given the address returned by ResolveHost instead of the endpoint's name.
+package netpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ErrDenied is returned when a destination is outside the allowed networks
+var ErrDenied = errors.New("destination denied by network policy")
+
+// Policy decides which addresses BMC clients may connect to. A nil Policy
+// allows every address.
+type Policy struct {
+	allowed     []netip.Prefix // Empty allows all networks
+	denyPrivate bool
+}
+
+// New creates a policy allowing the networks in CIDR notation, or all
+// networks when allowedNetworks is empty. With denyPrivate, private,
+// loopback, link-local and unspecified addresses are denied even inside an
+// allowed network.
+func New(allowedNetworks []string, denyPrivate bool) (*Policy, error) {
+	p := &Policy{denyPrivate: denyPrivate}
+	for _, network := range allowedNetworks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network %s: %w", network, err)
+		}
+		p.allowed = append(p.allowed, prefix.Masked())
+	}
+	return p, nil
+}
+
+// Allows returns true when the policy allows connecting to addr
+func (p *Policy) Allows(addr netip.Addr) bool {
+	if p == nil {
+		return true
+	}
+	addr = addr.Unmap()
+
+	if p.denyPrivate && isPrivate(addr) {
+		return false
+	}
+	if len(p.allowed) == 0 {
+		return true
+	}
+	for _, prefix := range p.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns ErrDenied when the policy does not allow addr
+func (p *Policy) Check(addr netip.Addr) error {
+	if !p.Allows(addr) {
+		return fmt.Errorf("%w: %s", ErrDenied, addr)
+	}
+	return nil
+}
+
+// Control checks the address of a connection about to be made. It has the
+// signature of net.Dialer.Control, which is called with the resolved
+// address.
+func (p *Policy) Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: unresolved address %s", ErrDenied, address)
+	}
+	return p.Check(addr)
+}
+
+// ResolveHost resolves host and returns the first of its addresses that the
+// policy allows, for clients such as subprocesses whose connections cannot
+// be checked. Without a policy, host is returned unchanged.
+func (p *Policy) ResolveHost(ctx context.Context, host string) (string, error) {
+	if p == nil {
+		return host, nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if err := p.Check(addr); err != nil {
+			return "", err
+		}
+		return host, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if p.Allows(addr) {
+			return addr.Unmap().String(), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s resolves to %v", ErrDenied, host, addrs)
+}
+
+// isPrivate returns true for addresses that are not publicly routable
+func isPrivate(addr netip.Addr) bool {
+	return addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified()
+}
+
+// defaultPolicy is the policy of the BMC clients of the process
+var defaultPolicy atomic.Pointer[Policy]
+
+// SetDefault sets the policy enforced by the agent's BMC clients. It is set
+// once at startup, before any client connects.
+func SetDefault(p *Policy) {
+	defaultPolicy.Store(p)
+}
+
+// Default returns the policy enforced by the agent's BMC clients, nil when
+// none is set
+func Default() *Policy {
+	return defaultPolicy.Load()
+}
+
+// Dialer returns a dialer enforcing the default policy
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			return Default().Control(network, address, c)
+		},
+	}
+}
+
+// ResolveEndpointHost returns the host of a BMC endpoint, given as a URL or
+// as host[:port], resolved with the default policy
+func ResolveEndpointHost(ctx context.Context, endpoint string) (string, error) {
+	return Default().ResolveHost(ctx, EndpointHost(endpoint))
+}
+
+// CheckEndpoint returns ErrDenied when the default policy allows none of the
+// addresses of a BMC endpoint
+func CheckEndpoint(ctx context.Context, endpoint string) error {
+	_, err := ResolveEndpointHost(ctx, endpoint)
+	return err
+}
+
+// EndpointHost returns the host of an endpoint given as a URL or as
+// host[:port]
+func EndpointHost(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host
+	}
+	return strings.Trim(endpoint, "[]")
+}
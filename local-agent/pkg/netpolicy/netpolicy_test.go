@@ -0,0 +1,122 @@
+package netpolicy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestPolicyAllows(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowed     []string
+		denyPrivate bool
+		addr        string
+		want        bool
+	}{
+		{"no restriction", nil, false, "127.0.0.1", true},
+		{"inside allowed network", []string{"10.0.0.0/8"}, false, "10.1.2.3", true},
+		{"outside allowed networks", []string{"10.0.0.0/8", "192.168.0.0/16"}, false, "172.16.0.1", false},
+		{"IPv4-mapped IPv6", []string{"10.0.0.0/8"}, false, "::ffff:10.1.2.3", true},
+		{"private denied", nil, true, "192.168.1.10", false},
+		{"loopback denied", nil, true, "127.0.0.1", false},
+		{"link-local metadata denied", nil, true, "169.254.169.254", false},
+		{"unique local IPv6 denied", nil, true, "fd00::1", false},
+		{"public allowed", nil, true, "203.0.113.10", true},
+		{"private denied inside allowed network", []string{"10.0.0.0/8"}, true, "10.1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := New(tt.allowed, tt.denyPrivate)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if got := policy.Allows(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("Allows(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidNetwork(t *testing.T) {
+	if _, err := New([]string{"10.0.0.0/33"}, false); err == nil {
+		t.Error("Expected an error for an invalid network")
+	}
+}
+
+func TestNilPolicyAllowsAll(t *testing.T) {
+	var policy *Policy
+	if !policy.Allows(netip.MustParseAddr("127.0.0.1")) {
+		t.Error("Expected a nil policy to allow all addresses")
+	}
+	host, err := policy.ResolveHost(context.Background(), "bmc-01.example.internal")
+	if err != nil || host != "bmc-01.example.internal" {
+		t.Errorf("Expected the host unchanged, got %q, %v", host, err)
+	}
+}
+
+func TestResolveHost(t *testing.T) {
+	policy, err := New([]string{"127.0.0.0/8"}, false)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	host, err := policy.ResolveHost(context.Background(), "localhost")
+	if err != nil {
+		t.Fatalf("ResolveHost failed: %v", err)
+	}
+	if !netip.MustParseAddr(host).IsLoopback() {
+		t.Errorf("Expected a loopback address, got %s", host)
+	}
+
+	if _, err := policy.ResolveHost(context.Background(), "10.0.0.1"); !errors.Is(err, ErrDenied) {
+		t.Errorf("Expected ErrDenied, got %v", err)
+	}
+}
+
+func TestEndpointHost(t *testing.T) {
+	tests := map[string]string{
+		"192.168.1.100:623":                         "192.168.1.100",
+		"192.168.1.100":                             "192.168.1.100",
+		"https://bmc-01:8443/redfish/v1":            "bmc-01",
+		"ws://localhost:9001/redfish/v1/Systems/1":  "localhost",
+		"[fd00::10]:623":                            "fd00::10",
+		"wss://[fd00::10]/redfish/v1/SerialConsole": "fd00::10",
+	}
+	for endpoint, want := range tests {
+		if got := EndpointHost(endpoint); got != want {
+			t.Errorf("EndpointHost(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestDialerEnforcesDefaultPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{DialContext: Dialer(5 * time.Second).DialContext}}
+
+	// Without a policy the loopback server is reachable
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected the request to succeed without a policy: %v", err)
+	}
+	resp.Body.Close()
+
+	policy, err := New([]string{"10.0.0.0/8"}, false)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	SetDefault(policy)
+	t.Cleanup(func() { SetDefault(nil) })
+	client.CloseIdleConnections()
+
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrDenied) {
+		t.Errorf("Expected ErrDenied, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+// Package reconcile checks the BMCs of static hosts against the hosts'
+// declared configuration, treated as their desired state: each BMC must
+// answer with the declared credentials, have the declared consoles, and
+// grant the declared user privileges. The settings that can be set, SOL
+// enablement and user privileges, are re-applied when they drifted if the
+// reconciler is asked to.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/types"
+	"local-agent/pkg/bmc"
+	"local-agent/pkg/config"
+)
+
+// Checks made on the BMCs
+const (
+	CheckReachable     = "reachable"      // The control endpoint accepts connections
+	CheckCredentials   = "credentials"    // The BMC accepts the declared credentials
+	CheckSOLEnabled    = "sol_enabled"    // The serial console of hosts declaring one is enabled
+	CheckVNCReachable  = "vnc_reachable"  // The VNC endpoint accepts connections
+	CheckUserPrivilege = "user_privilege" // The user has the declared privilege
+)
+
+// BMC reads and sets the settings of the BMCs, see bmc.Client
+type BMC interface {
+	CheckCredentials(ctx context.Context, endpoint *types.BMCControlEndpoint) error
+	GetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint) (bool, error)
+	SetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint, enabled bool) error
+	GetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint) (string, error)
+	SetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint, privilege string) error
+}
+
+// Finding is a difference between a BMC and the declared configuration of
+// its host
+type Finding struct {
+	Check    string `json:"check"`
+	Endpoint string `json:"endpoint"`
+	Desired  string `json:"desired"`
+	Actual   string `json:"actual,omitempty"`  // Empty when it could not be read
+	Applied  bool   `json:"applied,omitempty"` // The desired value was re-applied
+	Error    string `json:"error,omitempty"`   // Why the check or the re-application failed
+}
+
+// Result is the outcome of the reconciliation of a host
+type Result struct {
+	HostID    string    `json:"host_id"`
+	CheckedAt time.Time `json:"checked_at"`
+	Findings  []Finding `json:"findings"`
+}
+
+// InSync reports whether the BMC of the host matched its configuration
+func (r *Result) InSync() bool {
+	return len(r.Findings) == 0
+}
+
+// Signature identifies the drift found, to tell whether it changed since a
+// previous reconciliation: it is empty when the host is in sync, and leaves
+// out the re-applications and errors, which vary between runs.
+func (r *Result) Signature() string {
+	parts := make([]string, len(r.Findings))
+	for i, finding := range r.Findings {
+		parts[i] = strings.Join([]string{finding.Check, finding.Endpoint, finding.Desired, finding.Actual}, "|")
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Reconciler checks the BMCs of hosts against their configuration
+type Reconciler struct {
+	bmc     BMC
+	apply   bool
+	timeout time.Duration
+
+	// checkReachable opens a TCP connection to address, see
+	// bmc.CheckReachable
+	checkReachable func(ctx context.Context, address string) error
+}
+
+// New creates a reconciler checking BMCs with client, allowing timeout for
+// each host. The settings that drifted are re-applied when apply is set.
+func New(client BMC, apply bool, timeout time.Duration) *Reconciler {
+	return &Reconciler{
+		bmc:     client,
+		apply:   apply,
+		timeout: timeout,
+		checkReachable: func(ctx context.Context, address string) error {
+			return bmc.CheckReachable(ctx, address, timeout)
+		},
+	}
+}
+
+// Reconcile checks the BMC of a host against its configuration. The
+// settings of a control endpoint are only checked once it accepted its
+// credentials; the serial console is a setting of the primary one.
+func (r *Reconciler) Reconcile(ctx context.Context, host config.BMCHost) *Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result := &Result{HostID: host.ID, CheckedAt: time.Now()}
+	consoleDeclared := host.SOLEndpoint != nil || slices.Contains(host.Features, string(types.FeatureConsole))
+
+	for i, configured := range host.ControlEndpoints {
+		endpoint := configured.ToTypesEndpoint()
+		if !r.checkAccess(ctx, endpoint, result) {
+			continue
+		}
+		if i == 0 && consoleDeclared {
+			r.checkSOLEnabled(ctx, endpoint, result)
+		}
+		if configured.Privilege != "" {
+			r.checkUserPrivilege(ctx, endpoint, configured.Privilege, result)
+		}
+	}
+
+	if host.VNCEndpoint != nil {
+		r.checkVNCReachable(ctx, host.VNCEndpoint.ToTypesEndpoint(), result)
+	}
+
+	if !result.InSync() {
+		log.Debug().
+			Str("host_id", host.ID).
+			Int("findings", len(result.Findings)).
+			Msg("Static host drifted from its configuration")
+	}
+	return result
+}
+
+// checkAccess checks that a control endpoint accepts connections, when it
+// has a TCP address, and its credentials. It returns whether it does.
+func (r *Reconciler) checkAccess(ctx context.Context, endpoint *types.BMCControlEndpoint, result *Result) bool {
+	address, err := bmc.ControlAddress(endpoint)
+	if err == nil && address != "" {
+		err = r.checkReachable(ctx, address)
+	}
+	if err != nil {
+		result.Findings = append(result.Findings, Finding{
+			Check:    CheckReachable,
+			Endpoint: endpoint.Endpoint,
+			Desired:  "reachable",
+			Actual:   "unreachable",
+			Error:    err.Error(),
+		})
+		return false
+	}
+
+	if err := r.bmc.CheckCredentials(ctx, endpoint); err != nil {
+		result.Findings = append(result.Findings, Finding{
+			Check:    CheckCredentials,
+			Endpoint: endpoint.Endpoint,
+			Desired:  "accepted",
+			Actual:   "rejected",
+			Error:    err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// checkSOLEnabled checks that the serial console of a BMC is enabled, and
+// enables it when applying
+func (r *Reconciler) checkSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint, result *Result) {
+	enabled, err := r.bmc.GetSOLEnabled(ctx, endpoint)
+	if err == nil && enabled {
+		return
+	}
+
+	finding := Finding{
+		Check:    CheckSOLEnabled,
+		Endpoint: endpoint.Endpoint,
+		Desired:  strconv.FormatBool(true),
+	}
+	if err != nil {
+		finding.Error = fmt.Sprintf("failed to read SOL enablement: %v", err)
+	} else {
+		finding.Actual = strconv.FormatBool(enabled)
+		if r.apply {
+			r.reapply(&finding, r.bmc.SetSOLEnabled(ctx, endpoint, true))
+		}
+	}
+	result.Findings = append(result.Findings, finding)
+}
+
+// checkUserPrivilege checks that the user of a control endpoint has the
+// declared privilege level, and sets it when applying
+func (r *Reconciler) checkUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint, privilege string, result *Result) {
+	actual, err := r.bmc.GetUserPrivilege(ctx, endpoint)
+	if err == nil && actual == privilege {
+		return
+	}
+
+	finding := Finding{
+		Check:    CheckUserPrivilege,
+		Endpoint: endpoint.Endpoint,
+		Desired:  privilege,
+	}
+	if err != nil {
+		finding.Error = fmt.Sprintf("failed to read user privilege: %v", err)
+	} else {
+		finding.Actual = actual
+		if r.apply {
+			r.reapply(&finding, r.bmc.SetUserPrivilege(ctx, endpoint, privilege))
+		}
+	}
+	result.Findings = append(result.Findings, finding)
+}
+
+// checkVNCReachable checks that a VNC endpoint accepts connections
+func (r *Reconciler) checkVNCReachable(ctx context.Context, endpoint *types.VNCEndpoint, result *Result) {
+	address, err := bmc.VNCAddress(endpoint)
+	if err == nil {
+		err = r.checkReachable(ctx, address)
+	}
+	if err != nil {
+		result.Findings = append(result.Findings, Finding{
+			Check:    CheckVNCReachable,
+			Endpoint: endpoint.Endpoint,
+			Desired:  "reachable",
+			Actual:   "unreachable",
+			Error:    err.Error(),
+		})
+	}
+}
+
+// reapply records the outcome of the re-application of a finding
+func (r *Reconciler) reapply(finding *Finding, err error) {
+	if err != nil {
+		finding.Error = fmt.Sprintf("failed to apply %s: %v", finding.Desired, err)
+		log.Warn().Err(err).Str("check", finding.Check).Str("endpoint", finding.Endpoint).Msg("Failed to re-apply BMC setting")
+		return
+	}
+	finding.Applied = true
+	log.Info().Str("check", finding.Check).Str("endpoint", finding.Endpoint).Str("value", finding.Desired).Msg("Re-applied drifted BMC setting")
+}
@@ -0,0 +1,177 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"core/types"
+	"local-agent/pkg/config"
+)
+
+// fakeBMC serves settings from memory, recording the settings applied
+type fakeBMC struct {
+	credentialsErr error
+	solEnabled     bool
+	privilege      string
+	setErr         error
+
+	solApplied       bool
+	privilegeApplied string
+}
+
+func (f *fakeBMC) CheckCredentials(ctx context.Context, endpoint *types.BMCControlEndpoint) error {
+	return f.credentialsErr
+}
+
+func (f *fakeBMC) GetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint) (bool, error) {
+	return f.solEnabled, nil
+}
+
+func (f *fakeBMC) SetSOLEnabled(ctx context.Context, endpoint *types.BMCControlEndpoint, enabled bool) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	f.solApplied = enabled
+	return nil
+}
+
+func (f *fakeBMC) GetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint) (string, error) {
+	return f.privilege, nil
+}
+
+func (f *fakeBMC) SetUserPrivilege(ctx context.Context, endpoint *types.BMCControlEndpoint, privilege string) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	f.privilegeApplied = privilege
+	return nil
+}
+
+func newHost(privilege string) config.BMCHost {
+	return config.BMCHost{
+		ID: "server-01",
+		ControlEndpoints: []*config.ConfigBMCControlEndpoint{
+			{Endpoint: "192.168.1.100:623", Type: "ipmi", Username: "admin", Password: "secret", Privilege: privilege},
+		},
+		Features: []string{"power", "console"},
+	}
+}
+
+func newReconciler(bmc BMC, apply bool, unreachable map[string]bool) *Reconciler {
+	r := New(bmc, apply, time.Second)
+	r.checkReachable = func(ctx context.Context, address string) error {
+		if unreachable[address] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	return r
+}
+
+func TestReconcileInSync(t *testing.T) {
+	bmc := &fakeBMC{solEnabled: true, privilege: "administrator"}
+	result := newReconciler(bmc, true, nil).Reconcile(context.Background(), newHost("administrator"))
+
+	if !result.InSync() || result.Signature() != "" {
+		t.Errorf("Expected host in sync, got findings %+v", result.Findings)
+	}
+	if result.HostID != "server-01" {
+		t.Errorf("HostID = %q, want server-01", result.HostID)
+	}
+	if bmc.solApplied || bmc.privilegeApplied != "" {
+		t.Error("Expected nothing applied to a host in sync")
+	}
+}
+
+func TestReconcileDrift(t *testing.T) {
+	tests := []struct {
+		name        string
+		bmc         *fakeBMC
+		apply       bool
+		wantChecks  []string
+		wantApplied bool
+	}{
+		{
+			name:       "credentials rejected skip settings",
+			bmc:        &fakeBMC{credentialsErr: errors.New("unauthorized")},
+			wantChecks: []string{CheckCredentials},
+		},
+		{
+			name:       "SOL disabled and privilege lowered reported",
+			bmc:        &fakeBMC{solEnabled: false, privilege: "user"},
+			wantChecks: []string{CheckSOLEnabled, CheckUserPrivilege},
+		},
+		{
+			name:        "SOL disabled and privilege lowered re-applied",
+			bmc:         &fakeBMC{solEnabled: false, privilege: "user"},
+			apply:       true,
+			wantChecks:  []string{CheckSOLEnabled, CheckUserPrivilege},
+			wantApplied: true,
+		},
+		{
+			name:       "re-application failures reported",
+			bmc:        &fakeBMC{solEnabled: false, privilege: "user", setErr: errors.New("insufficient privilege")},
+			apply:      true,
+			wantChecks: []string{CheckSOLEnabled, CheckUserPrivilege},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newReconciler(tt.bmc, tt.apply, nil).Reconcile(context.Background(), newHost("administrator"))
+
+			if len(result.Findings) != len(tt.wantChecks) {
+				t.Fatalf("Findings = %+v, want checks %v", result.Findings, tt.wantChecks)
+			}
+			for i, finding := range result.Findings {
+				if finding.Check != tt.wantChecks[i] {
+					t.Errorf("Finding %d check = %q, want %q", i, finding.Check, tt.wantChecks[i])
+				}
+				if finding.Applied != tt.wantApplied {
+					t.Errorf("Finding %d applied = %v, want %v", i, finding.Applied, tt.wantApplied)
+				}
+				if tt.apply && !tt.wantApplied && finding.Error == "" {
+					t.Errorf("Finding %d should report the re-application failure", i)
+				}
+			}
+			if tt.wantApplied && (!tt.bmc.solApplied || tt.bmc.privilegeApplied != "administrator") {
+				t.Errorf("Expected SOL and privilege applied, got %v and %q", tt.bmc.solApplied, tt.bmc.privilegeApplied)
+			}
+		})
+	}
+}
+
+func TestReconcileReachability(t *testing.T) {
+	host := config.BMCHost{
+		ID: "server-02",
+		ControlEndpoints: []*config.ConfigBMCControlEndpoint{
+			{Endpoint: "https://192.168.1.101", Type: "redfish", Username: "admin", Password: "secret"},
+		},
+		VNCEndpoint: &config.ConfigVNCEndpoint{Endpoint: "192.168.1.101:5901", Type: "native"},
+	}
+	unreachable := map[string]bool{"192.168.1.101:443": true, "192.168.1.101:5901": true}
+
+	result := newReconciler(&fakeBMC{}, false, unreachable).Reconcile(context.Background(), host)
+
+	if len(result.Findings) != 2 {
+		t.Fatalf("Findings = %+v, want unreachable control and VNC endpoints", result.Findings)
+	}
+	if result.Findings[0].Check != CheckReachable || result.Findings[1].Check != CheckVNCReachable {
+		t.Errorf("Unexpected findings: %+v", result.Findings)
+	}
+}
+
+func TestResultSignature(t *testing.T) {
+	reported := &Result{Findings: []Finding{{Check: CheckSOLEnabled, Endpoint: "bmc", Desired: "true", Actual: "false"}}}
+	applied := &Result{Findings: []Finding{{Check: CheckSOLEnabled, Endpoint: "bmc", Desired: "true", Actual: "false", Applied: true}}}
+	changed := &Result{Findings: []Finding{{Check: CheckUserPrivilege, Endpoint: "bmc", Desired: "operator", Actual: "user"}}}
+
+	if reported.Signature() != applied.Signature() {
+		t.Error("Re-application should not change the signature")
+	}
+	if reported.Signature() == changed.Signature() {
+		t.Error("Different drift should change the signature")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/bmcproxy"
 )
 
 // Client handles Redfish BMC communications
@@ -17,6 +19,9 @@ type Client struct {
 	httpClient     *http.Client
 	timeout        time.Duration
 	SessionManager *SessionManager
+
+	// taskPollInterval is the time between polls of task monitors
+	taskPollInterval time.Duration
 }
 
 func NewClient() *Client {
@@ -24,6 +29,9 @@ func NewClient() *Client {
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
+			// BMCs are reached through their proxy, and the network policy
+			// refuses those outside the allowed networks
+			DialContext: bmcproxy.NewDialer(30 * time.Second).DialContext,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: true, // BMCs often use self-signed certificates
 			},
@@ -31,12 +39,20 @@ func NewClient() *Client {
 	}
 
 	return &Client{
-		httpClient:     httpClient,
-		timeout:        10 * time.Second,
-		SessionManager: NewSessionManager(httpClient),
+		httpClient:       httpClient,
+		timeout:          10 * time.Second,
+		SessionManager:   NewSessionManager(httpClient),
+		taskPollInterval: defaultTaskPollInterval,
 	}
 }
 
+// CloseIdleConnections closes the connections to BMCs kept alive, so that the
+// following requests dial the endpoints again, e.g. once their names resolve
+// to new addresses
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
 // IsAccessible checks if a Redfish BMC is accessible at the given endpoint
 func (c *Client) IsAccessible(ctx context.Context, endpoint string) bool {
 	log.Debug().Str("endpoint", endpoint).Msg("Checking Redfish accessibility")
@@ -201,33 +217,79 @@ func (c *Client) getComputerSystem(ctx context.Context, endpoint, username, pass
 	return &system, nil
 }
 
+// ComputerSystem.Reset types of the power operations
+const (
+	ResetTypeOn               = "On"
+	ResetTypeForceOff         = "ForceOff"
+	ResetTypeGracefulShutdown = "GracefulShutdown"
+	ResetTypePowerCycle       = "PowerCycle"
+	ResetTypeForceRestart     = "ForceRestart"
+	ResetTypeNmi              = "Nmi"
+)
+
 // PowerOn powers on the server
 func (c *Client) PowerOn(ctx context.Context, endpoint, username, password string) error {
-	return c.performPowerAction(ctx, endpoint, username, password, "On")
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypeOn)
+	return err
 }
 
 // PowerOff powers off the server
 func (c *Client) PowerOff(ctx context.Context, endpoint, username, password string) error {
-	return c.performPowerAction(ctx, endpoint, username, password, "ForceOff")
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypeForceOff)
+	return err
+}
+
+// GracefulShutdown asks the OS of the server to shut down
+func (c *Client) GracefulShutdown(ctx context.Context, endpoint, username, password string) error {
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypeGracefulShutdown)
+	return err
 }
 
 // PowerCycle power cycles the server
 func (c *Client) PowerCycle(ctx context.Context, endpoint, username, password string) error {
-	return c.performPowerAction(ctx, endpoint, username, password, "PowerCycle")
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypePowerCycle)
+	return err
 }
 
 // Reset resets the server
 func (c *Client) Reset(ctx context.Context, endpoint, username, password string) error {
-	return c.performPowerAction(ctx, endpoint, username, password, "ForceRestart")
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypeForceRestart)
+	return err
+}
+
+// DiagnosticInterrupt sends a non-maskable interrupt to the server
+func (c *Client) DiagnosticInterrupt(ctx context.Context, endpoint, username, password string) error {
+	_, err := c.performPowerAction(ctx, endpoint, username, password, ResetTypeNmi)
+	return err
+}
+
+// RunPowerAction performs a power action of the given ResetType and, if the
+// BMC runs it as a task, waits for the task to complete, calling onProgress
+// (if not nil) as it runs. The other power methods return as soon as the
+// BMC accepts the action.
+func (c *Client) RunPowerAction(ctx context.Context, endpoint, username, password, resetType string, onProgress TaskProgressFunc) error {
+	monitor, err := c.performPowerAction(ctx, endpoint, username, password, resetType)
+	if err != nil {
+		return err
+	}
+	if monitor == "" {
+		return nil
+	}
+
+	if _, err := c.WaitForTask(ctx, endpoint, username, password, monitor, onProgress); err != nil {
+		return fmt.Errorf("power action %s: %w", resetType, err)
+	}
+	return nil
 }
 
-// performPowerAction performs a power action on the server
-func (c *Client) performPowerAction(ctx context.Context, endpoint, username, password, action string) error {
+// performPowerAction performs a power action on the server. It returns the
+// task monitor of the action when the BMC accepts it as a task.
+func (c *Client) performPowerAction(ctx context.Context, endpoint, username, password, action string) (string, error) {
 	log.Debug().Str("action", action).Str("endpoint", endpoint).Msg("Performing power action")
 
 	system, err := c.getComputerSystem(ctx, endpoint, username, password)
 	if err != nil {
-		return fmt.Errorf("failed to get computer system: %w", err)
+		return "", fmt.Errorf("failed to get computer system: %w", err)
 	}
 
 	// Perform the reset action
@@ -239,12 +301,12 @@ func (c *Client) performPowerAction(ctx context.Context, endpoint, username, pas
 
 	payloadBytes, err := json.Marshal(resetPayload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal reset payload: %w", err)
+		return "", fmt.Errorf("failed to marshal reset payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", resetURL, strings.NewReader(string(payloadBytes)))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -255,16 +317,22 @@ func (c *Client) performPowerAction(ctx context.Context, endpoint, username, pas
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("power action failed: HTTP %d: %s", resp.StatusCode, resp.Status)
+		return "", fmt.Errorf("power action failed: HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	// 202 Accepted with a Location: the action runs as a task
+	if monitor := resp.Header.Get("Location"); resp.StatusCode == http.StatusAccepted && monitor != "" {
+		log.Debug().Str("action", action).Str("monitor", monitor).Msg("Power action accepted as a task")
+		return monitor, nil
 	}
 
 	log.Debug().Str("action", action).Msg("Power action completed")
-	return nil
+	return "", nil
 }
 
 // GetManagerInfo retrieves Manager (BMC) information from Redfish
@@ -473,6 +541,12 @@ func detectVendorFromManager(manager *Manager) VendorType {
 		return VendorIDRAC
 	}
 
+	// OpenBMC's bmcweb extends its manager with the OpenBmc Oem, whatever
+	// the manufacturer of the board
+	if _, ok := manager.Oem["OpenBmc"]; ok {
+		return VendorOpenBMC
+	}
+
 	// Add more vendor detection patterns here as needed
 	// Example: HPE iLO, Supermicro, etc.
 
@@ -535,6 +609,18 @@ func (c *Client) GetWithToken(ctx context.Context, url, token string, target int
 // DiscoverSerialConsole checks if SerialConsole is supported.
 // It automatically detects the vendor and delegates to the appropriate handler.
 func (c *Client) DiscoverSerialConsole(ctx context.Context, endpoint, username, password string) (*SerialConsoleInfo, error) {
+	return c.discoverSerialConsole(ctx, "", endpoint, username, password)
+}
+
+// DiscoverSerialConsoleAs checks if SerialConsole is supported like
+// DiscoverSerialConsole, with the handler of a vendor known beforehand
+func (c *Client) DiscoverSerialConsoleAs(ctx context.Context, vendorType VendorType, endpoint, username, password string) (*SerialConsoleInfo, error) {
+	return c.discoverSerialConsole(ctx, vendorType, endpoint, username, password)
+}
+
+// discoverSerialConsole delegates serial console discovery to the handler
+// of vendorType, detected from the manager when empty
+func (c *Client) discoverSerialConsole(ctx context.Context, vendorType VendorType, endpoint, username, password string) (*SerialConsoleInfo, error) {
 	token, sessionURI, err := c.SessionManager.CreateSession(ctx, endpoint, username, password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
@@ -545,15 +631,17 @@ func (c *Client) DiscoverSerialConsole(ctx context.Context, endpoint, username,
 		}
 	}()
 
-	// Get manager for vendor detection
-	manager, err := c.getFirstManager(ctx, endpoint, token)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get manager: %w", err)
-	}
+	if vendorType == "" {
+		// Get manager for vendor detection
+		manager, err := c.getFirstManager(ctx, endpoint, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get manager: %w", err)
+		}
 
-	// Detect vendor from manager
-	vendorType := detectVendorFromManager(manager)
-	log.Debug().Str("vendor", string(vendorType)).Str("endpoint", endpoint).Msg("Detected BMC vendor")
+		// Detect vendor from manager
+		vendorType = detectVendorFromManager(manager)
+		log.Debug().Str("vendor", string(vendorType)).Str("endpoint", endpoint).Msg("Detected BMC vendor")
+	}
 
 	// Get the appropriate vendor handler
 	handler := NewVendorHandler(vendorType, c)
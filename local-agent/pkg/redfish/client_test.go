@@ -2,9 +2,13 @@ package redfish
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"core/testing/redfishsim"
 )
 
 func TestCreateSession(t *testing.T) {
@@ -66,3 +70,112 @@ func TestDiscoverSerialConsole(t *testing.T) {
 		t.Errorf("Expected supported and enabled, got %+v", info)
 	}
 }
+
+func TestDiagnosticInterrupt(t *testing.T) {
+	var resetType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/":
+			w.Write([]byte(`{"Systems": {"@odata.id": "/redfish/v1/Systems"}}`))
+		case "/redfish/v1/Systems":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`))
+		case "/redfish/v1/Systems/1":
+			w.Write([]byte(`{"Actions": {"#ComputerSystem.Reset": {"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}}}`))
+		case "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+			var payload struct{ ResetType string }
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Failed to decode reset payload: %v", err)
+			}
+			resetType = payload.ResetType
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	if err := client.DiagnosticInterrupt(context.Background(), server.URL, "user", "pass"); err != nil {
+		t.Fatalf("DiagnosticInterrupt failed: %v", err)
+	}
+	if resetType != "Nmi" {
+		t.Errorf("Expected ResetType Nmi, got %q", resetType)
+	}
+}
+
+func TestPowerActions_Simulator(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	ctx := context.Background()
+	client := NewClient()
+
+	steps := []struct {
+		action   func(ctx context.Context, endpoint, username, password string) error
+		expected PowerState
+	}{
+		{client.PowerOff, PowerStateOff},
+		{client.PowerOn, PowerStateOn},
+		{client.GracefulShutdown, PowerStateOff},
+		{client.PowerCycle, PowerStateOn},
+	}
+	for _, step := range steps {
+		if err := step.action(ctx, bmc.URL, bmc.Username(), bmc.Password()); err != nil {
+			t.Fatalf("Power action failed: %v", err)
+		}
+		state, err := client.GetPowerState(ctx, bmc.URL, bmc.Username(), bmc.Password())
+		if err != nil {
+			t.Fatalf("GetPowerState failed: %v", err)
+		}
+		if state != step.expected {
+			t.Errorf("Expected %s, got %s", step.expected, state)
+		}
+	}
+
+	expected := []string{"ForceOff", "On", "GracefulShutdown", "PowerCycle"}
+	if resets := bmc.Resets(); strings.Join(resets, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected resets %v, got %v", expected, resets)
+	}
+}
+
+func TestGetManagerInfo_Simulator(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{Manufacturer: "Dell Inc.", FirmwareVersion: "7.00.00"})
+	defer bmc.Close()
+
+	client := NewClient()
+	manager, netProto, err := client.GetManagerInfo(context.Background(), bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("GetManagerInfo failed: %v", err)
+	}
+	if manager.FirmwareVersion != "7.00.00" {
+		t.Errorf("Expected firmware 7.00.00, got %q", manager.FirmwareVersion)
+	}
+	if netProto == nil || !netProto.IPMI.ProtocolEnabled || netProto.IPMI.Port != 623 {
+		t.Errorf("Expected IPMI enabled on 623, got %+v", netProto)
+	}
+
+	vendor, err := client.DetectVendor(context.Background(), bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("DetectVendor failed: %v", err)
+	}
+	if vendor != VendorIDRAC {
+		t.Errorf("Expected iDRAC vendor, got %s", vendor)
+	}
+}
+
+func TestDiscoverSerialConsole_Simulator(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	client := NewClient()
+	info, err := client.DiscoverSerialConsole(context.Background(), bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("DiscoverSerialConsole failed: %v", err)
+	}
+	if !info.Supported || !info.Enabled {
+		t.Errorf("Expected supported and enabled, got %+v", info)
+	}
+	if bmc.Sessions() != 0 {
+		t.Errorf("Expected the discovery session to be deleted, %d left", bmc.Sessions())
+	}
+}
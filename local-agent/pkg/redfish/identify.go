@@ -0,0 +1,71 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Indicator LED states of a computer system
+const (
+	IndicatorLEDLit      = "Lit"
+	IndicatorLEDBlinking = "Blinking"
+	IndicatorLEDOff      = "Off"
+)
+
+// SetIndicatorLED sets the identify (locate) LED of the server to one of the
+// IndicatorLED states. BMCs that implement LocationIndicatorActive instead of
+// the deprecated IndicatorLED only have on and off; the BMC decides whether
+// the LED blinks.
+func (c *Client) SetIndicatorLED(ctx context.Context, endpoint, username, password, state string) error {
+	log.Debug().Str("state", state).Str("endpoint", endpoint).Msg("Setting indicator LED")
+
+	system, err := c.getComputerSystem(ctx, endpoint, username, password)
+	if err != nil {
+		return fmt.Errorf("failed to get computer system: %w", err)
+	}
+	if system.ODataID == "" {
+		return fmt.Errorf("computer system has no @odata.id")
+	}
+
+	var payload map[string]interface{}
+	if system.LocationIndicatorActive != nil {
+		payload = map[string]interface{}{"LocationIndicatorActive": state != IndicatorLEDOff}
+	} else {
+		payload = map[string]interface{}{"IndicatorLED": state}
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal indicator LED payload: %w", err)
+	}
+
+	systemURL := BuildRedfishURL(endpoint, system.ODataID)
+	req, err := http.NewRequestWithContext(ctx, "PATCH", systemURL, strings.NewReader(string(payloadBytes)))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return NewHTTPError(resp.StatusCode, resp.Status, "set indicator LED")
+	}
+
+	log.Debug().Str("state", state).Msg("Indicator LED set")
+	return nil
+}
@@ -0,0 +1,72 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newIdentifyServer serves a computer system and records the PATCH payloads
+// sent to it
+func newIdentifyServer(t *testing.T, system string, patches *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/" || r.URL.Path == "/redfish/v1":
+			w.Write([]byte(`{"Systems": {"@odata.id": "/redfish/v1/Systems"}}`))
+		case r.URL.Path == "/redfish/v1/Systems":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`))
+		case r.URL.Path == "/redfish/v1/Systems/1" && r.Method == "GET":
+			w.Write([]byte(system))
+		case r.URL.Path == "/redfish/v1/Systems/1" && r.Method == "PATCH":
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Failed to decode PATCH payload: %v", err)
+			}
+			*patches = append(*patches, payload)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSetIndicatorLED(t *testing.T) {
+	var patches []map[string]interface{}
+	server := newIdentifyServer(t, `{"@odata.id": "/redfish/v1/Systems/1", "IndicatorLED": "Off"}`, &patches)
+	defer server.Close()
+
+	client := NewClient()
+	if err := client.SetIndicatorLED(context.Background(), server.URL, "user", "pass", IndicatorLEDBlinking); err != nil {
+		t.Fatalf("SetIndicatorLED failed: %v", err)
+	}
+
+	if len(patches) != 1 || patches[0]["IndicatorLED"] != IndicatorLEDBlinking {
+		t.Errorf("Expected IndicatorLED Blinking patch, got %v", patches)
+	}
+}
+
+func TestSetIndicatorLED_LocationIndicatorActive(t *testing.T) {
+	var patches []map[string]interface{}
+	server := newIdentifyServer(t, `{"@odata.id": "/redfish/v1/Systems/1", "LocationIndicatorActive": false}`, &patches)
+	defer server.Close()
+
+	client := NewClient()
+	for _, state := range []string{IndicatorLEDLit, IndicatorLEDOff} {
+		if err := client.SetIndicatorLED(context.Background(), server.URL, "user", "pass", state); err != nil {
+			t.Fatalf("SetIndicatorLED(%s) failed: %v", state, err)
+		}
+	}
+
+	if len(patches) != 2 {
+		t.Fatalf("Expected 2 patches, got %v", patches)
+	}
+	if patches[0]["LocationIndicatorActive"] != true || patches[1]["LocationIndicatorActive"] != false {
+		t.Errorf("Expected LocationIndicatorActive true then false, got %v", patches)
+	}
+	if _, ok := patches[0]["IndicatorLED"]; ok {
+		t.Errorf("Expected no IndicatorLED in patch, got %v", patches[0])
+	}
+}
@@ -0,0 +1,76 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EthernetInterface is a network interface of a Redfish Manager, the BMC's
+// own management interface
+type EthernetInterface struct {
+	ID               string `json:"Id"`
+	MACAddress       string `json:"MACAddress"`
+	InterfaceEnabled *bool  `json:"InterfaceEnabled"`
+	IPv4Addresses    []struct {
+		Address       string `json:"Address"`
+		SubnetMask    string `json:"SubnetMask"`
+		AddressOrigin string `json:"AddressOrigin"`
+		Gateway       string `json:"Gateway"`
+	} `json:"IPv4Addresses"`
+	IPv6Addresses []struct {
+		Address       string `json:"Address"`
+		PrefixLength  int    `json:"PrefixLength"`
+		AddressOrigin string `json:"AddressOrigin"`
+	} `json:"IPv6Addresses"`
+	VLAN struct {
+		VLANEnable bool  `json:"VLANEnable"`
+		VLANID     int32 `json:"VLANId"`
+	} `json:"VLAN"`
+}
+
+// GetEthernetInterfaces retrieves the network interfaces of the first
+// Manager (BMC)
+func (c *Client) GetEthernetInterfaces(ctx context.Context, endpoint, username, password string) ([]*EthernetInterface, error) {
+	log.Debug().Str("endpoint", endpoint).Msg("Getting Manager EthernetInterfaces")
+
+	var managersCollection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.getJSON(ctx, BuildManagersURL(endpoint), username, password, &managersCollection); err != nil {
+		return nil, err
+	}
+	if len(managersCollection.Members) == 0 {
+		return nil, fmt.Errorf("no managers found")
+	}
+
+	var manager Manager
+	if err := c.getJSON(ctx, BuildRedfishURL(endpoint, managersCollection.Members[0].ODataID), username, password, &manager); err != nil {
+		return nil, err
+	}
+	if manager.EthernetInterfaces.ODataID == "" {
+		return nil, fmt.Errorf("manager %s has no EthernetInterfaces", manager.ID)
+	}
+
+	var interfacesCollection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.getJSON(ctx, BuildRedfishURL(endpoint, manager.EthernetInterfaces.ODataID), username, password, &interfacesCollection); err != nil {
+		return nil, fmt.Errorf("failed to get EthernetInterfaces: %w", err)
+	}
+
+	interfaces := make([]*EthernetInterface, 0, len(interfacesCollection.Members))
+	for _, member := range interfacesCollection.Members {
+		var iface EthernetInterface
+		if err := c.getJSON(ctx, BuildRedfishURL(endpoint, member.ODataID), username, password, &iface); err != nil {
+			return nil, fmt.Errorf("failed to get EthernetInterface %s: %w", member.ODataID, err)
+		}
+		interfaces = append(interfaces, &iface)
+	}
+	return interfaces, nil
+}
@@ -0,0 +1,79 @@
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newNetworkServer serves a manager with the given resources, keyed by path
+func newNetworkServer(t *testing.T, manager string, resources map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/redfish/v1/Managers":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/1"}]}`))
+		case "/redfish/v1/Managers/1":
+			w.Write([]byte(manager))
+		default:
+			body, ok := resources[r.URL.Path]
+			if !ok {
+				t.Errorf("Unexpected path: %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		}
+	}))
+}
+
+func TestGetEthernetInterfaces(t *testing.T) {
+	server := newNetworkServer(t,
+		`{"Id": "1", "EthernetInterfaces": {"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces"}}`,
+		map[string]string{
+			"/redfish/v1/Managers/1/EthernetInterfaces": `{"Members": [{"@odata.id": "/redfish/v1/Managers/1/EthernetInterfaces/eth0"}]}`,
+			"/redfish/v1/Managers/1/EthernetInterfaces/eth0": `{
+				"Id": "eth0",
+				"MACAddress": "0c:c4:7a:12:34:56",
+				"InterfaceEnabled": true,
+				"IPv4Addresses": [{"Address": "10.0.0.12", "SubnetMask": "255.255.255.0", "AddressOrigin": "Static", "Gateway": "10.0.0.1"}],
+				"IPv6Addresses": [{"Address": "fd00::12", "PrefixLength": 64, "AddressOrigin": "SLAAC"}],
+				"VLAN": {"VLANEnable": true, "VLANId": 100}
+			}`,
+		})
+	defer server.Close()
+
+	client := NewClient()
+	interfaces, err := client.GetEthernetInterfaces(context.Background(), server.URL, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetEthernetInterfaces failed: %v", err)
+	}
+	if len(interfaces) != 1 {
+		t.Fatalf("Expected 1 interface, got %d", len(interfaces))
+	}
+
+	iface := interfaces[0]
+	if iface.ID != "eth0" || iface.MACAddress != "0c:c4:7a:12:34:56" {
+		t.Errorf("Unexpected interface %s (%s)", iface.ID, iface.MACAddress)
+	}
+	if len(iface.IPv4Addresses) != 1 || iface.IPv4Addresses[0].Gateway != "10.0.0.1" {
+		t.Errorf("Unexpected IPv4 addresses %+v", iface.IPv4Addresses)
+	}
+	if !iface.VLAN.VLANEnable || iface.VLAN.VLANID != 100 {
+		t.Errorf("Expected VLAN 100, got %+v", iface.VLAN)
+	}
+}
+
+func TestGetEthernetInterfaces_NotSupported(t *testing.T) {
+	server := newNetworkServer(t, `{"Id": "1"}`, nil)
+	defer server.Close()
+
+	client := NewClient()
+	if _, err := client.GetEthernetInterfaces(context.Background(), server.URL, "user", "pass"); err == nil {
+		t.Error("Expected an error for a manager without EthernetInterfaces")
+	}
+}
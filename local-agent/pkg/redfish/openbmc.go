@@ -0,0 +1,80 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WebSocket paths of the consoles bmcweb serves next to its Redfish service
+const (
+	// OpenBMCConsolePath is the obmc-console WebSocket of the host serial
+	// console, carrying raw console bytes in binary messages
+	OpenBMCConsolePath = "/console0"
+
+	// OpenBMCKVMPath is the KVM WebSocket, carrying RFB
+	OpenBMCKVMPath = "/kvm/0"
+)
+
+// OpenBMCRedfish handles OpenBMC-specific Redfish operations. Its bmcweb
+// does not implement the SerialConsole.Connect action: the host console is
+// the obmc-console WebSocket, and the graphical console a KVM WebSocket,
+// both authenticated like Redfish requests.
+type OpenBMCRedfish struct {
+	Client *Client
+}
+
+// GetVendorType returns the vendor type this handler is for
+func (o *OpenBMCRedfish) GetVendorType() VendorType {
+	return VendorOpenBMC
+}
+
+// DiscoverSerialConsole discovers the obmc-console and KVM WebSockets of
+// OpenBMC BMCs. This is the VendorHandler interface implementation.
+func (o *OpenBMCRedfish) DiscoverSerialConsole(ctx context.Context, endpoint, username, password, token string) (*SerialConsoleInfo, error) {
+	manager, err := o.Client.getFirstManager(ctx, endpoint, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manager: %w", err)
+	}
+	return openBMCConsoleInfo(manager), nil
+}
+
+// openBMCConsoleInfo derives the consoles of an OpenBMC BMC from its
+// manager. The manager's SerialConsole reports obmc-console, which is
+// enabled unless it says otherwise; the KVM is optional in OpenBMC builds
+// and is only used when the manager reports it.
+func openBMCConsoleInfo(manager *Manager) *SerialConsoleInfo {
+	enabled := manager.SerialConsole.ServiceEnabled == nil || *manager.SerialConsole.ServiceEnabled
+	info := &SerialConsoleInfo{
+		Vendor:    VendorOpenBMC,
+		Supported: enabled,
+		Enabled:   enabled,
+	}
+	if enabled {
+		info.SerialPath = OpenBMCConsolePath
+	}
+	if manager.GraphicalConsole.ServiceEnabled && slices.Contains(manager.GraphicalConsole.ConnectTypesSupported, "KVMIP") {
+		info.KVMPath = OpenBMCKVMPath
+	}
+
+	log.Debug().
+		Str("manager", manager.ID).
+		Bool("console", info.Supported).
+		Bool("kvm", info.KVMPath != "").
+		Msg("OpenBMC console discovery completed")
+	return info
+}
+
+// OpenBMCConsoleInfo returns the consoles OpenBMC BMCs serve by default,
+// for hosts declared as OpenBMC whose BMC cannot be queried
+func OpenBMCConsoleInfo() *SerialConsoleInfo {
+	return &SerialConsoleInfo{
+		Vendor:     VendorOpenBMC,
+		Supported:  true,
+		Enabled:    true,
+		SerialPath: OpenBMCConsolePath,
+		KVMPath:    OpenBMCKVMPath,
+	}
+}
@@ -0,0 +1,72 @@
+package redfish
+
+import (
+	"context"
+	"testing"
+
+	"core/testing/redfishsim"
+)
+
+func TestDiscoverSerialConsole_OpenBMC(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{OpenBMC: true})
+	defer bmc.Close()
+
+	info, err := NewClient().DiscoverSerialConsole(context.Background(), bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("DiscoverSerialConsole failed: %v", err)
+	}
+	if info.Vendor != VendorOpenBMC {
+		t.Errorf("Vendor = %q, want %q", info.Vendor, VendorOpenBMC)
+	}
+	if !info.Supported || info.SerialPath != OpenBMCConsolePath || info.KVMPath != OpenBMCKVMPath {
+		t.Errorf("Unexpected console info: %+v", info)
+	}
+	if bmc.Sessions() != 0 {
+		t.Errorf("Expected the discovery session deleted, %d open", bmc.Sessions())
+	}
+}
+
+func TestDiscoverSerialConsoleAs(t *testing.T) {
+	// A BMC not detected as OpenBMC is still handled as declared
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	info, err := NewClient().DiscoverSerialConsoleAs(context.Background(), VendorOpenBMC, bmc.URL, bmc.Username(), bmc.Password())
+	if err != nil {
+		t.Fatalf("DiscoverSerialConsoleAs failed: %v", err)
+	}
+	if info.Vendor != VendorOpenBMC || info.SerialPath != OpenBMCConsolePath {
+		t.Errorf("Unexpected console info: %+v", info)
+	}
+	if info.KVMPath != "" {
+		t.Errorf("Expected no KVM without a graphical console, got %q", info.KVMPath)
+	}
+}
+
+func TestOpenBMCConsoleInfo(t *testing.T) {
+	disabled := false
+	manager := &Manager{ID: "bmc"}
+	manager.SerialConsole.ServiceEnabled = &disabled
+
+	info := openBMCConsoleInfo(manager)
+	if info.Supported || info.SerialPath != "" {
+		t.Errorf("Expected no console when its service is disabled, got %+v", info)
+	}
+}
+
+func TestBuildWebSocketURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{"https://bmc.example.com", "wss://bmc.example.com/console0"},
+		{"http://127.0.0.1:8080/", "ws://127.0.0.1:8080/console0"},
+		{"bmc.example.com", "wss://bmc.example.com/console0"},
+	}
+
+	for _, tt := range tests {
+		if result := BuildWebSocketURL(tt.endpoint, OpenBMCConsolePath); result != tt.expected {
+			t.Errorf("BuildWebSocketURL(%q) = %q; want %q", tt.endpoint, result, tt.expected)
+		}
+	}
+}
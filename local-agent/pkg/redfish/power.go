@@ -0,0 +1,192 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrMeteringNotSupported indicates that a BMC does not report the
+// requested power or energy measurement
+var ErrMeteringNotSupported = errors.New("BMC does not report this measurement")
+
+// Chassis represents the power related links of a Redfish chassis
+type Chassis struct {
+	ID    string `json:"Id"`
+	Power struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"Power"`
+	EnvironmentMetrics struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"EnvironmentMetrics"`
+}
+
+// Power represents the (deprecated but widely implemented) Redfish Power
+// resource of a chassis
+type Power struct {
+	PowerControl []struct {
+		PowerConsumedWatts *float64 `json:"PowerConsumedWatts"`
+		PowerCapacityWatts *float64 `json:"PowerCapacityWatts"`
+		PowerMetrics       struct {
+			IntervalInMin        int      `json:"IntervalInMin"`
+			MinConsumedWatts     *float64 `json:"MinConsumedWatts"`
+			MaxConsumedWatts     *float64 `json:"MaxConsumedWatts"`
+			AverageConsumedWatts *float64 `json:"AverageConsumedWatts"`
+		} `json:"PowerMetrics"`
+	} `json:"PowerControl"`
+}
+
+// EnvironmentMetrics represents the Redfish EnvironmentMetrics resource of a
+// chassis, which newer BMCs implement in place of Power
+type EnvironmentMetrics struct {
+	PowerWatts struct {
+		Reading *float64 `json:"Reading"`
+	} `json:"PowerWatts"`
+	EnergykWh struct {
+		Reading *float64 `json:"Reading"`
+	} `json:"EnergykWh"`
+}
+
+// PowerReading is the power consumption reported by a BMC. Interval
+// statistics are 0 when the BMC does not report them.
+type PowerReading struct {
+	ConsumedWatts   float64
+	AverageWatts    float64
+	MinWatts        float64
+	MaxWatts        float64
+	IntervalMinutes int
+	CapacityWatts   float64
+}
+
+// GetPowerReading retrieves the power consumption of the first chassis,
+// from its Power resource or else its EnvironmentMetrics
+func (c *Client) GetPowerReading(ctx context.Context, endpoint, username, password string) (*PowerReading, error) {
+	log.Debug().Str("endpoint", endpoint).Msg("Getting power reading")
+
+	chassis, err := c.getFirstChassis(ctx, endpoint, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis: %w", err)
+	}
+
+	if chassis.Power.ODataID != "" {
+		var power Power
+		if err := c.getJSON(ctx, BuildRedfishURL(endpoint, chassis.Power.ODataID), username, password, &power); err != nil {
+			return nil, fmt.Errorf("failed to get power: %w", err)
+		}
+		if len(power.PowerControl) > 0 && power.PowerControl[0].PowerConsumedWatts != nil {
+			control := power.PowerControl[0]
+			return &PowerReading{
+				ConsumedWatts:   *control.PowerConsumedWatts,
+				AverageWatts:    valueOrZero(control.PowerMetrics.AverageConsumedWatts),
+				MinWatts:        valueOrZero(control.PowerMetrics.MinConsumedWatts),
+				MaxWatts:        valueOrZero(control.PowerMetrics.MaxConsumedWatts),
+				IntervalMinutes: control.PowerMetrics.IntervalInMin,
+				CapacityWatts:   valueOrZero(control.PowerCapacityWatts),
+			}, nil
+		}
+	}
+
+	metrics, err := c.getEnvironmentMetrics(ctx, endpoint, username, password, chassis)
+	if err != nil {
+		return nil, err
+	}
+	if metrics.PowerWatts.Reading == nil {
+		return nil, ErrMeteringNotSupported
+	}
+	return &PowerReading{ConsumedWatts: *metrics.PowerWatts.Reading}, nil
+}
+
+// GetEnergyUsage retrieves the cumulative energy consumed by the first
+// chassis, in kWh, from its EnvironmentMetrics
+func (c *Client) GetEnergyUsage(ctx context.Context, endpoint, username, password string) (float64, error) {
+	log.Debug().Str("endpoint", endpoint).Msg("Getting energy usage")
+
+	chassis, err := c.getFirstChassis(ctx, endpoint, username, password)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get chassis: %w", err)
+	}
+
+	metrics, err := c.getEnvironmentMetrics(ctx, endpoint, username, password, chassis)
+	if err != nil {
+		return 0, err
+	}
+	if metrics.EnergykWh.Reading == nil {
+		return 0, ErrMeteringNotSupported
+	}
+	return *metrics.EnergykWh.Reading, nil
+}
+
+// getFirstChassis retrieves the first chassis of the BMC
+func (c *Client) getFirstChassis(ctx context.Context, endpoint, username, password string) (*Chassis, error) {
+	var chassisCollection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.getJSON(ctx, BuildChassisURL(endpoint), username, password, &chassisCollection); err != nil {
+		return nil, err
+	}
+
+	if len(chassisCollection.Members) == 0 {
+		return nil, fmt.Errorf("no chassis found")
+	}
+
+	var chassis Chassis
+	if err := c.getJSON(ctx, BuildRedfishURL(endpoint, chassisCollection.Members[0].ODataID), username, password, &chassis); err != nil {
+		return nil, err
+	}
+	return &chassis, nil
+}
+
+// getEnvironmentMetrics retrieves the EnvironmentMetrics of a chassis
+func (c *Client) getEnvironmentMetrics(ctx context.Context, endpoint, username, password string, chassis *Chassis) (*EnvironmentMetrics, error) {
+	if chassis.EnvironmentMetrics.ODataID == "" {
+		return nil, ErrMeteringNotSupported
+	}
+
+	var metrics EnvironmentMetrics
+	if err := c.getJSON(ctx, BuildRedfishURL(endpoint, chassis.EnvironmentMetrics.ODataID), username, password, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to get environment metrics: %w", err)
+	}
+	return &metrics, nil
+}
+
+// getJSON performs a GET request with basic authentication and decodes the
+// JSON response into target
+func (c *Client) getJSON(ctx context.Context, url, username, password string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return NewHTTPError(resp.StatusCode, resp.Status, "GET "+url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func valueOrZero(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
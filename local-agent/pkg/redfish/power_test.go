@@ -0,0 +1,114 @@
+package redfish
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newMeteringServer serves a chassis with the given resources, keyed by path
+func newMeteringServer(t *testing.T, chassis string, resources map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/redfish/v1/Chassis":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Chassis/1"}]}`))
+		case "/redfish/v1/Chassis/1":
+			w.Write([]byte(chassis))
+		default:
+			body, ok := resources[r.URL.Path]
+			if !ok {
+				t.Errorf("Unexpected path: %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		}
+	}))
+}
+
+func TestGetPowerReading(t *testing.T) {
+	server := newMeteringServer(t,
+		`{"Id": "1", "Power": {"@odata.id": "/redfish/v1/Chassis/1/Power"}}`,
+		map[string]string{
+			"/redfish/v1/Chassis/1/Power": `{"PowerControl": [{
+				"PowerConsumedWatts": 344,
+				"PowerCapacityWatts": 800,
+				"PowerMetrics": {"IntervalInMin": 30, "MinConsumedWatts": 271, "MaxConsumedWatts": 405, "AverageConsumedWatts": 319}
+			}]}`,
+		})
+	defer server.Close()
+
+	client := NewClient()
+	reading, err := client.GetPowerReading(context.Background(), server.URL, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetPowerReading failed: %v", err)
+	}
+	expected := PowerReading{
+		ConsumedWatts:   344,
+		AverageWatts:    319,
+		MinWatts:        271,
+		MaxWatts:        405,
+		IntervalMinutes: 30,
+		CapacityWatts:   800,
+	}
+	if *reading != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *reading)
+	}
+}
+
+func TestGetPowerReading_EnvironmentMetrics(t *testing.T) {
+	server := newMeteringServer(t,
+		`{"Id": "1", "EnvironmentMetrics": {"@odata.id": "/redfish/v1/Chassis/1/EnvironmentMetrics"}}`,
+		map[string]string{
+			"/redfish/v1/Chassis/1/EnvironmentMetrics": `{"PowerWatts": {"Reading": 212.5}, "EnergykWh": {"Reading": 1520.25}}`,
+		})
+	defer server.Close()
+
+	client := NewClient()
+	reading, err := client.GetPowerReading(context.Background(), server.URL, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetPowerReading failed: %v", err)
+	}
+	if reading.ConsumedWatts != 212.5 {
+		t.Errorf("Expected 212.5 W, got %v", reading.ConsumedWatts)
+	}
+
+	energy, err := client.GetEnergyUsage(context.Background(), server.URL, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetEnergyUsage failed: %v", err)
+	}
+	if energy != 1520.25 {
+		t.Errorf("Expected 1520.25 kWh, got %v", energy)
+	}
+}
+
+func TestGetEnergyUsage_NotSupported(t *testing.T) {
+	server := newMeteringServer(t,
+		`{"Id": "1", "Power": {"@odata.id": "/redfish/v1/Chassis/1/Power"}}`,
+		nil)
+	defer server.Close()
+
+	client := NewClient()
+	_, err := client.GetEnergyUsage(context.Background(), server.URL, "user", "pass")
+	if !errors.Is(err, ErrMeteringNotSupported) {
+		t.Errorf("Expected ErrMeteringNotSupported, got %v", err)
+	}
+}
+
+func TestGetPowerReading_Unauthorized(t *testing.T) {
+	server := newMeteringServer(t, `{}`, nil)
+	defer server.Close()
+
+	client := NewClient()
+	_, err := client.GetPowerReading(context.Background(), server.URL, "user", "wrong")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected HTTP 401 error, got %v", err)
+	}
+}
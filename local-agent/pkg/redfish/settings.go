@@ -0,0 +1,160 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Roles of the accounts of a Redfish AccountService
+const (
+	RoleAdministrator = "Administrator"
+	RoleOperator      = "Operator"
+	RoleReadOnly      = "ReadOnly"
+)
+
+// Account is an account of the AccountService of a BMC
+type Account struct {
+	ODataID  string `json:"@odata.id"`
+	ID       string `json:"Id"`
+	UserName string `json:"UserName"`
+	RoleID   string `json:"RoleId"`
+	Enabled  bool   `json:"Enabled"`
+}
+
+// GetSerialConsoleEnabled reports whether the serial console service of the
+// first Manager (BMC) is enabled
+func (c *Client) GetSerialConsoleEnabled(ctx context.Context, endpoint, username, password string) (bool, error) {
+	log.Debug().Str("endpoint", endpoint).Msg("Getting Manager SerialConsole")
+
+	manager, _, err := c.getFirstManagerWithURL(ctx, endpoint, username, password)
+	if err != nil {
+		return false, err
+	}
+	if manager.SerialConsole.ServiceEnabled == nil {
+		return false, fmt.Errorf("manager %s has no SerialConsole service", manager.ID)
+	}
+	return *manager.SerialConsole.ServiceEnabled, nil
+}
+
+// SetSerialConsoleEnabled enables or disables the serial console service of
+// the first Manager (BMC)
+func (c *Client) SetSerialConsoleEnabled(ctx context.Context, endpoint, username, password string, enabled bool) error {
+	log.Debug().Str("endpoint", endpoint).Bool("enabled", enabled).Msg("Setting Manager SerialConsole")
+
+	_, managerURL, err := c.getFirstManagerWithURL(ctx, endpoint, username, password)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"SerialConsole": map[string]interface{}{"ServiceEnabled": enabled},
+	}
+	if err := c.patchJSON(ctx, managerURL, username, password, payload, "set serial console"); err != nil {
+		return err
+	}
+
+	log.Info().Str("endpoint", endpoint).Bool("enabled", enabled).Msg("Serial console service set")
+	return nil
+}
+
+// GetAccount retrieves the account of the AccountService named user
+func (c *Client) GetAccount(ctx context.Context, endpoint, username, password, user string) (*Account, error) {
+	log.Debug().Str("endpoint", endpoint).Str("user", user).Msg("Getting account")
+
+	var accountsCollection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.getJSON(ctx, BuildAccountsURL(endpoint), username, password, &accountsCollection); err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	for _, member := range accountsCollection.Members {
+		var account Account
+		if err := c.getJSON(ctx, BuildRedfishURL(endpoint, member.ODataID), username, password, &account); err != nil {
+			return nil, fmt.Errorf("failed to get account %s: %w", member.ODataID, err)
+		}
+		if account.UserName == user {
+			if account.ODataID == "" {
+				account.ODataID = member.ODataID
+			}
+			return &account, nil
+		}
+	}
+	return nil, fmt.Errorf("no account %s", user)
+}
+
+// SetAccountRole sets the role of the account of the AccountService named
+// user to one of the Role values
+func (c *Client) SetAccountRole(ctx context.Context, endpoint, username, password, user, role string) error {
+	account, err := c.GetAccount(ctx, endpoint, username, password, user)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{"RoleId": role}
+	if err := c.patchJSON(ctx, BuildRedfishURL(endpoint, account.ODataID), username, password, payload, "set account role"); err != nil {
+		return err
+	}
+
+	log.Info().Str("endpoint", endpoint).Str("user", user).Str("role", role).Msg("Account role set")
+	return nil
+}
+
+// getFirstManagerWithURL retrieves the first Manager (BMC) and its URL
+func (c *Client) getFirstManagerWithURL(ctx context.Context, endpoint, username, password string) (*Manager, string, error) {
+	var managersCollection struct {
+		Members []struct {
+			ODataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.getJSON(ctx, BuildManagersURL(endpoint), username, password, &managersCollection); err != nil {
+		return nil, "", err
+	}
+	if len(managersCollection.Members) == 0 {
+		return nil, "", fmt.Errorf("no managers found")
+	}
+
+	managerURL := BuildRedfishURL(endpoint, managersCollection.Members[0].ODataID)
+	var manager Manager
+	if err := c.getJSON(ctx, managerURL, username, password, &manager); err != nil {
+		return nil, "", err
+	}
+	return &manager, managerURL, nil
+}
+
+// patchJSON PATCHes payload to url, operation naming the request in errors
+func (c *Client) patchJSON(ctx context.Context, url, username, password string, payload interface{}, operation string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s payload: %w", operation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return NewHTTPError(resp.StatusCode, resp.Status, operation)
+	}
+	return nil
+}
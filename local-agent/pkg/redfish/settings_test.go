@@ -0,0 +1,93 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newSettingsServer serves a manager and two accounts, recording the PATCH
+// requests by path
+func newSettingsServer(t *testing.T, patches map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == "PATCH" {
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Invalid PATCH payload: %v", err)
+			}
+			patches[r.URL.Path] = payload
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/redfish/v1/Managers":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/bmc"}]}`))
+		case "/redfish/v1/Managers/bmc":
+			w.Write([]byte(`{"Id": "bmc", "SerialConsole": {"ServiceEnabled": false, "MaxConcurrentSessions": 1}}`))
+		case "/redfish/v1/AccountService/Accounts":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/AccountService/Accounts/1"}, {"@odata.id": "/redfish/v1/AccountService/Accounts/2"}]}`))
+		case "/redfish/v1/AccountService/Accounts/1":
+			w.Write([]byte(`{"Id": "1", "UserName": "root", "RoleId": "Administrator", "Enabled": true}`))
+		case "/redfish/v1/AccountService/Accounts/2":
+			w.Write([]byte(`{"Id": "2", "UserName": "user", "RoleId": "ReadOnly", "Enabled": true}`))
+		default:
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSerialConsoleEnabled(t *testing.T) {
+	patches := make(map[string]map[string]interface{})
+	server := newSettingsServer(t, patches)
+	defer server.Close()
+
+	client := NewClient()
+	enabled, err := client.GetSerialConsoleEnabled(context.Background(), server.URL, "user", "pass")
+	if err != nil {
+		t.Fatalf("GetSerialConsoleEnabled failed: %v", err)
+	}
+	if enabled {
+		t.Error("Expected the serial console to be disabled")
+	}
+
+	if err := client.SetSerialConsoleEnabled(context.Background(), server.URL, "user", "pass", true); err != nil {
+		t.Fatalf("SetSerialConsoleEnabled failed: %v", err)
+	}
+	serialConsole, _ := patches["/redfish/v1/Managers/bmc"]["SerialConsole"].(map[string]interface{})
+	if serialConsole["ServiceEnabled"] != true {
+		t.Errorf("Expected the serial console to be enabled, got PATCH %v", patches)
+	}
+}
+
+func TestAccountRole(t *testing.T) {
+	patches := make(map[string]map[string]interface{})
+	server := newSettingsServer(t, patches)
+	defer server.Close()
+
+	client := NewClient()
+	account, err := client.GetAccount(context.Background(), server.URL, "user", "pass", "user")
+	if err != nil {
+		t.Fatalf("GetAccount failed: %v", err)
+	}
+	if account.RoleID != RoleReadOnly || account.ODataID != "/redfish/v1/AccountService/Accounts/2" {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	if _, err := client.GetAccount(context.Background(), server.URL, "user", "pass", "admin"); err == nil {
+		t.Error("Expected an error for a missing account")
+	}
+
+	if err := client.SetAccountRole(context.Background(), server.URL, "user", "pass", "user", RoleOperator); err != nil {
+		t.Fatalf("SetAccountRole failed: %v", err)
+	}
+	if role := patches["/redfish/v1/AccountService/Accounts/2"]["RoleId"]; role != RoleOperator {
+		t.Errorf("Expected the Operator role to be set, got PATCH %v", patches)
+	}
+}
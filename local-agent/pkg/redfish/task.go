@@ -0,0 +1,268 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TaskState is the state of a Redfish task
+type TaskState string
+
+// Redfish task states. Completed, Exception, Killed and Cancelled are final.
+const (
+	TaskStateNew         TaskState = "New"
+	TaskStateStarting    TaskState = "Starting"
+	TaskStateRunning     TaskState = "Running"
+	TaskStateSuspended   TaskState = "Suspended"
+	TaskStateInterrupted TaskState = "Interrupted"
+	TaskStatePending     TaskState = "Pending"
+	TaskStateStopping    TaskState = "Stopping"
+	TaskStateCompleted   TaskState = "Completed"
+	TaskStateKilled      TaskState = "Killed"
+	TaskStateException   TaskState = "Exception"
+	TaskStateService     TaskState = "Service"
+	TaskStateCancelling  TaskState = "Cancelling"
+	TaskStateCancelled   TaskState = "Cancelled"
+)
+
+// defaultTaskPollInterval is the time between two polls of a task monitor
+// when the BMC does not send Retry-After
+const defaultTaskPollInterval = 2 * time.Second
+
+// maxTaskPollInterval bounds the Retry-After delays requested by BMCs
+const maxTaskPollInterval = 30 * time.Second
+
+// Task represents a Redfish task, returned by task monitors while a
+// long-running operation runs
+type Task struct {
+	ID              string    `json:"Id"`
+	Name            string    `json:"Name"`
+	TaskState       TaskState `json:"TaskState"`
+	TaskStatus      string    `json:"TaskStatus"` // OK, Warning or Critical
+	PercentComplete *int      `json:"PercentComplete"`
+	Messages        []struct {
+		MessageID string `json:"MessageId"`
+		Message   string `json:"Message"`
+	} `json:"Messages"`
+}
+
+// Done reports whether the task reached a final state
+func (t *Task) Done() bool {
+	switch t.TaskState {
+	case TaskStateCompleted, TaskStateException, TaskStateKilled, TaskStateCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Succeeded reports whether the task completed without a critical status
+func (t *Task) Succeeded() bool {
+	return t.TaskState == TaskStateCompleted && t.TaskStatus != "Critical"
+}
+
+// lastMessage returns the most recent message of the task, if any
+func (t *Task) lastMessage() string {
+	if len(t.Messages) == 0 {
+		return ""
+	}
+	return t.Messages[len(t.Messages)-1].Message
+}
+
+// TaskProgress is the progress of a task reported to a TaskProgressFunc
+type TaskProgress struct {
+	TaskID          string
+	State           TaskState
+	PercentComplete int // -1 when the BMC does not report it
+	Message         string
+}
+
+// TaskProgressFunc is called each time the progress of a task changes
+type TaskProgressFunc func(TaskProgress)
+
+// TaskError reports a task that ended in a state other than Completed, or
+// completed with a critical status
+type TaskError struct {
+	TaskID     string
+	State      TaskState
+	TaskStatus string
+	Message    string
+}
+
+func (e *TaskError) Error() string {
+	msg := fmt.Sprintf("task %s ended in state %s", e.TaskID, e.State)
+	if e.TaskStatus != "" {
+		msg += fmt.Sprintf(" (%s)", e.TaskStatus)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// WaitForTask polls the task monitor of a long-running operation until the
+// operation completes, calling onProgress (if not nil) when its progress
+// changes. It returns a TaskError if the task fails.
+//
+// A task monitor answers 202 Accepted while the operation runs and the
+// response of the operation once complete. Some BMCs (iDRAC) return the
+// task resource instead, which answers 200 OK with the task state.
+func (c *Client) WaitForTask(ctx context.Context, endpoint, username, password, monitor string, onProgress TaskProgressFunc) (*Task, error) {
+	monitorURL, err := resolveTaskMonitor(endpoint, monitor)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("monitor", monitorURL).Msg("Waiting for Redfish task")
+
+	var last TaskProgress
+	reported := false
+	for {
+		task, running, retryAfter, err := c.pollTaskMonitor(ctx, monitorURL, username, password)
+		if err != nil {
+			return nil, err
+		}
+
+		if task != nil {
+			if task.ID == "" {
+				task.ID = last.TaskID
+			}
+			progress := TaskProgress{
+				TaskID:          task.ID,
+				State:           task.TaskState,
+				PercentComplete: -1,
+				Message:         task.lastMessage(),
+			}
+			if task.PercentComplete != nil {
+				progress.PercentComplete = *task.PercentComplete
+			}
+			if onProgress != nil && (!reported || progress != last) {
+				onProgress(progress)
+			}
+			last, reported = progress, true
+
+			if task.Done() {
+				if !task.Succeeded() {
+					return task, &TaskError{TaskID: task.ID, State: task.TaskState, TaskStatus: task.TaskStatus, Message: progress.Message}
+				}
+				log.Debug().Str("task_id", task.ID).Msg("Redfish task completed")
+				return task, nil
+			}
+		} else if !running {
+			// The monitor returned the response of the completed operation
+			task = &Task{ID: last.TaskID, TaskState: TaskStateCompleted}
+			if onProgress != nil {
+				onProgress(TaskProgress{TaskID: task.ID, State: TaskStateCompleted, PercentComplete: 100})
+			}
+			log.Debug().Str("task_id", task.ID).Msg("Redfish task completed")
+			return task, nil
+		}
+
+		interval := c.taskPollInterval
+		if retryAfter > 0 {
+			interval = min(retryAfter, maxTaskPollInterval)
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pollTaskMonitor gets a task monitor once. It returns the task if the
+// response is one, whether the operation is still running, and the delay
+// requested by Retry-After.
+func (c *Client) pollTaskMonitor(ctx context.Context, monitorURL, username, password string) (*Task, bool, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", monitorURL, nil)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, false, 0, NewHTTPError(resp.StatusCode, resp.Status, "get task monitor")
+	}
+	running := resp.StatusCode == http.StatusAccepted
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, 0, fmt.Errorf("failed to read task monitor response: %w", err)
+	}
+	var task Task
+	if len(body) == 0 || json.Unmarshal(body, &task) != nil || task.TaskState == "" {
+		// Not a task: an empty 202, or the response of the operation
+		return nil, running, retryAfter, nil
+	}
+	return &task, running, retryAfter, nil
+}
+
+// resolveTaskMonitor resolves the Location of a task monitor, a path or an
+// absolute URL, against the BMC endpoint. Monitors are polled with the BMC
+// credentials, so an absolute URL must have the origin of the endpoint.
+func resolveTaskMonitor(endpoint, monitor string) (string, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+	ref, err := url.Parse(monitor)
+	if err != nil {
+		return "", fmt.Errorf("invalid task monitor %q: %w", monitor, err)
+	}
+	resolved := base.ResolveReference(ref)
+	if !sameOrigin(base, resolved) {
+		return "", fmt.Errorf("task monitor %q is not on the BMC endpoint %s", monitor, endpoint)
+	}
+	return resolved.String(), nil
+}
+
+// sameOrigin reports whether two URLs have the same scheme, host and port,
+// the port defaulting to that of the scheme
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Hostname(), b.Hostname()) &&
+		originPort(a) == originPort(b)
+}
+
+// originPort returns the port of a URL, or the default port of its scheme
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
+
+// parseRetryAfter parses a Retry-After header in seconds. HTTP dates are
+// ignored, BMCs send seconds.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,217 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// taskServer is a BMC running reset actions as tasks. Each poll of the task
+// monitor returns the next response, the last one is repeated.
+type taskServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses []taskResponse
+	polls     int
+	resetType string
+}
+
+type taskResponse struct {
+	status int
+	body   string
+}
+
+func newTaskServer(t *testing.T, monitor string, responses ...taskResponse) *taskServer {
+	s := &taskServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redfish/v1/":
+			w.Write([]byte(`{"Systems": {"@odata.id": "/redfish/v1/Systems"}}`))
+		case "/redfish/v1/Systems":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`))
+		case "/redfish/v1/Systems/1":
+			w.Write([]byte(`{"Actions": {"#ComputerSystem.Reset": {"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}}}`))
+		case "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset":
+			var payload struct{ ResetType string }
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("Invalid reset payload: %v", err)
+			}
+			s.mu.Lock()
+			s.resetType = payload.ResetType
+			s.mu.Unlock()
+			w.Header().Set("Location", monitor)
+			w.WriteHeader(http.StatusAccepted)
+		case "/redfish/v1/TaskService/TaskMonitors/1", "/redfish/v1/TaskService/Tasks/JID_1":
+			s.mu.Lock()
+			response := s.responses[min(s.polls, len(s.responses)-1)]
+			s.polls++
+			s.mu.Unlock()
+			w.WriteHeader(response.status)
+			w.Write([]byte(response.body))
+		default:
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return s
+}
+
+func newTaskClient() *Client {
+	client := NewClient()
+	client.taskPollInterval = time.Millisecond
+	return client
+}
+
+func runningTask(percent int, message string) taskResponse {
+	return taskResponse{http.StatusAccepted, fmt.Sprintf(
+		`{"Id": "1", "TaskState": "Running", "TaskStatus": "OK", "PercentComplete": %d, "Messages": [{"Message": %q}]}`,
+		percent, message)}
+}
+
+func TestRunPowerAction_TaskMonitor(t *testing.T) {
+	server := newTaskServer(t, "/redfish/v1/TaskService/TaskMonitors/1",
+		runningTask(10, "Powering off"),
+		runningTask(10, "Powering off"),
+		runningTask(60, "Powering on"),
+		taskResponse{http.StatusNoContent, ""},
+	)
+	defer server.Close()
+
+	var progress []TaskProgress
+	err := newTaskClient().RunPowerAction(context.Background(), server.URL, "user", "pass", ResetTypePowerCycle, func(p TaskProgress) {
+		progress = append(progress, p)
+	})
+	if err != nil {
+		t.Fatalf("RunPowerAction failed: %v", err)
+	}
+	if server.resetType != ResetTypePowerCycle {
+		t.Errorf("Expected a PowerCycle reset, got %q", server.resetType)
+	}
+
+	// Unchanged progress is reported once
+	expected := []TaskProgress{
+		{TaskID: "1", State: TaskStateRunning, PercentComplete: 10, Message: "Powering off"},
+		{TaskID: "1", State: TaskStateRunning, PercentComplete: 60, Message: "Powering on"},
+		{TaskID: "1", State: TaskStateCompleted, PercentComplete: 100},
+	}
+	if len(progress) != len(expected) {
+		t.Fatalf("Expected %d progress reports, got %+v", len(expected), progress)
+	}
+	for i := range expected {
+		if progress[i] != expected[i] {
+			t.Errorf("Progress %d: expected %+v, got %+v", i, expected[i], progress[i])
+		}
+	}
+}
+
+func TestRunPowerAction_TaskResource(t *testing.T) {
+	// iDRAC returns the task itself, which answers 200 OK with its state
+	server := newTaskServer(t, "/redfish/v1/TaskService/Tasks/JID_1",
+		taskResponse{http.StatusOK, `{"Id": "JID_1", "TaskState": "New"}`},
+		taskResponse{http.StatusOK, `{"Id": "JID_1", "TaskState": "Completed", "TaskStatus": "OK", "PercentComplete": 100}`},
+	)
+	defer server.Close()
+
+	var last TaskProgress
+	err := newTaskClient().RunPowerAction(context.Background(), server.URL, "user", "pass", ResetTypeForceRestart, func(p TaskProgress) {
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("RunPowerAction failed: %v", err)
+	}
+	if last.TaskID != "JID_1" || last.State != TaskStateCompleted || last.PercentComplete != 100 {
+		t.Errorf("Unexpected last progress %+v", last)
+	}
+}
+
+func TestRunPowerAction_TaskFailed(t *testing.T) {
+	server := newTaskServer(t, "/redfish/v1/TaskService/TaskMonitors/1",
+		runningTask(0, "Starting"),
+		taskResponse{http.StatusOK, `{"Id": "1", "TaskState": "Exception", "TaskStatus": "Critical", "Messages": [{"Message": "Unable to power on: PSU failure"}]}`},
+	)
+	defer server.Close()
+
+	err := newTaskClient().RunPowerAction(context.Background(), server.URL, "user", "pass", ResetTypeOn, nil)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("Expected a TaskError, got %v", err)
+	}
+	if taskErr.State != TaskStateException || taskErr.Message != "Unable to power on: PSU failure" {
+		t.Errorf("Unexpected task error %+v", taskErr)
+	}
+}
+
+func TestWaitForTask_ContextCancelled(t *testing.T) {
+	server := newTaskServer(t, "/redfish/v1/TaskService/TaskMonitors/1", runningTask(5, "Updating"))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := newTaskClient().WaitForTask(ctx, server.URL, "user", "pass", "/redfish/v1/TaskService/TaskMonitors/1", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context deadline, got %v", err)
+	}
+}
+
+func TestWaitForTask_CrossHostMonitor(t *testing.T) {
+	// A monitor on another host must not receive the BMC credentials
+	var credentialsLeaked bool
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			credentialsLeaked = true
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer other.Close()
+
+	server := newTaskServer(t, other.URL+"/redfish/v1/TaskService/Tasks/JID_1", runningTask(5, "Updating"))
+	defer server.Close()
+
+	err := newTaskClient().RunPowerAction(context.Background(), server.URL, "user", "pass", ResetTypeOn, nil)
+	if err == nil {
+		t.Fatal("Expected a cross-host task monitor to be refused")
+	}
+	if credentialsLeaked {
+		t.Error("Expected no credentials to be sent to the other host")
+	}
+}
+
+func TestResolveTaskMonitor(t *testing.T) {
+	tests := map[string]string{
+		"/redfish/v1/TaskService/TaskMonitors/1":                  "https://10.0.0.1/redfish/v1/TaskService/TaskMonitors/1",
+		"https://10.0.0.1:443/redfish/v1/TaskService/Tasks/JID_1": "https://10.0.0.1:443/redfish/v1/TaskService/Tasks/JID_1",
+		"redfish/v1/TaskService/TaskMonitors/2":                   "https://10.0.0.1/redfish/v1/TaskService/TaskMonitors/2",
+	}
+	for monitor, expected := range tests {
+		got, err := resolveTaskMonitor("https://10.0.0.1", monitor)
+		if err != nil || got != expected {
+			t.Errorf("resolveTaskMonitor(%q) = %q, %v, expected %q", monitor, got, err, expected)
+		}
+	}
+
+	// The monitor is polled with the BMC credentials, other origins are refused
+	for _, monitor := range []string{
+		"https://attacker.example.com/redfish/v1/TaskService/Tasks/JID_1",
+		"http://10.0.0.1/redfish/v1/TaskService/Tasks/JID_1",
+		"https://10.0.0.1:8443/redfish/v1/TaskService/Tasks/JID_1",
+		"//10.0.0.2/redfish/v1/TaskService/Tasks/JID_1",
+	} {
+		if got, err := resolveTaskMonitor("https://10.0.0.1", monitor); err == nil {
+			t.Errorf("resolveTaskMonitor(%q) = %q, expected an error", monitor, got)
+		}
+	}
+
+	if got := parseRetryAfter("5"); got != 5*time.Second {
+		t.Errorf("Expected 5s, got %s", got)
+	}
+	if got := parseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"); got != 0 {
+		t.Errorf("Expected HTTP dates to be ignored, got %s", got)
+	}
+}
@@ -1,5 +1,7 @@
 package redfish
 
+import "encoding/json"
+
 // PowerState represents the power state of a server
 type PowerState string
 
@@ -25,6 +27,7 @@ type ServiceRoot struct {
 
 // ComputerSystem represents a Redfish computer system
 type ComputerSystem struct {
+	ODataID       string     `json:"@odata.id"`
 	ID            string     `json:"Id"`
 	Name          string     `json:"Name"`
 	Manufacturer  string     `json:"Manufacturer"`
@@ -51,7 +54,11 @@ type ComputerSystem struct {
 		OemLastState string `json:"OemLastState"`
 	} `json:"BootProgress"`
 	PostState string `json:"PostState"`
-	Oem       struct {
+	// IndicatorLED is deprecated in favour of LocationIndicatorActive, which
+	// newer BMCs implement instead
+	IndicatorLED            string `json:"IndicatorLED"`
+	LocationIndicatorActive *bool  `json:"LocationIndicatorActive"`
+	Oem                     struct {
 		Dell struct {
 			DellSystem struct {
 				CPURollupStatus          string `json:"CPURollupStatus"`
@@ -98,6 +105,18 @@ type Manager struct {
 	NetworkProtocol struct {
 		ODataID string `json:"@odata.id"`
 	} `json:"NetworkProtocol"`
+	EthernetInterfaces struct {
+		ODataID string `json:"@odata.id"`
+	} `json:"EthernetInterfaces"`
+	SerialConsole struct {
+		ServiceEnabled *bool `json:"ServiceEnabled"`
+	} `json:"SerialConsole"`
+	GraphicalConsole struct {
+		ServiceEnabled        bool     `json:"ServiceEnabled"`
+		ConnectTypesSupported []string `json:"ConnectTypesSupported"`
+	} `json:"GraphicalConsole"`
+	// Oem holds the vendor extensions by vendor, e.g. OpenBmc
+	Oem map[string]json.RawMessage `json:"Oem"`
 }
 
 // NetworkProtocol represents Redfish network protocol information
@@ -147,5 +166,6 @@ type SerialConsoleInfo struct {
 	Vendor         VendorType
 	FallbackToIPMI bool
 	SerialPath     string // Specific path for vendor, e.g., /Managers/iDRAC.Embedded.1/SerialInterfaces/Serial.1
+	KVMPath        string // WebSocket path of the graphical console (RFB), for vendors serving one
 	// Add more fields as needed, e.g., ServiceEnabled, MaxConcurrentSessions
 }
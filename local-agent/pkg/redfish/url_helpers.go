@@ -29,6 +29,11 @@ func BuildSessionsURL(endpoint string) string {
 	return BuildRedfishURL(endpoint, "/redfish/v1/SessionService/Sessions")
 }
 
+// BuildAccountsURL returns the Accounts collection URL of the AccountService
+func BuildAccountsURL(endpoint string) string {
+	return BuildRedfishURL(endpoint, "/redfish/v1/AccountService/Accounts")
+}
+
 // BuildSystemsURL returns the Systems collection URL
 func BuildSystemsURL(endpoint string) string {
 	return BuildRedfishURL(endpoint, "/redfish/v1/Systems")
@@ -38,3 +43,17 @@ func BuildSystemsURL(endpoint string) string {
 func BuildChassisURL(endpoint string) string {
 	return BuildRedfishURL(endpoint, "/redfish/v1/Chassis")
 }
+
+// BuildWebSocketURL returns the WebSocket URL of a path of the BMC, served
+// over TLS unless the endpoint is plain HTTP
+func BuildWebSocketURL(endpoint, path string) string {
+	switch {
+	case strings.HasPrefix(endpoint, "https://"):
+		endpoint = "wss://" + strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "http://"):
+		endpoint = "ws://" + strings.TrimPrefix(endpoint, "http://")
+	default:
+		endpoint = "wss://" + endpoint
+	}
+	return BuildRedfishURL(endpoint, path)
+}
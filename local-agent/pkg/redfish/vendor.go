@@ -6,4 +6,5 @@ type VendorType string
 const (
 	VendorGeneric VendorType = "generic"
 	VendorIDRAC   VendorType = "idrac"
+	VendorOpenBMC VendorType = "openbmc"
 )
@@ -5,7 +5,7 @@ import (
 )
 
 // VendorHandler defines the interface for vendor-specific Redfish operations.
-// Different BMC vendors (Dell iDRAC, HPE iLO, Supermicro, etc.) may have
+// Different BMC vendors (Dell iDRAC, OpenBMC, HPE iLO, Supermicro, etc.) may have
 // vendor-specific implementations or extensions to the Redfish standard.
 type VendorHandler interface {
 	// DiscoverSerialConsole checks if serial console is supported and how to access it.
@@ -21,6 +21,8 @@ func NewVendorHandler(vendorType VendorType, client *Client) VendorHandler {
 	switch vendorType {
 	case VendorIDRAC:
 		return &IDRACRedfish{Client: client}
+	case VendorOpenBMC:
+		return &OpenBMCRedfish{Client: client}
 	case VendorGeneric:
 		return &GenericRedfish{Client: client}
 	default:
@@ -1,6 +1,7 @@
 package redfish
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -34,6 +35,15 @@ func TestDetectVendorFromManager(t *testing.T) {
 			},
 			expected: VendorIDRAC,
 		},
+		{
+			name: "OpenBMC by Oem",
+			manager: &Manager{
+				ID:           "bmc",
+				Manufacturer: "Acme",
+				Oem:          map[string]json.RawMessage{"OpenBmc": json.RawMessage(`{}`)},
+			},
+			expected: VendorOpenBMC,
+		},
 		{
 			name: "Generic BMC",
 			manager: &Manager{
@@ -75,6 +85,11 @@ func TestNewVendorHandler(t *testing.T) {
 			vendorType: VendorIDRAC,
 			wantType:   VendorIDRAC,
 		},
+		{
+			name:       "OpenBMC handler",
+			vendorType: VendorOpenBMC,
+			wantType:   VendorOpenBMC,
+		},
 		{
 			name:       "Generic handler",
 			vendorType: VendorGeneric,
@@ -106,4 +121,7 @@ func TestVendorHandlerInterface(t *testing.T) {
 
 	// Verify GenericRedfish implements VendorHandler
 	var _ VendorHandler = &GenericRedfish{Client: client}
+
+	// Verify OpenBMCRedfish implements VendorHandler
+	var _ VendorHandler = &OpenBMCRedfish{Client: client}
 }
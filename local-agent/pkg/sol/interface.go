@@ -2,6 +2,7 @@ package sol
 
 import (
 	"context"
+	"errors"
 
 	"core/types"
 )
@@ -21,6 +22,39 @@ type Session interface {
 	Status() SessionStatus
 }
 
+// Resizer is implemented by sessions and transports whose console can be
+// told the size of the client terminal. Serial consoles such as IPMI SOL and
+// Redfish serial carry no size information, so their transports do not
+// implement it.
+type Resizer interface {
+	// Resize applies the client terminal size in character cells
+	Resize(ctx context.Context, cols, rows int) error
+}
+
+// ErrResizeNotSupported is returned by Resize when the transport has no
+// notion of terminal size
+var ErrResizeNotSupported = errors.New("terminal resize not supported by SOL transport")
+
+// Deactivator is implemented by clients and transports that can deactivate
+// the SOL payload of a BMC. Deactivating drops whichever session currently
+// holds the console, including sessions opened outside the agent, so that a
+// new session can be activated.
+type Deactivator interface {
+	// Deactivate deactivates the SOL payload of the BMC at endpoint
+	Deactivate(ctx context.Context, endpoint, username, password string) error
+}
+
+// ErrDeactivateNotSupported is returned by Deactivate when the transport
+// cannot deactivate the BMC's SOL payload
+var ErrDeactivateNotSupported = errors.New("SOL deactivation not supported by SOL transport")
+
+// ErrSerialSettingsNotSupported is returned by Connect when the BMC cannot
+// apply the requested baud rate or flow control
+var ErrSerialSettingsNotSupported = errors.New("serial settings not supported by BMC")
+
+// ErrAuthenticationFailed is returned when the BMC rejects the SOL credentials
+var ErrAuthenticationFailed = errors.New("BMC authentication failed")
+
 // SessionStatus represents the status of a SOL session
 type SessionStatus struct {
 	Active    bool   `json:"active"`
@@ -43,6 +77,16 @@ type Config struct {
 	FlowControl        string `json:"flow_control"`         // Flow control settings ("none", "hardware", "software")
 	TimeoutSeconds     int    `json:"timeout_seconds"`      // Session timeout in seconds
 	InsecureSkipVerify bool   `json:"insecure_skip_verify"` // Skip TLS certificate verification (for Redfish)
+
+	// ApplySerialSettings configures BaudRate and FlowControl on the BMC
+	// before connecting. Zero values leave the BMC's setting unchanged.
+	ApplySerialSettings bool `json:"apply_serial_settings"`
+
+	// Largest WebSocket message read from the BMC, 0 for no limit (Redfish)
+	MaxMessageSize int64 `json:"max_message_size"`
+	// Largest WebSocket message written to the BMC, larger input is split
+	// over several messages, 0 for no limit (Redfish)
+	MaxWriteSize int `json:"max_write_size"`
 }
 
 // DefaultSOLConfig returns a default SOL configuration
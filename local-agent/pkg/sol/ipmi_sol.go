@@ -6,14 +6,19 @@ import (
 	"io"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/creack/pty"
 	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/bmcproxy"
+	"local-agent/pkg/netpolicy"
 )
 
 // IPMISOLSession manages a Serial-over-LAN session using FreeIPMI's ipmiconsole subprocess
@@ -73,6 +78,11 @@ type backoffState struct {
 
 // NewIPMISOLSession creates a new IPMI SOL session using ipmiconsole subprocess
 func NewIPMISOLSession(ctx context.Context, endpoint, username, password string, replayBufferSize int) (*IPMISOLSession, error) {
+	ipmiconsolePath, err := lookupIPMIConsole()
+	if err != nil {
+		return nil, err
+	}
+
 	sessionCtx, cancel := context.WithCancel(ctx)
 
 	session := &IPMISOLSession{
@@ -88,21 +98,12 @@ func NewIPMISOLSession(ctx context.Context, endpoint, username, password string,
 		retryDelay:       2 * time.Second,
 		maxRetryDelay:    60 * time.Second,
 		retryMultiplier:  2.0,
-		ipmiconsoleePath: "/usr/sbin/ipmiconsole",
+		ipmiconsoleePath: ipmiconsolePath,
 		metrics: SOLMetrics{
 			uptime: time.Now(),
 		},
 	}
 
-	// Check for ipmiconsole in PATH if default doesn't exist
-	if _, err := os.Stat(session.ipmiconsoleePath); os.IsNotExist(err) {
-		if path, err := exec.LookPath("ipmiconsole"); err == nil {
-			session.ipmiconsoleePath = path
-		} else {
-			return nil, fmt.Errorf("ipmiconsole not found: install freeipmi-tools package")
-		}
-	}
-
 	// Initialize replay buffer if requested
 	if replayBufferSize > 0 {
 		session.replayBuffer = newCircularBuffer(replayBufferSize)
@@ -185,18 +186,68 @@ func (s *IPMISOLSession) calculateBackoff(backoff *backoffState) time.Duration {
 	return delay
 }
 
+// lookupIPMIConsole returns the path of the ipmiconsole binary, preferring
+// the default install location over PATH
+func lookupIPMIConsole() (string, error) {
+	const defaultPath = "/usr/sbin/ipmiconsole"
+
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath, nil
+	}
+	if path, err := exec.LookPath("ipmiconsole"); err == nil {
+		return path, nil
+	}
+	return "", fmt.Errorf("ipmiconsole not found: install freeipmi-tools package")
+}
+
+// defaultIPMIPort is the RMCP+ port of BMCs
+const defaultIPMIPort = "623"
+
+// freeIPMIHost resolves the host of an IPMI endpoint for the FreeIPMI tools,
+// refusing hosts outside the allowed networks. A port other than the default
+// one is kept as host:port, the form FreeIPMI reads ports in: their -p option
+// is the password.
+func freeIPMIHost(ctx context.Context, endpoint string) (string, error) {
+	host, err := netpolicy.ResolveEndpointHost(ctx, endpoint)
+	if err != nil {
+		return "", err
+	}
+	if port := endpointPort(endpoint); port != "" && port != defaultIPMIPort {
+		return net.JoinHostPort(host, port), nil
+	}
+	return host, nil
+}
+
+// endpointPort returns the port of an endpoint given as a URL or as
+// host[:port], empty when it has none
+func endpointPort(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			return u.Port()
+		}
+		return ""
+	}
+	if _, port, err := net.SplitHostPort(endpoint); err == nil {
+		return port
+	}
+	return ""
+}
+
 // startProcess starts the ipmiconsole subprocess
 func (s *IPMISOLSession) startProcess() error {
-	// Parse endpoint for host/port
-	host, _, err := net.SplitHostPort(s.endpoint)
+	// IPMI runs over UDP, which BMC proxies do not carry
+	if err := bmcproxy.RequireDirect(s.endpoint); err != nil {
+		return err
+	}
+
+	host, err := freeIPMIHost(s.ctx, s.endpoint)
 	if err != nil {
-		host = s.endpoint
+		return err
 	}
 
 	log.Debug().
 		Str("host", host).
 		Str("username", s.username).
-		Str("password", s.password).
 		Msg("ipmiconsole starting")
 
 	// Build ipmiconsole command
@@ -170,6 +170,30 @@ func TestIPMISOLSession_WriteRead(t *testing.T) {
 	}
 }
 
+func TestFreeIPMIHost(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"10.0.0.5", "10.0.0.5"},
+		{"10.0.0.5:623", "10.0.0.5"},
+		{"10.0.0.5:6230", "10.0.0.5:6230"},
+		{"ipmi://10.0.0.5:6230", "10.0.0.5:6230"},
+		{"[fd00::5]:6230", "[fd00::5]:6230"},
+		{"fd00::5", "fd00::5"},
+	}
+
+	for _, tt := range tests {
+		got, err := freeIPMIHost(context.Background(), tt.endpoint)
+		if err != nil {
+			t.Fatalf("freeIPMIHost(%q) failed: %v", tt.endpoint, err)
+		}
+		if got != tt.want {
+			t.Errorf("freeIPMIHost(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
 func TestIPMITransport_Lifecycle(t *testing.T) {
 	transport := NewIPMITransport()
 	if transport == nil {
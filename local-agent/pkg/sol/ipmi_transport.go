@@ -3,9 +3,13 @@ package sol
 import (
 	"context"
 	"fmt"
+	"os/exec"
+	"strings"
 	"sync"
 
 	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/bmcproxy"
 )
 
 // IPMITransport implements Transport using FreeIPMI's ipmiconsole subprocess
@@ -35,6 +39,13 @@ func (t *IPMITransport) Connect(ctx context.Context, endpoint, username, passwor
 	t.ctx = sessionCtx
 	t.cancel = cancel
 
+	if config != nil && config.ApplySerialSettings {
+		if err := applyIPMISerialSettings(ctx, endpoint, username, password, config); err != nil {
+			cancel()
+			return err
+		}
+	}
+
 	// Determine replay buffer size (default 64KB for session replay)
 	replayBufferSize := 65536
 
@@ -137,6 +148,41 @@ func (t *IPMITransport) SupportsSOL(ctx context.Context, endpoint, username, pas
 	return true, nil
 }
 
+// Deactivate deactivates the BMC's SOL payload with ipmiconsole --deactivate,
+// dropping any SOL session held by another client
+func (t *IPMITransport) Deactivate(ctx context.Context, endpoint, username, password string) error {
+	path, err := lookupIPMIConsole()
+	if err != nil {
+		return err
+	}
+
+	// IPMI runs over UDP, which BMC proxies do not carry
+	if err := bmcproxy.RequireDirect(endpoint); err != nil {
+		return err
+	}
+
+	host, err := freeIPMIHost(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, path,
+		"-h", host,
+		"-u", username,
+		"-p", password,
+		"--deactivate",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ipmiconsole --deactivate failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	log.Info().
+		Str("host", host).
+		Msg("IPMI SOL payload deactivated")
+
+	return nil
+}
+
 // GetMetrics returns session metrics (IPMI-specific extension)
 func (t *IPMITransport) GetMetrics() *SOLMetrics {
 	t.mu.RLock()
@@ -130,6 +130,17 @@ func (t *MockTransport) Write(ctx context.Context, data []byte) error {
 	}
 }
 
+// Resize exports COLUMNS and LINES in the simulated shell, which has no
+// terminal of its own, so that programs size their output to the client
+func (t *MockTransport) Resize(ctx context.Context, cols, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		return fmt.Errorf("invalid terminal size %dx%d", cols, rows)
+	}
+
+	// The shell reads from a pipe and does not echo, so this is invisible
+	return t.Write(ctx, []byte(fmt.Sprintf("export COLUMNS=%d LINES=%d\n", cols, rows)))
+}
+
 // Close terminates the mock SOL transport
 func (t *MockTransport) Close() error {
 	t.mu.Lock()
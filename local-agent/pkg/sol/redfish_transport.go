@@ -6,13 +6,18 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/websocket"
+
+	"local-agent/pkg/bmcproxy"
 )
 
 // RedfishTransport implements Transport using Redfish WebSocket
@@ -32,6 +37,9 @@ func NewRedfishTransport() *RedfishTransport {
 	return &RedfishTransport{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				DialContext: bmcproxy.NewDialer(30 * time.Second).DialContext,
+			},
 		},
 		status:  TransportStatus{Connected: false, Protocol: "redfish", Message: "disconnected"},
 		stopCh:  make(chan struct{}),
@@ -55,16 +63,31 @@ func (t *RedfishTransport) Connect(ctx context.Context, endpoint, username, pass
 	}
 	t.config = config
 
-	// Find system ID
-	systemID, err := t.findSystemID(ctx, endpoint, username, password)
-	if err != nil {
-		return fmt.Errorf("failed to find system ID: %w", err)
-	}
+	wsURI, isOBMCConsole := obmcConsoleURI(endpoint)
+	if isOBMCConsole {
+		// OpenBMC has no SerialConsole.Connect action, its console is the
+		// obmc-console WebSocket, whose line settings are not in Redfish
+		if config.ApplySerialSettings {
+			return fmt.Errorf("%w: OpenBMC console settings are not exposed through Redfish", ErrSerialSettingsNotSupported)
+		}
+	} else {
+		if config.ApplySerialSettings {
+			if err := t.applySerialSettings(ctx, endpoint, username, password); err != nil {
+				return err
+			}
+		}
 
-	// Get the serial console WebSocket URI
-	wsURI, err := t.getSerialConsoleURI(ctx, endpoint, username, password, systemID)
-	if err != nil {
-		return fmt.Errorf("failed to get serial console URI: %w", err)
+		// Find system ID
+		systemID, err := t.findSystemID(ctx, endpoint, username, password)
+		if err != nil {
+			return fmt.Errorf("failed to find system ID: %w", err)
+		}
+
+		// Get the serial console WebSocket URI
+		wsURI, err = t.getSerialConsoleURI(ctx, endpoint, username, password, systemID)
+		if err != nil {
+			return fmt.Errorf("failed to get serial console URI: %w", err)
+		}
 	}
 
 	// Establish WebSocket connection
@@ -73,7 +96,7 @@ func (t *RedfishTransport) Connect(ctx context.Context, endpoint, username, pass
 	}
 
 	// Start WebSocket data handling
-	go t.handleWebSocketData(ctx)
+	go t.handleWebSocketData(ctx, t.wsConn)
 
 	t.status = TransportStatus{Connected: true, Protocol: "redfish", Message: "WebSocket connected"}
 	return nil
@@ -98,7 +121,8 @@ func (t *RedfishTransport) Read(ctx context.Context) ([]byte, error) {
 	}
 }
 
-// Write sends console input to the Redfish transport
+// Write sends console input to the Redfish transport, in several WebSocket
+// messages when larger than the configured write size
 func (t *RedfishTransport) Write(ctx context.Context, data []byte) error {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -107,14 +131,40 @@ func (t *RedfishTransport) Write(ctx context.Context, data []byte) error {
 		return fmt.Errorf("transport not connected")
 	}
 
-	select {
-	case t.writeCh <- data:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-t.stopCh:
-		return fmt.Errorf("transport stopped")
+	for _, message := range splitMessage(data, t.config.MaxWriteSize) {
+		select {
+		case t.writeCh <- message:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.stopCh:
+			return fmt.Errorf("transport stopped")
+		}
+	}
+	return nil
+}
+
+// splitMessage splits data into messages of at most size bytes, without
+// splitting UTF-8 characters as the messages are text. Zero size does not
+// split.
+func splitMessage(data []byte, size int) [][]byte {
+	if size <= 0 || len(data) <= size {
+		return [][]byte{data}
+	}
+
+	messages := make([][]byte, 0, (len(data)+size-1)/size)
+	for len(data) > size {
+		end := size
+		// Back up to the start of a character, unless it is longer than size
+		for end > 0 && !utf8.RuneStart(data[end]) {
+			end--
+		}
+		if end == 0 {
+			end = size
+		}
+		messages = append(messages, data[:end])
+		data = data[end:]
 	}
+	return append(messages, data)
 }
 
 // Close terminates the Redfish transport
@@ -144,6 +194,7 @@ func (t *RedfishTransport) getHTTPClient() *http.Client {
 	}
 
 	transport := &http.Transport{
+		DialContext: bmcproxy.NewDialer(30 * time.Second).DialContext,
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: t.config.InsecureSkipVerify,
 		},
@@ -225,6 +276,9 @@ func (t *RedfishTransport) findSystemID(ctx context.Context, endpoint, username,
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return "", fmt.Errorf("%w: %d", ErrAuthenticationFailed, resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("failed to get systems: %d", resp.StatusCode)
 	}
@@ -274,6 +328,9 @@ func (t *RedfishTransport) getSerialConsoleURI(ctx context.Context, endpoint, us
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return "", fmt.Errorf("%w: %d", ErrAuthenticationFailed, resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("failed to get serial console info: %d", resp.StatusCode)
 	}
@@ -317,10 +374,27 @@ func (t *RedfishTransport) getSerialConsoleURI(ctx context.Context, endpoint, us
 	return u.String(), nil
 }
 
+// obmcConsoleURI returns the WebSocket URI of an OpenBMC obmc-console
+// endpoint, e.g. https://bmc/console0, and whether endpoint is one
+func obmcConsoleURI(endpoint string) (string, bool) {
+	u, err := url.Parse(normalizeRedfishEndpoint(endpoint))
+	if err != nil || (u.Path != "/console0" && !strings.HasPrefix(u.Path, "/console/")) {
+		return "", false
+	}
+
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+	return u.String(), true
+}
+
 // connectWebSocket establishes WebSocket connection for console access
 func (t *RedfishTransport) connectWebSocket(ctx context.Context, wsURI, username, password string) error {
 	// Set up WebSocket dialer with authentication
 	dialer := websocket.Dialer{
+		NetDialContext:   bmcproxy.NewDialer(30 * time.Second).DialContext,
 		HandshakeTimeout: 45 * time.Second,
 	}
 
@@ -334,18 +408,21 @@ func (t *RedfishTransport) connectWebSocket(ctx context.Context, wsURI, username
 		return err
 	}
 
+	if t.config.MaxMessageSize > 0 {
+		conn.SetReadLimit(t.config.MaxMessageSize)
+	}
+
 	t.wsConn = conn
 	return nil
 }
 
-// handleWebSocketData manages bidirectional WebSocket data flow
-func (t *RedfishTransport) handleWebSocketData(ctx context.Context) {
+// handleWebSocketData manages bidirectional WebSocket data flow. It owns
+// conn, Close clears t.wsConn concurrently.
+func (t *RedfishTransport) handleWebSocketData(ctx context.Context, conn *websocket.Conn) {
 	defer func() {
 		close(t.readCh)
 		close(t.writeCh)
-		if t.wsConn != nil {
-			t.wsConn.Close()
-		}
+		conn.Close()
 	}()
 
 	// Start read goroutine
@@ -355,8 +432,11 @@ func (t *RedfishTransport) handleWebSocketData(ctx context.Context) {
 			case <-t.stopCh:
 				return
 			default:
-				_, message, err := t.wsConn.ReadMessage()
+				_, message, err := conn.ReadMessage()
 				if err != nil {
+					if errors.Is(err, websocket.ErrReadLimit) {
+						err = fmt.Errorf("BMC sent a console message larger than %d bytes", t.config.MaxMessageSize)
+					}
 					if !t.isConnectionClosed(err) {
 						t.mu.Lock()
 						t.status = TransportStatus{Connected: false, Protocol: "redfish", Message: fmt.Sprintf("WebSocket error: %v", err)}
@@ -386,7 +466,7 @@ func (t *RedfishTransport) handleWebSocketData(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case data := <-t.writeCh:
-			if err := t.wsConn.WriteMessage(websocket.TextMessage, data); err != nil {
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
 				t.mu.Lock()
 				t.status = TransportStatus{Connected: false, Protocol: "redfish", Message: fmt.Sprintf("write error: %v", err)}
 				t.mu.Unlock()
@@ -0,0 +1,192 @@
+package sol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"core/testing/redfishsim"
+)
+
+func TestRedfishTransport_ConsoleEcho(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transport := NewRedfishTransport()
+	if err := transport.Connect(ctx, bmc.URL, bmc.Username(), bmc.Password(), nil); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer transport.Close()
+
+	if err := transport.Write(ctx, []byte("uptime\r")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := transport.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if string(data) != "uptime\r" {
+		t.Errorf("Expected echo of input, got %q", data)
+	}
+
+	bmc.WriteConsole([]byte("login: "))
+	data, err = transport.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if string(data) != "login: " {
+		t.Errorf("Expected host output, got %q", data)
+	}
+}
+
+func TestRedfishTransport_ConsoleDisabled(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{DisableSerialConsole: true})
+	defer bmc.Close()
+
+	err := NewRedfishTransport().Connect(context.Background(), bmc.URL, bmc.Username(), bmc.Password(), nil)
+	if err == nil {
+		t.Fatal("Expected Connect to fail with the serial console disabled")
+	}
+}
+
+func TestRedfishTransport_AuthenticationFailed(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	err := NewRedfishTransport().Connect(context.Background(), bmc.URL, bmc.Username(), "wrong", nil)
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Expected ErrAuthenticationFailed, got %v", err)
+	}
+}
+
+func TestRedfishTransport_SplitsLargeInput(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{})
+	defer bmc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	config := DefaultSOLConfig()
+	config.MaxWriteSize = 4096
+	transport := NewRedfishTransport()
+	if err := transport.Connect(ctx, bmc.URL, bmc.Username(), bmc.Password(), config); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer transport.Close()
+
+	paste := bytes.Repeat([]byte("0123456789"), 1000)
+	if err := transport.Write(ctx, paste); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// The BMC echoes every message
+	var echoed []byte
+	for messages := 0; len(echoed) < len(paste); messages++ {
+		if messages == 3 {
+			t.Fatalf("Expected the paste in 3 messages, got %d bytes so far", len(echoed))
+		}
+		data, err := transport.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if len(data) > 4096 {
+			t.Errorf("Expected messages of at most 4096 bytes, got %d", len(data))
+		}
+		echoed = append(echoed, data...)
+	}
+	if !bytes.Equal(echoed, paste) {
+		t.Error("Expected the paste to reach the BMC unchanged")
+	}
+}
+
+func TestRedfishTransport_OpenBMCConsole(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{OpenBMC: true})
+	defer bmc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transport := NewRedfishTransport()
+	if err := transport.Connect(ctx, bmc.URL+redfishsim.OBMCConsolePath, bmc.Username(), bmc.Password(), nil); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer transport.Close()
+
+	bmc.WriteConsole([]byte("login: "))
+	data, err := transport.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if string(data) != "login: " {
+		t.Errorf("Expected host output, got %q", data)
+	}
+
+	if err := transport.Write(ctx, []byte("root\r")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if data, err := transport.Read(ctx); err != nil || string(data) != "root\r" {
+		t.Errorf("Expected echo of input, got %q (%v)", data, err)
+	}
+}
+
+func TestRedfishTransport_OpenBMCConsoleSettings(t *testing.T) {
+	config := DefaultSOLConfig()
+	config.ApplySerialSettings = true
+	config.BaudRate = 115200
+
+	err := NewRedfishTransport().Connect(context.Background(), "https://bmc.example.com/console0", "admin", "password", config)
+	if !errors.Is(err, ErrSerialSettingsNotSupported) {
+		t.Errorf("Expected ErrSerialSettingsNotSupported, got %v", err)
+	}
+}
+
+func TestOBMCConsoleURI(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+		ok       bool
+	}{
+		{"https://bmc.example.com/console0", "wss://bmc.example.com/console0", true},
+		{"http://127.0.0.1:8080/console/default", "ws://127.0.0.1:8080/console/default", true},
+		{"https://bmc.example.com/redfish/v1/Systems/1/SerialConsole", "", false},
+		{"https://bmc.example.com", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := obmcConsoleURI(tt.endpoint)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("obmcConsoleURI(%q) = %q, %v; want %q, %v", tt.endpoint, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		size int
+		want []string
+	}{
+		{name: "unlimited", data: "abcdef", size: 0, want: []string{"abcdef"}},
+		{name: "within size", data: "abc", size: 3, want: []string{"abc"}},
+		{name: "split", data: "abcdefg", size: 3, want: []string{"abc", "def", "g"}},
+		{name: "characters kept whole", data: "aé日本", size: 4, want: []string{"aé", "日", "本"}},
+		{name: "character over size", data: "日本", size: 2, want: []string{"\xe6\x97", "\xa5", "\xe6\x9c", "\xac"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, message := range splitMessage([]byte(tt.data), tt.size) {
+				got = append(got, string(message))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
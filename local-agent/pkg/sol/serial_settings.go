@@ -0,0 +1,234 @@
+package sol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ipmiBitRates maps baud rates to the bit rate values of ipmi-config's
+// SOL_Conf section
+var ipmiBitRates = map[int]string{
+	9600:   "9.6",
+	19200:  "19.2",
+	38400:  "38.4",
+	57600:  "57.6",
+	115200: "115.2",
+}
+
+// redfishFlowControl maps flow control modes to Redfish SerialInterface
+// FlowControl values
+var redfishFlowControl = map[string]string{
+	"none":     "None",
+	"hardware": "Hardware",
+	"software": "Software",
+}
+
+// applyIPMISerialSettings sets the SOL bit rate of an IPMI BMC with
+// ipmi-config. The volatile bit rate is used so that the BMC reverts to its
+// configured rate when it resets. IPMI SOL has no flow control parameter,
+// so only "none" is accepted.
+func applyIPMISerialSettings(ctx context.Context, endpoint, username, password string, config *Config) error {
+	if config.FlowControl != "" && config.FlowControl != "none" {
+		return fmt.Errorf("%w: IPMI SOL has no %s flow control", ErrSerialSettingsNotSupported, config.FlowControl)
+	}
+	if config.BaudRate == 0 {
+		return nil
+	}
+
+	host, err := freeIPMIHost(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	args, err := ipmiBitRateArgs(host, username, password, config.BaudRate)
+	if err != nil {
+		return err
+	}
+
+	path, err := lookupIPMIConfig()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ipmi-config failed to set SOL bit rate: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	log.Info().
+		Str("endpoint", endpoint).
+		Int("baud_rate", config.BaudRate).
+		Msg("IPMI SOL bit rate set")
+
+	return nil
+}
+
+// ipmiBitRateArgs returns the ipmi-config arguments setting the volatile SOL
+// bit rate of the BMC at host, as returned by freeIPMIHost
+func ipmiBitRateArgs(host, username, password string, baudRate int) ([]string, error) {
+	bitRate, ok := ipmiBitRates[baudRate]
+	if !ok {
+		return nil, fmt.Errorf("%w: IPMI SOL has no %d baud rate", ErrSerialSettingsNotSupported, baudRate)
+	}
+
+	return []string{
+		"-h", host,
+		"-u", username,
+		"-p", password,
+		"--category=sol",
+		"--commit",
+		"--key-pair=SOL_Conf:Volatile_Bit_Rate=" + bitRate,
+	}, nil
+}
+
+// lookupIPMIConfig returns the path of the ipmi-config binary, preferring
+// the default install location over PATH
+func lookupIPMIConfig() (string, error) {
+	const defaultPath = "/usr/sbin/ipmi-config"
+
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath, nil
+	}
+	if path, err := exec.LookPath("ipmi-config"); err == nil {
+		return path, nil
+	}
+	return "", fmt.Errorf("ipmi-config not found: install freeipmi-tools package")
+}
+
+// applySerialSettings sets the bit rate and flow control of the first serial
+// interface of the BMC's first manager. Callers hold mu.
+func (t *RedfishTransport) applySerialSettings(ctx context.Context, endpoint, username, password string) error {
+	patch := map[string]string{}
+	if t.config.BaudRate != 0 {
+		patch["BitRate"] = strconv.Itoa(t.config.BaudRate)
+	}
+	if t.config.FlowControl != "" {
+		flowControl, ok := redfishFlowControl[t.config.FlowControl]
+		if !ok {
+			return fmt.Errorf("%w: unknown flow control %s", ErrSerialSettingsNotSupported, t.config.FlowControl)
+		}
+		patch["FlowControl"] = flowControl
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+
+	baseURL := normalizeRedfishEndpoint(endpoint)
+	interfaceURI, err := t.findSerialInterface(ctx, baseURL, username, password)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, baseURL+interfaceURI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.getHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %d", ErrAuthenticationFailed, resp.StatusCode)
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusMethodNotAllowed ||
+		resp.StatusCode == http.StatusNotImplemented:
+		return fmt.Errorf("%w: serial interface update rejected: %d", ErrSerialSettingsNotSupported, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("failed to update serial interface: %d", resp.StatusCode)
+	}
+
+	log.Info().
+		Str("endpoint", endpoint).
+		Str("serial_interface", interfaceURI).
+		Int("baud_rate", t.config.BaudRate).
+		Str("flow_control", t.config.FlowControl).
+		Msg("Redfish serial interface configured")
+
+	return nil
+}
+
+// findSerialInterface returns the URI of the first serial interface of the
+// BMC's first manager
+func (t *RedfishTransport) findSerialInterface(ctx context.Context, baseURL, username, password string) (string, error) {
+	var managers redfishCollection
+	if err := t.getJSON(ctx, baseURL+"/redfish/v1/Managers", username, password, &managers); err != nil {
+		return "", err
+	}
+	if len(managers.Members) == 0 {
+		return "", fmt.Errorf("%w: no managers found", ErrSerialSettingsNotSupported)
+	}
+
+	var manager struct {
+		SerialInterfaces struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"SerialInterfaces"`
+	}
+	if err := t.getJSON(ctx, baseURL+managers.Members[0].OdataID, username, password, &manager); err != nil {
+		return "", err
+	}
+	if manager.SerialInterfaces.OdataID == "" {
+		return "", fmt.Errorf("%w: manager has no serial interfaces", ErrSerialSettingsNotSupported)
+	}
+
+	var interfaces redfishCollection
+	if err := t.getJSON(ctx, baseURL+manager.SerialInterfaces.OdataID, username, password, &interfaces); err != nil {
+		return "", err
+	}
+	if len(interfaces.Members) == 0 {
+		return "", fmt.Errorf("%w: manager has no serial interfaces", ErrSerialSettingsNotSupported)
+	}
+
+	return interfaces.Members[0].OdataID, nil
+}
+
+// redfishCollection is the member list of a Redfish collection
+type redfishCollection struct {
+	Members []struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"Members"`
+}
+
+// getJSON decodes the Redfish resource at url into v
+func (t *RedfishTransport) getJSON(ctx context.Context, url, username, password string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, password)
+
+	resp, err := t.getHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %d", ErrAuthenticationFailed, resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s not found", ErrSerialSettingsNotSupported, url)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to get %s: %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,123 @@
+package sol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestIPMIBitRateArgs(t *testing.T) {
+	args, err := ipmiBitRateArgs("10.0.0.5", "admin", "secret", 19200)
+	if err != nil {
+		t.Fatalf("ipmiBitRateArgs failed: %v", err)
+	}
+	if !slices.Contains(args, "--key-pair=SOL_Conf:Volatile_Bit_Rate=19.2") {
+		t.Errorf("Expected volatile bit rate 19.2, got %v", args)
+	}
+
+	// A BMC on a non-default port is addressed as host:port
+	args, err = ipmiBitRateArgs("10.0.0.5:6230", "admin", "secret", 19200)
+	if err != nil {
+		t.Fatalf("ipmiBitRateArgs failed: %v", err)
+	}
+	if i := slices.Index(args, "-h"); i < 0 || args[i+1] != "10.0.0.5:6230" {
+		t.Errorf("Expected -h 10.0.0.5:6230, got %v", args)
+	}
+
+	if _, err := ipmiBitRateArgs("10.0.0.5", "admin", "secret", 14400); !errors.Is(err, ErrSerialSettingsNotSupported) {
+		t.Errorf("Expected ErrSerialSettingsNotSupported, got %v", err)
+	}
+}
+
+func TestApplyIPMISerialSettings_FlowControl(t *testing.T) {
+	config := &Config{FlowControl: "hardware", ApplySerialSettings: true}
+	err := applyIPMISerialSettings(context.Background(), "10.0.0.5", "admin", "secret", config)
+	if !errors.Is(err, ErrSerialSettingsNotSupported) {
+		t.Errorf("Expected ErrSerialSettingsNotSupported, got %v", err)
+	}
+}
+
+// newSerialInterfaceServer serves a manager with one serial interface and
+// records the PATCH bodies it receives
+func newSerialInterfaceServer(t *testing.T, patches *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/redfish/v1/Managers":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/1"}]}`))
+		case r.URL.Path == "/redfish/v1/Managers/1":
+			w.Write([]byte(`{"SerialInterfaces": {"@odata.id": "/redfish/v1/Managers/1/SerialInterfaces"}}`))
+		case r.URL.Path == "/redfish/v1/Managers/1/SerialInterfaces":
+			w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/Managers/1/SerialInterfaces/TTY0"}]}`))
+		case r.URL.Path == "/redfish/v1/Managers/1/SerialInterfaces/TTY0" && r.Method == http.MethodPatch:
+			var patch map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Errorf("Invalid PATCH body: %v", err)
+			}
+			*patches = append(*patches, patch)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRedfishTransport_ApplySerialSettings(t *testing.T) {
+	var patches []map[string]string
+	server := newSerialInterfaceServer(t, &patches)
+	defer server.Close()
+
+	transport := NewRedfishTransport()
+	transport.config = &Config{BaudRate: 57600, FlowControl: "hardware", ApplySerialSettings: true}
+	if err := transport.applySerialSettings(context.Background(), server.URL, "user", "pass"); err != nil {
+		t.Fatalf("applySerialSettings failed: %v", err)
+	}
+
+	if len(patches) != 1 {
+		t.Fatalf("Expected 1 PATCH, got %d", len(patches))
+	}
+	if patches[0]["BitRate"] != "57600" || patches[0]["FlowControl"] != "Hardware" {
+		t.Errorf("Unexpected PATCH body: %v", patches[0])
+	}
+}
+
+func TestRedfishTransport_ApplySerialSettings_OnlyRequested(t *testing.T) {
+	var patches []map[string]string
+	server := newSerialInterfaceServer(t, &patches)
+	defer server.Close()
+
+	// Only the baud rate is requested, the BMC's flow control is kept
+	transport := NewRedfishTransport()
+	transport.config = &Config{BaudRate: 9600, ApplySerialSettings: true}
+	if err := transport.applySerialSettings(context.Background(), server.URL, "user", "pass"); err != nil {
+		t.Fatalf("applySerialSettings failed: %v", err)
+	}
+
+	if len(patches) != 1 {
+		t.Fatalf("Expected 1 PATCH, got %d", len(patches))
+	}
+	if _, ok := patches[0]["FlowControl"]; ok || patches[0]["BitRate"] != "9600" {
+		t.Errorf("Unexpected PATCH body: %v", patches[0])
+	}
+}
+
+func TestRedfishTransport_ApplySerialSettings_Unauthorized(t *testing.T) {
+	var patches []map[string]string
+	server := newSerialInterfaceServer(t, &patches)
+	defer server.Close()
+
+	transport := NewRedfishTransport()
+	transport.config = &Config{BaudRate: 9600, ApplySerialSettings: true}
+	err := transport.applySerialSettings(context.Background(), server.URL, "user", "wrong")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Expected ErrAuthenticationFailed, got %v", err)
+	}
+}
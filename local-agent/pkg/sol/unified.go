@@ -44,6 +44,16 @@ func (c *UnifiedClient) SupportsSOL(ctx context.Context, endpoint, username, pas
 	return c.transport.SupportsSOL(ctx, endpoint, username, password)
 }
 
+// Deactivate deactivates the BMC's SOL payload if the transport supports it,
+// otherwise ErrDeactivateNotSupported is returned
+func (c *UnifiedClient) Deactivate(ctx context.Context, endpoint, username, password string) error {
+	deactivator, ok := c.transport.(Deactivator)
+	if !ok {
+		return ErrDeactivateNotSupported
+	}
+	return deactivator.Deactivate(ctx, endpoint, username, password)
+}
+
 // UnifiedSession implements Session using transport abstraction
 type UnifiedSession struct {
 	mu         sync.RWMutex
@@ -99,6 +109,28 @@ func (s *UnifiedSession) Write(ctx context.Context, data []byte) error {
 	return s.transport.Write(ctx, data)
 }
 
+// Resize applies the client terminal size if the transport supports it,
+// otherwise ErrResizeNotSupported is returned
+func (s *UnifiedSession) Resize(ctx context.Context, cols, rows int) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resizer, ok := s.transport.(Resizer)
+	if !ok {
+		return ErrResizeNotSupported
+	}
+
+	if s.closed {
+		return fmt.Errorf("session is closed")
+	}
+
+	if !s.status.Active {
+		return fmt.Errorf("session is not active")
+	}
+
+	return resizer.Resize(ctx, cols, rows)
+}
+
 // Close terminates the SOL session
 func (s *UnifiedSession) Close() error {
 	s.mu.Lock()
@@ -0,0 +1,67 @@
+package sol
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnifiedSession_ResizeNotSupported(t *testing.T) {
+	client := NewClientWithTransport(NewIPMITransport())
+	session, err := client.CreateSession(context.Background(), "localhost:623", "admin", "password", nil)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	resizer, ok := session.(Resizer)
+	if !ok {
+		t.Fatal("UnifiedSession should implement Resizer")
+	}
+
+	if err := resizer.Resize(context.Background(), 120, 40); !errors.Is(err, ErrResizeNotSupported) {
+		t.Errorf("Resize() error = %v, want ErrResizeNotSupported", err)
+	}
+}
+
+func TestUnifiedClient_DeactivateNotSupported(t *testing.T) {
+	client := NewUnifiedClient(NewMockTransport())
+
+	err := client.Deactivate(context.Background(), "mock", "", "")
+	if !errors.Is(err, ErrDeactivateNotSupported) {
+		t.Errorf("Deactivate() error = %v, want ErrDeactivateNotSupported", err)
+	}
+}
+
+func TestMockTransport_Resize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transport := NewMockTransport()
+	if err := transport.Connect(ctx, "mock", "", "", DefaultSOLConfig()); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer transport.Close()
+
+	if err := transport.Resize(ctx, 0, 40); err == nil {
+		t.Error("Resize() should reject an empty size")
+	}
+
+	if err := transport.Resize(ctx, 132, 43); err != nil {
+		t.Fatalf("Resize() error: %v", err)
+	}
+	if err := transport.Write(ctx, []byte("echo size=$COLUMNS:$LINES\n")); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var output strings.Builder
+	for !strings.Contains(output.String(), "size=132:43") {
+		data, err := transport.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read() error: %v (output so far %q)", err, output.String())
+		}
+		output.Write(data)
+	}
+}
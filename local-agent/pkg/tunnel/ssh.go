@@ -0,0 +1,153 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// sshUpTimeout bounds waiting for the SOCKS5 proxy of an SSH tunnel
+	sshUpTimeout = 15 * time.Second
+	// sshAliveInterval is the interval of the keepalives of SSH tunnels, which
+	// exit after 3 unanswered ones
+	sshAliveInterval = 15 * time.Second
+)
+
+// sshDriver runs ssh with dynamic port forwarding, its SOCKS5 proxy reaching
+// the BMC networks from the jump host
+type sshDriver struct {
+	args         []string
+	socksAddress string
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	exited chan struct{}
+	err    error
+	stderr bytes.Buffer
+}
+
+func newSSHDriver(config Config) *sshDriver {
+	return &sshDriver{args: sshArgs(config), socksAddress: config.SOCKSAddress}
+}
+
+// sshArgs returns the arguments of the ssh command of a tunnel. Host keys are
+// checked strictly and authentication is never interactive.
+func sshArgs(config Config) []string {
+	args := []string{
+		"-N",
+		"-D", config.SOCKSAddress,
+		"-o", "BatchMode=yes",
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", "ServerAliveInterval=" + strconv.Itoa(int(sshAliveInterval.Seconds())),
+		"-o", "ServerAliveCountMax=3",
+	}
+	if config.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(config.Port))
+	}
+	if config.IdentityFile != "" {
+		args = append(args, "-i", config.IdentityFile, "-o", "IdentitiesOnly=yes")
+	}
+	if config.KnownHostsFile != "" {
+		args = append(args, "-o", "UserKnownHostsFile="+config.KnownHostsFile)
+	}
+	return append(args, config.Host)
+}
+
+func (d *sshDriver) up(ctx context.Context) error {
+	d.mu.Lock()
+	cmd := exec.Command("ssh", d.args...)
+	d.stderr.Reset()
+	cmd.Stderr = &d.stderr
+	if err := cmd.Start(); err != nil {
+		d.mu.Unlock()
+		return fmt.Errorf("failed to start ssh: %w", err)
+	}
+	exited := make(chan struct{})
+	d.cmd, d.exited = cmd, exited
+	d.mu.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		d.mu.Lock()
+		d.err = err
+		d.mu.Unlock()
+		close(exited)
+	}()
+
+	// The SOCKS5 proxy listens once the connection is established
+	ctx, cancel := context.WithTimeout(ctx, sshUpTimeout)
+	defer cancel()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if err := d.check(ctx); err == nil {
+			return nil
+		}
+		select {
+		case <-exited:
+			return d.exitError()
+		case <-ctx.Done():
+			return fmt.Errorf("SOCKS5 proxy of ssh tunnel not listening on %s: %w", d.socksAddress, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *sshDriver) check(ctx context.Context) error {
+	d.mu.Lock()
+	exited := d.exited
+	d.mu.Unlock()
+	if exited == nil {
+		return fmt.Errorf("ssh is not running")
+	}
+	select {
+	case <-exited:
+		return d.exitError()
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: 2 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", d.socksAddress)
+	if err != nil {
+		return fmt.Errorf("SOCKS5 proxy of ssh tunnel unreachable: %w", err)
+	}
+	return conn.Close()
+}
+
+// exitError returns the error of an exited ssh, with its output
+func (d *sshDriver) exitError() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fmt.Errorf("ssh exited: %v: %s", d.err, bytes.TrimSpace(d.stderr.Bytes()))
+}
+
+func (d *sshDriver) down(ctx context.Context) error {
+	d.mu.Lock()
+	cmd, exited := d.cmd, d.exited
+	d.mu.Unlock()
+	if cmd == nil {
+		return nil
+	}
+
+	select {
+	case <-exited:
+		return nil
+	default:
+	}
+	if err := cmd.Process.Kill(); err != nil {
+		return fmt.Errorf("failed to stop ssh: %w", err)
+	}
+	select {
+	case <-exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,298 @@
+// Package tunnel establishes and supervises the tunnels of agents to isolated
+// BMC networks. WireGuard tunnels route the BMC networks through their
+// interface; SSH tunnels expose them through a local SOCKS5 proxy. Tunnels are
+// restarted when they fail, with exponential backoff.
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/bmcproxy"
+)
+
+// Type is the kind of a tunnel
+type Type string
+
+const (
+	TypeWireGuard Type = "wireguard"
+	TypeSSH       Type = "ssh"
+)
+
+const (
+	// checkInterval is the interval between health checks of a tunnel
+	checkInterval = 10 * time.Second
+	// minRestartDelay and maxRestartDelay bound the backoff of restarts
+	minRestartDelay = time.Second
+	maxRestartDelay = time.Minute
+	// stopTimeout bounds taking the tunnels down when the agent stops
+	stopTimeout = 10 * time.Second
+)
+
+// Config configures a tunnel
+type Config struct {
+	Name string
+	Type Type
+	// Networks are the BMC networks reached through the tunnel, in CIDR
+	// notation
+	Networks []string
+
+	// ConfigFile is the wg-quick configuration of a WireGuard tunnel, its base
+	// name being the interface
+	ConfigFile string
+
+	// Host is the [user@]host of the jump host of an SSH tunnel, Port its SSH
+	// port when not 22
+	Host           string
+	Port           int
+	IdentityFile   string
+	KnownHostsFile string
+	// SOCKSAddress is the local host:port of the SOCKS5 proxy of an SSH
+	// tunnel
+	SOCKSAddress string
+}
+
+// Status is the state of a tunnel
+type Status struct {
+	Name      string    `json:"name"`
+	Type      Type      `json:"type"`
+	Up        bool      `json:"up"`
+	Since     time.Time `json:"since,omitempty"`
+	Restarts  int       `json:"restarts"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// driver brings a kind of tunnel up and down
+type driver interface {
+	// up establishes the tunnel
+	up(ctx context.Context) error
+	// check returns an error when the tunnel is no longer working
+	check(ctx context.Context) error
+	// down tears the tunnel down, also after a failed up
+	down(ctx context.Context) error
+}
+
+// tunnel is a supervised tunnel
+type tunnel struct {
+	config Config
+	driver driver
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Manager supervises the tunnels of an agent
+type Manager struct {
+	tunnels []*tunnel
+
+	checkInterval   time.Duration
+	minRestartDelay time.Duration
+	maxRestartDelay time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager validates the configurations of tunnels and returns their
+// manager. Tunnels are established by Start.
+func NewManager(configs []Config) (*Manager, error) {
+	m := &Manager{
+		checkInterval:   checkInterval,
+		minRestartDelay: minRestartDelay,
+		maxRestartDelay: maxRestartDelay,
+	}
+
+	names := make(map[string]bool)
+	for _, config := range configs {
+		if config.Name == "" {
+			return nil, fmt.Errorf("tunnel name is required")
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate tunnel %s", config.Name)
+		}
+		names[config.Name] = true
+
+		if len(config.Networks) == 0 {
+			return nil, fmt.Errorf("tunnel %s has no networks", config.Name)
+		}
+		for _, network := range config.Networks {
+			if _, _, err := net.ParseCIDR(network); err != nil {
+				return nil, fmt.Errorf("invalid network %s of tunnel %s: %w", network, config.Name, err)
+			}
+		}
+
+		var d driver
+		switch config.Type {
+		case TypeWireGuard:
+			if config.ConfigFile == "" {
+				return nil, fmt.Errorf("wireguard tunnel %s requires a config file", config.Name)
+			}
+			d = newWireGuardDriver(config.ConfigFile)
+		case TypeSSH:
+			if config.Host == "" {
+				return nil, fmt.Errorf("ssh tunnel %s requires a host", config.Name)
+			}
+			if _, _, err := net.SplitHostPort(config.SOCKSAddress); err != nil {
+				return nil, fmt.Errorf("invalid SOCKS address of ssh tunnel %s: %w", config.Name, err)
+			}
+			d = newSSHDriver(config)
+		default:
+			return nil, fmt.Errorf("invalid type %q of tunnel %s: must be wireguard or ssh", config.Type, config.Name)
+		}
+
+		m.tunnels = append(m.tunnels, &tunnel{
+			config: config,
+			driver: d,
+			status: Status{Name: config.Name, Type: config.Type},
+		})
+	}
+	return m, nil
+}
+
+// ProxyRules returns the BMC proxy rules of the tunnels' networks: SSH
+// tunnels through their SOCKS5 proxy, WireGuard tunnels directly through
+// their interface
+func (m *Manager) ProxyRules() []bmcproxy.Rule {
+	rules := make([]bmcproxy.Rule, 0, len(m.tunnels))
+	for _, t := range m.tunnels {
+		rule := bmcproxy.Rule{Destinations: t.config.Networks, URL: bmcproxy.Direct}
+		if t.config.Type == TypeSSH {
+			rule.URL = "socks5://" + t.config.SOCKSAddress
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Start establishes the tunnels and supervises them until Stop, or until ctx
+// is done
+func (m *Manager) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+	for _, t := range m.tunnels {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.supervise(ctx, t)
+		}()
+	}
+}
+
+// Stop stops supervising the tunnels and tears them down
+func (m *Manager) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	m.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	for _, t := range m.tunnels {
+		if err := t.driver.down(ctx); err != nil {
+			log.Warn().Err(err).Str("tunnel", t.config.Name).Msg("Failed to take tunnel down")
+		}
+		t.setDown(nil)
+	}
+}
+
+// Status returns the state of the tunnels
+func (m *Manager) Status() []Status {
+	statuses := make([]Status, 0, len(m.tunnels))
+	for _, t := range m.tunnels {
+		t.mu.Lock()
+		statuses = append(statuses, t.status)
+		t.mu.Unlock()
+	}
+	return statuses
+}
+
+// supervise brings a tunnel up and restarts it when it fails, until ctx is
+// done. The restart delay doubles while the tunnel keeps failing, and resets
+// once it stayed up longer than the maximum delay.
+func (m *Manager) supervise(ctx context.Context, t *tunnel) {
+	delay := m.minRestartDelay
+	for {
+		err := t.driver.up(ctx)
+		if err == nil {
+			t.setUp()
+			log.Info().
+				Str("tunnel", t.config.Name).
+				Str("type", string(t.config.Type)).
+				Strs("networks", t.config.Networks).
+				Msg("Tunnel up")
+
+			upSince := time.Now()
+			err = m.watch(ctx, t)
+			if time.Since(upSince) > m.maxRestartDelay {
+				delay = m.minRestartDelay
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		t.setDown(err)
+		log.Warn().
+			Err(err).
+			Str("tunnel", t.config.Name).
+			Dur("restart_in", delay).
+			Msg("Tunnel failed, restarting")
+		if err := t.driver.down(ctx); err != nil {
+			log.Debug().Err(err).Str("tunnel", t.config.Name).Msg("Failed to clean up tunnel")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, m.maxRestartDelay)
+
+		t.mu.Lock()
+		t.status.Restarts++
+		t.mu.Unlock()
+	}
+}
+
+// watch checks a tunnel periodically, returning the error of the first failed
+// check, or nil when ctx is done
+func (m *Manager) watch(ctx context.Context, t *tunnel) error {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := t.driver.check(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t *tunnel) setUp() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Up = true
+	t.status.Since = time.Now()
+	t.status.LastError = ""
+}
+
+func (t *tunnel) setDown(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status.Up {
+		t.status.Since = time.Now()
+	}
+	t.status.Up = false
+	if err != nil {
+		t.status.LastError = err.Error()
+	}
+}
@@ -0,0 +1,321 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"local-agent/pkg/bmcproxy"
+)
+
+func TestNewManager_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []Config
+	}{
+		{"missing name", []Config{{Type: TypeWireGuard, Networks: []string{"10.20.0.0/16"}, ConfigFile: "wg-bmc.conf"}}},
+		{"duplicate name", []Config{
+			{Name: "bmc", Type: TypeWireGuard, Networks: []string{"10.20.0.0/16"}, ConfigFile: "wg-bmc.conf"},
+			{Name: "bmc", Type: TypeWireGuard, Networks: []string{"10.21.0.0/16"}, ConfigFile: "wg-bmc2.conf"},
+		}},
+		{"no networks", []Config{{Name: "bmc", Type: TypeWireGuard, ConfigFile: "wg-bmc.conf"}}},
+		{"invalid network", []Config{{Name: "bmc", Type: TypeWireGuard, Networks: []string{"10.20.0.0"}, ConfigFile: "wg-bmc.conf"}}},
+		{"invalid type", []Config{{Name: "bmc", Type: "openvpn", Networks: []string{"10.20.0.0/16"}}}},
+		{"wireguard without config file", []Config{{Name: "bmc", Type: TypeWireGuard, Networks: []string{"10.20.0.0/16"}}}},
+		{"ssh without host", []Config{{Name: "bmc", Type: TypeSSH, Networks: []string{"10.20.0.0/16"}, SOCKSAddress: "127.0.0.1:1080"}}},
+		{"ssh without SOCKS address", []Config{{Name: "bmc", Type: TypeSSH, Networks: []string{"10.20.0.0/16"}, Host: "jump"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewManager(tt.configs); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestManager_ProxyRules(t *testing.T) {
+	m, err := NewManager([]Config{
+		{Name: "vlan-100", Type: TypeWireGuard, Networks: []string{"10.20.0.0/16"}, ConfigFile: "/etc/wireguard/wg-bmc.conf"},
+		{Name: "lab", Type: TypeSSH, Networks: []string{"172.30.0.0/24"}, Host: "agent@jump.lab.example.com", SOCKSAddress: "127.0.0.1:1081"},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	rules := m.ProxyRules()
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].URL != bmcproxy.Direct || !slices.Equal(rules[0].Destinations, []string{"10.20.0.0/16"}) {
+		t.Errorf("Expected the WireGuard networks to connect directly, got %+v", rules[0])
+	}
+	if rules[1].URL != "socks5://127.0.0.1:1081" || !slices.Equal(rules[1].Destinations, []string{"172.30.0.0/24"}) {
+		t.Errorf("Expected the SSH networks through the SOCKS5 proxy, got %+v", rules[1])
+	}
+
+	router, err := bmcproxy.New("", rules)
+	if err != nil {
+		t.Fatalf("Expected valid proxy rules, got %v", err)
+	}
+	if proxyURL := router.ProxyFor("172.30.0.10"); proxyURL == nil || proxyURL.Host != "127.0.0.1:1081" {
+		t.Errorf("Expected 172.30.0.10 through the SSH tunnel, got %v", proxyURL)
+	}
+}
+
+// fakeDriver is a tunnel failing its checks while broken is set
+type fakeDriver struct {
+	mu     sync.Mutex
+	ups    int
+	downs  int
+	broken bool
+	upErr  error
+}
+
+func (d *fakeDriver) up(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ups++
+	if d.upErr != nil {
+		return d.upErr
+	}
+	d.broken = false
+	return nil
+}
+
+func (d *fakeDriver) check(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.broken {
+		return errors.New("tunnel broken")
+	}
+	return nil
+}
+
+func (d *fakeDriver) down(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.downs++
+	return nil
+}
+
+func (d *fakeDriver) counts() (int, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ups, d.downs
+}
+
+// newFakeManager returns a manager of one tunnel with a fake driver, checked
+// and restarted quickly
+func newFakeManager(d *fakeDriver) *Manager {
+	return &Manager{
+		tunnels: []*tunnel{{
+			config: Config{Name: "bmc", Type: TypeWireGuard, Networks: []string{"10.20.0.0/16"}},
+			driver: d,
+			status: Status{Name: "bmc", Type: TypeWireGuard},
+		}},
+		checkInterval:   5 * time.Millisecond,
+		minRestartDelay: 5 * time.Millisecond,
+		maxRestartDelay: 20 * time.Millisecond,
+	}
+}
+
+// waitFor fails the test when condition stays false for a second
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestManager_RestartsFailedTunnel(t *testing.T) {
+	d := &fakeDriver{}
+	m := newFakeManager(d)
+	m.Start(context.Background())
+
+	waitFor(t, func() bool { return m.Status()[0].Up })
+
+	d.mu.Lock()
+	d.broken = true
+	d.mu.Unlock()
+
+	waitFor(t, func() bool {
+		ups, _ := d.counts()
+		return ups >= 2 && m.Status()[0].Up
+	})
+	status := m.Status()[0]
+	if status.Restarts < 1 || status.LastError != "" {
+		t.Errorf("Expected a restart and no error once up, got %+v", status)
+	}
+
+	m.Stop()
+	if _, downs := d.counts(); downs < 2 {
+		t.Errorf("Expected the tunnel to be taken down after its failure and on stop, got %d downs", downs)
+	}
+	if m.Status()[0].Up {
+		t.Error("Expected the tunnel down after Stop")
+	}
+}
+
+func TestManager_RetriesFailedUp(t *testing.T) {
+	d := &fakeDriver{upErr: errors.New("wg-quick failed")}
+	m := newFakeManager(d)
+	m.Start(context.Background())
+	defer m.Stop()
+
+	waitFor(t, func() bool {
+		ups, _ := d.counts()
+		return ups >= 3
+	})
+	status := m.Status()[0]
+	if status.Up || status.LastError != "wg-quick failed" {
+		t.Errorf("Expected the tunnel down with the error of up, got %+v", status)
+	}
+}
+
+// fakeCommands records the commands of a WireGuard driver, answering with
+// the outputs by command line
+type fakeCommands struct {
+	outputs map[string]string
+	failing map[string]bool
+	ran     []string
+}
+
+func (c *fakeCommands) run(_ context.Context, name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	c.ran = append(c.ran, line)
+	if c.failing[line] {
+		return nil, fmt.Errorf("%s failed", line)
+	}
+	return []byte(c.outputs[line]), nil
+}
+
+func TestWireGuardDriver(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	commands := &fakeCommands{
+		outputs: map[string]string{},
+		failing: map[string]bool{"wg show wg-bmc": true},
+	}
+	d := newWireGuardDriver("/etc/wireguard/wg-bmc.conf")
+	d.run = commands.run
+	d.now = func() time.Time { return now }
+
+	if d.iface != "wg-bmc" {
+		t.Errorf("Expected interface wg-bmc, got %s", d.iface)
+	}
+	if err := d.up(context.Background()); err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	if !slices.Contains(commands.ran, "wg-quick up /etc/wireguard/wg-bmc.conf") {
+		t.Errorf("Expected wg-quick up, ran %v", commands.ran)
+	}
+
+	// No handshake yet right after up
+	if err := d.check(context.Background()); err != nil {
+		t.Errorf("Expected a healthy tunnel right after up, got %v", err)
+	}
+
+	now = now.Add(10 * time.Minute)
+	handshakes := "wg show wg-bmc latest-handshakes"
+	commands.outputs[handshakes] = fmt.Sprintf("peerA=\t0\npeerB=\t%d\n", now.Add(-time.Minute).Unix())
+	if err := d.check(context.Background()); err != nil {
+		t.Errorf("Expected a healthy tunnel with a recent handshake, got %v", err)
+	}
+
+	commands.outputs[handshakes] = fmt.Sprintf("peerA=\t%d\n", now.Add(-5*time.Minute).Unix())
+	if err := d.check(context.Background()); err == nil {
+		t.Error("Expected a failed check with a stale handshake")
+	}
+
+	commands.outputs[handshakes] = "peerA=\t0\n"
+	if err := d.check(context.Background()); err == nil {
+		t.Error("Expected a failed check without handshake")
+	}
+
+	commands.failing[handshakes] = true
+	if err := d.check(context.Background()); err == nil {
+		t.Error("Expected a failed check without interface")
+	}
+}
+
+func TestWireGuardDriver_UpReplacesStaleInterface(t *testing.T) {
+	commands := &fakeCommands{outputs: map[string]string{}, failing: map[string]bool{}}
+	d := newWireGuardDriver("wg-bmc")
+	d.run = commands.run
+
+	if err := d.up(context.Background()); err != nil {
+		t.Fatalf("up failed: %v", err)
+	}
+	want := []string{"wg show wg-bmc", "wg-quick down wg-bmc", "wg-quick up wg-bmc"}
+	if !slices.Equal(commands.ran, want) {
+		t.Errorf("Expected %v, ran %v", want, commands.ran)
+	}
+}
+
+func TestSSHArgs(t *testing.T) {
+	args := sshArgs(Config{
+		Host:           "agent@jump.lab.example.com",
+		Port:           2222,
+		IdentityFile:   "/etc/bmc-agent/id_ed25519",
+		KnownHostsFile: "/etc/bmc-agent/known_hosts",
+		SOCKSAddress:   "127.0.0.1:1081",
+	})
+	line := strings.Join(args, " ")
+
+	for _, want := range []string{
+		"-N -D 127.0.0.1:1081",
+		"-o BatchMode=yes",
+		"-o ExitOnForwardFailure=yes",
+		"-o StrictHostKeyChecking=yes",
+		"-p 2222",
+		"-i /etc/bmc-agent/id_ed25519",
+		"-o UserKnownHostsFile=/etc/bmc-agent/known_hosts",
+	} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+	if args[len(args)-1] != "agent@jump.lab.example.com" {
+		t.Errorf("Expected the host last, got %v", args)
+	}
+}
+
+func TestSSHDriver_Check(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	address := listener.Addr().String()
+
+	d := newSSHDriver(Config{Host: "jump", SOCKSAddress: address})
+	if err := d.check(context.Background()); err == nil {
+		t.Error("Expected a failed check before up")
+	}
+
+	// A running ssh whose proxy is listening
+	d.exited = make(chan struct{})
+	if err := d.check(context.Background()); err != nil {
+		t.Errorf("Expected a healthy tunnel, got %v", err)
+	}
+
+	listener.Close()
+	if err := d.check(context.Background()); err == nil {
+		t.Error("Expected a failed check once the proxy stopped listening")
+	}
+
+	close(d.exited)
+	if err := d.check(context.Background()); err == nil || !strings.Contains(err.Error(), "ssh exited") {
+		t.Errorf("Expected a failed check once ssh exited, got %v", err)
+	}
+}
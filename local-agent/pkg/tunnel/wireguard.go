@@ -0,0 +1,107 @@
+package tunnel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handshakeTimeout is how long a WireGuard tunnel may go without a handshake
+// with any peer. Peers handshake every two minutes while traffic flows, which
+// PersistentKeepalive keeps up.
+const handshakeTimeout = 3 * time.Minute
+
+// wireGuardDriver brings a WireGuard interface up and down with wg-quick
+type wireGuardDriver struct {
+	configFile string
+	iface      string
+
+	// run runs a command, returning its combined output
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+	now func() time.Time
+
+	upAt time.Time
+}
+
+func newWireGuardDriver(configFile string) *wireGuardDriver {
+	return &wireGuardDriver{
+		configFile: configFile,
+		iface:      strings.TrimSuffix(filepath.Base(configFile), ".conf"),
+		run:        runCommand,
+		now:        time.Now,
+	}
+}
+
+// runCommand runs a command, returning its combined output
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return output, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, bytes.TrimSpace(output))
+	}
+	return output, nil
+}
+
+func (d *wireGuardDriver) up(ctx context.Context) error {
+	// An interface left by a previous run makes wg-quick up fail
+	if _, err := d.run(ctx, "wg", "show", d.iface); err == nil {
+		if _, err := d.run(ctx, "wg-quick", "down", d.configFile); err != nil {
+			return err
+		}
+	}
+	if _, err := d.run(ctx, "wg-quick", "up", d.configFile); err != nil {
+		return err
+	}
+	d.upAt = d.now()
+	return nil
+}
+
+func (d *wireGuardDriver) check(ctx context.Context) error {
+	output, err := d.run(ctx, "wg", "show", d.iface, "latest-handshakes")
+	if err != nil {
+		return err
+	}
+
+	// Peers have not handshaken yet right after the interface came up
+	now := d.now()
+	if now.Sub(d.upAt) < handshakeTimeout {
+		return nil
+	}
+
+	// Lines are "<public key>\t<unix time>", 0 before the first handshake
+	var latest time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || seconds == 0 {
+			continue
+		}
+		if handshake := time.Unix(seconds, 0); handshake.After(latest) {
+			latest = handshake
+		}
+	}
+	if latest.IsZero() {
+		return fmt.Errorf("no handshake with any peer of %s", d.iface)
+	}
+	if age := now.Sub(latest); age > handshakeTimeout {
+		return fmt.Errorf("no handshake with any peer of %s for %s", d.iface, age.Round(time.Second))
+	}
+	return nil
+}
+
+func (d *wireGuardDriver) down(ctx context.Context) error {
+	if _, err := d.run(ctx, "wg", "show", d.iface); err != nil {
+		return nil // Already down
+	}
+	_, err := d.run(ctx, "wg-quick", "down", d.configFile)
+	return err
+}
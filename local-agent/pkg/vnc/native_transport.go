@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
 
+	"local-agent/pkg/bmcproxy"
 	"local-agent/pkg/vnc/rfb"
 )
 
@@ -52,7 +54,7 @@ func (t *NativeTransport) ConnectWithTLS(ctx context.Context, host string, port
 		port = 5900 // Default VNC port
 	}
 
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 
 	log.Debug().
 		Str("host", host).
@@ -61,10 +63,10 @@ func (t *NativeTransport) ConnectWithTLS(ctx context.Context, host string, port
 		Bool("tls_enabled", tlsConfig != nil && tlsConfig.Enabled).
 		Msg("Connecting to VNC server")
 
-	dialer := &net.Dialer{
-		Timeout:   t.timeout,
-		KeepAlive: keepaliveTime, // Enable TCP keepaliveTime for long-lived VNC connections
-	}
+	// VNC servers are reached through their proxy, and the network policy
+	// refuses those outside the allowed networks
+	dialer := bmcproxy.NewDialer(t.timeout)
+	dialer.KeepAlive = keepaliveTime // Enable TCP keepaliveTime for long-lived VNC connections
 
 	conn, err := dialer.DialContext(ctx, "tcp", address)
 	if err != nil {
@@ -360,8 +362,19 @@ func (t *NativeTransport) GetServerInit() []byte {
 
 // Read reads data from the VNC connection
 func (t *NativeTransport) Read(ctx context.Context) ([]byte, error) {
+	buf := make([]byte, 8192) // VNC typically uses larger buffers for framebuffer updates
+	n, err := t.ReadBuffer(ctx, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// ReadBuffer reads available data from the VNC connection into buf, so that
+// streaming proxies can read into pooled buffers
+func (t *NativeTransport) ReadBuffer(ctx context.Context, buf []byte) (int, error) {
 	if t.conn == nil {
-		return nil, fmt.Errorf("not connected")
+		return 0, fmt.Errorf("not connected")
 	}
 
 	// For streaming connections, only set deadline if context has one
@@ -373,20 +386,19 @@ func (t *NativeTransport) Read(ctx context.Context) ([]byte, error) {
 		t.conn.SetReadDeadline(time.Time{})
 	}
 
-	buf := make([]byte, 8192) // VNC typically uses larger buffers for framebuffer updates
 	n, err := t.conn.Read(buf)
 	if err != nil {
 		if err == io.EOF {
-			return nil, fmt.Errorf("VNC connection closed: %w", err)
+			return 0, fmt.Errorf("VNC connection closed: %w", err)
 		}
 		// Check for timeout
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
-			return nil, fmt.Errorf("VNC read timeout: %w", err)
+			return 0, fmt.Errorf("VNC read timeout: %w", err)
 		}
-		return nil, fmt.Errorf("VNC read error: %w", err)
+		return 0, fmt.Errorf("VNC read error: %w", err)
 	}
 
-	return buf[:n], nil
+	return n, nil
 }
 
 // Write writes data to the VNC connection
@@ -1,10 +1,15 @@
 package rfb
 
 import (
+	"errors"
 	"fmt"
 	"io"
 )
 
+// ErrAuthenticationFailed is returned when the server rejects the client's
+// credentials
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
 // Handshake manages the RFB protocol handshake process
 type Handshake struct {
 	reader  *ProtocolReader
@@ -231,13 +236,13 @@ func (h *Handshake) ReadSecurityResult() error {
 	if h.version.Minor == 8 {
 		reason, err := h.reader.ReadString()
 		if err != nil {
-			return fmt.Errorf("authentication failed (no reason provided): %w", err)
+			return fmt.Errorf("%w (no reason provided): %w", ErrAuthenticationFailed, err)
 		}
-		return fmt.Errorf("authentication failed: %s", reason)
+		return fmt.Errorf("%w: %s", ErrAuthenticationFailed, reason)
 	}
 
 	// RFB 3.3/3.7: No reason string provided
-	return fmt.Errorf("authentication failed (server did not provide reason)")
+	return fmt.Errorf("%w (server did not provide reason)", ErrAuthenticationFailed)
 }
 
 // SendClientInit sends the ClientInit message to the server
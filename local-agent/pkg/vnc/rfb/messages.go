@@ -0,0 +1,411 @@
+package rfb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownMessage is returned for RFB messages or rectangle encodings whose
+// length cannot be determined, so that the messages following them cannot
+// be delimited
+var ErrUnknownMessage = errors.New("unknown RFB message")
+
+// Client message types, RFC 6143 section 7.5 and its extensions
+const (
+	ClientSetPixelFormat           = 0
+	ClientSetEncodings             = 2
+	ClientFramebufferUpdateRequest = 3
+	ClientKeyEvent                 = 4
+	ClientPointerEvent             = 5
+	ClientCutText                  = 6
+	ClientEnableContinuousUpdates  = 150
+	ClientFence                    = 248
+	ClientXVP                      = 250
+	ClientSetDesktopSize           = 251
+	ClientQEMU                     = 255
+)
+
+// Server message types, RFC 6143 section 7.6 and its extensions
+const (
+	ServerFramebufferUpdate      = 0
+	ServerSetColorMapEntries     = 1
+	ServerBell                   = 2
+	ServerCutText                = 3
+	ServerEndOfContinuousUpdates = 150
+	ServerFence                  = 248
+	ServerXVP                    = 250
+)
+
+// Lengths of the headers of framebuffer updates and of their rectangles
+const (
+	framebufferUpdateHeaderLength = 4
+	rectangleHeaderLength         = 12
+)
+
+// Rectangle encodings of framebuffer updates, including the pseudo-encodings
+// servers send rectangles of
+const (
+	encodingRaw                  = 0
+	encodingCopyRect             = 1
+	encodingRRE                  = 2
+	encodingHextile              = 5
+	encodingZRLE                 = 16
+	encodingDesktopSize          = -223
+	encodingLastRect             = -224
+	encodingCursor               = -239
+	encodingXCursor              = -240
+	encodingQEMUExtendedKeyEvent = -258
+	encodingQEMULEDState         = -261
+	encodingDesktopName          = -307
+	encodingExtendedDesktopSize  = -308
+	encodingFence                = -312
+	encodingContinuousUpdates    = -313
+)
+
+// Hextile subencoding flags
+const (
+	hextileRaw              = 1
+	hextileBackground       = 2
+	hextileForeground       = 4
+	hextileAnySubrects      = 8
+	hextileSubrectsColoured = 16
+)
+
+// EmptyFramebufferUpdate is a framebuffer update without rectangles, which
+// answers a FramebufferUpdateRequest without changing the viewer's display
+var EmptyFramebufferUpdate = []byte{ServerFramebufferUpdate, 0, 0, 0}
+
+// BytesPerPixel returns the bytes per pixel of a PIXEL_FORMAT structure, as
+// found in ServerInit and SetPixelFormat messages
+func BytesPerPixel(pixelFormat []byte) int {
+	if len(pixelFormat) == 0 {
+		return 0
+	}
+	return int(pixelFormat[0]) / 8
+}
+
+// ServerInitBytesPerPixel returns the bytes per pixel of the pixel format of
+// a ServerInit message
+func ServerInitBytesPerPixel(serverInit []byte) (int, error) {
+	// framebuffer-width (2) + framebuffer-height (2) + PIXEL_FORMAT (16) + name
+	if len(serverInit) < 20 {
+		return 0, fmt.Errorf("ServerInit too short: %d bytes", len(serverInit))
+	}
+	return BytesPerPixel(serverInit[4:20]), nil
+}
+
+// ClientMessageLength returns the length of the RFB client message starting
+// header, 0 when more bytes are needed to tell it. Messages of unknown types
+// fail with ErrUnknownMessage.
+func ClientMessageLength(header []byte) (int, error) {
+	if len(header) == 0 {
+		return 0, nil
+	}
+
+	switch header[0] {
+	case ClientSetPixelFormat:
+		return 20, nil
+	case ClientSetEncodings:
+		if len(header) < 4 {
+			return 0, nil
+		}
+		return 4 + 4*int(binary.BigEndian.Uint16(header[2:4])), nil
+	case ClientFramebufferUpdateRequest, ClientEnableContinuousUpdates:
+		return 10, nil
+	case ClientKeyEvent:
+		return 8, nil
+	case ClientPointerEvent:
+		return 6, nil
+	case ClientCutText:
+		if len(header) < 8 {
+			return 0, nil
+		}
+		// Extended clipboard messages have negative lengths
+		textLength := int64(int32(binary.BigEndian.Uint32(header[4:8])))
+		return 8 + int(max(textLength, -textLength)), nil
+	case ClientFence:
+		if len(header) < 9 {
+			return 0, nil
+		}
+		return 9 + int(header[8]), nil
+	case ClientXVP:
+		return 4, nil
+	case ClientSetDesktopSize:
+		if len(header) < 8 {
+			return 0, nil
+		}
+		return 8 + 16*int(header[6]), nil
+	case ClientQEMU:
+		if len(header) < 2 {
+			return 0, nil
+		}
+		if header[1] == 0 { // Extended key event
+			return 12, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: client message type %d", ErrUnknownMessage, header[0])
+}
+
+// ServerMessageSplitter splits the data of an RFB server into its messages,
+// following framebuffer updates across reads. A message is buffered until it
+// is complete; its rectangles are parsed as their data arrive, so that large
+// updates are not parsed again on every read.
+//
+// Updates whose rectangles use encodings of unknown length, such as Tight,
+// fail with ErrUnknownMessage.
+type ServerMessageSplitter struct {
+	bytesPerPixel int
+
+	buf  []byte // Data of the current message and of those following it
+	off  int    // Bytes of the current message parsed
+	need int    // Length buf must reach for parsing to progress
+
+	// Current framebuffer update
+	inUpdate    bool
+	rects       int  // Rectangles left to parse
+	parsedRects int  // Rectangles parsed, LastRect excluded
+	idempotent  bool // Whether applying the update twice displays the same
+	tile        int  // Next tile of the current Hextile rectangle
+	tileOff     int  // Offset of the next tile of the current Hextile rectangle
+}
+
+// NewServerMessageSplitter returns a splitter of the data of a server whose
+// pixel format has bytesPerPixel bytes per pixel
+func NewServerMessageSplitter(bytesPerPixel int) *ServerMessageSplitter {
+	return &ServerMessageSplitter{bytesPerPixel: bytesPerPixel}
+}
+
+// SetBytesPerPixel changes the bytes per pixel of the updates parsed, e.g.
+// once the client sent SetPixelFormat
+func (s *ServerMessageSplitter) SetBytesPerPixel(bytesPerPixel int) {
+	s.bytesPerPixel = bytesPerPixel
+}
+
+// Write appends data read from the server. It does not retain data.
+func (s *ServerMessageSplitter) Write(data []byte) {
+	s.buf = append(s.buf, data...)
+}
+
+// Buffered returns the number of bytes written and not yet returned by Next
+func (s *ServerMessageSplitter) Buffered() int {
+	return len(s.buf)
+}
+
+// Drain returns the bytes written and not yet returned by Next, and resets
+// the splitter, e.g. to pass the rest of the data through after an error
+func (s *ServerMessageSplitter) Drain() []byte {
+	data := s.buf
+	*s = ServerMessageSplitter{bytesPerPixel: s.bytesPerPixel}
+	return data
+}
+
+// Next returns the next complete message, nil when more data is needed.
+// Framebuffer updates are idempotent when they have rectangles and all of
+// them replace pixels with data independent of the state of the viewer, so
+// that the update displays the same when applied again: Raw, RRE, Hextile
+// and cursor rectangles. CopyRect and ZRLE, whose zlib stream spans updates,
+// are not.
+func (s *ServerMessageSplitter) Next() (message []byte, idempotent bool, err error) {
+	if len(s.buf) == 0 || len(s.buf) < s.need {
+		return nil, false, nil
+	}
+
+	complete, err := s.parse()
+	if err != nil || !complete {
+		return nil, false, err
+	}
+
+	message = s.buf[:s.off:s.off]
+	idempotent = s.inUpdate && s.idempotent && s.parsedRects > 0
+	var rest []byte
+	if len(s.buf) > s.off {
+		rest = append(rest, s.buf[s.off:]...)
+	}
+	*s = ServerMessageSplitter{bytesPerPixel: s.bytesPerPixel, buf: rest}
+	return message, idempotent, nil
+}
+
+// have returns true when buf holds n bytes, otherwise noting that parsing
+// needs them
+func (s *ServerMessageSplitter) have(n int) bool {
+	if len(s.buf) >= n {
+		return true
+	}
+	s.need = n
+	return false
+}
+
+// parse parses the current message as far as buf allows, returning true
+// once it is complete, s.off then being its length
+func (s *ServerMessageSplitter) parse() (bool, error) {
+	if !s.inUpdate {
+		if s.buf[0] != ServerFramebufferUpdate {
+			return s.parseMessage()
+		}
+		if !s.have(framebufferUpdateHeaderLength) {
+			return false, nil
+		}
+		s.inUpdate = true
+		s.rects = int(binary.BigEndian.Uint16(s.buf[2:4]))
+		s.idempotent = true
+		s.off = framebufferUpdateHeaderLength
+	}
+
+	for s.rects > 0 {
+		if !s.have(s.off + rectangleHeaderLength) {
+			return false, nil
+		}
+		header := s.buf[s.off : s.off+rectangleHeaderLength]
+		width := int(binary.BigEndian.Uint16(header[4:6]))
+		height := int(binary.BigEndian.Uint16(header[6:8]))
+		encoding := int32(binary.BigEndian.Uint32(header[8:12]))
+		data := s.off + rectangleHeaderLength
+		bpp := s.bytesPerPixel
+
+		var end int
+		switch encoding {
+		case encodingRaw:
+			end = data + width*height*bpp
+		case encodingRRE:
+			if !s.have(data + 4) {
+				return false, nil
+			}
+			subrects := int(binary.BigEndian.Uint32(s.buf[data : data+4]))
+			end = data + 4 + bpp + subrects*(bpp+8)
+		case encodingHextile:
+			var ok bool
+			if end, ok = s.hextile(data, width, height); !ok {
+				return false, nil
+			}
+		case encodingCursor:
+			end = data + width*height*bpp + (width+7)/8*height
+		case encodingXCursor:
+			end = data
+			if width*height > 0 {
+				end += 6 + 2*((width+7)/8)*height
+			}
+		case encodingCopyRect:
+			end = data + 4
+			s.idempotent = false
+		case encodingZRLE, encodingDesktopName:
+			if !s.have(data + 4) {
+				return false, nil
+			}
+			end = data + 4 + int(binary.BigEndian.Uint32(s.buf[data:data+4]))
+			s.idempotent = false
+		case encodingExtendedDesktopSize:
+			if !s.have(data + 4) {
+				return false, nil
+			}
+			end = data + 4 + 16*int(s.buf[data])
+			s.idempotent = false
+		case encodingQEMULEDState:
+			end = data + 1
+			s.idempotent = false
+		case encodingDesktopSize, encodingQEMUExtendedKeyEvent, encodingFence, encodingContinuousUpdates:
+			// Resizes the display or acknowledges an extension
+			end = data
+			s.idempotent = false
+		case encodingLastRect:
+			s.off = data
+			return true, nil
+		default:
+			return false, fmt.Errorf("%w: rectangle encoding %d", ErrUnknownMessage, encoding)
+		}
+
+		if !s.have(end) {
+			return false, nil
+		}
+		s.off = end
+		s.rects--
+		s.parsedRects++
+		s.tile = 0
+	}
+	return true, nil
+}
+
+// parseMessage parses a server message other than a framebuffer update
+func (s *ServerMessageSplitter) parseMessage() (bool, error) {
+	var length int
+	switch s.buf[0] {
+	case ServerBell, ServerEndOfContinuousUpdates:
+		length = 1
+	case ServerXVP:
+		length = 4
+	case ServerSetColorMapEntries:
+		if !s.have(6) {
+			return false, nil
+		}
+		length = 6 + 6*int(binary.BigEndian.Uint16(s.buf[4:6]))
+	case ServerCutText:
+		if !s.have(8) {
+			return false, nil
+		}
+		// Extended clipboard messages have negative lengths
+		textLength := int64(int32(binary.BigEndian.Uint32(s.buf[4:8])))
+		length = 8 + int(max(textLength, -textLength))
+	case ServerFence:
+		if !s.have(9) {
+			return false, nil
+		}
+		length = 9 + int(s.buf[8])
+	default:
+		return false, fmt.Errorf("%w: server message type %d", ErrUnknownMessage, s.buf[0])
+	}
+
+	if !s.have(length) {
+		return false, nil
+	}
+	s.off = length
+	return true, nil
+}
+
+// hextile walks the tiles of a Hextile rectangle whose data start at data,
+// from the tile where the previous call stopped. It returns the end of the
+// rectangle, or false when more data is needed.
+func (s *ServerMessageSplitter) hextile(data, width, height int) (int, bool) {
+	if s.tile == 0 {
+		s.tileOff = data
+	}
+	columns := (width + 15) / 16
+	rows := (height + 15) / 16
+	bpp := s.bytesPerPixel
+
+	for ; s.tile < columns*rows; s.tile++ {
+		tileWidth := min(16, width-16*(s.tile%columns))
+		tileHeight := min(16, height-16*(s.tile/columns))
+
+		off := s.tileOff
+		if !s.have(off + 1) {
+			return 0, false
+		}
+		subencoding := s.buf[off]
+		off++
+
+		if subencoding&hextileRaw != 0 {
+			off += tileWidth * tileHeight * bpp
+		} else {
+			if subencoding&hextileBackground != 0 {
+				off += bpp
+			}
+			if subencoding&hextileForeground != 0 {
+				off += bpp
+			}
+			if subencoding&hextileAnySubrects != 0 {
+				if !s.have(off + 1) {
+					return 0, false
+				}
+				subrects := int(s.buf[off])
+				off++
+				subrectLength := 2
+				if subencoding&hextileSubrectsColoured != 0 {
+					subrectLength += bpp
+				}
+				off += subrects * subrectLength
+			}
+		}
+		s.tileOff = off
+	}
+	return s.tileOff, true
+}
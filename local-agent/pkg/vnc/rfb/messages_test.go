@@ -0,0 +1,164 @@
+package rfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// rect returns a rectangle header followed by its data
+func rect(width, height int, encoding int32, data ...byte) []byte {
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint16(header[4:6], uint16(width))
+	binary.BigEndian.PutUint16(header[6:8], uint16(height))
+	binary.BigEndian.PutUint32(header[8:12], uint32(encoding))
+	return append(header, data...)
+}
+
+// update returns a framebuffer update of rects
+func update(rects ...[]byte) []byte {
+	message := []byte{ServerFramebufferUpdate, 0, 0, 0}
+	binary.BigEndian.PutUint16(message[2:4], uint16(len(rects)))
+	for _, r := range rects {
+		message = append(message, r...)
+	}
+	return message
+}
+
+// TestServerMessageSplitter tests splitting server data into messages
+func TestServerMessageSplitter(t *testing.T) {
+	hextile := rect(20, 16, encodingHextile,
+		hextileRaw, // 16x16 raw tile
+	)
+	hextile = append(hextile, make([]byte, 16*16*4)...)
+	// 4x16 tile: background, 2 coloured subrects
+	hextile = append(hextile, hextileBackground|hextileAnySubrects|hextileSubrectsColoured)
+	hextile = append(hextile, make([]byte, 4+1+2*(4+2))...)
+	hextile[12+1+16*16*4+1+4] = 2
+
+	// Updates ended by a LastRect rectangle have 0xFFFF rectangles
+	lastRect := append([]byte{ServerFramebufferUpdate, 0, 0xFF, 0xFF}, rect(1, 1, encodingRaw, 1, 2, 3, 4)...)
+	lastRect = append(lastRect, rect(0, 0, encodingLastRect)...)
+
+	tests := []struct {
+		name           string
+		message        []byte
+		wantIdempotent bool
+	}{
+		{"raw", update(rect(2, 2, encodingRaw, make([]byte, 16)...)), true},
+		{"rre", update(rect(8, 8, encodingRRE, append([]byte{0, 0, 0, 1}, make([]byte, 4+4+8)...)...)), true},
+		{"hextile", update(hextile), true},
+		{"cursor", update(rect(9, 1, encodingCursor, make([]byte, 9*4+2)...)), true},
+		{"copyrect", update(rect(2, 2, encodingCopyRect, 0, 0, 0, 0)), false},
+		{"zrle", update(rect(64, 64, encodingZRLE, 0, 0, 0, 3, 1, 2, 3)), false},
+		{"desktop size", update(rect(1024, 768, encodingDesktopSize)), false},
+		{"raw and copyrect", update(rect(1, 1, encodingRaw, 1, 2, 3, 4), rect(1, 1, encodingCopyRect, 0, 0, 0, 0)), false},
+		{"last rect", lastRect, true},
+		{"no rectangles", update(), false},
+		{"bell", []byte{ServerBell}, false},
+		{"color map", []byte{ServerSetColorMapEntries, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}, false},
+		{"cut text", []byte{ServerCutText, 0, 0, 0, 0, 0, 0, 2, 'h', 'i'}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitter := NewServerMessageSplitter(4)
+			data := append(append([]byte(nil), tt.message...), ServerBell)
+
+			// Feed the data byte by byte, then as a whole
+			var messages [][]byte
+			var idempotent []bool
+			for i := range data {
+				splitter.Write(data[i : i+1])
+				for {
+					message, ok, err := splitter.Next()
+					if err != nil {
+						t.Fatalf("Next() error = %v", err)
+					}
+					if message == nil {
+						break
+					}
+					messages = append(messages, message)
+					idempotent = append(idempotent, ok)
+				}
+			}
+
+			if len(messages) != 2 {
+				t.Fatalf("Expected the message and a bell, got %d messages", len(messages))
+			}
+			if !bytes.Equal(messages[0], tt.message) {
+				t.Errorf("Message = %v, want %v", messages[0], tt.message)
+			}
+			if idempotent[0] != tt.wantIdempotent {
+				t.Errorf("Idempotent = %v, want %v", idempotent[0], tt.wantIdempotent)
+			}
+			if splitter.Buffered() != 0 {
+				t.Errorf("Expected no data buffered, got %d bytes", splitter.Buffered())
+			}
+
+			splitter.Write(data)
+			if message, _, err := splitter.Next(); err != nil || !bytes.Equal(message, tt.message) {
+				t.Errorf("Next() = %v, %v, want the message", message, err)
+			}
+		})
+	}
+}
+
+// TestServerMessageSplitterUnknown tests that data of unknown length fails
+func TestServerMessageSplitterUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"tight rectangle", update(rect(4, 4, 7, 0x80, 1, 2, 3))},
+		{"message type", []byte{42, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitter := NewServerMessageSplitter(4)
+			splitter.Write(tt.data)
+			if _, _, err := splitter.Next(); !errors.Is(err, ErrUnknownMessage) {
+				t.Fatalf("Expected ErrUnknownMessage, got %v", err)
+			}
+			if data := splitter.Drain(); !bytes.Equal(data, tt.data) {
+				t.Errorf("Drain() = %v, want the data written", data)
+			}
+		})
+	}
+}
+
+// TestClientMessageLength tests the lengths of client messages
+func TestClientMessageLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []byte
+		want    int
+		wantErr bool
+	}{
+		{"empty", nil, 0, false},
+		{"set pixel format", []byte{ClientSetPixelFormat}, 20, false},
+		{"set encodings header", []byte{ClientSetEncodings, 0, 0}, 0, false},
+		{"set encodings", []byte{ClientSetEncodings, 0, 0, 3}, 16, false},
+		{"update request", []byte{ClientFramebufferUpdateRequest}, 10, false},
+		{"key event", []byte{ClientKeyEvent}, 8, false},
+		{"pointer event", []byte{ClientPointerEvent}, 6, false},
+		{"cut text", []byte{ClientCutText, 0, 0, 0, 0, 0, 0, 5}, 13, false},
+		{"extended cut text", []byte{ClientCutText, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFC}, 12, false},
+		{"qemu key event", []byte{ClientQEMU, 0}, 12, false},
+		{"unknown", []byte{42}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClientMessageLength(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClientMessageLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ClientMessageLength() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
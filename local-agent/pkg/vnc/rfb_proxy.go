@@ -124,19 +124,9 @@ func (h *RFBProxyHandler) HandleBrowserHandshake(ctx context.Context, browserCon
 	// Step 5: Get cached ServerInit from transport
 	// The transport already sent ClientInit during authentication and cached the ServerInit response.
 	// We just need to replay it to the browser.
-	var serverInitData []byte
-
-	switch t := h.bmcTransport.(type) {
-	case *NativeTransport:
-		serverInitData = t.GetServerInit()
-	case *WebSocketTransport:
-		serverInitData = t.GetServerInit()
-	default:
-		return fmt.Errorf("unsupported transport type for ServerInit retrieval: %T", h.bmcTransport)
-	}
-
-	if len(serverInitData) == 0 {
-		return fmt.Errorf("ServerInit not cached - authentication may have failed")
+	serverInitData, err := serverInit(h.bmcTransport)
+	if err != nil {
+		return err
 	}
 
 	log.Debug().
@@ -153,6 +143,27 @@ func (h *RFBProxyHandler) HandleBrowserHandshake(ctx context.Context, browserCon
 	return nil
 }
 
+// serverInitCache is implemented by the transports caching the ServerInit
+// message of the server during authentication
+type serverInitCache interface {
+	GetServerInit() []byte
+}
+
+// serverInit returns the ServerInit message cached by an authenticated
+// transport
+func serverInit(transport Transport) ([]byte, error) {
+	cache, ok := transport.(serverInitCache)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transport type for ServerInit retrieval: %T", transport)
+	}
+
+	serverInitData := cache.GetServerInit()
+	if len(serverInitData) == 0 {
+		return nil, fmt.Errorf("ServerInit not cached - authentication may have failed")
+	}
+	return serverInitData, nil
+}
+
 // transportWriter adapts Transport to io.Writer for RFB protocol writer
 type transportWriter struct {
 	transport Transport
@@ -0,0 +1,326 @@
+package vnc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/vnc/rfb"
+)
+
+// maxBufferedUpdate bounds the framebuffer updates buffered to be compared
+// with the previous one. Larger updates stop skipping unchanged updates for
+// the rest of the stream.
+const maxBufferedUpdate = 64 << 20
+
+// ThrottleConfig configures the throttling of the framebuffer updates of a
+// VNC server, to cut the bandwidth of consoles whose display rarely changes
+type ThrottleConfig struct {
+	// MaxFPS bounds the rate of the incremental FramebufferUpdateRequests
+	// reaching the server, and so of its updates. 0 for no bound.
+	MaxFPS int
+
+	// SkipUnchanged replaces framebuffer updates identical to the previous
+	// one with empty updates, for servers repeating unchanged frames
+	SkipUnchanged bool
+
+	// OnSkip is told of the size of every update skipped
+	OnSkip func(bytes int)
+}
+
+// Enabled returns true when the config throttles updates
+func (c ThrottleConfig) Enabled() bool {
+	return c.MaxFPS > 0 || c.SkipUnchanged
+}
+
+// ThrottledTransport wraps the transport of an authenticated VNC server,
+// throttling its framebuffer updates once the viewer's handshake completed.
+//
+// The viewer's incremental FramebufferUpdateRequests are held until 1/MaxFPS
+// elapsed since the previous one, its other messages being forwarded in the
+// meantime. Viewers such as noVNC request the next update once they drew the
+// previous one, so servers answering requests update at most MaxFPS times a
+// second.
+//
+// Updates which render the same when applied twice and are byte-identical to
+// the previous update are replaced by empty updates, so that the viewer keeps
+// requesting updates. Updates after a SetPixelFormat, a non-incremental
+// request or a SetColorMapEntries message are always forwarded. Once a server
+// sends data the transport cannot delimit, such as Tight rectangles, the rest
+// of its data passes through.
+type ThrottledTransport struct {
+	Transport
+	config   ThrottleConfig
+	interval time.Duration
+
+	// Server data, read by a single goroutine
+	splitter *rfb.ServerMessageSplitter // nil when not skipping updates
+	seed     maphash.Seed
+	last     uint64 // Hash of the previous update, when idempotent
+	haveLast bool
+
+	// Client state changes invalidating the previous update
+	bytesPerPixel atomic.Int32
+	invalidated   atomic.Bool
+
+	// Client data
+	mu          sync.Mutex
+	pending     []byte // Incomplete message of the client
+	unparsed    bool   // Whether client messages are no longer parsed
+	lastRequest time.Time
+	held        []byte // Incremental FramebufferUpdateRequest held back
+	heldCtx     context.Context
+	timer       *time.Timer
+	err         error // Failure to send a held request
+}
+
+// Throttle wraps an authenticated transport, whose cached ServerInit tells
+// the initial pixel format of the server
+func Throttle(transport Transport, config ThrottleConfig) (*ThrottledTransport, error) {
+	serverInitData, err := serverInit(transport)
+	if err != nil {
+		return nil, err
+	}
+	bytesPerPixel, err := rfb.ServerInitBytesPerPixel(serverInitData)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &ThrottledTransport{
+		Transport: transport,
+		config:    config,
+		seed:      maphash.MakeSeed(),
+	}
+	if config.MaxFPS > 0 {
+		t.interval = time.Second / time.Duration(config.MaxFPS)
+	}
+	if config.SkipUnchanged {
+		t.splitter = rfb.NewServerMessageSplitter(bytesPerPixel)
+	}
+	t.bytesPerPixel.Store(int32(bytesPerPixel))
+	return t, nil
+}
+
+// Read reads the data of the server, with unchanged updates skipped
+func (t *ThrottledTransport) Read(ctx context.Context) ([]byte, error) {
+	for {
+		data, err := t.Transport.Read(ctx)
+		if err != nil || t.splitter == nil {
+			return data, err
+		}
+		if out := t.skipUnchanged(data); len(out) > 0 {
+			return out, nil
+		}
+	}
+}
+
+// skipUnchanged returns the complete messages of the server data read so
+// far, with the updates identical to the previous one skipped
+func (t *ThrottledTransport) skipUnchanged(data []byte) []byte {
+	t.splitter.SetBytesPerPixel(int(t.bytesPerPixel.Load()))
+	t.splitter.Write(data)
+
+	var out []byte
+	for {
+		message, idempotent, err := t.splitter.Next()
+		if err != nil {
+			log.Debug().Err(err).Msg("VNC server data not delimited, no longer skipping unchanged updates")
+			return t.passThrough(out)
+		}
+		if message == nil {
+			break
+		}
+		message = t.updateOf(message, idempotent)
+		if out == nil {
+			out = message
+		} else {
+			out = append(out, message...)
+		}
+	}
+
+	if t.splitter.Buffered() > maxBufferedUpdate {
+		log.Debug().Int("buffered", t.splitter.Buffered()).Msg("VNC update too large, no longer skipping unchanged updates")
+		return t.passThrough(out)
+	}
+	return out
+}
+
+// passThrough stops skipping updates, returning out followed by the server
+// data buffered
+func (t *ThrottledTransport) passThrough(out []byte) []byte {
+	out = append(out, t.splitter.Drain()...)
+	t.splitter = nil
+	return out
+}
+
+// updateOf returns the message to forward in place of a server message,
+// rfb.EmptyFramebufferUpdate for an unchanged update
+func (t *ThrottledTransport) updateOf(message []byte, idempotent bool) []byte {
+	if t.invalidated.Swap(false) {
+		t.haveLast = false
+	}
+	if message[0] != rfb.ServerFramebufferUpdate {
+		if message[0] == rfb.ServerSetColorMapEntries {
+			t.haveLast = false
+		}
+		return message
+	}
+	if !idempotent {
+		t.haveLast = false
+		return message
+	}
+
+	sum := maphash.Bytes(t.seed, message)
+	if t.haveLast && sum == t.last {
+		if t.config.OnSkip != nil {
+			t.config.OnSkip(len(message))
+		}
+		return append([]byte(nil), rfb.EmptyFramebufferUpdate...)
+	}
+	t.last, t.haveLast = sum, true
+	return message
+}
+
+// Write writes the data of the viewer, holding back the incremental update
+// requests sent faster than the max FPS
+func (t *ThrottledTransport) Write(ctx context.Context, data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+	if t.unparsed {
+		return t.Transport.Write(ctx, data)
+	}
+
+	t.pending = append(t.pending, data...)
+	var out []byte
+	off := 0
+	for off < len(t.pending) {
+		length, err := rfb.ClientMessageLength(t.pending[off:])
+		if err != nil {
+			log.Debug().Err(err).Msg("VNC viewer data not delimited, no longer throttling update requests")
+			t.unparsed = true
+			out = append(out, t.pending[off:]...)
+			off = len(t.pending)
+			t.sendHeld()
+			break
+		}
+		if length == 0 || off+length > len(t.pending) {
+			break
+		}
+		out = t.clientMessage(ctx, out, t.pending[off:off+length])
+		off += length
+	}
+	t.pending = append(t.pending[:0], t.pending[off:]...)
+
+	if len(out) == 0 {
+		return nil
+	}
+	return t.Transport.Write(ctx, out)
+}
+
+// clientMessage appends a complete message of the viewer to out, unless it
+// is an update request held back
+func (t *ThrottledTransport) clientMessage(ctx context.Context, out, message []byte) []byte {
+	switch message[0] {
+	case rfb.ClientSetPixelFormat:
+		t.bytesPerPixel.Store(int32(rfb.BytesPerPixel(message[4:])))
+		t.invalidated.Store(true)
+	case rfb.ClientFramebufferUpdateRequest:
+		return t.updateRequest(ctx, out, message)
+	}
+	return append(out, message...)
+}
+
+// updateRequest appends a FramebufferUpdateRequest to out, or holds it back
+// until 1/MaxFPS elapsed since the previous request. Requests arriving while
+// one is held are merged into it.
+func (t *ThrottledTransport) updateRequest(ctx context.Context, out, request []byte) []byte {
+	if t.held != nil {
+		request = mergeUpdateRequests(t.held, request)
+		t.held = nil
+		t.timer.Stop()
+	}
+
+	incremental := request[1] != 0
+	if !incremental {
+		t.invalidated.Store(true)
+	}
+	if wait := time.Until(t.lastRequest.Add(t.interval)); incremental && wait > 0 {
+		t.held = append([]byte(nil), request...)
+		t.heldCtx = ctx
+		var timer *time.Timer
+		timer = time.AfterFunc(wait, func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.timer == timer {
+				t.sendHeld()
+			}
+		})
+		t.timer = timer
+		return out
+	}
+
+	t.lastRequest = time.Now()
+	return append(out, request...)
+}
+
+// sendHeld sends the request held back, if any
+func (t *ThrottledTransport) sendHeld() {
+	if t.held == nil {
+		return
+	}
+	request := t.held
+	t.held = nil
+	t.timer.Stop()
+
+	t.lastRequest = time.Now()
+	if err := t.Transport.Write(t.heldCtx, request); err != nil && t.err == nil {
+		t.err = fmt.Errorf("failed to send held update request: %w", err)
+	}
+}
+
+// Close stops sending held requests and closes the transport
+func (t *ThrottledTransport) Close() error {
+	t.mu.Lock()
+	if t.held != nil {
+		t.held = nil
+		t.timer.Stop()
+	}
+	if t.err == nil {
+		t.err = errors.New("transport closed")
+	}
+	t.mu.Unlock()
+	return t.Transport.Close()
+}
+
+// mergeUpdateRequests returns a FramebufferUpdateRequest for the union of
+// the areas of two requests, incremental only when both are
+func mergeUpdateRequests(a, b []byte) []byte {
+	x := min(binary.BigEndian.Uint16(a[2:4]), binary.BigEndian.Uint16(b[2:4]))
+	y := min(binary.BigEndian.Uint16(a[4:6]), binary.BigEndian.Uint16(b[4:6]))
+	right := max(uint32(binary.BigEndian.Uint16(a[2:4]))+uint32(binary.BigEndian.Uint16(a[6:8])),
+		uint32(binary.BigEndian.Uint16(b[2:4]))+uint32(binary.BigEndian.Uint16(b[6:8])))
+	bottom := max(uint32(binary.BigEndian.Uint16(a[4:6]))+uint32(binary.BigEndian.Uint16(a[8:10])),
+		uint32(binary.BigEndian.Uint16(b[4:6]))+uint32(binary.BigEndian.Uint16(b[8:10])))
+
+	merged := make([]byte, 10)
+	merged[0] = rfb.ClientFramebufferUpdateRequest
+	if a[1] != 0 && b[1] != 0 {
+		merged[1] = 1
+	}
+	binary.BigEndian.PutUint16(merged[2:4], x)
+	binary.BigEndian.PutUint16(merged[4:6], y)
+	binary.BigEndian.PutUint16(merged[6:8], uint16(min(right-uint32(x), 0xFFFF)))
+	binary.BigEndian.PutUint16(merged[8:10], uint16(min(bottom-uint32(y), 0xFFFF)))
+	return merged
+}
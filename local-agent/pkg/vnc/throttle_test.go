@@ -0,0 +1,220 @@
+package vnc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"local-agent/pkg/vnc/rfb"
+)
+
+// throttleTestTransport is a transport of a 32 bpp server, reading queued
+// data and recording the data written
+type throttleTestTransport struct {
+	reads [][]byte
+
+	mu     sync.Mutex
+	writes [][]byte
+}
+
+func (f *throttleTestTransport) Read(ctx context.Context) ([]byte, error) {
+	data := f.reads[0]
+	f.reads = f.reads[1:]
+	return data, nil
+}
+
+func (f *throttleTestTransport) Write(ctx context.Context, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writes = append(f.writes, append([]byte(nil), data...))
+	return nil
+}
+
+func (f *throttleTestTransport) written() [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.writes
+}
+
+func (f *throttleTestTransport) Close() error      { return nil }
+func (f *throttleTestTransport) IsConnected() bool { return true }
+
+func (f *throttleTestTransport) GetServerInit() []byte {
+	serverInit := make([]byte, 24)
+	serverInit[4] = 32 // bits-per-pixel
+	return serverInit
+}
+
+// rawUpdate returns a framebuffer update of a 32 bpp 1x1 Raw rectangle
+func rawUpdate(pixel byte) []byte {
+	return []byte{
+		rfb.ServerFramebufferUpdate, 0, 0, 1,
+		0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0,
+		pixel, pixel, pixel, 0,
+	}
+}
+
+// updateRequest returns a FramebufferUpdateRequest of a 1024x768 display
+func updateRequest(incremental bool) []byte {
+	request := make([]byte, 10)
+	request[0] = rfb.ClientFramebufferUpdateRequest
+	if incremental {
+		request[1] = 1
+	}
+	binary.BigEndian.PutUint16(request[6:8], 1024)
+	binary.BigEndian.PutUint16(request[8:10], 768)
+	return request
+}
+
+// TestThrottledTransport_SkipsUnchanged tests that repeated updates become
+// empty updates
+func TestThrottledTransport_SkipsUnchanged(t *testing.T) {
+	update := rawUpdate(0xAA)
+	bmc := &throttleTestTransport{reads: [][]byte{
+		update,
+		update[:7], update[7:], // Same update, split across reads
+		rawUpdate(0xBB),
+		update,
+	}}
+
+	var skipped int
+	throttled, err := Throttle(bmc, ThrottleConfig{
+		SkipUnchanged: true,
+		OnSkip:        func(bytes int) { skipped += bytes },
+	})
+	if err != nil {
+		t.Fatalf("Throttle() error = %v", err)
+	}
+
+	want := [][]byte{update, rfb.EmptyFramebufferUpdate, rawUpdate(0xBB), update}
+	for i, expected := range want {
+		data, err := throttled.Read(context.Background())
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if !bytes.Equal(data, expected) {
+			t.Errorf("Read() #%d = %v, want %v", i, data, expected)
+		}
+	}
+	if skipped != len(update) {
+		t.Errorf("Expected %d bytes skipped, got %d", len(update), skipped)
+	}
+}
+
+// TestThrottledTransport_ForwardsAfterInvalidation tests that updates are
+// forwarded again once the viewer may have lost its display
+func TestThrottledTransport_ForwardsAfterInvalidation(t *testing.T) {
+	setPixelFormat := make([]byte, 20)
+	setPixelFormat[0] = rfb.ClientSetPixelFormat
+	setPixelFormat[4] = 32
+
+	tests := []struct {
+		name    string
+		message []byte
+	}{
+		{"set pixel format", setPixelFormat},
+		{"non-incremental request", updateRequest(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := rawUpdate(0xAA)
+			bmc := &throttleTestTransport{reads: [][]byte{update, update}}
+			throttled, err := Throttle(bmc, ThrottleConfig{SkipUnchanged: true})
+			if err != nil {
+				t.Fatalf("Throttle() error = %v", err)
+			}
+
+			if _, err := throttled.Read(context.Background()); err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if err := throttled.Write(context.Background(), tt.message); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if data, _ := throttled.Read(context.Background()); !bytes.Equal(data, update) {
+				t.Errorf("Expected the update forwarded, got %v", data)
+			}
+		})
+	}
+}
+
+// TestThrottledTransport_PassesThroughUnknown tests that data of encodings
+// of unknown length are forwarded untouched
+func TestThrottledTransport_PassesThroughUnknown(t *testing.T) {
+	tight := []byte{
+		rfb.ServerFramebufferUpdate, 0, 0, 1,
+		0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 7,
+		0x80, 1, 2, 3,
+	}
+	update := rawUpdate(0xAA)
+	bmc := &throttleTestTransport{reads: [][]byte{update, tight, update}}
+	throttled, err := Throttle(bmc, ThrottleConfig{SkipUnchanged: true})
+	if err != nil {
+		t.Fatalf("Throttle() error = %v", err)
+	}
+
+	for i, expected := range [][]byte{update, tight, update} {
+		if data, _ := throttled.Read(context.Background()); !bytes.Equal(data, expected) {
+			t.Errorf("Read() #%d = %v, want %v", i, data, expected)
+		}
+	}
+}
+
+// TestThrottledTransport_MaxFPS tests that incremental update requests are
+// held back to the max FPS, without delaying other input
+func TestThrottledTransport_MaxFPS(t *testing.T) {
+	bmc := &throttleTestTransport{}
+	throttled, err := Throttle(bmc, ThrottleConfig{MaxFPS: 10})
+	if err != nil {
+		t.Fatalf("Throttle() error = %v", err)
+	}
+	defer throttled.Close()
+
+	keyEvent := []byte{rfb.ClientKeyEvent, 1, 0, 0, 0, 0, 0, 0x61}
+	ctx := context.Background()
+	start := time.Now()
+	for _, data := range [][]byte{updateRequest(true), updateRequest(true), keyEvent, updateRequest(true)} {
+		if err := throttled.Write(ctx, data); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	writes := bmc.written()
+	if len(writes) != 2 || !bytes.Equal(writes[0], updateRequest(true)) || !bytes.Equal(writes[1], keyEvent) {
+		t.Fatalf("Expected the first request and the key event sent, got %v", writes)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(bmc.written()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	writes = bmc.written()
+	if len(writes) != 3 || !bytes.Equal(writes[2], updateRequest(true)) {
+		t.Fatalf("Expected the held requests merged and sent, got %v", writes)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the held request sent after 100ms, sent after %v", elapsed)
+	}
+
+	// Full refreshes are never held back
+	if err := throttled.Write(ctx, updateRequest(false)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if writes := bmc.written(); len(writes) != 4 {
+		t.Errorf("Expected the non-incremental request sent, got %v", writes)
+	}
+}
+
+// TestMergeUpdateRequests tests the union of the areas of update requests
+func TestMergeUpdateRequests(t *testing.T) {
+	a := []byte{rfb.ClientFramebufferUpdateRequest, 1, 0, 10, 0, 10, 0, 10, 0, 10}
+	b := []byte{rfb.ClientFramebufferUpdateRequest, 0, 0, 0, 0, 15, 0, 5, 0, 20}
+
+	want := []byte{rfb.ClientFramebufferUpdateRequest, 0, 0, 0, 0, 10, 0, 20, 0, 25}
+	if got := mergeUpdateRequests(a, b); !bytes.Equal(got, want) {
+		t.Errorf("mergeUpdateRequests() = %v, want %v", got, want)
+	}
+}
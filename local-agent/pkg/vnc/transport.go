@@ -3,6 +3,8 @@ package vnc
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 )
 
@@ -74,6 +76,9 @@ type Endpoint struct {
 	Username string
 	Password string
 	TLS      *TLSConfig // Optional TLS configuration for encrypted VNC connections
+
+	// Largest WebSocket message read from the BMC, 0 for no limit
+	MaxMessageSize int64
 }
 
 // TLSConfig represents TLS/SSL configuration for VNC connections
@@ -106,7 +111,9 @@ func NewTransport(endpoint *Endpoint) (Transport, error) {
 
 	case TypeWebSocket:
 		// WebSocket-based VNC connection
-		return NewWebSocketTransport(0), nil
+		transport := NewWebSocketTransport(0)
+		transport.maxMessageSize = endpoint.MaxMessageSize
+		return transport, nil
 
 	default:
 		return nil, fmt.Errorf("unable to detect transport type from endpoint: %s", endpoint.Endpoint)
@@ -184,7 +191,8 @@ func ConnectTransport(ctx context.Context, transport Transport, endpoint *Endpoi
 }
 
 // parseEndpoint parses a VNC endpoint string to extract host and port
-// Supports formats: "host:port", "vnc://host:port", "host" (defaults to port 5900)
+// Supports formats: "host:port", "vnc://host:port", "host" (defaults to port 5900),
+// with IPv6 addresses bracketed when a port follows, e.g. "[fd00::10]:5900"
 func parseEndpoint(endpoint string) (string, int, error) {
 	// If it looks like a WebSocket URL, it's probably misconfigured
 	if strings.HasPrefix(endpoint, "ws://") || strings.HasPrefix(endpoint, "wss://") {
@@ -196,6 +204,11 @@ func parseEndpoint(endpoint string) (string, int, error) {
 		return parseEndpointURL(endpoint)
 	}
 
+	// An IPv6 address without port, e.g. "fd00::10" or "[fd00::10]"
+	if ip := net.ParseIP(strings.Trim(endpoint, "[]")); ip != nil {
+		return ip.String(), 5900, nil
+	}
+
 	// Try parsing as host:port
 	if strings.Contains(endpoint, ":") {
 		return parseHostPort(endpoint)
@@ -207,7 +220,7 @@ func parseEndpoint(endpoint string) (string, int, error) {
 
 // parseEndpointURL parses a URL-formatted VNC endpoint
 func parseEndpointURL(endpoint string) (string, int, error) {
-	u, err := parseURL(endpoint)
+	u, err := url.Parse(endpoint)
 	if err != nil {
 		return "", 0, fmt.Errorf("invalid URL format: %w", err)
 	}
@@ -225,39 +238,17 @@ func parseEndpointURL(endpoint string) (string, int, error) {
 
 // parseHostPort parses a host:port formatted endpoint
 func parseHostPort(endpoint string) (string, int, error) {
-	parts := strings.Split(endpoint, ":")
-	if len(parts) != 2 {
-		return "", 0, fmt.Errorf("invalid host:port format")
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid host:port format: %w", err)
 	}
-	host := parts[0]
-	port, err := parseInt(parts[1])
+	port, err := parseInt(portStr)
 	if err != nil {
-		return "", 0, fmt.Errorf("invalid port %s: %w", parts[1], err)
+		return "", 0, fmt.Errorf("invalid port %s: %w", portStr, err)
 	}
 	return host, port, nil
 }
 
-// Helper functions to avoid import conflicts
-func parseURL(s string) (*simpleURL, error) {
-	u := &simpleURL{}
-	// Simple URL parsing - extract scheme, host, port
-	if idx := strings.Index(s, "://"); idx > 0 {
-		u.scheme = s[:idx]
-		rest := s[idx+3:]
-
-		// Extract path if present
-		if pathIdx := strings.Index(rest, "/"); pathIdx > 0 {
-			u.host = rest[:pathIdx]
-			u.path = rest[pathIdx:]
-		} else {
-			u.host = rest
-		}
-
-		return u, nil
-	}
-	return nil, fmt.Errorf("invalid URL: missing scheme")
-}
-
 func parseInt(s string) (int, error) {
 	var result int
 	for _, c := range s {
@@ -268,23 +259,3 @@ func parseInt(s string) (int, error) {
 	}
 	return result, nil
 }
-
-type simpleURL struct {
-	scheme string
-	host   string
-	path   string
-}
-
-func (u *simpleURL) Hostname() string {
-	if idx := strings.Index(u.host, ":"); idx > 0 {
-		return u.host[:idx]
-	}
-	return u.host
-}
-
-func (u *simpleURL) Port() string {
-	if idx := strings.Index(u.host, ":"); idx > 0 {
-		return u.host[idx+1:]
-	}
-	return ""
-}
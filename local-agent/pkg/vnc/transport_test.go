@@ -127,6 +127,38 @@ func TestParseEndpoint(t *testing.T) {
 			wantErr:  false,
 		},
 
+		// IPv6 endpoints
+		{
+			name:     "IPv6 address and port",
+			endpoint: "[fd00::10]:5901",
+			wantHost: "fd00::10",
+			wantPort: 5901,
+		},
+		{
+			name:     "IPv6 address only (defaults to 5900)",
+			endpoint: "fd00::10",
+			wantHost: "fd00::10",
+			wantPort: 5900,
+		},
+		{
+			name:     "Bracketed IPv6 address only (defaults to 5900)",
+			endpoint: "[2001:db8::1]",
+			wantHost: "2001:db8::1",
+			wantPort: 5900,
+		},
+		{
+			name:     "VNC scheme with IPv6 address",
+			endpoint: "vnc://[fd00::10]:5902",
+			wantHost: "fd00::10",
+			wantPort: 5902,
+		},
+		{
+			name:     "VNC scheme with IPv6 address without port",
+			endpoint: "vnc://[fd00::10]",
+			wantHost: "fd00::10",
+			wantPort: 5900,
+		},
+
 		// Invalid endpoints
 		{
 			name:     "WebSocket URL (should error)",
@@ -148,6 +180,11 @@ func TestParseEndpoint(t *testing.T) {
 			endpoint: "http://[::1:5900",
 			wantErr:  true,
 		},
+		{
+			name:     "Unbracketed IPv6 address with port",
+			endpoint: "fd00::10:5900:x",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,6 +3,7 @@ package vnc
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -11,6 +12,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
 
+	"local-agent/pkg/bmcproxy"
 	"local-agent/pkg/vnc/rfb"
 )
 
@@ -23,6 +25,7 @@ import (
 type WebSocketTransport struct {
 	conn           *websocket.Conn
 	timeout        time.Duration
+	maxMessageSize int64  // Largest message read, 0 for no limit
 	serverInitData []byte // Cached ServerInit message for RFB proxy mode
 }
 
@@ -64,15 +67,23 @@ func (t *WebSocketTransport) Connect(ctx context.Context, endpoint, username, pa
 
 	// Create WebSocket dialer with timeout
 	dialer := &websocket.Dialer{
+		NetDialContext:   bmcproxy.NewDialer(t.timeout).DialContext,
 		HandshakeTimeout: t.timeout,
 		Subprotocols:     []string{"binary", "rfb"}, // RFB is the VNC protocol
 	}
 
 	// Dial WebSocket connection
-	conn, _, err := dialer.DialContext(ctx, wsURL.String(), headers)
+	conn, resp, err := dialer.DialContext(ctx, wsURL.String(), headers)
 	if err != nil {
+		// The BMC rejects the Basic credentials before the upgrade
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return fmt.Errorf("%w: WebSocket VNC at %s returned %s", rfb.ErrAuthenticationFailed, wsURL.String(), resp.Status)
+		}
 		return fmt.Errorf("failed to connect to WebSocket VNC at %s: %w", wsURL.String(), err)
 	}
+	if t.maxMessageSize > 0 {
+		conn.SetReadLimit(t.maxMessageSize)
+	}
 
 	t.conn = conn
 	return nil
@@ -370,6 +381,9 @@ func (t *WebSocketTransport) Read(ctx context.Context) ([]byte, error) {
 		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 			return nil, fmt.Errorf("WebSocket VNC connection closed: %w", err)
 		}
+		if errors.Is(err, websocket.ErrReadLimit) {
+			return nil, fmt.Errorf("WebSocket VNC message larger than %d bytes: %w", t.maxMessageSize, err)
+		}
 		return nil, fmt.Errorf("WebSocket VNC read error: %w", err)
 	}
 
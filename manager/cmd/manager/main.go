@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"net/http"
 	"os"
 	"time"
@@ -11,11 +12,19 @@ import (
 	"github.com/rs/zerolog/log"
 
 	baseconf "core/config"
+	"core/eventbus"
+	"core/events"
+	"core/logging"
+	coremetrics "core/metrics"
+	"core/tracing"
 	managerv1 "manager/gen/manager/v1"
 	"manager/gen/manager/v1/managerv1connect"
 	"manager/internal/database"
 	"manager/internal/manager"
 	"manager/internal/metrics"
+	"manager/internal/rest"
+	"manager/internal/slo"
+	"manager/internal/webhook"
 	"manager/internal/webui"
 	"manager/pkg/auth"
 	"manager/pkg/config"
@@ -26,17 +35,48 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// recentEventLogSize is the number of system events kept in memory for the
+// admin server pages
+const recentEventLogSize = 1000
+
 func init() {
 	// Configure zerolog for human-friendly console output
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})
+	log.Logger = log.Output(logging.NewRedactWriter(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.Kitchen})).Hook(logging.ModuleLevelHook)
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 }
 
 func main() {
+	// Parse command line flags
+	var validate bool
+	var validateFormat string
+	var envDocs bool
+	flag.BoolVar(&validate, "validate-config", false, "Validate the configuration and check the endpoints it declares, then exit")
+	flag.StringVar(&validateFormat, "validate-format", "text", "Format of the validation report: text or json")
+	flag.BoolVar(&envDocs, "env-docs", false, "Print the environment variables of the configuration as Markdown, then exit")
+	flag.Parse()
+
+	if envDocs {
+		if err := baseconf.WriteEnvDocs(os.Stdout, "manager", &config.Config{}); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write environment variables")
+		}
+		return
+	}
+
 	// Load configuration
 	configFile := baseconf.FindConfigFile("manager")
 	envFile := baseconf.FindEnvironmentFile("manager")
 
+	if validate {
+		report := validateConfig(context.Background(), configFile, envFile)
+		if err := report.Write(os.Stdout, validateFormat); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write validation report")
+		}
+		if !report.Valid {
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.Load(configFile, envFile)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load configuration")
@@ -53,6 +93,18 @@ func main() {
 		Bool("debug", cfg.Log.Debug).
 		Msg("Log level configured")
 
+	// Install the tracer provider before any Connect client or handler is created
+	shutdownTracing, err := tracing.Setup(context.Background(), "manager", cfg.Tracing)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+	defer shutdownTracing(context.Background())
+	log.Info().
+		Bool("enabled", cfg.Tracing.Enabled).
+		Str("endpoint", cfg.Tracing.Endpoint).
+		Float64("sample_ratio", cfg.Tracing.SampleRatio).
+		Msg("Tracing configured")
+
 	// Initialize database with debug option from config
 	db, err := database.New(cfg.Database.DSN, database.WithDebug(cfg.Log.Debug))
 	if err != nil {
@@ -77,16 +129,54 @@ func main() {
 
 	// Initialize Connect handler
 	managerHandler := manager.NewBMCManagerServiceHandler(db, jwtManager, cfg.Auth.AdminEmails)
+	managerHandler.SetSessionQuota(cfg.Manager.SessionQuota.MaxConcurrentSessions, cfg.Manager.SessionQuota.MaxSessionsPerMinute)
+	managerHandler.SetGatewayApproval(cfg.Manager.GatewayDiscovery.RequireApproval)
+	gatewayAccounts, err := cfg.Auth.GatewayAccountEmails()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid gateway accounts")
+	}
+	if len(gatewayAccounts) == 0 {
+		log.Warn().Msg("No gateway accounts configured - only admins may report gateway events and measurements")
+	}
+	managerHandler.SetGatewayAccounts(gatewayAccounts)
+	managerHandler.SetPasswordPolicy(manager.PasswordPolicy{
+		MinLength:       cfg.Manager.CustomerManagement.PasswordMinLength,
+		MaxFailedLogins: cfg.Auth.MaxFailedLogins,
+		LockoutDuration: cfg.Auth.LockoutDuration,
+		DemoAccounts:    cfg.Auth.DemoAccounts,
+	})
+	if cfg.Auth.DemoAccounts {
+		log.Warn().Msg("Demo accounts enabled - accounts without a password accept any password")
+	}
+	if cfg.Auth.AdminPassword != "" {
+		if err := managerHandler.BootstrapAdmins(context.Background(), cfg.Auth.AdminPassword); err != nil {
+			log.Fatal().Err(err).Msg("Failed to set the initial admin password")
+		}
+	}
 
 	// Initialize Admin service handler
-	adminHandler := manager.NewAdminServiceHandler(db, jwtManager)
+	sloObjectives := slo.Objectives{
+		AvailabilityTarget: cfg.Manager.ConsoleSLO.AvailabilityTarget,
+		TTFBTarget:         cfg.Manager.ConsoleSLO.TTFBTarget,
+		TTFBThreshold:      cfg.Manager.ConsoleSLO.TTFBThreshold,
+		Window:             cfg.Manager.ConsoleSLO.Window,
+	}
+	adminHandler := manager.NewAdminServiceHandler(db, jwtManager, sloObjectives)
+	adminHandler.SetMaxPowerSampleGap(cfg.Manager.PowerMetering.MaxSampleGap)
+	adminHandler.SetAPIKeyLength(cfg.Manager.CustomerManagement.APIKeyLength)
+	adminHandler.SetPasswordResetTTL(cfg.Auth.PasswordResetTTL)
+
+	// Keep recent system events for the admin server pages
+	eventLog := manager.NewEventLog(recentEventLogSize)
+	managerHandler.SetEventLog(eventLog)
+	adminHandler.SetEventLog(eventLog)
 
 	// Create interceptors
-	interceptors := connect.WithInterceptors(managerHandler.AuthInterceptor())
+	interceptors := connect.WithInterceptors(tracing.NewInterceptor(), managerHandler.AuthInterceptor())
 
 	// Create admin interceptor (requires admin privileges)
-	adminAuthInterceptor := auth.NewAdminAuthInterceptor(jwtManager)
-	adminInterceptors := connect.WithInterceptors(adminAuthInterceptor)
+	adminAuthInterceptor := auth.NewAdminAuthInterceptor(jwtManager).WithAccessCheck(managerHandler.CheckCustomerAccess)
+	adminInterceptors := connect.WithInterceptors(tracing.NewInterceptor(), adminAuthInterceptor)
 
 	// Create the Connect service handler
 	path, handler := managerv1connect.NewBMCManagerServiceHandler(
@@ -141,9 +231,24 @@ func main() {
 		w.Write(jsonBytes)
 	})
 
+	// Add REST/JSON API for clients that don't speak Connect
+	mux.Handle(rest.PathPrefix, rest.NewHandler(managerHandler, cfg.Manager.REST.GatewayTimeout))
+
 	// Add Prometheus metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
+	// Add the effective configuration, secrets redacted
+	if cfg.Debug.ConfigEndpoint {
+		mux.Handle("/config", baseconf.ConfigHandler("manager", func() interface{} { return cfg }))
+		log.Warn().Msg("Configuration debug endpoint enabled on /config")
+	}
+	if cfg.Debug.Diagnostics {
+		diagnostics := cfg.Debug.DiagnosticsHandler()
+		mux.Handle("/debug/pprof/", diagnostics)
+		mux.Handle("/debug/vars", diagnostics)
+		log.Warn().Msg("Diagnostics endpoints enabled on /debug/pprof/ and /debug/vars")
+	}
+
 	// Add login page
 	loginHandler := webui.NewLoginHandler()
 	mux.Handle("/login", loginHandler)
@@ -156,6 +261,10 @@ func main() {
 	adminDashboardHandler := webui.NewAdminDashboardHandler(jwtManager)
 	mux.Handle("/admin", adminDashboardHandler)
 
+	// Add admin server detail pages
+	adminServerHandler := webui.NewAdminServerHandler(jwtManager)
+	mux.Handle("/admin/servers/{serverID}", adminServerHandler)
+
 	// Add CORS and metrics middleware for web clients
 	corsHandler := addCORS(metrics.HTTPMetricsMiddleware(mux))
 
@@ -165,6 +274,73 @@ func main() {
 	go metricsCollector.Start(ctx)
 	defer metricsCollector.Stop()
 
+	// Prune console SLI measurements outside the retention period
+	adminHandler.StartConsoleSLIRetention(ctx, cfg.Manager.ConsoleSLO.Retention)
+
+	// Prune power readings outside the retention period
+	adminHandler.StartPowerReadingRetention(ctx, cfg.Manager.PowerMetering.Retention)
+
+	// Poll the live status of gateways for the system status
+	if interval := cfg.Manager.GatewayStatus.PollInterval; interval > 0 {
+		managerHandler.StartGatewayStatusPolling(ctx, interval)
+	}
+
+	// Audit the credentials and consoles of every server's BMC
+	audits := cfg.Manager.ConnectivityAudit
+	adminHandler.SetConnectivityAuditOptions(audits.Timeout, audits.CheckConsoles)
+	if audits.Interval > 0 {
+		adminHandler.StartConnectivityAudits(ctx, audits.Interval, audits.Concurrency)
+	}
+
+	// Deliver system events to the configured webhook endpoints
+	if webhooks := cfg.Manager.Webhooks; len(webhooks.Endpoints) > 0 {
+		endpoints := make([]webhook.Endpoint, 0, len(webhooks.Endpoints))
+		for _, e := range webhooks.Endpoints {
+			endpoint := webhook.Endpoint{Name: e.Name, URL: e.URL, Secret: e.SigningSecret()}
+			for _, eventType := range e.Events {
+				endpoint.Events = append(endpoint.Events, events.Type(eventType))
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+
+		dispatcher := webhook.NewDispatcher(endpoints, db.WebhookDeliveries, webhook.Config{
+			Timeout:        webhooks.Timeout,
+			MaxAttempts:    webhooks.MaxAttempts,
+			InitialBackoff: webhooks.InitialBackoff,
+			MaxBackoff:     webhooks.MaxBackoff,
+			QueueSize:      webhooks.QueueSize,
+		})
+		dispatcher.Start(ctx)
+		dispatcher.StartRetention(ctx, webhooks.DeliveryRetention)
+
+		managerHandler.SetEventPublisher(dispatcher)
+		log.Info().Int("endpoints", len(endpoints)).Msg("Webhook notifications enabled")
+	}
+
+	// Publish system events to the event bus
+	if cfg.EventBus.Enabled {
+		bus, err := eventbus.Connect(ctx, cfg.EventBus)
+		if err != nil {
+			log.Fatal().Err(err).Str("driver", cfg.EventBus.Driver).Msg("Failed to connect to the event bus")
+		}
+		defer bus.Close()
+
+		publisher := eventbus.NewPublisher(bus, cfg.EventBus)
+		publisher.SetObserver(coremetrics.EventBusObserver(metrics.EventBusEventsTotal))
+		publisher.Start(ctx)
+
+		managerHandler.SetEventBus(publisher)
+		log.Info().
+			Str("driver", cfg.EventBus.Driver).
+			Str("subject_prefix", cfg.EventBus.SubjectPrefix).
+			Msg("Event bus publishing enabled")
+	}
+
+	// Watch gateway liveness when events have a consumer
+	if len(cfg.Manager.Webhooks.Endpoints) > 0 || cfg.EventBus.Enabled {
+		managerHandler.StartGatewayMonitor(ctx, 30*time.Second)
+	}
+
 	// Create server with HTTP/2 support
 	server := &http.Server{
 		Addr:           cfg.GetListenAddress(),
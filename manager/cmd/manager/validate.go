@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	baseconf "core/config"
+	"manager/pkg/config"
+)
+
+// validateConfig loads and validates the configuration, and checks that the
+// endpoints it declares are reachable, without starting the manager
+func validateConfig(ctx context.Context, configFile, envFile string) *baseconf.ValidationReport {
+	report := baseconf.NewValidationReport("manager", configFile, envFile)
+	report.AddFiles()
+
+	cfg, err := config.Load(configFile, envFile)
+	report.AddResult("configuration", err)
+	if err != nil {
+		return report
+	}
+
+	report.Add(baseconf.CheckListen(cfg.GetListenAddress()))
+	report.Add(checkDatabase(cfg.Database.DSN))
+	report.CheckTLS(cfg.TLS)
+	report.CheckTracing(ctx, cfg.Tracing)
+	report.CheckEventBus(ctx, cfg.EventBus)
+	for _, endpoint := range cfg.Manager.Webhooks.Endpoints {
+		report.Add(baseconf.CheckReachable(ctx, "webhook "+endpoint.Name, endpoint.URL, "", nil))
+	}
+	return report
+}
+
+// checkDatabase checks that the directory of the SQLite database exists and
+// is writable, without opening the database, which would create it and run
+// the migrations
+func checkDatabase(dsn string) baseconf.Check {
+	path, _, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	check := baseconf.Check{Name: "database", Target: path}
+	if path == ":memory:" || strings.Contains(dsn, "mode=memory") {
+		check.Status = baseconf.CheckWarning
+		check.Detail = "in-memory database, data is lost on restart"
+		return check
+	}
+
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		check.Status = baseconf.CheckFailed
+		check.Detail = fmt.Sprintf("database directory: %v", err)
+		return check
+	}
+	if !info.IsDir() {
+		check.Status = baseconf.CheckFailed
+		check.Detail = fmt.Sprintf("%s is not a directory", dir)
+		return check
+	}
+	probe, err := os.CreateTemp(dir, ".manager-validate-*")
+	if err != nil {
+		check.Status = baseconf.CheckFailed
+		check.Detail = fmt.Sprintf("database directory is not writable: %v", err)
+		return check
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	check.Status = baseconf.CheckOK
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		check.Detail = "created on first start"
+	}
+	return check
+}
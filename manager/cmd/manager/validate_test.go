@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	baseconf "core/config"
+)
+
+func TestCheckDatabase(t *testing.T) {
+	dir := t.TempDir()
+
+	check := checkDatabase("file:" + filepath.Join(dir, "manager.db") + "?_pragma=foreign_keys(1)")
+	assert.Equal(t, baseconf.CheckOK, check.Status, check.Detail)
+	assert.Equal(t, filepath.Join(dir, "manager.db"), check.Target)
+	assert.Equal(t, "created on first start", check.Detail)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "validation creates no database")
+
+	check = checkDatabase(filepath.Join(dir, "missing", "manager.db"))
+	assert.Equal(t, baseconf.CheckFailed, check.Status)
+
+	check = checkDatabase("file::memory:?cache=shared")
+	assert.Equal(t, baseconf.CheckWarning, check.Status)
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "a-very-long-secret-key-for-validation-tests")
+	t.Setenv("DATABASE_URL", "file:"+filepath.Join(t.TempDir(), "manager.db"))
+
+	// A free port, which the listen check binds
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	configFile := filepath.Join(t.TempDir(), "manager.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf("manager:\n  host: 127.0.0.1\n  port: %d\n", port)), 0o644))
+	report := validateConfig(context.Background(), configFile, "")
+	assert.True(t, report.Valid, "%+v", report.Checks)
+
+	t.Setenv("JWT_SECRET_KEY", "short")
+	report = validateConfig(context.Background(), configFile, "")
+	assert.False(t, report.Valid)
+	assert.Equal(t, baseconf.CheckFailed, report.Checks[len(report.Checks)-1].Status)
+}
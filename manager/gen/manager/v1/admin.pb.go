@@ -7,6 +7,7 @@
 package managerv1
 
 import (
+	v1 "core/gen/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -297,20 +298,23 @@ func (x *ListAllServersResponse) GetTotalCount() int32 {
 }
 
 type ServerDetails struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	CustomerId      string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	DatacenterId    string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
-	GatewayId       string                 `protobuf:"bytes,4,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
-	PrimaryEndpoint string                 `protobuf:"bytes,5,opt,name=primary_endpoint,json=primaryEndpoint,proto3" json:"primary_endpoint,omitempty"`
-	PrimaryProtocol string                 `protobuf:"bytes,6,opt,name=primary_protocol,json=primaryProtocol,proto3" json:"primary_protocol,omitempty"` // "ipmi" or "redfish"
-	Status          string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                          // "online", "offline", "unknown"
-	HasVnc          bool                   `protobuf:"varint,8,opt,name=has_vnc,json=hasVnc,proto3" json:"has_vnc,omitempty"`
-	HasSol          bool                   `protobuf:"varint,9,opt,name=has_sol,json=hasSol,proto3" json:"has_sol,omitempty"`
-	LastSeen        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ServerId          string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CustomerId        string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	DatacenterId      string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	GatewayId         string                 `protobuf:"bytes,4,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	PrimaryEndpoint   string                 `protobuf:"bytes,5,opt,name=primary_endpoint,json=primaryEndpoint,proto3" json:"primary_endpoint,omitempty"`
+	PrimaryProtocol   string                 `protobuf:"bytes,6,opt,name=primary_protocol,json=primaryProtocol,proto3" json:"primary_protocol,omitempty"` // "ipmi" or "redfish"
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                          // "online", "offline", "unknown"
+	HasVnc            bool                   `protobuf:"varint,8,opt,name=has_vnc,json=hasVnc,proto3" json:"has_vnc,omitempty"`
+	HasSol            bool                   `protobuf:"varint,9,opt,name=has_sol,json=hasSol,proto3" json:"has_sol,omitempty"`
+	LastSeen          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MaintenanceMode   bool                   `protobuf:"varint,12,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"`
+	DiscoveryMetadata *v1.DiscoveryMetadata  `protobuf:"bytes,13,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"` // How the agent discovered the BMC, and its vendor and firmware
+	ConnectivityAudit *ConnectivityAudit     `protobuf:"bytes,14,opt,name=connectivity_audit,json=connectivityAudit,proto3" json:"connectivity_audit,omitempty"` // Last connectivity audit of the BMC, unset until audited
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ServerDetails) Reset() {
@@ -420,6 +424,27 @@ func (x *ServerDetails) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ServerDetails) GetMaintenanceMode() bool {
+	if x != nil {
+		return x.MaintenanceMode
+	}
+	return false
+}
+
+func (x *ServerDetails) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
+	if x != nil {
+		return x.DiscoveryMetadata
+	}
+	return nil
+}
+
+func (x *ServerDetails) GetConnectivityAudit() *ConnectivityAudit {
+	if x != nil {
+		return x.ConnectivityAudit
+	}
+	return nil
+}
+
 // List customers with server counts (admin only)
 type ListAllCustomersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -526,15 +551,21 @@ func (x *ListAllCustomersResponse) GetNextPageToken() string {
 }
 
 type CustomerSummary struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId        string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Email             string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	ServerCount       int32                  `protobuf:"varint,3,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`
-	OnlineServerCount int32                  `protobuf:"varint,4,opt,name=online_server_count,json=onlineServerCount,proto3" json:"online_server_count,omitempty"`
-	IsAdmin           bool                   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId            string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Email                 string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	ServerCount           int32                  `protobuf:"varint,3,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`
+	OnlineServerCount     int32                  `protobuf:"varint,4,opt,name=online_server_count,json=onlineServerCount,proto3" json:"online_server_count,omitempty"`
+	IsAdmin               bool                   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MaxConcurrentSessions int32                  `protobuf:"varint,7,opt,name=max_concurrent_sessions,json=maxConcurrentSessions,proto3" json:"max_concurrent_sessions,omitempty"` // Console session limits: 0 for the manager's default, -1 for no limit
+	MaxSessionsPerMinute  int32                  `protobuf:"varint,8,opt,name=max_sessions_per_minute,json=maxSessionsPerMinute,proto3" json:"max_sessions_per_minute,omitempty"`
+	Disabled              bool                   `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`                           // Access revoked by DisableCustomer
+	TotpEnabled           bool                   `protobuf:"varint,10,opt,name=totp_enabled,json=totpEnabled,proto3" json:"totp_enabled,omitempty"` // Two-factor authentication enrolled
+	RequireMfa            bool                   `protobuf:"varint,11,opt,name=require_mfa,json=requireMfa,proto3" json:"require_mfa,omitempty"`    // Console sessions require two-factor authentication
+	IpAllowlist           []string               `protobuf:"bytes,12,rep,name=ip_allowlist,json=ipAllowlist,proto3" json:"ip_allowlist,omitempty"`  // Source IP ranges of BMC access, empty for any
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CustomerSummary) Reset() {
@@ -609,6 +640,48 @@ func (x *CustomerSummary) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *CustomerSummary) GetMaxConcurrentSessions() int32 {
+	if x != nil {
+		return x.MaxConcurrentSessions
+	}
+	return 0
+}
+
+func (x *CustomerSummary) GetMaxSessionsPerMinute() int32 {
+	if x != nil {
+		return x.MaxSessionsPerMinute
+	}
+	return 0
+}
+
+func (x *CustomerSummary) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *CustomerSummary) GetTotpEnabled() bool {
+	if x != nil {
+		return x.TotpEnabled
+	}
+	return false
+}
+
+func (x *CustomerSummary) GetRequireMfa() bool {
+	if x != nil {
+		return x.RequireMfa
+	}
+	return false
+}
+
+func (x *CustomerSummary) GetIpAllowlist() []string {
+	if x != nil {
+		return x.IpAllowlist
+	}
+	return nil
+}
+
 // Gateway health metrics (admin only)
 type GetGatewayHealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -695,7 +768,7 @@ type GatewayHealth struct {
 	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
 	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
 	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "active", "degraded", "offline"
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "active", "degraded", "offline", or "pending" approval
 	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 	ServerCount   int32                  `protobuf:"varint,6,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`
 	DatacenterIds []string               `protobuf:"bytes,7,rep,name=datacenter_ids,json=datacenterIds,proto3" json:"datacenter_ids,omitempty"`
@@ -867,6 +940,7 @@ func (x *GetRegionsResponse) GetRegions() []string {
 type LaunchSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // Server ID to launch console session for
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                     // Why the admin opens the customer's console, recorded in the audit log
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -908,6 +982,13 @@ func (x *LaunchSessionRequest) GetServerId() string {
 	return ""
 }
 
+func (x *LaunchSessionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type LaunchSessionResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                         // Unique session identifier
@@ -976,93 +1057,4995 @@ func (x *LaunchSessionResponse) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
-var File_manager_v1_admin_proto protoreflect.FileDescriptor
+// Console availability SLO report (admin only)
+type GetConsoleSLOReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayFilter string                 `protobuf:"bytes,1,opt,name=gateway_filter,json=gatewayFilter,proto3" json:"gateway_filter,omitempty"` // Optional: filter by specific gateway_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_manager_v1_admin_proto_rawDesc = "" +
-	"\n" +
-	"\x16manager/v1/admin.proto\x12\n" +
-	"manager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1c\n" +
-	"\x1aGetDashboardMetricsRequest\"\xa2\x02\n" +
-	"\x1bGetDashboardMetricsResponse\x12\x1d\n" +
-	"\n" +
-	"total_bmcs\x18\x01 \x01(\x05R\ttotalBmcs\x12\x1f\n" +
-	"\vonline_bmcs\x18\x02 \x01(\x05R\n" +
-	"onlineBmcs\x12!\n" +
-	"\foffline_bmcs\x18\x03 \x01(\x05R\vofflineBmcs\x12%\n" +
-	"\x0etotal_gateways\x18\x04 \x01(\x05R\rtotalGateways\x12'\n" +
-	"\x0factive_gateways\x18\x05 \x01(\x05R\x0eactiveGateways\x12'\n" +
-	"\x0ftotal_customers\x18\x06 \x01(\x05R\x0etotalCustomers\x12'\n" +
-	"\x0factive_sessions\x18\a \x01(\x05R\x0eactiveSessions\"\xed\x01\n" +
-	"\x15ListAllServersRequest\x12\x1b\n" +
-	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\x12'\n" +
-	"\x0fcustomer_filter\x18\x03 \x01(\tR\x0ecustomerFilter\x12#\n" +
-	"\rregion_filter\x18\x04 \x03(\tR\fregionFilter\x12%\n" +
-	"\x0egateway_filter\x18\x05 \x01(\tR\rgatewayFilter\x12#\n" +
-	"\rstatus_filter\x18\x06 \x01(\tR\fstatusFilter\"\x96\x01\n" +
-	"\x16ListAllServersResponse\x123\n" +
-	"\aservers\x18\x01 \x03(\v2\x19.manager.v1.ServerDetailsR\aservers\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
-	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"\xa5\x03\n" +
-	"\rServerDetails\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
-	"\vcustomer_id\x18\x02 \x01(\tR\n" +
-	"customerId\x12#\n" +
-	"\rdatacenter_id\x18\x03 \x01(\tR\fdatacenterId\x12\x1d\n" +
-	"\n" +
-	"gateway_id\x18\x04 \x01(\tR\tgatewayId\x12)\n" +
-	"\x10primary_endpoint\x18\x05 \x01(\tR\x0fprimaryEndpoint\x12)\n" +
-	"\x10primary_protocol\x18\x06 \x01(\tR\x0fprimaryProtocol\x12\x16\n" +
-	"\x06status\x18\a \x01(\tR\x06status\x12\x17\n" +
-	"\ahas_vnc\x18\b \x01(\bR\x06hasVnc\x12\x17\n" +
-	"\ahas_sol\x18\t \x01(\bR\x06hasSol\x127\n" +
-	"\tlast_seen\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x129\n" +
-	"\n" +
-	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"U\n" +
-	"\x17ListAllCustomersRequest\x12\x1b\n" +
-	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"}\n" +
-	"\x18ListAllCustomersResponse\x129\n" +
-	"\tcustomers\x18\x01 \x03(\v2\x1b.manager.v1.CustomerSummaryR\tcustomers\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf1\x01\n" +
-	"\x0fCustomerSummary\x12\x1f\n" +
-	"\vcustomer_id\x18\x01 \x01(\tR\n" +
-	"customerId\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12!\n" +
-	"\fserver_count\x18\x03 \x01(\x05R\vserverCount\x12.\n" +
-	"\x13online_server_count\x18\x04 \x01(\x05R\x11onlineServerCount\x12\x19\n" +
-	"\bis_admin\x18\x05 \x01(\bR\aisAdmin\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x19\n" +
-	"\x17GetGatewayHealthRequest\"Q\n" +
-	"\x18GetGatewayHealthResponse\x125\n" +
-	"\bgateways\x18\x01 \x03(\v2\x19.manager.v1.GatewayHealthR\bgateways\"\xfd\x01\n" +
-	"\rGatewayHealth\x12\x1d\n" +
-	"\n" +
-	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x16\n" +
-	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
-	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x127\n" +
-	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12!\n" +
-	"\fserver_count\x18\x06 \x01(\x05R\vserverCount\x12%\n" +
-	"\x0edatacenter_ids\x18\a \x03(\tR\rdatacenterIds\"\x13\n" +
-	"\x11GetRegionsRequest\".\n" +
-	"\x12GetRegionsResponse\x12\x18\n" +
-	"\aregions\x18\x01 \x03(\tR\aregions\"3\n" +
-	"\x14LaunchSessionRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"\xbf\x01\n" +
-	"\x15LaunchSessionResponse\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
-	"\x12websocket_endpoint\x18\x02 \x01(\tR\x11websocketEndpoint\x12\x1d\n" +
-	"\n" +
-	"viewer_url\x18\x03 \x01(\tR\tviewerUrl\x129\n" +
+func (x *GetConsoleSLOReportRequest) Reset() {
+	*x = GetConsoleSLOReportRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsoleSLOReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleSLOReportRequest) ProtoMessage() {}
+
+func (x *GetConsoleSLOReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleSLOReportRequest.ProtoReflect.Descriptor instead.
+func (*GetConsoleSLOReportRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetConsoleSLOReportRequest) GetGatewayFilter() string {
+	if x != nil {
+		return x.GatewayFilter
+	}
+	return ""
+}
+
+type GetConsoleSLOReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"` // Start of the rolling SLO window
+	WindowEnd     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`       // End of the rolling SLO window (now)
+	Objectives    *ConsoleSLOObjectives  `protobuf:"bytes,3,opt,name=objectives,proto3" json:"objectives,omitempty"`                      // Configured objectives
+	Overall       *ConsoleSLOStatus      `protobuf:"bytes,4,opt,name=overall,proto3" json:"overall,omitempty"`                            // All gateways and agents combined
+	Gateways      []*ConsoleSLOStatus    `protobuf:"bytes,5,rep,name=gateways,proto3" json:"gateways,omitempty"`                          // Per gateway (agent_id empty)
+	Agents        []*ConsoleSLOStatus    `protobuf:"bytes,6,rep,name=agents,proto3" json:"agents,omitempty"`                              // Per gateway and agent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsoleSLOReportResponse) Reset() {
+	*x = GetConsoleSLOReportResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsoleSLOReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsoleSLOReportResponse) ProtoMessage() {}
+
+func (x *GetConsoleSLOReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsoleSLOReportResponse.ProtoReflect.Descriptor instead.
+func (*GetConsoleSLOReportResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetConsoleSLOReportResponse) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *GetConsoleSLOReportResponse) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *GetConsoleSLOReportResponse) GetObjectives() *ConsoleSLOObjectives {
+	if x != nil {
+		return x.Objectives
+	}
+	return nil
+}
+
+func (x *GetConsoleSLOReportResponse) GetOverall() *ConsoleSLOStatus {
+	if x != nil {
+		return x.Overall
+	}
+	return nil
+}
+
+func (x *GetConsoleSLOReportResponse) GetGateways() []*ConsoleSLOStatus {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *GetConsoleSLOReportResponse) GetAgents() []*ConsoleSLOStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type ConsoleSLOObjectives struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AvailabilityTarget float64                `protobuf:"fixed64,1,opt,name=availability_target,json=availabilityTarget,proto3" json:"availability_target,omitempty"` // Session establishment success rate, e.g. 0.995
+	TtfbTarget         float64                `protobuf:"fixed64,2,opt,name=ttfb_target,json=ttfbTarget,proto3" json:"ttfb_target,omitempty"`                         // Fraction of sessions with first byte under the threshold, e.g. 0.99
+	TtfbThresholdMs    int64                  `protobuf:"varint,3,opt,name=ttfb_threshold_ms,json=ttfbThresholdMs,proto3" json:"ttfb_threshold_ms,omitempty"`         // Time-to-first-byte threshold
+	WindowSeconds      int64                  `protobuf:"varint,4,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`                 // Rolling window length
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ConsoleSLOObjectives) Reset() {
+	*x = ConsoleSLOObjectives{}
+	mi := &file_manager_v1_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleSLOObjectives) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleSLOObjectives) ProtoMessage() {}
+
+func (x *ConsoleSLOObjectives) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleSLOObjectives.ProtoReflect.Descriptor instead.
+func (*ConsoleSLOObjectives) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConsoleSLOObjectives) GetAvailabilityTarget() float64 {
+	if x != nil {
+		return x.AvailabilityTarget
+	}
+	return 0
+}
+
+func (x *ConsoleSLOObjectives) GetTtfbTarget() float64 {
+	if x != nil {
+		return x.TtfbTarget
+	}
+	return 0
+}
+
+func (x *ConsoleSLOObjectives) GetTtfbThresholdMs() int64 {
+	if x != nil {
+		return x.TtfbThresholdMs
+	}
+	return 0
+}
+
+func (x *ConsoleSLOObjectives) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+type ConsoleSLOStatus struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId            string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	AgentId              string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Attempts             int64                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`                                                        // Console streams opened
+	Successes            int64                  `protobuf:"varint,4,opt,name=successes,proto3" json:"successes,omitempty"`                                                      // Console streams established
+	SuccessRate          float64                `protobuf:"fixed64,5,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`                              // successes / attempts (1 when there were no attempts)
+	AvailabilityMet      bool                   `protobuf:"varint,6,opt,name=availability_met,json=availabilityMet,proto3" json:"availability_met,omitempty"`                   // success_rate >= availability_target
+	ErrorBudgetRemaining float64                `protobuf:"fixed64,7,opt,name=error_budget_remaining,json=errorBudgetRemaining,proto3" json:"error_budget_remaining,omitempty"` // Fraction of the error budget left, negative when overspent
+	TtfbSamples          int64                  `protobuf:"varint,8,opt,name=ttfb_samples,json=ttfbSamples,proto3" json:"ttfb_samples,omitempty"`                               // Sessions that received console data
+	TtfbWithinThreshold  int64                  `protobuf:"varint,9,opt,name=ttfb_within_threshold,json=ttfbWithinThreshold,proto3" json:"ttfb_within_threshold,omitempty"`     // Sessions with first byte under the threshold
+	TtfbCompliance       float64                `protobuf:"fixed64,10,opt,name=ttfb_compliance,json=ttfbCompliance,proto3" json:"ttfb_compliance,omitempty"`                    // ttfb_within_threshold / ttfb_samples (1 when there were no samples)
+	TtfbMet              bool                   `protobuf:"varint,11,opt,name=ttfb_met,json=ttfbMet,proto3" json:"ttfb_met,omitempty"`                                          // ttfb_compliance >= ttfb_target
+	AvgTtfbMs            float64                `protobuf:"fixed64,12,opt,name=avg_ttfb_ms,json=avgTtfbMs,proto3" json:"avg_ttfb_ms,omitempty"`                                 // Mean time to first byte
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ConsoleSLOStatus) Reset() {
+	*x = ConsoleSLOStatus{}
+	mi := &file_manager_v1_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleSLOStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleSLOStatus) ProtoMessage() {}
+
+func (x *ConsoleSLOStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleSLOStatus.ProtoReflect.Descriptor instead.
+func (*ConsoleSLOStatus) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConsoleSLOStatus) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ConsoleSLOStatus) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConsoleSLOStatus) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetSuccesses() int64 {
+	if x != nil {
+		return x.Successes
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetAvailabilityMet() bool {
+	if x != nil {
+		return x.AvailabilityMet
+	}
+	return false
+}
+
+func (x *ConsoleSLOStatus) GetErrorBudgetRemaining() float64 {
+	if x != nil {
+		return x.ErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetTtfbSamples() int64 {
+	if x != nil {
+		return x.TtfbSamples
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetTtfbWithinThreshold() int64 {
+	if x != nil {
+		return x.TtfbWithinThreshold
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetTtfbCompliance() float64 {
+	if x != nil {
+		return x.TtfbCompliance
+	}
+	return 0
+}
+
+func (x *ConsoleSLOStatus) GetTtfbMet() bool {
+	if x != nil {
+		return x.TtfbMet
+	}
+	return false
+}
+
+func (x *ConsoleSLOStatus) GetAvgTtfbMs() float64 {
+	if x != nil {
+		return x.AvgTtfbMs
+	}
+	return 0
+}
+
+// Power usage report (admin only)
+type GetPowerUsageReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`    // Optional: start of the period, 30 days before end_time by default
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`          // Optional: end of the period, now by default
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Optional: filter by customer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerUsageReportRequest) Reset() {
+	*x = GetPowerUsageReportRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerUsageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerUsageReportRequest) ProtoMessage() {}
+
+func (x *GetPowerUsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerUsageReportRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerUsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPowerUsageReportRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetPowerUsageReportRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetPowerUsageReportRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetPowerUsageReportResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TotalEnergyKwh float64                `protobuf:"fixed64,3,opt,name=total_energy_kwh,json=totalEnergyKwh,proto3" json:"total_energy_kwh,omitempty"` // Energy of all customers
+	Customers      []*CustomerPowerUsage  `protobuf:"bytes,4,rep,name=customers,proto3" json:"customers,omitempty"`                                     // Per customer, by customer ID
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetPowerUsageReportResponse) Reset() {
+	*x = GetPowerUsageReportResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerUsageReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerUsageReportResponse) ProtoMessage() {}
+
+func (x *GetPowerUsageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerUsageReportResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerUsageReportResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPowerUsageReportResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetPowerUsageReportResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetPowerUsageReportResponse) GetTotalEnergyKwh() float64 {
+	if x != nil {
+		return x.TotalEnergyKwh
+	}
+	return 0
+}
+
+func (x *GetPowerUsageReportResponse) GetCustomers() []*CustomerPowerUsage {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type CustomerPowerUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	EnergyKwh     float64                `protobuf:"fixed64,2,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"`          // Energy of the customer's servers over the period
+	AverageWatts  float64                `protobuf:"fixed64,3,opt,name=average_watts,json=averageWatts,proto3" json:"average_watts,omitempty"` // Sum of the average power of the customer's servers
+	ServerCount   int32                  `protobuf:"varint,4,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`
+	Servers       []*ServerPowerUsage    `protobuf:"bytes,5,rep,name=servers,proto3" json:"servers,omitempty"` // Per server, by server ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerPowerUsage) Reset() {
+	*x = CustomerPowerUsage{}
+	mi := &file_manager_v1_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerPowerUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerPowerUsage) ProtoMessage() {}
+
+func (x *CustomerPowerUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerPowerUsage.ProtoReflect.Descriptor instead.
+func (*CustomerPowerUsage) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CustomerPowerUsage) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CustomerPowerUsage) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
+}
+
+func (x *CustomerPowerUsage) GetAverageWatts() float64 {
+	if x != nil {
+		return x.AverageWatts
+	}
+	return 0
+}
+
+func (x *CustomerPowerUsage) GetServerCount() int32 {
+	if x != nil {
+		return x.ServerCount
+	}
+	return 0
+}
+
+func (x *CustomerPowerUsage) GetServers() []*ServerPowerUsage {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type ServerPowerUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	DatacenterId  string                 `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	EnergyKwh     float64                `protobuf:"fixed64,3,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"`          // Energy consumed over the period
+	AverageWatts  float64                `protobuf:"fixed64,4,opt,name=average_watts,json=averageWatts,proto3" json:"average_watts,omitempty"` // Mean power over the sampled time
+	PeakWatts     float64                `protobuf:"fixed64,5,opt,name=peak_watts,json=peakWatts,proto3" json:"peak_watts,omitempty"`          // Highest sampled power
+	SampleCount   int64                  `protobuf:"varint,6,opt,name=sample_count,json=sampleCount,proto3" json:"sample_count,omitempty"`
+	SampledHours  float64                `protobuf:"fixed64,7,opt,name=sampled_hours,json=sampledHours,proto3" json:"sampled_hours,omitempty"` // Time covered by samples, excluding gaps in sampling
+	Metered       bool                   `protobuf:"varint,8,opt,name=metered,proto3" json:"metered,omitempty"`                                // Energy counted by the BMC, rather than integrated from power samples
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerPowerUsage) Reset() {
+	*x = ServerPowerUsage{}
+	mi := &file_manager_v1_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerPowerUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerPowerUsage) ProtoMessage() {}
+
+func (x *ServerPowerUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerPowerUsage.ProtoReflect.Descriptor instead.
+func (*ServerPowerUsage) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ServerPowerUsage) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerPowerUsage) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *ServerPowerUsage) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
+}
+
+func (x *ServerPowerUsage) GetAverageWatts() float64 {
+	if x != nil {
+		return x.AverageWatts
+	}
+	return 0
+}
+
+func (x *ServerPowerUsage) GetPeakWatts() float64 {
+	if x != nil {
+		return x.PeakWatts
+	}
+	return 0
+}
+
+func (x *ServerPowerUsage) GetSampleCount() int64 {
+	if x != nil {
+		return x.SampleCount
+	}
+	return 0
+}
+
+func (x *ServerPowerUsage) GetSampledHours() float64 {
+	if x != nil {
+		return x.SampledHours
+	}
+	return 0
+}
+
+func (x *ServerPowerUsage) GetMetered() bool {
+	if x != nil {
+		return x.Metered
+	}
+	return false
+}
+
+// Console sessions across gateways (admin only)
+type ListConsoleSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayFilter string                 `protobuf:"bytes,1,opt,name=gateway_filter,json=gatewayFilter,proto3" json:"gateway_filter,omitempty"` // Optional: filter by specific gateway_id
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                // Optional: filter by server
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`          // Optional: filter by session owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsoleSessionsRequest) Reset() {
+	*x = ListConsoleSessionsRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsoleSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsoleSessionsRequest) ProtoMessage() {}
+
+func (x *ListConsoleSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsoleSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConsoleSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListConsoleSessionsRequest) GetGatewayFilter() string {
+	if x != nil {
+		return x.GatewayFilter
+	}
+	return ""
+}
+
+func (x *ListConsoleSessionsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ListConsoleSessionsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type ListConsoleSessionsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Sessions            []*ConsoleSession      `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	UnreachableGateways []*UnreachableGateway  `protobuf:"bytes,2,rep,name=unreachable_gateways,json=unreachableGateways,proto3" json:"unreachable_gateways,omitempty"` // Gateways that could not be queried
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListConsoleSessionsResponse) Reset() {
+	*x = ListConsoleSessionsResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsoleSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsoleSessionsResponse) ProtoMessage() {}
+
+func (x *ListConsoleSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsoleSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConsoleSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListConsoleSessionsResponse) GetSessions() []*ConsoleSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListConsoleSessionsResponse) GetUnreachableGateways() []*UnreachableGateway {
+	if x != nil {
+		return x.UnreachableGateways
+	}
+	return nil
+}
+
+type ConsoleSession struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId      string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	Region         string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	SessionId      string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // "sol" or "vnc"
+	ServerId       string                 `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CustomerId     string                 `protobuf:"bytes,6,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CustomerEmail  string                 `protobuf:"bytes,7,opt,name=customer_email,json=customerEmail,proto3" json:"customer_email,omitempty"`
+	AgentId        string                 `protobuf:"bytes,8,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Streams        []*ConsoleStream       `protobuf:"bytes,11,rep,name=streams,proto3" json:"streams,omitempty"`                                       // Clients currently attached
+	ImpersonatedBy string                 `protobuf:"bytes,12,opt,name=impersonated_by,json=impersonatedBy,proto3" json:"impersonated_by,omitempty"`   // Admin who opened the session on behalf of the customer, if any
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"` // Last console input of a client, or attach of a stream
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConsoleSession) Reset() {
+	*x = ConsoleSession{}
+	mi := &file_manager_v1_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleSession) ProtoMessage() {}
+
+func (x *ConsoleSession) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleSession.ProtoReflect.Descriptor instead.
+func (*ConsoleSession) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConsoleSession) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetCustomerEmail() string {
+	if x != nil {
+		return x.CustomerEmail
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ConsoleSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ConsoleSession) GetStreams() []*ConsoleStream {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *ConsoleSession) GetImpersonatedBy() string {
+	if x != nil {
+		return x.ImpersonatedBy
+	}
+	return ""
+}
+
+func (x *ConsoleSession) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+type ConsoleStream struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientAddress string                 `protobuf:"bytes,1,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"`
+	Transport     string                 `protobuf:"bytes,2,opt,name=transport,proto3" json:"transport,omitempty"` // "connect" (CLI) or "websocket" (browser)
+	AttachedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=attached_at,json=attachedAt,proto3" json:"attached_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsoleStream) Reset() {
+	*x = ConsoleStream{}
+	mi := &file_manager_v1_admin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsoleStream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleStream) ProtoMessage() {}
+
+func (x *ConsoleStream) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleStream.ProtoReflect.Descriptor instead.
+func (*ConsoleStream) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConsoleStream) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *ConsoleStream) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *ConsoleStream) GetAttachedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttachedAt
+	}
+	return nil
+}
+
+type UnreachableGateway struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnreachableGateway) Reset() {
+	*x = UnreachableGateway{}
+	mi := &file_manager_v1_admin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreachableGateway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreachableGateway) ProtoMessage() {}
+
+func (x *UnreachableGateway) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreachableGateway.ProtoReflect.Descriptor instead.
+func (*UnreachableGateway) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnreachableGateway) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *UnreachableGateway) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TerminateConsoleSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"` // Gateway holding the session
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Optional: shown to the disconnected clients
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateConsoleSessionRequest) Reset() {
+	*x = TerminateConsoleSessionRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateConsoleSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateConsoleSessionRequest) ProtoMessage() {}
+
+func (x *TerminateConsoleSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateConsoleSessionRequest.ProtoReflect.Descriptor instead.
+func (*TerminateConsoleSessionRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TerminateConsoleSessionRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *TerminateConsoleSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TerminateConsoleSessionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TerminateConsoleSessionResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DisconnectedStreams int32                  `protobuf:"varint,1,opt,name=disconnected_streams,json=disconnectedStreams,proto3" json:"disconnected_streams,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TerminateConsoleSessionResponse) Reset() {
+	*x = TerminateConsoleSessionResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateConsoleSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateConsoleSessionResponse) ProtoMessage() {}
+
+func (x *TerminateConsoleSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateConsoleSessionResponse.ProtoReflect.Descriptor instead.
+func (*TerminateConsoleSessionResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TerminateConsoleSessionResponse) GetDisconnectedStreams() int32 {
+	if x != nil {
+		return x.DisconnectedStreams
+	}
+	return 0
+}
+
+// Live topology across gateways (admin only)
+type GetTopologyRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RegionFilter     []string               `protobuf:"bytes,1,rep,name=region_filter,json=regionFilter,proto3" json:"region_filter,omitempty"`             // Optional: filter by gateway regions (multi-select)
+	DatacenterFilter string                 `protobuf:"bytes,2,opt,name=datacenter_filter,json=datacenterFilter,proto3" json:"datacenter_filter,omitempty"` // Optional: only agents, endpoints and sessions of this datacenter
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTopologyRequest) Reset() {
+	*x = GetTopologyRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopologyRequest) ProtoMessage() {}
+
+func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopologyRequest.ProtoReflect.Descriptor instead.
+func (*GetTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTopologyRequest) GetRegionFilter() []string {
+	if x != nil {
+		return x.RegionFilter
+	}
+	return nil
+}
+
+func (x *GetTopologyRequest) GetDatacenterFilter() string {
+	if x != nil {
+		return x.DatacenterFilter
+	}
+	return ""
+}
+
+type GetTopologyResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Gateways            []*TopologyGateway     `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"`                                                  // By region, then gateway ID
+	UnreachableGateways []*UnreachableGateway  `protobuf:"bytes,2,rep,name=unreachable_gateways,json=unreachableGateways,proto3" json:"unreachable_gateways,omitempty"` // Gateways that could not be queried
+	GeneratedAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetTopologyResponse) Reset() {
+	*x = GetTopologyResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopologyResponse) ProtoMessage() {}
+
+func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopologyResponse.ProtoReflect.Descriptor instead.
+func (*GetTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetTopologyResponse) GetGateways() []*TopologyGateway {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *GetTopologyResponse) GetUnreachableGateways() []*UnreachableGateway {
+	if x != nil {
+		return x.UnreachableGateways
+	}
+	return nil
+}
+
+func (x *GetTopologyResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+type TopologyGateway struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gateway       *GatewayHealth         `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Agents        []*TopologyAgent       `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`     // Agents registered with the gateway, by agent ID
+	Sessions      []*ConsoleSession      `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"` // Console sessions open on the gateway, oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopologyGateway) Reset() {
+	*x = TopologyGateway{}
+	mi := &file_manager_v1_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologyGateway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyGateway) ProtoMessage() {}
+
+func (x *TopologyGateway) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyGateway.ProtoReflect.Descriptor instead.
+func (*TopologyGateway) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TopologyGateway) GetGateway() *GatewayHealth {
+	if x != nil {
+		return x.Gateway
+	}
+	return nil
+}
+
+func (x *TopologyGateway) GetAgents() []*TopologyAgent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *TopologyGateway) GetSessions() []*ConsoleSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type TopologyAgent struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AgentId            string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	DatacenterId       string                 `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	Endpoint           string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "active" or "stale"
+	Version            string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	LastSeen           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	ActiveSessionCount int32                  `protobuf:"varint,7,opt,name=active_session_count,json=activeSessionCount,proto3" json:"active_session_count,omitempty"`
+	BmcEndpoints       []*TopologyEndpoint    `protobuf:"bytes,8,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // By server ID
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TopologyAgent) Reset() {
+	*x = TopologyAgent{}
+	mi := &file_manager_v1_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologyAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyAgent) ProtoMessage() {}
+
+func (x *TopologyAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyAgent.ProtoReflect.Descriptor instead.
+func (*TopologyAgent) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TopologyAgent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TopologyAgent) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *TopologyAgent) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *TopologyAgent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TopologyAgent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *TopologyAgent) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *TopologyAgent) GetActiveSessionCount() int32 {
+	if x != nil {
+		return x.ActiveSessionCount
+	}
+	return 0
+}
+
+func (x *TopologyAgent) GetBmcEndpoints() []*TopologyEndpoint {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+type TopologyEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	BmcEndpoint   string                 `protobuf:"bytes,2,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`
+	BmcType       string                 `protobuf:"bytes,3,opt,name=bmc_type,json=bmcType,proto3" json:"bmc_type,omitempty"` // "ipmi" or "redfish"
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                  // Server status last reported by the agent
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopologyEndpoint) Reset() {
+	*x = TopologyEndpoint{}
+	mi := &file_manager_v1_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologyEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyEndpoint) ProtoMessage() {}
+
+func (x *TopologyEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyEndpoint.ProtoReflect.Descriptor instead.
+func (*TopologyEndpoint) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TopologyEndpoint) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *TopologyEndpoint) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *TopologyEndpoint) GetBmcType() string {
+	if x != nil {
+		return x.BmcType
+	}
+	return ""
+}
+
+func (x *TopologyEndpoint) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TopologyEndpoint) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// Recent events of a server (admin only)
+type ListServerEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Default 50, max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServerEventsRequest) Reset() {
+	*x = ListServerEventsRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServerEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServerEventsRequest) ProtoMessage() {}
+
+func (x *ListServerEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServerEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListServerEventsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListServerEventsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ListServerEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListServerEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*SystemEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first, since the manager started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServerEventsResponse) Reset() {
+	*x = ListServerEventsResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServerEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServerEventsResponse) ProtoMessage() {}
+
+func (x *ListServerEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServerEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListServerEventsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListServerEventsResponse) GetEvents() []*SystemEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Put a server in or out of maintenance (admin only)
+type SetServerMaintenanceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	MaintenanceMode bool                   `protobuf:"varint,2,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetServerMaintenanceRequest) Reset() {
+	*x = SetServerMaintenanceRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerMaintenanceRequest) ProtoMessage() {}
+
+func (x *SetServerMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*SetServerMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetServerMaintenanceRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SetServerMaintenanceRequest) GetMaintenanceMode() bool {
+	if x != nil {
+		return x.MaintenanceMode
+	}
+	return false
+}
+
+type SetServerMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // The updated server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServerMaintenanceResponse) Reset() {
+	*x = SetServerMaintenanceResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerMaintenanceResponse) ProtoMessage() {}
+
+func (x *SetServerMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*SetServerMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetServerMaintenanceResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+// Set the operator notes of a server (admin only)
+type SetServerNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Notes         string                 `protobuf:"bytes,2,opt,name=notes,proto3" json:"notes,omitempty"` // Free text, at most 4096 characters; empty clears the notes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServerNotesRequest) Reset() {
+	*x = SetServerNotesRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerNotesRequest) ProtoMessage() {}
+
+func (x *SetServerNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerNotesRequest.ProtoReflect.Descriptor instead.
+func (*SetServerNotesRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetServerNotesRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SetServerNotesRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type SetServerNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // The updated server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServerNotesResponse) Reset() {
+	*x = SetServerNotesResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServerNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServerNotesResponse) ProtoMessage() {}
+
+func (x *SetServerNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServerNotesResponse.ProtoReflect.Descriptor instead.
+func (*SetServerNotesResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetServerNotesResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+// MaintenanceWindow is a scheduled maintenance of a server, or of the group of
+// servers whose metadata holds all of its labels
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                                       // Empty for a group of servers
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Labels of the group, when server_id is empty
+	Policy        string                 `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`                                                                           // "reject" or "require_override"
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // Email of the administrator
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	mi := &file_manager_v1_admin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MaintenanceWindow) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Schedule a maintenance window (admin only). Exactly one of server_id and
+// labels is set.
+type CreateMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Policy        string                 `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"` // "reject" or "require_override"
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // At most 1024 characters
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMaintenanceWindowRequest) Reset() {
+	*x = CreateMaintenanceWindowRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *CreateMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*CreateMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateMaintenanceWindowRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateMaintenanceWindowRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateMaintenanceWindowRequest) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *CreateMaintenanceWindowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateMaintenanceWindowRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreateMaintenanceWindowRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type CreateMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMaintenanceWindowResponse) Reset() {
+	*x = CreateMaintenanceWindowResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *CreateMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*CreateMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// List the maintenance windows that have not ended (admin only)
+type ListMaintenanceWindowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`              // Only the windows applying to this server
+	IncludeEnded  bool                   `protobuf:"varint,2,opt,name=include_ended,json=includeEnded,proto3" json:"include_ended,omitempty"` // Include the windows that have ended
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsRequest) Reset() {
+	*x = ListMaintenanceWindowsRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListMaintenanceWindowsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ListMaintenanceWindowsRequest) GetIncludeEnded() bool {
+	if x != nil {
+		return x.IncludeEnded
+	}
+	return false
+}
+
+type ListMaintenanceWindowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*MaintenanceWindow   `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"` // Ordered by start time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsResponse) Reset() {
+	*x = ListMaintenanceWindowsResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListMaintenanceWindowsResponse) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+// Cancel or remove a maintenance window (admin only)
+type DeleteMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowRequest) Reset() {
+	*x = DeleteMaintenanceWindowRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteMaintenanceWindowRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowResponse) Reset() {
+	*x = DeleteMaintenanceWindowResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{47}
+}
+
+// Set the console session limits of a customer (admin only). Limits are 0
+// for the manager's default and -1 for no limit.
+type SetCustomerSessionQuotaRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId            string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaxConcurrentSessions int32                  `protobuf:"varint,2,opt,name=max_concurrent_sessions,json=maxConcurrentSessions,proto3" json:"max_concurrent_sessions,omitempty"` // Console sessions open at once on a gateway
+	MaxSessionsPerMinute  int32                  `protobuf:"varint,3,opt,name=max_sessions_per_minute,json=maxSessionsPerMinute,proto3" json:"max_sessions_per_minute,omitempty"`  // Console sessions created per minute on a gateway
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SetCustomerSessionQuotaRequest) Reset() {
+	*x = SetCustomerSessionQuotaRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerSessionQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerSessionQuotaRequest) ProtoMessage() {}
+
+func (x *SetCustomerSessionQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerSessionQuotaRequest.ProtoReflect.Descriptor instead.
+func (*SetCustomerSessionQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetCustomerSessionQuotaRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerSessionQuotaRequest) GetMaxConcurrentSessions() int32 {
+	if x != nil {
+		return x.MaxConcurrentSessions
+	}
+	return 0
+}
+
+func (x *SetCustomerSessionQuotaRequest) GetMaxSessionsPerMinute() int32 {
+	if x != nil {
+		return x.MaxSessionsPerMinute
+	}
+	return 0
+}
+
+type SetCustomerSessionQuotaResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId            string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaxConcurrentSessions int32                  `protobuf:"varint,2,opt,name=max_concurrent_sessions,json=maxConcurrentSessions,proto3" json:"max_concurrent_sessions,omitempty"`
+	MaxSessionsPerMinute  int32                  `protobuf:"varint,3,opt,name=max_sessions_per_minute,json=maxSessionsPerMinute,proto3" json:"max_sessions_per_minute,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SetCustomerSessionQuotaResponse) Reset() {
+	*x = SetCustomerSessionQuotaResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerSessionQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerSessionQuotaResponse) ProtoMessage() {}
+
+func (x *SetCustomerSessionQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerSessionQuotaResponse.ProtoReflect.Descriptor instead.
+func (*SetCustomerSessionQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetCustomerSessionQuotaResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerSessionQuotaResponse) GetMaxConcurrentSessions() int32 {
+	if x != nil {
+		return x.MaxConcurrentSessions
+	}
+	return 0
+}
+
+func (x *SetCustomerSessionQuotaResponse) GetMaxSessionsPerMinute() int32 {
+	if x != nil {
+		return x.MaxSessionsPerMinute
+	}
+	return 0
+}
+
+// Set the two-factor authentication policy of a customer (admin only).
+// Customers required to use a second factor can't open consoles with the
+// tokens of logins without one.
+type SetCustomerMFAPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RequireMfa    bool                   `protobuf:"varint,2,opt,name=require_mfa,json=requireMfa,proto3" json:"require_mfa,omitempty"`
+	ResetTotp     bool                   `protobuf:"varint,3,opt,name=reset_totp,json=resetTotp,proto3" json:"reset_totp,omitempty"` // Remove the customer's authenticator, e.g. when lost, so it can enroll again
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomerMFAPolicyRequest) Reset() {
+	*x = SetCustomerMFAPolicyRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerMFAPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerMFAPolicyRequest) ProtoMessage() {}
+
+func (x *SetCustomerMFAPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerMFAPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetCustomerMFAPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetCustomerMFAPolicyRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerMFAPolicyRequest) GetRequireMfa() bool {
+	if x != nil {
+		return x.RequireMfa
+	}
+	return false
+}
+
+func (x *SetCustomerMFAPolicyRequest) GetResetTotp() bool {
+	if x != nil {
+		return x.ResetTotp
+	}
+	return false
+}
+
+type SetCustomerMFAPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RequireMfa    bool                   `protobuf:"varint,2,opt,name=require_mfa,json=requireMfa,proto3" json:"require_mfa,omitempty"`
+	TotpEnabled   bool                   `protobuf:"varint,3,opt,name=totp_enabled,json=totpEnabled,proto3" json:"totp_enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomerMFAPolicyResponse) Reset() {
+	*x = SetCustomerMFAPolicyResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerMFAPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerMFAPolicyResponse) ProtoMessage() {}
+
+func (x *SetCustomerMFAPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerMFAPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetCustomerMFAPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SetCustomerMFAPolicyResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerMFAPolicyResponse) GetRequireMfa() bool {
+	if x != nil {
+		return x.RequireMfa
+	}
+	return false
+}
+
+func (x *SetCustomerMFAPolicyResponse) GetTotpEnabled() bool {
+	if x != nil {
+		return x.TotpEnabled
+	}
+	return false
+}
+
+// Set the source IP ranges a customer accesses BMCs from (admin only).
+// Gateways refuse the customer's server tokens, console sessions and
+// WebSockets from other addresses. It applies to server tokens issued
+// afterwards.
+type SetCustomerIPAllowlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Cidrs         []string               `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"` // e.g. 10.8.0.0/16, or single addresses. Empty to allow any address.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomerIPAllowlistRequest) Reset() {
+	*x = SetCustomerIPAllowlistRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerIPAllowlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerIPAllowlistRequest) ProtoMessage() {}
+
+func (x *SetCustomerIPAllowlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerIPAllowlistRequest.ProtoReflect.Descriptor instead.
+func (*SetCustomerIPAllowlistRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SetCustomerIPAllowlistRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerIPAllowlistRequest) GetCidrs() []string {
+	if x != nil {
+		return x.Cidrs
+	}
+	return nil
+}
+
+type SetCustomerIPAllowlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Cidrs         []string               `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"` // Normalized, e.g. 203.0.113.7/32
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCustomerIPAllowlistResponse) Reset() {
+	*x = SetCustomerIPAllowlistResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCustomerIPAllowlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCustomerIPAllowlistResponse) ProtoMessage() {}
+
+func (x *SetCustomerIPAllowlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCustomerIPAllowlistResponse.ProtoReflect.Descriptor instead.
+func (*SetCustomerIPAllowlistResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SetCustomerIPAllowlistResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetCustomerIPAllowlistResponse) GetCidrs() []string {
+	if x != nil {
+		return x.Cidrs
+	}
+	return nil
+}
+
+// Export the usage of customers over a calendar month (admin only)
+type ExportUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`                             // Month to export, e.g. 2024-06, in UTC
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                           // Optional: csv (default) or json
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Optional: filter by customer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageRequest) Reset() {
+	*x = ExportUsageRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageRequest) ProtoMessage() {}
+
+func (x *ExportUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsageRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExportUsageRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type ExportUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"`
+	Customers     []*CustomerUsage       `protobuf:"bytes,2,rep,name=customers,proto3" json:"customers,omitempty"`                        // Per customer, by customer ID
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // text/csv or application/json
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                  // The exported document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageResponse) Reset() {
+	*x = ExportUsageResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageResponse) ProtoMessage() {}
+
+func (x *ExportUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageResponse.ProtoReflect.Descriptor instead.
+func (*ExportUsageResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExportUsageResponse) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ExportUsageResponse) GetCustomers() []*CustomerUsage {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+func (x *ExportUsageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportUsageResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CustomerUsage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId      string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ConsoleSessions int64                  `protobuf:"varint,2,opt,name=console_sessions,json=consoleSessions,proto3" json:"console_sessions,omitempty"` // Console streams closed over the month
+	ConsoleMinutes  float64                `protobuf:"fixed64,3,opt,name=console_minutes,json=consoleMinutes,proto3" json:"console_minutes,omitempty"`   // Time console streams were connected
+	PowerOperations int64                  `protobuf:"varint,4,opt,name=power_operations,json=powerOperations,proto3" json:"power_operations,omitempty"` // Successful power operations proxied to BMCs
+	BytesIn         int64                  `protobuf:"varint,5,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`                         // Console data sent by the customer's clients
+	BytesOut        int64                  `protobuf:"varint,6,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`                      // Console data sent to the customer's clients
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CustomerUsage) Reset() {
+	*x = CustomerUsage{}
+	mi := &file_manager_v1_admin_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerUsage) ProtoMessage() {}
+
+func (x *CustomerUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerUsage.ProtoReflect.Descriptor instead.
+func (*CustomerUsage) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CustomerUsage) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CustomerUsage) GetConsoleSessions() int64 {
+	if x != nil {
+		return x.ConsoleSessions
+	}
+	return 0
+}
+
+func (x *CustomerUsage) GetConsoleMinutes() float64 {
+	if x != nil {
+		return x.ConsoleMinutes
+	}
+	return 0
+}
+
+func (x *CustomerUsage) GetPowerOperations() int64 {
+	if x != nil {
+		return x.PowerOperations
+	}
+	return 0
+}
+
+func (x *CustomerUsage) GetBytesIn() int64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *CustomerUsage) GetBytesOut() int64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+// Approve a pending gateway (admin only)
+type ApproveGatewayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveGatewayRequest) Reset() {
+	*x = ApproveGatewayRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveGatewayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveGatewayRequest) ProtoMessage() {}
+
+func (x *ApproveGatewayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveGatewayRequest.ProtoReflect.Descriptor instead.
+func (*ApproveGatewayRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ApproveGatewayRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+type ApproveGatewayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gateway       *GatewayHealth         `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"` // The approved gateway
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveGatewayResponse) Reset() {
+	*x = ApproveGatewayResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveGatewayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveGatewayResponse) ProtoMessage() {}
+
+func (x *ApproveGatewayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveGatewayResponse.ProtoReflect.Descriptor instead.
+func (*ApproveGatewayResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ApproveGatewayResponse) GetGateway() *GatewayHealth {
+	if x != nil {
+		return x.Gateway
+	}
+	return nil
+}
+
+// Create a customer account (admin only)
+type CreateCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                             // Unique email of the customer
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Optional: defaults to the email, as for authenticated customers
+	IsAdmin       bool                   `protobuf:"varint,3,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`         // Grant admin access
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateCustomerRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateCustomerRequest) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+type CreateCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *CustomerSummary       `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"` // API key of the customer, only returned once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerResponse) Reset() {
+	*x = CreateCustomerResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerResponse) ProtoMessage() {}
+
+func (x *CreateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*CreateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateCustomerResponse) GetCustomer() *CustomerSummary {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *CreateCustomerResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+// Issue a new API key to a customer, revoking its previous key (admin only)
+type IssueAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueAPIKeyRequest) Reset() {
+	*x = IssueAPIKeyRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueAPIKeyRequest) ProtoMessage() {}
+
+func (x *IssueAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*IssueAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *IssueAPIKeyRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type IssueAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"` // Only returned once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueAPIKeyResponse) Reset() {
+	*x = IssueAPIKeyResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueAPIKeyResponse) ProtoMessage() {}
+
+func (x *IssueAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*IssueAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *IssueAPIKeyResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *IssueAPIKeyResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+// Issue a password reset token for a customer (admin only). It replaces
+// any previous token and unlocks the account.
+type ResetCustomerPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetCustomerPasswordRequest) Reset() {
+	*x = ResetCustomerPasswordRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetCustomerPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetCustomerPasswordRequest) ProtoMessage() {}
+
+func (x *ResetCustomerPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetCustomerPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetCustomerPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ResetCustomerPasswordRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type ResetCustomerPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ResetToken    string                 `protobuf:"bytes,2,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"` // Only returned once
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetCustomerPasswordResponse) Reset() {
+	*x = ResetCustomerPasswordResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetCustomerPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetCustomerPasswordResponse) ProtoMessage() {}
+
+func (x *ResetCustomerPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetCustomerPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetCustomerPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ResetCustomerPasswordResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ResetCustomerPasswordResponse) GetResetToken() string {
+	if x != nil {
+		return x.ResetToken
+	}
+	return ""
+}
+
+func (x *ResetCustomerPasswordResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Disable a customer account, or re-enable it (admin only). Disabled
+// customers keep their servers, but cannot authenticate nor open consoles.
+type DisableCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`  // Shown to the clients of the terminated console sessions
+	Enable        bool                   `protobuf:"varint,3,opt,name=enable,proto3" json:"enable,omitempty"` // Re-enable a disabled customer instead. Revoked tokens stay revoked.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableCustomerRequest) Reset() {
+	*x = DisableCustomerRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableCustomerRequest) ProtoMessage() {}
+
+func (x *DisableCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DisableCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DisableCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DisableCustomerRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DisableCustomerRequest) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+type DisableCustomerResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Customer            *CustomerSummary       `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	TerminatedSessions  int32                  `protobuf:"varint,2,opt,name=terminated_sessions,json=terminatedSessions,proto3" json:"terminated_sessions,omitempty"`
+	UnreachableGateways []*UnreachableGateway  `protobuf:"bytes,3,rep,name=unreachable_gateways,json=unreachableGateways,proto3" json:"unreachable_gateways,omitempty"` // Their sessions are rejected when a client next attaches
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DisableCustomerResponse) Reset() {
+	*x = DisableCustomerResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableCustomerResponse) ProtoMessage() {}
+
+func (x *DisableCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableCustomerResponse.ProtoReflect.Descriptor instead.
+func (*DisableCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DisableCustomerResponse) GetCustomer() *CustomerSummary {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *DisableCustomerResponse) GetTerminatedSessions() int32 {
+	if x != nil {
+		return x.TerminatedSessions
+	}
+	return 0
+}
+
+func (x *DisableCustomerResponse) GetUnreachableGateways() []*UnreachableGateway {
+	if x != nil {
+		return x.UnreachableGateways
+	}
+	return nil
+}
+
+// Delete a customer account (admin only). Customers owning servers can only
+// be deleted once their servers are reassigned.
+type DeleteCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ReassignTo    string                 `protobuf:"bytes,2,opt,name=reassign_to,json=reassignTo,proto3" json:"reassign_to,omitempty"` // Optional: customer to assign the deleted customer's servers to
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                           // Shown to the clients of the terminated console sessions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerRequest) Reset() {
+	*x = DeleteCustomerRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DeleteCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DeleteCustomerRequest) GetReassignTo() string {
+	if x != nil {
+		return x.ReassignTo
+	}
+	return ""
+}
+
+func (x *DeleteCustomerRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteCustomerResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ReassignedServers   int32                  `protobuf:"varint,1,opt,name=reassigned_servers,json=reassignedServers,proto3" json:"reassigned_servers,omitempty"`
+	TerminatedSessions  int32                  `protobuf:"varint,2,opt,name=terminated_sessions,json=terminatedSessions,proto3" json:"terminated_sessions,omitempty"`
+	UnreachableGateways []*UnreachableGateway  `protobuf:"bytes,3,rep,name=unreachable_gateways,json=unreachableGateways,proto3" json:"unreachable_gateways,omitempty"` // Their sessions are rejected when a client next attaches
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerResponse) Reset() {
+	*x = DeleteCustomerResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerResponse) ProtoMessage() {}
+
+func (x *DeleteCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeleteCustomerResponse) GetReassignedServers() int32 {
+	if x != nil {
+		return x.ReassignedServers
+	}
+	return 0
+}
+
+func (x *DeleteCustomerResponse) GetTerminatedSessions() int32 {
+	if x != nil {
+		return x.TerminatedSessions
+	}
+	return 0
+}
+
+func (x *DeleteCustomerResponse) GetUnreachableGateways() []*UnreachableGateway {
+	if x != nil {
+		return x.UnreachableGateways
+	}
+	return nil
+}
+
+// Assign a server to a customer (admin only). The customer's tokens give
+// access to the server, and the previous owner's no longer do.
+type AssignServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignServerRequest) Reset() {
+	*x = AssignServerRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignServerRequest) ProtoMessage() {}
+
+func (x *AssignServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignServerRequest.ProtoReflect.Descriptor instead.
+func (*AssignServerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *AssignServerRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AssignServerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type AssignServerResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Server             *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`                                                     // The assigned server
+	PreviousCustomerId string                 `protobuf:"bytes,2,opt,name=previous_customer_id,json=previousCustomerId,proto3" json:"previous_customer_id,omitempty"` // Customer the server was assigned to
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssignServerResponse) Reset() {
+	*x = AssignServerResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignServerResponse) ProtoMessage() {}
+
+func (x *AssignServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignServerResponse.ProtoReflect.Descriptor instead.
+func (*AssignServerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *AssignServerResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *AssignServerResponse) GetPreviousCustomerId() string {
+	if x != nil {
+		return x.PreviousCustomerId
+	}
+	return ""
+}
+
+// Import servers from an inventory file (admin only). Columns, or keys of
+// the YAML servers list: server_id, customer_id, datacenter_id, gateway_id,
+// bmc_type, bmc_endpoint, username, password, features (separated by ";" in
+// CSV) and external_id. Servers are matched like RegisterServer, by their
+// datacenter and BMC endpoint, else by ID; the credentials of existing
+// servers are kept when left empty.
+type ImportServersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                    // The inventory document
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`                // Optional: csv (default) or yaml
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Validate and report the changes without saving them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportServersRequest) Reset() {
+	*x = ImportServersRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportServersRequest) ProtoMessage() {}
+
+func (x *ImportServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportServersRequest.ProtoReflect.Descriptor instead.
+func (*ImportServersRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ImportServersRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportServersRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportServersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ServerImportResult  `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per row, in file order
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportServersResponse) Reset() {
+	*x = ImportServersResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportServersResponse) ProtoMessage() {}
+
+func (x *ImportServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportServersResponse.ProtoReflect.Descriptor instead.
+func (*ImportServersResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ImportServersResponse) GetResults() []*ServerImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportServersResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportServersResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportServersResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportServersResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// The outcome of importing one row of an inventory file
+type ServerImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`                        // Line of the row in the document
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // ID of the server, that of the existing server when updated
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                     // "create", "update" or "error"
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                       // Why the row was rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerImportResult) Reset() {
+	*x = ServerImportResult{}
+	mi := &file_manager_v1_admin_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerImportResult) ProtoMessage() {}
+
+func (x *ServerImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerImportResult.ProtoReflect.Descriptor instead.
+func (*ServerImportResult) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ServerImportResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ServerImportResult) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerImportResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ServerImportResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Export the servers as an inventory file (admin only). Passwords are
+// never exported.
+type ExportServersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Format         string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                                       // Optional: csv (default) or yaml
+	CustomerFilter string                 `protobuf:"bytes,2,opt,name=customer_filter,json=customerFilter,proto3" json:"customer_filter,omitempty"` // Optional: filter by customer
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportServersRequest) Reset() {
+	*x = ExportServersRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportServersRequest) ProtoMessage() {}
+
+func (x *ExportServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportServersRequest.ProtoReflect.Descriptor instead.
+func (*ExportServersRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ExportServersRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportServersRequest) GetCustomerFilter() string {
+	if x != nil {
+		return x.CustomerFilter
+	}
+	return ""
+}
+
+type ExportServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // text/csv or application/yaml
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                  // The exported document
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                               // Servers exported
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportServersResponse) Reset() {
+	*x = ExportServersResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportServersResponse) ProtoMessage() {}
+
+func (x *ExportServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportServersResponse.ProtoReflect.Descriptor instead.
+func (*ExportServersResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ExportServersResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportServersResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportServersResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// List the audit log, newest first (admin only)
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`                       // Optional: filter by admin email
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                     // Optional: filter by action, e.g. "customer.create"
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // Optional: filter by target, e.g. a server or customer ID
+	Since         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`                       // Optional: only events after this time
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                      // Defaults to 100, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// AuditEvent is a change made by an admin
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`                             // Email of the admin
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                           // e.g. "gateway.approve", "customer.create", "server.assign"
+	TargetType    string                 `protobuf:"bytes,5,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // "gateway", "customer", "server", "session", "maintenance_window" or "service"
+	TargetId      string                 `protobuf:"bytes,6,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,7,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Parameters of the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_manager_v1_admin_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ListConnectivityAuditsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FailuresOnly   bool                   `protobuf:"varint,1,opt,name=failures_only,json=failuresOnly,proto3" json:"failures_only,omitempty"`      // Only servers whose last audit failed
+	CustomerFilter string                 `protobuf:"bytes,2,opt,name=customer_filter,json=customerFilter,proto3" json:"customer_filter,omitempty"` // Optional: filter by customer_id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListConnectivityAuditsRequest) Reset() {
+	*x = ListConnectivityAuditsRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectivityAuditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectivityAuditsRequest) ProtoMessage() {}
+
+func (x *ListConnectivityAuditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectivityAuditsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectivityAuditsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListConnectivityAuditsRequest) GetFailuresOnly() bool {
+	if x != nil {
+		return x.FailuresOnly
+	}
+	return false
+}
+
+func (x *ListConnectivityAuditsRequest) GetCustomerFilter() string {
+	if x != nil {
+		return x.CustomerFilter
+	}
+	return ""
+}
+
+type ListConnectivityAuditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audits        []*ConnectivityAudit   `protobuf:"bytes,1,rep,name=audits,proto3" json:"audits,omitempty"`
+	LastRunAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"` // When the last periodic audit of all servers ended, unset until then
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectivityAuditsResponse) Reset() {
+	*x = ListConnectivityAuditsResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectivityAuditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectivityAuditsResponse) ProtoMessage() {}
+
+func (x *ListConnectivityAuditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectivityAuditsResponse.ProtoReflect.Descriptor instead.
+func (*ListConnectivityAuditsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListConnectivityAuditsResponse) GetAudits() []*ConnectivityAudit {
+	if x != nil {
+		return x.Audits
+	}
+	return nil
+}
+
+func (x *ListConnectivityAuditsResponse) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+type RunConnectivityAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunConnectivityAuditRequest) Reset() {
+	*x = RunConnectivityAuditRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunConnectivityAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunConnectivityAuditRequest) ProtoMessage() {}
+
+func (x *RunConnectivityAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunConnectivityAuditRequest.ProtoReflect.Descriptor instead.
+func (*RunConnectivityAuditRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RunConnectivityAuditRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type RunConnectivityAuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audit         *ConnectivityAudit     `protobuf:"bytes,1,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunConnectivityAuditResponse) Reset() {
+	*x = RunConnectivityAuditResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunConnectivityAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunConnectivityAuditResponse) ProtoMessage() {}
+
+func (x *RunConnectivityAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunConnectivityAuditResponse.ProtoReflect.Descriptor instead.
+func (*RunConnectivityAuditResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RunConnectivityAuditResponse) GetAudit() *ConnectivityAudit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+// ConnectivityAudit is the last connectivity audit of a server's BMC
+type ConnectivityAudit struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ServerId      string                  `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CustomerId    string                  `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	DatacenterId  string                  `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	CheckedAt     *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`    // When the last audit ran
+	VerifiedAt    *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"` // Last audit that passed, unset if none did
+	Healthy       bool                    `protobuf:"varint,6,opt,name=healthy,proto3" json:"healthy,omitempty"`                        // Whether the last audit passed
+	FailedHop     string                  `protobuf:"bytes,7,opt,name=failed_hop,json=failedHop,proto3" json:"failed_hop,omitempty"`    // First failed hop: "gateway", "agent", "bmc_control", "sol" or "vnc"
+	Error         string                  `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                             // Why the hop failed
+	Hops          []*ConnectivityAuditHop `protobuf:"bytes,9,rep,name=hops,proto3" json:"hops,omitempty"`                               // Hops checked by the gateway and agent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectivityAudit) Reset() {
+	*x = ConnectivityAudit{}
+	mi := &file_manager_v1_admin_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectivityAudit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectivityAudit) ProtoMessage() {}
+
+func (x *ConnectivityAudit) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectivityAudit.ProtoReflect.Descriptor instead.
+func (*ConnectivityAudit) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ConnectivityAudit) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ConnectivityAudit) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ConnectivityAudit) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *ConnectivityAudit) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *ConnectivityAudit) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *ConnectivityAudit) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ConnectivityAudit) GetFailedHop() string {
+	if x != nil {
+		return x.FailedHop
+	}
+	return ""
+}
+
+func (x *ConnectivityAudit) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ConnectivityAudit) GetHops() []*ConnectivityAuditHop {
+	if x != nil {
+		return x.Hops
+	}
+	return nil
+}
+
+// ConnectivityAuditHop is the status of one hop of a connectivity audit
+type ConnectivityAuditHop struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // "agent", "bmc_control", "sol" or "vnc"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "ok", "failed" or "skipped"
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"` // Agent ID or BMC endpoint checked
+	LatencyMs     int64                  `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectivityAuditHop) Reset() {
+	*x = ConnectivityAuditHop{}
+	mi := &file_manager_v1_admin_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectivityAuditHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectivityAuditHop) ProtoMessage() {}
+
+func (x *ConnectivityAuditHop) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectivityAuditHop.ProtoReflect.Descriptor instead.
+func (*ConnectivityAuditHop) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ConnectivityAuditHop) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConnectivityAuditHop) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConnectivityAuditHop) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ConnectivityAuditHop) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ConnectivityAuditHop) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Runtime log levels
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                                                             // Base level: trace, debug, info, warn or error; unchanged when empty
+	ModuleLevels  map[string]string      `protobuf:"bytes,2,rep,name=module_levels,json=moduleLevels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Levels of Go packages, e.g. "webhook" or "internal/manager"; an empty level clears one
+	ResetModules  bool                   `protobuf:"varint,3,opt,name=reset_modules,json=resetModules,proto3" json:"reset_modules,omitempty"`                                                                          // Clear the levels of all modules before applying module_levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_manager_v1_admin_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetModuleLevels() map[string]string {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
+
+func (x *SetLogLevelRequest) GetResetModules() bool {
+	if x != nil {
+		return x.ResetModules
+	}
+	return false
+}
+
+type SetLogLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                                                             // Base level in effect
+	ModuleLevels  map[string]string      `protobuf:"bytes,2,rep,name=module_levels,json=moduleLevels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Levels of modules in effect
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_manager_v1_admin_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_admin_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_admin_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *SetLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelResponse) GetModuleLevels() map[string]string {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
+
+var File_manager_v1_admin_proto protoreflect.FileDescriptor
+
+const file_manager_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x16manager/v1/admin.proto\x12\n" +
+	"manager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19common/v1/discovery.proto\x1a\x18manager/v1/manager.proto\"\x1c\n" +
+	"\x1aGetDashboardMetricsRequest\"\xa2\x02\n" +
+	"\x1bGetDashboardMetricsResponse\x12\x1d\n" +
+	"\n" +
+	"total_bmcs\x18\x01 \x01(\x05R\ttotalBmcs\x12\x1f\n" +
+	"\vonline_bmcs\x18\x02 \x01(\x05R\n" +
+	"onlineBmcs\x12!\n" +
+	"\foffline_bmcs\x18\x03 \x01(\x05R\vofflineBmcs\x12%\n" +
+	"\x0etotal_gateways\x18\x04 \x01(\x05R\rtotalGateways\x12'\n" +
+	"\x0factive_gateways\x18\x05 \x01(\x05R\x0eactiveGateways\x12'\n" +
+	"\x0ftotal_customers\x18\x06 \x01(\x05R\x0etotalCustomers\x12'\n" +
+	"\x0factive_sessions\x18\a \x01(\x05R\x0eactiveSessions\"\xed\x01\n" +
+	"\x15ListAllServersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12'\n" +
+	"\x0fcustomer_filter\x18\x03 \x01(\tR\x0ecustomerFilter\x12#\n" +
+	"\rregion_filter\x18\x04 \x03(\tR\fregionFilter\x12%\n" +
+	"\x0egateway_filter\x18\x05 \x01(\tR\rgatewayFilter\x12#\n" +
+	"\rstatus_filter\x18\x06 \x01(\tR\fstatusFilter\"\x96\x01\n" +
+	"\x16ListAllServersResponse\x123\n" +
+	"\aservers\x18\x01 \x03(\v2\x19.manager.v1.ServerDetailsR\aservers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"\xeb\x04\n" +
+	"\rServerDetails\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rdatacenter_id\x18\x03 \x01(\tR\fdatacenterId\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x04 \x01(\tR\tgatewayId\x12)\n" +
+	"\x10primary_endpoint\x18\x05 \x01(\tR\x0fprimaryEndpoint\x12)\n" +
+	"\x10primary_protocol\x18\x06 \x01(\tR\x0fprimaryProtocol\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x17\n" +
+	"\ahas_vnc\x18\b \x01(\bR\x06hasVnc\x12\x17\n" +
+	"\ahas_sol\x18\t \x01(\bR\x06hasSol\x127\n" +
+	"\tlast_seen\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12)\n" +
+	"\x10maintenance_mode\x18\f \x01(\bR\x0fmaintenanceMode\x12K\n" +
+	"\x12discovery_metadata\x18\r \x01(\v2\x1c.common.v1.DiscoveryMetadataR\x11discoveryMetadata\x12L\n" +
+	"\x12connectivity_audit\x18\x0e \x01(\v2\x1d.manager.v1.ConnectivityAuditR\x11connectivityAudit\"U\n" +
+	"\x17ListAllCustomersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"}\n" +
+	"\x18ListAllCustomersResponse\x129\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x1b.manager.v1.CustomerSummaryR\tcustomers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe3\x03\n" +
+	"\x0fCustomerSummary\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12!\n" +
+	"\fserver_count\x18\x03 \x01(\x05R\vserverCount\x12.\n" +
+	"\x13online_server_count\x18\x04 \x01(\x05R\x11onlineServerCount\x12\x19\n" +
+	"\bis_admin\x18\x05 \x01(\bR\aisAdmin\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\x17max_concurrent_sessions\x18\a \x01(\x05R\x15maxConcurrentSessions\x125\n" +
+	"\x17max_sessions_per_minute\x18\b \x01(\x05R\x14maxSessionsPerMinute\x12\x1a\n" +
+	"\bdisabled\x18\t \x01(\bR\bdisabled\x12!\n" +
+	"\ftotp_enabled\x18\n" +
+	" \x01(\bR\vtotpEnabled\x12\x1f\n" +
+	"\vrequire_mfa\x18\v \x01(\bR\n" +
+	"requireMfa\x12!\n" +
+	"\fip_allowlist\x18\f \x03(\tR\vipAllowlist\"\x19\n" +
+	"\x17GetGatewayHealthRequest\"Q\n" +
+	"\x18GetGatewayHealthResponse\x125\n" +
+	"\bgateways\x18\x01 \x03(\v2\x19.manager.v1.GatewayHealthR\bgateways\"\xfd\x01\n" +
+	"\rGatewayHealth\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x127\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12!\n" +
+	"\fserver_count\x18\x06 \x01(\x05R\vserverCount\x12%\n" +
+	"\x0edatacenter_ids\x18\a \x03(\tR\rdatacenterIds\"\x13\n" +
+	"\x11GetRegionsRequest\".\n" +
+	"\x12GetRegionsResponse\x12\x18\n" +
+	"\aregions\x18\x01 \x03(\tR\aregions\"K\n" +
+	"\x14LaunchSessionRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xbf\x01\n" +
+	"\x15LaunchSessionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12-\n" +
+	"\x12websocket_endpoint\x18\x02 \x01(\tR\x11websocketEndpoint\x12\x1d\n" +
+	"\n" +
+	"viewer_url\x18\x03 \x01(\tR\tviewerUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"C\n" +
+	"\x1aGetConsoleSLOReportRequest\x12%\n" +
+	"\x0egateway_filter\x18\x01 \x01(\tR\rgatewayFilter\"\x81\x03\n" +
+	"\x1bGetConsoleSLOReportResponse\x12=\n" +
+	"\fwindow_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\x12@\n" +
+	"\n" +
+	"objectives\x18\x03 \x01(\v2 .manager.v1.ConsoleSLOObjectivesR\n" +
+	"objectives\x126\n" +
+	"\aoverall\x18\x04 \x01(\v2\x1c.manager.v1.ConsoleSLOStatusR\aoverall\x128\n" +
+	"\bgateways\x18\x05 \x03(\v2\x1c.manager.v1.ConsoleSLOStatusR\bgateways\x124\n" +
+	"\x06agents\x18\x06 \x03(\v2\x1c.manager.v1.ConsoleSLOStatusR\x06agents\"\xbb\x01\n" +
+	"\x14ConsoleSLOObjectives\x12/\n" +
+	"\x13availability_target\x18\x01 \x01(\x01R\x12availabilityTarget\x12\x1f\n" +
+	"\vttfb_target\x18\x02 \x01(\x01R\n" +
+	"ttfbTarget\x12*\n" +
+	"\x11ttfb_threshold_ms\x18\x03 \x01(\x03R\x0fttfbThresholdMs\x12%\n" +
+	"\x0ewindow_seconds\x18\x04 \x01(\x03R\rwindowSeconds\"\xc5\x03\n" +
+	"\x10ConsoleSLOStatus\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x03R\battempts\x12\x1c\n" +
+	"\tsuccesses\x18\x04 \x01(\x03R\tsuccesses\x12!\n" +
+	"\fsuccess_rate\x18\x05 \x01(\x01R\vsuccessRate\x12)\n" +
+	"\x10availability_met\x18\x06 \x01(\bR\x0favailabilityMet\x124\n" +
+	"\x16error_budget_remaining\x18\a \x01(\x01R\x14errorBudgetRemaining\x12!\n" +
+	"\fttfb_samples\x18\b \x01(\x03R\vttfbSamples\x122\n" +
+	"\x15ttfb_within_threshold\x18\t \x01(\x03R\x13ttfbWithinThreshold\x12'\n" +
+	"\x0fttfb_compliance\x18\n" +
+	" \x01(\x01R\x0ettfbCompliance\x12\x19\n" +
+	"\bttfb_met\x18\v \x01(\bR\attfbMet\x12\x1e\n" +
+	"\vavg_ttfb_ms\x18\f \x01(\x01R\tavgTtfbMs\"\xaf\x01\n" +
+	"\x1aGetPowerUsageReportRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\"\xf7\x01\n" +
+	"\x1bGetPowerUsageReportResponse\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12(\n" +
+	"\x10total_energy_kwh\x18\x03 \x01(\x01R\x0etotalEnergyKwh\x12<\n" +
+	"\tcustomers\x18\x04 \x03(\v2\x1e.manager.v1.CustomerPowerUsageR\tcustomers\"\xd4\x01\n" +
+	"\x12CustomerPowerUsage\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x02 \x01(\x01R\tenergyKwh\x12#\n" +
+	"\raverage_watts\x18\x03 \x01(\x01R\faverageWatts\x12!\n" +
+	"\fserver_count\x18\x04 \x01(\x05R\vserverCount\x126\n" +
+	"\aservers\x18\x05 \x03(\v2\x1c.manager.v1.ServerPowerUsageR\aservers\"\x99\x02\n" +
+	"\x10ServerPowerUsage\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12#\n" +
+	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x03 \x01(\x01R\tenergyKwh\x12#\n" +
+	"\raverage_watts\x18\x04 \x01(\x01R\faverageWatts\x12\x1d\n" +
+	"\n" +
+	"peak_watts\x18\x05 \x01(\x01R\tpeakWatts\x12!\n" +
+	"\fsample_count\x18\x06 \x01(\x03R\vsampleCount\x12#\n" +
+	"\rsampled_hours\x18\a \x01(\x01R\fsampledHours\x12\x18\n" +
+	"\ametered\x18\b \x01(\bR\ametered\"\x81\x01\n" +
+	"\x1aListConsoleSessionsRequest\x12%\n" +
+	"\x0egateway_filter\x18\x01 \x01(\tR\rgatewayFilter\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\"\xa8\x01\n" +
+	"\x1bListConsoleSessionsResponse\x126\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1a.manager.v1.ConsoleSessionR\bsessions\x12Q\n" +
+	"\x14unreachable_gateways\x18\x02 \x03(\v2\x1e.manager.v1.UnreachableGatewayR\x13unreachableGateways\"\x94\x04\n" +
+	"\x0eConsoleSession\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1b\n" +
+	"\tserver_id\x18\x05 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x06 \x01(\tR\n" +
+	"customerId\x12%\n" +
+	"\x0ecustomer_email\x18\a \x01(\tR\rcustomerEmail\x12\x19\n" +
+	"\bagent_id\x18\b \x01(\tR\aagentId\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x123\n" +
+	"\astreams\x18\v \x03(\v2\x19.manager.v1.ConsoleStreamR\astreams\x12'\n" +
+	"\x0fimpersonated_by\x18\f \x01(\tR\x0eimpersonatedBy\x12D\n" +
+	"\x10last_activity_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"\x91\x01\n" +
+	"\rConsoleStream\x12%\n" +
+	"\x0eclient_address\x18\x01 \x01(\tR\rclientAddress\x12\x1c\n" +
+	"\ttransport\x18\x02 \x01(\tR\ttransport\x12;\n" +
+	"\vattached_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"attachedAt\"I\n" +
+	"\x12UnreachableGateway\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"v\n" +
+	"\x1eTerminateConsoleSessionRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"T\n" +
+	"\x1fTerminateConsoleSessionResponse\x121\n" +
+	"\x14disconnected_streams\x18\x01 \x01(\x05R\x13disconnectedStreams\"f\n" +
+	"\x12GetTopologyRequest\x12#\n" +
+	"\rregion_filter\x18\x01 \x03(\tR\fregionFilter\x12+\n" +
+	"\x11datacenter_filter\x18\x02 \x01(\tR\x10datacenterFilter\"\xe0\x01\n" +
+	"\x13GetTopologyResponse\x127\n" +
+	"\bgateways\x18\x01 \x03(\v2\x1b.manager.v1.TopologyGatewayR\bgateways\x12Q\n" +
+	"\x14unreachable_gateways\x18\x02 \x03(\v2\x1e.manager.v1.UnreachableGatewayR\x13unreachableGateways\x12=\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xb1\x01\n" +
+	"\x0fTopologyGateway\x123\n" +
+	"\agateway\x18\x01 \x01(\v2\x19.manager.v1.GatewayHealthR\agateway\x121\n" +
+	"\x06agents\x18\x02 \x03(\v2\x19.manager.v1.TopologyAgentR\x06agents\x126\n" +
+	"\bsessions\x18\x03 \x03(\v2\x1a.manager.v1.ConsoleSessionR\bsessions\"\xcb\x02\n" +
+	"\rTopologyAgent\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
+	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x127\n" +
+	"\tlast_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x120\n" +
+	"\x14active_session_count\x18\a \x01(\x05R\x12activeSessionCount\x12A\n" +
+	"\rbmc_endpoints\x18\b \x03(\v2\x1c.manager.v1.TopologyEndpointR\fbmcEndpoints\"\xbe\x01\n" +
+	"\x10TopologyEndpoint\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12!\n" +
+	"\fbmc_endpoint\x18\x02 \x01(\tR\vbmcEndpoint\x12\x19\n" +
+	"\bbmc_type\x18\x03 \x01(\tR\abmcType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x127\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"L\n" +
+	"\x17ListServerEventsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"K\n" +
+	"\x18ListServerEventsResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.manager.v1.SystemEventR\x06events\"e\n" +
+	"\x1bSetServerMaintenanceRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12)\n" +
+	"\x10maintenance_mode\x18\x02 \x01(\bR\x0fmaintenanceMode\"J\n" +
+	"\x1cSetServerMaintenanceResponse\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.manager.v1.ServerR\x06server\"J\n" +
+	"\x15SetServerNotesRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x14\n" +
+	"\x05notes\x18\x02 \x01(\tR\x05notes\"D\n" +
+	"\x16SetServerNotesResponse\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.manager.v1.ServerR\x06server\"\xb6\x03\n" +
+	"\x11MaintenanceWindow\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12A\n" +
+	"\x06labels\x18\x03 \x03(\v2).manager.v1.MaintenanceWindow.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06policy\x18\x04 \x01(\tR\x06policy\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x127\n" +
+	"\tstarts_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe6\x02\n" +
+	"\x1eCreateMaintenanceWindowRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12N\n" +
+	"\x06labels\x18\x02 \x03(\v26.manager.v1.CreateMaintenanceWindowRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06policy\x18\x03 \x01(\tR\x06policy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x127\n" +
+	"\tstarts_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"X\n" +
+	"\x1fCreateMaintenanceWindowResponse\x125\n" +
+	"\x06window\x18\x01 \x01(\v2\x1d.manager.v1.MaintenanceWindowR\x06window\"a\n" +
+	"\x1dListMaintenanceWindowsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12#\n" +
+	"\rinclude_ended\x18\x02 \x01(\bR\fincludeEnded\"Y\n" +
+	"\x1eListMaintenanceWindowsResponse\x127\n" +
+	"\awindows\x18\x01 \x03(\v2\x1d.manager.v1.MaintenanceWindowR\awindows\"0\n" +
+	"\x1eDeleteMaintenanceWindowRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"!\n" +
+	"\x1fDeleteMaintenanceWindowResponse\"\xb0\x01\n" +
+	"\x1eSetCustomerSessionQuotaRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x126\n" +
+	"\x17max_concurrent_sessions\x18\x02 \x01(\x05R\x15maxConcurrentSessions\x125\n" +
+	"\x17max_sessions_per_minute\x18\x03 \x01(\x05R\x14maxSessionsPerMinute\"\xb1\x01\n" +
+	"\x1fSetCustomerSessionQuotaResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x126\n" +
+	"\x17max_concurrent_sessions\x18\x02 \x01(\x05R\x15maxConcurrentSessions\x125\n" +
+	"\x17max_sessions_per_minute\x18\x03 \x01(\x05R\x14maxSessionsPerMinute\"~\n" +
+	"\x1bSetCustomerMFAPolicyRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\vrequire_mfa\x18\x02 \x01(\bR\n" +
+	"requireMfa\x12\x1d\n" +
+	"\n" +
+	"reset_totp\x18\x03 \x01(\bR\tresetTotp\"\x83\x01\n" +
+	"\x1cSetCustomerMFAPolicyResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\vrequire_mfa\x18\x02 \x01(\bR\n" +
+	"requireMfa\x12!\n" +
+	"\ftotp_enabled\x18\x03 \x01(\bR\vtotpEnabled\"V\n" +
+	"\x1dSetCustomerIPAllowlistRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05cidrs\x18\x02 \x03(\tR\x05cidrs\"W\n" +
+	"\x1eSetCustomerIPAllowlistResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05cidrs\x18\x02 \x03(\tR\x05cidrs\"c\n" +
+	"\x12ExportUsageRequest\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\"\x9b\x01\n" +
+	"\x13ExportUsageResponse\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x127\n" +
+	"\tcustomers\x18\x02 \x03(\v2\x19.manager.v1.CustomerUsageR\tcustomers\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\"\xe7\x01\n" +
+	"\rCustomerUsage\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12)\n" +
+	"\x10console_sessions\x18\x02 \x01(\x03R\x0fconsoleSessions\x12'\n" +
+	"\x0fconsole_minutes\x18\x03 \x01(\x01R\x0econsoleMinutes\x12)\n" +
+	"\x10power_operations\x18\x04 \x01(\x03R\x0fpowerOperations\x12\x19\n" +
+	"\bbytes_in\x18\x05 \x01(\x03R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\x06 \x01(\x03R\bbytesOut\"6\n" +
+	"\x15ApproveGatewayRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\"M\n" +
+	"\x16ApproveGatewayResponse\x123\n" +
+	"\agateway\x18\x01 \x01(\v2\x19.manager.v1.GatewayHealthR\agateway\"i\n" +
+	"\x15CreateCustomerRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x19\n" +
+	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\"j\n" +
+	"\x16CreateCustomerResponse\x127\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x1b.manager.v1.CustomerSummaryR\bcustomer\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\"5\n" +
+	"\x12IssueAPIKeyRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"O\n" +
+	"\x13IssueAPIKeyResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\"?\n" +
+	"\x1cResetCustomerPasswordRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"\x9c\x01\n" +
+	"\x1dResetCustomerPasswordResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\vreset_token\x18\x02 \x01(\tR\n" +
+	"resetToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"i\n" +
+	"\x16DisableCustomerRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06enable\x18\x03 \x01(\bR\x06enable\"\xd6\x01\n" +
+	"\x17DisableCustomerResponse\x127\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x1b.manager.v1.CustomerSummaryR\bcustomer\x12/\n" +
+	"\x13terminated_sessions\x18\x02 \x01(\x05R\x12terminatedSessions\x12Q\n" +
+	"\x14unreachable_gateways\x18\x03 \x03(\v2\x1e.manager.v1.UnreachableGatewayR\x13unreachableGateways\"q\n" +
+	"\x15DeleteCustomerRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\vreassign_to\x18\x02 \x01(\tR\n" +
+	"reassignTo\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xcb\x01\n" +
+	"\x16DeleteCustomerResponse\x12-\n" +
+	"\x12reassigned_servers\x18\x01 \x01(\x05R\x11reassignedServers\x12/\n" +
+	"\x13terminated_sessions\x18\x02 \x01(\x05R\x12terminatedSessions\x12Q\n" +
+	"\x14unreachable_gateways\x18\x03 \x03(\v2\x1e.manager.v1.UnreachableGatewayR\x13unreachableGateways\"S\n" +
+	"\x13AssignServerRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\"t\n" +
+	"\x14AssignServerResponse\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.manager.v1.ServerR\x06server\x120\n" +
+	"\x14previous_customer_id\x18\x02 \x01(\tR\x12previousCustomerId\"[\n" +
+	"\x14ImportServersRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\xb6\x01\n" +
+	"\x15ImportServersResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.manager.v1.ServerImportResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"s\n" +
+	"\x12ServerImportResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"W\n" +
+	"\x14ExportServersRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12'\n" +
+	"\x0fcustomer_filter\x18\x02 \x01(\tR\x0ecustomerFilter\"d\n" +
+	"\x15ExportServersResponse\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xab\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x120\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"I\n" +
+	"\x17ListAuditEventsResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.manager.v1.AuditEventR\x06events\"\xc0\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\vtarget_type\x18\x05 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x06 \x01(\tR\btargetId\x12=\n" +
+	"\adetails\x18\a \x03(\v2#.manager.v1.AuditEvent.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"m\n" +
+	"\x1dListConnectivityAuditsRequest\x12#\n" +
+	"\rfailures_only\x18\x01 \x01(\bR\ffailuresOnly\x12'\n" +
+	"\x0fcustomer_filter\x18\x02 \x01(\tR\x0ecustomerFilter\"\x93\x01\n" +
+	"\x1eListConnectivityAuditsResponse\x125\n" +
+	"\x06audits\x18\x01 \x03(\v2\x1d.manager.v1.ConnectivityAuditR\x06audits\x12:\n" +
+	"\vlast_run_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\":\n" +
+	"\x1bRunConnectivityAuditRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"S\n" +
+	"\x1cRunConnectivityAuditResponse\x123\n" +
+	"\x05audit\x18\x01 \x01(\v2\x1d.manager.v1.ConnectivityAuditR\x05audit\"\xf3\x02\n" +
+	"\x11ConnectivityAudit\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12#\n" +
+	"\rdatacenter_id\x18\x03 \x01(\tR\fdatacenterId\x129\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12;\n" +
+	"\vverified_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\x12\x18\n" +
+	"\ahealthy\x18\x06 \x01(\bR\ahealthy\x12\x1d\n" +
+	"\n" +
+	"failed_hop\x18\a \x01(\tR\tfailedHop\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x124\n" +
+	"\x04hops\x18\t \x03(\v2 .manager.v1.ConnectivityAuditHopR\x04hops\"\x8f\x01\n" +
+	"\x14ConnectivityAuditHop\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt2\x8c\x05\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xe7\x01\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12U\n" +
+	"\rmodule_levels\x18\x02 \x03(\v20.manager.v1.SetLogLevelRequest.ModuleLevelsEntryR\fmoduleLevels\x12#\n" +
+	"\rreset_modules\x18\x03 \x01(\bR\fresetModules\x1a?\n" +
+	"\x11ModuleLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc4\x01\n" +
+	"\x13SetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12V\n" +
+	"\rmodule_levels\x18\x02 \x03(\v21.manager.v1.SetLogLevelResponse.ModuleLevelsEntryR\fmoduleLevels\x1a?\n" +
+	"\x11ModuleLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xd0\x1a\n" +
 	"\fAdminService\x12f\n" +
 	"\x13GetDashboardMetrics\x12&.manager.v1.GetDashboardMetricsRequest\x1a'.manager.v1.GetDashboardMetricsResponse\x12W\n" +
 	"\x0eListAllServers\x12!.manager.v1.ListAllServersRequest\x1a\".manager.v1.ListAllServersResponse\x12]\n" +
@@ -1071,7 +6054,35 @@ const file_manager_v1_admin_proto_rawDesc = "" +
 	"\n" +
 	"GetRegions\x12\x1d.manager.v1.GetRegionsRequest\x1a\x1e.manager.v1.GetRegionsResponse\x12W\n" +
 	"\x10LaunchVNCSession\x12 .manager.v1.LaunchSessionRequest\x1a!.manager.v1.LaunchSessionResponse\x12W\n" +
-	"\x10LaunchSOLSession\x12 .manager.v1.LaunchSessionRequest\x1a!.manager.v1.LaunchSessionResponseB\"Z manager/gen/manager/v1;managerv1b\x06proto3"
+	"\x10LaunchSOLSession\x12 .manager.v1.LaunchSessionRequest\x1a!.manager.v1.LaunchSessionResponse\x12f\n" +
+	"\x13GetConsoleSLOReport\x12&.manager.v1.GetConsoleSLOReportRequest\x1a'.manager.v1.GetConsoleSLOReportResponse\x12f\n" +
+	"\x13GetPowerUsageReport\x12&.manager.v1.GetPowerUsageReportRequest\x1a'.manager.v1.GetPowerUsageReportResponse\x12f\n" +
+	"\x13ListConsoleSessions\x12&.manager.v1.ListConsoleSessionsRequest\x1a'.manager.v1.ListConsoleSessionsResponse\x12r\n" +
+	"\x17TerminateConsoleSession\x12*.manager.v1.TerminateConsoleSessionRequest\x1a+.manager.v1.TerminateConsoleSessionResponse\x12N\n" +
+	"\vGetTopology\x12\x1e.manager.v1.GetTopologyRequest\x1a\x1f.manager.v1.GetTopologyResponse\x12]\n" +
+	"\x10ListServerEvents\x12#.manager.v1.ListServerEventsRequest\x1a$.manager.v1.ListServerEventsResponse\x12i\n" +
+	"\x14SetServerMaintenance\x12'.manager.v1.SetServerMaintenanceRequest\x1a(.manager.v1.SetServerMaintenanceResponse\x12W\n" +
+	"\x0eSetServerNotes\x12!.manager.v1.SetServerNotesRequest\x1a\".manager.v1.SetServerNotesResponse\x12r\n" +
+	"\x17CreateMaintenanceWindow\x12*.manager.v1.CreateMaintenanceWindowRequest\x1a+.manager.v1.CreateMaintenanceWindowResponse\x12o\n" +
+	"\x16ListMaintenanceWindows\x12).manager.v1.ListMaintenanceWindowsRequest\x1a*.manager.v1.ListMaintenanceWindowsResponse\x12r\n" +
+	"\x17DeleteMaintenanceWindow\x12*.manager.v1.DeleteMaintenanceWindowRequest\x1a+.manager.v1.DeleteMaintenanceWindowResponse\x12r\n" +
+	"\x17SetCustomerSessionQuota\x12*.manager.v1.SetCustomerSessionQuotaRequest\x1a+.manager.v1.SetCustomerSessionQuotaResponse\x12i\n" +
+	"\x14SetCustomerMFAPolicy\x12'.manager.v1.SetCustomerMFAPolicyRequest\x1a(.manager.v1.SetCustomerMFAPolicyResponse\x12o\n" +
+	"\x16SetCustomerIPAllowlist\x12).manager.v1.SetCustomerIPAllowlistRequest\x1a*.manager.v1.SetCustomerIPAllowlistResponse\x12N\n" +
+	"\vExportUsage\x12\x1e.manager.v1.ExportUsageRequest\x1a\x1f.manager.v1.ExportUsageResponse\x12W\n" +
+	"\x0eApproveGateway\x12!.manager.v1.ApproveGatewayRequest\x1a\".manager.v1.ApproveGatewayResponse\x12W\n" +
+	"\x0eCreateCustomer\x12!.manager.v1.CreateCustomerRequest\x1a\".manager.v1.CreateCustomerResponse\x12N\n" +
+	"\vIssueAPIKey\x12\x1e.manager.v1.IssueAPIKeyRequest\x1a\x1f.manager.v1.IssueAPIKeyResponse\x12l\n" +
+	"\x15ResetCustomerPassword\x12(.manager.v1.ResetCustomerPasswordRequest\x1a).manager.v1.ResetCustomerPasswordResponse\x12Z\n" +
+	"\x0fDisableCustomer\x12\".manager.v1.DisableCustomerRequest\x1a#.manager.v1.DisableCustomerResponse\x12W\n" +
+	"\x0eDeleteCustomer\x12!.manager.v1.DeleteCustomerRequest\x1a\".manager.v1.DeleteCustomerResponse\x12Q\n" +
+	"\fAssignServer\x12\x1f.manager.v1.AssignServerRequest\x1a .manager.v1.AssignServerResponse\x12T\n" +
+	"\rImportServers\x12 .manager.v1.ImportServersRequest\x1a!.manager.v1.ImportServersResponse\x12T\n" +
+	"\rExportServers\x12 .manager.v1.ExportServersRequest\x1a!.manager.v1.ExportServersResponse\x12Z\n" +
+	"\x0fListAuditEvents\x12\".manager.v1.ListAuditEventsRequest\x1a#.manager.v1.ListAuditEventsResponse\x12o\n" +
+	"\x16ListConnectivityAudits\x12).manager.v1.ListConnectivityAuditsRequest\x1a*.manager.v1.ListConnectivityAuditsResponse\x12i\n" +
+	"\x14RunConnectivityAudit\x12'.manager.v1.RunConnectivityAuditRequest\x1a(.manager.v1.RunConnectivityAuditResponse\x12N\n" +
+	"\vSetLogLevel\x12\x1e.manager.v1.SetLogLevelRequest\x1a\x1f.manager.v1.SetLogLevelResponseB\"Z manager/gen/manager/v1;managerv1b\x06proto3"
 
 var (
 	file_manager_v1_admin_proto_rawDescOnce sync.Once
@@ -1085,53 +6096,252 @@ func file_manager_v1_admin_proto_rawDescGZIP() []byte {
 	return file_manager_v1_admin_proto_rawDescData
 }
 
-var file_manager_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_manager_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 92)
 var file_manager_v1_admin_proto_goTypes = []any{
-	(*GetDashboardMetricsRequest)(nil),  // 0: manager.v1.GetDashboardMetricsRequest
-	(*GetDashboardMetricsResponse)(nil), // 1: manager.v1.GetDashboardMetricsResponse
-	(*ListAllServersRequest)(nil),       // 2: manager.v1.ListAllServersRequest
-	(*ListAllServersResponse)(nil),      // 3: manager.v1.ListAllServersResponse
-	(*ServerDetails)(nil),               // 4: manager.v1.ServerDetails
-	(*ListAllCustomersRequest)(nil),     // 5: manager.v1.ListAllCustomersRequest
-	(*ListAllCustomersResponse)(nil),    // 6: manager.v1.ListAllCustomersResponse
-	(*CustomerSummary)(nil),             // 7: manager.v1.CustomerSummary
-	(*GetGatewayHealthRequest)(nil),     // 8: manager.v1.GetGatewayHealthRequest
-	(*GetGatewayHealthResponse)(nil),    // 9: manager.v1.GetGatewayHealthResponse
-	(*GatewayHealth)(nil),               // 10: manager.v1.GatewayHealth
-	(*GetRegionsRequest)(nil),           // 11: manager.v1.GetRegionsRequest
-	(*GetRegionsResponse)(nil),          // 12: manager.v1.GetRegionsResponse
-	(*LaunchSessionRequest)(nil),        // 13: manager.v1.LaunchSessionRequest
-	(*LaunchSessionResponse)(nil),       // 14: manager.v1.LaunchSessionResponse
-	(*timestamppb.Timestamp)(nil),       // 15: google.protobuf.Timestamp
+	(*GetDashboardMetricsRequest)(nil),      // 0: manager.v1.GetDashboardMetricsRequest
+	(*GetDashboardMetricsResponse)(nil),     // 1: manager.v1.GetDashboardMetricsResponse
+	(*ListAllServersRequest)(nil),           // 2: manager.v1.ListAllServersRequest
+	(*ListAllServersResponse)(nil),          // 3: manager.v1.ListAllServersResponse
+	(*ServerDetails)(nil),                   // 4: manager.v1.ServerDetails
+	(*ListAllCustomersRequest)(nil),         // 5: manager.v1.ListAllCustomersRequest
+	(*ListAllCustomersResponse)(nil),        // 6: manager.v1.ListAllCustomersResponse
+	(*CustomerSummary)(nil),                 // 7: manager.v1.CustomerSummary
+	(*GetGatewayHealthRequest)(nil),         // 8: manager.v1.GetGatewayHealthRequest
+	(*GetGatewayHealthResponse)(nil),        // 9: manager.v1.GetGatewayHealthResponse
+	(*GatewayHealth)(nil),                   // 10: manager.v1.GatewayHealth
+	(*GetRegionsRequest)(nil),               // 11: manager.v1.GetRegionsRequest
+	(*GetRegionsResponse)(nil),              // 12: manager.v1.GetRegionsResponse
+	(*LaunchSessionRequest)(nil),            // 13: manager.v1.LaunchSessionRequest
+	(*LaunchSessionResponse)(nil),           // 14: manager.v1.LaunchSessionResponse
+	(*GetConsoleSLOReportRequest)(nil),      // 15: manager.v1.GetConsoleSLOReportRequest
+	(*GetConsoleSLOReportResponse)(nil),     // 16: manager.v1.GetConsoleSLOReportResponse
+	(*ConsoleSLOObjectives)(nil),            // 17: manager.v1.ConsoleSLOObjectives
+	(*ConsoleSLOStatus)(nil),                // 18: manager.v1.ConsoleSLOStatus
+	(*GetPowerUsageReportRequest)(nil),      // 19: manager.v1.GetPowerUsageReportRequest
+	(*GetPowerUsageReportResponse)(nil),     // 20: manager.v1.GetPowerUsageReportResponse
+	(*CustomerPowerUsage)(nil),              // 21: manager.v1.CustomerPowerUsage
+	(*ServerPowerUsage)(nil),                // 22: manager.v1.ServerPowerUsage
+	(*ListConsoleSessionsRequest)(nil),      // 23: manager.v1.ListConsoleSessionsRequest
+	(*ListConsoleSessionsResponse)(nil),     // 24: manager.v1.ListConsoleSessionsResponse
+	(*ConsoleSession)(nil),                  // 25: manager.v1.ConsoleSession
+	(*ConsoleStream)(nil),                   // 26: manager.v1.ConsoleStream
+	(*UnreachableGateway)(nil),              // 27: manager.v1.UnreachableGateway
+	(*TerminateConsoleSessionRequest)(nil),  // 28: manager.v1.TerminateConsoleSessionRequest
+	(*TerminateConsoleSessionResponse)(nil), // 29: manager.v1.TerminateConsoleSessionResponse
+	(*GetTopologyRequest)(nil),              // 30: manager.v1.GetTopologyRequest
+	(*GetTopologyResponse)(nil),             // 31: manager.v1.GetTopologyResponse
+	(*TopologyGateway)(nil),                 // 32: manager.v1.TopologyGateway
+	(*TopologyAgent)(nil),                   // 33: manager.v1.TopologyAgent
+	(*TopologyEndpoint)(nil),                // 34: manager.v1.TopologyEndpoint
+	(*ListServerEventsRequest)(nil),         // 35: manager.v1.ListServerEventsRequest
+	(*ListServerEventsResponse)(nil),        // 36: manager.v1.ListServerEventsResponse
+	(*SetServerMaintenanceRequest)(nil),     // 37: manager.v1.SetServerMaintenanceRequest
+	(*SetServerMaintenanceResponse)(nil),    // 38: manager.v1.SetServerMaintenanceResponse
+	(*SetServerNotesRequest)(nil),           // 39: manager.v1.SetServerNotesRequest
+	(*SetServerNotesResponse)(nil),          // 40: manager.v1.SetServerNotesResponse
+	(*MaintenanceWindow)(nil),               // 41: manager.v1.MaintenanceWindow
+	(*CreateMaintenanceWindowRequest)(nil),  // 42: manager.v1.CreateMaintenanceWindowRequest
+	(*CreateMaintenanceWindowResponse)(nil), // 43: manager.v1.CreateMaintenanceWindowResponse
+	(*ListMaintenanceWindowsRequest)(nil),   // 44: manager.v1.ListMaintenanceWindowsRequest
+	(*ListMaintenanceWindowsResponse)(nil),  // 45: manager.v1.ListMaintenanceWindowsResponse
+	(*DeleteMaintenanceWindowRequest)(nil),  // 46: manager.v1.DeleteMaintenanceWindowRequest
+	(*DeleteMaintenanceWindowResponse)(nil), // 47: manager.v1.DeleteMaintenanceWindowResponse
+	(*SetCustomerSessionQuotaRequest)(nil),  // 48: manager.v1.SetCustomerSessionQuotaRequest
+	(*SetCustomerSessionQuotaResponse)(nil), // 49: manager.v1.SetCustomerSessionQuotaResponse
+	(*SetCustomerMFAPolicyRequest)(nil),     // 50: manager.v1.SetCustomerMFAPolicyRequest
+	(*SetCustomerMFAPolicyResponse)(nil),    // 51: manager.v1.SetCustomerMFAPolicyResponse
+	(*SetCustomerIPAllowlistRequest)(nil),   // 52: manager.v1.SetCustomerIPAllowlistRequest
+	(*SetCustomerIPAllowlistResponse)(nil),  // 53: manager.v1.SetCustomerIPAllowlistResponse
+	(*ExportUsageRequest)(nil),              // 54: manager.v1.ExportUsageRequest
+	(*ExportUsageResponse)(nil),             // 55: manager.v1.ExportUsageResponse
+	(*CustomerUsage)(nil),                   // 56: manager.v1.CustomerUsage
+	(*ApproveGatewayRequest)(nil),           // 57: manager.v1.ApproveGatewayRequest
+	(*ApproveGatewayResponse)(nil),          // 58: manager.v1.ApproveGatewayResponse
+	(*CreateCustomerRequest)(nil),           // 59: manager.v1.CreateCustomerRequest
+	(*CreateCustomerResponse)(nil),          // 60: manager.v1.CreateCustomerResponse
+	(*IssueAPIKeyRequest)(nil),              // 61: manager.v1.IssueAPIKeyRequest
+	(*IssueAPIKeyResponse)(nil),             // 62: manager.v1.IssueAPIKeyResponse
+	(*ResetCustomerPasswordRequest)(nil),    // 63: manager.v1.ResetCustomerPasswordRequest
+	(*ResetCustomerPasswordResponse)(nil),   // 64: manager.v1.ResetCustomerPasswordResponse
+	(*DisableCustomerRequest)(nil),          // 65: manager.v1.DisableCustomerRequest
+	(*DisableCustomerResponse)(nil),         // 66: manager.v1.DisableCustomerResponse
+	(*DeleteCustomerRequest)(nil),           // 67: manager.v1.DeleteCustomerRequest
+	(*DeleteCustomerResponse)(nil),          // 68: manager.v1.DeleteCustomerResponse
+	(*AssignServerRequest)(nil),             // 69: manager.v1.AssignServerRequest
+	(*AssignServerResponse)(nil),            // 70: manager.v1.AssignServerResponse
+	(*ImportServersRequest)(nil),            // 71: manager.v1.ImportServersRequest
+	(*ImportServersResponse)(nil),           // 72: manager.v1.ImportServersResponse
+	(*ServerImportResult)(nil),              // 73: manager.v1.ServerImportResult
+	(*ExportServersRequest)(nil),            // 74: manager.v1.ExportServersRequest
+	(*ExportServersResponse)(nil),           // 75: manager.v1.ExportServersResponse
+	(*ListAuditEventsRequest)(nil),          // 76: manager.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),         // 77: manager.v1.ListAuditEventsResponse
+	(*AuditEvent)(nil),                      // 78: manager.v1.AuditEvent
+	(*ListConnectivityAuditsRequest)(nil),   // 79: manager.v1.ListConnectivityAuditsRequest
+	(*ListConnectivityAuditsResponse)(nil),  // 80: manager.v1.ListConnectivityAuditsResponse
+	(*RunConnectivityAuditRequest)(nil),     // 81: manager.v1.RunConnectivityAuditRequest
+	(*RunConnectivityAuditResponse)(nil),    // 82: manager.v1.RunConnectivityAuditResponse
+	(*ConnectivityAudit)(nil),               // 83: manager.v1.ConnectivityAudit
+	(*ConnectivityAuditHop)(nil),            // 84: manager.v1.ConnectivityAuditHop
+	(*SetLogLevelRequest)(nil),              // 85: manager.v1.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),             // 86: manager.v1.SetLogLevelResponse
+	nil,                                     // 87: manager.v1.MaintenanceWindow.LabelsEntry
+	nil,                                     // 88: manager.v1.CreateMaintenanceWindowRequest.LabelsEntry
+	nil,                                     // 89: manager.v1.AuditEvent.DetailsEntry
+	nil,                                     // 90: manager.v1.SetLogLevelRequest.ModuleLevelsEntry
+	nil,                                     // 91: manager.v1.SetLogLevelResponse.ModuleLevelsEntry
+	(*timestamppb.Timestamp)(nil),           // 92: google.protobuf.Timestamp
+	(*v1.DiscoveryMetadata)(nil),            // 93: common.v1.DiscoveryMetadata
+	(*SystemEvent)(nil),                     // 94: manager.v1.SystemEvent
+	(*Server)(nil),                          // 95: manager.v1.Server
 }
 var file_manager_v1_admin_proto_depIdxs = []int32{
-	4,  // 0: manager.v1.ListAllServersResponse.servers:type_name -> manager.v1.ServerDetails
-	15, // 1: manager.v1.ServerDetails.last_seen:type_name -> google.protobuf.Timestamp
-	15, // 2: manager.v1.ServerDetails.created_at:type_name -> google.protobuf.Timestamp
-	7,  // 3: manager.v1.ListAllCustomersResponse.customers:type_name -> manager.v1.CustomerSummary
-	15, // 4: manager.v1.CustomerSummary.created_at:type_name -> google.protobuf.Timestamp
-	10, // 5: manager.v1.GetGatewayHealthResponse.gateways:type_name -> manager.v1.GatewayHealth
-	15, // 6: manager.v1.GatewayHealth.last_seen:type_name -> google.protobuf.Timestamp
-	15, // 7: manager.v1.LaunchSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
-	0,  // 8: manager.v1.AdminService.GetDashboardMetrics:input_type -> manager.v1.GetDashboardMetricsRequest
-	2,  // 9: manager.v1.AdminService.ListAllServers:input_type -> manager.v1.ListAllServersRequest
-	5,  // 10: manager.v1.AdminService.ListAllCustomers:input_type -> manager.v1.ListAllCustomersRequest
-	8,  // 11: manager.v1.AdminService.GetGatewayHealth:input_type -> manager.v1.GetGatewayHealthRequest
-	11, // 12: manager.v1.AdminService.GetRegions:input_type -> manager.v1.GetRegionsRequest
-	13, // 13: manager.v1.AdminService.LaunchVNCSession:input_type -> manager.v1.LaunchSessionRequest
-	13, // 14: manager.v1.AdminService.LaunchSOLSession:input_type -> manager.v1.LaunchSessionRequest
-	1,  // 15: manager.v1.AdminService.GetDashboardMetrics:output_type -> manager.v1.GetDashboardMetricsResponse
-	3,  // 16: manager.v1.AdminService.ListAllServers:output_type -> manager.v1.ListAllServersResponse
-	6,  // 17: manager.v1.AdminService.ListAllCustomers:output_type -> manager.v1.ListAllCustomersResponse
-	9,  // 18: manager.v1.AdminService.GetGatewayHealth:output_type -> manager.v1.GetGatewayHealthResponse
-	12, // 19: manager.v1.AdminService.GetRegions:output_type -> manager.v1.GetRegionsResponse
-	14, // 20: manager.v1.AdminService.LaunchVNCSession:output_type -> manager.v1.LaunchSessionResponse
-	14, // 21: manager.v1.AdminService.LaunchSOLSession:output_type -> manager.v1.LaunchSessionResponse
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	4,   // 0: manager.v1.ListAllServersResponse.servers:type_name -> manager.v1.ServerDetails
+	92,  // 1: manager.v1.ServerDetails.last_seen:type_name -> google.protobuf.Timestamp
+	92,  // 2: manager.v1.ServerDetails.created_at:type_name -> google.protobuf.Timestamp
+	93,  // 3: manager.v1.ServerDetails.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
+	83,  // 4: manager.v1.ServerDetails.connectivity_audit:type_name -> manager.v1.ConnectivityAudit
+	7,   // 5: manager.v1.ListAllCustomersResponse.customers:type_name -> manager.v1.CustomerSummary
+	92,  // 6: manager.v1.CustomerSummary.created_at:type_name -> google.protobuf.Timestamp
+	10,  // 7: manager.v1.GetGatewayHealthResponse.gateways:type_name -> manager.v1.GatewayHealth
+	92,  // 8: manager.v1.GatewayHealth.last_seen:type_name -> google.protobuf.Timestamp
+	92,  // 9: manager.v1.LaunchSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	92,  // 10: manager.v1.GetConsoleSLOReportResponse.window_start:type_name -> google.protobuf.Timestamp
+	92,  // 11: manager.v1.GetConsoleSLOReportResponse.window_end:type_name -> google.protobuf.Timestamp
+	17,  // 12: manager.v1.GetConsoleSLOReportResponse.objectives:type_name -> manager.v1.ConsoleSLOObjectives
+	18,  // 13: manager.v1.GetConsoleSLOReportResponse.overall:type_name -> manager.v1.ConsoleSLOStatus
+	18,  // 14: manager.v1.GetConsoleSLOReportResponse.gateways:type_name -> manager.v1.ConsoleSLOStatus
+	18,  // 15: manager.v1.GetConsoleSLOReportResponse.agents:type_name -> manager.v1.ConsoleSLOStatus
+	92,  // 16: manager.v1.GetPowerUsageReportRequest.start_time:type_name -> google.protobuf.Timestamp
+	92,  // 17: manager.v1.GetPowerUsageReportRequest.end_time:type_name -> google.protobuf.Timestamp
+	92,  // 18: manager.v1.GetPowerUsageReportResponse.start_time:type_name -> google.protobuf.Timestamp
+	92,  // 19: manager.v1.GetPowerUsageReportResponse.end_time:type_name -> google.protobuf.Timestamp
+	21,  // 20: manager.v1.GetPowerUsageReportResponse.customers:type_name -> manager.v1.CustomerPowerUsage
+	22,  // 21: manager.v1.CustomerPowerUsage.servers:type_name -> manager.v1.ServerPowerUsage
+	25,  // 22: manager.v1.ListConsoleSessionsResponse.sessions:type_name -> manager.v1.ConsoleSession
+	27,  // 23: manager.v1.ListConsoleSessionsResponse.unreachable_gateways:type_name -> manager.v1.UnreachableGateway
+	92,  // 24: manager.v1.ConsoleSession.created_at:type_name -> google.protobuf.Timestamp
+	92,  // 25: manager.v1.ConsoleSession.expires_at:type_name -> google.protobuf.Timestamp
+	26,  // 26: manager.v1.ConsoleSession.streams:type_name -> manager.v1.ConsoleStream
+	92,  // 27: manager.v1.ConsoleSession.last_activity_at:type_name -> google.protobuf.Timestamp
+	92,  // 28: manager.v1.ConsoleStream.attached_at:type_name -> google.protobuf.Timestamp
+	32,  // 29: manager.v1.GetTopologyResponse.gateways:type_name -> manager.v1.TopologyGateway
+	27,  // 30: manager.v1.GetTopologyResponse.unreachable_gateways:type_name -> manager.v1.UnreachableGateway
+	92,  // 31: manager.v1.GetTopologyResponse.generated_at:type_name -> google.protobuf.Timestamp
+	10,  // 32: manager.v1.TopologyGateway.gateway:type_name -> manager.v1.GatewayHealth
+	33,  // 33: manager.v1.TopologyGateway.agents:type_name -> manager.v1.TopologyAgent
+	25,  // 34: manager.v1.TopologyGateway.sessions:type_name -> manager.v1.ConsoleSession
+	92,  // 35: manager.v1.TopologyAgent.last_seen:type_name -> google.protobuf.Timestamp
+	34,  // 36: manager.v1.TopologyAgent.bmc_endpoints:type_name -> manager.v1.TopologyEndpoint
+	92,  // 37: manager.v1.TopologyEndpoint.last_seen:type_name -> google.protobuf.Timestamp
+	94,  // 38: manager.v1.ListServerEventsResponse.events:type_name -> manager.v1.SystemEvent
+	95,  // 39: manager.v1.SetServerMaintenanceResponse.server:type_name -> manager.v1.Server
+	95,  // 40: manager.v1.SetServerNotesResponse.server:type_name -> manager.v1.Server
+	87,  // 41: manager.v1.MaintenanceWindow.labels:type_name -> manager.v1.MaintenanceWindow.LabelsEntry
+	92,  // 42: manager.v1.MaintenanceWindow.starts_at:type_name -> google.protobuf.Timestamp
+	92,  // 43: manager.v1.MaintenanceWindow.ends_at:type_name -> google.protobuf.Timestamp
+	92,  // 44: manager.v1.MaintenanceWindow.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 45: manager.v1.CreateMaintenanceWindowRequest.labels:type_name -> manager.v1.CreateMaintenanceWindowRequest.LabelsEntry
+	92,  // 46: manager.v1.CreateMaintenanceWindowRequest.starts_at:type_name -> google.protobuf.Timestamp
+	92,  // 47: manager.v1.CreateMaintenanceWindowRequest.ends_at:type_name -> google.protobuf.Timestamp
+	41,  // 48: manager.v1.CreateMaintenanceWindowResponse.window:type_name -> manager.v1.MaintenanceWindow
+	41,  // 49: manager.v1.ListMaintenanceWindowsResponse.windows:type_name -> manager.v1.MaintenanceWindow
+	56,  // 50: manager.v1.ExportUsageResponse.customers:type_name -> manager.v1.CustomerUsage
+	10,  // 51: manager.v1.ApproveGatewayResponse.gateway:type_name -> manager.v1.GatewayHealth
+	7,   // 52: manager.v1.CreateCustomerResponse.customer:type_name -> manager.v1.CustomerSummary
+	92,  // 53: manager.v1.ResetCustomerPasswordResponse.expires_at:type_name -> google.protobuf.Timestamp
+	7,   // 54: manager.v1.DisableCustomerResponse.customer:type_name -> manager.v1.CustomerSummary
+	27,  // 55: manager.v1.DisableCustomerResponse.unreachable_gateways:type_name -> manager.v1.UnreachableGateway
+	27,  // 56: manager.v1.DeleteCustomerResponse.unreachable_gateways:type_name -> manager.v1.UnreachableGateway
+	95,  // 57: manager.v1.AssignServerResponse.server:type_name -> manager.v1.Server
+	73,  // 58: manager.v1.ImportServersResponse.results:type_name -> manager.v1.ServerImportResult
+	92,  // 59: manager.v1.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	78,  // 60: manager.v1.ListAuditEventsResponse.events:type_name -> manager.v1.AuditEvent
+	92,  // 61: manager.v1.AuditEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	89,  // 62: manager.v1.AuditEvent.details:type_name -> manager.v1.AuditEvent.DetailsEntry
+	83,  // 63: manager.v1.ListConnectivityAuditsResponse.audits:type_name -> manager.v1.ConnectivityAudit
+	92,  // 64: manager.v1.ListConnectivityAuditsResponse.last_run_at:type_name -> google.protobuf.Timestamp
+	83,  // 65: manager.v1.RunConnectivityAuditResponse.audit:type_name -> manager.v1.ConnectivityAudit
+	92,  // 66: manager.v1.ConnectivityAudit.checked_at:type_name -> google.protobuf.Timestamp
+	92,  // 67: manager.v1.ConnectivityAudit.verified_at:type_name -> google.protobuf.Timestamp
+	84,  // 68: manager.v1.ConnectivityAudit.hops:type_name -> manager.v1.ConnectivityAuditHop
+	90,  // 69: manager.v1.SetLogLevelRequest.module_levels:type_name -> manager.v1.SetLogLevelRequest.ModuleLevelsEntry
+	91,  // 70: manager.v1.SetLogLevelResponse.module_levels:type_name -> manager.v1.SetLogLevelResponse.ModuleLevelsEntry
+	0,   // 71: manager.v1.AdminService.GetDashboardMetrics:input_type -> manager.v1.GetDashboardMetricsRequest
+	2,   // 72: manager.v1.AdminService.ListAllServers:input_type -> manager.v1.ListAllServersRequest
+	5,   // 73: manager.v1.AdminService.ListAllCustomers:input_type -> manager.v1.ListAllCustomersRequest
+	8,   // 74: manager.v1.AdminService.GetGatewayHealth:input_type -> manager.v1.GetGatewayHealthRequest
+	11,  // 75: manager.v1.AdminService.GetRegions:input_type -> manager.v1.GetRegionsRequest
+	13,  // 76: manager.v1.AdminService.LaunchVNCSession:input_type -> manager.v1.LaunchSessionRequest
+	13,  // 77: manager.v1.AdminService.LaunchSOLSession:input_type -> manager.v1.LaunchSessionRequest
+	15,  // 78: manager.v1.AdminService.GetConsoleSLOReport:input_type -> manager.v1.GetConsoleSLOReportRequest
+	19,  // 79: manager.v1.AdminService.GetPowerUsageReport:input_type -> manager.v1.GetPowerUsageReportRequest
+	23,  // 80: manager.v1.AdminService.ListConsoleSessions:input_type -> manager.v1.ListConsoleSessionsRequest
+	28,  // 81: manager.v1.AdminService.TerminateConsoleSession:input_type -> manager.v1.TerminateConsoleSessionRequest
+	30,  // 82: manager.v1.AdminService.GetTopology:input_type -> manager.v1.GetTopologyRequest
+	35,  // 83: manager.v1.AdminService.ListServerEvents:input_type -> manager.v1.ListServerEventsRequest
+	37,  // 84: manager.v1.AdminService.SetServerMaintenance:input_type -> manager.v1.SetServerMaintenanceRequest
+	39,  // 85: manager.v1.AdminService.SetServerNotes:input_type -> manager.v1.SetServerNotesRequest
+	42,  // 86: manager.v1.AdminService.CreateMaintenanceWindow:input_type -> manager.v1.CreateMaintenanceWindowRequest
+	44,  // 87: manager.v1.AdminService.ListMaintenanceWindows:input_type -> manager.v1.ListMaintenanceWindowsRequest
+	46,  // 88: manager.v1.AdminService.DeleteMaintenanceWindow:input_type -> manager.v1.DeleteMaintenanceWindowRequest
+	48,  // 89: manager.v1.AdminService.SetCustomerSessionQuota:input_type -> manager.v1.SetCustomerSessionQuotaRequest
+	50,  // 90: manager.v1.AdminService.SetCustomerMFAPolicy:input_type -> manager.v1.SetCustomerMFAPolicyRequest
+	52,  // 91: manager.v1.AdminService.SetCustomerIPAllowlist:input_type -> manager.v1.SetCustomerIPAllowlistRequest
+	54,  // 92: manager.v1.AdminService.ExportUsage:input_type -> manager.v1.ExportUsageRequest
+	57,  // 93: manager.v1.AdminService.ApproveGateway:input_type -> manager.v1.ApproveGatewayRequest
+	59,  // 94: manager.v1.AdminService.CreateCustomer:input_type -> manager.v1.CreateCustomerRequest
+	61,  // 95: manager.v1.AdminService.IssueAPIKey:input_type -> manager.v1.IssueAPIKeyRequest
+	63,  // 96: manager.v1.AdminService.ResetCustomerPassword:input_type -> manager.v1.ResetCustomerPasswordRequest
+	65,  // 97: manager.v1.AdminService.DisableCustomer:input_type -> manager.v1.DisableCustomerRequest
+	67,  // 98: manager.v1.AdminService.DeleteCustomer:input_type -> manager.v1.DeleteCustomerRequest
+	69,  // 99: manager.v1.AdminService.AssignServer:input_type -> manager.v1.AssignServerRequest
+	71,  // 100: manager.v1.AdminService.ImportServers:input_type -> manager.v1.ImportServersRequest
+	74,  // 101: manager.v1.AdminService.ExportServers:input_type -> manager.v1.ExportServersRequest
+	76,  // 102: manager.v1.AdminService.ListAuditEvents:input_type -> manager.v1.ListAuditEventsRequest
+	79,  // 103: manager.v1.AdminService.ListConnectivityAudits:input_type -> manager.v1.ListConnectivityAuditsRequest
+	81,  // 104: manager.v1.AdminService.RunConnectivityAudit:input_type -> manager.v1.RunConnectivityAuditRequest
+	85,  // 105: manager.v1.AdminService.SetLogLevel:input_type -> manager.v1.SetLogLevelRequest
+	1,   // 106: manager.v1.AdminService.GetDashboardMetrics:output_type -> manager.v1.GetDashboardMetricsResponse
+	3,   // 107: manager.v1.AdminService.ListAllServers:output_type -> manager.v1.ListAllServersResponse
+	6,   // 108: manager.v1.AdminService.ListAllCustomers:output_type -> manager.v1.ListAllCustomersResponse
+	9,   // 109: manager.v1.AdminService.GetGatewayHealth:output_type -> manager.v1.GetGatewayHealthResponse
+	12,  // 110: manager.v1.AdminService.GetRegions:output_type -> manager.v1.GetRegionsResponse
+	14,  // 111: manager.v1.AdminService.LaunchVNCSession:output_type -> manager.v1.LaunchSessionResponse
+	14,  // 112: manager.v1.AdminService.LaunchSOLSession:output_type -> manager.v1.LaunchSessionResponse
+	16,  // 113: manager.v1.AdminService.GetConsoleSLOReport:output_type -> manager.v1.GetConsoleSLOReportResponse
+	20,  // 114: manager.v1.AdminService.GetPowerUsageReport:output_type -> manager.v1.GetPowerUsageReportResponse
+	24,  // 115: manager.v1.AdminService.ListConsoleSessions:output_type -> manager.v1.ListConsoleSessionsResponse
+	29,  // 116: manager.v1.AdminService.TerminateConsoleSession:output_type -> manager.v1.TerminateConsoleSessionResponse
+	31,  // 117: manager.v1.AdminService.GetTopology:output_type -> manager.v1.GetTopologyResponse
+	36,  // 118: manager.v1.AdminService.ListServerEvents:output_type -> manager.v1.ListServerEventsResponse
+	38,  // 119: manager.v1.AdminService.SetServerMaintenance:output_type -> manager.v1.SetServerMaintenanceResponse
+	40,  // 120: manager.v1.AdminService.SetServerNotes:output_type -> manager.v1.SetServerNotesResponse
+	43,  // 121: manager.v1.AdminService.CreateMaintenanceWindow:output_type -> manager.v1.CreateMaintenanceWindowResponse
+	45,  // 122: manager.v1.AdminService.ListMaintenanceWindows:output_type -> manager.v1.ListMaintenanceWindowsResponse
+	47,  // 123: manager.v1.AdminService.DeleteMaintenanceWindow:output_type -> manager.v1.DeleteMaintenanceWindowResponse
+	49,  // 124: manager.v1.AdminService.SetCustomerSessionQuota:output_type -> manager.v1.SetCustomerSessionQuotaResponse
+	51,  // 125: manager.v1.AdminService.SetCustomerMFAPolicy:output_type -> manager.v1.SetCustomerMFAPolicyResponse
+	53,  // 126: manager.v1.AdminService.SetCustomerIPAllowlist:output_type -> manager.v1.SetCustomerIPAllowlistResponse
+	55,  // 127: manager.v1.AdminService.ExportUsage:output_type -> manager.v1.ExportUsageResponse
+	58,  // 128: manager.v1.AdminService.ApproveGateway:output_type -> manager.v1.ApproveGatewayResponse
+	60,  // 129: manager.v1.AdminService.CreateCustomer:output_type -> manager.v1.CreateCustomerResponse
+	62,  // 130: manager.v1.AdminService.IssueAPIKey:output_type -> manager.v1.IssueAPIKeyResponse
+	64,  // 131: manager.v1.AdminService.ResetCustomerPassword:output_type -> manager.v1.ResetCustomerPasswordResponse
+	66,  // 132: manager.v1.AdminService.DisableCustomer:output_type -> manager.v1.DisableCustomerResponse
+	68,  // 133: manager.v1.AdminService.DeleteCustomer:output_type -> manager.v1.DeleteCustomerResponse
+	70,  // 134: manager.v1.AdminService.AssignServer:output_type -> manager.v1.AssignServerResponse
+	72,  // 135: manager.v1.AdminService.ImportServers:output_type -> manager.v1.ImportServersResponse
+	75,  // 136: manager.v1.AdminService.ExportServers:output_type -> manager.v1.ExportServersResponse
+	77,  // 137: manager.v1.AdminService.ListAuditEvents:output_type -> manager.v1.ListAuditEventsResponse
+	80,  // 138: manager.v1.AdminService.ListConnectivityAudits:output_type -> manager.v1.ListConnectivityAuditsResponse
+	82,  // 139: manager.v1.AdminService.RunConnectivityAudit:output_type -> manager.v1.RunConnectivityAuditResponse
+	86,  // 140: manager.v1.AdminService.SetLogLevel:output_type -> manager.v1.SetLogLevelResponse
+	106, // [106:141] is the sub-list for method output_type
+	71,  // [71:106] is the sub-list for method input_type
+	71,  // [71:71] is the sub-list for extension type_name
+	71,  // [71:71] is the sub-list for extension extendee
+	0,   // [0:71] is the sub-list for field type_name
 }
 
 func init() { file_manager_v1_admin_proto_init() }
@@ -1139,13 +6349,14 @@ func file_manager_v1_admin_proto_init() {
 	if File_manager_v1_admin_proto != nil {
 		return
 	}
+	file_manager_v1_manager_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_manager_v1_admin_proto_rawDesc), len(file_manager_v1_admin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   92,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -10,6 +10,7 @@ import (
 	v1 "core/gen/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -101,6 +102,10 @@ type Server struct {
 	UpdatedAt         *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                        // Last time server information was updated
 	Metadata          map[string]string        `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional server metadata
 	DiscoveryMetadata *v1.DiscoveryMetadata    `protobuf:"bytes,13,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"`                                // Discovery metadata (RFD 017)
+	ExternalId        string                   `protobuf:"bytes,14,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`                                                     // Identifier in the owner's inventory, set at registration
+	PowerState        string                   `protobuf:"bytes,15,opt,name=power_state,json=powerState,proto3" json:"power_state,omitempty"`                                                     // Live power state (on, off, cycling or unknown), set when requested by ListServers
+	MaintenanceMode   bool                     `protobuf:"varint,16,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"`                                     // Customer power operations are blocked while set
+	Notes             string                   `protobuf:"bytes,17,opt,name=notes,proto3" json:"notes,omitempty"`                                                                                 // Free-text operator notes
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -226,6 +231,34 @@ func (x *Server) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
 	return nil
 }
 
+func (x *Server) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Server) GetPowerState() string {
+	if x != nil {
+		return x.PowerState
+	}
+	return ""
+}
+
+func (x *Server) GetMaintenanceMode() bool {
+	if x != nil {
+		return x.MaintenanceMode
+	}
+	return false
+}
+
+func (x *Server) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
 // RegionalGateway represents a gateway instance serving one or more datacenters
 type RegionalGateway struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -439,8 +472,9 @@ func (x *ServerLocation) GetPrimaryProtocol() v1.BMCType {
 // AuthenticateRequest contains customer credentials for initial authentication
 type AuthenticateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`       // Customer email address (primary identifier)
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // Customer password (or OIDC/OAuth token in production environments)
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`                       // Customer email address (primary identifier)
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`                 // Customer password (or OIDC/OAuth token in production environments)
+	TotpCode      string                 `protobuf:"bytes,3,opt,name=totp_code,json=totpCode,proto3" json:"totp_code,omitempty"` // Required once two-factor authentication is enabled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -489,6 +523,13 @@ func (x *AuthenticateRequest) GetPassword() string {
 	return ""
 }
 
+func (x *AuthenticateRequest) GetTotpCode() string {
+	if x != nil {
+		return x.TotpCode
+	}
+	return ""
+}
+
 // AuthenticateResponse provides authentication tokens and customer information
 type AuthenticateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -664,28 +705,29 @@ func (x *RefreshTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// GetServerTokenRequest requests a server-specific token with encrypted BMC context
-type GetServerTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to create a token for
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ChangePasswordRequest replaces the password of the authenticated customer
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"` // Required when the account has a password
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetServerTokenRequest) Reset() {
-	*x = GetServerTokenRequest{}
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
 	mi := &file_manager_v1_manager_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerTokenRequest) String() string {
+func (x *ChangePasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerTokenRequest) ProtoMessage() {}
+func (*ChangePasswordRequest) ProtoMessage() {}
 
-func (x *GetServerTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -697,41 +739,45 @@ func (x *GetServerTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerTokenRequest.ProtoReflect.Descriptor instead.
-func (*GetServerTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetServerTokenRequest) GetServerId() string {
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
 	if x != nil {
-		return x.ServerId
+		return x.CurrentPassword
 	}
 	return ""
 }
 
-// GetServerTokenResponse provides a server-specific token with encrypted BMC context
-type GetServerTokenResponse struct {
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // Server-specific JWT token with encrypted BMC context
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // When the server token expires
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerTokenResponse) Reset() {
-	*x = GetServerTokenResponse{}
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
 	mi := &file_manager_v1_manager_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerTokenResponse) String() string {
+func (x *ChangePasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerTokenResponse) ProtoMessage() {}
+func (*ChangePasswordResponse) ProtoMessage() {}
 
-func (x *GetServerTokenResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -743,53 +789,36 @@ func (x *GetServerTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerTokenResponse.ProtoReflect.Descriptor instead.
-func (*GetServerTokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetServerTokenResponse) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
-}
-
-func (x *GetServerTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-// RegisterServerRequest registers a server with the BMC Manager during provisioning
-type RegisterServerRequest struct {
-	state             protoimpl.MessageState   `protogen:"open.v1"`
-	ServerId          string                   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                              // Unique server identifier (must be unique within customer namespace)
-	CustomerId        string                   `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                                        // Customer/tenant ID that owns this server
-	DatacenterId      string                   `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                                  // Physical datacenter where the server is located
-	RegionalGatewayId string                   `protobuf:"bytes,4,opt,name=regional_gateway_id,json=regionalGatewayId,proto3" json:"regional_gateway_id,omitempty"`                 // Gateway responsible for this server (must serve the datacenter)
-	Features          []string                 `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                                              // BMC capabilities (e.g., "power", "sol", "kvm", "sensors", "media")
-	BmcProtocols      []*v1.BMCControlEndpoint `protobuf:"bytes,6,rep,name=bmc_protocols,json=bmcProtocols,proto3" json:"bmc_protocols,omitempty"`                                  // Multiple protocol support (required for RFD 006)
-	PrimaryProtocol   v1.BMCType               `protobuf:"varint,7,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"` // Preferred protocol for operations
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// ResetPasswordRequest sets a password with a reset token, which also
+// unlocks the account
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	ResetToken    string                 `protobuf:"bytes,2,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"` // From the AdminService ResetCustomerPassword RPC
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterServerRequest) Reset() {
-	*x = RegisterServerRequest{}
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
 	mi := &file_manager_v1_manager_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterServerRequest) String() string {
+func (x *ResetPasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterServerRequest) ProtoMessage() {}
+func (*ResetPasswordRequest) ProtoMessage() {}
 
-func (x *RegisterServerRequest) ProtoReflect() protoreflect.Message {
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -801,83 +830,52 @@ func (x *RegisterServerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterServerRequest.ProtoReflect.Descriptor instead.
-func (*RegisterServerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RegisterServerRequest) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
-}
-
-func (x *RegisterServerRequest) GetCustomerId() string {
+func (x *ResetPasswordRequest) GetEmail() string {
 	if x != nil {
-		return x.CustomerId
+		return x.Email
 	}
 	return ""
 }
 
-func (x *RegisterServerRequest) GetDatacenterId() string {
+func (x *ResetPasswordRequest) GetResetToken() string {
 	if x != nil {
-		return x.DatacenterId
+		return x.ResetToken
 	}
 	return ""
 }
 
-func (x *RegisterServerRequest) GetRegionalGatewayId() string {
+func (x *ResetPasswordRequest) GetNewPassword() string {
 	if x != nil {
-		return x.RegionalGatewayId
+		return x.NewPassword
 	}
 	return ""
 }
 
-func (x *RegisterServerRequest) GetFeatures() []string {
-	if x != nil {
-		return x.Features
-	}
-	return nil
-}
-
-func (x *RegisterServerRequest) GetBmcProtocols() []*v1.BMCControlEndpoint {
-	if x != nil {
-		return x.BmcProtocols
-	}
-	return nil
-}
-
-func (x *RegisterServerRequest) GetPrimaryProtocol() v1.BMCType {
-	if x != nil {
-		return x.PrimaryProtocol
-	}
-	return v1.BMCType(0)
-}
-
-// RegisterServerResponse confirms server registration
-type RegisterServerResponse struct {
+type ResetPasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether registration was successful
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Success confirmation or detailed error message
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterServerResponse) Reset() {
-	*x = RegisterServerResponse{}
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
 	mi := &file_manager_v1_manager_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterServerResponse) String() string {
+func (x *ResetPasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterServerResponse) ProtoMessage() {}
+func (*ResetPasswordResponse) ProtoMessage() {}
 
-func (x *RegisterServerResponse) ProtoReflect() protoreflect.Message {
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -889,47 +887,33 @@ func (x *RegisterServerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterServerResponse.ProtoReflect.Descriptor instead.
-func (*RegisterServerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *RegisterServerResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *RegisterServerResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-// GetServerRequest retrieves information about a specific server
-type GetServerRequest struct {
+// EnrollTOTPRequest starts the enrollment of an authenticator app,
+// replacing any pending one
+type EnrollTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The unique identifier of the server to retrieve
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerRequest) Reset() {
-	*x = GetServerRequest{}
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
 	mi := &file_manager_v1_manager_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerRequest) String() string {
+func (x *EnrollTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerRequest) ProtoMessage() {}
+func (*EnrollTOTPRequest) ProtoMessage() {}
 
-func (x *GetServerRequest) ProtoReflect() protoreflect.Message {
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -941,40 +925,33 @@ func (x *GetServerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerRequest.ProtoReflect.Descriptor instead.
-func (*GetServerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetServerRequest) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
-}
-
-// GetServerResponse contains the requested server information
-type GetServerResponse struct {
+type EnrollTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // The server information, or error if not found/accessible
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32, for manual entry
+	OtpauthUrl    string                 `protobuf:"bytes,2,opt,name=otpauth_url,json=otpauthUrl,proto3" json:"otpauth_url,omitempty"` // otpauth:// URL, e.g. for a QR code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerResponse) Reset() {
-	*x = GetServerResponse{}
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
 	mi := &file_manager_v1_manager_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerResponse) String() string {
+func (x *EnrollTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerResponse) ProtoMessage() {}
+func (*EnrollTOTPResponse) ProtoMessage() {}
 
-func (x *GetServerResponse) ProtoReflect() protoreflect.Message {
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -986,42 +963,48 @@ func (x *GetServerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerResponse.ProtoReflect.Descriptor instead.
-func (*GetServerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetServerResponse) GetServer() *Server {
+func (x *EnrollTOTPResponse) GetSecret() string {
 	if x != nil {
-		return x.Server
+		return x.Secret
 	}
-	return nil
+	return ""
 }
 
-// ListServersRequest retrieves a list of servers accessible to the authenticated customer
-type ListServersRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional pagination controls
-	PageSize      int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of servers to return (default: 50, max: 1000)
-	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from previous response to continue pagination
+func (x *EnrollTOTPResponse) GetOtpauthUrl() string {
+	if x != nil {
+		return x.OtpauthUrl
+	}
+	return ""
+}
+
+// ConfirmTOTPRequest enables two-factor authentication with a code of the
+// enrolled secret
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListServersRequest) Reset() {
-	*x = ListServersRequest{}
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
 	mi := &file_manager_v1_manager_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListServersRequest) String() string {
+func (x *ConfirmTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListServersRequest) ProtoMessage() {}
+func (*ConfirmTOTPRequest) ProtoMessage() {}
 
-func (x *ListServersRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1033,48 +1016,38 @@ func (x *ListServersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListServersRequest.ProtoReflect.Descriptor instead.
-func (*ListServersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListServersRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListServersRequest) GetPageToken() string {
+func (x *ConfirmTOTPRequest) GetCode() string {
 	if x != nil {
-		return x.PageToken
+		return x.Code
 	}
 	return ""
 }
 
-// ListServersResponse contains a list of servers and pagination information
-type ListServersResponse struct {
+type ConfirmTOTPResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Servers       []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`                                    // List of servers accessible to the customer
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for retrieving the next page (empty if last page)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListServersResponse) Reset() {
-	*x = ListServersResponse{}
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
 	mi := &file_manager_v1_manager_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListServersResponse) String() string {
+func (x *ConfirmTOTPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListServersResponse) ProtoMessage() {}
+func (*ConfirmTOTPResponse) ProtoMessage() {}
 
-func (x *ListServersResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1086,47 +1059,33 @@ func (x *ListServersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListServersResponse.ProtoReflect.Descriptor instead.
-func (*ListServersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListServersResponse) GetServers() []*Server {
-	if x != nil {
-		return x.Servers
-	}
-	return nil
-}
-
-func (x *ListServersResponse) GetNextPageToken() string {
-	if x != nil {
-		return x.NextPageToken
-	}
-	return ""
-}
-
-// GetServerLocationRequest queries routing information for a server
-type GetServerLocationRequest struct {
+// DisableTOTPRequest disables two-factor authentication with a current code
+type DisableTOTPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to resolve location for
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerLocationRequest) Reset() {
-	*x = GetServerLocationRequest{}
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
 	mi := &file_manager_v1_manager_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerLocationRequest) String() string {
+func (x *DisableTOTPRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerLocationRequest) ProtoMessage() {}
+func (*DisableTOTPRequest) ProtoMessage() {}
 
-func (x *GetServerLocationRequest) ProtoReflect() protoreflect.Message {
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_manager_v1_manager_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1138,46 +1097,1251 @@ func (x *GetServerLocationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerLocationRequest.ProtoReflect.Descriptor instead.
-func (*GetServerLocationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
 	return file_manager_v1_manager_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetServerLocationRequest) GetServerId() string {
+func (x *DisableTOTPRequest) GetCode() string {
 	if x != nil {
-		return x.ServerId
+		return x.Code
 	}
 	return ""
 }
 
-// GetServerLocationResponse provides server routing and capability information
-type GetServerLocationResponse struct {
-	state                   protoimpl.MessageState   `protogen:"open.v1"`
-	RegionalGatewayId       string                   `protobuf:"bytes,1,opt,name=regional_gateway_id,json=regionalGatewayId,proto3" json:"regional_gateway_id,omitempty"`                   // Gateway that handles requests for this server
-	RegionalGatewayEndpoint string                   `protobuf:"bytes,2,opt,name=regional_gateway_endpoint,json=regionalGatewayEndpoint,proto3" json:"regional_gateway_endpoint,omitempty"` // Full URL/endpoint of the responsible gateway
-	DatacenterId            string                   `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                                    // Datacenter where the server is physically located
-	Features                []string                 `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`                                                                // BMC capabilities supported by this server
-	BmcProtocols            []*v1.BMCControlEndpoint `protobuf:"bytes,5,rep,name=bmc_protocols,json=bmcProtocols,proto3" json:"bmc_protocols,omitempty"`                                    // Multiple protocol support (required for RFD 006)
-	PrimaryProtocol         v1.BMCType               `protobuf:"varint,6,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"`   // Preferred protocol for operations
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
-}
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{17}
+}
+
+// GetServerTokenRequest requests a server-specific token with encrypted BMC context
+type GetServerTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to create a token for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerTokenRequest) Reset() {
+	*x = GetServerTokenRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerTokenRequest) ProtoMessage() {}
+
+func (x *GetServerTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetServerTokenRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetServerTokenRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// GetServerTokenResponse provides a server-specific token with encrypted BMC context
+type GetServerTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // Server-specific JWT token with encrypted BMC context
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // When the server token expires
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerTokenResponse) Reset() {
+	*x = GetServerTokenResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerTokenResponse) ProtoMessage() {}
+
+func (x *GetServerTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetServerTokenResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetServerTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetServerTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// RegisterServerRequest registers a server with the BMC Manager during provisioning
+type RegisterServerRequest struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	ServerId          string                   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                                              // Unique server identifier (must be unique within customer namespace)
+	CustomerId        string                   `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`                                        // Customer/tenant ID that owns this server
+	DatacenterId      string                   `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                                  // Physical datacenter where the server is located
+	RegionalGatewayId string                   `protobuf:"bytes,4,opt,name=regional_gateway_id,json=regionalGatewayId,proto3" json:"regional_gateway_id,omitempty"`                 // Gateway responsible for this server (must serve the datacenter)
+	Features          []string                 `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                                              // BMC capabilities (e.g., "power", "sol", "kvm", "sensors", "media")
+	BmcProtocols      []*v1.BMCControlEndpoint `protobuf:"bytes,6,rep,name=bmc_protocols,json=bmcProtocols,proto3" json:"bmc_protocols,omitempty"`                                  // Multiple protocol support (required for RFD 006)
+	PrimaryProtocol   v1.BMCType               `protobuf:"varint,7,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"` // Preferred protocol for operations
+	ExternalId        string                   `protobuf:"bytes,8,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`                                        // Identifier in the caller's inventory (e.g., a Terraform resource or CMDB ID)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegisterServerRequest) Reset() {
+	*x = RegisterServerRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterServerRequest) ProtoMessage() {}
+
+func (x *RegisterServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterServerRequest.ProtoReflect.Descriptor instead.
+func (*RegisterServerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegisterServerRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RegisterServerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *RegisterServerRequest) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *RegisterServerRequest) GetRegionalGatewayId() string {
+	if x != nil {
+		return x.RegionalGatewayId
+	}
+	return ""
+}
+
+func (x *RegisterServerRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *RegisterServerRequest) GetBmcProtocols() []*v1.BMCControlEndpoint {
+	if x != nil {
+		return x.BmcProtocols
+	}
+	return nil
+}
+
+func (x *RegisterServerRequest) GetPrimaryProtocol() v1.BMCType {
+	if x != nil {
+		return x.PrimaryProtocol
+	}
+	return v1.BMCType(0)
+}
+
+func (x *RegisterServerRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+// RegisterServerResponse confirms server registration
+type RegisterServerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                  // Whether registration was successful
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                   // Success confirmation or detailed error message
+	Created       bool                   `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`                  // True when the server was created, false when an existing server was updated
+	ServerId      string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // ID of the registered server, that of the existing server when updated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterServerResponse) Reset() {
+	*x = RegisterServerResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterServerResponse) ProtoMessage() {}
+
+func (x *RegisterServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterServerResponse.ProtoReflect.Descriptor instead.
+func (*RegisterServerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RegisterServerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterServerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RegisterServerResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *RegisterServerResponse) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// GetServerRequest retrieves information about a specific server
+type GetServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The unique identifier of the server to retrieve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerRequest) Reset() {
+	*x = GetServerRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerRequest) ProtoMessage() {}
+
+func (x *GetServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerRequest.ProtoReflect.Descriptor instead.
+func (*GetServerRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetServerRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// GetServerResponse contains the requested server information
+type GetServerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"` // The server information, or error if not found/accessible
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerResponse) Reset() {
+	*x = GetServerResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerResponse) ProtoMessage() {}
+
+func (x *GetServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerResponse.ProtoReflect.Descriptor instead.
+func (*GetServerResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetServerResponse) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+// ListServersRequest retrieves a list of servers accessible to the authenticated customer
+type ListServersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional pagination controls
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum number of servers to return (default: 50, max: 1000)
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token from previous response to continue pagination
+	// Query the power state of each server from its gateway, servers whose
+	// state cannot be queried in time are reported as unknown
+	IncludePowerState bool `protobuf:"varint,3,opt,name=include_power_state,json=includePowerState,proto3" json:"include_power_state,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListServersRequest) Reset() {
+	*x = ListServersRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServersRequest) ProtoMessage() {}
+
+func (x *ListServersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServersRequest.ProtoReflect.Descriptor instead.
+func (*ListServersRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListServersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListServersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListServersRequest) GetIncludePowerState() bool {
+	if x != nil {
+		return x.IncludePowerState
+	}
+	return false
+}
+
+// ListServersResponse contains a list of servers and pagination information
+type ListServersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Servers       []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`                                    // List of servers accessible to the customer
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for retrieving the next page (empty if last page)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServersResponse) Reset() {
+	*x = ListServersResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServersResponse) ProtoMessage() {}
+
+func (x *ListServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServersResponse.ProtoReflect.Descriptor instead.
+func (*ListServersResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListServersResponse) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *ListServersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetServerLocationRequest queries routing information for a server
+type GetServerLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // The server ID to resolve location for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerLocationRequest) Reset() {
+	*x = GetServerLocationRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerLocationRequest) ProtoMessage() {}
+
+func (x *GetServerLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerLocationRequest.ProtoReflect.Descriptor instead.
+func (*GetServerLocationRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetServerLocationRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+// GetServerLocationResponse provides server routing and capability information
+type GetServerLocationResponse struct {
+	state                   protoimpl.MessageState   `protogen:"open.v1"`
+	RegionalGatewayId       string                   `protobuf:"bytes,1,opt,name=regional_gateway_id,json=regionalGatewayId,proto3" json:"regional_gateway_id,omitempty"`                   // Gateway that handles requests for this server
+	RegionalGatewayEndpoint string                   `protobuf:"bytes,2,opt,name=regional_gateway_endpoint,json=regionalGatewayEndpoint,proto3" json:"regional_gateway_endpoint,omitempty"` // Full URL/endpoint of the responsible gateway
+	DatacenterId            string                   `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                                    // Datacenter where the server is physically located
+	Features                []string                 `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`                                                                // BMC capabilities supported by this server
+	BmcProtocols            []*v1.BMCControlEndpoint `protobuf:"bytes,5,rep,name=bmc_protocols,json=bmcProtocols,proto3" json:"bmc_protocols,omitempty"`                                    // Multiple protocol support (required for RFD 006)
+	PrimaryProtocol         v1.BMCType               `protobuf:"varint,6,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"`   // Preferred protocol for operations
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *GetServerLocationResponse) Reset() {
+	*x = GetServerLocationResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerLocationResponse) ProtoMessage() {}
+
+func (x *GetServerLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerLocationResponse.ProtoReflect.Descriptor instead.
+func (*GetServerLocationResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetServerLocationResponse) GetRegionalGatewayId() string {
+	if x != nil {
+		return x.RegionalGatewayId
+	}
+	return ""
+}
+
+func (x *GetServerLocationResponse) GetRegionalGatewayEndpoint() string {
+	if x != nil {
+		return x.RegionalGatewayEndpoint
+	}
+	return ""
+}
+
+func (x *GetServerLocationResponse) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *GetServerLocationResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetServerLocationResponse) GetBmcProtocols() []*v1.BMCControlEndpoint {
+	if x != nil {
+		return x.BmcProtocols
+	}
+	return nil
+}
+
+func (x *GetServerLocationResponse) GetPrimaryProtocol() v1.BMCType {
+	if x != nil {
+		return x.PrimaryProtocol
+	}
+	return v1.BMCType(0)
+}
+
+// RegisterGatewayRequest allows gateways to register with the BMC Manager
+type RegisterGatewayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`             // Unique identifier for this gateway instance
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                    // Geographic region (e.g., "us-east-1", "eu-west-1")
+	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                // Public endpoint URL where the gateway can be reached
+	DatacenterIds []string               `protobuf:"bytes,4,rep,name=datacenter_ids,json=datacenterIds,proto3" json:"datacenter_ids,omitempty"` // List of datacenters this gateway can serve
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterGatewayRequest) Reset() {
+	*x = RegisterGatewayRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterGatewayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterGatewayRequest) ProtoMessage() {}
+
+func (x *RegisterGatewayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterGatewayRequest.ProtoReflect.Descriptor instead.
+func (*RegisterGatewayRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RegisterGatewayRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *RegisterGatewayRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *RegisterGatewayRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *RegisterGatewayRequest) GetDatacenterIds() []string {
+	if x != nil {
+		return x.DatacenterIds
+	}
+	return nil
+}
+
+// RegisterGatewayResponse confirms gateway registration
+type RegisterGatewayResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether registration was successful
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Success confirmation or error details
+	// Revocations of customer tokens that may not have expired yet, for the
+	// gateway to reject the tokens it validates locally. Only sent to the
+	// account of the gateway and to admins.
+	TokenRevocations []*TokenRevocation `protobuf:"bytes,3,rep,name=token_revocations,json=tokenRevocations,proto3" json:"token_revocations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RegisterGatewayResponse) Reset() {
+	*x = RegisterGatewayResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterGatewayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterGatewayResponse) ProtoMessage() {}
+
+func (x *RegisterGatewayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterGatewayResponse.ProtoReflect.Descriptor instead.
+func (*RegisterGatewayResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RegisterGatewayResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterGatewayResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RegisterGatewayResponse) GetTokenRevocations() []*TokenRevocation {
+	if x != nil {
+		return x.TokenRevocations
+	}
+	return nil
+}
+
+// TokenRevocation rejects the tokens of a customer issued until revoked_at,
+// when the customer was disabled or deleted
+type TokenRevocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenRevocation) Reset() {
+	*x = TokenRevocation{}
+	mi := &file_manager_v1_manager_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenRevocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenRevocation) ProtoMessage() {}
+
+func (x *TokenRevocation) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenRevocation.ProtoReflect.Descriptor instead.
+func (*TokenRevocation) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TokenRevocation) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *TokenRevocation) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+// ListGatewaysRequest queries available gateways
+type ListGatewaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"` // Optional filter to return only gateways in a specific region
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGatewaysRequest) Reset() {
+	*x = ListGatewaysRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGatewaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysRequest) ProtoMessage() {}
+
+func (x *ListGatewaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysRequest.ProtoReflect.Descriptor instead.
+func (*ListGatewaysRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListGatewaysRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// ListGatewaysResponse provides a list of registered gateways
+type ListGatewaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gateways      []*RegionalGateway     `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"` // List of gateways matching the request criteria
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGatewaysResponse) Reset() {
+	*x = ListGatewaysResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGatewaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysResponse) ProtoMessage() {}
+
+func (x *ListGatewaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysResponse.ProtoReflect.Descriptor instead.
+func (*ListGatewaysResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListGatewaysResponse) GetGateways() []*RegionalGateway {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+// ReportAvailableEndpointsRequest reports BMC endpoints that a gateway can proxy
+type ReportAvailableEndpointsRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	GatewayId     string                     `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`          // Gateway identifier
+	Region        string                     `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                 // Gateway region
+	BmcEndpoints  []*BMCEndpointAvailability `protobuf:"bytes,3,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // BMC endpoints available through this gateway
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportAvailableEndpointsRequest) Reset() {
+	*x = ReportAvailableEndpointsRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportAvailableEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAvailableEndpointsRequest) ProtoMessage() {}
+
+func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAvailableEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ReportAvailableEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReportAvailableEndpointsRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ReportAvailableEndpointsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ReportAvailableEndpointsRequest) GetBmcEndpoints() []*BMCEndpointAvailability {
+	if x != nil {
+		return x.BmcEndpoints
+	}
+	return nil
+}
+
+// BMCEndpointAvailability describes a BMC endpoint available through a gateway
+type BMCEndpointAvailability struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BmcEndpoint       string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`                    // BMC endpoint (e.g., "192.168.1.100:623")
+	AgentId           string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                // Agent that provides access to this endpoint
+	DatacenterId      string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                 // Datacenter containing this BMC
+	BmcType           v1.BMCType             `protobuf:"varint,4,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"`        // Type of BMC interface
+	Features          []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                             // Available features
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                 // Endpoint status
+	LastSeen          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                             // When this endpoint was last verified
+	Username          string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                                             // BMC username
+	Capabilities      []string               `protobuf:"bytes,9,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                                     // BMC capabilities
+	DiscoveryMetadata *v1.DiscoveryMetadata  `protobuf:"bytes,10,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"` // Discovery metadata (RFD 017)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BMCEndpointAvailability) Reset() {
+	*x = BMCEndpointAvailability{}
+	mi := &file_manager_v1_manager_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BMCEndpointAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BMCEndpointAvailability) ProtoMessage() {}
+
+func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BMCEndpointAvailability.ProtoReflect.Descriptor instead.
+func (*BMCEndpointAvailability) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BMCEndpointAvailability) GetBmcEndpoint() string {
+	if x != nil {
+		return x.BmcEndpoint
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetBmcType() v1.BMCType {
+	if x != nil {
+		return x.BmcType
+	}
+	return v1.BMCType(0)
+}
+
+func (x *BMCEndpointAvailability) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *BMCEndpointAvailability) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *BMCEndpointAvailability) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BMCEndpointAvailability) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *BMCEndpointAvailability) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
+	if x != nil {
+		return x.DiscoveryMetadata
+	}
+	return nil
+}
+
+// ReportAvailableEndpointsResponse confirms endpoint registration
+type ReportAvailableEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportAvailableEndpointsResponse) Reset() {
+	*x = ReportAvailableEndpointsResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportAvailableEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAvailableEndpointsResponse) ProtoMessage() {}
+
+func (x *ReportAvailableEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAvailableEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ReportAvailableEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReportAvailableEndpointsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportAvailableEndpointsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ReportConsoleSLIsRequest carries console session measurements from a gateway
+type ReportConsoleSLIsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"` // Reporting gateway
+	Sessions      []*ConsoleSessionSLI   `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`                    // Sessions started since the last report
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportConsoleSLIsRequest) Reset() {
+	*x = ReportConsoleSLIsRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportConsoleSLIsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportConsoleSLIsRequest) ProtoMessage() {}
+
+func (x *ReportConsoleSLIsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (x *GetServerLocationResponse) Reset() {
-	*x = GetServerLocationResponse{}
-	mi := &file_manager_v1_manager_proto_msgTypes[17]
+// Deprecated: Use ReportConsoleSLIsRequest.ProtoReflect.Descriptor instead.
+func (*ReportConsoleSLIsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReportConsoleSLIsRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ReportConsoleSLIsRequest) GetSessions() []*ConsoleSessionSLI {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// ConsoleSessionSLI is the outcome of one console stream establishment
+type ConsoleSessionSLI struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                                // Gateway console session ID
+	AgentId           string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                      // Agent the stream was proxied to
+	DatacenterId      string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                       // Datacenter of the agent
+	SessionType       string                 `protobuf:"bytes,4,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`                          // "sol" or "vnc"
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`                                // When the stream was opened
+	Established       bool                   `protobuf:"varint,6,opt,name=established,proto3" json:"established,omitempty"`                                            // Stream established with the agent without error
+	FirstByte         bool                   `protobuf:"varint,7,opt,name=first_byte,json=firstByte,proto3" json:"first_byte,omitempty"`                               // Whether any console data was received
+	TimeToFirstByteMs int64                  `protobuf:"varint,8,opt,name=time_to_first_byte_ms,json=timeToFirstByteMs,proto3" json:"time_to_first_byte_ms,omitempty"` // Time from stream open to first data (when first_byte is set)
+	Error             string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`                                                         // Failure reason when not established
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConsoleSessionSLI) Reset() {
+	*x = ConsoleSessionSLI{}
+	mi := &file_manager_v1_manager_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerLocationResponse) String() string {
+func (x *ConsoleSessionSLI) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerLocationResponse) ProtoMessage() {}
+func (*ConsoleSessionSLI) ProtoMessage() {}
 
-func (x *GetServerLocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[17]
+func (x *ConsoleSessionSLI) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1188,79 +2352,98 @@ func (x *GetServerLocationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerLocationResponse.ProtoReflect.Descriptor instead.
-func (*GetServerLocationResponse) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ConsoleSessionSLI.ProtoReflect.Descriptor instead.
+func (*ConsoleSessionSLI) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetServerLocationResponse) GetRegionalGatewayId() string {
+func (x *ConsoleSessionSLI) GetSessionId() string {
 	if x != nil {
-		return x.RegionalGatewayId
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *GetServerLocationResponse) GetRegionalGatewayEndpoint() string {
+func (x *ConsoleSessionSLI) GetAgentId() string {
 	if x != nil {
-		return x.RegionalGatewayEndpoint
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *GetServerLocationResponse) GetDatacenterId() string {
+func (x *ConsoleSessionSLI) GetDatacenterId() string {
 	if x != nil {
 		return x.DatacenterId
 	}
 	return ""
 }
 
-func (x *GetServerLocationResponse) GetFeatures() []string {
+func (x *ConsoleSessionSLI) GetSessionType() string {
 	if x != nil {
-		return x.Features
+		return x.SessionType
 	}
-	return nil
+	return ""
 }
 
-func (x *GetServerLocationResponse) GetBmcProtocols() []*v1.BMCControlEndpoint {
+func (x *ConsoleSessionSLI) GetStartedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.BmcProtocols
+		return x.StartedAt
 	}
 	return nil
 }
 
-func (x *GetServerLocationResponse) GetPrimaryProtocol() v1.BMCType {
+func (x *ConsoleSessionSLI) GetEstablished() bool {
 	if x != nil {
-		return x.PrimaryProtocol
+		return x.Established
 	}
-	return v1.BMCType(0)
+	return false
 }
 
-// RegisterGatewayRequest allows gateways to register with the BMC Manager
-type RegisterGatewayRequest struct {
+func (x *ConsoleSessionSLI) GetFirstByte() bool {
+	if x != nil {
+		return x.FirstByte
+	}
+	return false
+}
+
+func (x *ConsoleSessionSLI) GetTimeToFirstByteMs() int64 {
+	if x != nil {
+		return x.TimeToFirstByteMs
+	}
+	return 0
+}
+
+func (x *ConsoleSessionSLI) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ReportConsoleSLIsResponse confirms the measurements were recorded
+type ReportConsoleSLIsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`             // Unique identifier for this gateway instance
-	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                    // Geographic region (e.g., "us-east-1", "eu-west-1")
-	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                // Public endpoint URL where the gateway can be reached
-	DatacenterIds []string               `protobuf:"bytes,4,rep,name=datacenter_ids,json=datacenterIds,proto3" json:"datacenter_ids,omitempty"` // List of datacenters this gateway can serve
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterGatewayRequest) Reset() {
-	*x = RegisterGatewayRequest{}
-	mi := &file_manager_v1_manager_proto_msgTypes[18]
+func (x *ReportConsoleSLIsResponse) Reset() {
+	*x = ReportConsoleSLIsResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterGatewayRequest) String() string {
+func (x *ReportConsoleSLIsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterGatewayRequest) ProtoMessage() {}
+func (*ReportConsoleSLIsResponse) ProtoMessage() {}
 
-func (x *RegisterGatewayRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[18]
+func (x *ReportConsoleSLIsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,63 +2454,105 @@ func (x *RegisterGatewayRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterGatewayRequest.ProtoReflect.Descriptor instead.
-func (*RegisterGatewayRequest) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ReportConsoleSLIsResponse.ProtoReflect.Descriptor instead.
+func (*ReportConsoleSLIsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *RegisterGatewayRequest) GetGatewayId() string {
+func (x *ReportConsoleSLIsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.GatewayId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *RegisterGatewayRequest) GetRegion() string {
+func (x *ReportConsoleSLIsResponse) GetMessage() string {
 	if x != nil {
-		return x.Region
+		return x.Message
 	}
 	return ""
 }
 
-func (x *RegisterGatewayRequest) GetEndpoint() string {
+// ReportEventsRequest carries system events observed by a gateway
+type ReportEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"` // Reporting gateway
+	Events        []*SystemEvent         `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`                        // Events observed since the last report
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportEventsRequest) Reset() {
+	*x = ReportEventsRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportEventsRequest) ProtoMessage() {}
+
+func (x *ReportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[39]
 	if x != nil {
-		return x.Endpoint
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReportEventsRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
 	}
 	return ""
 }
 
-func (x *RegisterGatewayRequest) GetDatacenterIds() []string {
+func (x *ReportEventsRequest) GetEvents() []*SystemEvent {
 	if x != nil {
-		return x.DatacenterIds
+		return x.Events
 	}
 	return nil
 }
 
-// RegisterGatewayResponse confirms gateway registration
-type RegisterGatewayResponse struct {
+// SystemEvent is a system event, e.g. "power.operation_executed"
+type SystemEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether registration was successful
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // Success confirmation or error details
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // Unique event ID, kept across report retries
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // Event type
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"` // Service that emitted the event
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`     // When the event occurred
+	Data          *structpb.Struct       `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`     // Event-specific fields
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterGatewayResponse) Reset() {
-	*x = RegisterGatewayResponse{}
-	mi := &file_manager_v1_manager_proto_msgTypes[19]
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	mi := &file_manager_v1_manager_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterGatewayResponse) String() string {
+func (x *SystemEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterGatewayResponse) ProtoMessage() {}
+func (*SystemEvent) ProtoMessage() {}
 
-func (x *RegisterGatewayResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[19]
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,48 +2563,70 @@ func (x *RegisterGatewayResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterGatewayResponse.ProtoReflect.Descriptor instead.
-func (*RegisterGatewayResponse) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *RegisterGatewayResponse) GetSuccess() bool {
+func (x *SystemEvent) GetId() string {
 	if x != nil {
-		return x.Success
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *RegisterGatewayResponse) GetMessage() string {
+func (x *SystemEvent) GetType() string {
 	if x != nil {
-		return x.Message
+		return x.Type
 	}
 	return ""
 }
 
-// ListGatewaysRequest queries available gateways
-type ListGatewaysRequest struct {
+func (x *SystemEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SystemEvent) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ReportEventsResponse confirms the events were accepted
+type ReportEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"` // Optional filter to return only gateways in a specific region
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGatewaysRequest) Reset() {
-	*x = ListGatewaysRequest{}
-	mi := &file_manager_v1_manager_proto_msgTypes[20]
+func (x *ReportEventsResponse) Reset() {
+	*x = ReportEventsResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGatewaysRequest) String() string {
+func (x *ReportEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGatewaysRequest) ProtoMessage() {}
+func (*ReportEventsResponse) ProtoMessage() {}
 
-func (x *ListGatewaysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[20]
+func (x *ReportEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1390,41 +2637,49 @@ func (x *ListGatewaysRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGatewaysRequest.ProtoReflect.Descriptor instead.
-func (*ListGatewaysRequest) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ReportEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReportEventsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ListGatewaysRequest) GetRegion() string {
+func (x *ReportEventsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Region
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportEventsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-// ListGatewaysResponse provides a list of registered gateways
-type ListGatewaysResponse struct {
+// ReportPowerReadingsRequest carries power samples taken by a gateway
+type ReportPowerReadingsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Gateways      []*RegionalGateway     `protobuf:"bytes,1,rep,name=gateways,proto3" json:"gateways,omitempty"` // List of gateways matching the request criteria
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"` // Reporting gateway
+	Samples       []*PowerSample         `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples,omitempty"`                      // Samples taken since the last report
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGatewaysResponse) Reset() {
-	*x = ListGatewaysResponse{}
-	mi := &file_manager_v1_manager_proto_msgTypes[21]
+func (x *ReportPowerReadingsRequest) Reset() {
+	*x = ReportPowerReadingsRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGatewaysResponse) String() string {
+func (x *ReportPowerReadingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGatewaysResponse) ProtoMessage() {}
+func (*ReportPowerReadingsRequest) ProtoMessage() {}
 
-func (x *ListGatewaysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[21]
+func (x *ReportPowerReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1435,43 +2690,52 @@ func (x *ListGatewaysResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGatewaysResponse.ProtoReflect.Descriptor instead.
-func (*ListGatewaysResponse) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ReportPowerReadingsRequest.ProtoReflect.Descriptor instead.
+func (*ReportPowerReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListGatewaysResponse) GetGateways() []*RegionalGateway {
+func (x *ReportPowerReadingsRequest) GetGatewayId() string {
 	if x != nil {
-		return x.Gateways
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *ReportPowerReadingsRequest) GetSamples() []*PowerSample {
+	if x != nil {
+		return x.Samples
 	}
 	return nil
 }
 
-// ReportAvailableEndpointsRequest reports BMC endpoints that a gateway can proxy
-type ReportAvailableEndpointsRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	GatewayId     string                     `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`          // Gateway identifier
-	Region        string                     `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                 // Gateway region
-	BmcEndpoints  []*BMCEndpointAvailability `protobuf:"bytes,3,rep,name=bmc_endpoints,json=bmcEndpoints,proto3" json:"bmc_endpoints,omitempty"` // BMC endpoints available through this gateway
+// PowerSample is the power consumption of a BMC at one point in time
+type PowerSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BmcEndpoint   string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`         // BMC the sample was read from
+	DatacenterId  string                 `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`      // Datacenter of the BMC
+	SampledAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=sampled_at,json=sampledAt,proto3" json:"sampled_at,omitempty"`               // When the BMC was read
+	ConsumedWatts float64                `protobuf:"fixed64,4,opt,name=consumed_watts,json=consumedWatts,proto3" json:"consumed_watts,omitempty"` // Power consumption
+	EnergyKwh     float64                `protobuf:"fixed64,5,opt,name=energy_kwh,json=energyKwh,proto3" json:"energy_kwh,omitempty"`             // Cumulative energy counter, 0 when the BMC does not meter energy
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportAvailableEndpointsRequest) Reset() {
-	*x = ReportAvailableEndpointsRequest{}
-	mi := &file_manager_v1_manager_proto_msgTypes[22]
+func (x *PowerSample) Reset() {
+	*x = PowerSample{}
+	mi := &file_manager_v1_manager_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportAvailableEndpointsRequest) String() string {
+func (x *PowerSample) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportAvailableEndpointsRequest) ProtoMessage() {}
+func (*PowerSample) ProtoMessage() {}
 
-func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[22]
+func (x *PowerSample) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1482,64 +2746,70 @@ func (x *ReportAvailableEndpointsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportAvailableEndpointsRequest.ProtoReflect.Descriptor instead.
-func (*ReportAvailableEndpointsRequest) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use PowerSample.ProtoReflect.Descriptor instead.
+func (*PowerSample) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ReportAvailableEndpointsRequest) GetGatewayId() string {
+func (x *PowerSample) GetBmcEndpoint() string {
 	if x != nil {
-		return x.GatewayId
+		return x.BmcEndpoint
 	}
 	return ""
 }
 
-func (x *ReportAvailableEndpointsRequest) GetRegion() string {
+func (x *PowerSample) GetDatacenterId() string {
 	if x != nil {
-		return x.Region
+		return x.DatacenterId
 	}
 	return ""
 }
 
-func (x *ReportAvailableEndpointsRequest) GetBmcEndpoints() []*BMCEndpointAvailability {
+func (x *PowerSample) GetSampledAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.BmcEndpoints
+		return x.SampledAt
 	}
 	return nil
 }
 
-// BMCEndpointAvailability describes a BMC endpoint available through a gateway
-type BMCEndpointAvailability struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	BmcEndpoint       string                 `protobuf:"bytes,1,opt,name=bmc_endpoint,json=bmcEndpoint,proto3" json:"bmc_endpoint,omitempty"`                    // BMC endpoint (e.g., "192.168.1.100:623")
-	AgentId           string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                // Agent that provides access to this endpoint
-	DatacenterId      string                 `protobuf:"bytes,3,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`                 // Datacenter containing this BMC
-	BmcType           v1.BMCType             `protobuf:"varint,4,opt,name=bmc_type,json=bmcType,proto3,enum=common.v1.BMCType" json:"bmc_type,omitempty"`        // Type of BMC interface
-	Features          []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                             // Available features
-	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                 // Endpoint status
-	LastSeen          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                             // When this endpoint was last verified
-	Username          string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                                             // BMC username
-	Capabilities      []string               `protobuf:"bytes,9,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                                     // BMC capabilities
-	DiscoveryMetadata *v1.DiscoveryMetadata  `protobuf:"bytes,10,opt,name=discovery_metadata,json=discoveryMetadata,proto3" json:"discovery_metadata,omitempty"` // Discovery metadata (RFD 017)
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *PowerSample) GetConsumedWatts() float64 {
+	if x != nil {
+		return x.ConsumedWatts
+	}
+	return 0
 }
 
-func (x *BMCEndpointAvailability) Reset() {
-	*x = BMCEndpointAvailability{}
-	mi := &file_manager_v1_manager_proto_msgTypes[23]
+func (x *PowerSample) GetEnergyKwh() float64 {
+	if x != nil {
+		return x.EnergyKwh
+	}
+	return 0
+}
+
+// ReportPowerReadingsResponse confirms the samples were recorded
+type ReportPowerReadingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPowerReadingsResponse) Reset() {
+	*x = ReportPowerReadingsResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCEndpointAvailability) String() string {
+func (x *ReportPowerReadingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BMCEndpointAvailability) ProtoMessage() {}
+func (*ReportPowerReadingsResponse) ProtoMessage() {}
 
-func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[23]
+func (x *ReportPowerReadingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1550,105 +2820,126 @@ func (x *BMCEndpointAvailability) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BMCEndpointAvailability.ProtoReflect.Descriptor instead.
-func (*BMCEndpointAvailability) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ReportPowerReadingsResponse.ProtoReflect.Descriptor instead.
+func (*ReportPowerReadingsResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *BMCEndpointAvailability) GetBmcEndpoint() string {
+func (x *ReportPowerReadingsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.BmcEndpoint
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *BMCEndpointAvailability) GetAgentId() string {
+func (x *ReportPowerReadingsResponse) GetMessage() string {
 	if x != nil {
-		return x.AgentId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetDatacenterId() string {
-	if x != nil {
-		return x.DatacenterId
-	}
-	return ""
+// ValidateSessionRequest identifies a gateway console session a client attaches to
+type ValidateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`       // Gateway holding the session
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`       // Gateway console session ID
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`          // Server of the console
+	CustomerId    string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`    // Customer the session was created for
+	SessionType   string                 `protobuf:"bytes,5,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"` // "sol" or "vnc"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BMCEndpointAvailability) GetBmcType() v1.BMCType {
-	if x != nil {
-		return x.BmcType
-	}
-	return v1.BMCType(0)
+func (x *ValidateSessionRequest) Reset() {
+	*x = ValidateSessionRequest{}
+	mi := &file_manager_v1_manager_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BMCEndpointAvailability) GetFeatures() []string {
+func (x *ValidateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateSessionRequest) ProtoMessage() {}
+
+func (x *ValidateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[45]
 	if x != nil {
-		return x.Features
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BMCEndpointAvailability) GetStatus() string {
+// Deprecated: Use ValidateSessionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ValidateSessionRequest) GetGatewayId() string {
 	if x != nil {
-		return x.Status
+		return x.GatewayId
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetLastSeen() *timestamppb.Timestamp {
+func (x *ValidateSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.LastSeen
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-func (x *BMCEndpointAvailability) GetUsername() string {
+func (x *ValidateSessionRequest) GetServerId() string {
 	if x != nil {
-		return x.Username
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *BMCEndpointAvailability) GetCapabilities() []string {
+func (x *ValidateSessionRequest) GetCustomerId() string {
 	if x != nil {
-		return x.Capabilities
+		return x.CustomerId
 	}
-	return nil
+	return ""
 }
 
-func (x *BMCEndpointAvailability) GetDiscoveryMetadata() *v1.DiscoveryMetadata {
+func (x *ValidateSessionRequest) GetSessionType() string {
 	if x != nil {
-		return x.DiscoveryMetadata
+		return x.SessionType
 	}
-	return nil
+	return ""
 }
 
-// ReportAvailableEndpointsResponse confirms endpoint registration
-type ReportAvailableEndpointsResponse struct {
+// ValidateSessionResponse tells whether the session is still authorized
+type ValidateSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Why the session is no longer authorized, when not valid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportAvailableEndpointsResponse) Reset() {
-	*x = ReportAvailableEndpointsResponse{}
-	mi := &file_manager_v1_manager_proto_msgTypes[24]
+func (x *ValidateSessionResponse) Reset() {
+	*x = ValidateSessionResponse{}
+	mi := &file_manager_v1_manager_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportAvailableEndpointsResponse) String() string {
+func (x *ValidateSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportAvailableEndpointsResponse) ProtoMessage() {}
+func (*ValidateSessionResponse) ProtoMessage() {}
 
-func (x *ReportAvailableEndpointsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[24]
+func (x *ValidateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,21 +2950,21 @@ func (x *ReportAvailableEndpointsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportAvailableEndpointsResponse.ProtoReflect.Descriptor instead.
-func (*ReportAvailableEndpointsResponse) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ValidateSessionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ReportAvailableEndpointsResponse) GetSuccess() bool {
+func (x *ValidateSessionResponse) GetValid() bool {
 	if x != nil {
-		return x.Success
+		return x.Valid
 	}
 	return false
 }
 
-func (x *ReportAvailableEndpointsResponse) GetMessage() string {
+func (x *ValidateSessionResponse) GetReason() string {
 	if x != nil {
-		return x.Message
+		return x.Reason
 	}
 	return ""
 }
@@ -1687,7 +2978,7 @@ type GetSystemStatusRequest struct {
 
 func (x *GetSystemStatusRequest) Reset() {
 	*x = GetSystemStatusRequest{}
-	mi := &file_manager_v1_manager_proto_msgTypes[25]
+	mi := &file_manager_v1_manager_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1699,7 +2990,7 @@ func (x *GetSystemStatusRequest) String() string {
 func (*GetSystemStatusRequest) ProtoMessage() {}
 
 func (x *GetSystemStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[25]
+	mi := &file_manager_v1_manager_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1712,7 +3003,7 @@ func (x *GetSystemStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSystemStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetSystemStatusRequest) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{25}
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{47}
 }
 
 // GetSystemStatusResponse provides comprehensive system status
@@ -1725,7 +3016,7 @@ type GetSystemStatusResponse struct {
 
 func (x *GetSystemStatusResponse) Reset() {
 	*x = GetSystemStatusResponse{}
-	mi := &file_manager_v1_manager_proto_msgTypes[26]
+	mi := &file_manager_v1_manager_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1737,7 +3028,7 @@ func (x *GetSystemStatusResponse) String() string {
 func (*GetSystemStatusResponse) ProtoMessage() {}
 
 func (x *GetSystemStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[26]
+	mi := &file_manager_v1_manager_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1750,7 +3041,7 @@ func (x *GetSystemStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSystemStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetSystemStatusResponse) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{26}
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *GetSystemStatusResponse) GetStatus() *SystemStatus {
@@ -1762,22 +3053,24 @@ func (x *GetSystemStatusResponse) GetStatus() *SystemStatus {
 
 // SystemStatus contains comprehensive system state information
 type SystemStatus struct {
-	state          protoimpl.MessageState     `protogen:"open.v1"`
-	Version        string                     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                                      // Manager service version
-	StartedAt      *timestamppb.Timestamp     `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`                 // When the manager service started
-	StatusTime     *timestamppb.Timestamp     `protobuf:"bytes,3,opt,name=status_time,json=statusTime,proto3" json:"status_time,omitempty"`              // When this status was generated
-	TotalGateways  int32                      `protobuf:"varint,4,opt,name=total_gateways,json=totalGateways,proto3" json:"total_gateways,omitempty"`    // Total number of registered gateways
-	ActiveGateways int32                      `protobuf:"varint,5,opt,name=active_gateways,json=activeGateways,proto3" json:"active_gateways,omitempty"` // Number of gateways that have reported recently
-	TotalServers   int32                      `protobuf:"varint,6,opt,name=total_servers,json=totalServers,proto3" json:"total_servers,omitempty"`       // Total number of registered servers
-	Gateways       []*GatewayStatus           `protobuf:"bytes,7,rep,name=gateways,proto3" json:"gateways,omitempty"`                                    // Detailed status of each gateway
-	Servers        []*SystemStatusServerEntry `protobuf:"bytes,8,rep,name=servers,proto3" json:"servers,omitempty"`                                      // Summary of all servers across gateways
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                 protoimpl.MessageState     `protogen:"open.v1"`
+	Version               string                     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                                                              // Manager service version
+	StartedAt             *timestamppb.Timestamp     `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`                                         // When the manager service started
+	StatusTime            *timestamppb.Timestamp     `protobuf:"bytes,3,opt,name=status_time,json=statusTime,proto3" json:"status_time,omitempty"`                                      // When this status was generated
+	TotalGateways         int32                      `protobuf:"varint,4,opt,name=total_gateways,json=totalGateways,proto3" json:"total_gateways,omitempty"`                            // Total number of registered gateways
+	ActiveGateways        int32                      `protobuf:"varint,5,opt,name=active_gateways,json=activeGateways,proto3" json:"active_gateways,omitempty"`                         // Number of gateways that have reported recently
+	TotalServers          int32                      `protobuf:"varint,6,opt,name=total_servers,json=totalServers,proto3" json:"total_servers,omitempty"`                               // Total number of registered servers
+	Gateways              []*GatewayStatus           `protobuf:"bytes,7,rep,name=gateways,proto3" json:"gateways,omitempty"`                                                            // Detailed status of each gateway
+	Servers               []*SystemStatusServerEntry `protobuf:"bytes,8,rep,name=servers,proto3" json:"servers,omitempty"`                                                              // Summary of all servers across gateways
+	ReachableGateways     int32                      `protobuf:"varint,9,opt,name=reachable_gateways,json=reachableGateways,proto3" json:"reachable_gateways,omitempty"`                // Gateways whose last status poll succeeded
+	ActiveConsoleSessions int32                      `protobuf:"varint,10,opt,name=active_console_sessions,json=activeConsoleSessions,proto3" json:"active_console_sessions,omitempty"` // Console sessions across the reachable gateways
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *SystemStatus) Reset() {
 	*x = SystemStatus{}
-	mi := &file_manager_v1_manager_proto_msgTypes[27]
+	mi := &file_manager_v1_manager_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1789,7 +3082,7 @@ func (x *SystemStatus) String() string {
 func (*SystemStatus) ProtoMessage() {}
 
 func (x *SystemStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[27]
+	mi := &file_manager_v1_manager_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1802,7 +3095,7 @@ func (x *SystemStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
 func (*SystemStatus) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{27}
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *SystemStatus) GetVersion() string {
@@ -1861,6 +3154,20 @@ func (x *SystemStatus) GetServers() []*SystemStatusServerEntry {
 	return nil
 }
 
+func (x *SystemStatus) GetReachableGateways() int32 {
+	if x != nil {
+		return x.ReachableGateways
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetActiveConsoleSessions() int32 {
+	if x != nil {
+		return x.ActiveConsoleSessions
+	}
+	return 0
+}
+
 // GatewayStatus provides detailed information about a specific gateway
 type GatewayStatus struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
@@ -1873,13 +3180,14 @@ type GatewayStatus struct {
 	CreatedAt     *timestamppb.Timestamp     `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`             // When gateway was first registered
 	ServerCount   int32                      `protobuf:"varint,8,opt,name=server_count,json=serverCount,proto3" json:"server_count,omitempty"`      // Number of servers registered through this gateway
 	Servers       []*SystemStatusServerEntry `protobuf:"bytes,9,rep,name=servers,proto3" json:"servers,omitempty"`                                  // List of servers managed by this gateway
+	Live          *GatewayLiveStatus         `protobuf:"bytes,10,opt,name=live,proto3" json:"live,omitempty"`                                       // Last status polled from the gateway, unset until polled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GatewayStatus) Reset() {
 	*x = GatewayStatus{}
-	mi := &file_manager_v1_manager_proto_msgTypes[28]
+	mi := &file_manager_v1_manager_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1891,7 +3199,7 @@ func (x *GatewayStatus) String() string {
 func (*GatewayStatus) ProtoMessage() {}
 
 func (x *GatewayStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[28]
+	mi := &file_manager_v1_manager_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1904,7 +3212,7 @@ func (x *GatewayStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewayStatus.ProtoReflect.Descriptor instead.
 func (*GatewayStatus) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{28}
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *GatewayStatus) GetId() string {
@@ -1970,6 +3278,207 @@ func (x *GatewayStatus) GetServers() []*SystemStatusServerEntry {
 	return nil
 }
 
+func (x *GatewayStatus) GetLive() *GatewayLiveStatus {
+	if x != nil {
+		return x.Live
+	}
+	return nil
+}
+
+// GatewayLiveStatus is the state of a gateway as last polled by the manager
+type GatewayLiveStatus struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	PolledAt              *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=polled_at,json=polledAt,proto3" json:"polled_at,omitempty"`                                           // When the gateway was last polled
+	Reachable             bool                   `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`                                                        // Whether the last poll succeeded
+	Error                 string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                                 // Why the last poll failed
+	ActiveConsoleSessions int32                  `protobuf:"varint,4,opt,name=active_console_sessions,json=activeConsoleSessions,proto3" json:"active_console_sessions,omitempty"` // Unexpired console sessions
+	AttachedStreams       int32                  `protobuf:"varint,5,opt,name=attached_streams,json=attachedStreams,proto3" json:"attached_streams,omitempty"`                     // Client streams attached to the sessions
+	EndpointCount         int32                  `protobuf:"varint,6,opt,name=endpoint_count,json=endpointCount,proto3" json:"endpoint_count,omitempty"`                           // BMC endpoints mapped to agents
+	Agents                []*GatewayAgentHealth  `protobuf:"bytes,7,rep,name=agents,proto3" json:"agents,omitempty"`                                                               // Agents registered with the gateway, by ID
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GatewayLiveStatus) Reset() {
+	*x = GatewayLiveStatus{}
+	mi := &file_manager_v1_manager_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GatewayLiveStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayLiveStatus) ProtoMessage() {}
+
+func (x *GatewayLiveStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayLiveStatus.ProtoReflect.Descriptor instead.
+func (*GatewayLiveStatus) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GatewayLiveStatus) GetPolledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PolledAt
+	}
+	return nil
+}
+
+func (x *GatewayLiveStatus) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *GatewayLiveStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GatewayLiveStatus) GetActiveConsoleSessions() int32 {
+	if x != nil {
+		return x.ActiveConsoleSessions
+	}
+	return 0
+}
+
+func (x *GatewayLiveStatus) GetAttachedStreams() int32 {
+	if x != nil {
+		return x.AttachedStreams
+	}
+	return 0
+}
+
+func (x *GatewayLiveStatus) GetEndpointCount() int32 {
+	if x != nil {
+		return x.EndpointCount
+	}
+	return 0
+}
+
+func (x *GatewayLiveStatus) GetAgents() []*GatewayAgentHealth {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// GatewayAgentHealth is the health of a Local Agent as seen by its gateway
+type GatewayAgentHealth struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AgentId            string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	DatacenterId       string                 `protobuf:"bytes,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	Status             string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                                      // Registry status ("active" or "stale")
+	LastSeen           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`                                  // Last registration or heartbeat
+	ActiveSessionCount int32                  `protobuf:"varint,5,opt,name=active_session_count,json=activeSessionCount,proto3" json:"active_session_count,omitempty"` // Console sessions routed through the agent
+	BmcEndpointCount   int32                  `protobuf:"varint,6,opt,name=bmc_endpoint_count,json=bmcEndpointCount,proto3" json:"bmc_endpoint_count,omitempty"`       // BMC endpoints mapped to the agent
+	LinkRttMs          float64                `protobuf:"fixed64,7,opt,name=link_rtt_ms,json=linkRttMs,proto3" json:"link_rtt_ms,omitempty"`                           // Round-trip time of the last link probe, 0 when not probed
+	LinkError          string                 `protobuf:"bytes,8,opt,name=link_error,json=linkError,proto3" json:"link_error,omitempty"`                               // Why the last link probe failed
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GatewayAgentHealth) Reset() {
+	*x = GatewayAgentHealth{}
+	mi := &file_manager_v1_manager_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GatewayAgentHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayAgentHealth) ProtoMessage() {}
+
+func (x *GatewayAgentHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_v1_manager_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayAgentHealth.ProtoReflect.Descriptor instead.
+func (*GatewayAgentHealth) Descriptor() ([]byte, []int) {
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GatewayAgentHealth) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GatewayAgentHealth) GetDatacenterId() string {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return ""
+}
+
+func (x *GatewayAgentHealth) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GatewayAgentHealth) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *GatewayAgentHealth) GetActiveSessionCount() int32 {
+	if x != nil {
+		return x.ActiveSessionCount
+	}
+	return 0
+}
+
+func (x *GatewayAgentHealth) GetBmcEndpointCount() int32 {
+	if x != nil {
+		return x.BmcEndpointCount
+	}
+	return 0
+}
+
+func (x *GatewayAgentHealth) GetLinkRttMs() float64 {
+	if x != nil {
+		return x.LinkRttMs
+	}
+	return 0
+}
+
+func (x *GatewayAgentHealth) GetLinkError() string {
+	if x != nil {
+		return x.LinkError
+	}
+	return ""
+}
+
 // SystemStatusServerEntry provides server information for status display
 type SystemStatusServerEntry struct {
 	state             protoimpl.MessageState   `protogen:"open.v1"`
@@ -1982,13 +3491,14 @@ type SystemStatusServerEntry struct {
 	UpdatedAt         *timestamppb.Timestamp   `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                           // Last update time
 	BmcProtocols      []*v1.BMCControlEndpoint `protobuf:"bytes,8,rep,name=bmc_protocols,json=bmcProtocols,proto3" json:"bmc_protocols,omitempty"`                                  // Multiple protocol support (required for RFD 006)
 	PrimaryProtocol   v1.BMCType               `protobuf:"varint,9,opt,name=primary_protocol,json=primaryProtocol,proto3,enum=common.v1.BMCType" json:"primary_protocol,omitempty"` // Preferred protocol for operations
+	EndpointReachable bool                     `protobuf:"varint,10,opt,name=endpoint_reachable,json=endpointReachable,proto3" json:"endpoint_reachable,omitempty"`                 // Whether the gateway's last status maps the BMC endpoint to an active agent
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *SystemStatusServerEntry) Reset() {
 	*x = SystemStatusServerEntry{}
-	mi := &file_manager_v1_manager_proto_msgTypes[29]
+	mi := &file_manager_v1_manager_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2000,7 +3510,7 @@ func (x *SystemStatusServerEntry) String() string {
 func (*SystemStatusServerEntry) ProtoMessage() {}
 
 func (x *SystemStatusServerEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_v1_manager_proto_msgTypes[29]
+	mi := &file_manager_v1_manager_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2013,7 +3523,7 @@ func (x *SystemStatusServerEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStatusServerEntry.ProtoReflect.Descriptor instead.
 func (*SystemStatusServerEntry) Descriptor() ([]byte, []int) {
-	return file_manager_v1_manager_proto_rawDescGZIP(), []int{29}
+	return file_manager_v1_manager_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *SystemStatusServerEntry) GetServerId() string {
@@ -2079,17 +3589,24 @@ func (x *SystemStatusServerEntry) GetPrimaryProtocol() v1.BMCType {
 	return v1.BMCType(0)
 }
 
+func (x *SystemStatusServerEntry) GetEndpointReachable() bool {
+	if x != nil {
+		return x.EndpointReachable
+	}
+	return false
+}
+
 var File_manager_v1_manager_proto protoreflect.FileDescriptor
 
 const file_manager_v1_manager_proto_rawDesc = "" +
 	"\n" +
 	"\x18manager/v1/manager.proto\x12\n" +
-	"manager.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19common/v1/discovery.proto\x1a\x16common/v1/server.proto\"k\n" +
+	"manager.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19common/v1/discovery.proto\x1a\x16common/v1/server.proto\"k\n" +
 	"\bCustomer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd1\x05\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd4\x06\n" +
 	"\x06Server\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -2107,7 +3624,13 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
 	"\bmetadata\x18\f \x03(\v2 .manager.v1.Server.MetadataEntryR\bmetadata\x12K\n" +
-	"\x12discovery_metadata\x18\r \x01(\v2\x1c.common.v1.DiscoveryMetadataR\x11discoveryMetadata\x1a;\n" +
+	"\x12discovery_metadata\x18\r \x01(\v2\x1c.common.v1.DiscoveryMetadataR\x11discoveryMetadata\x12\x1f\n" +
+	"\vexternal_id\x18\x0e \x01(\tR\n" +
+	"externalId\x12\x1f\n" +
+	"\vpower_state\x18\x0f \x01(\tR\n" +
+	"powerState\x12)\n" +
+	"\x10maintenance_mode\x18\x10 \x01(\bR\x0fmaintenanceMode\x12\x14\n" +
+	"\x05notes\x18\x11 \x01(\tR\x05notes\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb1\x02\n" +
@@ -2133,10 +3656,11 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12B\n" +
 	"\rbmc_protocols\x18\b \x03(\v2\x1d.common.v1.BMCControlEndpointR\fbmcProtocols\x12=\n" +
-	"\x10primary_protocol\x18\t \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol\"G\n" +
+	"\x10primary_protocol\x18\t \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol\"d\n" +
 	"\x13AuthenticateRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xcb\x01\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\ttotp_code\x18\x03 \x01(\tR\btotpCode\"\xcb\x01\n" +
 	"\x14AuthenticateResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
@@ -2149,13 +3673,34 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\x14RefreshTokenResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x129\n" +
 	"\n" +
-	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"4\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x18\n" +
+	"\x16ChangePasswordResponse\"p\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1f\n" +
+	"\vreset_token\x18\x02 \x01(\tR\n" +
+	"resetToken\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"\x17\n" +
+	"\x15ResetPasswordResponse\"\x13\n" +
+	"\x11EnrollTOTPRequest\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_url\x18\x02 \x01(\tR\n" +
+	"otpauthUrl\"(\n" +
+	"\x12ConfirmTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x15\n" +
+	"\x13ConfirmTOTPResponse\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x15\n" +
+	"\x13DisableTOTPResponse\"4\n" +
 	"\x15GetServerTokenRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"i\n" +
 	"\x16GetServerTokenResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
 	"\n" +
-	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xc9\x02\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xea\x02\n" +
 	"\x15RegisterServerRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -2164,18 +3709,23 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\x13regional_gateway_id\x18\x04 \x01(\tR\x11regionalGatewayId\x12\x1a\n" +
 	"\bfeatures\x18\x05 \x03(\tR\bfeatures\x12B\n" +
 	"\rbmc_protocols\x18\x06 \x03(\v2\x1d.common.v1.BMCControlEndpointR\fbmcProtocols\x12=\n" +
-	"\x10primary_protocol\x18\a \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol\"L\n" +
+	"\x10primary_protocol\x18\a \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol\x12\x1f\n" +
+	"\vexternal_id\x18\b \x01(\tR\n" +
+	"externalId\"\x83\x01\n" +
 	"\x16RegisterServerResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\bR\acreated\x12\x1b\n" +
+	"\tserver_id\x18\x04 \x01(\tR\bserverId\"/\n" +
 	"\x10GetServerRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"?\n" +
 	"\x11GetServerResponse\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.manager.v1.ServerR\x06server\"P\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.manager.v1.ServerR\x06server\"\x80\x01\n" +
 	"\x12ListServersRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"k\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12.\n" +
+	"\x13include_power_state\x18\x03 \x01(\bR\x11includePowerState\"k\n" +
 	"\x13ListServersResponse\x12,\n" +
 	"\aservers\x18\x01 \x03(\v2\x12.manager.v1.ServerR\aservers\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"7\n" +
@@ -2193,10 +3743,16 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x16\n" +
 	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
 	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12%\n" +
-	"\x0edatacenter_ids\x18\x04 \x03(\tR\rdatacenterIds\"M\n" +
+	"\x0edatacenter_ids\x18\x04 \x03(\tR\rdatacenterIds\"\x97\x01\n" +
 	"\x17RegisterGatewayResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"-\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12H\n" +
+	"\x11token_revocations\x18\x03 \x03(\v2\x1b.manager.v1.TokenRevocationR\x10tokenRevocations\"m\n" +
+	"\x0fTokenRevocation\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x129\n" +
+	"\n" +
+	"revoked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"-\n" +
 	"\x13ListGatewaysRequest\x12\x16\n" +
 	"\x06region\x18\x01 \x01(\tR\x06region\"O\n" +
 	"\x14ListGatewaysResponse\x127\n" +
@@ -2220,10 +3776,70 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	" \x01(\v2\x1c.common.v1.DiscoveryMetadataR\x11discoveryMetadata\"V\n" +
 	" ReportAvailableEndpointsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"t\n" +
+	"\x18ReportConsoleSLIsRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x129\n" +
+	"\bsessions\x18\x02 \x03(\v2\x1d.manager.v1.ConsoleSessionSLIR\bsessions\"\xd9\x02\n" +
+	"\x11ConsoleSessionSLI\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12#\n" +
+	"\rdatacenter_id\x18\x03 \x01(\tR\fdatacenterId\x12!\n" +
+	"\fsession_type\x18\x04 \x01(\tR\vsessionType\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12 \n" +
+	"\vestablished\x18\x06 \x01(\bR\vestablished\x12\x1d\n" +
+	"\n" +
+	"first_byte\x18\a \x01(\bR\tfirstByte\x120\n" +
+	"\x15time_to_first_byte_ms\x18\b \x01(\x03R\x11timeToFirstByteMs\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"O\n" +
+	"\x19ReportConsoleSLIsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
+	"\x13ReportEventsRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12/\n" +
+	"\x06events\x18\x02 \x03(\v2\x17.manager.v1.SystemEventR\x06events\"\xa6\x01\n" +
+	"\vSystemEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12+\n" +
+	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04data\"J\n" +
+	"\x14ReportEventsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"n\n" +
+	"\x1aReportPowerReadingsRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x121\n" +
+	"\asamples\x18\x02 \x03(\v2\x17.manager.v1.PowerSampleR\asamples\"\xd6\x01\n" +
+	"\vPowerSample\x12!\n" +
+	"\fbmc_endpoint\x18\x01 \x01(\tR\vbmcEndpoint\x12#\n" +
+	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x129\n" +
+	"\n" +
+	"sampled_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tsampledAt\x12%\n" +
+	"\x0econsumed_watts\x18\x04 \x01(\x01R\rconsumedWatts\x12\x1d\n" +
+	"\n" +
+	"energy_kwh\x18\x05 \x01(\x01R\tenergyKwh\"Q\n" +
+	"\x1bReportPowerReadingsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb7\x01\n" +
+	"\x16ValidateSessionRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vcustomer_id\x18\x04 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\fsession_type\x18\x05 \x01(\tR\vsessionType\"G\n" +
+	"\x17ValidateSessionResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x18\n" +
 	"\x16GetSystemStatusRequest\"K\n" +
 	"\x17GetSystemStatusResponse\x120\n" +
-	"\x06status\x18\x01 \x01(\v2\x18.manager.v1.SystemStatusR\x06status\"\x8b\x03\n" +
+	"\x06status\x18\x01 \x01(\v2\x18.manager.v1.SystemStatusR\x06status\"\xf2\x03\n" +
 	"\fSystemStatus\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x129\n" +
 	"\n" +
@@ -2234,7 +3850,10 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\x0factive_gateways\x18\x05 \x01(\x05R\x0eactiveGateways\x12#\n" +
 	"\rtotal_servers\x18\x06 \x01(\x05R\ftotalServers\x125\n" +
 	"\bgateways\x18\a \x03(\v2\x19.manager.v1.GatewayStatusR\bgateways\x12=\n" +
-	"\aservers\x18\b \x03(\v2#.manager.v1.SystemStatusServerEntryR\aservers\"\xe8\x02\n" +
+	"\aservers\x18\b \x03(\v2#.manager.v1.SystemStatusServerEntryR\aservers\x12-\n" +
+	"\x12reachable_gateways\x18\t \x01(\x05R\x11reachableGateways\x126\n" +
+	"\x17active_console_sessions\x18\n" +
+	" \x01(\x05R\x15activeConsoleSessions\"\x9b\x03\n" +
 	"\rGatewayStatus\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
@@ -2245,7 +3864,27 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
 	"\fserver_count\x18\b \x01(\x05R\vserverCount\x12=\n" +
-	"\aservers\x18\t \x03(\v2#.manager.v1.SystemStatusServerEntryR\aservers\"\xc1\x03\n" +
+	"\aservers\x18\t \x03(\v2#.manager.v1.SystemStatusServerEntryR\aservers\x121\n" +
+	"\x04live\x18\n" +
+	" \x01(\v2\x1d.manager.v1.GatewayLiveStatusR\x04live\"\xc2\x02\n" +
+	"\x11GatewayLiveStatus\x127\n" +
+	"\tpolled_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bpolledAt\x12\x1c\n" +
+	"\treachable\x18\x02 \x01(\bR\treachable\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x126\n" +
+	"\x17active_console_sessions\x18\x04 \x01(\x05R\x15activeConsoleSessions\x12)\n" +
+	"\x10attached_streams\x18\x05 \x01(\x05R\x0fattachedStreams\x12%\n" +
+	"\x0eendpoint_count\x18\x06 \x01(\x05R\rendpointCount\x126\n" +
+	"\x06agents\x18\a \x03(\v2\x1e.manager.v1.GatewayAgentHealthR\x06agents\"\xc4\x02\n" +
+	"\x12GatewayAgentHealth\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
+	"\rdatacenter_id\x18\x02 \x01(\tR\fdatacenterId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x127\n" +
+	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x120\n" +
+	"\x14active_session_count\x18\x05 \x01(\x05R\x12activeSessionCount\x12,\n" +
+	"\x12bmc_endpoint_count\x18\x06 \x01(\x05R\x10bmcEndpointCount\x12\x1e\n" +
+	"\vlink_rtt_ms\x18\a \x01(\x01R\tlinkRttMs\x12\x1d\n" +
+	"\n" +
+	"link_error\x18\b \x01(\tR\tlinkError\"\xf0\x03\n" +
 	"\x17SystemStatusServerEntry\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -2258,10 +3897,18 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12B\n" +
 	"\rbmc_protocols\x18\b \x03(\v2\x1d.common.v1.BMCControlEndpointR\fbmcProtocols\x12=\n" +
-	"\x10primary_protocol\x18\t \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol2\xe9\a\n" +
+	"\x10primary_protocol\x18\t \x01(\x0e2\x12.common.v1.BMCTypeR\x0fprimaryProtocol\x12-\n" +
+	"\x12endpoint_reachable\x18\n" +
+	" \x01(\bR\x11endpointReachable2\xfe\r\n" +
 	"\x11BMCManagerService\x12Q\n" +
 	"\fAuthenticate\x12\x1f.manager.v1.AuthenticateRequest\x1a .manager.v1.AuthenticateResponse\x12Q\n" +
 	"\fRefreshToken\x12\x1f.manager.v1.RefreshTokenRequest\x1a .manager.v1.RefreshTokenResponse\x12W\n" +
+	"\x0eChangePassword\x12!.manager.v1.ChangePasswordRequest\x1a\".manager.v1.ChangePasswordResponse\x12T\n" +
+	"\rResetPassword\x12 .manager.v1.ResetPasswordRequest\x1a!.manager.v1.ResetPasswordResponse\x12K\n" +
+	"\n" +
+	"EnrollTOTP\x12\x1d.manager.v1.EnrollTOTPRequest\x1a\x1e.manager.v1.EnrollTOTPResponse\x12N\n" +
+	"\vConfirmTOTP\x12\x1e.manager.v1.ConfirmTOTPRequest\x1a\x1f.manager.v1.ConfirmTOTPResponse\x12N\n" +
+	"\vDisableTOTP\x12\x1e.manager.v1.DisableTOTPRequest\x1a\x1f.manager.v1.DisableTOTPResponse\x12W\n" +
 	"\x0eGetServerToken\x12!.manager.v1.GetServerTokenRequest\x1a\".manager.v1.GetServerTokenResponse\x12W\n" +
 	"\x0eRegisterServer\x12!.manager.v1.RegisterServerRequest\x1a\".manager.v1.RegisterServerResponse\x12`\n" +
 	"\x11GetServerLocation\x12$.manager.v1.GetServerLocationRequest\x1a%.manager.v1.GetServerLocationResponse\x12Z\n" +
@@ -2270,7 +3917,11 @@ const file_manager_v1_manager_proto_rawDesc = "" +
 	"\x0fGetSystemStatus\x12\".manager.v1.GetSystemStatusRequest\x1a#.manager.v1.GetSystemStatusResponse\x12H\n" +
 	"\tGetServer\x12\x1c.manager.v1.GetServerRequest\x1a\x1d.manager.v1.GetServerResponse\x12N\n" +
 	"\vListServers\x12\x1e.manager.v1.ListServersRequest\x1a\x1f.manager.v1.ListServersResponse\x12u\n" +
-	"\x18ReportAvailableEndpoints\x12+.manager.v1.ReportAvailableEndpointsRequest\x1a,.manager.v1.ReportAvailableEndpointsResponseB\"Z manager/gen/manager/v1;managerv1b\x06proto3"
+	"\x18ReportAvailableEndpoints\x12+.manager.v1.ReportAvailableEndpointsRequest\x1a,.manager.v1.ReportAvailableEndpointsResponse\x12`\n" +
+	"\x11ReportConsoleSLIs\x12$.manager.v1.ReportConsoleSLIsRequest\x1a%.manager.v1.ReportConsoleSLIsResponse\x12Q\n" +
+	"\fReportEvents\x12\x1f.manager.v1.ReportEventsRequest\x1a .manager.v1.ReportEventsResponse\x12f\n" +
+	"\x13ReportPowerReadings\x12&.manager.v1.ReportPowerReadingsRequest\x1a'.manager.v1.ReportPowerReadingsResponse\x12Z\n" +
+	"\x0fValidateSession\x12\".manager.v1.ValidateSessionRequest\x1a#.manager.v1.ValidateSessionResponseB\"Z manager/gen/manager/v1;managerv1b\x06proto3"
 
 var (
 	file_manager_v1_manager_proto_rawDescOnce sync.Once
@@ -2284,7 +3935,7 @@ func file_manager_v1_manager_proto_rawDescGZIP() []byte {
 	return file_manager_v1_manager_proto_rawDescData
 }
 
-var file_manager_v1_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_manager_v1_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_manager_v1_manager_proto_goTypes = []any{
 	(*Customer)(nil),                         // 0: manager.v1.Customer
 	(*Server)(nil),                           // 1: manager.v1.Server
@@ -2294,106 +3945,162 @@ var file_manager_v1_manager_proto_goTypes = []any{
 	(*AuthenticateResponse)(nil),             // 5: manager.v1.AuthenticateResponse
 	(*RefreshTokenRequest)(nil),              // 6: manager.v1.RefreshTokenRequest
 	(*RefreshTokenResponse)(nil),             // 7: manager.v1.RefreshTokenResponse
-	(*GetServerTokenRequest)(nil),            // 8: manager.v1.GetServerTokenRequest
-	(*GetServerTokenResponse)(nil),           // 9: manager.v1.GetServerTokenResponse
-	(*RegisterServerRequest)(nil),            // 10: manager.v1.RegisterServerRequest
-	(*RegisterServerResponse)(nil),           // 11: manager.v1.RegisterServerResponse
-	(*GetServerRequest)(nil),                 // 12: manager.v1.GetServerRequest
-	(*GetServerResponse)(nil),                // 13: manager.v1.GetServerResponse
-	(*ListServersRequest)(nil),               // 14: manager.v1.ListServersRequest
-	(*ListServersResponse)(nil),              // 15: manager.v1.ListServersResponse
-	(*GetServerLocationRequest)(nil),         // 16: manager.v1.GetServerLocationRequest
-	(*GetServerLocationResponse)(nil),        // 17: manager.v1.GetServerLocationResponse
-	(*RegisterGatewayRequest)(nil),           // 18: manager.v1.RegisterGatewayRequest
-	(*RegisterGatewayResponse)(nil),          // 19: manager.v1.RegisterGatewayResponse
-	(*ListGatewaysRequest)(nil),              // 20: manager.v1.ListGatewaysRequest
-	(*ListGatewaysResponse)(nil),             // 21: manager.v1.ListGatewaysResponse
-	(*ReportAvailableEndpointsRequest)(nil),  // 22: manager.v1.ReportAvailableEndpointsRequest
-	(*BMCEndpointAvailability)(nil),          // 23: manager.v1.BMCEndpointAvailability
-	(*ReportAvailableEndpointsResponse)(nil), // 24: manager.v1.ReportAvailableEndpointsResponse
-	(*GetSystemStatusRequest)(nil),           // 25: manager.v1.GetSystemStatusRequest
-	(*GetSystemStatusResponse)(nil),          // 26: manager.v1.GetSystemStatusResponse
-	(*SystemStatus)(nil),                     // 27: manager.v1.SystemStatus
-	(*GatewayStatus)(nil),                    // 28: manager.v1.GatewayStatus
-	(*SystemStatusServerEntry)(nil),          // 29: manager.v1.SystemStatusServerEntry
-	nil,                                      // 30: manager.v1.Server.MetadataEntry
-	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
-	(*v1.BMCControlEndpoint)(nil),            // 32: common.v1.BMCControlEndpoint
-	(v1.BMCType)(0),                          // 33: common.v1.BMCType
-	(*v1.SOLEndpoint)(nil),                   // 34: common.v1.SOLEndpoint
-	(*v1.VNCEndpoint)(nil),                   // 35: common.v1.VNCEndpoint
-	(*v1.DiscoveryMetadata)(nil),             // 36: common.v1.DiscoveryMetadata
+	(*ChangePasswordRequest)(nil),            // 8: manager.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),           // 9: manager.v1.ChangePasswordResponse
+	(*ResetPasswordRequest)(nil),             // 10: manager.v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),            // 11: manager.v1.ResetPasswordResponse
+	(*EnrollTOTPRequest)(nil),                // 12: manager.v1.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),               // 13: manager.v1.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),               // 14: manager.v1.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),              // 15: manager.v1.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),               // 16: manager.v1.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),              // 17: manager.v1.DisableTOTPResponse
+	(*GetServerTokenRequest)(nil),            // 18: manager.v1.GetServerTokenRequest
+	(*GetServerTokenResponse)(nil),           // 19: manager.v1.GetServerTokenResponse
+	(*RegisterServerRequest)(nil),            // 20: manager.v1.RegisterServerRequest
+	(*RegisterServerResponse)(nil),           // 21: manager.v1.RegisterServerResponse
+	(*GetServerRequest)(nil),                 // 22: manager.v1.GetServerRequest
+	(*GetServerResponse)(nil),                // 23: manager.v1.GetServerResponse
+	(*ListServersRequest)(nil),               // 24: manager.v1.ListServersRequest
+	(*ListServersResponse)(nil),              // 25: manager.v1.ListServersResponse
+	(*GetServerLocationRequest)(nil),         // 26: manager.v1.GetServerLocationRequest
+	(*GetServerLocationResponse)(nil),        // 27: manager.v1.GetServerLocationResponse
+	(*RegisterGatewayRequest)(nil),           // 28: manager.v1.RegisterGatewayRequest
+	(*RegisterGatewayResponse)(nil),          // 29: manager.v1.RegisterGatewayResponse
+	(*TokenRevocation)(nil),                  // 30: manager.v1.TokenRevocation
+	(*ListGatewaysRequest)(nil),              // 31: manager.v1.ListGatewaysRequest
+	(*ListGatewaysResponse)(nil),             // 32: manager.v1.ListGatewaysResponse
+	(*ReportAvailableEndpointsRequest)(nil),  // 33: manager.v1.ReportAvailableEndpointsRequest
+	(*BMCEndpointAvailability)(nil),          // 34: manager.v1.BMCEndpointAvailability
+	(*ReportAvailableEndpointsResponse)(nil), // 35: manager.v1.ReportAvailableEndpointsResponse
+	(*ReportConsoleSLIsRequest)(nil),         // 36: manager.v1.ReportConsoleSLIsRequest
+	(*ConsoleSessionSLI)(nil),                // 37: manager.v1.ConsoleSessionSLI
+	(*ReportConsoleSLIsResponse)(nil),        // 38: manager.v1.ReportConsoleSLIsResponse
+	(*ReportEventsRequest)(nil),              // 39: manager.v1.ReportEventsRequest
+	(*SystemEvent)(nil),                      // 40: manager.v1.SystemEvent
+	(*ReportEventsResponse)(nil),             // 41: manager.v1.ReportEventsResponse
+	(*ReportPowerReadingsRequest)(nil),       // 42: manager.v1.ReportPowerReadingsRequest
+	(*PowerSample)(nil),                      // 43: manager.v1.PowerSample
+	(*ReportPowerReadingsResponse)(nil),      // 44: manager.v1.ReportPowerReadingsResponse
+	(*ValidateSessionRequest)(nil),           // 45: manager.v1.ValidateSessionRequest
+	(*ValidateSessionResponse)(nil),          // 46: manager.v1.ValidateSessionResponse
+	(*GetSystemStatusRequest)(nil),           // 47: manager.v1.GetSystemStatusRequest
+	(*GetSystemStatusResponse)(nil),          // 48: manager.v1.GetSystemStatusResponse
+	(*SystemStatus)(nil),                     // 49: manager.v1.SystemStatus
+	(*GatewayStatus)(nil),                    // 50: manager.v1.GatewayStatus
+	(*GatewayLiveStatus)(nil),                // 51: manager.v1.GatewayLiveStatus
+	(*GatewayAgentHealth)(nil),               // 52: manager.v1.GatewayAgentHealth
+	(*SystemStatusServerEntry)(nil),          // 53: manager.v1.SystemStatusServerEntry
+	nil,                                      // 54: manager.v1.Server.MetadataEntry
+	(*timestamppb.Timestamp)(nil),            // 55: google.protobuf.Timestamp
+	(*v1.BMCControlEndpoint)(nil),            // 56: common.v1.BMCControlEndpoint
+	(v1.BMCType)(0),                          // 57: common.v1.BMCType
+	(*v1.SOLEndpoint)(nil),                   // 58: common.v1.SOLEndpoint
+	(*v1.VNCEndpoint)(nil),                   // 59: common.v1.VNCEndpoint
+	(*v1.DiscoveryMetadata)(nil),             // 60: common.v1.DiscoveryMetadata
+	(*structpb.Struct)(nil),                  // 61: google.protobuf.Struct
 }
 var file_manager_v1_manager_proto_depIdxs = []int32{
-	31, // 0: manager.v1.Customer.created_at:type_name -> google.protobuf.Timestamp
-	32, // 1: manager.v1.Server.control_endpoints:type_name -> common.v1.BMCControlEndpoint
-	33, // 2: manager.v1.Server.primary_protocol:type_name -> common.v1.BMCType
-	34, // 3: manager.v1.Server.sol_endpoint:type_name -> common.v1.SOLEndpoint
-	35, // 4: manager.v1.Server.vnc_endpoint:type_name -> common.v1.VNCEndpoint
-	31, // 5: manager.v1.Server.created_at:type_name -> google.protobuf.Timestamp
-	31, // 6: manager.v1.Server.updated_at:type_name -> google.protobuf.Timestamp
-	30, // 7: manager.v1.Server.metadata:type_name -> manager.v1.Server.MetadataEntry
-	36, // 8: manager.v1.Server.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
-	31, // 9: manager.v1.RegionalGateway.last_seen:type_name -> google.protobuf.Timestamp
-	31, // 10: manager.v1.RegionalGateway.created_at:type_name -> google.protobuf.Timestamp
-	31, // 11: manager.v1.ServerLocation.created_at:type_name -> google.protobuf.Timestamp
-	31, // 12: manager.v1.ServerLocation.updated_at:type_name -> google.protobuf.Timestamp
-	32, // 13: manager.v1.ServerLocation.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
-	33, // 14: manager.v1.ServerLocation.primary_protocol:type_name -> common.v1.BMCType
-	31, // 15: manager.v1.AuthenticateResponse.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 0: manager.v1.Customer.created_at:type_name -> google.protobuf.Timestamp
+	56, // 1: manager.v1.Server.control_endpoints:type_name -> common.v1.BMCControlEndpoint
+	57, // 2: manager.v1.Server.primary_protocol:type_name -> common.v1.BMCType
+	58, // 3: manager.v1.Server.sol_endpoint:type_name -> common.v1.SOLEndpoint
+	59, // 4: manager.v1.Server.vnc_endpoint:type_name -> common.v1.VNCEndpoint
+	55, // 5: manager.v1.Server.created_at:type_name -> google.protobuf.Timestamp
+	55, // 6: manager.v1.Server.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 7: manager.v1.Server.metadata:type_name -> manager.v1.Server.MetadataEntry
+	60, // 8: manager.v1.Server.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
+	55, // 9: manager.v1.RegionalGateway.last_seen:type_name -> google.protobuf.Timestamp
+	55, // 10: manager.v1.RegionalGateway.created_at:type_name -> google.protobuf.Timestamp
+	55, // 11: manager.v1.ServerLocation.created_at:type_name -> google.protobuf.Timestamp
+	55, // 12: manager.v1.ServerLocation.updated_at:type_name -> google.protobuf.Timestamp
+	56, // 13: manager.v1.ServerLocation.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
+	57, // 14: manager.v1.ServerLocation.primary_protocol:type_name -> common.v1.BMCType
+	55, // 15: manager.v1.AuthenticateResponse.expires_at:type_name -> google.protobuf.Timestamp
 	0,  // 16: manager.v1.AuthenticateResponse.customer:type_name -> manager.v1.Customer
-	31, // 17: manager.v1.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	31, // 18: manager.v1.GetServerTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	32, // 19: manager.v1.RegisterServerRequest.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
-	33, // 20: manager.v1.RegisterServerRequest.primary_protocol:type_name -> common.v1.BMCType
+	55, // 17: manager.v1.RefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 18: manager.v1.GetServerTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	56, // 19: manager.v1.RegisterServerRequest.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
+	57, // 20: manager.v1.RegisterServerRequest.primary_protocol:type_name -> common.v1.BMCType
 	1,  // 21: manager.v1.GetServerResponse.server:type_name -> manager.v1.Server
 	1,  // 22: manager.v1.ListServersResponse.servers:type_name -> manager.v1.Server
-	32, // 23: manager.v1.GetServerLocationResponse.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
-	33, // 24: manager.v1.GetServerLocationResponse.primary_protocol:type_name -> common.v1.BMCType
-	2,  // 25: manager.v1.ListGatewaysResponse.gateways:type_name -> manager.v1.RegionalGateway
-	23, // 26: manager.v1.ReportAvailableEndpointsRequest.bmc_endpoints:type_name -> manager.v1.BMCEndpointAvailability
-	33, // 27: manager.v1.BMCEndpointAvailability.bmc_type:type_name -> common.v1.BMCType
-	31, // 28: manager.v1.BMCEndpointAvailability.last_seen:type_name -> google.protobuf.Timestamp
-	36, // 29: manager.v1.BMCEndpointAvailability.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
-	27, // 30: manager.v1.GetSystemStatusResponse.status:type_name -> manager.v1.SystemStatus
-	31, // 31: manager.v1.SystemStatus.started_at:type_name -> google.protobuf.Timestamp
-	31, // 32: manager.v1.SystemStatus.status_time:type_name -> google.protobuf.Timestamp
-	28, // 33: manager.v1.SystemStatus.gateways:type_name -> manager.v1.GatewayStatus
-	29, // 34: manager.v1.SystemStatus.servers:type_name -> manager.v1.SystemStatusServerEntry
-	31, // 35: manager.v1.GatewayStatus.last_seen:type_name -> google.protobuf.Timestamp
-	31, // 36: manager.v1.GatewayStatus.created_at:type_name -> google.protobuf.Timestamp
-	29, // 37: manager.v1.GatewayStatus.servers:type_name -> manager.v1.SystemStatusServerEntry
-	31, // 38: manager.v1.SystemStatusServerEntry.created_at:type_name -> google.protobuf.Timestamp
-	31, // 39: manager.v1.SystemStatusServerEntry.updated_at:type_name -> google.protobuf.Timestamp
-	32, // 40: manager.v1.SystemStatusServerEntry.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
-	33, // 41: manager.v1.SystemStatusServerEntry.primary_protocol:type_name -> common.v1.BMCType
-	4,  // 42: manager.v1.BMCManagerService.Authenticate:input_type -> manager.v1.AuthenticateRequest
-	6,  // 43: manager.v1.BMCManagerService.RefreshToken:input_type -> manager.v1.RefreshTokenRequest
-	8,  // 44: manager.v1.BMCManagerService.GetServerToken:input_type -> manager.v1.GetServerTokenRequest
-	10, // 45: manager.v1.BMCManagerService.RegisterServer:input_type -> manager.v1.RegisterServerRequest
-	16, // 46: manager.v1.BMCManagerService.GetServerLocation:input_type -> manager.v1.GetServerLocationRequest
-	18, // 47: manager.v1.BMCManagerService.RegisterGateway:input_type -> manager.v1.RegisterGatewayRequest
-	20, // 48: manager.v1.BMCManagerService.ListGateways:input_type -> manager.v1.ListGatewaysRequest
-	25, // 49: manager.v1.BMCManagerService.GetSystemStatus:input_type -> manager.v1.GetSystemStatusRequest
-	12, // 50: manager.v1.BMCManagerService.GetServer:input_type -> manager.v1.GetServerRequest
-	14, // 51: manager.v1.BMCManagerService.ListServers:input_type -> manager.v1.ListServersRequest
-	22, // 52: manager.v1.BMCManagerService.ReportAvailableEndpoints:input_type -> manager.v1.ReportAvailableEndpointsRequest
-	5,  // 53: manager.v1.BMCManagerService.Authenticate:output_type -> manager.v1.AuthenticateResponse
-	7,  // 54: manager.v1.BMCManagerService.RefreshToken:output_type -> manager.v1.RefreshTokenResponse
-	9,  // 55: manager.v1.BMCManagerService.GetServerToken:output_type -> manager.v1.GetServerTokenResponse
-	11, // 56: manager.v1.BMCManagerService.RegisterServer:output_type -> manager.v1.RegisterServerResponse
-	17, // 57: manager.v1.BMCManagerService.GetServerLocation:output_type -> manager.v1.GetServerLocationResponse
-	19, // 58: manager.v1.BMCManagerService.RegisterGateway:output_type -> manager.v1.RegisterGatewayResponse
-	21, // 59: manager.v1.BMCManagerService.ListGateways:output_type -> manager.v1.ListGatewaysResponse
-	26, // 60: manager.v1.BMCManagerService.GetSystemStatus:output_type -> manager.v1.GetSystemStatusResponse
-	13, // 61: manager.v1.BMCManagerService.GetServer:output_type -> manager.v1.GetServerResponse
-	15, // 62: manager.v1.BMCManagerService.ListServers:output_type -> manager.v1.ListServersResponse
-	24, // 63: manager.v1.BMCManagerService.ReportAvailableEndpoints:output_type -> manager.v1.ReportAvailableEndpointsResponse
-	53, // [53:64] is the sub-list for method output_type
-	42, // [42:53] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	56, // 23: manager.v1.GetServerLocationResponse.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
+	57, // 24: manager.v1.GetServerLocationResponse.primary_protocol:type_name -> common.v1.BMCType
+	30, // 25: manager.v1.RegisterGatewayResponse.token_revocations:type_name -> manager.v1.TokenRevocation
+	55, // 26: manager.v1.TokenRevocation.revoked_at:type_name -> google.protobuf.Timestamp
+	2,  // 27: manager.v1.ListGatewaysResponse.gateways:type_name -> manager.v1.RegionalGateway
+	34, // 28: manager.v1.ReportAvailableEndpointsRequest.bmc_endpoints:type_name -> manager.v1.BMCEndpointAvailability
+	57, // 29: manager.v1.BMCEndpointAvailability.bmc_type:type_name -> common.v1.BMCType
+	55, // 30: manager.v1.BMCEndpointAvailability.last_seen:type_name -> google.protobuf.Timestamp
+	60, // 31: manager.v1.BMCEndpointAvailability.discovery_metadata:type_name -> common.v1.DiscoveryMetadata
+	37, // 32: manager.v1.ReportConsoleSLIsRequest.sessions:type_name -> manager.v1.ConsoleSessionSLI
+	55, // 33: manager.v1.ConsoleSessionSLI.started_at:type_name -> google.protobuf.Timestamp
+	40, // 34: manager.v1.ReportEventsRequest.events:type_name -> manager.v1.SystemEvent
+	55, // 35: manager.v1.SystemEvent.time:type_name -> google.protobuf.Timestamp
+	61, // 36: manager.v1.SystemEvent.data:type_name -> google.protobuf.Struct
+	43, // 37: manager.v1.ReportPowerReadingsRequest.samples:type_name -> manager.v1.PowerSample
+	55, // 38: manager.v1.PowerSample.sampled_at:type_name -> google.protobuf.Timestamp
+	49, // 39: manager.v1.GetSystemStatusResponse.status:type_name -> manager.v1.SystemStatus
+	55, // 40: manager.v1.SystemStatus.started_at:type_name -> google.protobuf.Timestamp
+	55, // 41: manager.v1.SystemStatus.status_time:type_name -> google.protobuf.Timestamp
+	50, // 42: manager.v1.SystemStatus.gateways:type_name -> manager.v1.GatewayStatus
+	53, // 43: manager.v1.SystemStatus.servers:type_name -> manager.v1.SystemStatusServerEntry
+	55, // 44: manager.v1.GatewayStatus.last_seen:type_name -> google.protobuf.Timestamp
+	55, // 45: manager.v1.GatewayStatus.created_at:type_name -> google.protobuf.Timestamp
+	53, // 46: manager.v1.GatewayStatus.servers:type_name -> manager.v1.SystemStatusServerEntry
+	51, // 47: manager.v1.GatewayStatus.live:type_name -> manager.v1.GatewayLiveStatus
+	55, // 48: manager.v1.GatewayLiveStatus.polled_at:type_name -> google.protobuf.Timestamp
+	52, // 49: manager.v1.GatewayLiveStatus.agents:type_name -> manager.v1.GatewayAgentHealth
+	55, // 50: manager.v1.GatewayAgentHealth.last_seen:type_name -> google.protobuf.Timestamp
+	55, // 51: manager.v1.SystemStatusServerEntry.created_at:type_name -> google.protobuf.Timestamp
+	55, // 52: manager.v1.SystemStatusServerEntry.updated_at:type_name -> google.protobuf.Timestamp
+	56, // 53: manager.v1.SystemStatusServerEntry.bmc_protocols:type_name -> common.v1.BMCControlEndpoint
+	57, // 54: manager.v1.SystemStatusServerEntry.primary_protocol:type_name -> common.v1.BMCType
+	4,  // 55: manager.v1.BMCManagerService.Authenticate:input_type -> manager.v1.AuthenticateRequest
+	6,  // 56: manager.v1.BMCManagerService.RefreshToken:input_type -> manager.v1.RefreshTokenRequest
+	8,  // 57: manager.v1.BMCManagerService.ChangePassword:input_type -> manager.v1.ChangePasswordRequest
+	10, // 58: manager.v1.BMCManagerService.ResetPassword:input_type -> manager.v1.ResetPasswordRequest
+	12, // 59: manager.v1.BMCManagerService.EnrollTOTP:input_type -> manager.v1.EnrollTOTPRequest
+	14, // 60: manager.v1.BMCManagerService.ConfirmTOTP:input_type -> manager.v1.ConfirmTOTPRequest
+	16, // 61: manager.v1.BMCManagerService.DisableTOTP:input_type -> manager.v1.DisableTOTPRequest
+	18, // 62: manager.v1.BMCManagerService.GetServerToken:input_type -> manager.v1.GetServerTokenRequest
+	20, // 63: manager.v1.BMCManagerService.RegisterServer:input_type -> manager.v1.RegisterServerRequest
+	26, // 64: manager.v1.BMCManagerService.GetServerLocation:input_type -> manager.v1.GetServerLocationRequest
+	28, // 65: manager.v1.BMCManagerService.RegisterGateway:input_type -> manager.v1.RegisterGatewayRequest
+	31, // 66: manager.v1.BMCManagerService.ListGateways:input_type -> manager.v1.ListGatewaysRequest
+	47, // 67: manager.v1.BMCManagerService.GetSystemStatus:input_type -> manager.v1.GetSystemStatusRequest
+	22, // 68: manager.v1.BMCManagerService.GetServer:input_type -> manager.v1.GetServerRequest
+	24, // 69: manager.v1.BMCManagerService.ListServers:input_type -> manager.v1.ListServersRequest
+	33, // 70: manager.v1.BMCManagerService.ReportAvailableEndpoints:input_type -> manager.v1.ReportAvailableEndpointsRequest
+	36, // 71: manager.v1.BMCManagerService.ReportConsoleSLIs:input_type -> manager.v1.ReportConsoleSLIsRequest
+	39, // 72: manager.v1.BMCManagerService.ReportEvents:input_type -> manager.v1.ReportEventsRequest
+	42, // 73: manager.v1.BMCManagerService.ReportPowerReadings:input_type -> manager.v1.ReportPowerReadingsRequest
+	45, // 74: manager.v1.BMCManagerService.ValidateSession:input_type -> manager.v1.ValidateSessionRequest
+	5,  // 75: manager.v1.BMCManagerService.Authenticate:output_type -> manager.v1.AuthenticateResponse
+	7,  // 76: manager.v1.BMCManagerService.RefreshToken:output_type -> manager.v1.RefreshTokenResponse
+	9,  // 77: manager.v1.BMCManagerService.ChangePassword:output_type -> manager.v1.ChangePasswordResponse
+	11, // 78: manager.v1.BMCManagerService.ResetPassword:output_type -> manager.v1.ResetPasswordResponse
+	13, // 79: manager.v1.BMCManagerService.EnrollTOTP:output_type -> manager.v1.EnrollTOTPResponse
+	15, // 80: manager.v1.BMCManagerService.ConfirmTOTP:output_type -> manager.v1.ConfirmTOTPResponse
+	17, // 81: manager.v1.BMCManagerService.DisableTOTP:output_type -> manager.v1.DisableTOTPResponse
+	19, // 82: manager.v1.BMCManagerService.GetServerToken:output_type -> manager.v1.GetServerTokenResponse
+	21, // 83: manager.v1.BMCManagerService.RegisterServer:output_type -> manager.v1.RegisterServerResponse
+	27, // 84: manager.v1.BMCManagerService.GetServerLocation:output_type -> manager.v1.GetServerLocationResponse
+	29, // 85: manager.v1.BMCManagerService.RegisterGateway:output_type -> manager.v1.RegisterGatewayResponse
+	32, // 86: manager.v1.BMCManagerService.ListGateways:output_type -> manager.v1.ListGatewaysResponse
+	48, // 87: manager.v1.BMCManagerService.GetSystemStatus:output_type -> manager.v1.GetSystemStatusResponse
+	23, // 88: manager.v1.BMCManagerService.GetServer:output_type -> manager.v1.GetServerResponse
+	25, // 89: manager.v1.BMCManagerService.ListServers:output_type -> manager.v1.ListServersResponse
+	35, // 90: manager.v1.BMCManagerService.ReportAvailableEndpoints:output_type -> manager.v1.ReportAvailableEndpointsResponse
+	38, // 91: manager.v1.BMCManagerService.ReportConsoleSLIs:output_type -> manager.v1.ReportConsoleSLIsResponse
+	41, // 92: manager.v1.BMCManagerService.ReportEvents:output_type -> manager.v1.ReportEventsResponse
+	44, // 93: manager.v1.BMCManagerService.ReportPowerReadings:output_type -> manager.v1.ReportPowerReadingsResponse
+	46, // 94: manager.v1.BMCManagerService.ValidateSession:output_type -> manager.v1.ValidateSessionResponse
+	75, // [75:95] is the sub-list for method output_type
+	55, // [55:75] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
 }
 
 func init() { file_manager_v1_manager_proto_init() }
@@ -2407,7 +4114,7 @@ func file_manager_v1_manager_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_manager_v1_manager_proto_rawDesc), len(file_manager_v1_manager_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -53,6 +53,90 @@ const (
 	// AdminServiceLaunchSOLSessionProcedure is the fully-qualified name of the AdminService's
 	// LaunchSOLSession RPC.
 	AdminServiceLaunchSOLSessionProcedure = "/manager.v1.AdminService/LaunchSOLSession"
+	// AdminServiceGetConsoleSLOReportProcedure is the fully-qualified name of the AdminService's
+	// GetConsoleSLOReport RPC.
+	AdminServiceGetConsoleSLOReportProcedure = "/manager.v1.AdminService/GetConsoleSLOReport"
+	// AdminServiceGetPowerUsageReportProcedure is the fully-qualified name of the AdminService's
+	// GetPowerUsageReport RPC.
+	AdminServiceGetPowerUsageReportProcedure = "/manager.v1.AdminService/GetPowerUsageReport"
+	// AdminServiceListConsoleSessionsProcedure is the fully-qualified name of the AdminService's
+	// ListConsoleSessions RPC.
+	AdminServiceListConsoleSessionsProcedure = "/manager.v1.AdminService/ListConsoleSessions"
+	// AdminServiceTerminateConsoleSessionProcedure is the fully-qualified name of the AdminService's
+	// TerminateConsoleSession RPC.
+	AdminServiceTerminateConsoleSessionProcedure = "/manager.v1.AdminService/TerminateConsoleSession"
+	// AdminServiceGetTopologyProcedure is the fully-qualified name of the AdminService's GetTopology
+	// RPC.
+	AdminServiceGetTopologyProcedure = "/manager.v1.AdminService/GetTopology"
+	// AdminServiceListServerEventsProcedure is the fully-qualified name of the AdminService's
+	// ListServerEvents RPC.
+	AdminServiceListServerEventsProcedure = "/manager.v1.AdminService/ListServerEvents"
+	// AdminServiceSetServerMaintenanceProcedure is the fully-qualified name of the AdminService's
+	// SetServerMaintenance RPC.
+	AdminServiceSetServerMaintenanceProcedure = "/manager.v1.AdminService/SetServerMaintenance"
+	// AdminServiceSetServerNotesProcedure is the fully-qualified name of the AdminService's
+	// SetServerNotes RPC.
+	AdminServiceSetServerNotesProcedure = "/manager.v1.AdminService/SetServerNotes"
+	// AdminServiceCreateMaintenanceWindowProcedure is the fully-qualified name of the AdminService's
+	// CreateMaintenanceWindow RPC.
+	AdminServiceCreateMaintenanceWindowProcedure = "/manager.v1.AdminService/CreateMaintenanceWindow"
+	// AdminServiceListMaintenanceWindowsProcedure is the fully-qualified name of the AdminService's
+	// ListMaintenanceWindows RPC.
+	AdminServiceListMaintenanceWindowsProcedure = "/manager.v1.AdminService/ListMaintenanceWindows"
+	// AdminServiceDeleteMaintenanceWindowProcedure is the fully-qualified name of the AdminService's
+	// DeleteMaintenanceWindow RPC.
+	AdminServiceDeleteMaintenanceWindowProcedure = "/manager.v1.AdminService/DeleteMaintenanceWindow"
+	// AdminServiceSetCustomerSessionQuotaProcedure is the fully-qualified name of the AdminService's
+	// SetCustomerSessionQuota RPC.
+	AdminServiceSetCustomerSessionQuotaProcedure = "/manager.v1.AdminService/SetCustomerSessionQuota"
+	// AdminServiceSetCustomerMFAPolicyProcedure is the fully-qualified name of the AdminService's
+	// SetCustomerMFAPolicy RPC.
+	AdminServiceSetCustomerMFAPolicyProcedure = "/manager.v1.AdminService/SetCustomerMFAPolicy"
+	// AdminServiceSetCustomerIPAllowlistProcedure is the fully-qualified name of the AdminService's
+	// SetCustomerIPAllowlist RPC.
+	AdminServiceSetCustomerIPAllowlistProcedure = "/manager.v1.AdminService/SetCustomerIPAllowlist"
+	// AdminServiceExportUsageProcedure is the fully-qualified name of the AdminService's ExportUsage
+	// RPC.
+	AdminServiceExportUsageProcedure = "/manager.v1.AdminService/ExportUsage"
+	// AdminServiceApproveGatewayProcedure is the fully-qualified name of the AdminService's
+	// ApproveGateway RPC.
+	AdminServiceApproveGatewayProcedure = "/manager.v1.AdminService/ApproveGateway"
+	// AdminServiceCreateCustomerProcedure is the fully-qualified name of the AdminService's
+	// CreateCustomer RPC.
+	AdminServiceCreateCustomerProcedure = "/manager.v1.AdminService/CreateCustomer"
+	// AdminServiceIssueAPIKeyProcedure is the fully-qualified name of the AdminService's IssueAPIKey
+	// RPC.
+	AdminServiceIssueAPIKeyProcedure = "/manager.v1.AdminService/IssueAPIKey"
+	// AdminServiceResetCustomerPasswordProcedure is the fully-qualified name of the AdminService's
+	// ResetCustomerPassword RPC.
+	AdminServiceResetCustomerPasswordProcedure = "/manager.v1.AdminService/ResetCustomerPassword"
+	// AdminServiceDisableCustomerProcedure is the fully-qualified name of the AdminService's
+	// DisableCustomer RPC.
+	AdminServiceDisableCustomerProcedure = "/manager.v1.AdminService/DisableCustomer"
+	// AdminServiceDeleteCustomerProcedure is the fully-qualified name of the AdminService's
+	// DeleteCustomer RPC.
+	AdminServiceDeleteCustomerProcedure = "/manager.v1.AdminService/DeleteCustomer"
+	// AdminServiceAssignServerProcedure is the fully-qualified name of the AdminService's AssignServer
+	// RPC.
+	AdminServiceAssignServerProcedure = "/manager.v1.AdminService/AssignServer"
+	// AdminServiceImportServersProcedure is the fully-qualified name of the AdminService's
+	// ImportServers RPC.
+	AdminServiceImportServersProcedure = "/manager.v1.AdminService/ImportServers"
+	// AdminServiceExportServersProcedure is the fully-qualified name of the AdminService's
+	// ExportServers RPC.
+	AdminServiceExportServersProcedure = "/manager.v1.AdminService/ExportServers"
+	// AdminServiceListAuditEventsProcedure is the fully-qualified name of the AdminService's
+	// ListAuditEvents RPC.
+	AdminServiceListAuditEventsProcedure = "/manager.v1.AdminService/ListAuditEvents"
+	// AdminServiceListConnectivityAuditsProcedure is the fully-qualified name of the AdminService's
+	// ListConnectivityAudits RPC.
+	AdminServiceListConnectivityAuditsProcedure = "/manager.v1.AdminService/ListConnectivityAudits"
+	// AdminServiceRunConnectivityAuditProcedure is the fully-qualified name of the AdminService's
+	// RunConnectivityAudit RPC.
+	AdminServiceRunConnectivityAuditProcedure = "/manager.v1.AdminService/RunConnectivityAudit"
+	// AdminServiceSetLogLevelProcedure is the fully-qualified name of the AdminService's SetLogLevel
+	// RPC.
+	AdminServiceSetLogLevelProcedure = "/manager.v1.AdminService/SetLogLevel"
 )
 
 // AdminServiceClient is a client for the manager.v1.AdminService service.
@@ -67,9 +151,69 @@ type AdminServiceClient interface {
 	GetGatewayHealth(context.Context, *connect.Request[v1.GetGatewayHealthRequest]) (*connect.Response[v1.GetGatewayHealthResponse], error)
 	// Available regions for filtering
 	GetRegions(context.Context, *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error)
-	// VNC/SOL session management for admin console
+	// VNC/SOL sessions admins open on behalf of the server's customer, audited
 	LaunchVNCSession(context.Context, *connect.Request[v1.LaunchSessionRequest]) (*connect.Response[v1.LaunchSessionResponse], error)
 	LaunchSOLSession(context.Context, *connect.Request[v1.LaunchSessionRequest]) (*connect.Response[v1.LaunchSessionResponse], error)
+	// Console availability SLO and error budget reporting
+	GetConsoleSLOReport(context.Context, *connect.Request[v1.GetConsoleSLOReportRequest]) (*connect.Response[v1.GetConsoleSLOReportResponse], error)
+	// Energy usage per customer and server, for chargeback
+	GetPowerUsageReport(context.Context, *connect.Request[v1.GetPowerUsageReportRequest]) (*connect.Response[v1.GetPowerUsageReportResponse], error)
+	// Open console sessions across all gateways, and forced termination
+	ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error)
+	TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error)
+	// Live topology: gateways, their agents and BMC endpoints, and open console sessions
+	GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error)
+	// Recent system events of a server, for its detail page
+	ListServerEvents(context.Context, *connect.Request[v1.ListServerEventsRequest]) (*connect.Response[v1.ListServerEventsResponse], error)
+	// Maintenance mode and operator notes of a server. Customer power operations
+	// are blocked while a server is in maintenance, admins may still run them.
+	SetServerMaintenance(context.Context, *connect.Request[v1.SetServerMaintenanceRequest]) (*connect.Response[v1.SetServerMaintenanceResponse], error)
+	SetServerNotes(context.Context, *connect.Request[v1.SetServerNotesRequest]) (*connect.Response[v1.SetServerNotesResponse], error)
+	// Scheduled maintenance windows of servers or groups of servers, during
+	// which destructive power operations are rejected or require an override
+	CreateMaintenanceWindow(context.Context, *connect.Request[v1.CreateMaintenanceWindowRequest]) (*connect.Response[v1.CreateMaintenanceWindowResponse], error)
+	ListMaintenanceWindows(context.Context, *connect.Request[v1.ListMaintenanceWindowsRequest]) (*connect.Response[v1.ListMaintenanceWindowsResponse], error)
+	DeleteMaintenanceWindow(context.Context, *connect.Request[v1.DeleteMaintenanceWindowRequest]) (*connect.Response[v1.DeleteMaintenanceWindowResponse], error)
+	// Console session limits of a customer, enforced by gateways
+	SetCustomerSessionQuota(context.Context, *connect.Request[v1.SetCustomerSessionQuotaRequest]) (*connect.Response[v1.SetCustomerSessionQuotaResponse], error)
+	// Whether console sessions of a customer require two-factor
+	// authentication, enforced by gateways
+	SetCustomerMFAPolicy(context.Context, *connect.Request[v1.SetCustomerMFAPolicyRequest]) (*connect.Response[v1.SetCustomerMFAPolicyResponse], error)
+	// Source IP ranges a customer accesses BMCs from, enforced by gateways
+	SetCustomerIPAllowlist(context.Context, *connect.Request[v1.SetCustomerIPAllowlistRequest]) (*connect.Response[v1.SetCustomerIPAllowlistResponse], error)
+	// Console minutes, power operations and data transferred per customer over
+	// a month, exported as CSV or JSON for billing
+	ExportUsage(context.Context, *connect.Request[v1.ExportUsageRequest]) (*connect.Response[v1.ExportUsageResponse], error)
+	// Approval of gateways registering while the manager requires it. Pending
+	// gateways are not listed to clients and their BMC endpoints are ignored.
+	ApproveGateway(context.Context, *connect.Request[v1.ApproveGatewayRequest]) (*connect.Response[v1.ApproveGatewayResponse], error)
+	// Customer accounts and their API keys
+	CreateCustomer(context.Context, *connect.Request[v1.CreateCustomerRequest]) (*connect.Response[v1.CreateCustomerResponse], error)
+	IssueAPIKey(context.Context, *connect.Request[v1.IssueAPIKeyRequest]) (*connect.Response[v1.IssueAPIKeyResponse], error)
+	// Issue a one-time token letting a customer set a new password with
+	// ResetPassword, e.g. when forgotten or locked out
+	ResetCustomerPassword(context.Context, *connect.Request[v1.ResetCustomerPasswordRequest]) (*connect.Response[v1.ResetCustomerPasswordResponse], error)
+	// Revocation of customer accounts. Both revoke the customer's tokens and
+	// API key, and terminate its console sessions on the gateways.
+	DisableCustomer(context.Context, *connect.Request[v1.DisableCustomerRequest]) (*connect.Response[v1.DisableCustomerResponse], error)
+	DeleteCustomer(context.Context, *connect.Request[v1.DeleteCustomerRequest]) (*connect.Response[v1.DeleteCustomerResponse], error)
+	// Assignment of a server to the customer owning it
+	AssignServer(context.Context, *connect.Request[v1.AssignServerRequest]) (*connect.Response[v1.AssignServerResponse], error)
+	// Bulk registration of servers from an inventory file, CSV or YAML, and
+	// export of the inventory in the same format. Each row is imported on its
+	// own and reported with its error; dry runs validate without saving.
+	ImportServers(context.Context, *connect.Request[v1.ImportServersRequest]) (*connect.Response[v1.ImportServersResponse], error)
+	ExportServers(context.Context, *connect.Request[v1.ExportServersRequest]) (*connect.Response[v1.ExportServersResponse], error)
+	// Audit log of the changes made by admins
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	// Connectivity audits of BMCs: the manager periodically checks the
+	// credentials and console endpoints of every server through its gateway
+	// and agent. RunConnectivityAudit audits one server now.
+	ListConnectivityAudits(context.Context, *connect.Request[v1.ListConnectivityAuditsRequest]) (*connect.Response[v1.ListConnectivityAuditsResponse], error)
+	RunConnectivityAudit(context.Context, *connect.Request[v1.RunConnectivityAuditRequest]) (*connect.Response[v1.RunConnectivityAuditResponse], error)
+	// Log levels of the manager, changed at runtime without restarting. An
+	// empty request returns the current levels.
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewAdminServiceClient constructs a client for the manager.v1.AdminService service. By default, it
@@ -125,18 +269,214 @@ func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(adminServiceMethods.ByName("LaunchSOLSession")),
 			connect.WithClientOptions(opts...),
 		),
+		getConsoleSLOReport: connect.NewClient[v1.GetConsoleSLOReportRequest, v1.GetConsoleSLOReportResponse](
+			httpClient,
+			baseURL+AdminServiceGetConsoleSLOReportProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetConsoleSLOReport")),
+			connect.WithClientOptions(opts...),
+		),
+		getPowerUsageReport: connect.NewClient[v1.GetPowerUsageReportRequest, v1.GetPowerUsageReportResponse](
+			httpClient,
+			baseURL+AdminServiceGetPowerUsageReportProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetPowerUsageReport")),
+			connect.WithClientOptions(opts...),
+		),
+		listConsoleSessions: connect.NewClient[v1.ListConsoleSessionsRequest, v1.ListConsoleSessionsResponse](
+			httpClient,
+			baseURL+AdminServiceListConsoleSessionsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListConsoleSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		terminateConsoleSession: connect.NewClient[v1.TerminateConsoleSessionRequest, v1.TerminateConsoleSessionResponse](
+			httpClient,
+			baseURL+AdminServiceTerminateConsoleSessionProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("TerminateConsoleSession")),
+			connect.WithClientOptions(opts...),
+		),
+		getTopology: connect.NewClient[v1.GetTopologyRequest, v1.GetTopologyResponse](
+			httpClient,
+			baseURL+AdminServiceGetTopologyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetTopology")),
+			connect.WithClientOptions(opts...),
+		),
+		listServerEvents: connect.NewClient[v1.ListServerEventsRequest, v1.ListServerEventsResponse](
+			httpClient,
+			baseURL+AdminServiceListServerEventsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListServerEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		setServerMaintenance: connect.NewClient[v1.SetServerMaintenanceRequest, v1.SetServerMaintenanceResponse](
+			httpClient,
+			baseURL+AdminServiceSetServerMaintenanceProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetServerMaintenance")),
+			connect.WithClientOptions(opts...),
+		),
+		setServerNotes: connect.NewClient[v1.SetServerNotesRequest, v1.SetServerNotesResponse](
+			httpClient,
+			baseURL+AdminServiceSetServerNotesProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetServerNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		createMaintenanceWindow: connect.NewClient[v1.CreateMaintenanceWindowRequest, v1.CreateMaintenanceWindowResponse](
+			httpClient,
+			baseURL+AdminServiceCreateMaintenanceWindowProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("CreateMaintenanceWindow")),
+			connect.WithClientOptions(opts...),
+		),
+		listMaintenanceWindows: connect.NewClient[v1.ListMaintenanceWindowsRequest, v1.ListMaintenanceWindowsResponse](
+			httpClient,
+			baseURL+AdminServiceListMaintenanceWindowsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListMaintenanceWindows")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteMaintenanceWindow: connect.NewClient[v1.DeleteMaintenanceWindowRequest, v1.DeleteMaintenanceWindowResponse](
+			httpClient,
+			baseURL+AdminServiceDeleteMaintenanceWindowProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("DeleteMaintenanceWindow")),
+			connect.WithClientOptions(opts...),
+		),
+		setCustomerSessionQuota: connect.NewClient[v1.SetCustomerSessionQuotaRequest, v1.SetCustomerSessionQuotaResponse](
+			httpClient,
+			baseURL+AdminServiceSetCustomerSessionQuotaProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetCustomerSessionQuota")),
+			connect.WithClientOptions(opts...),
+		),
+		setCustomerMFAPolicy: connect.NewClient[v1.SetCustomerMFAPolicyRequest, v1.SetCustomerMFAPolicyResponse](
+			httpClient,
+			baseURL+AdminServiceSetCustomerMFAPolicyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetCustomerMFAPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		setCustomerIPAllowlist: connect.NewClient[v1.SetCustomerIPAllowlistRequest, v1.SetCustomerIPAllowlistResponse](
+			httpClient,
+			baseURL+AdminServiceSetCustomerIPAllowlistProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetCustomerIPAllowlist")),
+			connect.WithClientOptions(opts...),
+		),
+		exportUsage: connect.NewClient[v1.ExportUsageRequest, v1.ExportUsageResponse](
+			httpClient,
+			baseURL+AdminServiceExportUsageProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ExportUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		approveGateway: connect.NewClient[v1.ApproveGatewayRequest, v1.ApproveGatewayResponse](
+			httpClient,
+			baseURL+AdminServiceApproveGatewayProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ApproveGateway")),
+			connect.WithClientOptions(opts...),
+		),
+		createCustomer: connect.NewClient[v1.CreateCustomerRequest, v1.CreateCustomerResponse](
+			httpClient,
+			baseURL+AdminServiceCreateCustomerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("CreateCustomer")),
+			connect.WithClientOptions(opts...),
+		),
+		issueAPIKey: connect.NewClient[v1.IssueAPIKeyRequest, v1.IssueAPIKeyResponse](
+			httpClient,
+			baseURL+AdminServiceIssueAPIKeyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("IssueAPIKey")),
+			connect.WithClientOptions(opts...),
+		),
+		resetCustomerPassword: connect.NewClient[v1.ResetCustomerPasswordRequest, v1.ResetCustomerPasswordResponse](
+			httpClient,
+			baseURL+AdminServiceResetCustomerPasswordProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ResetCustomerPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		disableCustomer: connect.NewClient[v1.DisableCustomerRequest, v1.DisableCustomerResponse](
+			httpClient,
+			baseURL+AdminServiceDisableCustomerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("DisableCustomer")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteCustomer: connect.NewClient[v1.DeleteCustomerRequest, v1.DeleteCustomerResponse](
+			httpClient,
+			baseURL+AdminServiceDeleteCustomerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("DeleteCustomer")),
+			connect.WithClientOptions(opts...),
+		),
+		assignServer: connect.NewClient[v1.AssignServerRequest, v1.AssignServerResponse](
+			httpClient,
+			baseURL+AdminServiceAssignServerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("AssignServer")),
+			connect.WithClientOptions(opts...),
+		),
+		importServers: connect.NewClient[v1.ImportServersRequest, v1.ImportServersResponse](
+			httpClient,
+			baseURL+AdminServiceImportServersProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ImportServers")),
+			connect.WithClientOptions(opts...),
+		),
+		exportServers: connect.NewClient[v1.ExportServersRequest, v1.ExportServersResponse](
+			httpClient,
+			baseURL+AdminServiceExportServersProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ExportServers")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+AdminServiceListAuditEventsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		listConnectivityAudits: connect.NewClient[v1.ListConnectivityAuditsRequest, v1.ListConnectivityAuditsResponse](
+			httpClient,
+			baseURL+AdminServiceListConnectivityAuditsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListConnectivityAudits")),
+			connect.WithClientOptions(opts...),
+		),
+		runConnectivityAudit: connect.NewClient[v1.RunConnectivityAuditRequest, v1.RunConnectivityAuditResponse](
+			httpClient,
+			baseURL+AdminServiceRunConnectivityAuditProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RunConnectivityAudit")),
+			connect.WithClientOptions(opts...),
+		),
+		setLogLevel: connect.NewClient[v1.SetLogLevelRequest, v1.SetLogLevelResponse](
+			httpClient,
+			baseURL+AdminServiceSetLogLevelProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetLogLevel")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // adminServiceClient implements AdminServiceClient.
 type adminServiceClient struct {
-	getDashboardMetrics *connect.Client[v1.GetDashboardMetricsRequest, v1.GetDashboardMetricsResponse]
-	listAllServers      *connect.Client[v1.ListAllServersRequest, v1.ListAllServersResponse]
-	listAllCustomers    *connect.Client[v1.ListAllCustomersRequest, v1.ListAllCustomersResponse]
-	getGatewayHealth    *connect.Client[v1.GetGatewayHealthRequest, v1.GetGatewayHealthResponse]
-	getRegions          *connect.Client[v1.GetRegionsRequest, v1.GetRegionsResponse]
-	launchVNCSession    *connect.Client[v1.LaunchSessionRequest, v1.LaunchSessionResponse]
-	launchSOLSession    *connect.Client[v1.LaunchSessionRequest, v1.LaunchSessionResponse]
+	getDashboardMetrics     *connect.Client[v1.GetDashboardMetricsRequest, v1.GetDashboardMetricsResponse]
+	listAllServers          *connect.Client[v1.ListAllServersRequest, v1.ListAllServersResponse]
+	listAllCustomers        *connect.Client[v1.ListAllCustomersRequest, v1.ListAllCustomersResponse]
+	getGatewayHealth        *connect.Client[v1.GetGatewayHealthRequest, v1.GetGatewayHealthResponse]
+	getRegions              *connect.Client[v1.GetRegionsRequest, v1.GetRegionsResponse]
+	launchVNCSession        *connect.Client[v1.LaunchSessionRequest, v1.LaunchSessionResponse]
+	launchSOLSession        *connect.Client[v1.LaunchSessionRequest, v1.LaunchSessionResponse]
+	getConsoleSLOReport     *connect.Client[v1.GetConsoleSLOReportRequest, v1.GetConsoleSLOReportResponse]
+	getPowerUsageReport     *connect.Client[v1.GetPowerUsageReportRequest, v1.GetPowerUsageReportResponse]
+	listConsoleSessions     *connect.Client[v1.ListConsoleSessionsRequest, v1.ListConsoleSessionsResponse]
+	terminateConsoleSession *connect.Client[v1.TerminateConsoleSessionRequest, v1.TerminateConsoleSessionResponse]
+	getTopology             *connect.Client[v1.GetTopologyRequest, v1.GetTopologyResponse]
+	listServerEvents        *connect.Client[v1.ListServerEventsRequest, v1.ListServerEventsResponse]
+	setServerMaintenance    *connect.Client[v1.SetServerMaintenanceRequest, v1.SetServerMaintenanceResponse]
+	setServerNotes          *connect.Client[v1.SetServerNotesRequest, v1.SetServerNotesResponse]
+	createMaintenanceWindow *connect.Client[v1.CreateMaintenanceWindowRequest, v1.CreateMaintenanceWindowResponse]
+	listMaintenanceWindows  *connect.Client[v1.ListMaintenanceWindowsRequest, v1.ListMaintenanceWindowsResponse]
+	deleteMaintenanceWindow *connect.Client[v1.DeleteMaintenanceWindowRequest, v1.DeleteMaintenanceWindowResponse]
+	setCustomerSessionQuota *connect.Client[v1.SetCustomerSessionQuotaRequest, v1.SetCustomerSessionQuotaResponse]
+	setCustomerMFAPolicy    *connect.Client[v1.SetCustomerMFAPolicyRequest, v1.SetCustomerMFAPolicyResponse]
+	setCustomerIPAllowlist  *connect.Client[v1.SetCustomerIPAllowlistRequest, v1.SetCustomerIPAllowlistResponse]
+	exportUsage             *connect.Client[v1.ExportUsageRequest, v1.ExportUsageResponse]
+	approveGateway          *connect.Client[v1.ApproveGatewayRequest, v1.ApproveGatewayResponse]
+	createCustomer          *connect.Client[v1.CreateCustomerRequest, v1.CreateCustomerResponse]
+	issueAPIKey             *connect.Client[v1.IssueAPIKeyRequest, v1.IssueAPIKeyResponse]
+	resetCustomerPassword   *connect.Client[v1.ResetCustomerPasswordRequest, v1.ResetCustomerPasswordResponse]
+	disableCustomer         *connect.Client[v1.DisableCustomerRequest, v1.DisableCustomerResponse]
+	deleteCustomer          *connect.Client[v1.DeleteCustomerRequest, v1.DeleteCustomerResponse]
+	assignServer            *connect.Client[v1.AssignServerRequest, v1.AssignServerResponse]
+	importServers           *connect.Client[v1.ImportServersRequest, v1.ImportServersResponse]
+	exportServers           *connect.Client[v1.ExportServersRequest, v1.ExportServersResponse]
+	listAuditEvents         *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
+	listConnectivityAudits  *connect.Client[v1.ListConnectivityAuditsRequest, v1.ListConnectivityAuditsResponse]
+	runConnectivityAudit    *connect.Client[v1.RunConnectivityAuditRequest, v1.RunConnectivityAuditResponse]
+	setLogLevel             *connect.Client[v1.SetLogLevelRequest, v1.SetLogLevelResponse]
 }
 
 // GetDashboardMetrics calls manager.v1.AdminService.GetDashboardMetrics.
@@ -174,6 +514,146 @@ func (c *adminServiceClient) LaunchSOLSession(ctx context.Context, req *connect.
 	return c.launchSOLSession.CallUnary(ctx, req)
 }
 
+// GetConsoleSLOReport calls manager.v1.AdminService.GetConsoleSLOReport.
+func (c *adminServiceClient) GetConsoleSLOReport(ctx context.Context, req *connect.Request[v1.GetConsoleSLOReportRequest]) (*connect.Response[v1.GetConsoleSLOReportResponse], error) {
+	return c.getConsoleSLOReport.CallUnary(ctx, req)
+}
+
+// GetPowerUsageReport calls manager.v1.AdminService.GetPowerUsageReport.
+func (c *adminServiceClient) GetPowerUsageReport(ctx context.Context, req *connect.Request[v1.GetPowerUsageReportRequest]) (*connect.Response[v1.GetPowerUsageReportResponse], error) {
+	return c.getPowerUsageReport.CallUnary(ctx, req)
+}
+
+// ListConsoleSessions calls manager.v1.AdminService.ListConsoleSessions.
+func (c *adminServiceClient) ListConsoleSessions(ctx context.Context, req *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error) {
+	return c.listConsoleSessions.CallUnary(ctx, req)
+}
+
+// TerminateConsoleSession calls manager.v1.AdminService.TerminateConsoleSession.
+func (c *adminServiceClient) TerminateConsoleSession(ctx context.Context, req *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error) {
+	return c.terminateConsoleSession.CallUnary(ctx, req)
+}
+
+// GetTopology calls manager.v1.AdminService.GetTopology.
+func (c *adminServiceClient) GetTopology(ctx context.Context, req *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error) {
+	return c.getTopology.CallUnary(ctx, req)
+}
+
+// ListServerEvents calls manager.v1.AdminService.ListServerEvents.
+func (c *adminServiceClient) ListServerEvents(ctx context.Context, req *connect.Request[v1.ListServerEventsRequest]) (*connect.Response[v1.ListServerEventsResponse], error) {
+	return c.listServerEvents.CallUnary(ctx, req)
+}
+
+// SetServerMaintenance calls manager.v1.AdminService.SetServerMaintenance.
+func (c *adminServiceClient) SetServerMaintenance(ctx context.Context, req *connect.Request[v1.SetServerMaintenanceRequest]) (*connect.Response[v1.SetServerMaintenanceResponse], error) {
+	return c.setServerMaintenance.CallUnary(ctx, req)
+}
+
+// SetServerNotes calls manager.v1.AdminService.SetServerNotes.
+func (c *adminServiceClient) SetServerNotes(ctx context.Context, req *connect.Request[v1.SetServerNotesRequest]) (*connect.Response[v1.SetServerNotesResponse], error) {
+	return c.setServerNotes.CallUnary(ctx, req)
+}
+
+// CreateMaintenanceWindow calls manager.v1.AdminService.CreateMaintenanceWindow.
+func (c *adminServiceClient) CreateMaintenanceWindow(ctx context.Context, req *connect.Request[v1.CreateMaintenanceWindowRequest]) (*connect.Response[v1.CreateMaintenanceWindowResponse], error) {
+	return c.createMaintenanceWindow.CallUnary(ctx, req)
+}
+
+// ListMaintenanceWindows calls manager.v1.AdminService.ListMaintenanceWindows.
+func (c *adminServiceClient) ListMaintenanceWindows(ctx context.Context, req *connect.Request[v1.ListMaintenanceWindowsRequest]) (*connect.Response[v1.ListMaintenanceWindowsResponse], error) {
+	return c.listMaintenanceWindows.CallUnary(ctx, req)
+}
+
+// DeleteMaintenanceWindow calls manager.v1.AdminService.DeleteMaintenanceWindow.
+func (c *adminServiceClient) DeleteMaintenanceWindow(ctx context.Context, req *connect.Request[v1.DeleteMaintenanceWindowRequest]) (*connect.Response[v1.DeleteMaintenanceWindowResponse], error) {
+	return c.deleteMaintenanceWindow.CallUnary(ctx, req)
+}
+
+// SetCustomerSessionQuota calls manager.v1.AdminService.SetCustomerSessionQuota.
+func (c *adminServiceClient) SetCustomerSessionQuota(ctx context.Context, req *connect.Request[v1.SetCustomerSessionQuotaRequest]) (*connect.Response[v1.SetCustomerSessionQuotaResponse], error) {
+	return c.setCustomerSessionQuota.CallUnary(ctx, req)
+}
+
+// SetCustomerMFAPolicy calls manager.v1.AdminService.SetCustomerMFAPolicy.
+func (c *adminServiceClient) SetCustomerMFAPolicy(ctx context.Context, req *connect.Request[v1.SetCustomerMFAPolicyRequest]) (*connect.Response[v1.SetCustomerMFAPolicyResponse], error) {
+	return c.setCustomerMFAPolicy.CallUnary(ctx, req)
+}
+
+// SetCustomerIPAllowlist calls manager.v1.AdminService.SetCustomerIPAllowlist.
+func (c *adminServiceClient) SetCustomerIPAllowlist(ctx context.Context, req *connect.Request[v1.SetCustomerIPAllowlistRequest]) (*connect.Response[v1.SetCustomerIPAllowlistResponse], error) {
+	return c.setCustomerIPAllowlist.CallUnary(ctx, req)
+}
+
+// ExportUsage calls manager.v1.AdminService.ExportUsage.
+func (c *adminServiceClient) ExportUsage(ctx context.Context, req *connect.Request[v1.ExportUsageRequest]) (*connect.Response[v1.ExportUsageResponse], error) {
+	return c.exportUsage.CallUnary(ctx, req)
+}
+
+// ApproveGateway calls manager.v1.AdminService.ApproveGateway.
+func (c *adminServiceClient) ApproveGateway(ctx context.Context, req *connect.Request[v1.ApproveGatewayRequest]) (*connect.Response[v1.ApproveGatewayResponse], error) {
+	return c.approveGateway.CallUnary(ctx, req)
+}
+
+// CreateCustomer calls manager.v1.AdminService.CreateCustomer.
+func (c *adminServiceClient) CreateCustomer(ctx context.Context, req *connect.Request[v1.CreateCustomerRequest]) (*connect.Response[v1.CreateCustomerResponse], error) {
+	return c.createCustomer.CallUnary(ctx, req)
+}
+
+// IssueAPIKey calls manager.v1.AdminService.IssueAPIKey.
+func (c *adminServiceClient) IssueAPIKey(ctx context.Context, req *connect.Request[v1.IssueAPIKeyRequest]) (*connect.Response[v1.IssueAPIKeyResponse], error) {
+	return c.issueAPIKey.CallUnary(ctx, req)
+}
+
+// ResetCustomerPassword calls manager.v1.AdminService.ResetCustomerPassword.
+func (c *adminServiceClient) ResetCustomerPassword(ctx context.Context, req *connect.Request[v1.ResetCustomerPasswordRequest]) (*connect.Response[v1.ResetCustomerPasswordResponse], error) {
+	return c.resetCustomerPassword.CallUnary(ctx, req)
+}
+
+// DisableCustomer calls manager.v1.AdminService.DisableCustomer.
+func (c *adminServiceClient) DisableCustomer(ctx context.Context, req *connect.Request[v1.DisableCustomerRequest]) (*connect.Response[v1.DisableCustomerResponse], error) {
+	return c.disableCustomer.CallUnary(ctx, req)
+}
+
+// DeleteCustomer calls manager.v1.AdminService.DeleteCustomer.
+func (c *adminServiceClient) DeleteCustomer(ctx context.Context, req *connect.Request[v1.DeleteCustomerRequest]) (*connect.Response[v1.DeleteCustomerResponse], error) {
+	return c.deleteCustomer.CallUnary(ctx, req)
+}
+
+// AssignServer calls manager.v1.AdminService.AssignServer.
+func (c *adminServiceClient) AssignServer(ctx context.Context, req *connect.Request[v1.AssignServerRequest]) (*connect.Response[v1.AssignServerResponse], error) {
+	return c.assignServer.CallUnary(ctx, req)
+}
+
+// ImportServers calls manager.v1.AdminService.ImportServers.
+func (c *adminServiceClient) ImportServers(ctx context.Context, req *connect.Request[v1.ImportServersRequest]) (*connect.Response[v1.ImportServersResponse], error) {
+	return c.importServers.CallUnary(ctx, req)
+}
+
+// ExportServers calls manager.v1.AdminService.ExportServers.
+func (c *adminServiceClient) ExportServers(ctx context.Context, req *connect.Request[v1.ExportServersRequest]) (*connect.Response[v1.ExportServersResponse], error) {
+	return c.exportServers.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls manager.v1.AdminService.ListAuditEvents.
+func (c *adminServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// ListConnectivityAudits calls manager.v1.AdminService.ListConnectivityAudits.
+func (c *adminServiceClient) ListConnectivityAudits(ctx context.Context, req *connect.Request[v1.ListConnectivityAuditsRequest]) (*connect.Response[v1.ListConnectivityAuditsResponse], error) {
+	return c.listConnectivityAudits.CallUnary(ctx, req)
+}
+
+// RunConnectivityAudit calls manager.v1.AdminService.RunConnectivityAudit.
+func (c *adminServiceClient) RunConnectivityAudit(ctx context.Context, req *connect.Request[v1.RunConnectivityAuditRequest]) (*connect.Response[v1.RunConnectivityAuditResponse], error) {
+	return c.runConnectivityAudit.CallUnary(ctx, req)
+}
+
+// SetLogLevel calls manager.v1.AdminService.SetLogLevel.
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, req *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return c.setLogLevel.CallUnary(ctx, req)
+}
+
 // AdminServiceHandler is an implementation of the manager.v1.AdminService service.
 type AdminServiceHandler interface {
 	// Dashboard metrics and overview
@@ -186,9 +666,69 @@ type AdminServiceHandler interface {
 	GetGatewayHealth(context.Context, *connect.Request[v1.GetGatewayHealthRequest]) (*connect.Response[v1.GetGatewayHealthResponse], error)
 	// Available regions for filtering
 	GetRegions(context.Context, *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error)
-	// VNC/SOL session management for admin console
+	// VNC/SOL sessions admins open on behalf of the server's customer, audited
 	LaunchVNCSession(context.Context, *connect.Request[v1.LaunchSessionRequest]) (*connect.Response[v1.LaunchSessionResponse], error)
 	LaunchSOLSession(context.Context, *connect.Request[v1.LaunchSessionRequest]) (*connect.Response[v1.LaunchSessionResponse], error)
+	// Console availability SLO and error budget reporting
+	GetConsoleSLOReport(context.Context, *connect.Request[v1.GetConsoleSLOReportRequest]) (*connect.Response[v1.GetConsoleSLOReportResponse], error)
+	// Energy usage per customer and server, for chargeback
+	GetPowerUsageReport(context.Context, *connect.Request[v1.GetPowerUsageReportRequest]) (*connect.Response[v1.GetPowerUsageReportResponse], error)
+	// Open console sessions across all gateways, and forced termination
+	ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error)
+	TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error)
+	// Live topology: gateways, their agents and BMC endpoints, and open console sessions
+	GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error)
+	// Recent system events of a server, for its detail page
+	ListServerEvents(context.Context, *connect.Request[v1.ListServerEventsRequest]) (*connect.Response[v1.ListServerEventsResponse], error)
+	// Maintenance mode and operator notes of a server. Customer power operations
+	// are blocked while a server is in maintenance, admins may still run them.
+	SetServerMaintenance(context.Context, *connect.Request[v1.SetServerMaintenanceRequest]) (*connect.Response[v1.SetServerMaintenanceResponse], error)
+	SetServerNotes(context.Context, *connect.Request[v1.SetServerNotesRequest]) (*connect.Response[v1.SetServerNotesResponse], error)
+	// Scheduled maintenance windows of servers or groups of servers, during
+	// which destructive power operations are rejected or require an override
+	CreateMaintenanceWindow(context.Context, *connect.Request[v1.CreateMaintenanceWindowRequest]) (*connect.Response[v1.CreateMaintenanceWindowResponse], error)
+	ListMaintenanceWindows(context.Context, *connect.Request[v1.ListMaintenanceWindowsRequest]) (*connect.Response[v1.ListMaintenanceWindowsResponse], error)
+	DeleteMaintenanceWindow(context.Context, *connect.Request[v1.DeleteMaintenanceWindowRequest]) (*connect.Response[v1.DeleteMaintenanceWindowResponse], error)
+	// Console session limits of a customer, enforced by gateways
+	SetCustomerSessionQuota(context.Context, *connect.Request[v1.SetCustomerSessionQuotaRequest]) (*connect.Response[v1.SetCustomerSessionQuotaResponse], error)
+	// Whether console sessions of a customer require two-factor
+	// authentication, enforced by gateways
+	SetCustomerMFAPolicy(context.Context, *connect.Request[v1.SetCustomerMFAPolicyRequest]) (*connect.Response[v1.SetCustomerMFAPolicyResponse], error)
+	// Source IP ranges a customer accesses BMCs from, enforced by gateways
+	SetCustomerIPAllowlist(context.Context, *connect.Request[v1.SetCustomerIPAllowlistRequest]) (*connect.Response[v1.SetCustomerIPAllowlistResponse], error)
+	// Console minutes, power operations and data transferred per customer over
+	// a month, exported as CSV or JSON for billing
+	ExportUsage(context.Context, *connect.Request[v1.ExportUsageRequest]) (*connect.Response[v1.ExportUsageResponse], error)
+	// Approval of gateways registering while the manager requires it. Pending
+	// gateways are not listed to clients and their BMC endpoints are ignored.
+	ApproveGateway(context.Context, *connect.Request[v1.ApproveGatewayRequest]) (*connect.Response[v1.ApproveGatewayResponse], error)
+	// Customer accounts and their API keys
+	CreateCustomer(context.Context, *connect.Request[v1.CreateCustomerRequest]) (*connect.Response[v1.CreateCustomerResponse], error)
+	IssueAPIKey(context.Context, *connect.Request[v1.IssueAPIKeyRequest]) (*connect.Response[v1.IssueAPIKeyResponse], error)
+	// Issue a one-time token letting a customer set a new password with
+	// ResetPassword, e.g. when forgotten or locked out
+	ResetCustomerPassword(context.Context, *connect.Request[v1.ResetCustomerPasswordRequest]) (*connect.Response[v1.ResetCustomerPasswordResponse], error)
+	// Revocation of customer accounts. Both revoke the customer's tokens and
+	// API key, and terminate its console sessions on the gateways.
+	DisableCustomer(context.Context, *connect.Request[v1.DisableCustomerRequest]) (*connect.Response[v1.DisableCustomerResponse], error)
+	DeleteCustomer(context.Context, *connect.Request[v1.DeleteCustomerRequest]) (*connect.Response[v1.DeleteCustomerResponse], error)
+	// Assignment of a server to the customer owning it
+	AssignServer(context.Context, *connect.Request[v1.AssignServerRequest]) (*connect.Response[v1.AssignServerResponse], error)
+	// Bulk registration of servers from an inventory file, CSV or YAML, and
+	// export of the inventory in the same format. Each row is imported on its
+	// own and reported with its error; dry runs validate without saving.
+	ImportServers(context.Context, *connect.Request[v1.ImportServersRequest]) (*connect.Response[v1.ImportServersResponse], error)
+	ExportServers(context.Context, *connect.Request[v1.ExportServersRequest]) (*connect.Response[v1.ExportServersResponse], error)
+	// Audit log of the changes made by admins
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	// Connectivity audits of BMCs: the manager periodically checks the
+	// credentials and console endpoints of every server through its gateway
+	// and agent. RunConnectivityAudit audits one server now.
+	ListConnectivityAudits(context.Context, *connect.Request[v1.ListConnectivityAuditsRequest]) (*connect.Response[v1.ListConnectivityAuditsResponse], error)
+	RunConnectivityAudit(context.Context, *connect.Request[v1.RunConnectivityAuditRequest]) (*connect.Response[v1.RunConnectivityAuditResponse], error)
+	// Log levels of the manager, changed at runtime without restarting. An
+	// empty request returns the current levels.
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -240,6 +780,174 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(adminServiceMethods.ByName("LaunchSOLSession")),
 		connect.WithHandlerOptions(opts...),
 	)
+	adminServiceGetConsoleSLOReportHandler := connect.NewUnaryHandler(
+		AdminServiceGetConsoleSLOReportProcedure,
+		svc.GetConsoleSLOReport,
+		connect.WithSchema(adminServiceMethods.ByName("GetConsoleSLOReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetPowerUsageReportHandler := connect.NewUnaryHandler(
+		AdminServiceGetPowerUsageReportProcedure,
+		svc.GetPowerUsageReport,
+		connect.WithSchema(adminServiceMethods.ByName("GetPowerUsageReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListConsoleSessionsHandler := connect.NewUnaryHandler(
+		AdminServiceListConsoleSessionsProcedure,
+		svc.ListConsoleSessions,
+		connect.WithSchema(adminServiceMethods.ByName("ListConsoleSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceTerminateConsoleSessionHandler := connect.NewUnaryHandler(
+		AdminServiceTerminateConsoleSessionProcedure,
+		svc.TerminateConsoleSession,
+		connect.WithSchema(adminServiceMethods.ByName("TerminateConsoleSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetTopologyHandler := connect.NewUnaryHandler(
+		AdminServiceGetTopologyProcedure,
+		svc.GetTopology,
+		connect.WithSchema(adminServiceMethods.ByName("GetTopology")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListServerEventsHandler := connect.NewUnaryHandler(
+		AdminServiceListServerEventsProcedure,
+		svc.ListServerEvents,
+		connect.WithSchema(adminServiceMethods.ByName("ListServerEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetServerMaintenanceHandler := connect.NewUnaryHandler(
+		AdminServiceSetServerMaintenanceProcedure,
+		svc.SetServerMaintenance,
+		connect.WithSchema(adminServiceMethods.ByName("SetServerMaintenance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetServerNotesHandler := connect.NewUnaryHandler(
+		AdminServiceSetServerNotesProcedure,
+		svc.SetServerNotes,
+		connect.WithSchema(adminServiceMethods.ByName("SetServerNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateMaintenanceWindowHandler := connect.NewUnaryHandler(
+		AdminServiceCreateMaintenanceWindowProcedure,
+		svc.CreateMaintenanceWindow,
+		connect.WithSchema(adminServiceMethods.ByName("CreateMaintenanceWindow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListMaintenanceWindowsHandler := connect.NewUnaryHandler(
+		AdminServiceListMaintenanceWindowsProcedure,
+		svc.ListMaintenanceWindows,
+		connect.WithSchema(adminServiceMethods.ByName("ListMaintenanceWindows")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDeleteMaintenanceWindowHandler := connect.NewUnaryHandler(
+		AdminServiceDeleteMaintenanceWindowProcedure,
+		svc.DeleteMaintenanceWindow,
+		connect.WithSchema(adminServiceMethods.ByName("DeleteMaintenanceWindow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetCustomerSessionQuotaHandler := connect.NewUnaryHandler(
+		AdminServiceSetCustomerSessionQuotaProcedure,
+		svc.SetCustomerSessionQuota,
+		connect.WithSchema(adminServiceMethods.ByName("SetCustomerSessionQuota")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetCustomerMFAPolicyHandler := connect.NewUnaryHandler(
+		AdminServiceSetCustomerMFAPolicyProcedure,
+		svc.SetCustomerMFAPolicy,
+		connect.WithSchema(adminServiceMethods.ByName("SetCustomerMFAPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetCustomerIPAllowlistHandler := connect.NewUnaryHandler(
+		AdminServiceSetCustomerIPAllowlistProcedure,
+		svc.SetCustomerIPAllowlist,
+		connect.WithSchema(adminServiceMethods.ByName("SetCustomerIPAllowlist")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceExportUsageHandler := connect.NewUnaryHandler(
+		AdminServiceExportUsageProcedure,
+		svc.ExportUsage,
+		connect.WithSchema(adminServiceMethods.ByName("ExportUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceApproveGatewayHandler := connect.NewUnaryHandler(
+		AdminServiceApproveGatewayProcedure,
+		svc.ApproveGateway,
+		connect.WithSchema(adminServiceMethods.ByName("ApproveGateway")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateCustomerHandler := connect.NewUnaryHandler(
+		AdminServiceCreateCustomerProcedure,
+		svc.CreateCustomer,
+		connect.WithSchema(adminServiceMethods.ByName("CreateCustomer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceIssueAPIKeyHandler := connect.NewUnaryHandler(
+		AdminServiceIssueAPIKeyProcedure,
+		svc.IssueAPIKey,
+		connect.WithSchema(adminServiceMethods.ByName("IssueAPIKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceResetCustomerPasswordHandler := connect.NewUnaryHandler(
+		AdminServiceResetCustomerPasswordProcedure,
+		svc.ResetCustomerPassword,
+		connect.WithSchema(adminServiceMethods.ByName("ResetCustomerPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDisableCustomerHandler := connect.NewUnaryHandler(
+		AdminServiceDisableCustomerProcedure,
+		svc.DisableCustomer,
+		connect.WithSchema(adminServiceMethods.ByName("DisableCustomer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDeleteCustomerHandler := connect.NewUnaryHandler(
+		AdminServiceDeleteCustomerProcedure,
+		svc.DeleteCustomer,
+		connect.WithSchema(adminServiceMethods.ByName("DeleteCustomer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceAssignServerHandler := connect.NewUnaryHandler(
+		AdminServiceAssignServerProcedure,
+		svc.AssignServer,
+		connect.WithSchema(adminServiceMethods.ByName("AssignServer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceImportServersHandler := connect.NewUnaryHandler(
+		AdminServiceImportServersProcedure,
+		svc.ImportServers,
+		connect.WithSchema(adminServiceMethods.ByName("ImportServers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceExportServersHandler := connect.NewUnaryHandler(
+		AdminServiceExportServersProcedure,
+		svc.ExportServers,
+		connect.WithSchema(adminServiceMethods.ByName("ExportServers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		AdminServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(adminServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListConnectivityAuditsHandler := connect.NewUnaryHandler(
+		AdminServiceListConnectivityAuditsProcedure,
+		svc.ListConnectivityAudits,
+		connect.WithSchema(adminServiceMethods.ByName("ListConnectivityAudits")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRunConnectivityAuditHandler := connect.NewUnaryHandler(
+		AdminServiceRunConnectivityAuditProcedure,
+		svc.RunConnectivityAudit,
+		connect.WithSchema(adminServiceMethods.ByName("RunConnectivityAudit")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetLogLevelHandler := connect.NewUnaryHandler(
+		AdminServiceSetLogLevelProcedure,
+		svc.SetLogLevel,
+		connect.WithSchema(adminServiceMethods.ByName("SetLogLevel")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/manager.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AdminServiceGetDashboardMetricsProcedure:
@@ -256,6 +964,62 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 			adminServiceLaunchVNCSessionHandler.ServeHTTP(w, r)
 		case AdminServiceLaunchSOLSessionProcedure:
 			adminServiceLaunchSOLSessionHandler.ServeHTTP(w, r)
+		case AdminServiceGetConsoleSLOReportProcedure:
+			adminServiceGetConsoleSLOReportHandler.ServeHTTP(w, r)
+		case AdminServiceGetPowerUsageReportProcedure:
+			adminServiceGetPowerUsageReportHandler.ServeHTTP(w, r)
+		case AdminServiceListConsoleSessionsProcedure:
+			adminServiceListConsoleSessionsHandler.ServeHTTP(w, r)
+		case AdminServiceTerminateConsoleSessionProcedure:
+			adminServiceTerminateConsoleSessionHandler.ServeHTTP(w, r)
+		case AdminServiceGetTopologyProcedure:
+			adminServiceGetTopologyHandler.ServeHTTP(w, r)
+		case AdminServiceListServerEventsProcedure:
+			adminServiceListServerEventsHandler.ServeHTTP(w, r)
+		case AdminServiceSetServerMaintenanceProcedure:
+			adminServiceSetServerMaintenanceHandler.ServeHTTP(w, r)
+		case AdminServiceSetServerNotesProcedure:
+			adminServiceSetServerNotesHandler.ServeHTTP(w, r)
+		case AdminServiceCreateMaintenanceWindowProcedure:
+			adminServiceCreateMaintenanceWindowHandler.ServeHTTP(w, r)
+		case AdminServiceListMaintenanceWindowsProcedure:
+			adminServiceListMaintenanceWindowsHandler.ServeHTTP(w, r)
+		case AdminServiceDeleteMaintenanceWindowProcedure:
+			adminServiceDeleteMaintenanceWindowHandler.ServeHTTP(w, r)
+		case AdminServiceSetCustomerSessionQuotaProcedure:
+			adminServiceSetCustomerSessionQuotaHandler.ServeHTTP(w, r)
+		case AdminServiceSetCustomerMFAPolicyProcedure:
+			adminServiceSetCustomerMFAPolicyHandler.ServeHTTP(w, r)
+		case AdminServiceSetCustomerIPAllowlistProcedure:
+			adminServiceSetCustomerIPAllowlistHandler.ServeHTTP(w, r)
+		case AdminServiceExportUsageProcedure:
+			adminServiceExportUsageHandler.ServeHTTP(w, r)
+		case AdminServiceApproveGatewayProcedure:
+			adminServiceApproveGatewayHandler.ServeHTTP(w, r)
+		case AdminServiceCreateCustomerProcedure:
+			adminServiceCreateCustomerHandler.ServeHTTP(w, r)
+		case AdminServiceIssueAPIKeyProcedure:
+			adminServiceIssueAPIKeyHandler.ServeHTTP(w, r)
+		case AdminServiceResetCustomerPasswordProcedure:
+			adminServiceResetCustomerPasswordHandler.ServeHTTP(w, r)
+		case AdminServiceDisableCustomerProcedure:
+			adminServiceDisableCustomerHandler.ServeHTTP(w, r)
+		case AdminServiceDeleteCustomerProcedure:
+			adminServiceDeleteCustomerHandler.ServeHTTP(w, r)
+		case AdminServiceAssignServerProcedure:
+			adminServiceAssignServerHandler.ServeHTTP(w, r)
+		case AdminServiceImportServersProcedure:
+			adminServiceImportServersHandler.ServeHTTP(w, r)
+		case AdminServiceExportServersProcedure:
+			adminServiceExportServersHandler.ServeHTTP(w, r)
+		case AdminServiceListAuditEventsProcedure:
+			adminServiceListAuditEventsHandler.ServeHTTP(w, r)
+		case AdminServiceListConnectivityAuditsProcedure:
+			adminServiceListConnectivityAuditsHandler.ServeHTTP(w, r)
+		case AdminServiceRunConnectivityAuditProcedure:
+			adminServiceRunConnectivityAuditHandler.ServeHTTP(w, r)
+		case AdminServiceSetLogLevelProcedure:
+			adminServiceSetLogLevelHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -292,3 +1056,115 @@ func (UnimplementedAdminServiceHandler) LaunchVNCSession(context.Context, *conne
 func (UnimplementedAdminServiceHandler) LaunchSOLSession(context.Context, *connect.Request[v1.LaunchSessionRequest]) (*connect.Response[v1.LaunchSessionResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.LaunchSOLSession is not implemented"))
 }
+
+func (UnimplementedAdminServiceHandler) GetConsoleSLOReport(context.Context, *connect.Request[v1.GetConsoleSLOReportRequest]) (*connect.Response[v1.GetConsoleSLOReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.GetConsoleSLOReport is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetPowerUsageReport(context.Context, *connect.Request[v1.GetPowerUsageReportRequest]) (*connect.Response[v1.GetPowerUsageReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.GetPowerUsageReport is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListConsoleSessions(context.Context, *connect.Request[v1.ListConsoleSessionsRequest]) (*connect.Response[v1.ListConsoleSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ListConsoleSessions is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) TerminateConsoleSession(context.Context, *connect.Request[v1.TerminateConsoleSessionRequest]) (*connect.Response[v1.TerminateConsoleSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.TerminateConsoleSession is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.GetTopology is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListServerEvents(context.Context, *connect.Request[v1.ListServerEventsRequest]) (*connect.Response[v1.ListServerEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ListServerEvents is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetServerMaintenance(context.Context, *connect.Request[v1.SetServerMaintenanceRequest]) (*connect.Response[v1.SetServerMaintenanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetServerMaintenance is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetServerNotes(context.Context, *connect.Request[v1.SetServerNotesRequest]) (*connect.Response[v1.SetServerNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetServerNotes is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateMaintenanceWindow(context.Context, *connect.Request[v1.CreateMaintenanceWindowRequest]) (*connect.Response[v1.CreateMaintenanceWindowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.CreateMaintenanceWindow is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListMaintenanceWindows(context.Context, *connect.Request[v1.ListMaintenanceWindowsRequest]) (*connect.Response[v1.ListMaintenanceWindowsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ListMaintenanceWindows is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DeleteMaintenanceWindow(context.Context, *connect.Request[v1.DeleteMaintenanceWindowRequest]) (*connect.Response[v1.DeleteMaintenanceWindowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.DeleteMaintenanceWindow is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetCustomerSessionQuota(context.Context, *connect.Request[v1.SetCustomerSessionQuotaRequest]) (*connect.Response[v1.SetCustomerSessionQuotaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetCustomerSessionQuota is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetCustomerMFAPolicy(context.Context, *connect.Request[v1.SetCustomerMFAPolicyRequest]) (*connect.Response[v1.SetCustomerMFAPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetCustomerMFAPolicy is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetCustomerIPAllowlist(context.Context, *connect.Request[v1.SetCustomerIPAllowlistRequest]) (*connect.Response[v1.SetCustomerIPAllowlistResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetCustomerIPAllowlist is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ExportUsage(context.Context, *connect.Request[v1.ExportUsageRequest]) (*connect.Response[v1.ExportUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ExportUsage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ApproveGateway(context.Context, *connect.Request[v1.ApproveGatewayRequest]) (*connect.Response[v1.ApproveGatewayResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ApproveGateway is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateCustomer(context.Context, *connect.Request[v1.CreateCustomerRequest]) (*connect.Response[v1.CreateCustomerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.CreateCustomer is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) IssueAPIKey(context.Context, *connect.Request[v1.IssueAPIKeyRequest]) (*connect.Response[v1.IssueAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.IssueAPIKey is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ResetCustomerPassword(context.Context, *connect.Request[v1.ResetCustomerPasswordRequest]) (*connect.Response[v1.ResetCustomerPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ResetCustomerPassword is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DisableCustomer(context.Context, *connect.Request[v1.DisableCustomerRequest]) (*connect.Response[v1.DisableCustomerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.DisableCustomer is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DeleteCustomer(context.Context, *connect.Request[v1.DeleteCustomerRequest]) (*connect.Response[v1.DeleteCustomerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.DeleteCustomer is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) AssignServer(context.Context, *connect.Request[v1.AssignServerRequest]) (*connect.Response[v1.AssignServerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.AssignServer is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ImportServers(context.Context, *connect.Request[v1.ImportServersRequest]) (*connect.Response[v1.ImportServersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ImportServers is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ExportServers(context.Context, *connect.Request[v1.ExportServersRequest]) (*connect.Response[v1.ExportServersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ExportServers is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListConnectivityAudits(context.Context, *connect.Request[v1.ListConnectivityAuditsRequest]) (*connect.Response[v1.ListConnectivityAuditsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.ListConnectivityAudits is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RunConnectivityAudit(context.Context, *connect.Request[v1.RunConnectivityAuditRequest]) (*connect.Response[v1.RunConnectivityAuditResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.RunConnectivityAudit is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.AdminService.SetLogLevel is not implemented"))
+}
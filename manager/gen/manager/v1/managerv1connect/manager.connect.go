@@ -39,6 +39,21 @@ const (
 	// BMCManagerServiceRefreshTokenProcedure is the fully-qualified name of the BMCManagerService's
 	// RefreshToken RPC.
 	BMCManagerServiceRefreshTokenProcedure = "/manager.v1.BMCManagerService/RefreshToken"
+	// BMCManagerServiceChangePasswordProcedure is the fully-qualified name of the BMCManagerService's
+	// ChangePassword RPC.
+	BMCManagerServiceChangePasswordProcedure = "/manager.v1.BMCManagerService/ChangePassword"
+	// BMCManagerServiceResetPasswordProcedure is the fully-qualified name of the BMCManagerService's
+	// ResetPassword RPC.
+	BMCManagerServiceResetPasswordProcedure = "/manager.v1.BMCManagerService/ResetPassword"
+	// BMCManagerServiceEnrollTOTPProcedure is the fully-qualified name of the BMCManagerService's
+	// EnrollTOTP RPC.
+	BMCManagerServiceEnrollTOTPProcedure = "/manager.v1.BMCManagerService/EnrollTOTP"
+	// BMCManagerServiceConfirmTOTPProcedure is the fully-qualified name of the BMCManagerService's
+	// ConfirmTOTP RPC.
+	BMCManagerServiceConfirmTOTPProcedure = "/manager.v1.BMCManagerService/ConfirmTOTP"
+	// BMCManagerServiceDisableTOTPProcedure is the fully-qualified name of the BMCManagerService's
+	// DisableTOTP RPC.
+	BMCManagerServiceDisableTOTPProcedure = "/manager.v1.BMCManagerService/DisableTOTP"
 	// BMCManagerServiceGetServerTokenProcedure is the fully-qualified name of the BMCManagerService's
 	// GetServerToken RPC.
 	BMCManagerServiceGetServerTokenProcedure = "/manager.v1.BMCManagerService/GetServerToken"
@@ -66,6 +81,18 @@ const (
 	// BMCManagerServiceReportAvailableEndpointsProcedure is the fully-qualified name of the
 	// BMCManagerService's ReportAvailableEndpoints RPC.
 	BMCManagerServiceReportAvailableEndpointsProcedure = "/manager.v1.BMCManagerService/ReportAvailableEndpoints"
+	// BMCManagerServiceReportConsoleSLIsProcedure is the fully-qualified name of the
+	// BMCManagerService's ReportConsoleSLIs RPC.
+	BMCManagerServiceReportConsoleSLIsProcedure = "/manager.v1.BMCManagerService/ReportConsoleSLIs"
+	// BMCManagerServiceReportEventsProcedure is the fully-qualified name of the BMCManagerService's
+	// ReportEvents RPC.
+	BMCManagerServiceReportEventsProcedure = "/manager.v1.BMCManagerService/ReportEvents"
+	// BMCManagerServiceReportPowerReadingsProcedure is the fully-qualified name of the
+	// BMCManagerService's ReportPowerReadings RPC.
+	BMCManagerServiceReportPowerReadingsProcedure = "/manager.v1.BMCManagerService/ReportPowerReadings"
+	// BMCManagerServiceValidateSessionProcedure is the fully-qualified name of the BMCManagerService's
+	// ValidateSession RPC.
+	BMCManagerServiceValidateSessionProcedure = "/manager.v1.BMCManagerService/ValidateSession"
 )
 
 // BMCManagerServiceClient is a client for the manager.v1.BMCManagerService service.
@@ -76,11 +103,24 @@ type BMCManagerServiceClient interface {
 	// RefreshToken issues new access tokens using refresh tokens
 	// Can optionally scope tokens to specific servers for enhanced security
 	RefreshToken(context.Context, *connect.Request[v1.RefreshTokenRequest]) (*connect.Response[v1.RefreshTokenResponse], error)
+	// ChangePassword sets the password of the authenticated customer
+	ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error)
+	// ResetPassword sets a customer's password with a one-time reset token
+	// issued by an admin. It needs no access token.
+	ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error)
+	// TOTP two-factor authentication of the authenticated customer.
+	// EnrollTOTP generates a secret, which ConfirmTOTP enables with a code
+	// from the authenticator app. Once enabled, Authenticate requires a code.
+	EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error)
+	ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error)
+	DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error)
 	// GetServerToken generates a server-specific token with encrypted BMC context
 	// Enables stateless gateway operations without server ID lookups
 	GetServerToken(context.Context, *connect.Request[v1.GetServerTokenRequest]) (*connect.Response[v1.GetServerTokenResponse], error)
 	// RegisterServer registers a server and maps it to a regional gateway
 	// Called during server provisioning to establish BMC access routing
+	// Idempotent: a server already registered or discovered with the same
+	// datacenter and primary BMC endpoint is updated instead
 	RegisterServer(context.Context, *connect.Request[v1.RegisterServerRequest]) (*connect.Response[v1.RegisterServerResponse], error)
 	// GetServerLocation resolves which gateway handles a specific server
 	// Used by CLI and other clients to route server requests correctly
@@ -103,6 +143,20 @@ type BMCManagerServiceClient interface {
 	// ReportAvailableEndpoints allows gateways to report BMC endpoints they can proxy
 	// This establishes the BMC endpoint to gateway mapping for routing decisions
 	ReportAvailableEndpoints(context.Context, *connect.Request[v1.ReportAvailableEndpointsRequest]) (*connect.Response[v1.ReportAvailableEndpointsResponse], error)
+	// ReportConsoleSLIs allows gateways to report console session establishment
+	// outcomes and time-to-first-byte measurements
+	// Aggregated by the AdminService into rolling SLO compliance reports
+	ReportConsoleSLIs(context.Context, *connect.Request[v1.ReportConsoleSLIsRequest]) (*connect.Response[v1.ReportConsoleSLIsResponse], error)
+	// ReportEvents allows gateways to report the system events they observed,
+	// such as power operations and console sessions, so the manager can notify
+	// webhook subscribers
+	ReportEvents(context.Context, *connect.Request[v1.ReportEventsRequest]) (*connect.Response[v1.ReportEventsResponse], error)
+	// ReportPowerReadings allows gateways to report the power consumption they
+	// sampled from BMCs, the measurements behind the power usage report
+	ReportPowerReadings(context.Context, *connect.Request[v1.ReportPowerReadingsRequest]) (*connect.Response[v1.ReportPowerReadingsResponse], error)
+	// ValidateSession allows gateways to check, when a client attaches to a
+	// console session, that its customer may still open the server's console
+	ValidateSession(context.Context, *connect.Request[v1.ValidateSessionRequest]) (*connect.Response[v1.ValidateSessionResponse], error)
 }
 
 // NewBMCManagerServiceClient constructs a client for the manager.v1.BMCManagerService service. By
@@ -128,6 +182,36 @@ func NewBMCManagerServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(bMCManagerServiceMethods.ByName("RefreshToken")),
 			connect.WithClientOptions(opts...),
 		),
+		changePassword: connect.NewClient[v1.ChangePasswordRequest, v1.ChangePasswordResponse](
+			httpClient,
+			baseURL+BMCManagerServiceChangePasswordProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ChangePassword")),
+			connect.WithClientOptions(opts...),
+		),
+		resetPassword: connect.NewClient[v1.ResetPasswordRequest, v1.ResetPasswordResponse](
+			httpClient,
+			baseURL+BMCManagerServiceResetPasswordProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ResetPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		enrollTOTP: connect.NewClient[v1.EnrollTOTPRequest, v1.EnrollTOTPResponse](
+			httpClient,
+			baseURL+BMCManagerServiceEnrollTOTPProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("EnrollTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTOTP: connect.NewClient[v1.ConfirmTOTPRequest, v1.ConfirmTOTPResponse](
+			httpClient,
+			baseURL+BMCManagerServiceConfirmTOTPProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ConfirmTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		disableTOTP: connect.NewClient[v1.DisableTOTPRequest, v1.DisableTOTPResponse](
+			httpClient,
+			baseURL+BMCManagerServiceDisableTOTPProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("DisableTOTP")),
+			connect.WithClientOptions(opts...),
+		),
 		getServerToken: connect.NewClient[v1.GetServerTokenRequest, v1.GetServerTokenResponse](
 			httpClient,
 			baseURL+BMCManagerServiceGetServerTokenProcedure,
@@ -182,6 +266,30 @@ func NewBMCManagerServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(bMCManagerServiceMethods.ByName("ReportAvailableEndpoints")),
 			connect.WithClientOptions(opts...),
 		),
+		reportConsoleSLIs: connect.NewClient[v1.ReportConsoleSLIsRequest, v1.ReportConsoleSLIsResponse](
+			httpClient,
+			baseURL+BMCManagerServiceReportConsoleSLIsProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ReportConsoleSLIs")),
+			connect.WithClientOptions(opts...),
+		),
+		reportEvents: connect.NewClient[v1.ReportEventsRequest, v1.ReportEventsResponse](
+			httpClient,
+			baseURL+BMCManagerServiceReportEventsProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ReportEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		reportPowerReadings: connect.NewClient[v1.ReportPowerReadingsRequest, v1.ReportPowerReadingsResponse](
+			httpClient,
+			baseURL+BMCManagerServiceReportPowerReadingsProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ReportPowerReadings")),
+			connect.WithClientOptions(opts...),
+		),
+		validateSession: connect.NewClient[v1.ValidateSessionRequest, v1.ValidateSessionResponse](
+			httpClient,
+			baseURL+BMCManagerServiceValidateSessionProcedure,
+			connect.WithSchema(bMCManagerServiceMethods.ByName("ValidateSession")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -189,6 +297,11 @@ func NewBMCManagerServiceClient(httpClient connect.HTTPClient, baseURL string, o
 type bMCManagerServiceClient struct {
 	authenticate             *connect.Client[v1.AuthenticateRequest, v1.AuthenticateResponse]
 	refreshToken             *connect.Client[v1.RefreshTokenRequest, v1.RefreshTokenResponse]
+	changePassword           *connect.Client[v1.ChangePasswordRequest, v1.ChangePasswordResponse]
+	resetPassword            *connect.Client[v1.ResetPasswordRequest, v1.ResetPasswordResponse]
+	enrollTOTP               *connect.Client[v1.EnrollTOTPRequest, v1.EnrollTOTPResponse]
+	confirmTOTP              *connect.Client[v1.ConfirmTOTPRequest, v1.ConfirmTOTPResponse]
+	disableTOTP              *connect.Client[v1.DisableTOTPRequest, v1.DisableTOTPResponse]
 	getServerToken           *connect.Client[v1.GetServerTokenRequest, v1.GetServerTokenResponse]
 	registerServer           *connect.Client[v1.RegisterServerRequest, v1.RegisterServerResponse]
 	getServerLocation        *connect.Client[v1.GetServerLocationRequest, v1.GetServerLocationResponse]
@@ -198,6 +311,10 @@ type bMCManagerServiceClient struct {
 	getServer                *connect.Client[v1.GetServerRequest, v1.GetServerResponse]
 	listServers              *connect.Client[v1.ListServersRequest, v1.ListServersResponse]
 	reportAvailableEndpoints *connect.Client[v1.ReportAvailableEndpointsRequest, v1.ReportAvailableEndpointsResponse]
+	reportConsoleSLIs        *connect.Client[v1.ReportConsoleSLIsRequest, v1.ReportConsoleSLIsResponse]
+	reportEvents             *connect.Client[v1.ReportEventsRequest, v1.ReportEventsResponse]
+	reportPowerReadings      *connect.Client[v1.ReportPowerReadingsRequest, v1.ReportPowerReadingsResponse]
+	validateSession          *connect.Client[v1.ValidateSessionRequest, v1.ValidateSessionResponse]
 }
 
 // Authenticate calls manager.v1.BMCManagerService.Authenticate.
@@ -210,6 +327,31 @@ func (c *bMCManagerServiceClient) RefreshToken(ctx context.Context, req *connect
 	return c.refreshToken.CallUnary(ctx, req)
 }
 
+// ChangePassword calls manager.v1.BMCManagerService.ChangePassword.
+func (c *bMCManagerServiceClient) ChangePassword(ctx context.Context, req *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error) {
+	return c.changePassword.CallUnary(ctx, req)
+}
+
+// ResetPassword calls manager.v1.BMCManagerService.ResetPassword.
+func (c *bMCManagerServiceClient) ResetPassword(ctx context.Context, req *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error) {
+	return c.resetPassword.CallUnary(ctx, req)
+}
+
+// EnrollTOTP calls manager.v1.BMCManagerService.EnrollTOTP.
+func (c *bMCManagerServiceClient) EnrollTOTP(ctx context.Context, req *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error) {
+	return c.enrollTOTP.CallUnary(ctx, req)
+}
+
+// ConfirmTOTP calls manager.v1.BMCManagerService.ConfirmTOTP.
+func (c *bMCManagerServiceClient) ConfirmTOTP(ctx context.Context, req *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error) {
+	return c.confirmTOTP.CallUnary(ctx, req)
+}
+
+// DisableTOTP calls manager.v1.BMCManagerService.DisableTOTP.
+func (c *bMCManagerServiceClient) DisableTOTP(ctx context.Context, req *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error) {
+	return c.disableTOTP.CallUnary(ctx, req)
+}
+
 // GetServerToken calls manager.v1.BMCManagerService.GetServerToken.
 func (c *bMCManagerServiceClient) GetServerToken(ctx context.Context, req *connect.Request[v1.GetServerTokenRequest]) (*connect.Response[v1.GetServerTokenResponse], error) {
 	return c.getServerToken.CallUnary(ctx, req)
@@ -255,6 +397,26 @@ func (c *bMCManagerServiceClient) ReportAvailableEndpoints(ctx context.Context,
 	return c.reportAvailableEndpoints.CallUnary(ctx, req)
 }
 
+// ReportConsoleSLIs calls manager.v1.BMCManagerService.ReportConsoleSLIs.
+func (c *bMCManagerServiceClient) ReportConsoleSLIs(ctx context.Context, req *connect.Request[v1.ReportConsoleSLIsRequest]) (*connect.Response[v1.ReportConsoleSLIsResponse], error) {
+	return c.reportConsoleSLIs.CallUnary(ctx, req)
+}
+
+// ReportEvents calls manager.v1.BMCManagerService.ReportEvents.
+func (c *bMCManagerServiceClient) ReportEvents(ctx context.Context, req *connect.Request[v1.ReportEventsRequest]) (*connect.Response[v1.ReportEventsResponse], error) {
+	return c.reportEvents.CallUnary(ctx, req)
+}
+
+// ReportPowerReadings calls manager.v1.BMCManagerService.ReportPowerReadings.
+func (c *bMCManagerServiceClient) ReportPowerReadings(ctx context.Context, req *connect.Request[v1.ReportPowerReadingsRequest]) (*connect.Response[v1.ReportPowerReadingsResponse], error) {
+	return c.reportPowerReadings.CallUnary(ctx, req)
+}
+
+// ValidateSession calls manager.v1.BMCManagerService.ValidateSession.
+func (c *bMCManagerServiceClient) ValidateSession(ctx context.Context, req *connect.Request[v1.ValidateSessionRequest]) (*connect.Response[v1.ValidateSessionResponse], error) {
+	return c.validateSession.CallUnary(ctx, req)
+}
+
 // BMCManagerServiceHandler is an implementation of the manager.v1.BMCManagerService service.
 type BMCManagerServiceHandler interface {
 	// Authenticate verifies customer credentials and issues access tokens
@@ -263,11 +425,24 @@ type BMCManagerServiceHandler interface {
 	// RefreshToken issues new access tokens using refresh tokens
 	// Can optionally scope tokens to specific servers for enhanced security
 	RefreshToken(context.Context, *connect.Request[v1.RefreshTokenRequest]) (*connect.Response[v1.RefreshTokenResponse], error)
+	// ChangePassword sets the password of the authenticated customer
+	ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error)
+	// ResetPassword sets a customer's password with a one-time reset token
+	// issued by an admin. It needs no access token.
+	ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error)
+	// TOTP two-factor authentication of the authenticated customer.
+	// EnrollTOTP generates a secret, which ConfirmTOTP enables with a code
+	// from the authenticator app. Once enabled, Authenticate requires a code.
+	EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error)
+	ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error)
+	DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error)
 	// GetServerToken generates a server-specific token with encrypted BMC context
 	// Enables stateless gateway operations without server ID lookups
 	GetServerToken(context.Context, *connect.Request[v1.GetServerTokenRequest]) (*connect.Response[v1.GetServerTokenResponse], error)
 	// RegisterServer registers a server and maps it to a regional gateway
 	// Called during server provisioning to establish BMC access routing
+	// Idempotent: a server already registered or discovered with the same
+	// datacenter and primary BMC endpoint is updated instead
 	RegisterServer(context.Context, *connect.Request[v1.RegisterServerRequest]) (*connect.Response[v1.RegisterServerResponse], error)
 	// GetServerLocation resolves which gateway handles a specific server
 	// Used by CLI and other clients to route server requests correctly
@@ -290,6 +465,20 @@ type BMCManagerServiceHandler interface {
 	// ReportAvailableEndpoints allows gateways to report BMC endpoints they can proxy
 	// This establishes the BMC endpoint to gateway mapping for routing decisions
 	ReportAvailableEndpoints(context.Context, *connect.Request[v1.ReportAvailableEndpointsRequest]) (*connect.Response[v1.ReportAvailableEndpointsResponse], error)
+	// ReportConsoleSLIs allows gateways to report console session establishment
+	// outcomes and time-to-first-byte measurements
+	// Aggregated by the AdminService into rolling SLO compliance reports
+	ReportConsoleSLIs(context.Context, *connect.Request[v1.ReportConsoleSLIsRequest]) (*connect.Response[v1.ReportConsoleSLIsResponse], error)
+	// ReportEvents allows gateways to report the system events they observed,
+	// such as power operations and console sessions, so the manager can notify
+	// webhook subscribers
+	ReportEvents(context.Context, *connect.Request[v1.ReportEventsRequest]) (*connect.Response[v1.ReportEventsResponse], error)
+	// ReportPowerReadings allows gateways to report the power consumption they
+	// sampled from BMCs, the measurements behind the power usage report
+	ReportPowerReadings(context.Context, *connect.Request[v1.ReportPowerReadingsRequest]) (*connect.Response[v1.ReportPowerReadingsResponse], error)
+	// ValidateSession allows gateways to check, when a client attaches to a
+	// console session, that its customer may still open the server's console
+	ValidateSession(context.Context, *connect.Request[v1.ValidateSessionRequest]) (*connect.Response[v1.ValidateSessionResponse], error)
 }
 
 // NewBMCManagerServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -311,6 +500,36 @@ func NewBMCManagerServiceHandler(svc BMCManagerServiceHandler, opts ...connect.H
 		connect.WithSchema(bMCManagerServiceMethods.ByName("RefreshToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bMCManagerServiceChangePasswordHandler := connect.NewUnaryHandler(
+		BMCManagerServiceChangePasswordProcedure,
+		svc.ChangePassword,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ChangePassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceResetPasswordHandler := connect.NewUnaryHandler(
+		BMCManagerServiceResetPasswordProcedure,
+		svc.ResetPassword,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ResetPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceEnrollTOTPHandler := connect.NewUnaryHandler(
+		BMCManagerServiceEnrollTOTPProcedure,
+		svc.EnrollTOTP,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("EnrollTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceConfirmTOTPHandler := connect.NewUnaryHandler(
+		BMCManagerServiceConfirmTOTPProcedure,
+		svc.ConfirmTOTP,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ConfirmTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceDisableTOTPHandler := connect.NewUnaryHandler(
+		BMCManagerServiceDisableTOTPProcedure,
+		svc.DisableTOTP,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("DisableTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
 	bMCManagerServiceGetServerTokenHandler := connect.NewUnaryHandler(
 		BMCManagerServiceGetServerTokenProcedure,
 		svc.GetServerToken,
@@ -365,12 +584,46 @@ func NewBMCManagerServiceHandler(svc BMCManagerServiceHandler, opts ...connect.H
 		connect.WithSchema(bMCManagerServiceMethods.ByName("ReportAvailableEndpoints")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bMCManagerServiceReportConsoleSLIsHandler := connect.NewUnaryHandler(
+		BMCManagerServiceReportConsoleSLIsProcedure,
+		svc.ReportConsoleSLIs,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ReportConsoleSLIs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceReportEventsHandler := connect.NewUnaryHandler(
+		BMCManagerServiceReportEventsProcedure,
+		svc.ReportEvents,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ReportEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceReportPowerReadingsHandler := connect.NewUnaryHandler(
+		BMCManagerServiceReportPowerReadingsProcedure,
+		svc.ReportPowerReadings,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ReportPowerReadings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCManagerServiceValidateSessionHandler := connect.NewUnaryHandler(
+		BMCManagerServiceValidateSessionProcedure,
+		svc.ValidateSession,
+		connect.WithSchema(bMCManagerServiceMethods.ByName("ValidateSession")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/manager.v1.BMCManagerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BMCManagerServiceAuthenticateProcedure:
 			bMCManagerServiceAuthenticateHandler.ServeHTTP(w, r)
 		case BMCManagerServiceRefreshTokenProcedure:
 			bMCManagerServiceRefreshTokenHandler.ServeHTTP(w, r)
+		case BMCManagerServiceChangePasswordProcedure:
+			bMCManagerServiceChangePasswordHandler.ServeHTTP(w, r)
+		case BMCManagerServiceResetPasswordProcedure:
+			bMCManagerServiceResetPasswordHandler.ServeHTTP(w, r)
+		case BMCManagerServiceEnrollTOTPProcedure:
+			bMCManagerServiceEnrollTOTPHandler.ServeHTTP(w, r)
+		case BMCManagerServiceConfirmTOTPProcedure:
+			bMCManagerServiceConfirmTOTPHandler.ServeHTTP(w, r)
+		case BMCManagerServiceDisableTOTPProcedure:
+			bMCManagerServiceDisableTOTPHandler.ServeHTTP(w, r)
 		case BMCManagerServiceGetServerTokenProcedure:
 			bMCManagerServiceGetServerTokenHandler.ServeHTTP(w, r)
 		case BMCManagerServiceRegisterServerProcedure:
@@ -389,6 +642,14 @@ func NewBMCManagerServiceHandler(svc BMCManagerServiceHandler, opts ...connect.H
 			bMCManagerServiceListServersHandler.ServeHTTP(w, r)
 		case BMCManagerServiceReportAvailableEndpointsProcedure:
 			bMCManagerServiceReportAvailableEndpointsHandler.ServeHTTP(w, r)
+		case BMCManagerServiceReportConsoleSLIsProcedure:
+			bMCManagerServiceReportConsoleSLIsHandler.ServeHTTP(w, r)
+		case BMCManagerServiceReportEventsProcedure:
+			bMCManagerServiceReportEventsHandler.ServeHTTP(w, r)
+		case BMCManagerServiceReportPowerReadingsProcedure:
+			bMCManagerServiceReportPowerReadingsHandler.ServeHTTP(w, r)
+		case BMCManagerServiceValidateSessionProcedure:
+			bMCManagerServiceValidateSessionHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -406,6 +667,26 @@ func (UnimplementedBMCManagerServiceHandler) RefreshToken(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.RefreshToken is not implemented"))
 }
 
+func (UnimplementedBMCManagerServiceHandler) ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ChangePassword is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ResetPassword is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.EnrollTOTP is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ConfirmTOTP is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.DisableTOTP is not implemented"))
+}
+
 func (UnimplementedBMCManagerServiceHandler) GetServerToken(context.Context, *connect.Request[v1.GetServerTokenRequest]) (*connect.Response[v1.GetServerTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.GetServerToken is not implemented"))
 }
@@ -441,3 +722,19 @@ func (UnimplementedBMCManagerServiceHandler) ListServers(context.Context, *conne
 func (UnimplementedBMCManagerServiceHandler) ReportAvailableEndpoints(context.Context, *connect.Request[v1.ReportAvailableEndpointsRequest]) (*connect.Response[v1.ReportAvailableEndpointsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ReportAvailableEndpoints is not implemented"))
 }
+
+func (UnimplementedBMCManagerServiceHandler) ReportConsoleSLIs(context.Context, *connect.Request[v1.ReportConsoleSLIsRequest]) (*connect.Response[v1.ReportConsoleSLIsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ReportConsoleSLIs is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) ReportEvents(context.Context, *connect.Request[v1.ReportEventsRequest]) (*connect.Response[v1.ReportEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ReportEvents is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) ReportPowerReadings(context.Context, *connect.Request[v1.ReportPowerReadingsRequest]) (*connect.Response[v1.ReportPowerReadingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ReportPowerReadings is not implemented"))
+}
+
+func (UnimplementedBMCManagerServiceHandler) ValidateSession(context.Context, *connect.Request[v1.ValidateSessionRequest]) (*connect.Response[v1.ValidateSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("manager.v1.BMCManagerService.ValidateSession is not implemented"))
+}
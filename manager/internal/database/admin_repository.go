@@ -8,6 +8,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	managerv1 "manager/gen/manager/v1"
+	managermodels "manager/pkg/models"
 )
 
 // AdminRepository provides database operations for admin dashboard
@@ -200,13 +201,16 @@ func (r *adminRepository) ListAllServersWithFilters(ctx context.Context, filters
 		}
 
 		details := &managerv1.ServerDetails{
-			ServerId:     loc.ServerID,
-			CustomerId:   loc.CustomerID,
-			DatacenterId: loc.DatacenterID,
-			GatewayId:    loc.RegionalGatewayID,
-			Status:       server.Status,
-			HasVnc:       server.VNCEndpoint != nil,
-			HasSol:       server.SOLEndpoint != nil,
+			ServerId:        loc.ServerID,
+			CustomerId:      loc.CustomerID,
+			DatacenterId:    loc.DatacenterID,
+			GatewayId:       loc.RegionalGatewayID,
+			Status:          server.Status,
+			HasVnc:          server.VNCEndpoint != nil,
+			HasSol:          server.SOLEndpoint != nil,
+			MaintenanceMode: server.MaintenanceMode,
+
+			DiscoveryMetadata: server.DiscoveryMetadata.ConvertToProto(),
 		}
 
 		// Set primary endpoint and protocol
@@ -306,6 +310,10 @@ func (r *adminRepository) ListAllCustomersWithCounts(ctx context.Context, pageSi
 			OnlineServerCount: int32(onlineCount),
 			IsAdmin:           c.IsAdmin,
 			CreatedAt:         timestampProto(c.CreatedAt),
+			Disabled:          c.Disabled,
+
+			MaxConcurrentSessions: int32(c.MaxConcurrentSessions),
+			MaxSessionsPerMinute:  int32(c.MaxSessionsPerMinute),
 		}
 
 		result = append(result, summary)
@@ -350,7 +358,9 @@ func (r *adminRepository) GetGatewayHealth(ctx context.Context) ([]*managerv1.Ga
 		// Determine gateway status based on last_seen and status field
 		status := "offline"
 		activeThreshold := time.Now().UTC().Add(-1 * time.Minute)
-		if g.Status == "active" && g.LastSeen.After(activeThreshold) {
+		if g.Status == managermodels.GatewayStatusPending {
+			status = managermodels.GatewayStatusPending
+		} else if g.Status == "active" && g.LastSeen.After(activeThreshold) {
 			status = "active"
 		} else if g.Status == "active" {
 			status = "degraded"
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"core/domain"
+	commonv1 "core/gen/common/v1"
 	"core/types"
 	"manager/pkg/models"
 )
@@ -97,6 +98,10 @@ func TestAdminRepository_ListAllServersWithFilters(t *testing.T) {
 		PrimaryProtocol: types.BMCTypeIPMI,
 		Features:        []string{"power"},
 		Status:          "active",
+		DiscoveryMetadata: &types.DiscoveryMetadata{
+			DiscoveryMethod: types.DiscoveryMethodStaticConfig,
+			Vendor:          &types.VendorInfo{Manufacturer: "Dell", FirmwareVersion: "7.00.00"},
+		},
 	}
 	require.NoError(t, db.Servers.Create(context.Background(), server))
 
@@ -135,6 +140,9 @@ func TestAdminRepository_ListAllServersWithFilters(t *testing.T) {
 	assert.Equal(t, "server1", servers[0].ServerId)
 	assert.Equal(t, "customer1", servers[0].CustomerId)
 	assert.Equal(t, "active", servers[0].Status)
+	require.NotNil(t, servers[0].DiscoveryMetadata)
+	assert.Equal(t, commonv1.DiscoveryMethod_DISCOVERY_METHOD_STATIC_CONFIG, servers[0].DiscoveryMetadata.DiscoveryMethod)
+	assert.Equal(t, "7.00.00", servers[0].DiscoveryMetadata.Vendor.FirmwareVersion)
 }
 
 func TestAdminRepository_ListAllCustomersWithCounts(t *testing.T) {
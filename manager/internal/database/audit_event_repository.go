@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AuditEventFilter selects audit events. Empty fields match all events.
+type AuditEventFilter struct {
+	Actor    string
+	Action   string
+	TargetID string
+	Since    time.Time // Only events after this time
+	Limit    int       // At most this many events, 0 for all
+}
+
+// AuditEventRepository provides database operations for the audit log of
+// the changes made by admins
+type AuditEventRepository interface {
+	// Record stores an event, setting its ID
+	Record(ctx context.Context, event *AuditEvent) error
+
+	// List returns the events matching filter, newest first
+	List(ctx context.Context, filter AuditEventFilter) ([]*AuditEvent, error)
+}
+
+type auditEventRepository struct {
+	db *bun.DB
+}
+
+// NewAuditEventRepository creates a new audit event repository
+func NewAuditEventRepository(db *bun.DB) AuditEventRepository {
+	return &auditEventRepository{db: db}
+}
+
+func (r *auditEventRepository) Record(ctx context.Context, event *AuditEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	event.OccurredAt = event.OccurredAt.UTC()
+
+	_, err := r.db.NewInsert().Model(event).Exec(ctx)
+	return err
+}
+
+func (r *auditEventRepository) List(ctx context.Context, filter AuditEventFilter) ([]*AuditEvent, error) {
+	query := r.db.NewSelect().
+		Model((*AuditEvent)(nil)).
+		Order("occurred_at DESC", "id DESC")
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("occurred_at > ?", filter.Since.UTC())
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []*AuditEvent
+	if err := query.Scan(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	events := []*AuditEvent{
+		{OccurredAt: start, Actor: "admin@example.com", Action: "customer.create", TargetType: "customer", TargetID: "cust-1"},
+		{OccurredAt: start.Add(time.Hour), Actor: "ops@example.com", Action: "server.assign", TargetType: "server", TargetID: "srv-a",
+			Details: map[string]string{"customer_id": "cust-1"}},
+		{OccurredAt: start.Add(2 * time.Hour), Actor: "admin@example.com", Action: "customer.api_key", TargetType: "customer", TargetID: "cust-1"},
+	}
+	for _, event := range events {
+		require.NoError(t, db.AuditEvents.Record(ctx, event))
+		assert.NotZero(t, event.ID)
+	}
+
+	// Newest first
+	listed, err := db.AuditEvents.List(ctx, AuditEventFilter{})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, "customer.api_key", listed[0].Action)
+	assert.Equal(t, "cust-1", listed[1].Details["customer_id"])
+
+	tests := []struct {
+		name   string
+		filter AuditEventFilter
+		want   int
+	}{
+		{"actor", AuditEventFilter{Actor: "admin@example.com"}, 2},
+		{"action", AuditEventFilter{Action: "server.assign"}, 1},
+		{"target", AuditEventFilter{TargetID: "cust-1"}, 2},
+		{"since", AuditEventFilter{Since: start.Add(30 * time.Minute)}, 2},
+		{"limit", AuditEventFilter{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed, err := db.AuditEvents.List(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Len(t, listed, tt.want)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+)
+
+// ConnectivityAuditRepository provides database operations for the last
+// connectivity audit of each server
+type ConnectivityAuditRepository interface {
+	// Record replaces the last audit of a server. The time it was last
+	// verified is kept when the audit failed.
+	Record(ctx context.Context, audit *ConnectivityAudit) error
+
+	// Get returns the last audit of a server
+	Get(ctx context.Context, serverID string) (*ConnectivityAudit, error)
+
+	// List returns the last audits, ordered by server. An empty customerID
+	// includes all customers.
+	List(ctx context.Context, customerID string, failuresOnly bool) ([]*ConnectivityAudit, error)
+
+	// DeleteExcept removes the audits of the servers not in serverIDs
+	DeleteExcept(ctx context.Context, serverIDs []string) (int64, error)
+}
+
+type connectivityAuditRepository struct {
+	db *bun.DB
+}
+
+// NewConnectivityAuditRepository creates a new connectivity audit repository
+func NewConnectivityAuditRepository(db *bun.DB) ConnectivityAuditRepository {
+	return &connectivityAuditRepository{db: db}
+}
+
+func (r *connectivityAuditRepository) Record(ctx context.Context, audit *ConnectivityAudit) error {
+	audit.CheckedAt = audit.CheckedAt.UTC()
+	if audit.Healthy {
+		audit.VerifiedAt = audit.CheckedAt
+	} else if previous, err := r.Get(ctx, audit.ServerID); err == nil {
+		audit.VerifiedAt = previous.VerifiedAt
+	}
+
+	_, err := r.db.NewInsert().
+		Model(audit).
+		On("CONFLICT (server_id) DO UPDATE").
+		Exec(ctx)
+	return err
+}
+
+func (r *connectivityAuditRepository) Get(ctx context.Context, serverID string) (*ConnectivityAudit, error) {
+	audit := new(ConnectivityAudit)
+	err := r.db.NewSelect().
+		Model(audit).
+		Where("server_id = ?", serverID).
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("connectivity audit not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+func (r *connectivityAuditRepository) List(ctx context.Context, customerID string, failuresOnly bool) ([]*ConnectivityAudit, error) {
+	query := r.db.NewSelect().
+		Model((*ConnectivityAudit)(nil)).
+		Order("server_id ASC")
+
+	if customerID != "" {
+		query = query.Where("customer_id = ?", customerID)
+	}
+	if failuresOnly {
+		query = query.Where("healthy = ?", false)
+	}
+
+	var audits []*ConnectivityAudit
+	if err := query.Scan(ctx, &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+func (r *connectivityAuditRepository) DeleteExcept(ctx context.Context, serverIDs []string) (int64, error) {
+	query := r.db.NewDelete().
+		Model((*ConnectivityAudit)(nil))
+	if len(serverIDs) > 0 {
+		query = query.Where("server_id NOT IN (?)", bun.In(serverIDs))
+	} else {
+		query = query.Where("1 = 1")
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
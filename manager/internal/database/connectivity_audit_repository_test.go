@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectivityAuditRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	_, err := db.ConnectivityAudits.Get(ctx, "server-1")
+	require.Error(t, err)
+	assert.Equal(t, "connectivity audit not found", err.Error())
+
+	verifiedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, db.ConnectivityAudits.Record(ctx, &ConnectivityAudit{
+		ServerID:   "server-1",
+		CustomerID: "cust-1",
+		CheckedAt:  verifiedAt,
+		Healthy:    true,
+		Hops: []*ConnectivityAuditHop{
+			{Name: "agent", Status: "ok", Target: "agent-1", LatencyMs: 3},
+			{Name: "bmc_control", Status: "ok", Target: "192.168.1.100:623", LatencyMs: 120},
+		},
+	}))
+	require.NoError(t, db.ConnectivityAudits.Record(ctx, &ConnectivityAudit{
+		ServerID:   "server-2",
+		CustomerID: "cust-2",
+		CheckedAt:  verifiedAt,
+		Healthy:    true,
+	}))
+
+	audit, err := db.ConnectivityAudits.Get(ctx, "server-1")
+	require.NoError(t, err)
+	assert.True(t, audit.VerifiedAt.Equal(verifiedAt))
+	require.Len(t, audit.Hops, 2)
+	assert.Equal(t, "192.168.1.100:623", audit.Hops[1].Target)
+
+	// A failed audit keeps the time the server was last verified
+	require.NoError(t, db.ConnectivityAudits.Record(ctx, &ConnectivityAudit{
+		ServerID:   "server-1",
+		CustomerID: "cust-1",
+		CheckedAt:  time.Now(),
+		FailedHop:  "bmc_control",
+		Error:      "authentication failed",
+	}))
+	audit, err = db.ConnectivityAudits.Get(ctx, "server-1")
+	require.NoError(t, err)
+	assert.False(t, audit.Healthy)
+	assert.Equal(t, "bmc_control", audit.FailedHop)
+	assert.True(t, audit.VerifiedAt.Equal(verifiedAt))
+	assert.True(t, audit.CheckedAt.After(verifiedAt))
+
+	failures, err := db.ConnectivityAudits.List(ctx, "", true)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "server-1", failures[0].ServerID)
+
+	audits, err := db.ConnectivityAudits.List(ctx, "cust-2", false)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	assert.Equal(t, "server-2", audits[0].ServerID)
+
+	// Audits of servers no longer registered are removed
+	deleted, err := db.ConnectivityAudits.DeleteExcept(ctx, []string{"server-2"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	audits, err = db.ConnectivityAudits.List(ctx, "", false)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+}
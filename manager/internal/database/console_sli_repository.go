@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ConsoleSLIRepository provides database operations for console SLI measurements
+type ConsoleSLIRepository interface {
+	// Record stores console session measurements reported by a gateway
+	Record(ctx context.Context, sessions []*ConsoleSessionSLI) error
+
+	// Aggregate sums measurements started at or after since, grouped by
+	// gateway and agent. Sessions whose first byte arrived within
+	// ttfbThreshold are counted as within threshold. An empty gatewayID
+	// includes all gateways.
+	Aggregate(ctx context.Context, since time.Time, ttfbThreshold time.Duration, gatewayID string) ([]*ConsoleSLIAggregate, error)
+
+	// DeleteBefore removes measurements started before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ConsoleSLIAggregate holds SLI counters for one gateway/agent pair
+type ConsoleSLIAggregate struct {
+	GatewayID           string `bun:"gateway_id"`
+	AgentID             string `bun:"agent_id"`
+	Attempts            int64  `bun:"attempts"`
+	Successes           int64  `bun:"successes"`
+	TTFBSamples         int64  `bun:"ttfb_samples"`
+	TTFBWithinThreshold int64  `bun:"ttfb_within_threshold"`
+	TTFBSumMs           int64  `bun:"ttfb_sum_ms"`
+}
+
+type consoleSLIRepository struct {
+	db *bun.DB
+}
+
+// NewConsoleSLIRepository creates a new console SLI repository
+func NewConsoleSLIRepository(db *bun.DB) ConsoleSLIRepository {
+	return &consoleSLIRepository{db: db}
+}
+
+func (r *consoleSLIRepository) Record(ctx context.Context, sessions []*ConsoleSessionSLI) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	for _, s := range sessions {
+		toUTC(&s.StartedAt)
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&sessions).
+		Exec(ctx)
+	return err
+}
+
+func (r *consoleSLIRepository) Aggregate(ctx context.Context, since time.Time, ttfbThreshold time.Duration, gatewayID string) ([]*ConsoleSLIAggregate, error) {
+	query := r.db.NewSelect().
+		Model((*ConsoleSessionSLI)(nil)).
+		Column("gateway_id", "agent_id").
+		ColumnExpr("COUNT(*) AS attempts").
+		ColumnExpr("SUM(CASE WHEN established THEN 1 ELSE 0 END) AS successes").
+		ColumnExpr("SUM(CASE WHEN first_byte THEN 1 ELSE 0 END) AS ttfb_samples").
+		ColumnExpr("SUM(CASE WHEN first_byte AND time_to_first_byte_ms <= ? THEN 1 ELSE 0 END) AS ttfb_within_threshold", ttfbThreshold.Milliseconds()).
+		ColumnExpr("SUM(CASE WHEN first_byte THEN time_to_first_byte_ms ELSE 0 END) AS ttfb_sum_ms").
+		Where("started_at >= ?", since.UTC()).
+		Group("gateway_id", "agent_id").
+		Order("gateway_id ASC", "agent_id ASC")
+
+	if gatewayID != "" {
+		query = query.Where("gateway_id = ?", gatewayID)
+	}
+
+	var aggregates []*ConsoleSLIAggregate
+	if err := query.Scan(ctx, &aggregates); err != nil {
+		return nil, err
+	}
+	return aggregates, nil
+}
+
+func (r *consoleSLIRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*ConsoleSessionSLI)(nil)).
+		Where("started_at < ?", before.UTC()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
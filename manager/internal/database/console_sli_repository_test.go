@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleSLIRepository_Aggregate(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	sessions := []*ConsoleSessionSLI{
+		{GatewayID: "gw-1", AgentID: "agent-a", SessionType: "sol", StartedAt: now.Add(-time.Hour), Established: true, FirstByte: true, TimeToFirstByteMs: 500},
+		{GatewayID: "gw-1", AgentID: "agent-a", SessionType: "sol", StartedAt: now.Add(-time.Hour), Established: true, FirstByte: true, TimeToFirstByteMs: 3000},
+		{GatewayID: "gw-1", AgentID: "agent-a", SessionType: "vnc", StartedAt: now.Add(-time.Hour), Established: true},
+		{GatewayID: "gw-1", AgentID: "agent-a", SessionType: "sol", StartedAt: now.Add(-time.Hour), Error: "agent not available"},
+		{GatewayID: "gw-1", AgentID: "agent-b", SessionType: "sol", StartedAt: now.Add(-time.Minute), Established: true, FirstByte: true, TimeToFirstByteMs: 100},
+		{GatewayID: "gw-2", AgentID: "agent-c", SessionType: "sol", StartedAt: now.Add(-48 * time.Hour), Error: "outside window"},
+	}
+	require.NoError(t, db.ConsoleSLIs.Record(ctx, sessions))
+
+	aggregates, err := db.ConsoleSLIs.Aggregate(ctx, now.Add(-24*time.Hour), 2*time.Second, "")
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+
+	assert.Equal(t, &ConsoleSLIAggregate{
+		GatewayID:           "gw-1",
+		AgentID:             "agent-a",
+		Attempts:            4,
+		Successes:           3,
+		TTFBSamples:         2,
+		TTFBWithinThreshold: 1,
+		TTFBSumMs:           3500,
+	}, aggregates[0])
+	assert.Equal(t, "agent-b", aggregates[1].AgentID)
+	assert.Equal(t, int64(1), aggregates[1].TTFBWithinThreshold)
+
+	// Gateway filter
+	aggregates, err = db.ConsoleSLIs.Aggregate(ctx, now.Add(-72*time.Hour), 2*time.Second, "gw-2")
+	require.NoError(t, err)
+	require.Len(t, aggregates, 1)
+	assert.Equal(t, int64(0), aggregates[0].Successes)
+
+	// Retention
+	deleted, err := db.ConsoleSLIs.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	aggregates, err = db.ConsoleSLIs.Aggregate(ctx, now.Add(-72*time.Hour), 2*time.Second, "gw-2")
+	require.NoError(t, err)
+	assert.Empty(t, aggregates)
+}
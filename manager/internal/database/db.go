@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/uptrace/bun"
@@ -24,6 +26,25 @@ type BunDB struct {
 	Locations ServerLocationRepository
 	Sessions  ProxySessionRepository
 	Admin     AdminRepository
+
+	ConsoleSLIs       ConsoleSLIRepository
+	WebhookDeliveries WebhookDeliveryRepository
+	PowerReadings     PowerReadingRepository
+
+	MaintenanceWindows MaintenanceWindowRepository
+	UsageRecords       UsageRecordRepository
+	AuditEvents        AuditEventRepository
+	TokenRevocations   TokenRevocationRepository
+	ConnectivityAudits ConnectivityAuditRepository
+}
+
+// toUTC converts timestamps to UTC before they are stored. SQLite compares
+// timestamps as text, so rows and the bounds of the queries comparing them
+// must all be in the same zone.
+func toUTC(times ...*time.Time) {
+	for _, t := range times {
+		*t = t.UTC()
+	}
 }
 
 // Option is a functional option for configuring the database
@@ -69,6 +90,14 @@ func New(dbPath string, opts ...Option) (*BunDB, error) {
 	bunDB.Locations = NewServerLocationRepository(db)
 	bunDB.Sessions = NewProxySessionRepository(db)
 	bunDB.Admin = NewAdminRepository(db)
+	bunDB.ConsoleSLIs = NewConsoleSLIRepository(db)
+	bunDB.WebhookDeliveries = NewWebhookDeliveryRepository(db)
+	bunDB.PowerReadings = NewPowerReadingRepository(db)
+	bunDB.MaintenanceWindows = NewMaintenanceWindowRepository(db)
+	bunDB.UsageRecords = NewUsageRecordRepository(db)
+	bunDB.AuditEvents = NewAuditEventRepository(db)
+	bunDB.TokenRevocations = NewTokenRevocationRepository(db)
+	bunDB.ConnectivityAudits = NewConnectivityAuditRepository(db)
 
 	// Run migrations
 	if err := bunDB.Migrate(context.Background()); err != nil {
@@ -101,6 +130,14 @@ func (db *BunDB) Migrate(ctx context.Context) error {
 		(*ProxySession)(nil),
 		(*RegionalGateway)(nil),
 		(*ServerLocation)(nil),
+		(*ConsoleSessionSLI)(nil),
+		(*WebhookDelivery)(nil),
+		(*PowerReading)(nil),
+		(*MaintenanceWindow)(nil),
+		(*UsageRecord)(nil),
+		(*AuditEvent)(nil),
+		(*TokenRevocation)(nil),
+		(*ConnectivityAudit)(nil),
 	}
 
 	for _, model := range models {
@@ -112,12 +149,41 @@ func (db *BunDB) Migrate(ctx context.Context) error {
 		}
 	}
 
+	// Add columns introduced after tables were first created
+	alterStatements := []string{
+		"ALTER TABLE servers ADD COLUMN external_id VARCHAR",
+		"ALTER TABLE customers ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false",
+		"ALTER TABLE servers ADD COLUMN maintenance_mode BOOLEAN NOT NULL DEFAULT false",
+		"ALTER TABLE servers ADD COLUMN notes VARCHAR",
+		"ALTER TABLE customers ADD COLUMN max_concurrent_sessions INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE customers ADD COLUMN max_sessions_per_minute INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE customers ADD COLUMN password_hash VARCHAR",
+		"ALTER TABLE customers ADD COLUMN password_changed_at TIMESTAMP",
+		"ALTER TABLE customers ADD COLUMN failed_logins INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE customers ADD COLUMN locked_until TIMESTAMP",
+		"ALTER TABLE customers ADD COLUMN password_reset_hash VARCHAR",
+		"ALTER TABLE customers ADD COLUMN password_reset_expires_at TIMESTAMP",
+		"ALTER TABLE customers ADD COLUMN totp_secret VARCHAR",
+		"ALTER TABLE customers ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT false",
+		"ALTER TABLE customers ADD COLUMN totp_last_step INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE customers ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT false",
+		"ALTER TABLE customers ADD COLUMN allowed_source_ranges VARCHAR",
+	}
+
+	for _, stmt := range alterStatements {
+		// Ignore errors if columns already exist
+		if _, err := db.db.ExecContext(ctx, stmt); err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+			return fmt.Errorf("failed to add column: %w", err)
+		}
+	}
+
 	// Create indexes for foreign keys and common queries
 	indexes := []string{
 		// Server indexes
 		"CREATE INDEX IF NOT EXISTS idx_servers_customer_id ON servers(customer_id)",
 		"CREATE INDEX IF NOT EXISTS idx_servers_datacenter_id ON servers(datacenter_id)",
 		"CREATE INDEX IF NOT EXISTS idx_servers_status ON servers(status)",
+		"CREATE INDEX IF NOT EXISTS idx_servers_external_id ON servers(customer_id, external_id)",
 
 		// ServerLocation indexes
 		"CREATE INDEX IF NOT EXISTS idx_server_locations_gateway_id ON server_locations(regional_gateway_id)",
@@ -142,6 +208,32 @@ func (db *BunDB) Migrate(ctx context.Context) error {
 		// Gateway indexes
 		"CREATE INDEX IF NOT EXISTS idx_regional_gateways_region ON regional_gateways(region)",
 		"CREATE INDEX IF NOT EXISTS idx_regional_gateways_status ON regional_gateways(status)",
+
+		// Console SLI indexes
+		"CREATE INDEX IF NOT EXISTS idx_console_session_slis_started_at ON console_session_slis(started_at)",
+		"CREATE INDEX IF NOT EXISTS idx_console_session_slis_gateway_agent ON console_session_slis(gateway_id, agent_id)",
+
+		// Webhook delivery indexes
+		"CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_attempted_at ON webhook_deliveries(attempted_at)",
+		"CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(event_id)",
+
+		// Power reading indexes
+		"CREATE INDEX IF NOT EXISTS idx_power_readings_sampled_at ON power_readings(sampled_at)",
+		"CREATE INDEX IF NOT EXISTS idx_power_readings_customer_server ON power_readings(customer_id, server_id, sampled_at)",
+
+		// Maintenance window indexes
+		"CREATE INDEX IF NOT EXISTS idx_maintenance_windows_ends_at ON maintenance_windows(ends_at)",
+
+		// Usage record indexes
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_usage_records_event_id ON usage_records(event_id)",
+		"CREATE INDEX IF NOT EXISTS idx_usage_records_occurred_at ON usage_records(occurred_at)",
+
+		// Audit event indexes
+		"CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events(occurred_at)",
+		"CREATE INDEX IF NOT EXISTS idx_audit_events_target_id ON audit_events(target_id)",
+
+		// Connectivity audit indexes
+		"CREATE INDEX IF NOT EXISTS idx_connectivity_audits_customer_id ON connectivity_audits(customer_id)",
 	}
 
 	for _, idx := range indexes {
@@ -160,6 +252,30 @@ func (db *BunDB) BeginTx(ctx context.Context) (bun.Tx, error) {
 	return db.db.BeginTx(ctx, nil)
 }
 
+// Tx holds the repositories that take part in transactions, see RunInTx
+type Tx struct {
+	Servers          ServerRepository
+	Customers        CustomerRepository
+	Locations        ServerLocationRepository
+	Sessions         ProxySessionRepository
+	TokenRevocations TokenRevocationRepository
+}
+
+// RunInTx runs fn with repositories bound to a transaction, committed when
+// fn returns nil and rolled back otherwise. fn must not use the repositories
+// of db, which would wait for the transaction to end.
+func (db *BunDB) RunInTx(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(ctx, &Tx{
+			Servers:          NewServerRepository(tx),
+			Customers:        NewCustomerRepository(tx),
+			Locations:        NewServerLocationRepository(tx),
+			Sessions:         NewProxySessionRepository(tx),
+			TokenRevocations: NewTokenRevocationRepository(tx),
+		})
+	})
+}
+
 // Clean removes all data from all tables (useful for development/testing)
 // WARNING: This will delete ALL data in the database!
 func (db *BunDB) Clean(ctx context.Context) error {
@@ -167,6 +283,8 @@ func (db *BunDB) Clean(ctx context.Context) error {
 
 	// Delete in order to respect foreign key constraints
 	tables := []string{
+		"console_session_slis",
+		"webhook_deliveries",
 		"proxy_sessions",
 		"server_locations",
 		"servers",
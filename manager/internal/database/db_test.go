@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -137,6 +138,41 @@ func TestServerRepository_CRUD(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestServerRepository_FindByBMCEndpoint tests looking up servers by datacenter and BMC endpoint
+func TestServerRepository_FindByBMCEndpoint(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	server := &domain.Server{
+		ID:           "server-001",
+		CustomerID:   "customer-123",
+		DatacenterID: "dc-test-01",
+		ControlEndpoints: []*types.BMCControlEndpoint{
+			{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI},
+			{Endpoint: "https://192.168.1.100", Type: types.BMCTypeRedfish},
+		},
+		PrimaryProtocol: types.BMCTypeIPMI,
+		Status:          "active",
+		ExternalID:      "tf-server-001",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	require.NoError(t, db.Servers.Create(ctx, server))
+
+	// Any control endpoint matches
+	found, err := db.Servers.FindByBMCEndpoint(ctx, "dc-test-01", "https://192.168.1.100")
+	require.NoError(t, err)
+	assert.Equal(t, "server-001", found.ID)
+	assert.Equal(t, "tf-server-001", found.ExternalID)
+
+	// The same endpoint in another datacenter is another server
+	_, err = db.Servers.FindByBMCEndpoint(ctx, "dc-test-02", "192.168.1.100:623")
+	assert.EqualError(t, err, "server not found")
+
+	_, err = db.Servers.FindByBMCEndpoint(ctx, "dc-test-01", "192.168.1.101:623")
+	assert.EqualError(t, err, "server not found")
+}
+
 // TestServer_JSONFields tests that JSON fields are properly handled
 func TestServer_JSONFields(t *testing.T) {
 	db := setupTestDB(t)
@@ -405,3 +441,39 @@ func TestBunDB_Close(t *testing.T) {
 	err = db.Close()
 	assert.NoError(t, err)
 }
+
+// TestBunDB_RunInTx tests that the changes of a failed transaction are
+// rolled back
+func TestBunDB_RunInTx(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	customer := &models.Customer{ID: "cust-1", Email: "cust-1@example.com", APIKey: "key-1", CreatedAt: time.Now()}
+	require.NoError(t, db.Customers.Create(ctx, customer))
+
+	errAbort := errors.New("abort")
+	err := db.RunInTx(ctx, func(ctx context.Context, tx *Tx) error {
+		require.NoError(t, tx.TokenRevocations.Revoke(ctx, customer.ID, "deleted"))
+		require.NoError(t, tx.Customers.Delete(ctx, customer.ID))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = db.Customers.Get(ctx, customer.ID)
+	assert.NoError(t, err, "the deletion should be rolled back")
+	_, err = db.TokenRevocations.Get(ctx, customer.ID)
+	assert.Error(t, err, "the revocation should be rolled back")
+
+	err = db.RunInTx(ctx, func(ctx context.Context, tx *Tx) error {
+		if err := tx.TokenRevocations.Revoke(ctx, customer.ID, "deleted"); err != nil {
+			return err
+		}
+		return tx.Customers.Delete(ctx, customer.ID)
+	})
+	require.NoError(t, err)
+
+	_, err = db.Customers.Get(ctx, customer.ID)
+	assert.Error(t, err)
+	_, err = db.TokenRevocations.Get(ctx, customer.ID)
+	assert.NoError(t, err)
+}
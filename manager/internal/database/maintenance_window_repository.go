@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"core/domain"
+)
+
+// MaintenanceWindowRepository provides database operations for scheduled
+// maintenance windows
+type MaintenanceWindowRepository interface {
+	// Create stores a window, setting its ID
+	Create(ctx context.Context, window *domain.MaintenanceWindow) error
+
+	// List returns the windows ending after the given time, ordered by start
+	// time. A zero time includes past windows.
+	List(ctx context.Context, endsAfter time.Time) ([]*domain.MaintenanceWindow, error)
+
+	// Delete removes a window, reporting whether it existed
+	Delete(ctx context.Context, id int64) (bool, error)
+}
+
+type maintenanceWindowRepository struct {
+	db *bun.DB
+}
+
+// NewMaintenanceWindowRepository creates a new maintenance window repository
+func NewMaintenanceWindowRepository(db *bun.DB) MaintenanceWindowRepository {
+	return &maintenanceWindowRepository{db: db}
+}
+
+func (r *maintenanceWindowRepository) Create(ctx context.Context, window *domain.MaintenanceWindow) error {
+	toUTC(&window.StartsAt, &window.EndsAt)
+
+	model := MaintenanceWindowFromModel(window)
+	if _, err := r.db.NewInsert().Model(model).Exec(ctx); err != nil {
+		return err
+	}
+	window.ID = model.ID
+	window.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *maintenanceWindowRepository) List(ctx context.Context, endsAfter time.Time) ([]*domain.MaintenanceWindow, error) {
+	query := r.db.NewSelect().
+		Model((*MaintenanceWindow)(nil)).
+		Order("starts_at ASC", "id ASC")
+
+	if !endsAfter.IsZero() {
+		query = query.Where("ends_at > ?", endsAfter.UTC())
+	}
+
+	var models []*MaintenanceWindow
+	if err := query.Scan(ctx, &models); err != nil {
+		return nil, err
+	}
+
+	windows := make([]*domain.MaintenanceWindow, 0, len(models))
+	for _, model := range models {
+		windows = append(windows, model.ToModel())
+	}
+	return windows, nil
+}
+
+func (r *maintenanceWindowRepository) Delete(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.NewDelete().
+		Model((*MaintenanceWindow)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, err
+}
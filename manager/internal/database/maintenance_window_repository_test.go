@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+)
+
+func TestMaintenanceWindowRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	windows := []*domain.MaintenanceWindow{
+		{ServerID: "srv-a", Policy: domain.MaintenancePolicyReject, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Reason: "PSU replacement"},
+		{Labels: map[string]string{"rack": "r1"}, Policy: domain.MaintenancePolicyRequireOverride, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), CreatedBy: "admin@example.com"},
+		{ServerID: "srv-b", Policy: domain.MaintenancePolicyReject, StartsAt: now.Add(-3 * time.Hour), EndsAt: now.Add(-2 * time.Hour)},
+	}
+	for _, window := range windows {
+		require.NoError(t, db.MaintenanceWindows.Create(ctx, window))
+		assert.NotZero(t, window.ID)
+	}
+
+	// Ended windows are left out, the others are ordered by start time
+	listed, err := db.MaintenanceWindows.List(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, windows[1].ID, listed[0].ID)
+	assert.Equal(t, map[string]string{"rack": "r1"}, listed[0].Labels)
+	assert.Equal(t, domain.MaintenancePolicyRequireOverride, listed[0].Policy)
+	assert.Equal(t, "admin@example.com", listed[0].CreatedBy)
+	assert.Equal(t, "PSU replacement", listed[1].Reason)
+	assert.True(t, listed[1].StartsAt.Equal(now.Add(time.Hour)))
+
+	listed, err = db.MaintenanceWindows.List(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+
+	deleted, err := db.MaintenanceWindows.Delete(ctx, windows[0].ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = db.MaintenanceWindows.Delete(ctx, windows[0].ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
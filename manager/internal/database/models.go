@@ -18,8 +18,26 @@ type Customer struct {
 	Email     string    `bun:"email,unique,notnull"`
 	APIKey    string    `bun:"api_key,unique,notnull"`
 	IsAdmin   bool      `bun:"is_admin,notnull,default:false"`
+	Disabled  bool      `bun:"disabled,notnull,default:false"`
 	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 
+	MaxConcurrentSessions int `bun:"max_concurrent_sessions,notnull,default:0"`
+	MaxSessionsPerMinute  int `bun:"max_sessions_per_minute,notnull,default:0"`
+
+	PasswordHash           string    `bun:"password_hash,nullzero"`
+	PasswordChangedAt      time.Time `bun:"password_changed_at,nullzero"`
+	FailedLogins           int       `bun:"failed_logins,notnull,default:0"`
+	LockedUntil            time.Time `bun:"locked_until,nullzero"`
+	PasswordResetHash      string    `bun:"password_reset_hash,nullzero"`
+	PasswordResetExpiresAt time.Time `bun:"password_reset_expires_at,nullzero"`
+
+	TOTPSecret   string `bun:"totp_secret,nullzero"`
+	TOTPEnabled  bool   `bun:"totp_enabled,notnull,default:false"`
+	TOTPLastStep int64  `bun:"totp_last_step,notnull,default:0"`
+	RequireMFA   bool   `bun:"require_mfa,notnull,default:false"`
+
+	AllowedSourceRanges []string `bun:"allowed_source_ranges,type:json"`
+
 	// Relations
 	Servers []*Server `bun:"rel:has-many,join:id=customer_id"`
 }
@@ -31,7 +49,25 @@ func (c *Customer) ToModel() *models.Customer {
 		Email:     c.Email,
 		APIKey:    c.APIKey,
 		IsAdmin:   c.IsAdmin,
+		Disabled:  c.Disabled,
 		CreatedAt: c.CreatedAt,
+
+		MaxConcurrentSessions: c.MaxConcurrentSessions,
+		MaxSessionsPerMinute:  c.MaxSessionsPerMinute,
+
+		PasswordHash:           c.PasswordHash,
+		PasswordChangedAt:      c.PasswordChangedAt,
+		FailedLogins:           c.FailedLogins,
+		LockedUntil:            c.LockedUntil,
+		PasswordResetHash:      c.PasswordResetHash,
+		PasswordResetExpiresAt: c.PasswordResetExpiresAt,
+
+		TOTPSecret:   c.TOTPSecret,
+		TOTPEnabled:  c.TOTPEnabled,
+		TOTPLastStep: c.TOTPLastStep,
+		RequireMFA:   c.RequireMFA,
+
+		AllowedSourceRanges: c.AllowedSourceRanges,
 	}
 }
 
@@ -42,7 +78,25 @@ func CustomerFromModel(m *models.Customer) *Customer {
 		Email:     m.Email,
 		APIKey:    m.APIKey,
 		IsAdmin:   m.IsAdmin,
+		Disabled:  m.Disabled,
 		CreatedAt: m.CreatedAt,
+
+		MaxConcurrentSessions: m.MaxConcurrentSessions,
+		MaxSessionsPerMinute:  m.MaxSessionsPerMinute,
+
+		PasswordHash:           m.PasswordHash,
+		PasswordChangedAt:      m.PasswordChangedAt,
+		FailedLogins:           m.FailedLogins,
+		LockedUntil:            m.LockedUntil,
+		PasswordResetHash:      m.PasswordResetHash,
+		PasswordResetExpiresAt: m.PasswordResetExpiresAt,
+
+		TOTPSecret:   m.TOTPSecret,
+		TOTPEnabled:  m.TOTPEnabled,
+		TOTPLastStep: m.TOTPLastStep,
+		RequireMFA:   m.RequireMFA,
+
+		AllowedSourceRanges: m.AllowedSourceRanges,
 	}
 }
 
@@ -61,6 +115,9 @@ type Server struct {
 	VNCEndpoint       *types.VNCEndpoint          `bun:"vnc_endpoint,type:json"`
 	Metadata          map[string]string           `bun:"metadata,type:json"`
 	DiscoveryMetadata *types.DiscoveryMetadata    `bun:"discovery_metadata,type:json"`
+	ExternalID        string                      `bun:"external_id"`
+	MaintenanceMode   bool                        `bun:"maintenance_mode,notnull,default:false"`
+	Notes             string                      `bun:"notes"`
 	CreatedAt         time.Time                   `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt         time.Time                   `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 
@@ -82,6 +139,9 @@ func (s *Server) ToModel() *domain.Server {
 		VNCEndpoint:       s.VNCEndpoint,
 		Metadata:          s.Metadata,
 		DiscoveryMetadata: s.DiscoveryMetadata,
+		ExternalID:        s.ExternalID,
+		MaintenanceMode:   s.MaintenanceMode,
+		Notes:             s.Notes,
 		CreatedAt:         s.CreatedAt,
 		UpdatedAt:         s.UpdatedAt,
 	}
@@ -101,6 +161,9 @@ func ServerFromModel(m *domain.Server) *Server {
 		VNCEndpoint:       m.VNCEndpoint,
 		Metadata:          m.Metadata,
 		DiscoveryMetadata: m.DiscoveryMetadata,
+		ExternalID:        m.ExternalID,
+		MaintenanceMode:   m.MaintenanceMode,
+		Notes:             m.Notes,
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 	}
@@ -274,3 +337,173 @@ func ProxySessionFromModel(m *models.ProxySession) *ProxySession {
 		ExpiresAt:  m.ExpiresAt,
 	}
 }
+
+// ConsoleSessionSLI records the outcome of one console stream establishment
+// as reported by a gateway, used to compute console availability SLOs
+type ConsoleSessionSLI struct {
+	bun.BaseModel `bun:"table:console_session_slis"`
+
+	ID                int64     `bun:"id,pk,autoincrement"`
+	GatewayID         string    `bun:"gateway_id,notnull"`
+	AgentID           string    `bun:"agent_id,notnull"`
+	DatacenterID      string    `bun:"datacenter_id"`
+	SessionID         string    `bun:"session_id"`
+	SessionType       string    `bun:"session_type,notnull"`
+	StartedAt         time.Time `bun:"started_at,notnull"`
+	Established       bool      `bun:"established,notnull"`
+	FirstByte         bool      `bun:"first_byte,notnull"`
+	TimeToFirstByteMs int64     `bun:"time_to_first_byte_ms,notnull,default:0"`
+	Error             string    `bun:"error"`
+	CreatedAt         time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// WebhookDelivery records one attempt to deliver an event to a webhook
+// endpoint, the delivery log operators use to debug notifications
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	ID          int64     `bun:"id,pk,autoincrement"`
+	EventID     string    `bun:"event_id,notnull"`
+	EventType   string    `bun:"event_type,notnull"`
+	Endpoint    string    `bun:"endpoint,notnull"` // Configured endpoint name
+	URL         string    `bun:"url,notnull"`
+	Attempt     int       `bun:"attempt,notnull"`
+	StatusCode  int       `bun:"status_code,notnull,default:0"` // 0 when no response was received
+	Success     bool      `bun:"success,notnull"`
+	Error       string    `bun:"error"`
+	DurationMs  int64     `bun:"duration_ms,notnull,default:0"`
+	AttemptedAt time.Time `bun:"attempted_at,notnull"`
+}
+
+// PowerReading records a power consumption sample of a server reported by a
+// gateway, used for power usage reports. The customer is the owner of the
+// server when it was sampled.
+type PowerReading struct {
+	bun.BaseModel `bun:"table:power_readings"`
+
+	ID            int64     `bun:"id,pk,autoincrement"`
+	ServerID      string    `bun:"server_id,notnull"`
+	CustomerID    string    `bun:"customer_id,notnull"`
+	DatacenterID  string    `bun:"datacenter_id"`
+	GatewayID     string    `bun:"gateway_id,notnull"`
+	SampledAt     time.Time `bun:"sampled_at,notnull"`
+	ConsumedWatts float64   `bun:"consumed_watts,notnull"`
+	EnergyKWh     float64   `bun:"energy_kwh,notnull"` // Cumulative BMC counter, 0 when not metered
+}
+
+// UsageRecord records a use of a server by a customer reported by a gateway,
+// a console stream or a power operation, for usage exports. The customer is
+// the owner of the server when it was used.
+type UsageRecord struct {
+	bun.BaseModel `bun:"table:usage_records"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	EventID    string    `bun:"event_id,notnull"` // Event reporting the use, recorded once
+	Kind       string    `bun:"kind,notnull"`     // "console" or "power"
+	CustomerID string    `bun:"customer_id,notnull"`
+	ServerID   string    `bun:"server_id,notnull"`
+	GatewayID  string    `bun:"gateway_id,notnull"`
+	Operation  string    `bun:"operation"` // Power operation, or console session type
+	DurationMs int64     `bun:"duration_ms,notnull,default:0"`
+	BytesIn    int64     `bun:"bytes_in,notnull,default:0"`
+	BytesOut   int64     `bun:"bytes_out,notnull,default:0"`
+	OccurredAt time.Time `bun:"occurred_at,notnull"`
+}
+
+// MaintenanceWindow is a scheduled maintenance of a server, or of the servers
+// whose metadata holds all of its labels
+type MaintenanceWindow struct {
+	bun.BaseModel `bun:"table:maintenance_windows"`
+
+	ID        int64             `bun:"id,pk,autoincrement"`
+	ServerID  string            `bun:"server_id"`
+	Labels    map[string]string `bun:"labels,type:json"`
+	Policy    string            `bun:"policy,notnull"`
+	Reason    string            `bun:"reason"`
+	StartsAt  time.Time         `bun:"starts_at,notnull"`
+	EndsAt    time.Time         `bun:"ends_at,notnull"`
+	CreatedBy string            `bun:"created_by"`
+	CreatedAt time.Time         `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+// ToModel converts database MaintenanceWindow to domain model
+func (w *MaintenanceWindow) ToModel() *domain.MaintenanceWindow {
+	return &domain.MaintenanceWindow{
+		ID:        w.ID,
+		ServerID:  w.ServerID,
+		Labels:    w.Labels,
+		Policy:    domain.MaintenancePolicy(w.Policy),
+		Reason:    w.Reason,
+		StartsAt:  w.StartsAt,
+		EndsAt:    w.EndsAt,
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// MaintenanceWindowFromModel converts domain model to database
+// MaintenanceWindow
+func MaintenanceWindowFromModel(m *domain.MaintenanceWindow) *MaintenanceWindow {
+	return &MaintenanceWindow{
+		ID:        m.ID,
+		ServerID:  m.ServerID,
+		Labels:    m.Labels,
+		Policy:    string(m.Policy),
+		Reason:    m.Reason,
+		StartsAt:  m.StartsAt,
+		EndsAt:    m.EndsAt,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// AuditEvent is a change made by an admin, such as creating a customer or
+// approving a gateway
+type AuditEvent struct {
+	bun.BaseModel `bun:"table:audit_events"`
+
+	ID         int64             `bun:"id,pk,autoincrement"`
+	OccurredAt time.Time         `bun:"occurred_at,notnull"`
+	Actor      string            `bun:"actor,notnull"` // Email of the admin
+	Action     string            `bun:"action,notnull"`
+	TargetType string            `bun:"target_type,notnull"`
+	TargetID   string            `bun:"target_id,notnull"`
+	Details    map[string]string `bun:"details,type:json"`
+}
+
+// TokenRevocation records when the tokens of a customer were revoked, by
+// disabling or deleting the customer. It outlives deleted customers, whose
+// tokens would otherwise pass as those of customers authenticated by email.
+type TokenRevocation struct {
+	bun.BaseModel `bun:"table:token_revocations"`
+
+	CustomerID string    `bun:"customer_id,pk"`
+	RevokedAt  time.Time `bun:"revoked_at,notnull"` // Tokens issued up to this second are rejected
+	Reason     string    `bun:"reason,notnull"`     // "disabled" or "deleted"
+}
+
+// ConnectivityAudit is the last connectivity audit of a server's BMC: the
+// check of its credentials and console endpoints through its gateway and
+// agent
+type ConnectivityAudit struct {
+	bun.BaseModel `bun:"table:connectivity_audits"`
+
+	ServerID     string                  `bun:"server_id,pk"`
+	CustomerID   string                  `bun:"customer_id,notnull"`
+	DatacenterID string                  `bun:"datacenter_id"`
+	CheckedAt    time.Time               `bun:"checked_at,notnull"`
+	VerifiedAt   time.Time               `bun:"verified_at,nullzero"` // Last audit that passed, kept when later ones fail
+	Healthy      bool                    `bun:"healthy,notnull"`
+	FailedHop    string                  `bun:"failed_hop"` // "gateway", "agent", "bmc_control", "sol" or "vnc"
+	Error        string                  `bun:"error"`
+	Hops         []*ConnectivityAuditHop `bun:"hops,type:json"`
+}
+
+// ConnectivityAuditHop is the status of one hop of a connectivity audit
+type ConnectivityAuditHop struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // "ok", "failed" or "skipped"
+	Target    string `json:"target,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
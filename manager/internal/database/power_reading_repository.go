@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// PowerReadingRepository provides database operations for server power
+// readings
+type PowerReadingRepository interface {
+	// Record stores power readings reported by a gateway
+	Record(ctx context.Context, readings []*PowerReading) error
+
+	// List returns the readings sampled in [start, end), ordered by server
+	// and sample time. An empty customerID includes all customers.
+	List(ctx context.Context, start, end time.Time, customerID string) ([]*PowerReading, error)
+
+	// DeleteBefore removes readings sampled before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type powerReadingRepository struct {
+	db *bun.DB
+}
+
+// NewPowerReadingRepository creates a new power reading repository
+func NewPowerReadingRepository(db *bun.DB) PowerReadingRepository {
+	return &powerReadingRepository{db: db}
+}
+
+func (r *powerReadingRepository) Record(ctx context.Context, readings []*PowerReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+
+	for _, reading := range readings {
+		toUTC(&reading.SampledAt)
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&readings).
+		Exec(ctx)
+	return err
+}
+
+func (r *powerReadingRepository) List(ctx context.Context, start, end time.Time, customerID string) ([]*PowerReading, error) {
+	query := r.db.NewSelect().
+		Model((*PowerReading)(nil)).
+		Where("sampled_at >= ?", start.UTC()).
+		Where("sampled_at < ?", end.UTC()).
+		Order("server_id ASC", "sampled_at ASC")
+
+	if customerID != "" {
+		query = query.Where("customer_id = ?", customerID)
+	}
+
+	var readings []*PowerReading
+	if err := query.Scan(ctx, &readings); err != nil {
+		return nil, err
+	}
+	return readings, nil
+}
+
+func (r *powerReadingRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*PowerReading)(nil)).
+		Where("sampled_at < ?", before.UTC()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPowerReadingRepository_List(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	readings := []*PowerReading{
+		{ServerID: "srv-b", CustomerID: "cust-1", GatewayID: "gw-1", SampledAt: now.Add(-time.Hour), ConsumedWatts: 200},
+		{ServerID: "srv-a", CustomerID: "cust-1", GatewayID: "gw-1", SampledAt: now.Add(-time.Hour), ConsumedWatts: 300, EnergyKWh: 10.5},
+		{ServerID: "srv-a", CustomerID: "cust-1", GatewayID: "gw-1", SampledAt: now.Add(-2 * time.Hour), ConsumedWatts: 310, EnergyKWh: 10.2},
+		{ServerID: "srv-c", CustomerID: "cust-2", GatewayID: "gw-2", SampledAt: now.Add(-time.Minute), ConsumedWatts: 150},
+		{ServerID: "srv-c", CustomerID: "cust-2", GatewayID: "gw-2", SampledAt: now.Add(-48 * time.Hour), ConsumedWatts: 140},
+	}
+	require.NoError(t, db.PowerReadings.Record(ctx, readings))
+
+	listed, err := db.PowerReadings.List(ctx, now.Add(-24*time.Hour), now, "")
+	require.NoError(t, err)
+	require.Len(t, listed, 4)
+
+	// Ordered by server, then sample time
+	assert.Equal(t, "srv-a", listed[0].ServerID)
+	assert.Equal(t, 310.0, listed[0].ConsumedWatts)
+	assert.Equal(t, 10.5, listed[1].EnergyKWh)
+	assert.Equal(t, "srv-b", listed[2].ServerID)
+	assert.Equal(t, "srv-c", listed[3].ServerID)
+
+	// Customer filter
+	listed, err = db.PowerReadings.List(ctx, now.Add(-72*time.Hour), now, "cust-2")
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+
+	// Retention
+	deleted, err := db.PowerReadings.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	listed, err = db.PowerReadings.List(ctx, now.Add(-72*time.Hour), now, "cust-2")
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
@@ -15,6 +15,7 @@ import (
 // ServerRepository provides database operations for servers
 type ServerRepository interface {
 	Get(ctx context.Context, id string) (*domain.Server, error)
+	FindByBMCEndpoint(ctx context.Context, datacenterID, endpoint string) (*domain.Server, error)
 	List(ctx context.Context, customerID string) ([]*domain.Server, error)
 	ListAll(ctx context.Context) ([]*domain.Server, error)
 	Create(ctx context.Context, server *domain.Server) error
@@ -23,11 +24,11 @@ type ServerRepository interface {
 }
 
 type serverRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewServerRepository creates a new server repository
-func NewServerRepository(db *bun.DB) ServerRepository {
+func NewServerRepository(db bun.IDB) ServerRepository {
 	return &serverRepository{db: db}
 }
 
@@ -48,6 +49,28 @@ func (r *serverRepository) Get(ctx context.Context, id string) (*domain.Server,
 	return server.ToModel(), nil
 }
 
+// FindByBMCEndpoint returns the server of a datacenter with a control
+// endpoint at the given address
+func (r *serverRepository) FindByBMCEndpoint(ctx context.Context, datacenterID, endpoint string) (*domain.Server, error) {
+	server := new(Server)
+	err := r.db.NewSelect().
+		Model(server).
+		Where("datacenter_id = ?", datacenterID).
+		Where("EXISTS (SELECT 1 FROM json_each(control_endpoints) WHERE json_extract(value, '$.endpoint') = ?)", endpoint).
+		Order("created_at ASC").
+		Limit(1).
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("server not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return server.ToModel(), nil
+}
+
 func (r *serverRepository) List(ctx context.Context, customerID string) ([]*domain.Server, error) {
 	var servers []*Server
 	err := r.db.NewSelect().
@@ -113,7 +136,7 @@ func (r *serverRepository) Delete(ctx context.Context, id string) error {
 // CustomerRepository provides database operations for customers
 type CustomerRepository interface {
 	Get(ctx context.Context, id string) (*managermodels.Customer, error)
-	GetByEmail(ctx context.Context, email string) (*managermodels.Customer, error)
+	GetByEmail(ctx context.Context, email string) (*managermodels.Customer, error) // Emails are case-insensitive
 	GetByAPIKey(ctx context.Context, apiKey string) (*managermodels.Customer, error)
 	Create(ctx context.Context, customer *managermodels.Customer) error
 	Update(ctx context.Context, customer *managermodels.Customer) error
@@ -121,11 +144,11 @@ type CustomerRepository interface {
 }
 
 type customerRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewCustomerRepository creates a new customer repository
-func NewCustomerRepository(db *bun.DB) CustomerRepository {
+func NewCustomerRepository(db bun.IDB) CustomerRepository {
 	return &customerRepository{db: db}
 }
 
@@ -150,7 +173,7 @@ func (r *customerRepository) GetByEmail(ctx context.Context, email string) (*man
 	customer := new(Customer)
 	err := r.db.NewSelect().
 		Model(customer).
-		Where("email = ?", email).
+		Where("lower(email) = lower(?)", email).
 		Scan(ctx)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -405,11 +428,11 @@ type ServerLocationRepository interface {
 }
 
 type serverLocationRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewServerLocationRepository creates a new server location repository
-func NewServerLocationRepository(db *bun.DB) ServerLocationRepository {
+func NewServerLocationRepository(db bun.IDB) ServerLocationRepository {
 	return &serverLocationRepository{db: db}
 }
 
@@ -493,11 +516,11 @@ type ProxySessionRepository interface {
 }
 
 type proxySessionRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
 // NewProxySessionRepository creates a new proxy session repository
-func NewProxySessionRepository(db *bun.DB) ProxySessionRepository {
+func NewProxySessionRepository(db bun.IDB) ProxySessionRepository {
 	return &proxySessionRepository{db: db}
 }
 
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// TokenRevocationRepository provides database operations for the revocations
+// of the tokens of customers
+type TokenRevocationRepository interface {
+	// Revoke rejects the tokens of a customer issued until now
+	Revoke(ctx context.Context, customerID, reason string) error
+
+	// Get returns the latest revocation of a customer's tokens
+	Get(ctx context.Context, customerID string) (*TokenRevocation, error)
+
+	// ListSince returns the revocations made since a time
+	ListSince(ctx context.Context, since time.Time) ([]*TokenRevocation, error)
+}
+
+type tokenRevocationRepository struct {
+	db bun.IDB
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db bun.IDB) TokenRevocationRepository {
+	return &tokenRevocationRepository{db: db}
+}
+
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, customerID, reason string) error {
+	revocation := &TokenRevocation{
+		CustomerID: customerID,
+		// Tokens carry their issue time in seconds
+		RevokedAt: time.Now().UTC().Truncate(time.Second),
+		Reason:    reason,
+	}
+	_, err := r.db.NewInsert().
+		Model(revocation).
+		On("CONFLICT (customer_id) DO UPDATE").
+		Exec(ctx)
+	return err
+}
+
+func (r *tokenRevocationRepository) Get(ctx context.Context, customerID string) (*TokenRevocation, error) {
+	revocation := new(TokenRevocation)
+	err := r.db.NewSelect().
+		Model(revocation).
+		Where("customer_id = ?", customerID).
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("token revocation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return revocation, nil
+}
+
+func (r *tokenRevocationRepository) ListSince(ctx context.Context, since time.Time) ([]*TokenRevocation, error) {
+	var revocations []*TokenRevocation
+	err := r.db.NewSelect().
+		Model(&revocations).
+		Where("revoked_at >= ?", since.UTC()).
+		Order("customer_id ASC").
+		Scan(ctx)
+	return revocations, err
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRevocationRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	_, err := db.TokenRevocations.Get(ctx, "cust-1")
+	require.Error(t, err)
+	assert.Equal(t, "token revocation not found", err.Error())
+
+	require.NoError(t, db.TokenRevocations.Revoke(ctx, "cust-1", "disabled"))
+	revocation, err := db.TokenRevocations.Get(ctx, "cust-1")
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", revocation.Reason)
+	assert.WithinDuration(t, time.Now(), revocation.RevokedAt, 2*time.Second)
+	assert.Equal(t, revocation.RevokedAt.Truncate(time.Second), revocation.RevokedAt, "tokens carry their issue time in seconds")
+
+	// Revoking again replaces the revocation
+	require.NoError(t, db.TokenRevocations.Revoke(ctx, "cust-1", "deleted"))
+	revocation, err = db.TokenRevocations.Get(ctx, "cust-1")
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", revocation.Reason)
+
+	// Revocations are listed by the time they were made
+	require.NoError(t, db.TokenRevocations.Revoke(ctx, "cust-2", "disabled"))
+	revocations, err := db.TokenRevocations.ListSince(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, revocations, 2)
+	assert.Equal(t, "cust-1", revocations[0].CustomerID)
+	assert.Equal(t, "cust-2", revocations[1].CustomerID)
+
+	revocations, err = db.TokenRevocations.ListSince(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, revocations)
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Kinds of usage records
+const (
+	UsageKindConsole = "console" // A console stream, with its duration and data transferred
+	UsageKindPower   = "power"   // A power operation proxied to a BMC
+)
+
+// UsageRecordRepository provides database operations for the usage records
+// of customers
+type UsageRecordRepository interface {
+	// Record stores usage records. Records of an event already recorded are
+	// ignored, so that events reported again by a gateway count once.
+	Record(ctx context.Context, records []*UsageRecord) error
+
+	// List returns the records of uses in [start, end), ordered by customer
+	// and time. An empty customerID includes all customers.
+	List(ctx context.Context, start, end time.Time, customerID string) ([]*UsageRecord, error)
+}
+
+type usageRecordRepository struct {
+	db *bun.DB
+}
+
+// NewUsageRecordRepository creates a new usage record repository
+func NewUsageRecordRepository(db *bun.DB) UsageRecordRepository {
+	return &usageRecordRepository{db: db}
+}
+
+func (r *usageRecordRepository) Record(ctx context.Context, records []*UsageRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	for _, record := range records {
+		toUTC(&record.OccurredAt)
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&records).
+		On("CONFLICT (event_id) DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+func (r *usageRecordRepository) List(ctx context.Context, start, end time.Time, customerID string) ([]*UsageRecord, error) {
+	query := r.db.NewSelect().
+		Model((*UsageRecord)(nil)).
+		Where("occurred_at >= ?", start.UTC()).
+		Where("occurred_at < ?", end.UTC()).
+		Order("customer_id ASC", "occurred_at ASC")
+
+	if customerID != "" {
+		query = query.Where("customer_id = ?", customerID)
+	}
+
+	var records []*UsageRecord
+	if err := query.Scan(ctx, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
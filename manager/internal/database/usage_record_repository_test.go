@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageRecordRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	june := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	records := []*UsageRecord{
+		{EventID: "evt-1", Kind: UsageKindConsole, CustomerID: "cust-2", ServerID: "srv-c", GatewayID: "gw-1", Operation: "sol", DurationMs: 60000, BytesIn: 10, BytesOut: 2000, OccurredAt: june.Add(time.Hour)},
+		{EventID: "evt-2", Kind: UsageKindPower, CustomerID: "cust-1", ServerID: "srv-a", GatewayID: "gw-1", Operation: "PowerCycle", OccurredAt: june.Add(2 * time.Hour)},
+		{EventID: "evt-3", Kind: UsageKindPower, CustomerID: "cust-1", ServerID: "srv-a", GatewayID: "gw-1", Operation: "PowerOn", OccurredAt: june.Add(time.Hour)},
+		{EventID: "evt-4", Kind: UsageKindPower, CustomerID: "cust-1", ServerID: "srv-a", GatewayID: "gw-1", Operation: "PowerOff", OccurredAt: june.AddDate(0, 1, 0)},
+	}
+	require.NoError(t, db.UsageRecords.Record(ctx, records))
+
+	// An event reported again is recorded once
+	require.NoError(t, db.UsageRecords.Record(ctx, []*UsageRecord{
+		{EventID: "evt-1", Kind: UsageKindConsole, CustomerID: "cust-2", ServerID: "srv-c", GatewayID: "gw-2", DurationMs: 60000, OccurredAt: june.Add(time.Hour)},
+	}))
+
+	listed, err := db.UsageRecords.List(ctx, june, june.AddDate(0, 1, 0), "")
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+
+	// Ordered by customer, then time
+	assert.Equal(t, "evt-3", listed[0].EventID)
+	assert.Equal(t, "evt-2", listed[1].EventID)
+	assert.Equal(t, "evt-1", listed[2].EventID)
+	assert.Equal(t, "gw-1", listed[2].GatewayID)
+	assert.Equal(t, int64(2000), listed[2].BytesOut)
+
+	// Customer filter
+	listed, err = db.UsageRecords.List(ctx, june, june.AddDate(0, 2, 0), "cust-1")
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+}
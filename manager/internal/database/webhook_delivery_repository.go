@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// WebhookDeliveryRepository provides database operations for the webhook
+// delivery log
+type WebhookDeliveryRepository interface {
+	// Record stores a delivery attempt
+	Record(ctx context.Context, delivery *WebhookDelivery) error
+
+	// List returns the most recent attempts first, at most limit of them. An
+	// empty eventID includes all events.
+	List(ctx context.Context, eventID string, limit int) ([]*WebhookDelivery, error)
+
+	// DeleteBefore removes attempts made before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type webhookDeliveryRepository struct {
+	db *bun.DB
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *bun.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Record(ctx context.Context, delivery *WebhookDelivery) error {
+	toUTC(&delivery.AttemptedAt)
+
+	_, err := r.db.NewInsert().
+		Model(delivery).
+		Exec(ctx)
+	return err
+}
+
+func (r *webhookDeliveryRepository) List(ctx context.Context, eventID string, limit int) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	query := r.db.NewSelect().
+		Model(&deliveries).
+		Order("attempted_at DESC", "id DESC").
+		Limit(limit)
+
+	if eventID != "" {
+		query = query.Where("event_id = ?", eventID)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.NewDelete().
+		Model((*WebhookDelivery)(nil)).
+		Where("attempted_at < ?", before.UTC()).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	deliveries := []*WebhookDelivery{
+		{EventID: "evt-1", EventType: "gateway.offline", Endpoint: "ops", URL: "https://ops.example.com/hook", Attempt: 1, StatusCode: 503, Error: "unexpected status 503", AttemptedAt: now.Add(-48 * time.Hour)},
+		{EventID: "evt-1", EventType: "gateway.offline", Endpoint: "ops", URL: "https://ops.example.com/hook", Attempt: 2, StatusCode: 200, Success: true, AttemptedAt: now.Add(-47 * time.Hour)},
+		{EventID: "evt-2", EventType: "server.discovered", Endpoint: "cmdb", URL: "https://cmdb.example.com/hook", Attempt: 1, StatusCode: 204, Success: true, AttemptedAt: now.Add(-time.Minute)},
+	}
+	for _, d := range deliveries {
+		require.NoError(t, db.WebhookDeliveries.Record(ctx, d))
+	}
+
+	recent, err := db.WebhookDeliveries.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 3)
+	assert.Equal(t, "evt-2", recent[0].EventID)
+	assert.Equal(t, 2, recent[1].Attempt)
+
+	// Event filter
+	attempts, err := db.WebhookDeliveries.List(ctx, "evt-1", 10)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.True(t, attempts[0].Success)
+	assert.Equal(t, 503, attempts[1].StatusCode)
+
+	// Limit
+	recent, err = db.WebhookDeliveries.List(ctx, "", 1)
+	require.NoError(t, err)
+	assert.Len(t, recent, 1)
+
+	// Retention
+	deleted, err := db.WebhookDeliveries.DeleteBefore(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	recent, err = db.WebhookDeliveries.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "evt-2", recent[0].EventID)
+}
@@ -0,0 +1,250 @@
+// Package inventory reads and writes the server inventory files of bulk
+// imports and exports: one server per CSV row or YAML list item, with its
+// BMC endpoint, gateway and customer.
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"core/types"
+)
+
+// File formats
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+)
+
+// Columns of CSV files, in the order they are written. YAML items use the
+// same keys.
+var Columns = []string{
+	"server_id",
+	"customer_id",
+	"datacenter_id",
+	"gateway_id",
+	"bmc_type",
+	"bmc_endpoint",
+	"username",
+	"password",
+	"features",
+	"external_id",
+}
+
+// featureSeparator separates the features of a server in CSV files
+const featureSeparator = ";"
+
+// Record is a server of an inventory file
+type Record struct {
+	ServerID     string   `yaml:"server_id"`
+	CustomerID   string   `yaml:"customer_id"`
+	DatacenterID string   `yaml:"datacenter_id"`
+	GatewayID    string   `yaml:"gateway_id"`
+	BMCType      string   `yaml:"bmc_type"` // "ipmi" or "redfish"
+	BMCEndpoint  string   `yaml:"bmc_endpoint"`
+	Username     string   `yaml:"username,omitempty"`
+	Password     string   `yaml:"password,omitempty"` // Never exported
+	Features     []string `yaml:"features,flow,omitempty"`
+	ExternalID   string   `yaml:"external_id,omitempty"`
+
+	// Line of the record in the file it was parsed from, to report errors
+	Line int `yaml:"-"`
+}
+
+// Validate checks that a record has the fields required to register its
+// server, and normalizes its BMC type and features to lower case
+func (r *Record) Validate() error {
+	for _, field := range []struct{ name, value string }{
+		{"server_id", r.ServerID},
+		{"customer_id", r.CustomerID},
+		{"datacenter_id", r.DatacenterID},
+		{"gateway_id", r.GatewayID},
+		{"bmc_endpoint", r.BMCEndpoint},
+	} {
+		if field.value == "" {
+			return fmt.Errorf("%s is required", field.name)
+		}
+	}
+
+	r.BMCType = strings.ToLower(r.BMCType)
+	switch types.BMCType(r.BMCType) {
+	case types.BMCTypeIPMI, types.BMCTypeRedfish:
+	case "":
+		return fmt.Errorf("bmc_type is required")
+	default:
+		return fmt.Errorf("invalid bmc_type %q, expected %s or %s", r.BMCType, types.BMCTypeIPMI, types.BMCTypeRedfish)
+	}
+
+	for i, feature := range r.Features {
+		feature = strings.ToLower(feature)
+		if !types.Feature(feature).IsValid() {
+			return fmt.Errorf("invalid feature %q", feature)
+		}
+		r.Features[i] = feature
+	}
+	return nil
+}
+
+// Parse reads the records of a CSV document with a header row, or of a YAML
+// document with a list of servers. Records are not validated; errors are
+// for documents that cannot be read at all.
+func Parse(data []byte, format string) ([]*Record, error) {
+	switch format {
+	case FormatCSV, "":
+		return parseCSV(data)
+	case FormatYAML:
+		return parseYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatCSV, FormatYAML)
+	}
+}
+
+// parseCSV reads records by the column names of the header row, which may
+// be in any order and leave out optional columns
+func parseCSV(data []byte) ([]*Record, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty CSV document, expected a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(Columns, column) {
+			return nil, fmt.Errorf("unknown CSV column %q, expected columns among %s", column, strings.Join(Columns, ", "))
+		}
+		if slices.Contains(header[:i], column) {
+			return nil, fmt.Errorf("duplicate CSV column %q", column)
+		}
+		header[i] = column
+	}
+
+	var records []*Record
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		record := &Record{Line: line}
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			switch header[i] {
+			case "server_id":
+				record.ServerID = value
+			case "customer_id":
+				record.CustomerID = value
+			case "datacenter_id":
+				record.DatacenterID = value
+			case "gateway_id":
+				record.GatewayID = value
+			case "bmc_type":
+				record.BMCType = value
+			case "bmc_endpoint":
+				record.BMCEndpoint = value
+			case "username":
+				record.Username = value
+			case "password":
+				record.Password = value
+			case "features":
+				for _, feature := range strings.Split(value, featureSeparator) {
+					if feature = strings.TrimSpace(feature); feature != "" {
+						record.Features = append(record.Features, feature)
+					}
+				}
+			case "external_id":
+				record.ExternalID = value
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// parseYAML reads the records of the servers list of a YAML document
+func parseYAML(data []byte) ([]*Record, error) {
+	var document struct {
+		Servers []yaml.Node `yaml:"servers"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	records := make([]*Record, 0, len(document.Servers))
+	for _, node := range document.Servers {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: expected a server mapping", node.Line)
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i].Value; !slices.Contains(Columns, key) {
+				return nil, fmt.Errorf("line %d: unknown key %q", node.Content[i].Line, key)
+			}
+		}
+
+		record := &Record{}
+		if err := node.Decode(record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		record.Line = node.Line
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Write encodes records as a CSV document with a header row, or as a YAML
+// document with a list of servers. It returns the document and its content
+// type.
+func Write(records []*Record, format string) ([]byte, string, error) {
+	switch format {
+	case FormatCSV, "":
+		data, err := writeCSV(records)
+		return data, "text/csv", err
+	case FormatYAML:
+		if records == nil {
+			records = []*Record{}
+		}
+		data, err := yaml.Marshal(struct {
+			Servers []*Record `yaml:"servers"`
+		}{records})
+		return data, "application/yaml", err
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatCSV, FormatYAML)
+	}
+}
+
+// writeCSV encodes records with one row per server
+func writeCSV(records []*Record) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(Columns)
+	for _, record := range records {
+		w.Write([]string{
+			record.ServerID,
+			record.CustomerID,
+			record.DatacenterID,
+			record.GatewayID,
+			record.BMCType,
+			record.BMCEndpoint,
+			record.Username,
+			record.Password,
+			strings.Join(record.Features, featureSeparator),
+			record.ExternalID,
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,142 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := []byte(`bmc_endpoint,server_id,customer_id,datacenter_id,gateway_id,bmc_type,features
+192.168.1.10:623,server-1,customer-1,dc-1,gw-1,IPMI,power;console
+"https://10.0.0.5",server-2,customer-1,dc-1,gw-1,redfish,
+`)
+
+	records, err := Parse(data, FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, &Record{
+		ServerID:     "server-1",
+		CustomerID:   "customer-1",
+		DatacenterID: "dc-1",
+		GatewayID:    "gw-1",
+		BMCType:      "IPMI",
+		BMCEndpoint:  "192.168.1.10:623",
+		Features:     []string{"power", "console"},
+		Line:         2,
+	}, records[0])
+	assert.Equal(t, "https://10.0.0.5", records[1].BMCEndpoint)
+	assert.Empty(t, records[1].Features)
+	assert.Equal(t, 3, records[1].Line)
+
+	for name, data := range map[string]string{
+		"empty":            "",
+		"unknown column":   "server_id,rack\nserver-1,r1\n",
+		"duplicate column": "server_id,server_id\nserver-1,server-2\n",
+		"short row":        "server_id,customer_id\nserver-1\n",
+	} {
+		_, err := Parse([]byte(data), FormatCSV)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	data := []byte(`servers:
+  - server_id: server-1
+    customer_id: customer-1
+    datacenter_id: dc-1
+    gateway_id: gw-1
+    bmc_type: ipmi
+    bmc_endpoint: 192.168.1.10:623
+    username: admin
+    password: secret
+    features: [power, vnc]
+  - server_id: server-2
+    bmc_endpoint: https://10.0.0.5
+`)
+
+	records, err := Parse(data, FormatYAML)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "secret", records[0].Password)
+	assert.Equal(t, []string{"power", "vnc"}, records[0].Features)
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, 11, records[1].Line)
+
+	_, err = Parse([]byte("servers:\n  - server_id: server-1\n    rack: r1\n"), FormatYAML)
+	assert.ErrorContains(t, err, "line 3")
+
+	_, err = Parse([]byte("servers: [server-1]\n"), FormatYAML)
+	assert.Error(t, err)
+
+	_, err = Parse(data, "json")
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Record {
+		return &Record{
+			ServerID:     "server-1",
+			CustomerID:   "customer-1",
+			DatacenterID: "dc-1",
+			GatewayID:    "gw-1",
+			BMCType:      "Redfish",
+			BMCEndpoint:  "https://10.0.0.5",
+			Features:     []string{"Power"},
+		}
+	}
+
+	record := valid()
+	require.NoError(t, record.Validate())
+	assert.Equal(t, "redfish", record.BMCType)
+	assert.Equal(t, []string{"power"}, record.Features)
+
+	for name, change := range map[string]func(*Record){
+		"no server ID":     func(r *Record) { r.ServerID = "" },
+		"no customer":      func(r *Record) { r.CustomerID = "" },
+		"no gateway":       func(r *Record) { r.GatewayID = "" },
+		"no endpoint":      func(r *Record) { r.BMCEndpoint = "" },
+		"no BMC type":      func(r *Record) { r.BMCType = "" },
+		"unknown BMC type": func(r *Record) { r.BMCType = "snmp" },
+		"unknown feature":  func(r *Record) { r.Features = []string{"power", "teleport"} },
+	} {
+		record := valid()
+		change(record)
+		assert.Error(t, record.Validate(), name)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	records := []*Record{
+		{ServerID: "server-1", CustomerID: "customer-1", DatacenterID: "dc-1", GatewayID: "gw-1",
+			BMCType: "ipmi", BMCEndpoint: "192.168.1.10:623", Username: "admin", Features: []string{"power", "console"}},
+		{ServerID: "server-2", CustomerID: "customer-2", DatacenterID: "dc-1", GatewayID: "gw-1",
+			BMCType: "redfish", BMCEndpoint: "https://10.0.0.5", ExternalID: "cmdb-42"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			data, contentType, err := Write(records, format)
+			require.NoError(t, err)
+			assert.NotEmpty(t, contentType)
+
+			// Exports can be imported back
+			parsed, err := Parse(data, format)
+			require.NoError(t, err)
+			require.Len(t, parsed, len(records))
+			for i, record := range parsed {
+				record.Line = 0
+				assert.Equal(t, records[i], record)
+			}
+		})
+	}
+
+	data, _, err := Write(nil, FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, "server_id,customer_id,datacenter_id,gateway_id,bmc_type,bmc_endpoint,username,password,features,external_id\n", string(data))
+
+	_, _, err = Write(records, "xml")
+	assert.Error(t, err)
+}
@@ -4,29 +4,52 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"core/domain"
+	commonv1 "core/gen/common/v1"
+	"core/tracing"
+	"core/types"
 	gatewayv1 "gateway/gen/gateway/v1"
 	"gateway/gen/gateway/v1/gatewayv1connect"
 	managerv1 "manager/gen/manager/v1"
 	"manager/internal/database"
+	"manager/internal/metering"
+	"manager/internal/slo"
 	"manager/pkg/auth"
 	"manager/pkg/models"
 )
 
 // AdminServiceHandler handles admin dashboard operations
 type AdminServiceHandler struct {
-	db         *database.BunDB
-	jwtManager *auth.JWTManager
+	db                *database.BunDB
+	jwtManager        *auth.JWTManager
+	sloObjectives     slo.Objectives
+	maxPowerSampleGap time.Duration
+	eventLog          *EventLog     // Recent system events, may be nil
+	apiKeyLength      int           // Random bytes of the API keys issued
+	passwordResetTTL  time.Duration // Validity of the password reset tokens issued
+
+	connectivityAudits connectivityAuditState
 }
 
 // NewAdminServiceHandler creates a new admin service handler
-func NewAdminServiceHandler(db *database.BunDB, jwtManager *auth.JWTManager) *AdminServiceHandler {
+func NewAdminServiceHandler(db *database.BunDB, jwtManager *auth.JWTManager, sloObjectives slo.Objectives) *AdminServiceHandler {
 	return &AdminServiceHandler{
-		db:         db,
-		jwtManager: jwtManager,
+		db:            db,
+		jwtManager:    jwtManager,
+		sloObjectives: sloObjectives,
 	}
 }
 
@@ -92,6 +115,21 @@ func (h *AdminServiceHandler) ListAllServers(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get total count: %w", err))
 	}
 
+	audits, err := h.db.ConnectivityAudits.List(ctx, req.Msg.CustomerFilter, false)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list connectivity audits")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list connectivity audits: %w", err))
+	}
+	auditsByServer := make(map[string]*database.ConnectivityAudit, len(audits))
+	for _, audit := range audits {
+		auditsByServer[audit.ServerID] = audit
+	}
+	for _, server := range servers {
+		if audit, ok := auditsByServer[server.ServerId]; ok {
+			server.ConnectivityAudit = connectivityAuditToProto(audit)
+		}
+	}
+
 	response := &managerv1.ListAllServersResponse{
 		Servers:       servers,
 		NextPageToken: "", // TODO: Implement proper pagination tokens
@@ -174,157 +212,836 @@ func (h *AdminServiceHandler) GetRegions(
 	return connect.NewResponse(response), nil
 }
 
-// LaunchVNCSession creates a VNC session for admin console access
+// GetConsoleSLOReport returns console availability SLO compliance and error
+// budgets over the rolling window, overall and per gateway and agent
+func (h *AdminServiceHandler) GetConsoleSLOReport(
+	ctx context.Context,
+	req *connect.Request[managerv1.GetConsoleSLOReportRequest],
+) (*connect.Response[managerv1.GetConsoleSLOReportResponse], error) {
+	log.Info().Str("gateway_filter", req.Msg.GatewayFilter).Msg("GetConsoleSLOReport called")
+
+	windowEnd := time.Now().UTC()
+	windowStart := windowEnd.Add(-h.sloObjectives.Window)
+
+	aggregates, err := h.db.ConsoleSLIs.Aggregate(ctx, windowStart, h.sloObjectives.TTFBThreshold, req.Msg.GatewayFilter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to aggregate console SLIs")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to aggregate console SLIs: %w", err))
+	}
+
+	response := &managerv1.GetConsoleSLOReportResponse{
+		WindowStart: timestamppb.New(windowStart),
+		WindowEnd:   timestamppb.New(windowEnd),
+		Objectives: &managerv1.ConsoleSLOObjectives{
+			AvailabilityTarget: h.sloObjectives.AvailabilityTarget,
+			TtfbTarget:         h.sloObjectives.TTFBTarget,
+			TtfbThresholdMs:    h.sloObjectives.TTFBThreshold.Milliseconds(),
+			WindowSeconds:      int64(h.sloObjectives.Window.Seconds()),
+		},
+	}
+
+	// Aggregates are ordered by gateway, so per-gateway rollups keep that order
+	var overall slo.Counts
+	var gatewayIDs []string
+	gatewayCounts := make(map[string]*slo.Counts)
+
+	for _, aggregate := range aggregates {
+		counts := slo.Counts{
+			Attempts:            aggregate.Attempts,
+			Successes:           aggregate.Successes,
+			TTFBSamples:         aggregate.TTFBSamples,
+			TTFBWithinThreshold: aggregate.TTFBWithinThreshold,
+			TTFBSumMs:           aggregate.TTFBSumMs,
+		}
+
+		overall.Add(counts)
+		if _, exists := gatewayCounts[aggregate.GatewayID]; !exists {
+			gatewayIDs = append(gatewayIDs, aggregate.GatewayID)
+			gatewayCounts[aggregate.GatewayID] = &slo.Counts{}
+		}
+		gatewayCounts[aggregate.GatewayID].Add(counts)
+
+		response.Agents = append(response.Agents,
+			consoleSLOStatusToProto(aggregate.GatewayID, aggregate.AgentID, slo.Evaluate(counts, h.sloObjectives)))
+	}
+
+	for _, gatewayID := range gatewayIDs {
+		response.Gateways = append(response.Gateways,
+			consoleSLOStatusToProto(gatewayID, "", slo.Evaluate(*gatewayCounts[gatewayID], h.sloObjectives)))
+	}
+
+	response.Overall = consoleSLOStatusToProto("", "", slo.Evaluate(overall, h.sloObjectives))
+
+	return connect.NewResponse(response), nil
+}
+
+// consoleSLOStatusToProto converts an SLO status to its protobuf form
+func consoleSLOStatusToProto(gatewayID, agentID string, status slo.Status) *managerv1.ConsoleSLOStatus {
+	return &managerv1.ConsoleSLOStatus{
+		GatewayId:            gatewayID,
+		AgentId:              agentID,
+		Attempts:             status.Attempts,
+		Successes:            status.Successes,
+		SuccessRate:          status.SuccessRate,
+		AvailabilityMet:      status.AvailabilityMet,
+		ErrorBudgetRemaining: status.ErrorBudgetRemaining,
+		TtfbSamples:          status.TTFBSamples,
+		TtfbWithinThreshold:  status.TTFBWithinThreshold,
+		TtfbCompliance:       status.TTFBCompliance,
+		TtfbMet:              status.TTFBMet,
+		AvgTtfbMs:            status.AvgTTFBMs,
+	}
+}
+
+// StartConsoleSLIRetention starts a goroutine that periodically deletes console
+// SLI measurements older than the retention period.
+func (h *AdminServiceHandler) StartConsoleSLIRetention(ctx context.Context, retention time.Duration) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := h.db.ConsoleSLIs.DeleteBefore(ctx, time.Now().UTC().Add(-retention))
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to prune console SLI measurements")
+			} else if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("Pruned expired console SLI measurements")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// defaultPowerUsagePeriod is the period of power usage reports without a
+// start time
+const defaultPowerUsagePeriod = 30 * 24 * time.Hour
+
+// SetMaxPowerSampleGap sets the longest interval between two power samples
+// that is counted as usage, metering.DefaultMaxSampleGap by default
+func (h *AdminServiceHandler) SetMaxPowerSampleGap(gap time.Duration) {
+	h.maxPowerSampleGap = gap
+}
+
+// GetPowerUsageReport returns the energy consumed by servers over a period,
+// aggregated per customer for chargeback. Servers are attributed to the
+// customer that owned them when they were sampled.
+func (h *AdminServiceHandler) GetPowerUsageReport(
+	ctx context.Context,
+	req *connect.Request[managerv1.GetPowerUsageReportRequest],
+) (*connect.Response[managerv1.GetPowerUsageReportResponse], error) {
+	log.Info().Str("customer_id", req.Msg.CustomerId).Msg("GetPowerUsageReport called")
+
+	end := time.Now().UTC()
+	if req.Msg.EndTime != nil {
+		end = req.Msg.EndTime.AsTime()
+	}
+	start := end.Add(-defaultPowerUsagePeriod)
+	if req.Msg.StartTime != nil {
+		start = req.Msg.StartTime.AsTime()
+	}
+	if !start.Before(end) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("start_time must be before end_time"))
+	}
+
+	readings, err := h.db.PowerReadings.List(ctx, start, end, req.Msg.CustomerId)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list power readings")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list power readings: %w", err))
+	}
+
+	maxGap := h.maxPowerSampleGap
+	if maxGap <= 0 {
+		maxGap = metering.DefaultMaxSampleGap
+	}
+
+	// Group the samples of each server by the customer owning it when it was
+	// sampled. Readings are ordered by server and time, so are the groups.
+	type serverKey struct{ customerID, serverID string }
+	var keys []serverKey
+	samples := make(map[serverKey][]metering.Sample)
+	datacenters := make(map[serverKey]string)
+	for _, reading := range readings {
+		key := serverKey{reading.CustomerID, reading.ServerID}
+		if _, exists := samples[key]; !exists {
+			keys = append(keys, key)
+			datacenters[key] = reading.DatacenterID
+		}
+		samples[key] = append(samples[key], metering.Sample{
+			SampledAt:     reading.SampledAt,
+			ConsumedWatts: reading.ConsumedWatts,
+			EnergyKWh:     reading.EnergyKWh,
+		})
+	}
+
+	customers := make(map[string]*managerv1.CustomerPowerUsage)
+	for _, key := range keys {
+		usage := metering.Compute(samples[key], maxGap)
+
+		customer, exists := customers[key.customerID]
+		if !exists {
+			customer = &managerv1.CustomerPowerUsage{CustomerId: key.customerID}
+			customers[key.customerID] = customer
+		}
+		customer.EnergyKwh += usage.EnergyKWh
+		customer.AverageWatts += usage.AverageWatts
+		customer.Servers = append(customer.Servers, &managerv1.ServerPowerUsage{
+			ServerId:     key.serverID,
+			DatacenterId: datacenters[key],
+			EnergyKwh:    usage.EnergyKWh,
+			AverageWatts: usage.AverageWatts,
+			PeakWatts:    usage.PeakWatts,
+			SampleCount:  usage.Samples,
+			SampledHours: usage.SampledHours,
+			Metered:      usage.Metered,
+		})
+	}
+
+	response := &managerv1.GetPowerUsageReportResponse{
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(end),
+		Customers: make([]*managerv1.CustomerPowerUsage, 0, len(customers)),
+	}
+	for _, customer := range customers {
+		customer.ServerCount = int32(len(customer.Servers))
+		response.TotalEnergyKwh += customer.EnergyKwh
+		response.Customers = append(response.Customers, customer)
+	}
+	sort.Slice(response.Customers, func(i, j int) bool {
+		return response.Customers[i].CustomerId < response.Customers[j].CustomerId
+	})
+
+	return connect.NewResponse(response), nil
+}
+
+// StartPowerReadingRetention starts a goroutine that periodically deletes
+// power readings older than the retention period.
+func (h *AdminServiceHandler) StartPowerReadingRetention(ctx context.Context, retention time.Duration) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := h.db.PowerReadings.DeleteBefore(ctx, time.Now().UTC().Add(-retention))
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to prune power readings")
+			} else if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("Pruned expired power readings")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// LaunchVNCSession opens a VNC session to any server on behalf of its
+// customer, see launchConsoleSession
 func (h *AdminServiceHandler) LaunchVNCSession(
 	ctx context.Context,
 	req *connect.Request[managerv1.LaunchSessionRequest],
 ) (*connect.Response[managerv1.LaunchSessionResponse], error) {
 	log.Info().Str("server_id", req.Msg.ServerId).Msg("LaunchVNCSession called")
 
+	response, err := h.launchConsoleSession(ctx, req.Msg, "vnc", func(gatewayEndpoint, token string) (*managerv1.LaunchSessionResponse, error) {
+		sessionResp, err := h.createGatewayVNCSession(ctx, gatewayEndpoint, req.Msg.ServerId, token)
+		if err != nil {
+			return nil, err
+		}
+		return &managerv1.LaunchSessionResponse{
+			SessionId:         sessionResp.SessionId,
+			WebsocketEndpoint: sessionResp.WebsocketEndpoint,
+			ViewerUrl:         sessionResp.ViewerUrl,
+			ExpiresAt:         sessionResp.ExpiresAt,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(response), nil
+}
+
+// LaunchSOLSession opens a SOL session to any server on behalf of its
+// customer, see launchConsoleSession
+func (h *AdminServiceHandler) LaunchSOLSession(
+	ctx context.Context,
+	req *connect.Request[managerv1.LaunchSessionRequest],
+) (*connect.Response[managerv1.LaunchSessionResponse], error) {
+	log.Info().Str("server_id", req.Msg.ServerId).Msg("LaunchSOLSession called")
+
+	response, err := h.launchConsoleSession(ctx, req.Msg, "sol", func(gatewayEndpoint, token string) (*managerv1.LaunchSessionResponse, error) {
+		sessionResp, err := h.createGatewaySOLSession(ctx, gatewayEndpoint, req.Msg.ServerId, token)
+		if err != nil {
+			return nil, err
+		}
+		return &managerv1.LaunchSessionResponse{
+			SessionId:         sessionResp.SessionId,
+			WebsocketEndpoint: sessionResp.WebsocketEndpoint,
+			ViewerUrl:         sessionResp.ConsoleUrl,
+			ExpiresAt:         sessionResp.ExpiresAt,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(response), nil
+}
+
+// launchConsoleSession impersonates the customer of a server to open a
+// console session of sessionType with create: the admin gets a short lived
+// token of the customer, limited to the console, that makes gateways stamp
+// the session with the admin's email. The impersonation is recorded in the
+// audit log with the admin's reason.
+func (h *AdminServiceHandler) launchConsoleSession(
+	ctx context.Context,
+	req *managerv1.LaunchSessionRequest,
+	sessionType string,
+	create func(gatewayEndpoint, token string) (*managerv1.LaunchSessionResponse, error),
+) (*managerv1.LaunchSessionResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("a reason is required to open a customer's console"))
+	}
+
+	// Get admin claims from context
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
 	// Get server location to find the gateway
-	serverLocation, err := h.db.Locations.Get(ctx, req.Msg.ServerId)
+	serverLocation, err := h.db.Locations.Get(ctx, req.ServerId)
 	if err != nil {
-		log.Error().Err(err).Str("server_id", req.Msg.ServerId).Msg("Failed to get server location")
+		log.Error().Err(err).Str("server_id", req.ServerId).Msg("Failed to get server location")
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %w", err))
 	}
 
-	// Get gateway information
 	gateway, err := h.db.Gateways.Get(ctx, serverLocation.RegionalGatewayID)
 	if err != nil {
 		log.Error().Err(err).Str("gateway_id", serverLocation.RegionalGatewayID).Msg("Failed to get gateway")
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("gateway not found: %w", err))
 	}
 
-	// Get server-scoped token for gateway authentication
-	server, err := h.db.Servers.Get(ctx, req.Msg.ServerId)
+	server, err := h.db.Servers.Get(ctx, req.ServerId)
 	if err != nil {
-		log.Error().Err(err).Str("server_id", req.Msg.ServerId).Msg("Failed to get server")
+		log.Error().Err(err).Str("server_id", req.ServerId).Msg("Failed to get server")
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %w", err))
 	}
 
-	// Get admin claims from context
-	claims, ok := ctx.Value("claims").(*models.AuthClaims)
-	if !ok {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	// The token is the customer's, with its two-factor policy applied to
+	// the admin's login. Unassigned servers have no customer.
+	customer := &models.Customer{ID: server.CustomerID}
+	if server.CustomerID != "" {
+		record, err := h.db.Customers.Get(ctx, server.CustomerID)
+		if err != nil && err.Error() != "customer not found" {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+		}
+		if record != nil {
+			customer.Email = record.Email
+			customer.RequireMFA = record.RequireMFA
+		}
+	}
+
+	permissions := []string{"console:access", sessionType}
+	tokenString, err := h.jwtManager.GenerateImpersonationToken(customer, server, permissions, claims.Email, consoleMFAClaims(claims, customer))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate impersonation token")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
 	}
 
-	// Create customer object for token generation
-	customer := &models.Customer{
-		ID:    claims.CustomerID,
-		Email: claims.Email,
+	response, err := create(gateway.Endpoint, tokenString)
+	if err != nil {
+		log.Error().Err(err).Str("server_id", req.ServerId).Str("type", sessionType).Msg("Failed to create console session on gateway")
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("failed to create %s session: %w", strings.ToUpper(sessionType), err))
 	}
 
-	// Define permissions for VNC session
-	permissions := []string{"console:access", "vnc", "read"}
+	log.Info().
+		Str("server_id", req.ServerId).
+		Str("customer_id", server.CustomerID).
+		Str("session_id", response.SessionId).
+		Str("type", sessionType).
+		Str("impersonated_by", claims.Email).
+		Msg("Admin opened console session on behalf of customer")
 
-	// Generate server token for gateway authentication
-	log.Debug().
-		Str("customer_id", customer.ID).
-		Str("server_id", server.ID).
-		Int("permissions_count", len(permissions)).
-		Msg("Generating server token for VNC session")
+	h.audit(ctx, auditServerConsoleImpersonate, "server", req.ServerId, map[string]string{
+		"customer_id": server.CustomerID,
+		"session_id":  response.SessionId,
+		"type":        sessionType,
+		"gateway_id":  gateway.ID,
+		"reason":      reason,
+	})
 
-	tokenString, err := h.jwtManager.GenerateServerToken(customer, server, permissions)
+	return response, nil
+}
+
+// gatewayQueryTimeout bounds each gateway call of a fan-out, so that an
+// unreachable gateway does not stall the admin dashboard
+const gatewayQueryTimeout = 10 * time.Second
+
+// ListConsoleSessions returns the console sessions open on every gateway.
+// Gateways that cannot be queried are reported instead of failing the call.
+func (h *AdminServiceHandler) ListConsoleSessions(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListConsoleSessionsRequest],
+) (*connect.Response[managerv1.ListConsoleSessionsResponse], error) {
+	log.Info().Str("gateway_filter", req.Msg.GatewayFilter).Msg("ListConsoleSessions called")
+
+	token, err := h.gatewayAdminToken(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to generate server token")
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		return nil, err
 	}
 
-	tokenPrefix := tokenString
-	if len(tokenString) > 20 {
-		tokenPrefix = tokenString[:20] + "..."
+	gateways, err := h.db.Gateways.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list gateways")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list gateways: %w", err))
 	}
-	log.Debug().
-		Str("token_prefix", tokenPrefix).
-		Int("token_length", len(tokenString)).
-		Str("gateway_endpoint", gateway.Endpoint).
-		Msg("Server token generated, calling gateway")
 
-	// Create VNC session on gateway via direct HTTP call
-	// In production, this would use a proper gateway client
-	sessionResp, err := h.createGatewayVNCSession(ctx, gateway.Endpoint, req.Msg.ServerId, tokenString)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to create VNC session on gateway")
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create VNC session: %w", err))
+	type gatewayResult struct {
+		gateway  *models.RegionalGateway
+		sessions []*gatewayv1.ConsoleSessionInfo
+		err      error
+	}
+
+	var wg sync.WaitGroup
+	results := make([]gatewayResult, 0, len(gateways))
+	for _, gateway := range gateways {
+		if req.Msg.GatewayFilter != "" && gateway.ID != req.Msg.GatewayFilter {
+			continue
+		}
+		results = append(results, gatewayResult{gateway: gateway})
+	}
+
+	for i := range results {
+		wg.Add(1)
+		go func(result *gatewayResult) {
+			defer wg.Done()
+
+			queryCtx, cancel := context.WithTimeout(ctx, gatewayQueryTimeout)
+			defer cancel()
+
+			resp, err := newGatewayClient(result.gateway.Endpoint, token).ListConsoleSessions(queryCtx,
+				connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{
+					ServerId:   req.Msg.ServerId,
+					CustomerId: req.Msg.CustomerId,
+				}))
+			if err != nil {
+				result.err = err
+				return
+			}
+			result.sessions = resp.Msg.Sessions
+		}(&results[i])
 	}
+	wg.Wait()
 
-	response := &managerv1.LaunchSessionResponse{
-		SessionId:         sessionResp.SessionId,
-		WebsocketEndpoint: sessionResp.WebsocketEndpoint,
-		ViewerUrl:         sessionResp.ViewerUrl,
-		ExpiresAt:         sessionResp.ExpiresAt,
+	response := &managerv1.ListConsoleSessionsResponse{}
+	for _, result := range results {
+		if result.err != nil {
+			log.Warn().Err(result.err).Str("gateway_id", result.gateway.ID).Msg("Failed to list console sessions on gateway")
+			response.UnreachableGateways = append(response.UnreachableGateways, &managerv1.UnreachableGateway{
+				GatewayId: result.gateway.ID,
+				Error:     result.err.Error(),
+			})
+			continue
+		}
+		for _, session := range result.sessions {
+			response.Sessions = append(response.Sessions, consoleSessionToProto(result.gateway.ID, result.gateway.Region, session))
+		}
 	}
 
+	sort.SliceStable(response.Sessions, func(i, j int) bool {
+		return response.Sessions[i].CreatedAt.AsTime().Before(response.Sessions[j].CreatedAt.AsTime())
+	})
+
 	return connect.NewResponse(response), nil
 }
 
-// LaunchSOLSession creates a SOL session for admin console access
-func (h *AdminServiceHandler) LaunchSOLSession(
+// TerminateConsoleSession forcibly closes a console session on its gateway
+func (h *AdminServiceHandler) TerminateConsoleSession(
 	ctx context.Context,
-	req *connect.Request[managerv1.LaunchSessionRequest],
-) (*connect.Response[managerv1.LaunchSessionResponse], error) {
-	log.Info().Str("server_id", req.Msg.ServerId).Msg("LaunchSOLSession called")
+	req *connect.Request[managerv1.TerminateConsoleSessionRequest],
+) (*connect.Response[managerv1.TerminateConsoleSessionResponse], error) {
+	log.Info().
+		Str("gateway_id", req.Msg.GatewayId).
+		Str("session_id", req.Msg.SessionId).
+		Msg("TerminateConsoleSession called")
 
-	// Get server location to find the gateway
-	serverLocation, err := h.db.Locations.Get(ctx, req.Msg.ServerId)
+	if req.Msg.GatewayId == "" || req.Msg.SessionId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id and session_id are required"))
+	}
+
+	token, err := h.gatewayAdminToken(ctx)
 	if err != nil {
-		log.Error().Err(err).Str("server_id", req.Msg.ServerId).Msg("Failed to get server location")
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %w", err))
+		return nil, err
 	}
 
-	// Get gateway information
-	gateway, err := h.db.Gateways.Get(ctx, serverLocation.RegionalGatewayID)
+	gateway, err := h.db.Gateways.Get(ctx, req.Msg.GatewayId)
 	if err != nil {
-		log.Error().Err(err).Str("gateway_id", serverLocation.RegionalGatewayID).Msg("Failed to get gateway")
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("gateway not found: %w", err))
+		log.Error().Err(err).Str("gateway_id", req.Msg.GatewayId).Msg("Failed to get gateway")
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("gateway not found: %w", err))
 	}
 
-	// Get server-scoped token for gateway authentication
-	server, err := h.db.Servers.Get(ctx, req.Msg.ServerId)
+	resp, err := newGatewayClient(gateway.Endpoint, token).TerminateConsoleSession(ctx,
+		connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{
+			SessionId: req.Msg.SessionId,
+			Reason:    req.Msg.Reason,
+		}))
 	if err != nil {
-		log.Error().Err(err).Str("server_id", req.Msg.ServerId).Msg("Failed to get server")
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %w", err))
+		log.Error().Err(err).Str("gateway_id", gateway.ID).Msg("Failed to terminate console session on gateway")
+		return nil, connect.NewError(connect.CodeOf(err), fmt.Errorf("failed to terminate console session: %w", err))
 	}
+	h.audit(ctx, auditConsoleSessionTerminate, "session", req.Msg.SessionId, map[string]string{
+		"gateway_id": gateway.ID,
+		"reason":     req.Msg.Reason,
+	})
 
-	// Get admin claims from context
-	claims, ok := ctx.Value("claims").(*models.AuthClaims)
-	if !ok {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	return connect.NewResponse(&managerv1.TerminateConsoleSessionResponse{
+		DisconnectedStreams: resp.Msg.DisconnectedStreams,
+	}), nil
+}
+
+// GetTopology returns the live view of the gateways: the agents registered
+// with each of them, the BMC endpoints mapped to the agents, and the console
+// sessions open on the gateway. Gateways that cannot be queried are reported
+// instead of failing the call.
+func (h *AdminServiceHandler) GetTopology(
+	ctx context.Context,
+	req *connect.Request[managerv1.GetTopologyRequest],
+) (*connect.Response[managerv1.GetTopologyResponse], error) {
+	log.Info().
+		Strs("region_filter", req.Msg.RegionFilter).
+		Str("datacenter_filter", req.Msg.DatacenterFilter).
+		Msg("GetTopology called")
+
+	token, err := h.gatewayAdminToken(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create customer object for token generation
-	customer := &models.Customer{
-		ID:    claims.CustomerID,
-		Email: claims.Email,
+	gateways, err := h.db.Admin.GetGatewayHealth(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get gateway health")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get gateway health: %w", err))
+	}
+
+	regions := make(map[string]bool, len(req.Msg.RegionFilter))
+	for _, region := range req.Msg.RegionFilter {
+		regions[region] = true
+	}
+
+	type gatewayResult struct {
+		topology *managerv1.TopologyGateway
+		err      error
+	}
+
+	var wg sync.WaitGroup
+	results := make([]gatewayResult, 0, len(gateways))
+	for _, gateway := range gateways {
+		if len(regions) > 0 && !regions[gateway.Region] {
+			continue
+		}
+		results = append(results, gatewayResult{topology: &managerv1.TopologyGateway{Gateway: gateway}})
 	}
 
-	// Define permissions for SOL session
-	permissions := []string{"console:access", "sol", "read"}
+	for i := range results {
+		wg.Add(1)
+		go func(result *gatewayResult) {
+			defer wg.Done()
 
-	// Generate server token for gateway authentication
-	tokenString, err := h.jwtManager.GenerateServerToken(customer, server, permissions)
+			queryCtx, cancel := context.WithTimeout(ctx, gatewayQueryTimeout)
+			defer cancel()
+
+			result.err = queryGatewayTopology(queryCtx, newGatewayClient(result.topology.Gateway.Endpoint, token),
+				result.topology, req.Msg.DatacenterFilter)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	response := &managerv1.GetTopologyResponse{GeneratedAt: timestamppb.Now()}
+	for _, result := range results {
+		gateway := result.topology.Gateway
+		if result.err != nil {
+			log.Warn().Err(result.err).Str("gateway_id", gateway.GatewayId).Msg("Failed to query topology of gateway")
+			response.UnreachableGateways = append(response.UnreachableGateways, &managerv1.UnreachableGateway{
+				GatewayId: gateway.GatewayId,
+				Error:     result.err.Error(),
+			})
+			continue
+		}
+		// Leave out gateways not serving the datacenter
+		if req.Msg.DatacenterFilter != "" && len(result.topology.Agents) == 0 &&
+			!slices.Contains(gateway.DatacenterIds, req.Msg.DatacenterFilter) {
+			continue
+		}
+		response.Gateways = append(response.Gateways, result.topology)
+	}
+
+	sort.SliceStable(response.Gateways, func(i, j int) bool {
+		gi, gj := response.Gateways[i].Gateway, response.Gateways[j].Gateway
+		if gi.Region != gj.Region {
+			return gi.Region < gj.Region
+		}
+		return gi.GatewayId < gj.GatewayId
+	})
+
+	return connect.NewResponse(response), nil
+}
+
+// queryGatewayTopology fills topology with the agents and console sessions of
+// its gateway. With a datacenter, only its agents and their sessions are kept.
+func queryGatewayTopology(
+	ctx context.Context,
+	client gatewayv1connect.GatewayServiceClient,
+	topology *managerv1.TopologyGateway,
+	datacenterID string,
+) error {
+	agentsResp, err := client.ListAgents(ctx, connect.NewRequest(&gatewayv1.ListAgentsRequest{
+		DatacenterId: datacenterID,
+	}))
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to generate server token")
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		return err
 	}
 
-	// Create SOL session on gateway via direct HTTP call
-	sessionResp, err := h.createGatewaySOLSession(ctx, gateway.Endpoint, req.Msg.ServerId, tokenString)
+	sessionsResp, err := client.ListConsoleSessions(ctx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{}))
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create SOL session on gateway")
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create SOL session: %w", err))
+		return err
 	}
 
-	response := &managerv1.LaunchSessionResponse{
-		SessionId:         sessionResp.SessionId,
-		WebsocketEndpoint: sessionResp.WebsocketEndpoint,
-		ViewerUrl:         sessionResp.ConsoleUrl,
-		ExpiresAt:         sessionResp.ExpiresAt,
+	agentIDs := make(map[string]bool, len(agentsResp.Msg.Agents))
+	for _, agent := range agentsResp.Msg.Agents {
+		agentIDs[agent.AgentId] = true
+		topology.Agents = append(topology.Agents, topologyAgentToProto(agent))
+	}
+
+	gateway := topology.Gateway
+	for _, session := range sessionsResp.Msg.Sessions {
+		if datacenterID != "" && !agentIDs[session.AgentId] {
+			continue
+		}
+		topology.Sessions = append(topology.Sessions, consoleSessionToProto(gateway.GatewayId, gateway.Region, session))
+	}
+	return nil
+}
+
+// topologyAgentToProto converts the gateway's status of an agent to its
+// admin view
+func topologyAgentToProto(agent *gatewayv1.AgentStatus) *managerv1.TopologyAgent {
+	result := &managerv1.TopologyAgent{
+		AgentId:            agent.AgentId,
+		DatacenterId:       agent.DatacenterId,
+		Endpoint:           agent.Endpoint,
+		Status:             agent.Status,
+		Version:            agent.Version,
+		LastSeen:           agent.LastSeen,
+		ActiveSessionCount: agent.ActiveSessionCount,
+	}
+	for _, endpoint := range agent.BmcEndpoints {
+		result.BmcEndpoints = append(result.BmcEndpoints, &managerv1.TopologyEndpoint{
+			ServerId:    endpoint.ServerId,
+			BmcEndpoint: endpoint.BmcEndpoint,
+			BmcType:     bmcTypeName(endpoint.BmcType),
+			Status:      endpoint.Status,
+			LastSeen:    endpoint.LastSeen,
+		})
+	}
+	return result
+}
+
+// bmcTypeName returns the admin view name of a BMC type, e.g. ipmi
+func bmcTypeName(bmcType commonv1.BMCType) string {
+	switch bmcType {
+	case commonv1.BMCType_BMC_IPMI:
+		return string(types.BMCTypeIPMI)
+	case commonv1.BMCType_BMC_REDFISH:
+		return string(types.BMCTypeRedfish)
+	default:
+		return "unknown"
+	}
+}
+
+// SetEventLog sets the log of recent system events listed by
+// ListServerEvents, shared with the BMCManagerServiceHandler recording them
+func (h *AdminServiceHandler) SetEventLog(eventLog *EventLog) {
+	h.eventLog = eventLog
+}
+
+// ListServerEvents returns the recent system events of a server, newest first
+func (h *AdminServiceHandler) ListServerEvents(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListServerEventsRequest],
+) (*connect.Response[managerv1.ListServerEventsResponse], error) {
+	log.Info().Str("server_id", req.Msg.ServerId).Msg("ListServerEvents called")
+
+	if req.Msg.ServerId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("server_id is required"))
+	}
+
+	limit := req.Msg.Limit
+	if limit <= 0 {
+		limit = 50
+	} else if limit > 500 {
+		limit = 500
+	}
+
+	response := &managerv1.ListServerEventsResponse{}
+	if h.eventLog == nil {
+		return connect.NewResponse(response), nil
+	}
+
+	for _, event := range h.eventLog.Recent(req.Msg.ServerId, int(limit)) {
+		data, err := structpb.NewStruct(event.Data)
+		if err != nil {
+			log.Warn().Err(err).Str("event_id", event.ID).Msg("Skipping event with unsupported data")
+			continue
+		}
+		response.Events = append(response.Events, &managerv1.SystemEvent{
+			Id:     event.ID,
+			Type:   string(event.Type),
+			Source: event.Source,
+			Time:   timestamppb.New(event.Time),
+			Data:   data,
+		})
 	}
 
 	return connect.NewResponse(response), nil
 }
 
+// maxServerNotesLength bounds the operator notes of a server, in characters
+const maxServerNotesLength = 4096
+
+// SetServerMaintenance puts a server in or out of maintenance. Server tokens
+// issued while a server is in maintenance carry the flag, and gateways reject
+// their power operations unless they were issued to an admin.
+func (h *AdminServiceHandler) SetServerMaintenance(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetServerMaintenanceRequest],
+) (*connect.Response[managerv1.SetServerMaintenanceResponse], error) {
+	log.Info().
+		Str("server_id", req.Msg.ServerId).
+		Bool("maintenance_mode", req.Msg.MaintenanceMode).
+		Msg("SetServerMaintenance called")
+
+	server, err := h.updateServer(ctx, h.db.Servers, req.Msg.ServerId, func(server *domain.Server) {
+		server.MaintenanceMode = req.Msg.MaintenanceMode
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.audit(ctx, auditServerMaintenance, "server", server.ID, map[string]string{
+		"maintenance_mode": strconv.FormatBool(server.MaintenanceMode),
+	})
+
+	return connect.NewResponse(&managerv1.SetServerMaintenanceResponse{Server: serverToProto(server)}), nil
+}
+
+// SetServerNotes sets the operator notes of a server
+func (h *AdminServiceHandler) SetServerNotes(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetServerNotesRequest],
+) (*connect.Response[managerv1.SetServerNotesResponse], error) {
+	log.Info().Str("server_id", req.Msg.ServerId).Int("length", len(req.Msg.Notes)).Msg("SetServerNotes called")
+
+	if utf8.RuneCountInString(req.Msg.Notes) > maxServerNotesLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("notes exceed %d characters", maxServerNotesLength))
+	}
+
+	server, err := h.updateServer(ctx, h.db.Servers, req.Msg.ServerId, func(server *domain.Server) {
+		server.Notes = req.Msg.Notes
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.audit(ctx, auditServerNotes, "server", server.ID, nil)
+
+	return connect.NewResponse(&managerv1.SetServerNotesResponse{Server: serverToProto(server)}), nil
+}
+
+// updateServer applies update to the record of a server and stores it in
+// servers
+func (h *AdminServiceHandler) updateServer(ctx context.Context, servers database.ServerRepository, serverID string, update func(*domain.Server)) (*domain.Server, error) {
+	if serverID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("server_id is required"))
+	}
+
+	server, err := servers.Get(ctx, serverID)
+	if err != nil {
+		if err.Error() == "server not found" {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", serverID))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server: %w", err))
+	}
+
+	update(server)
+	server.UpdatedAt = time.Now()
+	if err := servers.Update(ctx, server); err != nil {
+		log.Error().Err(err).Str("server_id", serverID).Msg("Failed to update server")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update server: %w", err))
+	}
+	return server, nil
+}
+
+// gatewayAdminToken generates a token carrying the caller's admin identity
+// for gateway administration RPCs
+func (h *AdminServiceHandler) gatewayAdminToken(ctx context.Context) (string, error) {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
+	token, err := h.jwtManager.GenerateToken(&models.Customer{
+		ID:      claims.CustomerID,
+		Email:   claims.Email,
+		IsAdmin: claims.IsAdmin,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate gateway token")
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+	}
+	return token, nil
+}
+
+// consoleSessionToProto converts a console session of a gateway to its admin view
+func consoleSessionToProto(gatewayID, region string, session *gatewayv1.ConsoleSessionInfo) *managerv1.ConsoleSession {
+	result := &managerv1.ConsoleSession{
+		GatewayId:      gatewayID,
+		Region:         region,
+		SessionId:      session.SessionId,
+		Type:           session.Type,
+		ServerId:       session.ServerId,
+		CustomerId:     session.CustomerId,
+		CustomerEmail:  session.CustomerEmail,
+		AgentId:        session.AgentId,
+		CreatedAt:      session.CreatedAt,
+		ExpiresAt:      session.ExpiresAt,
+		ImpersonatedBy: session.ImpersonatedBy,
+		LastActivityAt: session.LastActivityAt,
+	}
+	for _, stream := range session.Streams {
+		result.Streams = append(result.Streams, &managerv1.ConsoleStream{
+			ClientAddress: stream.ClientAddress,
+			Transport:     stream.Transport,
+			AttachedAt:    stream.AttachedAt,
+		})
+	}
+	return result
+}
+
+// newGatewayClient creates a gateway client authenticated with token
+func newGatewayClient(gatewayEndpoint string, token string) gatewayv1connect.GatewayServiceClient {
+	return gatewayv1connect.NewGatewayServiceClient(
+		http.DefaultClient,
+		gatewayEndpoint,
+		connect.WithInterceptors(tracing.NewInterceptor(), newAuthInterceptor(token)),
+	)
+}
+
 // createGatewayVNCSession creates a VNC session on the gateway
 func (h *AdminServiceHandler) createGatewayVNCSession(
 	ctx context.Context,
@@ -336,7 +1053,7 @@ func (h *AdminServiceHandler) createGatewayVNCSession(
 	client := gatewayv1connect.NewGatewayServiceClient(
 		http.DefaultClient,
 		gatewayEndpoint,
-		connect.WithInterceptors(newAuthInterceptor(token)),
+		connect.WithInterceptors(tracing.NewInterceptor(), newAuthInterceptor(token)),
 	)
 
 	// Create VNC session
@@ -361,7 +1078,7 @@ func (h *AdminServiceHandler) createGatewaySOLSession(
 	client := gatewayv1connect.NewGatewayServiceClient(
 		http.DefaultClient,
 		gatewayEndpoint,
-		connect.WithInterceptors(newAuthInterceptor(token)),
+		connect.WithInterceptors(tracing.NewInterceptor(), newAuthInterceptor(token)),
 	)
 
 	// Create SOL session
@@ -0,0 +1,112 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// Actions of the audit log
+const (
+	auditGatewayApprove           = "gateway.approve"
+	auditCustomerCreate           = "customer.create"
+	auditCustomerAPIKey           = "customer.api_key"
+	auditCustomerPasswordReset    = "customer.password_reset"
+	auditCustomerDisable          = "customer.disable"
+	auditCustomerEnable           = "customer.enable"
+	auditCustomerDelete           = "customer.delete"
+	auditCustomerSessionQuota     = "customer.session_quota"
+	auditCustomerMFAPolicy        = "customer.mfa_policy"
+	auditCustomerIPAllowlist      = "customer.ip_allowlist"
+	auditServerAssign             = "server.assign"
+	auditServerMaintenance        = "server.maintenance"
+	auditServerNotes              = "server.notes"
+	auditServerConsoleImpersonate = "server.console_impersonate"
+	auditServerImport             = "server.import"
+	auditMaintenanceWindowCreate  = "maintenance_window.create"
+	auditMaintenanceWindowDelete  = "maintenance_window.delete"
+	auditConsoleSessionTerminate  = "session.terminate"
+	auditLogLevel                 = "service.log_level"
+)
+
+// Bounds of the audit events listed at once
+const (
+	defaultAuditEventLimit = 100
+	maxAuditEventLimit     = 1000
+)
+
+// audit records a change made by the admin of ctx in the audit log. The
+// change was already made, so failures to record it are only logged.
+func (h *AdminServiceHandler) audit(ctx context.Context, action, targetType, targetID string, details map[string]string) {
+	var actor string
+	if claims, ok := ctx.Value("claims").(*models.AuthClaims); ok {
+		actor = claims.Email
+		if actor == "" {
+			actor = claims.CustomerID
+		}
+	}
+
+	event := &database.AuditEvent{
+		Actor:      actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	}
+	if err := h.db.AuditEvents.Record(ctx, event); err != nil {
+		log.Error().Err(err).
+			Str("action", action).
+			Str("target_id", targetID).
+			Str("actor", actor).
+			Msg("Failed to record audit event")
+	}
+}
+
+// ListAuditEvents lists the changes made by admins, newest first
+func (h *AdminServiceHandler) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListAuditEventsRequest],
+) (*connect.Response[managerv1.ListAuditEventsResponse], error) {
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	} else if limit > maxAuditEventLimit {
+		limit = maxAuditEventLimit
+	}
+
+	filter := database.AuditEventFilter{
+		Actor:    req.Msg.Actor,
+		Action:   req.Msg.Action,
+		TargetID: req.Msg.TargetId,
+		Limit:    limit,
+	}
+	if req.Msg.Since != nil {
+		filter.Since = req.Msg.Since.AsTime()
+	}
+
+	events, err := h.db.AuditEvents.List(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list audit events: %w", err))
+	}
+
+	response := &managerv1.ListAuditEventsResponse{}
+	for _, event := range events {
+		response.Events = append(response.Events, &managerv1.AuditEvent{
+			Id:         event.ID,
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Actor:      event.Actor,
+			Action:     event.Action,
+			TargetType: event.TargetType,
+			TargetId:   event.TargetID,
+			Details:    event.Details,
+		})
+	}
+	return connect.NewResponse(response), nil
+}
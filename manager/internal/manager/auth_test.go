@@ -23,6 +23,8 @@ func TestAuthenticate_TokenExpirationTime(t *testing.T) {
 
 	jwtManager := auth.NewJWTManager("test-secret-key")
 	handler := NewBMCManagerServiceHandler(db, jwtManager, []string{})
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication request
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
@@ -72,6 +74,8 @@ func TestAuthenticate_TokenContentMatchesExpiration(t *testing.T) {
 
 	jwtManager := auth.NewJWTManager("test-secret-key")
 	handler := NewBMCManagerServiceHandler(db, jwtManager, []string{})
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication request
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
@@ -124,6 +128,8 @@ func TestAuthenticate_RegressTokenImmediateExpiration(t *testing.T) {
 
 	jwtManager := auth.NewJWTManager("test-secret-key")
 	handler := NewBMCManagerServiceHandler(db, jwtManager, []string{})
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication request
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
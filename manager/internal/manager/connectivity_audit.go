@@ -0,0 +1,335 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// connectivityAuditHopGateway is the failed hop of the audits the manager
+// could not run through the server's gateway. The other hops are the ones
+// of the gateway's deep health checks.
+const connectivityAuditHopGateway = "gateway"
+
+// defaultConnectivityAuditTimeout bounds the audit of one server when
+// SetConnectivityAuditOptions was not called
+const defaultConnectivityAuditTimeout = 60 * time.Second
+
+// connectivityAuditState holds the options of connectivity audits and the
+// time of the last run over all servers
+type connectivityAuditState struct {
+	mu            sync.RWMutex
+	timeout       time.Duration
+	checkConsoles bool
+	lastRunAt     time.Time
+}
+
+// SetConnectivityAuditOptions sets the timeout of the audit of one server,
+// and whether audits check the console endpoints in addition to the BMC
+// credentials. They apply to periodic and on-demand audits.
+func (h *AdminServiceHandler) SetConnectivityAuditOptions(timeout time.Duration, checkConsoles bool) {
+	h.connectivityAudits.mu.Lock()
+	defer h.connectivityAudits.mu.Unlock()
+	h.connectivityAudits.timeout = timeout
+	h.connectivityAudits.checkConsoles = checkConsoles
+}
+
+// StartConnectivityAudits audits every server each interval, auditing up
+// to concurrency servers at once
+func (h *AdminServiceHandler) StartConnectivityAudits(ctx context.Context, interval time.Duration, concurrency int) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := h.runConnectivityAudits(ctx, concurrency); err != nil {
+				log.Error().Err(err).Msg("Failed to run connectivity audits")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runConnectivityAudits audits every registered server and forgets the
+// audits of the servers removed since the last run
+func (h *AdminServiceHandler) runConnectivityAudits(ctx context.Context, concurrency int) error {
+	servers, err := h.db.Servers.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	gateways, err := h.auditGateways(ctx)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failed := 0
+	sem := make(chan struct{}, max(concurrency, 1))
+
+	for _, server := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(server *domain.Server) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			audit, err := h.auditServer(ctx, server, gateways)
+			if err != nil {
+				log.Error().Err(err).Str("server_id", server.ID).Msg("Failed to record connectivity audit")
+				return
+			}
+			if !audit.Healthy {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(server)
+	}
+	wg.Wait()
+
+	serverIDs := make([]string, 0, len(servers))
+	for _, server := range servers {
+		serverIDs = append(serverIDs, server.ID)
+	}
+	if _, err := h.db.ConnectivityAudits.DeleteExcept(ctx, serverIDs); err != nil {
+		log.Warn().Err(err).Msg("Failed to prune connectivity audits of removed servers")
+	}
+
+	h.connectivityAudits.mu.Lock()
+	h.connectivityAudits.lastRunAt = start
+	h.connectivityAudits.mu.Unlock()
+
+	log.Info().
+		Int("servers", len(servers)).
+		Int("failed", failed).
+		Dur("duration", time.Since(start)).
+		Msg("Connectivity audits completed")
+	return nil
+}
+
+// auditGateways returns the approved gateways by ID
+func (h *AdminServiceHandler) auditGateways(ctx context.Context) (map[string]*models.RegionalGateway, error) {
+	gateways, err := h.db.Gateways.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gateways: %w", err)
+	}
+
+	byID := make(map[string]*models.RegionalGateway, len(gateways))
+	for _, gateway := range gateways {
+		// Pending gateways are not trusted until approved
+		if gateway.Status == models.GatewayStatusPending {
+			continue
+		}
+		byID[gateway.ID] = gateway
+	}
+	return byID, nil
+}
+
+// auditServer runs a deep health check of a server through its gateway
+// and records the result. Failures to reach the gateway are recorded as a
+// failed audit; the returned error is for failures to record it.
+func (h *AdminServiceHandler) auditServer(
+	ctx context.Context,
+	server *domain.Server,
+	gateways map[string]*models.RegionalGateway,
+) (*database.ConnectivityAudit, error) {
+	h.connectivityAudits.mu.RLock()
+	timeout := h.connectivityAudits.timeout
+	checkConsoles := h.connectivityAudits.checkConsoles
+	h.connectivityAudits.mu.RUnlock()
+	if timeout <= 0 {
+		timeout = defaultConnectivityAuditTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	audit := &database.ConnectivityAudit{
+		ServerID:     server.ID,
+		CustomerID:   server.CustomerID,
+		DatacenterID: server.DatacenterID,
+		CheckedAt:    time.Now(),
+	}
+
+	resp, err := h.deepHealthCheck(ctx, server, gateways, checkConsoles)
+	if err != nil {
+		audit.FailedHop = connectivityAuditHopGateway
+		audit.Error = err.Error()
+	} else {
+		audit.Healthy = resp.Healthy
+		for _, hop := range resp.Hops {
+			audit.Hops = append(audit.Hops, &database.ConnectivityAuditHop{
+				Name:      hop.Name,
+				Status:    hop.Status,
+				Target:    hop.Target,
+				LatencyMs: hop.LatencyMs,
+				Error:     hop.Error,
+			})
+			if hop.Status == "failed" && audit.FailedHop == "" {
+				audit.FailedHop = hop.Name
+				audit.Error = hop.Error
+			}
+		}
+	}
+
+	if !audit.Healthy {
+		log.Warn().
+			Str("server_id", server.ID).
+			Str("failed_hop", audit.FailedHop).
+			Str("error", audit.Error).
+			Msg("Connectivity audit failed")
+	}
+
+	// The audit is recorded even when the check timed out
+	if err := h.db.ConnectivityAudits.Record(context.WithoutCancel(ctx), audit); err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+// deepHealthCheck asks the gateway serving a server to check each hop to
+// its BMC, with a server token of its customer
+func (h *AdminServiceHandler) deepHealthCheck(
+	ctx context.Context,
+	server *domain.Server,
+	gateways map[string]*models.RegionalGateway,
+	checkConsoles bool,
+) (*gatewayv1.DeepHealthCheckResponse, error) {
+	location, err := h.db.Locations.Get(ctx, server.ID)
+	if err != nil {
+		return nil, fmt.Errorf("server has no gateway: %w", err)
+	}
+	gateway, ok := gateways[location.RegionalGatewayID]
+	if !ok {
+		return nil, fmt.Errorf("gateway %s is not registered or not approved", location.RegionalGatewayID)
+	}
+
+	token, err := h.jwtManager.GenerateServerToken(&models.Customer{ID: server.CustomerID}, server, []string{"power:read"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate server token: %w", err)
+	}
+
+	resp, err := newGatewayClient(gateway.Endpoint, token).DeepHealthCheck(ctx,
+		connect.NewRequest(&gatewayv1.DeepHealthCheckRequest{
+			ServerId:      server.ID,
+			CheckConsoles: checkConsoles,
+		}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnimplemented {
+			return nil, fmt.Errorf("gateway %s does not support deep health checks, upgrade it", gateway.ID)
+		}
+		return nil, fmt.Errorf("gateway %s: %w", gateway.ID, err)
+	}
+	return resp.Msg, nil
+}
+
+// ListConnectivityAudits returns the last connectivity audit of each server
+func (h *AdminServiceHandler) ListConnectivityAudits(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListConnectivityAuditsRequest],
+) (*connect.Response[managerv1.ListConnectivityAuditsResponse], error) {
+	log.Info().
+		Bool("failures_only", req.Msg.FailuresOnly).
+		Str("customer_filter", req.Msg.CustomerFilter).
+		Msg("ListConnectivityAudits called")
+
+	audits, err := h.db.ConnectivityAudits.List(ctx, req.Msg.CustomerFilter, req.Msg.FailuresOnly)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list connectivity audits")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list connectivity audits: %w", err))
+	}
+
+	response := &managerv1.ListConnectivityAuditsResponse{
+		Audits: make([]*managerv1.ConnectivityAudit, 0, len(audits)),
+	}
+	for _, audit := range audits {
+		response.Audits = append(response.Audits, connectivityAuditToProto(audit))
+	}
+
+	h.connectivityAudits.mu.RLock()
+	if lastRunAt := h.connectivityAudits.lastRunAt; !lastRunAt.IsZero() {
+		response.LastRunAt = timestamppb.New(lastRunAt)
+	}
+	h.connectivityAudits.mu.RUnlock()
+
+	return connect.NewResponse(response), nil
+}
+
+// RunConnectivityAudit audits a server now, without waiting for the next
+// periodic run
+func (h *AdminServiceHandler) RunConnectivityAudit(
+	ctx context.Context,
+	req *connect.Request[managerv1.RunConnectivityAuditRequest],
+) (*connect.Response[managerv1.RunConnectivityAuditResponse], error) {
+	log.Info().Str("server_id", req.Msg.ServerId).Msg("RunConnectivityAudit called")
+
+	if req.Msg.ServerId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("server_id is required"))
+	}
+
+	server, err := h.db.Servers.Get(ctx, req.Msg.ServerId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %w", err))
+	}
+
+	gateways, err := h.auditGateways(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	audit, err := h.auditServer(ctx, server, gateways)
+	if err != nil {
+		log.Error().Err(err).Str("server_id", server.ID).Msg("Failed to record connectivity audit")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record connectivity audit: %w", err))
+	}
+
+	return connect.NewResponse(&managerv1.RunConnectivityAuditResponse{
+		Audit: connectivityAuditToProto(audit),
+	}), nil
+}
+
+// connectivityAuditToProto converts a connectivity audit to its protobuf
+// message
+func connectivityAuditToProto(audit *database.ConnectivityAudit) *managerv1.ConnectivityAudit {
+	msg := &managerv1.ConnectivityAudit{
+		ServerId:     audit.ServerID,
+		CustomerId:   audit.CustomerID,
+		DatacenterId: audit.DatacenterID,
+		CheckedAt:    timestamppb.New(audit.CheckedAt),
+		Healthy:      audit.Healthy,
+		FailedHop:    audit.FailedHop,
+		Error:        audit.Error,
+	}
+	if !audit.VerifiedAt.IsZero() {
+		msg.VerifiedAt = timestamppb.New(audit.VerifiedAt)
+	}
+	for _, hop := range audit.Hops {
+		msg.Hops = append(msg.Hops, &managerv1.ConnectivityAuditHop{
+			Name:      hop.Name,
+			Status:    hop.Status,
+			Target:    hop.Target,
+			LatencyMs: hop.LatencyMs,
+			Error:     hop.Error,
+		})
+	}
+	return msg
+}
@@ -0,0 +1,150 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+// fakeHealthGateway serves deep health checks, failing the BMC control hop
+// of the servers in broken
+type fakeHealthGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	broken        map[string]bool
+	checkConsoles bool
+}
+
+func (g *fakeHealthGateway) DeepHealthCheck(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.DeepHealthCheckRequest],
+) (*connect.Response[gatewayv1.DeepHealthCheckResponse], error) {
+	g.checkConsoles = req.Msg.CheckConsoles
+
+	control := &gatewayv1.HealthCheckHop{Name: "bmc_control", Status: "ok", Target: "10.0.0.1:623"}
+	if g.broken[req.Msg.ServerId] {
+		control.Status = "failed"
+		control.Error = "invalid credentials"
+	}
+	return connect.NewResponse(&gatewayv1.DeepHealthCheckResponse{
+		ServerId: req.Msg.ServerId,
+		Healthy:  !g.broken[req.Msg.ServerId],
+		Hops: []*gatewayv1.HealthCheckHop{
+			{Name: "agent", Status: "ok", Target: "agent-1"},
+			control,
+		},
+	}), nil
+}
+
+func TestConnectivityAudits(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	admin.SetConnectivityAuditOptions(5*time.Second, true)
+
+	fake := &fakeHealthGateway{broken: map[string]bool{}}
+	_, handlerFunc := gatewayv1connect.NewGatewayServiceHandler(fake)
+	server := httptest.NewServer(handlerFunc)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, handler.db.Gateways.Create(ctx, &models.RegionalGateway{
+		ID: "gw-1", Region: "us-east-1", Endpoint: server.URL, Status: "active", LastSeen: now, CreatedAt: now,
+	}))
+
+	for i, gatewayID := range []string{"gw-1", "gw-1", "gw-missing"} {
+		serverID := fmt.Sprintf("server-%d", i+1)
+		require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+			ID:               serverID,
+			CustomerID:       "customer-1",
+			DatacenterID:     "dc-1",
+			ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: fmt.Sprintf("10.0.0.%d:623", i+1), Type: types.BMCTypeIPMI}},
+			PrimaryProtocol:  types.BMCTypeIPMI,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}))
+		require.NoError(t, handler.db.Locations.Create(ctx, &models.ServerLocation{
+			ServerID:          serverID,
+			CustomerID:        "customer-1",
+			DatacenterID:      "dc-1",
+			RegionalGatewayID: gatewayID,
+			PrimaryProtocol:   types.BMCTypeIPMI,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}))
+	}
+
+	require.NoError(t, admin.runConnectivityAudits(ctx, 2))
+	assert.True(t, fake.checkConsoles)
+
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	resp, err := admin.ListConnectivityAudits(adminCtx, connect.NewRequest(&managerv1.ListConnectivityAuditsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Audits, 3)
+	assert.NotNil(t, resp.Msg.LastRunAt)
+
+	healthy := resp.Msg.Audits[0]
+	assert.Equal(t, "server-1", healthy.ServerId)
+	assert.True(t, healthy.Healthy)
+	assert.NotNil(t, healthy.VerifiedAt)
+	assert.Len(t, healthy.Hops, 2)
+
+	unrouted := resp.Msg.Audits[2]
+	assert.False(t, unrouted.Healthy)
+	assert.Equal(t, "gateway", unrouted.FailedHop)
+	assert.Nil(t, unrouted.VerifiedAt, "a server never verified has no verification time")
+
+	t.Run("failures keep the last verification time", func(t *testing.T) {
+		fake.broken["server-1"] = true
+
+		resp, err := admin.RunConnectivityAudit(adminCtx, connect.NewRequest(&managerv1.RunConnectivityAuditRequest{ServerId: "server-1"}))
+		require.NoError(t, err)
+		audit := resp.Msg.Audit
+		assert.False(t, audit.Healthy)
+		assert.Equal(t, "bmc_control", audit.FailedHop)
+		assert.Equal(t, "invalid credentials", audit.Error)
+		assert.Equal(t, healthy.VerifiedAt.AsTime(), audit.VerifiedAt.AsTime())
+
+		failures, err := admin.ListConnectivityAudits(adminCtx, connect.NewRequest(&managerv1.ListConnectivityAuditsRequest{FailuresOnly: true}))
+		require.NoError(t, err)
+		require.Len(t, failures.Msg.Audits, 2)
+		assert.Equal(t, "server-1", failures.Msg.Audits[0].ServerId)
+	})
+
+	t.Run("servers list their last audit", func(t *testing.T) {
+		resp, err := admin.ListAllServers(adminCtx, connect.NewRequest(&managerv1.ListAllServersRequest{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Msg.Servers)
+		for _, server := range resp.Msg.Servers {
+			require.NotNil(t, server.ConnectivityAudit, server.ServerId)
+			assert.Equal(t, server.ServerId, server.ConnectivityAudit.ServerId)
+		}
+	})
+
+	t.Run("audits of removed servers are pruned", func(t *testing.T) {
+		require.NoError(t, handler.db.Servers.Delete(ctx, "server-3"))
+		require.NoError(t, admin.runConnectivityAudits(ctx, 2))
+
+		resp, err := admin.ListConnectivityAudits(adminCtx, connect.NewRequest(&managerv1.ListConnectivityAuditsRequest{}))
+		require.NoError(t, err)
+		assert.Len(t, resp.Msg.Audits, 2)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		_, err := admin.RunConnectivityAudit(adminCtx, connect.NewRequest(&managerv1.RunConnectivityAuditRequest{ServerId: "server-9"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
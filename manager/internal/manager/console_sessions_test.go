@@ -0,0 +1,274 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	commonv1 "core/gen/common/v1"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+// fakeConsoleGateway serves the console session administration RPCs
+type fakeConsoleGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	sessions   []*gatewayv1.ConsoleSessionInfo
+	agents     []*gatewayv1.AgentStatus
+	authHeader string
+	terminated string
+}
+
+func (g *fakeConsoleGateway) ListConsoleSessions(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListConsoleSessionsRequest],
+) (*connect.Response[gatewayv1.ListConsoleSessionsResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	return connect.NewResponse(&gatewayv1.ListConsoleSessionsResponse{Sessions: g.sessions}), nil
+}
+
+func (g *fakeConsoleGateway) ListAgents(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.ListAgentsRequest],
+) (*connect.Response[gatewayv1.ListAgentsResponse], error) {
+	resp := &gatewayv1.ListAgentsResponse{}
+	for _, agent := range g.agents {
+		if req.Msg.DatacenterId == "" || agent.DatacenterId == req.Msg.DatacenterId {
+			resp.Agents = append(resp.Agents, agent)
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (g *fakeConsoleGateway) TerminateConsoleSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.TerminateConsoleSessionRequest],
+) (*connect.Response[gatewayv1.TerminateConsoleSessionResponse], error) {
+	if req.Msg.SessionId != "sol-1" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("console session not found: %s", req.Msg.SessionId))
+	}
+	g.terminated = req.Msg.SessionId
+	return connect.NewResponse(&gatewayv1.TerminateConsoleSessionResponse{DisconnectedStreams: 1}), nil
+}
+
+func (g *fakeConsoleGateway) CreateSOLSession(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.CreateSOLSessionRequest],
+) (*connect.Response[gatewayv1.CreateSOLSessionResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	return connect.NewResponse(&gatewayv1.CreateSOLSessionResponse{
+		SessionId:  "sol-2",
+		ConsoleUrl: "http://gateway/console/sol-2",
+	}), nil
+}
+
+func setupConsoleSessionTest(t *testing.T) (*AdminServiceHandler, *fakeConsoleGateway, context.Context) {
+	t.Helper()
+
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+
+	now := time.Now()
+	fake := &fakeConsoleGateway{
+		sessions: []*gatewayv1.ConsoleSessionInfo{
+			{SessionId: "sol-1", Type: "sol", ServerId: "server-1", CustomerId: "customer-1", AgentId: "agent-1", CreatedAt: timestamppb.New(now),
+				Streams: []*gatewayv1.ConsoleStreamInfo{{ClientAddress: "10.1.2.3:50000", Transport: "connect"}}},
+		},
+		agents: []*gatewayv1.AgentStatus{
+			{AgentId: "agent-1", DatacenterId: "dc-1", Status: "active", ActiveSessionCount: 1,
+				BmcEndpoints: []*gatewayv1.AgentBMCEndpointStatus{{ServerId: "server-1", BmcEndpoint: "10.0.0.1:623", BmcType: commonv1.BMCType_BMC_IPMI}}},
+			{AgentId: "agent-2", DatacenterId: "dc-2", Status: "stale"},
+		},
+	}
+	_, handlerFunc := gatewayv1connect.NewGatewayServiceHandler(fake)
+	server := httptest.NewServer(handlerFunc)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	for _, gateway := range []*models.RegionalGateway{
+		{ID: "gw-1", Region: "us-east-1", Endpoint: server.URL, Status: "active", LastSeen: now, CreatedAt: now},
+		// Nothing listens on the discard port
+		{ID: "gw-down", Region: "eu-west-1", Endpoint: "http://127.0.0.1:9", Status: "active", LastSeen: now, CreatedAt: now},
+	} {
+		require.NoError(t, handler.db.Gateways.Create(ctx, gateway))
+	}
+
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	return admin, fake, adminCtx
+}
+
+func TestAdminListConsoleSessions(t *testing.T) {
+	admin, fake, ctx := setupConsoleSessionTest(t)
+
+	resp, err := admin.ListConsoleSessions(ctx, connect.NewRequest(&managerv1.ListConsoleSessionsRequest{}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Sessions, 1)
+	session := resp.Msg.Sessions[0]
+	assert.Equal(t, "gw-1", session.GatewayId)
+	assert.Equal(t, "us-east-1", session.Region)
+	assert.Equal(t, "sol-1", session.SessionId)
+	require.Len(t, session.Streams, 1)
+	assert.Equal(t, "10.1.2.3:50000", session.Streams[0].ClientAddress)
+	assert.NotEmpty(t, fake.authHeader, "gateway calls should be authenticated")
+
+	require.Len(t, resp.Msg.UnreachableGateways, 1)
+	assert.Equal(t, "gw-down", resp.Msg.UnreachableGateways[0].GatewayId)
+	assert.NotEmpty(t, resp.Msg.UnreachableGateways[0].Error)
+
+	// Gateway filter
+	resp, err = admin.ListConsoleSessions(ctx, connect.NewRequest(&managerv1.ListConsoleSessionsRequest{GatewayFilter: "gw-1"}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Sessions, 1)
+	assert.Empty(t, resp.Msg.UnreachableGateways)
+}
+
+func TestAdminTerminateConsoleSession(t *testing.T) {
+	admin, fake, ctx := setupConsoleSessionTest(t)
+
+	resp, err := admin.TerminateConsoleSession(ctx, connect.NewRequest(&managerv1.TerminateConsoleSessionRequest{
+		GatewayId: "gw-1",
+		SessionId: "sol-1",
+		Reason:    "stuck session",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Msg.DisconnectedStreams)
+	assert.Equal(t, "sol-1", fake.terminated)
+
+	_, err = admin.TerminateConsoleSession(ctx, connect.NewRequest(&managerv1.TerminateConsoleSessionRequest{GatewayId: "gw-1", SessionId: "sol-2"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "gateway error codes should be preserved")
+
+	_, err = admin.TerminateConsoleSession(ctx, connect.NewRequest(&managerv1.TerminateConsoleSessionRequest{GatewayId: "gw-missing", SessionId: "sol-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = admin.TerminateConsoleSession(ctx, connect.NewRequest(&managerv1.TerminateConsoleSessionRequest{GatewayId: "gw-1"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAdminGetTopology(t *testing.T) {
+	admin, _, ctx := setupConsoleSessionTest(t)
+
+	resp, err := admin.GetTopology(ctx, connect.NewRequest(&managerv1.GetTopologyRequest{}))
+	require.NoError(t, err)
+	assert.NotNil(t, resp.Msg.GeneratedAt)
+
+	require.Len(t, resp.Msg.Gateways, 1)
+	gateway := resp.Msg.Gateways[0]
+	assert.Equal(t, "gw-1", gateway.Gateway.GatewayId)
+	assert.Equal(t, "active", gateway.Gateway.Status)
+	require.Len(t, gateway.Agents, 2)
+	assert.Equal(t, "agent-1", gateway.Agents[0].AgentId)
+	require.Len(t, gateway.Agents[0].BmcEndpoints, 1)
+	assert.Equal(t, "ipmi", gateway.Agents[0].BmcEndpoints[0].BmcType)
+	require.Len(t, gateway.Sessions, 1)
+	assert.Equal(t, "us-east-1", gateway.Sessions[0].Region)
+
+	require.Len(t, resp.Msg.UnreachableGateways, 1)
+	assert.Equal(t, "gw-down", resp.Msg.UnreachableGateways[0].GatewayId)
+
+	t.Run("region filter", func(t *testing.T) {
+		resp, err := admin.GetTopology(ctx, connect.NewRequest(&managerv1.GetTopologyRequest{RegionFilter: []string{"us-east-1"}}))
+		require.NoError(t, err)
+		assert.Len(t, resp.Msg.Gateways, 1)
+		assert.Empty(t, resp.Msg.UnreachableGateways)
+
+		resp, err = admin.GetTopology(ctx, connect.NewRequest(&managerv1.GetTopologyRequest{RegionFilter: []string{"ap-south-1"}}))
+		require.NoError(t, err)
+		assert.Empty(t, resp.Msg.Gateways)
+		assert.Empty(t, resp.Msg.UnreachableGateways)
+	})
+
+	t.Run("datacenter filter", func(t *testing.T) {
+		resp, err := admin.GetTopology(ctx, connect.NewRequest(&managerv1.GetTopologyRequest{
+			RegionFilter:     []string{"us-east-1"},
+			DatacenterFilter: "dc-2",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Gateways, 1)
+		require.Len(t, resp.Msg.Gateways[0].Agents, 1)
+		assert.Equal(t, "agent-2", resp.Msg.Gateways[0].Agents[0].AgentId)
+		assert.Empty(t, resp.Msg.Gateways[0].Sessions, "sessions of other datacenters should be left out")
+
+		resp, err = admin.GetTopology(ctx, connect.NewRequest(&managerv1.GetTopologyRequest{
+			RegionFilter:     []string{"us-east-1"},
+			DatacenterFilter: "dc-9",
+		}))
+		require.NoError(t, err)
+		assert.Empty(t, resp.Msg.Gateways, "gateways not serving the datacenter should be left out")
+	})
+}
+
+func TestAdminLaunchSOLSession_Impersonation(t *testing.T) {
+	admin, fake, ctx := setupConsoleSessionTest(t)
+
+	customer := setupTestCustomer(t, "customer-1")
+	customer.RequireMFA = true
+	require.NoError(t, admin.db.Customers.Create(ctx, customer))
+	require.NoError(t, admin.db.Servers.Create(ctx, &domain.Server{
+		ID:               "server-1",
+		CustomerID:       "customer-1",
+		DatacenterID:     "dc-1",
+		ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "10.0.0.1:623", Type: types.BMCTypeIPMI}},
+		PrimaryProtocol:  types.BMCTypeIPMI,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}))
+	require.NoError(t, admin.db.Locations.Create(ctx, &models.ServerLocation{
+		ServerID:          "server-1",
+		CustomerID:        "customer-1",
+		DatacenterID:      "dc-1",
+		RegionalGatewayID: "gw-1",
+		PrimaryProtocol:   types.BMCTypeIPMI,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}))
+
+	// Impersonations are justified
+	_, err := admin.LaunchSOLSession(ctx, connect.NewRequest(&managerv1.LaunchSessionRequest{ServerId: "server-1"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	resp, err := admin.LaunchSOLSession(ctx, connect.NewRequest(&managerv1.LaunchSessionRequest{
+		ServerId: "server-1",
+		Reason:   "ticket 4521, boot loop",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "sol-2", resp.Msg.SessionId)
+	assert.Equal(t, "http://gateway/console/sol-2", resp.Msg.ViewerUrl)
+
+	// The gateway got a console token of the customer naming the admin
+	token := strings.TrimPrefix(fake.authHeader, "Bearer ")
+	claims, serverContext, err := admin.jwtManager.ValidateServerToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "customer-1", claims.CustomerID)
+	assert.Equal(t, "customer-1@example.com", claims.Email)
+	assert.True(t, claims.MFARequired, "the customer's two-factor policy applies to the admin")
+	assert.Equal(t, "admin@example.com", serverContext.ImpersonatedBy)
+	assert.Equal(t, []string{"console:access", "sol"}, serverContext.Permissions)
+
+	events, err := admin.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditServerConsoleImpersonate})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "admin@example.com", events[0].Actor)
+	assert.Equal(t, "server-1", events[0].TargetID)
+	assert.Equal(t, map[string]string{
+		"customer_id": "customer-1",
+		"session_id":  "sol-2",
+		"type":        "sol",
+		"gateway_id":  "gw-1",
+		"reason":      "ticket 4521, boot loop",
+	}, events[0].Details)
+}
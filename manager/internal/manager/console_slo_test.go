@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestConsoleSLOReport(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{
+		AvailabilityTarget: 0.9,
+		TTFBTarget:         0.9,
+		TTFBThreshold:      time.Second,
+		Window:             24 * time.Hour,
+	})
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	started := timestamppb.New(time.Now().Add(-time.Hour))
+	report := func(gatewayID string, sessions ...*managerv1.ConsoleSessionSLI) {
+		t.Helper()
+		resp, err := handler.ReportConsoleSLIs(ctx, connect.NewRequest(&managerv1.ReportConsoleSLIsRequest{
+			GatewayId: gatewayID,
+			Sessions:  sessions,
+		}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Success)
+	}
+
+	report("gw-1",
+		&managerv1.ConsoleSessionSLI{AgentId: "agent-a", SessionType: "sol", StartedAt: started, Established: true, FirstByte: true, TimeToFirstByteMs: 200},
+		&managerv1.ConsoleSessionSLI{AgentId: "agent-a", SessionType: "sol", StartedAt: started, Error: "agent not available"},
+		&managerv1.ConsoleSessionSLI{AgentId: "agent-b", SessionType: "vnc", StartedAt: started, Established: true, FirstByte: true, TimeToFirstByteMs: 1500},
+		// Incomplete measurements are skipped
+		&managerv1.ConsoleSessionSLI{SessionType: "sol", StartedAt: started},
+	)
+	report("gw-2",
+		&managerv1.ConsoleSessionSLI{AgentId: "agent-c", SessionType: "sol", StartedAt: started, Established: true},
+	)
+
+	resp, err := admin.GetConsoleSLOReport(ctx, connect.NewRequest(&managerv1.GetConsoleSLOReportRequest{}))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1000), resp.Msg.Objectives.TtfbThresholdMs)
+	assert.Equal(t, int64(24*60*60), resp.Msg.Objectives.WindowSeconds)
+
+	overall := resp.Msg.Overall
+	assert.Equal(t, int64(4), overall.Attempts)
+	assert.Equal(t, int64(3), overall.Successes)
+	assert.InDelta(t, 0.75, overall.SuccessRate, 1e-9)
+	assert.False(t, overall.AvailabilityMet)
+	assert.Equal(t, int64(2), overall.TtfbSamples)
+	assert.Equal(t, int64(1), overall.TtfbWithinThreshold)
+	assert.InDelta(t, 850, overall.AvgTtfbMs, 1e-9)
+
+	require.Len(t, resp.Msg.Gateways, 2)
+	assert.Equal(t, "gw-1", resp.Msg.Gateways[0].GatewayId)
+	assert.Equal(t, int64(3), resp.Msg.Gateways[0].Attempts)
+	assert.Equal(t, "gw-2", resp.Msg.Gateways[1].GatewayId)
+	assert.True(t, resp.Msg.Gateways[1].AvailabilityMet)
+
+	require.Len(t, resp.Msg.Agents, 3)
+	assert.Equal(t, "agent-a", resp.Msg.Agents[0].AgentId)
+	assert.InDelta(t, 0.5, resp.Msg.Agents[0].SuccessRate, 1e-9)
+	assert.Less(t, resp.Msg.Agents[0].ErrorBudgetRemaining, 0.0)
+
+	// Gateway filter
+	resp, err = admin.GetConsoleSLOReport(ctx, connect.NewRequest(&managerv1.GetConsoleSLOReportRequest{
+		GatewayFilter: "gw-2",
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Agents, 1)
+	assert.Equal(t, int64(1), resp.Msg.Overall.Attempts)
+	assert.Equal(t, 1.0, resp.Msg.Overall.TtfbCompliance)
+}
+
+func TestReportConsoleSLIs_RequiresGatewayAccount(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetGatewayAccounts(map[string]string{"gw-1": "gw-1@example.com", "gw-2": "gw-2@example.com"})
+
+	report := func(ctx context.Context) error {
+		_, err := handler.ReportConsoleSLIs(ctx, connect.NewRequest(&managerv1.ReportConsoleSLIsRequest{GatewayId: "gw-1"}))
+		return err
+	}
+
+	customerCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, "customer-1"))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(report(customerCtx)))
+
+	otherGatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gw-2", Email: "gw-2@example.com"})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(report(otherGatewayCtx)))
+
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(report(context.Background())))
+
+	gatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gw-1", Email: "gw-1@example.com"})
+	assert.NoError(t, report(gatewayCtx))
+}
+
+func TestReportConsoleSLIs_RequiresGatewayID(t *testing.T) {
+	handler := setupTestHandler(t)
+
+	_, err := handler.ReportConsoleSLIs(context.Background(), connect.NewRequest(&managerv1.ReportConsoleSLIsRequest{}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
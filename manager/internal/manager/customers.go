@@ -0,0 +1,542 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// defaultAPIKeyLength is the number of random bytes of API keys, matching
+// the customer_management.api_key_length default
+const defaultAPIKeyLength = 32
+
+// apiKeyPrefix marks the API keys issued by the manager
+const apiKeyPrefix = "bmc_"
+
+// Reasons of token revocations
+const (
+	revocationDisabled = "disabled"
+	revocationDeleted  = "deleted"
+)
+
+// defaultRevocationReason is shown to the clients of the console sessions
+// of disabled and deleted customers
+const defaultRevocationReason = "customer access revoked"
+
+// SetAPIKeyLength sets the number of random bytes of the API keys issued to
+// customers
+func (h *AdminServiceHandler) SetAPIKeyLength(length int) {
+	h.apiKeyLength = length
+}
+
+// newAPIKey generates an API key of length random bytes
+func newAPIKey(length int) (string, error) {
+	if length <= 0 {
+		length = defaultAPIKeyLength
+	}
+	key := make([]byte, length)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// CheckCustomerAccess rejects the tokens of disabled customers, and those
+// issued before their customer was disabled or deleted. Customers
+// authenticated by email without a record keep their access.
+func (h *BMCManagerServiceHandler) CheckCustomerAccess(ctx context.Context, claims *models.AuthClaims) error {
+	customer, err := h.db.Customers.Get(ctx, claims.CustomerID)
+	if err != nil && err.Error() != "customer not found" {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer != nil && customer.Disabled {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("customer access revoked"))
+	}
+
+	revocation, err := h.db.TokenRevocations.Get(ctx, claims.CustomerID)
+	if err != nil {
+		if err.Error() == "token revocation not found" {
+			return nil
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get token revocation: %w", err))
+	}
+	if !claims.IssuedAt.After(revocation.RevokedAt) {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("token revoked, authenticate again"))
+	}
+	return nil
+}
+
+// CreateCustomer creates a customer account with an API key. Customers are
+// identified by their email unless given an ID, as when they authenticate.
+func (h *AdminServiceHandler) CreateCustomer(
+	ctx context.Context,
+	req *connect.Request[managerv1.CreateCustomerRequest],
+) (*connect.Response[managerv1.CreateCustomerResponse], error) {
+	log.Info().
+		Str("email", req.Msg.Email).
+		Str("customer_id", req.Msg.CustomerId).
+		Bool("is_admin", req.Msg.IsAdmin).
+		Msg("CreateCustomer called")
+
+	// Display names are not part of customer emails
+	email := strings.TrimSpace(req.Msg.Email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("a valid email is required"))
+	}
+	customerID := req.Msg.CustomerId
+	if customerID == "" {
+		customerID = email
+	}
+
+	// Emails are unique regardless of case
+	if existing, err := h.db.Customers.GetByEmail(ctx, email); err == nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("a customer with email %s already exists: %s", existing.Email, existing.ID))
+	} else if err.Error() != "customer not found" {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	if _, err := h.db.Customers.Get(ctx, customerID); err == nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("customer already exists: %s", customerID))
+	} else if err.Error() != "customer not found" {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+
+	apiKey, err := newAPIKey(h.apiKeyLength)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	customer := &models.Customer{
+		ID:        customerID,
+		Email:     email,
+		APIKey:    apiKey,
+		IsAdmin:   req.Msg.IsAdmin,
+		CreatedAt: time.Now(),
+	}
+	if err := h.db.Customers.Create(ctx, customer); err != nil {
+		log.Error().Err(err).Str("customer_id", customerID).Msg("Failed to create customer")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create customer: %w", err))
+	}
+
+	h.audit(ctx, auditCustomerCreate, "customer", customer.ID, map[string]string{
+		"email":    customer.Email,
+		"is_admin": strconv.FormatBool(customer.IsAdmin),
+	})
+
+	return connect.NewResponse(&managerv1.CreateCustomerResponse{
+		Customer: customerSummary(customer),
+		ApiKey:   apiKey,
+	}), nil
+}
+
+// IssueAPIKey issues a new API key to a customer. Its previous key stops
+// authenticating at once.
+func (h *AdminServiceHandler) IssueAPIKey(
+	ctx context.Context,
+	req *connect.Request[managerv1.IssueAPIKeyRequest],
+) (*connect.Response[managerv1.IssueAPIKeyResponse], error) {
+	log.Info().Str("customer_id", req.Msg.CustomerId).Msg("IssueAPIKey called")
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := newAPIKey(h.apiKeyLength)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	customer.APIKey = apiKey
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	// The audit log never holds keys
+	h.audit(ctx, auditCustomerAPIKey, "customer", customer.ID, nil)
+
+	return connect.NewResponse(&managerv1.IssueAPIKeyResponse{
+		CustomerId: customer.ID,
+		ApiKey:     apiKey,
+	}), nil
+}
+
+// DisableCustomer revokes the access of a customer, or restores it. Disabled
+// customers keep their servers and their data, so that they can be enabled
+// again; the tokens issued before they were disabled stay revoked.
+func (h *AdminServiceHandler) DisableCustomer(
+	ctx context.Context,
+	req *connect.Request[managerv1.DisableCustomerRequest],
+) (*connect.Response[managerv1.DisableCustomerResponse], error) {
+	log.Info().
+		Str("customer_id", req.Msg.CustomerId).
+		Bool("enable", req.Msg.Enable).
+		Msg("DisableCustomer called")
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.Enable {
+		customer.Disabled = false
+		if err := h.db.Customers.Update(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+		}
+		h.audit(ctx, auditCustomerEnable, "customer", customer.ID, nil)
+		return connect.NewResponse(&managerv1.DisableCustomerResponse{Customer: customerSummary(customer)}), nil
+	}
+
+	if err := checkNotSelf(ctx, customer.ID); err != nil {
+		return nil, err
+	}
+
+	customer.Disabled = true
+	err = h.db.RunInTx(ctx, func(ctx context.Context, tx *database.Tx) error {
+		if err := tx.Customers.Update(ctx, customer); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+		}
+		if err := tx.TokenRevocations.Revoke(ctx, customer.ID, revocationDisabled); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke tokens: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	terminated, unreachable, err := h.terminateCustomerSessions(ctx, customer.ID, req.Msg.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	h.audit(ctx, auditCustomerDisable, "customer", customer.ID, map[string]string{
+		"reason":              req.Msg.Reason,
+		"terminated_sessions": strconv.Itoa(int(terminated)),
+	})
+
+	return connect.NewResponse(&managerv1.DisableCustomerResponse{
+		Customer:            customerSummary(customer),
+		TerminatedSessions:  terminated,
+		UnreachableGateways: unreachable,
+	}), nil
+}
+
+// DeleteCustomer deletes a customer account and its proxy sessions. Servers
+// are never left without an owner: customers owning servers are only deleted
+// with a customer to reassign them to. The audit log and the usage records
+// of the customer are kept.
+func (h *AdminServiceHandler) DeleteCustomer(
+	ctx context.Context,
+	req *connect.Request[managerv1.DeleteCustomerRequest],
+) (*connect.Response[managerv1.DeleteCustomerResponse], error) {
+	log.Info().
+		Str("customer_id", req.Msg.CustomerId).
+		Str("reassign_to", req.Msg.ReassignTo).
+		Msg("DeleteCustomer called")
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotSelf(ctx, customer.ID); err != nil {
+		return nil, err
+	}
+
+	servers, err := h.db.Servers.List(ctx, customer.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list servers: %w", err))
+	}
+	if len(servers) > 0 {
+		if req.Msg.ReassignTo == "" {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("customer %s owns %d server(s): reassign them to another customer first", customer.ID, len(servers)))
+		}
+		if req.Msg.ReassignTo == customer.ID {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("cannot reassign servers to the deleted customer"))
+		}
+		if _, err := h.getCustomer(ctx, req.Msg.ReassignTo); err != nil {
+			return nil, err
+		}
+	}
+
+	// The servers are reassigned, the tokens revoked and the records deleted
+	// all at once, or not at all
+	err = h.db.RunInTx(ctx, func(ctx context.Context, tx *database.Tx) error {
+		for _, server := range servers {
+			if _, _, err := h.assignServer(ctx, tx, server.ID, req.Msg.ReassignTo); err != nil {
+				return err
+			}
+		}
+
+		// Revoke the tokens before the record disappears, so that they do not
+		// pass as those of a customer authenticated by email
+		if err := tx.TokenRevocations.Revoke(ctx, customer.ID, revocationDeleted); err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke tokens: %w", err))
+		}
+
+		proxySessions, err := tx.Sessions.ListByCustomer(ctx, customer.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list proxy sessions: %w", err))
+		}
+		for _, session := range proxySessions {
+			if err := tx.Sessions.Delete(ctx, session.ID); err != nil {
+				return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete proxy session: %w", err))
+			}
+		}
+
+		if err := tx.Customers.Delete(ctx, customer.ID); err != nil {
+			log.Error().Err(err).Str("customer_id", customer.ID).Msg("Failed to delete customer")
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete customer: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range servers {
+		h.auditServerAssign(ctx, server.ID, req.Msg.ReassignTo, customer.ID)
+	}
+
+	terminated, unreachable, err := h.terminateCustomerSessions(ctx, customer.ID, req.Msg.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	h.audit(ctx, auditCustomerDelete, "customer", customer.ID, map[string]string{
+		"email":               customer.Email,
+		"reason":              req.Msg.Reason,
+		"reassign_to":         req.Msg.ReassignTo,
+		"reassigned_servers":  strconv.Itoa(len(servers)),
+		"terminated_sessions": strconv.Itoa(int(terminated)),
+	})
+
+	return connect.NewResponse(&managerv1.DeleteCustomerResponse{
+		ReassignedServers:   int32(len(servers)),
+		TerminatedSessions:  terminated,
+		UnreachableGateways: unreachable,
+	}), nil
+}
+
+// AssignServer assigns a server to a customer. Server tokens are only issued
+// to the customer owning the server, so the previous owner loses access once
+// its tokens expire.
+func (h *AdminServiceHandler) AssignServer(
+	ctx context.Context,
+	req *connect.Request[managerv1.AssignServerRequest],
+) (*connect.Response[managerv1.AssignServerResponse], error) {
+	log.Info().
+		Str("server_id", req.Msg.ServerId).
+		Str("customer_id", req.Msg.CustomerId).
+		Msg("AssignServer called")
+
+	if _, err := h.getCustomer(ctx, req.Msg.CustomerId); err != nil {
+		return nil, err
+	}
+
+	var server *domain.Server
+	var previousCustomerID string
+	err := h.db.RunInTx(ctx, func(ctx context.Context, tx *database.Tx) error {
+		var err error
+		server, previousCustomerID, err = h.assignServer(ctx, tx, req.Msg.ServerId, req.Msg.CustomerId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.auditServerAssign(ctx, server.ID, server.CustomerID, previousCustomerID)
+
+	return connect.NewResponse(&managerv1.AssignServerResponse{
+		Server:             serverToProto(server),
+		PreviousCustomerId: previousCustomerID,
+	}), nil
+}
+
+// assignServer changes the owner of a server and of its location in a
+// transaction, returning the previous owner
+func (h *AdminServiceHandler) assignServer(ctx context.Context, tx *database.Tx, serverID, customerID string) (*domain.Server, string, error) {
+	var previousCustomerID string
+	server, err := h.updateServer(ctx, tx.Servers, serverID, func(server *domain.Server) {
+		previousCustomerID = server.CustomerID
+		server.CustomerID = customerID
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Keep the location of the server, used to route it, owned alike
+	location, err := tx.Locations.Get(ctx, server.ID)
+	switch {
+	case err == nil:
+		location.CustomerID = server.CustomerID
+		location.UpdatedAt = time.Now()
+		if err := tx.Locations.Update(ctx, location); err != nil {
+			return nil, "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update server location: %w", err))
+		}
+	case err.Error() != "server location not found":
+		return nil, "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server location: %w", err))
+	}
+	return server, previousCustomerID, nil
+}
+
+// auditServerAssign records the assignment of a server, once committed
+func (h *AdminServiceHandler) auditServerAssign(ctx context.Context, serverID, customerID, previousCustomerID string) {
+	h.audit(ctx, auditServerAssign, "server", serverID, map[string]string{
+		"customer_id":          customerID,
+		"previous_customer_id": previousCustomerID,
+	})
+}
+
+// getCustomer returns a customer by ID, failing with NotFound when it does
+// not exist
+func (h *AdminServiceHandler) getCustomer(ctx context.Context, customerID string) (*models.Customer, error) {
+	if customerID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("customer_id is required"))
+	}
+
+	customer, err := h.db.Customers.Get(ctx, customerID)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("customer not found: %s", customerID))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	return customer, nil
+}
+
+// checkNotSelf keeps admins from revoking their own access, which would
+// leave them unable to undo it
+func checkNotSelf(ctx context.Context, customerID string) error {
+	if claims, ok := ctx.Value("claims").(*models.AuthClaims); ok && claims.CustomerID == customerID {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("cannot revoke the access of your own account"))
+	}
+	return nil
+}
+
+// terminateCustomerSessions terminates the console sessions of a customer on
+// every gateway. The gateways that cannot be reached are returned: they
+// reject the sessions when a client next attaches, see ValidateSession.
+func (h *AdminServiceHandler) terminateCustomerSessions(
+	ctx context.Context,
+	customerID, reason string,
+) (int32, []*managerv1.UnreachableGateway, error) {
+	if reason == "" {
+		reason = defaultRevocationReason
+	}
+
+	token, err := h.gatewayAdminToken(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	gateways, err := h.db.Gateways.List(ctx)
+	if err != nil {
+		return 0, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list gateways: %w", err))
+	}
+
+	type gatewayResult struct {
+		gateway    *models.RegionalGateway
+		terminated int32
+		err        error
+	}
+
+	var wg sync.WaitGroup
+	results := make([]gatewayResult, 0, len(gateways))
+	for _, gateway := range gateways {
+		// Pending gateways hold no sessions, their endpoints are ignored
+		if gateway.Status == models.GatewayStatusPending {
+			continue
+		}
+		results = append(results, gatewayResult{gateway: gateway})
+	}
+
+	for i := range results {
+		wg.Add(1)
+		go func(result *gatewayResult) {
+			defer wg.Done()
+
+			queryCtx, cancel := context.WithTimeout(ctx, gatewayQueryTimeout)
+			defer cancel()
+
+			result.terminated, result.err = terminateGatewaySessions(queryCtx, result.gateway.Endpoint, token, customerID, reason)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var terminated int32
+	var unreachable []*managerv1.UnreachableGateway
+	for _, result := range results {
+		terminated += result.terminated
+		if result.err != nil {
+			log.Warn().Err(result.err).
+				Str("gateway_id", result.gateway.ID).
+				Str("customer_id", customerID).
+				Msg("Failed to terminate the console sessions of a customer on gateway")
+			unreachable = append(unreachable, &managerv1.UnreachableGateway{
+				GatewayId: result.gateway.ID,
+				Error:     result.err.Error(),
+			})
+		}
+	}
+	return terminated, unreachable, nil
+}
+
+// terminateGatewaySessions terminates the console sessions of a customer on
+// a gateway
+func terminateGatewaySessions(ctx context.Context, endpoint, token, customerID, reason string) (int32, error) {
+	client := newGatewayClient(endpoint, token)
+	resp, err := client.ListConsoleSessions(ctx, connect.NewRequest(&gatewayv1.ListConsoleSessionsRequest{
+		CustomerId: customerID,
+	}))
+	if err != nil {
+		return 0, err
+	}
+
+	var terminated int32
+	for _, session := range resp.Msg.Sessions {
+		if session.CustomerId != customerID {
+			continue
+		}
+		_, err := client.TerminateConsoleSession(ctx, connect.NewRequest(&gatewayv1.TerminateConsoleSessionRequest{
+			SessionId: session.SessionId,
+			Reason:    reason,
+		}))
+		switch {
+		case err == nil:
+			terminated++
+		case connect.CodeOf(err) != connect.CodeNotFound: // Sessions may end meanwhile
+			return terminated, err
+		}
+	}
+	return terminated, nil
+}
+
+// customerSummary returns the admin view of a customer, without its server
+// counts
+func customerSummary(customer *models.Customer) *managerv1.CustomerSummary {
+	return &managerv1.CustomerSummary{
+		CustomerId:            customer.ID,
+		Email:                 customer.Email,
+		IsAdmin:               customer.IsAdmin,
+		CreatedAt:             timestamppb.New(customer.CreatedAt),
+		MaxConcurrentSessions: int32(customer.MaxConcurrentSessions),
+		MaxSessionsPerMinute:  int32(customer.MaxSessionsPerMinute),
+		Disabled:              customer.Disabled,
+		TotpEnabled:           customer.TOTPEnabled,
+		RequireMfa:            customer.RequireMFA,
+		IpAllowlist:           customer.AllowedSourceRanges,
+	}
+}
@@ -0,0 +1,240 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestCreateCustomerAndIssueAPIKey(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	created, err := admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: "ops@acme.example"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ops@acme.example", created.Msg.Customer.CustomerId, "customers are identified by their email by default")
+	assert.True(t, strings.HasPrefix(created.Msg.ApiKey, apiKeyPrefix))
+
+	_, err = handler.AuthorizeAPIKey(ctx, created.Msg.ApiKey)
+	require.NoError(t, err)
+
+	t.Run("duplicates are rejected", func(t *testing.T) {
+		_, err := admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: "ops@acme.example", CustomerId: "acme"}))
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+		_, err = admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: "other@acme.example", CustomerId: "ops@acme.example"}))
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	})
+
+	t.Run("emails are unique regardless of case", func(t *testing.T) {
+		_, err := admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: "Ops@ACME.example"}))
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	})
+
+	t.Run("an email is required", func(t *testing.T) {
+		for _, email := range []string{"acme", "Ops <ops2@acme.example>"} {
+			_, err := admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: email}))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), email)
+		}
+	})
+
+	// A new key revokes the previous one
+	issued, err := admin.IssueAPIKey(ctx, connect.NewRequest(&managerv1.IssueAPIKeyRequest{CustomerId: "ops@acme.example"}))
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Msg.ApiKey, issued.Msg.ApiKey)
+
+	_, err = handler.AuthorizeAPIKey(ctx, created.Msg.ApiKey)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = handler.AuthorizeAPIKey(ctx, issued.Msg.ApiKey)
+	require.NoError(t, err)
+
+	_, err = admin.IssueAPIKey(ctx, connect.NewRequest(&managerv1.IssueAPIKeyRequest{CustomerId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// Both changes are audited, without the keys
+	events, err := admin.ListAuditEvents(ctx, connect.NewRequest(&managerv1.ListAuditEventsRequest{TargetId: "ops@acme.example"}))
+	require.NoError(t, err)
+	require.Len(t, events.Msg.Events, 2)
+	assert.Equal(t, auditCustomerAPIKey, events.Msg.Events[0].Action)
+	assert.Equal(t, auditCustomerCreate, events.Msg.Events[1].Action)
+	assert.Equal(t, "admin@example.com", events.Msg.Events[1].Actor)
+	for _, event := range events.Msg.Events {
+		for _, value := range event.Details {
+			assert.NotContains(t, value, apiKeyPrefix)
+		}
+	}
+}
+
+func TestAssignServer(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	for _, id := range []string{"customer-1", "customer-2"} {
+		require.NoError(t, handler.db.Customers.Create(ctx, setupTestCustomer(t, id)))
+	}
+	gateway := setupTestGateway(t, handler)
+	require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+		ID:               "server-1",
+		CustomerID:       "customer-1",
+		DatacenterID:     "dc-test-01",
+		ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI}},
+		PrimaryProtocol:  types.BMCTypeIPMI,
+		Status:           "active",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}))
+	require.NoError(t, handler.db.Locations.Create(ctx, &models.ServerLocation{
+		ServerID:          "server-1",
+		CustomerID:        "customer-1",
+		DatacenterID:      "dc-test-01",
+		RegionalGatewayID: gateway.ID,
+		PrimaryProtocol:   types.BMCTypeIPMI,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}))
+
+	resp, err := admin.AssignServer(ctx, connect.NewRequest(&managerv1.AssignServerRequest{ServerId: "server-1", CustomerId: "customer-2"}))
+	require.NoError(t, err)
+	assert.Equal(t, "customer-2", resp.Msg.Server.CustomerId)
+	assert.Equal(t, "customer-1", resp.Msg.PreviousCustomerId)
+
+	location, err := handler.db.Locations.Get(ctx, "server-1")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-2", location.CustomerID)
+
+	events, err := handler.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditServerAssign})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]string{"customer_id": "customer-2", "previous_customer_id": "customer-1"}, events[0].Details)
+
+	_, err = admin.AssignServer(ctx, connect.NewRequest(&managerv1.AssignServerRequest{ServerId: "server-1", CustomerId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = admin.AssignServer(ctx, connect.NewRequest(&managerv1.AssignServerRequest{ServerId: "unknown", CustomerId: "customer-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+// customerToken returns an access token of a customer issued before now
+func customerToken(t *testing.T, admin *AdminServiceHandler, customer *models.Customer) string {
+	t.Helper()
+
+	token, err := admin.jwtManager.GenerateToken(customer)
+	require.NoError(t, err)
+	// Tokens are issued by the second, revocations reject those issued up to
+	// their second
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	return token
+}
+
+func TestDisableCustomer(t *testing.T) {
+	admin, fake, ctx := setupConsoleSessionTest(t)
+	handler := NewBMCManagerServiceHandler(admin.db, admin.jwtManager, nil)
+
+	created, err := admin.CreateCustomer(ctx, connect.NewRequest(&managerv1.CreateCustomerRequest{Email: "ops@acme.example", CustomerId: "customer-1"}))
+	require.NoError(t, err)
+	token := customerToken(t, admin, &models.Customer{ID: "customer-1", Email: "ops@acme.example"})
+	_, err = handler.Authorize(ctx, "Bearer "+token)
+	require.NoError(t, err)
+
+	resp, err := admin.DisableCustomer(ctx, connect.NewRequest(&managerv1.DisableCustomerRequest{CustomerId: "customer-1", Reason: "unpaid invoices"}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Customer.Disabled)
+	assert.Equal(t, int32(1), resp.Msg.TerminatedSessions)
+	assert.Equal(t, "sol-1", fake.terminated)
+	require.Len(t, resp.Msg.UnreachableGateways, 1)
+	assert.Equal(t, "gw-down", resp.Msg.UnreachableGateways[0].GatewayId)
+
+	// Neither the API key nor the tokens authenticate
+	_, err = handler.AuthorizeAPIKey(ctx, created.Msg.ApiKey)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = handler.Authorize(ctx, "Bearer "+token)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = handler.Authenticate(ctx, connect.NewRequest(&managerv1.AuthenticateRequest{Email: "ops@acme.example"}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	t.Run("admins cannot disable themselves", func(t *testing.T) {
+		require.NoError(t, admin.db.Customers.Create(ctx, setupTestCustomer(t, "admin")))
+		_, err := admin.DisableCustomer(ctx, connect.NewRequest(&managerv1.DisableCustomerRequest{CustomerId: "admin"}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	// Enabling restores the API key, tokens issued before stay revoked
+	resp, err = admin.DisableCustomer(ctx, connect.NewRequest(&managerv1.DisableCustomerRequest{CustomerId: "customer-1", Enable: true}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Customer.Disabled)
+	_, err = handler.AuthorizeAPIKey(ctx, created.Msg.ApiKey)
+	require.NoError(t, err)
+	_, err = handler.Authorize(ctx, "Bearer "+token)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	events, err := admin.db.AuditEvents.List(ctx, database.AuditEventFilter{TargetID: "customer-1"})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, auditCustomerEnable, events[0].Action)
+	assert.Equal(t, auditCustomerDisable, events[1].Action)
+	assert.Equal(t, map[string]string{"reason": "unpaid invoices", "terminated_sessions": "1"}, events[1].Details)
+}
+
+func TestDeleteCustomer(t *testing.T) {
+	admin, fake, ctx := setupConsoleSessionTest(t)
+	handler := NewBMCManagerServiceHandler(admin.db, admin.jwtManager, nil)
+
+	for _, id := range []string{"customer-1", "customer-2"} {
+		require.NoError(t, admin.db.Customers.Create(ctx, setupTestCustomer(t, id)))
+	}
+	require.NoError(t, admin.db.Servers.Create(ctx, &domain.Server{
+		ID:               "server-1",
+		CustomerID:       "customer-1",
+		DatacenterID:     "dc-1",
+		ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI}},
+		PrimaryProtocol:  types.BMCTypeIPMI,
+		Status:           "active",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}))
+	token := customerToken(t, admin, &models.Customer{ID: "customer-1", Email: "customer-1@example.com"})
+
+	// Servers are never left without an owner
+	_, err := admin.DeleteCustomer(ctx, connect.NewRequest(&managerv1.DeleteCustomerRequest{CustomerId: "customer-1"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	_, err = admin.DeleteCustomer(ctx, connect.NewRequest(&managerv1.DeleteCustomerRequest{CustomerId: "customer-1", ReassignTo: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	resp, err := admin.DeleteCustomer(ctx, connect.NewRequest(&managerv1.DeleteCustomerRequest{CustomerId: "customer-1", ReassignTo: "customer-2"}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Msg.ReassignedServers)
+	assert.Equal(t, int32(1), resp.Msg.TerminatedSessions)
+	assert.Equal(t, "sol-1", fake.terminated)
+
+	_, err = admin.db.Customers.Get(ctx, "customer-1")
+	require.Error(t, err)
+	server, err := admin.db.Servers.Get(ctx, "server-1")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-2", server.CustomerID)
+
+	// Tokens of the deleted customer do not pass as those of a customer
+	// authenticated by email, nor do its console sessions
+	_, err = handler.Authorize(ctx, "Bearer "+token)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	validated, err := handler.ValidateSession(ctx, connect.NewRequest(&managerv1.ValidateSessionRequest{
+		GatewayId: "gw-1", SessionId: "sol-1", ServerId: "server-1", CustomerId: "customer-1",
+	}))
+	require.NoError(t, err)
+	assert.False(t, validated.Msg.Valid)
+	assert.Equal(t, "customer deleted", validated.Msg.Reason)
+
+	_, err = admin.DeleteCustomer(ctx, connect.NewRequest(&managerv1.DeleteCustomerRequest{CustomerId: "customer-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
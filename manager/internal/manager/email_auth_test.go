@@ -18,6 +18,8 @@ import (
 
 func TestAuthenticate_UsesEmailAsCustomerID(t *testing.T) {
 	handler := setupTestHandler(t)
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication with email
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
@@ -42,6 +44,8 @@ func TestAuthenticate_UsesEmailAsCustomerID(t *testing.T) {
 
 func TestAuthenticate_DifferentEmailsGetDifferentCustomerIDs(t *testing.T) {
 	handler := setupTestHandler(t)
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test with first email
 	req1 := connect.NewRequest(&managerv1.AuthenticateRequest{
@@ -377,6 +381,8 @@ func TestEmailBasedCustomerID_EndToEndFlow(t *testing.T) {
 		"Should be re-enabled when proper ServerCustomerMapping table logic is implemented.")
 
 	handler := setupTestHandler(t)
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 	setupTestGateway(t, handler)
 
 	// Step 1: Authenticate user
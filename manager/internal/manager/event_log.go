@@ -0,0 +1,61 @@
+package manager
+
+import (
+	"sync"
+
+	"core/events"
+)
+
+// EventLog keeps the most recent system events in memory, so that admins can
+// review what happened to a server without subscribing a webhook
+type EventLog struct {
+	mu     sync.Mutex
+	events []events.Event // Ring buffer, next is the oldest once full
+	next   int
+	full   bool
+}
+
+// NewEventLog creates an event log keeping the last size events
+func NewEventLog(size int) *EventLog {
+	return &EventLog{events: make([]events.Event, size)}
+}
+
+// Publish records an event, replacing the oldest one when the log is full
+func (l *EventLog) Publish(event events.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.events) == 0 {
+		return
+	}
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns up to limit events whose data names serverID, newest first.
+// A limit of 0 returns all of them.
+func (l *EventLog) Recent(serverID string, limit int) []events.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.events)
+	}
+
+	var recent []events.Event
+	for i := 1; i <= count; i++ {
+		event := l.events[(l.next-i+len(l.events))%len(l.events)]
+		if id, _ := event.Data["server_id"].(string); id != serverID {
+			continue
+		}
+		recent = append(recent, event)
+		if limit > 0 && len(recent) == limit {
+			break
+		}
+	}
+	return recent
+}
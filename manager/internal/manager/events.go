@@ -0,0 +1,230 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/events"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// gatewayOfflineAfter is how long a gateway may go without re-registering
+// before it is considered offline, matching GetSystemStatus
+const gatewayOfflineAfter = 2 * time.Minute
+
+// SetEventPublisher sets the publisher notified of system events, e.g. the
+// webhook dispatcher. Events are discarded until a publisher is set.
+func (h *BMCManagerServiceHandler) SetEventPublisher(publisher events.Publisher) {
+	h.events = publisher
+}
+
+// SetEventBus sets the publisher of the event bus. Only events originating
+// from the manager are published to it: gateways publish their own events to
+// the bus, and ReportEvents relays them to the event publisher only.
+func (h *BMCManagerServiceHandler) SetEventBus(publisher events.Publisher) {
+	h.eventBus = publisher
+}
+
+// SetEventLog sets the log recording all system events, those of the
+// manager and those reported by gateways, for the admin dashboard
+func (h *BMCManagerServiceHandler) SetEventLog(eventLog *EventLog) {
+	h.eventLog = eventLog
+}
+
+// publish emits a system event originating from the manager
+func (h *BMCManagerServiceHandler) publish(eventType events.Type, data map[string]any) {
+	event := events.New(eventType, "manager", data)
+	h.notify(event)
+	if h.eventBus != nil {
+		h.eventBus.Publish(event)
+	}
+}
+
+// notify delivers a system event to the event publisher and records it in the
+// event log
+func (h *BMCManagerServiceHandler) notify(event events.Event) {
+	if h.events != nil {
+		h.events.Publish(event)
+	}
+	if h.eventLog != nil {
+		h.eventLog.Publish(event)
+	}
+}
+
+// ReportEvents accepts system events observed by a gateway, such as power
+// operations and console sessions, and publishes them. Closed console streams
+// and successful power operations are recorded as usage of the customers.
+func (h *BMCManagerServiceHandler) ReportEvents(
+	ctx context.Context,
+	req *connect.Request[managerv1.ReportEventsRequest],
+) (*connect.Response[managerv1.ReportEventsResponse], error) {
+	if req.Msg.GatewayId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id is required"))
+	}
+	if err := h.authorizeGatewayReport(ctx, req.Msg.GatewayId); err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("gateway_id", req.Msg.GatewayId).
+		Int("event_count", len(req.Msg.Events)).
+		Msg("Gateway reporting events")
+
+	accepted := 0
+	var usageRecords []*database.UsageRecord
+	for _, e := range req.Msg.Events {
+		eventType := events.Type(e.Type)
+		if e.Id == "" || !eventType.Valid() {
+			log.Warn().
+				Str("gateway_id", req.Msg.GatewayId).
+				Str("event_id", e.Id).
+				Str("event_type", e.Type).
+				Msg("Skipping invalid event")
+			continue
+		}
+
+		data := e.Data.AsMap()
+		data["gateway_id"] = req.Msg.GatewayId
+
+		occurredAt := time.Now().UTC()
+		if e.Time != nil {
+			occurredAt = e.Time.AsTime()
+		}
+
+		event := events.Event{
+			ID:     e.Id,
+			Type:   eventType,
+			Source: e.Source,
+			Time:   occurredAt,
+			Data:   data,
+		}
+		h.notify(event)
+		if record := h.usageRecord(ctx, req.Msg.GatewayId, event); record != nil {
+			usageRecords = append(usageRecords, record)
+		}
+		accepted++
+	}
+
+	// The events were already published, failing would publish them again
+	// when the gateway retries
+	if err := h.db.UsageRecords.Record(ctx, usageRecords); err != nil {
+		log.Error().Err(err).Str("gateway_id", req.Msg.GatewayId).Msg("Failed to record usage")
+	}
+
+	resp := &managerv1.ReportEventsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Accepted %d events from gateway %s", accepted, req.Msg.GatewayId),
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// StartGatewayMonitor starts a goroutine that checks every interval whether
+// gateways stopped re-registering. When a gateway goes offline, a
+// GatewayOffline event is published, followed by a ServerUnreachable event for
+// each server located behind it. Gateways already offline when the monitor
+// starts are not reported.
+func (h *BMCManagerServiceHandler) StartGatewayMonitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		offline := make(map[string]bool)
+		if current, err := h.offlineGateways(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to list gateways for offline monitoring")
+		} else {
+			for gatewayID := range current {
+				offline[gatewayID] = true
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := h.checkGateways(ctx, offline); err != nil {
+					log.Error().Err(err).Msg("Failed to check gateway liveness")
+				}
+			}
+		}
+	}()
+}
+
+// offlineGateways returns the gateways currently offline, by ID
+func (h *BMCManagerServiceHandler) offlineGateways(ctx context.Context) (map[string]*models.RegionalGateway, error) {
+	gateways, err := h.db.Gateways.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoffTime := time.Now().Add(-gatewayOfflineAfter)
+	offline := make(map[string]*models.RegionalGateway)
+	for _, gateway := range gateways {
+		if !gateway.LastSeen.After(cutoffTime) {
+			offline[gateway.ID] = gateway
+		}
+	}
+	return offline, nil
+}
+
+// checkGateways publishes events for the gateways that went offline since the
+// previous check. offline holds the IDs of the gateways known to be offline
+// and is updated in place.
+func (h *BMCManagerServiceHandler) checkGateways(ctx context.Context, offline map[string]bool) error {
+	current, err := h.offlineGateways(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Gateways that came back online are reported again when they next go offline
+	for gatewayID := range offline {
+		if current[gatewayID] == nil {
+			delete(offline, gatewayID)
+		}
+	}
+
+	for gatewayID, gateway := range current {
+		if offline[gatewayID] {
+			continue
+		}
+
+		locations, err := h.db.Locations.List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list server locations: %w", err)
+		}
+		offline[gatewayID] = true
+
+		log.Warn().
+			Str("gateway_id", gateway.ID).
+			Time("last_seen", gateway.LastSeen).
+			Msg("Gateway went offline")
+		h.publish(events.GatewayOffline, map[string]any{
+			"gateway_id":     gateway.ID,
+			"region":         gateway.Region,
+			"endpoint":       gateway.Endpoint,
+			"datacenter_ids": gateway.DatacenterIDs,
+			"last_seen":      gateway.LastSeen.UTC().Format(time.RFC3339),
+		})
+
+		for _, location := range locations {
+			if location.RegionalGatewayID != gateway.ID {
+				continue
+			}
+			h.publish(events.ServerUnreachable, map[string]any{
+				"server_id":     location.ServerID,
+				"customer_id":   location.CustomerID,
+				"datacenter_id": location.DatacenterID,
+				"gateway_id":    gateway.ID,
+				"reason":        "gateway_offline",
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/events"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+// recordingPublisher collects published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) drain() []events.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	published := p.events
+	p.events = nil
+	return published
+}
+
+func TestReportAvailableEndpoints_PublishesServerDiscovered(t *testing.T) {
+	handler := setupTestHandler(t)
+	publisher := &recordingPublisher{}
+	handler.SetEventPublisher(publisher)
+	bus := &recordingPublisher{}
+	handler.SetEventBus(bus)
+
+	req := &managerv1.ReportAvailableEndpointsRequest{
+		GatewayId: "gw-1",
+		BmcEndpoints: []*managerv1.BMCEndpointAvailability{{
+			BmcEndpoint:  "192.168.1.100:623",
+			AgentId:      "agent-1",
+			DatacenterId: "dc-1",
+			Features:     []string{types.FeaturePower.String()},
+			Status:       "active",
+		}},
+	}
+
+	// Reporting the same endpoint again does not rediscover the server
+	for range 2 {
+		_, err := handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(req))
+		require.NoError(t, err)
+	}
+
+	published := publisher.drain()
+	require.Len(t, published, 1)
+	assert.Equal(t, events.ServerDiscovered, published[0].Type)
+	assert.Equal(t, "manager", published[0].Source)
+	assert.Equal(t, models.GenerateServerIDFromBMCEndpoint("dc-1", "192.168.1.100:623"), published[0].Data["server_id"])
+	assert.Equal(t, "gw-1", published[0].Data["gateway_id"])
+
+	// The same event is published to the event bus
+	assert.Equal(t, published, bus.drain())
+}
+
+func TestReportEvents(t *testing.T) {
+	handler := setupTestHandler(t)
+	publisher := &recordingPublisher{}
+	handler.SetEventPublisher(publisher)
+	bus := &recordingPublisher{}
+	handler.SetEventBus(bus)
+	handler.SetGatewayAccounts(map[string]string{"gw-1": "gw-1@example.com"})
+	gatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gw-1", Email: "gw-1@example.com"})
+
+	data, err := structpb.NewStruct(map[string]any{"server_id": "srv-1", "success": true})
+	require.NoError(t, err)
+	executedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Customers cannot report events in the name of a gateway
+	customerCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, "customer-1"))
+	_, err = handler.ReportEvents(customerCtx, connect.NewRequest(&managerv1.ReportEventsRequest{
+		GatewayId: "gw-1",
+		Events:    []*managerv1.SystemEvent{{Id: "evt-0", Type: string(events.PowerOperationExecuted), Data: data}},
+	}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	assert.Empty(t, publisher.drain())
+
+	resp, err := handler.ReportEvents(gatewayCtx, connect.NewRequest(&managerv1.ReportEventsRequest{
+		GatewayId: "gw-1",
+		Events: []*managerv1.SystemEvent{
+			{Id: "evt-1", Type: string(events.PowerOperationExecuted), Source: "gateway", Time: timestamppb.New(executedAt), Data: data},
+			{Id: "evt-2", Type: "unknown.event"},
+			{Type: string(events.ConsoleSessionOpened)},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resp.Msg.Message, "Accepted 1 events")
+
+	published := publisher.drain()
+	require.Len(t, published, 1)
+	assert.Equal(t, "evt-1", published[0].ID)
+	assert.Equal(t, executedAt, published[0].Time)
+	assert.Equal(t, map[string]any{"server_id": "srv-1", "success": true, "gateway_id": "gw-1"}, published[0].Data)
+
+	// Gateways publish their own events to the event bus
+	assert.Empty(t, bus.drain())
+
+	_, err = handler.ReportEvents(context.Background(), connect.NewRequest(&managerv1.ReportEventsRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestCheckGateways(t *testing.T) {
+	handler := setupTestHandler(t)
+	publisher := &recordingPublisher{}
+	handler.SetEventPublisher(publisher)
+	ctx := context.Background()
+
+	gateway := setupTestGateway(t, handler)
+	require.NoError(t, handler.db.Locations.Upsert(ctx, &models.ServerLocation{
+		ServerID:          "srv-1",
+		CustomerID:        "customer-1",
+		DatacenterID:      "dc-test-01",
+		RegionalGatewayID: gateway.ID,
+		PrimaryProtocol:   types.BMCTypeIPMI,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}))
+
+	offline := make(map[string]bool)
+	require.NoError(t, handler.checkGateways(ctx, offline))
+	assert.Empty(t, publisher.drain())
+
+	// The gateway stops re-registering
+	gateway.LastSeen = time.Now().Add(-time.Hour)
+	require.NoError(t, handler.db.Gateways.Upsert(ctx, gateway))
+
+	require.NoError(t, handler.checkGateways(ctx, offline))
+	published := publisher.drain()
+	require.Len(t, published, 2)
+	assert.Equal(t, events.GatewayOffline, published[0].Type)
+	assert.Equal(t, gateway.ID, published[0].Data["gateway_id"])
+	assert.Equal(t, events.ServerUnreachable, published[1].Type)
+	assert.Equal(t, "srv-1", published[1].Data["server_id"])
+
+	// Offline gateways are reported once
+	require.NoError(t, handler.checkGateways(ctx, offline))
+	assert.Empty(t, publisher.drain())
+
+	// A gateway back online is forgotten, to be reported when it next goes offline
+	gateway.LastSeen = time.Now()
+	require.NoError(t, handler.db.Gateways.Upsert(ctx, gateway))
+	require.NoError(t, handler.checkGateways(ctx, offline))
+	assert.Empty(t, offline)
+}
+
+func TestEventLog(t *testing.T) {
+	eventLog := NewEventLog(3)
+	for i, serverID := range []string{"srv-1", "srv-2", "srv-1", "srv-1"} {
+		eventLog.Publish(events.Event{ID: fmt.Sprintf("evt-%d", i), Data: map[string]any{"server_id": serverID}})
+	}
+
+	// The oldest event was replaced
+	recent := eventLog.Recent("srv-1", 0)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "evt-3", recent[0].ID, "events should be listed newest first")
+	assert.Equal(t, "evt-2", recent[1].ID)
+
+	require.Len(t, eventLog.Recent("srv-1", 1), 1)
+	assert.Empty(t, eventLog.Recent("srv-3", 0))
+}
+
+func TestAdminListServerEvents(t *testing.T) {
+	handler := setupTestHandler(t)
+	eventLog := NewEventLog(100)
+	handler.SetEventLog(eventLog)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	// Without an event log, nothing is listed
+	resp, err := admin.ListServerEvents(ctx, connect.NewRequest(&managerv1.ListServerEventsRequest{ServerId: "srv-1"}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.Events)
+
+	admin.SetEventLog(eventLog)
+
+	data, err := structpb.NewStruct(map[string]any{"server_id": "srv-1", "operation": "power_on"})
+	require.NoError(t, err)
+	_, err = handler.ReportEvents(ctx, connect.NewRequest(&managerv1.ReportEventsRequest{
+		GatewayId: "gw-1",
+		Events: []*managerv1.SystemEvent{
+			{Id: "evt-1", Type: string(events.PowerOperationExecuted), Source: "gateway", Data: data},
+		},
+	}))
+	require.NoError(t, err)
+
+	resp, err = admin.ListServerEvents(ctx, connect.NewRequest(&managerv1.ListServerEventsRequest{ServerId: "srv-1"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Events, 1)
+	assert.Equal(t, "evt-1", resp.Msg.Events[0].Id)
+	assert.Equal(t, string(events.PowerOperationExecuted), resp.Msg.Events[0].Type)
+	assert.Equal(t, "gw-1", resp.Msg.Events[0].Data.AsMap()["gateway_id"])
+
+	_, err = admin.ListServerEvents(ctx, connect.NewRequest(&managerv1.ListServerEventsRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/models"
+)
+
+// SetGatewayApproval sets whether gateways registering for the first time
+// await the approval of an admin. Pending gateways are not listed to clients
+// and the BMC endpoints they report are rejected.
+func (h *BMCManagerServiceHandler) SetGatewayApproval(required bool) {
+	h.requireGatewayApproval = required
+}
+
+// SetGatewayAccounts sets the emails of the accounts the gateways
+// authenticate with, by gateway ID
+func (h *BMCManagerServiceHandler) SetGatewayAccounts(accounts map[string]string) {
+	h.gatewayAccounts = accounts
+}
+
+// authorizeGatewayReport fails unless the caller is an admin or the account
+// of the gateway, so that customers cannot report measurements or events in
+// the name of a gateway
+func (h *BMCManagerServiceHandler) authorizeGatewayReport(ctx context.Context, gatewayID string) error {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing auth claims"))
+	}
+	if claims.IsAdmin || h.isAdminEmail(claims.Email) {
+		return nil
+	}
+	if email, ok := h.gatewayAccounts[gatewayID]; ok && email == claims.Email {
+		return nil
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("only the account of gateway %s or an admin may report for it", gatewayID))
+}
+
+// registeredGatewayStatus returns the status of a registering gateway:
+// pending until approved when approval is required, active otherwise
+func (h *BMCManagerServiceHandler) registeredGatewayStatus(ctx context.Context, gatewayID string) (string, error) {
+	if !h.requireGatewayApproval {
+		return models.GatewayStatusActive, nil
+	}
+
+	gateway, err := h.db.Gateways.Get(ctx, gatewayID)
+	if err != nil {
+		if err.Error() == "gateway not found" {
+			return models.GatewayStatusPending, nil
+		}
+		return "", err
+	}
+	if gateway.Status == models.GatewayStatusPending {
+		return models.GatewayStatusPending, nil
+	}
+	return models.GatewayStatusActive, nil
+}
+
+// checkGatewayApproved fails for gateways awaiting approval, when approval
+// is required
+func (h *BMCManagerServiceHandler) checkGatewayApproved(ctx context.Context, gatewayID string) error {
+	if !h.requireGatewayApproval {
+		return nil
+	}
+
+	gateway, err := h.db.Gateways.Get(ctx, gatewayID)
+	if err != nil {
+		if err.Error() == "gateway not found" {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("gateway %s is not registered", gatewayID))
+		}
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get gateway: %w", err))
+	}
+	if gateway.Status == models.GatewayStatusPending {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("gateway %s is awaiting approval", gatewayID))
+	}
+	return nil
+}
+
+// ApproveGateway approves a gateway awaiting approval. Approving an active
+// gateway has no effect.
+func (h *AdminServiceHandler) ApproveGateway(
+	ctx context.Context,
+	req *connect.Request[managerv1.ApproveGatewayRequest],
+) (*connect.Response[managerv1.ApproveGatewayResponse], error) {
+	log.Info().Str("gateway_id", req.Msg.GatewayId).Msg("ApproveGateway called")
+
+	if req.Msg.GatewayId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id is required"))
+	}
+
+	gateway, err := h.db.Gateways.Get(ctx, req.Msg.GatewayId)
+	if err != nil {
+		if err.Error() == "gateway not found" {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("gateway not found: %s", req.Msg.GatewayId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get gateway: %w", err))
+	}
+
+	if gateway.Status == models.GatewayStatusPending {
+		gateway.Status = models.GatewayStatusActive
+		if err := h.db.Gateways.Update(ctx, gateway); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update gateway: %w", err))
+		}
+		h.audit(ctx, auditGatewayApprove, "gateway", gateway.ID, map[string]string{
+			"region":   gateway.Region,
+			"endpoint": gateway.Endpoint,
+		})
+		log.Info().Str("gateway_id", gateway.ID).Msg("Approved gateway")
+	}
+
+	gateways, err := h.db.Admin.GetGatewayHealth(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get gateway health: %w", err))
+	}
+	for _, health := range gateways {
+		if health.GatewayId == gateway.ID {
+			return connect.NewResponse(&managerv1.ApproveGatewayResponse{Gateway: health}), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("gateway not found: %s", gateway.ID))
+}
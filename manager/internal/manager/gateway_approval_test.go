@@ -0,0 +1,109 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestGatewayApproval(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetGatewayApproval(true)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupCustomerContext("test-customer")
+
+	register := func() {
+		t.Helper()
+		_, err := handler.RegisterGateway(ctx, connect.NewRequest(&managerv1.RegisterGatewayRequest{
+			GatewayId:     "gateway-1",
+			Region:        "us-east-1",
+			Endpoint:      "http://gateway-1:8081",
+			DatacenterIds: []string{"dc-1"},
+		}))
+		require.NoError(t, err)
+	}
+	report := func() error {
+		_, err := handler.ReportAvailableEndpoints(ctx, connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+			GatewayId: "gateway-1",
+			Region:    "us-east-1",
+		}))
+		return err
+	}
+	listed := func() int {
+		t.Helper()
+		resp, err := handler.ListGateways(ctx, connect.NewRequest(&managerv1.ListGatewaysRequest{}))
+		require.NoError(t, err)
+		return len(resp.Msg.Gateways)
+	}
+
+	// New gateways await approval, even when registering again
+	register()
+	register()
+	gateway, err := handler.db.Gateways.Get(ctx, "gateway-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.GatewayStatusPending, gateway.Status)
+	assert.Equal(t, 0, listed())
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(report()))
+
+	health, err := admin.GetGatewayHealth(ctx, connect.NewRequest(&managerv1.GetGatewayHealthRequest{}))
+	require.NoError(t, err)
+	require.Len(t, health.Msg.Gateways, 1)
+	assert.Equal(t, models.GatewayStatusPending, health.Msg.Gateways[0].Status)
+
+	approved, err := admin.ApproveGateway(ctx, connect.NewRequest(&managerv1.ApproveGatewayRequest{GatewayId: "gateway-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "active", approved.Msg.Gateway.Status)
+
+	// Approved gateways stay approved when registering again
+	register()
+	assert.Equal(t, 1, listed())
+	assert.NoError(t, report())
+
+	_, err = admin.ApproveGateway(ctx, connect.NewRequest(&managerv1.ApproveGatewayRequest{GatewayId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestGatewayApproval_NotRequired(t *testing.T) {
+	handler := setupTestHandler(t)
+	ctx := context.Background()
+
+	_, err := handler.RegisterGateway(ctx, connect.NewRequest(&managerv1.RegisterGatewayRequest{GatewayId: "gateway-1", Region: "us-east-1"}))
+	require.NoError(t, err)
+
+	gateway, err := handler.db.Gateways.Get(ctx, "gateway-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.GatewayStatusActive, gateway.Status)
+}
+
+func TestRegisterGateway_TokenRevocations(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetGatewayAccounts(map[string]string{"gateway-1": "gateway-1@example.com"})
+	ctx := context.Background()
+	require.NoError(t, handler.db.TokenRevocations.Revoke(ctx, "customer-1", "disabled"))
+
+	register := func(ctx context.Context) *managerv1.RegisterGatewayResponse {
+		t.Helper()
+		resp, err := handler.RegisterGateway(ctx, connect.NewRequest(&managerv1.RegisterGatewayRequest{GatewayId: "gateway-1", Region: "us-east-1"}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	// The gateway learns of the revocations to reject the tokens it validates
+	gatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gateway-1", Email: "gateway-1@example.com"})
+	revocations := register(gatewayCtx).TokenRevocations
+	require.Len(t, revocations, 1)
+	assert.Equal(t, "customer-1", revocations[0].CustomerId)
+	assert.WithinDuration(t, time.Now(), revocations[0].RevokedAt.AsTime(), 2*time.Second)
+
+	// Other accounts do not
+	customerCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, "customer-2"))
+	assert.Empty(t, register(customerCtx).TokenRevocations)
+}
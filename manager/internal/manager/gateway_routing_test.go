@@ -322,6 +322,48 @@ func TestGetServerLocation_IncludesFeatures(t *testing.T) {
 	assert.GreaterOrEqual(t, len(locationResp.Msg.Features), 1, "Features should have at least one entry")
 }
 
+// TestGetServerLocation_IPv6Endpoint tests that servers reported with an
+// IPv6 BMC endpoint get an ID without brackets and keep the bracketed endpoint
+func TestGetServerLocation_IPv6Endpoint(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupTestCustomer(t, "test-customer")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+	gateway := setupTestGateway(t, handler)
+
+	endpoint := &managerv1.BMCEndpointAvailability{
+		BmcEndpoint:  "[fd00::10]:623",
+		AgentId:      "test-agent",
+		DatacenterId: "dc-test-01",
+		BmcType:      commonv1.BMCType_BMC_IPMI,
+		Features: types.FeaturesToStrings([]types.Feature{
+			types.FeaturePower,
+		}),
+		Status:   "active",
+		Username: "admin",
+	}
+
+	_, err := handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+		GatewayId:    gateway.ID,
+		Region:       gateway.Region,
+		BmcEndpoints: []*managerv1.BMCEndpointAvailability{endpoint},
+	}))
+	require.NoError(t, err)
+
+	serverID := models.GenerateServerIDFromBMCEndpoint(endpoint.DatacenterId, endpoint.BmcEndpoint)
+	assert.Equal(t, "bmc-dc-test-01-fd00--10-623", serverID)
+
+	locationResp, err := handler.GetServerLocation(ctx, connect.NewRequest(&managerv1.GetServerLocationRequest{
+		ServerId: serverID,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, gateway.ID, locationResp.Msg.RegionalGatewayId)
+
+	server, err := handler.db.Servers.Get(context.Background(), serverID)
+	require.NoError(t, err)
+	require.Len(t, server.ControlEndpoints, 1)
+	assert.Equal(t, "[fd00::10]:623", server.ControlEndpoints[0].Endpoint)
+}
+
 // TestGetServerLocation_DifferentBMCTypes tests that GetServerLocation
 // correctly returns the BMC type for different server types
 func TestGetServerLocation_DifferentBMCTypes(t *testing.T) {
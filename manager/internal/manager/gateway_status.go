@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/models"
+)
+
+// gatewayStatusPollerID identifies the manager in the admin tokens of its
+// gateway status polls
+const gatewayStatusPollerID = "manager-status-poller"
+
+// gatewayStatusSample is the last status poll of a gateway
+type gatewayStatusSample struct {
+	polledAt time.Time
+	status   *gatewayv1.GetGatewayStatusResponse // nil when the poll failed
+	err      error
+}
+
+// gatewayStatusCache holds the last status poll of each gateway, by ID
+type gatewayStatusCache struct {
+	mu      sync.RWMutex
+	samples map[string]gatewayStatusSample
+}
+
+func newGatewayStatusCache() *gatewayStatusCache {
+	return &gatewayStatusCache{samples: make(map[string]gatewayStatusSample)}
+}
+
+// StartGatewayStatusPolling polls the status of every gateway each interval,
+// for GetSystemStatus to report the live health of agents, console sessions
+// and BMC endpoints
+func (h *BMCManagerServiceHandler) StartGatewayStatusPolling(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := h.pollGatewayStatuses(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to poll gateway statuses")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// pollGatewayStatuses queries GetGatewayStatus on every approved gateway and
+// records the results
+func (h *BMCManagerServiceHandler) pollGatewayStatuses(ctx context.Context) error {
+	gateways, err := h.db.Gateways.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, err := h.jwtManager.GenerateToken(&models.Customer{
+		ID:      gatewayStatusPollerID,
+		Email:   gatewayStatusPollerID,
+		IsAdmin: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	samples := make(map[string]gatewayStatusSample, len(gateways))
+	for _, gateway := range gateways {
+		// Pending gateways are not trusted until approved
+		if gateway.Status == models.GatewayStatusPending {
+			continue
+		}
+
+		wg.Add(1)
+		go func(gateway *models.RegionalGateway) {
+			defer wg.Done()
+
+			queryCtx, cancel := context.WithTimeout(ctx, gatewayQueryTimeout)
+			defer cancel()
+
+			sample := gatewayStatusSample{}
+			resp, err := newGatewayClient(gateway.Endpoint, token).GetGatewayStatus(queryCtx,
+				connect.NewRequest(&gatewayv1.GetGatewayStatusRequest{}))
+			sample.polledAt = time.Now()
+			if err != nil {
+				log.Warn().Err(err).Str("gateway_id", gateway.ID).Msg("Failed to poll gateway status")
+				sample.err = err
+			} else {
+				sample.status = resp.Msg
+			}
+
+			mu.Lock()
+			samples[gateway.ID] = sample
+			mu.Unlock()
+		}(gateway)
+	}
+	wg.Wait()
+
+	// Gateways no longer registered are forgotten
+	h.gatewayStatuses.mu.Lock()
+	h.gatewayStatuses.samples = samples
+	h.gatewayStatuses.mu.Unlock()
+	return nil
+}
+
+// sample returns the last status poll of a gateway
+func (c *gatewayStatusCache) sample(gatewayID string) (gatewayStatusSample, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	sample, ok := c.samples[gatewayID]
+	return sample, ok
+}
+
+// liveGatewayStatus converts the last status poll of a gateway, nil until
+// polled
+func (c *gatewayStatusCache) liveGatewayStatus(gatewayID string) *managerv1.GatewayLiveStatus {
+	sample, ok := c.sample(gatewayID)
+	if !ok {
+		return nil
+	}
+
+	live := &managerv1.GatewayLiveStatus{PolledAt: timestamppb.New(sample.polledAt)}
+	if sample.status == nil {
+		live.Error = sample.err.Error()
+		return live
+	}
+
+	status := sample.status
+	live.Reachable = true
+	live.ActiveConsoleSessions = status.Sessions.GetTotal()
+	live.AttachedStreams = status.Sessions.GetAttachedStreams()
+	live.EndpointCount = int32(len(status.EndpointMappings))
+	for _, agent := range status.Agents {
+		health := &managerv1.GatewayAgentHealth{
+			AgentId:            agent.AgentId,
+			DatacenterId:       agent.DatacenterId,
+			Status:             agent.Status,
+			LastSeen:           agent.LastSeen,
+			ActiveSessionCount: agent.ActiveSessionCount,
+			BmcEndpointCount:   int32(len(agent.BmcEndpoints)),
+		}
+		if link := agent.Link; link != nil {
+			health.LinkRttMs = link.RttMs
+			health.LinkError = link.Error
+		}
+		live.Agents = append(live.Agents, health)
+	}
+	sort.Slice(live.Agents, func(i, j int) bool {
+		return live.Agents[i].AgentId < live.Agents[j].AgentId
+	})
+	return live
+}
+
+// reachableEndpoints returns the BMC endpoints that the last status poll of a
+// gateway maps to an active agent
+func (c *gatewayStatusCache) reachableEndpoints(gatewayID string) map[string]bool {
+	sample, ok := c.sample(gatewayID)
+	if !ok || sample.status == nil {
+		return nil
+	}
+
+	activeAgents := make(map[string]bool, len(sample.status.Agents))
+	for _, agent := range sample.status.Agents {
+		if agent.Status == "active" {
+			activeAgents[agent.AgentId] = true
+		}
+	}
+
+	reachable := make(map[string]bool, len(sample.status.EndpointMappings))
+	for _, mapping := range sample.status.EndpointMappings {
+		if activeAgents[mapping.AgentId] {
+			reachable[mapping.BmcEndpoint] = true
+		}
+	}
+	return reachable
+}
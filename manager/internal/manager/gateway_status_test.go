@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/models"
+)
+
+// fakeStatusGateway serves a fixed gateway status
+type fakeStatusGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	status     *gatewayv1.GetGatewayStatusResponse
+	authHeader string
+}
+
+func (g *fakeStatusGateway) GetGatewayStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.GetGatewayStatusRequest],
+) (*connect.Response[gatewayv1.GetGatewayStatusResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	return connect.NewResponse(g.status), nil
+}
+
+func TestGetSystemStatus_LiveGatewayStatus(t *testing.T) {
+	handler := setupTestHandler(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	fake := &fakeStatusGateway{status: &gatewayv1.GetGatewayStatusResponse{
+		Agents: []*gatewayv1.AgentStatus{
+			{AgentId: "agent-2", Status: "stale"},
+			{AgentId: "agent-1", DatacenterId: "dc-1", Status: "active", ActiveSessionCount: 2,
+				Link: &gatewayv1.AgentLinkStatus{RttMs: 3.5}},
+		},
+		Sessions: &gatewayv1.ConsoleSessionCounts{Total: 2, Sol: 2, AttachedStreams: 3},
+		EndpointMappings: []*gatewayv1.BMCEndpointMapping{
+			{BmcEndpoint: "10.0.0.1:623", ServerId: "server-1", AgentId: "agent-1"},
+			{BmcEndpoint: "10.0.0.2:623", ServerId: "server-2", AgentId: "agent-2"},
+		},
+	}}
+	_, handlerFunc := gatewayv1connect.NewGatewayServiceHandler(fake)
+	server := httptest.NewServer(handlerFunc)
+	t.Cleanup(server.Close)
+
+	for _, gateway := range []*models.RegionalGateway{
+		{ID: "gw-1", Region: "us-east-1", Endpoint: server.URL, Status: "active", LastSeen: now, CreatedAt: now},
+		// Nothing listens on the discard port
+		{ID: "gw-down", Region: "eu-west-1", Endpoint: "http://127.0.0.1:9", Status: "active", LastSeen: now, CreatedAt: now},
+		{ID: "gw-pending", Region: "eu-west-1", Endpoint: "http://127.0.0.1:9", Status: models.GatewayStatusPending, LastSeen: now, CreatedAt: now},
+	} {
+		require.NoError(t, handler.db.Gateways.Create(ctx, gateway))
+	}
+	for i, endpoint := range []string{"10.0.0.1:623", "10.0.0.2:623"} {
+		serverID := []string{"server-1", "server-2"}[i]
+		require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+			ID:               serverID,
+			CustomerID:       "customer-1",
+			ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: endpoint, Type: types.BMCTypeIPMI}},
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}))
+		require.NoError(t, handler.db.Locations.Create(ctx, &models.ServerLocation{
+			ServerID:          serverID,
+			CustomerID:        "customer-1",
+			RegionalGatewayID: "gw-1",
+			PrimaryProtocol:   types.BMCTypeIPMI,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}))
+	}
+
+	getStatus := func() *managerv1.SystemStatus {
+		resp, err := handler.GetSystemStatus(ctx, connect.NewRequest(&managerv1.GetSystemStatusRequest{}))
+		require.NoError(t, err)
+		return resp.Msg.Status
+	}
+
+	// Gateways are unset until polled
+	for _, gateway := range getStatus().Gateways {
+		assert.Nil(t, gateway.Live)
+	}
+
+	require.NoError(t, handler.pollGatewayStatuses(ctx))
+	assert.NotEmpty(t, fake.authHeader, "polls should be authenticated")
+
+	status := getStatus()
+	assert.Equal(t, int32(1), status.ReachableGateways)
+	assert.Equal(t, int32(2), status.ActiveConsoleSessions)
+
+	gateways := make(map[string]*managerv1.GatewayStatus)
+	for _, gateway := range status.Gateways {
+		gateways[gateway.Id] = gateway
+	}
+	live := gateways["gw-1"].Live
+	require.NotNil(t, live)
+	assert.True(t, live.Reachable)
+	assert.Equal(t, int32(3), live.AttachedStreams)
+	assert.Equal(t, int32(2), live.EndpointCount)
+	require.Len(t, live.Agents, 2)
+	assert.Equal(t, "agent-1", live.Agents[0].AgentId, "agents should be listed by ID")
+	assert.Equal(t, 3.5, live.Agents[0].LinkRttMs)
+
+	require.NotNil(t, gateways["gw-down"].Live)
+	assert.False(t, gateways["gw-down"].Live.Reachable)
+	assert.NotEmpty(t, gateways["gw-down"].Live.Error)
+	assert.Nil(t, gateways["gw-pending"].Live, "pending gateways are not polled")
+
+	// Endpoints are reachable through active agents only
+	reachable := make(map[string]bool)
+	for _, server := range status.Servers {
+		reachable[server.ServerId] = server.EndpointReachable
+	}
+	assert.Equal(t, map[string]bool{"server-1": true, "server-2": false}, reachable)
+}
@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	coreauth "core/auth"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// maxIPAllowlistRanges bounds the source ranges of a customer, which every
+// server token carries
+const maxIPAllowlistRanges = 64
+
+// SetCustomerIPAllowlist sets the source IP ranges a customer accesses BMCs
+// from. Ranges are normalized, and an empty list allows any address.
+func (h *AdminServiceHandler) SetCustomerIPAllowlist(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetCustomerIPAllowlistRequest],
+) (*connect.Response[managerv1.SetCustomerIPAllowlistResponse], error) {
+	log.Info().
+		Str("customer_id", req.Msg.CustomerId).
+		Strs("cidrs", req.Msg.Cidrs).
+		Msg("SetCustomerIPAllowlist called")
+
+	ranges, err := normalizeSourceRanges(req.Msg.Cidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	customer.AllowedSourceRanges = ranges
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+	h.audit(ctx, auditCustomerIPAllowlist, "customer", customer.ID, map[string]string{
+		"cidrs": strings.Join(ranges, ","),
+	})
+
+	return connect.NewResponse(&managerv1.SetCustomerIPAllowlistResponse{
+		CustomerId: customer.ID,
+		Cidrs:      ranges,
+	}), nil
+}
+
+// normalizeSourceRanges validates the entries of an IP allowlist, returning
+// them as CIDR ranges without duplicates
+func normalizeSourceRanges(sources []string) ([]string, error) {
+	if len(sources) > maxIPAllowlistRanges {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("at most %d source ranges are allowed, got %d", maxIPAllowlistRanges, len(sources)))
+	}
+
+	var ranges []string
+	seen := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		prefix, err := coreauth.ParseSourceRange(source)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if normalized := prefix.String(); !seen[normalized] {
+			seen[normalized] = true
+			ranges = append(ranges, normalized)
+		}
+	}
+	return ranges, nil
+}
+
+// serverTokenCustomer returns the customer of the server tokens of the
+// authenticated customer, with the policies gateways enforce from its record
+func serverTokenCustomer(ctx context.Context, db *database.BunDB, claims *models.AuthClaims) (*models.Customer, error) {
+	customer := &models.Customer{
+		ID:    claims.CustomerID,
+		Email: claims.Email,
+	}
+
+	record, err := db.Customers.Get(ctx, claims.CustomerID)
+	if err != nil {
+		// Customers authenticated by other means have no policy
+		if err.Error() == "customer not found" {
+			return customer, nil
+		}
+		return nil, err
+	}
+	customer.RequireMFA = record.RequireMFA
+	customer.AllowedSourceRanges = record.AllowedSourceRanges
+	return customer, nil
+}
+
+// CheckSourceAllowed enforces the IP allowlist of the authenticated customer
+// against the address of a client. Gateways see the manager's address on the
+// requests the REST API forwards to them, so the API checks its callers
+// itself.
+func (h *BMCManagerServiceHandler) CheckSourceAllowed(ctx context.Context, clientAddress string) error {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
+	customer, err := serverTokenCustomer(ctx, h.db, claims)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	if coreauth.SourceAllowed(customer.AllowedSourceRanges, clientAddress) {
+		return nil
+	}
+
+	log.Warn().
+		Str("customer_id", customer.ID).
+		Str("remote_addr", clientAddress).
+		Strs("allowed_source_ranges", customer.AllowedSourceRanges).
+		Msg("Rejected BMC access from a source address outside the customer's allowlist")
+	return connect.NewError(connect.CodePermissionDenied,
+		fmt.Errorf("source address %s is not in the IP allowlist of customer %s", clientAddress, customer.ID))
+}
@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestSetCustomerIPAllowlist(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	customer := setupTestCustomer(t, "customer-1")
+	require.NoError(t, handler.db.Customers.Create(context.Background(), customer))
+
+	set := func(customerID string, cidrs ...string) (*connect.Response[managerv1.SetCustomerIPAllowlistResponse], error) {
+		return admin.SetCustomerIPAllowlist(adminCtx, connect.NewRequest(&managerv1.SetCustomerIPAllowlistRequest{
+			CustomerId: customerID,
+			Cidrs:      cidrs,
+		}))
+	}
+
+	resp, err := set("customer-1", "10.8.1.0/16", "203.0.113.7", "10.8.0.0/16")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.8.0.0/16", "203.0.113.7/32"}, resp.Msg.Cidrs, "ranges are normalized")
+
+	_, err = set("customer-1", "vpn.example.com")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = set("unknown", "10.8.0.0/16")
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// Server tokens carry the allowlist
+	claims := &models.AuthClaims{CustomerID: "customer-1", Email: customer.Email}
+	tokenCustomer, err := serverTokenCustomer(context.Background(), handler.db, claims)
+	require.NoError(t, err)
+	token, err := handler.jwtManager.GenerateServerToken(tokenCustomer, &domain.Server{
+		ID:         "server-1",
+		CustomerID: "customer-1",
+		ControlEndpoints: []*types.BMCControlEndpoint{
+			{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI},
+		},
+	}, []string{"console:access"})
+	require.NoError(t, err)
+	_, serverContext, err := handler.jwtManager.ValidateServerToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.8.0.0/16", "203.0.113.7/32"}, serverContext.AllowedSourceRanges)
+
+	events, err := handler.db.AuditEvents.List(adminCtx, database.AuditEventFilter{Action: auditCustomerIPAllowlist})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "10.8.0.0/16,203.0.113.7/32", events[0].Details["cidrs"])
+
+	// Empty allowlists allow any address
+	resp, err = set("customer-1")
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.Cidrs)
+	stored, err := handler.db.Customers.Get(context.Background(), "customer-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.AllowedSourceRanges)
+}
+
+func TestCheckSourceAllowed(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupTestCustomer(t, "customer-1")
+	customer.AllowedSourceRanges = []string{"10.8.0.0/16"}
+	require.NoError(t, handler.db.Customers.Create(context.Background(), customer))
+	ctx := withClaims(context.Background(), &models.AuthClaims{CustomerID: "customer-1", Email: customer.Email})
+
+	assert.NoError(t, handler.CheckSourceAllowed(ctx, "10.8.3.4:51234"))
+	err := handler.CheckSourceAllowed(ctx, "192.0.2.1:51234")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	err = handler.CheckSourceAllowed(context.Background(), "10.8.3.4:51234")
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err), "claims are required")
+}
@@ -0,0 +1,42 @@
+package manager
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/logging"
+	managerv1 "manager/gen/manager/v1"
+)
+
+// SetLogLevel changes the log levels of the manager at runtime. Changes are
+// recorded in the audit log; an empty request returns the current levels.
+func (h *AdminServiceHandler) SetLogLevel(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetLogLevelRequest],
+) (*connect.Response[managerv1.SetLogLevelResponse], error) {
+	change := logging.LevelChange{
+		Level:        req.Msg.Level,
+		Modules:      req.Msg.ModuleLevels,
+		ResetModules: req.Msg.ResetModules,
+	}
+	if err := logging.ApplyLevels(change); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	levels := logging.CurrentLevels()
+	if change.Level != "" || len(change.Modules) > 0 || change.ResetModules {
+		details := map[string]string{"level": levels.Level}
+		for module, level := range levels.Modules {
+			details["module."+module] = level
+		}
+		h.audit(ctx, auditLogLevel, "service", "manager", details)
+		log.Warn().Str("level", levels.Level).Interface("modules", levels.Modules).Msg("Log levels changed")
+	}
+
+	return connect.NewResponse(&managerv1.SetLogLevelResponse{
+		Level:        levels.Level,
+		ModuleLevels: levels.Modules,
+	}), nil
+}
@@ -0,0 +1,53 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/logging"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := context.Background()
+
+	global := zerolog.GlobalLevel()
+	t.Cleanup(func() {
+		require.NoError(t, logging.ApplyLevels(logging.LevelChange{Level: "info", ResetModules: true}))
+		zerolog.SetGlobalLevel(global)
+	})
+
+	resp, err := admin.SetLogLevel(ctx, connect.NewRequest(&managerv1.SetLogLevelRequest{
+		Level:        "warn",
+		ModuleLevels: map[string]string{"webhook": "debug"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "warn", resp.Msg.Level)
+	assert.Equal(t, map[string]string{"webhook": "debug"}, resp.Msg.ModuleLevels)
+
+	events, err := handler.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditLogLevel})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "manager", events[0].TargetID)
+	assert.Equal(t, "debug", events[0].Details["module.webhook"])
+
+	// An empty request returns the levels, without recording a change
+	resp, err = admin.SetLogLevel(ctx, connect.NewRequest(&managerv1.SetLogLevelRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, "warn", resp.Msg.Level)
+	events, err = handler.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditLogLevel})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	_, err = admin.SetLogLevel(ctx, connect.NewRequest(&managerv1.SetLogLevelRequest{Level: "loud"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
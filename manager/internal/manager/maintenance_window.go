@@ -0,0 +1,185 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/pkg/models"
+)
+
+// maxMaintenanceReasonLength bounds the reason of a maintenance window, in
+// characters
+const maxMaintenanceReasonLength = 1024
+
+// CreateMaintenanceWindow schedules a maintenance window of a server or of a
+// group of servers. Server tokens issued before the window ends carry it, and
+// gateways apply its policy to destructive power operations while it is in
+// progress.
+func (h *AdminServiceHandler) CreateMaintenanceWindow(
+	ctx context.Context,
+	req *connect.Request[managerv1.CreateMaintenanceWindowRequest],
+) (*connect.Response[managerv1.CreateMaintenanceWindowResponse], error) {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
+	window := &domain.MaintenanceWindow{
+		ServerID:  req.Msg.ServerId,
+		Labels:    req.Msg.Labels,
+		Policy:    domain.MaintenancePolicy(req.Msg.Policy),
+		Reason:    req.Msg.Reason,
+		StartsAt:  req.Msg.StartsAt.AsTime(),
+		EndsAt:    req.Msg.EndsAt.AsTime(),
+		CreatedBy: claims.Email,
+	}
+
+	switch {
+	case (window.ServerID == "") == (len(window.Labels) == 0):
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("exactly one of server_id and labels is required"))
+	case !window.Policy.Valid():
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown policy %q, expected %q or %q",
+			req.Msg.Policy, domain.MaintenancePolicyReject, domain.MaintenancePolicyRequireOverride))
+	case req.Msg.StartsAt == nil || req.Msg.EndsAt == nil:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("starts_at and ends_at are required"))
+	case !window.EndsAt.After(window.StartsAt):
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ends_at must be after starts_at"))
+	case !window.EndsAt.After(time.Now()):
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ends_at must be in the future"))
+	case utf8.RuneCountInString(window.Reason) > maxMaintenanceReasonLength:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("reason exceeds %d characters", maxMaintenanceReasonLength))
+	}
+
+	if window.ServerID != "" {
+		if _, err := h.db.Servers.Get(ctx, window.ServerID); err != nil {
+			if err.Error() == "server not found" {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", window.ServerID))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server: %w", err))
+		}
+	}
+
+	if err := h.db.MaintenanceWindows.Create(ctx, window); err != nil {
+		log.Error().Err(err).Msg("Failed to create maintenance window")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create maintenance window: %w", err))
+	}
+
+	log.Info().
+		Int64("window_id", window.ID).
+		Str("server_id", window.ServerID).
+		Interface("labels", window.Labels).
+		Str("policy", string(window.Policy)).
+		Time("starts_at", window.StartsAt).
+		Time("ends_at", window.EndsAt).
+		Str("created_by", window.CreatedBy).
+		Msg("Scheduled maintenance window")
+	h.audit(ctx, auditMaintenanceWindowCreate, "maintenance_window", strconv.FormatInt(window.ID, 10), map[string]string{
+		"server_id": window.ServerID,
+		"policy":    string(window.Policy),
+		"starts_at": window.StartsAt.UTC().Format(time.RFC3339),
+		"ends_at":   window.EndsAt.UTC().Format(time.RFC3339),
+	})
+
+	return connect.NewResponse(&managerv1.CreateMaintenanceWindowResponse{Window: maintenanceWindowToProto(window)}), nil
+}
+
+// ListMaintenanceWindows lists the maintenance windows, optionally of a
+// single server
+func (h *AdminServiceHandler) ListMaintenanceWindows(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListMaintenanceWindowsRequest],
+) (*connect.Response[managerv1.ListMaintenanceWindowsResponse], error) {
+	endsAfter := time.Now()
+	if req.Msg.IncludeEnded {
+		endsAfter = time.Time{}
+	}
+
+	windows, err := h.db.MaintenanceWindows.List(ctx, endsAfter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list maintenance windows: %w", err))
+	}
+
+	if req.Msg.ServerId != "" {
+		server, err := h.db.Servers.Get(ctx, req.Msg.ServerId)
+		if err != nil {
+			if err.Error() == "server not found" {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server: %w", err))
+		}
+		windows = serverMaintenanceWindows(windows, server)
+	}
+
+	response := &managerv1.ListMaintenanceWindowsResponse{}
+	for _, window := range windows {
+		response.Windows = append(response.Windows, maintenanceWindowToProto(window))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// DeleteMaintenanceWindow cancels a maintenance window, or ends it when it is
+// in progress. Server tokens issued before still carry it until they expire.
+func (h *AdminServiceHandler) DeleteMaintenanceWindow(
+	ctx context.Context,
+	req *connect.Request[managerv1.DeleteMaintenanceWindowRequest],
+) (*connect.Response[managerv1.DeleteMaintenanceWindowResponse], error) {
+	deleted, err := h.db.MaintenanceWindows.Delete(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete maintenance window: %w", err))
+	}
+	if !deleted {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("maintenance window not found: %d", req.Msg.Id))
+	}
+
+	log.Info().Int64("window_id", req.Msg.Id).Msg("Deleted maintenance window")
+	h.audit(ctx, auditMaintenanceWindowDelete, "maintenance_window", strconv.FormatInt(req.Msg.Id, 10), nil)
+	return connect.NewResponse(&managerv1.DeleteMaintenanceWindowResponse{}), nil
+}
+
+// loadMaintenanceWindows sets the maintenance windows of a server that have
+// not ended, for its server tokens to carry them
+func loadMaintenanceWindows(ctx context.Context, db *database.BunDB, server *domain.Server) error {
+	windows, err := db.MaintenanceWindows.List(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	server.MaintenanceWindows = serverMaintenanceWindows(windows, server)
+	return nil
+}
+
+// serverMaintenanceWindows returns the windows applying to a server
+func serverMaintenanceWindows(windows []*domain.MaintenanceWindow, server *domain.Server) []*domain.MaintenanceWindow {
+	var matching []*domain.MaintenanceWindow
+	for _, window := range windows {
+		if window.Matches(server) {
+			matching = append(matching, window)
+		}
+	}
+	return matching
+}
+
+// maintenanceWindowToProto converts a maintenance window to its protobuf
+// message
+func maintenanceWindowToProto(window *domain.MaintenanceWindow) *managerv1.MaintenanceWindow {
+	return &managerv1.MaintenanceWindow{
+		Id:        window.ID,
+		ServerId:  window.ServerID,
+		Labels:    window.Labels,
+		Policy:    string(window.Policy),
+		Reason:    window.Reason,
+		StartsAt:  timestamppb.New(window.StartsAt),
+		EndsAt:    timestamppb.New(window.EndsAt),
+		CreatedBy: window.CreatedBy,
+		CreatedAt: timestamppb.New(window.CreatedAt),
+	}
+}
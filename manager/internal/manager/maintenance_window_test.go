@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, ""))
+
+	for _, server := range []*domain.Server{
+		{ID: "server-1", Metadata: map[string]string{"rack": "r1"}},
+		{ID: "server-2", Metadata: map[string]string{"rack": "r2"}},
+	} {
+		server.CustomerID = "test-customer"
+		server.DatacenterID = "dc-test-01"
+		server.ControlEndpoints = []*types.BMCControlEndpoint{{Endpoint: server.ID + ":623", Type: types.BMCTypeIPMI}}
+		server.PrimaryProtocol = types.BMCTypeIPMI
+		server.Status = "active"
+		server.CreatedAt = time.Now()
+		server.UpdatedAt = time.Now()
+		require.NoError(t, handler.db.Servers.Create(ctx, server))
+	}
+
+	now := time.Now()
+	serverWindow, err := admin.CreateMaintenanceWindow(ctx, connect.NewRequest(&managerv1.CreateMaintenanceWindowRequest{
+		ServerId: "server-1",
+		Policy:   string(domain.MaintenancePolicyRequireOverride),
+		Reason:   "BIOS update",
+		StartsAt: timestamppb.New(now.Add(-time.Minute)),
+		EndsAt:   timestamppb.New(now.Add(2 * time.Hour)),
+	}))
+	require.NoError(t, err)
+	assert.NotZero(t, serverWindow.Msg.Window.Id)
+	assert.Equal(t, "test-customer@example.com", serverWindow.Msg.Window.CreatedBy)
+
+	rackWindow, err := admin.CreateMaintenanceWindow(ctx, connect.NewRequest(&managerv1.CreateMaintenanceWindowRequest{
+		Labels:   map[string]string{"rack": "r2"},
+		Policy:   string(domain.MaintenancePolicyReject),
+		StartsAt: timestamppb.New(now.Add(24 * time.Hour)),
+		EndsAt:   timestamppb.New(now.Add(26 * time.Hour)),
+	}))
+	require.NoError(t, err)
+
+	listResp, err := admin.ListMaintenanceWindows(ctx, connect.NewRequest(&managerv1.ListMaintenanceWindowsRequest{}))
+	require.NoError(t, err)
+	assert.Len(t, listResp.Msg.Windows, 2)
+
+	listResp, err = admin.ListMaintenanceWindows(ctx, connect.NewRequest(&managerv1.ListMaintenanceWindowsRequest{ServerId: "server-2"}))
+	require.NoError(t, err)
+	require.Len(t, listResp.Msg.Windows, 1)
+	assert.Equal(t, rackWindow.Msg.Window.Id, listResp.Msg.Windows[0].Id)
+
+	// Server tokens carry the windows in progress during their lifetime
+	tokenResp, err := handler.GetServerToken(ctx, connect.NewRequest(&managerv1.GetServerTokenRequest{ServerId: "server-1"}))
+	require.NoError(t, err)
+	_, serverContext, err := handler.jwtManager.ValidateServerToken(tokenResp.Msg.Token)
+	require.NoError(t, err)
+	require.Len(t, serverContext.MaintenanceWindows, 1)
+	assert.Equal(t, serverWindow.Msg.Window.Id, serverContext.MaintenanceWindows[0].ID)
+	assert.Equal(t, string(domain.MaintenancePolicyRequireOverride), serverContext.MaintenanceWindows[0].Policy)
+	assert.Equal(t, "BIOS update", serverContext.MaintenanceWindows[0].Reason)
+
+	// The rack window starts after the token expires
+	tokenResp, err = handler.GetServerToken(ctx, connect.NewRequest(&managerv1.GetServerTokenRequest{ServerId: "server-2"}))
+	require.NoError(t, err)
+	_, serverContext, err = handler.jwtManager.ValidateServerToken(tokenResp.Msg.Token)
+	require.NoError(t, err)
+	assert.Empty(t, serverContext.MaintenanceWindows)
+
+	_, err = admin.DeleteMaintenanceWindow(ctx, connect.NewRequest(&managerv1.DeleteMaintenanceWindowRequest{Id: serverWindow.Msg.Window.Id}))
+	require.NoError(t, err)
+	_, err = admin.DeleteMaintenanceWindow(ctx, connect.NewRequest(&managerv1.DeleteMaintenanceWindowRequest{Id: serverWindow.Msg.Window.Id}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	t.Run("invalid windows", func(t *testing.T) {
+		for name, req := range map[string]*managerv1.CreateMaintenanceWindowRequest{
+			"no target": {
+				Policy: string(domain.MaintenancePolicyReject), StartsAt: timestamppb.New(now), EndsAt: timestamppb.New(now.Add(time.Hour)),
+			},
+			"server and labels": {
+				ServerId: "server-1", Labels: map[string]string{"rack": "r1"},
+				Policy: string(domain.MaintenancePolicyReject), StartsAt: timestamppb.New(now), EndsAt: timestamppb.New(now.Add(time.Hour)),
+			},
+			"unknown policy": {
+				ServerId: "server-1", Policy: "block", StartsAt: timestamppb.New(now), EndsAt: timestamppb.New(now.Add(time.Hour)),
+			},
+			"ends before start": {
+				ServerId: "server-1", Policy: string(domain.MaintenancePolicyReject),
+				StartsAt: timestamppb.New(now.Add(time.Hour)), EndsAt: timestamppb.New(now),
+			},
+			"ended": {
+				ServerId: "server-1", Policy: string(domain.MaintenancePolicyReject),
+				StartsAt: timestamppb.New(now.Add(-2 * time.Hour)), EndsAt: timestamppb.New(now.Add(-time.Hour)),
+			},
+		} {
+			_, err := admin.CreateMaintenanceWindow(ctx, connect.NewRequest(req))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), name)
+		}
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		_, err := admin.CreateMaintenanceWindow(ctx, connect.NewRequest(&managerv1.CreateMaintenanceWindowRequest{
+			ServerId: "server-3", Policy: string(domain.MaintenancePolicyReject),
+			StartsAt: timestamppb.New(now), EndsAt: timestamppb.New(now.Add(time.Hour)),
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
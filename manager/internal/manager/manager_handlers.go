@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"slices"
 	"time"
 
 	"connectrpc.com/connect"
-	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	coreauth "core/auth"
 	"core/domain"
+	"core/events"
 	commonv1 "core/gen/common/v1"
+	"core/identity"
 	"core/types"
 	managerv1 "manager/gen/manager/v1"
 	"manager/internal/database"
@@ -25,6 +28,24 @@ type BMCManagerServiceHandler struct {
 	jwtManager  *auth.JWTManager
 	startTime   time.Time
 	adminEmails []string
+	events      events.Publisher // Notified of system events, may be nil
+	eventBus    events.Publisher // Receives the manager's own events, may be nil
+	eventLog    *EventLog        // Records all system events for admins, may be nil
+
+	// Default console session limits of customers, 0 for none
+	sessionQuota coreauth.SessionQuota
+
+	// Whether gateways registering for the first time await approval
+	requireGatewayApproval bool
+
+	// Emails of the accounts of the gateways by gateway ID
+	gatewayAccounts map[string]string
+
+	// How customers authenticate with passwords
+	passwordPolicy PasswordPolicy
+
+	// Last status polls of the gateways, see StartGatewayStatusPolling
+	gatewayStatuses *gatewayStatusCache
 }
 
 func NewBMCManagerServiceHandler(db *database.BunDB, jwtManager *auth.JWTManager, adminEmails []string) *BMCManagerServiceHandler {
@@ -33,6 +54,8 @@ func NewBMCManagerServiceHandler(db *database.BunDB, jwtManager *auth.JWTManager
 		jwtManager:  jwtManager,
 		startTime:   time.Now(),
 		adminEmails: adminEmails,
+
+		gatewayStatuses: newGatewayStatusCache(),
 	}
 }
 
@@ -40,85 +63,85 @@ func NewBMCManagerServiceHandler(db *database.BunDB, jwtManager *auth.JWTManager
 func (h *BMCManagerServiceHandler) AuthInterceptor() connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			// Skip auth for authentication and status endpoints
+			// Skip auth for authentication and status endpoints. Password
+			// resets carry their own token.
 			if req.Spec().Procedure == "/manager.v1.BMCManagerService/Authenticate" ||
+				req.Spec().Procedure == "/manager.v1.BMCManagerService/ResetPassword" ||
 				req.Spec().Procedure == "/manager.v1.BMCManagerService/GetSystemStatus" {
 				return next(ctx, req)
 			}
 
-			// Check for JWT token in Authorization header
-			authHeader := req.Header().Get("Authorization")
-			if authHeader == "" {
-				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing authorization header"))
-			}
-
-			// Extract and validate JWT token
-			parts := []string{}
-			if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-				parts = []string{"Bearer", authHeader[7:]}
-			}
-
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid authorization header format"))
-			}
-
-			claims, err := h.jwtManager.ValidateToken(parts[1])
+			ctx, err := h.Authorize(ctx, req.Header().Get("Authorization"))
 			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token: %w", err))
+				return nil, err
 			}
-
-			// Store full claims object for new methods that need it
-			ctx = context.WithValue(ctx, "claims", claims)
-			// Keep individual values for backwards compatibility
-			ctx = context.WithValue(ctx, "customer_id", claims.CustomerID)
-			ctx = context.WithValue(ctx, "customer_email", claims.Email)
 			return next(ctx, req)
 		}
 	}
 }
 
-// Authenticate verifies customer credentials and issues access tokens
-func (h *BMCManagerServiceHandler) Authenticate(
-	ctx context.Context,
-	req *connect.Request[managerv1.AuthenticateRequest],
-) (*connect.Response[managerv1.AuthenticateResponse], error) {
-	// TODO: Implement actual authentication logic
-	// For now, return a placeholder response for demo purposes
+// Authorize validates the bearer token of an Authorization header and returns
+// ctx with the token's claims, as expected by the authenticated RPCs
+func (h *BMCManagerServiceHandler) Authorize(ctx context.Context, authHeader string) (context.Context, error) {
+	// Check for JWT token in Authorization header
+	if authHeader == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing authorization header"))
+	}
 
-	// Use email address as customer ID - this aligns with OIDC where email is a stable identifier
-	customerID := req.Msg.Email
+	// Extract and validate JWT token
+	parts := []string{}
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		parts = []string{"Bearer", authHeader[7:]}
+	}
 
-	// Check if user is admin
-	isAdmin := h.isAdminEmail(req.Msg.Email)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid authorization header format"))
+	}
 
-	customer := &models.Customer{
-		ID:      customerID,
-		Email:   req.Msg.Email,
-		IsAdmin: isAdmin,
+	claims, err := h.jwtManager.ValidateToken(parts[1])
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token: %w", err))
+	}
+	if err := h.CheckCustomerAccess(ctx, claims); err != nil {
+		return nil, err
 	}
 
-	// Log admin authentication
-	if isAdmin {
-		log.Info().Str("email", req.Msg.Email).Msg("Admin user authenticated")
+	return withClaims(ctx, claims), nil
+}
+
+// AuthorizeAPIKey authenticates a customer by API key and returns ctx with
+// the customer's claims, as Authorize does for access tokens
+func (h *BMCManagerServiceHandler) AuthorizeAPIKey(ctx context.Context, apiKey string) (context.Context, error) {
+	if apiKey == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("missing API key"))
 	}
 
-	accessToken, err := h.jwtManager.GenerateToken(customer)
+	customer, err := h.db.Customers.GetByAPIKey(ctx, apiKey)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		if err.Error() == "customer not found" {
+			return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid API key"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to look up API key: %w", err))
 	}
-
-	response := &managerv1.AuthenticateResponse{
-		AccessToken:  accessToken,
-		RefreshToken: "refresh_" + uuid.New().String(),
-		ExpiresAt:    timestamppb.New(time.Now().Add(24 * time.Hour)),
-		Customer: &managerv1.Customer{
-			Id:        customerID,
-			Email:     req.Msg.Email,
-			CreatedAt: timestamppb.Now(),
-		},
+	if customer.Disabled {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("customer access revoked"))
 	}
 
-	return connect.NewResponse(response), nil
+	return withClaims(ctx, &models.AuthClaims{
+		CustomerID: customer.ID,
+		Email:      customer.Email,
+		IsAdmin:    customer.IsAdmin || h.isAdminEmail(customer.Email),
+	}), nil
+}
+
+// withClaims returns ctx with the claims of an authenticated customer
+func withClaims(ctx context.Context, claims *models.AuthClaims) context.Context {
+	// Store full claims object for new methods that need it
+	ctx = context.WithValue(ctx, "claims", claims)
+	// Keep individual values for backwards compatibility
+	ctx = context.WithValue(ctx, "customer_id", claims.CustomerID)
+	ctx = context.WithValue(ctx, "customer_email", claims.Email)
+	return ctx
 }
 
 // isAdminEmail checks if an email is in the admin list
@@ -164,18 +187,30 @@ func (h *BMCManagerServiceHandler) GetServerToken(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server: %w", err))
 	}
 
-	// Create customer object for token generation
-	customer := &models.Customer{
-		ID:    claims.CustomerID,
-		Email: claims.Email,
+	// Create customer object for token generation, with the policies
+	// gateways enforce
+	customer, err := serverTokenCustomer(ctx, h.db, claims)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
 	}
 
-	// Define permissions for this server token
-	// In production, these would be determined by customer role/subscription
-	permissions := []string{"power:read", "power:write", "console:read", "console:write"}
+	permissions := serverTokenPermissions(claims, server)
+
+	// Gateways enforce the maintenance windows carried by the token
+	if err := loadMaintenanceWindows(ctx, h.db, server); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load maintenance windows: %w", err))
+	}
+
+	// Gateways limit the console sessions of the customer
+	quota, err := h.customerSessionQuota(ctx, claims.CustomerID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get session quota: %w", err))
+	}
 
-	// Generate server-specific token with encrypted BMC context
-	serverToken, err := h.jwtManager.GenerateServerToken(customer, server, permissions)
+	// Generate server-specific token with encrypted BMC context. Gateways
+	// refuse console sessions without the second factor the customer's
+	// policy requires.
+	serverToken, err := h.jwtManager.GenerateServerTokenWithMFA(customer, server, permissions, quota, consoleMFAClaims(claims, customer))
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate server token: %w", err))
 	}
@@ -198,7 +233,27 @@ func (h *BMCManagerServiceHandler) GetServerToken(
 	return connect.NewResponse(response), nil
 }
 
-// RegisterServer registers a server and maps it to a regional gateway
+// serverTokenPermissions returns the permissions of a server token. The
+// diagnostic interrupt crashes the server's OS, so power:diag is only granted
+// to the server's owner and to admins. power:maintenance lets admins run power
+// operations on servers in maintenance.
+// In production, the others would be determined by customer role/subscription.
+func serverTokenPermissions(claims *models.AuthClaims, server *domain.Server) []string {
+	permissions := []string{"power:read", "power:write", "console:read", "console:write"}
+	if claims.IsAdmin || server.CustomerID == claims.CustomerID {
+		permissions = append(permissions, "power:diag")
+	}
+	if claims.IsAdmin {
+		permissions = append(permissions, "power:maintenance")
+	}
+	return permissions
+}
+
+// RegisterServer registers a server and maps it to a regional gateway.
+// Registration is an upsert keyed by the datacenter and the primary BMC
+// endpoint, so that provisioning tools can apply it repeatedly: an existing
+// server with the same endpoint, registered or discovered, is updated and
+// keeps its ID.
 func (h *BMCManagerServiceHandler) RegisterServer(
 	ctx context.Context,
 	req *connect.Request[managerv1.RegisterServerRequest],
@@ -222,7 +277,8 @@ func (h *BMCManagerServiceHandler) RegisterServer(
 		}
 
 		endpoint := &types.BMCControlEndpoint{
-			Endpoint:     protoEndpoint.Endpoint,
+			// Endpoints named in DNS match those discovered whatever their case
+			Endpoint:     identity.NormalizeBMCEndpoint(protoEndpoint.Endpoint),
 			Type:         bmcType,
 			Username:     protoEndpoint.Username,
 			Password:     protoEndpoint.Password,
@@ -250,15 +306,33 @@ func (h *BMCManagerServiceHandler) RegisterServer(
 		Features:         req.Msg.Features,
 		Status:           "active",
 		Metadata:         make(map[string]string),
+		ExternalID:       req.Msg.ExternalId,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
-	// Populate SOL/Console endpoint if feature is present
+	existing, err := findRegisteredServer(ctx, h.db.Servers, server)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check existing server: %w", err))
+	}
+	if existing != nil {
+		// Servers discovered by gateways are owned by the system until registered
+		if existing.CustomerID != customerID && existing.CustomerID != "system" {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("server %s is registered by another customer", existing.ID))
+		}
+		server.ID = existing.ID
+		server.Metadata = existing.Metadata
+		server.DiscoveryMetadata = existing.DiscoveryMetadata
+		server.MaintenanceMode = existing.MaintenanceMode
+		server.Notes = existing.Notes
+		server.CreatedAt = existing.CreatedAt
+	}
+
 	log.Debug().
 		Str("server_id", req.Msg.ServerId).
 		Strs("features", req.Msg.Features).
 		Msg("Processing features for endpoint population")
+	setConsoleEndpoints(server)
 
 	// Use primary endpoint for SOL/VNC if not explicitly provided
 	primaryEndpoint := ""
@@ -266,59 +340,29 @@ func (h *BMCManagerServiceHandler) RegisterServer(
 		primaryEndpoint = controlEndpoints[0].Endpoint
 	}
 
-	for _, feature := range req.Msg.Features {
-		if feature == types.FeatureConsole.String() {
-			// Determine SOL type based on primary protocol
-			solType := types.SOLTypeIPMI
-			if primaryProtocol == types.BMCTypeRedfish {
-				solType = types.SOLTypeRedfishSerial
-			}
-			server.SOLEndpoint = &types.SOLEndpoint{
-				Type:     solType,
-				Endpoint: primaryEndpoint,
-				Username: "", // Will be filled later
-				Password: "", // Will be filled later
-			}
-			log.Debug().
-				Str("server_id", req.Msg.ServerId).
-				Str("sol_type", string(solType)).
-				Msg("Created SOL endpoint")
-			break
-		}
-	}
-
-	// Populate VNC endpoint if feature is present
-	for _, feature := range req.Msg.Features {
-		if feature == types.FeatureVNC.String() {
-			server.VNCEndpoint = &types.VNCEndpoint{
-				Type:     types.VNCTypeNative, // Default to native VNC
-				Endpoint: primaryEndpoint,
-				Username: "", // Will be filled later
-				Password: "", // Will be filled later
-			}
-			log.Debug().
-				Str("server_id", req.Msg.ServerId).
-				Msg("Created VNC endpoint")
-			break
-		}
-	}
-
 	log.Info().
 		Str("server_id", server.ID).
 		Str("primary_endpoint", primaryEndpoint).
 		Int("protocol_count", len(controlEndpoints)).
 		Bool("has_sol", server.SOLEndpoint != nil).
 		Bool("has_vnc", server.VNCEndpoint != nil).
-		Msg("Creating server record")
-	err := h.db.Servers.Create(ctx, server)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create server record: %w", err))
+		Bool("exists", existing != nil).
+		Msg("Registering server record")
+	if existing != nil {
+		if err := h.db.Servers.Update(ctx, server); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update server record: %w", err))
+		}
+		log.Info().Str("server_id", server.ID).Msg("Successfully updated server record")
+	} else {
+		if err := h.db.Servers.Create(ctx, server); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create server record: %w", err))
+		}
+		log.Info().Str("server_id", server.ID).Msg("Successfully created server record")
 	}
-	log.Info().Str("server_id", server.ID).Msg("Successfully created server record")
 
-	// Create server location record for gateway routing
+	// Create or update server location record for gateway routing
 	location := &models.ServerLocation{
-		ServerID:          req.Msg.ServerId,
+		ServerID:          server.ID,
 		CustomerID:        customerID,
 		DatacenterID:      req.Msg.DatacenterId,
 		RegionalGatewayID: req.Msg.RegionalGatewayId,
@@ -329,18 +373,85 @@ func (h *BMCManagerServiceHandler) RegisterServer(
 		UpdatedAt:         time.Now(),
 	}
 
-	err = h.db.Locations.Create(ctx, location)
-	if err != nil {
+	if err := h.db.Locations.Upsert(ctx, location); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to register server location: %w", err))
 	}
 
 	response := &managerv1.RegisterServerResponse{
-		Success: true,
-		Message: fmt.Sprintf("Server %s registered successfully", req.Msg.ServerId),
+		Success:  true,
+		Message:  fmt.Sprintf("Server %s registered successfully", server.ID),
+		Created:  existing == nil,
+		ServerId: server.ID,
+	}
+	if existing != nil {
+		response.Message = fmt.Sprintf("Server %s updated successfully", server.ID)
 	}
 	return connect.NewResponse(response), nil
 }
 
+// setConsoleEndpoints sets the SOL and VNC endpoints of a registered server
+// from its features, on its first control endpoint. Their credentials are
+// filled later.
+func setConsoleEndpoints(server *domain.Server) {
+	primaryEndpoint := ""
+	if len(server.ControlEndpoints) > 0 {
+		primaryEndpoint = server.ControlEndpoints[0].Endpoint
+	}
+
+	if slices.Contains(server.Features, types.FeatureConsole.String()) {
+		// Determine SOL type based on primary protocol
+		solType := types.SOLTypeIPMI
+		if server.PrimaryProtocol == types.BMCTypeRedfish {
+			solType = types.SOLTypeRedfishSerial
+		}
+		server.SOLEndpoint = &types.SOLEndpoint{
+			Type:     solType,
+			Endpoint: primaryEndpoint,
+		}
+		log.Debug().
+			Str("server_id", server.ID).
+			Str("sol_type", string(solType)).
+			Msg("Created SOL endpoint")
+	}
+
+	if slices.Contains(server.Features, types.FeatureVNC.String()) {
+		server.VNCEndpoint = &types.VNCEndpoint{
+			Type:     types.VNCTypeNative, // Default to native VNC
+			Endpoint: primaryEndpoint,
+		}
+		log.Debug().
+			Str("server_id", server.ID).
+			Msg("Created VNC endpoint")
+	}
+}
+
+// findRegisteredServer returns the server that a registration updates: the
+// server of the datacenter with the same primary BMC endpoint, else the
+// server with the requested ID. It returns nil when there is none.
+func findRegisteredServer(ctx context.Context, servers database.ServerRepository, server *domain.Server) (*domain.Server, error) {
+	if primary := server.GetPrimaryControlEndpoint(); primary != nil {
+		existing, err := servers.FindByBMCEndpoint(ctx, server.DatacenterID, primary.Endpoint)
+		if err == nil {
+			return existing, nil
+		}
+		if err.Error() != "server not found" {
+			return nil, err
+		}
+	}
+
+	if server.ID == "" {
+		return nil, nil
+	}
+	existing, err := servers.Get(ctx, server.ID)
+	if err != nil {
+		if err.Error() == "server not found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return existing, nil
+}
+
 // GetServerLocation resolves which gateway handles a specific server
 func (h *BMCManagerServiceHandler) GetServerLocation(
 	ctx context.Context,
@@ -394,25 +505,50 @@ func (h *BMCManagerServiceHandler) RegisterGateway(
 	ctx context.Context,
 	req *connect.Request[managerv1.RegisterGatewayRequest],
 ) (*connect.Response[managerv1.RegisterGatewayResponse], error) {
+	status, err := h.registeredGatewayStatus(ctx, req.Msg.GatewayId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to register gateway: %w", err))
+	}
+
 	// Create or update gateway record (using upsert for re-registration support)
 	gateway := &models.RegionalGateway{
 		ID:            req.Msg.GatewayId,
 		Region:        req.Msg.Region,
 		Endpoint:      req.Msg.Endpoint,
 		DatacenterIDs: req.Msg.DatacenterIds,
-		Status:        "active",
+		Status:        status,
 		LastSeen:      time.Now(),
 		CreatedAt:     time.Now(),
 	}
 
-	err := h.db.Gateways.Upsert(ctx, gateway)
+	err = h.db.Gateways.Upsert(ctx, gateway)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to register gateway: %w", err))
 	}
 
+	message := fmt.Sprintf("Gateway %s registered successfully", req.Msg.GatewayId)
+	if status == models.GatewayStatusPending {
+		log.Warn().Str("gateway_id", req.Msg.GatewayId).Str("endpoint", req.Msg.Endpoint).Msg("Gateway registered, awaiting approval")
+		message = fmt.Sprintf("Gateway %s registered, awaiting approval by an admin", req.Msg.GatewayId)
+	}
+
 	response := &managerv1.RegisterGatewayResponse{
 		Success: true,
-		Message: fmt.Sprintf("Gateway %s registered successfully", req.Msg.GatewayId),
+		Message: message,
+	}
+
+	// Gateways validate tokens locally, they learn of revoked ones here
+	if h.authorizeGatewayReport(ctx, req.Msg.GatewayId) == nil {
+		revocations, err := h.db.TokenRevocations.ListSince(ctx, time.Now().Add(-auth.AccessTokenTTL))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list token revocations: %w", err))
+		}
+		for _, revocation := range revocations {
+			response.TokenRevocations = append(response.TokenRevocations, &managerv1.TokenRevocation{
+				CustomerId: revocation.CustomerID,
+				RevokedAt:  timestamppb.New(revocation.RevokedAt),
+			})
+		}
 	}
 	return connect.NewResponse(response), nil
 }
@@ -428,16 +564,17 @@ func (h *BMCManagerServiceHandler) ListGateways(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list gateways: %w", err))
 	}
 
-	// Filter by region if specified
-	if req.Msg.Region != "" {
-		filtered := make([]*models.RegionalGateway, 0)
-		for _, g := range gateways {
-			if g.Region == req.Msg.Region {
-				filtered = append(filtered, g)
-			}
+	// Filter by region if specified, and leave out gateways awaiting approval
+	filtered := make([]*models.RegionalGateway, 0, len(gateways))
+	for _, g := range gateways {
+		if g.Status == models.GatewayStatusPending {
+			continue
+		}
+		if req.Msg.Region == "" || g.Region == req.Msg.Region {
+			filtered = append(filtered, g)
 		}
-		gateways = filtered
 	}
+	gateways = filtered
 
 	// Get customer ID from context for delegated token generation
 	customerID, ok := ctx.Value("customer_id").(string)
@@ -557,9 +694,12 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 			Msg("Server record")
 	}
 
-	// Build gateway status information
+	// Build gateway status information, with the live status last polled
 	var gatewayStatuses []*managerv1.GatewayStatus
 	activeGateways := 0
+	reachableGateways := 0
+	activeConsoleSessions := int32(0)
+	reachableEndpoints := make(map[string]map[string]bool, len(gateways))
 	cutoffTime := time.Now().Add(-2 * time.Minute) // Consider gateways active if seen within 2 minutes
 
 	for _, gateway := range gateways {
@@ -569,6 +709,13 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 			activeGateways++
 		}
 
+		live := h.gatewayStatuses.liveGatewayStatus(gateway.ID)
+		if live.GetReachable() {
+			reachableGateways++
+			activeConsoleSessions += live.ActiveConsoleSessions
+		}
+		reachableEndpoints[gateway.ID] = h.gatewayStatuses.reachableEndpoints(gateway.ID)
+
 		// Find servers for this gateway
 		var gatewayServers []*managerv1.SystemStatusServerEntry
 		for _, server := range allServers {
@@ -583,6 +730,7 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 					CreatedAt:         timestamppb.New(server.CreatedAt),
 					UpdatedAt:         timestamppb.New(server.UpdatedAt),
 					BmcProtocols:      []*commonv1.BMCControlEndpoint{{Endpoint: server.BMCEndpoint, Type: convertBMCTypeToProto(server.BMCType)}},
+					EndpointReachable: reachableEndpoints[gateway.ID][server.BMCEndpoint],
 				})
 			}
 		}
@@ -597,6 +745,7 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 			CreatedAt:     timestamppb.New(gateway.CreatedAt),
 			ServerCount:   int32(len(gatewayServers)),
 			Servers:       gatewayServers,
+			Live:          live,
 		}
 		gatewayStatuses = append(gatewayStatuses, gatewayStatus)
 	}
@@ -614,6 +763,7 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 			CreatedAt:         timestamppb.New(server.CreatedAt),
 			UpdatedAt:         timestamppb.New(server.UpdatedAt),
 			BmcProtocols:      []*commonv1.BMCControlEndpoint{{Endpoint: server.BMCEndpoint, Type: convertBMCTypeToProto(server.BMCType)}},
+			EndpointReachable: reachableEndpoints[server.RegionalGatewayID][server.BMCEndpoint],
 		})
 	}
 
@@ -627,6 +777,9 @@ func (h *BMCManagerServiceHandler) GetSystemStatus(
 		TotalServers:   int32(len(allServers)),
 		Gateways:       gatewayStatuses,
 		Servers:        allServerEntries,
+
+		ReachableGateways:     int32(reachableGateways),
+		ActiveConsoleSessions: activeConsoleSessions,
 	}
 
 	response := &managerv1.GetSystemStatusResponse{
@@ -699,7 +852,62 @@ func (h *BMCManagerServiceHandler) GetServer(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get server: %w", err))
 	}
 
+	resp := &managerv1.GetServerResponse{
+		Server: serverToProto(server),
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ListServers returns all servers accessible by the authenticated customer
+// Moved from gateway in BMC-centric architecture
+func (h *BMCManagerServiceHandler) ListServers(
+	ctx context.Context,
+	req *connect.Request[managerv1.ListServersRequest],
+) (*connect.Response[managerv1.ListServersResponse], error) {
+	// Get customer ID from JWT claims (set by auth interceptor)
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
+	// Set pagination defaults
+	pageSize := req.Msg.PageSize
+	if pageSize <= 0 || pageSize > 1000 {
+		pageSize = 50 // Default page size
+	}
+
+	// For now, show all servers to any authenticated customer
+	// TODO: Replace with proper server-customer mapping logic
+	servers, err := h.db.Servers.ListAll(ctx)
+	nextPageToken := "" // Disable pagination for simplicity
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list servers: %w", err))
+	}
+
+	var powerStates map[string]string
+	if req.Msg.IncludePowerState {
+		powerStates = h.serverPowerStates(ctx, claims, servers)
+	}
+
 	// Convert to protobuf format
+	var protoServers []*managerv1.Server
+	for _, server := range servers {
+		protoServer := serverToProto(server)
+		protoServer.PowerState = powerStates[server.ID]
+		protoServers = append(protoServers, protoServer)
+	}
+
+	resp := &managerv1.ListServersResponse{
+		Servers:       protoServers,
+		NextPageToken: nextPageToken,
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// serverToProto converts a server record to its protobuf form
+func serverToProto(server *domain.Server) *managerv1.Server {
 	protoServer := &managerv1.Server{
 		Id:                server.ID,
 		CustomerId:        server.CustomerID,
@@ -710,6 +918,9 @@ func (h *BMCManagerServiceHandler) GetServer(
 		UpdatedAt:         timestamppb.New(server.UpdatedAt),
 		Metadata:          server.Metadata,
 		DiscoveryMetadata: convertModelsToProtoDiscoveryMetadata(server.DiscoveryMetadata),
+		ExternalId:        server.ExternalID,
+		MaintenanceMode:   server.MaintenanceMode,
+		Notes:             server.Notes,
 	}
 
 	// Convert control endpoints (multi-protocol support)
@@ -768,119 +979,7 @@ func (h *BMCManagerServiceHandler) GetServer(
 		}
 	}
 
-	resp := &managerv1.GetServerResponse{
-		Server: protoServer,
-	}
-
-	return connect.NewResponse(resp), nil
-}
-
-// ListServers returns all servers accessible by the authenticated customer
-// Moved from gateway in BMC-centric architecture
-func (h *BMCManagerServiceHandler) ListServers(
-	ctx context.Context,
-	req *connect.Request[managerv1.ListServersRequest],
-) (*connect.Response[managerv1.ListServersResponse], error) {
-	// Get customer ID from JWT claims (set by auth interceptor)
-	_, ok := ctx.Value("claims").(*models.AuthClaims)
-	if !ok {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
-	}
-
-	// Set pagination defaults
-	pageSize := req.Msg.PageSize
-	if pageSize <= 0 || pageSize > 1000 {
-		pageSize = 50 // Default page size
-	}
-
-	// For now, show all servers to any authenticated customer
-	// TODO: Replace with proper server-customer mapping logic
-	servers, err := h.db.Servers.ListAll(ctx)
-	nextPageToken := "" // Disable pagination for simplicity
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list servers: %w", err))
-	}
-
-	// Convert to protobuf format
-	var protoServers []*managerv1.Server
-	for _, server := range servers {
-		protoServer := &managerv1.Server{
-			Id:                server.ID,
-			CustomerId:        server.CustomerID,
-			DatacenterId:      server.DatacenterID,
-			Features:          server.Features,
-			Status:            server.Status,
-			CreatedAt:         timestamppb.New(server.CreatedAt),
-			UpdatedAt:         timestamppb.New(server.UpdatedAt),
-			Metadata:          server.Metadata,
-			DiscoveryMetadata: convertModelsToProtoDiscoveryMetadata(server.DiscoveryMetadata),
-		}
-
-		// Convert control endpoints (multi-protocol support)
-		protoServer.ControlEndpoints = make([]*commonv1.BMCControlEndpoint, 0, len(server.ControlEndpoints))
-		for _, endpoint := range server.ControlEndpoints {
-			protoEndpoint := &commonv1.BMCControlEndpoint{
-				Endpoint:     endpoint.Endpoint,
-				Type:         convertBMCTypeToProto(endpoint.Type),
-				Username:     endpoint.Username,
-				Password:     endpoint.Password,
-				Capabilities: endpoint.Capabilities,
-			}
-			if endpoint.TLS != nil {
-				protoEndpoint.Tls = &commonv1.TLSConfig{
-					Enabled:            endpoint.TLS.Enabled,
-					InsecureSkipVerify: endpoint.TLS.InsecureSkipVerify,
-					CaCert:             endpoint.TLS.CACert,
-				}
-			}
-			protoServer.ControlEndpoints = append(protoServer.ControlEndpoints, protoEndpoint)
-		}
-		protoServer.PrimaryProtocol = convertBMCTypeToProto(server.PrimaryProtocol)
-
-		// Convert SOL endpoint
-		if server.SOLEndpoint != nil {
-			protoServer.SolEndpoint = &commonv1.SOLEndpoint{
-				Type:     convertSOLTypeToProto(server.SOLEndpoint.Type),
-				Endpoint: server.SOLEndpoint.Endpoint,
-				Username: server.SOLEndpoint.Username,
-				Password: server.SOLEndpoint.Password,
-			}
-			if server.SOLEndpoint.Config != nil {
-				protoServer.SolEndpoint.Config = &commonv1.SOLConfig{
-					BaudRate:       int32(server.SOLEndpoint.Config.BaudRate),
-					FlowControl:    server.SOLEndpoint.Config.FlowControl,
-					TimeoutSeconds: int32(server.SOLEndpoint.Config.TimeoutSeconds),
-				}
-			}
-		}
-
-		// Convert VNC endpoint
-		if server.VNCEndpoint != nil {
-			protoServer.VncEndpoint = &commonv1.VNCEndpoint{
-				Type:     convertVNCTypeToProto(server.VNCEndpoint.Type),
-				Endpoint: server.VNCEndpoint.Endpoint,
-				Username: server.VNCEndpoint.Username,
-				Password: server.VNCEndpoint.Password,
-			}
-			if server.VNCEndpoint.Config != nil {
-				protoServer.VncEndpoint.Config = &commonv1.VNCConfig{
-					Protocol: server.VNCEndpoint.Config.Protocol,
-					Path:     server.VNCEndpoint.Config.Path,
-					Display:  int32(server.VNCEndpoint.Config.Display),
-					ReadOnly: server.VNCEndpoint.Config.ReadOnly,
-				}
-			}
-		}
-
-		protoServers = append(protoServers, protoServer)
-	}
-
-	resp := &managerv1.ListServersResponse{
-		Servers:       protoServers,
-		NextPageToken: nextPageToken,
-	}
-
-	return connect.NewResponse(resp), nil
+	return protoServer
 }
 
 // ReportAvailableEndpoints allows gateways to report BMC endpoints they can proxy
@@ -895,6 +994,10 @@ func (h *BMCManagerServiceHandler) ReportAvailableEndpoints(
 		Str("region", req.Msg.Region).
 		Msg("Gateway reporting BMC endpoints")
 
+	if err := h.checkGatewayApproved(ctx, req.Msg.GatewayId); err != nil {
+		return nil, err
+	}
+
 	// Store BMC endpoint availability in database
 	for _, endpoint := range req.Msg.BmcEndpoints {
 		log.Debug().
@@ -921,6 +1024,222 @@ func (h *BMCManagerServiceHandler) ReportAvailableEndpoints(
 	return connect.NewResponse(resp), nil
 }
 
+// ReportConsoleSLIs records console session establishment outcomes reported
+// by a gateway, the raw measurements behind the console availability SLOs
+func (h *BMCManagerServiceHandler) ReportConsoleSLIs(
+	ctx context.Context,
+	req *connect.Request[managerv1.ReportConsoleSLIsRequest],
+) (*connect.Response[managerv1.ReportConsoleSLIsResponse], error) {
+	if req.Msg.GatewayId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id is required"))
+	}
+	if err := h.authorizeGatewayReport(ctx, req.Msg.GatewayId); err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("gateway_id", req.Msg.GatewayId).
+		Int("session_count", len(req.Msg.Sessions)).
+		Msg("Gateway reporting console SLIs")
+
+	sessions := make([]*database.ConsoleSessionSLI, 0, len(req.Msg.Sessions))
+	for _, s := range req.Msg.Sessions {
+		if s.AgentId == "" || s.StartedAt == nil {
+			log.Warn().
+				Str("gateway_id", req.Msg.GatewayId).
+				Str("session_id", s.SessionId).
+				Msg("Skipping console SLI without agent or start time")
+			continue
+		}
+
+		sessions = append(sessions, &database.ConsoleSessionSLI{
+			GatewayID:         req.Msg.GatewayId,
+			AgentID:           s.AgentId,
+			DatacenterID:      s.DatacenterId,
+			SessionID:         s.SessionId,
+			SessionType:       s.SessionType,
+			StartedAt:         s.StartedAt.AsTime(),
+			Established:       s.Established,
+			FirstByte:         s.FirstByte,
+			TimeToFirstByteMs: s.TimeToFirstByteMs,
+			Error:             s.Error,
+		})
+	}
+
+	if err := h.db.ConsoleSLIs.Record(ctx, sessions); err != nil {
+		log.Error().Err(err).Str("gateway_id", req.Msg.GatewayId).Msg("Failed to record console SLIs")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record console SLIs: %w", err))
+	}
+
+	resp := &managerv1.ReportConsoleSLIsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Recorded %d console sessions from gateway %s", len(sessions), req.Msg.GatewayId),
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ReportPowerReadings records server power samples reported by a gateway,
+// the raw measurements behind the power usage report. Samples are attributed
+// to the server registered with their BMC endpoint and to its current owner;
+// samples of unknown servers are skipped.
+func (h *BMCManagerServiceHandler) ReportPowerReadings(
+	ctx context.Context,
+	req *connect.Request[managerv1.ReportPowerReadingsRequest],
+) (*connect.Response[managerv1.ReportPowerReadingsResponse], error) {
+	if req.Msg.GatewayId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id is required"))
+	}
+	if err := h.authorizeGatewayReport(ctx, req.Msg.GatewayId); err != nil {
+		return nil, err
+	}
+
+	log.Debug().
+		Str("gateway_id", req.Msg.GatewayId).
+		Int("sample_count", len(req.Msg.Samples)).
+		Msg("Gateway reporting power readings")
+
+	// Gateways report several samples per server, resolve each server once
+	servers := make(map[string]*domain.Server)
+	readings := make([]*database.PowerReading, 0, len(req.Msg.Samples))
+	for _, s := range req.Msg.Samples {
+		if s.BmcEndpoint == "" || s.SampledAt == nil {
+			log.Warn().
+				Str("gateway_id", req.Msg.GatewayId).
+				Msg("Skipping power sample without BMC endpoint or sample time")
+			continue
+		}
+
+		key := s.DatacenterId + "/" + s.BmcEndpoint
+		server, resolved := servers[key]
+		if !resolved {
+			var err error
+			server, err = h.db.Servers.FindByBMCEndpoint(ctx, s.DatacenterId, s.BmcEndpoint)
+			if err != nil && err.Error() != "server not found" {
+				log.Error().Err(err).Str("bmc_endpoint", s.BmcEndpoint).Msg("Failed to resolve server of power sample")
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve server: %w", err))
+			}
+			servers[key] = server
+		}
+		if server == nil {
+			log.Debug().
+				Str("gateway_id", req.Msg.GatewayId).
+				Str("bmc_endpoint", s.BmcEndpoint).
+				Msg("Skipping power sample of unknown server")
+			continue
+		}
+
+		readings = append(readings, &database.PowerReading{
+			ServerID:      server.ID,
+			CustomerID:    server.CustomerID,
+			DatacenterID:  server.DatacenterID,
+			GatewayID:     req.Msg.GatewayId,
+			SampledAt:     s.SampledAt.AsTime(),
+			ConsumedWatts: s.ConsumedWatts,
+			EnergyKWh:     s.EnergyKwh,
+		})
+	}
+
+	if err := h.db.PowerReadings.Record(ctx, readings); err != nil {
+		log.Error().Err(err).Str("gateway_id", req.Msg.GatewayId).Msg("Failed to record power readings")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record power readings: %w", err))
+	}
+
+	resp := &managerv1.ReportPowerReadingsResponse{
+		Success: true,
+		Message: fmt.Sprintf("Recorded %d power readings from gateway %s", len(readings), req.Msg.GatewayId),
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ValidateSession tells a gateway whether a console session a client attaches
+// to is still authorized: its customer's access was not revoked, and its
+// server is neither disabled nor mapped to another gateway. Gateways keep
+// sessions in memory until they expire, this catches changes made since the
+// session was created.
+func (h *BMCManagerServiceHandler) ValidateSession(
+	ctx context.Context,
+	req *connect.Request[managerv1.ValidateSessionRequest],
+) (*connect.Response[managerv1.ValidateSessionResponse], error) {
+	if req.Msg.GatewayId == "" || req.Msg.ServerId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("gateway_id and server_id are required"))
+	}
+	if err := h.authorizeGatewayReport(ctx, req.Msg.GatewayId); err != nil {
+		return nil, err
+	}
+
+	reason, err := h.sessionRevocation(ctx, req.Msg)
+	if err != nil {
+		log.Error().Err(err).Str("session_id", req.Msg.SessionId).Msg("Failed to validate console session")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to validate session: %w", err))
+	}
+	if reason != "" {
+		log.Info().
+			Str("gateway_id", req.Msg.GatewayId).
+			Str("session_id", req.Msg.SessionId).
+			Str("server_id", req.Msg.ServerId).
+			Str("customer_id", req.Msg.CustomerId).
+			Str("reason", reason).
+			Msg("Console session no longer authorized")
+	}
+
+	return connect.NewResponse(&managerv1.ValidateSessionResponse{Valid: reason == "", Reason: reason}), nil
+}
+
+// sessionRevocation returns why a console session is no longer authorized,
+// empty when it still is
+func (h *BMCManagerServiceHandler) sessionRevocation(ctx context.Context, session *managerv1.ValidateSessionRequest) (string, error) {
+	// Customers authenticated by email have no record, only recorded ones
+	// can be revoked
+	if session.CustomerId != "" {
+		customer, err := h.db.Customers.Get(ctx, session.CustomerId)
+		if err != nil && err.Error() != "customer not found" {
+			return "", err
+		}
+		if customer != nil && customer.Disabled {
+			return "customer access revoked", nil
+		}
+		if customer == nil {
+			revocation, err := h.db.TokenRevocations.Get(ctx, session.CustomerId)
+			if err != nil && err.Error() != "token revocation not found" {
+				return "", err
+			}
+			if revocation != nil && revocation.Reason == revocationDeleted {
+				return "customer deleted", nil
+			}
+		}
+	}
+
+	server, err := h.db.Servers.Get(ctx, session.ServerId)
+	if err != nil {
+		if err.Error() == "server not found" {
+			return "server no longer registered", nil
+		}
+		return "", err
+	}
+	if server.Status == models.ServerStatusDisabled {
+		return "server disabled", nil
+	}
+	// Sessions end with the server's reassignment to another customer.
+	// Servers reported by gateways belong to "system" and are shared.
+	if session.CustomerId != "" && server.CustomerID != "system" && server.CustomerID != session.CustomerId {
+		return "server reassigned to another customer", nil
+	}
+
+	location, err := h.db.Locations.Get(ctx, session.ServerId)
+	if err != nil {
+		if err.Error() == "server location not found" {
+			return "server no longer mapped to a gateway", nil
+		}
+		return "", err
+	}
+	if location.RegionalGatewayID != session.GatewayId {
+		return "server mapped to another gateway", nil
+	}
+	return "", nil
+}
+
 // updateServerWithBMCEndpoint creates or updates server records with BMC endpoint information
 // from gateway endpoint reports
 func (h *BMCManagerServiceHandler) updateServerWithBMCEndpoint(ctx context.Context, endpoint *managerv1.BMCEndpointAvailability, gatewayID string) error {
@@ -1003,13 +1322,50 @@ func (h *BMCManagerServiceHandler) updateServerWithBMCEndpoint(ctx context.Conte
 		}
 	}
 
-	// Check if server already exists
-	existing, err := h.db.Servers.Get(ctx, serverID)
+	// Check if server already exists, registered under its own ID or discovered
+	existing, err := h.db.Servers.FindByBMCEndpoint(ctx, endpoint.DatacenterId, endpoint.BmcEndpoint)
+	if err != nil && err.Error() == "server not found" {
+		existing, err = h.db.Servers.Get(ctx, serverID)
+	}
 	if err != nil && err.Error() != "server not found" {
 		return fmt.Errorf("failed to check existing server: %w", err)
 	}
 
+	location := &models.ServerLocation{
+		ServerID:          serverID,
+		CustomerID:        "system", // System-managed servers from gateway reports
+		DatacenterID:      endpoint.DatacenterId,
+		RegionalGatewayID: gatewayID,
+		ControlEndpoints:  []*types.BMCControlEndpoint{controlEndpoint},
+		PrimaryProtocol:   bmcType,
+		Features:          endpoint.Features,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
 	if existing != nil {
+		server.ID = existing.ID
+		server.Metadata = existing.Metadata
+		server.MaintenanceMode = existing.MaintenanceMode
+		server.Notes = existing.Notes
+		server.CreatedAt = existing.CreatedAt
+		if existing.CustomerID != "system" {
+			// Registered servers keep their owner and the BMC configuration
+			// given at registration, with credentials that gateways don't report
+			server.CustomerID = existing.CustomerID
+			server.ExternalID = existing.ExternalID
+			server.ControlEndpoints = existing.ControlEndpoints
+			server.PrimaryProtocol = existing.PrimaryProtocol
+			server.Features = existing.Features
+			server.SOLEndpoint = existing.SOLEndpoint
+			server.VNCEndpoint = existing.VNCEndpoint
+			location.CustomerID = existing.CustomerID
+			location.ControlEndpoints = existing.ControlEndpoints
+			location.PrimaryProtocol = existing.PrimaryProtocol
+			location.Features = existing.Features
+		}
+		location.ServerID = existing.ID
+
 		// Server exists, update it
 		if err := h.db.Servers.Update(ctx, server); err != nil {
 			return fmt.Errorf("failed to update server record: %w", err)
@@ -1019,28 +1375,24 @@ func (h *BMCManagerServiceHandler) updateServerWithBMCEndpoint(ctx context.Conte
 		if err := h.db.Servers.Create(ctx, server); err != nil {
 			return fmt.Errorf("failed to create server record: %w", err)
 		}
+		h.publish(events.ServerDiscovered, map[string]any{
+			"server_id":     serverID,
+			"datacenter_id": endpoint.DatacenterId,
+			"gateway_id":    gatewayID,
+			"agent_id":      endpoint.AgentId,
+			"bmc_endpoint":  endpoint.BmcEndpoint,
+			"bmc_type":      string(bmcType),
+			"features":      endpoint.Features,
+		})
 	}
 
 	// Also create/update server location mapping
-	location := &models.ServerLocation{
-		ServerID:          serverID,
-		CustomerID:        "system", // System-managed servers from gateway reports
-		DatacenterID:      endpoint.DatacenterId,
-		RegionalGatewayID: gatewayID,
-		ControlEndpoints:  []*types.BMCControlEndpoint{controlEndpoint},
-		PrimaryProtocol:   bmcType,
-		Features:          endpoint.Features,
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
-	}
-
-	// Use Upsert for location since it has that method
 	if err := h.db.Locations.Upsert(ctx, location); err != nil {
 		return fmt.Errorf("failed to create/update server location: %w", err)
 	}
 
 	log.Info().
-		Str("server_id", serverID).
+		Str("server_id", server.ID).
 		Str("bmc_endpoint", endpoint.BmcEndpoint).
 		Msg("Created/updated server location")
 	return nil
@@ -2,12 +2,16 @@ package manager
 
 import (
 	"context"
+	"fmt"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"core/domain"
 	commonv1 "core/gen/common/v1"
 	"core/types"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
 	managerv1 "manager/gen/manager/v1"
 	"manager/internal/database"
 	"manager/pkg/auth"
@@ -449,6 +453,96 @@ func TestListServers_ReturnsSOLAndVNCEndpoints(t *testing.T) {
 	assert.Equal(t, bmcEndpoint.Username, server.VncEndpoint.Username)
 }
 
+// fakePowerGateway serves the power status of servers
+type fakePowerGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	states     map[string]gatewayv1.PowerState
+	authHeader string
+}
+
+func (g *fakePowerGateway) GetPowerStatus(
+	ctx context.Context,
+	req *connect.Request[gatewayv1.PowerStatusRequest],
+) (*connect.Response[gatewayv1.PowerStatusResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	state, ok := g.states[req.Msg.ServerId]
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent not available"))
+	}
+	return connect.NewResponse(&gatewayv1.PowerStatusResponse{State: state}), nil
+}
+
+// TestListServers_IncludesPowerState tests that ListServers queries power
+// states from the gateways when requested, reporting the servers that cannot
+// be queried as unknown
+func TestListServers_IncludesPowerState(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupTestCustomer(t, "")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+
+	fake := &fakePowerGateway{states: map[string]gatewayv1.PowerState{}}
+	_, handlerFunc := gatewayv1connect.NewGatewayServiceHandler(fake)
+	gatewayServer := httptest.NewServer(handlerFunc)
+	t.Cleanup(gatewayServer.Close)
+
+	now := time.Now()
+	for _, gateway := range []*models.RegionalGateway{
+		{ID: "gw-1", Region: "us-east-1", Endpoint: gatewayServer.URL, Status: "active", LastSeen: now, CreatedAt: now},
+		// Nothing listens on the discard port
+		{ID: "gw-down", Region: "eu-west-1", Endpoint: "http://127.0.0.1:9", Status: "active", LastSeen: now, CreatedAt: now},
+	} {
+		require.NoError(t, handler.db.Gateways.Create(context.Background(), gateway))
+	}
+
+	report := func(gateway *models.RegionalGateway, endpoints ...string) {
+		var bmcEndpoints []*managerv1.BMCEndpointAvailability
+		for _, endpoint := range endpoints {
+			bmcEndpoints = append(bmcEndpoints, &managerv1.BMCEndpointAvailability{
+				BmcEndpoint:  endpoint,
+				AgentId:      "agent-" + gateway.ID,
+				DatacenterId: "dc-test-01",
+				BmcType:      commonv1.BMCType_BMC_IPMI,
+				Features:     types.FeaturesToStrings([]types.Feature{types.FeaturePower}),
+				Status:       "active",
+			})
+		}
+		_, err := handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+			GatewayId:    gateway.ID,
+			Region:       gateway.Region,
+			BmcEndpoints: bmcEndpoints,
+		}))
+		require.NoError(t, err)
+	}
+	report(&models.RegionalGateway{ID: "gw-1", Region: "us-east-1"}, "192.168.1.100:623", "192.168.1.101:623")
+	report(&models.RegionalGateway{ID: "gw-down", Region: "eu-west-1"}, "192.168.2.100:623")
+
+	resp, err := handler.ListServers(ctx, connect.NewRequest(&managerv1.ListServersRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Servers, 3)
+
+	for _, server := range resp.Msg.Servers {
+		assert.Empty(t, server.PowerState, "power states should only be queried when requested")
+	}
+	assert.Empty(t, fake.authHeader)
+
+	fake.states["bmc-dc-test-01-192-168-1-100-623"] = gatewayv1.PowerState_POWER_STATE_ON
+	fake.states["bmc-dc-test-01-192-168-1-101-623"] = gatewayv1.PowerState_POWER_STATE_OFF
+
+	resp, err = handler.ListServers(ctx, connect.NewRequest(&managerv1.ListServersRequest{IncludePowerState: true}))
+	require.NoError(t, err)
+
+	states := make(map[string]string)
+	for _, server := range resp.Msg.Servers {
+		states[server.Id] = server.PowerState
+	}
+	assert.Equal(t, map[string]string{
+		"bmc-dc-test-01-192-168-1-100-623": "on",
+		"bmc-dc-test-01-192-168-1-101-623": "off",
+		"bmc-dc-test-01-192-168-2-100-623": "unknown",
+	}, states)
+	assert.NotEmpty(t, fake.authHeader, "gateway calls should be authenticated with server tokens")
+}
+
 // TestRegisterServer_PopulatesSOLAndVNCEndpoints tests the RegisterServer RPC method
 // correctly populates SOL and VNC endpoints from features
 func TestRegisterServer_PopulatesSOLAndVNCEndpoints(t *testing.T) {
@@ -553,6 +647,269 @@ func TestRegisterServer_PopulatesSOLAndVNCEndpoints(t *testing.T) {
 	}
 }
 
+func TestRegisterServer_IsIdempotent(t *testing.T) {
+	handler := setupTestHandler(t)
+	ctx := setupCustomerContext("test-customer")
+
+	newRequest := func(serverID string, features []string) *connect.Request[managerv1.RegisterServerRequest] {
+		return connect.NewRequest(&managerv1.RegisterServerRequest{
+			ServerId:          serverID,
+			DatacenterId:      "dc-test-01",
+			RegionalGatewayId: "gateway-1",
+			BmcProtocols: []*commonv1.BMCControlEndpoint{
+				{Endpoint: "192.168.1.100:623", Type: commonv1.BMCType_BMC_IPMI, Username: "admin", Password: "secret"},
+			},
+			PrimaryProtocol: commonv1.BMCType_BMC_IPMI,
+			Features:        features,
+			ExternalId:      "tf-rack-1-node-1",
+		})
+	}
+
+	resp, err := handler.RegisterServer(ctx, newRequest("server-1", []string{types.FeaturePower.String()}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Created)
+	assert.Equal(t, "server-1", resp.Msg.ServerId)
+
+	// Registering the same BMC endpoint again updates the server, even under another ID
+	features := types.FeaturesToStrings([]types.Feature{types.FeaturePower, types.FeatureConsole})
+	resp, err = handler.RegisterServer(ctx, newRequest("server-1-renamed", features))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Success)
+	assert.False(t, resp.Msg.Created)
+	assert.Equal(t, "server-1", resp.Msg.ServerId)
+
+	servers, err := handler.db.Servers.List(context.Background(), "test-customer")
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, features, servers[0].Features)
+	assert.NotNil(t, servers[0].SOLEndpoint)
+
+	authCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, ""))
+	getResp, err := handler.GetServer(authCtx, connect.NewRequest(&managerv1.GetServerRequest{ServerId: "server-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "tf-rack-1-node-1", getResp.Msg.Server.ExternalId)
+
+	location, err := handler.db.Locations.Get(context.Background(), "server-1")
+	require.NoError(t, err)
+	assert.Equal(t, features, location.Features)
+}
+
+func TestRegisterServer_RejectsServerOfAnotherCustomer(t *testing.T) {
+	handler := setupTestHandler(t)
+
+	req := connect.NewRequest(&managerv1.RegisterServerRequest{
+		ServerId:     "server-1",
+		DatacenterId: "dc-test-01",
+		BmcProtocols: []*commonv1.BMCControlEndpoint{
+			{Endpoint: "192.168.1.100:623", Type: commonv1.BMCType_BMC_IPMI},
+		},
+	})
+	_, err := handler.RegisterServer(setupCustomerContext("customer-a"), req)
+	require.NoError(t, err)
+
+	_, err = handler.RegisterServer(setupCustomerContext("customer-b"), req)
+	require.Error(t, err)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestRegisterServer_AdoptsDiscoveredServer(t *testing.T) {
+	handler := setupTestHandler(t)
+	gateway := setupTestGateway(t, handler)
+
+	_, err := handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+		GatewayId: gateway.ID,
+		BmcEndpoints: []*managerv1.BMCEndpointAvailability{{
+			BmcEndpoint:  "192.168.1.100:623",
+			AgentId:      "agent-1",
+			DatacenterId: "dc-test-01",
+			BmcType:      commonv1.BMCType_BMC_IPMI,
+			Features:     []string{types.FeaturePower.String()},
+			Status:       "active",
+		}},
+	}))
+	require.NoError(t, err)
+	discoveredID := models.GenerateServerIDFromBMCEndpoint("dc-test-01", "192.168.1.100:623")
+
+	ctx := setupCustomerContext("test-customer")
+	resp, err := handler.RegisterServer(ctx, connect.NewRequest(&managerv1.RegisterServerRequest{
+		ServerId:          "server-1",
+		DatacenterId:      "dc-test-01",
+		RegionalGatewayId: gateway.ID,
+		BmcProtocols: []*commonv1.BMCControlEndpoint{
+			{Endpoint: "192.168.1.100:623", Type: commonv1.BMCType_BMC_IPMI, Username: "admin", Password: "secret"},
+		},
+		PrimaryProtocol: commonv1.BMCType_BMC_IPMI,
+		ExternalId:      "cmdb-42",
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Created)
+	assert.Equal(t, discoveredID, resp.Msg.ServerId)
+
+	// Later discovery reports keep the registration
+	_, err = handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+		GatewayId: gateway.ID,
+		BmcEndpoints: []*managerv1.BMCEndpointAvailability{{
+			BmcEndpoint:  "192.168.1.100:623",
+			AgentId:      "agent-1",
+			DatacenterId: "dc-test-01",
+			BmcType:      commonv1.BMCType_BMC_IPMI,
+			Status:       "active",
+		}},
+	}))
+	require.NoError(t, err)
+
+	server, err := handler.db.Servers.Get(context.Background(), discoveredID)
+	require.NoError(t, err)
+	assert.Equal(t, "test-customer", server.CustomerID)
+	assert.Equal(t, "cmdb-42", server.ExternalID)
+	assert.Equal(t, "secret", server.ControlEndpoints[0].Password)
+}
+
+func TestRegisterServer_AdoptsDiscoveredHostname(t *testing.T) {
+	handler := setupTestHandler(t)
+	gateway := setupTestGateway(t, handler)
+
+	_, err := handler.ReportAvailableEndpoints(context.Background(), connect.NewRequest(&managerv1.ReportAvailableEndpointsRequest{
+		GatewayId: gateway.ID,
+		BmcEndpoints: []*managerv1.BMCEndpointAvailability{{
+			BmcEndpoint:  "bmc-01.dc.example.com:623",
+			AgentId:      "agent-1",
+			DatacenterId: "dc-test-01",
+			BmcType:      commonv1.BMCType_BMC_IPMI,
+			Status:       "active",
+		}},
+	}))
+	require.NoError(t, err)
+	discoveredID := models.GenerateServerIDFromBMCEndpoint("dc-test-01", "bmc-01.dc.example.com:623")
+
+	// DNS names are case insensitive
+	resp, err := handler.RegisterServer(setupCustomerContext("test-customer"), connect.NewRequest(&managerv1.RegisterServerRequest{
+		ServerId:          "server-1",
+		DatacenterId:      "dc-test-01",
+		RegionalGatewayId: gateway.ID,
+		BmcProtocols: []*commonv1.BMCControlEndpoint{
+			{Endpoint: "BMC-01.DC.example.com.:623", Type: commonv1.BMCType_BMC_IPMI},
+		},
+		PrimaryProtocol: commonv1.BMCType_BMC_IPMI,
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Created)
+	assert.Equal(t, discoveredID, resp.Msg.ServerId)
+
+	server, err := handler.db.Servers.Get(context.Background(), discoveredID)
+	require.NoError(t, err)
+	assert.Equal(t, "bmc-01.dc.example.com:623", server.ControlEndpoints[0].Endpoint)
+}
+
+func TestAuthorizeAPIKey(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupTestCustomer(t, "")
+	require.NoError(t, handler.db.Customers.Create(context.Background(), customer))
+
+	ctx, err := handler.AuthorizeAPIKey(context.Background(), customer.APIKey)
+	require.NoError(t, err)
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	require.True(t, ok)
+	assert.Equal(t, customer.ID, claims.CustomerID)
+	assert.Equal(t, customer.ID, ctx.Value("customer_id"))
+
+	_, err = handler.AuthorizeAPIKey(context.Background(), "unknown-key")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	_, err = handler.AuthorizeAPIKey(context.Background(), "")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	customer.Disabled = true
+	require.NoError(t, handler.db.Customers.Update(context.Background(), customer))
+	_, err = handler.AuthorizeAPIKey(context.Background(), customer.APIKey)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestValidateSession(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetGatewayAccounts(map[string]string{"gateway-1": "gateway-1@example.com", "gateway-2": "gateway-2@example.com"})
+	ctx := withClaims(context.Background(), &models.AuthClaims{CustomerID: "gateway-1@example.com", Email: "gateway-1@example.com"})
+
+	customer := setupTestCustomer(t, "")
+	require.NoError(t, handler.db.Customers.Create(ctx, customer))
+	register := func(serverID, customerID, endpoint string) {
+		t.Helper()
+		_, err := handler.RegisterServer(setupCustomerContext(customerID), connect.NewRequest(&managerv1.RegisterServerRequest{
+			ServerId:          serverID,
+			DatacenterId:      "dc-test-01",
+			RegionalGatewayId: "gateway-1",
+			BmcProtocols: []*commonv1.BMCControlEndpoint{
+				{Endpoint: endpoint, Type: commonv1.BMCType_BMC_IPMI},
+			},
+			PrimaryProtocol: commonv1.BMCType_BMC_IPMI,
+		}))
+		require.NoError(t, err)
+	}
+	register("server-1", customer.ID, "192.168.1.100:623")
+	register("server-3", "user@example.com", "192.168.1.103:623")
+
+	validate := func(gatewayID, serverID, customerID string) *managerv1.ValidateSessionResponse {
+		t.Helper()
+		resp, err := handler.ValidateSession(ctx, connect.NewRequest(&managerv1.ValidateSessionRequest{
+			GatewayId:   gatewayID,
+			SessionId:   "sol-1",
+			ServerId:    serverID,
+			CustomerId:  customerID,
+			SessionType: "sol",
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	assert.True(t, validate("gateway-1", "server-1", customer.ID).Valid)
+	assert.True(t, validate("gateway-1", "server-3", "user@example.com").Valid, "customers without a record are not revoked")
+
+	// Only the gateway's account validates its sessions
+	_, err := handler.ValidateSession(ctx, connect.NewRequest(&managerv1.ValidateSessionRequest{
+		GatewayId: "gateway-2", SessionId: "sol-1", ServerId: "server-1", CustomerId: customer.ID,
+	}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = handler.ValidateSession(context.Background(), connect.NewRequest(&managerv1.ValidateSessionRequest{
+		GatewayId: "gateway-1", SessionId: "sol-1", ServerId: "server-1", CustomerId: customer.ID,
+	}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	resp := validate("gateway-1", "server-1", "user@example.com")
+	assert.False(t, resp.Valid)
+	assert.Equal(t, "server reassigned to another customer", resp.Reason)
+
+	location, err := handler.db.Locations.Get(ctx, "server-1")
+	require.NoError(t, err)
+	location.RegionalGatewayID = "gateway-2"
+	require.NoError(t, handler.db.Locations.Update(ctx, location))
+	resp = validate("gateway-1", "server-1", customer.ID)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, "server mapped to another gateway", resp.Reason)
+	location.RegionalGatewayID = "gateway-1"
+	require.NoError(t, handler.db.Locations.Update(ctx, location))
+
+	resp = validate("gateway-1", "server-2", customer.ID)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, "server no longer registered", resp.Reason)
+
+	customer.Disabled = true
+	require.NoError(t, handler.db.Customers.Update(ctx, customer))
+	resp = validate("gateway-1", "server-1", customer.ID)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, "customer access revoked", resp.Reason)
+
+	server, err := handler.db.Servers.Get(ctx, "server-1")
+	require.NoError(t, err)
+	server.Status = models.ServerStatusDisabled
+	require.NoError(t, handler.db.Servers.Update(ctx, server))
+	resp = validate("gateway-1", "server-1", "")
+	assert.False(t, resp.Valid)
+	assert.Equal(t, "server disabled", resp.Reason)
+
+	_, err = handler.ValidateSession(ctx, connect.NewRequest(&managerv1.ValidateSessionRequest{SessionId: "sol-1"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
 // TestDatabaseRoundTrip_PreservesSOLAndVNCEndpoints tests that SOL and VNC endpoints
 // are correctly serialized and deserialized through the database
 func TestDatabaseRoundTrip_PreservesSOLAndVNCEndpoints(t *testing.T) {
@@ -640,3 +997,35 @@ func TestDatabaseRoundTrip_PreservesSOLAndVNCEndpoints(t *testing.T) {
 	// Verify metadata was preserved
 	assert.Equal(t, server.Metadata["location"], retrieved.Metadata["location"])
 }
+
+func TestServerTokenPermissions(t *testing.T) {
+	server := &domain.Server{ID: "server-1", CustomerID: "owner@example.com"}
+
+	tests := []struct {
+		name            string
+		claims          *models.AuthClaims
+		wantDiag        bool
+		wantMaintenance bool
+	}{
+		{"owner", &models.AuthClaims{CustomerID: "owner@example.com"}, true, false},
+		{"admin", &models.AuthClaims{CustomerID: "admin@example.com", IsAdmin: true}, true, true},
+		{"other customer", &models.AuthClaims{CustomerID: "other@example.com"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions := serverTokenPermissions(tt.claims, server)
+			assert.Contains(t, permissions, "power:write")
+			if tt.wantDiag {
+				assert.Contains(t, permissions, "power:diag")
+			} else {
+				assert.NotContains(t, permissions, "power:diag")
+			}
+			if tt.wantMaintenance {
+				assert.Contains(t, permissions, "power:maintenance")
+			} else {
+				assert.NotContains(t, permissions, "power:maintenance")
+			}
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/models"
+)
+
+// defaultPasswordMinLength matches the customer_management.password_min_length
+// default
+const defaultPasswordMinLength = 8
+
+// maxPasswordBytes is the longest password bcrypt hashes
+const maxPasswordBytes = 72
+
+// defaultPasswordResetTTL matches the auth.password_reset_ttl default
+const defaultPasswordResetTTL = time.Hour
+
+// PasswordPolicy configures how customers authenticate with passwords
+type PasswordPolicy struct {
+	MinLength       int           // Shortest password accepted, in characters
+	MaxFailedLogins int           // Consecutive failures locking an account, 0 to never lock
+	LockoutDuration time.Duration // How long locked accounts are refused
+
+	// DemoAccounts lets accounts without a password authenticate with any
+	// password. Passwords meeting the policy are stored as the account's.
+	DemoAccounts bool
+}
+
+// SetPasswordPolicy sets how customers authenticate with passwords
+func (h *BMCManagerServiceHandler) SetPasswordPolicy(policy PasswordPolicy) {
+	h.passwordPolicy = policy
+}
+
+// SetPasswordResetTTL sets how long the password reset tokens issued to
+// customers remain valid
+func (h *AdminServiceHandler) SetPasswordResetTTL(ttl time.Duration) {
+	h.passwordResetTTL = ttl
+}
+
+// errInvalidCredentials does not tell unknown accounts from wrong passwords
+var errInvalidCredentials = connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid email or password"))
+
+// errAccountLocked is returned while an account is locked, even for the
+// right password
+var errAccountLocked = connect.NewError(connect.CodeResourceExhausted,
+	fmt.Errorf("account locked after repeated failed logins, try again later or ask an admin for a password reset"))
+
+// dummyPasswordHash is compared against when an account has no password, so
+// that unknown accounts take as long to reject as wrong passwords
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hash
+})
+
+// Authenticate verifies customer credentials and issues access tokens
+func (h *BMCManagerServiceHandler) Authenticate(
+	ctx context.Context,
+	req *connect.Request[managerv1.AuthenticateRequest],
+) (*connect.Response[managerv1.AuthenticateResponse], error) {
+	email := strings.TrimSpace(req.Msg.Email)
+	now := time.Now()
+
+	customer, err := h.db.Customers.GetByEmail(ctx, email)
+	if err != nil {
+		if err.Error() != "customer not found" {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+		}
+		customer = nil
+	}
+	if customer != nil && customer.LockedUntil.After(now) {
+		return nil, errAccountLocked
+	}
+
+	switch {
+	case customer != nil && customer.PasswordHash != "":
+		if bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(req.Msg.Password)) != nil {
+			if err := h.recordFailedLogin(ctx, customer, now); err != nil {
+				return nil, err
+			}
+			return nil, errInvalidCredentials
+		}
+	case h.passwordPolicy.DemoAccounts && email != "" && (customer == nil || !customer.Disabled):
+		customer, err = h.claimDemoAccount(ctx, customer, email, req.Msg.Password, now)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(req.Msg.Password))
+		return nil, errInvalidCredentials
+	}
+
+	// Disabled customers cannot authenticate
+	if customer.Disabled {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("customer access revoked"))
+	}
+
+	// Accounts with two-factor authentication also need a current code
+	if customer.TOTPEnabled {
+		if err := h.verifyLoginTOTP(ctx, customer, req.Msg.TotpCode, now); err != nil {
+			return nil, err
+		}
+	}
+	// Clear the failures, and save the step of the code so it can't be reused
+	if customer.FailedLogins > 0 || customer.TOTPEnabled {
+		customer.FailedLogins = 0
+		if err := h.db.Customers.Update(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+		}
+	}
+
+	// Check if user is admin
+	isAdmin := customer.IsAdmin || h.isAdminEmail(customer.Email)
+	if isAdmin {
+		log.Info().Str("email", customer.Email).Msg("Admin user authenticated")
+	}
+
+	accessToken, err := h.jwtManager.GenerateTokenWithMFA(&models.Customer{
+		ID:      customer.ID,
+		Email:   customer.Email,
+		IsAdmin: isAdmin,
+	}, customer.TOTPEnabled)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+	}
+
+	createdAt := customer.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	response := &managerv1.AuthenticateResponse{
+		AccessToken:  accessToken,
+		RefreshToken: "refresh_" + uuid.New().String(),
+		ExpiresAt:    timestamppb.New(now.Add(24 * time.Hour)),
+		Customer: &managerv1.Customer{
+			Id:        customer.ID,
+			Email:     customer.Email,
+			CreatedAt: timestamppb.New(createdAt),
+		},
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// claimDemoAccount authenticates a demo account without a password, the way
+// the manager did before storing credentials. The account is identified by
+// its email, and keeps the password as its own when it meets the policy:
+// accounts without a record get one, so that they are migrated by logging in
+// once. Shorter passwords keep authenticating the account without one.
+func (h *BMCManagerServiceHandler) claimDemoAccount(
+	ctx context.Context,
+	customer *models.Customer,
+	email, password string,
+	now time.Time,
+) (*models.Customer, error) {
+	if err := h.validatePassword(password); err != nil {
+		log.Warn().Str("email", email).Msg("Demo account authenticated without a password, password too weak to be stored")
+		if customer == nil {
+			customer = &models.Customer{ID: email, Email: email}
+		}
+		return customer, nil
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		apiKey, err := newAPIKey(defaultAPIKeyLength)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		customer = &models.Customer{
+			ID:                email,
+			Email:             email,
+			APIKey:            apiKey,
+			PasswordHash:      hash,
+			PasswordChangedAt: now,
+			CreatedAt:         now,
+		}
+		if err := h.db.Customers.Create(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create customer: %w", err))
+		}
+	} else {
+		customer.PasswordHash = hash
+		customer.PasswordChangedAt = now
+		if err := h.db.Customers.Update(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+		}
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("Demo account migrated to password authentication")
+	return customer, nil
+}
+
+// BootstrapAdmins sets the initial password of the admins without one, so
+// that the first admins can log in without demo accounts. Admins without a
+// record get one, identified by their email. Admins who already have a
+// password keep it, so the initial password may stay configured once they
+// changed theirs.
+func (h *BMCManagerServiceHandler) BootstrapAdmins(ctx context.Context, password string) error {
+	if err := h.validatePassword(password); err != nil {
+		return fmt.Errorf("initial admin password: %w", err)
+	}
+
+	now := time.Now()
+	for _, email := range h.adminEmails {
+		customer, err := h.db.Customers.GetByEmail(ctx, email)
+		if err != nil {
+			if err.Error() != "customer not found" {
+				return fmt.Errorf("failed to get admin %s: %w", email, err)
+			}
+			customer = nil
+		}
+		if customer != nil && customer.PasswordHash != "" {
+			continue
+		}
+
+		hash, err := hashPassword(password)
+		if err != nil {
+			return err
+		}
+		if customer == nil {
+			apiKey, err := newAPIKey(defaultAPIKeyLength)
+			if err != nil {
+				return err
+			}
+			customer = &models.Customer{
+				ID:                email,
+				Email:             email,
+				APIKey:            apiKey,
+				PasswordHash:      hash,
+				PasswordChangedAt: now,
+				CreatedAt:         now,
+			}
+			if err := h.db.Customers.Create(ctx, customer); err != nil {
+				return fmt.Errorf("failed to create admin %s: %w", email, err)
+			}
+		} else {
+			customer.PasswordHash = hash
+			customer.PasswordChangedAt = now
+			if err := h.db.Customers.Update(ctx, customer); err != nil {
+				return fmt.Errorf("failed to update admin %s: %w", email, err)
+			}
+		}
+
+		log.Info().Str("customer_id", customer.ID).Msg("Initial admin password set")
+	}
+	return nil
+}
+
+// recordFailedLogin counts a wrong password of an account, locking it once
+// the failures reach the policy's limit
+func (h *BMCManagerServiceHandler) recordFailedLogin(ctx context.Context, customer *models.Customer, now time.Time) error {
+	customer.FailedLogins++
+	if limit := h.passwordPolicy.MaxFailedLogins; limit > 0 && customer.FailedLogins >= limit {
+		customer.FailedLogins = 0
+		customer.LockedUntil = now.Add(h.passwordPolicy.LockoutDuration)
+		log.Warn().
+			Str("customer_id", customer.ID).
+			Time("locked_until", customer.LockedUntil).
+			Msg("Account locked after repeated failed logins")
+	}
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+	return nil
+}
+
+// ChangePassword sets the password of the authenticated customer. Accounts
+// with a password must confirm it, and those authenticated by email without
+// a record get one.
+func (h *BMCManagerServiceHandler) ChangePassword(
+	ctx context.Context,
+	req *connect.Request[managerv1.ChangePasswordRequest],
+) (*connect.Response[managerv1.ChangePasswordResponse], error) {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+	if err := h.validatePassword(req.Msg.NewPassword); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	customer, err := h.db.Customers.Get(ctx, claims.CustomerID)
+	if err != nil {
+		if err.Error() != "customer not found" {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+		}
+		customer = nil
+	}
+	if customer != nil {
+		if customer.LockedUntil.After(now) {
+			return nil, errAccountLocked
+		}
+		if customer.PasswordHash != "" &&
+			bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(req.Msg.CurrentPassword)) != nil {
+			if err := h.recordFailedLogin(ctx, customer, now); err != nil {
+				return nil, err
+			}
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("current password is incorrect"))
+		}
+	}
+
+	hash, err := hashPassword(req.Msg.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		apiKey, err := newAPIKey(defaultAPIKeyLength)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		customer = &models.Customer{
+			ID:        claims.CustomerID,
+			Email:     claims.Email,
+			APIKey:    apiKey,
+			CreatedAt: now,
+		}
+		setPassword(customer, hash, now)
+		if err := h.db.Customers.Create(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create customer: %w", err))
+		}
+	} else {
+		setPassword(customer, hash, now)
+		if err := h.db.Customers.Update(ctx, customer); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+		}
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("Password changed")
+	return connect.NewResponse(&managerv1.ChangePasswordResponse{}), nil
+}
+
+// ResetPassword sets the password of a customer with the reset token an
+// admin issued it, unlocking the account. Tokens are used once.
+func (h *BMCManagerServiceHandler) ResetPassword(
+	ctx context.Context,
+	req *connect.Request[managerv1.ResetPasswordRequest],
+) (*connect.Response[managerv1.ResetPasswordResponse], error) {
+	if err := h.validatePassword(req.Msg.NewPassword); err != nil {
+		return nil, err
+	}
+	invalidToken := connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid or expired reset token"))
+	now := time.Now()
+
+	customer, err := h.db.Customers.GetByEmail(ctx, strings.TrimSpace(req.Msg.Email))
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return nil, invalidToken
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	if customer.PasswordResetHash == "" || !now.Before(customer.PasswordResetExpiresAt) ||
+		subtle.ConstantTimeCompare([]byte(hashResetToken(req.Msg.ResetToken)), []byte(customer.PasswordResetHash)) != 1 {
+		return nil, invalidToken
+	}
+	if customer.Disabled {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("customer access revoked"))
+	}
+
+	hash, err := hashPassword(req.Msg.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	setPassword(customer, hash, now)
+	customer.LockedUntil = time.Time{}
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("Password reset")
+	return connect.NewResponse(&managerv1.ResetPasswordResponse{}), nil
+}
+
+// ResetCustomerPassword issues a one-time token letting a customer set a new
+// password with ResetPassword. The account is unlocked, and its password
+// keeps working until it is reset.
+func (h *AdminServiceHandler) ResetCustomerPassword(
+	ctx context.Context,
+	req *connect.Request[managerv1.ResetCustomerPasswordRequest],
+) (*connect.Response[managerv1.ResetCustomerPasswordResponse], error) {
+	log.Info().Str("customer_id", req.Msg.CustomerId).Msg("ResetCustomerPassword called")
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate reset token: %w", err))
+	}
+	resetToken := base64.RawURLEncoding.EncodeToString(token)
+	ttl := h.passwordResetTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+
+	customer.PasswordResetHash = hashResetToken(resetToken)
+	customer.PasswordResetExpiresAt = time.Now().Add(ttl)
+	customer.FailedLogins = 0
+	customer.LockedUntil = time.Time{}
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	// The audit log never holds reset tokens
+	h.audit(ctx, auditCustomerPasswordReset, "customer", customer.ID, nil)
+
+	return connect.NewResponse(&managerv1.ResetCustomerPasswordResponse{
+		CustomerId: customer.ID,
+		ResetToken: resetToken,
+		ExpiresAt:  timestamppb.New(customer.PasswordResetExpiresAt),
+	}), nil
+}
+
+// validatePassword checks a new password against the policy
+func (h *BMCManagerServiceHandler) validatePassword(password string) error {
+	minLength := h.passwordPolicy.MinLength
+	if minLength <= 0 {
+		minLength = defaultPasswordMinLength
+	}
+	if utf8.RuneCountInString(password) < minLength {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("password must be at least %d characters", minLength))
+	}
+	if len(password) > maxPasswordBytes {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("password must be at most %d bytes", maxPasswordBytes))
+	}
+	return nil
+}
+
+// setPassword stores a password hash, clearing failed logins and any
+// pending reset
+func setPassword(customer *models.Customer, hash string, now time.Time) {
+	customer.PasswordHash = hash
+	customer.PasswordChangedAt = now
+	customer.FailedLogins = 0
+	customer.PasswordResetHash = ""
+	customer.PasswordResetExpiresAt = time.Time{}
+}
+
+// hashPassword returns the bcrypt hash of a password
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to hash password: %w", err))
+	}
+	return string(hash), nil
+}
+
+// hashResetToken returns the SHA-256 of a reset token, as stored. Tokens are
+// random, so they need no slow hash.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
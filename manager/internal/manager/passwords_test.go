@@ -0,0 +1,234 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+// authenticate logs in with an email and password
+func authenticate(handler *BMCManagerServiceHandler, email, password string) (*connect.Response[managerv1.AuthenticateResponse], error) {
+	return handler.Authenticate(context.Background(), connect.NewRequest(&managerv1.AuthenticateRequest{Email: email, Password: password}))
+}
+
+// setupPasswordCustomer stores a customer with a password
+func setupPasswordCustomer(t *testing.T, handler *BMCManagerServiceHandler, id, password string) *models.Customer {
+	t.Helper()
+
+	customer := setupTestCustomer(t, id)
+	hash, err := hashPassword(password)
+	require.NoError(t, err)
+	customer.PasswordHash = hash
+	require.NoError(t, handler.db.Customers.Create(context.Background(), customer))
+	return customer
+}
+
+func TestAuthenticate_Password(t *testing.T) {
+	handler := setupTestHandler(t)
+	setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+
+	resp, err := authenticate(handler, "Customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-1", resp.Msg.Customer.Id, "customers with a record are identified by its ID")
+	claims, err := handler.jwtManager.ValidateToken(resp.Msg.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "customer-1", claims.CustomerID)
+
+	_, err = authenticate(handler, "customer-1@example.com", "wrong horse")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	t.Run("accounts without a password are rejected", func(t *testing.T) {
+		_, err := authenticate(handler, "unknown@example.com", "correct horse")
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+		require.NoError(t, handler.db.Customers.Create(context.Background(), setupTestCustomer(t, "customer-2")))
+		_, err = authenticate(handler, "customer-2@example.com", "correct horse")
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
+
+func TestAuthenticate_Lockout(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetPasswordPolicy(PasswordPolicy{MaxFailedLogins: 3, LockoutDuration: time.Hour})
+	setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+
+	// A successful login clears failures
+	for range 2 {
+		_, err := authenticate(handler, "customer-1@example.com", "wrong horse")
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	}
+	_, err := authenticate(handler, "customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err := authenticate(handler, "customer-1@example.com", "wrong horse")
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	}
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err), "locked accounts refuse the right password")
+
+	// Locks expire
+	customer, err := handler.db.Customers.Get(context.Background(), "customer-1")
+	require.NoError(t, err)
+	customer.LockedUntil = time.Now().Add(-time.Second)
+	require.NoError(t, handler.db.Customers.Update(context.Background(), customer))
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetPasswordPolicy(PasswordPolicy{MaxFailedLogins: 1, LockoutDuration: time.Hour})
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	setupPasswordCustomer(t, handler, "customer-1", "forgotten password")
+
+	_, err := authenticate(handler, "customer-1@example.com", "wrong password")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = authenticate(handler, "customer-1@example.com", "forgotten password")
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+	issued, err := admin.ResetCustomerPassword(ctx, connect.NewRequest(&managerv1.ResetCustomerPasswordRequest{CustomerId: "customer-1"}))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(defaultPasswordResetTTL), issued.Msg.ExpiresAt.AsTime(), time.Minute)
+
+	// Resets unlock the account, its password works until reset
+	_, err = authenticate(handler, "customer-1@example.com", "forgotten password")
+	require.NoError(t, err)
+
+	events, err := handler.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditCustomerPasswordReset})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Empty(t, events[0].Details, "the audit log never holds reset tokens")
+
+	reset := func(token, password string) error {
+		_, err := handler.ResetPassword(context.Background(), connect.NewRequest(&managerv1.ResetPasswordRequest{
+			Email:       "customer-1@example.com",
+			ResetToken:  token,
+			NewPassword: password,
+		}))
+		return err
+	}
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(reset(issued.Msg.ResetToken, "short")))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(reset("wrong-token", "new password")))
+	require.NoError(t, reset(issued.Msg.ResetToken, "new password"))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(reset(issued.Msg.ResetToken, "other password")), "tokens are used once")
+
+	_, err = authenticate(handler, "customer-1@example.com", "new password")
+	require.NoError(t, err)
+
+	t.Run("expired tokens are rejected", func(t *testing.T) {
+		admin.SetPasswordResetTTL(time.Nanosecond)
+		issued, err := admin.ResetCustomerPassword(ctx, connect.NewRequest(&managerv1.ResetCustomerPasswordRequest{CustomerId: "customer-1"}))
+		require.NoError(t, err)
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(reset(issued.Msg.ResetToken, "newer password")))
+	})
+
+	_, err = admin.ResetCustomerPassword(ctx, connect.NewRequest(&managerv1.ResetCustomerPasswordRequest{CustomerId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestChangePassword(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupPasswordCustomer(t, handler, "customer-1", "old password")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+
+	change := func(ctx context.Context, current, password string) error {
+		_, err := handler.ChangePassword(ctx, connect.NewRequest(&managerv1.ChangePasswordRequest{
+			CurrentPassword: current,
+			NewPassword:     password,
+		}))
+		return err
+	}
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(change(ctx, "wrong password", "new password")))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(change(ctx, "old password", "short")))
+	require.NoError(t, change(ctx, "old password", "new password"))
+
+	_, err := authenticate(handler, "customer-1@example.com", "old password")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = authenticate(handler, "customer-1@example.com", "new password")
+	require.NoError(t, err)
+
+	t.Run("accounts without a record get one", func(t *testing.T) {
+		ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "ops@acme.example", Email: "ops@acme.example"})
+		require.NoError(t, change(ctx, "", "a new password"))
+
+		_, err := authenticate(handler, "ops@acme.example", "a new password")
+		require.NoError(t, err)
+	})
+}
+
+func TestAuthenticate_DemoAccounts(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
+
+	// Passwords meeting the policy migrate the account
+	resp, err := authenticate(handler, "ops@acme.example", "first password")
+	require.NoError(t, err)
+	assert.Equal(t, "ops@acme.example", resp.Msg.Customer.Id)
+	customer, err := handler.db.Customers.Get(context.Background(), "ops@acme.example")
+	require.NoError(t, err)
+	assert.NotEmpty(t, customer.PasswordHash)
+
+	_, err = authenticate(handler, "ops@acme.example", "other password")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = authenticate(handler, "ops@acme.example", "first password")
+	require.NoError(t, err)
+
+	t.Run("short passwords are not stored", func(t *testing.T) {
+		_, err := authenticate(handler, "dev@acme.example", "dev")
+		require.NoError(t, err)
+		_, err = handler.db.Customers.Get(context.Background(), "dev@acme.example")
+		assert.EqualError(t, err, "customer not found")
+	})
+
+	t.Run("disabled accounts are rejected", func(t *testing.T) {
+		customer := setupTestCustomer(t, "customer-2")
+		customer.Disabled = true
+		require.NoError(t, handler.db.Customers.Create(context.Background(), customer))
+
+		_, err := authenticate(handler, "customer-2@example.com", "a long password")
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+		customer, err = handler.db.Customers.Get(context.Background(), "customer-2")
+		require.NoError(t, err)
+		assert.Empty(t, customer.PasswordHash)
+	})
+}
+
+func TestBootstrapAdmins(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.adminEmails = []string{"admin@example.com", "customer-1@example.com", "customer-2@example.com"}
+	require.NoError(t, handler.db.Customers.Create(context.Background(), setupTestCustomer(t, "customer-1")))
+	setupPasswordCustomer(t, handler, "customer-2", "own password")
+
+	require.Error(t, handler.BootstrapAdmins(context.Background(), "short"), "the password policy applies")
+	require.NoError(t, handler.BootstrapAdmins(context.Background(), "initial password"))
+
+	// Admins without a record get one, and those without a password the
+	// initial one
+	resp, err := authenticate(handler, "admin@example.com", "initial password")
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", resp.Msg.Customer.Id)
+	_, err = authenticate(handler, "customer-1@example.com", "initial password")
+	require.NoError(t, err)
+
+	// Admins keep the password they have
+	_, err = authenticate(handler, "customer-2@example.com", "initial password")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = authenticate(handler, "customer-2@example.com", "own password")
+	require.NoError(t, err)
+
+	// Bootstrapping again leaves the passwords alone
+	require.NoError(t, handler.BootstrapAdmins(context.Background(), "another password"))
+	_, err = authenticate(handler, "admin@example.com", "initial password")
+	require.NoError(t, err)
+}
@@ -0,0 +1,146 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestPowerUsageReport(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := context.Background()
+	handler.SetGatewayAccounts(map[string]string{"gw-1": "gw-1@example.com"})
+	gatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gw-1", Email: "gw-1@example.com"})
+
+	for _, s := range []struct{ id, customerID, datacenterID, endpoint string }{
+		{"srv-a", "cust-1", "dc-1", "https://10.0.0.1"},
+		{"srv-b", "cust-1", "dc-1", "https://10.0.0.2"},
+		{"srv-c", "cust-2", "dc-2", "https://10.0.0.3"},
+	} {
+		require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+			ID:               s.id,
+			CustomerID:       s.customerID,
+			DatacenterID:     s.datacenterID,
+			ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: s.endpoint, Type: types.BMCTypeRedfish}},
+			PrimaryProtocol:  types.BMCTypeRedfish,
+			Status:           "active",
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
+		}))
+	}
+
+	start := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+	at := func(minutes int) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(time.Duration(minutes) * time.Minute))
+	}
+
+	resp, err := handler.ReportPowerReadings(gatewayCtx, connect.NewRequest(&managerv1.ReportPowerReadingsRequest{
+		GatewayId: "gw-1",
+		Samples: []*managerv1.PowerSample{
+			// Integrated from power readings: 300 W for 30 minutes
+			{BmcEndpoint: "https://10.0.0.1", DatacenterId: "dc-1", SampledAt: at(0), ConsumedWatts: 300},
+			{BmcEndpoint: "https://10.0.0.1", DatacenterId: "dc-1", SampledAt: at(10), ConsumedWatts: 300},
+			{BmcEndpoint: "https://10.0.0.1", DatacenterId: "dc-1", SampledAt: at(20), ConsumedWatts: 300},
+			{BmcEndpoint: "https://10.0.0.1", DatacenterId: "dc-1", SampledAt: at(30), ConsumedWatts: 300},
+			// Metered by the BMC's energy counter
+			{BmcEndpoint: "https://10.0.0.2", DatacenterId: "dc-1", SampledAt: at(0), ConsumedWatts: 100, EnergyKwh: 50},
+			{BmcEndpoint: "https://10.0.0.2", DatacenterId: "dc-1", SampledAt: at(12), ConsumedWatts: 140, EnergyKwh: 50.04},
+			{BmcEndpoint: "https://10.0.0.3", DatacenterId: "dc-2", SampledAt: at(0), ConsumedWatts: 600},
+			{BmcEndpoint: "https://10.0.0.3", DatacenterId: "dc-2", SampledAt: at(15), ConsumedWatts: 600},
+			// Unknown servers and incomplete samples are skipped
+			{BmcEndpoint: "https://10.0.0.9", DatacenterId: "dc-1", SampledAt: at(0), ConsumedWatts: 900},
+			{BmcEndpoint: "https://10.0.0.1", DatacenterId: "dc-1", ConsumedWatts: 900},
+		},
+	}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Success)
+
+	report, err := admin.GetPowerUsageReport(ctx, connect.NewRequest(&managerv1.GetPowerUsageReportRequest{}))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 0.15+0.04+0.15, report.Msg.TotalEnergyKwh, 1e-9)
+	require.Len(t, report.Msg.Customers, 2)
+
+	customer := report.Msg.Customers[0]
+	assert.Equal(t, "cust-1", customer.CustomerId)
+	assert.Equal(t, int32(2), customer.ServerCount)
+	assert.InDelta(t, 0.19, customer.EnergyKwh, 1e-9)
+	assert.InDelta(t, 300+200, customer.AverageWatts, 1e-6)
+
+	require.Len(t, customer.Servers, 2)
+	assert.Equal(t, "srv-a", customer.Servers[0].ServerId)
+	assert.Equal(t, "dc-1", customer.Servers[0].DatacenterId)
+	assert.Equal(t, int64(4), customer.Servers[0].SampleCount)
+	assert.InDelta(t, 0.5, customer.Servers[0].SampledHours, 1e-9)
+	assert.Equal(t, 300.0, customer.Servers[0].PeakWatts)
+	assert.False(t, customer.Servers[0].Metered)
+	assert.Equal(t, "srv-b", customer.Servers[1].ServerId)
+	assert.True(t, customer.Servers[1].Metered)
+
+	assert.Equal(t, "cust-2", report.Msg.Customers[1].CustomerId)
+
+	t.Run("customer filter", func(t *testing.T) {
+		report, err := admin.GetPowerUsageReport(ctx, connect.NewRequest(&managerv1.GetPowerUsageReportRequest{
+			CustomerId: "cust-2",
+		}))
+		require.NoError(t, err)
+		require.Len(t, report.Msg.Customers, 1)
+		assert.InDelta(t, 0.15, report.Msg.TotalEnergyKwh, 1e-9)
+	})
+
+	t.Run("period", func(t *testing.T) {
+		report, err := admin.GetPowerUsageReport(ctx, connect.NewRequest(&managerv1.GetPowerUsageReportRequest{
+			StartTime: at(10),
+			EndTime:   at(30),
+		}))
+		require.NoError(t, err)
+		require.Len(t, report.Msg.Customers, 2)
+		assert.InDelta(t, 0.05, report.Msg.Customers[0].EnergyKwh, 1e-9)
+		assert.Equal(t, int64(2), report.Msg.Customers[0].Servers[0].SampleCount)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := admin.GetPowerUsageReport(ctx, connect.NewRequest(&managerv1.GetPowerUsageReportRequest{
+			StartTime: at(30),
+			EndTime:   at(10),
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestReportPowerReadings_RequiresGatewayID(t *testing.T) {
+	handler := setupTestHandler(t)
+
+	_, err := handler.ReportPowerReadings(context.Background(), connect.NewRequest(&managerv1.ReportPowerReadingsRequest{}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestReportPowerReadings_RequiresGatewayAccount(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetGatewayAccounts(map[string]string{"gw-1": "gw-1@example.com"})
+
+	report := func(ctx context.Context) error {
+		_, err := handler.ReportPowerReadings(ctx, connect.NewRequest(&managerv1.ReportPowerReadingsRequest{GatewayId: "gw-1"}))
+		return err
+	}
+
+	customerCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, "customer-1"))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(report(customerCtx)))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(report(context.Background())))
+
+	gatewayCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "gw-1", Email: "gw-1@example.com"})
+	assert.NoError(t, report(gatewayCtx))
+}
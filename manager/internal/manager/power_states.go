@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"manager/pkg/models"
+)
+
+const (
+	// powerQueryConcurrency bounds the power status queries in flight
+	powerQueryConcurrency = 16
+	// powerQueryTimeout bounds the power status query of each server, so that
+	// an unreachable gateway or BMC does not stall the listing
+	powerQueryTimeout = 5 * time.Second
+	// powerStateUnknown is the power state of servers that cannot be queried
+	powerStateUnknown = "unknown"
+)
+
+// serverPowerStates queries the power state of servers from their gateways
+// with server tokens of the caller, returning on, off, cycling or unknown by
+// server ID
+func (h *BMCManagerServiceHandler) serverPowerStates(
+	ctx context.Context,
+	claims *models.AuthClaims,
+	servers []*domain.Server,
+) map[string]string {
+	gateways := make(map[string]*models.RegionalGateway)
+	if list, err := h.db.Gateways.List(ctx); err != nil {
+		log.Warn().Err(err).Msg("Failed to list gateways for power states")
+	} else {
+		for _, gateway := range list {
+			gateways[gateway.ID] = gateway
+		}
+	}
+
+	customer := &models.Customer{ID: claims.CustomerID, Email: claims.Email}
+	states := make(map[string]string, len(servers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, powerQueryConcurrency)
+
+	for _, server := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(server *domain.Server) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			state := h.serverPowerState(ctx, claims, customer, server, gateways)
+			mu.Lock()
+			states[server.ID] = state
+			mu.Unlock()
+		}(server)
+	}
+	wg.Wait()
+
+	return states
+}
+
+// serverPowerState queries the power state of a server from the gateway
+// serving it
+func (h *BMCManagerServiceHandler) serverPowerState(
+	ctx context.Context,
+	claims *models.AuthClaims,
+	customer *models.Customer,
+	server *domain.Server,
+	gateways map[string]*models.RegionalGateway,
+) string {
+	ctx, cancel := context.WithTimeout(ctx, powerQueryTimeout)
+	defer cancel()
+
+	logger := log.With().Str("server_id", server.ID).Logger()
+
+	location, err := h.db.Locations.Get(ctx, server.ID)
+	if err != nil {
+		logger.Debug().Err(err).Msg("No location to query power state")
+		return powerStateUnknown
+	}
+	gateway, ok := gateways[location.RegionalGatewayID]
+	if !ok {
+		logger.Debug().Str("gateway_id", location.RegionalGatewayID).Msg("Unknown gateway to query power state")
+		return powerStateUnknown
+	}
+
+	token, err := h.jwtManager.GenerateServerToken(customer, server, serverTokenPermissions(claims, server))
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to generate server token to query power state")
+		return powerStateUnknown
+	}
+
+	resp, err := newGatewayClient(gateway.Endpoint, token).GetPowerStatus(ctx,
+		connect.NewRequest(&gatewayv1.PowerStatusRequest{ServerId: server.ID}))
+	if err != nil {
+		logger.Debug().Err(err).Str("gateway_id", gateway.ID).Msg("Failed to query power state")
+		return powerStateUnknown
+	}
+	return powerStateName(resp.Msg.State)
+}
+
+// powerStateName returns the name of a power state, e.g. on
+func powerStateName(state gatewayv1.PowerState) string {
+	return strings.ToLower(strings.TrimPrefix(state.String(), "POWER_STATE_"))
+}
@@ -0,0 +1,347 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"core/identity"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/inventory"
+	"manager/pkg/models"
+)
+
+// Actions of the rows of server imports
+const (
+	importActionCreate = "create"
+	importActionUpdate = "update"
+	importActionError  = "error"
+)
+
+// ImportServers registers the servers of an inventory file. Rows are
+// imported on their own: a rejected row is reported with its error and does
+// not stop the others. Dry runs report what would change without saving.
+func (h *AdminServiceHandler) ImportServers(
+	ctx context.Context,
+	req *connect.Request[managerv1.ImportServersRequest],
+) (*connect.Response[managerv1.ImportServersResponse], error) {
+	log.Info().
+		Str("format", req.Msg.Format).
+		Int("size", len(req.Msg.Data)).
+		Bool("dry_run", req.Msg.DryRun).
+		Msg("ImportServers called")
+
+	records, err := inventory.Parse(req.Msg.Data, req.Msg.Format)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	importer := &serverImporter{
+		h:         h,
+		dryRun:    req.Msg.DryRun,
+		customers: make(map[string]error),
+		gateways:  make(map[string]error),
+		serverIDs: make(map[string]int),
+		endpoints: make(map[string]int),
+	}
+
+	response := &managerv1.ImportServersResponse{
+		Results: make([]*managerv1.ServerImportResult, 0, len(records)),
+		DryRun:  req.Msg.DryRun,
+	}
+	for _, record := range records {
+		result := importer.importRecord(ctx, record)
+		switch result.Action {
+		case importActionCreate:
+			response.Created++
+		case importActionUpdate:
+			response.Updated++
+		default:
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	log.Info().
+		Int32("created", response.Created).
+		Int32("updated", response.Updated).
+		Int32("failed", response.Failed).
+		Bool("dry_run", req.Msg.DryRun).
+		Msg("Servers imported")
+
+	if !req.Msg.DryRun && response.Created+response.Updated > 0 {
+		h.audit(ctx, auditServerImport, "server", "", map[string]string{
+			"format":  req.Msg.Format,
+			"created": strconv.Itoa(int(response.Created)),
+			"updated": strconv.Itoa(int(response.Updated)),
+			"failed":  strconv.Itoa(int(response.Failed)),
+		})
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// serverImporter imports the rows of an inventory file, remembering the
+// customers and gateways already checked and the servers already imported
+type serverImporter struct {
+	h      *AdminServiceHandler
+	dryRun bool
+
+	customers map[string]error // Lookup error by customer ID, nil when it exists
+	gateways  map[string]error // Lookup error by gateway ID, nil when it exists
+	serverIDs map[string]int   // Line of the row importing each server ID
+	endpoints map[string]int   // Line of the row importing each datacenter BMC endpoint
+}
+
+// importRecord validates and saves one row, returning its result
+func (i *serverImporter) importRecord(ctx context.Context, record *inventory.Record) *managerv1.ServerImportResult {
+	result := &managerv1.ServerImportResult{
+		Line:     int32(record.Line),
+		ServerId: record.ServerID,
+	}
+
+	server, action, err := i.importServer(ctx, record)
+	if err != nil {
+		result.Action = importActionError
+		result.Error = err.Error()
+		log.Debug().Err(err).Int("line", record.Line).Str("server_id", record.ServerID).Msg("Rejected server import row")
+		return result
+	}
+	result.ServerId = server.ID
+	result.Action = action
+	return result
+}
+
+// importServer registers the server of a row like RegisterServer, for the
+// customer of the row. Existing servers keep the credentials left empty, so
+// that exports, which leave out passwords, can be imported back.
+func (i *serverImporter) importServer(ctx context.Context, record *inventory.Record) (*domain.Server, string, error) {
+	if err := record.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	endpoint := identity.NormalizeBMCEndpoint(record.BMCEndpoint)
+	endpointKey := record.DatacenterID + "/" + endpoint
+	if line, ok := i.serverIDs[record.ServerID]; ok {
+		return nil, "", fmt.Errorf("server %s is already imported on line %d", record.ServerID, line)
+	}
+	if line, ok := i.endpoints[endpointKey]; ok {
+		return nil, "", fmt.Errorf("BMC endpoint %s is already imported on line %d", endpoint, line)
+	}
+
+	if err := i.checkCustomer(ctx, record.CustomerID); err != nil {
+		return nil, "", err
+	}
+	if err := i.checkGateway(ctx, record.GatewayID); err != nil {
+		return nil, "", err
+	}
+
+	bmcType := types.BMCType(record.BMCType)
+	now := time.Now()
+	server := &domain.Server{
+		ID:           record.ServerID,
+		CustomerID:   record.CustomerID,
+		DatacenterID: record.DatacenterID,
+		ControlEndpoints: []*types.BMCControlEndpoint{{
+			Endpoint: endpoint,
+			Type:     bmcType,
+			Username: record.Username,
+			Password: record.Password,
+		}},
+		PrimaryProtocol: bmcType,
+		Features:        record.Features,
+		Status:          "active",
+		Metadata:        make(map[string]string),
+		ExternalID:      record.ExternalID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	existing, err := findRegisteredServer(ctx, i.h.db.Servers, server)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check existing server: %w", err)
+	}
+	setConsoleEndpoints(server)
+
+	action := importActionCreate
+	if existing != nil {
+		action = importActionUpdate
+		server.ID = existing.ID
+		server.Metadata = existing.Metadata
+		server.DiscoveryMetadata = existing.DiscoveryMetadata
+		server.MaintenanceMode = existing.MaintenanceMode
+		server.Notes = existing.Notes
+		server.CreatedAt = existing.CreatedAt
+		keepExistingEndpoints(server, existing)
+	}
+
+	i.serverIDs[record.ServerID] = record.Line
+	i.serverIDs[server.ID] = record.Line
+	i.endpoints[endpointKey] = record.Line
+	if i.dryRun {
+		return server, action, nil
+	}
+
+	if existing != nil {
+		err = i.h.db.Servers.Update(ctx, server)
+	} else {
+		err = i.h.db.Servers.Create(ctx, server)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to save server: %w", err)
+	}
+
+	location := &models.ServerLocation{
+		ServerID:          server.ID,
+		CustomerID:        server.CustomerID,
+		DatacenterID:      server.DatacenterID,
+		RegionalGatewayID: record.GatewayID,
+		ControlEndpoints:  server.ControlEndpoints,
+		PrimaryProtocol:   server.PrimaryProtocol,
+		Features:          server.Features,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := i.h.db.Locations.Upsert(ctx, location); err != nil {
+		return nil, "", fmt.Errorf("failed to save server location: %w", err)
+	}
+	return server, action, nil
+}
+
+// checkCustomer returns an error unless the customer of a row exists
+func (i *serverImporter) checkCustomer(ctx context.Context, customerID string) error {
+	err, checked := i.customers[customerID]
+	if !checked {
+		if _, err = i.h.db.Customers.Get(ctx, customerID); err != nil {
+			if err.Error() == "customer not found" {
+				err = fmt.Errorf("customer %s not found", customerID)
+			} else {
+				err = fmt.Errorf("failed to get customer %s: %w", customerID, err)
+			}
+		}
+		i.customers[customerID] = err
+	}
+	return err
+}
+
+// checkGateway returns an error unless the gateway of a row is registered
+// and approved
+func (i *serverImporter) checkGateway(ctx context.Context, gatewayID string) error {
+	err, checked := i.gateways[gatewayID]
+	if !checked {
+		gateway, getErr := i.h.db.Gateways.Get(ctx, gatewayID)
+		switch {
+		case getErr != nil:
+			err = fmt.Errorf("gateway %s not found", gatewayID)
+		case gateway.Status == models.GatewayStatusPending:
+			err = fmt.Errorf("gateway %s is pending approval", gatewayID)
+		}
+		i.gateways[gatewayID] = err
+	}
+	return err
+}
+
+// keepExistingEndpoints completes the import of an existing server with
+// what inventory files leave out: the credentials of the endpoints imported
+// without any, the control endpoints of its other protocols, and its
+// console endpoints on the same BMC
+func keepExistingEndpoints(server, existing *domain.Server) {
+	imported := server.ControlEndpoints[0]
+	for _, previous := range existing.ControlEndpoints {
+		if previous.Type != imported.Type {
+			server.ControlEndpoints = append(server.ControlEndpoints, previous)
+			continue
+		}
+		if previous.Endpoint == imported.Endpoint && imported.Username == "" && imported.Password == "" {
+			imported.Username = previous.Username
+			imported.Password = previous.Password
+			imported.Capabilities = previous.Capabilities
+		}
+	}
+
+	if server.SOLEndpoint != nil && existing.SOLEndpoint != nil {
+		server.SOLEndpoint = existing.SOLEndpoint
+	}
+	if server.VNCEndpoint != nil && existing.VNCEndpoint != nil {
+		server.VNCEndpoint = existing.VNCEndpoint
+	}
+}
+
+// ExportServers returns the servers as an inventory file that
+// ImportServers reads back, without their passwords
+func (h *AdminServiceHandler) ExportServers(
+	ctx context.Context,
+	req *connect.Request[managerv1.ExportServersRequest],
+) (*connect.Response[managerv1.ExportServersResponse], error) {
+	log.Info().
+		Str("format", req.Msg.Format).
+		Str("customer_filter", req.Msg.CustomerFilter).
+		Msg("ExportServers called")
+
+	if format := req.Msg.Format; format != "" && format != inventory.FormatCSV && format != inventory.FormatYAML {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("format must be %s or %s", inventory.FormatCSV, inventory.FormatYAML))
+	}
+
+	var servers []*domain.Server
+	var err error
+	if req.Msg.CustomerFilter != "" {
+		servers, err = h.db.Servers.List(ctx, req.Msg.CustomerFilter)
+	} else {
+		servers, err = h.db.Servers.ListAll(ctx)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list servers")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list servers: %w", err))
+	}
+
+	locations, err := h.db.Locations.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list server locations")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list server locations: %w", err))
+	}
+	gatewayIDs := make(map[string]string, len(locations))
+	for _, location := range locations {
+		gatewayIDs[location.ServerID] = location.RegionalGatewayID
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].ID < servers[j].ID
+	})
+
+	records := make([]*inventory.Record, 0, len(servers))
+	for _, server := range servers {
+		record := &inventory.Record{
+			ServerID:     server.ID,
+			CustomerID:   server.CustomerID,
+			DatacenterID: server.DatacenterID,
+			GatewayID:    gatewayIDs[server.ID],
+			Features:     server.Features,
+			ExternalID:   server.ExternalID,
+		}
+		if primary := server.GetPrimaryControlEndpoint(); primary != nil {
+			record.BMCType = string(primary.Type)
+			record.BMCEndpoint = primary.Endpoint
+			record.Username = primary.Username
+		}
+		records = append(records, record)
+	}
+
+	data, contentType, err := inventory.Write(records, req.Msg.Format)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export servers")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to export servers: %w", err))
+	}
+
+	return connect.NewResponse(&managerv1.ExportServersResponse{
+		ContentType: contentType,
+		Data:        data,
+		Count:       int32(len(records)),
+	}), nil
+}
@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/inventory"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestImportServers(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, id := range []string{"customer-1", "customer-2"} {
+		require.NoError(t, handler.db.Customers.Create(ctx, setupTestCustomer(t, id)))
+	}
+	for _, gateway := range []*models.RegionalGateway{
+		{ID: "gw-1", Region: "us-east-1", Endpoint: "http://gw-1", Status: "active", LastSeen: now, CreatedAt: now},
+		{ID: "gw-pending", Region: "us-east-1", Endpoint: "http://gw-2", Status: models.GatewayStatusPending, LastSeen: now, CreatedAt: now},
+	} {
+		require.NoError(t, handler.db.Gateways.Create(ctx, gateway))
+	}
+
+	// An existing server, whose credentials are not in the file
+	require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+		ID:           "server-2",
+		CustomerID:   "customer-1",
+		DatacenterID: "dc-1",
+		ControlEndpoints: []*types.BMCControlEndpoint{
+			{Endpoint: "192.168.1.20:623", Type: types.BMCTypeIPMI, Username: "admin", Password: "secret"},
+		},
+		PrimaryProtocol: types.BMCTypeIPMI,
+		Notes:           "rack 12",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}))
+
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	data := []byte(`server_id,customer_id,datacenter_id,gateway_id,bmc_type,bmc_endpoint,username,password,features
+server-1,customer-1,dc-1,gw-1,ipmi,192.168.1.10:623,admin,pass,power;console
+server-2,customer-2,dc-1,gw-1,ipmi,192.168.1.20:623,,,power
+server-3,customer-9,dc-1,gw-1,ipmi,192.168.1.30:623,,,
+server-4,customer-1,dc-1,gw-pending,ipmi,192.168.1.40:623,,,
+server-5,customer-1,dc-1,gw-1,snmp,192.168.1.50:623,,,
+server-6,customer-1,dc-1,gw-1,ipmi,192.168.1.10:623,,,
+`)
+
+	t.Run("dry run", func(t *testing.T) {
+		resp, err := admin.ImportServers(adminCtx, connect.NewRequest(&managerv1.ImportServersRequest{Data: data, DryRun: true}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.DryRun)
+		assert.Equal(t, int32(1), resp.Msg.Created)
+		assert.Equal(t, int32(1), resp.Msg.Updated)
+		assert.Equal(t, int32(4), resp.Msg.Failed)
+
+		_, err = handler.db.Servers.Get(ctx, "server-1")
+		assert.Error(t, err, "dry runs should not save servers")
+	})
+
+	resp, err := admin.ImportServers(adminCtx, connect.NewRequest(&managerv1.ImportServersRequest{Data: data}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Results, 6)
+
+	expected := []struct {
+		line   int32
+		action string
+		error  string
+	}{
+		{2, "create", ""},
+		{3, "update", ""},
+		{4, "error", "customer customer-9 not found"},
+		{5, "error", "gateway gw-pending is pending approval"},
+		{6, "error", `invalid bmc_type "snmp", expected ipmi or redfish`},
+		{7, "error", "BMC endpoint 192.168.1.10:623 is already imported on line 2"},
+	}
+	for i, want := range expected {
+		result := resp.Msg.Results[i]
+		assert.Equal(t, want.line, result.Line)
+		assert.Equal(t, want.action, result.Action, result.ServerId)
+		assert.Equal(t, want.error, result.Error, result.ServerId)
+	}
+
+	created, err := handler.db.Servers.Get(ctx, "server-1")
+	require.NoError(t, err)
+	require.NotNil(t, created.SOLEndpoint)
+	assert.Equal(t, "pass", created.ControlEndpoints[0].Password)
+	location, err := handler.db.Locations.Get(ctx, "server-1")
+	require.NoError(t, err)
+	assert.Equal(t, "gw-1", location.RegionalGatewayID)
+
+	updated, err := handler.db.Servers.Get(ctx, "server-2")
+	require.NoError(t, err)
+	assert.Equal(t, "customer-2", updated.CustomerID)
+	assert.Equal(t, "secret", updated.ControlEndpoints[0].Password, "empty credentials should keep the existing ones")
+	assert.Equal(t, "rack 12", updated.Notes)
+
+	events, err := handler.db.AuditEvents.List(ctx, database.AuditEventFilter{Action: auditServerImport})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "admin@example.com", events[0].Actor)
+	assert.Equal(t, "4", events[0].Details["failed"])
+
+	_, err = admin.ImportServers(adminCtx, connect.NewRequest(&managerv1.ImportServersRequest{Data: data, Format: "xml"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestExportServers(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, server := range []*domain.Server{
+		{ID: "server-2", CustomerID: "customer-2", DatacenterID: "dc-1", PrimaryProtocol: types.BMCTypeRedfish,
+			ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "https://10.0.0.5", Type: types.BMCTypeRedfish, Username: "root", Password: "secret"}}},
+		{ID: "server-1", CustomerID: "customer-1", DatacenterID: "dc-1", PrimaryProtocol: types.BMCTypeIPMI, Features: []string{"power", "console"},
+			ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "192.168.1.10:623", Type: types.BMCTypeIPMI, Username: "admin", Password: "pass"}}},
+	} {
+		server.CreatedAt, server.UpdatedAt = now, now
+		require.NoError(t, handler.db.Servers.Create(ctx, server))
+		require.NoError(t, handler.db.Locations.Create(ctx, &models.ServerLocation{
+			ServerID: server.ID, CustomerID: server.CustomerID, DatacenterID: "dc-1", RegionalGatewayID: "gw-1",
+			PrimaryProtocol: server.PrimaryProtocol, CreatedAt: now, UpdatedAt: now,
+		}))
+	}
+
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	resp, err := admin.ExportServers(adminCtx, connect.NewRequest(&managerv1.ExportServersRequest{Format: inventory.FormatYAML}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Msg.Count)
+	assert.Equal(t, "application/yaml", resp.Msg.ContentType)
+	assert.NotContains(t, string(resp.Msg.Data), "secret", "passwords should not be exported")
+
+	records, err := inventory.Parse(resp.Msg.Data, inventory.FormatYAML)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "server-1", records[0].ServerID)
+	assert.Equal(t, "gw-1", records[0].GatewayID)
+	assert.Equal(t, "ipmi", records[0].BMCType)
+	assert.Equal(t, "admin", records[0].Username)
+	assert.Equal(t, []string{"power", "console"}, records[0].Features)
+	assert.NoError(t, records[1].Validate())
+
+	resp, err = admin.ExportServers(adminCtx, connect.NewRequest(&managerv1.ExportServersRequest{CustomerFilter: "customer-2"}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Msg.Count)
+	assert.Equal(t, "text/csv", resp.Msg.ContentType)
+
+	_, err = admin.ExportServers(adminCtx, connect.NewRequest(&managerv1.ExportServersRequest{Format: "xml"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
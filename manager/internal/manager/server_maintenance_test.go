@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+)
+
+func TestSetServerMaintenanceAndNotes(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := context.Background()
+
+	require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+		ID:               "server-1",
+		CustomerID:       "test-customer",
+		DatacenterID:     "dc-test-01",
+		ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI}},
+		PrimaryProtocol:  types.BMCTypeIPMI,
+		Status:           "active",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}))
+
+	maintenanceResp, err := admin.SetServerMaintenance(ctx, connect.NewRequest(&managerv1.SetServerMaintenanceRequest{
+		ServerId:        "server-1",
+		MaintenanceMode: true,
+	}))
+	require.NoError(t, err)
+	assert.True(t, maintenanceResp.Msg.Server.MaintenanceMode)
+
+	notesResp, err := admin.SetServerNotes(ctx, connect.NewRequest(&managerv1.SetServerNotesRequest{
+		ServerId: "server-1",
+		Notes:    "PSU replacement scheduled",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "PSU replacement scheduled", notesResp.Msg.Server.Notes)
+	assert.True(t, notesResp.Msg.Server.MaintenanceMode)
+
+	authCtx := setupAuthenticatedContext(t, handler, setupTestCustomer(t, ""))
+	getResp, err := handler.GetServer(authCtx, connect.NewRequest(&managerv1.GetServerRequest{ServerId: "server-1"}))
+	require.NoError(t, err)
+	assert.True(t, getResp.Msg.Server.MaintenanceMode)
+	assert.Equal(t, "PSU replacement scheduled", getResp.Msg.Server.Notes)
+
+	// Server tokens tell gateways the server is in maintenance
+	tokenResp, err := handler.GetServerToken(authCtx, connect.NewRequest(&managerv1.GetServerTokenRequest{ServerId: "server-1"}))
+	require.NoError(t, err)
+	_, serverContext, err := handler.jwtManager.ValidateServerToken(tokenResp.Msg.Token)
+	require.NoError(t, err)
+	assert.True(t, serverContext.Maintenance)
+	assert.NotContains(t, serverContext.Permissions, "power:maintenance")
+
+	t.Run("unknown server", func(t *testing.T) {
+		_, err := admin.SetServerMaintenance(ctx, connect.NewRequest(&managerv1.SetServerMaintenanceRequest{ServerId: "server-2"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("missing server ID", func(t *testing.T) {
+		_, err := admin.SetServerNotes(ctx, connect.NewRequest(&managerv1.SetServerNotesRequest{Notes: "notes"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("notes too long", func(t *testing.T) {
+		_, err := admin.SetServerNotes(ctx, connect.NewRequest(&managerv1.SetServerNotesRequest{
+			ServerId: "server-1",
+			Notes:    strings.Repeat("x", maxServerNotesLength+1),
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	coreauth "core/auth"
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/models"
+)
+
+// SetSessionQuota sets the default console session limits of customers: the
+// sessions open at once and created per minute on a gateway, 0 for no limit
+func (h *BMCManagerServiceHandler) SetSessionQuota(maxConcurrent, perMinute int) {
+	h.sessionQuota = coreauth.SessionQuota{MaxConcurrent: maxConcurrent, PerMinute: perMinute}
+}
+
+// customerSessionQuota returns the console session limits of a customer for
+// its server tokens, nil when it has none
+func (h *BMCManagerServiceHandler) customerSessionQuota(ctx context.Context, customerID string) (*coreauth.SessionQuota, error) {
+	customer, err := h.db.Customers.Get(ctx, customerID)
+	if err != nil {
+		if err.Error() != "customer not found" {
+			return nil, err
+		}
+		// Customers authenticated by other means get the defaults
+		customer = &models.Customer{ID: customerID}
+	}
+
+	quota := &coreauth.SessionQuota{
+		CustomerID:    customerID,
+		MaxConcurrent: sessionLimit(customer.MaxConcurrentSessions, h.sessionQuota.MaxConcurrent),
+		PerMinute:     sessionLimit(customer.MaxSessionsPerMinute, h.sessionQuota.PerMinute),
+	}
+	if quota.MaxConcurrent == 0 && quota.PerMinute == 0 {
+		return nil, nil
+	}
+	return quota, nil
+}
+
+// sessionLimit resolves a console session limit of a customer against the
+// default, 0 for no limit
+func sessionLimit(limit, defaultLimit int) int {
+	switch {
+	case limit == models.NoSessionLimit:
+		return 0
+	case limit > 0:
+		return limit
+	default:
+		return defaultLimit
+	}
+}
+
+// SetCustomerSessionQuota sets the console session limits of a customer.
+// Server tokens issued afterwards carry them.
+func (h *AdminServiceHandler) SetCustomerSessionQuota(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetCustomerSessionQuotaRequest],
+) (*connect.Response[managerv1.SetCustomerSessionQuotaResponse], error) {
+	log.Info().
+		Str("customer_id", req.Msg.CustomerId).
+		Int32("max_concurrent_sessions", req.Msg.MaxConcurrentSessions).
+		Int32("max_sessions_per_minute", req.Msg.MaxSessionsPerMinute).
+		Msg("SetCustomerSessionQuota called")
+
+	if req.Msg.CustomerId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("customer_id is required"))
+	}
+	if req.Msg.MaxConcurrentSessions < models.NoSessionLimit || req.Msg.MaxSessionsPerMinute < models.NoSessionLimit {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("session limits must be positive, 0 for the default or %d for no limit", models.NoSessionLimit))
+	}
+
+	customer, err := h.db.Customers.Get(ctx, req.Msg.CustomerId)
+	if err != nil {
+		if err.Error() == "customer not found" {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("customer not found: %s", req.Msg.CustomerId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+
+	customer.MaxConcurrentSessions = int(req.Msg.MaxConcurrentSessions)
+	customer.MaxSessionsPerMinute = int(req.Msg.MaxSessionsPerMinute)
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+	h.audit(ctx, auditCustomerSessionQuota, "customer", customer.ID, map[string]string{
+		"max_concurrent_sessions": strconv.Itoa(customer.MaxConcurrentSessions),
+		"max_sessions_per_minute": strconv.Itoa(customer.MaxSessionsPerMinute),
+	})
+
+	return connect.NewResponse(&managerv1.SetCustomerSessionQuotaResponse{
+		CustomerId:            customer.ID,
+		MaxConcurrentSessions: int32(customer.MaxConcurrentSessions),
+		MaxSessionsPerMinute:  int32(customer.MaxSessionsPerMinute),
+	}), nil
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreauth "core/auth"
+	"core/domain"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestCustomerSessionQuota(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetSessionQuota(10, 20)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	ctx := context.Background()
+
+	customer := setupTestCustomer(t, "")
+	require.NoError(t, handler.db.Customers.Create(ctx, customer))
+	require.NoError(t, handler.db.Servers.Create(ctx, &domain.Server{
+		ID:               "server-1",
+		CustomerID:       customer.ID,
+		DatacenterID:     "dc-test-01",
+		ControlEndpoints: []*types.BMCControlEndpoint{{Endpoint: "192.168.1.100:623", Type: types.BMCTypeIPMI}},
+		PrimaryProtocol:  types.BMCTypeIPMI,
+		Status:           "active",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}))
+	authCtx := setupAuthenticatedContext(t, handler, customer)
+
+	// tokenQuota returns the session quota of a server token of the customer
+	tokenQuota := func() *coreauth.SessionQuota {
+		resp, err := handler.GetServerToken(authCtx, connect.NewRequest(&managerv1.GetServerTokenRequest{ServerId: "server-1"}))
+		require.NoError(t, err)
+		_, serverContext, err := handler.jwtManager.ValidateServerToken(resp.Msg.Token)
+		require.NoError(t, err)
+		return serverContext.SessionQuota
+	}
+
+	assert.Equal(t, &coreauth.SessionQuota{CustomerID: customer.ID, MaxConcurrent: 10, PerMinute: 20}, tokenQuota())
+
+	resp, err := admin.SetCustomerSessionQuota(ctx, connect.NewRequest(&managerv1.SetCustomerSessionQuotaRequest{
+		CustomerId:            customer.ID,
+		MaxConcurrentSessions: 3,
+		MaxSessionsPerMinute:  models.NoSessionLimit,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Msg.MaxConcurrentSessions)
+	assert.Equal(t, &coreauth.SessionQuota{CustomerID: customer.ID, MaxConcurrent: 3}, tokenQuota())
+
+	listResp, err := admin.ListAllCustomers(ctx, connect.NewRequest(&managerv1.ListAllCustomersRequest{}))
+	require.NoError(t, err)
+	require.Len(t, listResp.Msg.Customers, 1)
+	assert.Equal(t, int32(3), listResp.Msg.Customers[0].MaxConcurrentSessions)
+	assert.Equal(t, int32(models.NoSessionLimit), listResp.Msg.Customers[0].MaxSessionsPerMinute)
+
+	// No limit at all leaves the quota out of the token
+	_, err = admin.SetCustomerSessionQuota(ctx, connect.NewRequest(&managerv1.SetCustomerSessionQuotaRequest{
+		CustomerId:            customer.ID,
+		MaxConcurrentSessions: models.NoSessionLimit,
+		MaxSessionsPerMinute:  models.NoSessionLimit,
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, tokenQuota())
+
+	t.Run("invalid limits", func(t *testing.T) {
+		_, err := admin.SetCustomerSessionQuota(ctx, connect.NewRequest(&managerv1.SetCustomerSessionQuotaRequest{
+			CustomerId:            customer.ID,
+			MaxConcurrentSessions: -2,
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("unknown customer", func(t *testing.T) {
+		_, err := admin.SetCustomerSessionQuota(ctx, connect.NewRequest(&managerv1.SetCustomerSessionQuotaRequest{CustomerId: "customer-2"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
@@ -14,6 +14,8 @@ import (
 
 func TestAuthenticate_TokenTimezoneConsistency(t *testing.T) {
 	handler := setupTestHandler(t)
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
@@ -48,6 +50,8 @@ func TestAuthenticate_TokenTimezoneConsistency(t *testing.T) {
 
 func TestAuthenticate_TokenNotExpiredImmediately(t *testing.T) {
 	handler := setupTestHandler(t)
+	// Accounts authenticated by email, without a password
+	handler.SetPasswordPolicy(PasswordPolicy{DemoAccounts: true})
 
 	// Test authentication
 	req := connect.NewRequest(&managerv1.AuthenticateRequest{
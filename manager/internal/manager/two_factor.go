@@ -0,0 +1,216 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/pkg/auth"
+	"manager/pkg/models"
+)
+
+// errTOTPCodeRequired is returned when the password of an account with
+// two-factor authentication is right but no code was given, so that clients
+// prompt for one
+var errTOTPCodeRequired = connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("two-factor code required"))
+
+// errInvalidTOTPCode is returned for wrong, expired and reused codes
+var errInvalidTOTPCode = connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid two-factor code"))
+
+// verifyLoginTOTP checks the code of a login to an account with two-factor
+// authentication, counting wrong codes as failed logins
+func (h *BMCManagerServiceHandler) verifyLoginTOTP(ctx context.Context, customer *models.Customer, code string, now time.Time) error {
+	if strings.TrimSpace(code) == "" {
+		return errTOTPCodeRequired
+	}
+	step, ok := auth.VerifyTOTP(customer.TOTPSecret, code, now, customer.TOTPLastStep)
+	if !ok {
+		if err := h.recordFailedLogin(ctx, customer, now); err != nil {
+			return err
+		}
+		return errInvalidTOTPCode
+	}
+	customer.TOTPLastStep = step
+	return nil
+}
+
+// EnrollTOTP generates a TOTP secret for the authenticated customer, pending
+// until ConfirmTOTP enables it. Accounts need a password first.
+func (h *BMCManagerServiceHandler) EnrollTOTP(
+	ctx context.Context,
+	req *connect.Request[managerv1.EnrollTOTPRequest],
+) (*connect.Response[managerv1.EnrollTOTPResponse], error) {
+	customer, err := h.twoFactorCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if customer.TOTPEnabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("two-factor authentication is already enabled, disable it to enroll again"))
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	customer.TOTPSecret = secret
+	customer.TOTPLastStep = 0
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("TOTP enrollment started")
+	return connect.NewResponse(&managerv1.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUrl: auth.TOTPURL(secret, customer.Email),
+	}), nil
+}
+
+// ConfirmTOTP enables the pending TOTP secret of the authenticated customer
+// with a code of the authenticator app. Wrong codes count as failed logins,
+// so that the code cannot be guessed while the enrollment is pending.
+func (h *BMCManagerServiceHandler) ConfirmTOTP(
+	ctx context.Context,
+	req *connect.Request[managerv1.ConfirmTOTPRequest],
+) (*connect.Response[managerv1.ConfirmTOTPResponse], error) {
+	customer, err := h.twoFactorCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if customer.TOTPEnabled || customer.TOTPSecret == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("no pending two-factor enrollment, start one with EnrollTOTP"))
+	}
+
+	now := time.Now()
+	if customer.LockedUntil.After(now) {
+		return nil, errAccountLocked
+	}
+
+	step, ok := auth.VerifyTOTP(customer.TOTPSecret, req.Msg.Code, now, customer.TOTPLastStep)
+	if !ok {
+		if err := h.recordFailedLogin(ctx, customer, now); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid two-factor code"))
+	}
+	customer.TOTPEnabled = true
+	customer.TOTPLastStep = step
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("Two-factor authentication enabled")
+	return connect.NewResponse(&managerv1.ConfirmTOTPResponse{}), nil
+}
+
+// DisableTOTP disables two-factor authentication of the authenticated
+// customer with a current code, unless its policy requires it
+func (h *BMCManagerServiceHandler) DisableTOTP(
+	ctx context.Context,
+	req *connect.Request[managerv1.DisableTOTPRequest],
+) (*connect.Response[managerv1.DisableTOTPResponse], error) {
+	customer, err := h.twoFactorCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.TOTPEnabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("two-factor authentication is not enabled"))
+	}
+	if customer.RequireMFA {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("two-factor authentication is required for this account, ask an admin to reset it"))
+	}
+	now := time.Now()
+	if customer.LockedUntil.After(now) {
+		return nil, errAccountLocked
+	}
+
+	if _, ok := auth.VerifyTOTP(customer.TOTPSecret, req.Msg.Code, now, customer.TOTPLastStep); !ok {
+		if err := h.recordFailedLogin(ctx, customer, now); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("invalid two-factor code"))
+	}
+	clearTOTP(customer)
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+
+	log.Info().Str("customer_id", customer.ID).Msg("Two-factor authentication disabled")
+	return connect.NewResponse(&managerv1.DisableTOTPResponse{}), nil
+}
+
+// twoFactorCustomer returns the authenticated customer for two-factor
+// enrollment, which needs an account with a password
+func (h *BMCManagerServiceHandler) twoFactorCustomer(ctx context.Context) (*models.Customer, error) {
+	claims, ok := ctx.Value("claims").(*models.AuthClaims)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get auth claims"))
+	}
+
+	customer, err := h.db.Customers.Get(ctx, claims.CustomerID)
+	if err != nil && err.Error() != "customer not found" {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get customer: %w", err))
+	}
+	if err != nil || customer.PasswordHash == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("set a password before enabling two-factor authentication"))
+	}
+	return customer, nil
+}
+
+// SetCustomerMFAPolicy sets whether the console sessions of a customer
+// require two-factor authentication, and resets its authenticator on demand
+func (h *AdminServiceHandler) SetCustomerMFAPolicy(
+	ctx context.Context,
+	req *connect.Request[managerv1.SetCustomerMFAPolicyRequest],
+) (*connect.Response[managerv1.SetCustomerMFAPolicyResponse], error) {
+	log.Info().
+		Str("customer_id", req.Msg.CustomerId).
+		Bool("require_mfa", req.Msg.RequireMfa).
+		Bool("reset_totp", req.Msg.ResetTotp).
+		Msg("SetCustomerMFAPolicy called")
+
+	customer, err := h.getCustomer(ctx, req.Msg.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	customer.RequireMFA = req.Msg.RequireMfa
+	if req.Msg.ResetTotp {
+		clearTOTP(customer)
+	}
+	if err := h.db.Customers.Update(ctx, customer); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update customer: %w", err))
+	}
+	h.audit(ctx, auditCustomerMFAPolicy, "customer", customer.ID, map[string]string{
+		"require_mfa": strconv.FormatBool(customer.RequireMFA),
+		"reset_totp":  strconv.FormatBool(req.Msg.ResetTotp),
+	})
+
+	return connect.NewResponse(&managerv1.SetCustomerMFAPolicyResponse{
+		CustomerId:  customer.ID,
+		RequireMfa:  customer.RequireMFA,
+		TotpEnabled: customer.TOTPEnabled,
+	}), nil
+}
+
+// clearTOTP removes the TOTP secret of a customer, enrolled or pending
+func clearTOTP(customer *models.Customer) {
+	customer.TOTPSecret = ""
+	customer.TOTPEnabled = false
+	customer.TOTPLastStep = 0
+}
+
+// consoleMFAClaims returns the two-factor claims of the server tokens of a
+// customer: whether it logged in with a second factor, and whether its
+// policy requires one to open consoles
+func consoleMFAClaims(claims *models.AuthClaims, customer *models.Customer) auth.MFAClaims {
+	return auth.MFAClaims{Verified: claims.MFA, Required: customer.RequireMFA}
+}
@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/slo"
+	"manager/pkg/auth"
+	"manager/pkg/models"
+)
+
+// enrollTOTP enables two-factor authentication of a customer, and returns
+// its secret
+func enrollTOTP(t *testing.T, handler *BMCManagerServiceHandler, ctx context.Context) string {
+	t.Helper()
+
+	enrolled, err := handler.EnrollTOTP(ctx, connect.NewRequest(&managerv1.EnrollTOTPRequest{}))
+	require.NoError(t, err)
+	code, err := auth.TOTPCode(enrolled.Msg.Secret, auth.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	_, err = handler.ConfirmTOTP(ctx, connect.NewRequest(&managerv1.ConfirmTOTPRequest{Code: code}))
+	require.NoError(t, err)
+	return enrolled.Msg.Secret
+}
+
+func TestAuthenticate_TOTP(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+
+	_, err := handler.ConfirmTOTP(ctx, connect.NewRequest(&managerv1.ConfirmTOTPRequest{Code: "123456"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "nothing to confirm before enrolling")
+
+	enrolled, err := handler.EnrollTOTP(ctx, connect.NewRequest(&managerv1.EnrollTOTPRequest{}))
+	require.NoError(t, err)
+	assert.Contains(t, enrolled.Msg.OtpauthUrl, "secret="+enrolled.Msg.Secret)
+	_, err = handler.ConfirmTOTP(ctx, connect.NewRequest(&managerv1.ConfirmTOTPRequest{Code: "000000"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Pending enrollments don't affect logins
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+
+	secret := enrollTOTP(t, handler, ctx)
+	_, err = handler.EnrollTOTP(ctx, connect.NewRequest(&managerv1.EnrollTOTPRequest{}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	login := func(password, code string) (*connect.Response[managerv1.AuthenticateResponse], error) {
+		return handler.Authenticate(context.Background(), connect.NewRequest(&managerv1.AuthenticateRequest{
+			Email:    "customer-1@example.com",
+			Password: password,
+			TotpCode: code,
+		}))
+	}
+
+	_, err = login("correct horse", "")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "two-factor code required")
+	_, err = login("correct horse", "000000")
+	assert.ErrorContains(t, err, "invalid two-factor code")
+
+	// The code confirming the enrollment was used, the next step's is accepted
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+1)
+	require.NoError(t, err)
+	_, err = login("wrong horse", code)
+	assert.ErrorContains(t, err, "invalid email or password", "codes don't stand in for passwords")
+	resp, err := login("correct horse", code)
+	require.NoError(t, err)
+	claims, err := handler.jwtManager.ValidateToken(resp.Msg.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.MFA)
+
+	_, err = login("correct horse", code)
+	assert.ErrorContains(t, err, "invalid two-factor code", "codes are used once")
+
+	t.Run("accounts without a password can't enroll", func(t *testing.T) {
+		ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "ops@acme.example", Email: "ops@acme.example"})
+		_, err := handler.EnrollTOTP(ctx, connect.NewRequest(&managerv1.EnrollTOTPRequest{}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+}
+
+func TestDisableTOTP(t *testing.T) {
+	handler := setupTestHandler(t)
+	customer := setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+	secret := enrollTOTP(t, handler, ctx)
+
+	disable := func(code string) error {
+		_, err := handler.DisableTOTP(ctx, connect.NewRequest(&managerv1.DisableTOTPRequest{Code: code}))
+		return err
+	}
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(disable("000000")))
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+1)
+	require.NoError(t, err)
+	require.NoError(t, disable(code))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(disable(code)))
+
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+}
+
+func TestConfirmTOTP_Lockout(t *testing.T) {
+	handler := setupTestHandler(t)
+	handler.SetPasswordPolicy(PasswordPolicy{MaxFailedLogins: 3, LockoutDuration: time.Hour})
+	customer := setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+
+	enrolled, err := handler.EnrollTOTP(ctx, connect.NewRequest(&managerv1.EnrollTOTPRequest{}))
+	require.NoError(t, err)
+	confirm := func(code string) error {
+		_, err := handler.ConfirmTOTP(ctx, connect.NewRequest(&managerv1.ConfirmTOTPRequest{Code: code}))
+		return err
+	}
+
+	for range 3 {
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(confirm("000000")))
+	}
+
+	// Locked accounts are refused the right code too, and can't log in
+	code, err := auth.TOTPCode(enrolled.Msg.Secret, auth.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(confirm(code)))
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+	stored, err := handler.db.Customers.Get(context.Background(), "customer-1")
+	require.NoError(t, err)
+	assert.False(t, stored.TOTPEnabled)
+}
+
+func TestSetCustomerMFAPolicy(t *testing.T) {
+	handler := setupTestHandler(t)
+	admin := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+	adminCtx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	customer := setupPasswordCustomer(t, handler, "customer-1", "correct horse")
+	ctx := setupAuthenticatedContext(t, handler, customer)
+	secret := enrollTOTP(t, handler, ctx)
+
+	resp, err := admin.SetCustomerMFAPolicy(adminCtx, connect.NewRequest(&managerv1.SetCustomerMFAPolicyRequest{
+		CustomerId: "customer-1",
+		RequireMfa: true,
+	}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.RequireMfa)
+	assert.True(t, resp.Msg.TotpEnabled)
+
+	// Server tokens carry the policy, and whether the login used a code
+	claims, err := handler.jwtManager.ValidateToken(mustToken(t, handler, customer, false))
+	require.NoError(t, err)
+	tokenCustomer, err := serverTokenCustomer(ctx, handler.db, claims)
+	require.NoError(t, err)
+	assert.Equal(t, auth.MFAClaims{Verified: false, Required: true}, consoleMFAClaims(claims, tokenCustomer))
+	claims, err = handler.jwtManager.ValidateToken(mustToken(t, handler, customer, true))
+	require.NoError(t, err)
+	assert.Equal(t, auth.MFAClaims{Verified: true, Required: true}, consoleMFAClaims(claims, tokenCustomer))
+
+	// Required second factors can't be disabled by customers
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+1)
+	require.NoError(t, err)
+	_, err = handler.DisableTOTP(ctx, connect.NewRequest(&managerv1.DisableTOTPRequest{Code: code}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// Admins reset lost authenticators
+	resp, err = admin.SetCustomerMFAPolicy(adminCtx, connect.NewRequest(&managerv1.SetCustomerMFAPolicyRequest{
+		CustomerId: "customer-1",
+		RequireMfa: true,
+		ResetTotp:  true,
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.TotpEnabled)
+	_, err = authenticate(handler, "customer-1@example.com", "correct horse")
+	require.NoError(t, err)
+
+	events, err := handler.db.AuditEvents.List(adminCtx, database.AuditEventFilter{Action: auditCustomerMFAPolicy})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = admin.SetCustomerMFAPolicy(adminCtx, connect.NewRequest(&managerv1.SetCustomerMFAPolicyRequest{CustomerId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+// mustToken generates an access token of a customer
+func mustToken(t *testing.T, handler *BMCManagerServiceHandler, customer *models.Customer, mfa bool) string {
+	t.Helper()
+
+	token, err := handler.jwtManager.GenerateTokenWithMFA(customer, mfa)
+	require.NoError(t, err)
+	return token
+}
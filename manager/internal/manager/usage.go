@@ -0,0 +1,152 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"core/events"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/database"
+	"manager/internal/usage"
+)
+
+// usageRecord returns the usage record of an event reported by a gateway:
+// closed console streams and successful power operations. It returns nil for
+// other events and for events of unknown servers.
+func (h *BMCManagerServiceHandler) usageRecord(ctx context.Context, gatewayID string, event events.Event) *database.UsageRecord {
+	record := &database.UsageRecord{
+		EventID:    event.ID,
+		GatewayID:  gatewayID,
+		OccurredAt: event.Time,
+	}
+	switch event.Type {
+	case events.ConsoleSessionClosed:
+		record.Kind = database.UsageKindConsole
+		record.Operation, _ = event.Data["session_type"].(string)
+		record.DurationMs = eventInt(event.Data, "duration_ms")
+		record.BytesIn = eventInt(event.Data, "bytes_in")
+		record.BytesOut = eventInt(event.Data, "bytes_out")
+	case events.PowerOperationExecuted:
+		if success, _ := event.Data["success"].(bool); !success {
+			return nil
+		}
+		record.Kind = database.UsageKindPower
+		record.Operation, _ = event.Data["operation"].(string)
+	default:
+		return nil
+	}
+
+	server := h.usageServer(ctx, event.Data)
+	if server == nil {
+		log.Debug().
+			Str("gateway_id", gatewayID).
+			Str("event_id", event.ID).
+			Msg("Skipping usage of unknown server")
+		return nil
+	}
+	record.ServerID = server.ID
+	record.CustomerID = server.CustomerID
+	return record
+}
+
+// usageServer resolves the server of a usage event, by its ID or else by its
+// BMC endpoint, as reported for power operations
+func (h *BMCManagerServiceHandler) usageServer(ctx context.Context, data map[string]any) *domain.Server {
+	if serverID, _ := data["server_id"].(string); serverID != "" {
+		if server, err := h.db.Servers.Get(ctx, serverID); err == nil {
+			return server
+		}
+	}
+	endpoint, _ := data["bmc_endpoint"].(string)
+	datacenterID, _ := data["datacenter_id"].(string)
+	if endpoint == "" {
+		return nil
+	}
+	server, err := h.db.Servers.FindByBMCEndpoint(ctx, datacenterID, endpoint)
+	if err != nil {
+		return nil
+	}
+	return server
+}
+
+// eventInt returns an integer field of event data. Numbers of reported
+// events are decoded from JSON as float64.
+func eventInt(data map[string]any, key string) int64 {
+	switch value := data[key].(type) {
+	case float64:
+		return int64(value)
+	case int64:
+		return value
+	case int:
+		return int64(value)
+	}
+	return 0
+}
+
+// ExportUsage returns the console minutes, power operations and console data
+// of customers over a month, and the same encoded as CSV or JSON for billing.
+// Usage is attributed to the customer owning the server when it was used.
+func (h *AdminServiceHandler) ExportUsage(
+	ctx context.Context,
+	req *connect.Request[managerv1.ExportUsageRequest],
+) (*connect.Response[managerv1.ExportUsageResponse], error) {
+	log.Info().
+		Str("month", req.Msg.Month).
+		Str("customer_id", req.Msg.CustomerId).
+		Msg("ExportUsage called")
+
+	start, end, err := usage.ParseMonth(req.Msg.Month)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if format := req.Msg.Format; format != "" && format != usage.FormatCSV && format != usage.FormatJSON {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("format must be %s or %s", usage.FormatCSV, usage.FormatJSON))
+	}
+
+	records, err := h.db.UsageRecords.List(ctx, start, end, req.Msg.CustomerId)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list usage records")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list usage records: %w", err))
+	}
+
+	uses := make([]usage.Record, len(records))
+	for i, record := range records {
+		uses[i] = usage.Record{
+			CustomerID: record.CustomerID,
+			Console:    record.Kind == database.UsageKindConsole,
+			Duration:   time.Duration(record.DurationMs) * time.Millisecond,
+			BytesIn:    record.BytesIn,
+			BytesOut:   record.BytesOut,
+		}
+	}
+	usages := usage.Aggregate(uses)
+
+	data, contentType, err := usage.Export(req.Msg.Month, usages, req.Msg.Format)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to export usage")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to export usage: %w", err))
+	}
+
+	response := &managerv1.ExportUsageResponse{
+		Month:       req.Msg.Month,
+		Customers:   make([]*managerv1.CustomerUsage, len(usages)),
+		ContentType: contentType,
+		Data:        data,
+	}
+	for i, customer := range usages {
+		response.Customers[i] = &managerv1.CustomerUsage{
+			CustomerId:      customer.CustomerID,
+			ConsoleSessions: customer.ConsoleSessions,
+			ConsoleMinutes:  customer.ConsoleMinutes,
+			PowerOperations: customer.PowerOperations,
+			BytesIn:         customer.BytesIn,
+			BytesOut:        customer.BytesOut,
+		}
+	}
+	return connect.NewResponse(response), nil
+}
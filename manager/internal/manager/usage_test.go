@@ -0,0 +1,128 @@
+package manager
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"core/domain"
+	"core/events"
+	"core/types"
+	managerv1 "manager/gen/manager/v1"
+	"manager/internal/slo"
+	"manager/pkg/models"
+)
+
+func TestExportUsage(t *testing.T) {
+	handler := setupTestHandler(t)
+	ctx := setupAuthenticatedContext(t, handler, &models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+
+	for _, server := range []*domain.Server{
+		{ID: "server-1", CustomerID: "customer-1"},
+		{ID: "server-2", CustomerID: "customer-2"},
+	} {
+		server.DatacenterID = "dc-test-01"
+		server.ControlEndpoints = []*types.BMCControlEndpoint{{Endpoint: server.ID + ":623", Type: types.BMCTypeIPMI}}
+		server.PrimaryProtocol = types.BMCTypeIPMI
+		server.Status = "active"
+		server.CreatedAt = time.Now()
+		server.UpdatedAt = time.Now()
+		require.NoError(t, handler.db.Servers.Create(ctx, server))
+	}
+
+	june := time.Date(2024, time.June, 10, 12, 0, 0, 0, time.UTC)
+	event := func(id string, eventType events.Type, at time.Time, data map[string]any) *managerv1.SystemEvent {
+		fields, err := structpb.NewStruct(data)
+		require.NoError(t, err)
+		return &managerv1.SystemEvent{Id: id, Type: string(eventType), Source: "gateway", Time: timestamppb.New(at), Data: fields}
+	}
+	consoleClosed := func(id, serverID string, durationMs, bytesIn, bytesOut int64) *managerv1.SystemEvent {
+		return event(id, events.ConsoleSessionClosed, june, map[string]any{
+			"server_id": serverID, "session_type": "sol", "duration_ms": durationMs, "bytes_in": bytesIn, "bytes_out": bytesOut,
+		})
+	}
+	powerExecuted := func(id string, at time.Time, data map[string]any) *managerv1.SystemEvent {
+		data["operation"] = "PowerCycle"
+		return event(id, events.PowerOperationExecuted, at, data)
+	}
+
+	reported := []*managerv1.SystemEvent{
+		consoleClosed("evt-1", "server-1", 90000, 100, 20000),
+		consoleClosed("evt-2", "server-1", 30000, 50, 5000),
+		consoleClosed("evt-3", "server-2", 600000, 10, 1000),
+		powerExecuted("evt-4", june, map[string]any{"server_id": "server-1", "success": true}),
+		// Power operations identify servers by their BMC endpoint
+		powerExecuted("evt-5", june, map[string]any{"server_id": "bmc-dc-test-01-server-2:623", "bmc_endpoint": "server-2:623", "datacenter_id": "dc-test-01", "success": true}),
+		// Failed operations, unknown servers and other months are not counted
+		powerExecuted("evt-6", june, map[string]any{"server_id": "server-1", "success": false}),
+		powerExecuted("evt-7", june, map[string]any{"server_id": "server-unknown", "success": true}),
+		powerExecuted("evt-8", june.AddDate(0, 1, 0), map[string]any{"server_id": "server-1", "success": true}),
+		event("evt-9", events.ConsoleSessionOpened, june, map[string]any{"server_id": "server-1"}),
+	}
+	_, err := handler.ReportEvents(ctx, connect.NewRequest(&managerv1.ReportEventsRequest{GatewayId: "gw-1", Events: reported}))
+	require.NoError(t, err)
+
+	// Events reported again by a gateway are counted once
+	_, err = handler.ReportEvents(ctx, connect.NewRequest(&managerv1.ReportEventsRequest{GatewayId: "gw-1", Events: reported[:1]}))
+	require.NoError(t, err)
+
+	adminHandler := NewAdminServiceHandler(handler.db, handler.jwtManager, slo.Objectives{})
+
+	t.Run("csv", func(t *testing.T) {
+		resp, err := adminHandler.ExportUsage(ctx, connect.NewRequest(&managerv1.ExportUsageRequest{Month: "2024-06"}))
+		require.NoError(t, err)
+
+		require.Len(t, resp.Msg.Customers, 2)
+		customer := resp.Msg.Customers[0]
+		assert.Equal(t, "customer-1", customer.CustomerId)
+		assert.Equal(t, int64(2), customer.ConsoleSessions)
+		assert.InDelta(t, 2, customer.ConsoleMinutes, 1e-9)
+		assert.Equal(t, int64(1), customer.PowerOperations)
+		assert.Equal(t, int64(150), customer.BytesIn)
+		assert.Equal(t, int64(25000), customer.BytesOut)
+
+		customer = resp.Msg.Customers[1]
+		assert.Equal(t, "customer-2", customer.CustomerId)
+		assert.InDelta(t, 10, customer.ConsoleMinutes, 1e-9)
+		assert.Equal(t, int64(1), customer.PowerOperations)
+
+		assert.Equal(t, "text/csv", resp.Msg.ContentType)
+		assert.Equal(t, "month,customer_id,console_sessions,console_minutes,power_operations,bytes_in,bytes_out\n"+
+			"2024-06,customer-1,2,2.00,1,150,25000\n"+
+			"2024-06,customer-2,1,10.00,1,10,1000\n", string(resp.Msg.Data))
+	})
+
+	t.Run("json of a customer", func(t *testing.T) {
+		resp, err := adminHandler.ExportUsage(ctx, connect.NewRequest(&managerv1.ExportUsageRequest{
+			Month: "2024-07", Format: "json", CustomerId: "customer-1",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", resp.Msg.ContentType)
+
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(resp.Msg.Data, &document))
+		assert.Equal(t, "2024-07", document["month"])
+		assert.Equal(t, []any{map[string]any{
+			"customer_id":      "customer-1",
+			"console_sessions": 0.0,
+			"console_minutes":  0.0,
+			"power_operations": 1.0,
+			"bytes_in":         0.0,
+			"bytes_out":        0.0,
+		}}, document["customers"])
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := adminHandler.ExportUsage(ctx, connect.NewRequest(&managerv1.ExportUsageRequest{Month: "06-2024"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = adminHandler.ExportUsage(ctx, connect.NewRequest(&managerv1.ExportUsageRequest{Month: "2024-06", Format: "xml"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
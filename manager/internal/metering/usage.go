@@ -0,0 +1,76 @@
+// Package metering computes the power usage of servers from the power
+// samples reported by gateways, for usage reports and chargeback.
+package metering
+
+import "time"
+
+// DefaultMaxSampleGap is the longest interval between two samples that is
+// counted as usage. Longer gaps, e.g. while a gateway was down, are left out
+// rather than extrapolated.
+const DefaultMaxSampleGap = 15 * time.Minute
+
+// Sample is a power reading of a server
+type Sample struct {
+	SampledAt     time.Time
+	ConsumedWatts float64
+	EnergyKWh     float64 // Cumulative BMC energy counter, 0 when not metered
+}
+
+// Usage is the power usage of a server over a period
+type Usage struct {
+	EnergyKWh    float64
+	AverageWatts float64
+	PeakWatts    float64
+	Samples      int64
+	SampledHours float64 // Time covered by consecutive samples
+	Metered      bool    // Energy comes from the BMC's counter for all of SampledHours
+}
+
+// Compute returns the usage of samples ordered by sample time.
+//
+// The energy of an interval between two samples is the difference of their
+// BMC energy counters when both report one and it did not go backwards (e.g.
+// on a BMC reset), otherwise the integral of the power readings using the
+// trapezoidal rule. Intervals longer than maxGap are not counted.
+func Compute(samples []Sample, maxGap time.Duration) Usage {
+	usage := Usage{Samples: int64(len(samples))}
+	if len(samples) == 0 {
+		return usage
+	}
+
+	var wattsSum float64
+	intervals, meteredIntervals := 0, 0
+	for i, sample := range samples {
+		wattsSum += sample.ConsumedWatts
+		if sample.ConsumedWatts > usage.PeakWatts {
+			usage.PeakWatts = sample.ConsumedWatts
+		}
+		if i == 0 {
+			continue
+		}
+
+		previous := samples[i-1]
+		elapsed := sample.SampledAt.Sub(previous.SampledAt)
+		if elapsed <= 0 || elapsed > maxGap {
+			continue
+		}
+
+		hours := elapsed.Hours()
+		intervals++
+		usage.SampledHours += hours
+		if previous.EnergyKWh > 0 && sample.EnergyKWh >= previous.EnergyKWh {
+			usage.EnergyKWh += sample.EnergyKWh - previous.EnergyKWh
+			meteredIntervals++
+		} else {
+			usage.EnergyKWh += (previous.ConsumedWatts + sample.ConsumedWatts) / 2 * hours / 1000
+		}
+	}
+
+	usage.Metered = intervals > 0 && meteredIntervals == intervals
+	if usage.SampledHours > 0 {
+		usage.AverageWatts = usage.EnergyKWh * 1000 / usage.SampledHours
+	} else {
+		usage.AverageWatts = wattsSum / float64(len(samples))
+	}
+	return usage
+}
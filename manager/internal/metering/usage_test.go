@@ -0,0 +1,75 @@
+package metering
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	t.Run("integrates power readings", func(t *testing.T) {
+		usage := Compute([]Sample{
+			{SampledAt: at(0), ConsumedWatts: 200},
+			{SampledAt: at(6), ConsumedWatts: 400},
+			{SampledAt: at(12), ConsumedWatts: 400},
+		}, DefaultMaxSampleGap)
+
+		// 300 W and 400 W for 6 minutes each
+		assert.InDelta(t, 0.07, usage.EnergyKWh, 1e-9)
+		assert.InDelta(t, 0.2, usage.SampledHours, 1e-9)
+		assert.InDelta(t, 350, usage.AverageWatts, 1e-9)
+		assert.Equal(t, 400.0, usage.PeakWatts)
+		assert.Equal(t, int64(3), usage.Samples)
+		assert.False(t, usage.Metered)
+	})
+
+	t.Run("prefers energy counters", func(t *testing.T) {
+		usage := Compute([]Sample{
+			{SampledAt: at(0), ConsumedWatts: 200, EnergyKWh: 100},
+			{SampledAt: at(60), ConsumedWatts: 200, EnergyKWh: 100.5},
+		}, 2*time.Hour)
+
+		assert.InDelta(t, 0.5, usage.EnergyKWh, 1e-9)
+		assert.InDelta(t, 500, usage.AverageWatts, 1e-9)
+		assert.True(t, usage.Metered)
+	})
+
+	t.Run("integrates over counter resets", func(t *testing.T) {
+		usage := Compute([]Sample{
+			{SampledAt: at(0), ConsumedWatts: 100, EnergyKWh: 100},
+			{SampledAt: at(60), ConsumedWatts: 100, EnergyKWh: 0.1},
+		}, 2*time.Hour)
+
+		assert.InDelta(t, 0.1, usage.EnergyKWh, 1e-9)
+		assert.False(t, usage.Metered)
+	})
+
+	t.Run("skips gaps", func(t *testing.T) {
+		usage := Compute([]Sample{
+			{SampledAt: at(0), ConsumedWatts: 300},
+			{SampledAt: at(5), ConsumedWatts: 300},
+			// Gateway down for an hour
+			{SampledAt: at(65), ConsumedWatts: 900},
+		}, DefaultMaxSampleGap)
+
+		assert.InDelta(t, 0.025, usage.EnergyKWh, 1e-9)
+		assert.InDelta(t, 5.0/60, usage.SampledHours, 1e-9)
+		assert.Equal(t, 900.0, usage.PeakWatts)
+	})
+
+	t.Run("single sample", func(t *testing.T) {
+		usage := Compute([]Sample{{SampledAt: at(0), ConsumedWatts: 250}}, DefaultMaxSampleGap)
+
+		assert.Zero(t, usage.EnergyKWh)
+		assert.Equal(t, 250.0, usage.AverageWatts)
+		assert.Equal(t, int64(1), usage.Samples)
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		assert.Equal(t, Usage{}, Compute(nil, DefaultMaxSampleGap))
+	})
+}
@@ -86,6 +86,34 @@ var (
 		[]string{"status"},
 	)
 
+	// Webhook Notifications
+
+	WebhookDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "manager_webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts",
+		},
+		[]string{"endpoint", "event_type", "status"},
+	)
+
+	WebhookEventsDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "manager_webhook_events_dropped_total",
+			Help: "Total number of events dropped because a webhook queue was full",
+		},
+		[]string{"endpoint", "event_type"},
+	)
+
+	// Event Bus
+
+	EventBusEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "manager_eventbus_events_total",
+			Help: "Total number of events published to the event bus by outcome",
+		},
+		[]string{"event_type", "status"},
+	)
+
 	// Database Operations
 
 	DBQueriesTotal = promauto.NewCounterVec(
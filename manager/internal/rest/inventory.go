@@ -0,0 +1,257 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+
+	commonv1 "core/gen/common/v1"
+	gatewayv1 "gateway/gen/gateway/v1"
+	managerv1 "manager/gen/manager/v1"
+)
+
+// InventoryPath is the path of the Ansible dynamic inventory
+const InventoryPath = "/api/v1/inventory/ansible"
+
+// APIKeyHeader is the header carrying the API key of inventory requests
+const APIKeyHeader = "X-API-Key"
+
+const (
+	// powerQueryConcurrency bounds the power status queries in flight
+	powerQueryConcurrency = 16
+	// powerQueryTimeout bounds the power status query of each server
+	powerQueryTimeout = 5 * time.Second
+	// inventoryPageSize is the page size used to list servers
+	inventoryPageSize = 1000
+)
+
+// ansibleGroup is a group of the Ansible inventory
+type ansibleGroup struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// serveAnsibleInventory serves the servers of the caller as an Ansible
+// dynamic inventory, in the JSON format of inventory scripts invoked with
+// --list. Hosts are the server IDs, grouped by datacenter, BMC type, label
+// (server metadata) and power state; their hostvars describe the server.
+//
+// Power states are queried from the gateways unless power_state=false,
+// servers whose state cannot be queried are in the power_unknown group.
+func (h *Handler) serveAnsibleInventory(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.service.AuthorizeAPIKey(r.Context(), r.Header.Get(APIKeyHeader))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	queryPower := true
+	if value := r.URL.Query().Get("power_state"); value != "" {
+		queryPower, err = strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid power_state: %q", value)))
+			return
+		}
+	}
+
+	servers, err := h.listAllServers(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var powerStates map[string]string
+	if queryPower {
+		// Power states come from gateways, which see the manager's address
+		if err := h.service.CheckSourceAllowed(ctx, r.RemoteAddr); err != nil {
+			writeError(w, err)
+			return
+		}
+		powerStates = h.powerStates(ctx, servers)
+	}
+
+	body, err := json.Marshal(ansibleInventory(servers, powerStates))
+	if err != nil {
+		writeError(w, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to marshal inventory: %w", err)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// listAllServers lists the servers of the caller, following pagination
+func (h *Handler) listAllServers(ctx context.Context) ([]*managerv1.Server, error) {
+	var servers []*managerv1.Server
+	pageToken := ""
+	for {
+		resp, err := h.service.ListServers(ctx, connect.NewRequest(&managerv1.ListServersRequest{
+			PageSize:  inventoryPageSize,
+			PageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, resp.Msg.Servers...)
+		if resp.Msg.NextPageToken == "" || resp.Msg.NextPageToken == pageToken {
+			return servers, nil
+		}
+		pageToken = resp.Msg.NextPageToken
+	}
+}
+
+// powerStates queries the power state of servers from their gateways,
+// returning on, off, cycling or unknown by server ID
+func (h *Handler) powerStates(ctx context.Context, servers []*managerv1.Server) map[string]string {
+	states := make(map[string]string, len(servers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, powerQueryConcurrency)
+
+	for _, server := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(serverID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			state := h.powerState(ctx, serverID)
+			mu.Lock()
+			states[serverID] = state
+			mu.Unlock()
+		}(server.Id)
+	}
+	wg.Wait()
+
+	return states
+}
+
+// powerState queries the power state of a server from its gateway
+func (h *Handler) powerState(ctx context.Context, serverID string) string {
+	ctx, cancel := context.WithTimeout(ctx, powerQueryTimeout)
+	defer cancel()
+
+	gateway, err := h.gatewayClient(ctx, serverID)
+	if err == nil {
+		var resp *connect.Response[gatewayv1.PowerStatusResponse]
+		resp, err = gateway.GetPowerStatus(ctx, connect.NewRequest(&gatewayv1.PowerStatusRequest{ServerId: serverID}))
+		if err == nil {
+			return strings.ToLower(strings.TrimPrefix(resp.Msg.State.String(), "POWER_STATE_"))
+		}
+	}
+
+	log.Debug().
+		Err(err).
+		Str("server_id", serverID).
+		Msg("Failed to query power state for inventory")
+	return "unknown"
+}
+
+// ansibleInventory builds the inventory of servers. powerStates is nil when
+// power states were not queried.
+func ansibleInventory(servers []*managerv1.Server, powerStates map[string]string) map[string]any {
+	groups := map[string]*ansibleGroup{}
+	addHost := func(group, host string) {
+		g, ok := groups[group]
+		if !ok {
+			g = &ansibleGroup{}
+			groups[group] = g
+		}
+		g.Hosts = append(g.Hosts, host)
+	}
+
+	hostvars := make(map[string]any, len(servers))
+	for _, server := range servers {
+		bmcType := bmcTypeName(server.PrimaryProtocol)
+		vars := map[string]any{
+			"conduit_server_id":     server.Id,
+			"conduit_customer_id":   server.CustomerId,
+			"conduit_datacenter_id": server.DatacenterId,
+			"conduit_bmc_type":      bmcType,
+			"conduit_features":      server.Features,
+			"conduit_status":        server.Status,
+			"conduit_labels":        server.Metadata,
+		}
+		if server.ExternalId != "" {
+			vars["conduit_external_id"] = server.ExternalId
+		}
+		if endpoint := primaryEndpoint(server); endpoint != "" {
+			vars["conduit_bmc_endpoint"] = endpoint
+		}
+
+		addHost(groupName("datacenter", server.DatacenterId), server.Id)
+		addHost(groupName("bmc", bmcType), server.Id)
+		for key, value := range server.Metadata {
+			addHost(groupName("label", key, value), server.Id)
+		}
+		if powerStates != nil {
+			state := powerStates[server.Id]
+			vars["conduit_power_state"] = state
+			addHost(groupName("power", state), server.Id)
+		}
+
+		hostvars[server.Id] = vars
+	}
+
+	names := make([]string, 0, len(groups))
+	for name, group := range groups {
+		sort.Strings(group.Hosts)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	inventory := map[string]any{
+		"_meta": map[string]any{"hostvars": hostvars},
+		"all":   &ansibleGroup{Children: append([]string{"ungrouped"}, names...)},
+	}
+	for _, name := range names {
+		inventory[name] = groups[name]
+	}
+	return inventory
+}
+
+// groupName joins parts into an Ansible group name, replacing the
+// characters that are not valid in group names with underscores
+func groupName(parts ...string) string {
+	name := strings.ToLower(strings.Join(parts, "_"))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// bmcTypeName returns the inventory name of a BMC type, e.g. ipmi
+func bmcTypeName(bmcType commonv1.BMCType) string {
+	switch bmcType {
+	case commonv1.BMCType_BMC_IPMI:
+		return "ipmi"
+	case commonv1.BMCType_BMC_REDFISH:
+		return "redfish"
+	default:
+		return "unknown"
+	}
+}
+
+// primaryEndpoint returns the address of the control endpoint of the
+// server's primary protocol, else of its first control endpoint
+func primaryEndpoint(server *managerv1.Server) string {
+	for _, endpoint := range server.ControlEndpoints {
+		if endpoint.Type == server.PrimaryProtocol {
+			return endpoint.Endpoint
+		}
+	}
+	if len(server.ControlEndpoints) > 0 {
+		return server.ControlEndpoints[0].Endpoint
+	}
+	return ""
+}
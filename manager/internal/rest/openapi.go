@@ -0,0 +1,246 @@
+package rest
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// errorSchema is the component name of the error response schema
+const errorSchema = "Error"
+
+// pathParam matches the wildcards of route paths
+var pathParam = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// OpenAPI generates the OpenAPI 3 document of the REST API. Operations come
+// from the route table and schemas from the protobuf descriptors of their
+// messages, so the document follows the proto definitions.
+func OpenAPI() map[string]any {
+	schemas := map[string]any{
+		errorSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"code", "message"},
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "string", "description": "Connect error code, e.g. not_found"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	paths := map[string]any{}
+	for _, rt := range routes {
+		item, ok := paths[rt.path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = operation(rt, schemas)
+	}
+	paths[InventoryPath] = map[string]any{"get": inventoryOperation()}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "BMC Manager REST API",
+			"version":     "v1",
+			"description": "JSON-over-HTTP translation of the BMC Manager Connect API",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+				"apiKeyAuth": map[string]any{
+					"type": "apiKey",
+					"in":   "header",
+					"name": APIKeyHeader,
+				},
+			},
+		},
+	}
+}
+
+// operation returns the OpenAPI operation of a route, adding the schemas of
+// its messages to schemas
+func operation(rt route, schemas map[string]any) map[string]any {
+	var params []any
+	for _, match := range pathParam.FindAllStringSubmatch(rt.path, -1) {
+		params = append(params, map[string]any{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+	for _, q := range rt.query {
+		schema := map[string]any{"type": "string"}
+		switch {
+		case q.integer:
+			schema = map[string]any{"type": "integer", "format": "int32"}
+		case q.boolean:
+			schema = map[string]any{"type": "boolean"}
+		}
+		params = append(params, map[string]any{
+			"name":        q.name,
+			"in":          "query",
+			"description": q.description,
+			"schema":      schema,
+		})
+	}
+
+	op := map[string]any{
+		"operationId": rt.operationID,
+		"summary":     rt.summary,
+		"tags":        []string{rt.tag},
+		"responses": map[string]any{
+			strconv.Itoa(rt.status): map[string]any{
+				"description": http.StatusText(rt.status),
+				"content":     jsonContent(messageSchema(rt.response.ProtoReflect().Descriptor(), schemas)),
+			},
+			"default": map[string]any{
+				"description": "Error",
+				"content":     jsonContent(ref(errorSchema)),
+			},
+		},
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if rt.request != nil {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  jsonContent(messageSchema(rt.request.ProtoReflect().Descriptor(), schemas)),
+		}
+	}
+	if rt.public {
+		op["security"] = []any{}
+	} else {
+		op["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	}
+	return op
+}
+
+// inventoryOperation returns the OpenAPI operation of the Ansible inventory,
+// whose body is not a protobuf message
+func inventoryOperation() map[string]any {
+	return map[string]any{
+		"operationId": "GetAnsibleInventory",
+		"summary":     "Get the servers as an Ansible dynamic inventory",
+		"tags":        []string{"Inventory"},
+		"parameters": []any{map[string]any{
+			"name":        "power_state",
+			"in":          "query",
+			"description": "Query power states from the gateways, true by default",
+			"schema":      map[string]any{"type": "boolean"},
+		}},
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "Inventory in the JSON format of Ansible inventory scripts",
+				"content":     jsonContent(map[string]any{"type": "object", "additionalProperties": true}),
+			},
+			"default": map[string]any{
+				"description": "Error",
+				"content":     jsonContent(ref(errorSchema)),
+			},
+		},
+		"security": []any{map[string]any{"apiKeyAuth": []string{}}},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// messageSchema returns the schema of a message in the protobuf JSON mapping.
+// Well-known types map to their JSON representation; other messages are
+// added to schemas, with the messages they reference, and referenced by
+// their full name.
+func messageSchema(md protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "description": "Duration in seconds with an s suffix, e.g. 1.5s"}
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object", "additionalProperties": true}
+	}
+
+	name := string(md.FullName())
+	if _, ok := schemas[name]; ok {
+		return ref(name)
+	}
+
+	properties := map[string]any{}
+	schema := map[string]any{"type": "object", "properties": properties}
+	// Register before the fields so that recursive messages terminate
+	schemas[name] = schema
+
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(fd, schemas)
+	}
+	return ref(name)
+}
+
+// fieldSchema returns the schema of a message field
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": singularSchema(fd.MapValue(), schemas),
+		}
+	case fd.IsList():
+		return map[string]any{
+			"type":  "array",
+			"items": singularSchema(fd, schemas),
+		}
+	default:
+		return singularSchema(fd, schemas)
+	}
+}
+
+// singularSchema returns the schema of one value of a field
+func singularSchema(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64-bit integers are encoded as strings to preserve their precision
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	default:
+		return messageSchema(fd.Message(), schemas)
+	}
+}
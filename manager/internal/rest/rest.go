@@ -0,0 +1,432 @@
+// Package rest serves a JSON-over-HTTP API of the BMC Manager for clients
+// that cannot speak Connect, such as inventory tooling and shell scripts.
+//
+// Each route translates to a BMCManagerService RPC, or for power operations
+// and console sessions, to a GatewayService RPC on the gateway of the server,
+// authenticated with a server token as the CLI does. Messages are encoded
+// with the protobuf JSON mapping, the same as Connect's JSON encoding, and
+// errors as {"code": "not_found", "message": "..."} with the matching HTTP
+// status. The OpenAPI document of the API is served at /api/v1/openapi.json.
+//
+// The servers are also served as an Ansible dynamic inventory at
+// /api/v1/inventory/ansible, authenticated with a customer API key.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"core/tracing"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+)
+
+// PathPrefix is the path prefix of all routes
+const PathPrefix = "/api/v1/"
+
+// maxBodySize bounds the size of request bodies
+const maxBodySize = 1 << 20
+
+// Service is the part of the BMC Manager service exposed over REST,
+// implemented by manager.BMCManagerServiceHandler
+type Service interface {
+	Authorize(ctx context.Context, authHeader string) (context.Context, error)
+	AuthorizeAPIKey(ctx context.Context, apiKey string) (context.Context, error)
+	Authenticate(context.Context, *connect.Request[managerv1.AuthenticateRequest]) (*connect.Response[managerv1.AuthenticateResponse], error)
+	ListServers(context.Context, *connect.Request[managerv1.ListServersRequest]) (*connect.Response[managerv1.ListServersResponse], error)
+	GetServer(context.Context, *connect.Request[managerv1.GetServerRequest]) (*connect.Response[managerv1.GetServerResponse], error)
+	GetServerToken(context.Context, *connect.Request[managerv1.GetServerTokenRequest]) (*connect.Response[managerv1.GetServerTokenResponse], error)
+	GetServerLocation(context.Context, *connect.Request[managerv1.GetServerLocationRequest]) (*connect.Response[managerv1.GetServerLocationResponse], error)
+	CheckSourceAllowed(ctx context.Context, clientAddress string) error
+}
+
+// route is a REST endpoint and the description of its OpenAPI operation
+type route struct {
+	method      string
+	path        string // http.ServeMux pattern path, with {server_id} wildcards
+	operationID string
+	summary     string
+	tag         string
+	public      bool          // No bearer token required
+	query       []queryParam  // Query parameters
+	request     proto.Message // Request body message, nil for none
+	response    proto.Message // Response body message
+	status      int           // Status of successful responses
+	handle      func(h *Handler, r *http.Request) (proto.Message, error)
+}
+
+// queryParam is a query parameter of a route
+type queryParam struct {
+	name        string
+	integer     bool
+	boolean     bool
+	description string
+}
+
+// powerRoute returns the route of a power operation, POST
+// /api/v1/servers/{server_id}/power/<operation>. The Idempotency-Key header
+// is sent to the gateway, which runs the operation once per key.
+func powerRoute(operation, operationID, summary string, call func(gatewayv1connect.GatewayServiceClient, context.Context, *connect.Request[gatewayv1.PowerOperationRequest]) (*connect.Response[gatewayv1.PowerOperationResponse], error)) route {
+	return route{
+		method:      http.MethodPost,
+		path:        "/api/v1/servers/{server_id}/power/" + operation,
+		operationID: operationID,
+		summary:     summary,
+		tag:         "Power",
+		response:    &gatewayv1.PowerOperationResponse{},
+		status:      http.StatusOK,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			serverID := r.PathValue("server_id")
+			gateway, err := h.callerGatewayClient(r, serverID)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := call(gateway, r.Context(), connect.NewRequest(&gatewayv1.PowerOperationRequest{
+				ServerId:       serverID,
+				IdempotencyKey: r.Header.Get("Idempotency-Key"),
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	}
+}
+
+var routes = []route{
+	{
+		method:      http.MethodPost,
+		path:        "/api/v1/auth/token",
+		operationID: "Authenticate",
+		summary:     "Authenticate and obtain an access token",
+		tag:         "Authentication",
+		public:      true,
+		request:     &managerv1.AuthenticateRequest{},
+		response:    &managerv1.AuthenticateResponse{},
+		status:      http.StatusOK,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			req := &managerv1.AuthenticateRequest{}
+			if err := decodeBody(r, req); err != nil {
+				return nil, err
+			}
+			resp, err := h.service.Authenticate(r.Context(), connect.NewRequest(req))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/api/v1/servers",
+		operationID: "ListServers",
+		summary:     "List the servers of the authenticated customer",
+		tag:         "Servers",
+		query: []queryParam{
+			{name: "page_size", integer: true, description: "Maximum number of servers to return"},
+			{name: "page_token", description: "next_page_token of the previous page"},
+			{name: "include_power_state", boolean: true, description: "Query the power state of each server from its gateway"},
+		},
+		response: &managerv1.ListServersResponse{},
+		status:   http.StatusOK,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			req := &managerv1.ListServersRequest{PageToken: r.URL.Query().Get("page_token")}
+			if pageSize := r.URL.Query().Get("page_size"); pageSize != "" {
+				size, err := strconv.ParseInt(pageSize, 10, 32)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page_size: %q", pageSize))
+				}
+				req.PageSize = int32(size)
+			}
+			if include := r.URL.Query().Get("include_power_state"); include != "" {
+				value, err := strconv.ParseBool(include)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid include_power_state: %q", include))
+				}
+				req.IncludePowerState = value
+			}
+			resp, err := h.service.ListServers(r.Context(), connect.NewRequest(req))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/api/v1/servers/{server_id}",
+		operationID: "GetServer",
+		summary:     "Get a server",
+		tag:         "Servers",
+		response:    &managerv1.Server{},
+		status:      http.StatusOK,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			resp, err := h.service.GetServer(r.Context(), connect.NewRequest(&managerv1.GetServerRequest{
+				ServerId: r.PathValue("server_id"),
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg.Server, nil
+		},
+	},
+	{
+		method:      http.MethodGet,
+		path:        "/api/v1/servers/{server_id}/power",
+		operationID: "GetPowerStatus",
+		summary:     "Get the power state of a server",
+		tag:         "Power",
+		response:    &gatewayv1.PowerStatusResponse{},
+		status:      http.StatusOK,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			serverID := r.PathValue("server_id")
+			gateway, err := h.callerGatewayClient(r, serverID)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := gateway.GetPowerStatus(r.Context(), connect.NewRequest(&gatewayv1.PowerStatusRequest{ServerId: serverID}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	},
+	powerRoute("on", "PowerOn", "Power a server on", gatewayv1connect.GatewayServiceClient.PowerOn),
+	powerRoute("off", "PowerOff", "Power a server off", gatewayv1connect.GatewayServiceClient.PowerOff),
+	powerRoute("cycle", "PowerCycle", "Power cycle a server", gatewayv1connect.GatewayServiceClient.PowerCycle),
+	powerRoute("reset", "Reset", "Reset a server", gatewayv1connect.GatewayServiceClient.Reset),
+	{
+		method:      http.MethodPost,
+		path:        "/api/v1/servers/{server_id}/vnc-sessions",
+		operationID: "CreateVNCSession",
+		summary:     "Create a VNC console session",
+		tag:         "Console",
+		response:    &gatewayv1.CreateVNCSessionResponse{},
+		status:      http.StatusCreated,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			serverID := r.PathValue("server_id")
+			gateway, err := h.callerGatewayClient(r, serverID)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := gateway.CreateVNCSession(r.Context(), connect.NewRequest(&gatewayv1.CreateVNCSessionRequest{ServerId: serverID}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/api/v1/servers/{server_id}/sol-sessions",
+		operationID: "CreateSOLSession",
+		summary:     "Create a serial console (SOL) session",
+		tag:         "Console",
+		response:    &gatewayv1.CreateSOLSessionResponse{},
+		status:      http.StatusCreated,
+		handle: func(h *Handler, r *http.Request) (proto.Message, error) {
+			serverID := r.PathValue("server_id")
+			gateway, err := h.callerGatewayClient(r, serverID)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := gateway.CreateSOLSession(r.Context(), connect.NewRequest(&gatewayv1.CreateSOLSessionRequest{ServerId: serverID}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+	},
+}
+
+// Handler serves the REST API
+type Handler struct {
+	service    Service
+	httpClient connect.HTTPClient // Client of the gateways
+	mux        *http.ServeMux
+	openAPI    []byte
+}
+
+// NewHandler creates a REST handler translating requests to service. Requests
+// forwarded to gateways time out after gatewayTimeout.
+func NewHandler(service Service, gatewayTimeout time.Duration) *Handler {
+	h := &Handler{
+		service:    service,
+		httpClient: &http.Client{Timeout: gatewayTimeout},
+		mux:        http.NewServeMux(),
+	}
+
+	openAPI, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode OpenAPI document: %v", err))
+	}
+	h.openAPI = openAPI
+
+	for _, rt := range routes {
+		h.mux.HandleFunc(rt.method+" "+rt.path, h.serve(rt))
+	}
+	h.mux.HandleFunc("GET /api/v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(h.openAPI)
+	})
+	h.mux.HandleFunc("GET "+InventoryPath, h.serveAnsibleInventory)
+	h.mux.HandleFunc(PathPrefix, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, connect.NewError(connect.CodeNotFound, fmt.Errorf("no route for %s %s", r.Method, r.URL.Path)))
+	})
+
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// serve returns the HTTP handler of a route
+func (h *Handler) serve(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !rt.public {
+			ctx, err := h.service.Authorize(r.Context(), r.Header.Get("Authorization"))
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			r = r.WithContext(ctx)
+		}
+
+		msg, err := rt.handle(h, r)
+		if err != nil {
+			log.Debug().
+				Err(err).
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Msg("REST request failed")
+			writeError(w, err)
+			return
+		}
+
+		body, err := protojson.Marshal(msg)
+		if err != nil {
+			writeError(w, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to marshal response: %w", err)))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(rt.status)
+		w.Write(body)
+	}
+}
+
+// callerGatewayClient returns the gatewayClient of a server for the caller
+// of r. Gateways see the manager's address rather than the caller's, so the
+// IP allowlist of the caller is enforced here.
+func (h *Handler) callerGatewayClient(r *http.Request, serverID string) (gatewayv1connect.GatewayServiceClient, error) {
+	if err := h.service.CheckSourceAllowed(r.Context(), r.RemoteAddr); err != nil {
+		return nil, err
+	}
+	return h.gatewayClient(r.Context(), serverID)
+}
+
+// gatewayClient returns a client of the gateway serving a server,
+// authenticated with a server token of the caller
+func (h *Handler) gatewayClient(ctx context.Context, serverID string) (gatewayv1connect.GatewayServiceClient, error) {
+	token, err := h.service.GetServerToken(ctx, connect.NewRequest(&managerv1.GetServerTokenRequest{ServerId: serverID}))
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := h.service.GetServerLocation(ctx, connect.NewRequest(&managerv1.GetServerLocationRequest{ServerId: serverID}))
+	if err != nil {
+		return nil, err
+	}
+
+	return gatewayv1connect.NewGatewayServiceClient(
+		h.httpClient,
+		location.Msg.RegionalGatewayEndpoint,
+		connect.WithInterceptors(tracing.NewInterceptor(), bearerToken(token.Msg.Token)),
+	), nil
+}
+
+// bearerToken creates an interceptor setting the Authorization header
+func bearerToken(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", "Bearer "+token)
+			return next(ctx, req)
+		}
+	}
+}
+
+// decodeBody decodes a JSON request body into msg
+func decodeBody(r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to read request body: %w", err))
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid request body: %w", err))
+	}
+	return nil
+}
+
+// errorBody is the JSON body of error responses
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError writes err with the HTTP status of its Connect code
+func writeError(w http.ResponseWriter, err error) {
+	code := connect.CodeInternal
+	message := err.Error()
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		code = connectErr.Code()
+		message = connectErr.Message()
+	}
+
+	body, _ := json.Marshal(errorBody{Code: code.String(), Message: message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(code))
+	w.Write(body)
+}
+
+// httpStatus maps a Connect error code to an HTTP status, as the Connect
+// protocol does
+func httpStatus(code connect.Code) int {
+	switch code {
+	case connect.CodeCanceled:
+		return 499
+	case connect.CodeInvalidArgument, connect.CodeFailedPrecondition, connect.CodeOutOfRange:
+		return http.StatusBadRequest
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted:
+		return http.StatusConflict
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
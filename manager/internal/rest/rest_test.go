@@ -0,0 +1,358 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "core/gen/common/v1"
+	gatewayv1 "gateway/gen/gateway/v1"
+	"gateway/gen/gateway/v1/gatewayv1connect"
+	managerv1 "manager/gen/manager/v1"
+)
+
+// fakeService serves the manager RPCs used by the REST handler
+type fakeService struct {
+	gatewayURL        string
+	pageSize          int32
+	includePowerState bool
+	deniedSource      string
+}
+
+func (s *fakeService) Authorize(ctx context.Context, authHeader string) (context.Context, error) {
+	if authHeader != "Bearer access-token" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token"))
+	}
+	return ctx, nil
+}
+
+func (s *fakeService) AuthorizeAPIKey(ctx context.Context, apiKey string) (context.Context, error) {
+	if apiKey != "api-key" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid API key"))
+	}
+	return ctx, nil
+}
+
+func (s *fakeService) Authenticate(ctx context.Context, req *connect.Request[managerv1.AuthenticateRequest]) (*connect.Response[managerv1.AuthenticateResponse], error) {
+	return connect.NewResponse(&managerv1.AuthenticateResponse{
+		AccessToken: "access-token",
+		Customer:    &managerv1.Customer{Email: req.Msg.Email},
+	}), nil
+}
+
+func (s *fakeService) ListServers(ctx context.Context, req *connect.Request[managerv1.ListServersRequest]) (*connect.Response[managerv1.ListServersResponse], error) {
+	s.pageSize = req.Msg.PageSize
+	s.includePowerState = req.Msg.IncludePowerState
+	if req.Msg.PageToken == "next" {
+		return connect.NewResponse(&managerv1.ListServersResponse{
+			Servers: []*managerv1.Server{{
+				Id:               "srv-2",
+				DatacenterId:     "dc-1",
+				PrimaryProtocol:  commonv1.BMCType_BMC_REDFISH,
+				ControlEndpoints: []*commonv1.BMCControlEndpoint{{Endpoint: "https://10.0.0.2", Type: commonv1.BMCType_BMC_REDFISH}},
+			}},
+		}), nil
+	}
+	return connect.NewResponse(&managerv1.ListServersResponse{
+		Servers: []*managerv1.Server{{
+			Id:               "srv-1",
+			DatacenterId:     "dc-1",
+			PrimaryProtocol:  commonv1.BMCType_BMC_IPMI,
+			ControlEndpoints: []*commonv1.BMCControlEndpoint{{Endpoint: "10.0.0.1:623", Type: commonv1.BMCType_BMC_IPMI}},
+			Metadata:         map[string]string{"role": "k8s-worker"},
+			ExternalId:       "cmdb-1",
+		}},
+		NextPageToken: "next",
+	}), nil
+}
+
+func (s *fakeService) GetServer(ctx context.Context, req *connect.Request[managerv1.GetServerRequest]) (*connect.Response[managerv1.GetServerResponse], error) {
+	if req.Msg.ServerId != "srv-1" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("server not found: %s", req.Msg.ServerId))
+	}
+	return connect.NewResponse(&managerv1.GetServerResponse{Server: &managerv1.Server{Id: "srv-1"}}), nil
+}
+
+func (s *fakeService) GetServerToken(ctx context.Context, req *connect.Request[managerv1.GetServerTokenRequest]) (*connect.Response[managerv1.GetServerTokenResponse], error) {
+	return connect.NewResponse(&managerv1.GetServerTokenResponse{Token: "server-token-" + req.Msg.ServerId}), nil
+}
+
+func (s *fakeService) GetServerLocation(ctx context.Context, req *connect.Request[managerv1.GetServerLocationRequest]) (*connect.Response[managerv1.GetServerLocationResponse], error) {
+	return connect.NewResponse(&managerv1.GetServerLocationResponse{RegionalGatewayEndpoint: s.gatewayURL}), nil
+}
+
+func (s *fakeService) CheckSourceAllowed(ctx context.Context, clientAddress string) error {
+	if clientAddress == s.deniedSource {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("source address %s is not allowed", clientAddress))
+	}
+	return nil
+}
+
+// fakeGateway serves the power and console RPCs
+type fakeGateway struct {
+	gatewayv1connect.UnimplementedGatewayServiceHandler
+	authHeader     string
+	operation      string
+	idempotencyKey string
+}
+
+func (g *fakeGateway) PowerCycle(ctx context.Context, req *connect.Request[gatewayv1.PowerOperationRequest]) (*connect.Response[gatewayv1.PowerOperationResponse], error) {
+	g.authHeader = req.Header().Get("Authorization")
+	g.operation = "cycle " + req.Msg.ServerId
+	g.idempotencyKey = req.Msg.IdempotencyKey
+	return connect.NewResponse(&gatewayv1.PowerOperationResponse{Success: true, Message: "Power cycled"}), nil
+}
+
+func (g *fakeGateway) GetPowerStatus(ctx context.Context, req *connect.Request[gatewayv1.PowerStatusRequest]) (*connect.Response[gatewayv1.PowerStatusResponse], error) {
+	if req.Msg.ServerId == "srv-2" {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("BMC unreachable"))
+	}
+	return connect.NewResponse(&gatewayv1.PowerStatusResponse{State: gatewayv1.PowerState_POWER_STATE_ON}), nil
+}
+
+func (g *fakeGateway) CreateSOLSession(ctx context.Context, req *connect.Request[gatewayv1.CreateSOLSessionRequest]) (*connect.Response[gatewayv1.CreateSOLSessionResponse], error) {
+	return connect.NewResponse(&gatewayv1.CreateSOLSessionResponse{SessionId: "sol-1", ConsoleUrl: "https://gateway/console/sol-1"}), nil
+}
+
+func setupTestHandler(t *testing.T) (*Handler, *fakeService, *fakeGateway) {
+	t.Helper()
+
+	gateway := &fakeGateway{}
+	_, gatewayHandler := gatewayv1connect.NewGatewayServiceHandler(gateway)
+	server := httptest.NewServer(gatewayHandler)
+	t.Cleanup(server.Close)
+
+	service := &fakeService{gatewayURL: server.URL}
+	return NewHandler(service, 5*time.Second), service, gateway
+}
+
+// do serves a request with the access token and decodes the JSON response
+func do(t *testing.T, h *Handler, method, path, body string) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer access-token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	return rec.Code, decoded
+}
+
+func TestAuthenticate(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", strings.NewReader(`{"email": "ops@example.com", "password": "secret"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"accessToken":"access-token"`)
+	assert.Contains(t, rec.Body.String(), `"email":"ops@example.com"`)
+
+	status, body := do(t, h, http.MethodPost, "/api/v1/auth/token", `{"email": 42}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_argument", body["code"])
+}
+
+func TestListServers(t *testing.T) {
+	h, service, _ := setupTestHandler(t)
+
+	status, body := do(t, h, http.MethodGet, "/api/v1/servers?page_size=10", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int32(10), service.pageSize)
+	assert.Equal(t, "next", body["nextPageToken"])
+	require.Len(t, body["servers"], 1)
+	assert.Equal(t, "srv-1", body["servers"].([]any)[0].(map[string]any)["id"])
+
+	status, _ = do(t, h, http.MethodGet, "/api/v1/servers?page_size=many", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.False(t, service.includePowerState)
+
+	status, _ = do(t, h, http.MethodGet, "/api/v1/servers?include_power_state=true", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.True(t, service.includePowerState)
+
+	status, _ = do(t, h, http.MethodGet, "/api/v1/servers?include_power_state=maybe", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestRequiresAccessToken(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"code": "unauthenticated", "message": "invalid token"}`, rec.Body.String())
+}
+
+func TestGetServer(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	status, body := do(t, h, http.MethodGet, "/api/v1/servers/srv-1", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "srv-1", body["id"])
+
+	status, body = do(t, h, http.MethodGet, "/api/v1/servers/srv-404", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "not_found", body["code"])
+	assert.Equal(t, "server not found: srv-404", body["message"])
+}
+
+func TestPowerOperations(t *testing.T) {
+	h, _, gateway := setupTestHandler(t)
+
+	status, body := do(t, h, http.MethodPost, "/api/v1/servers/srv-1/power/cycle", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, true, body["success"])
+	assert.Equal(t, "cycle srv-1", gateway.operation)
+	assert.Equal(t, "Bearer server-token-srv-1", gateway.authHeader)
+	assert.Empty(t, gateway.idempotencyKey)
+
+	// The Idempotency-Key header is passed on to the gateway
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/servers/srv-1/power/cycle", nil)
+	req.Header.Set("Authorization", "Bearer access-token")
+	req.Header.Set("Idempotency-Key", "retry-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "retry-1", gateway.idempotencyKey)
+
+	status, body = do(t, h, http.MethodGet, "/api/v1/servers/srv-1/power", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "POWER_STATE_ON", body["state"])
+
+	// Gateway errors keep their code
+	status, body = do(t, h, http.MethodPost, "/api/v1/servers/srv-1/power/on", "")
+	assert.Equal(t, http.StatusNotImplemented, status)
+	assert.Equal(t, "unimplemented", body["code"])
+}
+
+func TestPowerOperationsSourceAllowlist(t *testing.T) {
+	h, service, gateway := setupTestHandler(t)
+
+	// httptest requests come from 192.0.2.1
+	service.deniedSource = "192.0.2.1:1234"
+	status, body := do(t, h, http.MethodPost, "/api/v1/servers/srv-1/power/cycle", "")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "permission_denied", body["code"])
+	assert.Empty(t, gateway.operation)
+}
+
+func TestCreateSOLSession(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	status, body := do(t, h, http.MethodPost, "/api/v1/servers/srv-1/sol-sessions", "")
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "sol-1", body["sessionId"])
+	assert.Equal(t, "https://gateway/console/sol-1", body["consoleUrl"])
+}
+
+func TestUnknownRoute(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	status, body := do(t, h, http.MethodGet, "/api/v1/racks", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "not_found", body["code"])
+}
+
+func TestAnsibleInventory(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	get := func(query, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/inventory/ansible"+query, nil)
+		req.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("", "api-key")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var inventory map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &inventory))
+
+	group := func(name string) ansibleGroup {
+		var g ansibleGroup
+		require.Contains(t, inventory, name)
+		require.NoError(t, json.Unmarshal(inventory[name], &g))
+		return g
+	}
+	assert.Equal(t, []string{"srv-1", "srv-2"}, group("datacenter_dc_1").Hosts)
+	assert.Equal(t, []string{"srv-1"}, group("bmc_ipmi").Hosts)
+	assert.Equal(t, []string{"srv-2"}, group("bmc_redfish").Hosts)
+	assert.Equal(t, []string{"srv-1"}, group("label_role_k8s_worker").Hosts)
+	assert.Equal(t, []string{"srv-1"}, group("power_on").Hosts)
+	assert.Equal(t, []string{"srv-2"}, group("power_unknown").Hosts)
+	assert.Contains(t, group("all").Children, "datacenter_dc_1")
+
+	var meta struct {
+		Hostvars map[string]map[string]any `json:"hostvars"`
+	}
+	require.NoError(t, json.Unmarshal(inventory["_meta"], &meta))
+	assert.Equal(t, "10.0.0.1:623", meta.Hostvars["srv-1"]["conduit_bmc_endpoint"])
+	assert.Equal(t, "cmdb-1", meta.Hostvars["srv-1"]["conduit_external_id"])
+	assert.Equal(t, "on", meta.Hostvars["srv-1"]["conduit_power_state"])
+
+	// Power states are not queried when disabled
+	rec = get("?power_state=false", "api-key")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "power_on")
+
+	rec = get("", "wrong-key")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"code": "unauthenticated", "message": "invalid API key"}`, rec.Body.String())
+}
+
+func TestOpenAPI(t *testing.T) {
+	h, _, _ := setupTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	for _, rt := range routes {
+		op, ok := doc.Paths[rt.path][strings.ToLower(rt.method)]
+		if assert.True(t, ok, "missing operation %s %s", rt.method, rt.path) {
+			assert.Equal(t, rt.operationID, op["operationId"])
+		}
+	}
+
+	// Every reference resolves to a schema
+	refs := 0
+	for _, match := range strings.Split(rec.Body.String(), `"$ref": "#/components/schemas/`)[1:] {
+		name := match[:strings.Index(match, `"`)]
+		assert.Contains(t, doc.Components.Schemas, name)
+		refs++
+	}
+	assert.Positive(t, refs)
+
+	assert.Contains(t, doc.Paths["/api/v1/inventory/ansible"], "get")
+
+	server := doc.Components.Schemas["manager.v1.Server"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, server["createdAt"])
+	assert.Equal(t, "object", server["metadata"].(map[string]any)["type"])
+}
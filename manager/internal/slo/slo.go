@@ -0,0 +1,92 @@
+// Package slo computes console availability SLO compliance and error budgets
+// from the session measurements reported by gateways.
+package slo
+
+import "time"
+
+// Objectives are the console availability service level objectives
+type Objectives struct {
+	// AvailabilityTarget is the required session establishment success rate
+	AvailabilityTarget float64
+	// TTFBTarget is the required fraction of sessions whose first byte
+	// arrives within TTFBThreshold
+	TTFBTarget float64
+	// TTFBThreshold is the time-to-first-byte objective for a single session
+	TTFBThreshold time.Duration
+	// Window is the rolling window the objectives are evaluated over
+	Window time.Duration
+}
+
+// Counts are the SLI counters for a group of console sessions
+type Counts struct {
+	Attempts            int64
+	Successes           int64
+	TTFBSamples         int64
+	TTFBWithinThreshold int64
+	TTFBSumMs           int64
+}
+
+// Add accumulates other into c
+func (c *Counts) Add(other Counts) {
+	c.Attempts += other.Attempts
+	c.Successes += other.Successes
+	c.TTFBSamples += other.TTFBSamples
+	c.TTFBWithinThreshold += other.TTFBWithinThreshold
+	c.TTFBSumMs += other.TTFBSumMs
+}
+
+// Status is the SLO compliance of a group of console sessions
+type Status struct {
+	Counts
+
+	SuccessRate          float64
+	AvailabilityMet      bool
+	ErrorBudgetRemaining float64
+	TTFBCompliance       float64
+	TTFBMet              bool
+	AvgTTFBMs            float64
+}
+
+// Evaluate computes SLO compliance for counts against the objectives.
+// Groups without any sessions are reported as compliant with a full budget.
+func Evaluate(counts Counts, objectives Objectives) Status {
+	status := Status{
+		Counts:               counts,
+		SuccessRate:          1,
+		ErrorBudgetRemaining: 1,
+		TTFBCompliance:       1,
+	}
+
+	if counts.Attempts > 0 {
+		status.SuccessRate = float64(counts.Successes) / float64(counts.Attempts)
+		status.ErrorBudgetRemaining = errorBudgetRemaining(counts, objectives.AvailabilityTarget)
+	}
+
+	if counts.TTFBSamples > 0 {
+		status.TTFBCompliance = float64(counts.TTFBWithinThreshold) / float64(counts.TTFBSamples)
+		status.AvgTTFBMs = float64(counts.TTFBSumMs) / float64(counts.TTFBSamples)
+	}
+
+	status.AvailabilityMet = status.SuccessRate >= objectives.AvailabilityTarget
+	status.TTFBMet = status.TTFBCompliance >= objectives.TTFBTarget
+
+	return status
+}
+
+// errorBudgetRemaining returns the fraction of allowed failures not yet used.
+// The budget is (1 - target) of all attempts; the result goes negative once
+// more sessions failed than the objective allows.
+func errorBudgetRemaining(counts Counts, target float64) float64 {
+	failures := float64(counts.Attempts - counts.Successes)
+	allowed := (1 - target) * float64(counts.Attempts)
+
+	if allowed <= 0 {
+		// A 100% target has no budget to spend
+		if failures == 0 {
+			return 1
+		}
+		return 0
+	}
+
+	return 1 - failures/allowed
+}
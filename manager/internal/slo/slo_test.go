@@ -0,0 +1,101 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	objectives := Objectives{
+		AvailabilityTarget: 0.99,
+		TTFBTarget:         0.9,
+		TTFBThreshold:      2 * time.Second,
+		Window:             24 * time.Hour,
+	}
+
+	tests := []struct {
+		name                 string
+		counts               Counts
+		successRate          float64
+		availabilityMet      bool
+		errorBudgetRemaining float64
+		ttfbCompliance       float64
+		ttfbMet              bool
+		avgTTFBMs            float64
+	}{
+		{
+			name:                 "no sessions",
+			counts:               Counts{},
+			successRate:          1,
+			availabilityMet:      true,
+			errorBudgetRemaining: 1,
+			ttfbCompliance:       1,
+			ttfbMet:              true,
+		},
+		{
+			name: "no failures",
+			counts: Counts{
+				Attempts: 200, Successes: 200,
+				TTFBSamples: 100, TTFBWithinThreshold: 100, TTFBSumMs: 50000,
+			},
+			successRate:          1,
+			availabilityMet:      true,
+			errorBudgetRemaining: 1,
+			ttfbCompliance:       1,
+			ttfbMet:              true,
+			avgTTFBMs:            500,
+		},
+		{
+			name: "half the budget spent",
+			counts: Counts{
+				Attempts: 200, Successes: 199,
+				TTFBSamples: 10, TTFBWithinThreshold: 8, TTFBSumMs: 10000,
+			},
+			successRate:          0.995,
+			availabilityMet:      true,
+			errorBudgetRemaining: 0.5,
+			ttfbCompliance:       0.8,
+			ttfbMet:              false,
+			avgTTFBMs:            1000,
+		},
+		{
+			name:                 "budget overspent",
+			counts:               Counts{Attempts: 100, Successes: 97},
+			successRate:          0.97,
+			availabilityMet:      false,
+			errorBudgetRemaining: -2,
+			ttfbCompliance:       1,
+			ttfbMet:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := Evaluate(tt.counts, objectives)
+
+			assert.Equal(t, tt.counts, status.Counts)
+			assert.InDelta(t, tt.successRate, status.SuccessRate, 1e-9)
+			assert.Equal(t, tt.availabilityMet, status.AvailabilityMet)
+			assert.InDelta(t, tt.errorBudgetRemaining, status.ErrorBudgetRemaining, 1e-9)
+			assert.InDelta(t, tt.ttfbCompliance, status.TTFBCompliance, 1e-9)
+			assert.Equal(t, tt.ttfbMet, status.TTFBMet)
+			assert.InDelta(t, tt.avgTTFBMs, status.AvgTTFBMs, 1e-9)
+		})
+	}
+}
+
+func TestEvaluate_FullTarget(t *testing.T) {
+	objectives := Objectives{AvailabilityTarget: 1, TTFBTarget: 1}
+
+	assert.Equal(t, 1.0, Evaluate(Counts{Attempts: 10, Successes: 10}, objectives).ErrorBudgetRemaining)
+	assert.Equal(t, 0.0, Evaluate(Counts{Attempts: 10, Successes: 9}, objectives).ErrorBudgetRemaining)
+}
+
+func TestCounts_Add(t *testing.T) {
+	total := Counts{Attempts: 1, Successes: 1, TTFBSamples: 1, TTFBWithinThreshold: 1, TTFBSumMs: 100}
+	total.Add(Counts{Attempts: 2, Successes: 1, TTFBSamples: 1, TTFBWithinThreshold: 0, TTFBSumMs: 3000})
+
+	assert.Equal(t, Counts{Attempts: 3, Successes: 2, TTFBSamples: 2, TTFBWithinThreshold: 1, TTFBSumMs: 3100}, total)
+}
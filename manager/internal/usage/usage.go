@@ -0,0 +1,125 @@
+// Package usage aggregates the console sessions, power operations and console
+// data of customers per calendar month, and exports it for billing.
+package usage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MonthLayout is the layout of months, e.g. 2024-06
+const MonthLayout = "2006-01"
+
+// Export formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Record is a use of a server by a customer: a console stream, with its
+// duration and the data it transferred, or a power operation
+type Record struct {
+	CustomerID string
+	Console    bool // A console stream rather than a power operation
+	Duration   time.Duration
+	BytesIn    int64 // Console data sent by the client
+	BytesOut   int64 // Console data sent to the client
+}
+
+// CustomerUsage is the usage of a customer over a month
+type CustomerUsage struct {
+	CustomerID      string  `json:"customer_id"`
+	ConsoleSessions int64   `json:"console_sessions"`
+	ConsoleMinutes  float64 `json:"console_minutes"`
+	PowerOperations int64   `json:"power_operations"`
+	BytesIn         int64   `json:"bytes_in"`
+	BytesOut        int64   `json:"bytes_out"`
+}
+
+// ParseMonth returns the bounds [start, end) of a month in UTC, e.g. 2024-06
+func ParseMonth(month string) (start, end time.Time, err error) {
+	start, err = time.Parse(MonthLayout, month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid month %q, expected YYYY-MM", month)
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// Aggregate returns the usage of each customer of records, ordered by
+// customer ID
+func Aggregate(records []Record) []CustomerUsage {
+	byCustomer := make(map[string]*CustomerUsage)
+	durations := make(map[string]time.Duration)
+	for _, record := range records {
+		usage, exists := byCustomer[record.CustomerID]
+		if !exists {
+			usage = &CustomerUsage{CustomerID: record.CustomerID}
+			byCustomer[record.CustomerID] = usage
+		}
+		if !record.Console {
+			usage.PowerOperations++
+			continue
+		}
+		usage.ConsoleSessions++
+		usage.BytesIn += record.BytesIn
+		usage.BytesOut += record.BytesOut
+		durations[record.CustomerID] += record.Duration
+	}
+
+	usages := make([]CustomerUsage, 0, len(byCustomer))
+	for customerID, usage := range byCustomer {
+		usage.ConsoleMinutes = durations[customerID].Minutes()
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].CustomerID < usages[j].CustomerID
+	})
+	return usages
+}
+
+// Export encodes the usage of customers over a month as a CSV document with
+// a header row, or as a JSON document. It returns the document and its
+// content type.
+func Export(month string, usages []CustomerUsage, format string) ([]byte, string, error) {
+	switch format {
+	case FormatCSV, "":
+		data, err := exportCSV(month, usages)
+		return data, "text/csv", err
+	case FormatJSON:
+		if usages == nil {
+			usages = []CustomerUsage{}
+		}
+		data, err := json.MarshalIndent(struct {
+			Month     string          `json:"month"`
+			Customers []CustomerUsage `json:"customers"`
+		}{month, usages}, "", "  ")
+		return append(data, '\n'), "application/json", err
+	default:
+		return nil, "", fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatCSV, FormatJSON)
+	}
+}
+
+// exportCSV encodes usages with one row per customer
+func exportCSV(month string, usages []CustomerUsage) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"month", "customer_id", "console_sessions", "console_minutes", "power_operations", "bytes_in", "bytes_out"})
+	for _, usage := range usages {
+		w.Write([]string{
+			month,
+			usage.CustomerID,
+			strconv.FormatInt(usage.ConsoleSessions, 10),
+			strconv.FormatFloat(usage.ConsoleMinutes, 'f', 2, 64),
+			strconv.FormatInt(usage.PowerOperations, 10),
+			strconv.FormatInt(usage.BytesIn, 10),
+			strconv.FormatInt(usage.BytesOut, 10),
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,95 @@
+package usage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMonth(t *testing.T) {
+	start, end, err := ParseMonth("2024-06")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), end)
+
+	start, end, err = ParseMonth("2024-12")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, 31*24*time.Hour, end.Sub(start))
+
+	for _, month := range []string{"", "2024-6", "2024-13", "2024-06-01", "June"} {
+		_, _, err := ParseMonth(month)
+		assert.Error(t, err, month)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	usages := Aggregate([]Record{
+		{CustomerID: "cust-2"},
+		{CustomerID: "cust-1", Console: true, Duration: 90 * time.Second, BytesIn: 10, BytesOut: 1000},
+		{CustomerID: "cust-1"},
+		{CustomerID: "cust-1", Console: true, Duration: 30 * time.Second, BytesIn: 5, BytesOut: 500},
+		{CustomerID: "cust-1"},
+	})
+
+	require.Len(t, usages, 2)
+	assert.Equal(t, CustomerUsage{
+		CustomerID:      "cust-1",
+		ConsoleSessions: 2,
+		ConsoleMinutes:  2,
+		PowerOperations: 2,
+		BytesIn:         15,
+		BytesOut:        1500,
+	}, usages[0])
+	assert.Equal(t, CustomerUsage{CustomerID: "cust-2", PowerOperations: 1}, usages[1])
+
+	assert.Empty(t, Aggregate(nil))
+}
+
+func TestExport(t *testing.T) {
+	usages := []CustomerUsage{
+		{CustomerID: "cust-1", ConsoleSessions: 2, ConsoleMinutes: 12.5, PowerOperations: 3, BytesIn: 15, BytesOut: 1500},
+		{CustomerID: "cust-2", PowerOperations: 1},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		data, contentType, err := Export("2024-06", usages, FormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, "text/csv", contentType)
+		assert.Equal(t, "month,customer_id,console_sessions,console_minutes,power_operations,bytes_in,bytes_out\n"+
+			"2024-06,cust-1,2,12.50,3,15,1500\n"+
+			"2024-06,cust-2,0,0.00,1,0,0\n", string(data))
+
+		// CSV is the default
+		defaulted, _, err := Export("2024-06", usages, "")
+		require.NoError(t, err)
+		assert.Equal(t, data, defaulted)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, contentType, err := Export("2024-06", usages, FormatJSON)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+
+		var document struct {
+			Month     string          `json:"month"`
+			Customers []CustomerUsage `json:"customers"`
+		}
+		require.NoError(t, json.Unmarshal(data, &document))
+		assert.Equal(t, "2024-06", document.Month)
+		assert.Equal(t, usages, document.Customers)
+
+		// No usage is an empty list rather than null
+		data, _, err = Export("2024-06", nil, FormatJSON)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"customers": []`)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, _, err := Export("2024-06", usages, "xml")
+		assert.ErrorContains(t, err, `unsupported format "xml"`)
+	})
+}
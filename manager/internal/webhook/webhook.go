@@ -0,0 +1,272 @@
+// Package webhook delivers system events to HTTP endpoints configured by
+// operators. Each event is POSTed as JSON and signed with HMAC-SHA256 so that
+// receivers can verify it came from the manager. Failed deliveries are
+// retried with exponential backoff, and every attempt is recorded in the
+// delivery log.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/events"
+	"manager/internal/database"
+	"manager/internal/metrics"
+)
+
+// Headers set on every delivery
+const (
+	EventHeader     = "X-Conduit-Event"     // Event type
+	DeliveryHeader  = "X-Conduit-Delivery"  // Event ID, identical across retries
+	TimestampHeader = "X-Conduit-Timestamp" // Unix time of the attempt, in seconds
+	SignatureHeader = "X-Conduit-Signature" // "sha256=" followed by the hex HMAC
+)
+
+// Endpoint is an HTTP endpoint subscribed to events
+type Endpoint struct {
+	Name   string
+	URL    string
+	Secret string        // HMAC key shared with the receiver
+	Events []events.Type // Subscribed event types, empty for all
+}
+
+// subscribed reports whether the endpoint receives events of type t
+func (e Endpoint) subscribed(t events.Type) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range e.Events {
+		if subscribed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Config configures a Dispatcher
+type Config struct {
+	Timeout        time.Duration // Timeout of one delivery attempt
+	MaxAttempts    int           // Attempts per event and endpoint, including the first
+	InitialBackoff time.Duration // Wait before the first retry, doubled on each retry
+	MaxBackoff     time.Duration // Upper bound of the wait between retries
+	QueueSize      int           // Events buffered per endpoint before new ones are dropped
+}
+
+// Dispatcher delivers published events to the subscribed endpoints. Each
+// endpoint has its own queue and worker, so a slow or failing endpoint does
+// not delay deliveries to the others.
+type Dispatcher struct {
+	endpoints  []Endpoint
+	queues     []chan events.Event
+	deliveries database.WebhookDeliveryRepository
+	config     Config
+	client     *http.Client
+}
+
+// NewDispatcher creates a dispatcher for endpoints, recording attempts in
+// deliveries
+func NewDispatcher(endpoints []Endpoint, deliveries database.WebhookDeliveryRepository, config Config) *Dispatcher {
+	queues := make([]chan events.Event, len(endpoints))
+	for i := range queues {
+		queues[i] = make(chan events.Event, config.QueueSize)
+	}
+
+	return &Dispatcher{
+		endpoints:  endpoints,
+		queues:     queues,
+		deliveries: deliveries,
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Publish queues an event for delivery to the subscribed endpoints. It never
+// blocks: when an endpoint's queue is full the event is dropped for it.
+func (d *Dispatcher) Publish(event events.Event) {
+	for i, endpoint := range d.endpoints {
+		if !endpoint.subscribed(event.Type) {
+			continue
+		}
+
+		select {
+		case d.queues[i] <- event:
+		default:
+			metrics.WebhookEventsDroppedTotal.WithLabelValues(endpoint.Name, string(event.Type)).Inc()
+			log.Warn().
+				Str("endpoint", endpoint.Name).
+				Str("event_id", event.ID).
+				Str("event_type", string(event.Type)).
+				Msg("Webhook queue full, dropping event")
+		}
+	}
+}
+
+// Start delivers queued events until ctx ends
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := range d.endpoints {
+		go func(endpoint Endpoint, queue <-chan events.Event) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-queue:
+					d.deliver(ctx, endpoint, event)
+				}
+			}
+		}(d.endpoints[i], d.queues[i])
+	}
+}
+
+// StartRetention starts a goroutine that periodically deletes delivery log
+// entries older than the retention period
+func (d *Dispatcher) StartRetention(ctx context.Context, retention time.Duration) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := d.deliveries.DeleteBefore(ctx, time.Now().UTC().Add(-retention))
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to prune webhook delivery log")
+			} else if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("Pruned expired webhook deliveries")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// deliver sends an event to an endpoint, retrying until it is accepted, the
+// attempts are exhausted or the endpoint rejects it permanently
+func (d *Dispatcher) deliver(ctx context.Context, endpoint Endpoint, event events.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	backoff := d.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		statusCode, err := d.send(ctx, endpoint, event, body)
+		d.record(ctx, endpoint, event, attempt, start, statusCode, err)
+
+		if err == nil {
+			return nil
+		}
+		if !retryable(statusCode) || attempt >= d.config.MaxAttempts {
+			log.Error().
+				Err(err).
+				Str("endpoint", endpoint.Name).
+				Str("event_id", event.ID).
+				Str("event_type", string(event.Type)).
+				Int("attempts", attempt).
+				Msg("Webhook delivery failed")
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, d.config.MaxBackoff)
+	}
+}
+
+// send makes one delivery attempt and returns the response status code, 0
+// when no response was received
+func (d *Dispatcher) send(ctx context.Context, endpoint Endpoint, event events.Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "conduit-bmc-manager")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record adds an attempt to the delivery log and metrics
+func (d *Dispatcher) record(ctx context.Context, endpoint Endpoint, event events.Event, attempt int, start time.Time, statusCode int, err error) {
+	delivery := &database.WebhookDelivery{
+		EventID:     event.ID,
+		EventType:   string(event.Type),
+		Endpoint:    endpoint.Name,
+		URL:         endpoint.URL,
+		Attempt:     attempt,
+		StatusCode:  statusCode,
+		Success:     err == nil,
+		DurationMs:  time.Since(start).Milliseconds(),
+		AttemptedAt: start,
+	}
+
+	status := "success"
+	if err != nil {
+		status = "error"
+		delivery.Error = err.Error()
+	}
+	metrics.WebhookDeliveriesTotal.WithLabelValues(endpoint.Name, string(event.Type), status).Inc()
+
+	// Record the attempt even when ctx was cancelled during shutdown
+	if err := d.deliveries.Record(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Warn().Err(err).Str("event_id", event.ID).Msg("Failed to record webhook delivery")
+	}
+}
+
+// retryable reports whether a failed attempt with the given status code may
+// succeed later. Requests without a response, server errors, timeouts and
+// rate limiting are retried; other client errors are not.
+func retryable(statusCode int) bool {
+	switch {
+	case statusCode == 0:
+		return true
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= 500
+	}
+}
+
+// Sign returns the signature header value of a delivery: the HMAC-SHA256 of
+// the timestamp, a dot and the body, keyed with the endpoint's secret.
+// Receivers recompute it to authenticate deliveries, and reject stale
+// timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"core/events"
+	"manager/internal/database"
+)
+
+func setupTestDB(t *testing.T) *database.BunDB {
+	t.Helper()
+
+	db, err := database.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func testConfig() Config {
+	return Config{
+		Timeout:        time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		QueueSize:      10,
+	}
+}
+
+func TestDeliverSignsEvents(t *testing.T) {
+	db := setupTestDB(t)
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	endpoint := Endpoint{Name: "ops", URL: server.URL, Secret: "webhook-secret"}
+	dispatcher := NewDispatcher([]Endpoint{endpoint}, db.WebhookDeliveries, testConfig())
+
+	event := events.New(events.GatewayOffline, "manager", map[string]any{"gateway_id": "gw-1"})
+	require.NoError(t, dispatcher.deliver(context.Background(), endpoint, event))
+
+	req := <-received
+	assert.Equal(t, string(events.GatewayOffline), req.Header.Get(EventHeader))
+	assert.Equal(t, event.ID, req.Header.Get(DeliveryHeader))
+	assert.Equal(t, Sign("webhook-secret", req.Header.Get(TimestampHeader), body), req.Header.Get(SignatureHeader))
+	assert.NotEqual(t, Sign("other-secret", req.Header.Get(TimestampHeader), body), req.Header.Get(SignatureHeader))
+
+	var delivered events.Event
+	require.NoError(t, json.Unmarshal(body, &delivered))
+	assert.Equal(t, event.ID, delivered.ID)
+	assert.Equal(t, "gw-1", delivered.Data["gateway_id"])
+
+	deliveries, err := db.WebhookDeliveries.List(context.Background(), event.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Success)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].StatusCode)
+}
+
+func TestDeliverRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{"succeeds after server errors", []int{503, 500, 200}, false, 3},
+		{"gives up after max attempts", []int{503, 503, 503, 503}, true, 3},
+		{"client errors are not retried", []int{400, 200}, true, 1},
+		{"rate limiting is retried", []int{429, 200}, false, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[calls.Add(1)-1])
+			}))
+			defer server.Close()
+
+			endpoint := Endpoint{Name: "ops", URL: server.URL, Secret: "webhook-secret"}
+			dispatcher := NewDispatcher([]Endpoint{endpoint}, db.WebhookDeliveries, testConfig())
+
+			event := events.New(events.ServerDiscovered, "manager", nil)
+			err := dispatcher.deliver(context.Background(), endpoint, event)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			deliveries, err := db.WebhookDeliveries.List(context.Background(), event.ID, 10)
+			require.NoError(t, err)
+			require.Len(t, deliveries, tt.wantAttempts)
+			assert.Equal(t, tt.wantAttempts, deliveries[0].Attempt)
+			assert.Equal(t, !tt.wantErr, deliveries[0].Success)
+		})
+	}
+}
+
+func TestPublishFiltersSubscriptions(t *testing.T) {
+	db := setupTestDB(t)
+
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.URL.Path + " " + r.Header.Get(EventHeader)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher([]Endpoint{
+		{Name: "all", URL: server.URL + "/all"},
+		{Name: "power", URL: server.URL + "/power", Events: []events.Type{events.PowerOperationExecuted}},
+	}, db.WebhookDeliveries, testConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dispatcher.Start(ctx)
+
+	dispatcher.Publish(events.New(events.ConsoleSessionOpened, "manager", nil))
+	dispatcher.Publish(events.New(events.PowerOperationExecuted, "manager", nil))
+
+	var got []string
+	for range 3 {
+		select {
+		case delivery := <-received:
+			got = append(got, delivery)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for deliveries, got %v", got)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"/all console.session_opened",
+		"/all power.operation_executed",
+		"/power power.operation_executed",
+	}, got)
+}
@@ -9,6 +9,7 @@ import (
 var embedFS embed.FS
 
 var adminTemplates *template.Template
+var serverTemplates *template.Template
 var loginTemplates *template.Template
 
 func init() {
@@ -20,6 +21,12 @@ func init() {
 		panic("Failed to parse admin templates: " + err.Error())
 	}
 
+	// Parse server detail page templates
+	serverTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/server.html")
+	if err != nil {
+		panic("Failed to parse server templates: " + err.Error())
+	}
+
 	// Parse login templates
 	loginTemplates, err = template.ParseFS(embedFS, "templates/base.html", "templates/login.html")
 	if err != nil {
@@ -32,6 +39,11 @@ func GetAdminTemplates() *template.Template {
 	return adminTemplates
 }
 
+// GetServerTemplates returns the compiled server detail page templates
+func GetServerTemplates() *template.Template {
+	return serverTemplates
+}
+
 // GetLoginTemplates returns the compiled login templates
 func GetLoginTemplates() *template.Template {
 	return loginTemplates
@@ -7,8 +7,21 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"manager/pkg/auth"
+	"manager/pkg/models"
 )
 
+// themeCookieName is the cookie remembering the theme chosen in the web UI
+const themeCookieName = "bmc_theme"
+
+// requestTheme returns the theme of a page, "light" when chosen in the theme
+// cookie and "dark" otherwise
+func requestTheme(r *http.Request) string {
+	if cookie, err := r.Cookie(themeCookieName); err == nil && cookie.Value == "light" {
+		return "light"
+	}
+	return "dark"
+}
+
 // LoginHandler handles the login page
 type LoginHandler struct{}
 
@@ -23,6 +36,7 @@ func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	data := map[string]interface{}{
 		"Title":       "Admin Login - BMC Manager",
 		"HeaderTitle": "BMC Admin Login",
+		"Theme":       requestTheme(r),
 	}
 
 	templates := GetLoginTemplates()
@@ -72,6 +86,75 @@ func NewAdminDashboardHandler(jwtManager *auth.JWTManager) *AdminDashboardHandle
 
 // ServeHTTP handles requests to /admin
 func (h *AdminDashboardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, tokenString, ok := authenticateAdmin(w, r, h.jwtManager)
+	if !ok {
+		return
+	}
+
+	// Render the admin dashboard
+	data := map[string]interface{}{
+		"Title":       "Admin Dashboard - BMC Manager",
+		"HeaderTitle": "BMC Admin Dashboard",
+		"UserEmail":   claims.Email,
+		"Token":       tokenString,
+		"Theme":       requestTheme(r),
+	}
+
+	templates := GetAdminTemplates()
+	if err := templates.ExecuteTemplate(w, "base.html", data); err != nil {
+		log.Error().Err(err).Msg("Failed to render admin dashboard template")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// AdminServerHandler handles the server detail page of the admin web UI
+type AdminServerHandler struct {
+	jwtManager *auth.JWTManager
+}
+
+// NewAdminServerHandler creates a new server detail page handler
+func NewAdminServerHandler(jwtManager *auth.JWTManager) *AdminServerHandler {
+	return &AdminServerHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+// ServeHTTP handles requests to /admin/servers/{serverID}
+func (h *AdminServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serverID := r.PathValue("serverID")
+	if serverID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	claims, tokenString, ok := authenticateAdmin(w, r, h.jwtManager)
+	if !ok {
+		return
+	}
+
+	// Render the server page, which loads the server's details itself
+	data := map[string]interface{}{
+		"Title":       "Server " + serverID + " - BMC Manager",
+		"HeaderTitle": "Server " + serverID,
+		"UserEmail":   claims.Email,
+		"Token":       tokenString,
+		"ServerID":    serverID,
+		"Theme":       requestTheme(r),
+	}
+
+	templates := GetServerTemplates()
+	if err := templates.ExecuteTemplate(w, "base.html", data); err != nil {
+		log.Error().Err(err).Msg("Failed to render server template")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// authenticateAdmin validates the admin token of a request, from the
+// Authorization header or the auth_token cookie. When it is missing, invalid
+// or not an admin's, it writes the error response and returns false.
+func authenticateAdmin(w http.ResponseWriter, r *http.Request, jwtManager *auth.JWTManager) (*models.AuthClaims, string, bool) {
 	// Extract JWT token from Authorization header
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
@@ -84,43 +167,30 @@ func (h *AdminDashboardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	if authHeader == "" {
 		http.Error(w, "Unauthorized: Missing authentication", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	// Extract token from "Bearer <token>"
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	if tokenString == authHeader {
 		http.Error(w, "Unauthorized: Invalid authorization format", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	// Validate token
-	claims, err := h.jwtManager.ValidateToken(tokenString)
+	claims, err := jwtManager.ValidateToken(tokenString)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to validate token")
 		http.Error(w, "Unauthorized: Invalid token", http.StatusUnauthorized)
-		return
+		return nil, "", false
 	}
 
 	// Check if user is admin
 	if !claims.IsAdmin {
-		log.Warn().Str("email", claims.Email).Msg("Non-admin user attempted to access admin dashboard")
+		log.Warn().Str("email", claims.Email).Str("path", r.URL.Path).Msg("Non-admin user attempted to access admin dashboard")
 		http.Error(w, "Forbidden: Admin privileges required", http.StatusForbidden)
-		return
-	}
-
-	// Render the admin dashboard
-	data := map[string]interface{}{
-		"Title":       "Admin Dashboard - BMC Manager",
-		"HeaderTitle": "BMC Admin Dashboard",
-		"UserEmail":   claims.Email,
-		"Token":       tokenString,
+		return nil, "", false
 	}
 
-	templates := GetAdminTemplates()
-	if err := templates.ExecuteTemplate(w, "base.html", data); err != nil {
-		log.Error().Err(err).Msg("Failed to render admin dashboard template")
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
+	return claims, tokenString, true
 }
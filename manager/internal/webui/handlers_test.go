@@ -0,0 +1,97 @@
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"manager/pkg/auth"
+	"manager/pkg/models"
+)
+
+// TestAdminServerHandler ensures the server page is rendered for admins only
+func TestAdminServerHandler(t *testing.T) {
+	jwtManager := auth.NewJWTManager("test-secret-key-for-admin-server-page")
+	mux := http.NewServeMux()
+	mux.Handle("/admin/servers/{serverID}", NewAdminServerHandler(jwtManager))
+
+	adminToken, err := jwtManager.GenerateToken(&models.Customer{ID: "admin", Email: "admin@example.com", IsAdmin: true})
+	if err != nil {
+		t.Fatalf("Failed to generate admin token: %v", err)
+	}
+	customerToken, err := jwtManager.GenerateToken(&models.Customer{ID: "customer-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to generate customer token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{name: "admin", token: adminToken, status: http.StatusOK},
+		{name: "non-admin", token: customerToken, status: http.StatusForbidden},
+		{name: "anonymous", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/servers/server-42", nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "auth_token", Value: tt.token})
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			content := rec.Body.String()
+			for _, element := range []string{"server-42", "admin@example.com", "Recent Events", "ListServerEvents", `role="toolbar" aria-label="Power and console actions for server-42"`} {
+				if !strings.Contains(content, element) {
+					t.Errorf("Rendered server page missing expected element: %s", element)
+				}
+			}
+		})
+	}
+}
+
+// TestAdminPagesTheme ensures the pages render the theme of the theme cookie,
+// defaulting to the dark theme
+func TestAdminPagesTheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		cookie   string
+		expected string
+	}{
+		{name: "default", expected: `data-theme="dark"`},
+		{name: "light", cookie: "light", expected: `data-theme="light"`},
+		{name: "unknown", cookie: "solarized", expected: `data-theme="dark"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/login", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: themeCookieName, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			NewLoginHandler().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			content := rec.Body.String()
+			for _, element := range []string{tt.expected, `id="theme-toggle"`, `id="main-content"`} {
+				if !strings.Contains(content, element) {
+					t.Errorf("Rendered login page missing expected element: %s", element)
+				}
+			}
+		})
+	}
+}
@@ -7,11 +7,17 @@ import (
 	"connectrpc.com/connect"
 
 	coreauth "core/auth"
+	"manager/pkg/models"
 )
 
+// AccessCheck rejects the claims of a valid token whose access was revoked,
+// e.g. those of a disabled customer
+type AccessCheck func(ctx context.Context, claims *models.AuthClaims) error
+
 // AdminAuthInterceptor is a Connect interceptor that validates admin privileges
 type AdminAuthInterceptor struct {
-	jwtManager *JWTManager
+	jwtManager  *JWTManager
+	accessCheck AccessCheck
 }
 
 // NewAdminAuthInterceptor creates a new admin authentication interceptor
@@ -21,6 +27,12 @@ func NewAdminAuthInterceptor(jwtManager *JWTManager) *AdminAuthInterceptor {
 	}
 }
 
+// WithAccessCheck makes the interceptor reject the tokens failing check
+func (a *AdminAuthInterceptor) WithAccessCheck(check AccessCheck) *AdminAuthInterceptor {
+	a.accessCheck = check
+	return a
+}
+
 // WrapUnary wraps unary RPC calls with admin authentication
 func (a *AdminAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
@@ -39,6 +51,12 @@ func (a *AdminAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFu
 			return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token: %w", err))
 		}
 
+		if a.accessCheck != nil {
+			if err := a.accessCheck(ctx, claims); err != nil {
+				return nil, err
+			}
+		}
+
 		// Check if user is admin
 		if !claims.IsAdmin {
 			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("admin privileges required"))
@@ -7,6 +7,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 
+	coreauth "core/auth"
 	"core/domain"
 	"manager/pkg/models"
 )
@@ -16,6 +17,9 @@ type JWTManager struct {
 	serverContextService *ServerContextService
 }
 
+// AccessTokenTTL is the lifetime of access tokens, the longest of all tokens
+const AccessTokenTTL = 24 * time.Hour
+
 func NewJWTManager(secretKey string) *JWTManager {
 	return &JWTManager{
 		secretKey:            secretKey,
@@ -24,6 +28,12 @@ func NewJWTManager(secretKey string) *JWTManager {
 }
 
 func (j *JWTManager) GenerateToken(customer *models.Customer) (string, error) {
+	return j.GenerateTokenWithMFA(customer, false)
+}
+
+// GenerateTokenWithMFA generates an access token, recording whether its
+// holder authenticated with a second factor
+func (j *JWTManager) GenerateTokenWithMFA(customer *models.Customer, mfa bool) (string, error) {
 	if j.secretKey == "" {
 		return "", fmt.Errorf("JWT secret key is empty")
 	}
@@ -40,8 +50,9 @@ func (j *JWTManager) GenerateToken(customer *models.Customer) (string, error) {
 		"email":       claims.Email,
 		"is_admin":    claims.IsAdmin,
 		"jti":         claims.UUID.String(),
-		"exp":         time.Now().UTC().Add(24 * time.Hour).Unix(),
+		"exp":         time.Now().UTC().Add(AccessTokenTTL).Unix(),
 		"iat":         time.Now().UTC().Unix(),
+		"mfa":         mfa,
 	})
 
 	return token.SignedString([]byte(j.secretKey))
@@ -49,13 +60,65 @@ func (j *JWTManager) GenerateToken(customer *models.Customer) (string, error) {
 
 // GenerateServerToken generates a JWT token with encrypted server context
 func (j *JWTManager) GenerateServerToken(customer *models.Customer, server *domain.Server, permissions []string) (string, error) {
+	return j.GenerateServerTokenWithQuota(customer, server, permissions, nil)
+}
+
+// GenerateServerTokenWithQuota generates a JWT token with encrypted server
+// context, whose console sessions gateways limit by quota (nil for none)
+func (j *JWTManager) GenerateServerTokenWithQuota(customer *models.Customer, server *domain.Server, permissions []string, quota *coreauth.SessionQuota) (string, error) {
+	return j.GenerateServerTokenWithMFA(customer, server, permissions, quota, MFAClaims{})
+}
+
+// MFAClaims are the two-factor authentication claims of server tokens
+type MFAClaims struct {
+	Verified bool // The holder authenticated with a second factor
+	Required bool // Gateways refuse console sessions unless Verified
+}
+
+// GenerateServerTokenWithMFA generates a JWT token with encrypted server
+// context and session quota, whose console sessions gateways refuse when
+// the customer requires a second factor the holder did not use. Gateways
+// only accept the token from the customer's allowed source ranges.
+func (j *JWTManager) GenerateServerTokenWithMFA(customer *models.Customer, server *domain.Server, permissions []string, quota *coreauth.SessionQuota, mfa MFAClaims) (string, error) {
 	if j.secretKey == "" {
 		return "", fmt.Errorf("JWT secret key is empty")
 	}
 
 	// Create server context
 	serverContext := j.serverContextService.CreateServerContext(server, permissions)
+	serverContext.SessionQuota = quota
+	serverContext.AllowedSourceRanges = customer.AllowedSourceRanges
+
+	return j.signServerToken(customer, serverContext, mfa)
+}
+
+// ImpersonationTokenTTL is the lifetime of the server tokens admins open
+// consoles with on behalf of a server's customer
+const ImpersonationTokenTTL = 15 * time.Minute
+
+// GenerateImpersonationToken generates a short lived server token of the
+// server's customer for an admin, whose server context records the admin in
+// ImpersonatedBy. The token carries no session quota nor source ranges: the
+// admin's sessions neither count against the customer's quota nor come from
+// the customer's networks.
+func (j *JWTManager) GenerateImpersonationToken(customer *models.Customer, server *domain.Server, permissions []string, admin string, mfa MFAClaims) (string, error) {
+	if j.secretKey == "" {
+		return "", fmt.Errorf("JWT secret key is empty")
+	}
+	if admin == "" {
+		return "", fmt.Errorf("impersonating admin is empty")
+	}
+
+	serverContext := j.serverContextService.CreateServerContext(server, permissions)
+	serverContext.ExpiresAt = serverContext.IssuedAt.Add(ImpersonationTokenTTL)
+	serverContext.ImpersonatedBy = admin
 
+	return j.signServerToken(customer, serverContext, mfa)
+}
+
+// signServerToken encrypts a server context into a signed token of the
+// customer, expiring with the server context
+func (j *JWTManager) signServerToken(customer *models.Customer, serverContext *ServerContext, mfa MFAClaims) (string, error) {
 	// Encrypt server context
 	encryptedContext, err := j.serverContextService.EncryptServerContext(serverContext)
 	if err != nil {
@@ -68,7 +131,7 @@ func (j *JWTManager) GenerateServerToken(customer *models.Customer, server *doma
 		Email:         customer.Email,
 		JTI:           uuid.New().String(),
 		IssuedAt:      time.Now().UTC().Unix(),
-		ExpiresAt:     time.Now().UTC().Add(1 * time.Hour).Unix(), // Match server context expiration
+		ExpiresAt:     serverContext.ExpiresAt.UTC().Unix(), // Match server context expiration
 		ServerContext: encryptedContext,
 	}
 
@@ -79,6 +142,8 @@ func (j *JWTManager) GenerateServerToken(customer *models.Customer, server *doma
 		"iat":            claims.IssuedAt,
 		"exp":            claims.ExpiresAt,
 		"server_context": claims.ServerContext,
+		"mfa":            mfa.Verified,
+		"mfa_required":   mfa.Required,
 	})
 
 	return token.SignedString([]byte(j.secretKey))
@@ -128,14 +193,28 @@ func (j *JWTManager) ValidateToken(tokenString string) (*models.AuthClaims, erro
 		return nil, fmt.Errorf("invalid jti format")
 	}
 
+	// mfa is optional and defaults to false
+	mfa, _ := claims["mfa"].(bool)
+
 	return &models.AuthClaims{
 		CustomerID: customerID,
 		Email:      email,
 		IsAdmin:    isAdmin,
 		UUID:       jti,
+		IssuedAt:   issuedAt(claims),
+		MFA:        mfa,
 	}, nil
 }
 
+// issuedAt returns the issue time of a token, zero when it has none
+func issuedAt(claims jwt.MapClaims) time.Time {
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return time.Time{}
+	}
+	return iat.Time
+}
+
 // ValidateServerToken validates a server token and returns both auth claims and server context
 func (j *JWTManager) ValidateServerToken(tokenString string) (*models.AuthClaims, *ServerContext, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -182,11 +261,18 @@ func (j *JWTManager) ValidateServerToken(tokenString string) (*models.AuthClaims
 		return nil, nil, fmt.Errorf("invalid jti format")
 	}
 
+	// The two-factor claims are optional and default to false
+	mfa, _ := claims["mfa"].(bool)
+	mfaRequired, _ := claims["mfa_required"].(bool)
+
 	authClaims := &models.AuthClaims{
-		CustomerID: customerID,
-		Email:      email,
-		IsAdmin:    isAdmin,
-		UUID:       jti,
+		CustomerID:  customerID,
+		Email:       email,
+		IsAdmin:     isAdmin,
+		UUID:        jti,
+		IssuedAt:    issuedAt(claims),
+		MFA:         mfa,
+		MFARequired: mfaRequired,
 	}
 
 	// Extract and decrypt server context if present
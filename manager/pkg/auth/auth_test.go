@@ -360,3 +360,70 @@ func TestJWTManager_GetServerContextService(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, context.ServerID, decrypted.ServerID)
 }
+
+func TestJWTManager_MFAClaims(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret-key")
+	customer := &models.Customer{ID: "customer-123", Email: "test@example.com"}
+	server := &domain.Server{
+		ID:         "server-001",
+		CustomerID: "customer-123",
+		ControlEndpoints: []*types.BMCControlEndpoint{
+			{Endpoint: "http://localhost:9001", Type: types.BMCTypeRedfish},
+		},
+		PrimaryProtocol: types.BMCTypeRedfish,
+	}
+
+	token, err := jwtManager.GenerateTokenWithMFA(customer, true)
+	require.NoError(t, err)
+	claims, err := jwtManager.ValidateToken(token)
+	require.NoError(t, err)
+	assert.True(t, claims.MFA)
+
+	token, err = jwtManager.GenerateServerTokenWithMFA(customer, server, []string{"console:access"}, nil, MFAClaims{Required: true})
+	require.NoError(t, err)
+	claims, _, err = jwtManager.ValidateServerToken(token)
+	require.NoError(t, err)
+	assert.False(t, claims.MFA)
+	assert.True(t, claims.MFARequired)
+
+	// Server tokens require no second factor by default
+	token, err = jwtManager.GenerateServerToken(customer, server, []string{"console:access"})
+	require.NoError(t, err)
+	claims, _, err = jwtManager.ValidateServerToken(token)
+	require.NoError(t, err)
+	assert.False(t, claims.MFARequired)
+}
+
+func TestJWTManager_ImpersonationToken(t *testing.T) {
+	jwtManager := NewJWTManager("test-secret-key")
+	customer := &models.Customer{
+		ID:                  "customer-123",
+		Email:               "test@example.com",
+		AllowedSourceRanges: []string{"10.0.0.0/8"},
+	}
+	server := &domain.Server{
+		ID:         "server-001",
+		CustomerID: "customer-123",
+		ControlEndpoints: []*types.BMCControlEndpoint{
+			{Endpoint: "http://localhost:9001", Type: types.BMCTypeRedfish},
+		},
+		PrimaryProtocol: types.BMCTypeRedfish,
+	}
+
+	token, err := jwtManager.GenerateImpersonationToken(customer, server, []string{"console:access", "sol"}, "admin@example.com", MFAClaims{Verified: true, Required: true})
+	require.NoError(t, err)
+
+	claims, serverContext, err := jwtManager.ValidateServerToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "customer-123", claims.CustomerID)
+	assert.True(t, claims.MFA)
+	assert.Equal(t, "admin@example.com", serverContext.ImpersonatedBy)
+	assert.Empty(t, serverContext.AllowedSourceRanges)
+	assert.Nil(t, serverContext.SessionQuota)
+	assert.Equal(t, []string{"console:access", "sol"}, serverContext.Permissions)
+	assert.WithinDuration(t, time.Now().Add(ImpersonationTokenTTL), serverContext.ExpiresAt, time.Minute)
+
+	// Impersonation tokens name their admin
+	_, err = jwtManager.GenerateImpersonationToken(customer, server, []string{"console:access"}, "", MFAClaims{})
+	require.Error(t, err)
+}
@@ -10,9 +10,13 @@ import (
 	"io"
 	"time"
 
+	coreauth "core/auth"
 	"core/domain"
 )
 
+// ServerTokenTTL is the lifetime of server tokens
+const ServerTokenTTL = time.Hour
+
 // ServerContext contains the BMC endpoint information that will be encrypted
 // in JWT tokens.
 type ServerContext struct {
@@ -23,8 +27,16 @@ type ServerContext struct {
 	Features     []string  `json:"features"`
 	DatacenterID string    `json:"datacenter_id"`
 	Permissions  []string  `json:"permissions"`
+	Maintenance  bool      `json:"maintenance,omitempty"`
 	IssuedAt     time.Time `json:"iat"`
 	ExpiresAt    time.Time `json:"exp"`
+
+	MaintenanceWindows []coreauth.MaintenanceWindow `json:"maintenance_windows,omitempty"`
+	SessionQuota       *coreauth.SessionQuota       `json:"session_quota,omitempty"`
+
+	AllowedSourceRanges []string `json:"allowed_source_ranges,omitempty"`
+
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 }
 
 // EncryptedJWT represents a JWT token with encrypted server context.
@@ -79,6 +91,22 @@ func (s *ServerContextService) CreateServerContext(server *domain.Server, permis
 		}
 	}
 
+	expiresAt := now.Add(ServerTokenTTL)
+
+	// Windows of the server in progress while the token is valid
+	var windows []coreauth.MaintenanceWindow
+	for _, window := range server.MaintenanceWindows {
+		if window.Matches(server) && window.Overlaps(now, expiresAt) {
+			windows = append(windows, coreauth.MaintenanceWindow{
+				ID:       window.ID,
+				Policy:   string(window.Policy),
+				Reason:   window.Reason,
+				StartsAt: window.StartsAt,
+				EndsAt:   window.EndsAt,
+			})
+		}
+	}
+
 	return &ServerContext{
 		ServerID:     server.ID,
 		CustomerID:   server.CustomerID,
@@ -87,8 +115,11 @@ func (s *ServerContextService) CreateServerContext(server *domain.Server, permis
 		Features:     server.Features,
 		DatacenterID: server.DatacenterID,
 		Permissions:  permissions,
+		Maintenance:  server.MaintenanceMode,
 		IssuedAt:     now,
-		ExpiresAt:    now.Add(1 * time.Hour), // Server tokens expire in 1 hour
+		ExpiresAt:    expiresAt,
+
+		MaintenanceWindows: windows,
 	}
 }
 
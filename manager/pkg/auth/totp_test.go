@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890"
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	// The last six digits of the RFC's eight-digit codes
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "time %d", unix)
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := TOTPStep(now)
+
+	matched, ok := VerifyTOTP(rfc6238Secret, "005924", now, 0)
+	require.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	// Codes of the previous and next steps are accepted for clock drift
+	previous, err := TOTPCode(rfc6238Secret, step-1)
+	require.NoError(t, err)
+	_, ok = VerifyTOTP(rfc6238Secret, previous, now, 0)
+	assert.True(t, ok)
+	stale, err := TOTPCode(rfc6238Secret, step-2)
+	require.NoError(t, err)
+	_, ok = VerifyTOTP(rfc6238Secret, stale, now, 0)
+	assert.False(t, ok)
+
+	// Codes are used once
+	_, ok = VerifyTOTP(rfc6238Secret, "005924", now, step)
+	assert.False(t, ok)
+
+	_, ok = VerifyTOTP(rfc6238Secret, "005 924", now, 0)
+	assert.True(t, ok, "spaces are ignored")
+	_, ok = VerifyTOTP(rfc6238Secret, "5924", now, 0)
+	assert.False(t, ok)
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+	_, err = TOTPCode(secret, 1)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(TOTPURL(secret, "ops@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "/Conduit BMC:ops@example.com", parsed.Path)
+	assert.Equal(t, secret, parsed.Query().Get("secret"))
+	assert.Equal(t, TOTPIssuer, parsed.Query().Get("issuer"))
+}
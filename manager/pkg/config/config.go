@@ -2,10 +2,16 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"core/config"
+	"core/events"
+	"core/logging"
 
 	"github.com/rs/zerolog"
 )
@@ -26,11 +32,20 @@ type Config struct {
 
 	// TLS configuration
 	TLS config.TLSConfig `yaml:"tls"`
+
+	// Tracing configuration
+	Tracing config.TracingConfig `yaml:"tracing"`
+
+	// Event bus configuration
+	EventBus config.EventBusConfig `yaml:"event_bus"`
+
+	// Debug endpoints
+	Debug config.DebugConfig `yaml:"debug"`
 }
 
 // LogConfig contains manager-specific logging configuration
 type LogConfig struct {
-	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=trace debug info warn warning error fatal panic"`
 	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
 	Debug  bool   `yaml:"debug" env:"DEBUG" default:"false"`
 }
@@ -59,7 +74,7 @@ func (c *LogConfig) ConfigureZerolog() {
 			level = zerolog.PanicLevel
 		}
 	}
-	zerolog.SetGlobalLevel(level)
+	logging.SetLevel(level)
 }
 
 // DatabaseConfig contains manager-specific database configuration
@@ -73,10 +88,43 @@ type DatabaseConfig struct {
 
 // AuthConfig contains manager-specific authentication configuration
 type AuthConfig struct {
-	JWTSecretKey    string        `yaml:"-" env:"JWT_SECRET_KEY"`
+	JWTSecretKey    string        `yaml:"-" env:"JWT_SECRET_KEY" secret:"true"`
 	TokenTTL        time.Duration `yaml:"token_ttl" default:"24h"`          // TODO: Not currently used in code
 	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" default:"168h"` // TODO: Not currently used in code
 	AdminEmails     []string      `yaml:"admin_emails" env:"ADMIN_EMAILS"`  // List of admin user emails
+
+	// AdminPassword is set at startup as the password of the admins without
+	// one, so that the first admins can log in. They change it afterwards.
+	AdminPassword string `yaml:"-" env:"AUTH_ADMIN_PASSWORD" secret:"true"`
+
+	// GatewayAccounts binds gateways to the accounts they authenticate with,
+	// as gateway_id=email entries. Only the account of a gateway and admins
+	// may report measurements and events in the gateway's name.
+	GatewayAccounts []string `yaml:"gateway_accounts" env:"GATEWAY_ACCOUNTS"`
+
+	// Password authentication
+	MaxFailedLogins  int           `yaml:"max_failed_logins" env:"AUTH_MAX_FAILED_LOGINS" default:"5" validate:"min=0"`     // Consecutive failures locking an account, 0 to never lock
+	LockoutDuration  time.Duration `yaml:"lockout_duration" env:"AUTH_LOCKOUT_DURATION" default:"15m" validate:"min=1s"`    // How long locked accounts are refused
+	PasswordResetTTL time.Duration `yaml:"password_reset_ttl" env:"AUTH_PASSWORD_RESET_TTL" default:"1h" validate:"min=1m"` // Validity of password reset tokens
+
+	// DemoAccounts lets accounts without a password authenticate with any
+	// password, storing it as theirs. For development stacks only.
+	DemoAccounts bool `yaml:"demo_accounts" env:"AUTH_DEMO_ACCOUNTS" default:"false"`
+}
+
+// GatewayAccountEmails returns the emails of the gateway accounts by gateway
+// ID
+func (c *AuthConfig) GatewayAccountEmails() (map[string]string, error) {
+	accounts := make(map[string]string, len(c.GatewayAccounts))
+	for _, entry := range c.GatewayAccounts {
+		gatewayID, email, ok := strings.Cut(entry, "=")
+		gatewayID, email = strings.TrimSpace(gatewayID), strings.TrimSpace(email)
+		if !ok || gatewayID == "" || email == "" {
+			return nil, fmt.Errorf("invalid gateway account %q, expected gateway_id=email", entry)
+		}
+		accounts[gatewayID] = email
+	}
+	return accounts, nil
 }
 
 // ManagerConfig contains manager-specific configuration
@@ -99,6 +147,27 @@ type ManagerConfig struct {
 
 	// Session management
 	SessionManagement SessionManagementConfig `yaml:"session_management"`
+
+	// Console availability objectives
+	ConsoleSLO ConsoleSLOConfig `yaml:"console_slo"`
+
+	// Power usage reports from gateway power samples
+	PowerMetering PowerMeteringConfig `yaml:"power_metering"`
+
+	// Default console session limits of customers
+	SessionQuota SessionQuotaConfig `yaml:"session_quota"`
+
+	// Live gateway status polls for GetSystemStatus
+	GatewayStatus GatewayStatusConfig `yaml:"gateway_status"`
+
+	// Periodic checks of the credentials and console endpoints of BMCs
+	ConnectivityAudit ConnectivityAuditConfig `yaml:"connectivity_audit"`
+
+	// Webhook notifications of system events
+	Webhooks WebhookConfig `yaml:"webhooks"`
+
+	// REST/JSON API
+	REST RESTConfig `yaml:"rest"`
 }
 
 // GatewayDiscoveryConfig configures how the manager discovers gateways
@@ -108,6 +177,10 @@ type GatewayDiscoveryConfig struct {
 	UpdateInterval  time.Duration `yaml:"update_interval" default:"30s"`
 	HealthCheckPath string        `yaml:"health_check_path" default:"/health"`
 	Timeout         time.Duration `yaml:"timeout" default:"5s"`
+
+	// RequireApproval keeps gateways registering for the first time pending
+	// until an admin approves them
+	RequireApproval bool `yaml:"require_approval" env:"MANAGER_GATEWAY_REQUIRE_APPROVAL" default:"false"`
 }
 
 // ServerManagementConfig configures server management behavior
@@ -121,7 +194,7 @@ type ServerManagementConfig struct {
 }
 
 // CustomerManagementConfig configures customer management behavior
-// TODO: Not currently used in code - reserved for future implementation
+// Note: Currently only .PasswordMinLength and .APIKeyLength are used in code
 type CustomerManagementConfig struct {
 	AllowSelfRegistration     bool          `yaml:"allow_self_registration" default:"false"`
 	EmailVerificationRequired bool          `yaml:"email_verification_required" default:"true"`
@@ -154,6 +227,82 @@ type SessionManagementConfig struct {
 	MaxConcurrentSessions int           `yaml:"max_concurrent_sessions" default:"10"`
 }
 
+// ConsoleSLOConfig configures the console availability SLOs computed from
+// gateway session reports
+type ConsoleSLOConfig struct {
+	AvailabilityTarget float64       `yaml:"availability_target" default:"0.995"` // Session establishment success rate
+	TTFBTarget         float64       `yaml:"ttfb_target" default:"0.99"`          // Fraction of sessions within the TTFB threshold
+	TTFBThreshold      time.Duration `yaml:"ttfb_threshold" default:"2s"`         // Time-to-first-byte objective
+	Window             time.Duration `yaml:"window" default:"720h"`               // Rolling window (30 days)
+	Retention          time.Duration `yaml:"retention" default:"2160h"`           // How long measurements are kept (90 days)
+}
+
+// PowerMeteringConfig configures the power usage reports computed from the
+// power samples reported by gateways
+type PowerMeteringConfig struct {
+	MaxSampleGap time.Duration `yaml:"max_sample_gap" default:"15m"` // Longest interval between samples counted as usage
+	Retention    time.Duration `yaml:"retention" default:"2160h"`    // How long samples are kept (90 days)
+}
+
+// GatewayStatusConfig configures the polls of gateway statuses, whose agent
+// health, session counts and endpoint mappings GetSystemStatus reports
+type GatewayStatusConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"MANAGER_GATEWAY_STATUS_POLL_INTERVAL" default:"30s"` // Time between polls, 0 to disable
+}
+
+// RESTConfig configures the REST/JSON API, which forwards power operations
+// and console sessions to the gateways
+type RESTConfig struct {
+	GatewayTimeout time.Duration `yaml:"gateway_timeout" env:"MANAGER_REST_GATEWAY_TIMEOUT" default:"30s" validate:"min=1s"` // Timeout of one request to a gateway
+}
+
+// ConnectivityAuditConfig configures the periodic audits of every server's
+// BMC, checked through its gateway and agent with a deep health check
+type ConnectivityAuditConfig struct {
+	Interval      time.Duration `yaml:"interval" default:"24h"`        // Time between audits of all servers, 0 to disable
+	Concurrency   int           `yaml:"concurrency" default:"8"`       // Servers audited at once
+	Timeout       time.Duration `yaml:"timeout" default:"60s"`         // Timeout of the audit of one server
+	CheckConsoles bool          `yaml:"check_consoles" default:"true"` // Also check the SOL and VNC endpoints
+}
+
+// SessionQuotaConfig configures the default console session limits of
+// customers, enforced by each gateway on its own sessions: a customer whose
+// servers are behind several gateways may reach them on each. Zero limits
+// are unlimited.
+type SessionQuotaConfig struct {
+	MaxConcurrentSessions int `yaml:"max_concurrent_sessions" default:"10"` // Console sessions open at once
+	MaxSessionsPerMinute  int `yaml:"max_sessions_per_minute" default:"20"` // Console sessions created per minute
+}
+
+// WebhookConfig configures the delivery of system events to webhook endpoints
+type WebhookConfig struct {
+	Endpoints         []WebhookEndpointConfig `yaml:"endpoints"`
+	Timeout           time.Duration           `yaml:"timeout" default:"10s"`             // Timeout of one delivery attempt
+	MaxAttempts       int                     `yaml:"max_attempts" default:"5"`          // Attempts per event, including the first
+	InitialBackoff    time.Duration           `yaml:"initial_backoff" default:"1s"`      // Wait before the first retry, doubled on each retry
+	MaxBackoff        time.Duration           `yaml:"max_backoff" default:"5m"`          // Upper bound of the wait between retries
+	QueueSize         int                     `yaml:"queue_size" default:"1000"`         // Events buffered per endpoint
+	DeliveryRetention time.Duration           `yaml:"delivery_retention" default:"168h"` // How long the delivery log is kept (7 days)
+}
+
+// WebhookEndpointConfig configures one webhook endpoint
+type WebhookEndpointConfig struct {
+	Name      string   `yaml:"name"`
+	URL       string   `yaml:"url"`
+	Secret    string   `yaml:"secret" secret:"true"` // HMAC signing secret
+	SecretEnv string   `yaml:"secret_env"`           // Environment variable holding the secret, preferred over Secret
+	Events    []string `yaml:"events"`               // Subscribed event types, empty for all
+}
+
+// SigningSecret returns the HMAC secret of the endpoint, read from SecretEnv
+// when set
+func (e WebhookEndpointConfig) SigningSecret() string {
+	if e.SecretEnv != "" {
+		return os.Getenv(e.SecretEnv)
+	}
+	return e.Secret
+}
+
 // Load loads the manager configuration from multiple sources
 func Load(configFile, envFile string) (*Config, error) {
 	cfg := &Config{}
@@ -186,6 +335,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_SECRET_KEY must be at least 32 characters long")
 	}
 
+	if _, err := c.Auth.GatewayAccountEmails(); err != nil {
+		return err
+	}
+
 	// Validate database configuration
 	if c.Database.DSN == "" {
 		return fmt.Errorf("database DSN is required")
@@ -228,10 +381,132 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("console session TTL must be positive")
 	}
 
+	// Validate console SLOs
+	slo := c.Manager.ConsoleSLO
+	if slo.AvailabilityTarget <= 0 || slo.AvailabilityTarget > 1 {
+		return fmt.Errorf("console SLO availability target must be between 0 and 1")
+	}
+
+	if slo.TTFBTarget <= 0 || slo.TTFBTarget > 1 {
+		return fmt.Errorf("console SLO TTFB target must be between 0 and 1")
+	}
+
+	if slo.TTFBThreshold <= 0 {
+		return fmt.Errorf("console SLO TTFB threshold must be positive")
+	}
+
+	if slo.Window <= 0 {
+		return fmt.Errorf("console SLO window must be positive")
+	}
+
+	if slo.Retention < slo.Window {
+		return fmt.Errorf("console SLO retention must be at least the SLO window")
+	}
+
+	if c.Manager.PowerMetering.MaxSampleGap <= 0 {
+		return fmt.Errorf("power metering max sample gap must be positive")
+	}
+
+	if c.Manager.PowerMetering.Retention <= 0 {
+		return fmt.Errorf("power metering retention must be positive")
+	}
+
+	if c.Manager.ConnectivityAudit.Interval < 0 {
+		return fmt.Errorf("connectivity audit interval must not be negative")
+	}
+
+	if c.Manager.ConnectivityAudit.Interval > 0 {
+		if c.Manager.ConnectivityAudit.Concurrency <= 0 {
+			return fmt.Errorf("connectivity audit concurrency must be positive")
+		}
+		if c.Manager.ConnectivityAudit.Timeout <= 0 {
+			return fmt.Errorf("connectivity audit timeout must be positive")
+		}
+	}
+
+	if c.Manager.GatewayStatus.PollInterval < 0 {
+		return fmt.Errorf("gateway status poll interval must not be negative")
+	}
+
+	if c.Manager.SessionQuota.MaxConcurrentSessions < 0 || c.Manager.SessionQuota.MaxSessionsPerMinute < 0 {
+		return fmt.Errorf("session quota limits must not be negative")
+	}
+
+	// Validate webhooks
+	if err := c.Manager.Webhooks.Validate(); err != nil {
+		return err
+	}
+
+	// Validate tracing
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	// Validate event bus
+	if err := c.EventBus.Validate(); err != nil {
+		return err
+	}
+
+	// Validate debug endpoints
+	if err := c.Debug.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate validates the webhook configuration
+func (w *WebhookConfig) Validate() error {
+	if w.Timeout <= 0 {
+		return fmt.Errorf("webhook timeout must be positive")
+	}
+
+	if w.MaxAttempts < 1 {
+		return fmt.Errorf("webhook max attempts must be at least 1")
+	}
+
+	if w.InitialBackoff <= 0 || w.MaxBackoff < w.InitialBackoff {
+		return fmt.Errorf("webhook backoff must be positive, with max backoff at least the initial backoff")
+	}
+
+	if w.QueueSize <= 0 {
+		return fmt.Errorf("webhook queue size must be positive")
+	}
+
+	if w.DeliveryRetention <= 0 {
+		return fmt.Errorf("webhook delivery retention must be positive")
+	}
+
+	names := make(map[string]bool)
+	for _, endpoint := range w.Endpoints {
+		if endpoint.Name == "" {
+			return fmt.Errorf("webhook endpoint name is required")
+		}
+		if names[endpoint.Name] {
+			return fmt.Errorf("duplicate webhook endpoint name %q", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+
+		u, err := url.Parse(endpoint.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook endpoint %q URL must be an http or https URL", endpoint.Name)
+		}
+
+		if endpoint.SigningSecret() == "" {
+			return fmt.Errorf("webhook endpoint %q requires a signing secret", endpoint.Name)
+		}
+
+		for _, eventType := range endpoint.Events {
+			if !events.Type(eventType).Valid() {
+				return fmt.Errorf("webhook endpoint %q subscribes to unknown event type %q", endpoint.Name, eventType)
+			}
+		}
+	}
+
 	return nil
 }
 
 // GetListenAddress returns the address the manager should listen on
 func (c *Config) GetListenAddress() string {
-	return fmt.Sprintf("%s:%d", c.Manager.Host, c.Manager.Port)
+	return net.JoinHostPort(c.Manager.Host, strconv.Itoa(c.Manager.Port))
 }
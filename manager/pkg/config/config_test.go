@@ -208,6 +208,38 @@ func TestManagerConfigDefaults(t *testing.T) {
 		t.Errorf("Expected default RequestsPerMinute 100, got %d", cfg.Manager.RateLimit.RequestsPerMinute)
 	}
 
+	if cfg.Manager.ConsoleSLO.AvailabilityTarget != 0.995 {
+		t.Errorf("Expected default ConsoleSLO.AvailabilityTarget 0.995, got %v", cfg.Manager.ConsoleSLO.AvailabilityTarget)
+	}
+
+	if cfg.Manager.ConsoleSLO.TTFBThreshold != 2*time.Second {
+		t.Errorf("Expected default ConsoleSLO.TTFBThreshold 2s, got %v", cfg.Manager.ConsoleSLO.TTFBThreshold)
+	}
+
+	if cfg.Manager.ConsoleSLO.Window != 30*24*time.Hour {
+		t.Errorf("Expected default ConsoleSLO.Window 720h, got %v", cfg.Manager.ConsoleSLO.Window)
+	}
+
+	if cfg.Manager.PowerMetering.MaxSampleGap != 15*time.Minute {
+		t.Errorf("Expected default PowerMetering.MaxSampleGap 15m, got %v", cfg.Manager.PowerMetering.MaxSampleGap)
+	}
+
+	if cfg.Manager.SessionQuota.MaxConcurrentSessions != 10 || cfg.Manager.SessionQuota.MaxSessionsPerMinute != 20 {
+		t.Errorf("Expected default SessionQuota 10 concurrent and 20 per minute, got %+v", cfg.Manager.SessionQuota)
+	}
+
+	if len(cfg.Manager.Webhooks.Endpoints) != 0 {
+		t.Errorf("Expected no default webhook endpoints, got %d", len(cfg.Manager.Webhooks.Endpoints))
+	}
+
+	if cfg.Manager.Webhooks.MaxAttempts != 5 {
+		t.Errorf("Expected default Webhooks.MaxAttempts 5, got %d", cfg.Manager.Webhooks.MaxAttempts)
+	}
+
+	if cfg.Manager.Webhooks.MaxBackoff != 5*time.Minute {
+		t.Errorf("Expected default Webhooks.MaxBackoff 5m, got %v", cfg.Manager.Webhooks.MaxBackoff)
+	}
+
 	// Test common config defaults
 	if cfg.Log.Level != "info" {
 		t.Errorf("Expected default Log.Level 'info', got '%s'", cfg.Log.Level)
@@ -337,6 +369,12 @@ func TestManagerConfigGetListenAddress(t *testing.T) {
 			port:     8080,
 			expected: ":8080",
 		},
+		{
+			name:     "IPv6 address",
+			host:     "::1",
+			port:     8080,
+			expected: "[::1]:8080",
+		},
 	}
 
 	for _, tt := range tests {
@@ -528,3 +566,252 @@ manager:
 		})
 	}
 }
+
+func TestManagerConfigConsoleSLOValidation(t *testing.T) {
+	// Set required environment variables
+	os.Setenv("JWT_SECRET_KEY", "test-jwt-secret-key-at-least-32-characters-long")
+	os.Setenv("DATABASE_URL", "file:./test.db")
+	defer os.Unsetenv("JWT_SECRET_KEY")
+	defer os.Unsetenv("DATABASE_URL")
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "manager.yaml")
+
+	tests := []struct {
+		name        string
+		configYAML  string
+		expectError bool
+		errorText   string
+	}{
+		{
+			name: "availability target above 1",
+			configYAML: `
+manager:
+  console_slo:
+    availability_target: 99.5
+`,
+			expectError: true,
+			errorText:   "console SLO availability target must be between 0 and 1",
+		},
+		{
+			name: "zero TTFB threshold",
+			configYAML: `
+manager:
+  console_slo:
+    ttfb_threshold: 0s
+`,
+			expectError: true,
+			errorText:   "console SLO TTFB threshold must be positive",
+		},
+		{
+			name: "retention shorter than window",
+			configYAML: `
+manager:
+  console_slo:
+    window: 720h
+    retention: 24h
+`,
+			expectError: true,
+			errorText:   "console SLO retention must be at least the SLO window",
+		},
+		{
+			name: "zero power metering sample gap",
+			configYAML: `
+manager:
+  power_metering:
+    max_sample_gap: 0s
+`,
+			expectError: true,
+			errorText:   "power metering max sample gap must be positive",
+		},
+		{
+			name: "negative gateway status poll interval",
+			configYAML: `
+manager:
+  gateway_status:
+    poll_interval: -1s
+`,
+			expectError: true,
+			errorText:   "gateway status poll interval must not be negative",
+		},
+		{
+			name: "valid console SLO",
+			configYAML: `
+manager:
+  console_slo:
+    availability_target: 0.999
+    ttfb_target: 0.95
+    ttfb_threshold: 1s
+    window: 168h
+`,
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := os.WriteFile(configFile, []byte(tt.configYAML), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			_, err = Load(configFile, "")
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !strings.Contains(err.Error(), tt.errorText) {
+					t.Errorf("Expected error containing '%s', got '%v'", tt.errorText, err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestManagerConfigWebhookValidation(t *testing.T) {
+	// Set required environment variables
+	os.Setenv("JWT_SECRET_KEY", "test-jwt-secret-key-at-least-32-characters-long")
+	os.Setenv("DATABASE_URL", "file:./test.db")
+	os.Setenv("TEST_WEBHOOK_SECRET", "webhook-secret-from-env")
+	defer os.Unsetenv("JWT_SECRET_KEY")
+	defer os.Unsetenv("DATABASE_URL")
+	defer os.Unsetenv("TEST_WEBHOOK_SECRET")
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "manager.yaml")
+
+	tests := []struct {
+		name        string
+		configYAML  string
+		expectError bool
+		errorText   string
+	}{
+		{
+			name: "missing secret",
+			configYAML: `
+manager:
+  webhooks:
+    endpoints:
+      - name: ops
+        url: https://ops.example.com/hooks/bmc
+`,
+			expectError: true,
+			errorText:   "webhook endpoint \"ops\" requires a signing secret",
+		},
+		{
+			name: "unset secret environment variable",
+			configYAML: `
+manager:
+  webhooks:
+    endpoints:
+      - name: ops
+        url: https://ops.example.com/hooks/bmc
+        secret_env: TEST_WEBHOOK_SECRET_UNSET
+`,
+			expectError: true,
+			errorText:   "requires a signing secret",
+		},
+		{
+			name: "invalid URL",
+			configYAML: `
+manager:
+  webhooks:
+    endpoints:
+      - name: ops
+        url: ops.example.com
+        secret: webhook-secret
+`,
+			expectError: true,
+			errorText:   "URL must be an http or https URL",
+		},
+		{
+			name: "unknown event type",
+			configYAML: `
+manager:
+  webhooks:
+    endpoints:
+      - name: ops
+        url: https://ops.example.com/hooks/bmc
+        secret: webhook-secret
+        events: [server.exploded]
+`,
+			expectError: true,
+			errorText:   "unknown event type \"server.exploded\"",
+		},
+		{
+			name: "duplicate names",
+			configYAML: `
+manager:
+  webhooks:
+    endpoints:
+      - name: ops
+        url: https://ops.example.com/hooks/bmc
+        secret: webhook-secret
+      - name: ops
+        url: https://cmdb.example.com/hooks/bmc
+        secret: webhook-secret
+`,
+			expectError: true,
+			errorText:   "duplicate webhook endpoint name",
+		},
+		{
+			name: "max backoff below initial backoff",
+			configYAML: `
+manager:
+  webhooks:
+    initial_backoff: 10s
+    max_backoff: 1s
+`,
+			expectError: true,
+			errorText:   "max backoff at least the initial backoff",
+		},
+		{
+			name: "valid webhooks",
+			configYAML: `
+manager:
+  webhooks:
+    max_attempts: 3
+    endpoints:
+      - name: ops
+        url: https://ops.example.com/hooks/bmc
+        secret_env: TEST_WEBHOOK_SECRET
+        events: [gateway.offline, server.unreachable]
+      - name: cmdb
+        url: http://cmdb.internal:8000/events
+        secret: webhook-secret
+`,
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := os.WriteFile(configFile, []byte(tt.configYAML), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			cfg, err := Load(configFile, "")
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				} else if !strings.Contains(err.Error(), tt.errorText) {
+					t.Errorf("Expected error containing '%s', got '%v'", tt.errorText, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got := cfg.Manager.Webhooks.Endpoints[0].SigningSecret(); got != "webhook-secret-from-env" {
+				t.Errorf("Expected signing secret from environment, got '%s'", got)
+			}
+		})
+	}
+}
@@ -34,9 +34,49 @@ type Customer struct {
 	Email     string    `json:"email" db:"email"`
 	APIKey    string    `json:"api_key" db:"api_key"`
 	IsAdmin   bool      `json:"is_admin" db:"is_admin"`
+	Disabled  bool      `json:"disabled" db:"disabled"` // Access revoked, console sessions are rejected
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Console session limits, 0 for the manager's defaults and
+	// NoSessionLimit for none
+	MaxConcurrentSessions int `json:"max_concurrent_sessions" db:"max_concurrent_sessions"`
+	MaxSessionsPerMinute  int `json:"max_sessions_per_minute" db:"max_sessions_per_minute"`
+
+	// Password credentials, empty for accounts without a password
+	PasswordHash      string    `json:"-" db:"password_hash"` // bcrypt
+	PasswordChangedAt time.Time `json:"password_changed_at" db:"password_changed_at"`
+	FailedLogins      int       `json:"failed_logins" db:"failed_logins"` // Consecutive failures since the last login
+	LockedUntil       time.Time `json:"locked_until" db:"locked_until"`
+
+	// Pending password reset, the SHA-256 of its one-time token
+	PasswordResetHash      string    `json:"-" db:"password_reset_hash"`
+	PasswordResetExpiresAt time.Time `json:"password_reset_expires_at" db:"password_reset_expires_at"`
+
+	// TOTP two-factor authentication, pending confirmation while the secret
+	// is set but not enabled
+	TOTPSecret   string `json:"-" db:"totp_secret"` // base32
+	TOTPEnabled  bool   `json:"totp_enabled" db:"totp_enabled"`
+	TOTPLastStep int64  `json:"-" db:"totp_last_step"`        // Step of the last code used, which can't be reused
+	RequireMFA   bool   `json:"require_mfa" db:"require_mfa"` // Console sessions require a second factor
+
+	// Source IP ranges the customer accesses BMCs from, in CIDR notation,
+	// enforced by gateways. Empty for any.
+	AllowedSourceRanges []string `json:"allowed_source_ranges" db:"allowed_source_ranges"`
 }
 
+// NoSessionLimit lifts a console session limit of a customer
+const NoSessionLimit = -1
+
+// Statuses of regional gateways
+const (
+	GatewayStatusActive  = "active"
+	GatewayStatusPending = "pending" // Registered, awaiting approval by an admin
+)
+
+// ServerStatusDisabled is the status of servers whose consoles can no longer
+// be opened, even through existing sessions
+const ServerStatusDisabled = "disabled"
+
 type CreateProxyRequest struct {
 	ServerID string `json:"server_id"`
 }
@@ -64,6 +104,13 @@ type AuthClaims struct {
 	Email      string `json:"email"`
 	IsAdmin    bool   `json:"is_admin"`
 	uuid.UUID  `json:"jti"`
+
+	IssuedAt time.Time `json:"iat"` // Zero for tokens without an issue time
+
+	// Whether the token's holder authenticated with a second factor, and
+	// whether its customer's console sessions require one (server tokens)
+	MFA         bool `json:"mfa"`
+	MFARequired bool `json:"mfa_required"`
 }
 
 // New models for the updated architecture
@@ -129,18 +176,18 @@ type AgentBMCMapping struct {
 // This is used by the manager to create synthetic server IDs for BMC endpoints reported by gateways.
 //
 // The format is: bmc-{datacenter_id}-{sanitized_endpoint}
-// where sanitized_endpoint has colons (:) and dots (.) replaced with hyphens (-).
+// where sanitized_endpoint has colons (:) and dots (.) replaced with hyphens (-),
+// and the brackets of IPv6 literals removed.
 // Slashes (/) are NOT replaced to maintain URL structure visibility.
 //
 // Examples:
 //   - "http://localhost:9001" in "dc-local-dev" -> "bmc-dc-local-dev-http-//localhost-9001"
 //   - "http://redfish-01:8000" in "dc-east-1" -> "bmc-dc-east-1-http-//redfish-01-8000"
 //   - "192.168.1.100:623" in "dc-west-2" -> "bmc-dc-west-2-192-168-1-100-623"
+//   - "[fd00::10]:623" in "dc-west-2" -> "bmc-dc-west-2-fd00--10-623"
 func GenerateServerIDFromBMCEndpoint(datacenterID, bmcEndpoint string) string {
 	// Sanitize endpoint: replace : and . with -, but NOT /
-	sanitizedEndpoint := strings.ReplaceAll(
-		strings.ReplaceAll(bmcEndpoint, ":", "-"),
-		".", "-")
+	sanitizedEndpoint := strings.NewReplacer(":", "-", ".", "-", "[", "", "]", "").Replace(bmcEndpoint)
 
 	return fmt.Sprintf("bmc-%s-%s", datacenterID, sanitizedEndpoint)
 }
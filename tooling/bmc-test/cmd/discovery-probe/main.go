@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"local-agent/pkg/config"
+	"local-agent/pkg/discovery"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+var (
+	// Scan flags
+	cidrs         []string
+	username      string
+	password      string
+	credentials   []string
+	protocols     []string
+	ipmiPorts     []int
+	redfishPorts  []int
+	scanTimeout   time.Duration
+	maxConcurrent int
+	timeout       time.Duration
+	bmcInfo       bool
+
+	// Output flags
+	jsonOutput bool
+	verbose    bool
+	debug      bool
+)
+
+// maxScannedHosts is the number of addresses the discovery engine scans per
+// network, counting up from .1 of its first /24
+const maxScannedHosts = 100
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "discovery-probe",
+	Short: "Run the agent's BMC discovery against a network and report what it finds",
+	Long: `Discovery Probe - BMC Discovery Validation
+
+Runs the local agent's discovery engine standalone against the given networks,
+with the given credentials, and prints a report of the BMCs found: protocols,
+vendors, console capabilities and discovery errors. Use it to validate a new
+datacenter network before deploying agents: what the probe finds is what an
+agent configured with the same networks and credentials registers.
+
+Credentials are tried in order on each Redfish BMC until one is accepted.
+IPMI BMCs are detected without authentication and use the first credentials.
+
+Features:
+  • IPMI detection (requires ipmitool) and Redfish detection
+  • Serial console discovery, including vendor IPMI fallbacks (iDRAC)
+  • Manager details: manufacturer, model, firmware, enabled protocols
+  • Table or JSON report`,
+	Example: `  # Probe a BMC network with the credentials of the new datacenter:
+  discovery-probe --cidr 10.20.0.0/24 --username admin --password secret
+
+  # Try several credentials, Redfish only, on a non-standard port:
+  discovery-probe --cidr 10.20.0.0/24 --credential root:calvin --credential ADMIN:ADMIN \
+    --protocols redfish --redfish-ports 443,8443
+
+  # Machine-readable report of two networks:
+  discovery-probe --cidr 10.20.0.0/24 --cidr 10.21.0.0/24 --username admin --password secret --json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid network %q: %w", cidr, err)
+			}
+		}
+		if (username == "") != (password == "") {
+			return fmt.Errorf("--username and --password must be given together")
+		}
+		if _, err := discoveryCredentials(); err != nil {
+			return err
+		}
+		for _, protocol := range protocols {
+			if protocol != "ipmi" && protocol != "redfish" {
+				return fmt.Errorf("unsupported protocol %q: use ipmi or redfish", protocol)
+			}
+		}
+		if maxConcurrent <= 0 {
+			return fmt.Errorf("max-concurrent must be positive")
+		}
+		return nil
+	},
+	RunE:          runDiscoveryProbe,
+	SilenceUsage:  true,  // Don't show usage on errors from RunE
+	SilenceErrors: false, // Still print errors, just not usage
+}
+
+func init() {
+	// Scan flags
+	rootCmd.Flags().StringSliceVar(&cidrs, "cidr", nil, "Network to scan, e.g. 10.20.0.0/24 (required, repeatable)")
+	rootCmd.Flags().StringVar(&username, "username", "", "BMC username")
+	rootCmd.Flags().StringVar(&password, "password", "", "BMC password")
+	rootCmd.Flags().StringArrayVar(&credentials, "credential", nil, "Additional credentials as username:password, tried in order (repeatable)")
+	rootCmd.Flags().StringSliceVar(&protocols, "protocols", []string{"ipmi", "redfish"}, "Protocols to detect: ipmi, redfish")
+	rootCmd.Flags().IntSliceVar(&ipmiPorts, "ipmi-ports", []int{623}, "Ports probed for IPMI")
+	rootCmd.Flags().IntSliceVar(&redfishPorts, "redfish-ports", []int{443, 8000, 8443}, "Ports probed for Redfish, in order of preference")
+	rootCmd.Flags().DurationVar(&scanTimeout, "scan-timeout", 10*time.Second, "Timeout of each accessibility check")
+	rootCmd.Flags().IntVar(&maxConcurrent, "max-concurrent", 50, "Addresses probed concurrently")
+	rootCmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "Timeout of the whole probe")
+	rootCmd.Flags().BoolVar(&bmcInfo, "bmc-info", true, "Query manufacturer, model and firmware of the BMCs found")
+
+	// Output flags
+	rootCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the report as JSON")
+	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (info level)")
+	rootCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug logging (most detailed)")
+
+	_ = rootCmd.MarkFlagRequired("cidr")
+}
+
+// discoveryCredentials returns --username/--password followed by the
+// --credential pairs
+func discoveryCredentials() ([]config.CredentialConfig, error) {
+	var result []config.CredentialConfig
+	if username != "" {
+		result = append(result, config.CredentialConfig{Username: username, Password: password})
+	}
+	for _, credential := range credentials {
+		user, pass, ok := strings.Cut(credential, ":")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("invalid credential %q: use username:password", credential)
+		}
+		result = append(result, config.CredentialConfig{Username: user, Password: pass})
+	}
+	return result, nil
+}
+
+// discoveryConfig is the agent configuration of a discovery limited to the
+// probed networks: no static hosts, auto-discovery only
+func discoveryConfig() *config.Config {
+	creds, _ := discoveryCredentials()
+
+	cfg := &config.Config{}
+	cfg.Agent.ID = "discovery-probe"
+	cfg.Agent.BMCDiscovery = config.BMCDiscoveryConfig{
+		Enabled:                true,
+		NetworkRanges:          cidrs,
+		IPMIPorts:              ipmiPorts,
+		RedfishPorts:           redfishPorts,
+		ScanTimeout:            scanTimeout,
+		MaxConcurrent:          maxConcurrent,
+		EnableIPMIDetection:    hasProtocol("ipmi"),
+		EnableRedfishDetection: hasProtocol("redfish"),
+		DefaultCredentials:     creds,
+	}
+	return cfg
+}
+
+func hasProtocol(protocol string) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+func runDiscoveryProbe(cmd *cobra.Command, args []string) error {
+	setupLogging(verbose, debug)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// IPMI detection shells out to ipmitool, like the agent
+	if hasProtocol("ipmi") {
+		if _, err := exec.LookPath("ipmitool"); err != nil {
+			log.Warn().Msg("ipmitool not found: skipping IPMI detection (install ipmitool or use --protocols redfish)")
+			protocols = removeProtocol(protocols, "ipmi")
+			if len(protocols) == 0 {
+				return fmt.Errorf("no protocol left to detect")
+			}
+		}
+	}
+
+	for _, cidr := range cidrs {
+		_, ipnet, _ := net.ParseCIDR(cidr)
+		if ones, bits := ipnet.Mask.Size(); bits-ones >= 7 {
+			log.Warn().
+				Str("network", cidr).
+				Msgf("The discovery engine scans at most %d addresses per network, from .1 of its first /24: split larger networks", maxScannedHosts)
+		}
+	}
+
+	cfg := discoveryConfig()
+	log.Info().
+		Strs("networks", cidrs).
+		Strs("protocols", protocols).
+		Int("credentials", len(cfg.Agent.BMCDiscovery.DefaultCredentials)).
+		Msg("Discovery Probe starting")
+
+	ipmiClient := ipmi.NewClient()
+	redfishClient := redfish.NewClient()
+	service := discovery.NewService(ipmiClient, redfishClient, cfg)
+
+	start := time.Now()
+	servers, err := service.DiscoverServers(ctx)
+	if err != nil {
+		return fmt.Errorf("discovery failed: %w", err)
+	}
+	if ctx.Err() != nil {
+		log.Warn().Err(ctx.Err()).Msg("Discovery interrupted, reporting the BMCs found so far")
+	}
+
+	report := buildReport(servers, cidrs, protocols, time.Since(start))
+	if bmcInfo {
+		// Manager queries get their own time budget, the scan may have
+		// used all of --timeout
+		infoCtx, infoCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer infoCancel()
+		enrichReport(infoCtx, report, redfishClient, ipmiClient)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printReport(report)
+	return nil
+}
+
+func removeProtocol(list []string, protocol string) []string {
+	var result []string
+	for _, p := range list {
+		if p != protocol {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func setupLogging(verbose, debug bool) {
+	// Setup zerolog
+	zerolog.TimeFieldFormat = time.RFC3339
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "3:04PM"})
+
+	// Set log level
+	if debug {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	} else if verbose {
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	} else {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+}
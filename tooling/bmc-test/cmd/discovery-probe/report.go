@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"core/domain"
+	"core/types"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+// Report is the result of a probe
+type Report struct {
+	Networks  []string      `json:"networks"`
+	Protocols []string      `json:"protocols"`
+	Elapsed   time.Duration `json:"elapsed_ns"`
+
+	Servers []ServerReport `json:"servers"`
+
+	// Counts of the servers found by protocol, vendor and console type
+	ByProtocol map[string]int `json:"by_protocol"`
+	ByVendor   map[string]int `json:"by_vendor"`
+	ByConsole  map[string]int `json:"by_console"`
+}
+
+// ServerReport describes a BMC found by the discovery engine
+type ServerReport struct {
+	ID       string `json:"id"`
+	Protocol string `json:"protocol"`
+	Endpoint string `json:"endpoint"`
+	Username string `json:"username"`
+
+	// Vendor is the vendor the agent detected, which drives its console
+	// handling. Manufacturer, Model and Firmware come from the BMC.
+	Vendor       string `json:"vendor,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Firmware     string `json:"firmware,omitempty"`
+
+	// EnabledProtocols are the network protocols the BMC manager reports
+	// enabled, e.g. "ipmi:623" (Redfish only)
+	EnabledProtocols []string `json:"enabled_protocols,omitempty"`
+
+	Console         string `json:"console"`
+	ConsoleEndpoint string `json:"console_endpoint,omitempty"`
+	ConsoleFallback bool   `json:"console_fallback,omitempty"`
+	VNC             string `json:"vnc,omitempty"`
+
+	Features     []string `json:"features"`
+	Capabilities []string `json:"capabilities"`
+	Errors       []string `json:"errors,omitempty"`
+
+	// password is used to query the BMC, it is not reported
+	password string
+}
+
+// consoleNone is the console type of servers without a serial console
+const consoleNone = "none"
+
+// buildReport describes the servers returned by the discovery engine
+func buildReport(servers []*domain.Server, networks, protocols []string, elapsed time.Duration) *Report {
+	report := &Report{
+		Networks:   networks,
+		Protocols:  protocols,
+		Elapsed:    elapsed,
+		Servers:    make([]ServerReport, 0, len(servers)),
+		ByProtocol: make(map[string]int),
+		ByVendor:   make(map[string]int),
+		ByConsole:  make(map[string]int),
+	}
+
+	for _, server := range servers {
+		s := ServerReport{
+			ID:       server.ID,
+			Protocol: string(server.PrimaryProtocol),
+			Vendor:   server.Metadata["vendor"],
+			Console:  consoleNone,
+			Features: server.Features,
+		}
+		if control := server.GetPrimaryControlEndpoint(); control != nil {
+			s.Endpoint = control.Endpoint
+			s.Username = control.Username
+			s.password = control.Password
+			s.Capabilities = control.Capabilities
+		}
+		if server.SOLEndpoint != nil {
+			s.Console = string(server.SOLEndpoint.Type)
+			s.ConsoleEndpoint = server.SOLEndpoint.Endpoint
+			s.ConsoleFallback = server.Metadata["sol_fallback"] != ""
+		}
+		if server.VNCEndpoint != nil {
+			s.VNC = server.VNCEndpoint.Endpoint
+		}
+		if reason, failed := server.Metadata["discovery_error"]; failed {
+			s.Errors = append(s.Errors, reason)
+		}
+		report.Servers = append(report.Servers, s)
+	}
+	report.count()
+	return report
+}
+
+// count updates the counts of the report from its servers
+func (r *Report) count() {
+	clear(r.ByProtocol)
+	clear(r.ByVendor)
+	clear(r.ByConsole)
+	for _, s := range r.Servers {
+		r.ByProtocol[s.Protocol]++
+		r.ByVendor[s.vendorName()]++
+		r.ByConsole[s.Console]++
+	}
+}
+
+// vendorName is the manufacturer reported by the BMC, else the vendor the
+// agent detected
+func (s ServerReport) vendorName() string {
+	switch {
+	case s.Manufacturer != "":
+		return s.Manufacturer
+	case s.Vendor != "":
+		return s.Vendor
+	default:
+		return "unknown"
+	}
+}
+
+// enrichReport queries the BMCs found for their manufacturer, model and
+// firmware with the credentials discovery settled on. Failures are recorded
+// as errors of the server, they do not fail the probe.
+func enrichReport(ctx context.Context, report *Report, redfishClient *redfish.Client, ipmiClient *ipmi.Client) {
+	for i := range report.Servers {
+		s := &report.Servers[i]
+		switch types.BMCType(s.Protocol) {
+		case types.BMCTypeRedfish:
+			manager, protocol, err := redfishClient.GetManagerInfo(ctx, s.Endpoint, s.Username, s.password)
+			if err != nil {
+				log.Debug().Err(err).Str("endpoint", s.Endpoint).Msg("Failed to get manager info")
+				s.Errors = append(s.Errors, fmt.Sprintf("manager info: %v", err))
+				continue
+			}
+			s.Manufacturer = manager.Manufacturer
+			s.Model = manager.Model
+			s.Firmware = manager.FirmwareVersion
+			if protocol != nil {
+				s.EnabledProtocols = enabledProtocols(protocol)
+			}
+
+		case types.BMCTypeIPMI:
+			info, err := ipmiClient.GetBMCInfo(ctx, s.Endpoint, s.Username, s.password)
+			if err != nil {
+				log.Debug().Err(err).Str("endpoint", s.Endpoint).Msg("Failed to get BMC info")
+				s.Errors = append(s.Errors, fmt.Sprintf("bmc info: %v", err))
+				continue
+			}
+			s.Manufacturer = info.Vendor
+			s.Model = info.Model
+			s.Firmware = info.FirmwareVersion
+		}
+	}
+	report.count()
+}
+
+// enabledProtocols lists the network protocols a manager reports enabled
+func enabledProtocols(p *redfish.NetworkProtocol) []string {
+	var enabled []string
+	add := func(name string, on bool, port int32) {
+		if on {
+			enabled = append(enabled, fmt.Sprintf("%s:%d", name, port))
+		}
+	}
+	add("https", p.HTTPS.ProtocolEnabled, p.HTTPS.Port)
+	add("http", p.HTTP.ProtocolEnabled, p.HTTP.Port)
+	add("ssh", p.SSH.ProtocolEnabled, p.SSH.Port)
+	add("ipmi", p.IPMI.ProtocolEnabled, p.IPMI.Port)
+	return enabled
+}
+
+func printReport(r *Report) {
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("Discovery Probe Results")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Networks:       %s\n", strings.Join(r.Networks, ", "))
+	fmt.Printf("Protocols:      %s\n", strings.Join(r.Protocols, ", "))
+	fmt.Printf("Elapsed:        %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Printf("BMCs found:     %d\n", len(r.Servers))
+	if len(r.Servers) > 0 {
+		fmt.Printf("By protocol:    %s\n", formatCounts(r.ByProtocol))
+		fmt.Printf("By vendor:      %s\n", formatCounts(r.ByVendor))
+		fmt.Printf("By console:     %s\n", formatCounts(r.ByConsole))
+	}
+
+	for _, s := range r.Servers {
+		fmt.Println(strings.Repeat("-", 60))
+		fmt.Printf("%s\n", s.ID)
+		fmt.Printf("  Endpoint:     %s (%s, user %s)\n", s.Endpoint, s.Protocol, s.Username)
+		fmt.Printf("  Vendor:       %s\n", formatVendor(s))
+		if len(s.EnabledProtocols) > 0 {
+			fmt.Printf("  Services:     %s\n", strings.Join(s.EnabledProtocols, ", "))
+		}
+		console := s.Console
+		if s.ConsoleEndpoint != "" {
+			console = fmt.Sprintf("%s at %s", s.Console, s.ConsoleEndpoint)
+		}
+		if s.ConsoleFallback {
+			console += " (vendor IPMI fallback)"
+		}
+		fmt.Printf("  Console:      %s\n", console)
+		if s.VNC != "" {
+			fmt.Printf("  VNC:          %s\n", s.VNC)
+		}
+		fmt.Printf("  Features:     %s\n", strings.Join(s.Features, ", "))
+		fmt.Printf("  Capabilities: %s\n", strings.Join(s.Capabilities, ", "))
+		for _, err := range s.Errors {
+			fmt.Printf("  ⚠️  %s\n", err)
+		}
+	}
+	fmt.Println(strings.Repeat("=", 60))
+}
+
+// formatVendor describes the vendor of a server, e.g. "Dell Inc. iDRAC 9
+// (firmware 7.00.00, detected as idrac)"
+func formatVendor(s ServerReport) string {
+	if s.Manufacturer == "" {
+		if s.Vendor == "" {
+			return "unknown"
+		}
+		return s.Vendor
+	}
+
+	vendor := strings.TrimSpace(s.Manufacturer + " " + s.Model)
+	var details []string
+	if s.Firmware != "" {
+		details = append(details, "firmware "+s.Firmware)
+	}
+	if s.Vendor != "" {
+		details = append(details, "detected as "+s.Vendor)
+	}
+	if len(details) > 0 {
+		vendor += " (" + strings.Join(details, ", ") + ")"
+	}
+	return vendor
+}
+
+// formatCounts lists counts by decreasing count, e.g. "redfish=12 ipmi=3"
+func formatCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, counts[name])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"core/domain"
+	"core/testing/redfishsim"
+	"core/types"
+	"local-agent/pkg/ipmi"
+	"local-agent/pkg/redfish"
+)
+
+func TestDiscoveryCredentials(t *testing.T) {
+	username, password = "admin", "secret"
+	credentials = []string{"root:calvin", "ADMIN:with:colon"}
+	defer func() { username, password, credentials = "", "", nil }()
+
+	creds, err := discoveryCredentials()
+	if err != nil {
+		t.Fatalf("discoveryCredentials failed: %v", err)
+	}
+	if len(creds) != 3 || creds[0].Username != "admin" || creds[1].Password != "calvin" || creds[2].Password != "with:colon" {
+		t.Errorf("Unexpected credentials %+v", creds)
+	}
+
+	credentials = []string{"nopassword"}
+	if _, err := discoveryCredentials(); err == nil {
+		t.Error("Expected an error for a credential without password")
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	servers := []*domain.Server{
+		{
+			ID:              "server-10-0-0-1",
+			PrimaryProtocol: types.BMCTypeRedfish,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint:     "https://10.0.0.1:443",
+				Type:         types.BMCTypeRedfish,
+				Username:     "root",
+				Password:     "calvin",
+				Capabilities: []string{"power", "sol"},
+			}},
+			SOLEndpoint: &types.SOLEndpoint{Type: types.SOLTypeIPMI, Endpoint: "10.0.0.1:623"},
+			Features:    []string{"power", "console"},
+			Metadata:    map[string]string{"vendor": "dell", "sol_fallback": "ipmi"},
+		},
+		{
+			ID:              "server-10-0-0-2",
+			PrimaryProtocol: types.BMCTypeRedfish,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "https://10.0.0.2:443",
+				Type:     types.BMCTypeRedfish,
+				Username: "admin",
+			}},
+			Features: []string{"power"},
+			Metadata: map[string]string{"vendor": "generic", "discovery_error": "401 Unauthorized"},
+		},
+		{
+			ID:              "server-10-0-0-3",
+			PrimaryProtocol: types.BMCTypeIPMI,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: "10.0.0.3:623",
+				Type:     types.BMCTypeIPMI,
+				Username: "admin",
+			}},
+			SOLEndpoint: &types.SOLEndpoint{Type: types.SOLTypeIPMI, Endpoint: "10.0.0.3:623"},
+			Features:    []string{"power", "console"},
+			Metadata:    map[string]string{},
+		},
+	}
+
+	report := buildReport(servers, []string{"10.0.0.0/24"}, []string{"ipmi", "redfish"}, time.Second)
+	if len(report.Servers) != 3 {
+		t.Fatalf("Expected 3 servers, got %d", len(report.Servers))
+	}
+
+	dell := report.Servers[0]
+	if dell.Endpoint != "https://10.0.0.1:443" || dell.Username != "root" || dell.password != "calvin" {
+		t.Errorf("Unexpected control endpoint %+v", dell)
+	}
+	if dell.Console != "ipmi" || dell.ConsoleEndpoint != "10.0.0.1:623" || !dell.ConsoleFallback {
+		t.Errorf("Expected an IPMI fallback console, got %+v", dell)
+	}
+	if failed := report.Servers[1]; failed.Console != consoleNone || len(failed.Errors) != 1 || failed.Errors[0] != "401 Unauthorized" {
+		t.Errorf("Expected no console and the discovery error, got %+v", failed)
+	}
+	if ipmiServer := report.Servers[2]; ipmiServer.ConsoleFallback || ipmiServer.vendorName() != "unknown" {
+		t.Errorf("Unexpected IPMI server %+v", ipmiServer)
+	}
+
+	if got := formatCounts(report.ByProtocol); got != "redfish=2 ipmi=1" {
+		t.Errorf("Unexpected protocol counts %q", got)
+	}
+	if got := formatCounts(report.ByVendor); got != "dell=1 generic=1 unknown=1" {
+		t.Errorf("Unexpected vendor counts %q", got)
+	}
+	if got := formatCounts(report.ByConsole); got != "ipmi=2 none=1" {
+		t.Errorf("Unexpected console counts %q", got)
+	}
+}
+
+func TestEnrichReport(t *testing.T) {
+	bmc := redfishsim.New(redfishsim.Options{
+		Manufacturer:    "Dell Inc.",
+		Model:           "iDRAC 9",
+		FirmwareVersion: "7.00.00",
+	})
+	defer bmc.Close()
+
+	servers := []*domain.Server{
+		{
+			ID:              "server-1",
+			PrimaryProtocol: types.BMCTypeRedfish,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: bmc.URL,
+				Type:     types.BMCTypeRedfish,
+				Username: bmc.Username(),
+				Password: bmc.Password(),
+			}},
+			Metadata: map[string]string{"vendor": "dell"},
+		},
+		{
+			ID:              "server-2",
+			PrimaryProtocol: types.BMCTypeRedfish,
+			ControlEndpoints: []*types.BMCControlEndpoint{{
+				Endpoint: bmc.URL,
+				Type:     types.BMCTypeRedfish,
+				Username: bmc.Username(),
+				Password: "wrong",
+			}},
+			Metadata: map[string]string{"vendor": "generic"},
+		},
+	}
+	report := buildReport(servers, []string{"127.0.0.1/32"}, []string{"redfish"}, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	enrichReport(ctx, report, redfish.NewClient(), ipmi.NewClient())
+
+	dell := report.Servers[0]
+	if dell.Manufacturer != "Dell Inc." || dell.Model != "iDRAC 9" || dell.Firmware != "7.00.00" {
+		t.Errorf("Expected the manager details, got %+v", dell)
+	}
+	if got := formatVendor(dell); got != "Dell Inc. iDRAC 9 (firmware 7.00.00, detected as dell)" {
+		t.Errorf("Unexpected vendor description %q", got)
+	}
+	if failed := report.Servers[1]; len(failed.Errors) != 1 || !strings.HasPrefix(failed.Errors[0], "manager info:") {
+		t.Errorf("Expected a manager info error, got %+v", failed)
+	}
+	if got := formatCounts(report.ByVendor); got != "Dell Inc.=1 generic=1" {
+		t.Errorf("Unexpected vendor counts %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/sol"
+)
+
+// console pumps the output of a SOL session for the interactive and script
+// modes, which read the console and write to it concurrently
+type console struct {
+	session sol.Session
+	output  chan []byte
+
+	mu  sync.Mutex
+	err error
+}
+
+// startConsole starts reading session output. The session connects on its
+// first read, and keeps its transport bound to the context of that read, so
+// ctx must live as long as the session. startConsole returns once the
+// session is active, or fails after timeout.
+func startConsole(ctx context.Context, session sol.Session, timeout time.Duration) (*console, error) {
+	c := &console{session: session, output: make(chan []byte, 64)}
+	go c.pump(ctx)
+
+	deadline := time.Now().Add(timeout)
+	for !session.Status().Active {
+		if err := c.Err(); err != nil {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("SOL session not active after %s: %s", timeout, session.Status().Message)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return c, nil
+}
+
+func (c *console) pump(ctx context.Context) {
+	defer close(c.output)
+	for {
+		data, err := c.session.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				c.setErr(ctx.Err())
+				return
+			}
+			// Reads time out on an idle console, the session is only gone
+			// once it is no longer active
+			if status := c.session.Status(); status.Active {
+				log.Debug().Err(err).Msg("No console output")
+				continue
+			}
+			c.setErr(fmt.Errorf("SOL session ended: %w", err))
+			return
+		}
+		select {
+		case c.output <- data:
+		case <-ctx.Done():
+			c.setErr(ctx.Err())
+			return
+		}
+	}
+}
+
+// Output returns the console output, closed when the session ends
+func (c *console) Output() <-chan []byte {
+	return c.output
+}
+
+// Write sends input to the console
+func (c *console) Write(ctx context.Context, data []byte) error {
+	return c.session.Write(ctx, data)
+}
+
+// Err returns why the output ended, nil while the session is running
+func (c *console) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *console) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
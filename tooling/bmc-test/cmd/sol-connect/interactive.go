@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+
+	"cli/pkg/terminal"
+)
+
+// escapeCommandQuit closes the interactive session after the escape key
+const escapeCommandQuit = 'q'
+
+// ctrlC exits the interactive session when the escape key is disabled
+const ctrlC = 0x03
+
+// escapeScanner finds the escape key followed by the quit command in
+// terminal input, like the bmc-cli console. Pressing the escape key twice
+// sends it once, and any other byte sends both. State is kept across calls
+// so that the sequence may be split across reads.
+type escapeScanner struct {
+	key     byte
+	pressed bool
+}
+
+// scan returns the input to forward to the console, and whether the user
+// asked to quit
+func (s *escapeScanner) scan(data []byte) ([]byte, bool) {
+	forward := make([]byte, 0, len(data))
+	for _, b := range data {
+		if s.key == 0 {
+			if b == ctrlC {
+				return forward, true
+			}
+			forward = append(forward, b)
+			continue
+		}
+
+		if s.pressed {
+			s.pressed = false
+			switch b {
+			case escapeCommandQuit:
+				return forward, true
+			case s.key:
+				forward = append(forward, b)
+			default:
+				forward = append(forward, s.key, b)
+			}
+			continue
+		}
+		if b == s.key {
+			s.pressed = true
+			continue
+		}
+		forward = append(forward, b)
+	}
+	return forward, false
+}
+
+// escapeHelp describes how to leave the interactive session
+func escapeHelp(key byte) string {
+	if key == 0 {
+		return "Press Ctrl+C to exit."
+	}
+	return fmt.Sprintf("Press %s then '%c' to exit.", terminal.FormatEscapeKey(key), escapeCommandQuit)
+}
+
+// runInteractive connects the local terminal, in raw mode, to the console
+// until the user quits or the session ends. Raw mode passes every key,
+// including Ctrl+C and the escape sequences of function keys, to the BMC.
+func runInteractive(ctx context.Context, c *console, escapeKey byte) error {
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return fmt.Errorf("interactive mode requires a terminal on stdin")
+	}
+
+	fmt.Fprintln(os.Stderr, "Connected to SOL console. "+escapeHelp(escapeKey))
+	fmt.Fprintln(os.Stderr, repeat("-", 40))
+
+	oldState, err := term.MakeRaw(stdin)
+	if err != nil {
+		return fmt.Errorf("failed to set raw mode: %w", err)
+	}
+	defer func() {
+		_ = term.Restore(stdin, oldState)
+		fmt.Fprintln(os.Stderr, "\r\nDisconnected from SOL console.")
+	}()
+
+	// Console output to stdout
+	outputDone := make(chan error, 1)
+	go func() {
+		for data := range c.Output() {
+			if _, err := os.Stdout.Write(data); err != nil {
+				outputDone <- err
+				return
+			}
+		}
+		outputDone <- c.Err()
+	}()
+
+	// Terminal input to the console. The read blocks until a key is
+	// pressed, it is abandoned when the session ends first.
+	inputDone := make(chan error, 1)
+	go func() {
+		inputDone <- forwardInput(ctx, os.Stdin, c, &escapeScanner{key: escapeKey})
+	}()
+
+	select {
+	case err := <-inputDone:
+		return err
+	case err := <-outputDone:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// forwardInput copies input to the console until the escape sequence, which
+// returns nil
+func forwardInput(ctx context.Context, input io.Reader, c *console, scanner *escapeScanner) error {
+	buf := make([]byte, 1024)
+	for {
+		n, err := input.Read(buf)
+		if n > 0 {
+			forward, quit := scanner.scan(buf[:n])
+			if len(forward) > 0 {
+				if err := c.Write(ctx, forward); err != nil {
+					return fmt.Errorf("failed to write to console: %w", err)
+				}
+			}
+			if quit {
+				return nil
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read terminal input: %w", err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEscapeScanner(t *testing.T) {
+	s := &escapeScanner{key: 0x1D}
+
+	if forward, quit := s.scan([]byte("ls\r")); string(forward) != "ls\r" || quit {
+		t.Errorf("scan(ls) = %q, %v", forward, quit)
+	}
+	// Ctrl+C is sent to the console while an escape key is set
+	if forward, quit := s.scan([]byte{ctrlC}); !bytes.Equal(forward, []byte{ctrlC}) || quit {
+		t.Errorf("scan(Ctrl+C) = %q, %v", forward, quit)
+	}
+	// The escape key twice sends it once, followed by another byte sends both
+	if forward, _ := s.scan([]byte{0x1D, 0x1D, 0x1D, 'x'}); !bytes.Equal(forward, []byte{0x1D, 0x1D, 'x'}) {
+		t.Errorf("scan(escapes) = %q", forward)
+	}
+	// The sequence may be split across reads
+	if forward, quit := s.scan([]byte{'a', 0x1D}); string(forward) != "a" || quit {
+		t.Errorf("scan(a, escape) = %q, %v", forward, quit)
+	}
+	if forward, quit := s.scan([]byte("qignored")); len(forward) != 0 || !quit {
+		t.Errorf("scan(q) = %q, %v", forward, quit)
+	}
+
+	disabled := &escapeScanner{}
+	if forward, quit := disabled.scan([]byte{0x1D, 'q', ctrlC, 'z'}); !bytes.Equal(forward, []byte{0x1D, 'q'}) || !quit {
+		t.Errorf("scan without escape key = %q, %v", forward, quit)
+	}
+}
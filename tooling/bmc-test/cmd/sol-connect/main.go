@@ -3,14 +3,19 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"cli/pkg/terminal"
 	"local-agent/pkg/sol"
 )
 
@@ -23,9 +28,18 @@ var (
 	timeout  time.Duration
 	solType  string
 
+	// Mode flags
+	interactive bool
+	scriptPath  string
+	escapeKey   string
+
 	// Logging flags
 	verbose bool
 	debug   bool
+
+	// Parsed from the mode flags
+	escapeByte byte
+	script     *Script
 )
 
 func main() {
@@ -48,7 +62,7 @@ Features:
   • Redfish Serial Console (WebSocket-based)
   • Connection diagnostics and testing
   • Debug logging for troubleshooting
-  • Interactive or one-shot testing modes`,
+  • One-shot test, interactive raw terminal, or expect script`,
 	Example: `  # Test Dell iDRAC IPMI SOL:
   sol-connect --host 10.147.8.25 --port 623 --username admin --password secret --type ipmi --debug
 
@@ -56,7 +70,13 @@ Features:
   sol-connect --host 10.147.8.25 --username admin --password secret --type redfish --debug
 
   # Test with custom timeout:
-  sol-connect --host 192.168.1.100 --username admin --password secret --timeout 60s --verbose`,
+  sol-connect --host 192.168.1.100 --username admin --password secret --timeout 60s --verbose
+
+  # Open the console in the terminal (Ctrl+] then q to exit):
+  sol-connect --host 10.147.8.25 --username admin --password secret --interactive
+
+  # Enter BIOS setup with an expect script:
+  sol-connect --host 10.147.8.25 --username admin --password secret --script bios-setup.yaml --verbose`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Validate required flags
 		if host == "" {
@@ -71,6 +91,22 @@ Features:
 		if solType != "ipmi" && solType != "redfish" {
 			return fmt.Errorf("sol-type must be either 'ipmi' or 'redfish'")
 		}
+		if interactive && scriptPath != "" {
+			return fmt.Errorf("--interactive and --script are mutually exclusive")
+		}
+
+		var err error
+		if escapeByte, err = terminal.ParseEscapeKey(escapeKey); err != nil {
+			return err
+		}
+		if escapeByte == escapeCommandQuit {
+			return fmt.Errorf("invalid escape key %q: conflicts with the quit command", escapeKey)
+		}
+		if scriptPath != "" {
+			if script, err = loadScript(scriptPath); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 	RunE:          runSOLConnect,
@@ -87,6 +123,11 @@ func init() {
 	rootCmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Connection timeout")
 	rootCmd.Flags().StringVar(&solType, "type", "ipmi", "SOL type: 'ipmi' or 'redfish'")
 
+	// Mode flags
+	rootCmd.Flags().BoolVar(&interactive, "interactive", false, "Connect the terminal to the console in raw mode")
+	rootCmd.Flags().StringVar(&scriptPath, "script", "", "Run send/expect steps from a YAML script")
+	rootCmd.Flags().StringVar(&escapeKey, "escape-key", terminal.DefaultEscapeKey, "Interactive escape key, followed by 'q' to exit: caret notation (^]), a character, or none")
+
 	// Logging flags
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (info level)")
 	rootCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug logging (most detailed)")
@@ -115,13 +156,18 @@ func runSOLConnect(cmd *cobra.Command, args []string) error {
 	var err error
 
 	if solType == "ipmi" {
-		endpoint = fmt.Sprintf("%s:%d", host, port)
+		endpoint = net.JoinHostPort(host, strconv.Itoa(port))
 		log.Info().
 			Str("endpoint", endpoint).
 			Msg("Creating IPMI SOL client...")
 		client, err = sol.NewClient("ipmi")
 	} else {
-		endpoint = fmt.Sprintf("https://%s", host)
+		redfishHost := host
+		if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+			// IPv6 literals are bracketed in URLs
+			redfishHost = "[" + host + "]"
+		}
+		endpoint = fmt.Sprintf("https://%s", redfishHost)
 		log.Info().
 			Str("endpoint", endpoint).
 			Msg("Creating Redfish serial console client...")
@@ -156,6 +202,10 @@ func runSOLConnect(cmd *cobra.Command, args []string) error {
 	log.Info().Msg("✅ SOL connection successful!")
 	log.Info().Msg("✅ Authentication successful")
 
+	if interactive || script != nil {
+		return runConsoleMode(session)
+	}
+
 	// Test reading data with timeout
 	log.Info().Msg("Testing data transfer (waiting for console output)...")
 	readCtx, readCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -243,6 +293,47 @@ func runSOLConnect(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runConsoleMode runs the interactive or script mode until it completes or
+// the process is interrupted
+func runConsoleMode(session sol.Session) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := startConsole(ctx, session, timeout)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to start SOL session")
+		return fmt.Errorf("SOL connection failed: %w", err)
+	}
+
+	if interactive {
+		return runInteractive(ctx, c, escapeByte)
+	}
+
+	start := time.Now()
+	err = runScript(ctx, c, script, os.Stdout)
+
+	// Summary
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("SOL Script Results")
+	fmt.Println(repeat("=", 60))
+	fmt.Printf("Host:           %s:%d\n", host, port)
+	fmt.Printf("Type:           %s\n", solType)
+	fmt.Printf("Script:         %s (%d steps)\n", scriptPath, len(script.Steps))
+	fmt.Printf("Duration:       %s\n", time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		fmt.Printf("Status:         ❌ FAILED - %v\n", err)
+		fmt.Println(repeat("=", 60))
+		return fmt.Errorf("script failed: %w", err)
+	}
+	fmt.Printf("Status:         ✅ SUCCESS\n")
+	fmt.Println(repeat("=", 60))
+
+	log.Info().Msg("SOL script completed successfully")
+	return nil
+}
+
 func setupLogging(verbose, debug bool) {
 	// Setup zerolog
 	zerolog.TimeFieldFormat = time.RFC3339
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultExpectTimeout applies to expect steps when the script sets none
+const defaultExpectTimeout = 30 * time.Second
+
+// maxExpectBuffer bounds the console output kept for matching. Older output
+// is dropped, a pattern is unlikely to span more.
+const maxExpectBuffer = 64 * 1024
+
+// Script is an expect script: steps run in order, each sends input to the
+// console, waits for output, or both
+type Script struct {
+	// Timeout is the default expect timeout of the steps
+	Timeout time.Duration `yaml:"timeout"`
+
+	Steps []ScriptStep `yaml:"steps"`
+}
+
+// ScriptStep sends Send, then waits until the console output contains
+// Expect. Sleep pauses after both, e.g. for BIOS menus that drop keys
+// typed while they redraw.
+type ScriptStep struct {
+	Name    string        `yaml:"name"`
+	Send    string        `yaml:"send"`
+	Expect  string        `yaml:"expect"`
+	Timeout time.Duration `yaml:"timeout"`
+	Sleep   time.Duration `yaml:"sleep"`
+}
+
+func (s ScriptStep) describe(index int) string {
+	if s.Name != "" {
+		return fmt.Sprintf("step %d (%s)", index+1, s.Name)
+	}
+	return fmt.Sprintf("step %d", index+1)
+}
+
+// loadScript reads and validates a YAML expect script
+func loadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	return parseScript(data)
+}
+
+func parseScript(data []byte) (*Script, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var script Script
+	if err := decoder.Decode(&script); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+
+	if script.Timeout < 0 {
+		return nil, fmt.Errorf("invalid script: negative timeout %s", script.Timeout)
+	}
+	if script.Timeout == 0 {
+		script.Timeout = defaultExpectTimeout
+	}
+	if len(script.Steps) == 0 {
+		return nil, fmt.Errorf("invalid script: no steps")
+	}
+	for i, step := range script.Steps {
+		if step.Send == "" && step.Expect == "" && step.Sleep == 0 {
+			return nil, fmt.Errorf("invalid script: %s has no send, expect or sleep", step.describe(i))
+		}
+		if step.Timeout < 0 || step.Sleep < 0 {
+			return nil, fmt.Errorf("invalid script: %s has a negative duration", step.describe(i))
+		}
+		if step.Timeout > 0 && step.Expect == "" {
+			return nil, fmt.Errorf("invalid script: %s has a timeout but nothing to expect", step.describe(i))
+		}
+	}
+	return &script, nil
+}
+
+// scriptRunner runs a script against a console, copying the console output
+// to out as it arrives
+type scriptRunner struct {
+	console *console
+	out     io.Writer
+
+	// pending is the output received since the last match
+	pending []byte
+}
+
+// runScript runs the steps of script in order, stopping at the first
+// failing step
+func runScript(ctx context.Context, c *console, script *Script, out io.Writer) error {
+	r := &scriptRunner{console: c, out: out}
+
+	for i, step := range script.Steps {
+		name := step.describe(i)
+		log.Info().
+			Str("step", name).
+			Str("send", fmt.Sprintf("%q", step.Send)).
+			Str("expect", step.Expect).
+			Msg("Running script step")
+
+		if step.Send != "" {
+			if err := c.Write(ctx, []byte(step.Send)); err != nil {
+				return fmt.Errorf("%s: failed to send: %w", name, err)
+			}
+		}
+
+		if step.Expect != "" {
+			timeout := step.Timeout
+			if timeout == 0 {
+				timeout = script.Timeout
+			}
+			if err := r.expect(ctx, step.Expect, timeout); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			log.Info().Str("step", name).Msg("✅ Expected output received")
+		}
+
+		if step.Sleep > 0 {
+			if err := r.drain(ctx, step.Sleep); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// expect waits until the output since the last match contains pattern,
+// then discards the output up to the end of the match
+func (r *scriptRunner) expect(ctx context.Context, pattern string, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		if i := bytes.Index(r.pending, []byte(pattern)); i >= 0 {
+			r.pending = r.pending[i+len(pattern):]
+			return nil
+		}
+
+		select {
+		case data, ok := <-r.console.Output():
+			if !ok {
+				return fmt.Errorf("console closed while waiting for %q: %w", pattern, r.console.Err())
+			}
+			r.receive(data)
+		case <-timer.C:
+			return fmt.Errorf("timed out after %s waiting for %q (last output: %q)", timeout, pattern, tail(r.pending, 80))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// drain keeps receiving output for d, so that it can be matched by the
+// next expect
+func (r *scriptRunner) drain(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		select {
+		case data, ok := <-r.console.Output():
+			if !ok {
+				return fmt.Errorf("console closed: %w", r.console.Err())
+			}
+			r.receive(data)
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *scriptRunner) receive(data []byte) {
+	if _, err := r.out.Write(data); err != nil {
+		log.Debug().Err(err).Msg("Failed to copy console output")
+	}
+	r.pending = append(r.pending, data...)
+	if len(r.pending) > maxExpectBuffer {
+		r.pending = r.pending[len(r.pending)-maxExpectBuffer:]
+	}
+}
+
+// tail returns the last n bytes of data as a string, for error messages
+func tail(data []byte, n int) string {
+	if len(data) > n {
+		data = data[len(data)-n:]
+	}
+	return strings.ToValidUTF8(string(data), "")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"local-agent/pkg/sol"
+)
+
+// fakeSession is a SOL session whose console answers each input with the
+// configured responses, e.g. a BIOS redrawing its menu after a key press
+type fakeSession struct {
+	output    chan []byte
+	responses map[string][]string
+	written   chan string
+}
+
+func newFakeSession(responses map[string][]string) *fakeSession {
+	return &fakeSession{
+		output:    make(chan []byte, 16),
+		responses: responses,
+		written:   make(chan string, 16),
+	}
+}
+
+func (s *fakeSession) Read(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-s.output:
+		return data, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *fakeSession) Write(ctx context.Context, data []byte) error {
+	s.written <- string(data)
+	for _, response := range s.responses[string(data)] {
+		s.output <- []byte(response)
+	}
+	return nil
+}
+
+func (s *fakeSession) Close() error { return nil }
+
+func (s *fakeSession) Status() sol.SessionStatus {
+	return sol.SessionStatus{Active: true, Connected: true}
+}
+
+func TestParseScript(t *testing.T) {
+	script, err := parseScript([]byte(`
+timeout: 10s
+steps:
+  - expect: "Press F2"
+    timeout: 5m
+  - name: enter setup
+    send: "\e[12~"
+    expect: System Setup
+    sleep: 500ms
+  - send: "\r"
+`))
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+	if script.Timeout != 10*time.Second || len(script.Steps) != 3 {
+		t.Fatalf("Unexpected script %+v", script)
+	}
+	if step := script.Steps[0]; step.Expect != "Press F2" || step.Timeout != 5*time.Minute {
+		t.Errorf("Unexpected first step %+v", step)
+	}
+	if step := script.Steps[1]; step.Send != "\x1b[12~" || step.Sleep != 500*time.Millisecond || step.describe(1) != "step 2 (enter setup)" {
+		t.Errorf("Unexpected second step %+v", step)
+	}
+	if step := script.Steps[2]; step.Send != "\r" {
+		t.Errorf("Unexpected third step %+v", step)
+	}
+
+	script, err = parseScript([]byte("steps:\n  - send: x\n"))
+	if err != nil || script.Timeout != defaultExpectTimeout {
+		t.Errorf("Expected the default timeout, got %v, %v", script, err)
+	}
+}
+
+func TestParseScript_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no steps":        "timeout: 10s\n",
+		"empty step":      "steps:\n  - name: nothing\n",
+		"unknown field":   "steps:\n  - sned: x\n",
+		"negative":        "steps:\n  - expect: x\n    timeout: -1s\n",
+		"timeout no wait": "steps:\n  - send: x\n    timeout: 1s\n",
+		"bad duration":    "timeout: soon\nsteps:\n  - send: x\n",
+	}
+	for name, data := range tests {
+		if _, err := parseScript([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	session := newFakeSession(map[string][]string{
+		"\r":  {"Boot Menu\r\n", "1. Disk\r\n2. Net", "work\r\n> "},
+		"2\r": {"Booting from Network...\r\n"},
+	})
+	session.output <- []byte("Press <Enter> for the boot menu")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := startConsole(ctx, session, time.Second)
+	if err != nil {
+		t.Fatalf("startConsole failed: %v", err)
+	}
+
+	script, err := parseScript([]byte(`
+timeout: 1s
+steps:
+  - expect: "boot menu"
+  - send: "\r"
+    expect: "2. Network"
+  - expect: "> "
+  - send: "2\r"
+    expect: "Booting from Network"
+`))
+	if err != nil {
+		t.Fatalf("parseScript failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runScript(ctx, c, script, &out); err != nil {
+		t.Fatalf("runScript failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Boot Menu") || !strings.HasSuffix(out.String(), "Booting from Network...\r\n") {
+		t.Errorf("Expected the console output to be copied, got %q", out.String())
+	}
+	if first, second := <-session.written, <-session.written; first != "\r" || second != "2\r" {
+		t.Errorf("Unexpected input %q then %q", first, second)
+	}
+}
+
+func TestRunScript_Timeout(t *testing.T) {
+	session := newFakeSession(nil)
+	session.output <- []byte("Grub loading")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := startConsole(ctx, session, time.Second)
+	if err != nil {
+		t.Fatalf("startConsole failed: %v", err)
+	}
+
+	script := &Script{Timeout: time.Second, Steps: []ScriptStep{
+		{Expect: "Grub"},
+		{Name: "login", Expect: "login:", Timeout: 50 * time.Millisecond},
+	}}
+	err = runScript(ctx, c, script, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "step 2 (login): timed out") || !strings.Contains(err.Error(), `" loading"`) {
+		t.Errorf("Expected a timeout of step 2 with the unmatched output, got %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"cli/pkg/client"
+	"cli/pkg/config"
+)
+
+var (
+	// Connection flags
+	managerURL string
+	email      string
+	password   string
+	servers    []string
+
+	// Load flags
+	protocol        string
+	sessions        int
+	duration        time.Duration
+	rampUp          time.Duration
+	responseTimeout time.Duration
+
+	// Traffic flags
+	pattern        string
+	rate           float64
+	burst          int
+	burstInterval  time.Duration
+	payloadSize    int
+	vncIncremental bool
+
+	// Output flags
+	jsonOutput bool
+	verbose    bool
+	debug      bool
+)
+
+// benchOptions configures the traffic of each session
+type benchOptions struct {
+	Pattern         Pattern
+	PayloadSize     int
+	ResponseTimeout time.Duration
+	VNCIncremental  bool
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "stream-bench",
+	Short: "Load test console streaming through regional gateways",
+	Long: `Stream Bench - Console Streaming Load Tester
+
+Opens simultaneous synthetic SOL or VNC sessions through the regional gateways
+of the given servers, generates traffic following a pattern and reports setup
+latency, round-trip latency and throughput percentiles. Use it to size gateways
+per region.
+
+SOL sessions send shell comments carrying a marker, the round-trip latency is
+the time until the console echoes the marker back. VNC sessions request
+framebuffer updates, the round-trip latency is the time until the update is
+received in full.
+
+Traffic patterns:
+  • steady - one message every 1/rate seconds per session, like typing
+  • burst  - burst messages back to back every burst interval, like pasting
+  • idle   - no traffic, sessions are only held open
+
+Authentication reuses the login of bmc-cli, or logs in with --email.`,
+	Example: `  # One SOL session on each of 4 servers, typing 5 lines per second:
+  stream-bench --servers srv-01,srv-02,srv-03,srv-04 --sessions 4 --rate 5
+
+  # 50 VNC sessions requesting full updates in bursts for 5 minutes:
+  stream-bench --protocol vnc --servers srv-01,srv-02 --sessions 50 \
+    --pattern burst --burst 10 --burst-interval 2s --duration 5m
+
+  # Hold 100 idle SOL sessions open and report results as JSON:
+  stream-bench --servers "$(paste -sd, servers.txt)" --sessions 100 --pattern idle --json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("at least one server is required")
+		}
+		if protocol != "sol" && protocol != "vnc" {
+			return fmt.Errorf("protocol must be either 'sol' or 'vnc'")
+		}
+		if sessions <= 0 {
+			return fmt.Errorf("sessions must be positive")
+		}
+		// The gateway attaches a single stream to each SOL console
+		if protocol == "sol" && sessions > len(servers) {
+			return fmt.Errorf("SOL consoles accept one stream each: %d sessions need as many servers, got %d", sessions, len(servers))
+		}
+		if protocol == "sol" && payloadSize < minSOLPayload {
+			return fmt.Errorf("payload-size must be at least %d bytes", minSOLPayload)
+		}
+		return trafficPattern().Validate()
+	},
+	RunE:          runStreamBench,
+	SilenceUsage:  true,  // Don't show usage on errors from RunE
+	SilenceErrors: false, // Still print errors, just not usage
+}
+
+func init() {
+	// Connection flags
+	rootCmd.Flags().StringVar(&managerURL, "manager", "", "Manager endpoint (default from the bmc-cli config)")
+	rootCmd.Flags().StringVar(&email, "email", "", "Log in with this email instead of reusing the bmc-cli login")
+	rootCmd.Flags().StringVar(&password, "password", "", "Password for --email")
+	rootCmd.Flags().StringSliceVar(&servers, "servers", nil, "Servers to open sessions on, round robin (required)")
+
+	// Load flags
+	rootCmd.Flags().StringVar(&protocol, "protocol", "sol", "Session type: 'sol' or 'vnc'")
+	rootCmd.Flags().IntVar(&sessions, "sessions", 10, "Number of simultaneous sessions")
+	rootCmd.Flags().DurationVar(&duration, "duration", time.Minute, "Traffic duration once all sessions are started")
+	rootCmd.Flags().DurationVar(&rampUp, "ramp-up", 10*time.Second, "Time over which session starts are spread")
+	rootCmd.Flags().DurationVar(&responseTimeout, "response-timeout", 10*time.Second, "Time after which a message without response is lost")
+
+	// Traffic flags
+	rootCmd.Flags().StringVar(&pattern, "pattern", PatternSteady, "Traffic pattern: 'steady', 'burst' or 'idle'")
+	rootCmd.Flags().Float64Var(&rate, "rate", 1, "Messages per second per session (steady)")
+	rootCmd.Flags().IntVar(&burst, "burst", 20, "Messages per burst (burst)")
+	rootCmd.Flags().DurationVar(&burstInterval, "burst-interval", 5*time.Second, "Time between bursts (burst)")
+	rootCmd.Flags().IntVar(&payloadSize, "payload-size", 64, "Bytes per SOL message, including the marker")
+	rootCmd.Flags().BoolVar(&vncIncremental, "vnc-incremental", false, "Request incremental VNC updates (static screens may not answer)")
+
+	// Output flags
+	rootCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the report as JSON")
+	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (info level)")
+	rootCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug logging (most detailed)")
+
+	_ = rootCmd.MarkFlagRequired("servers")
+}
+
+func trafficPattern() Pattern {
+	return Pattern{
+		Name:          pattern,
+		Rate:          rate,
+		Burst:         burst,
+		BurstInterval: burstInterval,
+	}
+}
+
+func runStreamBench(cmd *cobra.Command, args []string) error {
+	setupLogging(verbose, debug)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := benchOptions{
+		Pattern:         trafficPattern(),
+		PayloadSize:     payloadSize,
+		ResponseTimeout: responseTimeout,
+		VNCIncremental:  vncIncremental,
+	}
+	runSession := runSOLSession
+	if protocol == "vnc" {
+		runSession = runVNCSession
+	}
+
+	log.Info().
+		Str("protocol", protocol).
+		Int("sessions", sessions).
+		Int("servers", len(servers)).
+		Str("pattern", pattern).
+		Dur("duration", duration).
+		Dur("ramp_up", rampUp).
+		Msg("Stream Bench starting")
+
+	// Sessions stop together, after the ramp-up and the traffic duration
+	runCtx, cancel := context.WithTimeout(ctx, rampUp+duration)
+	defer cancel()
+
+	rec := NewRecorder()
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < sessions; i++ {
+		delay := time.Duration(0)
+		if sessions > 1 {
+			delay = rampUp * time.Duration(i) / time.Duration(sessions-1)
+		}
+		serverID := servers[i%len(servers)]
+
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			select {
+			case <-time.After(delay):
+			case <-runCtx.Done():
+				return
+			}
+			// The client caches gateways and locations without locking,
+			// each session gets its own
+			runSession(runCtx, client.New(cfg), serverID, id, opts, rec)
+		}(i)
+	}
+	wg.Wait()
+
+	report := rec.Report(time.Since(start))
+	report.Protocol = protocol
+	report.Pattern = pattern
+	report.Servers = len(servers)
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printReport(report)
+	return nil
+}
+
+// loadConfig returns the CLI configuration of the sessions: a fresh login
+// with --email, or else the bmc-cli login
+func loadConfig(ctx context.Context) (*config.Config, error) {
+	var cfg *config.Config
+	if email != "" {
+		cfg = &config.Config{Manager: config.ManagerConfig{Endpoint: managerURL}}
+		if cfg.Manager.Endpoint == "" {
+			cfg.Manager.Endpoint = "http://localhost:8080"
+		}
+		if _, err := client.NewBMCManagerClient(cfg).Authenticate(ctx, email, password); err != nil {
+			return nil, fmt.Errorf("failed to log in as %s: %w", email, err)
+		}
+	} else {
+		loaded, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bmc-cli config: %w", err)
+		}
+		cfg = loaded
+		if managerURL != "" {
+			cfg.Manager.Endpoint = managerURL
+		}
+		if cfg.Auth.AccessToken == "" {
+			return nil, fmt.Errorf("not logged in: run 'bmc-cli auth login' or pass --email")
+		}
+	}
+
+	// Sessions share the config, the location cache file is not safe for
+	// concurrent writers
+	cfg.Gateway.LocationCacheTTL = 0
+	return cfg, nil
+}
+
+func printReport(r Report) {
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Println("Stream Bench Results")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("Protocol:       %s (%s pattern)\n", r.Protocol, r.Pattern)
+	fmt.Printf("Sessions:       %d started, %d failed, on %d servers\n", r.SessionsOK, r.SessionsFailed, r.Servers)
+	fmt.Printf("Elapsed:        %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Printf("Messages:       %d sent, %d answered, %d lost\n", r.MessagesSent, r.RoundTrip.Count, r.MessagesLost)
+	fmt.Printf("Sent:           %s (%s/s)\n", formatBytes(float64(r.BytesSent)), formatBytes(r.SendThroughput))
+	fmt.Printf("Received:       %s (%s/s)\n", formatBytes(float64(r.BytesReceived)), formatBytes(r.ReceiveThroughput))
+	fmt.Println(strings.Repeat("-", 60))
+	fmt.Printf("%-12s %9s %9s %9s %9s %9s %9s\n", "Latency", "min", "mean", "p50", "p90", "p99", "max")
+	printLatency("setup", r.Setup)
+	printLatency("round trip", r.RoundTrip)
+
+	if len(r.Errors) > 0 {
+		fmt.Println(strings.Repeat("-", 60))
+		fmt.Println("Errors:")
+		messages := make([]string, 0, len(r.Errors))
+		for message := range r.Errors {
+			messages = append(messages, message)
+		}
+		sort.Slice(messages, func(i, j int) bool { return r.Errors[messages[i]] > r.Errors[messages[j]] })
+		for _, message := range messages {
+			fmt.Printf("  %5d  %s\n", r.Errors[message], message)
+		}
+	}
+	fmt.Println(strings.Repeat("=", 60))
+}
+
+func printLatency(name string, s LatencyStats) {
+	if s.Count == 0 {
+		fmt.Printf("%-12s %9s\n", name, "-")
+		return
+	}
+	durations := []time.Duration{s.Min, s.Mean, s.P50, s.P90, s.P99, s.Max}
+	fmt.Printf("%-12s", name)
+	for _, d := range durations {
+		fmt.Printf(" %9s", formatDuration(d))
+	}
+	fmt.Println()
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+}
+
+func formatBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	unit := 0
+	for n >= 1024 && unit < len(units)-1 {
+		n /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[unit])
+}
+
+func setupLogging(verbose, debug bool) {
+	// Setup zerolog
+	zerolog.TimeFieldFormat = time.RFC3339
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "3:04PM"})
+
+	// Set log level
+	if debug {
+		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	} else if verbose {
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	} else {
+		zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Traffic patterns of a session
+const (
+	// PatternSteady sends one message every 1/rate seconds, like typing
+	PatternSteady = "steady"
+	// PatternBurst sends burst messages back to back every burst interval,
+	// like pasting text or redrawing a screen
+	PatternBurst = "burst"
+	// PatternIdle sends nothing, sessions are only held open
+	PatternIdle = "idle"
+)
+
+// Pattern describes the traffic generated by each session
+type Pattern struct {
+	Name string
+
+	// Rate is the number of messages per second of PatternSteady
+	Rate float64
+
+	// Burst messages are sent every BurstInterval by PatternBurst
+	Burst         int
+	BurstInterval time.Duration
+}
+
+// Validate checks the parameters of the pattern
+func (p Pattern) Validate() error {
+	switch p.Name {
+	case PatternSteady:
+		if p.Rate <= 0 {
+			return fmt.Errorf("rate must be positive for the %s pattern", p.Name)
+		}
+	case PatternBurst:
+		if p.Burst <= 0 || p.BurstInterval <= 0 {
+			return fmt.Errorf("burst and burst-interval must be positive for the %s pattern", p.Name)
+		}
+	case PatternIdle:
+	default:
+		return fmt.Errorf("unknown pattern %q: use %s, %s or %s", p.Name, PatternSteady, PatternBurst, PatternIdle)
+	}
+	return nil
+}
+
+// Run calls send with the number of messages due at each tick until ctx is
+// done or send fails. Ticks missed while send blocks are skipped, so that a
+// slow session does not queue up traffic.
+func (p Pattern) Run(ctx context.Context, send func(messages int) error) error {
+	var interval time.Duration
+	messages := 1
+	switch p.Name {
+	case PatternSteady:
+		interval = time.Duration(float64(time.Second) / p.Rate)
+	case PatternBurst:
+		interval = p.BurstInterval
+		messages = p.Burst
+	default:
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := send(messages); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"cli/pkg/client"
+	gatewayv1 "gateway/gen/gateway/v1"
+)
+
+const (
+	// maxEchoBuffer bounds the console output kept to find echoed markers
+	maxEchoBuffer = 256 * 1024
+
+	// minSOLPayload is the size of a message with an empty padding
+	minSOLPayload = len("# sb-0000-000000 \r")
+)
+
+// runSOLSession opens a SOL session on serverID and sends console lines
+// following the pattern until ctx is done. Each line carries a marker, the
+// round-trip latency is the time until the console echoes it back.
+func runSOLSession(ctx context.Context, c *client.Client, serverID string, id int, opts benchOptions, rec *Recorder) {
+	start := time.Now()
+	session, err := c.CreateSOLSession(ctx, serverID)
+	if err != nil {
+		rec.SessionFailed(fmt.Errorf("create SOL session: %w", err))
+		return
+	}
+	defer func() {
+		_ = c.CloseServerSOLSession(context.Background(), serverID, session.ID)
+	}()
+
+	stream, err := c.StreamConsoleData(ctx, serverID, session.ID)
+	if err != nil {
+		rec.SessionFailed(fmt.Errorf("open console stream: %w", err))
+		return
+	}
+	rec.SessionStarted(time.Since(start))
+	log.Debug().Int("session", id).Str("server_id", serverID).Str("session_id", session.ID).Msg("SOL session started")
+
+	tracker := newEchoTracker(opts.ResponseTimeout)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			msg, err := stream.Receive()
+			if err == nil && msg.ErrorCode != "" {
+				err = fmt.Errorf("console error %s: %s", msg.ErrorCode, msg.ErrorMessage)
+			} else if err == nil && msg.CloseStream {
+				err = errors.New("console closed by the gateway")
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					rec.Error(err)
+				}
+				return
+			}
+			if len(msg.Data) == 0 {
+				continue
+			}
+			rec.Received(len(msg.Data))
+			for _, latency := range tracker.observe(msg.Data, time.Now()) {
+				rec.RoundTrip(latency)
+			}
+		}
+	}()
+
+	seq := 0
+	err = opts.Pattern.Run(ctx, func(messages int) error {
+		select {
+		case <-done:
+			return errors.New("console stream ended")
+		default:
+		}
+		rec.Lost(tracker.expire(time.Now()))
+
+		for i := 0; i < messages; i++ {
+			seq++
+			marker := fmt.Sprintf("sb-%04d-%06d", id, seq)
+			line := solLine(marker, opts.PayloadSize)
+			if err := stream.Send(&gatewayv1.ConsoleDataChunk{
+				SessionId: session.ID,
+				ServerId:  serverID,
+				Data:      line,
+			}); err != nil {
+				return fmt.Errorf("send console data: %w", err)
+			}
+			tracker.sent(marker, time.Now())
+			rec.Sent(len(line))
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		rec.Error(err)
+	}
+
+	_ = stream.Send(&gatewayv1.ConsoleDataChunk{SessionId: session.ID, CloseStream: true})
+	_ = stream.CloseRequest()
+	rec.Lost(tracker.expire(time.Now()))
+}
+
+// solLine returns a shell comment carrying marker, padded to size bytes so
+// that it is harmless on a shell prompt
+func solLine(marker string, size int) []byte {
+	line := "# " + marker + " "
+	if padding := size - len(line) - 1; padding > 0 {
+		line += strings.Repeat("x", padding)
+	}
+	return []byte(line + "\r")
+}
+
+// echoTracker matches the markers sent to a console with its output
+type echoTracker struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending []pendingMarker
+	output  []byte
+}
+
+type pendingMarker struct {
+	marker []byte
+	sentAt time.Time
+}
+
+func newEchoTracker(timeout time.Duration) *echoTracker {
+	return &echoTracker{timeout: timeout}
+}
+
+// sent registers a marker sent at the given time
+func (t *echoTracker) sent(marker string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, pendingMarker{marker: []byte(marker), sentAt: at})
+}
+
+// observe adds console output received at the given time, and returns the
+// round-trip latencies of the markers it completes. The console echoes
+// input in order, so output before the last echoed marker is dropped.
+func (t *echoTracker) observe(data []byte, at time.Time) []time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.output = append(t.output, data...)
+
+	var latencies []time.Duration
+	consumed := 0
+	pending := t.pending[:0]
+	for _, p := range t.pending {
+		i := bytes.Index(t.output, p.marker)
+		if i < 0 {
+			pending = append(pending, p)
+			continue
+		}
+		latencies = append(latencies, at.Sub(p.sentAt))
+		consumed = max(consumed, i+len(p.marker))
+	}
+	t.pending = pending
+	t.output = t.output[consumed:]
+
+	if len(t.output) > maxEchoBuffer {
+		t.output = append([]byte(nil), t.output[len(t.output)-maxEchoBuffer/2:]...)
+	}
+	return latencies
+}
+
+// expire drops the markers that were not echoed within the timeout, and
+// returns how many it dropped
+func (t *echoTracker) expire(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expired := 0
+	for len(t.pending) > 0 && now.Sub(t.pending[0].sentAt) > t.timeout {
+		t.pending = t.pending[1:]
+		expired++
+	}
+	return expired
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxErrorKinds bounds the distinct errors kept in a report, later errors
+// are counted under "other"
+const maxErrorKinds = 20
+
+// LatencyStats summarizes a latency distribution
+type LatencyStats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+	Max   time.Duration `json:"max"`
+}
+
+// summarize computes the latency stats of samples, which it sorts
+func summarize(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var total time.Duration
+	for _, sample := range samples {
+		total += sample
+	}
+	return LatencyStats{
+		Count: len(samples),
+		Min:   samples[0],
+		Mean:  total / time.Duration(len(samples)),
+		P50:   percentile(samples, 50),
+		P90:   percentile(samples, 90),
+		P99:   percentile(samples, 99),
+		Max:   samples[len(samples)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted samples
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Recorder collects the measurements of all the sessions of a run. It is
+// safe for concurrent use.
+type Recorder struct {
+	mu sync.Mutex
+
+	setup     []time.Duration
+	roundTrip []time.Duration
+
+	sessionsOK     int
+	sessionsFailed int
+	messagesSent   int
+	messagesLost   int
+	bytesSent      int64
+	bytesReceived  int64
+	errors         map[string]int
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{errors: make(map[string]int)}
+}
+
+// SessionStarted records the setup time of a session, from its creation
+// until its stream is connected
+func (r *Recorder) SessionStarted(setup time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessionsOK++
+	r.setup = append(r.setup, setup)
+}
+
+// SessionFailed records a session that could not be set up
+func (r *Recorder) SessionFailed(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessionsFailed++
+	r.addError(err)
+}
+
+// Error records an error of an established session
+func (r *Recorder) Error(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addError(err)
+}
+
+// Sent records a message sent to the console
+func (r *Recorder) Sent(bytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messagesSent++
+	r.bytesSent += int64(bytes)
+}
+
+// Received records console output
+func (r *Recorder) Received(bytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bytesReceived += int64(bytes)
+}
+
+// RoundTrip records the time until a message got its response
+func (r *Recorder) RoundTrip(latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roundTrip = append(r.roundTrip, latency)
+}
+
+// Lost records messages that got no response in time
+func (r *Recorder) Lost(messages int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messagesLost += messages
+}
+
+func (r *Recorder) addError(err error) {
+	message := err.Error()
+	if _, ok := r.errors[message]; !ok && len(r.errors) >= maxErrorKinds {
+		message = "other"
+	}
+	r.errors[message]++
+}
+
+// Report is the outcome of a run
+type Report struct {
+	Protocol string        `json:"protocol"`
+	Pattern  string        `json:"pattern"`
+	Servers  int           `json:"servers"`
+	Elapsed  time.Duration `json:"elapsed"`
+
+	SessionsOK     int `json:"sessions_ok"`
+	SessionsFailed int `json:"sessions_failed"`
+
+	Setup     LatencyStats `json:"setup_latency"`
+	RoundTrip LatencyStats `json:"round_trip_latency"`
+
+	MessagesSent int `json:"messages_sent"`
+	MessagesLost int `json:"messages_lost"`
+
+	BytesSent     int64 `json:"bytes_sent"`
+	BytesReceived int64 `json:"bytes_received"`
+
+	// Throughput of the whole run, in bytes per second
+	SendThroughput    float64 `json:"send_throughput"`
+	ReceiveThroughput float64 `json:"receive_throughput"`
+
+	Errors map[string]int `json:"errors,omitempty"`
+}
+
+// Report summarizes the measurements over the elapsed time of the run
+func (r *Recorder) Report(elapsed time.Duration) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := Report{
+		Elapsed:        elapsed,
+		SessionsOK:     r.sessionsOK,
+		SessionsFailed: r.sessionsFailed,
+		Setup:          summarize(append([]time.Duration(nil), r.setup...)),
+		RoundTrip:      summarize(append([]time.Duration(nil), r.roundTrip...)),
+		MessagesSent:   r.messagesSent,
+		MessagesLost:   r.messagesLost,
+		BytesSent:      r.bytesSent,
+		BytesReceived:  r.bytesReceived,
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		report.SendThroughput = float64(r.bytesSent) / seconds
+		report.ReceiveThroughput = float64(r.bytesReceived) / seconds
+	}
+	if len(r.errors) > 0 {
+		report.Errors = make(map[string]int, len(r.errors))
+		for message, count := range r.errors {
+			report.Errors[message] = count
+		}
+	}
+	return report
+}
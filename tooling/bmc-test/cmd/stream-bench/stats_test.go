@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+
+	stats := summarize(samples)
+	expected := LatencyStats{
+		Count: 100,
+		Min:   time.Millisecond,
+		Mean:  50500 * time.Microsecond,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}
+	if stats != expected {
+		t.Errorf("summarize() = %+v, want %+v", stats, expected)
+	}
+
+	if single := summarize([]time.Duration{time.Second}); single.P50 != time.Second || single.P99 != time.Second {
+		t.Errorf("Expected every percentile of a single sample to be the sample, got %+v", single)
+	}
+	if empty := summarize(nil); empty != (LatencyStats{}) {
+		t.Errorf("Expected empty stats without samples, got %+v", empty)
+	}
+}
+
+func TestRecorder_Report(t *testing.T) {
+	rec := NewRecorder()
+	rec.SessionStarted(100 * time.Millisecond)
+	rec.SessionFailed(errors.New("console busy"))
+	rec.Sent(64)
+	rec.Sent(64)
+	rec.Received(256)
+	rec.RoundTrip(20 * time.Millisecond)
+	rec.Lost(1)
+	for i := 0; i < maxErrorKinds+5; i++ {
+		rec.Error(fmt.Errorf("error %d", i))
+	}
+
+	report := rec.Report(2 * time.Second)
+	if report.SessionsOK != 1 || report.SessionsFailed != 1 {
+		t.Errorf("Expected 1 started and 1 failed session, got %d and %d", report.SessionsOK, report.SessionsFailed)
+	}
+	if report.MessagesSent != 2 || report.MessagesLost != 1 || report.RoundTrip.Count != 1 {
+		t.Errorf("Unexpected message counts: %+v", report)
+	}
+	if report.SendThroughput != 64 || report.ReceiveThroughput != 128 {
+		t.Errorf("Expected 64 B/s sent and 128 B/s received, got %v and %v", report.SendThroughput, report.ReceiveThroughput)
+	}
+	if len(report.Errors) != maxErrorKinds+1 || report.Errors["other"] != 6 {
+		t.Errorf("Expected %d error kinds plus 6 others, got %v", maxErrorKinds, report.Errors)
+	}
+}
+
+func TestEchoTracker(t *testing.T) {
+	start := time.Now()
+	tracker := newEchoTracker(time.Second)
+	tracker.sent("sb-0001-000001", start)
+	tracker.sent("sb-0001-000002", start.Add(10*time.Millisecond))
+	tracker.sent("sb-0001-000003", start.Add(20*time.Millisecond))
+
+	// The first marker arrives split over two chunks
+	if latencies := tracker.observe([]byte("$ # sb-0001-00"), start.Add(30*time.Millisecond)); len(latencies) != 0 {
+		t.Errorf("Expected no match on a partial marker, got %v", latencies)
+	}
+	latencies := tracker.observe([]byte("0001 xx\r\n$ # sb-0001-000003 xx\r\n"), start.Add(50*time.Millisecond))
+	if len(latencies) != 2 || latencies[0] != 50*time.Millisecond || latencies[1] != 30*time.Millisecond {
+		t.Errorf("Expected latencies of 50ms and 30ms, got %v", latencies)
+	}
+
+	if lost := tracker.expire(start.Add(500 * time.Millisecond)); lost != 0 {
+		t.Errorf("Expected no lost marker before the timeout, got %d", lost)
+	}
+	if lost := tracker.expire(start.Add(2 * time.Second)); lost != 1 {
+		t.Errorf("Expected the marker that was not echoed to be lost, got %d", lost)
+	}
+}
+
+func TestSOLLine(t *testing.T) {
+	if line := solLine("sb-0001-000001", 32); len(line) != 32 || string(line[:17]) != "# sb-0001-000001 " || line[31] != '\r' {
+		t.Errorf("Unexpected padded line %q", line)
+	}
+	if line := solLine("sb-0001-000001", 0); string(line) != "# sb-0001-000001 \r" || len(line) != minSOLPayload {
+		t.Errorf("Unexpected minimal line %q", line)
+	}
+}
+
+func TestPattern_Validate(t *testing.T) {
+	tests := []struct {
+		pattern Pattern
+		valid   bool
+	}{
+		{Pattern{Name: PatternSteady, Rate: 1}, true},
+		{Pattern{Name: PatternSteady}, false},
+		{Pattern{Name: PatternBurst, Burst: 10, BurstInterval: time.Second}, true},
+		{Pattern{Name: PatternBurst, Burst: 10}, false},
+		{Pattern{Name: PatternIdle}, true},
+		{Pattern{Name: "random"}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.pattern.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%+v) = %v, want valid %v", tt.pattern, err, tt.valid)
+		}
+	}
+}
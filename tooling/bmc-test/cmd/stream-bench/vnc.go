@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+
+	"cli/pkg/client"
+	"local-agent/pkg/vnc/rfb"
+)
+
+// RFB messages used by the benchmark (RFC 6143 section 7)
+const (
+	rfbSetEncodings             = 2
+	rfbFramebufferUpdateRequest = 3
+
+	rfbFramebufferUpdate   = 0
+	rfbSetColourMapEntries = 1
+	rfbBell                = 2
+	rfbServerCutText       = 3
+)
+
+// Encodings the benchmark accepts. Raw and CopyRect rectangles have a size
+// known from their header, so updates can be skipped without decoding.
+const (
+	encodingRaw         int32 = 0
+	encodingCopyRect    int32 = 1
+	encodingDesktopSize int32 = -223
+	encodingLastRect    int32 = -224
+)
+
+// runVNCSession opens a VNC session on serverID and requests framebuffer
+// updates following the pattern until ctx is done. The round-trip latency
+// is the time from a FramebufferUpdateRequest to the end of its update.
+func runVNCSession(ctx context.Context, c *client.Client, serverID string, id int, opts benchOptions, rec *Recorder) {
+	start := time.Now()
+	session, err := c.CreateVNCSession(ctx, serverID, client.VNCSessionOptions{})
+	if err != nil {
+		rec.SessionFailed(fmt.Errorf("create VNC session: %w", err))
+		return
+	}
+	defer func() {
+		_ = c.CloseVNCSession(context.Background(), session.ID)
+	}()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, session.WebsocketEndpoint, nil)
+	if err != nil {
+		rec.SessionFailed(fmt.Errorf("open VNC WebSocket: %w", err))
+		return
+	}
+	defer conn.Close()
+
+	stream := &wsStream{conn: conn, rec: rec}
+	fb, err := vncHandshake(stream, opts.ResponseTimeout)
+	if err != nil {
+		rec.SessionFailed(fmt.Errorf("VNC handshake: %w", err))
+		return
+	}
+	rec.SessionStarted(time.Since(start))
+	log.Debug().Int("session", id).Str("server_id", serverID).Str("session_id", session.ID).
+		Uint16("width", fb.width).Uint16("height", fb.height).Msg("VNC session started")
+
+	// The reader parses server messages in the background so that the
+	// WebSocket keeps being served while idle, it reports each update
+	updates := make(chan struct{}, 1)
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- fb.readUpdates(stream, updates)
+	}()
+
+	incremental := byte(0)
+	if opts.VNCIncremental {
+		incremental = 1
+	}
+	request := make([]byte, 10)
+	request[0] = rfbFramebufferUpdateRequest
+
+	err = opts.Pattern.Run(ctx, func(messages int) error {
+		for i := 0; i < messages; i++ {
+			request[1] = incremental
+			binary.BigEndian.PutUint16(request[6:8], fb.width)
+			binary.BigEndian.PutUint16(request[8:10], fb.height)
+
+			// Drop an update received since the last request, e.g. after
+			// a timeout, so that it is not taken for the response
+			select {
+			case <-updates:
+			default:
+			}
+
+			sentAt := time.Now()
+			if _, err := stream.Write(request); err != nil {
+				return fmt.Errorf("request framebuffer update: %w", err)
+			}
+			rec.Sent(len(request))
+
+			timer := time.NewTimer(opts.ResponseTimeout)
+			select {
+			case <-updates:
+				timer.Stop()
+				rec.RoundTrip(time.Since(sentAt))
+			case err := <-readErr:
+				timer.Stop()
+				return err
+			case <-timer.C:
+				// An update may still be in flight, it is not matched
+				// with a later request
+				rec.Lost(1)
+				return errors.New("framebuffer update timed out")
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		rec.Error(err)
+	}
+}
+
+// framebuffer is the remote display, as announced by ServerInit
+type framebuffer struct {
+	width         uint16
+	height        uint16
+	bytesPerPixel int
+}
+
+// vncHandshake performs the RFB handshake the way the web viewer does: the
+// gateway authenticated with the BMC already and offers no authentication
+func vncHandshake(stream *wsStream, timeout time.Duration) (*framebuffer, error) {
+	if err := stream.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	defer stream.conn.SetReadDeadline(time.Time{})
+
+	handshake := rfb.NewHandshake(stream)
+	version, err := handshake.NegotiateVersion()
+	if err != nil {
+		return nil, err
+	}
+	securityType, err := handshake.NegotiateSecurityType(false)
+	if err != nil {
+		return nil, err
+	}
+	if securityType != rfb.SecurityTypeNone {
+		return nil, fmt.Errorf("unexpected security type %s", securityType)
+	}
+	if version.Minor >= 8 {
+		if err := handshake.ReadSecurityResult(); err != nil {
+			return nil, err
+		}
+	}
+	if err := handshake.SendClientInit(true); err != nil {
+		return nil, err
+	}
+
+	// ServerInit: width, height, pixel format, then the desktop name
+	reader := rfb.NewProtocolReader(stream)
+	serverInit, err := reader.ReadBytes(20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ServerInit: %w", err)
+	}
+	if _, err := reader.ReadString(); err != nil {
+		return nil, fmt.Errorf("failed to read desktop name: %w", err)
+	}
+	fb := &framebuffer{
+		width:         binary.BigEndian.Uint16(serverInit[0:2]),
+		height:        binary.BigEndian.Uint16(serverInit[2:4]),
+		bytesPerPixel: int(serverInit[4]) / 8,
+	}
+	if fb.width == 0 || fb.height == 0 || fb.bytesPerPixel == 0 {
+		return nil, fmt.Errorf("unsupported framebuffer %dx%d at %d bits per pixel", fb.width, fb.height, serverInit[4])
+	}
+
+	// Restrict the server to encodings whose size is known from the header
+	encodings := []int32{encodingRaw, encodingCopyRect, encodingDesktopSize, encodingLastRect}
+	message := make([]byte, 4+4*len(encodings))
+	message[0] = rfbSetEncodings
+	binary.BigEndian.PutUint16(message[2:4], uint16(len(encodings)))
+	for i, encoding := range encodings {
+		binary.BigEndian.PutUint32(message[4+4*i:], uint32(encoding))
+	}
+	if _, err := stream.Write(message); err != nil {
+		return nil, fmt.Errorf("failed to set encodings: %w", err)
+	}
+	return fb, nil
+}
+
+// readUpdates reads server messages until the stream fails, and signals
+// each complete framebuffer update on updates
+func (fb *framebuffer) readUpdates(stream io.Reader, updates chan<- struct{}) error {
+	reader := rfb.NewProtocolReader(stream)
+	for {
+		messageType, err := reader.ReadU8()
+		if err != nil {
+			return fmt.Errorf("failed to read server message: %w", err)
+		}
+
+		switch messageType {
+		case rfbFramebufferUpdate:
+			if err := fb.skipUpdate(stream, reader); err != nil {
+				return err
+			}
+			select {
+			case updates <- struct{}{}:
+			default:
+			}
+
+		case rfbSetColourMapEntries:
+			header, err := reader.ReadBytes(5)
+			if err != nil {
+				return err
+			}
+			if err := skip(stream, 6*int64(binary.BigEndian.Uint16(header[3:5]))); err != nil {
+				return err
+			}
+
+		case rfbBell:
+
+		case rfbServerCutText:
+			header, err := reader.ReadBytes(7)
+			if err != nil {
+				return err
+			}
+			if err := skip(stream, int64(binary.BigEndian.Uint32(header[3:7]))); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unexpected server message type %d", messageType)
+		}
+	}
+}
+
+// skipUpdate reads the rectangles of a FramebufferUpdate without decoding
+// their pixels
+func (fb *framebuffer) skipUpdate(stream io.Reader, reader *rfb.ProtocolReader) error {
+	header, err := reader.ReadBytes(3)
+	if err != nil {
+		return fmt.Errorf("failed to read framebuffer update: %w", err)
+	}
+
+	rectangles := int(binary.BigEndian.Uint16(header[1:3]))
+	for i := 0; i < rectangles || rectangles == 0xFFFF; i++ {
+		rect, err := reader.ReadBytes(12)
+		if err != nil {
+			return fmt.Errorf("failed to read rectangle: %w", err)
+		}
+		width := int64(binary.BigEndian.Uint16(rect[4:6]))
+		height := int64(binary.BigEndian.Uint16(rect[6:8]))
+
+		switch encoding := int32(binary.BigEndian.Uint32(rect[8:12])); encoding {
+		case encodingRaw:
+			if err := skip(stream, width*height*int64(fb.bytesPerPixel)); err != nil {
+				return err
+			}
+		case encodingCopyRect:
+			if err := skip(stream, 4); err != nil {
+				return err
+			}
+		case encodingDesktopSize:
+			// Requests keep the initial size, the server clips them
+		case encodingLastRect:
+			return nil
+		default:
+			return fmt.Errorf("server sent unrequested encoding %d", encoding)
+		}
+	}
+	return nil
+}
+
+func skip(stream io.Reader, n int64) error {
+	if _, err := io.CopyN(io.Discard, stream, n); err != nil {
+		return fmt.Errorf("failed to read %d bytes: %w", n, err)
+	}
+	return nil
+}
+
+// wsStream reads and writes the RFB byte stream carried by binary WebSocket
+// messages, and records the bytes received
+type wsStream struct {
+	conn   *websocket.Conn
+	rec    *Recorder
+	reader io.Reader
+}
+
+func (s *wsStream) Read(p []byte) (int, error) {
+	for {
+		if s.reader == nil {
+			_, reader, err := s.conn.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			s.reader = reader
+		}
+
+		n, err := s.reader.Read(p)
+		s.rec.Received(n)
+		if err == io.EOF {
+			s.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *wsStream) Write(p []byte) (int, error) {
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
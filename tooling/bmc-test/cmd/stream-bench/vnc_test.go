@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func rectangle(width, height uint16, encoding int32) []byte {
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint16(header[4:6], width)
+	binary.BigEndian.PutUint16(header[6:8], height)
+	binary.BigEndian.PutUint32(header[8:12], uint32(encoding))
+	return header
+}
+
+func TestReadUpdates(t *testing.T) {
+	fb := &framebuffer{width: 4, height: 2, bytesPerPixel: 4}
+
+	var stream bytes.Buffer
+	// Bell, then an update with a raw and a CopyRect rectangle
+	stream.Write([]byte{rfbBell})
+	stream.Write([]byte{rfbFramebufferUpdate, 0, 0, 2})
+	stream.Write(rectangle(4, 2, encodingRaw))
+	stream.Write(make([]byte, 4*2*4))
+	stream.Write(rectangle(2, 2, encodingCopyRect))
+	stream.Write([]byte{0, 0, 0, 0})
+	// An update ended by LastRect instead of a rectangle count
+	stream.Write([]byte{rfbFramebufferUpdate, 0, 0xFF, 0xFF})
+	stream.Write(rectangle(1, 1, encodingRaw))
+	stream.Write(make([]byte, 4))
+	stream.Write(rectangle(0, 0, encodingLastRect))
+
+	updates := make(chan struct{}, 2)
+	err := fb.readUpdates(&stream, updates)
+	if err == nil || stream.Len() != 0 {
+		t.Fatalf("Expected the stream to be consumed until EOF, got %v with %d bytes left", err, stream.Len())
+	}
+	if len(updates) != 2 {
+		t.Errorf("Expected 2 updates, got %d", len(updates))
+	}
+}
+
+func TestReadUpdates_UnrequestedEncoding(t *testing.T) {
+	fb := &framebuffer{width: 4, height: 2, bytesPerPixel: 4}
+
+	var stream bytes.Buffer
+	stream.Write([]byte{rfbFramebufferUpdate, 0, 0, 1})
+	stream.Write(rectangle(4, 2, 7)) // Tight
+
+	if err := fb.readUpdates(&stream, make(chan struct{}, 1)); err == nil || !strings.Contains(err.Error(), "unrequested encoding 7") {
+		t.Errorf("Expected an unrequested encoding error, got %v", err)
+	}
+}
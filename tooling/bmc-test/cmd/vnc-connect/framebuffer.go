@@ -0,0 +1,411 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+)
+
+// RFB server-to-client message types (RFC 6143 section 7.6)
+const (
+	msgFramebufferUpdate   = 0
+	msgSetColourMapEntries = 1
+	msgBell                = 2
+	msgServerCutText       = 3
+)
+
+// RFB client-to-server message types (RFC 6143 section 7.5)
+const (
+	msgSetPixelFormat           = 0
+	msgSetEncodings             = 2
+	msgFramebufferUpdateRequest = 3
+)
+
+// Encodings and pseudo-encodings (RFC 6143 section 7.7 and 7.8)
+const (
+	encodingRaw         int32 = 0
+	encodingCopyRect    int32 = 1
+	encodingTight       int32 = 7
+	encodingDesktopSize int32 = -223
+	encodingLastRect    int32 = -224
+
+	// encodingJPEGQuality0 is the Tight JPEG quality level 0, levels 1 to 9
+	// follow it. Servers only use JPEG when a quality level is requested.
+	encodingJPEGQuality0 int32 = -32
+)
+
+// encodingNames are the encodings accepted by --encodings
+var encodingNames = map[string]int32{
+	"raw":      encodingRaw,
+	"copyrect": encodingCopyRect,
+	"tight":    encodingTight,
+}
+
+func encodingName(encoding int32) string {
+	switch encoding {
+	case encodingRaw:
+		return "Raw"
+	case encodingCopyRect:
+		return "CopyRect"
+	case encodingTight:
+		return "Tight"
+	case encodingDesktopSize:
+		return "DesktopSize"
+	case encodingLastRect:
+		return "LastRect"
+	}
+	return fmt.Sprintf("Encoding(%d)", encoding)
+}
+
+// PixelFormat is the RFB pixel format (RFC 6143 section 7.4)
+type PixelFormat struct {
+	BitsPerPixel uint8
+	Depth        uint8
+	BigEndian    bool
+	TrueColour   bool
+	RedMax       uint16
+	GreenMax     uint16
+	BlueMax      uint16
+	RedShift     uint8
+	GreenShift   uint8
+	BlueShift    uint8
+}
+
+// preferredPixelFormat is requested from the server: 32-bit little-endian
+// true colour with 8 bits per component, which Tight sends as 3-byte pixels
+var preferredPixelFormat = PixelFormat{
+	BitsPerPixel: 32,
+	Depth:        24,
+	TrueColour:   true,
+	RedMax:       255,
+	GreenMax:     255,
+	BlueMax:      255,
+	RedShift:     16,
+	GreenShift:   8,
+	BlueShift:    0,
+}
+
+func parsePixelFormat(b []byte) PixelFormat {
+	return PixelFormat{
+		BitsPerPixel: b[0],
+		Depth:        b[1],
+		BigEndian:    b[2] != 0,
+		TrueColour:   b[3] != 0,
+		RedMax:       binary.BigEndian.Uint16(b[4:6]),
+		GreenMax:     binary.BigEndian.Uint16(b[6:8]),
+		BlueMax:      binary.BigEndian.Uint16(b[8:10]),
+		RedShift:     b[10],
+		GreenShift:   b[11],
+		BlueShift:    b[12],
+	}
+}
+
+// marshal returns the 16-byte wire format of the pixel format
+func (pf PixelFormat) marshal() []byte {
+	b := make([]byte, 16)
+	b[0] = pf.BitsPerPixel
+	b[1] = pf.Depth
+	if pf.BigEndian {
+		b[2] = 1
+	}
+	if pf.TrueColour {
+		b[3] = 1
+	}
+	binary.BigEndian.PutUint16(b[4:6], pf.RedMax)
+	binary.BigEndian.PutUint16(b[6:8], pf.GreenMax)
+	binary.BigEndian.PutUint16(b[8:10], pf.BlueMax)
+	b[10] = pf.RedShift
+	b[11] = pf.GreenShift
+	b[12] = pf.BlueShift
+	return b
+}
+
+func (pf PixelFormat) bytesPerPixel() int {
+	return int(pf.BitsPerPixel) / 8
+}
+
+func (pf PixelFormat) String() string {
+	if !pf.TrueColour {
+		return fmt.Sprintf("%d bpp colour map", pf.BitsPerPixel)
+	}
+	return fmt.Sprintf("%d bpp, depth %d, RGB max %d/%d/%d shift %d/%d/%d",
+		pf.BitsPerPixel, pf.Depth, pf.RedMax, pf.GreenMax, pf.BlueMax, pf.RedShift, pf.GreenShift, pf.BlueShift)
+}
+
+// Update summarizes a decoded FramebufferUpdate
+type Update struct {
+	Rectangles int
+	Bytes      int
+	Encodings  map[int32]int
+}
+
+// Framebuffer decodes the server messages of an RFB session into an image
+type Framebuffer struct {
+	Name   string
+	Format PixelFormat
+	Image  *image.RGBA
+
+	r         *countingReader
+	colourMap [256]color.RGBA
+	tight     tightStreams
+}
+
+// NewFramebuffer creates the framebuffer announced by a ServerInit message
+// (width, height, pixel format, name). Messages are read from r.
+func NewFramebuffer(serverInit []byte, r io.Reader) (*Framebuffer, error) {
+	if len(serverInit) < 24 {
+		return nil, fmt.Errorf("ServerInit too short: %d bytes", len(serverInit))
+	}
+	width := int(binary.BigEndian.Uint16(serverInit[0:2]))
+	height := int(binary.BigEndian.Uint16(serverInit[2:4]))
+	nameLength := int(binary.BigEndian.Uint32(serverInit[20:24]))
+	if len(serverInit) < 24+nameLength {
+		return nil, fmt.Errorf("ServerInit name truncated")
+	}
+
+	return &Framebuffer{
+		Name:   string(serverInit[24 : 24+nameLength]),
+		Format: parsePixelFormat(serverInit[4:20]),
+		Image:  image.NewRGBA(image.Rect(0, 0, width, height)),
+		r:      &countingReader{r: r},
+	}, nil
+}
+
+// Width returns the current width of the framebuffer
+func (fb *Framebuffer) Width() int {
+	return fb.Image.Bounds().Dx()
+}
+
+// Height returns the current height of the framebuffer
+func (fb *Framebuffer) Height() int {
+	return fb.Image.Bounds().Dy()
+}
+
+// SetPixelFormatMessage returns the SetPixelFormat message of pf, the
+// framebuffer decodes later updates with pf
+func (fb *Framebuffer) SetPixelFormatMessage(pf PixelFormat) []byte {
+	fb.Format = pf
+	return append([]byte{msgSetPixelFormat, 0, 0, 0}, pf.marshal()...)
+}
+
+// SetEncodingsMessage returns the SetEncodings message of encodings
+func SetEncodingsMessage(encodings []int32) []byte {
+	b := make([]byte, 4+4*len(encodings))
+	b[0] = msgSetEncodings
+	binary.BigEndian.PutUint16(b[2:4], uint16(len(encodings)))
+	for i, encoding := range encodings {
+		binary.BigEndian.PutUint32(b[4+4*i:], uint32(encoding))
+	}
+	return b
+}
+
+// UpdateRequestMessage returns a FramebufferUpdateRequest for the whole
+// framebuffer
+func (fb *Framebuffer) UpdateRequestMessage(incremental bool) []byte {
+	b := make([]byte, 10)
+	b[0] = msgFramebufferUpdateRequest
+	if incremental {
+		b[1] = 1
+	}
+	binary.BigEndian.PutUint16(b[6:8], uint16(fb.Width()))
+	binary.BigEndian.PutUint16(b[8:10], uint16(fb.Height()))
+	return b
+}
+
+// NextMessage reads the type of the next server message
+func (fb *Framebuffer) NextMessage() (uint8, error) {
+	return fb.readU8()
+}
+
+// ReadMessage reads and applies the server message of messageType, as
+// returned by NextMessage. It returns the summary of framebuffer updates,
+// nil for other messages.
+func (fb *Framebuffer) ReadMessage(messageType uint8) (*Update, error) {
+	start := fb.r.n
+	switch messageType {
+	case msgFramebufferUpdate:
+		update, err := fb.readUpdate()
+		if update != nil {
+			update.Bytes = fb.r.n - start + 1
+		}
+		return update, err
+
+	case msgSetColourMapEntries:
+		header, err := fb.read(5)
+		if err != nil {
+			return nil, err
+		}
+		first := int(binary.BigEndian.Uint16(header[1:3]))
+		count := int(binary.BigEndian.Uint16(header[3:5]))
+		entries, err := fb.read(6 * count)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < count && first+i < len(fb.colourMap); i++ {
+			e := entries[6*i:]
+			fb.colourMap[first+i] = color.RGBA{e[0], e[2], e[4], 0xFF}
+		}
+		return nil, nil
+
+	case msgBell:
+		return nil, nil
+
+	case msgServerCutText:
+		header, err := fb.read(7)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.CopyN(io.Discard, fb.r, int64(binary.BigEndian.Uint32(header[3:7])))
+		return nil, err
+	}
+	return nil, fmt.Errorf("unexpected server message type %d", messageType)
+}
+
+func (fb *Framebuffer) readUpdate() (*Update, error) {
+	header, err := fb.read(3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read framebuffer update: %w", err)
+	}
+
+	// 0xFFFF rectangles means the update is ended by a LastRect rectangle
+	count := int(binary.BigEndian.Uint16(header[1:3]))
+	update := &Update{Encodings: make(map[int32]int)}
+	for i := 0; i < count || count == 0xFFFF; i++ {
+		rect, err := fb.read(12)
+		if err != nil {
+			return update, fmt.Errorf("failed to read rectangle header: %w", err)
+		}
+		x := int(binary.BigEndian.Uint16(rect[0:2]))
+		y := int(binary.BigEndian.Uint16(rect[2:4]))
+		w := int(binary.BigEndian.Uint16(rect[4:6]))
+		h := int(binary.BigEndian.Uint16(rect[6:8]))
+		encoding := int32(binary.BigEndian.Uint32(rect[8:12]))
+		if encoding == encodingLastRect {
+			break
+		}
+
+		update.Rectangles++
+		update.Encodings[encoding]++
+		if err := fb.readRectangle(image.Rect(x, y, x+w, y+h), encoding); err != nil {
+			return update, fmt.Errorf("%s rectangle %dx%d at %d,%d: %w", encodingName(encoding), w, h, x, y, err)
+		}
+	}
+	return update, nil
+}
+
+func (fb *Framebuffer) readRectangle(r image.Rectangle, encoding int32) error {
+	switch encoding {
+	case encodingRaw:
+		return fb.readRaw(r)
+	case encodingCopyRect:
+		return fb.readCopyRect(r)
+	case encodingTight:
+		return fb.readTight(r)
+	case encodingDesktopSize:
+		resized := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+		draw.Draw(resized, resized.Bounds(), fb.Image, image.Point{}, draw.Src)
+		fb.Image = resized
+		return nil
+	}
+	return fmt.Errorf("unsupported encoding")
+}
+
+func (fb *Framebuffer) readRaw(r image.Rectangle) error {
+	bpp := fb.Format.bytesPerPixel()
+	data, err := fb.read(r.Dx() * r.Dy() * bpp)
+	if err != nil {
+		return err
+	}
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < r.Dx(); x++ {
+			offset := (y*r.Dx() + x) * bpp
+			fb.Image.SetRGBA(r.Min.X+x, r.Min.Y+y, fb.pixel(data[offset:offset+bpp]))
+		}
+	}
+	return nil
+}
+
+func (fb *Framebuffer) readCopyRect(r image.Rectangle) error {
+	src, err := fb.read(4)
+	if err != nil {
+		return err
+	}
+	srcPoint := image.Pt(int(binary.BigEndian.Uint16(src[0:2])), int(binary.BigEndian.Uint16(src[2:4])))
+
+	// Copy through a buffer, the source and destination may overlap
+	buffer := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(buffer, buffer.Bounds(), fb.Image, srcPoint, draw.Src)
+	draw.Draw(fb.Image, r, buffer, image.Point{}, draw.Src)
+	return nil
+}
+
+// pixel converts a pixel in the session's pixel format
+func (fb *Framebuffer) pixel(b []byte) color.RGBA {
+	var value uint32
+	switch len(b) {
+	case 1:
+		value = uint32(b[0])
+	case 2:
+		if fb.Format.BigEndian {
+			value = uint32(binary.BigEndian.Uint16(b))
+		} else {
+			value = uint32(binary.LittleEndian.Uint16(b))
+		}
+	case 4:
+		if fb.Format.BigEndian {
+			value = binary.BigEndian.Uint32(b)
+		} else {
+			value = binary.LittleEndian.Uint32(b)
+		}
+	}
+
+	pf := fb.Format
+	if !pf.TrueColour {
+		return fb.colourMap[value&0xFF]
+	}
+	return color.RGBA{
+		R: scale(value>>pf.RedShift, pf.RedMax),
+		G: scale(value>>pf.GreenShift, pf.GreenMax),
+		B: scale(value>>pf.BlueShift, pf.BlueMax),
+		A: 0xFF,
+	}
+}
+
+// scale converts a colour component from 0..max to 0..255
+func scale(value uint32, max uint16) uint8 {
+	if max == 0 {
+		return 0
+	}
+	return uint8((value & uint32(max)) * 255 / uint32(max))
+}
+
+func (fb *Framebuffer) read(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(fb.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (fb *Framebuffer) readU8() (uint8, error) {
+	b, err := fb.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// serverInit returns a ServerInit message in the preferred pixel format
+func serverInit(width, height uint16, name string) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint16(b[0:2], width)
+	binary.BigEndian.PutUint16(b[2:4], height)
+	b = append(b, preferredPixelFormat.marshal()...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(name)))
+	return append(b, name...)
+}
+
+// updateWriter builds the FramebufferUpdate messages of a test server
+type updateWriter struct {
+	bytes.Buffer
+	zlib [4]*zlib.Writer
+	out  [4]*bytes.Buffer
+}
+
+func (w *updateWriter) update(rectangles uint16) {
+	w.Write([]byte{msgFramebufferUpdate, 0})
+	w.Write(binary.BigEndian.AppendUint16(nil, rectangles))
+}
+
+func (w *updateWriter) rectangle(r image.Rectangle, encoding int32) {
+	for _, v := range []int{r.Min.X, r.Min.Y, r.Dx(), r.Dy()} {
+		w.Write(binary.BigEndian.AppendUint16(nil, uint16(v)))
+	}
+	w.Write(binary.BigEndian.AppendUint32(nil, uint32(encoding)))
+}
+
+// rawPixel writes a pixel in the preferred pixel format
+func (w *updateWriter) rawPixel(c color.RGBA) {
+	w.Write([]byte{c.B, c.G, c.R, 0})
+}
+
+func (w *updateWriter) compactLength(n int) {
+	for {
+		b := byte(n & 0x7F)
+		n >>= 7
+		if n == 0 {
+			w.WriteByte(b)
+			return
+		}
+		w.WriteByte(b | 0x80)
+	}
+}
+
+// tightData writes basic compression data on a zlib stream, flushed at the
+// end of the rectangle like Tight servers do
+func (w *updateWriter) tightData(stream int, data []byte) {
+	if len(data) < tightMinToCompress {
+		w.Write(data)
+		return
+	}
+	if w.zlib[stream] == nil {
+		w.out[stream] = &bytes.Buffer{}
+		w.zlib[stream] = zlib.NewWriter(w.out[stream])
+	}
+	w.out[stream].Reset()
+	w.zlib[stream].Write(data)
+	w.zlib[stream].Flush()
+	w.compactLength(w.out[stream].Len())
+	w.Write(w.out[stream].Bytes())
+}
+
+var (
+	red   = color.RGBA{0xFF, 0, 0, 0xFF}
+	green = color.RGBA{0, 0xFF, 0, 0xFF}
+	blue  = color.RGBA{0, 0, 0xFF, 0xFF}
+	white = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+)
+
+func newTestFramebuffer(t *testing.T, w *updateWriter, width, height uint16) *Framebuffer {
+	t.Helper()
+	fb, err := NewFramebuffer(serverInit(width, height, "test"), w)
+	if err != nil {
+		t.Fatalf("NewFramebuffer failed: %v", err)
+	}
+	return fb
+}
+
+func readTestUpdate(t *testing.T, fb *Framebuffer) *Update {
+	t.Helper()
+	messageType, err := fb.NextMessage()
+	if err != nil {
+		t.Fatalf("NextMessage failed: %v", err)
+	}
+	update, err := fb.ReadMessage(messageType)
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if update == nil {
+		t.Fatalf("Expected a framebuffer update, got message type %d", messageType)
+	}
+	return update
+}
+
+func expectPixel(t *testing.T, fb *Framebuffer, x, y int, expected color.RGBA) {
+	t.Helper()
+	if got := fb.Image.RGBAAt(x, y); got != expected {
+		t.Errorf("Pixel %d,%d = %v, want %v", x, y, got, expected)
+	}
+}
+
+func TestNewFramebuffer(t *testing.T) {
+	fb, err := NewFramebuffer(serverInit(1024, 768, "iDRAC"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("NewFramebuffer failed: %v", err)
+	}
+	if fb.Width() != 1024 || fb.Height() != 768 || fb.Name != "iDRAC" || fb.Format != preferredPixelFormat {
+		t.Errorf("Unexpected framebuffer %dx%d %q %s", fb.Width(), fb.Height(), fb.Name, fb.Format)
+	}
+
+	if _, err := NewFramebuffer(serverInit(1024, 768, "iDRAC")[:26], &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for a truncated name")
+	}
+}
+
+func TestRawAndCopyRect(t *testing.T) {
+	w := &updateWriter{}
+	fb := newTestFramebuffer(t, w, 4, 2)
+
+	w.update(2)
+	w.rectangle(image.Rect(0, 0, 2, 2), encodingRaw)
+	for _, c := range []color.RGBA{red, green, blue, white} {
+		w.rawPixel(c)
+	}
+	w.rectangle(image.Rect(2, 0, 4, 2), encodingCopyRect)
+	w.Write([]byte{0, 0, 0, 0})
+
+	update := readTestUpdate(t, fb)
+	if update.Rectangles != 2 || update.Encodings[encodingRaw] != 1 || update.Encodings[encodingCopyRect] != 1 {
+		t.Errorf("Unexpected update %+v", update)
+	}
+	if update.Bytes != 4+2*12+16+4 {
+		t.Errorf("Expected %d bytes, got %d", 4+2*12+16+4, update.Bytes)
+	}
+	expectPixel(t, fb, 0, 0, red)
+	expectPixel(t, fb, 1, 1, white)
+	expectPixel(t, fb, 2, 0, red)
+	expectPixel(t, fb, 3, 1, white)
+}
+
+func TestTight(t *testing.T) {
+	w := &updateWriter{}
+	fb := newTestFramebuffer(t, w, 8, 8)
+
+	// Ended by LastRect rather than a rectangle count
+	w.update(0xFFFF)
+
+	// Fill
+	w.rectangle(image.Rect(0, 0, 8, 2), encodingTight)
+	w.WriteByte(tightFill << 4)
+	w.Write([]byte{0xFF, 0, 0})
+
+	// Basic compression with the copy filter on zlib stream 0
+	w.rectangle(image.Rect(0, 2, 4, 4), encodingTight)
+	w.WriteByte(0x00)
+	copyData := bytes.Repeat([]byte{0, 0xFF, 0}, 8)
+	w.tightData(0, copyData)
+
+	// Two-colour palette on stream 1: 1 bit per pixel, padded rows
+	w.rectangle(image.Rect(4, 2, 8, 4), encodingTight)
+	w.WriteByte((0x1 | tightExplicitFilter) << 4)
+	w.Write([]byte{tightFilterPalette, 1, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF})
+	w.tightData(1, []byte{0b10100000, 0b01010000})
+
+	// Gradient on stream 2: a horizontal ramp is sent as constant
+	// differences
+	w.rectangle(image.Rect(0, 4, 4, 5), encodingTight)
+	w.WriteByte((0x2 | tightExplicitFilter) << 4)
+	w.WriteByte(tightFilterGradient)
+	w.tightData(2, []byte{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10})
+
+	// The copy filter again on stream 0, which keeps its state
+	w.rectangle(image.Rect(4, 4, 8, 6), encodingTight)
+	w.WriteByte(0x00)
+	w.tightData(0, bytes.Repeat([]byte{0, 0, 0xFF}, 8))
+
+	w.rectangle(image.Rect(0, 0, 0, 0), encodingLastRect)
+
+	update := readTestUpdate(t, fb)
+	if update.Rectangles != 5 || update.Encodings[encodingTight] != 5 {
+		t.Errorf("Unexpected update %+v", update)
+	}
+	if w.Len() != 0 {
+		t.Errorf("Expected the update to be consumed, %d bytes left", w.Len())
+	}
+
+	expectPixel(t, fb, 7, 1, red)
+	expectPixel(t, fb, 3, 3, green)
+	expectPixel(t, fb, 4, 2, white)
+	expectPixel(t, fb, 5, 2, blue)
+	expectPixel(t, fb, 5, 3, white)
+	expectPixel(t, fb, 0, 4, color.RGBA{10, 10, 10, 0xFF})
+	expectPixel(t, fb, 3, 4, color.RGBA{40, 40, 40, 0xFF})
+	expectPixel(t, fb, 7, 5, blue)
+}
+
+func TestTight_JPEG(t *testing.T) {
+	w := &updateWriter{}
+	fb := newTestFramebuffer(t, w, 16, 16)
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("jpeg.Encode failed: %v", err)
+	}
+
+	w.update(1)
+	w.rectangle(image.Rect(0, 0, 16, 16), encodingTight)
+	w.WriteByte(tightJPEG << 4)
+	w.compactLength(encoded.Len())
+	w.Write(encoded.Bytes())
+
+	readTestUpdate(t, fb)
+	if got := fb.Image.RGBAAt(8, 8); got.R < 0xF0 || got.G < 0xF0 || got.B < 0xF0 {
+		t.Errorf("Expected a white pixel, got %v", got)
+	}
+}
+
+func TestDesktopSize(t *testing.T) {
+	w := &updateWriter{}
+	fb := newTestFramebuffer(t, w, 4, 4)
+
+	w.update(1)
+	w.rectangle(image.Rect(0, 0, 8, 6), encodingDesktopSize)
+	readTestUpdate(t, fb)
+
+	if fb.Width() != 8 || fb.Height() != 6 {
+		t.Errorf("Expected the framebuffer to be resized to 8x6, got %dx%d", fb.Width(), fb.Height())
+	}
+	request := fb.UpdateRequestMessage(true)
+	if request[1] != 1 || binary.BigEndian.Uint16(request[6:8]) != 8 || binary.BigEndian.Uint16(request[8:10]) != 6 {
+		t.Errorf("Unexpected update request %v", request)
+	}
+}
+
+func TestReadMessage_OtherMessages(t *testing.T) {
+	w := &updateWriter{}
+	fb := newTestFramebuffer(t, w, 4, 4)
+
+	w.WriteByte(msgBell)
+	w.Write([]byte{msgServerCutText, 0, 0, 0, 0, 0, 0, 5})
+	w.WriteString("hello")
+	w.update(0)
+
+	for _, expected := range []uint8{msgBell, msgServerCutText, msgFramebufferUpdate} {
+		messageType, err := fb.NextMessage()
+		if err != nil || messageType != expected {
+			t.Fatalf("NextMessage() = %d, %v, want %d", messageType, err, expected)
+		}
+		update, err := fb.ReadMessage(messageType)
+		if err != nil {
+			t.Fatalf("ReadMessage(%d) failed: %v", messageType, err)
+		}
+		if (update != nil) != (messageType == msgFramebufferUpdate) {
+			t.Errorf("ReadMessage(%d) = %+v", messageType, update)
+		}
+	}
+	if w.Len() != 0 {
+		t.Errorf("Expected the messages to be consumed, %d bytes left", w.Len())
+	}
+}
+
+func TestCompactLength(t *testing.T) {
+	for _, n := range []int{0, 127, 128, 16383, 16384, 4194303} {
+		w := &updateWriter{}
+		w.compactLength(n)
+		fb := newTestFramebuffer(t, w, 1, 1)
+		if got, err := fb.readCompactLength(); err != nil || got != n {
+			t.Errorf("readCompactLength() = %d, %v, want %d", got, err, n)
+		}
+	}
+}
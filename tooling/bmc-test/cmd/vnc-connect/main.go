@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -22,6 +24,12 @@ var (
 	tlsInsecure bool
 	timeout     time.Duration
 
+	// Framebuffer flags
+	screenshot   string
+	loopDuration time.Duration
+	encodings    []string
+	jpegQuality  int
+
 	// Logging flags
 	verbose bool
 	debug   bool
@@ -47,6 +55,8 @@ Features:
   • TLS/SSL encrypted connections for secure BMC access
   • VNC Authentication (password-based)
   • Connection diagnostics and testing
+  • Framebuffer decoding (Raw, CopyRect, Tight) and PNG screenshots
+  • Incremental update loop to check that the KVM keeps rendering
   • Debug logging for troubleshooting`,
 	Example: `  # Test Dell iDRAC VNC with TLS:
   vnc-connect --host 10.147.8.25 --port 5901 --password secret --tls --debug
@@ -58,12 +68,18 @@ Features:
   vnc-connect --host 192.168.1.100 --port 5900
 
   # Test with verbose logging and custom timeout:
-  vnc-connect --host 10.0.0.100 --port 5901 --timeout 60s --verbose`,
+  vnc-connect --host 10.0.0.100 --port 5901 --timeout 60s --verbose
+
+  # Save a screenshot after watching the screen for 30 seconds:
+  vnc-connect --host 10.147.8.25 --password secret --duration 30s --screenshot screen.png`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Validate required flags
 		if host == "" {
 			return fmt.Errorf("host is required")
 		}
+		if jpegQuality < -1 || jpegQuality > 9 {
+			return fmt.Errorf("jpeg-quality must be between 0 and 9, or -1")
+		}
 		return nil
 	},
 	RunE:          runVNCConnect,
@@ -80,6 +96,12 @@ func init() {
 	rootCmd.Flags().BoolVar(&tlsInsecure, "tls-insecure", true, "Skip TLS certificate verification (only with --tls)")
 	rootCmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Connection timeout")
 
+	// Framebuffer flags
+	rootCmd.Flags().StringVar(&screenshot, "screenshot", "", "Save the framebuffer as a PNG screenshot to this path")
+	rootCmd.Flags().DurationVar(&loopDuration, "duration", 0, "Keep requesting incremental updates for this long")
+	rootCmd.Flags().StringSliceVar(&encodings, "encodings", []string{"tight", "copyrect", "raw"}, "Encodings to request, in order of preference: tight, copyrect, raw")
+	rootCmd.Flags().IntVar(&jpegQuality, "jpeg-quality", -1, "Allow Tight JPEG at this quality level (0-9), -1 disables JPEG")
+
 	// Logging flags
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (info level)")
 	rootCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug logging (most detailed)")
@@ -102,7 +124,7 @@ func runVNCConnect(cmd *cobra.Command, args []string) error {
 
 	// Create VNC endpoint configuration
 	endpoint := &vnc.Endpoint{
-		Endpoint: fmt.Sprintf("%s:%d", host, port),
+		Endpoint: net.JoinHostPort(host, strconv.Itoa(port)),
 		Password: password,
 	}
 
@@ -138,57 +160,76 @@ func runVNCConnect(cmd *cobra.Command, args []string) error {
 	log.Info().Msg("✅ RFB handshake completed")
 	log.Info().Msg("✅ Authentication successful")
 
-	// Step 5: Send FramebufferUpdateRequest to trigger server to send screen data
-	//
-	// CRITICAL: VNC servers are passive after authentication - they don't send
-	// framebuffer data until the client explicitly requests it.
-	//
-	// FramebufferUpdateRequest message format (10 bytes):
-	//   - Message type: 3 (1 byte)
-	//   - Incremental: 0=full update, 1=incremental (1 byte)
-	//   - X position: big-endian u16 (2 bytes)
-	//   - Y position: big-endian u16 (2 bytes)
-	//   - Width: big-endian u16 (2 bytes)
-	//   - Height: big-endian u16 (2 bytes)
-	//
-	// Reference: RFC 6143 Section 7.5.3
-	log.Info().Msg("Requesting framebuffer update...")
-
-	fbUpdateReq := []byte{
-		3,    // Message type: FramebufferUpdateRequest
-		1,    // Incremental: 1 (only send changes, more efficient)
-		0, 0, // X position: 0 (big-endian u16)
-		0, 0, // Y position: 0 (big-endian u16)
-		0xFF, 0xFF, // Width: 65535 (request entire screen width)
-		0xFF, 0xFF, // Height: 65535 (request entire screen height)
-	}
-
-	if err := transport.Write(context.Background(), fbUpdateReq); err != nil {
+	// The transport cached ServerInit: framebuffer size, pixel format and
+	// desktop name
+	reader := &transportReader{transport: transport, timeout: timeout}
+	fb, err := NewFramebuffer(transport.GetServerInit(), reader)
+	if err != nil {
+		return fmt.Errorf("invalid ServerInit: %w", err)
+	}
+	log.Info().
+		Str("desktop_name", fb.Name).
+		Int("width", fb.Width()).
+		Int("height", fb.Height()).
+		Str("pixel_format", fb.Format.String()).
+		Msg("✅ ServerInit received")
+
+	// Ask for a pixel format and encodings the decoder supports. Servers
+	// must honour the pixel format and fall back to Raw for encodings they
+	// do not support.
+	requested, err := requestedEncodings()
+	if err != nil {
+		return err
+	}
+	if err := transport.Write(context.Background(), fb.SetPixelFormatMessage(preferredPixelFormat)); err != nil {
+		return fmt.Errorf("SetPixelFormat failed: %w", err)
+	}
+	if err := transport.Write(context.Background(), SetEncodingsMessage(requested)); err != nil {
+		return fmt.Errorf("SetEncodings failed: %w", err)
+	}
+
+	// VNC servers are passive after the handshake: they send framebuffer
+	// data when the client requests it (RFC 6143 section 7.5.3). The first
+	// request is a full update, later ones are incremental.
+	log.Info().Msg("Requesting full framebuffer update...")
+	if err := transport.Write(context.Background(), fb.UpdateRequestMessage(false)); err != nil {
 		log.Error().
 			Err(err).
 			Msg("Failed to send FramebufferUpdateRequest")
 		return fmt.Errorf("FramebufferUpdateRequest failed: %w", err)
 	}
 
-	log.Info().Msg("✅ FramebufferUpdateRequest sent")
-
-	// Now read the server's response (should be a FramebufferUpdate message)
 	log.Info().Msg("Testing data transfer (waiting for FramebufferUpdate)...")
-	readCtx, readCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer readCancel()
-
-	data, err := transport.Read(readCtx)
+	stats := newUpdateStats()
+	update, err := readUpdate(fb, reader, time.Now().Add(timeout))
 	if err != nil {
 		log.Error().
 			Err(err).
-			Msg("Read test failed - server did not send FramebufferUpdate")
+			Msg("Read test failed - server did not send a decodable FramebufferUpdate")
 		return fmt.Errorf("failed to read FramebufferUpdate: %w", err)
 	}
-
+	stats.add(update)
 	log.Info().
-		Int("bytes_received", len(data)).
-		Str("data_hex", fmt.Sprintf("%x", data[:min(16, len(data))])).
-		Msg("✅ Data transfer working - received FramebufferUpdate")
+		Int("rectangles", update.Rectangles).
+		Int("bytes_received", update.Bytes).
+		Str("encodings", formatEncodings(update.Encodings)).
+		Msg("✅ Data transfer working - decoded FramebufferUpdate")
+
+	// Keep requesting incremental updates, e.g. to watch the KVM render a
+	// boot sequence
+	if loopDuration > 0 {
+		log.Info().Dur("duration", loopDuration).Msg("Requesting incremental updates...")
+		if err := loopUpdates(transport, fb, reader, stats, time.Now().Add(loopDuration)); err != nil {
+			return err
+		}
+	}
+
+	if screenshot != "" {
+		if err := saveScreenshot(fb, screenshot); err != nil {
+			return err
+		}
+		log.Info().Str("path", screenshot).Msg("✅ Screenshot saved")
+	}
 
 	// Summary
 	fmt.Println("\n" + repeat("=", 60))
@@ -197,6 +238,12 @@ func runVNCConnect(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Host:           %s:%d\n", host, port)
 	fmt.Printf("TLS:            %v\n", tlsEnabled)
 	fmt.Printf("Authentication: %s\n", authStatus(password))
+	fmt.Printf("Desktop:        %q %dx%d\n", fb.Name, fb.Width(), fb.Height())
+	fmt.Printf("Updates:        %d (%d rectangles, %s)\n", stats.updates, stats.rectangles, formatEncodings(stats.encodings))
+	fmt.Printf("Received:       %d bytes in %s\n", stats.bytes, stats.elapsed().Round(time.Millisecond))
+	if screenshot != "" {
+		fmt.Printf("Screenshot:     %s\n", screenshot)
+	}
 	fmt.Printf("Status:         ✅ SUCCESS\n")
 	fmt.Println(repeat("=", 60))
 
@@ -226,13 +273,6 @@ func authStatus(password string) string {
 	return "VNC Authentication (password provided)"
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 // String repeat helper
 type stringRepeat string
 
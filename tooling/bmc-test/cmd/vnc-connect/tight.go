@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+)
+
+// Tight compression control (https://github.com/rfbproto/rfbproto, Tight
+// Encoding): the low 4 bits reset zlib streams, the high 4 bits select the
+// compression
+const (
+	tightFill = 0x8
+	tightJPEG = 0x9
+
+	// tightExplicitFilter flags basic compression followed by a filter ID
+	tightExplicitFilter = 0x4
+)
+
+// Tight filters of basic compression
+const (
+	tightFilterCopy     = 0
+	tightFilterPalette  = 1
+	tightFilterGradient = 2
+)
+
+// tightMinToCompress is the data size below which Tight sends data as is
+const tightMinToCompress = 12
+
+// tightStreams are the 4 zlib streams of a Tight session. Each stream
+// carries the data of many rectangles, its state is kept between them.
+type tightStreams [4]*tightStream
+
+type tightStream struct {
+	input bytes.Buffer
+	zlib  io.ReadCloser
+}
+
+// read returns length bytes decompressed from compressed data appended to
+// the stream. The server flushes the stream at the end of each rectangle,
+// so the decompressor never reads past the data it is given.
+func (s *tightStream) read(compressed []byte, length int) ([]byte, error) {
+	s.input.Write(compressed)
+	if s.zlib == nil {
+		z, err := zlib.NewReader(&s.input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zlib stream: %w", err)
+		}
+		s.zlib = z
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(s.zlib, data); err != nil {
+		return nil, fmt.Errorf("zlib: %w", err)
+	}
+	return data, nil
+}
+
+func (fb *Framebuffer) readTight(r image.Rectangle) error {
+	control, err := fb.readU8()
+	if err != nil {
+		return err
+	}
+	for i := range fb.tight {
+		if control&(1<<i) != 0 {
+			fb.tight[i] = nil
+		}
+	}
+
+	switch compression := control >> 4; {
+	case compression == tightFill:
+		pixel, err := fb.read(fb.tightPixelSize())
+		if err != nil {
+			return err
+		}
+		draw.Draw(fb.Image, r, image.NewUniform(fb.tightPixel(pixel)), image.Point{}, draw.Src)
+		return nil
+
+	case compression == tightJPEG:
+		length, err := fb.readCompactLength()
+		if err != nil {
+			return err
+		}
+		data, err := fb.read(length)
+		if err != nil {
+			return err
+		}
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("invalid JPEG: %w", err)
+		}
+		draw.Draw(fb.Image, r, img, img.Bounds().Min, draw.Src)
+		return nil
+
+	case compression&0x8 != 0:
+		return fmt.Errorf("invalid compression control 0x%02x", control)
+
+	default:
+		filter := uint8(tightFilterCopy)
+		if compression&tightExplicitFilter != 0 {
+			if filter, err = fb.readU8(); err != nil {
+				return err
+			}
+		}
+		return fb.readTightBasic(r, int(compression&0x3), filter)
+	}
+}
+
+func (fb *Framebuffer) readTightBasic(r image.Rectangle, stream int, filter uint8) error {
+	width, height := r.Dx(), r.Dy()
+	pixelSize := fb.tightPixelSize()
+
+	switch filter {
+	case tightFilterCopy:
+		data, err := fb.readTightData(stream, width*height*pixelSize)
+		if err != nil {
+			return err
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				offset := (y*width + x) * pixelSize
+				fb.Image.SetRGBA(r.Min.X+x, r.Min.Y+y, fb.tightPixel(data[offset:offset+pixelSize]))
+			}
+		}
+		return nil
+
+	case tightFilterPalette:
+		colours, err := fb.readU8()
+		if err != nil {
+			return err
+		}
+		paletteData, err := fb.read((int(colours) + 1) * pixelSize)
+		if err != nil {
+			return err
+		}
+		palette := make([]color.RGBA, int(colours)+1)
+		for i := range palette {
+			palette[i] = fb.tightPixel(paletteData[i*pixelSize : (i+1)*pixelSize])
+		}
+
+		// Two colours are packed as 1 bit per pixel, with padded rows
+		rowSize := width
+		if len(palette) == 2 {
+			rowSize = (width + 7) / 8
+		}
+		data, err := fb.readTightData(stream, rowSize*height)
+		if err != nil {
+			return err
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				var index int
+				if len(palette) == 2 {
+					index = int(data[y*rowSize+x/8]>>(7-x%8)) & 1
+				} else {
+					index = int(data[y*rowSize+x])
+				}
+				if index >= len(palette) {
+					return fmt.Errorf("palette index %d out of %d colours", index, len(palette))
+				}
+				fb.Image.SetRGBA(r.Min.X+x, r.Min.Y+y, palette[index])
+			}
+		}
+		return nil
+
+	case tightFilterGradient:
+		if pixelSize != 3 {
+			return fmt.Errorf("gradient filter requires 24-bit colour, got %s", fb.Format)
+		}
+		data, err := fb.readTightData(stream, width*height*3)
+		if err != nil {
+			return err
+		}
+		fb.applyGradient(r, data)
+		return nil
+	}
+	return fmt.Errorf("unknown filter %d", filter)
+}
+
+// applyGradient reverses the gradient filter: each component was sent as
+// the difference with the prediction left + up - upper left, clamped to
+// 0..255
+func (fb *Framebuffer) applyGradient(r image.Rectangle, data []byte) {
+	width := r.Dx()
+	previous := make([]int, width*3)
+	current := make([]int, width*3)
+
+	for y := 0; y < r.Dy(); y++ {
+		for x := 0; x < width; x++ {
+			for c := 0; c < 3; c++ {
+				var left, upperLeft int
+				if x > 0 {
+					left = current[(x-1)*3+c]
+					upperLeft = previous[(x-1)*3+c]
+				}
+				prediction := min(max(left+previous[x*3+c]-upperLeft, 0), 255)
+				current[x*3+c] = (int(data[(y*width+x)*3+c]) + prediction) & 0xFF
+			}
+			p := current[x*3:]
+			fb.Image.SetRGBA(r.Min.X+x, r.Min.Y+y, color.RGBA{uint8(p[0]), uint8(p[1]), uint8(p[2]), 0xFF})
+		}
+		previous, current = current, previous
+	}
+}
+
+// readTightData reads length bytes of basic compression data: as is below
+// tightMinToCompress bytes, else zlib-compressed on the given stream
+func (fb *Framebuffer) readTightData(stream, length int) ([]byte, error) {
+	if length < tightMinToCompress {
+		return fb.read(length)
+	}
+
+	compressedLength, err := fb.readCompactLength()
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := fb.read(compressedLength)
+	if err != nil {
+		return nil, err
+	}
+	if fb.tight[stream] == nil {
+		fb.tight[stream] = &tightStream{}
+	}
+	return fb.tight[stream].read(compressed, length)
+}
+
+// readCompactLength reads a Tight length: 1 to 3 bytes of 7 bits, least
+// significant first, the high bit of each byte flags another byte
+func (fb *Framebuffer) readCompactLength() (int, error) {
+	length := 0
+	for i := 0; i < 3; i++ {
+		b, err := fb.readU8()
+		if err != nil {
+			return 0, err
+		}
+		if i == 2 {
+			return length | int(b)<<14, nil
+		}
+		length |= int(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return length, nil
+}
+
+// tightPixelSize is the size of a Tight pixel: 3 bytes (red, green, blue)
+// for 24-bit true colour in 32-bit pixels, else the pixel size
+func (fb *Framebuffer) tightPixelSize() int {
+	pf := fb.Format
+	if pf.TrueColour && pf.BitsPerPixel == 32 && pf.Depth == 24 &&
+		pf.RedMax == 255 && pf.GreenMax == 255 && pf.BlueMax == 255 {
+		return 3
+	}
+	return pf.bytesPerPixel()
+}
+
+func (fb *Framebuffer) tightPixel(b []byte) color.RGBA {
+	if len(b) == 3 {
+		return color.RGBA{b[0], b[1], b[2], 0xFF}
+	}
+	return fb.pixel(b)
+}
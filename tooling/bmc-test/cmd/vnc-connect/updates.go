@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/png"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"local-agent/pkg/vnc"
+)
+
+// transportReader reads the RFB byte stream of a VNC transport. Reads wait
+// until the deadline when set, else for the timeout.
+type transportReader struct {
+	transport vnc.Transport
+	timeout   time.Duration
+	deadline  time.Time
+	buf       []byte
+}
+
+func (r *transportReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		deadline := r.deadline
+		if deadline.IsZero() {
+			deadline = time.Now().Add(r.timeout)
+		}
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		data, err := r.transport.Read(ctx)
+		cancel()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// updateStats accumulates the framebuffer updates of a session
+type updateStats struct {
+	start      time.Time
+	updates    int
+	rectangles int
+	bytes      int
+	encodings  map[int32]int
+}
+
+func newUpdateStats() *updateStats {
+	return &updateStats{start: time.Now(), encodings: make(map[int32]int)}
+}
+
+func (s *updateStats) add(update *Update) {
+	s.updates++
+	s.rectangles += update.Rectangles
+	s.bytes += update.Bytes
+	for encoding, count := range update.Encodings {
+		s.encodings[encoding] += count
+	}
+}
+
+func (s *updateStats) elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// readUpdate reads server messages until a framebuffer update is decoded
+func readUpdate(fb *Framebuffer, reader *transportReader, deadline time.Time) (*Update, error) {
+	reader.deadline = deadline
+	defer func() { reader.deadline = time.Time{} }()
+
+	for {
+		messageType, err := fb.NextMessage()
+		if err != nil {
+			return nil, err
+		}
+		update, err := fb.ReadMessage(messageType)
+		if err != nil {
+			return nil, err
+		}
+		if update != nil {
+			return update, nil
+		}
+	}
+}
+
+// loopUpdates requests an incremental update after each update until end.
+// Servers only answer incremental requests when the screen changes, so a
+// static screen at the end is not an error.
+func loopUpdates(transport vnc.Transport, fb *Framebuffer, reader *transportReader, stats *updateStats, end time.Time) error {
+	for time.Now().Before(end) {
+		if err := transport.Write(context.Background(), fb.UpdateRequestMessage(true)); err != nil {
+			return fmt.Errorf("FramebufferUpdateRequest failed: %w", err)
+		}
+
+		// Wait until the end for the screen to change, then read the
+		// update itself with the usual timeout
+		reader.deadline = end
+		messageType, err := fb.NextMessage()
+		reader.deadline = time.Time{}
+		if err != nil {
+			if isTimeout(err) && !time.Now().Before(end) {
+				return nil
+			}
+			return fmt.Errorf("failed to read server message: %w", err)
+		}
+
+		update, err := fb.ReadMessage(messageType)
+		if err != nil {
+			return fmt.Errorf("failed to decode server message: %w", err)
+		}
+		if update == nil {
+			continue
+		}
+		stats.add(update)
+		log.Debug().
+			Int("rectangles", update.Rectangles).
+			Int("bytes", update.Bytes).
+			Str("encodings", formatEncodings(update.Encodings)).
+			Msg("FramebufferUpdate decoded")
+	}
+	return nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// requestedEncodings returns the encodings of --encodings, followed by the
+// supported pseudo-encodings
+func requestedEncodings() ([]int32, error) {
+	requested := make([]int32, 0, len(encodings)+3)
+	for _, name := range encodings {
+		encoding, ok := encodingNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported encoding %q: use tight, copyrect or raw", name)
+		}
+		requested = append(requested, encoding)
+	}
+	requested = append(requested, encodingDesktopSize, encodingLastRect)
+	if jpegQuality >= 0 {
+		requested = append(requested, encodingJPEGQuality0+int32(jpegQuality))
+	}
+	return requested, nil
+}
+
+// formatEncodings lists rectangle counts by encoding, e.g. "Tight=12 Raw=1"
+func formatEncodings(counts map[int32]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(counts))
+	for encoding, count := range counts {
+		names = append(names, fmt.Sprintf("%s=%d", encodingName(encoding), count))
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+func saveScreenshot(fb *Framebuffer, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create screenshot: %w", err)
+	}
+	if err := png.Encode(file, fb.Image); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return file.Close()
+}
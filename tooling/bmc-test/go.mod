@@ -2,19 +2,46 @@ module bmc-test
 
 go 1.25.1
 
-replace local-agent => ../../local-agent
+replace (
+	cli => ../../cli
+	core => ../../core
+	gateway => ../../gateway
+	local-agent => ../../local-agent
+	manager => ../../manager
+)
 
 require (
+	cli v0.0.0-00010101000000-000000000000
+	core v0.0.0-00010101000000-000000000000
+	gateway v0.0.0-00010101000000-000000000000
+	github.com/gorilla/websocket v1.5.3
 	github.com/rs/zerolog v1.34.0
+	github.com/spf13/cobra v1.10.1
+	golang.org/x/term v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
 	local-agent v0.0.0
 )
 
 require (
-	github.com/gorilla/websocket v1.5.3 // indirect
+	connectrpc.com/connect v1.19.0 // indirect
+	github.com/creack/pty v1.1.24 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/spf13/cobra v1.10.1 // indirect
-	github.com/spf13/pflag v1.0.9 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/sagikazarmark/locafero v0.11.0 // indirect
+	github.com/sourcegraph/conc v0.3.1-0.20240121214520-5f936abd7ae8 // indirect
+	github.com/spf13/afero v1.15.0 // indirect
+	github.com/spf13/cast v1.10.0 // indirect
+	github.com/spf13/pflag v1.0.10 // indirect
+	github.com/spf13/viper v1.21.0 // indirect
+	github.com/subosito/gotenv v1.6.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.4 // indirect
+	golang.org/x/net v0.44.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
+	golang.org/x/text v0.29.0 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
+	manager v0.0.0-00010101000000-000000000000 // indirect
 )
@@ -0,0 +1,272 @@
+// Package e2e is an end-to-end test harness: it runs the manager, a gateway
+// and a local agent in Docker next to virtual BMCs, an ipmi_sim IPMI BMC and
+// a Redfish emulator, and drives the stack the way users do, through the CLI
+// client.
+//
+// The stack is described by docker-compose.yml in this directory. The
+// scenarios are the e2e-tagged tests of this package:
+//
+//	cd tooling/e2e && go test -tags e2e -v ./...
+//
+// Set E2E_EXTERNAL=1 to run the scenarios against a stack that is already
+// running, and E2E_KEEP=1 to leave the stack running after the tests.
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"cli/pkg/client"
+	"cli/pkg/config"
+)
+
+// Config describes the stack under test. Every field can be overridden from
+// the environment, see ConfigFromEnv.
+type Config struct {
+	// ComposeFile describes the stack (E2E_COMPOSE_FILE)
+	ComposeFile string
+	// Project is the compose project name, isolating the stack's
+	// containers and volumes from the development environment (E2E_PROJECT)
+	Project string
+
+	// Service endpoints as published on the host (E2E_MANAGER_URL,
+	// E2E_GATEWAY_URL, E2E_AGENT_URL)
+	ManagerURL string
+	GatewayURL string
+	AgentURL   string
+
+	// GatewayID and AgentID are the identities configured in the stack
+	// (E2E_GATEWAY_ID, E2E_AGENT_ID)
+	GatewayID string
+	AgentID   string
+
+	// AdminEmail logs in to the manager, it must be one of its admin emails
+	// (E2E_ADMIN_EMAIL)
+	AdminEmail string
+
+	// External targets a running stack instead of starting one (E2E_EXTERNAL)
+	External bool
+	// Keep leaves the stack running after the tests (E2E_KEEP)
+	Keep bool
+
+	// ReadyTimeout bounds the stack startup (E2E_READY_TIMEOUT)
+	ReadyTimeout time.Duration
+}
+
+// ConfigFromEnv returns the configuration of the stack of docker-compose.yml,
+// overridden by E2E_* environment variables
+func ConfigFromEnv() Config {
+	cfg := Config{
+		ComposeFile:  envOr("E2E_COMPOSE_FILE", "docker-compose.yml"),
+		Project:      envOr("E2E_PROJECT", "conduit-e2e"),
+		ManagerURL:   envOr("E2E_MANAGER_URL", "http://localhost:18080"),
+		GatewayURL:   envOr("E2E_GATEWAY_URL", "http://localhost:18081"),
+		AgentURL:     envOr("E2E_AGENT_URL", "http://localhost:18082"),
+		GatewayID:    envOr("E2E_GATEWAY_ID", "gateway-e2e"),
+		AgentID:      envOr("E2E_AGENT_ID", "e2e-agent-01"),
+		AdminEmail:   envOr("E2E_ADMIN_EMAIL", "admin@e2e.local"),
+		External:     os.Getenv("E2E_EXTERNAL") == "1",
+		Keep:         os.Getenv("E2E_KEEP") == "1",
+		ReadyTimeout: 5 * time.Minute,
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("E2E_READY_TIMEOUT")); err == nil {
+		cfg.ReadyTimeout = timeout
+	}
+	return cfg
+}
+
+// Harness is a running stack and a CLI client logged in to it as an admin
+type Harness struct {
+	Config Config
+
+	// Client talks to the manager and, through it, to the gateway
+	Client *client.Client
+
+	cliConfig *config.Config
+}
+
+// Start starts the stack, waits until the services are healthy and logs in.
+// On failure the stack logs are written to stderr and the stack is stopped.
+func Start(ctx context.Context, cfg Config) (*Harness, error) {
+	h := &Harness{Config: cfg}
+
+	if !cfg.External {
+		if err := h.compose(ctx, os.Stderr, "up", "--build", "--detach"); err != nil {
+			return nil, fmt.Errorf("failed to start stack: %w", err)
+		}
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, cfg.ReadyTimeout)
+	defer cancel()
+
+	err := h.waitHealthy(readyCtx)
+	if err == nil {
+		err = h.login(readyCtx)
+	}
+	if err != nil {
+		h.Logs(ctx, os.Stderr)
+		if stopErr := h.Stop(ctx); stopErr != nil {
+			err = errors.Join(err, stopErr)
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+// Stop stops the stack and removes its volumes, unless it is external or
+// kept for inspection
+func (h *Harness) Stop(ctx context.Context) error {
+	if h.Config.External || h.Config.Keep {
+		return nil
+	}
+	if err := h.compose(ctx, os.Stderr, "down", "--volumes", "--remove-orphans"); err != nil {
+		return fmt.Errorf("failed to stop stack: %w", err)
+	}
+	return nil
+}
+
+// Logs writes the logs of the stack services to w, to diagnose failures
+func (h *Harness) Logs(ctx context.Context, w io.Writer) {
+	if h.Config.External {
+		return
+	}
+	_ = h.compose(ctx, w, "logs", "--no-color", "--timestamps")
+}
+
+// Manager returns a manager client sharing the harness login
+func (h *Harness) Manager() *client.BMCManagerClient {
+	return client.NewBMCManagerClient(h.cliConfig)
+}
+
+// WaitForServers waits until the manager lists all the given servers, and
+// returns them in the order of ids
+func (h *Harness) WaitForServers(ctx context.Context, timeout time.Duration, ids ...string) ([]client.ServerInfo, error) {
+	var found []client.ServerInfo
+	err := Eventually(ctx, timeout, 2*time.Second, func() error {
+		servers, err := h.Client.ListServers(ctx)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[string]client.ServerInfo, len(servers))
+		for _, server := range servers {
+			byID[server.ID] = server
+		}
+
+		found = found[:0]
+		var missing []string
+		for _, id := range ids {
+			server, ok := byID[id]
+			if !ok {
+				missing = append(missing, id)
+				continue
+			}
+			found = append(found, server)
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return fmt.Errorf("servers not registered yet: %v", missing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// Eventually calls fn every interval until it succeeds, and returns its last
+// error if it did not succeed within timeout
+func Eventually(ctx context.Context, timeout, interval time.Duration, fn func() error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("condition not met after %s: %w", timeout, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitHealthy waits until the health endpoint of every service answers
+func (h *Harness) waitHealthy(ctx context.Context) error {
+	services := map[string]string{
+		"manager":     h.Config.ManagerURL,
+		"gateway":     h.Config.GatewayURL,
+		"local-agent": h.Config.AgentURL,
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	for name, endpoint := range services {
+		err := Eventually(ctx, h.Config.ReadyTimeout, 2*time.Second, func() error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/health", nil)
+			if err != nil {
+				return err
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("health check returned %s", resp.Status)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s is not healthy: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// login authenticates the harness client with the manager. The stack
+// accepts any password, admin rights come from the manager's admin emails.
+func (h *Harness) login(ctx context.Context) error {
+	h.cliConfig = &config.Config{
+		Manager: config.ManagerConfig{Endpoint: h.Config.ManagerURL},
+	}
+	if _, err := client.NewBMCManagerClient(h.cliConfig).Authenticate(ctx, h.Config.AdminEmail, "e2e"); err != nil {
+		return fmt.Errorf("failed to log in as %s: %w", h.Config.AdminEmail, err)
+	}
+	h.Client = client.New(h.cliConfig)
+	return nil
+}
+
+// compose runs a docker compose command on the stack, writing its output to w
+func (h *Harness) compose(ctx context.Context, w io.Writer, args ...string) error {
+	args = append([]string{"compose", "--file", h.Config.ComposeFile, "--project-name", h.Config.Project}, args...)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+
+	cmd.Stdout = w
+	cmd.Stderr = w
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker %s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,33 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// harness is the stack shared by all scenarios
+var harness *Harness
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	h, err := Start(ctx, ConfigFromEnv())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start the e2e stack: %v\n", err)
+		os.Exit(1)
+	}
+	harness = h
+
+	code := m.Run()
+	if code != 0 {
+		harness.Logs(ctx, os.Stderr)
+	}
+	if err := harness.Stop(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to stop the e2e stack: %v\n", err)
+	}
+	os.Exit(code)
+}
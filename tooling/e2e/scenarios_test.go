@@ -0,0 +1,182 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"cli/pkg/client"
+	"cli/pkg/terminal"
+	"core/types"
+)
+
+// Servers of configs/agent.yaml. Scenarios run in this file's order: power
+// operations come last since powering off may interrupt the consoles.
+const (
+	ipmiServer    = "e2e-ipmi-01"
+	redfishServer = "e2e-redfish-01"
+)
+
+const (
+	discoveryTimeout = 2 * time.Minute
+	powerTimeout     = time.Minute
+)
+
+func TestDiscovery(t *testing.T) {
+	ctx := context.Background()
+
+	servers, err := harness.WaitForServers(ctx, discoveryTimeout, ipmiServer, redfishServer)
+	if err != nil {
+		t.Fatalf("Static hosts were not discovered: %v", err)
+	}
+
+	for i, expected := range []types.BMCType{types.BMCTypeIPMI, types.BMCTypeRedfish} {
+		server := servers[i]
+		if server.PrimaryProtocol != expected {
+			t.Errorf("Expected %s to use %s, got %q", server.ID, expected, server.PrimaryProtocol)
+		}
+		if server.VNCEndpoint == nil {
+			t.Errorf("Expected %s to have a VNC endpoint", server.ID)
+		}
+	}
+	if servers[0].SOLEndpoint == nil {
+		t.Errorf("Expected %s to have a SOL endpoint", ipmiServer)
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	ctx := context.Background()
+	requireServers(t)
+
+	status, err := harness.Client.GetAgentStatus(ctx, harness.Config.AgentID, "")
+	if err != nil {
+		t.Fatalf("Agent %s is not registered: %v", harness.Config.AgentID, err)
+	}
+	if status.GatewayId != harness.Config.GatewayID {
+		t.Errorf("Expected agent on %s, got %s", harness.Config.GatewayID, status.GatewayId)
+	}
+
+	for _, serverID := range []string{ipmiServer, redfishServer} {
+		location, err := harness.Manager().GetServerLocation(ctx, serverID)
+		if err != nil {
+			t.Errorf("Failed to locate %s: %v", serverID, err)
+			continue
+		}
+		if location.RegionalGatewayID != harness.Config.GatewayID {
+			t.Errorf("Expected %s on %s, got %s", serverID, harness.Config.GatewayID, location.RegionalGatewayID)
+		}
+	}
+}
+
+func TestSOLEcho(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	requireServers(t)
+
+	session, err := harness.Client.CreateSOLSession(ctx, ipmiServer)
+	if err != nil {
+		t.Fatalf("Failed to create SOL session: %v", err)
+	}
+	defer harness.Client.CloseServerSOLSession(context.Background(), ipmiServer, session.ID)
+
+	stream, err := harness.Client.StreamConsoleData(ctx, ipmiServer, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to open console stream: %v", err)
+	}
+
+	marker := fmt.Sprintf("e2e-%d", time.Now().UnixNano())
+	result, err := terminal.Exec(ctx, stream, session.ID, terminal.ExecOptions{
+		Command: "echo " + marker,
+		Expect:  regexp.MustCompile(regexp.QuoteMeta(marker)),
+		Timeout: 30 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Console did not echo %s: %v (output %q)", marker, err, outputOf(result))
+	}
+}
+
+func TestVNCFramebuffer(t *testing.T) {
+	requireServers(t)
+
+	for _, serverID := range []string{ipmiServer, redfishServer} {
+		t.Run(serverID, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			session, err := harness.Client.CreateVNCSession(ctx, serverID, client.VNCSessionOptions{})
+			if err != nil {
+				t.Fatalf("Failed to create VNC session: %v", err)
+			}
+			defer harness.Client.CloseVNCSession(context.Background(), session.ID)
+
+			fb, err := ReadFramebuffer(ctx, session.WebsocketEndpoint)
+			if err != nil {
+				t.Fatalf("Failed to read framebuffer: %v", err)
+			}
+			if fb.Rectangles == 0 {
+				t.Errorf("Expected the framebuffer update to carry pixels, got %+v", fb)
+			}
+			t.Logf("Framebuffer %dx%d %q", fb.Width, fb.Height, fb.Name)
+		})
+	}
+}
+
+func TestPowerOperations(t *testing.T) {
+	requireServers(t)
+
+	for _, serverID := range []string{ipmiServer, redfishServer} {
+		t.Run(serverID, func(t *testing.T) {
+			ctx := context.Background()
+
+			if err := harness.Client.PowerOff(ctx, serverID); err != nil {
+				t.Fatalf("Power off failed: %v", err)
+			}
+			waitPowerState(t, serverID, "POWER_STATE_OFF")
+
+			// Leave the server on for later runs against a kept stack
+			if err := harness.Client.PowerOn(ctx, serverID); err != nil {
+				t.Fatalf("Power on failed: %v", err)
+			}
+			waitPowerState(t, serverID, "POWER_STATE_ON")
+		})
+	}
+}
+
+// requireServers waits for the servers of the stack, for scenarios run on
+// their own
+func requireServers(t *testing.T) {
+	t.Helper()
+	if _, err := harness.WaitForServers(context.Background(), discoveryTimeout, ipmiServer, redfishServer); err != nil {
+		t.Fatalf("Servers are not available: %v", err)
+	}
+}
+
+func waitPowerState(t *testing.T, serverID, expected string) {
+	t.Helper()
+	ctx := context.Background()
+
+	err := Eventually(ctx, powerTimeout, 2*time.Second, func() error {
+		state, err := harness.Client.GetPowerStatus(ctx, serverID)
+		if err != nil {
+			return err
+		}
+		if state != expected {
+			return fmt.Errorf("power state is %s", state)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s did not reach %s: %v", serverID, expected, err)
+	}
+}
+
+func outputOf(result *terminal.ExecResult) string {
+	if result == nil {
+		return ""
+	}
+	return result.Output
+}
@@ -0,0 +1,180 @@
+package e2e
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"local-agent/pkg/vnc/rfb"
+)
+
+// RFB message types used to check the framebuffer
+const (
+	rfbFramebufferUpdateRequest = 3
+	rfbFramebufferUpdate        = 0
+	rfbSetColourMapEntries      = 1
+	rfbBell                     = 2
+	rfbServerCutText            = 3
+)
+
+// Framebuffer describes the display reached through a VNC session
+type Framebuffer struct {
+	Width  uint16
+	Height uint16
+	Name   string
+
+	// Rectangles is the number of rectangles of the first full update
+	Rectangles uint16
+}
+
+// ReadFramebuffer opens the WebSocket of a VNC session the way the web
+// viewer does, performs the RFB handshake and waits for a full framebuffer
+// update. It checks that pixels flow end to end, from the BMC through the
+// agent and the gateway.
+func ReadFramebuffer(ctx context.Context, websocketURL string) (*Framebuffer, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, websocketURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open VNC WebSocket: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(30 * time.Second)
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	stream := &wsStream{conn: conn}
+	handshake := rfb.NewHandshake(stream)
+
+	version, err := handshake.NegotiateVersion()
+	if err != nil {
+		return nil, err
+	}
+	// The gateway authenticated with the BMC already, it offers no auth
+	securityType, err := handshake.NegotiateSecurityType(false)
+	if err != nil {
+		return nil, err
+	}
+	if securityType != rfb.SecurityTypeNone {
+		return nil, fmt.Errorf("unexpected security type %s", securityType)
+	}
+	if version.Minor >= 8 {
+		if err := handshake.ReadSecurityResult(); err != nil {
+			return nil, err
+		}
+	}
+	if err := handshake.SendClientInit(true); err != nil {
+		return nil, err
+	}
+
+	reader := rfb.NewProtocolReader(stream)
+	writer := rfb.NewProtocolWriter(stream)
+
+	// ServerInit: width, height, pixel format, then the desktop name
+	serverInit, err := reader.ReadBytes(20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ServerInit: %w", err)
+	}
+	name, err := reader.ReadString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read desktop name: %w", err)
+	}
+	fb := &Framebuffer{
+		Width:  binary.BigEndian.Uint16(serverInit[0:2]),
+		Height: binary.BigEndian.Uint16(serverInit[2:4]),
+		Name:   name,
+	}
+	if fb.Width == 0 || fb.Height == 0 {
+		return nil, fmt.Errorf("empty framebuffer %dx%d", fb.Width, fb.Height)
+	}
+
+	request := make([]byte, 10)
+	request[0] = rfbFramebufferUpdateRequest
+	binary.BigEndian.PutUint16(request[6:8], fb.Width)
+	binary.BigEndian.PutUint16(request[8:10], fb.Height)
+	if err := writer.Write(request); err != nil {
+		return nil, fmt.Errorf("failed to request framebuffer update: %w", err)
+	}
+
+	for {
+		messageType, err := reader.ReadU8()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read server message: %w", err)
+		}
+
+		switch messageType {
+		case rfbFramebufferUpdate:
+			header, err := reader.ReadBytes(3)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read framebuffer update: %w", err)
+			}
+			fb.Rectangles = binary.BigEndian.Uint16(header[1:3])
+			return fb, nil
+
+		case rfbSetColourMapEntries:
+			header, err := reader.ReadBytes(5)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := reader.ReadBytes(6 * int(binary.BigEndian.Uint16(header[3:5]))); err != nil {
+				return nil, err
+			}
+
+		case rfbBell:
+
+		case rfbServerCutText:
+			if _, err := reader.ReadBytes(3); err != nil {
+				return nil, err
+			}
+			if _, err := reader.ReadString(); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("unexpected server message type %d", messageType)
+		}
+	}
+}
+
+// wsStream reads and writes the RFB byte stream carried by binary WebSocket
+// messages
+type wsStream struct {
+	conn   *websocket.Conn
+	reader io.Reader
+}
+
+func (s *wsStream) Read(p []byte) (int, error) {
+	for {
+		if s.reader == nil {
+			_, reader, err := s.conn.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			s.reader = reader
+		}
+
+		n, err := s.reader.Read(p)
+		if err == io.EOF {
+			s.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *wsStream) Write(p []byte) (int, error) {
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}